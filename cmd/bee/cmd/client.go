@@ -0,0 +1,660 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/big"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/client"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/spf13/cobra"
+)
+
+const (
+	optionNameAPIURL = "api-url"
+	defaultAPIURL    = "http://localhost:1633"
+
+	progressInterval = 500 * time.Millisecond
+	tagPollInterval  = time.Second
+	stampPollPeriod  = 5 * time.Second
+)
+
+func (c *command) initClientCmds() {
+	c.initUploadCmd()
+	c.initDownloadCmd()
+	c.initPinCmd()
+	c.initStampCmd()
+}
+
+func (c *command) initUploadCmd() {
+	const (
+		optionNameStamp         = "stamp"
+		optionNamePin           = "pin"
+		optionNameEncrypt       = "encrypt"
+		optionNameDeferred      = "deferred"
+		optionNameRaw           = "raw"
+		optionNameName          = "name"
+		optionNameContentType   = "content-type"
+		optionNameIndexDocument = "index-document"
+		optionNameErrorDocument = "error-document"
+		optionNameWait          = "wait"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "upload <file or directory>",
+		Short: "Upload a file or a directory to a running node",
+		Long: `Upload a file or a directory to the node listening on the API URL.
+
+The reference of the uploaded content is written to the standard output and
+the progress is written to the standard error.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stamp, err := cmd.Flags().GetString(optionNameStamp)
+			if err != nil {
+				return fmt.Errorf("get stamp: %w", err)
+			}
+			batchID, err := hex.DecodeString(stamp)
+			if err != nil || len(batchID) != 32 {
+				return fmt.Errorf("invalid stamp %q", stamp)
+			}
+
+			var o client.DirOptions
+			if o.Pin, err = cmd.Flags().GetBool(optionNamePin); err != nil {
+				return fmt.Errorf("get pin: %w", err)
+			}
+			if o.Encrypt, err = cmd.Flags().GetBool(optionNameEncrypt); err != nil {
+				return fmt.Errorf("get encrypt: %w", err)
+			}
+			if cmd.Flags().Changed(optionNameDeferred) {
+				deferred, err := cmd.Flags().GetBool(optionNameDeferred)
+				if err != nil {
+					return fmt.Errorf("get deferred: %w", err)
+				}
+				o.Deferred = &deferred
+			}
+			if o.IndexDocument, err = cmd.Flags().GetString(optionNameIndexDocument); err != nil {
+				return fmt.Errorf("get index document: %w", err)
+			}
+			if o.ErrorDocument, err = cmd.Flags().GetString(optionNameErrorDocument); err != nil {
+				return fmt.Errorf("get error document: %w", err)
+			}
+			raw, err := cmd.Flags().GetBool(optionNameRaw)
+			if err != nil {
+				return fmt.Errorf("get raw: %w", err)
+			}
+			name, err := cmd.Flags().GetString(optionNameName)
+			if err != nil {
+				return fmt.Errorf("get name: %w", err)
+			}
+			contentType, err := cmd.Flags().GetString(optionNameContentType)
+			if err != nil {
+				return fmt.Errorf("get content type: %w", err)
+			}
+			wait, err := cmd.Flags().GetBool(optionNameWait)
+			if err != nil {
+				return fmt.Errorf("get wait: %w", err)
+			}
+
+			path := args[0]
+			info, err := os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("stat: %w", err)
+			}
+
+			var size int64
+			if info.IsDir() {
+				if raw {
+					return errors.New("directories can not be uploaded as raw data")
+				}
+				if size, err = dirSize(path); err != nil {
+					return fmt.Errorf("directory size: %w", err)
+				}
+			} else {
+				size = info.Size()
+			}
+
+			p := newProgress(cmd.ErrOrStderr(), "uploading", size)
+			c, err := newAPIClient(cmd, p)
+			if err != nil {
+				p.stop()
+				return err
+			}
+
+			var res client.UploadResult
+			switch {
+			case info.IsDir():
+				res, err = c.UploadDir(cmd.Context(), batchID, path, o)
+			default:
+				var f *os.File
+				f, err = os.Open(path)
+				if err != nil {
+					p.stop()
+					return fmt.Errorf("open file: %w", err)
+				}
+				defer f.Close()
+
+				if raw {
+					res, err = c.UploadBytes(cmd.Context(), batchID, f, o.UploadOptions)
+					break
+				}
+				if name == "" {
+					name = filepath.Base(path)
+				}
+				if contentType == "" {
+					contentType = mime.TypeByExtension(filepath.Ext(path))
+				}
+				res, err = c.UploadFile(cmd.Context(), batchID, name, contentType, f, o.UploadOptions)
+			}
+			p.stop()
+			if err != nil {
+				return fmt.Errorf("upload: %w", err)
+			}
+
+			if wait && res.Tag != 0 {
+				if err := waitSynced(cmd, c, res.Tag); err != nil {
+					return err
+				}
+			}
+
+			cmd.Println(res.Reference)
+			return nil
+		},
+	}
+
+	addAPIURLFlag(cmd)
+	cmd.Flags().String(optionNameStamp, "", "postage batch id to stamp the uploaded chunks with")
+	cmd.Flags().Bool(optionNamePin, false, "pin the uploaded content on the node")
+	cmd.Flags().Bool(optionNameEncrypt, false, "encrypt the uploaded content")
+	cmd.Flags().Bool(optionNameDeferred, true, "return before the content is synced to the network")
+	cmd.Flags().Bool(optionNameRaw, false, "upload the file as raw data without the manifest")
+	cmd.Flags().String(optionNameName, "", "file name stored in the manifest, the base name of the path by default")
+	cmd.Flags().String(optionNameContentType, "", "content type of the file, detected from the extension by default")
+	cmd.Flags().String(optionNameIndexDocument, "", "document served for the directory root")
+	cmd.Flags().String(optionNameErrorDocument, "", "document served for the missing directory paths")
+	cmd.Flags().Bool(optionNameWait, false, "wait until the uploaded chunks are synced to the network")
+	_ = cmd.MarkFlagRequired(optionNameStamp)
+
+	c.root.AddCommand(cmd)
+}
+
+func (c *command) initDownloadCmd() {
+	const (
+		optionNameOutput = "output"
+		optionNameRaw    = "raw"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "download <reference>[/path]",
+		Short: "Download a file from a running node",
+		Long: `Download the file of the reference, or the file under the path of the
+collection reference, from the node listening on the API URL.
+
+The file is written to the base name of the path or to the reference file
+by default. Use "-" as the output to write it to the standard output.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, err := cmd.Flags().GetString(optionNameOutput)
+			if err != nil {
+				return fmt.Errorf("get output: %w", err)
+			}
+			raw, err := cmd.Flags().GetBool(optionNameRaw)
+			if err != nil {
+				return fmt.Errorf("get raw: %w", err)
+			}
+
+			refHex, path, _ := strings.Cut(args[0], "/")
+			ref, err := swarm.ParseHexAddress(refHex)
+			if err != nil {
+				return fmt.Errorf("parse reference: %w", err)
+			}
+			if raw && path != "" {
+				return errors.New("raw data has no paths")
+			}
+			if output == "" {
+				output = ref.String()
+				if path != "" && !strings.HasSuffix(path, "/") {
+					output = filepath.Base(path)
+				}
+			}
+
+			p := newProgress(cmd.ErrOrStderr(), "downloading", 0)
+			defer p.stop()
+			c, err := newAPIClient(cmd, p)
+			if err != nil {
+				return err
+			}
+
+			var rc io.ReadCloser
+			if raw {
+				rc, err = c.Download(cmd.Context(), ref)
+			} else {
+				rc, err = c.DownloadFile(cmd.Context(), ref, path)
+			}
+			if err != nil {
+				return fmt.Errorf("download: %w", err)
+			}
+			defer rc.Close()
+
+			if output == "-" {
+				if _, err := io.Copy(cmd.OutOrStdout(), rc); err != nil {
+					return fmt.Errorf("download: %w", err)
+				}
+				return nil
+			}
+
+			f, err := os.OpenFile(output, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+			if err != nil {
+				return fmt.Errorf("create output file: %w", err)
+			}
+			if _, err := io.Copy(f, rc); err != nil {
+				_ = f.Close()
+				return fmt.Errorf("download: %w", err)
+			}
+			return f.Close()
+		},
+	}
+
+	addAPIURLFlag(cmd)
+	cmd.Flags().StringP(optionNameOutput, "o", "", "output file, - for the standard output")
+	cmd.Flags().Bool(optionNameRaw, false, "download the raw data without the manifest")
+
+	c.root.AddCommand(cmd)
+}
+
+func (c *command) initPinCmd() {
+	cmd := &cobra.Command{
+		Use:   "pin",
+		Short: "Manage the pinned content of a running node",
+	}
+
+	pinCmd := func(use, short string, pin func(*client.Client, *cobra.Command, swarm.Address) error) *cobra.Command {
+		cmd := &cobra.Command{
+			Use:   use + " <reference>",
+			Short: short,
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				ref, err := swarm.ParseHexAddress(args[0])
+				if err != nil {
+					return fmt.Errorf("parse reference: %w", err)
+				}
+				c, err := newAPIClient(cmd, nil)
+				if err != nil {
+					return err
+				}
+				return pin(c, cmd, ref)
+			},
+		}
+		addAPIURLFlag(cmd)
+		return cmd
+	}
+
+	cmd.AddCommand(pinCmd("add", "Pin the content of the reference", func(c *client.Client, cmd *cobra.Command, ref swarm.Address) error {
+		if err := c.Pin(cmd.Context(), ref); err != nil {
+			return fmt.Errorf("pin: %w", err)
+		}
+		return nil
+	}))
+	cmd.AddCommand(pinCmd("rm", "Unpin the content of the reference", func(c *client.Client, cmd *cobra.Command, ref swarm.Address) error {
+		if err := c.Unpin(cmd.Context(), ref); err != nil {
+			return fmt.Errorf("unpin: %w", err)
+		}
+		return nil
+	}))
+
+	lsCmd := &cobra.Command{
+		Use:   "ls",
+		Short: "List the pinned references",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newAPIClient(cmd, nil)
+			if err != nil {
+				return err
+			}
+			refs, err := c.Pins(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("list pins: %w", err)
+			}
+			for _, ref := range refs {
+				cmd.Println(ref)
+			}
+			return nil
+		},
+	}
+	addAPIURLFlag(lsCmd)
+	cmd.AddCommand(lsCmd)
+
+	c.root.AddCommand(cmd)
+}
+
+func (c *command) initStampCmd() {
+	const (
+		optionNameAmount    = "amount"
+		optionNameDepth     = "depth"
+		optionNameLabel     = "label"
+		optionNameImmutable = "immutable"
+		optionNameWait      = "wait"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stamp",
+		Short: "Manage the postage batches of a running node",
+	}
+
+	buyCmd := &cobra.Command{
+		Use:   "buy",
+		Short: "Buy a postage batch",
+		Long: `Buy a postage batch with the node listening on the API URL.
+
+The id of the bought batch is written to the standard output.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			amountStr, err := cmd.Flags().GetString(optionNameAmount)
+			if err != nil {
+				return fmt.Errorf("get amount: %w", err)
+			}
+			amount, ok := new(big.Int).SetString(amountStr, 10)
+			if !ok || amount.Sign() <= 0 {
+				return fmt.Errorf("invalid amount %q", amountStr)
+			}
+			depth, err := cmd.Flags().GetUint8(optionNameDepth)
+			if err != nil {
+				return fmt.Errorf("get depth: %w", err)
+			}
+			label, err := cmd.Flags().GetString(optionNameLabel)
+			if err != nil {
+				return fmt.Errorf("get label: %w", err)
+			}
+			immutable, err := cmd.Flags().GetBool(optionNameImmutable)
+			if err != nil {
+				return fmt.Errorf("get immutable: %w", err)
+			}
+			wait, err := cmd.Flags().GetBool(optionNameWait)
+			if err != nil {
+				return fmt.Errorf("get wait: %w", err)
+			}
+
+			c, err := newAPIClient(cmd, nil)
+			if err != nil {
+				return err
+			}
+
+			cmd.PrintErrf("buying the postage batch of depth %d with amount %s per chunk...\n", depth, amount)
+			batchID, err := c.StampsCreate(cmd.Context(), amount, depth, label, immutable)
+			if err != nil {
+				return fmt.Errorf("buy stamp: %w", err)
+			}
+
+			if wait {
+				if err := waitUsable(cmd, c, batchID); err != nil {
+					return err
+				}
+			}
+
+			cmd.Println(hex.EncodeToString(batchID))
+			return nil
+		},
+	}
+	addAPIURLFlag(buyCmd)
+	buyCmd.Flags().String(optionNameAmount, "", "amount paid per chunk in PLUR")
+	buyCmd.Flags().Uint8(optionNameDepth, 0, "depth of the batch, the batch holds 2^depth chunks")
+	buyCmd.Flags().String(optionNameLabel, "", "label of the batch")
+	buyCmd.Flags().Bool(optionNameImmutable, false, "buy the immutable batch")
+	buyCmd.Flags().Bool(optionNameWait, true, "wait until the batch is usable")
+	_ = buyCmd.MarkFlagRequired(optionNameAmount)
+	_ = buyCmd.MarkFlagRequired(optionNameDepth)
+	cmd.AddCommand(buyCmd)
+
+	lsCmd := &cobra.Command{
+		Use:   "ls",
+		Short: "List the postage batches",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newAPIClient(cmd, nil)
+			if err != nil {
+				return err
+			}
+			stamps, err := c.Stamps(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("list stamps: %w", err)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "BATCH ID\tLABEL\tDEPTH\tUTILIZATION\tUSABLE\tTTL")
+			for _, s := range stamps {
+				fmt.Fprintf(w, "%x\t%s\t%d\t%d\t%t\t%s\n", s.BatchID, s.Label, s.Depth, s.Utilization, s.Usable, time.Duration(s.BatchTTL)*time.Second)
+			}
+			return w.Flush()
+		},
+	}
+	addAPIURLFlag(lsCmd)
+	cmd.AddCommand(lsCmd)
+
+	c.root.AddCommand(cmd)
+}
+
+func addAPIURLFlag(cmd *cobra.Command) {
+	cmd.Flags().String(optionNameAPIURL, defaultAPIURL, "url of the node api")
+}
+
+// newAPIClient returns the client of the node API. The transferred
+// bytes are reported to the progress when it is not nil.
+func newAPIClient(cmd *cobra.Command, p *progress) (*client.Client, error) {
+	apiURL, err := cmd.Flags().GetString(optionNameAPIURL)
+	if err != nil {
+		return nil, fmt.Errorf("get api url: %w", err)
+	}
+	var httpClient *http.Client
+	if p != nil {
+		httpClient = &http.Client{Transport: &progressTransport{next: http.DefaultTransport, progress: p}}
+	}
+	c, err := client.New(apiURL, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("new api client: %w", err)
+	}
+	return c, nil
+}
+
+// waitSynced polls the tag until all the split chunks are synced.
+func waitSynced(cmd *cobra.Command, c *client.Client, uid uint64) error {
+	ticker := time.NewTicker(tagPollInterval)
+	defer ticker.Stop()
+	for {
+		t, err := c.Tag(cmd.Context(), uid)
+		if err != nil {
+			return fmt.Errorf("get tag: %w", err)
+		}
+		cmd.PrintErrf("\rsyncing: %d / %d chunks", t.Synced, t.Split)
+		if t.Split > 0 && t.Synced >= t.Split {
+			cmd.PrintErrln()
+			return nil
+		}
+		select {
+		case <-cmd.Context().Done():
+			cmd.PrintErrln()
+			return cmd.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitUsable polls the postage batch until it is usable.
+func waitUsable(cmd *cobra.Command, c *client.Client, batchID []byte) error {
+	cmd.PrintErrf("waiting for the batch %x to become usable...\n", batchID)
+	ticker := time.NewTicker(stampPollPeriod)
+	defer ticker.Stop()
+	for {
+		s, err := c.Stamp(cmd.Context(), batchID)
+		if err != nil {
+			var apiErr *client.Error
+			// the batch is not known to the node until the transaction is processed
+			if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+				return fmt.Errorf("get stamp: %w", err)
+			}
+		} else if s.Usable {
+			return nil
+		}
+		select {
+		case <-cmd.Context().Done():
+			return cmd.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// dirSize returns the size of the regular files in the directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// progress periodically writes the number of the transferred bytes
+// and the transfer rate.
+type progress struct {
+	w       io.Writer
+	label   string
+	total   atomic.Int64
+	n       atomic.Int64
+	start   time.Time
+	quit    chan struct{}
+	wg      sync.WaitGroup
+	stopped sync.Once
+}
+
+// newProgress starts writing the progress. The percentage is written
+// when the total is known.
+func newProgress(w io.Writer, label string, total int64) *progress {
+	p := &progress{
+		w:     w,
+		label: label,
+		start: time.Now(),
+		quit:  make(chan struct{}),
+	}
+	p.total.Store(total)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.quit:
+				return
+			case <-ticker.C:
+				p.print()
+			}
+		}
+	}()
+	return p
+}
+
+func (p *progress) add(n int) {
+	p.n.Add(int64(n))
+}
+
+// setTotal sets the total when it is not known yet.
+func (p *progress) setTotal(total int64) {
+	p.total.CompareAndSwap(0, total)
+}
+
+func (p *progress) print() {
+	n, total := p.n.Load(), p.total.Load()
+	rate := float64(n) / time.Since(p.start).Seconds()
+	if total > 0 {
+		// the directory archive is slightly larger than its files
+		pct := min(100*n/total, 100)
+		fmt.Fprintf(p.w, "\r%s: %s / %s (%d%%) %s/s   ", p.label, formatBytes(n), formatBytes(total), pct, formatBytes(int64(rate)))
+		return
+	}
+	fmt.Fprintf(p.w, "\r%s: %s %s/s   ", p.label, formatBytes(n), formatBytes(int64(rate)))
+}
+
+// stop writes the final progress.
+func (p *progress) stop() {
+	p.stopped.Do(func() {
+		close(p.quit)
+		p.wg.Wait()
+		if p.n.Load() > 0 {
+			p.print()
+			fmt.Fprintln(p.w)
+		}
+	})
+}
+
+// progressTransport reports the bytes of the request bodies and the
+// response bodies to the progress.
+type progressTransport struct {
+	next     http.RoundTripper
+	progress *progress
+}
+
+func (t *progressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body = &progressReader{ReadCloser: req.Body, progress: t.progress}
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Method == http.MethodGet {
+		if resp.ContentLength > 0 {
+			t.progress.setTotal(resp.ContentLength)
+		}
+		resp.Body = &progressReader{ReadCloser: resp.Body, progress: t.progress}
+	}
+	return resp, nil
+}
+
+type progressReader struct {
+	io.ReadCloser
+	progress *progress
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.ReadCloser.Read(b)
+	r.progress.add(n)
+	return n, err
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/cmd/bee/cmd"
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestClientCmds(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ref     = swarm.RandAddress(t)
+		batchID = strings.Repeat("ab", 32)
+		data    = []byte("hello swarm")
+		pinned  []string
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /bzz", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(api.SwarmPostageBatchIdHeader); got != batchID {
+			t.Errorf("got batch id %q", got)
+		}
+		if got := r.URL.Query().Get("name"); got != "file.txt" {
+			t.Errorf("got name %q", got)
+		}
+		if got := r.Header.Get(api.ContentTypeHeader); !strings.HasPrefix(got, "text/plain") {
+			t.Errorf("got content type %q", got)
+		}
+		if got := r.Header.Get(api.SwarmDeferredUploadHeader); got != "" {
+			t.Errorf("got deferred %q", got)
+		}
+		if body, _ := io.ReadAll(r.Body); !bytes.Equal(body, data) {
+			t.Errorf("got body %q", body)
+		}
+		w.Header().Set(api.SwarmTagHeader, "7")
+		jsonhttp.Created(w, map[string]swarm.Address{"reference": ref})
+	})
+	mux.HandleFunc("GET /tags/7", func(w http.ResponseWriter, r *http.Request) {
+		jsonhttp.OK(w, map[string]uint64{"uid": 7, "split": 1, "synced": 1})
+	})
+	mux.HandleFunc("GET /bzz/{ref}/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "11")
+		_, _ = w.Write(data)
+	})
+	mux.HandleFunc("POST /pins/{ref}", func(w http.ResponseWriter, r *http.Request) {
+		pinned = append(pinned, r.PathValue("ref"))
+		jsonhttp.Created(w, nil)
+	})
+	mux.HandleFunc("GET /pins", func(w http.ResponseWriter, r *http.Request) {
+		jsonhttp.OK(w, map[string][]string{"references": pinned})
+	})
+	mux.HandleFunc("POST /stamps/{amount}/{depth}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("amount") != "1000" || r.PathValue("depth") != "20" {
+			t.Errorf("got path %q", r.URL.Path)
+		}
+		jsonhttp.Created(w, map[string]string{"batchID": batchID, "txHash": "0x00"})
+	})
+	mux.HandleFunc("GET /stamps/{id}", func(w http.ResponseWriter, r *http.Request) {
+		jsonhttp.OK(w, map[string]any{"batchID": batchID, "usable": true, "exists": true})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	run := func(t *testing.T, args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		err := newCommand(t,
+			cmd.WithArgs(append(args, "--api-url", srv.URL)...),
+			cmd.WithOutput(&out),
+			cmd.WithErrorOutput(io.Discard),
+		).Execute()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out.String()
+	}
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := run(t, "upload", file, "--stamp", batchID, "--wait"); got != ref.String()+"\n" {
+		t.Fatalf("upload: got output %q", got)
+	}
+
+	output := filepath.Join(dir, "downloaded")
+	run(t, "download", ref.String(), "-o", output)
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("download: got %q, want %q", got, data)
+	}
+
+	run(t, "pin", "add", ref.String())
+	if got := run(t, "pin", "ls"); got != ref.String()+"\n" {
+		t.Fatalf("pin ls: got output %q", got)
+	}
+
+	if got := run(t, "stamp", "buy", "--amount", "1000", "--depth", "20"); got != batchID+"\n" {
+		t.Fatalf("stamp buy: got output %q", got)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	chaincfg "github.com/ethersphere/bee/v2/pkg/config"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/node"
+	"github.com/ethersphere/bee/v2/pkg/p2p/traffic"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -24,18 +25,60 @@ import (
 const (
 	optionNameDataDir                      = "data-dir"
 	optionNameCacheCapacity                = "cache-capacity"
+	optionNameSOCCacheTTL                  = "soc-cache-ttl"
+	optionNameFeedCacheTTL                 = "feed-cache-ttl"
 	optionNameDBOpenFilesLimit             = "db-open-files-limit"
 	optionNameDBBlockCacheCapacity         = "db-block-cache-capacity"
 	optionNameDBWriteBufferSize            = "db-write-buffer-size"
 	optionNameDBDisableSeeksCompaction     = "db-disable-seeks-compaction"
+	optionNameDBIndexStoreBackend          = "db-index-store-backend"
 	optionNamePassword                     = "password"
 	optionNamePasswordFile                 = "password-file"
+	optionNameKeystoreBackend              = "keystore-backend"
+	optionNameKeystoreNamespace            = "keystore-namespace"
+	optionNameKeystorePKCS11Module         = "keystore-pkcs11-module"
+	optionNameKeystorePKCS11Slot           = "keystore-pkcs11-slot"
+	optionNameKeystorePKCS11PIN            = "keystore-pkcs11-pin"
 	optionNameAPIAddr                      = "api-addr"
+	optionNameAPIReusePort                 = "api-reuse-port"
+	optionNameAPIAdminAddr                 = "api-admin-addr"
+	optionNameAPIDebugAddr                 = "api-debug-addr"
+	optionNameAPIValidateRequests          = "api-validate-requests"
+	optionNameAPIIdempotencyWindow         = "api-idempotency-window"
+	optionNameAPIBzzAccessLog              = "api-bzz-access-log"
+	optionNameAPIBzzAccessLogMaxSize       = "api-bzz-access-log-max-size"
+	optionNameAPIBzzAccessLogMaxBackups    = "api-bzz-access-log-max-backups"
+	optionNameAPIReadOnly                  = "api-read-only"
+	optionNameAPIRequestSigningSecret      = "api-request-signing-secret"
+	optionNameAPIRequestSigningWindow      = "api-request-signing-window"
+	optionNameChunkValidationOffload       = "chunk-validation-offload"
+	optionNameGRPCAddr                     = "grpc-addr"
+	optionNameS3Addr                       = "s3-addr"
+	optionNameS3BatchID                    = "s3-batch-id"
+	optionNameS3RootTopic                  = "s3-root-topic"
+	optionNameWebDAVAddr                   = "webdav-addr"
+	optionNameWebDAVBatchID                = "webdav-batch-id"
+	optionNameStampProxyAddr               = "stamp-proxy-addr"
+	optionNameFUSEEnable                   = "fuse-enable"
+	optionNameImageTransformEnable         = "image-transform-enable"
 	optionNameP2PAddr                      = "p2p-addr"
 	optionNameNATAddr                      = "nat-addr"
 	optionNameP2PWSEnable                  = "p2p-ws-enable"
+	optionNameP2PQUICEnable                = "p2p-quic-enable"
+	optionNameP2PWebTransportEnable        = "p2p-webtransport-enable"
+	optionNameP2PDialPreference            = "p2p-dial-preference"
+	optionNameP2PRelayServiceEnable        = "p2p-relay-service-enable"
+	optionNameP2PRelayClientEnable         = "p2p-relay-client-enable"
+	optionNameP2PStaticRelays              = "p2p-static-relays"
+	optionNameP2PCompressionEnable         = "p2p-compression-enable"
+	optionNameTrafficMonthlyCap            = "traffic-monthly-cap"
+	optionNameTrafficThrottleRate          = "traffic-throttle-rate"
 	optionNameBootnodes                    = "bootnode"
+	optionNameDNSSeeds                     = "dns-seeds"
+	optionNameMDNSEnable                   = "mdns-enable"
 	optionNameNetworkID                    = "network-id"
+	optionNameNetworkKey                   = "network-key"
+	optionNameNetworkAllowlist             = "network-allowlist"
 	optionWelcomeMessage                   = "welcome-message"
 	optionCORSAllowedOrigins               = "cors-allowed-origins"
 	optionNameTracingEnabled               = "tracing-enable"
@@ -52,19 +95,27 @@ const (
 	optionNameBlockchainRpcEndpoint        = "blockchain-rpc-endpoint"
 	optionNameSwapFactoryAddress           = "swap-factory-address"
 	optionNameSwapInitialDeposit           = "swap-initial-deposit"
+	optionNameSwapChequebookFunderKey      = "swap-chequebook-funder-key"
 	optionNameSwapEnable                   = "swap-enable"
 	optionNameChequebookEnable             = "chequebook-enable"
 	optionNameFullNode                     = "full-node"
 	optionNamePostageContractAddress       = "postage-stamp-address"
 	optionNamePostageContractStartBlock    = "postage-stamp-start-block"
 	optionNamePriceOracleAddress           = "price-oracle-address"
+	optionNameSwapExchangeRate             = "swap-exchange-rate"
+	optionNameSwapDeduction                = "swap-deduction"
+	optionNamePostagePrice                 = "postage-price"
 	optionNameRedistributionAddress        = "redistribution-address"
 	optionNameStakingAddress               = "staking-address"
 	optionNameBlockTime                    = "block-time"
 	optionWarmUpTime                       = "warmup-time"
 	optionNameMainNet                      = "mainnet"
 	optionNameRetrievalCaching             = "cache-retrieval"
+	optionNameUpstreamGateway              = "upstream-gateway"
+	optionNameUpstreamGatewayTimeout       = "upstream-gateway-timeout"
 	optionNameDevReserveCapacity           = "dev-reserve-capacity"
+	optionNameDevSeed                      = "dev-seed"
+	optionNameDevFixturesDir               = "dev-fixtures-dir"
 	optionNameResync                       = "resync"
 	optionNamePProfBlock                   = "pprof-profile"
 	optionNamePProfMutex                   = "pprof-mutex"
@@ -80,6 +131,38 @@ const (
 	optionNameTransactionDebugMode         = "transaction-debug-mode"
 	optionMinimumStorageRadius             = "minimum-storage-radius"
 	optionReserveCapacityDoubling          = "reserve-capacity-doubling"
+	optionNameRestoreFrom                  = "restore-from"
+	optionNameReserveDisable               = "reserve-disable"
+	optionNameEphemeral                    = "ephemeral"
+	optionNameRestorePassword              = "restore-password"
+	optionNameStateStoreAPIEnable          = "statestore-api-enable"
+	optionNamePostageSnapshotTrustedNodes  = "postage-snapshot-trusted-nodes"
+	optionNameBlockchainRpcCacheEnable     = "blockchain-rpc-cache-enable"
+	optionNameBlockchainRpcHourlyBudget    = "blockchain-rpc-hourly-budget"
+	optionNamePushSyncReceiptsEnable       = "pushsync-receipts-enable"
+	optionNamePushSyncReplicationFactor    = "pushsync-replication-factor"
+	optionNamePullSyncBandwidthLimit       = "pullsync-bandwidth-limit"
+	optionNamePullSyncHistoricalHours      = "pullsync-historical-hours"
+	optionNamePullSyncHistoryDays          = "pullsync-history-days"
+	optionNamePullSyncHistoryBins          = "pullsync-history-bins"
+	optionNameMaintenanceWindows           = "maintenance-windows"
+	optionNamePushSyncBandwidthLimit       = "pushsync-bandwidth-limit"
+	optionNameReplicationRepairEnable      = "replication-repair-enable"
+	optionNameReplicationRepairInterval    = "replication-repair-interval"
+	optionNameRecoveryBatchID              = "recovery-batch-id"
+	optionNameKademliaPrunePolicy          = "kademlia-prune-policy"
+	optionNamePeeringPinned                = "peering-pinned"
+	optionNamePeeringDenied                = "peering-denied"
+	optionNamePeeringGroups                = "peering-groups"
+	optionNameWebhookURL                   = "webhook-url"
+	optionNameWebhookTopics                = "webhook-topics"
+	optionNameWebhookSecret                = "webhook-secret"
+	optionNameShutdownDrainDelay           = "shutdown-drain-delay"
+	optionNameShutdownTimeout              = "shutdown-timeout"
+	optionNameReadinessMinPeers            = "readiness-min-peers"
+	optionNameReadinessMinDepth            = "readiness-min-depth"
+	optionNameReadinessBatchStoreSynced    = "readiness-batchstore-synced"
+	optionNameReadinessChainSynced         = "readiness-chain-synced"
 )
 
 // nolint:gochecknoinits
@@ -94,6 +177,7 @@ type command struct {
 	cfgFile          string
 	homeDir          string
 	isWindowsService bool
+	recentLogs       *log.RecentSink
 }
 
 type option func(*command)
@@ -147,6 +231,8 @@ func newCommand(opts ...option) (c *command, err error) {
 
 	c.initVersionCmd()
 	c.initDBCmd()
+	c.initHiveCmd()
+	c.initClientCmds()
 	if err := c.initSplitCmd(); err != nil {
 		return nil, err
 	}
@@ -233,22 +319,65 @@ func (c *command) setHomeDir() (err error) {
 
 func (c *command) setAllFlags(cmd *cobra.Command) {
 	cmd.Flags().String(optionNameDataDir, filepath.Join(c.homeDir, ".bee"), "data directory")
+	cmd.Flags().Bool(optionNameEphemeral, false, fmt.Sprintf("keep all node state and keys in memory, leaving nothing on disk; only the cache is capped, at %d chunks, the state and the uploads waiting to be synced are not", maxEphemeralCacheCapacity))
 	cmd.Flags().Uint64(optionNameCacheCapacity, 1_000_000, fmt.Sprintf("cache capacity in chunks, multiply by %d to get approximate capacity in bytes", swarm.ChunkSize))
+	cmd.Flags().Duration(optionNameSOCCacheTTL, 5*time.Minute, "age after which the cached single owner chunks are retrieved again by the soc downloads, zero for no expiry")
+	cmd.Flags().Duration(optionNameFeedCacheTTL, time.Minute, "age after which the cached feed updates are retrieved again by the feed lookups, zero for no expiry")
 	cmd.Flags().Uint64(optionNameDBOpenFilesLimit, 200, "number of open files allowed by database")
 	cmd.Flags().Uint64(optionNameDBBlockCacheCapacity, 32*1024*1024, "size of block cache of the database in bytes")
 	cmd.Flags().Uint64(optionNameDBWriteBufferSize, 32*1024*1024, "size of the database write buffer in bytes")
 	cmd.Flags().Bool(optionNameDBDisableSeeksCompaction, true, "disables db compactions triggered by seeks")
-	cmd.Flags().String(optionNamePassword, "", "password for decrypting keys")
+	cmd.Flags().String(optionNameDBIndexStoreBackend, "", "key-value store used for the localstore indexes: leveldb or pebble (default is the existing one or leveldb); an existing index store is migrated on change")
+	cmd.Flags().String(optionNamePassword, "", "password for decrypting keys, a secret can be referenced as ${env:NAME}, ${file:path} or ${vault:path#field}")
 	cmd.Flags().String(optionNamePasswordFile, "", "path to a file that contains password for decrypting keys")
-	cmd.Flags().String(optionNameAPIAddr, "127.0.0.1:1633", "HTTP API listen address")
+	cmd.Flags().String(optionNameKeystoreBackend, "file", "where the keys are kept: file (in the data directory), keychain (of the operating system) or pkcs11 (on the token)")
+	cmd.Flags().String(optionNameKeystoreNamespace, "bee", "keychain service name or pkcs11 object label prefix of the keys, distinct for the nodes sharing the keychain or the token")
+	cmd.Flags().String(optionNameKeystorePKCS11Module, "", "path to the PKCS#11 module library of the token")
+	cmd.Flags().String(optionNameKeystorePKCS11Slot, "", "slot of the PKCS#11 token, the first slot with a token if empty")
+	cmd.Flags().String(optionNameKeystorePKCS11PIN, "", "user PIN of the PKCS#11 token")
+	cmd.Flags().String(optionNameAPIAddr, "127.0.0.1:1633", "HTTP API listen address, ignored when the api socket is passed by the systemd socket activation")
+	cmd.Flags().Bool(optionNameAPIReusePort, false, "allow other processes to bind the api address, so that the replacing node takes over the connections while this one is drained")
+	cmd.Flags().String(optionNameAPIAdminAddr, "", "HTTP API listen address of the operator routes, including the technical routes changing the node state, served only on the api address if not set")
+	cmd.Flags().String(optionNameAPIDebugAddr, "", "HTTP API listen address of the read-only debugging and profiling routes, served only on the api address if not set")
+	cmd.Flags().Bool(optionNameAPIValidateRequests, false, "reject the API requests with the path parameters not matching the OpenAPI document")
+	cmd.Flags().Duration(optionNameAPIIdempotencyWindow, 24*time.Hour, "time for which the responses of the uploads made with an idempotency key are returned to the retried requests, zero to disable")
+	cmd.Flags().String(optionNameAPIBzzAccessLog, "", "file to which the accesses to the content served by the bzz endpoint are logged as JSON lines, empty to disable")
+	cmd.Flags().Int64(optionNameAPIBzzAccessLogMaxSize, 100*1024*1024, "size in bytes after which the bzz access log file is rotated, zero to disable the rotation")
+	cmd.Flags().Int(optionNameAPIBzzAccessLogMaxBackups, 5, "number of the rotated bzz access log files to keep")
+	cmd.Flags().Bool(optionNameAPIReadOnly, false, "start the node in the read-only mode in which the uploads, pins, wallet and chain transactions are rejected by the HTTP and gRPC APIs, the S3 gateway and the WebDAV server")
+	cmd.Flags().String(optionNameAPIRequestSigningSecret, "", "secret the requests to the withdrawal, stake, batch transfer, state store, key rotation, node mode, read-only, reserve import and backup endpoints must be signed with in the Swarm-Request-Signature header, empty to disable")
+	cmd.Flags().Duration(optionNameAPIRequestSigningWindow, 5*time.Minute, "maximum age of the signed requests, each signed request is accepted once")
+	cmd.Flags().Bool(optionNameChunkValidationOffload, true, "skip the re-validation of the chunks created by the uploads of the node")
+	cmd.Flags().String(optionNameGRPCAddr, "", "gRPC API listen address, disabled when empty")
+	cmd.Flags().String(optionNameS3Addr, "", "S3 gateway listen address, disabled when empty")
+	cmd.Flags().String(optionNameS3BatchID, "", "postage batch ID the S3 gateway uploads are stamped with")
+	cmd.Flags().String(optionNameS3RootTopic, "s3", "topic of the feed listing the S3 gateway buckets")
+	cmd.Flags().String(optionNameWebDAVAddr, "", "WebDAV server listen address, disabled when empty")
+	cmd.Flags().String(optionNameWebDAVBatchID, "", "postage batch ID the WebDAV uploads are stamped with, the feeds are read only when empty")
+	cmd.Flags().String(optionNameStampProxyAddr, "", "listen address of the uploads stamped on behalf of the clients registered through the API, disabled when empty")
+	cmd.Flags().Bool(optionNameFUSEEnable, false, "enable the FUSE mounts of the manifests and feeds through the API")
+	cmd.Flags().Bool(optionNameImageTransformEnable, false, "enable the resizing and the conversion of the images downloaded from the manifests with the width, height and format query parameters")
 	cmd.Flags().String(optionNameP2PAddr, ":1634", "P2P listen address")
 	cmd.Flags().String(optionNameNATAddr, "", "NAT exposed address")
 	cmd.Flags().Bool(optionNameP2PWSEnable, false, "enable P2P WebSocket transport")
+	cmd.Flags().Bool(optionNameP2PQUICEnable, false, "enable P2P QUIC transport")
+	cmd.Flags().Bool(optionNameP2PWebTransportEnable, false, "enable P2P WebTransport transport")
+	cmd.Flags().String(optionNameP2PDialPreference, "auto", "transport dialed first when a peer is reachable over more than one: auto, tcp or quic")
+	cmd.Flags().Bool(optionNameP2PRelayServiceEnable, false, "relay the connections of unreachable nodes when publicly reachable")
+	cmd.Flags().Bool(optionNameP2PRelayClientEnable, false, "connect through circuit relays when not publicly reachable")
+	cmd.Flags().StringSlice(optionNameP2PStaticRelays, nil, "multiaddresses of the circuit relays, the connected full nodes are used if not set")
+	cmd.Flags().Bool(optionNameP2PCompressionEnable, false, "compress the pullsync and retrieval streams with the peers supporting it")
+	cmd.Flags().Uint64(optionNameTrafficMonthlyCap, 0, "monthly cap of the p2p traffic in bytes after which the non-essential protocols are refused, 0 disables the cap")
+	cmd.Flags().Int(optionNameTrafficThrottleRate, traffic.DefaultThrottleRate, "transfer rate in bytes per second of the non-essential protocols when the p2p traffic nears the monthly cap")
 	cmd.Flags().StringSlice(optionNameBootnodes, []string{"/dnsaddr/mainnet.ethswarm.org"}, "initial nodes to connect to")
+	cmd.Flags().StringSlice(optionNameDNSSeeds, nil, "domains whose TXT records list the initial nodes to connect to")
+	cmd.Flags().Bool(optionNameMDNSEnable, false, "discover the nodes on the local network over the multicast DNS")
 	cmd.Flags().Uint64(optionNameNetworkID, chaincfg.Mainnet.NetworkID, "ID of the Swarm network")
+	cmd.Flags().String(optionNameNetworkKey, "", "pre-shared secret of the private swarm the peers must know to connect")
+	cmd.Flags().StringSlice(optionNameNetworkAllowlist, nil, "overlays of the only peers allowed to connect to the private swarm")
 	cmd.Flags().StringSlice(optionCORSAllowedOrigins, []string{}, "origins with CORS headers enabled")
 	cmd.Flags().Bool(optionNameTracingEnabled, false, "enable tracing")
-	cmd.Flags().String(optionNameTracingEndpoint, "127.0.0.1:6831", "endpoint to send tracing data")
+	cmd.Flags().String(optionNameTracingEndpoint, "127.0.0.1:6831", "endpoint to send tracing data: host:port of the Jaeger agent, or grpc://, grpcs://, http:// or https:// URL of the OpenTelemetry collector")
 	cmd.Flags().String(optionNameTracingHost, "", "host to send tracing data")
 	cmd.Flags().String(optionNameTracingPort, "", "port to send tracing data")
 	cmd.Flags().String(optionNameTracingServiceName, "bee", "service name identifier for tracing")
@@ -262,18 +391,25 @@ func (c *command) setAllFlags(cmd *cobra.Command) {
 	cmd.Flags().String(optionNameBlockchainRpcEndpoint, "", "rpc blockchain endpoint")
 	cmd.Flags().String(optionNameSwapFactoryAddress, "", "swap factory addresses")
 	cmd.Flags().String(optionNameSwapInitialDeposit, "0", "initial deposit if deploying a new chequebook")
+	cmd.Flags().String(optionNameSwapChequebookFunderKey, "", "hex encoded private key of the account paying the gas for the chequebook deployment")
 	cmd.Flags().Bool(optionNameSwapEnable, false, "enable swap")
 	cmd.Flags().Bool(optionNameChequebookEnable, true, "enable chequebook")
 	cmd.Flags().Bool(optionNameFullNode, false, "cause the node to start in full mode")
+	cmd.Flags().Bool(optionNameReserveDisable, false, "run the full node without the reserve, pullsync and the storage incentives")
 	cmd.Flags().String(optionNamePostageContractAddress, "", "postage stamp contract address")
 	cmd.Flags().Uint64(optionNamePostageContractStartBlock, 0, "postage stamp contract start block number")
 	cmd.Flags().String(optionNamePriceOracleAddress, "", "price oracle contract address")
+	cmd.Flags().String(optionNameSwapExchangeRate, "", "static exchange rate of the accounting units to PLUR used instead of the price oracle contract, all the nodes must use the same rate")
+	cmd.Flags().String(optionNameSwapDeduction, "0", "static deduction of the first cheque used with the static exchange rate")
+	cmd.Flags().String(optionNamePostagePrice, "", "static postage price per chunk per block in PLUR used instead of the postage contract price, all the nodes must use the same price")
 	cmd.Flags().String(optionNameRedistributionAddress, "", "redistribution contract address")
 	cmd.Flags().String(optionNameStakingAddress, "", "staking contract address")
 	cmd.Flags().Uint64(optionNameBlockTime, 5, "chain block time")
 	cmd.Flags().Duration(optionWarmUpTime, time.Minute*5, "time to warmup the node before some major protocols can be kicked off")
 	cmd.Flags().Bool(optionNameMainNet, true, "triggers connect to main net bootnodes.")
 	cmd.Flags().Bool(optionNameRetrievalCaching, true, "enable forwarded content caching")
+	cmd.Flags().String(optionNameUpstreamGateway, "", "URL of the API of an upstream bee gateway from which the chunks missing locally are retrieved before the network, disabled when empty")
+	cmd.Flags().Duration(optionNameUpstreamGatewayTimeout, 10*time.Second, "timeout of the chunk requests to the upstream gateway")
 	cmd.Flags().Bool(optionNameResync, false, "forces the node to resync postage contract data")
 	cmd.Flags().Bool(optionNamePProfBlock, false, "enable pprof block profile")
 	cmd.Flags().Bool(optionNamePProfMutex, false, "enable pprof mutex profile")
@@ -288,29 +424,53 @@ func (c *command) setAllFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool(optionNameTransactionDebugMode, false, "skips the gas estimate step for contract transactions")
 	cmd.Flags().Uint(optionMinimumStorageRadius, 0, "minimum radius storage threshold")
 	cmd.Flags().Int(optionReserveCapacityDoubling, 0, "reserve capacity doubling")
+	cmd.Flags().String(optionNameRestoreFrom, "", "path to a backup archive to restore the node state from before start")
+	cmd.Flags().String(optionNameRestorePassword, "", "password for decrypting the keys of the restored backup")
+	cmd.Flags().Bool(optionNameStateStoreAPIEnable, false, "enable the statestore inspection and editing API endpoints")
+	cmd.Flags().StringSlice(optionNamePostageSnapshotTrustedNodes, []string{}, "API endpoints of trusted nodes to bootstrap the batch store from")
+	cmd.Flags().Bool(optionNameBlockchainRpcCacheEnable, false, "cache the blockchain backend responses")
+	cmd.Flags().Uint64(optionNameBlockchainRpcHourlyBudget, 0, "maximum number of blockchain backend requests per hour, 0 means unlimited")
+	cmd.Flags().Bool(optionNamePushSyncReceiptsEnable, false, "keep the receipts of the pushed chunks for a week to challenge their storers")
+	cmd.Flags().Uint(optionNamePushSyncReplicationFactor, 3, "number of neighborhood peers the pushed chunks are replicated to")
+	cmd.Flags().Float64(optionNamePullSyncBandwidthLimit, 0, "maximum pullsync bandwidth in megabytes per second, zero means unlimited")
+	cmd.Flags().String(optionNamePullSyncHistoricalHours, "", "daily local time hours of the historical syncing, e.g. 22-6, empty means all day")
+	cmd.Flags().Uint(optionNamePullSyncHistoryDays, 0, "number of the recent days of the chunks pulled by the historical syncing, zero means the full history")
+	cmd.Flags().String(optionNamePullSyncHistoryBins, "", "comma separated bins synced with the limited history, e.g. 0,1,2, empty means all bins")
+	cmd.Flags().String(optionNameMaintenanceWindows, "", "comma separated daily local time windows during which the pullsync and the cashouts are paused, e.g. 02:00-04:00")
+	cmd.Flags().Float64(optionNamePushSyncBandwidthLimit, 0, "maximum pushsync bandwidth of the deferred uploads in megabytes per second, zero means unlimited")
+	cmd.Flags().Bool(optionNameReplicationRepairEnable, false, "periodically push the reserve chunks held by too few neighborhood peers to the peers missing them")
+	cmd.Flags().Duration(optionNameReplicationRepairInterval, 10*time.Minute, "time between the replication repair rounds")
+	cmd.Flags().String(optionNameRecoveryBatchID, "", "postage batch id the pinned content is re-uploaded with on the recovery requests, empty disables the responses")
+	cmd.Flags().String(optionNameKademliaPrunePolicy, "score", "policy picking the peers pruned from oversaturated bins: score, latency or random")
+	cmd.Flags().StringSlice(optionNamePeeringPinned, []string{}, "overlays of the peers always kept connected and never pruned")
+	cmd.Flags().StringSlice(optionNamePeeringDenied, []string{}, "overlays or ip ranges in cidr notation never connected")
+	cmd.Flags().StringSlice(optionNamePeeringGroups, []string{}, "private peering groups of the peers kept connected, each as name:overlay[:overlay...]")
+	cmd.Flags().String(optionNameWebhookURL, "", "url the node events are posted to as json")
+	cmd.Flags().StringSlice(optionNameWebhookTopics, []string{}, "topics of the node events posted to the webhook, all topics if empty")
+	cmd.Flags().String(optionNameWebhookSecret, "", "secret signing the webhook requests with hmac-sha256 in the Swarm-Event-Signature header")
+	cmd.Flags().Duration(optionNameShutdownDrainDelay, 0, "time the api keeps serving requests on shutdown after the readiness is flipped to not ready")
+	cmd.Flags().Duration(optionNameShutdownTimeout, 15*time.Second, "time to wait for the in-flight api requests to finish on shutdown")
+	cmd.Flags().Int(optionNameReadinessMinPeers, 0, "minimum number of connected peers for the node to be reported as ready")
+	cmd.Flags().Uint8(optionNameReadinessMinDepth, 0, "minimum kademlia depth for the node to be reported as ready")
+	cmd.Flags().Bool(optionNameReadinessBatchStoreSynced, false, "require the postage batch store to be synced for the node to be reported as ready")
+	cmd.Flags().Bool(optionNameReadinessChainSynced, false, "require the blockchain backend to be synced for the node to be reported as ready")
 }
 
-func newLogger(cmd *cobra.Command, verbosity string) (log.Logger, error) {
-	var (
-		sink   = cmd.OutOrStdout()
-		vLevel = log.VerbosityNone
-	)
+// newLogger returns the logger writing to the command output and the
+// additional sinks at the given verbosity.
+func newLogger(cmd *cobra.Command, verbosity string, sinks ...io.Writer) (log.Logger, error) {
+	vLevel, err := parseVerbosity(verbosity)
+	if err != nil {
+		return nil, err
+	}
 
-	switch verbosity {
-	case "0", "silent":
+	sink := cmd.OutOrStdout()
+	if vLevel == log.VerbosityNone {
 		sink = io.Discard
-	case "1", "error":
-		vLevel = log.VerbosityError
-	case "2", "warn":
-		vLevel = log.VerbosityWarning
-	case "3", "info":
-		vLevel = log.VerbosityInfo
-	case "4", "debug":
-		vLevel = log.VerbosityDebug
-	case "5", "trace":
-		vLevel = log.VerbosityDebug + 1 // For backwards compatibility, just enable v1 debugging as trace.
-	default:
-		return nil, fmt.Errorf("unknown verbosity level %q", verbosity)
+	}
+
+	if len(sinks) > 0 {
+		sink = io.MultiWriter(append([]io.Writer{sink}, sinks...)...)
 	}
 
 	log.ModifyDefaults(
@@ -325,6 +485,25 @@ func newLogger(cmd *cobra.Command, verbosity string) (log.Logger, error) {
 	).Register(), nil
 }
 
+// parseVerbosity returns the logger verbosity level of the verbosity option.
+func parseVerbosity(verbosity string) (log.Level, error) {
+	switch verbosity {
+	case "0", "silent":
+		return log.VerbosityNone, nil
+	case "1", "error":
+		return log.VerbosityError, nil
+	case "2", "warn":
+		return log.VerbosityWarning, nil
+	case "3", "info":
+		return log.VerbosityInfo, nil
+	case "4", "debug":
+		return log.VerbosityDebug, nil
+	case "5", "trace":
+		return log.VerbosityDebug + 1, nil // For backwards compatibility, just enable v1 debugging as trace.
+	}
+	return 0, fmt.Errorf("unknown verbosity level %q", verbosity)
+}
+
 func (c *command) CheckUnknownParams(cmd *cobra.Command, args []string) error {
 	if err := c.initConfig(); err != nil {
 		return err
@@ -9,13 +9,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/node"
@@ -34,6 +37,8 @@ const (
 	optionNameValidationPin  = "validate-pin"
 	optionNameCollectionPin  = "pin"
 	optionNameOutputLocation = "output"
+	optionNameJSON           = "json"
+	optionNameTopBatches     = "top-batches"
 )
 
 func (c *command) initDBCmd() {
@@ -46,6 +51,7 @@ func (c *command) initDBCmd() {
 	dbImportCmd(cmd)
 	dbNukeCmd(cmd)
 	dbInfoCmd(cmd)
+	dbAnalyzeCmd(cmd)
 	dbCompactCmd(cmd)
 	dbValidateCmd(cmd)
 	dbValidatePinsCmd(cmd)
@@ -114,6 +120,157 @@ func dbInfoCmd(cmd *cobra.Command) {
 	cmd.AddCommand(c)
 }
 
+func dbAnalyzeCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "analyze",
+		Short: "Prints the reserve, cache and sharky statistics and checks the consistency of the indexes with sharky.",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			v, err := cmd.Flags().GetString(optionNameVerbosity)
+			if err != nil {
+				return fmt.Errorf("get verbosity: %w", err)
+			}
+			v = strings.ToLower(v)
+			logger, err := newLogger(cmd, v)
+			if err != nil {
+				return fmt.Errorf("new logger: %w", err)
+			}
+
+			dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+			if err != nil {
+				return fmt.Errorf("get data-dir: %w", err)
+			}
+			if dataDir == "" {
+				return errors.New("no data-dir provided")
+			}
+
+			asJSON, err := cmd.Flags().GetBool(optionNameJSON)
+			if err != nil {
+				return fmt.Errorf("get json: %w", err)
+			}
+			topBatches, err := cmd.Flags().GetInt(optionNameTopBatches)
+			if err != nil {
+				return fmt.Errorf("get top batches: %w", err)
+			}
+
+			localstorePath := path.Join(dataDir, ioutil.DataPathLocalstore)
+
+			a, err := storer.Analyze(cmd.Context(), localstorePath, &storer.Options{
+				Logger:          logger,
+				RadiusSetter:    noopRadiusSetter{},
+				Batchstore:      new(postage.NoOpBatchStore),
+				ReserveCapacity: storer.DefaultReserveCapacity,
+			})
+			if err != nil {
+				return fmt.Errorf("localstore: %w", err)
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(a)
+			}
+
+			printAnalysis(cmd.OutOrStdout(), a, topBatches)
+
+			if n := a.Consistency.Total(); n > 0 {
+				logger.Warning("localstore inconsistencies found", "count", n)
+			}
+			return nil
+		},
+	}
+	c.Flags().String(optionNameDataDir, "", "data directory")
+	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	c.Flags().Bool(optionNameJSON, false, "print the analysis as json")
+	c.Flags().Int(optionNameTopBatches, 10, "number of the batches with the most chunks to print")
+	cmd.AddCommand(c)
+}
+
+// printAnalysis writes the human readable localstore analysis.
+func printAnalysis(out io.Writer, a *storer.Analysis, topBatches int) {
+	// table writes the rows aligned in the columns under the title
+	table := func(title string, rows ...string) {
+		fmt.Fprintf(out, "%s\n", title)
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, row := range rows {
+			fmt.Fprintf(w, "  %s\n", row)
+		}
+		_ = w.Flush()
+		fmt.Fprintln(out)
+	}
+
+	ageLabels := make([]string, 0, len(storer.AgeBuckets)+1)
+	for _, d := range storer.AgeBuckets {
+		ageLabels = append(ageLabels, "<= "+formatAge(d))
+	}
+	ageLabels = append(ageLabels, "> "+formatAge(storer.AgeBuckets[len(storer.AgeBuckets)-1]))
+
+	rows := []string{"BIN\tCHUNKS"}
+	for bin, n := range a.Reserve.Bins {
+		if n > 0 {
+			rows = append(rows, fmt.Sprintf("%d\t%d", bin, n))
+		}
+	}
+	table(fmt.Sprintf("Reserve: %d chunks", a.Reserve.Chunks), rows...)
+
+	batches := make([]string, 0, len(a.Reserve.Batches))
+	for id := range a.Reserve.Batches {
+		batches = append(batches, id)
+	}
+	sort.Slice(batches, func(i, j int) bool {
+		return a.Reserve.Batches[batches[i]] > a.Reserve.Batches[batches[j]]
+	})
+	rows = []string{"BATCH\tCHUNKS"}
+	for _, id := range batches[:min(topBatches, len(batches))] {
+		rows = append(rows, fmt.Sprintf("%s\t%d", id, a.Reserve.Batches[id]))
+	}
+	table(fmt.Sprintf("Reserve batches: %d", len(batches)), rows...)
+
+	rows = []string{"STAMP AGE\tCHUNKS"}
+	for i, n := range a.Reserve.StampAges {
+		rows = append(rows, fmt.Sprintf("%s\t%d", ageLabels[i], n))
+	}
+	table("Reserve stamp ages:", rows...)
+
+	rows = []string{"LAST ACCESS\tENTRIES"}
+	for i, n := range a.Cache.AccessAges {
+		rows = append(rows, fmt.Sprintf("%s\t%d", ageLabels[i], n))
+	}
+	table(fmt.Sprintf("Cache: %d entries", a.Cache.Entries), rows...)
+
+	rows = []string{"SHARD\tSLOTS\tUSED\tFREE\tHOLES\tFRAGMENTATION"}
+	for _, s := range a.Sharky.Shards {
+		rows = append(rows, fmt.Sprintf("%d\t%d\t%d\t%d\t%d\t%.1f%%", s.Shard, s.Slots, s.Used, s.Free, s.Holes, 100*s.Fragmentation()))
+	}
+	table("Sharky:", rows...)
+
+	c := a.Consistency
+	rows = []string{"CHECK\tISSUES"}
+	for _, check := range []struct {
+		name  string
+		count int
+	}{
+		{"reserve entries without chunk", c.MissingReserveChunks},
+		{"reserve entries without stamp", c.MissingReserveStamps},
+		{"reserve entries without bin entry", c.MissingBinEntries},
+		{"bin entries without reserve entry", c.DanglingBinEntries},
+		{"cache entries without chunk", c.MissingCacheChunks},
+		{"slots shared by chunks", c.SlotConflicts},
+		{"used slots marked free", c.FreeUsedSlots},
+		{"slots beyond shard end", c.OutOfRangeSlots},
+		{"slots neither used nor free", c.LeakedSlots},
+	} {
+		rows = append(rows, fmt.Sprintf("%s\t%d", check.name, check.count))
+	}
+	table(fmt.Sprintf("Consistency: %d issues", c.Total()), rows...)
+}
+
+func formatAge(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return fmt.Sprintf("%dh", d/time.Hour)
+}
+
 func dbCompactCmd(cmd *cobra.Command) {
 	c := &cobra.Command{
 		Use:   "compact",
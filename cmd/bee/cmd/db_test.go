@@ -7,6 +7,7 @@ package cmd_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"path"
 	"strings"
@@ -269,6 +270,54 @@ func TestDBInfo(t *testing.T) {
 	}
 }
 
+func TestDBAnalyze(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	db := newTestDB(t, ctx, &storer.Options{
+		Batchstore:      new(postage.NoOpBatchStore),
+		RadiusSetter:    kademlia.NewTopologyDriver(),
+		Logger:          testutil.NewLogger(t),
+		ReserveCapacity: storer.DefaultReserveCapacity,
+	}, path.Join(dir, "localstore"))
+
+	nChunks := 10
+	for i := 0; i < nChunks; i++ {
+		ch := storagetest.GenerateTestRandomChunk()
+		if err := db.ReservePutter().Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.Close()
+
+	var buf bytes.Buffer
+	err := newCommand(t, cmd.WithArgs("db", "analyze", "--data-dir", dir, "--json", "--verbosity", "silent"), cmd.WithOutput(&buf)).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var a storer.Analysis
+	if err := json.Unmarshal(buf.Bytes(), &a); err != nil {
+		t.Fatal(err)
+	}
+	if a.Reserve.Chunks != nChunks {
+		t.Errorf("got %d reserve chunks, want %d", a.Reserve.Chunks, nChunks)
+	}
+	if n := a.Consistency.Total(); n != 0 {
+		t.Errorf("got %d inconsistencies, want none", n)
+	}
+
+	buf.Reset()
+	err = newCommand(t, cmd.WithArgs("db", "analyze", "--data-dir", dir, "--verbosity", "silent"), cmd.WithOutput(&buf)).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), fmt.Sprintf("Reserve: %d chunks", nChunks)) {
+		t.Errorf("got output %q", buf.String())
+	}
+}
+
 func TestMarshalChunk(t *testing.T) {
 	t.Parallel()
 	ch := storagetest.GenerateTestRandomChunk()
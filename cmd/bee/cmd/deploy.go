@@ -9,7 +9,9 @@ import (
 	"strings"
 
 	"github.com/ethersphere/bee/v2/pkg/node"
+	"github.com/ethersphere/bee/v2/pkg/settlement/swap/chequebook"
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap/erc20"
+	"github.com/ethersphere/bee/v2/pkg/transaction/cached"
 	"github.com/spf13/cobra"
 )
 
@@ -34,6 +36,7 @@ func (c *command) initDeployCmd() error {
 			dataDir := c.config.GetString(optionNameDataDir)
 			factoryAddress := c.config.GetString(optionNameSwapFactoryAddress)
 			swapInitialDeposit := c.config.GetString(optionNameSwapInitialDeposit)
+			funderKey := c.config.GetString(optionNameSwapChequebookFunderKey)
 			blockchainRpcEndpoint := c.config.GetString(optionNameBlockchainRpcEndpoint)
 			stateStore, _, err := node.InitStateStore(logger, dataDir, 1000)
 			if err != nil {
@@ -59,6 +62,10 @@ func (c *command) initDeployCmd() error {
 				signer,
 				blocktime,
 				true,
+				cached.Options{
+					Cache:        c.config.GetBool(optionNameBlockchainRpcCacheEnable),
+					HourlyBudget: c.config.GetUint64(optionNameBlockchainRpcHourlyBudget),
+				},
 			)
 			if err != nil {
 				return err
@@ -78,6 +85,16 @@ func (c *command) initDeployCmd() error {
 
 			erc20Service := erc20.New(transactionService, erc20Address)
 
+			var sponsor *chequebook.Sponsor
+			if funderKey != "" {
+				var closeSponsor func()
+				sponsor, closeSponsor, err = node.InitChequebookSponsor(logger, swapBackend, chainID, blocktime, factoryAddress, funderKey)
+				if err != nil {
+					return err
+				}
+				defer closeSponsor()
+			}
+
 			_, err = node.InitChequebookService(
 				ctx,
 				logger,
@@ -90,6 +107,7 @@ func (c *command) initDeployCmd() error {
 				chequebookFactory,
 				swapInitialDeposit,
 				erc20Service,
+				sponsor,
 			)
 			if err != nil {
 				return err
@@ -98,7 +116,10 @@ func (c *command) initDeployCmd() error {
 			return err
 		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return c.config.BindPFlags(cmd.Flags())
+			if err := c.config.BindPFlags(cmd.Flags()); err != nil {
+				return err
+			}
+			return c.resolveSecrets(cmd.Context())
 		},
 	}
 
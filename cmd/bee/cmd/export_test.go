@@ -4,7 +4,12 @@
 
 package cmd
 
-import "io"
+import (
+	"io"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/spf13/viper"
+)
 
 type (
 	Command        = command
@@ -12,8 +17,13 @@ type (
 	PasswordReader = passwordReader
 )
 
+type HiveOptions = hiveOptions
+
+const MaxEphemeralCacheCapacity = maxEphemeralCacheCapacity
+
 var (
-	NewCommand = newCommand
+	NewCommand    = newCommand
+	RestoreBackup = restoreBackup
 
 	// avoid unused lint errors until the functions are used
 	_ = WithCfgFile
@@ -63,3 +73,35 @@ func WithPasswordReader(r PasswordReader) func(c *Command) {
 		c.passwordReader = r
 	}
 }
+
+// HiveNode is the name, the data directory, the API
+// address and the arguments of the hive node.
+type HiveNode struct {
+	Name    string
+	DataDir string
+	APIAddr string
+	Args    []string
+}
+
+func NewHiveNodes(o HiveOptions) ([]HiveNode, error) {
+	nodes, err := newHiveNodes(o)
+	if err != nil {
+		return nil, err
+	}
+	hn := make([]HiveNode, len(nodes))
+	for i, n := range nodes {
+		hn[i] = HiveNode{Name: n.name, DataDir: n.dataDir, APIAddr: n.apiAddr, Args: n.args}
+	}
+	return hn, nil
+}
+
+// ConfigureEphemeral configures the ephemeral mode of the node with the
+// options and returns the resulting configuration.
+func ConfigureEphemeral(options map[string]any) (*viper.Viper, error) {
+	c := &command{config: viper.New()}
+	for k, v := range options {
+		c.config.Set(k, v)
+	}
+	err := c.configureEphemeral(log.Noop)
+	return c.config, err
+}
@@ -0,0 +1,430 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// hiveMaxRestartDelay caps the delay of the restarts of the failing node.
+	hiveMaxRestartDelay = 5 * time.Minute
+	// hiveStableRun is the run duration after which the restart delay is reset.
+	hiveStableRun = time.Minute
+	// hiveShutdownTimeout is the time given to the node to shut down
+	// after the interrupt signal before it is killed.
+	hiveShutdownTimeout = time.Minute
+	// hiveStatusTimeout is the timeout of the status requests to the nodes.
+	hiveStatusTimeout = 5 * time.Second
+)
+
+// hiveOptions are the options of the supervised nodes.
+type hiveOptions struct {
+	Nodes      int
+	DataDir    string
+	ConfigFile string
+	APIPort    int
+	P2PPort    int
+	PortStep   int
+	NATAddr    string
+	Args       []string
+}
+
+// hiveNode is the bee node started and supervised by the hive command.
+type hiveNode struct {
+	name    string
+	dataDir string
+	apiAddr string
+	args    []string
+
+	mu       sync.Mutex
+	pid      int
+	running  bool
+	restarts int
+	exitErr  error
+}
+
+// newHiveNodes returns the nodes with the distinct data directories
+// and ports. The node i listens on the base ports increased by i times
+// the port step. The extra arguments are passed to all the nodes and
+// take precedence over the options of the config file.
+func newHiveNodes(o hiveOptions) ([]*hiveNode, error) {
+	if o.Nodes < 1 {
+		return nil, fmt.Errorf("invalid number of nodes: %d", o.Nodes)
+	}
+	if o.PortStep < 1 && o.Nodes > 1 {
+		return nil, fmt.Errorf("invalid port step: %d", o.PortStep)
+	}
+	if last := max(o.APIPort, o.P2PPort) + (o.Nodes-1)*o.PortStep; last > 65535 {
+		return nil, fmt.Errorf("port %d out of range", last)
+	}
+
+	var natHost string
+	if o.NATAddr != "" {
+		host, _, err := net.SplitHostPort(o.NATAddr)
+		if err != nil {
+			return nil, fmt.Errorf("nat address: %w", err)
+		}
+		natHost = host
+	}
+
+	nodes := make([]*hiveNode, o.Nodes)
+	for i := range nodes {
+		name := fmt.Sprintf("node-%d", i)
+		dataDir := filepath.Join(o.DataDir, name)
+		apiAddr := net.JoinHostPort("127.0.0.1", strconv.Itoa(o.APIPort+i*o.PortStep))
+		p2pPort := strconv.Itoa(o.P2PPort + i*o.PortStep)
+
+		args := []string{"start"}
+		if o.ConfigFile != "" {
+			args = append(args, "--config", o.ConfigFile)
+		}
+		args = append(args,
+			"--"+optionNameDataDir, dataDir,
+			"--"+optionNameAPIAddr, apiAddr,
+			"--"+optionNameP2PAddr, ":"+p2pPort,
+		)
+		if o.NATAddr != "" {
+			// the nodes are exposed on their own p2p ports
+			args = append(args, "--"+optionNameNATAddr, net.JoinHostPort(natHost, p2pPort))
+		}
+		args = append(args, o.Args...)
+
+		nodes[i] = &hiveNode{
+			name:    name,
+			dataDir: dataDir,
+			apiAddr: apiAddr,
+			args:    args,
+		}
+	}
+	return nodes, nil
+}
+
+func (c *command) initHiveCmd() {
+	const (
+		optionNameNodes          = "nodes"
+		optionNameAPIPort        = "api-port"
+		optionNameP2PPort        = "p2p-port"
+		optionNamePortStep       = "port-step"
+		optionNameStatusInterval = "status-interval"
+		optionNameRestartDelay   = "restart-delay"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "hive [-- start flags]",
+		Short: "Start and supervise multiple bee nodes",
+		Long: `Start and supervise multiple bee nodes on one machine.
+
+The nodes share the config file and are started with the distinct data directories
+in the hive data directory and the distinct API and P2P ports. The flags after the
+double dash are passed to the start command of every node. The failed nodes are
+restarted and the aggregated status of the nodes is printed periodically. The logs
+of every node are written to the bee.log file in its data directory.
+
+The keys of the nodes are unlocked with the password or the password file from the
+config, as the nodes are not started interactively.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nodesCount, err := cmd.Flags().GetInt(optionNameNodes)
+			if err != nil {
+				return fmt.Errorf("get nodes: %w", err)
+			}
+			dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+			if err != nil {
+				return fmt.Errorf("get data dir: %w", err)
+			}
+			apiPort, err := cmd.Flags().GetInt(optionNameAPIPort)
+			if err != nil {
+				return fmt.Errorf("get api port: %w", err)
+			}
+			p2pPort, err := cmd.Flags().GetInt(optionNameP2PPort)
+			if err != nil {
+				return fmt.Errorf("get p2p port: %w", err)
+			}
+			portStep, err := cmd.Flags().GetInt(optionNamePortStep)
+			if err != nil {
+				return fmt.Errorf("get port step: %w", err)
+			}
+			statusInterval, err := cmd.Flags().GetDuration(optionNameStatusInterval)
+			if err != nil {
+				return fmt.Errorf("get status interval: %w", err)
+			}
+			restartDelay, err := cmd.Flags().GetDuration(optionNameRestartDelay)
+			if err != nil {
+				return fmt.Errorf("get restart delay: %w", err)
+			}
+
+			if !hasPassword(c.config.GetString(optionNamePassword), c.config.GetString(optionNamePasswordFile), args) {
+				return errors.New("password or password file required to start the nodes")
+			}
+
+			var configFile string
+			if c.config.ConfigFileUsed() != "" {
+				if _, err := os.Stat(c.config.ConfigFileUsed()); err == nil {
+					configFile = c.config.ConfigFileUsed()
+				}
+			}
+
+			nodes, err := newHiveNodes(hiveOptions{
+				Nodes:      nodesCount,
+				DataDir:    dataDir,
+				ConfigFile: configFile,
+				APIPort:    apiPort,
+				P2PPort:    p2pPort,
+				PortStep:   portStep,
+				NATAddr:    c.config.GetString(optionNameNATAddr),
+				Args:       args,
+			})
+			if err != nil {
+				return err
+			}
+
+			exe, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("bee executable: %w", err)
+			}
+
+			logger, err := newLogger(cmd, "info")
+			if err != nil {
+				return fmt.Errorf("new logger: %w", err)
+			}
+
+			ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer cancel()
+
+			var wg sync.WaitGroup
+			for _, n := range nodes {
+				if err := os.MkdirAll(n.dataDir, 0o700); err != nil {
+					return fmt.Errorf("%s data dir: %w", n.name, err)
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					n.supervise(ctx, exe, restartDelay, logger)
+				}()
+			}
+			logger.Info("hive started", "nodes", len(nodes), "data_dir", dataDir)
+
+			client := &http.Client{Timeout: hiveStatusTimeout}
+			ticker := time.NewTicker(statusInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					printHiveStatus(ctx, cmd.OutOrStdout(), client, nodes)
+				case <-ctx.Done():
+					logger.Info("shutting down the hive nodes")
+					wg.Wait()
+					printHiveStatus(context.Background(), cmd.OutOrStdout(), client, nodes)
+					return nil
+				}
+			}
+		},
+	}
+
+	cmd.Flags().Int(optionNameNodes, 2, "number of the nodes")
+	cmd.Flags().String(optionNameDataDir, filepath.Join(c.homeDir, ".bee-hive"), "data directory of the nodes")
+	cmd.Flags().Int(optionNameAPIPort, 1633, "API port of the first node")
+	cmd.Flags().Int(optionNameP2PPort, 1634, "P2P port of the first node")
+	cmd.Flags().Int(optionNamePortStep, 10, "port increase of every next node")
+	cmd.Flags().Duration(optionNameStatusInterval, time.Minute, "interval of the status output")
+	cmd.Flags().Duration(optionNameRestartDelay, 5*time.Second, "initial delay of the restart of the stopped node")
+
+	c.root.AddCommand(cmd)
+}
+
+// hasPassword reports whether the password is set in the
+// config or passed in the start flags of the nodes.
+func hasPassword(password, passwordFile string, args []string) bool {
+	if password != "" || passwordFile != "" {
+		return true
+	}
+	for _, a := range args {
+		if strings.HasPrefix(a, "--"+optionNamePassword) {
+			return true
+		}
+	}
+	return false
+}
+
+// supervise runs the node until the context is canceled and restarts it
+// with the exponentially increasing delay when it stops.
+func (n *hiveNode) supervise(ctx context.Context, exe string, restartDelay time.Duration, logger log.Logger) {
+	delay := restartDelay
+	for {
+		start := time.Now()
+		err := n.run(ctx, exe)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(start) > hiveStableRun {
+			delay = restartDelay
+		}
+		logger.Warning("node stopped, restarting", "node", n.name, "error", err, "delay", delay)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+		delay = min(2*delay, hiveMaxRestartDelay)
+
+		n.mu.Lock()
+		n.restarts++
+		n.mu.Unlock()
+	}
+}
+
+// run starts the node process and waits for it to exit. The process is
+// interrupted when the context is canceled and killed if it does not
+// shut down in time.
+func (n *hiveNode) run(ctx context.Context, exe string) error {
+	logFile, err := os.OpenFile(filepath.Join(n.dataDir, "bee.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	defer logFile.Close()
+
+	p := exec.Command(exe, n.args...)
+	p.Stdout = logFile
+	p.Stderr = logFile
+	if err := p.Start(); err != nil {
+		n.setExited(err)
+		return fmt.Errorf("start: %w", err)
+	}
+
+	n.mu.Lock()
+	n.pid = p.Process.Pid
+	n.running = true
+	n.mu.Unlock()
+
+	done := make(chan error, 1)
+	go func() { done <- p.Wait() }()
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		if err := p.Process.Signal(os.Interrupt); err != nil {
+			_ = p.Process.Kill()
+		}
+		select {
+		case err = <-done:
+		case <-time.After(hiveShutdownTimeout):
+			_ = p.Process.Kill()
+			err = <-done
+		}
+	}
+	n.setExited(err)
+	return err
+}
+
+func (n *hiveNode) setExited(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.running = false
+	n.exitErr = err
+}
+
+// hiveNodeStatus is the part of the node status shown in the hive status.
+type hiveNodeStatus struct {
+	Overlay        string `json:"overlay"`
+	BeeMode        string `json:"beeMode"`
+	ConnectedPeers uint64 `json:"connectedPeers"`
+	StorageRadius  uint8  `json:"storageRadius"`
+	ReserveSize    uint64 `json:"reserveSize"`
+	IsWarmingUp    bool   `json:"isWarmingUp"`
+}
+
+// status requests the status of the node from its API.
+func (n *hiveNode) status(ctx context.Context, client *http.Client) (hiveNodeStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+n.apiAddr+"/status", nil)
+	if err != nil {
+		return hiveNodeStatus{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return hiveNodeStatus{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return hiveNodeStatus{}, fmt.Errorf("status response %s", resp.Status)
+	}
+	var body struct {
+		Snapshots []hiveNodeStatus `json:"snapshots"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return hiveNodeStatus{}, fmt.Errorf("decode status: %w", err)
+	}
+	if len(body.Snapshots) == 0 {
+		return hiveNodeStatus{}, errors.New("empty status")
+	}
+	return body.Snapshots[0], nil
+}
+
+// printHiveStatus writes the table with the status of every node.
+func printHiveStatus(ctx context.Context, w io.Writer, client *http.Client, nodes []*hiveNode) {
+	statuses := make([]hiveNodeStatus, len(nodes))
+	errs := make([]error, len(nodes))
+
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i], errs[i] = n.status(ctx, client)
+		}()
+	}
+	wg.Wait()
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NODE\tPID\tSTATE\tRESTARTS\tAPI\tMODE\tOVERLAY\tPEERS\tRADIUS\tRESERVE")
+	for i, n := range nodes {
+		n.mu.Lock()
+		pid, running, restarts, exitErr := n.pid, n.running, n.restarts, n.exitErr
+		n.mu.Unlock()
+
+		state := "running"
+		switch {
+		case !running && exitErr != nil:
+			state = "exited: " + exitErr.Error()
+		case !running:
+			state = "stopped"
+		case errs[i] != nil:
+			state = "starting"
+		case statuses[i].IsWarmingUp:
+			state = "warming up"
+		}
+
+		s := statuses[i]
+		if errs[i] != nil {
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t%s\t-\t-\t-\t-\t-\n", n.name, pid, state, restarts, n.apiAddr)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t%s\t%s\t%s\t%d\t%d\t%d\n", n.name, pid, state, restarts, n.apiAddr, s.BeeMode, s.Overlay, s.ConnectedPeers, s.StorageRadius, s.ReserveSize)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/cmd/bee/cmd"
+)
+
+func TestNewHiveNodes(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	nodes, err := cmd.NewHiveNodes(cmd.HiveOptions{
+		Nodes:      2,
+		DataDir:    dataDir,
+		ConfigFile: "bee.yaml",
+		APIPort:    1633,
+		P2PPort:    1634,
+		PortStep:   10,
+		NATAddr:    "1.2.3.4:1634",
+		Args:       []string{"--full-node"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []cmd.HiveNode{
+		{
+			Name:    "node-0",
+			DataDir: filepath.Join(dataDir, "node-0"),
+			APIAddr: "127.0.0.1:1633",
+			Args: []string{
+				"start", "--config", "bee.yaml",
+				"--data-dir", filepath.Join(dataDir, "node-0"),
+				"--api-addr", "127.0.0.1:1633",
+				"--p2p-addr", ":1634",
+				"--nat-addr", "1.2.3.4:1634",
+				"--full-node",
+			},
+		},
+		{
+			Name:    "node-1",
+			DataDir: filepath.Join(dataDir, "node-1"),
+			APIAddr: "127.0.0.1:1643",
+			Args: []string{
+				"start", "--config", "bee.yaml",
+				"--data-dir", filepath.Join(dataDir, "node-1"),
+				"--api-addr", "127.0.0.1:1643",
+				"--p2p-addr", ":1644",
+				"--nat-addr", "1.2.3.4:1644",
+				"--full-node",
+			},
+		},
+	}
+	if !reflect.DeepEqual(nodes, want) {
+		t.Fatalf("got nodes %+v, want %+v", nodes, want)
+	}
+
+	for _, o := range []cmd.HiveOptions{
+		{Nodes: 0, APIPort: 1633, P2PPort: 1634, PortStep: 10},
+		{Nodes: 2, APIPort: 1633, P2PPort: 1634, PortStep: 0},
+		{Nodes: 2, APIPort: 1633, P2PPort: 65535, PortStep: 10},
+	} {
+		if _, err := cmd.NewHiveNodes(o); err == nil {
+			t.Errorf("expected error for options %+v", o)
+		}
+	}
+}
@@ -42,7 +42,10 @@ func (c *command) initInitCmd() (err error) {
 			return nil
 		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return c.config.BindPFlags(cmd.Flags())
+			if err := c.config.BindPFlags(cmd.Flags()); err != nil {
+				return err
+			}
+			return c.resolveSecrets(cmd.Context())
 		},
 	}
 
@@ -0,0 +1,75 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethersphere/bee/v2/pkg/backup"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/node"
+	"github.com/ethersphere/bee/v2/pkg/puller"
+)
+
+// restoreMarkerFile is the file written into the data directory after the
+// backup is restored. It holds the path of the restored backup archive.
+const restoreMarkerFile = ".restored"
+
+// restoreBackup restores the node state from the backup archive
+// into the data directory before the node is started. The restore
+// is done only once, it is skipped on the next starts of the node
+// with the same backup archive.
+func restoreBackup(logger log.Logger, filename, dataDir, password string) error {
+	if dataDir == "" {
+		return errors.New("data directory is required")
+	}
+
+	archive, err := filepath.Abs(filename)
+	if err != nil {
+		return err
+	}
+	marker := filepath.Join(dataDir, restoreMarkerFile)
+	if restored, err := os.ReadFile(marker); err == nil && string(restored) == archive {
+		logger.Info("backup already restored, skipping", "backup", archive)
+		return nil
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	md, err := backup.Restore(f, dataDir, password)
+	if err != nil {
+		return err
+	}
+
+	// The reserve is not part of the backup, so the pull sync
+	// intervals are reset in order to sync the reserve again.
+	stateStore, _, err := node.InitStateStore(logger, dataDir, 1000)
+	if err != nil {
+		return fmt.Errorf("new statestore: %w", err)
+	}
+	defer stateStore.Close()
+
+	err = stateStore.Iterate(puller.IntervalPrefix, func(key, val []byte) (stop bool, err error) {
+		return false, stateStore.Delete(string(key))
+	})
+	if err != nil {
+		return fmt.Errorf("reset pull sync intervals: %w", err)
+	}
+
+	if err := os.WriteFile(marker, []byte(archive), 0600); err != nil {
+		return fmt.Errorf("write restore marker: %w", err)
+	}
+
+	logger.Info("node state restored", "backup_created_at", md.CreatedAt, "keys", md.KeysIncluded, "reserve_size", md.ReserveSize)
+
+	return nil
+}
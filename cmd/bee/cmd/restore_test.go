@@ -0,0 +1,79 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/cmd/bee/cmd"
+	"github.com/ethersphere/bee/v2/pkg/backup"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+)
+
+type emptyReserve struct{}
+
+func (emptyReserve) ReserveIterateChunkItems(func(storer.ReserveChunkItem) (bool, error)) error {
+	return nil
+}
+
+// TestRestoreBackupOnce tests that the backup is restored only on the first
+// start of the node and that it is skipped on the restarts.
+func TestRestoreBackupOnce(t *testing.T) {
+	t.Parallel()
+
+	srcDir := t.TempDir()
+	stateStore, err := leveldbstore.New(filepath.Join(srcDir, "statestore"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stateStore.Close()
+	stamperStore, err := leveldbstore.New(filepath.Join(srcDir, "stamperstore"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stamperStore.Close()
+	if err := stateStore.DB().Put([]byte("key"), []byte("value"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "backup.tar")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := backup.New(log.Noop, stateStore, stamperStore, filepath.Join(srcDir, "keys"), emptyReserve{})
+	if err := svc.Backup(context.Background(), f, backup.Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dataDir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		if err := cmd.RestoreBackup(log.Noop, archive, dataDir, ""); err != nil {
+			t.Fatalf("start %d: %v", i, err)
+		}
+	}
+
+	// another backup is not restored over the state
+	other := filepath.Join(t.TempDir(), "other.tar")
+	data, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(other, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.RestoreBackup(log.Noop, other, dataDir, ""); !errors.Is(err, backup.ErrStateExists) {
+		t.Fatalf("want error %v, have %v", backup.ErrStateExists, err)
+	}
+}
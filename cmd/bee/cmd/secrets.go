@@ -0,0 +1,61 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ethersphere/bee/v2/pkg/secrets"
+)
+
+// secretOptions are the options which can reference the secrets
+// in the ${provider:ref} form instead of containing them in plain text.
+var secretOptions = []string{
+	optionNamePassword,
+	optionNameRestorePassword,
+	optionNameKeystorePKCS11PIN,
+	optionNameBlockchainRpcEndpoint,
+	optionNameResolverEndpoints,
+	optionNameWebhookURL,
+	optionNameWebhookSecret,
+	optionNameAPIRequestSigningSecret,
+	optionNameSwapChequebookFunderKey,
+}
+
+// resolveSecrets replaces the secret references
+// in the secret options with the secrets.
+func (c *command) resolveSecrets(ctx context.Context) error {
+	r := secrets.NewResolver()
+	for _, name := range secretOptions {
+		if !c.config.IsSet(name) {
+			continue
+		}
+
+		if v, ok := c.config.Get(name).(string); ok {
+			resolved, err := r.Resolve(ctx, v)
+			if err != nil {
+				return fmt.Errorf("option %s: %w", name, err)
+			}
+			if resolved != v {
+				c.config.Set(name, resolved)
+			}
+			continue
+		}
+
+		values := c.config.GetStringSlice(name)
+		resolved := slices.Clone(values)
+		for i := range resolved {
+			if err := r.ResolveAll(ctx, &resolved[i]); err != nil {
+				return fmt.Errorf("option %s: %w", name, err)
+			}
+		}
+		if !slices.Equal(resolved, values) {
+			c.config.Set(name, resolved)
+		}
+	}
+	return nil
+}
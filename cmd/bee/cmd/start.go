@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"crypto/rand"
 	_ "embed"
 	"encoding/hex"
 	"errors"
@@ -24,20 +25,29 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/accesscontrol"
 	chaincfg "github.com/ethersphere/bee/v2/pkg/config"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/keyrotation"
 	"github.com/ethersphere/bee/v2/pkg/keystore"
 	filekeystore "github.com/ethersphere/bee/v2/pkg/keystore/file"
+	keychainkeystore "github.com/ethersphere/bee/v2/pkg/keystore/keychain"
 	memkeystore "github.com/ethersphere/bee/v2/pkg/keystore/mem"
+	pkcs11keystore "github.com/ethersphere/bee/v2/pkg/keystore/pkcs11"
 	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/modeswitch"
 	"github.com/ethersphere/bee/v2/pkg/node"
 	"github.com/ethersphere/bee/v2/pkg/resolver/multiresolver"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/kardianos/service"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 const (
 	serviceName      = "SwarmBeeSvc"
 	libp2pPKFilename = "libp2p_v2"
+
+	// recentLogLines is the number of the recent log lines
+	// included in the diagnostics bundle.
+	recentLogLines = 1000
 )
 
 //go:embed bee-welcome-message.txt
@@ -55,7 +65,8 @@ func (c *command) initStartCmd() (err error) {
 
 			v := strings.ToLower(c.config.GetString(optionNameVerbosity))
 
-			logger, err := newLogger(cmd, v)
+			c.recentLogs = log.NewRecentSink(recentLogLines)
+			logger, err := newLogger(cmd, v, c.recentLogs)
 			if err != nil {
 				return fmt.Errorf("new logger: %w", err)
 			}
@@ -94,26 +105,62 @@ func (c *command) initStartCmd() (err error) {
 			respC := buildBeeNodeAsync(ctx, c, cmd, logger)
 			var beeNode atomic.Value
 
-			p := &program{
-				start: func() {
-					// Wait for bee node to fully build and initialized
+			// The part of the configuration which can be changed
+			// on the running node is reloaded on the hangup signal.
+			sysHangupChannel := make(chan os.Signal, 1)
+			signal.Notify(sysHangupChannel, syscall.SIGHUP)
+
+			go func() {
+				for {
 					select {
-					case resp := <-respC:
-						if resp.err != nil {
-							logger.Error(resp.err, "failed to build bee node")
-							return
+					case <-sysHangupChannel:
+						val := beeNode.Load()
+						if val == nil {
+							logger.Warning("config reload skipped, node not started")
+							continue
+						}
+						if _, err := val.(*node.Bee).ReloadConfig(); err != nil {
+							logger.Error(err, "config reload failed")
 						}
-						beeNode.Store(resp.bee)
 					case <-ctx.Done():
 						return
 					}
+				}
+			}()
 
-					// Bee has fully started at this point, from now on we
-					// block main goroutine until it is interrupted or stopped
-					select {
-					case <-ctx.Done():
-					case <-beeNode.Load().(*node.Bee).SyncingStopped():
-						logger.Debug("syncing has stopped")
+			p := &program{
+				start: func() {
+					for {
+						// Wait for bee node to fully build and initialized
+						select {
+						case resp := <-respC:
+							if resp.err != nil {
+								logger.Error(resp.err, "failed to build bee node")
+								return
+							}
+							beeNode.Store(resp.bee)
+						case <-ctx.Done():
+							return
+						}
+						bee := beeNode.Load().(*node.Bee)
+
+						// Bee has fully started at this point, from now on we
+						// block main goroutine until it is interrupted, stopped
+						// or requested to switch the mode
+						select {
+						case <-ctx.Done():
+						case <-bee.SyncingStopped():
+							logger.Debug("syncing has stopped")
+						case m := <-bee.ModeSwitched():
+							logger.Info("switching node mode, restarting...", "mode", m, "reserve", m.Reserve)
+							if err := bee.Shutdown(); err != nil {
+								logger.Error(err, "shutdown failed")
+							}
+							c.setNodeMode(m)
+							respC = buildBeeNodeAsync(ctx, c, cmd, logger)
+							continue
+						}
+						break
 					}
 
 					logger.Info("shutting down...")
@@ -133,7 +180,7 @@ func (c *command) initStartCmd() (err error) {
 					go func(beeNode *node.Bee) {
 						defer close(done)
 
-						if err := beeNode.Shutdown(); err != nil {
+						if err := beeNode.Shutdown(); err != nil && !errors.Is(err, node.ErrShutdownInProgress) {
 							logger.Error(err, "shutdown failed")
 						}
 					}(val.(*node.Bee))
@@ -171,7 +218,10 @@ func (c *command) initStartCmd() (err error) {
 			return nil
 		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return c.config.BindPFlags(cmd.Flags())
+			if err := c.config.BindPFlags(cmd.Flags()); err != nil {
+				return err
+			}
+			return c.resolveSecrets(cmd.Context())
 		},
 	}
 
@@ -180,6 +230,54 @@ func (c *command) initStartCmd() (err error) {
 	return nil
 }
 
+// maxEphemeralCacheCapacity caps the number of cached chunks held
+// in memory by the node in the ephemeral mode.
+const maxEphemeralCacheCapacity = 100_000
+
+// configureEphemeral configures the node in the ephemeral mode to keep
+// all of its state and keys in memory so that nothing is left on disk
+// after the node stops. Only the cache is capped; the state store and the
+// chunks of the uploads waiting to be synced are not, they grow with the
+// use of the node for as long as it runs.
+func (c *command) configureEphemeral(logger log.Logger) error {
+	if !c.config.GetBool(optionNameEphemeral) {
+		return nil
+	}
+	if c.config.GetBool(optionNameFullNode) {
+		return errors.New("not supported for full nodes")
+	}
+	if c.config.GetString(optionNameRestoreFrom) != "" {
+		return errors.New("backup can not be restored")
+	}
+
+	c.config.Set(optionNameDataDir, "")
+	if c.config.GetUint64(optionNameCacheCapacity) > maxEphemeralCacheCapacity {
+		c.config.Set(optionNameCacheCapacity, uint64(maxEphemeralCacheCapacity))
+	}
+	// the keys are thrown away with the node, there is no need to prompt for the password
+	if c.config.GetString(optionNamePassword) == "" && c.config.GetString(optionNamePasswordFile) == "" {
+		password := make([]byte, 32)
+		if _, err := rand.Read(password); err != nil {
+			return fmt.Errorf("generate password: %w", err)
+		}
+		c.config.Set(optionNamePassword, hex.EncodeToString(password))
+	}
+
+	logger.Info("running in ephemeral mode, no state is persisted", "cache_capacity", c.config.GetUint64(optionNameCacheCapacity))
+	return nil
+}
+
+// setNodeMode overrides the configured node mode with the one the
+// running node is switched to. The switch is not persisted and the
+// configuration is authoritative again on the next start of the process.
+func (c *command) setNodeMode(m modeswitch.Mode) {
+	c.config.Set(optionNameFullNode, m.FullNode)
+	c.config.Set(optionNameReserveDisable, m.FullNode && !m.Reserve)
+	// the backup is restored only on the first start of the process,
+	// restoreBackup skips it on the later starts of the node
+	c.config.Set(optionNameRestoreFrom, "")
+}
+
 type buildBeeNodeResp struct {
 	bee *node.Bee
 	err error
@@ -199,6 +297,15 @@ func buildBeeNodeAsync(ctx context.Context, c *command, cmd *cobra.Command, logg
 func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger log.Logger) (*node.Bee, error) {
 	var err error
 
+	if err := c.configureEphemeral(logger); err != nil {
+		return nil, fmt.Errorf("ephemeral mode: %w", err)
+	}
+
+	verbosity, err := parseVerbosity(strings.ToLower(c.config.GetString(optionNameVerbosity)))
+	if err != nil {
+		return nil, err
+	}
+
 	// If the resolver is specified, resolve all connection strings
 	// and fail on any errors.
 	var resolverCfgs []multiresolver.ConnectionConfig
@@ -210,6 +317,13 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 		}
 	}
 
+	if restoreFrom := c.config.GetString(optionNameRestoreFrom); restoreFrom != "" {
+		err := restoreBackup(logger, restoreFrom, c.config.GetString(optionNameDataDir), c.config.GetString(optionNameRestorePassword))
+		if err != nil {
+			return nil, fmt.Errorf("restore backup: %w", err)
+		}
+	}
+
 	signerConfig, err := c.configureSigner(cmd, logger)
 	if err != nil {
 		return nil, fmt.Errorf("configure signer: %w", err)
@@ -281,16 +395,53 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 	b, err := node.NewBee(ctx, c.config.GetString(optionNameP2PAddr), signerConfig.publicKey, signerConfig.signer, networkID, logger, signerConfig.libp2pPrivateKey, signerConfig.pssPrivateKey, signerConfig.session, &node.Options{
 		DataDir:                       c.config.GetString(optionNameDataDir),
 		CacheCapacity:                 c.config.GetUint64(optionNameCacheCapacity),
+		SOCCacheTTL:                   c.config.GetDuration(optionNameSOCCacheTTL),
+		FeedCacheTTL:                  c.config.GetDuration(optionNameFeedCacheTTL),
 		DBOpenFilesLimit:              c.config.GetUint64(optionNameDBOpenFilesLimit),
 		DBBlockCacheCapacity:          c.config.GetUint64(optionNameDBBlockCacheCapacity),
 		DBWriteBufferSize:             c.config.GetUint64(optionNameDBWriteBufferSize),
 		DBDisableSeeksCompaction:      c.config.GetBool(optionNameDBDisableSeeksCompaction),
+		DBIndexStoreBackend:           c.config.GetString(optionNameDBIndexStoreBackend),
 		APIAddr:                       c.config.GetString(optionNameAPIAddr),
+		APIReusePort:                  c.config.GetBool(optionNameAPIReusePort),
+		APIAdminAddr:                  c.config.GetString(optionNameAPIAdminAddr),
+		APIDebugAddr:                  c.config.GetString(optionNameAPIDebugAddr),
+		APIValidateRequests:           c.config.GetBool(optionNameAPIValidateRequests),
+		APIIdempotencyWindow:          c.config.GetDuration(optionNameAPIIdempotencyWindow),
+		APIBzzAccessLog:               c.config.GetString(optionNameAPIBzzAccessLog),
+		APIBzzAccessLogMaxSize:        c.config.GetInt64(optionNameAPIBzzAccessLogMaxSize),
+		APIBzzAccessLogMaxBackups:     c.config.GetInt(optionNameAPIBzzAccessLogMaxBackups),
+		APIReadOnly:                   c.config.GetBool(optionNameAPIReadOnly),
+		APIRequestSigningSecret:       c.config.GetString(optionNameAPIRequestSigningSecret),
+		APIRequestSigningWindow:       c.config.GetDuration(optionNameAPIRequestSigningWindow),
+		ChunkValidationOffload:        c.config.GetBool(optionNameChunkValidationOffload),
+		GRPCAddr:                      c.config.GetString(optionNameGRPCAddr),
+		S3Addr:                        c.config.GetString(optionNameS3Addr),
+		S3BatchID:                     c.config.GetString(optionNameS3BatchID),
+		S3RootTopic:                   c.config.GetString(optionNameS3RootTopic),
+		WebDAVAddr:                    c.config.GetString(optionNameWebDAVAddr),
+		WebDAVBatchID:                 c.config.GetString(optionNameWebDAVBatchID),
+		StampProxyAddr:                c.config.GetString(optionNameStampProxyAddr),
+		FUSEEnable:                    c.config.GetBool(optionNameFUSEEnable),
+		ImageTransformEnable:          c.config.GetBool(optionNameImageTransformEnable),
 		Addr:                          c.config.GetString(optionNameP2PAddr),
 		NATAddr:                       c.config.GetString(optionNameNATAddr),
 		EnableWS:                      c.config.GetBool(optionNameP2PWSEnable),
+		EnableQUIC:                    c.config.GetBool(optionNameP2PQUICEnable),
+		EnableWebTransport:            c.config.GetBool(optionNameP2PWebTransportEnable),
+		P2PDialPreference:             c.config.GetString(optionNameP2PDialPreference),
+		EnableRelayService:            c.config.GetBool(optionNameP2PRelayServiceEnable),
+		EnableRelayClient:             c.config.GetBool(optionNameP2PRelayClientEnable),
+		StaticRelays:                  c.config.GetStringSlice(optionNameP2PStaticRelays),
+		EnableP2PCompression:          c.config.GetBool(optionNameP2PCompressionEnable),
+		TrafficMonthlyCap:             c.config.GetUint64(optionNameTrafficMonthlyCap),
+		TrafficThrottleRate:           c.config.GetInt(optionNameTrafficThrottleRate),
 		WelcomeMessage:                c.config.GetString(optionWelcomeMessage),
 		Bootnodes:                     networkConfig.bootNodes,
+		NetworkKey:                    c.config.GetString(optionNameNetworkKey),
+		NetworkAllowlist:              c.config.GetStringSlice(optionNameNetworkAllowlist),
+		DNSSeeds:                      c.config.GetStringSlice(optionNameDNSSeeds),
+		EnableMDNS:                    c.config.GetBool(optionNameMDNSEnable),
 		CORSAllowedOrigins:            c.config.GetStringSlice(optionCORSAllowedOrigins),
 		TracingEnabled:                c.config.GetBool(optionNameTracingEnabled),
 		TracingEndpoint:               tracingEndpoint,
@@ -304,18 +455,26 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 		BlockchainRpcEndpoint:         c.config.GetString(optionNameBlockchainRpcEndpoint),
 		SwapFactoryAddress:            c.config.GetString(optionNameSwapFactoryAddress),
 		SwapInitialDeposit:            c.config.GetString(optionNameSwapInitialDeposit),
+		SwapChequebookFunderKey:       c.config.GetString(optionNameSwapChequebookFunderKey),
 		SwapEnable:                    c.config.GetBool(optionNameSwapEnable),
 		ChequebookEnable:              c.config.GetBool(optionNameChequebookEnable),
 		FullNodeMode:                  fullNode,
+		Ephemeral:                     c.config.GetBool(optionNameEphemeral),
+		DisableReserve:                c.config.GetBool(optionNameReserveDisable),
 		PostageContractAddress:        c.config.GetString(optionNamePostageContractAddress),
 		PostageContractStartBlock:     c.config.GetUint64(optionNamePostageContractStartBlock),
 		PriceOracleAddress:            c.config.GetString(optionNamePriceOracleAddress),
+		SwapExchangeRate:              c.config.GetString(optionNameSwapExchangeRate),
+		SwapDeduction:                 c.config.GetString(optionNameSwapDeduction),
+		PostagePrice:                  c.config.GetString(optionNamePostagePrice),
 		RedistributionContractAddress: c.config.GetString(optionNameRedistributionAddress),
 		StakingContractAddress:        c.config.GetString(optionNameStakingAddress),
 		BlockTime:                     networkConfig.blockTime,
 		WarmupTime:                    c.config.GetDuration(optionWarmUpTime),
 		ChainID:                       networkConfig.chainID,
 		RetrievalCaching:              c.config.GetBool(optionNameRetrievalCaching),
+		UpstreamGateway:               c.config.GetString(optionNameUpstreamGateway),
+		UpstreamGatewayTimeout:        c.config.GetDuration(optionNameUpstreamGatewayTimeout),
 		Resync:                        c.config.GetBool(optionNameResync),
 		BlockProfile:                  c.config.GetBool(optionNamePProfBlock),
 		MutexProfile:                  c.config.GetBool(optionNamePProfMutex),
@@ -330,11 +489,71 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 		TrxDebugMode:                  c.config.GetBool(optionNameTransactionDebugMode),
 		MinimumStorageRadius:          c.config.GetUint(optionMinimumStorageRadius),
 		ReserveCapacityDoubling:       c.config.GetInt(optionReserveCapacityDoubling),
+		StateStoreAPIEnable:           c.config.GetBool(optionNameStateStoreAPIEnable),
+		PostageSnapshotTrustedNodes:   c.config.GetStringSlice(optionNamePostageSnapshotTrustedNodes),
+		BlockchainRpcCacheEnable:      c.config.GetBool(optionNameBlockchainRpcCacheEnable),
+		BlockchainRpcHourlyBudget:     c.config.GetUint64(optionNameBlockchainRpcHourlyBudget),
+		PushSyncReceiptsEnable:        c.config.GetBool(optionNamePushSyncReceiptsEnable),
+		PushSyncReplicationFactor:     uint8(c.config.GetUint(optionNamePushSyncReplicationFactor)),
+		PullSyncBandwidthLimit:        c.config.GetFloat64(optionNamePullSyncBandwidthLimit),
+		PullSyncHistoricalHours:       c.config.GetString(optionNamePullSyncHistoricalHours),
+		PullSyncHistoryDays:           c.config.GetUint(optionNamePullSyncHistoryDays),
+		PullSyncHistoryBins:           c.config.GetString(optionNamePullSyncHistoryBins),
+		MaintenanceWindows:            c.config.GetString(optionNameMaintenanceWindows),
+		KeyRotation:                   signerConfig.keyRotation,
+		PushSyncBandwidthLimit:        c.config.GetFloat64(optionNamePushSyncBandwidthLimit),
+		ReplicationRepairEnable:       c.config.GetBool(optionNameReplicationRepairEnable),
+		ReplicationRepairInterval:     c.config.GetDuration(optionNameReplicationRepairInterval),
+		RecoveryBatchID:               c.config.GetString(optionNameRecoveryBatchID),
+		KademliaPrunePolicy:           c.config.GetString(optionNameKademliaPrunePolicy),
+		PeeringPinned:                 c.config.GetStringSlice(optionNamePeeringPinned),
+		PeeringDenied:                 c.config.GetStringSlice(optionNamePeeringDenied),
+		PeeringGroups:                 c.config.GetStringSlice(optionNamePeeringGroups),
+		WebhookURL:                    c.config.GetString(optionNameWebhookURL),
+		WebhookTopics:                 c.config.GetStringSlice(optionNameWebhookTopics),
+		WebhookSecret:                 c.config.GetString(optionNameWebhookSecret),
+		ShutdownDrainDelay:            c.config.GetDuration(optionNameShutdownDrainDelay),
+		ShutdownTimeout:               c.config.GetDuration(optionNameShutdownTimeout),
+		ReadinessMinPeers:             c.config.GetInt(optionNameReadinessMinPeers),
+		ReadinessMinDepth:             uint8(c.config.GetUint(optionNameReadinessMinDepth)),
+		ReadinessBatchStoreSynced:     c.config.GetBool(optionNameReadinessBatchStoreSynced),
+		ReadinessChainSynced:          c.config.GetBool(optionNameReadinessChainSynced),
+		RecentLogs:                    c.recentLogs,
+		Config:                        c.config.AllSettings(),
+		Verbosity:                     verbosity,
+		ConfigLoader:                  c.reloadOptions,
 	})
 
 	return b, err
 }
 
+// reloadOptions reads the config file again and returns the options which can
+// be changed on the running node. The options set by the command line flags
+// take precedence over the config file, as on the node start.
+func (c *command) reloadOptions() (node.ReloadOptions, error) {
+	if err := c.config.ReadInConfig(); err != nil {
+		var e viper.ConfigFileNotFoundError
+		if !errors.As(err, &e) {
+			return node.ReloadOptions{}, fmt.Errorf("read config file: %w", err)
+		}
+	}
+
+	verbosity, err := parseVerbosity(strings.ToLower(c.config.GetString(optionNameVerbosity)))
+	if err != nil {
+		return node.ReloadOptions{}, err
+	}
+
+	return node.ReloadOptions{
+		CORSAllowedOrigins:     c.config.GetStringSlice(optionCORSAllowedOrigins),
+		Verbosity:              verbosity,
+		TrafficThrottleRate:    c.config.GetInt(optionNameTrafficThrottleRate),
+		PullSyncBandwidthLimit: c.config.GetFloat64(optionNamePullSyncBandwidthLimit),
+		PushSyncBandwidthLimit: c.config.GetFloat64(optionNamePushSyncBandwidthLimit),
+		PaymentThreshold:       c.config.GetString(optionNamePaymentThreshold),
+		NATAddr:                c.config.GetString(optionNameNATAddr),
+	}, nil
+}
+
 type program struct {
 	start func()
 	stop  func()
@@ -357,15 +576,13 @@ type signerConfig struct {
 	libp2pPrivateKey *ecdsa.PrivateKey
 	pssPrivateKey    *ecdsa.PrivateKey
 	session          accesscontrol.Session
+	keyRotation      *keyrotation.Service
 }
 
 func (c *command) configureSigner(cmd *cobra.Command, logger log.Logger) (config *signerConfig, err error) {
-	var keystore keystore.Service
-	if c.config.GetString(optionNameDataDir) == "" {
-		keystore = memkeystore.New()
-		logger.Warning("data directory not provided, keys are not persisted")
-	} else {
-		keystore = filekeystore.New(filepath.Join(c.config.GetString(optionNameDataDir), "keys"))
+	keystore, err := c.configureKeystore(logger)
+	if err != nil {
+		return nil, err
 	}
 
 	var signer crypto.Signer
@@ -446,9 +663,40 @@ func (c *command) configureSigner(cmd *cobra.Command, logger log.Logger) (config
 		libp2pPrivateKey: libp2pPrivateKey,
 		pssPrivateKey:    pssPrivateKey,
 		session:          session,
+		keyRotation:      keyrotation.New(logger, keystore, password),
 	}, nil
 }
 
+// configureKeystore returns the keystore of the configured backend.
+func (c *command) configureKeystore(logger log.Logger) (keystore.Service, error) {
+	switch backend := c.config.GetString(optionNameKeystoreBackend); backend {
+	case "", "file":
+		if c.config.GetString(optionNameDataDir) == "" {
+			logger.Warning("data directory not provided, keys are not persisted")
+			return memkeystore.New(), nil
+		}
+		return filekeystore.New(filepath.Join(c.config.GetString(optionNameDataDir), "keys")), nil
+	case "keychain":
+		ks, err := keychainkeystore.New(c.config.GetString(optionNameKeystoreNamespace))
+		if err != nil {
+			return nil, fmt.Errorf("keychain keystore: %w", err)
+		}
+		return ks, nil
+	case "pkcs11":
+		if c.config.GetString(optionNameKeystorePKCS11Module) == "" {
+			return nil, fmt.Errorf("pkcs11 keystore: %s option is required", optionNameKeystorePKCS11Module)
+		}
+		return pkcs11keystore.New(pkcs11keystore.Options{
+			Module:    c.config.GetString(optionNameKeystorePKCS11Module),
+			Slot:      c.config.GetString(optionNameKeystorePKCS11Slot),
+			PIN:       c.config.GetString(optionNameKeystorePKCS11PIN),
+			Namespace: c.config.GetString(optionNameKeystoreNamespace),
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown keystore backend %q, want one of file, keychain, pkcs11", backend)
+	}
+}
+
 type networkConfig struct {
 	bootNodes []string
 	blockTime time.Duration
@@ -67,6 +67,8 @@ func (c *command) initStartDevCmd() (err error) {
 				DBDisableSeeksCompaction: c.config.GetBool(optionNameDBDisableSeeksCompaction),
 				CORSAllowedOrigins:       c.config.GetStringSlice(optionCORSAllowedOrigins),
 				ReserveCapacity:          c.config.GetUint64(optionNameDevReserveCapacity),
+				Seed:                     c.config.GetInt64(optionNameDevSeed),
+				FixturesDir:              c.config.GetString(optionNameDevFixturesDir),
 			})
 			if err != nil {
 				return err
@@ -134,6 +136,8 @@ func (c *command) initStartDevCmd() (err error) {
 	cmd.Flags().String(optionNameAPIAddr, "127.0.0.1:1633", "HTTP API listen address")
 	cmd.Flags().String(optionNameVerbosity, "info", "log verbosity level 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=trace")
 	cmd.Flags().Uint64(optionNameDevReserveCapacity, 4194304, "cache reserve capacity")
+	cmd.Flags().Int64(optionNameDevSeed, 0, "seed of the keys, addresses, batch ids and injected faults, random if zero")
+	cmd.Flags().String(optionNameDevFixturesDir, "", "directory with the fixtures.json of the batches and the content preloaded on start")
 	cmd.Flags().StringSlice(optionCORSAllowedOrigins, []string{}, "origins with CORS headers enabled")
 	cmd.Flags().Uint64(optionNameDBOpenFilesLimit, 200, "number of open files allowed by database")
 	cmd.Flags().Uint64(optionNameDBBlockCacheCapacity, 32*1024*1024, "size of block cache of the database in bytes")
@@ -0,0 +1,137 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/cmd/bee/cmd"
+)
+
+func TestConfigureEphemeral(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		options     map[string]any
+		wantErr     string
+		wantCache   uint64
+		genPassword bool
+		password    string
+	}{
+		{
+			name: "disabled",
+			options: map[string]any{
+				"data-dir":       "/data",
+				"cache-capacity": uint64(1_000_000),
+			},
+			wantCache: 1_000_000,
+		},
+		{
+			name: "full node",
+			options: map[string]any{
+				"ephemeral": true,
+				"full-node": true,
+			},
+			wantErr: "not supported for full nodes",
+		},
+		{
+			name: "restore",
+			options: map[string]any{
+				"ephemeral":    true,
+				"restore-from": "backup.tar",
+			},
+			wantErr: "backup can not be restored",
+		},
+		{
+			name: "cache capped",
+			options: map[string]any{
+				"ephemeral":      true,
+				"data-dir":       "/data",
+				"cache-capacity": uint64(1_000_000),
+			},
+			wantCache:   cmd.MaxEphemeralCacheCapacity,
+			genPassword: true,
+		},
+		{
+			name: "cache below the cap",
+			options: map[string]any{
+				"ephemeral":      true,
+				"cache-capacity": uint64(1000),
+			},
+			wantCache:   1000,
+			genPassword: true,
+		},
+		{
+			name: "password",
+			options: map[string]any{
+				"ephemeral":      true,
+				"cache-capacity": uint64(1000),
+				"password":       "secret",
+			},
+			wantCache: 1000,
+			password:  "secret",
+		},
+		{
+			name: "password file",
+			options: map[string]any{
+				"ephemeral":      true,
+				"cache-capacity": uint64(1000),
+				"password-file":  "password.txt",
+			},
+			wantCache: 1000,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			config, err := cmd.ConfigureEphemeral(tc.options)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("got error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := config.GetUint64("cache-capacity"); got != tc.wantCache {
+				t.Fatalf("got cache capacity %d, want %d", got, tc.wantCache)
+			}
+			ephemeral := config.GetBool("ephemeral")
+			if got := config.GetString("data-dir"); ephemeral && got != "" {
+				t.Fatalf("got data dir %q, want none", got)
+			}
+
+			password := config.GetString("password")
+			switch {
+			case tc.genPassword:
+				if b, err := hex.DecodeString(password); err != nil || len(b) != 32 {
+					t.Fatalf("got password %q, want a generated one", password)
+				}
+			case password != tc.password:
+				t.Fatalf("got password %q, want %q", password, tc.password)
+			}
+		})
+	}
+
+	t.Run("generated passwords differ", func(t *testing.T) {
+		t.Parallel()
+
+		a, err := cmd.ConfigureEphemeral(map[string]any{"ephemeral": true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := cmd.ConfigureEphemeral(map[string]any{"ephemeral": true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.GetString("password") == b.GetString("password") {
+			t.Fatal("generated passwords are equal")
+		}
+	})
+}
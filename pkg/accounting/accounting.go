@@ -95,6 +95,9 @@ type PayFunc func(context.Context, swarm.Address, *big.Int)
 // RefreshFunc is the function used for sync time-based settlement
 type RefreshFunc func(context.Context, swarm.Address, *big.Int)
 
+// DisputeFunc is the function notified about the peers overdrawing their debt
+type DisputeFunc func(swarm.Address)
+
 // Mutex is a drop in replacement for the sync.Mutex
 // it will not lock if the context is expired
 type Mutex struct {
@@ -155,6 +158,8 @@ type Accounting struct {
 	accountingPeers   map[string]*accountingPeer
 	logger            log.Logger
 	store             storage.StateStorer
+	// Mutex for accessing the payment thresholds and the disconnect limits.
+	thresholdMu sync.RWMutex
 	// The payment threshold in BZZ we communicate to our peers.
 	paymentThreshold *big.Int
 	// The amount in percent we let peers exceed the payment threshold before we
@@ -168,6 +173,8 @@ type Accounting struct {
 	payFunction PayFunc
 	// function used for time settlement
 	refreshFunction RefreshFunc
+	// function notified about the accounting disputes
+	disputeFunction DisputeFunc
 	// allowance based on time used in pseudo settle
 	refreshRate      *big.Int
 	lightRefreshRate *big.Int
@@ -181,6 +188,7 @@ type Accounting struct {
 	thresholdGrowStep   *big.Int
 	thresholdGrowChange *big.Int
 	// light node counterparts
+	lightFactor              int64
 	lightPaymentThreshold    *big.Int
 	lightDisconnectLimit     *big.Int
 	lightThresholdGrowStep   *big.Int
@@ -234,6 +242,7 @@ func NewAccounting(
 		p2p:                      p2pService,
 		thresholdGrowChange:      new(big.Int).Mul(refreshRate, big.NewInt(linearCheckpointNumber)),
 		thresholdGrowStep:        new(big.Int).Mul(refreshRate, big.NewInt(linearCheckpointStep)),
+		lightFactor:              lightFactor,
 		lightPaymentThreshold:    new(big.Int).Set(lightPaymentThreshold),
 		lightDisconnectLimit:     percentOf(100+PaymentTolerance, lightPaymentThreshold),
 		lightThresholdGrowChange: new(big.Int).Mul(lightRefreshRate, big.NewInt(linearCheckpointNumber)),
@@ -611,6 +620,9 @@ func (a *Accounting) getAccountingPeer(peer swarm.Address) *accountingPeer {
 
 	peerData, ok := a.accountingPeers[peer.String()]
 	if !ok {
+		a.thresholdMu.RLock()
+		defer a.thresholdMu.RUnlock()
+
 		peerData = &accountingPeer{
 			lock:                    NewMutex(),
 			reservedBalance:         big.NewInt(0),
@@ -1370,6 +1382,7 @@ func (d *debitAction) Apply() error {
 	if nextBalance.Cmp(disconnectLimit) >= 0 {
 		// peer too much in debt
 		a.metrics.AccountingDisconnectsOverdrawCount.Inc()
+		a.dispute(d.peer)
 
 		disconnectFor, err := a.blocklistUntil(d.peer, 1)
 		if err != nil {
@@ -1396,6 +1409,7 @@ func (d *debitAction) Cleanup() {
 	d.accountingPeer.ghostBalance = new(big.Int).Add(d.accountingPeer.ghostBalance, d.price)
 	if d.accountingPeer.ghostBalance.Cmp(d.accountingPeer.disconnectLimit) > 0 {
 		a.metrics.AccountingDisconnectsGhostOverdrawCount.Inc()
+		a.dispute(d.peer)
 		_ = a.blocklist(d.peer, 1, "ghost overdraw")
 	}
 }
@@ -1411,7 +1425,9 @@ func (a *Accounting) blocklistUntil(peer swarm.Address, multiplier int64) (int64
 		debt.Set(a.refreshRate)
 	}
 
+	a.thresholdMu.RLock()
 	additionalDebt := new(big.Int).Add(debt, a.paymentThreshold)
+	a.thresholdMu.RUnlock()
 
 	multiplyDebt := new(big.Int).Mul(additionalDebt, big.NewInt(multiplier))
 
@@ -1438,6 +1454,7 @@ func (a *Accounting) Connect(peer swarm.Address, fullNode bool) {
 	accountingPeer.lock.Lock()
 	defer accountingPeer.lock.Unlock()
 
+	a.thresholdMu.RLock()
 	paymentThreshold := new(big.Int).Set(a.paymentThreshold)
 	thresholdGrowStep := new(big.Int).Set(a.thresholdGrowStep)
 	disconnectLimit := new(big.Int).Set(a.disconnectLimit)
@@ -1447,6 +1464,7 @@ func (a *Accounting) Connect(peer swarm.Address, fullNode bool) {
 		thresholdGrowStep.Set(a.lightThresholdGrowStep)
 		disconnectLimit.Set(a.lightDisconnectLimit)
 	}
+	a.thresholdMu.RUnlock()
 
 	accountingPeer.connected = true
 	accountingPeer.fullNode = fullNode
@@ -1536,6 +1554,20 @@ func (a *Accounting) Disconnect(peer swarm.Address) {
 	}
 }
 
+// SetPaymentThreshold replaces the payment threshold communicated to the
+// peers and the derived disconnect limits. The connected peers keep their
+// thresholds, the new ones apply to the peers connecting afterwards.
+func (a *Accounting) SetPaymentThreshold(paymentThreshold *big.Int) {
+	a.thresholdMu.Lock()
+	defer a.thresholdMu.Unlock()
+
+	lightPaymentThreshold := new(big.Int).Div(paymentThreshold, big.NewInt(a.lightFactor))
+	a.paymentThreshold = new(big.Int).Set(paymentThreshold)
+	a.disconnectLimit = percentOf(100+a.paymentTolerance, paymentThreshold)
+	a.lightPaymentThreshold = lightPaymentThreshold
+	a.lightDisconnectLimit = percentOf(100+a.paymentTolerance, lightPaymentThreshold)
+}
+
 func (a *Accounting) SetRefreshFunc(f RefreshFunc) {
 	a.refreshFunction = f
 }
@@ -1544,6 +1576,16 @@ func (a *Accounting) SetPayFunc(f PayFunc) {
 	a.payFunction = f
 }
 
+func (a *Accounting) SetDisputeFunc(f DisputeFunc) {
+	a.disputeFunction = f
+}
+
+func (a *Accounting) dispute(peer swarm.Address) {
+	if a.disputeFunction != nil {
+		a.disputeFunction(peer)
+	}
+}
+
 // Close hangs up running websockets on shutdown.
 func (a *Accounting) Close() error {
 	a.wg.Wait()
@@ -1302,6 +1302,50 @@ func TestAccountingConnected(t *testing.T) {
 	}
 }
 
+func TestAccountingSetPaymentThreshold(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+
+	store := mock.NewStateStore()
+	defer store.Close()
+
+	acc, err := accounting.NewAccounting(testPaymentThreshold, testPaymentTolerance, testPaymentEarly, logger, store, &pricingMock{}, big.NewInt(testRefreshRate), testLightFactor, p2pmock.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	connected := swarm.RandAddress(t)
+	acc.Connect(connected, true)
+
+	paymentThreshold := new(big.Int).Mul(testPaymentThreshold, big.NewInt(2))
+	acc.SetPaymentThreshold(paymentThreshold)
+
+	full := swarm.RandAddress(t)
+	acc.Connect(full, true)
+	light := swarm.RandAddress(t)
+	acc.Connect(light, false)
+
+	peers, err := acc.PeerAccounting()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		peer swarm.Address
+		want *big.Int
+	}{
+		{"connected before", connected, testPaymentThreshold},
+		{"full node", full, paymentThreshold},
+		{"light node", light, new(big.Int).Div(paymentThreshold, big.NewInt(testLightFactor))},
+	} {
+		if got := peers[tc.peer.String()].ThresholdGiven; got.Cmp(tc.want) != 0 {
+			t.Errorf("%s: got threshold %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
 func TestAccountingNotifyPaymentThreshold(t *testing.T) {
 	t.Parallel()
 
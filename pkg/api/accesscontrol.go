@@ -133,7 +133,7 @@ func (s *Service) actDecryptionHandler() func(h http.Handler) http.Handler {
 				logger.Error(nil, "access control download failed")
 				switch {
 				case errors.Is(err, accesscontrol.ErrNotFound):
-					jsonhttp.NotFound(w, "act or history entry not found")
+					jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeACTEntryNotFound, "act or history entry not found"))
 				case errors.Is(err, accesscontrol.ErrInvalidTimestamp):
 					jsonhttp.BadRequest(w, "invalid timestamp")
 				case errors.Is(err, accesscontrol.ErrInvalidPublicKey) || errors.Is(err, accesscontrol.ErrSecretKeyInfinity):
@@ -268,7 +268,7 @@ func (s *Service) actGrantRevokeHandler(w http.ResponseWriter, r *http.Request)
 			logger.Error(nil, "get or create tag failed")
 			switch {
 			case errors.Is(err, storage.ErrNotFound):
-				jsonhttp.NotFound(w, "tag not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not found"))
 			default:
 				jsonhttp.InternalServerError(w, "cannot get or create tag")
 			}
@@ -329,13 +329,13 @@ func (s *Service) actGrantRevokeHandler(w http.ResponseWriter, r *http.Request)
 		logger.Error(nil, "putter failed")
 		switch {
 		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
 		case errors.Is(err, postage.ErrNotFound):
-			jsonhttp.NotFound(w, "batch with id not found")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
 		case errors.Is(err, errInvalidPostageBatch):
-			jsonhttp.BadRequest(w, "invalid batch id")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
 		case errors.Is(err, errUnsupportedDevNodeOperation):
-			jsonhttp.BadRequest(w, errUnsupportedDevNodeOperation)
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
 		default:
 			jsonhttp.BadRequest(w, nil)
 		}
@@ -352,7 +352,7 @@ func (s *Service) actGrantRevokeHandler(w http.ResponseWriter, r *http.Request)
 		logger.Error(nil, "failed to update grantee list")
 		switch {
 		case errors.Is(err, accesscontrol.ErrNotFound):
-			jsonhttp.NotFound(w, "act or history entry not found")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeACTEntryNotFound, "act or history entry not found"))
 		case errors.Is(err, accesscontrol.ErrNoGranteeFound):
 			jsonhttp.BadRequest(w, "remove from empty grantee list")
 		case errors.Is(err, accesscontrol.ErrUnexpectedType):
@@ -434,7 +434,7 @@ func (s *Service) actCreateGranteesHandler(w http.ResponseWriter, r *http.Reques
 			logger.Error(nil, "get or create tag failed")
 			switch {
 			case errors.Is(err, storage.ErrNotFound):
-				jsonhttp.NotFound(w, "tag not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not found"))
 			default:
 				jsonhttp.InternalServerError(w, "cannot get or create tag")
 			}
@@ -484,13 +484,13 @@ func (s *Service) actCreateGranteesHandler(w http.ResponseWriter, r *http.Reques
 		logger.Error(nil, "putter failed")
 		switch {
 		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
 		case errors.Is(err, postage.ErrNotFound):
-			jsonhttp.NotFound(w, "batch with id not found")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
 		case errors.Is(err, errInvalidPostageBatch):
-			jsonhttp.BadRequest(w, "invalid batch id")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
 		case errors.Is(err, errUnsupportedDevNodeOperation):
-			jsonhttp.BadRequest(w, errUnsupportedDevNodeOperation)
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
 		default:
 			jsonhttp.BadRequest(w, nil)
 		}
@@ -506,7 +506,7 @@ func (s *Service) actCreateGranteesHandler(w http.ResponseWriter, r *http.Reques
 		logger.Error(nil, "failed to create grantee list")
 		switch {
 		case errors.Is(err, accesscontrol.ErrNotFound):
-			jsonhttp.NotFound(w, "act or history entry not found")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeACTEntryNotFound, "act or history entry not found"))
 		case errors.Is(err, accesscontrol.ErrUnexpectedType):
 			jsonhttp.BadRequest(w, "failed to create history")
 		default:
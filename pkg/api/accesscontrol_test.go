@@ -280,8 +280,9 @@ func TestAccessLogicWithoutAct(t *testing.T) {
 
 		jsonhttptest.Request(t, client, http.MethodGet, fileDownloadResource(encryptedRef), http.StatusNotFound,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "address not found or incorrect",
-				Code:    http.StatusNotFound,
+				Message:   "address not found or incorrect",
+				Code:      http.StatusNotFound,
+				ErrorCode: "not_found",
 			}),
 			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "application/json; charset=utf-8"),
 		)
@@ -326,8 +327,9 @@ func TestAccessLogicWithoutAct(t *testing.T) {
 			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, fixtureHref.String()),
 			jsonhttptest.WithRequestHeader(api.SwarmActPublisherHeader, publisher),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "act or history entry not found",
-				Code:    http.StatusNotFound,
+				Message:   "act or history entry not found",
+				Code:      http.StatusNotFound,
+				ErrorCode: api.ErrorCodeACTEntryNotFound,
 			}),
 			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "application/json; charset=utf-8"),
 		)
@@ -374,6 +376,7 @@ func TestAccessLogicInvalidPath(t *testing.T) {
 						Error: api.HexInvalidByteError('s').Error(),
 					},
 				},
+				ErrorCode: "bad_request",
 			}),
 			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/html; charset=utf-8"),
 		)
@@ -513,8 +516,9 @@ func TestAccessLogicHistory(t *testing.T) {
 			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, "fc4e9fe978991257b897d987bc4ff13058b66ef45a53189a0b4fe84bb3346396"),
 			jsonhttptest.WithRequestHeader(api.SwarmActPublisherHeader, publisher),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "act or history entry not found",
-				Code:    http.StatusNotFound,
+				Message:   "act or history entry not found",
+				Code:      http.StatusNotFound,
+				ErrorCode: api.ErrorCodeACTEntryNotFound,
 			}),
 			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "application/json; charset=utf-8"),
 		)
@@ -536,8 +540,9 @@ func TestAccessLogicHistory(t *testing.T) {
 			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, fixtureHref.String()),
 			jsonhttptest.WithRequestBody(strings.NewReader(testfile)),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "act or history entry not found",
-				Code:    http.StatusNotFound,
+				Message:   "act or history entry not found",
+				Code:      http.StatusNotFound,
+				ErrorCode: api.ErrorCodeACTEntryNotFound,
 			}),
 			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/html; charset=utf-8"),
 		)
@@ -660,8 +665,9 @@ func TestAccessLogicTimestamp(t *testing.T) {
 			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, fixtureHref.String()),
 			jsonhttptest.WithRequestHeader(api.SwarmActPublisherHeader, publisher),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusBadRequest,
-				Message: accesscontrol.ErrInvalidTimestamp.Error(),
+				Code:      http.StatusBadRequest,
+				Message:   accesscontrol.ErrInvalidTimestamp.Error(),
+				ErrorCode: "bad_request",
 			}),
 			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/html; charset=utf-8"),
 		)
@@ -785,6 +791,7 @@ func TestAccessLogicPublisher(t *testing.T) {
 						Error: "malformed public key: invalid length: 32",
 					},
 				},
+				ErrorCode: "bad_request",
 			}),
 			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/html; charset=utf-8"),
 		)
@@ -808,8 +815,9 @@ func TestAccessLogicPublisher(t *testing.T) {
 			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, fixtureHref.String()),
 			jsonhttptest.WithRequestHeader(api.SwarmActPublisherHeader, downloader),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: accesscontrol.ErrInvalidPublicKey.Error(),
-				Code:    http.StatusBadRequest,
+				Message:   accesscontrol.ErrInvalidPublicKey.Error(),
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
 			}),
 			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "application/json; charset=utf-8"),
 		)
@@ -833,8 +841,9 @@ func TestAccessLogicPublisher(t *testing.T) {
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
 			jsonhttptest.WithRequestBody(strings.NewReader(testfile)),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusBadRequest,
-				Message: "invalid public key",
+				Code:      http.StatusBadRequest,
+				Message:   "invalid public key",
+				ErrorCode: "bad_request",
 			}),
 			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/html; charset=utf-8"),
 		)
@@ -902,8 +911,9 @@ func TestAccessLogicGrantees(t *testing.T) {
 	t.Run("get-grantees-unauthorized", func(t *testing.T) {
 		jsonhttptest.Request(t, client, http.MethodGet, "/grantee/fc4e9fe978991257b897d987bc4ff13058b66ef45a53189a0b4fe84bb3346396", http.StatusNotFound,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "granteelist not found",
-				Code:    http.StatusNotFound,
+				Message:   "granteelist not found",
+				Code:      http.StatusNotFound,
+				ErrorCode: "not_found",
 			}),
 		)
 	})
@@ -918,6 +928,7 @@ func TestAccessLogicGrantees(t *testing.T) {
 						Error: api.HexInvalidByteError('s').Error(),
 					},
 				},
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
@@ -941,8 +952,9 @@ func TestAccessLogicGrantees(t *testing.T) {
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
 			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, swarm.EmptyAddress.String()),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "act or history entry not found",
-				Code:    http.StatusNotFound,
+				Message:   "act or history entry not found",
+				Code:      http.StatusNotFound,
+				ErrorCode: api.ErrorCodeACTEntryNotFound,
 			}),
 			jsonhttptest.WithJSONRequestBody(body),
 		)
@@ -955,8 +967,9 @@ func TestAccessLogicGrantees(t *testing.T) {
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
 			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, addr.String()),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "invalid add list",
-				Code:    http.StatusBadRequest,
+				Message:   "invalid add list",
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
 			}),
 			jsonhttptest.WithJSONRequestBody(body),
 		)
@@ -969,8 +982,9 @@ func TestAccessLogicGrantees(t *testing.T) {
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
 			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, addr.String()),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "invalid revoke list",
-				Code:    http.StatusBadRequest,
+				Message:   "invalid revoke list",
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
 			}),
 			jsonhttptest.WithJSONRequestBody(body),
 		)
@@ -980,8 +994,9 @@ func TestAccessLogicGrantees(t *testing.T) {
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
 			jsonhttptest.WithRequestBody(bytes.NewReader(nil)),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "could not validate request",
-				Code:    http.StatusBadRequest,
+				Message:   "could not validate request",
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
@@ -1032,8 +1047,9 @@ func TestAccessLogicGrantees(t *testing.T) {
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
 			jsonhttptest.WithRequestBody(bytes.NewReader(nil)),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "could not validate request",
-				Code:    http.StatusBadRequest,
+				Message:   "could not validate request",
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
@@ -1045,8 +1061,9 @@ func TestAccessLogicGrantees(t *testing.T) {
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
 			jsonhttptest.WithRequestBody(bytes.NewReader(nil)),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "invalid grantee list",
-				Code:    http.StatusBadRequest,
+				Message:   "invalid grantee list",
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
 			}),
 			jsonhttptest.WithJSONRequestBody(body),
 		)
@@ -116,8 +116,9 @@ func TestAccountingInfoError(t *testing.T) {
 
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/accounting", http.StatusInternalServerError,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.HttpErrGetAccountingInfo,
-			Code:    http.StatusInternalServerError,
+			Message:   api.HttpErrGetAccountingInfo,
+			Code:      http.StatusInternalServerError,
+			ErrorCode: "internal_server_error",
 		}),
 	)
 }
@@ -22,32 +22,42 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethersphere/bee/v2/pkg/accesscontrol"
 	"github.com/ethersphere/bee/v2/pkg/accounting"
+	"github.com/ethersphere/bee/v2/pkg/backup"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/events"
 	"github.com/ethersphere/bee/v2/pkg/feeds"
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
 	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/fusefs"
 	"github.com/ethersphere/bee/v2/pkg/gsoc"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
 	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/media"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/pingpong"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/postage/postagecontract"
 	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	"github.com/ethersphere/bee/v2/pkg/qos"
+	"github.com/ethersphere/bee/v2/pkg/readonly"
 	"github.com/ethersphere/bee/v2/pkg/resolver"
 	"github.com/ethersphere/bee/v2/pkg/resolver/client/ens"
+	"github.com/ethersphere/bee/v2/pkg/scoreboard"
 	"github.com/ethersphere/bee/v2/pkg/sctx"
 	"github.com/ethersphere/bee/v2/pkg/settlement"
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap"
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap/chequebook"
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap/erc20"
+	"github.com/ethersphere/bee/v2/pkg/stampproxy"
 	"github.com/ethersphere/bee/v2/pkg/status"
 	"github.com/ethersphere/bee/v2/pkg/steward"
 	"github.com/ethersphere/bee/v2/pkg/storage"
@@ -59,6 +69,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/topology/lightnode"
 	"github.com/ethersphere/bee/v2/pkg/tracing"
 	"github.com/ethersphere/bee/v2/pkg/transaction"
+	"github.com/ethersphere/bee/v2/pkg/util/ioutil"
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
 	"github.com/hashicorp/go-multierror"
@@ -78,21 +89,41 @@ const (
 	SwarmSocSignatureHeader           = "Swarm-Soc-Signature"
 	SwarmFeedIndexHeader              = "Swarm-Feed-Index"
 	SwarmFeedIndexNextHeader          = "Swarm-Feed-Index-Next"
+	SwarmFeedSnapshotHeader           = "Swarm-Feed-Snapshot"
 	SwarmLegacyFeedResolve            = "Swarm-Feed-Legacy-Resolve"
 	SwarmOnlyRootChunk                = "Swarm-Only-Root-Chunk"
 	SwarmCollectionHeader             = "Swarm-Collection"
 	SwarmPostageBatchIdHeader         = "Swarm-Postage-Batch-Id"
 	SwarmPostageStampHeader           = "Swarm-Postage-Stamp"
 	SwarmDeferredUploadHeader         = "Swarm-Deferred-Upload"
+	SwarmReplicationFactorHeader      = "Swarm-Replication-Factor"
 	SwarmRedundancyLevelHeader        = "Swarm-Redundancy-Level"
 	SwarmRedundancyStrategyHeader     = "Swarm-Redundancy-Strategy"
 	SwarmRedundancyFallbackModeHeader = "Swarm-Redundancy-Fallback-Mode"
 	SwarmChunkRetrievalTimeoutHeader  = "Swarm-Chunk-Retrieval-Timeout"
 	SwarmLookAheadBufferSizeHeader    = "Swarm-Lookahead-Buffer-Size"
+	SwarmCacheHeader                  = "Swarm-Cache"
+	SwarmCacheTTLHeader               = "Swarm-Cache-Ttl"
+	SwarmStoreHeader                  = "Swarm-Store"
+	SwarmDownloadSessionHeader        = "Swarm-Download-Session"
+	SwarmDownloadModeHeader           = "Swarm-Download-Mode"
+	SwarmExpectedReferenceHeader      = "Swarm-Expected-Reference"
+	SwarmIdempotencyKeyHeader         = "Swarm-Idempotency-Key"
+	SwarmIdempotentReplayHeader       = "Swarm-Idempotent-Replay"
+	SwarmRecoveryHeader               = "Swarm-Recovery"
+	SwarmRecoveryPublisherHeader      = "Swarm-Recovery-Publisher"
 	SwarmActHeader                    = "Swarm-Act"
 	SwarmActTimestampHeader           = "Swarm-Act-Timestamp"
 	SwarmActPublisherHeader           = "Swarm-Act-Publisher"
 	SwarmActHistoryAddressHeader      = "Swarm-Act-History-Address"
+	SwarmFileModeHeader               = "Swarm-File-Mode"
+	SwarmPriorityHeader               = "Swarm-Priority"
+	SwarmOriginHintHeader             = "Swarm-Origin-Hint"
+	SwarmTimeoutHeader                = "Swarm-Timeout"
+	SwarmRequestTimestampHeader       = "Swarm-Request-Timestamp"
+	SwarmRequestNonceHeader           = "Swarm-Request-Nonce"
+	SwarmRequestSignatureHeader       = "Swarm-Request-Signature"
+	SwarmRequestBodyHashHeader        = "Swarm-Request-Body-Hash"
 
 	ImmutableHeader = "Immutable"
 	GasPriceHeader  = "Gas-Price"
@@ -101,10 +132,12 @@ const (
 
 	AuthorizationHeader        = "Authorization"
 	AcceptEncodingHeader       = "Accept-Encoding"
+	IfNoneMatchHeader          = "If-None-Match"
 	ContentTypeHeader          = "Content-Type"
 	ContentDispositionHeader   = "Content-Disposition"
 	ContentLengthHeader        = "Content-Length"
 	RangeHeader                = "Range"
+	ContentRangeHeader         = "Content-Range"
 	OriginHeader               = "Origin"
 	AccessControlExposeHeaders = "Access-Control-Expose-Headers"
 )
@@ -170,7 +203,9 @@ type Service struct {
 	Options
 
 	http.Handler
-	router *mux.Router
+	router          *mux.Router
+	groupRouters    [numRouteGroups]*mux.Router
+	separatedGroups [numRouteGroups]bool
 
 	metrics metrics
 
@@ -179,11 +214,14 @@ type Service struct {
 
 	overlay           *swarm.Address
 	publicKey         ecdsa.PublicKey
-	pssPublicKey      ecdsa.PublicKey
+	pssPublicKey      atomic.Pointer[ecdsa.PublicKey]
 	ethereumAddress   common.Address
 	chequebookEnabled bool
 	swapEnabled       bool
 	fullAPIEnabled    bool
+	readOnly          *readonly.Mode
+	downloadSessions  *downloadSessions
+	routes            []route
 
 	topologyDriver topology.Driver
 	p2p            p2p.DebugService
@@ -192,9 +230,43 @@ type Service struct {
 	pseudosettle   settlement.Interface
 	pingpong       pingpong.Interface
 
-	batchStore   postage.Storer
-	stamperStore storage.Store
-	pinIntegrity PinIntegrity
+	batchStore       postage.Storer
+	stamperStore     storage.Store
+	pinIntegrity     PinIntegrity
+	backup           *backup.Service
+	mounter          *fusefs.Mounter
+	stampProxy       *stampproxy.Service
+	prefetcher       *media.Prefetcher
+	images           *media.ImageTransformer
+	reserve          ReserveStore
+	stateStore       storage.StateStorer
+	batchSnapshotter BatchSnapshotter
+	scoreboard       *scoreboard.Board
+	receipts         ReceiptChallenger
+	syncLimiter      SyncLimiter
+	pushLimiter      PushLimiter
+	syncProgress     SyncProgress
+	selfTest         SelfTester
+	peering          PeeringPolicy
+	topologyEvents   topology.EventSubscriber
+	events           events.Interface
+	traffic          TrafficMeter
+	modeSwitcher     NodeModeSwitcher
+	maintenance      MaintenanceScheduler
+	keyRotator       KeyRotator
+	chaos            ChaosInjector
+	chunkTracer      ChunkTracer
+	recentLogs       io.WriterTo
+	config           map[string]any
+	configReloader   ConfigReloader
+	readiness        ReadinessCriteria
+	availability     AvailabilityChecker
+	chunkPricer      ChunkPricer
+	exchangeRater    ExchangeRater
+	idempotency      *idempotencyCache
+	replayGuard      *replayGuard
+	bzzAccessLog     *bzzAccessLog
+	corsMu           sync.RWMutex
 
 	syncStatus func() (bool, error)
 
@@ -230,6 +302,13 @@ func (s *Service) SetP2P(p2p p2p.DebugService) {
 	}
 }
 
+// SetPSSPublicKey sets the public key of the pss key after the key rotation.
+func (s *Service) SetPSSPublicKey(key ecdsa.PublicKey) {
+	if s != nil {
+		s.pssPublicKey.Store(&key)
+	}
+}
+
 func (s *Service) SetSwarmAddress(addr *swarm.Address) {
 	if s != nil {
 		s.overlay = addr
@@ -245,6 +324,37 @@ func (s *Service) SetRedistributionAgent(redistributionAgent *storageincentives.
 type Options struct {
 	CORSAllowedOrigins []string
 	WsPingPeriod       time.Duration
+	// ValidateRequests rejects the requests with the path parameters
+	// that do not match the definitions of the OpenAPI document.
+	ValidateRequests bool
+	// ValidationOffload lets the storer and pushsync skip the validation
+	// of the chunks created by the upload pipelines of the node.
+	ValidationOffload bool
+	// SOCCacheTTL and FeedCacheTTL are the ages after which the cached
+	// single owner chunks are retrieved again by the soc downloads and the
+	// feed lookups, zero for no expiry.
+	SOCCacheTTL  time.Duration
+	FeedCacheTTL time.Duration
+	// IdempotencyWindow is the time for which the responses of the uploads
+	// made with the idempotency keys are replayed, zero to disable.
+	IdempotencyWindow time.Duration
+	// BzzAccessLogPath is the file to which the accesses to the content
+	// served by the bzz endpoint are logged, empty to disable. The file
+	// is rotated after it reaches BzzAccessLogMaxSize bytes, keeping up
+	// to BzzAccessLogMaxBackups rotated files.
+	BzzAccessLogPath       string
+	BzzAccessLogMaxSize    int64
+	BzzAccessLogMaxBackups int
+	// ReadOnly is the read-only mode of the node in which the uploads,
+	// pins, wallet and chain transactions are rejected. It is shared with
+	// the other frontends of the node.
+	ReadOnly *readonly.Mode
+	// RequestSigningSecret requires the requests to the destructive
+	// endpoints to be signed with the secret, each signed request is
+	// accepted once within the RequestSigningWindow of its timestamp.
+	// Empty to disable.
+	RequestSigningSecret string
+	RequestSigningWindow time.Duration
 }
 
 type ExtraOptions struct {
@@ -269,6 +379,35 @@ type ExtraOptions struct {
 	SyncStatus      func() (bool, error)
 	NodeStatus      *status.Service
 	PinIntegrity    PinIntegrity
+	Backup          *backup.Service
+	Mounter         *fusefs.Mounter
+	StampProxy      *stampproxy.Service
+	Images          *media.ImageTransformer
+	Reserve         ReserveStore
+	StateStore      storage.StateStorer
+	BatchSnapshot   BatchSnapshotter
+	Scoreboard      *scoreboard.Board
+	Receipts        ReceiptChallenger
+	SyncLimiter     SyncLimiter
+	PushLimiter     PushLimiter
+	SyncProgress    SyncProgress
+	SelfTest        SelfTester
+	Peering         PeeringPolicy
+	TopologyEvents  topology.EventSubscriber
+	Events          events.Interface
+	Traffic         TrafficMeter
+	ModeSwitcher    NodeModeSwitcher
+	Maintenance     MaintenanceScheduler
+	KeyRotator      KeyRotator
+	Chaos           ChaosInjector
+	ChunkTracer     ChunkTracer
+	RecentLogs      io.WriterTo
+	Config          map[string]any
+	ConfigReloader  ConfigReloader
+	Readiness       ReadinessCriteria
+	Availability    AvailabilityChecker
+	ChunkPricer     ChunkPricer
+	ExchangeRater   ExchangeRater
 }
 
 func New(
@@ -294,12 +433,15 @@ func New(
 	s.chequebookEnabled = chequebookEnabled
 	s.swapEnabled = swapEnabled
 	s.publicKey = publicKey
-	s.pssPublicKey = pssPublicKey
+	s.pssPublicKey.Store(&pssPublicKey)
 	s.ethereumAddress = ethereumAddress
 	s.transaction = transaction
 	s.batchStore = batchStore
 	s.chainBackend = chainBackend
 	s.metricsRegistry = newDebugMetrics()
+	s.metrics = newMetrics()
+	s.readOnly = readonly.New(false)
+	s.downloadSessions = newDownloadSessions(downloadSessionsCapacity)
 	s.preMapHooks = map[string]func(v string) (string, error){
 		"mimeMediaType": func(v string) (string, error) {
 			typ, _, err := mime.ParseMediaType(v)
@@ -336,9 +478,28 @@ func (s *Service) Configure(signer crypto.Signer, tracer *tracing.Tracer, o Opti
 	s.signer = signer
 	s.Options = o
 	s.tracer = tracer
-	s.metrics = newMetrics()
 
 	s.quit = make(chan struct{})
+	if o.ReadOnly != nil {
+		s.readOnly = o.ReadOnly
+	}
+
+	if o.IdempotencyWindow > 0 {
+		s.idempotency = newIdempotencyCache(o.IdempotencyWindow)
+	}
+
+	if o.RequestSigningSecret != "" {
+		s.replayGuard = newReplayGuard(o.RequestSigningSecret, o.RequestSigningWindow)
+	}
+
+	if o.BzzAccessLogPath != "" {
+		f, err := ioutil.NewRotatingFile(o.BzzAccessLogPath, o.BzzAccessLogMaxSize, o.BzzAccessLogMaxBackups)
+		if err != nil {
+			s.logger.Error(err, "bzz access log disabled: open file failed", "path", o.BzzAccessLogPath)
+		} else {
+			s.bzzAccessLog = newBzzAccessLog(f)
+		}
+	}
 
 	s.storer = e.Storer
 	s.resolver = e.Resolver
@@ -383,6 +544,38 @@ func (s *Service) Configure(signer crypto.Signer, tracer *tracing.Tracer, o Opti
 	}
 
 	s.pinIntegrity = e.PinIntegrity
+	s.backup = e.Backup
+	s.mounter = e.Mounter
+	s.stampProxy = e.StampProxy
+	s.images = e.Images
+	if s.storer != nil {
+		s.prefetcher = media.NewPrefetcher(s.storer.Download(true), s.storer.Cache(), s.logger, media.Options{})
+	}
+	s.reserve = e.Reserve
+	s.stateStore = e.StateStore
+	s.batchSnapshotter = e.BatchSnapshot
+	s.scoreboard = e.Scoreboard
+	s.receipts = e.Receipts
+	s.syncLimiter = e.SyncLimiter
+	s.pushLimiter = e.PushLimiter
+	s.syncProgress = e.SyncProgress
+	s.selfTest = e.SelfTest
+	s.peering = e.Peering
+	s.topologyEvents = e.TopologyEvents
+	s.events = e.Events
+	s.traffic = e.Traffic
+	s.modeSwitcher = e.ModeSwitcher
+	s.maintenance = e.Maintenance
+	s.keyRotator = e.KeyRotator
+	s.chaos = e.Chaos
+	s.chunkTracer = e.ChunkTracer
+	s.recentLogs = e.RecentLogs
+	s.config = e.Config
+	s.configReloader = e.ConfigReloader
+	s.readiness = e.Readiness
+	s.availability = e.Availability
+	s.chunkPricer = e.ChunkPricer
+	s.exchangeRater = e.ExchangeRater
 }
 
 func (s *Service) SetProbe(probe *Probe) {
@@ -398,6 +591,14 @@ func (s *Service) Close() error {
 	s.logger.Info("api shutting down")
 	close(s.quit)
 
+	if s.prefetcher != nil {
+		_ = s.prefetcher.Close()
+	}
+
+	if s.bzzAccessLog != nil {
+		_ = s.bzzAccessLog.Close()
+	}
+
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
@@ -527,10 +728,11 @@ func (s *Service) downloadSpeedMetricMiddleware(endpoint string) func(h http.Han
 }
 
 // observeUploadSpeed measures the speed of the upload and sets appropriate
-// labels to the metrics. This function can be called as a deferred function in
+// labels to the metrics, the usage is also attributed to the batch and the
+// API key. This function can be called as a deferred function in
 // side of handler. This functions is not in a form of a middleware to more
 // directly pass the deferred flag.
-func (s *Service) observeUploadSpeed(w http.ResponseWriter, r *http.Request, start time.Time, endpoint string, deferred bool) {
+func (s *Service) observeUploadSpeed(w http.ResponseWriter, r *http.Request, start time.Time, endpoint string, deferred bool, batchID []byte) {
 	rw, ok := w.(*responseWriter)
 	if !ok {
 		return
@@ -547,6 +749,12 @@ func (s *Service) observeUploadSpeed(w http.ResponseWriter, r *http.Request, sta
 
 	speed := float64(r.ContentLength) / time.Since(start).Seconds()
 	s.metrics.UploadSpeed.WithLabelValues(endpoint, mode).Observe(speed)
+
+	batch, apiKey := batchLabel(batchID), apiKeyLabel(r.Context())
+	s.metrics.BatchUploadSpeed.WithLabelValues(endpoint, batch, apiKey).Observe(speed)
+	if r.ContentLength > 0 {
+		s.metrics.BatchUploadedBytes.WithLabelValues(endpoint, batch, apiKey).Add(float64(r.ContentLength))
+	}
 }
 
 // gasConfigMiddleware can be used by the APIs that allow block chain transactions to set
@@ -573,16 +781,60 @@ func (s *Service) gasConfigMiddleware(handlerName string) func(h http.Handler) h
 	}
 }
 
+// priorityHandler sets the priority class of the chunk operations of the
+// request from the priority header. The requests are interactive by default.
+func (s *Service) priorityHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := r.Header.Get(SwarmPriorityHeader)
+		if v == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		class, err := qos.ParseClass(strings.ToLower(v))
+		if err != nil {
+			s.logger.Debug("invalid priority header", "value", v, "error", err)
+			jsonhttp.BadRequest(w, "invalid priority class")
+			return
+		}
+		h.ServeHTTP(w, r.WithContext(qos.WithClass(r.Context(), class)))
+	})
+}
+
+// timeoutHandler bounds the request context with the deadline from the
+// Swarm-Timeout header, so that the retrieval and push work started for the
+// request is canceled once the client stops waiting for it.
+func (s *Service) timeoutHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := r.Header.Get(SwarmTimeoutHeader)
+		if v == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		timeout, err := time.ParseDuration(v)
+		if err != nil || timeout <= 0 {
+			s.logger.Debug("invalid timeout header", "value", v, "error", err)
+			jsonhttp.BadRequest(w, "invalid timeout")
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // corsHandler sets CORS headers to HTTP response if allowed origins are configured.
 func (s *Service) corsHandler(h http.Handler) http.Handler {
 	allowedHeaders := []string{
 		"User-Agent", "Accept", "X-Requested-With", "Access-Control-Request-Headers", "Access-Control-Request-Method", "Accept-Ranges", "Content-Encoding",
-		AuthorizationHeader, AcceptEncodingHeader, ContentTypeHeader, ContentDispositionHeader, RangeHeader, OriginHeader,
+		AuthorizationHeader, AcceptEncodingHeader, IfNoneMatchHeader, ContentTypeHeader, ContentDispositionHeader, RangeHeader, OriginHeader,
 		SwarmTagHeader, SwarmPinHeader, SwarmEncryptHeader, SwarmIndexDocumentHeader, SwarmErrorDocumentHeader, SwarmCollectionHeader,
-		SwarmPostageBatchIdHeader, SwarmPostageStampHeader, SwarmDeferredUploadHeader, SwarmRedundancyLevelHeader,
+		SwarmPostageBatchIdHeader, SwarmPostageStampHeader, SwarmDeferredUploadHeader, SwarmReplicationFactorHeader, SwarmRedundancyLevelHeader,
 		SwarmRedundancyStrategyHeader, SwarmRedundancyFallbackModeHeader, SwarmChunkRetrievalTimeoutHeader, SwarmLookAheadBufferSizeHeader,
-		SwarmFeedIndexHeader, SwarmFeedIndexNextHeader, SwarmSocSignatureHeader, SwarmOnlyRootChunk, GasPriceHeader, GasLimitHeader, ImmutableHeader,
-		SwarmActHeader, SwarmActTimestampHeader, SwarmActPublisherHeader, SwarmActHistoryAddressHeader,
+		SwarmFeedIndexHeader, SwarmFeedIndexNextHeader, SwarmFeedSnapshotHeader, SwarmSocSignatureHeader, SwarmOnlyRootChunk, GasPriceHeader, GasLimitHeader, ImmutableHeader,
+		SwarmActHeader, SwarmActTimestampHeader, SwarmActPublisherHeader, SwarmActHistoryAddressHeader, SwarmPriorityHeader,
+		SwarmOriginHintHeader, SwarmRecoveryHeader, SwarmRecoveryPublisherHeader, SwarmDownloadSessionHeader, SwarmDownloadModeHeader,
+		SwarmExpectedReferenceHeader, SwarmIdempotencyKeyHeader, SwarmTimeoutHeader, SwarmStoreHeader, SwarmCacheTTLHeader,
+		SwarmRequestTimestampHeader, SwarmRequestNonceHeader, SwarmRequestSignatureHeader, SwarmRequestBodyHashHeader,
 	}
 	allowedHeadersStr := strings.Join(allowedHeaders, ", ")
 
@@ -608,7 +860,10 @@ func (s *Service) checkOrigin(r *http.Request) bool {
 	if r.TLS != nil {
 		scheme = "https"
 	}
-	hosts := append(s.CORSAllowedOrigins, scheme+"://"+r.Host)
+	s.corsMu.RLock()
+	// the capacity is limited so that the append copies the shared origins
+	hosts := append(s.CORSAllowedOrigins[:len(s.CORSAllowedOrigins):len(s.CORSAllowedOrigins)], scheme+"://"+r.Host)
+	s.corsMu.RUnlock()
 	for _, v := range hosts {
 		if equalASCIIFold(origin[0], v) || v == "*" {
 			return true
@@ -731,12 +986,22 @@ type putterOptions struct {
 	TagID    uint64
 	Deferred bool
 	Pin      bool
+	// ReplicationFactor is the number of the neighborhood peers the
+	// chunks of a direct upload are pushed to. Zero means the default.
+	ReplicationFactor uint8
+	// CacheOnly stores the chunks only in the local cache, without
+	// stamping them, where they are retained for the CacheTTL.
+	CacheOnly bool
+	CacheTTL  time.Duration
 }
 
 type putterSessionWrapper struct {
 	storer.PutterSession
-	stamper postage.Stamper
-	save    func() error
+	stamper           postage.Stamper
+	save              func() error
+	replicationFactor uint8
+	validationOffload bool
+	stampedChunks     prometheus.Counter
 }
 
 func (p *putterSessionWrapper) Put(ctx context.Context, chunk swarm.Chunk) error {
@@ -749,9 +1014,44 @@ func (p *putterSessionWrapper) Put(ctx context.Context, chunk swarm.Chunk) error
 	if err != nil {
 		return err
 	}
+	p.stampedChunks.Inc()
+	if p.replicationFactor != 0 {
+		ctx = pushsync.SetReplicationFactor(ctx, p.replicationFactor)
+	}
+	if !p.validationOffload {
+		swarm.MarkValid(chunk, swarm.ChunkTypeUnspecified)
+	}
 	return p.PutterSession.Put(ctx, chunk.WithStamp(stamp))
 }
 
+func (p *putterSessionWrapper) PutMany(ctx context.Context, chunks []swarm.Chunk) ([]error, error) {
+	stamped := make([]swarm.Chunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		idAddress, err := storage.IdentityAddress(chunk)
+		if err != nil {
+			return nil, err
+		}
+
+		stamp, err := p.stamper.Stamp(chunk.Address(), idAddress)
+		if err != nil {
+			return nil, err
+		}
+		if !p.validationOffload {
+			swarm.MarkValid(chunk, swarm.ChunkTypeUnspecified)
+		}
+		stamped = append(stamped, chunk.WithStamp(stamp))
+	}
+	p.stampedChunks.Add(float64(len(stamped)))
+	if p.replicationFactor != 0 {
+		ctx = pushsync.SetReplicationFactor(ctx, p.replicationFactor)
+	}
+	return storage.PutMany(ctx, p.PutterSession, stamped)
+}
+
+func (p *putterSessionWrapper) ZeroCopy() bool {
+	return storage.IsZeroCopy(p.PutterSession)
+}
+
 func (p *putterSessionWrapper) Done(ref swarm.Address) error {
 	return errors.Join(p.PutterSession.Done(ref), p.save())
 }
@@ -779,6 +1079,13 @@ func (s *Service) getStamper(batchID []byte) (postage.Stamper, func() error, err
 }
 
 func (s *Service) newStamperPutter(ctx context.Context, opts putterOptions) (storer.PutterSession, error) {
+	if opts.CacheOnly {
+		if opts.Pin {
+			return nil, errCacheOnlyPin
+		}
+		return s.newCacheOnlyPutter(opts.CacheTTL), nil
+	}
+
 	if !opts.Deferred && s.beeMode == DevMode {
 		return nil, errUnsupportedDevNodeOperation
 	}
@@ -788,6 +1095,14 @@ func (s *Service) newStamperPutter(ctx context.Context, opts putterOptions) (sto
 		return nil, fmt.Errorf("get stamper: %w", err)
 	}
 
+	// the uploads of the stamping proxy clients are counted towards their limits
+	if id, ok := stampproxy.ClientID(ctx); ok && s.stampProxy != nil {
+		issuerSave := save
+		var clientSave func() error
+		stamper, clientSave = s.stampProxy.Stamper(id, stamper)
+		save = func() error { return errors.Join(issuerSave(), clientSave()) }
+	}
+
 	var session storer.PutterSession
 	if opts.Deferred || opts.Pin {
 		session, err = s.storer.Upload(ctx, opts.Pin, opts.TagID)
@@ -800,9 +1115,12 @@ func (s *Service) newStamperPutter(ctx context.Context, opts putterOptions) (sto
 	}
 
 	return &putterSessionWrapper{
-		PutterSession: session,
-		stamper:       stamper,
-		save:          save,
+		PutterSession:     session,
+		stamper:           stamper,
+		save:              save,
+		replicationFactor: opts.ReplicationFactor,
+		validationOffload: s.ValidationOffload,
+		stampedChunks:     s.metrics.StampedChunks.WithLabelValues(batchLabel(opts.BatchID), apiKeyLabel(ctx)),
 	}, nil
 }
 
@@ -829,9 +1147,12 @@ func (s *Service) newStampedPutter(ctx context.Context, opts putterOptions, stam
 	stamper := postage.NewPresignedStamper(stamp, storedBatch.Owner)
 
 	return &putterSessionWrapper{
-		PutterSession: session,
-		stamper:       stamper,
-		save:          func() error { return nil },
+		PutterSession:     session,
+		stamper:           stamper,
+		save:              func() error { return nil },
+		replicationFactor: opts.ReplicationFactor,
+		validationOffload: s.ValidationOffload,
+		stampedChunks:     s.metrics.StampedChunks.WithLabelValues(batchLabel(stamp.BatchID()), apiKeyLabel(ctx)),
 	}, nil
 }
 
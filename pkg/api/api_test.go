@@ -12,6 +12,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"io"
 	"math/big"
 	"net"
 	"net/http"
@@ -26,14 +27,18 @@ import (
 	mockac "github.com/ethersphere/bee/v2/pkg/accesscontrol/mock"
 	accountingmock "github.com/ethersphere/bee/v2/pkg/accounting/mock"
 	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/backup"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/events"
 	"github.com/ethersphere/bee/v2/pkg/feeds"
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
 	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/fusefs"
 	"github.com/ethersphere/bee/v2/pkg/gsoc"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
 	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/media"
 	p2pmock "github.com/ethersphere/bee/v2/pkg/p2p/mock"
 	"github.com/ethersphere/bee/v2/pkg/pingpong"
 	"github.com/ethersphere/bee/v2/pkg/postage"
@@ -43,14 +48,17 @@ import (
 	contractMock "github.com/ethersphere/bee/v2/pkg/postage/postagecontract/mock"
 	"github.com/ethersphere/bee/v2/pkg/pss"
 	"github.com/ethersphere/bee/v2/pkg/pusher"
+	"github.com/ethersphere/bee/v2/pkg/readonly"
 	"github.com/ethersphere/bee/v2/pkg/resolver"
 	resolverMock "github.com/ethersphere/bee/v2/pkg/resolver/mock"
+	"github.com/ethersphere/bee/v2/pkg/scoreboard"
 	"github.com/ethersphere/bee/v2/pkg/settlement/pseudosettle"
 	chequebookmock "github.com/ethersphere/bee/v2/pkg/settlement/swap/chequebook/mock"
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap/erc20"
 	erc20mock "github.com/ethersphere/bee/v2/pkg/settlement/swap/erc20/mock"
 	swapmock "github.com/ethersphere/bee/v2/pkg/settlement/swap/mock"
 	"github.com/ethersphere/bee/v2/pkg/spinlock"
+	"github.com/ethersphere/bee/v2/pkg/stampproxy"
 	statestore "github.com/ethersphere/bee/v2/pkg/statestore/mock"
 	"github.com/ethersphere/bee/v2/pkg/status"
 	"github.com/ethersphere/bee/v2/pkg/steward"
@@ -63,6 +71,7 @@ import (
 	mock2 "github.com/ethersphere/bee/v2/pkg/storageincentives/staking/mock"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
 	"github.com/ethersphere/bee/v2/pkg/topology/lightnode"
 	topologymock "github.com/ethersphere/bee/v2/pkg/topology/mock"
 	"github.com/ethersphere/bee/v2/pkg/tracing"
@@ -100,6 +109,11 @@ type testServerOptions struct {
 	PreventRedirect    bool
 	Feeds              feeds.Factory
 	CORSAllowedOrigins []string
+	ValidateRequests   bool
+	IdempotencyWindow  time.Duration
+	BzzAccessLogPath   string
+	ReadOnly           bool
+	RequestSigning     string
 	PostageContract    postagecontract.Interface
 	StakingContract    staking.Contract
 	Post               postage.Service
@@ -131,16 +145,49 @@ type testServerOptions struct {
 	RedistributionAgent *storageincentives.Agent
 	NodeStatus          *status.Service
 	PinIntegrity        api.PinIntegrity
+	Backup              *backup.Service
+	Mounter             *fusefs.Mounter
+	StampProxy          *stampproxy.Service
+	Images              *media.ImageTransformer
+	Reserve             api.ReserveStore
+	StateStoreAPI       storage.StateStorer
+	BatchSnapshot       api.BatchSnapshotter
+	Scoreboard          *scoreboard.Board
+	Receipts            api.ReceiptChallenger
+	SyncLimiter         api.SyncLimiter
+	PushLimiter         api.PushLimiter
+	SyncProgress        api.SyncProgress
+	SelfTest            api.SelfTester
+	Peering             api.PeeringPolicy
+	TopologyEvents      topology.EventSubscriber
+	Events              events.Interface
+	Traffic             api.TrafficMeter
+	ModeSwitcher        api.NodeModeSwitcher
+	Maintenance         api.MaintenanceScheduler
+	KeyRotator          api.KeyRotator
+	Chaos               api.ChaosInjector
+	ChunkTracer         api.ChunkTracer
+	RecentLogs          io.WriterTo
+	Config              map[string]any
+	ConfigReloader      api.ConfigReloader
+	Readiness           api.ReadinessCriteria
+	Availability        api.AvailabilityChecker
+	ChunkPricer         api.ChunkPricer
+	ExchangeRater       api.ExchangeRater
 	WhitelistedAddr     string
 	FullAPIDisabled     bool
 	ChequebookDisabled  bool
 	SwapDisabled        bool
+	Signer              crypto.Signer
 }
 
 func newTestServer(t *testing.T, o testServerOptions) (*http.Client, *websocket.Conn, string, *chanStorer) {
 	t.Helper()
-	pk, _ := crypto.GenerateSecp256k1Key()
-	signer := crypto.NewDefaultSigner(pk)
+	signer := o.Signer
+	if signer == nil {
+		pk, _ := crypto.GenerateSecp256k1Key()
+		signer = crypto.NewDefaultSigner(pk)
+	}
 
 	if o.Logger == nil {
 		o.Logger = log.Noop
@@ -205,6 +252,35 @@ func newTestServer(t *testing.T, o testServerOptions) (*http.Client, *websocket.
 		Staking:         o.StakingContract,
 		NodeStatus:      o.NodeStatus,
 		PinIntegrity:    o.PinIntegrity,
+		Backup:          o.Backup,
+		Mounter:         o.Mounter,
+		StampProxy:      o.StampProxy,
+		Images:          o.Images,
+		Reserve:         o.Reserve,
+		StateStore:      o.StateStoreAPI,
+		BatchSnapshot:   o.BatchSnapshot,
+		Scoreboard:      o.Scoreboard,
+		Receipts:        o.Receipts,
+		SyncLimiter:     o.SyncLimiter,
+		PushLimiter:     o.PushLimiter,
+		SyncProgress:    o.SyncProgress,
+		SelfTest:        o.SelfTest,
+		Peering:         o.Peering,
+		TopologyEvents:  o.TopologyEvents,
+		Events:          o.Events,
+		Traffic:         o.Traffic,
+		ModeSwitcher:    o.ModeSwitcher,
+		Maintenance:     o.Maintenance,
+		KeyRotator:      o.KeyRotator,
+		Chaos:           o.Chaos,
+		ChunkTracer:     o.ChunkTracer,
+		RecentLogs:      o.RecentLogs,
+		Config:          o.Config,
+		ConfigReloader:  o.ConfigReloader,
+		Readiness:       o.Readiness,
+		Availability:    o.Availability,
+		ChunkPricer:     o.ChunkPricer,
+		ExchangeRater:   o.ExchangeRater,
 	}
 
 	// By default bee mode is set to full mode.
@@ -232,9 +308,15 @@ func newTestServer(t *testing.T, o testServerOptions) (*http.Client, *websocket.
 	testutil.CleanupCloser(t, tracerCloser)
 
 	s.Configure(signer, noOpTracer, api.Options{
-		CORSAllowedOrigins: o.CORSAllowedOrigins,
-		WsPingPeriod:       o.WsPingPeriod,
+		CORSAllowedOrigins:   o.CORSAllowedOrigins,
+		WsPingPeriod:         o.WsPingPeriod,
+		ValidateRequests:     o.ValidateRequests,
+		IdempotencyWindow:    o.IdempotencyWindow,
+		BzzAccessLogPath:     o.BzzAccessLogPath,
+		ReadOnly:             readonly.New(o.ReadOnly),
+		RequestSigningSecret: o.RequestSigning,
 	}, extraOpts, 1, erc20)
+	s.MustRegisterMetrics(s.Metrics()...)
 
 	s.Mount()
 	if !o.FullAPIDisabled {
@@ -701,6 +783,7 @@ func createRedistributionAgentService(
 		erc20Service,
 		tranService,
 		&mockHealth{},
+		nil,
 		log.Noop,
 	)
 }
@@ -0,0 +1,82 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/availability"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+// AvailabilityChecker checks whether the chunks of the content are held on
+// the network without downloading them.
+type AvailabilityChecker interface {
+	Check(context.Context, swarm.Address) (*availability.Report, error)
+}
+
+type AvailabilityBinResponse struct {
+	Bin         uint8 `json:"bin"`
+	Total       int   `json:"total"`
+	Available   int   `json:"available"`
+	Missing     int   `json:"missing"`
+	Unreachable int   `json:"unreachable"`
+}
+
+type AvailabilityResponse struct {
+	Reference   swarm.Address             `json:"reference"`
+	Resolvable  bool                      `json:"resolvable"`
+	Complete    bool                      `json:"complete"`
+	Total       int                       `json:"total"`
+	Available   int                       `json:"available"`
+	Missing     int                       `json:"missing"`
+	Unreachable int                       `json:"unreachable"`
+	Bins        []AvailabilityBinResponse `json:"bins"`
+}
+
+// availabilityHandler returns whether all the chunks of the content are
+// held on the network with the counts per proximity order bin.
+func (s *Service) availabilityHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_availability").Build()
+
+	paths := struct {
+		Reference swarm.Address `map:"reference,resolve" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	report, err := s.availability.Check(r.Context(), paths.Reference)
+	if err != nil {
+		logger.Debug("availability check failed", "reference", paths.Reference, "error", err)
+		if errors.Is(err, availability.ErrNotFound) {
+			jsonhttp.NotFound(w, "content not found")
+			return
+		}
+		logger.Error(nil, "availability check failed")
+		jsonhttp.InternalServerError(w, "availability check failed")
+		return
+	}
+
+	resp := AvailabilityResponse{
+		Reference:   paths.Reference,
+		Resolvable:  report.Resolvable(),
+		Complete:    report.Complete,
+		Total:       report.Total,
+		Available:   report.Available,
+		Missing:     report.Missing,
+		Unreachable: report.Unreachable,
+		Bins:        make([]AvailabilityBinResponse, 0, len(report.Bins)),
+	}
+	for _, b := range report.Bins {
+		resp.Bins = append(resp.Bins, AvailabilityBinResponse(b))
+	}
+	jsonhttp.OK(w, resp)
+}
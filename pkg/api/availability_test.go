@@ -0,0 +1,100 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/availability"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+type mockAvailabilityChecker struct {
+	report *availability.Report
+	err    error
+}
+
+func (m mockAvailabilityChecker) Check(context.Context, swarm.Address) (*availability.Report, error) {
+	return m.report, m.err
+}
+
+func TestAvailability(t *testing.T) {
+	t.Parallel()
+
+	ref := swarm.RandAddress(t)
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Availability: mockAvailabilityChecker{report: &availability.Report{
+			Total:       5,
+			Available:   3,
+			Missing:     1,
+			Unreachable: 1,
+			Complete:    true,
+			Bins: []availability.Bin{
+				{Bin: 0, Total: 3, Available: 2, Missing: 1},
+				{Bin: 2, Total: 2, Available: 1, Unreachable: 1},
+			},
+		}},
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/availability/"+ref.String(), http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.AvailabilityResponse{
+			Reference:   ref,
+			Resolvable:  false,
+			Complete:    true,
+			Total:       5,
+			Available:   3,
+			Missing:     1,
+			Unreachable: 1,
+			Bins: []api.AvailabilityBinResponse{
+				{Bin: 0, Total: 3, Available: 2, Missing: 1},
+				{Bin: 2, Total: 2, Available: 1, Unreachable: 1},
+			},
+		}),
+	)
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			Availability: mockAvailabilityChecker{err: availability.ErrNotFound},
+		})
+		jsonhttptest.Request(t, client, http.MethodGet, "/availability/"+ref.String(), http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusNotFound,
+				Message:   "content not found",
+				ErrorCode: "not_found",
+			}),
+		)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			Availability: mockAvailabilityChecker{err: errors.New("failure")},
+		})
+		jsonhttptest.Request(t, client, http.MethodGet, "/availability/"+ref.String(), http.StatusInternalServerError)
+	})
+
+	t.Run("invalid reference", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/availability/abc", http.StatusBadRequest)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodGet, "/availability/"+ref.String(), http.StatusNotFound)
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/backup"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+)
+
+// backupHandler streams a backup archive of the node state.
+func (s *Service) backupHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_backup").Build()
+
+	headers := struct {
+		Keys     bool   `map:"Swarm-Backup-Keys"`
+		Password string `map:"Swarm-Backup-Password"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	filename := fmt.Sprintf("bee-backup-%s.tar", time.Now().UTC().Format("20060102T150405Z"))
+	bw := &backupResponseWriter{w: w, filename: filename}
+
+	err := s.backup.Backup(r.Context(), bw, backup.Options{
+		IncludeKeys: headers.Keys,
+		Password:    headers.Password,
+	})
+	if err == nil {
+		return
+	}
+
+	logger.Debug("backup failed", "error", err)
+	logger.Error(nil, "backup failed")
+
+	if bw.started {
+		// The response is already being streamed, the client
+		// is notified about the failure by the truncated archive.
+		return
+	}
+	switch {
+	case errors.Is(err, backup.ErrInProgress):
+		jsonhttp.TooManyRequests(w, "backup in progress")
+	default:
+		jsonhttp.InternalServerError(w, "backup failed")
+	}
+}
+
+// backupResponseWriter sets the archive response
+// headers just before the first write.
+type backupResponseWriter struct {
+	w        http.ResponseWriter
+	filename string
+	started  bool
+}
+
+func (bw *backupResponseWriter) Write(p []byte) (int, error) {
+	if !bw.started {
+		bw.started = true
+		bw.w.Header().Set(ContentTypeHeader, contentTypeTar)
+		bw.w.Header().Set(ContentDispositionHeader, fmt.Sprintf("attachment; filename=%q", bw.filename))
+		bw.w.WriteHeader(http.StatusOK)
+	}
+	return bw.w.Write(p)
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/backup"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+)
+
+func TestBackup(t *testing.T) {
+	t.Parallel()
+
+	stateStore, err := leveldbstore.New("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = stateStore.Close() })
+	stamperStore, err := leveldbstore.New("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = stamperStore.Close() })
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Backup: backup.New(log.Noop, stateStore, stamperStore, "", nil),
+	})
+
+	var body []byte
+	jsonhttptest.Request(t, client, http.MethodPost, "/backup", http.StatusOK,
+		jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "application/x-tar"),
+		jsonhttptest.WithPutResponseBody(&body),
+	)
+
+	hdr, err := tar.NewReader(bytes.NewReader(body)).Next()
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+	if hdr.Name != "backup.json" {
+		t.Fatalf("first entry: want %q, have %q", "backup.json", hdr.Name)
+	}
+}
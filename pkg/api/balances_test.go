@@ -76,8 +76,9 @@ func TestBalancesError(t *testing.T) {
 
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/balances", http.StatusInternalServerError,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrCantBalances,
-			Code:    http.StatusInternalServerError,
+			Message:   api.ErrCantBalances,
+			Code:      http.StatusInternalServerError,
+			ErrorCode: "internal_server_error",
 		}),
 	)
 }
@@ -115,8 +116,9 @@ func TestBalancesPeersError(t *testing.T) {
 
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/balances/"+peer, http.StatusInternalServerError,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrCantBalance,
-			Code:    http.StatusInternalServerError,
+			Message:   api.ErrCantBalance,
+			Code:      http.StatusInternalServerError,
+			ErrorCode: "internal_server_error",
 		}),
 	)
 }
@@ -134,8 +136,9 @@ func TestBalancesPeersNoBalance(t *testing.T) {
 
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/balances/"+peer, http.StatusNotFound,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrNoBalance,
-			Code:    http.StatusNotFound,
+			Message:   api.ErrNoBalance,
+			Code:      http.StatusNotFound,
+			ErrorCode: "not_found",
 		}),
 	)
 }
@@ -225,8 +228,9 @@ func TestConsumedError(t *testing.T) {
 
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/consumed", http.StatusInternalServerError,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrCantBalances,
-			Code:    http.StatusInternalServerError,
+			Message:   api.ErrCantBalances,
+			Code:      http.StatusInternalServerError,
+			ErrorCode: "internal_server_error",
 		}),
 	)
 }
@@ -264,8 +268,9 @@ func TestConsumedPeersError(t *testing.T) {
 
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/consumed/"+peer, http.StatusInternalServerError,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrCantBalance,
-			Code:    http.StatusInternalServerError,
+			Message:   api.ErrCantBalance,
+			Code:      http.StatusInternalServerError,
+			ErrorCode: "internal_server_error",
 		}),
 	)
 }
@@ -283,8 +288,9 @@ func TestConsumedPeersNoBalance(t *testing.T) {
 
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/consumed/"+peer, http.StatusNotFound,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrNoBalance,
-			Code:    http.StatusNotFound,
+			Message:   api.ErrNoBalance,
+			Code:      http.StatusNotFound,
+			ErrorCode: "not_found",
 		}),
 	)
 }
@@ -310,6 +316,7 @@ func Test_peerBalanceHandler_invalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name: "peer - invalid hex character",
@@ -323,6 +330,7 @@ func Test_peerBalanceHandler_invalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -358,6 +366,7 @@ func Test_compensatedPeerBalanceHandler_invalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name: "peer - invalid hex character",
@@ -371,6 +380,7 @@ func Test_compensatedPeerBalanceHandler_invalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
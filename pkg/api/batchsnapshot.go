@@ -0,0 +1,39 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/postage/batchservice"
+)
+
+// BatchSnapshotter provides the snapshots of the batch store state.
+type BatchSnapshotter interface {
+	Snapshot() (*postage.BatchSnapshot, error)
+}
+
+// batchSnapshotHandler returns a snapshot of the batch store state which
+// can be used by other nodes to bootstrap their batch store.
+func (s *Service) batchSnapshotHandler(w http.ResponseWriter, _ *http.Request) {
+	logger := s.logger.WithName("get_batches_snapshot").Build()
+
+	snapshot, err := s.batchSnapshotter.Snapshot()
+	if err != nil {
+		if errors.Is(err, batchservice.ErrSnapshotUnavailable) {
+			jsonhttp.ServiceUnavailable(w, "batch snapshot unavailable, retry later")
+			return
+		}
+		logger.Debug("batch snapshot failed", "error", err)
+		logger.Error(nil, "batch snapshot failed")
+		jsonhttp.InternalServerError(w, "batch snapshot failed")
+		return
+	}
+
+	jsonhttp.OK(w, snapshot)
+}
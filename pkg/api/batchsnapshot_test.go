@@ -0,0 +1,67 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/postage/batchservice"
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+)
+
+type batchSnapshotterFunc func() (*postage.BatchSnapshot, error)
+
+func (f batchSnapshotterFunc) Snapshot() (*postage.BatchSnapshot, error) { return f() }
+
+var _ api.BatchSnapshotter = batchSnapshotterFunc(nil)
+
+func TestBatchSnapshot(t *testing.T) {
+	t.Parallel()
+
+	snapshot, err := postage.NewBatchSnapshot(postagetesting.NewChainState(), "", []*postage.Batch{postagetesting.MustNewBatch()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			BatchSnapshot: batchSnapshotterFunc(func() (*postage.BatchSnapshot, error) { return snapshot, nil }),
+		})
+
+		var got postage.BatchSnapshot
+		jsonhttptest.Request(t, client, http.MethodGet, "/batches/snapshot", http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&got),
+		)
+		if _, err := got.DecodeBatches(); err != nil {
+			t.Fatalf("decode batches: %v", err)
+		}
+		if got.Digest != snapshot.Digest {
+			t.Fatalf("digest: want %s, have %s", snapshot.Digest, got.Digest)
+		}
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			BatchSnapshot: batchSnapshotterFunc(func() (*postage.BatchSnapshot, error) { return nil, batchservice.ErrSnapshotUnavailable }),
+		})
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/batches/snapshot", http.StatusServiceUnavailable,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message:   "batch snapshot unavailable, retry later",
+				Code:      http.StatusServiceUnavailable,
+				ErrorCode: "service_unavailable",
+			}),
+		)
+	})
+}
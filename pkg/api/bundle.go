@@ -0,0 +1,249 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2"
+)
+
+const (
+	// defaultBundleCPUSeconds is the default duration of the CPU profile of the bundle.
+	defaultBundleCPUSeconds = 5
+
+	redacted = "<redacted>"
+)
+
+// secretConfigKeys are the substrings of the config keys with the redacted values.
+var secretConfigKeys = []string{"password", "secret", "token", "private", "key"}
+
+type bundleInfo struct {
+	Time            time.Time `json:"time"`
+	Version         string    `json:"version"`
+	GoVersion       string    `json:"goVersion"`
+	Overlay         string    `json:"overlay,omitempty"`
+	EthereumAddress string    `json:"ethereumAddress"`
+	Goroutines      int       `json:"goroutines"`
+	CPUs            int       `json:"cpus"`
+}
+
+// bundleFile is the file of the diagnostics bundle archive.
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+// debugBundleHandler returns the gzipped tar archive with the goroutine dump,
+// the heap and the CPU profiles, the recent logs, the topology snapshot and
+// the configuration with the secrets redacted.
+func (s *Service) debugBundleHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_debug_bundle").Build()
+
+	queries := struct {
+		Seconds *int `map:"seconds" validate:"omitempty,gte=0,lte=60"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+	seconds := defaultBundleCPUSeconds
+	if queries.Seconds != nil {
+		seconds = *queries.Seconds
+	}
+
+	now := time.Now().UTC()
+	var files []bundleFile
+	add := func(name string, data []byte) {
+		files = append(files, bundleFile{name: name, data: data})
+	}
+	addJSON := func(name string, v any) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			add(name+".error.txt", []byte(err.Error()))
+			return
+		}
+		add(name, data)
+	}
+	addProfile := func(name, profile string, debug int) {
+		buf := new(bytes.Buffer)
+		if p := pprof.Lookup(profile); p != nil {
+			if err := p.WriteTo(buf, debug); err != nil {
+				add(name+".error.txt", []byte(err.Error()))
+				return
+			}
+		}
+		add(name, buf.Bytes())
+	}
+
+	if seconds > 0 {
+		buf := new(bytes.Buffer)
+		if err := pprof.StartCPUProfile(buf); err != nil {
+			add("cpu.pprof.error.txt", []byte(err.Error()))
+		} else {
+			select {
+			case <-time.After(time.Duration(seconds) * time.Second):
+			case <-r.Context().Done():
+			}
+			pprof.StopCPUProfile()
+			add("cpu.pprof", buf.Bytes())
+		}
+		if r.Context().Err() != nil {
+			return
+		}
+	}
+
+	info := bundleInfo{
+		Time:            now,
+		Version:         bee.Version,
+		GoVersion:       runtime.Version(),
+		EthereumAddress: s.ethereumAddress.Hex(),
+		Goroutines:      runtime.NumGoroutine(),
+		CPUs:            runtime.NumCPU(),
+	}
+	if s.overlay != nil {
+		info.Overlay = s.overlay.String()
+	}
+	addJSON("info.json", info)
+	addProfile("goroutines.txt", "goroutine", 2)
+	addProfile("heap.pprof", "heap", 0)
+	addProfile("allocs.pprof", "allocs", 0)
+	addProfile("block.pprof", "block", 0)
+	addProfile("mutex.pprof", "mutex", 0)
+
+	if s.recentLogs != nil {
+		buf := new(bytes.Buffer)
+		if _, err := s.recentLogs.WriteTo(buf); err != nil {
+			add("logs.error.txt", []byte(err.Error()))
+		} else {
+			add("logs.txt", buf.Bytes())
+		}
+	}
+	if s.topologyDriver != nil {
+		snapshot := s.topologyDriver.Snapshot()
+		if s.lightNodes != nil {
+			snapshot.LightNodes = s.lightNodes.PeerInfo()
+		}
+		addJSON("topology.json", snapshot)
+	}
+	if s.config != nil {
+		addJSON("config.json", redactConfig(s.config))
+	}
+
+	name := fmt.Sprintf("bee-bundle-%s", now.Format("20060102T150405Z"))
+	w.Header().Set(ContentTypeHeader, "application/gzip")
+	w.Header().Set(ContentDispositionHeader, fmt.Sprintf("attachment; filename=%q", name+".tar.gz"))
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeBundle(w, name, now, files); err != nil {
+		logger.Debug("write bundle failed", "error", err)
+		logger.Error(nil, "write bundle failed")
+	}
+}
+
+// writeBundle writes the files to the gzipped tar archive in the directory.
+func writeBundle(w io.Writer, dir string, modTime time.Time, files []bundleFile) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	for _, f := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    dir + "/" + f.name,
+			Mode:    0o644,
+			Size:    int64(len(f.data)),
+			ModTime: modTime,
+		})
+		if err != nil {
+			return fmt.Errorf("write header %s: %w", f.name, err)
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return fmt.Errorf("write %s: %w", f.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("close tar: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("close gzip: %w", err)
+	}
+	return nil
+}
+
+// redactConfig returns the copy of the configuration with the values of
+// the secret keys redacted and the credentials, paths and queries of the
+// URLs, which often carry the API keys, removed.
+func redactConfig(config map[string]any) map[string]any {
+	out := make(map[string]any, len(config))
+	for k, v := range config {
+		if isSecretConfigKey(k) {
+			if v != nil && v != "" {
+				v = redacted
+			}
+			out[k] = v
+			continue
+		}
+		out[k] = redactConfigValue(v)
+	}
+	return out
+}
+
+func isSecretConfigKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range secretConfigKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactConfigValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return redactConfig(v)
+	case []string:
+		out := make([]string, len(v))
+		for i, s := range v {
+			out[i] = redactURL(s)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = redactConfigValue(e)
+		}
+		return out
+	case string:
+		return redactURL(v)
+	}
+	return v
+}
+
+// redactURL removes the credentials, the path and the query of the URL.
+func redactURL(s string) string {
+	// the resolver options are in the [tld:][contract-addr@]url format
+	if i := strings.LastIndex(s, "@"); i >= 0 && !strings.Contains(s[:i], "://") {
+		return s[:i+1] + redactURL(s[i+1:])
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return s
+	}
+	redactedURL := u.Scheme + "://" + u.Host
+	if strings.Trim(u.Path, "/") != "" || u.RawQuery != "" {
+		redactedURL += "/" + redacted
+	}
+	return redactedURL
+}
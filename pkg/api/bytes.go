@@ -35,14 +35,17 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 	defer span.Finish()
 
 	headers := struct {
-		BatchID        []byte           `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		BatchID        []byte           `map:"Swarm-Postage-Batch-Id" validate:"required_unless=Store cache-only"`
 		SwarmTag       uint64           `map:"Swarm-Tag"`
 		Pin            bool             `map:"Swarm-Pin"`
 		Deferred       *bool            `map:"Swarm-Deferred-Upload"`
+		Replication    uint8            `map:"Swarm-Replication-Factor" validate:"lte=8"`
 		Encrypt        bool             `map:"Swarm-Encrypt"`
 		RLevel         redundancy.Level `map:"Swarm-Redundancy-Level"`
 		Act            bool             `map:"Swarm-Act"`
 		HistoryAddress swarm.Address    `map:"Swarm-Act-History-Address"`
+		Store          string           `map:"Swarm-Store" validate:"omitempty,oneof=cache-only"`
+		CacheTTL       uint64           `map:"Swarm-Cache-Ttl"`
 	}{}
 	if response := s.mapStructure(r.Header, &headers); response != nil {
 		response("invalid header params", logger, w)
@@ -50,19 +53,20 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var (
-		tag      uint64
-		err      error
-		deferred = defaultUploadMethod(headers.Deferred)
+		tag       uint64
+		err       error
+		deferred  = defaultUploadMethod(headers.Deferred)
+		cacheOnly = headers.Store == storeCacheOnly
 	)
 
-	if deferred || headers.Pin {
+	if (deferred || headers.Pin) && !cacheOnly {
 		tag, err = s.getOrCreateSessionID(headers.SwarmTag)
 		if err != nil {
 			logger.Debug("get or create tag failed", "error", err)
 			logger.Error(nil, "get or create tag failed")
 			switch {
 			case errors.Is(err, storage.ErrNotFound):
-				jsonhttp.NotFound(w, "tag not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not found"))
 			default:
 				jsonhttp.InternalServerError(w, "cannot get or create tag")
 			}
@@ -72,26 +76,31 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 		span.SetTag("tagID", tag)
 	}
 
-	defer s.observeUploadSpeed(w, r, time.Now(), "bytes", deferred)
+	defer s.observeUploadSpeed(w, r, time.Now(), "bytes", deferred, headers.BatchID)
 
 	putter, err := s.newStamperPutter(ctx, putterOptions{
-		BatchID:  headers.BatchID,
-		TagID:    tag,
-		Pin:      headers.Pin,
-		Deferred: deferred,
+		BatchID:           headers.BatchID,
+		TagID:             tag,
+		Pin:               headers.Pin,
+		Deferred:          deferred,
+		ReplicationFactor: headers.Replication,
+		CacheOnly:         cacheOnly,
+		CacheTTL:          time.Duration(headers.CacheTTL) * time.Second,
 	})
 	if err != nil {
 		logger.Debug("get putter failed", "error", err)
 		logger.Error(nil, "get putter failed")
 		switch {
 		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
 		case errors.Is(err, postage.ErrNotFound):
-			jsonhttp.NotFound(w, "batch with id not found")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
 		case errors.Is(err, errInvalidPostageBatch):
-			jsonhttp.BadRequest(w, "invalid batch id")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
 		case errors.Is(err, errUnsupportedDevNodeOperation):
-			jsonhttp.BadRequest(w, errUnsupportedDevNodeOperation)
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
+		case errors.Is(err, errCacheOnlyPin):
+			jsonhttp.BadRequest(w, errCacheOnlyPin)
 		default:
 			jsonhttp.BadRequest(w, nil)
 		}
@@ -112,7 +121,7 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 		logger.Error(nil, "split write all failed")
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
-			jsonhttp.PaymentRequired(ow, "batch is overissued")
+			jsonhttp.PaymentRequired(ow, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
 		default:
 			jsonhttp.InternalServerError(ow, "split write all failed")
 		}
@@ -120,6 +129,13 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := checkExpectedReference(r, reference); err != nil {
+		logger.Debug("expected reference check failed", "reference", reference, "error", err)
+		logger.Error(nil, "expected reference check failed")
+		expectedReferenceResponse(ow, err)
+		return
+	}
+
 	encryptedReference := reference
 	historyReference := swarm.ZeroAddress
 	if headers.Act {
@@ -129,7 +145,7 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 			logger.Error(nil, "access control upload failed")
 			switch {
 			case errors.Is(err, accesscontrol.ErrNotFound):
-				jsonhttp.NotFound(w, "act or history entry not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeACTEntryNotFound, "act or history entry not found"))
 			case errors.Is(err, accesscontrol.ErrInvalidPublicKey) || errors.Is(err, accesscontrol.ErrSecretKeyInfinity):
 				jsonhttp.BadRequest(w, "invalid public key")
 			case errors.Is(err, accesscontrol.ErrUnexpectedType):
@@ -154,6 +170,7 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 	if tag != 0 {
 		w.Header().Set(SwarmTagHeader, fmt.Sprint(tag))
 	}
+	s.publishUpload(encryptedReference, tag)
 
 	span.LogFields(olog.Bool("success", true))
 
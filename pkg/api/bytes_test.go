@@ -8,9 +8,12 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/api"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
@@ -18,6 +21,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	mockbatchstore "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
 	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/spinlock"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"gitlab.com/nolash/go-mockbytes"
@@ -78,6 +82,37 @@ func TestBytes(t *testing.T) {
 		}
 	})
 
+	t.Run("upload with expected reference", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, resource, http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+			jsonhttptest.WithRequestTrailer(api.SwarmExpectedReferenceHeader, expHash),
+			jsonhttptest.WithExpectedJSONResponse(api.BytesPostResponse{
+				Reference: swarm.MustParseHexAddress(expHash),
+			}),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodPost, resource, http.StatusConflict,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(content[1:])),
+			jsonhttptest.WithRequestTrailer(api.SwarmExpectedReferenceHeader, expHash),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message:   "reference does not match the expected reference",
+				Code:      http.StatusConflict,
+				ErrorCode: "conflict",
+			}),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodPost, resource, http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+			jsonhttptest.WithRequestTrailer(api.SwarmExpectedReferenceHeader, "invalid"),
+		)
+	})
+
 	t.Run("upload-with-pinning", func(t *testing.T) {
 		var res api.BytesPostResponse
 		jsonhttptest.Request(t, client, http.MethodPost, resource, http.StatusCreated,
@@ -114,10 +149,36 @@ func TestBytes(t *testing.T) {
 			jsonhttptest.WithExpectedContentLength(len(content)),
 			jsonhttptest.WithExpectedResponse(content),
 			jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.ContentDispositionHeader),
+			jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.SwarmDownloadSessionHeader),
 			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "application/octet-stream"),
 		)
 	})
 
+	t.Run("download session", func(t *testing.T) {
+		header := jsonhttptest.Request(t, client, http.MethodGet, resource+"/"+expHash, http.StatusPartialContent,
+			jsonhttptest.WithRequestHeader(api.RangeHeader, "bytes=100-199"),
+			jsonhttptest.WithExpectedResponse(content[100:200]),
+		)
+		session := header.Get(api.SwarmDownloadSessionHeader)
+		if session == "" {
+			t.Fatal("download session header not set")
+		}
+
+		// the session of the completed download is not kept
+		jsonhttptest.Request(t, client, http.MethodGet, resource+"/"+expHash, http.StatusNotFound,
+			jsonhttptest.WithRequestHeader(api.SwarmDownloadSessionHeader, session),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message:   "download session not found",
+				Code:      http.StatusNotFound,
+				ErrorCode: "not_found",
+			}),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodGet, resource+"/"+expHash, http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDownloadSessionHeader, "invalid"),
+		)
+	})
+
 	t.Run("head", func(t *testing.T) {
 		jsonhttptest.Request(t, client, http.MethodHead, resource+"/"+expHash, http.StatusOK,
 			jsonhttptest.WithExpectedContentLength(len(content)),
@@ -133,8 +194,9 @@ func TestBytes(t *testing.T) {
 	t.Run("internal error", func(t *testing.T) {
 		jsonhttptest.Request(t, client, http.MethodGet, resource+"/abcd", http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "joiner failed",
-				Code:    http.StatusInternalServerError,
+				Message:   "joiner failed",
+				Code:      http.StatusInternalServerError,
+				ErrorCode: "internal_server_error",
 			}),
 		)
 	})
@@ -298,9 +360,30 @@ func TestBytesUploadHandlerInvalidInputs(t *testing.T) {
 				Reasons: []jsonhttp.Reason{
 					{
 						Field: "swarm-postage-batch-id",
-						Error: "want required:",
+						Error: "want required_unless:Store cache-only",
+					},
+				},
+				ErrorCode: "bad_request",
+			},
+		},
+		{
+			name:   "replication factor out of range",
+			hdrKey: api.SwarmReplicationFactorHeader,
+			hdrVal: "9",
+			want: jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid header params",
+				Reasons: []jsonhttp.Reason{
+					{
+						Field: "swarm-postage-batch-id",
+						Error: "want required_unless:Store cache-only",
+					},
+					{
+						Field: "swarm-replication-factor",
+						Error: "want lte:8",
 					},
 				},
+				ErrorCode: "bad_request",
 			},
 		},
 		{
@@ -308,8 +391,9 @@ func TestBytesUploadHandlerInvalidInputs(t *testing.T) {
 			hdrKey: api.SwarmPostageBatchIdHeader,
 			hdrVal: batchOkStr,
 			want: jsonhttp.StatusResponse{
-				Code:    http.StatusNotFound,
-				Message: "batch with id not found",
+				Code:      http.StatusNotFound,
+				Message:   "batch with id not found",
+				ErrorCode: api.ErrorCodeBatchNotFound,
 			},
 		},
 	}
@@ -347,6 +431,7 @@ func TestBytesGetHandlerInvalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "address - invalid hex character",
@@ -360,6 +445,7 @@ func TestBytesGetHandlerInvalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -403,8 +489,115 @@ func TestBytesDirectUpload(t *testing.T) {
 		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
 		jsonhttptest.WithRequestBody(bytes.NewReader(content)),
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrUnsupportedDevNodeOperation.Error(),
-			Code:    http.StatusBadRequest,
+			Message:   api.ErrUnsupportedDevNodeOperation.Error(),
+			Code:      http.StatusBadRequest,
+			ErrorCode: api.ErrorCodeUnsupportedInDevMode,
 		}),
 	)
 }
+
+// TestBytesDownloadSessionResume cuts the connection in the middle of the
+// response body and resumes the download from the offset of the session.
+func TestBytesDownloadSessionResume(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Logger: log.Noop,
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+	})
+
+	g := mockbytes.New(0, mockbytes.MockTypeStandard).WithModulus(255)
+	content, err := g.SequentialBytes(swarm.ChunkSize * 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var upload api.BytesPostResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+		jsonhttptest.WithUnmarshalJSONResponse(&upload),
+	)
+	resource := "/bytes/" + upload.Reference.String()
+
+	const read = 64 * 1024
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, resource, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(api.SwarmRedundancyStrategyHeader, "2")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := resp.Header.Get(api.SwarmDownloadSessionHeader)
+	if session == "" {
+		t.Fatal("download session header not set")
+	}
+	if _, err := io.ReadFull(resp.Body, make([]byte, read)); err != nil {
+		t.Fatal(err)
+	}
+	// closing the body before it is read cuts the connection
+	if err := resp.Body.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the session is stored once the handler returns, it is not resumed
+	// for other content
+	err = spinlock.Wait(5*time.Second, func() bool {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/bytes/"+swarm.RandAddress(t).String(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(api.SwarmDownloadSessionHeader, session)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode == http.StatusPreconditionFailed
+	})
+	if err != nil {
+		t.Fatal("download session not stored")
+	}
+
+	req, err = http.NewRequestWithContext(context.Background(), http.MethodGet, resource, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(api.SwarmDownloadSessionHeader, session)
+	resumed, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resumed.Body.Close()
+
+	if resumed.StatusCode != http.StatusPartialContent {
+		t.Fatalf("got status %d, want %d", resumed.StatusCode, http.StatusPartialContent)
+	}
+	if got := resumed.Header.Get(api.SwarmDownloadSessionHeader); got != session {
+		t.Fatalf("got download session %q, want %q", got, session)
+	}
+	var offset int
+	if _, err := fmt.Sscanf(resumed.Header.Get(api.ContentRangeHeader), "bytes %d-", &offset); err != nil {
+		t.Fatal(err)
+	}
+	if offset < read || offset >= len(content) {
+		t.Fatalf("got offset %d, want in range [%d, %d)", offset, read, len(content))
+	}
+	data, err := io.ReadAll(resumed.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, content[offset:]) {
+		t.Fatal("resumed content mismatch")
+	}
+
+	// the session of the completed download is removed
+	jsonhttptest.Request(t, client, http.MethodGet, resource, http.StatusNotFound,
+		jsonhttptest.WithRequestHeader(api.SwarmDownloadSessionHeader, session),
+	)
+}
@@ -9,6 +9,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"path"
 	"path/filepath"
@@ -31,6 +33,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/manifest"
+	"github.com/ethersphere/bee/v2/pkg/media"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storer"
@@ -66,15 +69,18 @@ func (s *Service) bzzUploadHandler(w http.ResponseWriter, r *http.Request) {
 
 	headers := struct {
 		ContentType    string           `map:"Content-Type,mimeMediaType" validate:"required"`
-		BatchID        []byte           `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		BatchID        []byte           `map:"Swarm-Postage-Batch-Id" validate:"required_unless=Store cache-only"`
 		SwarmTag       uint64           `map:"Swarm-Tag"`
 		Pin            bool             `map:"Swarm-Pin"`
 		Deferred       *bool            `map:"Swarm-Deferred-Upload"`
+		Replication    uint8            `map:"Swarm-Replication-Factor" validate:"lte=8"`
 		Encrypt        bool             `map:"Swarm-Encrypt"`
 		IsDir          bool             `map:"Swarm-Collection"`
 		RLevel         redundancy.Level `map:"Swarm-Redundancy-Level"`
 		Act            bool             `map:"Swarm-Act"`
 		HistoryAddress swarm.Address    `map:"Swarm-Act-History-Address"`
+		Store          string           `map:"Swarm-Store" validate:"omitempty,oneof=cache-only"`
+		CacheTTL       uint64           `map:"Swarm-Cache-Ttl"`
 	}{}
 	if response := s.mapStructure(r.Header, &headers); response != nil {
 		response("invalid header params", logger, w)
@@ -82,21 +88,22 @@ func (s *Service) bzzUploadHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var (
-		tag      uint64
-		err      error
-		deferred = defaultUploadMethod(headers.Deferred)
+		tag       uint64
+		err       error
+		deferred  = defaultUploadMethod(headers.Deferred)
+		cacheOnly = headers.Store == storeCacheOnly
 	)
 
-	defer s.observeUploadSpeed(w, r, time.Now(), "bzz", deferred)
+	defer s.observeUploadSpeed(w, r, time.Now(), "bzz", deferred, headers.BatchID)
 
-	if deferred || headers.Pin {
+	if (deferred || headers.Pin) && !cacheOnly {
 		tag, err = s.getOrCreateSessionID(headers.SwarmTag)
 		if err != nil {
 			logger.Debug("get or create tag failed", "error", err)
 			logger.Error(nil, "get or create tag failed")
 			switch {
 			case errors.Is(err, storage.ErrNotFound):
-				jsonhttp.NotFound(w, "tag not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not found"))
 			default:
 				jsonhttp.InternalServerError(w, "cannot get or create tag")
 			}
@@ -107,23 +114,28 @@ func (s *Service) bzzUploadHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	putter, err := s.newStamperPutter(ctx, putterOptions{
-		BatchID:  headers.BatchID,
-		TagID:    tag,
-		Pin:      headers.Pin,
-		Deferred: deferred,
+		BatchID:           headers.BatchID,
+		TagID:             tag,
+		Pin:               headers.Pin,
+		Deferred:          deferred,
+		ReplicationFactor: headers.Replication,
+		CacheOnly:         cacheOnly,
+		CacheTTL:          time.Duration(headers.CacheTTL) * time.Second,
 	})
 	if err != nil {
 		logger.Debug("putter failed", "error", err)
 		logger.Error(nil, "putter failed")
 		switch {
 		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
 		case errors.Is(err, postage.ErrNotFound):
-			jsonhttp.NotFound(w, "batch with id not found")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
 		case errors.Is(err, errInvalidPostageBatch):
-			jsonhttp.BadRequest(w, "invalid batch id")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
 		case errors.Is(err, errUnsupportedDevNodeOperation):
-			jsonhttp.BadRequest(w, errUnsupportedDevNodeOperation)
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
+		case errors.Is(err, errCacheOnlyPin):
+			jsonhttp.BadRequest(w, errCacheOnlyPin)
 		default:
 			jsonhttp.BadRequest(w, nil)
 		}
@@ -181,7 +193,7 @@ func (s *Service) fileUploadHandler(
 		logger.Error(nil, "file store failed", "file_name", queries.FileName)
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
-			jsonhttp.PaymentRequired(w, "batch is overissued")
+			jsonhttp.PaymentRequired(w, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
 		default:
 			jsonhttp.InternalServerError(w, errFileStore)
 		}
@@ -189,6 +201,13 @@ func (s *Service) fileUploadHandler(
 		return
 	}
 
+	if err := checkExpectedReference(r, fr); err != nil {
+		logger.Debug("expected reference check failed", "file_name", queries.FileName, "reference", fr, "error", err)
+		logger.Error(nil, "expected reference check failed", "file_name", queries.FileName)
+		expectedReferenceResponse(w, err)
+		return
+	}
+
 	// If filename is still empty, use the file hash as the filename
 	if queries.FileName == "" {
 		queries.FileName = fr.String()
@@ -260,7 +279,7 @@ func (s *Service) fileUploadHandler(
 		logger.Error(nil, "manifest store failed", "file_name", queries.FileName)
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
-			jsonhttp.PaymentRequired(w, "batch is overissued")
+			jsonhttp.PaymentRequired(w, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
 		default:
 			jsonhttp.InternalServerError(w, "manifest store failed")
 		}
@@ -277,7 +296,7 @@ func (s *Service) fileUploadHandler(
 			logger.Error(nil, "access control upload failed")
 			switch {
 			case errors.Is(err, accesscontrol.ErrNotFound):
-				jsonhttp.NotFound(w, "act or history entry not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeACTEntryNotFound, "act or history entry not found"))
 			case errors.Is(err, accesscontrol.ErrInvalidPublicKey) || errors.Is(err, accesscontrol.ErrSecretKeyInfinity):
 				jsonhttp.BadRequest(w, "invalid public key")
 			case errors.Is(err, accesscontrol.ErrUnexpectedType):
@@ -304,6 +323,7 @@ func (s *Service) fileUploadHandler(
 		w.Header().Set(SwarmTagHeader, fmt.Sprint(tagID))
 		span.SetTag("tagID", tagID)
 	}
+	s.publishUpload(reference, tagID)
 	w.Header().Set(ETagHeader, fmt.Sprintf("%q", reference.String()))
 	w.Header().Set(AccessControlExposeHeaders, SwarmTagHeader)
 	if act {
@@ -420,7 +440,7 @@ FETCH:
 	if !feedDereferenced {
 		if l, err := s.manifestFeed(ctx, m); err == nil {
 			// we have a feed manifest here
-			ch, cur, _, err := l.At(ctx, time.Now().Unix(), 0)
+			ch, cur, _, err := l.At(mutableCacheContext(ctx, s.FeedCacheTTL, ""), time.Now().Unix(), 0)
 			if err != nil {
 				logger.Debug("bzz download: feed lookup failed", "error", err)
 				logger.Error(nil, "bzz download: feed lookup failed")
@@ -430,7 +450,7 @@ FETCH:
 			if ch == nil {
 				logger.Debug("bzz download: feed lookup: no updates")
 				logger.Error(nil, "bzz download: feed lookup")
-				jsonhttp.NotFound(w, "no update found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeFeedUpdateNotFound, "no update found"))
 				return
 			}
 			wc, err := feeds.GetWrappedChunk(ctx, s.storer.Download(cache), ch, false)
@@ -543,6 +563,11 @@ FETCH:
 		return
 	}
 
+	// the upcoming segments of the stream are fetched while this one is served
+	if s.prefetcher != nil && !headerOnly && media.IsSegment(pathVar) {
+		s.prefetcher.Prefetch(ls, address, pathVar)
+	}
+
 	// serve requested path
 	s.serveManifestEntry(logger, w, r, me, !feedDereferenced, headerOnly)
 }
@@ -554,8 +579,57 @@ func (s *Service) serveManifestEntry(
 	manifestEntry manifest.Entry,
 	etag, headersOnly bool,
 ) {
+	queries := struct {
+		Start   *float64 `map:"start" validate:"omitempty,gte=0"`
+		Width   int      `map:"width" validate:"gte=0,lte=4096"`
+		Height  int      `map:"height" validate:"gte=0,lte=4096"`
+		Format  string   `map:"format" validate:"omitempty,oneof=jpeg png gif webp"`
+		Quality int      `map:"quality" validate:"gte=0,lte=100"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
 	additionalHeaders := http.Header{}
 	mtdt := manifestEntry.Metadata()
+	reference := manifestEntry.Reference()
+	if queries.Start != nil && r.Header.Get(RangeHeader) == "" &&
+		media.IsMP4(mtdt[manifest.EntryMetadataContentTypeKey], mtdt[manifest.EntryMetadataFilenameKey]) {
+		start := time.Duration(*queries.Start * float64(time.Second))
+		offset, err := s.mp4Offset(r.Context(), reference, start)
+		if err != nil {
+			// the whole file is served when its movie box cannot be read
+			logger.Debug("bzz download: mp4 start offset failed", "address", reference, "error", err)
+		} else {
+			r.Header.Set(RangeHeader, fmt.Sprintf("bytes=%d-", offset))
+		}
+	}
+	imageOptions := media.ImageOptions{Width: queries.Width, Height: queries.Height, Format: queries.Format, Quality: queries.Quality}
+	if s.images != nil && imageOptions != (media.ImageOptions{}) && media.IsImage(mtdt[manifest.EntryMetadataContentTypeKey]) {
+		derived, contentType, err := s.images.Transform(r.Context(), reference, imageOptions)
+		if err != nil {
+			logger.Debug("bzz download: image transform failed", "address", reference, "error", err)
+			logger.Error(nil, "bzz download: image transform failed")
+			switch {
+			case errors.Is(err, media.ErrUnsupportedImage):
+				jsonhttp.UnsupportedMediaType(w, "unsupported image")
+			case errors.Is(err, media.ErrImageTooLarge):
+				jsonhttp.UnprocessableEntity(w, "image too large")
+			case errors.Is(err, storage.ErrNotFound), errors.Is(err, topology.ErrNotFound):
+				jsonhttp.NotFound(w, nil)
+			default:
+				jsonhttp.InternalServerError(w, "image transform failed")
+			}
+			return
+		}
+		reference = derived
+		mtdt = maps.Clone(mtdt)
+		mtdt[manifest.EntryMetadataContentTypeKey] = contentType
+		if fname, ok := mtdt[manifest.EntryMetadataFilenameKey]; ok && imageOptions.Format != "" {
+			mtdt[manifest.EntryMetadataFilenameKey] = strings.TrimSuffix(fname, path.Ext(fname)) + "." + imageOptions.Format
+		}
+	}
 	if fname, ok := mtdt[manifest.EntryMetadataFilenameKey]; ok {
 		fname = filepath.Base(fname) // only keep the file name
 		additionalHeaders[ContentDispositionHeader] = []string{fmt.Sprintf("inline; filename=\"%s\"", escapeQuotes(fname))}
@@ -564,7 +638,16 @@ func (s *Service) serveManifestEntry(
 		additionalHeaders[ContentTypeHeader] = []string{mimeType}
 	}
 
-	s.downloadHandler(logger, w, r, manifestEntry.Reference(), additionalHeaders, etag, headersOnly, nil)
+	s.downloadHandler(logger, w, r, reference, additionalHeaders, etag, headersOnly, nil)
+}
+
+// mp4Offset returns the byte offset of the MP4 file at the start time.
+func (s *Service) mp4Offset(ctx context.Context, reference swarm.Address, start time.Duration) (int64, error) {
+	reader, l, err := joiner.New(ctx, s.storer.Download(true), s.storer.Cache(), reference, redundancy.DefaultLevel)
+	if err != nil {
+		return 0, err
+	}
+	return media.MP4Offset(reader, l, start)
 }
 
 // downloadHandler contains common logic for downloading Swarm file from API
@@ -576,12 +659,46 @@ func (s *Service) downloadHandler(logger log.Logger, w http.ResponseWriter, r *h
 		ChunkRetrievalTimeout *string           `map:"Swarm-Chunk-Retrieval-Timeout"`
 		LookaheadBufferSize   *int              `map:"Swarm-Lookahead-Buffer-Size"`
 		Cache                 *bool             `map:"Swarm-Cache"`
+		DownloadSession       string            `map:"Swarm-Download-Session"`
 	}{}
 
 	if response := s.mapStructure(r.Header, &headers); response != nil {
 		response("invalid header params", logger, w)
 		return
 	}
+	token := headers.DownloadSession
+	if token != "" {
+		session, ok, err := s.downloadSessions.get(token)
+		if err != nil {
+			logger.Debug("api download: invalid download session", "error", err)
+			jsonhttp.BadRequest(w, "invalid download session")
+			return
+		}
+		if !ok {
+			jsonhttp.NotFound(w, "download session not found")
+			return
+		}
+		if !session.Root.Equal(reference) {
+			jsonhttp.PreconditionFailed(w, "download session does not match the content")
+			return
+		}
+		// the retrieval configuration of the session is resumed unless overridden
+		if headers.Strategy == nil {
+			headers.Strategy = &session.Strategy
+		}
+		if headers.FallbackMode == nil {
+			fallback := !session.Strict
+			headers.FallbackMode = &fallback
+		}
+		if headers.RLevel == nil {
+			headers.RLevel = &session.RLevel
+		}
+		// the joiner is seeked to the offset of the first byte not written,
+		// its reads walk the chunk tree from the root chunk to the offset
+		if r.Header.Get(RangeHeader) == "" && session.Offset > 0 {
+			r.Header.Set(RangeHeader, fmt.Sprintf("bytes=%d-", session.Offset))
+		}
+	}
 	cache := true
 	if headers.Cache != nil {
 		cache = *headers.Cache
@@ -598,6 +715,15 @@ func (s *Service) downloadHandler(logger log.Logger, w http.ResponseWriter, r *h
 	if headers.RLevel != nil {
 		rLevel = *headers.RLevel
 	}
+	if cache {
+		// the prefetched chunks are only of use when they are cached
+		ctx = joiner.SetPrefetch(ctx, joiner.DefaultPrefetchWindow)
+	}
+
+	recoveryCallback, ok := s.recoveryCallback(w, r, logger)
+	if !ok {
+		return
+	}
 
 	var (
 		reader file.Joiner
@@ -610,11 +736,20 @@ func (s *Service) downloadHandler(logger log.Logger, w http.ResponseWriter, r *h
 	}
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, topology.ErrNotFound) {
+			if recoverContent(ctx, logger, recoveryCallback, reference) {
+				jsonhttp.Accepted(w, recoveryInitiated)
+				return
+			}
 			logger.Debug("api download: not found ", "address", reference, "error", err)
 			logger.Error(nil, err.Error())
 			jsonhttp.NotFound(w, nil)
 			return
 		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			logger.Debug("api download: timeout", "address", reference, "error", err)
+			jsonhttp.GatewayTimeout(w, "request timed out")
+			return
+		}
 		logger.Debug("api download: unexpected error", "address", reference, "error", err)
 		logger.Error(nil, "api download: unexpected error")
 		jsonhttp.InternalServerError(w, "joiner failed")
@@ -633,6 +768,16 @@ func (s *Service) downloadHandler(logger log.Logger, w http.ResponseWriter, r *h
 	w.Header().Set(ContentLengthHeader, strconv.FormatInt(l, 10))
 	w.Header().Add(AccessControlExposeHeaders, ContentDispositionHeader)
 
+	if token == "" {
+		if token, err = s.downloadSessions.token(); err != nil {
+			logger.Debug("api download: download session token failed", "error", err)
+		}
+	}
+	if token != "" {
+		w.Header().Set(SwarmDownloadSessionHeader, token)
+		w.Header().Add(AccessControlExposeHeaders, SwarmDownloadSessionHeader)
+	}
+
 	if headersOnly {
 		w.WriteHeader(http.StatusOK)
 		return
@@ -642,11 +787,41 @@ func (s *Service) downloadHandler(logger log.Logger, w http.ResponseWriter, r *h
 	if headers.LookaheadBufferSize != nil {
 		bufSize = *(headers.LookaheadBufferSize)
 	}
+	var content io.ReadSeeker = reader
 	if bufSize > 0 {
-		http.ServeContent(w, r, "", time.Now(), langos.NewBufferedLangos(reader, bufSize))
+		content = langos.NewBufferedLangos(reader, bufSize)
+	}
+	cw := &countingWriter{ResponseWriter: w}
+	http.ServeContent(cw, r, "", time.Now(), content)
+
+	if token == "" {
 		return
 	}
-	http.ServeContent(w, r, "", time.Now(), reader)
+	start, size, ok := cw.bodyRange(l)
+	if !ok {
+		return
+	}
+	if cw.written >= size {
+		s.downloadSessions.remove(token)
+		return
+	}
+	// the response body ended early, the session is kept to be resumed from
+	// the first byte which was not written
+	session := downloadSession{
+		Root:     reference,
+		Offset:   start + cw.written,
+		Strategy: getter.DefaultStrategy,
+		Strict:   getter.DefaultStrict,
+		RLevel:   rLevel,
+	}
+	if headers.Strategy != nil {
+		session.Strategy = *headers.Strategy
+	}
+	if headers.FallbackMode != nil {
+		session.Strict = !*headers.FallbackMode
+	}
+	s.downloadSessions.put(token, session)
+	logger.Debug("api download: interrupted", "address", reference, "offset", session.Offset)
 }
 
 // manifestMetadataLoad returns the value for a key stored in the metadata of
@@ -7,8 +7,11 @@ package api_test
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"image"
+	"image/png"
 	"io"
 	"mime"
 	"mime/multipart"
@@ -24,9 +27,11 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/manifest"
+	"github.com/ethersphere/bee/v2/pkg/media"
 	mockbatchstore "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
 	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
 	testingsoc "github.com/ethersphere/bee/v2/pkg/soc/testing"
+	statestore "github.com/ethersphere/bee/v2/pkg/statestore/mock"
 	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -849,6 +854,7 @@ func TestFeedIndirection(t *testing.T) {
 		jsonhttptest.WithExpectedContentLength(len(updateData)),
 		jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.SwarmFeedIndexHeader),
 		jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.ContentDispositionHeader),
+		jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.SwarmDownloadSessionHeader),
 		jsonhttptest.WithExpectedResponseHeader(api.ContentDispositionHeader, `inline; filename="index.html"`),
 		jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "text/html; charset=utf-8"),
 	)
@@ -875,6 +881,7 @@ func Test_bzzDownloadHandler_invalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "address - invalid hex character",
@@ -888,6 +895,7 @@ func Test_bzzDownloadHandler_invalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -1095,8 +1103,9 @@ func TestDirectUploadBzz(t *testing.T) {
 		jsonhttptest.WithRequestBody(tr),
 		jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrUnsupportedDevNodeOperation.Error(),
-			Code:    http.StatusBadRequest,
+			Message:   api.ErrUnsupportedDevNodeOperation.Error(),
+			Code:      http.StatusBadRequest,
+			ErrorCode: api.ErrorCodeUnsupportedInDevMode,
 		}),
 	)
 }
@@ -1146,7 +1155,143 @@ func TestBzzDownloadHeaders(t *testing.T) {
 		jsonhttptest.WithExpectedResponse(data),
 		jsonhttptest.WithExpectedContentLength(len(data)),
 		jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.ContentDispositionHeader),
+		jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.SwarmDownloadSessionHeader),
 		jsonhttptest.WithExpectedResponseHeader(api.ContentDispositionHeader, `inline; filename="index.html"`),
 		jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "text/html; charset=utf-8"),
 	)
 }
+
+// mp4Box returns the MP4 box of the type with the payload.
+func mp4Box(typ string, payload ...[]byte) []byte {
+	data := bytes.Join(payload, nil)
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(data)))
+	return append(append(b, typ...), data...)
+}
+
+func TestBzzMP4Start(t *testing.T) {
+	t.Parallel()
+
+	u32 := func(values ...uint32) []byte {
+		var b []byte
+		for _, v := range values {
+			b = binary.BigEndian.AppendUint32(b, v)
+		}
+		return b
+	}
+	// two one second samples of 16 bytes, each in its own chunk
+	ftyp := mp4Box("ftyp", []byte("isom"), make([]byte, 4))
+	mdat := mp4Box("mdat", bytes.Repeat([]byte("a"), 16), bytes.Repeat([]byte("b"), 16))
+	base := uint32(len(ftyp) + 8)
+	moov := mp4Box("moov", mp4Box("trak", mp4Box("mdia",
+		mp4Box("mdhd", u32(0, 0, 0, 1000, 2000, 0)),
+		mp4Box("hdlr", u32(0, 0), []byte("vide"), make([]byte, 12)),
+		mp4Box("minf", mp4Box("stbl",
+			mp4Box("stts", u32(0, 1, 2, 1000)),
+			mp4Box("stsc", u32(0, 1, 1, 1, 1)),
+			mp4Box("stsz", u32(0, 16, 2)),
+			mp4Box("stco", u32(0, 2, base, base+16)),
+		)),
+	)))
+	movie := bytes.Join([][]byte{ftyp, mdat, moov}, nil)
+	text := []byte("not a movie")
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Logger: log.Noop,
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+	})
+	var resp api.BzzUploadResponse
+	jsonhttptest.Request(t, testServer, http.MethodPost, "/bzz", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(tarFiles(t, []f{
+			{data: movie, name: "movie.mp4", header: http.Header{api.ContentTypeHeader: {"video/mp4"}}},
+			{data: text, name: "text.mp4", header: http.Header{api.ContentTypeHeader: {"video/mp4"}}},
+		})),
+		jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+		jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "true"),
+		jsonhttptest.WithUnmarshalJSONResponse(&resp),
+	)
+	endpoint := "/bzz/" + resp.Reference.String()
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, endpoint+"/movie.mp4?start=1.5", http.StatusPartialContent,
+		jsonhttptest.WithExpectedResponse(movie[base+16:]),
+		jsonhttptest.WithExpectedResponseHeader("Content-Range", fmt.Sprintf("bytes %d-%d/%d", base+16, len(movie)-1, len(movie))),
+	)
+	// the movie box which cannot be read leaves the file whole
+	jsonhttptest.Request(t, testServer, http.MethodGet, endpoint+"/text.mp4?start=1", http.StatusOK,
+		jsonhttptest.WithExpectedResponse(text),
+	)
+	jsonhttptest.Request(t, testServer, http.MethodGet, endpoint+"/movie.mp4?start=-1", http.StatusBadRequest,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:      http.StatusBadRequest,
+			Message:   "invalid query params",
+			Reasons:   []jsonhttp.Reason{{Field: "start", Error: "want gte:0"}},
+			ErrorCode: "bad_request",
+		}),
+	)
+}
+
+func TestBzzImageTransform(t *testing.T) {
+	t.Parallel()
+
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		t.Fatal(err)
+	}
+	photo := buf.Bytes()
+	text := []byte("not an image")
+
+	storer := mockstorer.New()
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: storer,
+		Logger: log.Noop,
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+		Images: media.NewImageTransformer(storer, statestore.NewStateStore(), log.Noop),
+	})
+	var resp api.BzzUploadResponse
+	jsonhttptest.Request(t, testServer, http.MethodPost, "/bzz", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(tarFiles(t, []f{
+			{data: photo, name: "photo.png", header: http.Header{api.ContentTypeHeader: {"image/png"}}},
+			{data: text, name: "text.txt", header: http.Header{api.ContentTypeHeader: {"text/plain"}}},
+			{data: text, name: "broken.png", header: http.Header{api.ContentTypeHeader: {"image/png"}}},
+		})),
+		jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+		jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "true"),
+		jsonhttptest.WithUnmarshalJSONResponse(&resp),
+	)
+	endpoint := "/bzz/" + resp.Reference.String()
+
+	header := jsonhttptest.Request(t, testServer, http.MethodGet, endpoint+"/photo.png?width=10&format=jpeg", http.StatusOK,
+		jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "image/jpeg"),
+		jsonhttptest.WithExpectedResponseHeader(api.ContentDispositionHeader, `inline; filename="photo.jpeg"`),
+	)
+	if header.Get(api.ETagHeader) == fmt.Sprintf("%q", resp.Reference) {
+		t.Fatal("derived image has the etag of the collection")
+	}
+	jsonhttptest.Request(t, testServer, http.MethodGet, endpoint+"/photo.png", http.StatusOK,
+		jsonhttptest.WithExpectedResponse(photo),
+	)
+	// the transform options of the content other than images are ignored
+	jsonhttptest.Request(t, testServer, http.MethodGet, endpoint+"/text.txt?width=10", http.StatusOK,
+		jsonhttptest.WithExpectedResponse(text),
+	)
+	jsonhttptest.Request(t, testServer, http.MethodGet, endpoint+"/broken.png?width=10", http.StatusUnsupportedMediaType,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:      http.StatusUnsupportedMediaType,
+			Message:   "unsupported image",
+			ErrorCode: "unsupported_media_type",
+		}),
+	)
+	jsonhttptest.Request(t, testServer, http.MethodGet, endpoint+"/photo.png?format=bmp", http.StatusBadRequest,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:      http.StatusBadRequest,
+			Message:   "invalid query params",
+			Reasons:   []jsonhttp.Reason{{Field: "format", Error: "want oneof:jpeg png gif webp"}},
+			ErrorCode: "bad_request",
+		}),
+	)
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// bzzAccessLogEntry is a single line of the bzz access log.
+type bzzAccessLogEntry struct {
+	Time      time.Time `json:"time"`
+	Method    string    `json:"method"`
+	Reference string    `json:"reference"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	Bytes     int64     `json:"bytes"`
+	LatencyMs int64     `json:"latencyMs"`
+	Referrer  string    `json:"referrer,omitempty"`
+	UserAgent string    `json:"userAgent,omitempty"`
+}
+
+// bzzAccessLog writes the accesses to the content served by the bzz
+// endpoint as JSON lines, so the gateway operators can aggregate them
+// per reference and path within the manifest.
+type bzzAccessLog struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	w   io.WriteCloser
+}
+
+func newBzzAccessLog(w io.WriteCloser) *bzzAccessLog {
+	return &bzzAccessLog{enc: json.NewEncoder(w), w: w}
+}
+
+func (l *bzzAccessLog) log(e bzzAccessLogEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.enc.Encode(e)
+}
+
+func (l *bzzAccessLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.w.Close()
+}
+
+// bzzAccessLogWriter records the status and the
+// number of bytes written to the response.
+type bzzAccessLogWriter struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+func (w *bzzAccessLogWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *bzzAccessLogWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *bzzAccessLogWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// bzzAccessLogMiddleware logs the accesses to the content served by the bzz
+// endpoint if the bzz access log is enabled.
+func (s *Service) bzzAccessLogMiddleware() func(h http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if s.bzzAccessLog == nil {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			var status func() int
+			var size func() int64
+			if rw, ok := w.(*responseWriter); ok {
+				// reuse the metrics wrapper so the
+				// following middlewares can find it
+				status = rw.Status
+				size = func() int64 { return int64(rw.size) }
+			} else {
+				lw := &bzzAccessLogWriter{ResponseWriter: w}
+				w = lw
+				status = func() int {
+					if lw.status == 0 {
+						return http.StatusOK
+					}
+					return lw.status
+				}
+				size = func() int64 { return lw.size }
+			}
+
+			h.ServeHTTP(w, r)
+
+			vars := mux.Vars(r)
+			err := s.bzzAccessLog.log(bzzAccessLogEntry{
+				Time:      start.UTC(),
+				Method:    r.Method,
+				Reference: vars["address"],
+				Path:      "/" + vars["path"],
+				Status:    status(),
+				Bytes:     size(),
+				LatencyMs: time.Since(start).Milliseconds(),
+				Referrer:  r.Referer(),
+				UserAgent: r.UserAgent(),
+			})
+			if err != nil {
+				s.logger.Debug("bzz access log: write failed", "error", err)
+			}
+		})
+	}
+}
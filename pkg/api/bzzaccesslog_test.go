@@ -0,0 +1,101 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestBzzAccessLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "bzz-access.log")
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer:           mockstorer.New(),
+		Post:             mockpost.New(mockpost.WithAcceptAll()),
+		BzzAccessLogPath: path,
+	})
+
+	var resp api.BzzUploadResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/bzz", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+		jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "true"),
+		jsonhttptest.WithRequestBody(tarFiles(t, []f{{
+			data: []byte("<h1>Swarm"),
+			name: "index.html",
+			dir:  "site",
+		}})),
+		jsonhttptest.WithUnmarshalJSONResponse(&resp),
+	)
+
+	reference := resp.Reference.String()
+	jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+reference+"/site/index.html", http.StatusOK,
+		jsonhttptest.WithRequestHeader("User-Agent", "test-agent"),
+		jsonhttptest.WithExpectedResponse([]byte("<h1>Swarm")),
+	)
+	jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+reference+"/missing.html", http.StatusNotFound)
+	jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+swarm.RandAddress(t).String()+"/", http.StatusNotFound)
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	type entry struct {
+		Method    string `json:"method"`
+		Reference string `json:"reference"`
+		Path      string `json:"path"`
+		Status    int    `json:"status"`
+		Bytes     int64  `json:"bytes"`
+		UserAgent string `json:"userAgent"`
+	}
+	var got []entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, e)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("got %d entries, want 3", len(got))
+	}
+	if want := (entry{
+		Method:    http.MethodGet,
+		Reference: reference,
+		Path:      "/site/index.html",
+		Status:    http.StatusOK,
+		Bytes:     int64(len("<h1>Swarm")),
+		UserAgent: "test-agent",
+	}); got[0] != want {
+		t.Errorf("got entry %+v, want %+v", got[0], want)
+	}
+	if got[1].Path != "/missing.html" || got[1].Status != http.StatusNotFound {
+		t.Errorf("got entry %+v, want not found missing.html", got[1])
+	}
+	if got[2].Status != http.StatusNotFound || strings.EqualFold(got[2].Reference, reference) {
+		t.Errorf("got entry %+v, want not found for another reference", got[2])
+	}
+}
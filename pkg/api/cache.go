@@ -0,0 +1,81 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/traversal"
+	"github.com/gorilla/mux"
+)
+
+type CacheRetainResponse struct {
+	Reference swarm.Address `json:"reference"`
+	Chunks    int           `json:"chunks"`
+	Retained  int           `json:"retained"`
+	Until     time.Time     `json:"until"`
+}
+
+// cacheRetainHandler protects the cached chunks of the locally stored
+// reference from the cache eviction for the ttl without pinning them.
+func (s *Service) cacheRetainHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_cache_retain").Build()
+
+	paths := struct {
+		Reference swarm.Address `map:"reference,resolve" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	queries := struct {
+		TTL uint64 `map:"ttl" validate:"required,gt=0"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+	ttl := time.Duration(queries.TTL) * time.Second
+
+	var addrs []swarm.Address
+	traverser := traversal.New(s.storer.ChunkStore(), s.storer.Cache(), redundancy.DefaultLevel)
+	err := traverser.Traverse(r.Context(), paths.Reference, func(addr swarm.Address) error {
+		addrs = append(addrs, addr)
+		return nil
+	})
+	if err != nil {
+		logger.Debug("cache retain: traversal failed", "reference", paths.Reference, "error", err)
+		if errors.Is(err, storage.ErrNotFound) {
+			jsonhttp.NotFound(w, "content not found locally")
+			return
+		}
+		logger.Error(nil, "cache retain: traversal failed")
+		jsonhttp.InternalServerError(w, "cache retain failed")
+		return
+	}
+
+	until := time.Now().Add(ttl)
+	retained, err := s.storer.Retain(r.Context(), ttl, addrs...)
+	if err != nil {
+		logger.Debug("cache retain failed", "reference", paths.Reference, "error", err)
+		logger.Error(nil, "cache retain failed")
+		jsonhttp.InternalServerError(w, "cache retain failed")
+		return
+	}
+
+	jsonhttp.OK(w, CacheRetainResponse{
+		Reference: paths.Reference,
+		Chunks:    len(addrs),
+		Retained:  retained,
+		Until:     until,
+	})
+}
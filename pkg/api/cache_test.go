@@ -0,0 +1,68 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestCacheRetain(t *testing.T) {
+	t.Parallel()
+
+	const rootHash = "838d0a193ecd1152d1bb1432d5ecc02398533b2494889e23b8bd5ace30ac2aeb"
+
+	storerMock := mockstorer.New()
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: storerMock,
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+	})
+
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(strings.NewReader("this is a simple text")),
+	)
+
+	var resp api.CacheRetainResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/cache/retain/"+rootHash+"?ttl=60", http.StatusOK,
+		jsonhttptest.WithUnmarshalJSONResponse(&resp),
+	)
+	if !resp.Reference.Equal(swarm.MustParseHexAddress(rootHash)) {
+		t.Fatalf("got reference %s, want %s", resp.Reference, rootHash)
+	}
+	if resp.Chunks != 1 || resp.Retained != 1 {
+		t.Fatalf("got %d chunks and %d retained, want 1 and 1", resp.Chunks, resp.Retained)
+	}
+	until, ok := storerMock.RetainedUntil(swarm.MustParseHexAddress(rootHash))
+	if !ok {
+		t.Fatal("chunk not retained")
+	}
+	if d := time.Until(until); d <= 0 || d > time.Minute {
+		t.Fatalf("got retention of %s, want up to a minute", d)
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/cache/retain/"+swarm.RandAddress(t).String()+"?ttl=60", http.StatusNotFound)
+	})
+
+	t.Run("invalid ttl", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/cache/retain/"+rootHash, http.StatusBadRequest)
+		jsonhttptest.Request(t, client, http.MethodPost, "/cache/retain/"+rootHash+"?ttl=0", http.StatusBadRequest)
+		jsonhttptest.Request(t, client, http.MethodPost, "/cache/retain/"+rootHash+"?ttl=abc", http.StatusBadRequest)
+	})
+}
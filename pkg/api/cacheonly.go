@@ -0,0 +1,75 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// storeCacheOnly is the Swarm-Store header value with which the uploaded
+// content is stored only in the local cache.
+const storeCacheOnly = "cache-only"
+
+var errCacheOnlyPin = errors.New("cache-only uploads can not be pinned")
+
+// cacheOnlyPutter stores the chunks in the local cache of the node without
+// stamping them and without pushing them to the network. The chunks are
+// evicted from the cache as any other cached chunks once the ttl elapses.
+type cacheOnlyPutter struct {
+	storage.Putter
+	storer storer.CacheStore
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	addrs []swarm.Address
+}
+
+var _ storer.PutterSession = (*cacheOnlyPutter)(nil)
+
+// newCacheOnlyPutter returns the putter session of the cache-only upload
+// whose chunks are retained in the cache for the ttl, if it is not zero.
+func (s *Service) newCacheOnlyPutter(ttl time.Duration) storer.PutterSession {
+	return &cacheOnlyPutter{
+		Putter: s.storer.Cache(),
+		storer: s.storer,
+		ttl:    ttl,
+	}
+}
+
+func (p *cacheOnlyPutter) Put(ctx context.Context, ch swarm.Chunk) error {
+	if err := p.Putter.Put(ctx, ch); err != nil {
+		return err
+	}
+	if p.ttl > 0 {
+		p.mu.Lock()
+		p.addrs = append(p.addrs, ch.Address())
+		p.mu.Unlock()
+	}
+	return nil
+}
+
+// Done retains the cached chunks for the ttl.
+func (p *cacheOnlyPutter) Done(swarm.Address) error {
+	if p.ttl <= 0 {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err := p.storer.Retain(context.Background(), p.ttl, p.addrs...)
+	return err
+}
+
+// Cleanup leaves the chunks in the cache to be evicted as usual.
+func (p *cacheOnlyPutter) Cleanup() error {
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+)
+
+func TestCacheOnlyUpload(t *testing.T) {
+	t.Parallel()
+
+	var (
+		storerMock      = mockstorer.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: storerMock,
+		})
+	)
+
+	t.Run("bytes", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.BytesPostResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmStoreHeader, "cache-only"),
+			jsonhttptest.WithRequestHeader(api.SwarmCacheTTLHeader, "3600"),
+			jsonhttptest.WithRequestBody(bytes.NewReader([]byte("scratch"))),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		has, err := storerMock.ChunkStore().Has(context.Background(), resp.Reference)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !has {
+			t.Fatal("want the chunk cached")
+		}
+		until, ok := storerMock.RetainedUntil(resp.Reference)
+		if !ok {
+			t.Fatal("want the chunk retained")
+		}
+		if d := time.Until(until); d <= 59*time.Minute || d > time.Hour {
+			t.Fatalf("got retention %s, want an hour", d)
+		}
+		sessions, err := storerMock.ListSessions(0, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sessions) != 0 {
+			t.Fatalf("got %d upload sessions, want none", len(sessions))
+		}
+	})
+
+	t.Run("bzz", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.BzzUploadResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/bzz?name=scratch.txt", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmStoreHeader, "cache-only"),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/plain"),
+			jsonhttptest.WithRequestBody(bytes.NewReader([]byte("scratch file"))),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+resp.Reference.String(), http.StatusOK,
+			jsonhttptest.WithExpectedResponse([]byte("scratch file")),
+		)
+		if _, ok := storerMock.RetainedUntil(resp.Reference); ok {
+			t.Fatal("want the chunk without the ttl not retained")
+		}
+	})
+
+	t.Run("pin", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmStoreHeader, "cache-only"),
+			jsonhttptest.WithRequestHeader(api.SwarmPinHeader, "true"),
+			jsonhttptest.WithRequestBody(bytes.NewReader([]byte("pinned scratch"))),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusBadRequest,
+				Message:   "cache-only uploads can not be pinned",
+				ErrorCode: "bad_request",
+			}),
+		)
+	})
+
+	t.Run("no batch", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusBadRequest,
+			jsonhttptest.WithRequestBody(bytes.NewReader([]byte("stored"))),
+		)
+	})
+}
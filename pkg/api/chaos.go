@@ -0,0 +1,90 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/chaos"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+)
+
+// ChaosInjector gets and sets the faults injected by the development node.
+type ChaosInjector interface {
+	Config() chaos.Config
+	SetConfig(chaos.Config) error
+}
+
+type chaosResponse struct {
+	RetrievalDropRate float64 `json:"retrievalDropRate"`
+	PushSyncDelay     string  `json:"pushSyncDelay"`
+}
+
+type chaosRequest struct {
+	RetrievalDropRate *float64 `json:"retrievalDropRate"`
+	PushSyncDelay     *string  `json:"pushSyncDelay"`
+}
+
+func newChaosResponse(c chaos.Config) chaosResponse {
+	return chaosResponse{
+		RetrievalDropRate: c.RetrievalDropRate,
+		PushSyncDelay:     c.PushSyncDelay.String(),
+	}
+}
+
+// chaosGetHandler returns the faults injected by the development node.
+func (s *Service) chaosGetHandler(w http.ResponseWriter, _ *http.Request) {
+	jsonhttp.OK(w, newChaosResponse(s.chaos.Config()))
+}
+
+// chaosPutHandler changes the faults injected by the development node,
+// the fields missing from the request keep their current values.
+func (s *Service) chaosPutHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_dev_chaos").Build()
+
+	var req chaosRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid chaos config")
+		return
+	}
+
+	config := s.chaos.Config()
+	if req.RetrievalDropRate != nil {
+		config.RetrievalDropRate = *req.RetrievalDropRate
+	}
+	if req.PushSyncDelay != nil {
+		d, err := time.ParseDuration(*req.PushSyncDelay)
+		if err != nil {
+			logger.Debug("invalid push sync delay", "error", err)
+			jsonhttp.BadRequest(w, jsonhttp.StatusResponse{
+				Message: "invalid body params",
+				Code:    http.StatusBadRequest,
+				Reasons: []jsonhttp.Reason{{
+					Field: "pushSyncDelay",
+					Error: err.Error(),
+				}},
+			})
+			return
+		}
+		config.PushSyncDelay = d
+	}
+
+	if err := s.chaos.SetConfig(config); err != nil {
+		logger.Debug("set chaos config failed", "error", err)
+		if errors.Is(err, chaos.ErrInvalidConfig) {
+			jsonhttp.BadRequest(w, "invalid chaos config")
+			return
+		}
+		jsonhttp.InternalServerError(w, "set chaos config failed")
+		return
+	}
+
+	logger.Info("chaos config changed", "retrieval_drop_rate", config.RetrievalDropRate, "push_sync_delay", config.PushSyncDelay)
+	jsonhttp.OK(w, newChaosResponse(config))
+}
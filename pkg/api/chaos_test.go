@@ -0,0 +1,61 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/chaos"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+)
+
+func TestChaos(t *testing.T) {
+	t.Parallel()
+
+	injector := chaos.New(1)
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Chaos: injector,
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/dev/chaos", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.ChaosResponse{PushSyncDelay: "0s"}),
+	)
+
+	rate := 12.5
+	delay := "250ms"
+	jsonhttptest.Request(t, client, http.MethodPut, "/dev/chaos", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(api.ChaosRequest{RetrievalDropRate: &rate, PushSyncDelay: &delay}),
+		jsonhttptest.WithExpectedJSONResponse(api.ChaosResponse{RetrievalDropRate: 12.5, PushSyncDelay: "250ms"}),
+	)
+
+	// the missing fields are kept
+	rate = 0
+	jsonhttptest.Request(t, client, http.MethodPut, "/dev/chaos", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(api.ChaosRequest{RetrievalDropRate: &rate}),
+		jsonhttptest.WithExpectedJSONResponse(api.ChaosResponse{PushSyncDelay: "250ms"}),
+	)
+	if got := injector.Config().PushSyncDelay.String(); got != "250ms" {
+		t.Fatalf("got push sync delay %s, want 250ms", got)
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, body := range []string{
+			`{"retrievalDropRate": 101}`,
+			`{"retrievalDropRate": -1}`,
+			`{"pushSyncDelay": "soon"}`,
+			`{"pushSyncDelay": "1h"}`,
+			`not json`,
+		} {
+			jsonhttptest.Request(t, client, http.MethodPut, "/dev/chaos", http.StatusBadRequest,
+				jsonhttptest.WithRequestBody(strings.NewReader(body)),
+			)
+		}
+	})
+}
@@ -248,6 +248,11 @@ func (s *Service) swapCashoutHandler(w http.ResponseWriter, r *http.Request) {
 		jsonhttp.MethodNotAllowed(w, err)
 		return
 	}
+	if errors.Is(err, swap.ErrCashoutPaused) {
+		logger.Debug("cash cheque failed", "peer_address", paths.Peer, "error", err)
+		jsonhttp.ServiceUnavailable(w, err)
+		return
+	}
 	if err != nil {
 		logger.Debug("cash cheque failed", "peer_address", paths.Peer, "error", err)
 		logger.Error(nil, "cash cheque failed", "peer_address", paths.Peer)
@@ -75,8 +75,9 @@ func TestChequebookBalanceError(t *testing.T) {
 
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/chequebook/balance", http.StatusInternalServerError,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrChequebookBalance,
-			Code:    http.StatusInternalServerError,
+			Message:   api.ErrChequebookBalance,
+			Code:      http.StatusInternalServerError,
+			ErrorCode: "internal_server_error",
 		}),
 	)
 }
@@ -101,8 +102,9 @@ func TestChequebookAvailableBalanceError(t *testing.T) {
 
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/chequebook/balance", http.StatusInternalServerError,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrChequebookBalance,
-			Code:    http.StatusInternalServerError,
+			Message:   api.ErrChequebookBalance,
+			Code:      http.StatusInternalServerError,
+			ErrorCode: "internal_server_error",
 		}),
 	)
 }
@@ -732,6 +734,7 @@ func Test_chequebookLastPeerHandler_invalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name: "peer - invalid hex character",
@@ -745,6 +748,7 @@ func Test_chequebookLastPeerHandler_invalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -6,6 +6,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -35,6 +36,7 @@ func (s *Service) chunkUploadHandler(w http.ResponseWriter, r *http.Request) {
 		BatchID        []byte        `map:"Swarm-Postage-Batch-Id"`
 		StampSig       []byte        `map:"Swarm-Postage-Stamp"`
 		SwarmTag       uint64        `map:"Swarm-Tag"`
+		Replication    uint8         `map:"Swarm-Replication-Factor" validate:"lte=8"`
 		Act            bool          `map:"Swarm-Act"`
 		HistoryAddress swarm.Address `map:"Swarm-Act-History-Address"`
 	}{}
@@ -54,7 +56,7 @@ func (s *Service) chunkUploadHandler(w http.ResponseWriter, r *http.Request) {
 			logger.Error(nil, "get or create tag failed")
 			switch {
 			case errors.Is(err, storage.ErrNotFound):
-				jsonhttp.NotFound(w, "tag not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not found"))
 			default:
 				jsonhttp.InternalServerError(w, "cannot get or create tag")
 			}
@@ -86,15 +88,17 @@ func (s *Service) chunkUploadHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		putter, err = s.newStampedPutter(r.Context(), putterOptions{
-			BatchID:  stamp.BatchID(),
-			TagID:    tag,
-			Deferred: deferred,
+			BatchID:           stamp.BatchID(),
+			TagID:             tag,
+			Deferred:          deferred,
+			ReplicationFactor: headers.Replication,
 		}, &stamp)
 	} else {
 		putter, err = s.newStamperPutter(r.Context(), putterOptions{
-			BatchID:  headers.BatchID,
-			TagID:    tag,
-			Deferred: deferred,
+			BatchID:           headers.BatchID,
+			TagID:             tag,
+			Deferred:          deferred,
+			ReplicationFactor: headers.Replication,
 		})
 	}
 	if err != nil {
@@ -103,13 +107,13 @@ func (s *Service) chunkUploadHandler(w http.ResponseWriter, r *http.Request) {
 		logger.Error(nil, errorMsg)
 		switch {
 		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
 		case errors.Is(err, postage.ErrNotFound):
-			jsonhttp.NotFound(w, "batch with id not found")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
 		case errors.Is(err, errInvalidPostageBatch):
-			jsonhttp.BadRequest(w, "invalid batch id")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
 		case errors.Is(err, errUnsupportedDevNodeOperation):
-			jsonhttp.BadRequest(w, errUnsupportedDevNodeOperation)
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
 		default:
 			jsonhttp.BadRequest(w, errorMsg)
 		}
@@ -175,7 +179,7 @@ func (s *Service) chunkUploadHandler(w http.ResponseWriter, r *http.Request) {
 		logger.Error(nil, "chunk upload: write chunk failed")
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
-			jsonhttp.PaymentRequired(ow, "batch is overissued")
+			jsonhttp.PaymentRequired(ow, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
 		case errors.Is(err, postage.ErrInvalidBatchSignature):
 			jsonhttp.BadRequest(ow, "stamp signature is invalid")
 		default:
@@ -193,7 +197,7 @@ func (s *Service) chunkUploadHandler(w http.ResponseWriter, r *http.Request) {
 			logger.Error(nil, "access control upload failed")
 			switch {
 			case errors.Is(err, accesscontrol.ErrNotFound):
-				jsonhttp.NotFound(w, "act or history entry not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeACTEntryNotFound, "act or history entry not found"))
 			case errors.Is(err, accesscontrol.ErrInvalidPublicKey) || errors.Is(err, accesscontrol.ErrSecretKeyInfinity):
 				jsonhttp.BadRequest(w, "invalid public key")
 			case errors.Is(err, accesscontrol.ErrUnexpectedType):
@@ -254,14 +258,37 @@ func (s *Service) chunkGetHandler(w http.ResponseWriter, r *http.Request) {
 		address = v
 	}
 
+	// the chunks are immutable under their addresses,
+	// so the cached copy of the client is always valid
+	etag := fmt.Sprintf("%q", address)
+	if r.Header.Get(IfNoneMatchHeader) == etag {
+		w.Header().Set(ETagHeader, etag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	recoveryCallback, ok := s.recoveryCallback(w, r, logger)
+	if !ok {
+		return
+	}
+
 	chunk, err := s.storer.Download(cache).Get(r.Context(), address)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
+			if recoverContent(r.Context(), logger, recoveryCallback, address) {
+				jsonhttp.Accepted(w, recoveryInitiated)
+				return
+			}
 			loggerV1.Debug("chunk not found", "address", address)
-			jsonhttp.NotFound(w, "chunk not found")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeChunkNotFound, "chunk not found"))
 			return
 
 		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			logger.Debug("read chunk timed out", "chunk_address", address, "error", err)
+			jsonhttp.GatewayTimeout(w, "request timed out")
+			return
+		}
 		logger.Debug("read chunk failed", "chunk_address", address, "error", err)
 		logger.Error(nil, "read chunk failed")
 		jsonhttp.InternalServerError(w, "read chunk failed")
@@ -269,5 +296,6 @@ func (s *Service) chunkGetHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	w.Header().Set(ContentTypeHeader, "binary/octet-stream")
 	w.Header().Set(ContentLengthHeader, strconv.FormatInt(int64(len(chunk.Data())), 10))
+	w.Header().Set(ETagHeader, etag)
 	_, _ = io.Copy(w, bytes.NewReader(chunk.Data()))
 }
@@ -47,7 +47,7 @@ func (s *Service) chunkUploadStreamHandler(w http.ResponseWriter, r *http.Reques
 			logger.Error(nil, "get or create tag failed")
 			switch {
 			case errors.Is(err, storage.ErrNotFound):
-				jsonhttp.NotFound(w, "tag not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not found"))
 			default:
 				jsonhttp.InternalServerError(w, "cannot get or create tag")
 			}
@@ -67,13 +67,13 @@ func (s *Service) chunkUploadStreamHandler(w http.ResponseWriter, r *http.Reques
 		logger.Error(nil, "get putter failed")
 		switch {
 		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
 		case errors.Is(err, postage.ErrNotFound):
-			jsonhttp.NotFound(w, "batch with id not found")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
 		case errors.Is(err, errInvalidPostageBatch):
-			jsonhttp.BadRequest(w, "invalid batch id")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
 		case errors.Is(err, errUnsupportedDevNodeOperation):
-			jsonhttp.BadRequest(w, errUnsupportedDevNodeOperation)
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
 		default:
 			jsonhttp.BadRequest(w, nil)
 		}
@@ -52,8 +52,9 @@ func TestChunkUploadDownload(t *testing.T) {
 		jsonhttptest.Request(t, client, http.MethodPost, chunksEndpoint, http.StatusBadRequest,
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "insufficient data length",
-				Code:    http.StatusBadRequest,
+				Message:   "insufficient data length",
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
@@ -124,12 +125,26 @@ func TestChunkHasHandler(t *testing.T) {
 		jsonhttptest.Request(t, testServer, http.MethodGet, "/chunks/"+key.String(), http.StatusOK,
 			jsonhttptest.WithExpectedResponse(value),
 			jsonhttptest.WithExpectedContentLength(len(value)),
+			jsonhttptest.WithExpectedResponseHeader(api.ETagHeader, `"`+key.String()+`"`),
+		)
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/chunks/"+key.String(), http.StatusNotModified,
+			jsonhttptest.WithRequestHeader(api.IfNoneMatchHeader, `"`+key.String()+`"`),
+			jsonhttptest.WithExpectedResponseHeader(api.ETagHeader, `"`+key.String()+`"`),
+			jsonhttptest.WithNoResponseBody(),
 		)
 	})
 
 	t.Run("not found", func(t *testing.T) {
 		jsonhttptest.Request(t, testServer, http.MethodHead, "/chunks/abbbbb", http.StatusNotFound,
 			jsonhttptest.WithNoResponseBody())
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/chunks/abbbbb", http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusNotFound,
+				Message:   "chunk not found",
+				ErrorCode: api.ErrorCodeChunkNotFound,
+			}),
+		)
 	})
 
 	t.Run("bad address", func(t *testing.T) {
@@ -159,6 +174,7 @@ func TestChunkHandlersInvalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "address invalid hex character",
@@ -172,6 +188,7 @@ func TestChunkHandlersInvalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -267,8 +284,9 @@ func TestChunkDirectUpload(t *testing.T) {
 		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
 		jsonhttptest.WithRequestBody(bytes.NewReader(chunk.Data())),
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrUnsupportedDevNodeOperation.Error(),
-			Code:    http.StatusBadRequest,
+			Message:   api.ErrUnsupportedDevNodeOperation.Error(),
+			Code:      http.StatusBadRequest,
+			ErrorCode: api.ErrorCodeUnsupportedInDevMode,
 		}),
 	)
 }
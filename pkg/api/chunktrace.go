@@ -0,0 +1,106 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/chunktrace"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+// ChunkTracer follows the chunks tagged for tracing through the subsystems.
+type ChunkTracer interface {
+	Tag(swarm.Address) error
+	Untag(swarm.Address) bool
+	Trace(swarm.Address) (chunktrace.Trace, bool)
+}
+
+type chunkTraceResponse struct {
+	Address swarm.Address      `json:"address"`
+	Tagged  time.Time          `json:"tagged"`
+	Events  []chunktrace.Event `json:"events"`
+	Dropped int                `json:"dropped"`
+}
+
+// chunkTraceGetHandler returns the events recorded for the traced chunk.
+func (s *Service) chunkTraceGetHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_chunk_trace").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	tr, ok := s.chunkTracer.Trace(paths.Address)
+	if !ok {
+		jsonhttp.NotFound(w, "chunk not traced")
+		return
+	}
+
+	events := tr.Events
+	if events == nil {
+		events = []chunktrace.Event{}
+	}
+	jsonhttp.OK(w, chunkTraceResponse{
+		Address: paths.Address,
+		Tagged:  tr.Tagged,
+		Events:  events,
+		Dropped: tr.Dropped,
+	})
+}
+
+// chunkTracePostHandler tags the chunk for tracing.
+func (s *Service) chunkTracePostHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_chunk_trace").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.chunkTracer.Tag(paths.Address); err != nil {
+		if errors.Is(err, chunktrace.ErrTooManyTagged) {
+			jsonhttp.TooManyRequests(w, "too many traced chunks")
+			return
+		}
+		logger.Debug("tag chunk failed", "chunk_address", paths.Address, "error", err)
+		logger.Error(nil, "tag chunk failed")
+		jsonhttp.InternalServerError(w, "tag chunk failed")
+		return
+	}
+
+	jsonhttp.Created(w, nil)
+}
+
+// chunkTraceDeleteHandler stops tracing the chunk and drops its events.
+func (s *Service) chunkTraceDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_chunk_trace").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if !s.chunkTracer.Untag(paths.Address) {
+		jsonhttp.NotFound(w, "chunk not traced")
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/chunktrace"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestChunkTrace(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tracer = chunktrace.New(log.Noop)
+		addr   = swarm.RandAddress(t)
+		peer   = swarm.RandAddress(t)
+		path   = "/debug/chunk-trace/" + addr.String()
+	)
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		ChunkTracer: tracer,
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, path, http.StatusNotFound,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message:   "chunk not traced",
+			Code:      http.StatusNotFound,
+			ErrorCode: "not_found",
+		}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodPost, path, http.StatusCreated)
+
+	tracer.Record(addr, chunktrace.SubsystemPushSync, "receive", "peer_address", peer)
+	tracer.Record(addr, chunktrace.SubsystemReserve, "put")
+
+	var resp api.ChunkTraceResponse
+	jsonhttptest.Request(t, client, http.MethodGet, path, http.StatusOK,
+		jsonhttptest.WithUnmarshalJSONResponse(&resp),
+	)
+	if !resp.Address.Equal(addr) {
+		t.Fatalf("got address %s, want %s", resp.Address, addr)
+	}
+	if len(resp.Events) != 2 {
+		t.Fatalf("got %d events, want 2", len(resp.Events))
+	}
+	if e := resp.Events[0]; e.Subsystem != chunktrace.SubsystemPushSync || e.Action != "receive" || e.Fields["peer_address"] != peer.String() {
+		t.Fatalf("got event %+v", e)
+	}
+	if e := resp.Events[1]; e.Subsystem != chunktrace.SubsystemReserve || e.Action != "put" {
+		t.Fatalf("got event %+v", e)
+	}
+
+	jsonhttptest.Request(t, client, http.MethodDelete, path, http.StatusOK)
+	jsonhttptest.Request(t, client, http.MethodDelete, path, http.StatusNotFound)
+	jsonhttptest.Request(t, client, http.MethodGet, path, http.StatusNotFound)
+
+	t.Run("invalid address", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/debug/chunk-trace/xyz", http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+)
+
+// ConfigReloader reloads the part of the node configuration
+// which can be changed without restarting the node.
+type ConfigReloader interface {
+	// ReloadConfig reads the configuration again, applies the changes
+	// and returns the names of the changed options.
+	ReloadConfig() ([]string, error)
+}
+
+// ConfigReloadResponse lists the options changed by the configuration reload.
+type ConfigReloadResponse struct {
+	Changed []string `json:"changed"`
+}
+
+// configReloadHandler reloads the runtime changeable configuration.
+func (s *Service) configReloadHandler(w http.ResponseWriter, _ *http.Request) {
+	logger := s.logger.WithName("post_config_reload").Build()
+
+	changed, err := s.configReloader.ReloadConfig()
+	if err != nil {
+		logger.Debug("reload config failed", "error", err)
+		logger.Error(nil, "reload config failed")
+		jsonhttp.BadRequest(w, err.Error())
+		return
+	}
+	if changed == nil {
+		changed = []string{}
+	}
+	jsonhttp.OK(w, ConfigReloadResponse{Changed: changed})
+}
+
+// SetCORSAllowedOrigins replaces the origins allowed
+// to access the API in addition to the API host.
+func (s *Service) SetCORSAllowedOrigins(origins []string) {
+	s.corsMu.Lock()
+	defer s.corsMu.Unlock()
+
+	s.CORSAllowedOrigins = origins
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+)
+
+type configReloaderMock func() ([]string, error)
+
+func (f configReloaderMock) ReloadConfig() ([]string, error) { return f() }
+
+func TestConfigReload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("changed", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			ConfigReloader: configReloaderMock(func() ([]string, error) {
+				return []string{"cors-allowed-origins", "verbosity"}, nil
+			}),
+		})
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/config/reload", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.ConfigReloadResponse{
+				Changed: []string{"cors-allowed-origins", "verbosity"},
+			}),
+		)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			ConfigReloader: configReloaderMock(func() ([]string, error) {
+				return nil, nil
+			}),
+		})
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/config/reload", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.ConfigReloadResponse{
+				Changed: []string{},
+			}),
+		)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			ConfigReloader: configReloaderMock(func() ([]string, error) {
+				return nil, errors.New("invalid payment threshold")
+			}),
+		})
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/config/reload", http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusBadRequest,
+				Message:   "invalid payment threshold",
+				ErrorCode: "bad_request",
+			}),
+		)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/config/reload", http.StatusNotFound)
+	})
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/ethersphere/bee/v2/pkg/file/joiner"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+type bytesDeltaResponse struct {
+	Reference swarm.Address `json:"reference"`
+	Chunks    int64         `json:"chunks"`
+	Uploaded  int64         `json:"uploaded"`
+	// ReusesEarlierStamps is set when the chunks of the earlier version are
+	// referenced, which expire with the batch of the earlier upload.
+	ReusesEarlierStamps bool `json:"reusesEarlierStamps"`
+}
+
+var errDeltaPin = errors.New("delta upload can not be pinned")
+
+// deltaPutter puts only the chunks which are not in the set of the chunk
+// addresses of the earlier version of the content.
+type deltaPutter struct {
+	storage.Putter
+	known    map[string]struct{}
+	chunks   atomic.Int64
+	uploaded atomic.Int64
+}
+
+func (p *deltaPutter) Put(ctx context.Context, ch swarm.Chunk) error {
+	p.chunks.Add(1)
+	if _, ok := p.known[ch.Address().ByteString()]; ok {
+		return nil
+	}
+	p.uploaded.Add(1)
+	return p.Putter.Put(ctx, ch)
+}
+
+// bytesDeltaUploadHandler uploads the new version of the content of the
+// reference. The chunk addresses of the earlier version are collected from
+// its intermediate chunks, the leaf chunks are not retrieved. The chunks of
+// the new version with the same address, the unchanged leaf chunks and the
+// subtrees of them, are neither stamped nor pushed again; they stay under
+// the stamps of the earlier upload, which the response tells about. The new
+// version can not be pinned, as the unchanged chunks are not at hand.
+//
+// The whole new version is read from the request body, no signatures or
+// rolling hashes are exchanged with the client; only the stamping and the
+// pushing of the unchanged chunks are saved, not the bandwidth between the
+// client and the node.
+func (s *Service) bytesDeltaUploadHandler(w http.ResponseWriter, r *http.Request) {
+	span, logger, ctx := s.tracer.StartSpanFromContext(r.Context(), "post_bytes_delta", s.logger.WithName("post_bytes_delta").Build())
+	defer span.Finish()
+
+	paths := struct {
+		Address swarm.Address `map:"address,resolve" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	headers := struct {
+		BatchID  []byte           `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		SwarmTag uint64           `map:"Swarm-Tag"`
+		Pin      bool             `map:"Swarm-Pin"`
+		Deferred *bool            `map:"Swarm-Deferred-Upload"`
+		RLevel   redundancy.Level `map:"Swarm-Redundancy-Level"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	if headers.Pin {
+		jsonhttp.BadRequest(w, errDeltaPin)
+		return
+	}
+	if len(paths.Address.Bytes()) != swarm.HashSize {
+		jsonhttp.BadRequest(w, "encrypted reference not supported")
+		return
+	}
+
+	known := make(map[string]struct{})
+	j, _, err := joiner.New(ctx, s.storer.Download(true), s.storer.Cache(), paths.Address, redundancy.DefaultLevel)
+	if err == nil {
+		err = j.IterateChunkAddresses(func(addr swarm.Address) error {
+			known[addr.ByteString()] = struct{}{}
+			return nil
+		})
+	}
+	if err != nil {
+		logger.Debug("collect chunk addresses failed", "reference", paths.Address, "error", err)
+		logger.Error(nil, "collect chunk addresses failed")
+		if errors.Is(err, storage.ErrNotFound) {
+			jsonhttp.NotFound(w, "content not found")
+			return
+		}
+		jsonhttp.InternalServerError(w, "collect chunk addresses failed")
+		return
+	}
+
+	var (
+		tag      uint64
+		deferred = defaultUploadMethod(headers.Deferred)
+	)
+	if deferred {
+		tag, err = s.getOrCreateSessionID(headers.SwarmTag)
+		if err != nil {
+			logger.Debug("get or create tag failed", "error", err)
+			logger.Error(nil, "get or create tag failed")
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not found"))
+			default:
+				jsonhttp.InternalServerError(w, "cannot get or create tag")
+			}
+			return
+		}
+	}
+
+	putter, err := s.newStamperPutter(ctx, putterOptions{
+		BatchID:  headers.BatchID,
+		TagID:    tag,
+		Deferred: deferred,
+	})
+	if err != nil {
+		logger.Debug("get putter failed", "error", err)
+		logger.Error(nil, "get putter failed")
+		switch {
+		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
+		case errors.Is(err, postage.ErrNotFound):
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
+		case errors.Is(err, errInvalidPostageBatch):
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
+		case errors.Is(err, errUnsupportedDevNodeOperation):
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
+		default:
+			jsonhttp.BadRequest(w, nil)
+		}
+		return
+	}
+
+	ow := &cleanupOnErrWriter{
+		ResponseWriter: w,
+		onErr:          putter.Cleanup,
+		logger:         logger,
+	}
+
+	dp := &deltaPutter{Putter: putter, known: known}
+	reference, err := requestPipelineFn(dp, false, headers.RLevel)(ctx, r.Body)
+	if err != nil {
+		logger.Debug("split write all failed", "error", err)
+		logger.Error(nil, "split write all failed")
+		switch {
+		case errors.Is(err, postage.ErrBucketFull):
+			jsonhttp.PaymentRequired(ow, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
+		default:
+			jsonhttp.InternalServerError(ow, "split write all failed")
+		}
+		return
+	}
+
+	if err := putter.Done(reference); err != nil {
+		logger.Debug("done split failed", "error", err)
+		logger.Error(nil, "done split failed")
+		jsonhttp.InternalServerError(ow, "done split failed")
+		return
+	}
+
+	if tag != 0 {
+		w.Header().Set(SwarmTagHeader, fmt.Sprint(tag))
+	}
+	s.publishUpload(reference, tag)
+
+	w.Header().Set(AccessControlExposeHeaders, SwarmTagHeader)
+	resp := bytesDeltaResponse{
+		Reference: reference,
+		Chunks:    dp.chunks.Load(),
+		Uploaded:  dp.uploaded.Load(),
+	}
+	resp.ReusesEarlierStamps = resp.Uploaded < resp.Chunks
+	jsonhttp.Created(w, resp)
+}
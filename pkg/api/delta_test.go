@@ -0,0 +1,120 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"gitlab.com/nolash/go-mockbytes"
+)
+
+func TestBytesDelta(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+	})
+
+	g := mockbytes.New(0, mockbytes.MockTypeStandard).WithModulus(255)
+	content, err := g.SequentialBytes(swarm.ChunkSize * 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var upload api.BytesPostResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+		jsonhttptest.WithUnmarshalJSONResponse(&upload),
+	)
+
+	// change the third leaf chunk only
+	changed := bytes.Clone(content)
+	changed[2*swarm.ChunkSize+10]++
+
+	t.Run("changed chunk", func(t *testing.T) {
+		t.Parallel()
+
+		var delta api.BytesDeltaResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes/"+upload.Reference.String()+"/delta", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(changed)),
+			jsonhttptest.WithUnmarshalJSONResponse(&delta),
+		)
+
+		// the four leaf chunks and the root chunk, of which the changed
+		// leaf chunk and the root chunk are uploaded
+		if delta.Chunks != 5 {
+			t.Fatalf("got %d chunks, want %d", delta.Chunks, 5)
+		}
+		if delta.Uploaded != 2 {
+			t.Fatalf("got %d uploaded chunks, want %d", delta.Uploaded, 2)
+		}
+		if !delta.ReusesEarlierStamps {
+			t.Fatal("expected the earlier stamps to be reused")
+		}
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/bytes/"+delta.Reference.String(), http.StatusOK,
+			jsonhttptest.WithExpectedResponse(changed),
+			jsonhttptest.WithExpectedContentLength(len(changed)),
+		)
+	})
+
+	t.Run("same content", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes/"+upload.Reference.String()+"/delta", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+			jsonhttptest.WithExpectedJSONResponse(api.BytesDeltaResponse{
+				Reference:           upload.Reference,
+				Chunks:              5,
+				Uploaded:            0,
+				ReusesEarlierStamps: true,
+			}),
+		)
+	})
+
+	t.Run("pin", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes/"+upload.Reference.String()+"/delta", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmPinHeader, "true"),
+			jsonhttptest.WithRequestBody(bytes.NewReader(changed)),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message:   "delta upload can not be pinned",
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
+			}),
+		)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes/"+swarm.RandAddress(t).String()+"/delta", http.StatusNotFound,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(changed)),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message:   "content not found",
+				Code:      http.StatusNotFound,
+				ErrorCode: "not_found",
+			}),
+		)
+	})
+}
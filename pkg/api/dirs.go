@@ -57,16 +57,8 @@ func (s *Service) dirUploadHandler(
 		return
 	}
 
-	// The error is ignored because the header was already validated by the caller.
-	mediaType, params, _ := mime.ParseMediaType(contentTypeString)
-
-	var dReader dirReader
-	switch mediaType {
-	case contentTypeTar:
-		dReader = &tarReader{r: tar.NewReader(r.Body), logger: s.logger}
-	case multiPartFormData:
-		dReader = &multipartReader{r: multipart.NewReader(r.Body, params["boundary"])}
-	default:
+	dReader, err := s.newDirReader(r, contentTypeString)
+	if err != nil {
 		logger.Error(nil, "invalid content-type for directory upload")
 		jsonhttp.BadRequest(w, errInvalidContentType)
 		return
@@ -89,7 +81,7 @@ func (s *Service) dirUploadHandler(
 		logger.Error(nil, "store dir failed")
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
-			jsonhttp.PaymentRequired(w, "batch is overissued")
+			jsonhttp.PaymentRequired(w, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
 		case errors.Is(err, errEmptyDir):
 			jsonhttp.BadRequest(w, errEmptyDir)
 		case errors.Is(err, tar.ErrHeader):
@@ -110,7 +102,7 @@ func (s *Service) dirUploadHandler(
 			logger.Error(nil, "access control upload failed")
 			switch {
 			case errors.Is(err, accesscontrol.ErrNotFound):
-				jsonhttp.NotFound(w, "act or history entry not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeACTEntryNotFound, "act or history entry not found"))
 			case errors.Is(err, accesscontrol.ErrInvalidPublicKey) || errors.Is(err, accesscontrol.ErrSecretKeyInfinity):
 				jsonhttp.BadRequest(w, "invalid public key")
 			case errors.Is(err, accesscontrol.ErrUnexpectedType):
@@ -135,6 +127,7 @@ func (s *Service) dirUploadHandler(
 		w.Header().Set(SwarmTagHeader, fmt.Sprint(tag))
 		span.LogFields(olog.Bool("success", true))
 	}
+	s.publishUpload(encryptedReference, tag)
 	w.Header().Set(AccessControlExposeHeaders, SwarmTagHeader)
 	if act {
 		w.Header().Set(SwarmActHistoryAddressHeader, historyReference.String())
@@ -145,6 +138,21 @@ func (s *Service) dirUploadHandler(
 	})
 }
 
+// newDirReader returns the directory reader of the request body
+// for the tar and multipart content types.
+func (s *Service) newDirReader(r *http.Request, contentTypeString string) (dirReader, error) {
+	// The error is ignored because the header was already validated by the caller.
+	mediaType, params, _ := mime.ParseMediaType(contentTypeString)
+
+	switch mediaType {
+	case contentTypeTar:
+		return &tarReader{r: tar.NewReader(r.Body), logger: s.logger}, nil
+	case multiPartFormData:
+		return &multipartReader{r: multipart.NewReader(r.Body, params["boundary"])}, nil
+	}
+	return nil, errInvalidContentType
+}
+
 // storeDir stores all files recursively contained in the directory given as a tar/multipart
 // it returns the hash for the uploaded manifest corresponding to the uploaded dir
 func storeDir(
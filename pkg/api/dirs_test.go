@@ -49,8 +49,9 @@ func TestDirs(t *testing.T) {
 			jsonhttptest.WithRequestBody(bytes.NewReader(nil)),
 			jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "True"),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: api.InvalidRequest.Error(),
-				Code:    http.StatusBadRequest,
+				Message:   api.InvalidRequest.Error(),
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
 			}),
 			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
 		)
@@ -66,8 +67,9 @@ func TestDirs(t *testing.T) {
 			jsonhttptest.WithRequestBody(file),
 			jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "True"),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: api.DirectoryStoreError.Error(),
-				Code:    http.StatusInternalServerError,
+				Message:   api.DirectoryStoreError.Error(),
+				Code:      http.StatusInternalServerError,
+				ErrorCode: "internal_server_error",
 			}),
 			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
 		)
@@ -86,8 +88,9 @@ func TestDirs(t *testing.T) {
 			jsonhttptest.WithRequestBody(tarReader),
 			jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "True"),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: api.InvalidContentType.Error(),
-				Code:    http.StatusBadRequest,
+				Message:   api.InvalidContentType.Error(),
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
 			}),
 			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "other"),
 		)
@@ -473,6 +476,7 @@ func TestDirs(t *testing.T) {
 						Error: "invalid syntax",
 					},
 				},
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
@@ -520,8 +524,9 @@ func TestDirsEmtpyDir(t *testing.T) {
 		jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "true"),
 		jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.EmptyDir.Error(),
-			Code:    http.StatusBadRequest,
+			Message:   api.EmptyDir.Error(),
+			Code:      http.StatusBadRequest,
+			ErrorCode: "bad_request",
 		}),
 	)
 }
@@ -0,0 +1,41 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+)
+
+// downloadModes are the values of the Swarm-Download-Mode header.
+var downloadModes = map[string]storer.DownloadMode{
+	"prefer-local": storer.DownloadPreferLocal,
+	"local-only":   storer.DownloadLocalOnly,
+	"network":      storer.DownloadNetwork,
+}
+
+// downloadModeHandler sets the source of the chunks of the download requests
+// from the Swarm-Download-Mode header. With local-only the content missing
+// locally is not retrieved from the network, so the request fails fast, and
+// with network the locally stored chunks are retrieved again.
+func (s *Service) downloadModeHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := r.Header.Get(SwarmDownloadModeHeader)
+		if v == "" || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		mode, ok := downloadModes[strings.ToLower(v)]
+		if !ok {
+			s.logger.Debug("invalid download mode header", "value", v)
+			jsonhttp.BadRequest(w, "invalid download mode")
+			return
+		}
+		h.ServeHTTP(w, r.WithContext(storer.SetDownloadMode(r.Context(), mode)))
+	})
+}
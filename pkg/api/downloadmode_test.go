@@ -0,0 +1,57 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	testingc "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+)
+
+func TestDownloadModeHeader(t *testing.T) {
+	t.Parallel()
+
+	var (
+		storerMock      = mockstorer.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: storerMock,
+		})
+		chunk    = testingc.GenerateTestRandomChunk()
+		resource = "/chunks/" + chunk.Address().String()
+	)
+	if err := storerMock.Cache().Put(context.Background(), chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range []string{"local-only", "prefer-local", "network", "Local-Only"} {
+		t.Run(v, func(t *testing.T) {
+			t.Parallel()
+
+			jsonhttptest.Request(t, client, http.MethodGet, resource, http.StatusOK,
+				jsonhttptest.WithRequestHeader(api.SwarmDownloadModeHeader, v),
+				jsonhttptest.WithExpectedResponse(chunk.Data()),
+			)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, resource, http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDownloadModeHeader, "anywhere"),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusBadRequest,
+				Message:   "invalid download mode",
+				ErrorCode: "bad_request",
+			}),
+		)
+	})
+}
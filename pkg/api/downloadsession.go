@@ -0,0 +1,153 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy/getter"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// downloadSessionsCapacity is the number of the interrupted downloads which
+// are kept to be resumed, the least recently used ones are evicted.
+const downloadSessionsCapacity = 1000
+
+var errInvalidDownloadSession = errors.New("invalid download session")
+
+// downloadSession is the state of an interrupted download. The session is
+// kept on the node under the token returned to the client with the
+// Swarm-Download-Session header. It is stored when the response body ends
+// before all the content is written, with the offset of the first byte which
+// was not written, so that the client can resume the download from there
+// with the same retrieval configuration. The session of a download which
+// completes is removed.
+//
+// Only the offset is kept, not the position in the chunk tree. The joiner
+// walks the tree from the root chunk on every read, so the resumed download
+// retrieves the root chunk and the intermediate chunks on the path to the
+// offset again, usually from the local cache, but none of the data chunks
+// before the offset.
+type downloadSession struct {
+	Root     swarm.Address
+	Offset   int64
+	Strategy getter.Strategy
+	Strict   bool
+	RLevel   redundancy.Level
+}
+
+// downloadSessions holds the interrupted download sessions by their tokens.
+type downloadSessions struct {
+	cache *lru.Cache[string, downloadSession]
+}
+
+func newDownloadSessions(capacity int) *downloadSessions {
+	cache, _ := lru.New[string, downloadSession](capacity)
+	return &downloadSessions{cache: cache}
+}
+
+// token returns a new random download session token.
+func (ds *downloadSessions) token() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// get returns the session of the token. The token must be a valid one,
+// the boolean result reports whether its session is stored.
+func (ds *downloadSessions) get(token string) (downloadSession, bool, error) {
+	if b, err := hex.DecodeString(token); err != nil || len(b) != 16 {
+		return downloadSession{}, false, errInvalidDownloadSession
+	}
+	session, ok := ds.cache.Get(token)
+	return session, ok, nil
+}
+
+// put stores the session of the interrupted download.
+func (ds *downloadSessions) put(token string, session downloadSession) {
+	ds.cache.Add(token, session)
+}
+
+// remove removes the session of the completed download.
+func (ds *downloadSessions) remove(token string) {
+	ds.cache.Remove(token)
+}
+
+// countingWriter counts the bytes of the response body which are written
+// to the underlying response writer without an error.
+type countingWriter struct {
+	http.ResponseWriter
+	status  int
+	written int64
+}
+
+func (w *countingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.written += int64(n)
+	return n, err
+}
+
+// Unwrap is used by the http.ResponseController.
+func (w *countingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// bodyRange returns the offset of the first byte of the content in the
+// response body and the length of the body for the written response of the
+// content of the given length. The boolean result is false when the body
+// is not a single part of the content, as is the case for errors and for
+// multipart responses.
+func (w *countingWriter) bodyRange(length int64) (int64, int64, bool) {
+	switch w.status {
+	case http.StatusOK:
+		return 0, length, true
+	case http.StatusPartialContent:
+		start, end, ok := contentRange(w.Header().Get(ContentRangeHeader))
+		return start, end - start + 1, ok
+	}
+	return 0, 0, false
+}
+
+// contentRange returns the first and the last byte offsets of the
+// Content-Range header value.
+func contentRange(value string) (int64, int64, bool) {
+	spec, ok := strings.CutPrefix(value, "bytes ")
+	if !ok {
+		return 0, 0, false
+	}
+	spec, _, _ = strings.Cut(spec, "/")
+	first, last, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	end, err := strconv.ParseInt(last, 10, 64)
+	if err != nil || start < 0 || end < start {
+		return 0, 0, false
+	}
+	return start, end, true
+}
@@ -47,7 +47,7 @@ func TestPostEnvelope(t *testing.T) {
 
 		jsonhttptest.Request(t, client, http.MethodPost, envelopeEndpoint(zeroHex), http.StatusNotFound,
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, zeroHex),
-			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{Message: "batch with id not found", Code: http.StatusNotFound}),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{Message: "batch with id not found", Code: http.StatusNotFound, ErrorCode: api.ErrorCodeBatchNotFound}),
 		)
 	})
 
@@ -60,7 +60,7 @@ func TestPostEnvelope(t *testing.T) {
 
 		jsonhttptest.Request(t, client, http.MethodPost, envelopeEndpoint(zeroHex), http.StatusUnprocessableEntity,
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
-			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{Message: "batch not usable yet or does not exist", Code: http.StatusUnprocessableEntity}),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{Message: "batch not usable yet or does not exist", Code: http.StatusUnprocessableEntity, ErrorCode: api.ErrorCodeBatchNotUsable}),
 		)
 	})
 }
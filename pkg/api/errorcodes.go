@@ -0,0 +1,35 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+// Machine-readable error codes set to the errorCode field of the error
+// responses, so that the clients can branch on them instead of matching the
+// messages. Error responses without a specific code have the code derived
+// from the HTTP status code, for example "bad_request" or "not_found".
+//
+// The internal errors are mapped to the codes as follows:
+//
+//	postage.ErrNotUsable, errBatchUnusable  batch_not_usable
+//	postage.ErrNotFound                     batch_not_found
+//	errInvalidPostageBatch                  invalid_batch_id
+//	postage.ErrBucketFull                   bucket_full
+//	storage.ErrNotFound (chunk)             chunk_not_found
+//	storage.ErrNotFound (tag)               tag_not_found
+//	feeds lookup without an update          feed_update_not_found
+//	accesscontrol.ErrNotFound               act_entry_not_found
+//	errUnsupportedDevNodeOperation          unsupported_in_dev_mode
+//	write requests in the read-only mode    read_only
+const (
+	ErrorCodeBatchNotUsable       = "batch_not_usable"
+	ErrorCodeBatchNotFound        = "batch_not_found"
+	ErrorCodeInvalidBatchID       = "invalid_batch_id"
+	ErrorCodeBucketFull           = "bucket_full"
+	ErrorCodeChunkNotFound        = "chunk_not_found"
+	ErrorCodeTagNotFound          = "tag_not_found"
+	ErrorCodeFeedUpdateNotFound   = "feed_update_not_found"
+	ErrorCodeACTEntryNotFound     = "act_entry_not_found"
+	ErrorCodeUnsupportedInDevMode = "unsupported_in_dev_mode"
+	ErrorCodeReadOnly             = "read_only"
+)
@@ -0,0 +1,146 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/events"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/websocket"
+)
+
+// publishUpload publishes the upload completion event.
+func (s *Service) publishUpload(reference swarm.Address, tag uint64) {
+	if s.events != nil {
+		s.events.Publish(events.TopicUpload, events.Upload{Reference: reference, Tag: tag})
+	}
+}
+
+// eventsWsHandler streams the node events of the topics given in
+// the comma separated topics query over the websocket.
+func (s *Service) eventsWsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("events").Build()
+
+	var topics []events.Topic
+	for _, v := range r.URL.Query()["topics"] {
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t == "" {
+				continue
+			}
+			if !events.IsTopic(events.Topic(t)) {
+				jsonhttp.BadRequest(w, jsonhttp.StatusResponse{
+					Message: "invalid query params",
+					Code:    http.StatusBadRequest,
+					Reasons: []jsonhttp.Reason{{
+						Field: "topics",
+						Error: fmt.Sprintf("unknown topic %q", t),
+					}},
+				})
+				return
+			}
+			topics = append(topics, events.Topic(t))
+		}
+	}
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  swarm.ChunkSize,
+		WriteBufferSize: swarm.ChunkSize,
+		CheckOrigin:     s.checkOrigin,
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Debug("upgrade failed", "error", err)
+		logger.Error(nil, "upgrade failed")
+		jsonhttp.InternalServerError(w, "upgrade failed")
+		return
+	}
+
+	s.wsWg.Add(1)
+	go s.eventsWs(conn, topics)
+}
+
+func (s *Service) eventsWs(conn *websocket.Conn, topics []events.Topic) {
+	defer s.wsWg.Done()
+
+	var (
+		gone   = make(chan struct{})
+		ticker = time.NewTicker(s.WsPingPeriod)
+		err    error
+	)
+	defer func() {
+		ticker.Stop()
+		_ = conn.Close()
+	}()
+
+	c, unsubscribe := s.events.Subscribe(topics...)
+	defer unsubscribe()
+
+	conn.SetCloseHandler(func(code int, text string) error {
+		s.logger.Debug("events ws: client gone", "code", code, "message", text)
+		close(gone)
+		return nil
+	})
+
+	// the reads are needed to process the close messages of the client
+	go func() {
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case e, ok := <-c:
+			if !ok {
+				return
+			}
+			err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("events ws: set write deadline failed", "error", err)
+				return
+			}
+
+			err = conn.WriteJSON(e)
+			if err != nil {
+				s.logger.Debug("events ws: write message failed", "error", err)
+				return
+			}
+
+		case <-s.quit:
+			// shutdown
+			err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("events ws: set write deadline failed", "error", err)
+				return
+			}
+			err = conn.WriteMessage(websocket.CloseMessage, []byte{})
+			if err != nil {
+				s.logger.Debug("events ws: write close message failed", "error", err)
+			}
+			return
+		case <-gone:
+			// client gone
+			return
+		case <-ticker.C:
+			err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("events ws: set write deadline failed", "error", err)
+				return
+			}
+			if err = conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				// error encountered while pinging client. client probably gone
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/events"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+	"github.com/gorilla/websocket"
+)
+
+// subscribedBus signals when the events are subscribed to.
+type subscribedBus struct {
+	*events.Bus
+	subscribed chan []events.Topic
+}
+
+func (b *subscribedBus) Subscribe(topics ...events.Topic) (<-chan events.Event, func()) {
+	c, unsubscribe := b.Bus.Subscribe(topics...)
+	b.subscribed <- topics
+	return c, unsubscribe
+}
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	bus := &subscribedBus{Bus: events.New(), subscribed: make(chan []events.Topic, 1)}
+	client, _, addr, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+		Events: bus,
+	})
+
+	u := url.URL{Scheme: "ws", Host: addr, Path: "/events", RawQuery: "topics=depth,upload"}
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	testutil.CleanupCloser(t, conn)
+
+	select {
+	case topics := <-bus.subscribed:
+		if len(topics) != 2 || topics[0] != events.TopicDepth || topics[1] != events.TopicUpload {
+			t.Fatalf("want topics [depth upload], have %v", topics)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("events not subscribed")
+	}
+
+	bus.Publish(events.TopicCashout, events.Cashout{Peer: swarm.RandAddress(t)})
+	bus.Publish(events.TopicDepth, events.Depth{Depth: 5})
+
+	var upload api.BytesPostResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(bytes.NewReader([]byte("events"))),
+		jsonhttptest.WithUnmarshalJSONResponse(&upload),
+	)
+
+	type event struct {
+		Topic events.Topic    `json:"topic"`
+		Time  time.Time       `json:"time"`
+		Data  json.RawMessage `json:"data"`
+	}
+	read := func(t *testing.T, topic events.Topic, data any) {
+		t.Helper()
+		if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+			t.Fatal(err)
+		}
+		var e event
+		if err := conn.ReadJSON(&e); err != nil {
+			t.Fatal(err)
+		}
+		if e.Topic != topic {
+			t.Fatalf("want topic %q, have %q", topic, e.Topic)
+		}
+		if e.Time.IsZero() {
+			t.Fatal("want event time")
+		}
+		if err := json.Unmarshal(e.Data, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var depth events.Depth
+	read(t, events.TopicDepth, &depth)
+	if depth.Depth != 5 {
+		t.Fatalf("want depth 5, have %d", depth.Depth)
+	}
+
+	var uploaded events.Upload
+	read(t, events.TopicUpload, &uploaded)
+	if !uploaded.Reference.Equal(upload.Reference) {
+		t.Fatalf("want reference %s, have %s", upload.Reference, uploaded.Reference)
+	}
+	if uploaded.Tag == 0 {
+		t.Fatal("want upload tag")
+	}
+
+	t.Run("invalid topic", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/events?topics=depth,unknown", http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid query params",
+				Reasons: []jsonhttp.Reason{{
+					Field: "topics",
+					Error: `unknown topic "unknown"`,
+				}},
+				ErrorCode: "bad_request",
+			}),
+		)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodGet, "/events", http.StatusNotFound)
+	})
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+var (
+	errInvalidExpectedReference = errors.New("invalid expected reference")
+	errReferenceMismatch        = errors.New("reference does not match the expected reference")
+)
+
+// checkExpectedReference verifies the reference computed from the request
+// body against the one the client expects, which is sent in the trailer of
+// the body, or in the header if known upfront. The trailer is only available
+// once the body is read entirely.
+func checkExpectedReference(r *http.Request, reference swarm.Address) error {
+	value := r.Trailer.Get(SwarmExpectedReferenceHeader)
+	if value == "" {
+		value = r.Header.Get(SwarmExpectedReferenceHeader)
+	}
+	if value == "" {
+		return nil
+	}
+	expected, err := swarm.ParseHexAddress(value)
+	if err != nil {
+		return errInvalidExpectedReference
+	}
+	if !expected.Equal(reference) {
+		return errReferenceMismatch
+	}
+	return nil
+}
+
+// expectedReferenceResponse writes the response for the failed check of the
+// expected reference.
+func expectedReferenceResponse(w http.ResponseWriter, err error) {
+	if errors.Is(err, errReferenceMismatch) {
+		jsonhttp.Conflict(w, errReferenceMismatch.Error())
+		return
+	}
+	jsonhttp.BadRequest(w, errInvalidExpectedReference.Error())
+}
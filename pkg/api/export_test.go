@@ -5,21 +5,64 @@
 package api
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
 type (
-	BytesPostResponse     = bytesPostResponse
-	ChunkAddressResponse  = chunkAddressResponse
-	SocPostResponse       = socPostResponse
-	FeedReferenceResponse = feedReferenceResponse
-	BzzUploadResponse     = bzzUploadResponse
-	TagRequest            = tagRequest
-	ListTagsResponse      = listTagsResponse
-	IsRetrievableResponse = isRetrievableResponse
+	BytesPostResponse        = bytesPostResponse
+	BytesDeltaResponse       = bytesDeltaResponse
+	ChunkAddressResponse     = chunkAddressResponse
+	SocPostResponse          = socPostResponse
+	SocBatchEntry            = socBatchEntry
+	SocBatchRequest          = socBatchRequest
+	SocBatchResponse         = socBatchResponse
+	FeedReferenceResponse    = feedReferenceResponse
+	BzzUploadResponse        = bzzUploadResponse
+	TagRequest               = tagRequest
+	ListTagsResponse         = listTagsResponse
+	IsRetrievableResponse    = isRetrievableResponse
+	ReserveImportResponse    = reserveImportResponse
+	ScoreboardResponse       = scoreboardResponse
+	ReceiptResponse          = receiptResponse
+	LoggerVerbosityRequest   = loggerVerbosityRequest
+	ReceiptChallengeResponse = receiptChallengeResponse
+	SyncLimitsResponse       = syncLimitsResponse
+	MaintenanceResponse      = maintenanceResponse
+	MaintenanceRequest       = maintenanceRequest
+	ChaosResponse            = chaosResponse
+	ChaosRequest             = chaosRequest
+	ChunkTraceResponse       = chunkTraceResponse
+	PushLimitsResponse       = pushLimitsResponse
+	SyncProgressResponse     = syncProgressResponse
+	BinSyncStatusResponse    = binSyncStatusResponse
+	SelfTestResponse         = selfTestResponse
+	SelfTestCheckResponse    = selfTestCheckResponse
+	PeeringResponse          = peeringResponse
+	TopologyEventResponse    = topologyEventResponse
+	TrafficResponse          = trafficResponse
+	TrafficStatsResponse     = trafficStatsResponse
+	MountResponse            = mountResponse
+	MountsResponse           = mountsResponse
+	ChunksExistRequest       = chunksExistRequest
+	ChunksExistResponse      = chunksExistResponse
+	SnapshotEntry            = snapshotEntry
+	SnapshotRequest          = snapshotRequest
+	SnapshotResponse         = snapshotResponse
+	SnapshotFileResponse     = snapshotFileResponse
+	SnapshotEntriesResponse  = snapshotEntriesResponse
+
+	StateStoreKey             = stateStoreKey
+	StateStoreKeysResponse    = stateStoreKeysResponse
+	StateStoreDump            = stateStoreDump
+	StateStoreRestoreResponse = stateStoreRestoreResponse
 )
 
+var WriteReserveFrame = writeReserveFrame
+
 var (
 	InvalidContentType  = errInvalidContentType
 	InvalidRequest      = errInvalidRequest
@@ -53,6 +96,8 @@ var (
 	ToFileSizeBucket      = toFileSizeBucket
 )
 
+var NetworkMedians = networkMedians
+
 func (s *Service) ResolveNameOrAddress(str string) (swarm.Address, error) {
 	return s.resolveNameOrAddress(str)
 }
@@ -100,6 +145,7 @@ type (
 	GetWithdrawableResponse           = getWithdrawableResponse
 	StakeTransactionReponse           = stakeTransactionReponse
 	StatusSnapshotResponse            = statusSnapshotResponse
+	StatusNetworkResponse             = statusNetworkResponse
 	StatusResponse                    = statusResponse
 )
 
@@ -119,17 +165,20 @@ var (
 )
 
 type (
-	LogRegistryIterateFn   func(fn func(string, string, log.Level, uint) bool)
-	LogSetVerbosityByExpFn func(e string, v log.Level) error
+	LogRegistryIterateFn      func(fn func(string, string, log.Level, uint) bool)
+	LogSetVerbosityByExpFn    func(e string, v log.Level) error
+	LogSetVerbosityByExpForFn func(e string, v log.Level, d time.Duration) error
 )
 
 var (
-	LogRegistryIterate   = logRegistryIterate
-	LogSetVerbosityByExp = logSetVerbosityByExp
+	LogRegistryIterate      = logRegistryIterate
+	LogSetVerbosityByExp    = logSetVerbosityByExp
+	LogSetVerbosityByExpFor = logSetVerbosityByExpFor
 )
 
-func ReplaceLogRegistryIterateFn(fn LogRegistryIterateFn)   { logRegistryIterate = fn }
-func ReplaceLogSetVerbosityByExp(fn LogSetVerbosityByExpFn) { logSetVerbosityByExp = fn }
+func ReplaceLogRegistryIterateFn(fn LogRegistryIterateFn)         { logRegistryIterate = fn }
+func ReplaceLogSetVerbosityByExp(fn LogSetVerbosityByExpFn)       { logSetVerbosityByExp = fn }
+func ReplaceLogSetVerbosityByExpFor(fn LogSetVerbosityByExpForFn) { logSetVerbosityByExpFor = fn }
 
 var ErrHexLength = errHexLength
 
@@ -141,3 +190,16 @@ func MapStructure(input, output interface{}, hooks map[string]func(v string) (st
 func NewParseError(entry, value string, cause error) error {
 	return newParseError(entry, value, cause)
 }
+
+type ReplayGuard = replayGuard
+
+var NewReplayGuard = newReplayGuard
+
+func (g *ReplayGuard) Verify(r *http.Request, now time.Time) error { return g.verify(r, now) }
+
+// Nonces returns the number of the remembered nonces.
+func (g *ReplayGuard) Nonces() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.nonces
+}
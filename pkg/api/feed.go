@@ -6,8 +6,10 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strconv"
@@ -16,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethersphere/bee/v2/pkg/accesscontrol"
 	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/file"
 	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
 	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
@@ -65,8 +68,9 @@ func (s *Service) feedGetHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	headers := struct {
-		OnlyRootChunk     bool `map:"Swarm-Only-Root-Chunk"`
-		LegacyFeedResolve bool `map:"Swarm-Feed-Legacy-Resolve"`
+		OnlyRootChunk     bool   `map:"Swarm-Only-Root-Chunk"`
+		LegacyFeedResolve bool   `map:"Swarm-Feed-Legacy-Resolve"`
+		Cache             string `map:"Swarm-Cache" validate:"omitempty,oneof=true false no-cache"`
 	}{}
 	if response := s.mapStructure(r.Header, &headers); response != nil {
 		response("invalid header params", logger, w)
@@ -87,7 +91,7 @@ func (s *Service) feedGetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ch, cur, next, err := lookup.At(r.Context(), queries.At, queries.After)
+	ch, cur, next, err := lookup.At(mutableCacheContext(r.Context(), s.FeedCacheTTL, headers.Cache), queries.At, queries.After)
 	if err != nil {
 		logger.Debug("lookup at failed", "at", queries.At, "error", err)
 		logger.Error(nil, "lookup at failed")
@@ -99,7 +103,7 @@ func (s *Service) feedGetHandler(w http.ResponseWriter, r *http.Request) {
 	if ch == nil {
 		logger.Debug("no update found")
 		logger.Error(nil, "no update found")
-		jsonhttp.NotFound(w, "no update found")
+		jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeFeedUpdateNotFound, "no update found"))
 		return
 	}
 
@@ -154,6 +158,11 @@ func (s *Service) feedGetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the no-cache rule only applies to the mutable root chunk
+	if headers.Cache == noCache {
+		r.Header.Del(SwarmCacheHeader)
+	}
+
 	s.downloadHandler(logger, w, r, wc.Address(), additionalHeaders, true, false, wc)
 }
 
@@ -173,6 +182,7 @@ func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
 		BatchID        []byte        `map:"Swarm-Postage-Batch-Id" validate:"required"`
 		Pin            bool          `map:"Swarm-Pin"`
 		Deferred       *bool         `map:"Swarm-Deferred-Upload"`
+		Replication    uint8         `map:"Swarm-Replication-Factor" validate:"lte=8"`
 		Act            bool          `map:"Swarm-Act"`
 		HistoryAddress swarm.Address `map:"Swarm-Act-History-Address"`
 	}{}
@@ -193,7 +203,7 @@ func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
 			logger.Error(nil, "get or create tag failed")
 			switch {
 			case errors.Is(err, storage.ErrNotFound):
-				jsonhttp.NotFound(w, "tag not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not found"))
 			default:
 				jsonhttp.InternalServerError(w, "cannot get or create tag")
 			}
@@ -202,23 +212,24 @@ func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	putter, err := s.newStamperPutter(r.Context(), putterOptions{
-		BatchID:  headers.BatchID,
-		TagID:    tag.TagID,
-		Pin:      headers.Pin,
-		Deferred: deferred,
+		BatchID:           headers.BatchID,
+		TagID:             tag.TagID,
+		Pin:               headers.Pin,
+		Deferred:          deferred,
+		ReplicationFactor: headers.Replication,
 	})
 	if err != nil {
 		logger.Debug("get putter failed", "error", err)
 		logger.Error(nil, "get putter failed")
 		switch {
 		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
 		case errors.Is(err, postage.ErrNotFound):
-			jsonhttp.NotFound(w, "batch with id not found")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
 		case errors.Is(err, errInvalidPostageBatch):
-			jsonhttp.BadRequest(w, "invalid batch id")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
 		case errors.Is(err, errUnsupportedDevNodeOperation):
-			jsonhttp.BadRequest(w, errUnsupportedDevNodeOperation)
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
 		default:
 			jsonhttp.BadRequest(w, nil)
 		}
@@ -232,48 +243,19 @@ func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	l := loadsave.New(s.storer.ChunkStore(), s.storer.Cache(), requestPipelineFactory(r.Context(), putter, false, 0), redundancy.DefaultLevel)
-	feedManifest, err := manifest.NewDefaultManifest(l, false)
+	ref, err := storeFeedManifest(r.Context(), l, paths.Owner, paths.Topic)
 	if err != nil {
-		logger.Debug("create manifest failed", "error", err)
-		logger.Error(nil, "create manifest failed")
+		logger.Debug("store manifest failed", "error", err)
+		logger.Error(nil, "store manifest failed")
 		switch {
 		case errors.Is(err, manifest.ErrInvalidManifestType):
 			jsonhttp.BadRequest(ow, "invalid manifest type")
-		default:
-			jsonhttp.InternalServerError(ow, "create manifest failed")
-		}
-	}
-
-	meta := map[string]string{
-		feedMetadataEntryOwner: hex.EncodeToString(paths.Owner.Bytes()),
-		feedMetadataEntryTopic: hex.EncodeToString(paths.Topic),
-		feedMetadataEntryType:  feeds.Sequence.String(), // only sequence allowed for now
-	}
-
-	emptyAddr := make([]byte, 32)
-
-	// a feed manifest stores the metadata at the root "/" path
-	err = feedManifest.Add(r.Context(), "/", manifest.NewEntry(swarm.NewAddress(emptyAddr), meta))
-	if err != nil {
-		logger.Debug("add manifest entry failed", "error", err)
-		logger.Error(nil, "add manifest entry failed")
-		switch {
 		case errors.Is(err, simple.ErrEmptyPath):
 			jsonhttp.NotFound(ow, "invalid or empty path")
 		case errors.Is(err, mantaray.ErrEmptyPath):
 			jsonhttp.NotFound(ow, "invalid path or mantaray path is empty")
-		default:
-			jsonhttp.InternalServerError(ow, "add manifest entry failed")
-		}
-		return
-	}
-	ref, err := feedManifest.Store(r.Context())
-	if err != nil {
-		logger.Debug("store manifest failed", "error", err)
-		logger.Error(nil, "store manifest failed")
-		switch {
 		case errors.Is(err, postage.ErrBucketFull):
-			jsonhttp.PaymentRequired(ow, "batch is overissued")
+			jsonhttp.PaymentRequired(ow, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
 		default:
 			jsonhttp.InternalServerError(ow, "store manifest failed")
 		}
@@ -289,7 +271,7 @@ func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
 			logger.Error(nil, "access control upload failed")
 			switch {
 			case errors.Is(err, accesscontrol.ErrNotFound):
-				jsonhttp.NotFound(w, "act or history entry not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeACTEntryNotFound, "act or history entry not found"))
 			case errors.Is(err, accesscontrol.ErrInvalidPublicKey) || errors.Is(err, accesscontrol.ErrSecretKeyInfinity):
 				jsonhttp.BadRequest(w, "invalid public key")
 			case errors.Is(err, accesscontrol.ErrUnexpectedType):
@@ -315,3 +297,32 @@ func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	jsonhttp.Created(w, feedReferenceResponse{Reference: encryptedReference})
 }
+
+// storeFeedManifest stores a manifest that resolves to the latest update of
+// the sequence feed with the given owner and topic.
+func storeFeedManifest(ctx context.Context, ls file.LoadSaver, owner common.Address, topic []byte) (swarm.Address, error) {
+	feedManifest, err := manifest.NewDefaultManifest(ls, false)
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("create manifest: %w", err)
+	}
+
+	meta := map[string]string{
+		feedMetadataEntryOwner: hex.EncodeToString(owner.Bytes()),
+		feedMetadataEntryTopic: hex.EncodeToString(topic),
+		feedMetadataEntryType:  feeds.Sequence.String(), // only sequence allowed for now
+	}
+
+	emptyAddr := make([]byte, 32)
+
+	// a feed manifest stores the metadata at the root "/" path
+	err = feedManifest.Add(ctx, "/", manifest.NewEntry(swarm.NewAddress(emptyAddr), meta))
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("add manifest entry: %w", err)
+	}
+
+	ref, err := feedManifest.Store(ctx)
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("store manifest: %w", err)
+	}
+	return ref, nil
+}
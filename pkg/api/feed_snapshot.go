@@ -0,0 +1,267 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/feeds/sequence"
+	"github.com/ethersphere/bee/v2/pkg/file"
+	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// maxFeedSnapshotSize is the maximum number of the updates
+	// referenced by the feed snapshot.
+	maxFeedSnapshotSize = 256
+
+	feedSnapshotEntrySize  = "swarm-feed-snapshot-size"
+	feedSnapshotEntryIndex = "swarm-feed-snapshot-index"
+)
+
+// feedSnapshotPath returns the manifest path of the update at the index.
+func feedSnapshotPath(index uint64) string {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, index)
+	return hex.EncodeToString(b)
+}
+
+// feedIndex returns the position of the sequence feed index.
+func feedIndex(index feeds.Index) (uint64, error) {
+	b, err := index.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	if len(b) != 8 {
+		return 0, fmt.Errorf("invalid sequence index length %d", len(b))
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// storeFeedSnapshot publishes the manifest of the references of the last
+// size updates of the node owned feed, ending with the reference of the
+// update at the index, as the update of the snapshot feed at the same index.
+// The references of the former updates are taken from the previous snapshot,
+// so the snapshot rolls over the updates without looking up the feed.
+func (s *Service) storeFeedSnapshot(ctx context.Context, ls file.LoadSaver, putter storage.Putter, feed *feeds.Feed, index uint64, reference swarm.Address, size uint64) error {
+	topic, err := feeds.SnapshotTopic(feed.Topic)
+	if err != nil {
+		return fmt.Errorf("snapshot topic: %w", err)
+	}
+	snapshotFeed := feeds.New(topic, feed.Owner)
+
+	var previous manifest.Interface
+	if index > 0 {
+		addr, err := snapshotFeed.Update(sequence.NewIndex(index - 1)).Address()
+		if err != nil {
+			return fmt.Errorf("previous snapshot address: %w", err)
+		}
+		ch, err := s.storer.ChunkStore().Get(ctx, addr)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			// the snapshot starts over with the update
+		case err != nil:
+			return fmt.Errorf("get previous snapshot: %w", err)
+		default:
+			wc, err := feeds.FromChunk(ch)
+			if err != nil {
+				return fmt.Errorf("previous snapshot: %w", err)
+			}
+			previous, err = manifest.NewDefaultManifestReference(wc.Address(), ls)
+			if err != nil {
+				return fmt.Errorf("load previous snapshot: %w", err)
+			}
+		}
+	}
+
+	snapshot, err := manifest.NewDefaultManifest(ls, false)
+	if err != nil {
+		return fmt.Errorf("create manifest: %w", err)
+	}
+
+	var from uint64
+	if index >= size {
+		from = index - size + 1
+	}
+	for i := from; previous != nil && i < index; i++ {
+		e, err := previous.Lookup(ctx, feedSnapshotPath(i))
+		if errors.Is(err, manifest.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("lookup previous update %d: %w", i, err)
+		}
+		if err := snapshot.Add(ctx, feedSnapshotPath(i), manifest.NewEntry(e.Reference(), nil)); err != nil {
+			return fmt.Errorf("add manifest entry: %w", err)
+		}
+	}
+	if err := snapshot.Add(ctx, feedSnapshotPath(index), manifest.NewEntry(reference, nil)); err != nil {
+		return fmt.Errorf("add manifest entry: %w", err)
+	}
+
+	meta := map[string]string{
+		feedSnapshotEntrySize:  strconv.FormatUint(size, 10),
+		feedSnapshotEntryIndex: strconv.FormatUint(index, 10),
+	}
+	if err := snapshot.Add(ctx, manifest.RootPath, manifest.NewEntry(swarm.NewAddress(make([]byte, swarm.HashSize)), meta)); err != nil {
+		return fmt.Errorf("add manifest entry: %w", err)
+	}
+
+	ref, err := snapshot.Store(ctx)
+	if err != nil {
+		return fmt.Errorf("store manifest: %w", err)
+	}
+	rootCh, err := s.storer.ChunkStore().Get(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("get manifest root chunk: %w", err)
+	}
+	update, err := newFeedUpdate(s.signer, snapshotFeed, sequence.NewIndex(index), rootCh)
+	if err != nil {
+		return fmt.Errorf("create snapshot update: %w", err)
+	}
+	return putter.Put(ctx, update)
+}
+
+type feedSnapshotUpdate struct {
+	Index     uint64        `json:"index"`
+	Reference swarm.Address `json:"reference"`
+}
+
+type feedSnapshotResponse struct {
+	Index   uint64               `json:"index"`
+	Size    uint64               `json:"size"`
+	Updates []feedSnapshotUpdate `json:"updates"`
+}
+
+// feedSnapshotGetHandler returns the references of the recent updates of the
+// feed from its latest snapshot, the oldest update first, so that a client
+// may catch up with the feed in a single request.
+func (s *Service) feedSnapshotGetHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_feed_snapshot").Build()
+
+	paths := struct {
+		Owner common.Address `map:"owner" validate:"required"`
+		Topic []byte         `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	headers := struct {
+		Cache string `map:"Swarm-Cache" validate:"omitempty,oneof=true false no-cache"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	topic, err := feeds.SnapshotTopic(paths.Topic)
+	if err != nil {
+		logger.Debug("snapshot topic failed", "error", err)
+		logger.Error(nil, "snapshot topic failed")
+		jsonhttp.InternalServerError(w, "snapshot topic failed")
+		return
+	}
+	lookup, err := s.feedFactory.NewLookup(feeds.Sequence, feeds.New(topic, paths.Owner))
+	if err != nil {
+		logger.Debug("new lookup failed", "owner", paths.Owner, "error", err)
+		logger.Error(nil, "new lookup failed")
+		jsonhttp.InternalServerError(w, "new lookup failed")
+		return
+	}
+
+	ch, _, _, err := lookup.At(mutableCacheContext(r.Context(), s.FeedCacheTTL, headers.Cache), time.Now().Unix(), 0)
+	if err != nil {
+		logger.Debug("lookup at failed", "error", err)
+		logger.Error(nil, "lookup at failed")
+		jsonhttp.NotFound(w, "lookup at failed")
+		return
+	}
+	if ch == nil {
+		logger.Debug("no snapshot found")
+		jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeFeedUpdateNotFound, "no snapshot found"))
+		return
+	}
+
+	wc, err := feeds.FromChunk(ch)
+	if err != nil {
+		logger.Debug("wrapped chunk cannot be retrieved", "error", err)
+		logger.Error(nil, "wrapped chunk cannot be retrieved")
+		jsonhttp.NotFound(w, "wrapped chunk cannot be retrieved")
+		return
+	}
+
+	ls := loadsave.NewReadonly(s.storer.Download(true), s.storer.Cache(), redundancy.DefaultLevel)
+	snapshot, err := manifest.NewDefaultManifestReference(wc.Address(), ls)
+	if err != nil {
+		logger.Debug("load snapshot failed", "error", err)
+		logger.Error(nil, "load snapshot failed")
+		jsonhttp.InternalServerError(w, "load snapshot failed")
+		return
+	}
+
+	root, err := snapshot.Lookup(r.Context(), manifest.RootPath)
+	if err != nil {
+		logger.Debug("snapshot metadata lookup failed", "error", err)
+		logger.Error(nil, "snapshot metadata lookup failed")
+		jsonhttp.NotFound(w, "snapshot metadata not found")
+		return
+	}
+	size, err := strconv.ParseUint(root.Metadata()[feedSnapshotEntrySize], 10, 64)
+	if err != nil || size == 0 || size > maxFeedSnapshotSize {
+		logger.Debug("invalid snapshot size", "size", root.Metadata()[feedSnapshotEntrySize])
+		logger.Error(nil, "invalid snapshot size")
+		jsonhttp.InternalServerError(w, "invalid snapshot")
+		return
+	}
+	index, err := strconv.ParseUint(root.Metadata()[feedSnapshotEntryIndex], 10, 64)
+	if err != nil {
+		logger.Debug("invalid snapshot index", "index", root.Metadata()[feedSnapshotEntryIndex])
+		logger.Error(nil, "invalid snapshot index")
+		jsonhttp.InternalServerError(w, "invalid snapshot")
+		return
+	}
+
+	var from uint64
+	if index >= size {
+		from = index - size + 1
+	}
+	updates := make([]feedSnapshotUpdate, 0, index-from+1)
+	for i := from; i <= index; i++ {
+		e, err := snapshot.Lookup(r.Context(), feedSnapshotPath(i))
+		if errors.Is(err, manifest.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			logger.Debug("snapshot entry lookup failed", "index", i, "error", err)
+			logger.Error(nil, "snapshot entry lookup failed")
+			jsonhttp.InternalServerError(w, "snapshot entry lookup failed")
+			return
+		}
+		updates = append(updates, feedSnapshotUpdate{Index: i, Reference: e.Reference()})
+	}
+
+	jsonhttp.OK(w, feedSnapshotResponse{
+		Index:   index,
+		Size:    size,
+		Updates: updates,
+	})
+}
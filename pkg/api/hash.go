@@ -0,0 +1,44 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// discardPutter drops the chunks created by the dry run of the splitter.
+var discardPutter = storage.PutterFunc(func(context.Context, swarm.Chunk) error { return nil })
+
+// hashHandler computes the swarm reference of the body as the bytes upload
+// of it would, without storing the chunks or requiring a postage batch.
+func (s *Service) hashHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_hash").Build()
+
+	headers := struct {
+		Encrypt bool             `map:"Swarm-Encrypt"`
+		RLevel  redundancy.Level `map:"Swarm-Redundancy-Level"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	p := requestPipelineFn(discardPutter, headers.Encrypt, headers.RLevel)
+	reference, err := p(r.Context(), r.Body)
+	if err != nil {
+		logger.Debug("hash computation failed", "error", err)
+		logger.Error(nil, "hash computation failed")
+		jsonhttp.InternalServerError(w, "hash computation failed")
+		return
+	}
+
+	jsonhttp.OK(w, bytesPostResponse{Reference: reference})
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"gitlab.com/nolash/go-mockbytes"
+)
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+
+	storerMock := mockstorer.New()
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: storerMock,
+	})
+
+	g := mockbytes.New(0, mockbytes.MockTypeStandard).WithModulus(255)
+	content, err := g.SequentialBytes(swarm.ChunkSize * 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reference := swarm.MustParseHexAddress("29a5fb121ce96194ba8b7b823a1f9c6af87e1791f824940a53b5a7efe3f790d9")
+	jsonhttptest.Request(t, client, http.MethodPost, "/hash", http.StatusOK,
+		jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+		jsonhttptest.WithExpectedJSONResponse(api.BytesPostResponse{
+			Reference: reference,
+		}),
+	)
+
+	has, err := storerMock.ChunkStore().Has(context.Background(), reference)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if has {
+		t.Fatal("hashed content stored")
+	}
+
+	t.Run("encrypted", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.BytesPostResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/hash", http.StatusOK,
+			jsonhttptest.WithRequestHeader(api.SwarmEncryptHeader, "true"),
+			jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if got := len(resp.Reference.Bytes()); got != 2*swarm.HashSize {
+			t.Fatalf("got reference length %d, want %d", got, 2*swarm.HashSize)
+		}
+	})
+
+	t.Run("invalid redundancy level", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/hash", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmRedundancyLevelHeader, "invalid"),
+			jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+		)
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+)
+
+// maxIdempotencyKeyLength is the maximum length of the Swarm-Idempotency-Key
+// header value.
+const maxIdempotencyKeyLength = 255
+
+// idempotentResponse is the response of the upload made with an idempotency
+// key. It is replayed to the retried requests with the same key.
+type idempotentResponse struct {
+	fingerprint string
+	done        bool
+	expires     time.Time
+	status      int
+	header      http.Header
+	body        []byte
+}
+
+// idempotencyCache keeps the responses of the successful uploads made with
+// the idempotency keys for the window.
+type idempotencyCache struct {
+	window time.Duration
+
+	mu        sync.Mutex
+	responses map[string]*idempotentResponse
+}
+
+func newIdempotencyCache(window time.Duration) *idempotencyCache {
+	return &idempotencyCache{
+		window:    window,
+		responses: make(map[string]*idempotentResponse),
+	}
+}
+
+// start returns the response recorded for the key or registers the request
+// as in progress, if there is none. The returned bool reports whether the
+// request was registered.
+func (c *idempotencyCache) start(key, fingerprint string) (*idempotentResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, resp := range c.responses {
+		if resp.done && now.After(resp.expires) {
+			delete(c.responses, k)
+		}
+	}
+
+	if resp, ok := c.responses[key]; ok {
+		return resp, false
+	}
+	resp := &idempotentResponse{fingerprint: fingerprint}
+	c.responses[key] = resp
+	return resp, true
+}
+
+// finish records the response of the request of the key. The failed requests
+// are forgotten so that they can be retried.
+func (c *idempotencyCache) finish(key string, rec *recordingWriter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if rec.status < http.StatusOK || rec.status >= http.StatusMultipleChoices {
+		delete(c.responses, key)
+		return
+	}
+	resp := c.responses[key]
+	resp.done = true
+	resp.expires = time.Now().Add(c.window)
+	resp.status = rec.status
+	resp.header = rec.Header().Clone()
+	resp.body = rec.body.Bytes()
+}
+
+// recordingWriter records the response written to the ResponseWriter.
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rw *recordingWriter) WriteHeader(statusCode int) {
+	if rw.status == 0 {
+		rw.status = statusCode
+	}
+	rw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rw *recordingWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
+
+// idempotencyMiddleware replays the response of the successful upload to the
+// requests retried with the same Swarm-Idempotency-Key header value within
+// the idempotency window, so that the data is not split and stamped again.
+func (s *Service) idempotencyMiddleware() func(h http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(SwarmIdempotencyKeyHeader)
+			if key == "" || s.idempotency == nil {
+				h.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				jsonhttp.BadRequest(w, "invalid idempotency key")
+				return
+			}
+
+			// the key may only be reused for the same request
+			fingerprint := r.Method + " " + r.URL.Path + " " + r.Header.Get(SwarmPostageBatchIdHeader)
+
+			resp, started := s.idempotency.start(key, fingerprint)
+			if !started {
+				switch {
+				case resp.fingerprint != fingerprint:
+					jsonhttp.UnprocessableEntity(w, "idempotency key used for a different request")
+				case !resp.done:
+					jsonhttp.Conflict(w, "request with the idempotency key in progress")
+				default:
+					for name, values := range resp.header {
+						w.Header()[name] = values
+					}
+					w.Header().Set(SwarmIdempotentReplayHeader, "true")
+					w.Header().Add(AccessControlExposeHeaders, SwarmIdempotentReplayHeader)
+					w.WriteHeader(resp.status)
+					_, _ = w.Write(resp.body)
+				}
+				return
+			}
+
+			rec := &recordingWriter{ResponseWriter: w}
+			defer s.idempotency.finish(key, rec)
+			h.ServeHTTP(rec, r)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	const rootHash = "838d0a193ecd1152d1bb1432d5ecc02398533b2494889e23b8bd5ace30ac2aeb"
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer:            mockstorer.New(),
+		Post:              mockpost.New(mockpost.WithAcceptAll()),
+		IdempotencyWindow: time.Minute,
+	})
+
+	upload := func(key string, body string, status int, opts ...jsonhttptest.Option) http.Header {
+		t.Helper()
+
+		return jsonhttptest.Request(t, client, http.MethodPost, "/bytes", status, append([]jsonhttptest.Option{
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmIdempotencyKeyHeader, key),
+			jsonhttptest.WithRequestBody(strings.NewReader(body)),
+		}, opts...)...)
+	}
+
+	header := upload("key-1", "this is a simple text", http.StatusCreated,
+		jsonhttptest.WithExpectedJSONResponse(api.BytesPostResponse{
+			Reference: swarm.MustParseHexAddress(rootHash),
+		}),
+	)
+	if header.Get(api.SwarmIdempotentReplayHeader) != "" {
+		t.Fatal("first upload replayed")
+	}
+	tag := header.Get(api.SwarmTagHeader)
+
+	// the retried request gets the original response even with another body
+	header = upload("key-1", "another text", http.StatusCreated,
+		jsonhttptest.WithExpectedJSONResponse(api.BytesPostResponse{
+			Reference: swarm.MustParseHexAddress(rootHash),
+		}),
+	)
+	if header.Get(api.SwarmIdempotentReplayHeader) != "true" {
+		t.Fatal("retried upload not replayed")
+	}
+	if got := header.Get(api.SwarmTagHeader); got != tag {
+		t.Fatalf("got tag %q, want %q", got, tag)
+	}
+
+	t.Run("different request", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/bzz", http.StatusUnprocessableEntity,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmIdempotencyKeyHeader, "key-1"),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/plain"),
+			jsonhttptest.WithRequestBody(strings.NewReader("this is a simple text")),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message:   "idempotency key used for a different request",
+				Code:      http.StatusUnprocessableEntity,
+				ErrorCode: "unprocessable_entity",
+			}),
+		)
+	})
+
+	t.Run("failed request is not replayed", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, "invalid"),
+			jsonhttptest.WithRequestHeader(api.SwarmIdempotencyKeyHeader, "key-2"),
+			jsonhttptest.WithRequestBody(strings.NewReader("this is a simple text")),
+		)
+		upload("key-2", "this is a simple text", http.StatusCreated)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		t.Parallel()
+
+		upload(strings.Repeat("k", 256), "this is a simple text", http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/multiformats/go-multiaddr"
+)
+
+type attestationResponse struct {
+	Nonce     string                `json:"nonce"`
+	Overlay   swarm.Address         `json:"overlay"`
+	Underlay  []multiaddr.Multiaddr `json:"underlay"`
+	Ethereum  common.Address        `json:"ethereum"`
+	PublicKey string                `json:"publicKey"`
+	Signature string                `json:"signature"`
+}
+
+// AttestationData returns the data signed by the node in the identity
+// attestation: the nonce followed by the overlay, the ethereum address
+// and the binary representation of each underlay address.
+func AttestationData(nonce []byte, overlay swarm.Address, ethereum common.Address, underlay []multiaddr.Multiaddr) []byte {
+	data := make([]byte, 0, len(nonce)+swarm.HashSize+common.AddressLength)
+	data = append(data, nonce...)
+	data = append(data, overlay.Bytes()...)
+	data = append(data, ethereum.Bytes()...)
+	for _, u := range underlay {
+		data = append(data, u.Bytes()...)
+	}
+	return data
+}
+
+// identityAttestationHandler returns the addresses of the node signed over the
+// nonce supplied by the caller, so that the caller can verify the identity of
+// the node by recovering the signer of the AttestationData.
+func (s *Service) identityAttestationHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_identity_attestation").Build()
+
+	queries := struct {
+		Nonce []byte `map:"nonce" validate:"required,max=64"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	if s.overlay == nil {
+		jsonhttp.ServiceUnavailable(w, "overlay address not available")
+		return
+	}
+
+	underlay := make([]multiaddr.Multiaddr, 0)
+	if s.p2p != nil {
+		u, err := s.p2p.Addresses()
+		if err != nil {
+			logger.Debug("get addresses failed", "error", err)
+			logger.Error(nil, "get addresses failed")
+			jsonhttp.InternalServerError(w, "get addresses failed")
+			return
+		}
+		underlay = u
+	}
+
+	publicKey, err := s.signer.PublicKey()
+	if err != nil {
+		logger.Debug("get public key failed", "error", err)
+		logger.Error(nil, "get public key failed")
+		jsonhttp.InternalServerError(w, "get public key failed")
+		return
+	}
+
+	signature, err := s.signer.Sign(AttestationData(queries.Nonce, *s.overlay, s.ethereumAddress, underlay))
+	if err != nil {
+		logger.Debug("sign attestation failed", "error", err)
+		logger.Error(nil, "sign attestation failed")
+		jsonhttp.InternalServerError(w, "sign attestation failed")
+		return
+	}
+
+	jsonhttp.OK(w, attestationResponse{
+		Nonce:     hex.EncodeToString(queries.Nonce),
+		Overlay:   *s.overlay,
+		Underlay:  underlay,
+		Ethereum:  s.ethereumAddress,
+		PublicKey: hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(publicKey)),
+		Signature: hex.EncodeToString(signature),
+	})
+}
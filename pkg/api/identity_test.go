@@ -0,0 +1,87 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/p2p/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/multiformats/go-multiaddr"
+)
+
+func TestIdentityAttestation(t *testing.T) {
+	t.Parallel()
+
+	overlay := swarm.MustParseHexAddress("ca1e9f3938cc1425c6061b96ad9eb93e134dfe8734ad490164ef20af9d1cf59c")
+	underlay := []multiaddr.Multiaddr{
+		mustMultiaddr(t, "/ip4/127.0.0.1/tcp/7071/p2p/16Uiu2HAmTBuJT9LvNmBiQiNoTsxE5mtNy6YG3paw79m94CRa9sRb"),
+	}
+	ethereumAddress := common.HexToAddress("abcd")
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		Overlay:         overlay,
+		EthereumAddress: ethereumAddress,
+		P2P: mock.New(mock.WithAddressesFunc(func() ([]multiaddr.Multiaddr, error) {
+			return underlay, nil
+		})),
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		nonce := []byte("fleet-manager-nonce")
+
+		var resp struct {
+			Nonce     string         `json:"nonce"`
+			Overlay   swarm.Address  `json:"overlay"`
+			Underlay  []string       `json:"underlay"`
+			Ethereum  common.Address `json:"ethereum"`
+			PublicKey string         `json:"publicKey"`
+			Signature string         `json:"signature"`
+		}
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/identity/attestation?nonce="+hex.EncodeToString(nonce), http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		if resp.Nonce != hex.EncodeToString(nonce) {
+			t.Fatalf("got nonce %s, want %x", resp.Nonce, nonce)
+		}
+		if !resp.Overlay.Equal(overlay) {
+			t.Fatalf("got overlay %s, want %s", resp.Overlay, overlay)
+		}
+		if len(resp.Underlay) != 1 || resp.Underlay[0] != underlay[0].String() {
+			t.Fatalf("got underlay %v, want %v", resp.Underlay, underlay)
+		}
+		if resp.Ethereum != ethereumAddress {
+			t.Fatalf("got ethereum address %s, want %s", resp.Ethereum, ethereumAddress)
+		}
+
+		signature, err := hex.DecodeString(resp.Signature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKey, err := crypto.Recover(signature, api.AttestationData(nonce, overlay, ethereumAddress, underlay))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(publicKey)); got != resp.PublicKey {
+			t.Fatalf("signed by %s, want %s", got, resp.PublicKey)
+		}
+	})
+
+	t.Run("invalid nonce", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/identity/attestation", http.StatusBadRequest)
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/identity/attestation?nonce=xyz", http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"crypto/elliptic"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/keyrotation"
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultKeyRotationGrace = 24 * time.Hour
+	maxKeyRotationGrace     = 30 * 24 * time.Hour
+)
+
+// KeyRotator rotates the keys of the node.
+type KeyRotator interface {
+	Rotate(name string, grace time.Duration) (keyrotation.Rotation, error)
+}
+
+type keyRotationRequest struct {
+	Grace string `json:"grace"`
+}
+
+type keyRotationResponse struct {
+	Name            string     `json:"name"`
+	PublicKey       string     `json:"publicKey"`
+	RestartRequired bool       `json:"restartRequired"`
+	GraceUntil      *time.Time `json:"graceUntil,omitempty"`
+}
+
+// keyRotationHandler replaces the key of the node with a newly generated one.
+// The replaced key is still accepted during the grace period given in the
+// optional body, if the key is applied without the restart of the node.
+func (s *Service) keyRotationHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_key_rotation").Build()
+
+	paths := struct {
+		Name string `map:"name" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	var req keyRotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid key rotation request")
+		return
+	}
+
+	grace := defaultKeyRotationGrace
+	if req.Grace != "" {
+		var err error
+		grace, err = time.ParseDuration(req.Grace)
+		if err != nil || grace < 0 || grace > maxKeyRotationGrace {
+			jsonhttp.BadRequest(w, jsonhttp.StatusResponse{
+				Message: "invalid body params",
+				Code:    http.StatusBadRequest,
+				Reasons: []jsonhttp.Reason{{
+					Field: "grace",
+					Error: fmt.Sprintf("want duration in range [0,%s]", maxKeyRotationGrace),
+				}},
+			})
+			return
+		}
+	}
+
+	rotation, err := s.keyRotator.Rotate(paths.Name, grace)
+	if err != nil {
+		logger.Debug("rotate key failed", "name", paths.Name, "error", err)
+		if errors.Is(err, keyrotation.ErrUnknownKey) {
+			jsonhttp.NotFound(w, "unknown key")
+			return
+		}
+		logger.Error(nil, "rotate key failed", "name", paths.Name)
+		jsonhttp.InternalServerError(w, "rotate key failed")
+		return
+	}
+
+	resp := keyRotationResponse{
+		Name:            rotation.Name,
+		PublicKey:       hex.EncodeToString(elliptic.MarshalCompressed(rotation.PublicKey.Curve, rotation.PublicKey.X, rotation.PublicKey.Y)),
+		RestartRequired: rotation.RestartRequired,
+	}
+	if !rotation.GraceUntil.IsZero() {
+		resp.GraceUntil = &rotation.GraceUntil
+	}
+	jsonhttp.OK(w, resp)
+}
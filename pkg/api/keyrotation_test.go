@@ -0,0 +1,111 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/keyrotation"
+	"github.com/ethersphere/bee/v2/pkg/keystore/mem"
+	"github.com/ethersphere/bee/v2/pkg/log"
+)
+
+func TestKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	const password = "password"
+
+	ks := mem.New()
+	if _, _, err := ks.Key("pss", password, crypto.EDGSecp256_K1); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ks.Key("libp2p_v2", password, crypto.EDGSecp256_R1); err != nil {
+		t.Fatal(err)
+	}
+
+	appliedGrace := make(chan time.Duration, 1)
+	rotator := keyrotation.New(log.Noop, ks, password)
+	rotator.Register("pss", keyrotation.Key{
+		Filename: "pss",
+		EDG:      crypto.EDGSecp256_K1,
+		Apply: func(_ *ecdsa.PrivateKey, grace time.Duration) {
+			appliedGrace <- grace
+		},
+	})
+	rotator.Register("libp2p", keyrotation.Key{
+		Filename: "libp2p_v2",
+		EDG:      crypto.EDGSecp256_R1,
+	})
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		KeyRotator: rotator,
+	})
+
+	t.Run("pss", func(t *testing.T) {
+		var resp struct {
+			Name            string     `json:"name"`
+			PublicKey       string     `json:"publicKey"`
+			RestartRequired bool       `json:"restartRequired"`
+			GraceUntil      *time.Time `json:"graceUntil"`
+		}
+		jsonhttptest.Request(t, client, http.MethodPost, "/keys/pss/rotate", http.StatusOK,
+			jsonhttptest.WithRequestBody(strings.NewReader(`{"grace":"1h"}`)),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		if got := <-appliedGrace; got != time.Hour {
+			t.Fatalf("got grace %s, want %s", got, time.Hour)
+		}
+		if resp.Name != "pss" || resp.RestartRequired || resp.GraceUntil == nil {
+			t.Fatalf("unexpected response %+v", resp)
+		}
+
+		key, _, err := ks.Key("pss", password, crypto.EDGSecp256_K1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(&key.PublicKey)); resp.PublicKey != want {
+			t.Fatalf("got public key %s, want %s", resp.PublicKey, want)
+		}
+	})
+
+	t.Run("libp2p", func(t *testing.T) {
+		var resp struct {
+			RestartRequired bool       `json:"restartRequired"`
+			GraceUntil      *time.Time `json:"graceUntil"`
+		}
+		jsonhttptest.Request(t, client, http.MethodPost, "/keys/libp2p/rotate", http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		if !resp.RestartRequired || resp.GraceUntil != nil {
+			t.Fatalf("unexpected response %+v", resp)
+		}
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, "/keys/swarm/rotate", http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusNotFound,
+				Message:   "unknown key",
+				ErrorCode: "not_found",
+			}),
+		)
+	})
+
+	t.Run("invalid grace", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, "/keys/pss/rotate", http.StatusBadRequest,
+			jsonhttptest.WithRequestBody(strings.NewReader(`{"grace":"forever"}`)),
+		)
+	})
+}
@@ -6,10 +6,12 @@ package api
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
 	"github.com/ethersphere/bee/v2/pkg/log"
@@ -18,8 +20,19 @@ import (
 
 // The following variables exist only to be mocked in tests.
 var (
-	logRegistryIterate   = log.RegistryIterate
-	logSetVerbosityByExp = log.SetVerbosityByExp
+	logRegistryIterate      = log.RegistryIterate
+	logSetVerbosityByExp    = log.SetVerbosityByExp
+	logSetVerbosityByExpFor = log.SetVerbosityByExpFor
+	logVerbosityRevertTime  = log.VerbosityRevertTime
+)
+
+const (
+	// defaultVerbosityTimeout is the default duration
+	// of the temporary logger verbosity change.
+	defaultVerbosityTimeout = 10 * time.Minute
+	// maxVerbosityTimeout is the maximum duration
+	// of the temporary logger verbosity change.
+	maxVerbosityTimeout = 24 * time.Hour
 )
 
 type (
@@ -31,10 +44,16 @@ type (
 	node map[string]*data
 
 	loggerInfo struct {
-		Logger    string `json:"logger"`
+		Logger    string     `json:"logger"`
+		Verbosity string     `json:"verbosity"`
+		Subsystem string     `json:"subsystem"`
+		ID        string     `json:"id"`
+		RevertAt  *time.Time `json:"revertAt,omitempty"`
+	}
+
+	loggerVerbosityRequest struct {
 		Verbosity string `json:"verbosity"`
-		Subsystem string `json:"subsystem"`
-		ID        string `json:"id"`
+		Timeout   string `json:"timeout,omitempty"`
 	}
 
 	loggerResult struct {
@@ -80,12 +99,16 @@ func (s *Service) loggerGetHandler(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// Flat structure.
-			result.Loggers = append(result.Loggers, loggerInfo{
+			info := loggerInfo{
 				Logger:    name,
 				Verbosity: verbosity.String(),
 				Subsystem: id,
 				ID:        base64.URLEncoding.EncodeToString([]byte(id)),
-			})
+			}
+			if at, ok := logVerbosityRevertTime(id); ok {
+				info.RevertAt = &at
+			}
+			result.Loggers = append(result.Loggers, info)
 		}
 		return true
 	})
@@ -135,3 +158,68 @@ func (s *Service) loggerSetVerbosityHandler(w http.ResponseWriter, r *http.Reque
 		jsonhttp.OK(w, nil)
 	}
 }
+
+// loggerSetTemporaryVerbosityHandler sets logger(s) verbosity level based on
+// the specified expression or subsystem that matches the logger(s) for the
+// given timeout, after which the previous verbosity level is restored.
+func (s *Service) loggerSetTemporaryVerbosityHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_loggers_timeout").Build()
+
+	paths := struct {
+		Exp string `map:"exp,decBase64url" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	var req loggerVerbosityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid logger verbosity request")
+		return
+	}
+
+	var reasons []jsonhttp.Reason
+	verbosity, err := log.ParseVerbosityLevel(req.Verbosity)
+	if err != nil {
+		reasons = append(reasons, jsonhttp.Reason{
+			Field: "verbosity",
+			Error: "want oneof:none error warning info debug all",
+		})
+	}
+	timeout := defaultVerbosityTimeout
+	if req.Timeout != "" {
+		timeout, err = time.ParseDuration(req.Timeout)
+		if err != nil || timeout <= 0 || timeout > maxVerbosityTimeout {
+			reasons = append(reasons, jsonhttp.Reason{
+				Field: "timeout",
+				Error: fmt.Sprintf("want duration in range (0,%s]", maxVerbosityTimeout),
+			})
+		}
+	}
+	if len(reasons) > 0 {
+		jsonhttp.BadRequest(w, jsonhttp.StatusResponse{
+			Message: "invalid body params",
+			Code:    http.StatusBadRequest,
+			Reasons: reasons,
+		})
+		return
+	}
+
+	if err := logSetVerbosityByExpFor(paths.Exp, verbosity, timeout); err != nil {
+		logger.Debug("invalid path params", "error", err)
+		logger.Error(nil, "invalid path params")
+		jsonhttp.BadRequest(w, jsonhttp.StatusResponse{
+			Message: "invalid path params",
+			Code:    http.StatusBadRequest,
+			Reasons: []jsonhttp.Reason{{
+				Field: "exp",
+				Error: err.Error(),
+			}},
+		})
+		return
+	}
+	logger.Info("logger verbosity changed temporarily", "exp", paths.Exp, "verbosity", verbosity, "timeout", timeout)
+	jsonhttp.OK(w, nil)
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/api"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
@@ -140,6 +141,62 @@ func TestSetLoggerVerbosity(t *testing.T) {
 	}
 }
 
+// nolint:paralleltest
+func TestSetLoggerTemporaryVerbosity(t *testing.T) {
+	defer func(fn api.LogSetVerbosityByExpForFn) {
+		api.ReplaceLogSetVerbosityByExpFor(fn)
+	}(api.LogSetVerbosityByExpFor)
+
+	client, _, _, _ := newTestServer(t, testServerOptions{})
+
+	type data struct {
+		exp string
+		ver log.Level
+		dur time.Duration
+	}
+
+	have := new(data)
+	api.ReplaceLogSetVerbosityByExpFor(func(e string, v log.Level, d time.Duration) error {
+		have.exp = e
+		have.ver = v
+		have.dur = d
+		return nil
+	})
+
+	tests := []struct {
+		timeout string
+		want    data
+	}{{
+		want: data{exp: `pushsync`, ver: log.VerbosityDebug, dur: 10 * time.Minute},
+	}, {
+		timeout: "30s",
+		want:    data{exp: `^node/pushsync`, ver: log.VerbosityAll, dur: 30 * time.Second},
+	}, {
+		timeout: "24h",
+		want:    data{exp: `^node/pushsync\[0\]`, ver: log.VerbosityInfo, dur: 24 * time.Hour},
+	}}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("to=%s,exp=%s,timeout=%s", tc.want.ver, tc.want.exp, tc.timeout), func(t *testing.T) {
+			exp := base64.URLEncoding.EncodeToString([]byte(tc.want.exp))
+			jsonhttptest.Request(t, client, http.MethodPut, "/loggers/"+exp, http.StatusOK,
+				jsonhttptest.WithJSONRequestBody(api.LoggerVerbosityRequest{
+					Verbosity: tc.want.ver.String(),
+					Timeout:   tc.timeout,
+				}),
+				jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+					Message: http.StatusText(http.StatusOK),
+					Code:    http.StatusOK,
+				}),
+			)
+
+			if *have != tc.want {
+				t.Errorf("mismatch: want: %+v; have: %+v", tc.want, *have)
+			}
+		})
+	}
+}
+
 func Test_loggerGetHandler_invalidInputs(t *testing.T) {
 	t.Parallel()
 
@@ -161,6 +218,7 @@ func Test_loggerGetHandler_invalidInputs(t *testing.T) {
 					Error: "illegal base64 data at input byte 0",
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name: "exp - invalid regex",
@@ -174,6 +232,7 @@ func Test_loggerGetHandler_invalidInputs(t *testing.T) {
 					Error: "error parsing regexp: missing closing ]: `[`",
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -211,6 +270,7 @@ func Test_loggerSetVerbosityHandler_invalidInputs(t *testing.T) {
 					Error: "illegal base64 data at input byte 0",
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:      "exp - invalid regex",
@@ -225,6 +285,7 @@ func Test_loggerSetVerbosityHandler_invalidInputs(t *testing.T) {
 					Error: "error parsing regexp: missing closing ]: `[`",
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:      "verbosity - invalid value",
@@ -239,6 +300,7 @@ func Test_loggerSetVerbosityHandler_invalidInputs(t *testing.T) {
 					Error: "want oneof:none error warning info debug all",
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -252,3 +314,79 @@ func Test_loggerSetVerbosityHandler_invalidInputs(t *testing.T) {
 		})
 	}
 }
+
+func Test_loggerSetTemporaryVerbosityHandler_invalidInputs(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{})
+
+	tests := []struct {
+		name string
+		exp  string
+		req  api.LoggerVerbosityRequest
+		want jsonhttp.StatusResponse
+	}{{
+		name: "exp - invalid regex",
+		exp:  base64.URLEncoding.EncodeToString([]byte("[")),
+		req:  api.LoggerVerbosityRequest{Verbosity: "debug"},
+		want: jsonhttp.StatusResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid path params",
+			Reasons: []jsonhttp.Reason{{
+				Field: "exp",
+				Error: "error parsing regexp: missing closing ]: `[`",
+			}},
+			ErrorCode: "bad_request",
+		},
+	}, {
+		name: "verbosity - invalid value",
+		exp:  base64.URLEncoding.EncodeToString([]byte("pushsync")),
+		req:  api.LoggerVerbosityRequest{Verbosity: "invalid"},
+		want: jsonhttp.StatusResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid body params",
+			Reasons: []jsonhttp.Reason{{
+				Field: "verbosity",
+				Error: "want oneof:none error warning info debug all",
+			}},
+			ErrorCode: "bad_request",
+		},
+	}, {
+		name: "timeout - too long",
+		exp:  base64.URLEncoding.EncodeToString([]byte("pushsync")),
+		req:  api.LoggerVerbosityRequest{Verbosity: "debug", Timeout: "25h"},
+		want: jsonhttp.StatusResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid body params",
+			Reasons: []jsonhttp.Reason{{
+				Field: "timeout",
+				Error: "want duration in range (0,24h0m0s]",
+			}},
+			ErrorCode: "bad_request",
+		},
+	}, {
+		name: "timeout - invalid value",
+		exp:  base64.URLEncoding.EncodeToString([]byte("pushsync")),
+		req:  api.LoggerVerbosityRequest{Verbosity: "debug", Timeout: "soon"},
+		want: jsonhttp.StatusResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid body params",
+			Reasons: []jsonhttp.Reason{{
+				Field: "timeout",
+				Error: "want duration in range (0,24h0m0s]",
+			}},
+			ErrorCode: "bad_request",
+		},
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			jsonhttptest.Request(t, client, http.MethodPut, "/loggers/"+tc.exp, tc.want.Code,
+				jsonhttptest.WithJSONRequestBody(tc.req),
+				jsonhttptest.WithExpectedJSONResponse(tc.want),
+			)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/maintenance"
+)
+
+// MaintenanceScheduler gets and sets the maintenance windows of the node.
+type MaintenanceScheduler interface {
+	Status() maintenance.Status
+	SetWindows([]maintenance.Window)
+	SetOverride(*bool)
+}
+
+type maintenanceResponse struct {
+	Active     bool     `json:"active"`
+	Override   *bool    `json:"override"`
+	Windows    string   `json:"windows"`
+	Activities []string `json:"activities"`
+}
+
+type maintenanceRequest struct {
+	Override *bool   `json:"override"`
+	Windows  *string `json:"windows"`
+}
+
+func newMaintenanceResponse(st maintenance.Status) maintenanceResponse {
+	return maintenanceResponse{
+		Active:     st.Active,
+		Override:   st.Override,
+		Windows:    maintenance.FormatWindows(st.Windows),
+		Activities: st.Activities,
+	}
+}
+
+// maintenanceGetHandler returns the maintenance state of the node.
+func (s *Service) maintenanceGetHandler(w http.ResponseWriter, _ *http.Request) {
+	jsonhttp.OK(w, newMaintenanceResponse(s.maintenance.Status()))
+}
+
+// maintenancePutHandler replaces the maintenance windows when they are given
+// and sets the override which manually enters or leaves the maintenance, the
+// null override returns the control to the windows.
+func (s *Service) maintenancePutHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_maintenance").Build()
+
+	var req maintenanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid maintenance")
+		return
+	}
+
+	if req.Windows != nil {
+		windows, err := maintenance.ParseWindows(*req.Windows)
+		if err != nil {
+			logger.Debug("invalid maintenance windows", "error", err)
+			if errors.Is(err, maintenance.ErrInvalidWindow) {
+				jsonhttp.BadRequest(w, "invalid maintenance windows")
+				return
+			}
+			jsonhttp.InternalServerError(w, "parse maintenance windows failed")
+			return
+		}
+		s.maintenance.SetWindows(windows)
+	}
+	s.maintenance.SetOverride(req.Override)
+
+	jsonhttp.OK(w, newMaintenanceResponse(s.maintenance.Status()))
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/maintenance"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+func TestMaintenance(t *testing.T) {
+	t.Parallel()
+
+	var paused bool
+	s := maintenance.New(log.Noop, nil)
+	s.Register("pullsync", maintenance.Funcs{
+		OnPause:  func() { paused = true },
+		OnResume: func() { paused = false },
+	})
+	testutil.CleanupCloser(t, s)
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Maintenance: s,
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/maintenance", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.MaintenanceResponse{Activities: []string{"pullsync"}}),
+	)
+
+	on := true
+	windows := "02:00-04:00"
+	jsonhttptest.Request(t, client, http.MethodPut, "/maintenance", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(api.MaintenanceRequest{Override: &on, Windows: &windows}),
+		jsonhttptest.WithExpectedJSONResponse(api.MaintenanceResponse{
+			Active:     true,
+			Override:   &on,
+			Windows:    windows,
+			Activities: []string{"pullsync"},
+		}),
+	)
+	if !paused {
+		t.Fatal("want activity paused")
+	}
+
+	off := false
+	jsonhttptest.Request(t, client, http.MethodPut, "/maintenance", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(api.MaintenanceRequest{Override: &off}),
+		jsonhttptest.WithExpectedJSONResponse(api.MaintenanceResponse{
+			Override:   &off,
+			Windows:    windows,
+			Activities: []string{"pullsync"},
+		}),
+	)
+	if paused {
+		t.Fatal("want activity resumed")
+	}
+
+	invalid := "02:00-25:00"
+	jsonhttptest.Request(t, client, http.MethodPut, "/maintenance", http.StatusBadRequest,
+		jsonhttptest.WithJSONRequestBody(api.MaintenanceRequest{Windows: &invalid}),
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message:   "invalid maintenance windows",
+			Code:      http.StatusBadRequest,
+			ErrorCode: "bad_request",
+		}),
+	)
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodGet, "/maintenance", http.StatusNotFound)
+	})
+}
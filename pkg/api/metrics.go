@@ -5,12 +5,16 @@
 package api
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/ethersphere/bee/v2"
 	m "github.com/ethersphere/bee/v2/pkg/metrics"
+	"github.com/ethersphere/bee/v2/pkg/stampproxy"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 )
@@ -31,6 +35,14 @@ type metrics struct {
 	ContentApiDuration *prometheus.HistogramVec
 	UploadSpeed        *prometheus.HistogramVec
 	DownloadSpeed      *prometheus.HistogramVec
+
+	BatchUploadSpeed   *prometheus.HistogramVec
+	BatchUploadedBytes *prometheus.CounterVec
+	StampedChunks      *prometheus.CounterVec
+
+	RouteDuration     *prometheus.HistogramVec
+	RouteResponseSize *prometheus.HistogramVec
+	RouteInFlight     *prometheus.GaugeVec
 }
 
 func newMetrics() metrics {
@@ -80,9 +92,68 @@ func newMetrics() metrics {
 			Help:      "Histogram of download speed in B/s.",
 			Buckets:   []float64{0.5, 1, 1.5, 2, 2.5, 3, 4, 5, 6, 7, 8, 9},
 		}, []string{"endpoint"}),
+		BatchUploadSpeed: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "batch_upload_speed",
+			Help:      "Histogram of upload speed in B/s by the hashed batch ID and the API key.",
+			Buckets:   []float64{0.25, 0.5, 0.75, 1, 1.25, 1.5, 1.75, 2, 2.5, 3, 4, 5},
+		}, []string{"endpoint", "batch", "api_key"}),
+		BatchUploadedBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "batch_uploaded_bytes",
+			Help:      "Number of uploaded bytes by the hashed batch ID and the API key.",
+		}, []string{"endpoint", "batch", "api_key"}),
+		StampedChunks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "stamped_chunk_count",
+			Help:      "Number of uploaded chunks stamped by the hashed batch ID and the API key.",
+		}, []string{"batch", "api_key"}),
+		RouteDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "route_duration_seconds",
+			Help:      "Histogram of API response durations by route template, method and status class.",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"route", "method", "status"}),
+		RouteResponseSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "route_response_size_bytes",
+			Help:      "Histogram of API response body sizes by route template and method.",
+			Buckets:   prometheus.ExponentialBuckets(256, 4, 10),
+		}, []string{"route", "method"}),
+		RouteInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "route_in_flight_requests",
+			Help:      "Number of API requests being served by route template and method.",
+		}, []string{"route", "method"}),
 	}
 }
 
+// noAPIKey is the API key label of the uploads which were not
+// authenticated by the stamping proxy.
+const noAPIKey = "none"
+
+// batchLabel returns the metrics label of the batch, the prefix of the hash
+// of the batch ID, so that the batch IDs are not exposed by the metrics.
+func batchLabel(batchID []byte) string {
+	h := sha256.Sum256(batchID)
+	return hex.EncodeToString(h[:8])
+}
+
+// apiKeyLabel returns the metrics label of the API key, the ID of the
+// stamping proxy client the upload was authenticated as.
+func apiKeyLabel(ctx context.Context) string {
+	if id, ok := stampproxy.ClientID(ctx); ok {
+		return id
+	}
+	return noAPIKey
+}
+
 func toFileSizeBucket(bytes int64) int64 {
 
 	for _, s := range fileSizeBucketsKBytes {
@@ -126,6 +197,29 @@ func (s *Service) responseCodeMetricsHandler(h http.Handler) http.Handler {
 	})
 }
 
+// routeMetricsHandler measures the requests of the route by its template,
+// so the label values are bounded by the number of the routes.
+func (s *Service) routeMetricsHandler(route string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inFlight := s.metrics.RouteInFlight.WithLabelValues(route, r.Method)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		start := time.Now()
+		uw, ok := w.(UpgradedResponseWriter)
+		if !ok {
+			h.ServeHTTP(w, r)
+			s.metrics.RouteDuration.WithLabelValues(route, r.Method, "unknown").Observe(time.Since(start).Seconds())
+			return
+		}
+		wrapper := newResponseWriter(uw)
+		h.ServeHTTP(wrapper, r)
+		status := strconv.Itoa(wrapper.statusCode/100) + "xx"
+		s.metrics.RouteDuration.WithLabelValues(route, r.Method, status).Observe(time.Since(start).Seconds())
+		s.metrics.RouteResponseSize.WithLabelValues(route, r.Method).Observe(float64(wrapper.size))
+	})
+}
+
 // UpgradedResponseWriter adds more functionality on top of ResponseWriter
 type UpgradedResponseWriter interface {
 	http.ResponseWriter
@@ -5,9 +5,20 @@
 package api_test
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
 func TestToFileSizeBucket(t *testing.T) {
@@ -31,3 +42,74 @@ func TestToFileSizeBucket(t *testing.T) {
 		t.Fatalf("bucket should be the last bucket")
 	}
 }
+
+func TestRouteMetrics(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{Storer: mockstorer.New()})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/node", http.StatusOK)
+	jsonhttptest.Request(t, client, http.MethodGet, "/bytes/"+swarm.RandAddress(t).String(), http.StatusNotFound)
+
+	resp, err := client.Get("/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`bee_api_route_duration_seconds_count{method="GET",route="/node",status="2xx"} 1`,
+		`bee_api_route_duration_seconds_count{method="GET",route="/bytes/{address}",status="4xx"} 1`,
+		`bee_api_route_response_size_bytes_count{method="GET",route="/node"} 1`,
+		`bee_api_route_in_flight_requests{method="GET",route="/metrics"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics do not contain %s", want)
+		}
+	}
+}
+
+func TestUploadMetrics(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+	})
+
+	// two data chunks and the root chunk
+	data := bytes.Repeat([]byte{1}, 2*swarm.ChunkSize)
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+	)
+
+	resp, err := client.Get("/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := sha256.Sum256(batchOk)
+	batch := hex.EncodeToString(h[:8])
+	for _, want := range []string{
+		fmt.Sprintf(`bee_api_stamped_chunk_count{api_key="none",batch="%s"} 3`, batch),
+		fmt.Sprintf(`bee_api_batch_uploaded_bytes{api_key="none",batch="%s",endpoint="bytes"} %d`, batch, len(data)),
+		fmt.Sprintf(`bee_api_batch_upload_speed_count{api_key="none",batch="%s",endpoint="bytes"} 1`, batch),
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics do not contain %s", want)
+		}
+	}
+	if strings.Contains(string(body), batchOkStr) {
+		t.Error("metrics contain the batch ID")
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/fusefs"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+type mountRequest struct {
+	Reference swarm.Address `json:"reference"`
+	Topic     string        `json:"topic"`
+	Path      string        `json:"path"`
+}
+
+type mountResponse struct {
+	Path      string         `json:"path"`
+	Reference *swarm.Address `json:"reference,omitempty"`
+	Topic     string         `json:"topic,omitempty"`
+	Writable  bool           `json:"writable"`
+}
+
+type mountsResponse struct {
+	Mounts []mountResponse `json:"mounts"`
+}
+
+func newMountResponse(info fusefs.Info) mountResponse {
+	resp := mountResponse{Path: info.Path, Writable: info.Writable}
+	if info.Topic != nil {
+		resp.Topic = hex.EncodeToString(info.Topic)
+	} else {
+		ref := info.Reference
+		resp.Reference = &ref
+	}
+	return resp
+}
+
+// mountHandler mounts the manifest of the reference read only or the feed
+// of the topic owned by the node. The feed is mounted read-write when the
+// postage batch to stamp the changes is given.
+func (s *Service) mountHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_mount").Build()
+
+	headers := struct {
+		BatchID []byte `map:"Swarm-Postage-Batch-Id"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	var req mountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid mount request")
+		return
+	}
+	if req.Path == "" {
+		jsonhttp.BadRequest(w, "missing mount path")
+		return
+	}
+
+	var (
+		info fusefs.Info
+		err  error
+	)
+	switch {
+	case req.Topic != "" && !req.Reference.IsZero():
+		jsonhttp.BadRequest(w, "reference and topic are exclusive")
+		return
+	case req.Topic != "":
+		topic, err := hex.DecodeString(req.Topic)
+		if err != nil || len(topic) != swarm.HashSize {
+			jsonhttp.BadRequest(w, "invalid topic")
+			return
+		}
+		var putter manifestfs.PutterFunc
+		if headers.BatchID != nil {
+			batchID := headers.BatchID
+			putter = func(ctx context.Context) (storer.PutterSession, error) {
+				return s.newStamperPutter(ctx, putterOptions{
+					BatchID:  batchID,
+					Deferred: true,
+				})
+			}
+			// the batch is checked before the file system is mounted
+			if _, _, err := s.getStamper(batchID); err != nil {
+				logger.Debug("get stamper failed", "error", err)
+				jsonhttp.BadRequest(w, "invalid postage batch")
+				return
+			}
+		}
+		info, err = s.mounter.MountFeed(req.Path, topic, putter)
+	case !req.Reference.IsZero():
+		info, err = s.mounter.MountReference(req.Path, req.Reference)
+	default:
+		jsonhttp.BadRequest(w, "missing reference or topic")
+		return
+	}
+	if err != nil {
+		logger.Debug("mount failed", "path", req.Path, "error", err)
+		switch {
+		case errors.Is(err, fusefs.ErrMounted):
+			jsonhttp.Conflict(w, "path already mounted")
+		case errors.Is(err, fusefs.ErrUnsupported):
+			jsonhttp.NotImplemented(w, "fuse is not supported")
+		default:
+			logger.Error(nil, "mount failed", "path", req.Path)
+			jsonhttp.InternalServerError(w, "mount failed")
+		}
+		return
+	}
+
+	jsonhttp.Created(w, newMountResponse(info))
+}
+
+// mountsHandler lists the mounted file systems.
+func (s *Service) mountsHandler(w http.ResponseWriter, _ *http.Request) {
+	infos := s.mounter.Mounts()
+	resp := mountsResponse{Mounts: make([]mountResponse, 0, len(infos))}
+	for _, info := range infos {
+		resp.Mounts = append(resp.Mounts, newMountResponse(info))
+	}
+	jsonhttp.OK(w, resp)
+}
+
+// unmountHandler unmounts the file system of the path.
+func (s *Service) unmountHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_mount").Build()
+
+	queries := struct {
+		Path string `map:"path" validate:"required"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	if err := s.mounter.Unmount(queries.Path); err != nil {
+		logger.Debug("unmount failed", "path", queries.Path, "error", err)
+		if errors.Is(err, fusefs.ErrNotMounted) {
+			jsonhttp.NotFound(w, "path not mounted")
+			return
+		}
+		logger.Error(nil, "unmount failed", "path", queries.Path)
+		jsonhttp.InternalServerError(w, "unmount failed")
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
+	"github.com/ethersphere/bee/v2/pkg/fusefs"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+)
+
+func TestMount(t *testing.T) {
+	t.Parallel()
+
+	const topicHex = "7c2f7d2d5fd2b6ebd1fa8d4e4d8c6bb6a84d0aa1bc4e0cd9e5bb9b4b06bde4fd"
+
+	st := mockstorer.New()
+	pk, _ := crypto.GenerateSecp256k1Key()
+	store, err := manifestfs.NewStore(manifestfs.Options{
+		Storer: st,
+		Feeds:  factory.New(st.Download(true)),
+		Signer: crypto.NewDefaultSigner(pk),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mounter := fusefs.NewMounter(store, log.Noop)
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Mounter: mounter,
+	})
+
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/mount", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.MountsResponse{
+				Mounts: []api.MountResponse{},
+			}),
+		)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		t.Parallel()
+
+		for _, tc := range []struct {
+			name string
+			body string
+			msg  string
+		}{
+			{name: "body", body: "{", msg: "invalid mount request"},
+			{name: "path", body: `{"topic":"` + topicHex + `"}`, msg: "missing mount path"},
+			{name: "source", body: `{"path":"/mnt"}`, msg: "missing reference or topic"},
+			{name: "topic", body: `{"path":"/mnt","topic":"abcd"}`, msg: "invalid topic"},
+			{name: "exclusive", body: `{"path":"/mnt","topic":"` + topicHex + `","reference":"` + topicHex + `"}`, msg: "reference and topic are exclusive"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				jsonhttptest.Request(t, client, http.MethodPost, "/mount", http.StatusBadRequest,
+					jsonhttptest.WithRequestBody(strings.NewReader(tc.body)),
+					jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+						Message:   tc.msg,
+						Code:      http.StatusBadRequest,
+						ErrorCode: "bad_request",
+					}),
+				)
+			})
+		}
+	})
+
+	t.Run("not mounted", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodDelete, "/mount?path=/not/mounted", http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message:   "path not mounted",
+				Code:      http.StatusNotFound,
+				ErrorCode: "not_found",
+			}),
+		)
+	})
+}
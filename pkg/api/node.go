@@ -5,9 +5,11 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/modeswitch"
 )
 
 type BeeNodeMode uint
@@ -48,3 +50,69 @@ func (s *Service) nodeGetHandler(w http.ResponseWriter, _ *http.Request) {
 		SwapEnabled:       s.swapEnabled,
 	})
 }
+
+// NodeModeSwitcher switches the mode of the running node.
+type NodeModeSwitcher interface {
+	Mode() modeswitch.Mode
+	Switch(modeswitch.Mode) (bool, error)
+}
+
+// NodeModeResponse is the mode of the node and whether it is being switched to it.
+type NodeModeResponse struct {
+	Mode      string `json:"mode"`
+	Reserve   bool   `json:"reserve"`
+	Switching bool   `json:"switching"`
+}
+
+// nodeModeGetHandler gives back the current mode of the node.
+func (s *Service) nodeModeGetHandler(w http.ResponseWriter, _ *http.Request) {
+	m := s.modeSwitcher.Mode()
+	jsonhttp.OK(w, NodeModeResponse{
+		Mode:    m.String(),
+		Reserve: m.Reserve,
+	})
+}
+
+// nodeModeSwitchHandler switches the running node to the requested mode.
+// The node is restarted in the new mode after the response is sent.
+func (s *Service) nodeModeSwitchHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithValues("put_node_mode").Build()
+
+	queries := struct {
+		Mode    string `map:"mode" validate:"required,oneof=light full"`
+		Reserve *bool  `map:"reserve"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	m := modeswitch.Mode{FullNode: queries.Mode == FullMode.String()}
+	m.Reserve = m.FullNode
+	if queries.Reserve != nil {
+		m.Reserve = *queries.Reserve
+	}
+
+	switched, err := s.modeSwitcher.Switch(m)
+	switch {
+	case errors.Is(err, modeswitch.ErrPending):
+		jsonhttp.Conflict(w, "mode switch pending")
+		return
+	case errors.Is(err, modeswitch.ErrFullModeUnavailable), errors.Is(err, modeswitch.ErrInvalidMode):
+		logger.Debug("switch mode failed", "mode", m, "error", err)
+		jsonhttp.BadRequest(w, err.Error())
+		return
+	case err != nil:
+		logger.Debug("switch mode failed", "mode", m, "error", err)
+		logger.Error(nil, "switch mode failed")
+		jsonhttp.InternalServerError(w, "switch mode failed")
+		return
+	}
+
+	if !switched {
+		jsonhttp.OK(w, NodeModeResponse{Mode: m.String(), Reserve: m.Reserve})
+		return
+	}
+	logger.Info("switching node mode", "mode", m, "reserve", m.Reserve)
+	jsonhttp.Accepted(w, NodeModeResponse{Mode: m.String(), Reserve: m.Reserve, Switching: true})
+}
@@ -5,9 +5,13 @@
 package api_test
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/modeswitch"
 )
 
 func TestBeeNodeMode_String(t *testing.T) {
@@ -26,3 +30,56 @@ func TestBeeNodeMode_String(t *testing.T) {
 		}
 	}
 }
+
+func TestNodeMode(t *testing.T) {
+	t.Parallel()
+
+	switcher := modeswitch.New(modeswitch.Mode{}, true)
+	client, _, _, _ := newTestServer(t, testServerOptions{ModeSwitcher: switcher})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/node/mode", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.NodeModeResponse{Mode: "light"}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodPut, "/node/mode?mode=light", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.NodeModeResponse{Mode: "light"}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodPut, "/node/mode?mode=light&reserve=true", http.StatusBadRequest,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:      http.StatusBadRequest,
+			Message:   modeswitch.ErrInvalidMode.Error(),
+			ErrorCode: "bad_request",
+		}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodPut, "/node/mode?mode=full", http.StatusAccepted,
+		jsonhttptest.WithExpectedJSONResponse(api.NodeModeResponse{Mode: "full", Reserve: true, Switching: true}),
+	)
+	if m := <-switcher.Switched(); m != (modeswitch.Mode{FullNode: true, Reserve: true}) {
+		t.Fatalf("got switched mode %+v", m)
+	}
+
+	jsonhttptest.Request(t, client, http.MethodPut, "/node/mode?mode=full&reserve=false", http.StatusConflict)
+
+	t.Run("full mode unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{ModeSwitcher: modeswitch.New(modeswitch.Mode{}, false)})
+		jsonhttptest.Request(t, client, http.MethodPut, "/node/mode?mode=full", http.StatusBadRequest)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{ModeSwitcher: modeswitch.New(modeswitch.Mode{}, true)})
+		jsonhttptest.Request(t, client, http.MethodPut, "/node/mode?mode=ultra", http.StatusBadRequest)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodGet, "/node/mode", http.StatusNotFound)
+	})
+}
@@ -0,0 +1,220 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethersphere/bee/v2"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/gorilla/mux"
+)
+
+const (
+	routeTagAPI   = "API"
+	routeTagDebug = "Debug"
+)
+
+// routeParam is the definition of the path parameter shared by the routes.
+// The same definition is used for the generated OpenAPI document and for
+// the validation of the requests.
+type routeParam struct {
+	description string
+	typ         string
+	pattern     *regexp.Regexp
+}
+
+// routeParams are the definitions of the known path parameters. The
+// parameters that are not listed are documented as plain strings.
+var routeParams = map[string]routeParam{
+	"peer":     {description: "Swarm address of the peer", typ: "string", pattern: regexp.MustCompile(`^[0-9a-fA-F]{64}$`)},
+	"batch_id": {description: "Postage batch ID", typ: "string", pattern: regexp.MustCompile(`^[0-9a-fA-F]{64}$`)},
+	"hash":     {description: "Transaction hash", typ: "string", pattern: regexp.MustCompile(`^(0x)?[0-9a-fA-F]{64}$`)},
+	"owner":    {description: "Ethereum address of the owner", typ: "string", pattern: regexp.MustCompile(`^(0x)?[0-9a-fA-F]{40}$`)},
+	"amount":   {description: "Amount in PLUR", typ: "string", pattern: regexp.MustCompile(`^[0-9]+$`)},
+	"depth":    {description: "Depth of the postage batch", typ: "integer", pattern: regexp.MustCompile(`^[0-9]+$`)},
+}
+
+// route is the route registered on the router.
+type route struct {
+	path    string
+	tag     string
+	methods []string
+	params  []string
+}
+
+// methodsHandler annotates the handler which dispatches the methods past
+// its middlewares with the methods for the OpenAPI document.
+type methodsHandler struct {
+	http.Handler
+	methods []string
+}
+
+// withMethods annotates the handler with the methods it serves.
+func withMethods(handler http.Handler, methods ...string) http.Handler {
+	return methodsHandler{Handler: handler, methods: methods}
+}
+
+// routeTemplateParam matches the variables of the route path template,
+// optionally followed by the regular expression.
+var routeTemplateParam = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// route records the route of the path template for the OpenAPI document
+// and returns the handler which validates the requests against it.
+func (s *Service) route(tag, path string, handler http.Handler) http.Handler {
+	r := route{
+		path: routeTemplateParam.ReplaceAllString(path, "{$1}"),
+		tag:  tag,
+	}
+	for _, m := range routeTemplateParam.FindAllStringSubmatch(path, -1) {
+		r.params = append(r.params, m[1])
+	}
+	switch h := handler.(type) {
+	case jsonhttp.MethodHandler:
+		for method := range h {
+			r.methods = append(r.methods, method)
+		}
+	case methodsHandler:
+		r.methods = append(r.methods, h.methods...)
+	default:
+		r.methods = []string{http.MethodGet}
+	}
+	sort.Strings(r.methods)
+	s.routes = append(s.routes, r)
+
+	return s.routeMetricsHandler(r.path, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if s.ValidateRequests && req.Method != http.MethodOptions {
+			if resp := r.validate(req); resp != nil {
+				jsonhttp.BadRequest(w, resp)
+				return
+			}
+		}
+		handler.ServeHTTP(w, req)
+	}))
+}
+
+// validate validates the path parameters of the request against the known
+// definitions and returns the response for the invalid ones.
+func (r route) validate(req *http.Request) *jsonhttp.StatusResponse {
+	vars := mux.Vars(req)
+
+	var reasons []jsonhttp.Reason
+	for _, name := range r.params {
+		p, ok := routeParams[name]
+		if !ok || p.pattern == nil || p.pattern.MatchString(vars[name]) {
+			continue
+		}
+		reasons = append(reasons, jsonhttp.Reason{
+			Field: name,
+			Error: "does not match " + p.pattern.String(),
+		})
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+	return &jsonhttp.StatusResponse{
+		Message: "invalid path params",
+		Code:    http.StatusBadRequest,
+		Reasons: reasons,
+	}
+}
+
+// openAPIHandler serves the OpenAPI document generated from the routes
+// registered on the router.
+func (s *Service) openAPIHandler(w http.ResponseWriter, _ *http.Request) {
+	jsonhttp.OK(w, s.openAPIDocument())
+}
+
+type (
+	openAPIDocument struct {
+		OpenAPI string                                 `json:"openapi"`
+		Info    openAPIInfo                            `json:"info"`
+		Paths   map[string]map[string]openAPIOperation `json:"paths"`
+	}
+	openAPIInfo struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	}
+	openAPIOperation struct {
+		Tags        []string                   `json:"tags"`
+		Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+		Responses   map[string]openAPIResponse `json:"responses"`
+		OperationID string                     `json:"operationId"`
+	}
+	openAPIParameter struct {
+		Name        string        `json:"name"`
+		In          string        `json:"in"`
+		Required    bool          `json:"required"`
+		Description string        `json:"description,omitempty"`
+		Schema      openAPISchema `json:"schema"`
+	}
+	openAPISchema struct {
+		Type    string `json:"type"`
+		Pattern string `json:"pattern,omitempty"`
+	}
+	openAPIResponse struct {
+		Description string `json:"description"`
+	}
+)
+
+// openAPIDocument returns the OpenAPI document of the registered routes.
+func (s *Service) openAPIDocument() openAPIDocument {
+	doc := openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title:   "Bee API",
+			Version: bee.Version,
+		},
+		Paths: make(map[string]map[string]openAPIOperation),
+	}
+
+	for _, r := range s.routes {
+		params := make([]openAPIParameter, 0, len(r.params))
+		for _, name := range r.params {
+			p := openAPIParameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   openAPISchema{Type: "string"},
+			}
+			if rp, ok := routeParams[name]; ok {
+				p.Description = rp.description
+				p.Schema.Type = rp.typ
+				if rp.pattern != nil && rp.typ == "string" {
+					p.Schema.Pattern = rp.pattern.String()
+				}
+			}
+			params = append(params, p)
+		}
+
+		ops := make(map[string]openAPIOperation, len(r.methods))
+		for _, method := range r.methods {
+			ops[strings.ToLower(method)] = openAPIOperation{
+				Tags:        []string{r.tag},
+				Parameters:  params,
+				Responses:   map[string]openAPIResponse{"default": {Description: "Default response"}},
+				OperationID: operationID(method, r.path),
+			}
+		}
+		doc.Paths[r.path] = ops
+	}
+	return doc
+}
+
+// operationID derives the unique operation ID from the method and the path.
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == '-' || r == '_' || r == '.'
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]))
+		b.WriteString(part[1:])
+	}
+	return b.String()
+}
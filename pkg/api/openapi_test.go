@@ -0,0 +1,98 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+)
+
+func TestOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	type parameter struct {
+		Name   string `json:"name"`
+		In     string `json:"in"`
+		Schema struct {
+			Type    string `json:"type"`
+			Pattern string `json:"pattern"`
+		} `json:"schema"`
+	}
+	type operation struct {
+		Tags       []string    `json:"tags"`
+		Parameters []parameter `json:"parameters"`
+	}
+	var doc struct {
+		OpenAPI string                          `json:"openapi"`
+		Paths   map[string]map[string]operation `json:"paths"`
+	}
+
+	client, _, _, _ := newTestServer(t, testServerOptions{})
+	jsonhttptest.Request(t, client, http.MethodGet, "/openapi.json", http.StatusOK,
+		jsonhttptest.WithUnmarshalJSONResponse(&doc),
+	)
+
+	if doc.OpenAPI == "" {
+		t.Fatal("missing openapi version")
+	}
+
+	bytes, ok := doc.Paths["/bytes/{address}"]
+	if !ok {
+		t.Fatal("missing /bytes/{address} path")
+	}
+	if _, ok := bytes["get"]; !ok {
+		t.Fatal("missing get operation of /bytes/{address}")
+	}
+	if _, ok := bytes["head"]; !ok {
+		t.Fatal("missing head operation of /bytes/{address}")
+	}
+	if _, ok := bytes["post"]; ok {
+		t.Fatal("unexpected post operation of /bytes/{address}")
+	}
+
+	if _, ok := doc.Paths["/bzz/{address}/{path}"]; !ok {
+		t.Fatal("missing /bzz/{address}/{path} path without the regular expression")
+	}
+	if _, ok := doc.Paths["/node"]["get"]; !ok {
+		t.Fatal("missing get operation of /node")
+	}
+
+	create, ok := doc.Paths["/stamps/{amount}/{depth}"]["post"]
+	if !ok {
+		t.Fatal("missing post operation of /stamps/{amount}/{depth}")
+	}
+	if len(create.Parameters) != 2 {
+		t.Fatalf("got %d parameters, want 2", len(create.Parameters))
+	}
+	if p := create.Parameters[0]; p.Name != "amount" || p.In != "path" || p.Schema.Pattern == "" {
+		t.Fatalf("got parameter %+v", p)
+	}
+	if p := create.Parameters[1]; p.Name != "depth" || p.Schema.Type != "integer" {
+		t.Fatalf("got parameter %+v", p)
+	}
+}
+
+func TestOpenAPIValidateRequests(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{ValidateRequests: true})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/stamps/abcd", http.StatusBadRequest,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid path params",
+			Reasons: []jsonhttp.Reason{{
+				Field: "batch_id",
+				Error: "does not match ^[0-9a-fA-F]{64}$",
+			}},
+			ErrorCode: "bad_request",
+		}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodOptions, "/stamps/abcd", http.StatusNoContent)
+}
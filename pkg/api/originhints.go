@@ -0,0 +1,114 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/retrieval"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// originHintConnectTimeout limits the connecting to the origin hint given as multiaddr.
+const originHintConnectTimeout = 5 * time.Second
+
+var errInvalidOriginHint = errors.New("invalid origin hint")
+
+// originHintsHandler sets the origin hints of the retrieval from the origin
+// hint header of the download requests. The header is the comma separated
+// list of the peer overlays or multiaddrs, the node connects to the latter.
+func (s *Service) originHintsHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := r.Header.Get(SwarmOriginHintHeader)
+		if v == "" || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		hints, err := s.resolveOriginHints(r.Context(), v)
+		if err != nil {
+			s.logger.Debug("invalid origin hint header", "value", v, "error", err)
+			jsonhttp.BadRequest(w, errInvalidOriginHint.Error())
+			return
+		}
+		h.ServeHTTP(w, r.WithContext(retrieval.SetOriginHints(r.Context(), hints)))
+	})
+}
+
+// resolveOriginHints parses the origin hints and returns their overlays.
+// The hints which can not be connected to are skipped.
+func (s *Service) resolveOriginHints(ctx context.Context, v string) ([]swarm.Address, error) {
+	entries := strings.Split(v, ",")
+	if len(entries) > retrieval.MaxOriginHints {
+		return nil, fmt.Errorf("%w: more than %d hints", errInvalidOriginHint, retrieval.MaxOriginHints)
+	}
+
+	var hints []swarm.Address
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if !strings.HasPrefix(e, "/") {
+			overlay, err := swarm.ParseHexAddress(e)
+			if err != nil || !overlay.IsValidNonEmpty() {
+				return nil, fmt.Errorf("%w: %q", errInvalidOriginHint, e)
+			}
+			hints = appendOriginHint(hints, overlay)
+			continue
+		}
+
+		addr, err := multiaddr.NewMultiaddr(e)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", errInvalidOriginHint, e, err)
+		}
+		if s.p2p == nil {
+			continue
+		}
+		overlay, err := s.connectOriginHint(ctx, addr)
+		if err != nil {
+			s.logger.Debug("connect to origin hint failed", "address", addr, "error", err)
+			continue
+		}
+		hints = appendOriginHint(hints, overlay)
+	}
+	return hints, nil
+}
+
+// connectOriginHint connects to the peer with the multiaddr
+// unless it is already connected and returns its overlay.
+func (s *Service) connectOriginHint(ctx context.Context, addr multiaddr.Multiaddr) (swarm.Address, error) {
+	ctx, cancel := context.WithTimeout(ctx, originHintConnectTimeout)
+	defer cancel()
+
+	bzzAddr, err := s.p2p.Connect(ctx, addr)
+	if errors.Is(err, p2p.ErrAlreadyConnected) && bzzAddr != nil {
+		return bzzAddr.Overlay, nil
+	}
+	if err != nil {
+		return swarm.ZeroAddress, err
+	}
+	if s.topologyDriver != nil {
+		if err := s.topologyDriver.Connected(ctx, p2p.Peer{Address: bzzAddr.Overlay}, true); err != nil {
+			_ = s.p2p.Disconnect(bzzAddr.Overlay, "failed to notify topology")
+			return swarm.ZeroAddress, fmt.Errorf("notify topology: %w", err)
+		}
+	}
+	return bzzAddr.Overlay, nil
+}
+
+func appendOriginHint(hints []swarm.Address, overlay swarm.Address) []swarm.Address {
+	for _, h := range hints {
+		if h.Equal(overlay) {
+			return hints
+		}
+	}
+	return append(hints, overlay)
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/bzz"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	p2pmock "github.com/ethersphere/bee/v2/pkg/p2p/mock"
+	testingc "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestOriginHintHeader(t *testing.T) {
+	t.Parallel()
+
+	var connected atomic.Int32
+	storerMock := mockstorer.New()
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: storerMock,
+		P2P: p2pmock.New(p2pmock.WithConnectFunc(func(_ context.Context, addr ma.Multiaddr) (*bzz.Address, error) {
+			connected.Add(1)
+			return &bzz.Address{Overlay: swarm.RandAddress(t), Underlay: addr}, nil
+		})),
+	})
+
+	chunk := testingc.GenerateTestRandomChunk()
+	if err := storerMock.Cache().Put(context.Background(), chunk); err != nil {
+		t.Fatal(err)
+	}
+	resource := "/chunks/" + chunk.Address().String()
+
+	hint := swarm.RandAddress(t).String() + ", /ip4/127.0.0.1/tcp/1634"
+	jsonhttptest.Request(t, client, http.MethodGet, resource, http.StatusOK,
+		jsonhttptest.WithRequestHeader(api.SwarmOriginHintHeader, hint),
+		jsonhttptest.WithExpectedResponse(chunk.Data()),
+	)
+	if got := connected.Load(); got != 1 {
+		t.Fatalf("got %d connects to the multiaddr hint, want 1", got)
+	}
+
+	for _, hint := range []string{
+		"not-an-address",
+		"/not/a/multiaddr",
+		strings.Repeat(swarm.RandAddress(t).String()+",", 9) + swarm.RandAddress(t).String(),
+	} {
+		jsonhttptest.Request(t, client, http.MethodGet, resource, http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmOriginHintHeader, hint),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusBadRequest,
+				Message:   "invalid origin hint",
+				ErrorCode: "bad_request",
+			}),
+		)
+	}
+}
@@ -46,6 +46,6 @@ func (s *Service) addressesHandler(w http.ResponseWriter, _ *http.Request) {
 		Ethereum:     s.ethereumAddress,
 		ChainAddress: s.ethereumAddress,
 		PublicKey:    hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(&s.publicKey)),
-		PSSPublicKey: hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(&s.pssPublicKey)),
+		PSSPublicKey: hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(s.pssPublicKey.Load())),
 	})
 }
@@ -86,8 +86,9 @@ func TestAddresses_error(t *testing.T) {
 
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/addresses", http.StatusInternalServerError,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Code:    http.StatusInternalServerError,
-			Message: testErr.Error(),
+			Code:      http.StatusInternalServerError,
+			Message:   testErr.Error(),
+			ErrorCode: "internal_server_error",
 		}),
 	)
 }
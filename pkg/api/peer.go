@@ -7,6 +7,7 @@ package api
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
@@ -85,8 +86,9 @@ type Peer struct {
 
 type BlockListedPeer struct {
 	Peer
-	Reason   string `json:"reason"`
-	Duration int    `json:"duration"`
+	Reason    string `json:"reason"`
+	Duration  int    `json:"duration"`
+	Remaining int    `json:"remaining"`
 }
 
 type peersResponse struct {
@@ -118,6 +120,68 @@ func (s *Service) blocklistedPeersHandler(w http.ResponseWriter, _ *http.Request
 	})
 }
 
+// defaultBlocklistReason is the reason of the blocklist entries added on demand without one.
+const defaultBlocklistReason = "blocklisted by the node operator"
+
+func (s *Service) blocklistAddHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithValues("post_blocklist").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	queries := struct {
+		Duration int64  `map:"duration" validate:"min=0"`
+		Reason   string `map:"reason"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+	if queries.Reason == "" {
+		queries.Reason = defaultBlocklistReason
+	}
+
+	duration := time.Duration(queries.Duration) * time.Second
+	if err := s.p2p.AddBlocklist(paths.Address, duration, queries.Reason); err != nil {
+		logger.Debug("blocklist peer failed", "peer_address", paths.Address, "error", err)
+		logger.Error(nil, "blocklist peer failed", "peer_address", paths.Address)
+		jsonhttp.InternalServerError(w, "blocklist peer failed")
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
+
+func (s *Service) blocklistRemoveHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithValues("delete_blocklist").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.p2p.RemoveBlocklist(paths.Address); err != nil {
+		logger.Debug("remove peer from blocklist failed", "peer_address", paths.Address, "error", err)
+		if errors.Is(err, p2p.ErrPeerNotFound) {
+			jsonhttp.NotFound(w, "peer not blocklisted")
+			return
+		}
+		logger.Error(nil, "remove peer from blocklist failed", "peer_address", paths.Address)
+		jsonhttp.InternalServerError(w, "remove peer from blocklist failed")
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
+
 func mapPeers(peers []p2p.Peer) (out []Peer) {
 	out = make([]Peer, 0, len(peers))
 	for _, peer := range peers {
@@ -137,8 +201,9 @@ func mapBlockListedPeers(peers []p2p.BlockListedPeer) []BlockListedPeer {
 				Address:  peer.Address,
 				FullNode: peer.FullNode,
 			},
-			Reason:   peer.Reason,
-			Duration: int(peer.Duration.Seconds()),
+			Reason:    peer.Reason,
+			Duration:  int(peer.Duration.Seconds()),
+			Remaining: int(peer.Remaining.Seconds()),
 		})
 	}
 	return out
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethersphere/bee/v2/pkg/api"
@@ -72,8 +73,9 @@ func TestConnect(t *testing.T) {
 		t.Parallel()
 		jsonhttptest.Request(t, testServer, http.MethodPost, "/connect"+errorUnderlay, http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusInternalServerError,
-				Message: testErr.Error(),
+				Code:      http.StatusInternalServerError,
+				Message:   testErr.Error(),
+				ErrorCode: "internal_server_error",
 			}),
 		)
 	})
@@ -91,8 +93,9 @@ func TestConnect(t *testing.T) {
 
 		jsonhttptest.Request(t, testServer, http.MethodPost, "/connect"+errorUnderlay, http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusInternalServerError,
-				Message: testErr.Error(),
+				Code:      http.StatusInternalServerError,
+				Message:   testErr.Error(),
+				ErrorCode: "internal_server_error",
 			}),
 		)
 	})
@@ -140,8 +143,9 @@ func TestDisconnect(t *testing.T) {
 
 		jsonhttptest.Request(t, testServer, http.MethodDelete, "/peers/"+unknownAddress.String(), http.StatusNotFound,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusNotFound,
-				Message: "peer not found",
+				Code:      http.StatusNotFound,
+				Message:   "peer not found",
+				ErrorCode: "not_found",
 			}),
 		)
 	})
@@ -151,8 +155,9 @@ func TestDisconnect(t *testing.T) {
 
 		jsonhttptest.Request(t, testServer, http.MethodDelete, "/peers/"+errorAddress.String(), http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusInternalServerError,
-				Message: testErr.Error(),
+				Code:      http.StatusInternalServerError,
+				Message:   testErr.Error(),
+				ErrorCode: "internal_server_error",
 			}),
 		)
 	})
@@ -209,12 +214,57 @@ func TestBlocklistedPeersErr(t *testing.T) {
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/blocklist", http.StatusInternalServerError,
 		jsonhttptest.WithExpectedJSONResponse(
 			jsonhttp.StatusResponse{
-				Code:    http.StatusInternalServerError,
-				Message: "get blocklisted peers failed",
+				Code:      http.StatusInternalServerError,
+				Message:   "get blocklisted peers failed",
+				ErrorCode: "internal_server_error",
 			}),
 	)
 }
 
+func TestBlocklistManagement(t *testing.T) {
+	t.Parallel()
+
+	var (
+		overlay   = swarm.MustParseHexAddress("ca1e9f3938cc1425c6061b96ad9eb93e134dfe8734ad490164ef20af9d1cf59c")
+		unknown   = swarm.MustParseHexAddress("ca1e9f3938cc1425c6061b96ad9eb93e134dfe8734ad490164ef20af9d1cf59d")
+		added     = make(map[string]time.Duration)
+		addReason string
+	)
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		P2P: mock.New(
+			mock.WithAddBlocklistFunc(func(addr swarm.Address, d time.Duration, reason string) error {
+				added[addr.String()] = d
+				addReason = reason
+				return nil
+			}),
+			mock.WithRemoveBlocklistFunc(func(addr swarm.Address) error {
+				if _, ok := added[addr.String()]; !ok {
+					return p2p.ErrPeerNotFound
+				}
+				delete(added, addr.String())
+				return nil
+			}),
+		),
+	})
+
+	jsonhttptest.Request(t, testServer, http.MethodPost, "/blocklist/"+overlay.String()+"?duration=3600&reason=spam", http.StatusOK)
+	if d := added[overlay.String()]; d != time.Hour || addReason != "spam" {
+		t.Fatalf("got duration %v and reason %q", d, addReason)
+	}
+
+	jsonhttptest.Request(t, testServer, http.MethodPost, "/blocklist/"+overlay.String()+"?duration=-1", http.StatusBadRequest)
+
+	jsonhttptest.Request(t, testServer, http.MethodDelete, "/blocklist/"+overlay.String(), http.StatusOK)
+	jsonhttptest.Request(t, testServer, http.MethodDelete, "/blocklist/"+unknown.String(), http.StatusNotFound,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:      http.StatusNotFound,
+			Message:   "peer not blocklisted",
+			ErrorCode: "not_found",
+		}),
+	)
+}
+
 func Test_peerConnectHandler_invalidInputs(t *testing.T) {
 	t.Parallel()
 
@@ -236,6 +286,7 @@ func Test_peerConnectHandler_invalidInputs(t *testing.T) {
 					Error: "failed to parse multiaddr \"/ca1e9f3938cc1425c6061b96ad9eb93e134dfe8734ad490164ef20af9d1cf59a\": unknown protocol ca1e9f3938cc1425c6061b96ad9eb93e134dfe8734ad490164ef20af9d1cf59a",
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -271,6 +322,7 @@ func Test_peerDisconnectHandler_invalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "address - invalid hex character",
@@ -284,6 +336,7 @@ func Test_peerDisconnectHandler_invalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
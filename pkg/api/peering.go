@@ -0,0 +1,76 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/p2p/peering"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// PeeringPolicy gets and sets the static peering configuration.
+type PeeringPolicy interface {
+	Config() peering.Config
+	Set(peering.Config) error
+}
+
+type peeringResponse struct {
+	Pinned []swarm.Address            `json:"pinned"`
+	Denied []string                   `json:"denied"`
+	Groups map[string][]swarm.Address `json:"groups"`
+}
+
+func newPeeringResponse(c peering.Config) peeringResponse {
+	resp := peeringResponse{
+		Pinned: c.Pinned,
+		Denied: c.Denied,
+		Groups: c.Groups,
+	}
+	if resp.Pinned == nil {
+		resp.Pinned = []swarm.Address{}
+	}
+	if resp.Denied == nil {
+		resp.Denied = []string{}
+	}
+	if resp.Groups == nil {
+		resp.Groups = map[string][]swarm.Address{}
+	}
+	return resp
+}
+
+// peeringGetHandler returns the static peering configuration.
+func (s *Service) peeringGetHandler(w http.ResponseWriter, _ *http.Request) {
+	jsonhttp.OK(w, newPeeringResponse(s.peering.Config()))
+}
+
+// peeringPutHandler replaces the static peering configuration.
+func (s *Service) peeringPutHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_peering").Build()
+
+	var req peeringResponse
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid peering configuration")
+		return
+	}
+
+	config := peering.Config{Pinned: req.Pinned, Denied: req.Denied, Groups: req.Groups}
+	if err := s.peering.Set(config); err != nil {
+		logger.Debug("set peering configuration failed", "error", err)
+		if errors.Is(err, peering.ErrInvalidConfig) {
+			jsonhttp.BadRequest(w, "invalid peering configuration")
+			return
+		}
+		logger.Error(nil, "set peering configuration failed")
+		jsonhttp.InternalServerError(w, "set peering configuration failed")
+		return
+	}
+
+	jsonhttp.OK(w, newPeeringResponse(s.peering.Config()))
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/p2p/peering"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestPeering(t *testing.T) {
+	t.Parallel()
+
+	policy := peering.NewPolicy()
+	client, _, _, _ := newTestServer(t, testServerOptions{Peering: policy})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/peering", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.PeeringResponse{
+			Pinned: []swarm.Address{},
+			Denied: []string{},
+			Groups: map[string][]swarm.Address{},
+		}),
+	)
+
+	pinned := swarm.RandAddress(t)
+	member := swarm.RandAddress(t)
+	want := api.PeeringResponse{
+		Pinned: []swarm.Address{pinned},
+		Denied: []string{"192.168.0.0/16"},
+		Groups: map[string][]swarm.Address{"friends": {member}},
+	}
+
+	jsonhttptest.Request(t, client, http.MethodPut, "/peering", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(want),
+		jsonhttptest.WithExpectedJSONResponse(want),
+	)
+	if !policy.Protected(member) {
+		t.Fatal("want group member protected")
+	}
+
+	jsonhttptest.Request(t, client, http.MethodPut, "/peering", http.StatusBadRequest,
+		jsonhttptest.WithJSONRequestBody(api.PeeringResponse{Denied: []string{"invalid"}}),
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:      http.StatusBadRequest,
+			Message:   "invalid peering configuration",
+			ErrorCode: "bad_request",
+		}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/peering", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(want),
+	)
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodGet, "/peering", http.StatusNotFound)
+	})
+}
@@ -23,6 +23,7 @@ import (
 // pinRootHash pins root hash of given reference. This method is idempotent.
 func (s *Service) pinRootHash(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("post_pin").Build()
+	w, r = withTraversalProgress(w, r)
 
 	paths := struct {
 		Reference swarm.Address `map:"reference" validate:"required"`
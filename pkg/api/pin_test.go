@@ -6,6 +6,7 @@ package api_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strings"
 	"testing"
@@ -37,8 +38,9 @@ func checkPinHandlers(t *testing.T, client *http.Client, rootHash string, create
 
 	jsonhttptest.Request(t, client, http.MethodGet, pinsUnknownReferencePath, http.StatusNotFound,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: http.StatusText(http.StatusNotFound),
-			Code:    http.StatusNotFound,
+			Message:   http.StatusText(http.StatusNotFound),
+			Code:      http.StatusNotFound,
+			ErrorCode: "not_found",
 		}),
 	)
 
@@ -71,8 +73,9 @@ func checkPinHandlers(t *testing.T, client *http.Client, rootHash string, create
 
 	jsonhttptest.Request(t, client, http.MethodGet, pinsReferencePath, http.StatusNotFound,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: http.StatusText(http.StatusNotFound),
-			Code:    http.StatusNotFound,
+			Message:   http.StatusText(http.StatusNotFound),
+			Code:      http.StatusNotFound,
+			ErrorCode: "not_found",
 		}),
 	)
 }
@@ -110,6 +113,43 @@ func TestPinHandlers(t *testing.T) {
 		)
 	})
 
+	t.Run("bytes progress", func(t *testing.T) {
+		const rootHash = "838d0a193ecd1152d1bb1432d5ecc02398533b2494889e23b8bd5ace30ac2aeb"
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(strings.NewReader("this is a simple text")),
+		)
+
+		var body []byte
+		jsonhttptest.Request(t, client, http.MethodPost, "/pins/"+rootHash, http.StatusOK,
+			jsonhttptest.WithRequestHeader("Accept", "application/x-ndjson"),
+			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "application/x-ndjson"),
+			jsonhttptest.WithPutResponseBody(&body),
+		)
+
+		lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("got %d lines, want 2: %q", len(lines), body)
+		}
+		var progress api.TraversalProgressResponse
+		if err := json.Unmarshal([]byte(lines[0]), &progress); err != nil {
+			t.Fatal(err)
+		}
+		if want := (api.TraversalProgressResponse{Chunks: 1, Files: 1}); progress != want {
+			t.Fatalf("got progress %+v, want %+v", progress, want)
+		}
+		var result jsonhttp.StatusResponse
+		if err := json.Unmarshal([]byte(lines[1]), &result); err != nil {
+			t.Fatal(err)
+		}
+		if result.Code != http.StatusCreated {
+			t.Fatalf("got result code %d, want %d", result.Code, http.StatusCreated)
+		}
+
+		jsonhttptest.Request(t, client, http.MethodDelete, "/pins/"+rootHash, http.StatusOK)
+	})
+
 	t.Run("bytes missing", func(t *testing.T) {
 		jsonhttptest.Request(t, client, http.MethodPost, "/pins/"+swarm.RandAddress(t).String(), http.StatusNotFound)
 	})
@@ -169,6 +209,7 @@ func TestPinHandlersInvalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:      "reference - invalid hex character",
@@ -182,6 +223,7 @@ func TestPinHandlersInvalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
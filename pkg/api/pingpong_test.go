@@ -57,8 +57,9 @@ func TestPingpong(t *testing.T) {
 
 		jsonhttptest.Request(t, ts, http.MethodPost, "/pingpong/"+unknownPeerID.String(), http.StatusNotFound,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusNotFound,
-				Message: "peer not found",
+				Code:      http.StatusNotFound,
+				Message:   "peer not found",
+				ErrorCode: "not_found",
 			}),
 		)
 	})
@@ -68,8 +69,9 @@ func TestPingpong(t *testing.T) {
 
 		jsonhttptest.Request(t, ts, http.MethodPost, "/pingpong/"+errorPeerID.String(), http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusInternalServerError,
-				Message: "pingpong: ping failed",
+				Code:      http.StatusInternalServerError,
+				Message:   "pingpong: ping failed",
+				ErrorCode: "internal_server_error",
 			}),
 		)
 	})
@@ -96,6 +98,7 @@ func Test_pingpongHandler_invalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "address - invalid hex character",
@@ -109,6 +112,7 @@ func Test_pingpongHandler_invalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -11,8 +11,8 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
-	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethersphere/bee/v2/pkg/bigint"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
 	"github.com/ethersphere/bee/v2/pkg/postage"
@@ -278,9 +278,9 @@ func (s *Service) postageGetStampBucketsHandler(w http.ResponseWriter, r *http.R
 		logger.Error(nil, "get stamp issuer: get issuer failed")
 		switch {
 		case errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.BadRequest(w, "batch not usable")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable"))
 		case errors.Is(err, postage.ErrNotFound):
-			jsonhttp.NotFound(w, "issuer does not exist")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "issuer does not exist"))
 		default:
 			jsonhttp.InternalServerError(w, "get issuer failed")
 		}
@@ -320,9 +320,9 @@ func (s *Service) postageGetStampHandler(w http.ResponseWriter, r *http.Request)
 		logger.Error(nil, "get stamp issuer: get issuer failed")
 		switch {
 		case errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.BadRequest(w, "batch not usable")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable"))
 		case errors.Is(err, postage.ErrNotFound):
-			jsonhttp.NotFound(w, "issuer does not exist")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "issuer does not exist"))
 		default:
 			jsonhttp.InternalServerError(w, "get issuer failed")
 		}
@@ -338,7 +338,7 @@ func (s *Service) postageGetStampHandler(w http.ResponseWriter, r *http.Request)
 	}
 	if !exists {
 		logger.Debug("batch does not exists", "batch_id", hexBatchID)
-		jsonhttp.NotFound(w, "issuer does not exist")
+		jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "issuer does not exist"))
 		return
 	}
 
@@ -433,21 +433,7 @@ func (s *Service) estimateBatchTTLFromID(id []byte) (int64, error) {
 // estimateBatchTTL estimates the time remaining until the batch expires.
 // The -1 signals that the batch never expires.
 func (s *Service) estimateBatchTTL(batch *postage.Batch) (int64, error) {
-	state := s.batchStore.GetChainState()
-	if len(state.CurrentPrice.Bits()) == 0 {
-		return -1, nil
-	}
-
-	var (
-		normalizedBalance = batch.Value
-		cumulativePayout  = state.TotalAmount
-		pricePerBlock     = state.CurrentPrice
-	)
-	ttl := new(big.Int).Sub(normalizedBalance, cumulativePayout)
-	ttl = ttl.Mul(ttl, big.NewInt(int64(s.blockTime/time.Second)))
-	ttl = ttl.Div(ttl, pricePerBlock)
-
-	return ttl.Int64(), nil
+	return postage.BatchTTL(batch, s.batchStore.GetChainState(), s.blockTime), nil
 }
 
 func (s *Service) postageTopUpHandler(w http.ResponseWriter, r *http.Request) {
@@ -527,3 +513,65 @@ func (s *Service) postageDiluteHandler(w http.ResponseWriter, r *http.Request) {
 		TxHash:  txHash.String(),
 	})
 }
+
+type postageTransferRequest struct {
+	To common.Address `json:"to"`
+}
+
+// postageTransferHandler transfers the ownership of the batch of the node
+// to the address given in the body of the request.
+func (s *Service) postageTransferHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_stamp_transfer").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+	hexBatchID := hex.EncodeToString(paths.BatchID)
+
+	var req postageTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("transfer batch: failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid transfer request")
+		return
+	}
+
+	txHash, err := s.postageContract.TransferBatch(r.Context(), paths.BatchID, req.To)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			logger.Debug("transfer batch: batch not found", "batch_id", hexBatchID)
+			jsonhttp.NotFound(w, "batch not found")
+		case errors.Is(err, postagecontract.ErrNotBatchOwner):
+			logger.Debug("transfer batch: not batch owner", "batch_id", hexBatchID)
+			jsonhttp.Forbidden(w, "not batch owner")
+		case errors.Is(err, postagecontract.ErrInvalidNewOwner):
+			logger.Debug("transfer batch: invalid new owner", "batch_id", hexBatchID, "to", req.To)
+			jsonhttp.BadRequest(w, "invalid new owner")
+		case errors.Is(err, postagecontract.ErrInsufficientFunds):
+			logger.Debug("transfer batch: out of funds", "batch_id", hexBatchID, "error", err)
+			logger.Error(nil, "transfer batch: out of funds")
+			jsonhttp.PaymentRequired(w, "out of funds")
+		case errors.Is(err, postagecontract.ErrNotImplemented):
+			logger.Debug("transfer batch: not supported by the contract", "error", err)
+			jsonhttp.NotImplemented(w, "batch transfer not supported by the contract")
+		case errors.Is(err, postagecontract.ErrChainDisabled):
+			logger.Debug("transfer batch: no chain backend", "error", err)
+			logger.Error(nil, "transfer batch: no chain backend")
+			jsonhttp.MethodNotAllowed(w, "no chain backend")
+		default:
+			logger.Debug("transfer batch: transfer failed", "batch_id", hexBatchID, "to", req.To, "error", err)
+			logger.Error(nil, "transfer batch: transfer failed")
+			jsonhttp.InternalServerError(w, "cannot transfer batch")
+		}
+		return
+	}
+
+	jsonhttp.Accepted(w, &postageCreateResponse{
+		BatchID: paths.BatchID,
+		TxHash:  txHash.String(),
+	})
+}
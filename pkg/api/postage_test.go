@@ -29,6 +29,7 @@ import (
 	contractMock "github.com/ethersphere/bee/v2/pkg/postage/postagecontract/mock"
 	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
 	"github.com/ethersphere/bee/v2/pkg/sctx"
+	"github.com/ethersphere/bee/v2/pkg/storage"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
 	"github.com/ethersphere/bee/v2/pkg/transaction/backendmock"
 )
@@ -94,8 +95,9 @@ func TestPostageCreateStamp(t *testing.T) {
 
 		jsonhttptest.Request(t, ts, http.MethodPost, createBatch(initialBalance, depth, label), http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
-				Code:    http.StatusInternalServerError,
-				Message: "cannot create batch",
+				Code:      http.StatusInternalServerError,
+				Message:   "cannot create batch",
+				ErrorCode: "internal_server_error",
 			}),
 		)
 	})
@@ -114,8 +116,9 @@ func TestPostageCreateStamp(t *testing.T) {
 
 		jsonhttptest.Request(t, ts, http.MethodPost, createBatch(initialBalance, depth, label), http.StatusBadRequest,
 			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
-				Code:    http.StatusBadRequest,
-				Message: "out of funds",
+				Code:      http.StatusBadRequest,
+				Message:   "out of funds",
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
@@ -142,6 +145,7 @@ func TestPostageCreateStamp(t *testing.T) {
 						Error: "want min:17",
 					},
 				},
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
@@ -182,8 +186,9 @@ func TestPostageCreateStamp(t *testing.T) {
 
 		jsonhttptest.Request(t, ts, http.MethodPost, createBatch(initialBalance, depth, label), http.StatusServiceUnavailable,
 			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
-				Message: "syncing in progress",
-				Code:    503,
+				Message:   "syncing in progress",
+				Code:      503,
+				ErrorCode: "service_unavailable",
 			}),
 		)
 	})
@@ -196,8 +201,9 @@ func TestPostageCreateStamp(t *testing.T) {
 
 		jsonhttptest.Request(t, ts, http.MethodPost, createBatch(initialBalance, depth, label), http.StatusServiceUnavailable,
 			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
-				Message: "syncing failed",
-				Code:    503,
+				Message:   "syncing failed",
+				Code:      503,
+				ErrorCode: "service_unavailable",
 			}),
 		)
 	})
@@ -256,8 +262,9 @@ func TestPostageGetStamps(t *testing.T) {
 
 		jsonhttptest.Request(t, ts, http.MethodGet, "/stamps/"+hex.EncodeToString(eb.ID), http.StatusNotFound,
 			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
-				Message: "issuer does not exist",
-				Code:    404,
+				Message:   "issuer does not exist",
+				Code:      404,
+				ErrorCode: api.ErrorCodeBatchNotFound,
 			}),
 		)
 	})
@@ -504,8 +511,9 @@ func TestPostageTopUpStamp(t *testing.T) {
 
 		jsonhttptest.Request(t, ts, http.MethodPatch, topupBatch(batchOkStr, topupAmount), http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
-				Code:    http.StatusInternalServerError,
-				Message: "cannot topup batch",
+				Code:      http.StatusInternalServerError,
+				Message:   "cannot topup batch",
+				ErrorCode: "internal_server_error",
 			}),
 		)
 	})
@@ -524,8 +532,9 @@ func TestPostageTopUpStamp(t *testing.T) {
 
 		jsonhttptest.Request(t, ts, http.MethodPatch, topupBatch(batchOkStr, topupAmount), http.StatusPaymentRequired,
 			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
-				Code:    http.StatusPaymentRequired,
-				Message: "out of funds",
+				Code:      http.StatusPaymentRequired,
+				Message:   "out of funds",
+				ErrorCode: "payment_required",
 			}),
 		)
 	})
@@ -634,8 +643,9 @@ func TestPostageDiluteStamp(t *testing.T) {
 
 		jsonhttptest.Request(t, ts, http.MethodPatch, diluteBatch(batchOkStr, newBatchDepth), http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
-				Code:    http.StatusInternalServerError,
-				Message: "cannot dilute batch",
+				Code:      http.StatusInternalServerError,
+				Message:   "cannot dilute batch",
+				ErrorCode: "internal_server_error",
 			}),
 		)
 	})
@@ -654,8 +664,9 @@ func TestPostageDiluteStamp(t *testing.T) {
 
 		jsonhttptest.Request(t, ts, http.MethodPatch, diluteBatch(batchOkStr, newBatchDepth), http.StatusBadRequest,
 			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
-				Code:    http.StatusBadRequest,
-				Message: "invalid depth",
+				Code:      http.StatusBadRequest,
+				Message:   "invalid depth",
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
@@ -685,6 +696,120 @@ func TestPostageDiluteStamp(t *testing.T) {
 	})
 }
 
+func TestPostageTransferStamp(t *testing.T) {
+	t.Parallel()
+
+	txHash := common.HexToHash("0x1234")
+	newOwner := common.HexToAddress("0xbe2f2cfa0e6b2e87d2d2f1a4f3c7a4a7d9b3e1c5")
+	transferBatch := func(id string) string {
+		return fmt.Sprintf("/stamps/%s/transfer", id)
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		contract := contractMock.New(
+			contractMock.WithTransferBatchFunc(func(ctx context.Context, id []byte, to common.Address) (common.Hash, error) {
+				if !bytes.Equal(id, batchOk) {
+					return common.Hash{}, errors.New("incorrect batch ID in call")
+				}
+				if to != newOwner {
+					return common.Hash{}, fmt.Errorf("called with wrong new owner. wanted %s, got %s", newOwner, to)
+				}
+				return txHash, nil
+			}),
+		)
+		ts, _, _, _ := newTestServer(t, testServerOptions{
+			PostageContract: contract,
+		})
+
+		jsonhttptest.Request(t, ts, http.MethodPost, transferBatch(batchOkStr), http.StatusAccepted,
+			jsonhttptest.WithJSONRequestBody(map[string]string{"to": newOwner.Hex()}),
+			jsonhttptest.WithExpectedJSONResponse(&api.PostageCreateResponse{
+				BatchID: batchOk,
+				TxHash:  txHash.String(),
+			}),
+		)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		t.Parallel()
+
+		ts, _, _, _ := newTestServer(t, testServerOptions{
+			PostageContract: contractMock.New(),
+		})
+
+		jsonhttptest.Request(t, ts, http.MethodPost, transferBatch(batchOkStr), http.StatusBadRequest,
+			jsonhttptest.WithJSONRequestBody(map[string]string{"to": "not an address"}),
+			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
+				Code:      http.StatusBadRequest,
+				Message:   "invalid transfer request",
+				ErrorCode: "bad_request",
+			}),
+		)
+	})
+
+	for _, tc := range []struct {
+		name    string
+		err     error
+		code    int
+		message string
+	}{
+		{
+			name:    "batch not found",
+			err:     storage.ErrNotFound,
+			code:    http.StatusNotFound,
+			message: "batch not found",
+		},
+		{
+			name:    "not batch owner",
+			err:     postagecontract.ErrNotBatchOwner,
+			code:    http.StatusForbidden,
+			message: "not batch owner",
+		},
+		{
+			name:    "invalid new owner",
+			err:     postagecontract.ErrInvalidNewOwner,
+			code:    http.StatusBadRequest,
+			message: "invalid new owner",
+		},
+		{
+			name:    "not supported by the contract",
+			err:     postagecontract.ErrNotImplemented,
+			code:    http.StatusNotImplemented,
+			message: "batch transfer not supported by the contract",
+		},
+		{
+			name:    "with-error",
+			err:     errors.New("err"),
+			code:    http.StatusInternalServerError,
+			message: "cannot transfer batch",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			contract := contractMock.New(
+				contractMock.WithTransferBatchFunc(func(context.Context, []byte, common.Address) (common.Hash, error) {
+					return common.Hash{}, tc.err
+				}),
+			)
+			ts, _, _, _ := newTestServer(t, testServerOptions{
+				PostageContract: contract,
+			})
+
+			jsonhttptest.Request(t, ts, http.MethodPost, transferBatch(batchOkStr), tc.code,
+				jsonhttptest.WithJSONRequestBody(map[string]string{"to": newOwner.Hex()}),
+				jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
+					Code:      tc.code,
+					Message:   tc.message,
+					ErrorCode: jsonhttp.DefaultErrorCode(tc.code),
+				}),
+			)
+		})
+	}
+}
+
 // Tests the postageAccessHandler middleware for any set of operations that are guarded
 // by the postage semaphore
 func TestPostageAccessHandler(t *testing.T) {
@@ -740,8 +865,9 @@ func TestPostageAccessHandler(t *testing.T) {
 			url:      "/stamps/1000/24?label=test",
 			respCode: http.StatusTooManyRequests,
 			resp: &jsonhttp.StatusResponse{
-				Code:    http.StatusTooManyRequests,
-				Message: "simultaneous on-chain operations not supported",
+				Code:      http.StatusTooManyRequests,
+				Message:   "simultaneous on-chain operations not supported",
+				ErrorCode: "too_many_requests",
 			},
 		},
 		{
@@ -750,8 +876,9 @@ func TestPostageAccessHandler(t *testing.T) {
 			url:      fmt.Sprintf("/stamps/topup/%s/10", batchOkStr),
 			respCode: http.StatusTooManyRequests,
 			resp: &jsonhttp.StatusResponse{
-				Code:    http.StatusTooManyRequests,
-				Message: "simultaneous on-chain operations not supported",
+				Code:      http.StatusTooManyRequests,
+				Message:   "simultaneous on-chain operations not supported",
+				ErrorCode: "too_many_requests",
 			},
 		},
 		{
@@ -760,8 +887,9 @@ func TestPostageAccessHandler(t *testing.T) {
 			url:      fmt.Sprintf("/stamps/dilute/%s/18", batchOkStr),
 			respCode: http.StatusTooManyRequests,
 			resp: &jsonhttp.StatusResponse{
-				Code:    http.StatusTooManyRequests,
-				Message: "simultaneous on-chain operations not supported",
+				Code:      http.StatusTooManyRequests,
+				Message:   "simultaneous on-chain operations not supported",
+				ErrorCode: "too_many_requests",
 			},
 		},
 	}
@@ -832,6 +960,7 @@ func Test_postageCreateHandler_invalidInputs(t *testing.T) {
 					Error: "invalid value",
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:   "depth - invalid value",
@@ -846,6 +975,7 @@ func Test_postageCreateHandler_invalidInputs(t *testing.T) {
 					Error: strconv.ErrSyntax.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -880,6 +1010,7 @@ func Test_postageGetStampBucketsHandler_invalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "batch_id - invalid hex character",
@@ -893,6 +1024,7 @@ func Test_postageGetStampBucketsHandler_invalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "batch_id - invalid length",
@@ -906,6 +1038,7 @@ func Test_postageGetStampBucketsHandler_invalidInputs(t *testing.T) {
 					Error: "want len:32",
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -941,6 +1074,7 @@ func Test_postageGetStampHandler_invalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "batch_id - invalid hex character",
@@ -954,6 +1088,7 @@ func Test_postageGetStampHandler_invalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "batch_id - invalid length",
@@ -967,6 +1102,7 @@ func Test_postageGetStampHandler_invalidInputs(t *testing.T) {
 					Error: "want len:32",
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -1005,6 +1141,7 @@ func Test_postageTopUpHandler_invalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "batch_id - invalid hex character",
@@ -1019,6 +1156,7 @@ func Test_postageTopUpHandler_invalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "batch_id - invalid length",
@@ -1033,6 +1171,7 @@ func Test_postageTopUpHandler_invalidInputs(t *testing.T) {
 					Error: "want len:32",
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "amount - invalid value",
@@ -1047,6 +1186,7 @@ func Test_postageTopUpHandler_invalidInputs(t *testing.T) {
 					Error: "invalid value",
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -1084,6 +1224,7 @@ func Test_postageDiluteHandler_invalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "batch_id - invalid hex character",
@@ -1098,6 +1239,7 @@ func Test_postageDiluteHandler_invalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "batch_id - invalid length",
@@ -1112,6 +1254,7 @@ func Test_postageDiluteHandler_invalidInputs(t *testing.T) {
 					Error: "want len:32",
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "depth - invalid syntax",
@@ -1126,6 +1269,7 @@ func Test_postageDiluteHandler_invalidInputs(t *testing.T) {
 					Error: strconv.ErrSyntax.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
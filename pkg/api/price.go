@@ -0,0 +1,123 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/bigint"
+	"github.com/ethersphere/bee/v2/pkg/file/joiner"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	"github.com/gorilla/mux"
+)
+
+// priceSamples is the number of the sampled chunk addresses the average
+// retrieval price of the chunk is estimated from.
+const priceSamples = 128
+
+var errNoPricedPeers = errors.New("no peers to price the retrieval")
+
+// ChunkPricer returns the price the peer charges for the chunk.
+type ChunkPricer interface {
+	PeerPrice(peer, chunk swarm.Address) uint64
+}
+
+// ExchangeRater returns the current exchange rate of the accounting units
+// to PLUR and the deduction.
+type ExchangeRater interface {
+	CurrentRates() (exchangeRate, deduction *big.Int, err error)
+}
+
+type PriceResponse struct {
+	Reference  swarm.Address  `json:"reference"`
+	Size       int64          `json:"size"`
+	Chunks     int64          `json:"chunks"`
+	ChunkPrice uint64         `json:"chunkPrice"`
+	Cost       *bigint.BigInt `json:"cost"`
+	CostBZZ    *bigint.BigInt `json:"costBZZ,omitempty"`
+}
+
+// priceHandler estimates the bandwidth cost of the retrieval of the content
+// from its size and the prices of the connected peers. Only the root chunk
+// is retrieved. The cost is in the accounting units and in PLUR if the
+// exchange rate is known.
+func (s *Service) priceHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_price").Build()
+
+	paths := struct {
+		Reference swarm.Address `map:"reference,resolve" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	_, size, err := joiner.New(r.Context(), s.storer.Download(true), s.storer.Cache(), paths.Reference, redundancy.DefaultLevel)
+	if err != nil {
+		logger.Debug("get root chunk failed", "reference", paths.Reference, "error", err)
+		logger.Error(nil, "get root chunk failed")
+		jsonhttp.NotFound(w, "content not found")
+		return
+	}
+
+	chunkPrice, err := s.chunkPrice(paths.Reference)
+	if err != nil {
+		logger.Debug("estimate chunk price failed", "reference", paths.Reference, "error", err)
+		logger.Error(nil, "estimate chunk price failed")
+		jsonhttp.ServiceUnavailable(w, "no peers to estimate the price")
+		return
+	}
+
+	chunks := CalculateNumberOfChunks(size, len(paths.Reference.Bytes()) == swarm.HashSize*2)
+	cost := new(big.Int).Mul(big.NewInt(chunks), new(big.Int).SetUint64(chunkPrice))
+
+	resp := PriceResponse{
+		Reference:  paths.Reference,
+		Size:       size,
+		Chunks:     chunks,
+		ChunkPrice: chunkPrice,
+		Cost:       bigint.Wrap(cost),
+	}
+	if s.exchangeRater != nil {
+		if rate, _, err := s.exchangeRater.CurrentRates(); err == nil {
+			resp.CostBZZ = bigint.Wrap(new(big.Int).Mul(cost, rate))
+		} else {
+			logger.Debug("exchange rate not available", "error", err)
+		}
+	}
+	jsonhttp.OK(w, resp)
+}
+
+// chunkPrice returns the average price of the chunk retrieval charged by
+// the peers the chunks are retrieved from. The chunk addresses are sampled
+// deterministically from the reference, so that the estimate is stable
+// while the topology does not change.
+func (s *Service) chunkPrice(reference swarm.Address) (uint64, error) {
+	var total, n uint64
+	for i := 0; i < priceSamples; i++ {
+		h := sha256.Sum256(append(reference.Bytes(), byte(i)))
+		addr := swarm.NewAddress(h[:])
+
+		peer, err := s.topologyDriver.ClosestPeer(addr, false, topology.Select{Reachable: true})
+		if err != nil {
+			if errors.Is(err, topology.ErrNotFound) {
+				continue
+			}
+			return 0, err
+		}
+		total += s.chunkPricer.PeerPrice(peer, addr)
+		n++
+	}
+	if n == 0 {
+		return 0, errNoPricedPeers
+	}
+	return total / n, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/bigint"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	pricermock "github.com/ethersphere/bee/v2/pkg/pricer/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	topologymock "github.com/ethersphere/bee/v2/pkg/topology/mock"
+)
+
+type exchangeRaterFunc func() (*big.Int, *big.Int, error)
+
+func (f exchangeRaterFunc) CurrentRates() (*big.Int, *big.Int, error) { return f() }
+
+func TestPrice(t *testing.T) {
+	t.Parallel()
+
+	const peerPrice = 10
+
+	var (
+		storer = mockstorer.New()
+		rate   = exchangeRaterFunc(func() (*big.Int, *big.Int, error) {
+			return big.NewInt(100), big.NewInt(0), nil
+		})
+		data = bytes.Repeat([]byte{1}, 2*swarm.ChunkSize)
+	)
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer:        storer,
+		Post:          mockpost.New(mockpost.WithAcceptAll()),
+		ChunkPricer:   pricermock.NewMockService(0, peerPrice),
+		ExchangeRater: rate,
+		TopologyOpts:  []topologymock.Option{topologymock.WithClosestPeer(swarm.RandAddress(t))},
+	})
+
+	var upload api.BytesPostResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+		jsonhttptest.WithUnmarshalJSONResponse(&upload),
+	)
+
+	// two data chunks and the root chunk
+	jsonhttptest.Request(t, client, http.MethodGet, "/price/"+upload.Reference.String(), http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.PriceResponse{
+			Reference:  upload.Reference,
+			Size:       int64(len(data)),
+			Chunks:     3,
+			ChunkPrice: peerPrice,
+			Cost:       bigint.Wrap(big.NewInt(3 * peerPrice)),
+			CostBZZ:    bigint.Wrap(big.NewInt(3 * peerPrice * 100)),
+		}),
+	)
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/price/"+swarm.RandAddress(t).String(), http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message:   "content not found",
+				Code:      http.StatusNotFound,
+				ErrorCode: "not_found",
+			}),
+		)
+	})
+
+	t.Run("no exchange rate", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			Storer:      storer,
+			ChunkPricer: pricermock.NewMockService(0, peerPrice),
+			ExchangeRater: exchangeRaterFunc(func() (*big.Int, *big.Int, error) {
+				return nil, nil, errors.New("exchange rate not yet available")
+			}),
+			TopologyOpts: []topologymock.Option{topologymock.WithClosestPeer(swarm.RandAddress(t))},
+		})
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/price/"+upload.Reference.String(), http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.PriceResponse{
+				Reference:  upload.Reference,
+				Size:       int64(len(data)),
+				Chunks:     3,
+				ChunkPrice: peerPrice,
+				Cost:       bigint.Wrap(big.NewInt(3 * peerPrice)),
+			}),
+		)
+	})
+
+	t.Run("no peers", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			Storer:       storer,
+			ChunkPricer:  pricermock.NewMockService(0, peerPrice),
+			TopologyOpts: []topologymock.Option{topologymock.WithClosestPeerErr(topology.ErrNotFound)},
+		})
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/price/"+upload.Reference.String(), http.StatusServiceUnavailable)
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	testingc "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+)
+
+func TestPriorityHeader(t *testing.T) {
+	t.Parallel()
+
+	storerMock := mockstorer.New()
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: storerMock,
+	})
+
+	chunk := testingc.GenerateTestRandomChunk()
+	if err := storerMock.Cache().Put(context.Background(), chunk); err != nil {
+		t.Fatal(err)
+	}
+	resource := "/chunks/" + chunk.Address().String()
+
+	for _, class := range []string{"interactive", "Prefetch", "background"} {
+		jsonhttptest.Request(t, client, http.MethodGet, resource, http.StatusOK,
+			jsonhttptest.WithRequestHeader(api.SwarmPriorityHeader, class),
+			jsonhttptest.WithExpectedResponse(chunk.Data()),
+		)
+	}
+
+	jsonhttptest.Request(t, client, http.MethodGet, resource, http.StatusBadRequest,
+		jsonhttptest.WithRequestHeader(api.SwarmPriorityHeader, "urgent"),
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:      http.StatusBadRequest,
+			Message:   "invalid priority class",
+			ErrorCode: "bad_request",
+		}),
+	)
+}
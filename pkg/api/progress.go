@@ -0,0 +1,87 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ethersphere/bee/v2/pkg/traversal"
+)
+
+// ndjsonContentType is the media type of the streamed traversal progress.
+const ndjsonContentType = "application/x-ndjson"
+
+// TraversalProgressResponse is the line of the streamed traversal progress.
+type TraversalProgressResponse struct {
+	Chunks int `json:"chunks"`
+	Files  int `json:"files"`
+}
+
+// withTraversalProgress returns the response writer and the request with
+// which the progress of the traversals of the handler is streamed as newline
+// delimited JSON, if the client accepts it. The response of the handler is
+// written as the last line of the stream.
+func withTraversalProgress(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, *http.Request) {
+	if !strings.Contains(r.Header.Get("Accept"), ndjsonContentType) {
+		return w, r
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return w, r
+	}
+	pw := &progressWriter{ResponseWriter: w, flusher: flusher}
+	return pw, r.WithContext(traversal.WithProgress(r.Context(), pw.progress))
+}
+
+// progressWriter streams the traversal progress ahead of the response.
+// Once the progress is streamed, the status code of the response is only
+// carried in its body as the status is already sent.
+type progressWriter struct {
+	http.ResponseWriter
+	flusher http.Flusher
+
+	mu      sync.Mutex
+	started bool
+}
+
+func (pw *progressWriter) progress(p traversal.Progress) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	if !pw.started {
+		pw.started = true
+		pw.Header().Set(ContentTypeHeader, ndjsonContentType)
+		pw.ResponseWriter.WriteHeader(http.StatusOK)
+	}
+	// the failed writes are noticed by the handler writing the response
+	_ = json.NewEncoder(pw.ResponseWriter).Encode(TraversalProgressResponse{
+		Chunks: p.Chunks,
+		Files:  p.Files,
+	})
+	pw.flusher.Flush()
+}
+
+func (pw *progressWriter) WriteHeader(statusCode int) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	if !pw.started {
+		pw.ResponseWriter.WriteHeader(statusCode)
+	}
+}
+
+func (pw *progressWriter) Write(b []byte) (int, error) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	return pw.ResponseWriter.Write(b)
+}
+
+func (pw *progressWriter) Flush() {
+	pw.flusher.Flush()
+}
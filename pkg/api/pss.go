@@ -85,9 +85,9 @@ func (s *Service) pssPostHandler(w http.ResponseWriter, r *http.Request) {
 		logger.Error(nil, "get postage batch issuer failed")
 		switch {
 		case errors.Is(err, postage.ErrNotFound):
-			jsonhttp.BadRequest(w, "batch not found")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch not found"))
 		case errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.BadRequest(w, "batch not usable yet")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet"))
 		default:
 			jsonhttp.BadRequest(w, "postage stamp issuer")
 		}
@@ -102,7 +102,7 @@ func (s *Service) pssPostHandler(w http.ResponseWriter, r *http.Request) {
 		logger.Error(nil, "send payload failed")
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
-			jsonhttp.PaymentRequired(w, "batch is overissued")
+			jsonhttp.PaymentRequired(w, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
 		default:
 			jsonhttp.InternalServerError(w, "pss send failed")
 		}
@@ -214,6 +214,7 @@ func TestPssSend(t *testing.T) {
 						Error: api.HexInvalidByteError('g').Error(),
 					},
 				},
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
@@ -416,6 +417,7 @@ func TestPssPostHandlerInvalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "targets - odd length hex string",
@@ -430,6 +432,7 @@ func TestPssPostHandlerInvalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -478,6 +481,10 @@ func (m *mpss) SetPushSyncer(pushSyncer pushsync.PushSyncer) {
 	panic("not implemented") // TODO: Implement
 }
 
+func (m *mpss) RotateKey(_ *ecdsa.PrivateKey, _ time.Duration) {
+	panic("not implemented") // TODO: Implement
+}
+
 func (m *mpss) Close() error {
 	panic("not implemented") // TODO: Implement
 }
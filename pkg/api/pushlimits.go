@@ -0,0 +1,68 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/pusher"
+)
+
+// PushLimiter gets and sets the runtime limits of the pushing of the deferred uploads.
+type PushLimiter interface {
+	Limits() pusher.Limits
+	SetLimits(pusher.Limits) error
+}
+
+type pushLimitsResponse struct {
+	// Bandwidth is in megabytes per second.
+	Bandwidth     float64        `json:"bandwidth"`
+	TagPriorities map[uint32]int `json:"tagPriorities"`
+}
+
+func newPushLimitsResponse(l pusher.Limits) pushLimitsResponse {
+	priorities := l.TagPriorities
+	if priorities == nil {
+		priorities = make(map[uint32]int)
+	}
+	return pushLimitsResponse{
+		Bandwidth:     l.Bandwidth,
+		TagPriorities: priorities,
+	}
+}
+
+// pushLimitsGetHandler returns the current pushing limits.
+func (s *Service) pushLimitsGetHandler(w http.ResponseWriter, _ *http.Request) {
+	jsonhttp.OK(w, newPushLimitsResponse(s.pushLimiter.Limits()))
+}
+
+// pushLimitsPutHandler replaces the pushing limits.
+func (s *Service) pushLimitsPutHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_pushsync_limits").Build()
+
+	var req pushLimitsResponse
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid limits")
+		return
+	}
+
+	limits := pusher.Limits{Bandwidth: req.Bandwidth, TagPriorities: req.TagPriorities}
+	if err := s.pushLimiter.SetLimits(limits); err != nil {
+		logger.Debug("set limits failed", "error", err)
+		logger.Error(nil, "set limits failed")
+		if errors.Is(err, pusher.ErrInvalidLimits) {
+			jsonhttp.BadRequest(w, "invalid limits")
+			return
+		}
+		jsonhttp.InternalServerError(w, "set limits failed")
+		return
+	}
+
+	jsonhttp.OK(w, newPushLimitsResponse(s.pushLimiter.Limits()))
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/pusher"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+func TestPushLimits(t *testing.T) {
+	t.Parallel()
+
+	p := pusher.New(1, nil, nil, nil, log.Noop, time.Hour, pusher.DefaultRetryCount)
+	testutil.CleanupCloser(t, p)
+	if err := p.SetLimits(pusher.Limits{Bandwidth: 4}); err != nil {
+		t.Fatal(err)
+	}
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		PushLimiter: p,
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/pushsync/limits", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.PushLimitsResponse{Bandwidth: 4, TagPriorities: map[uint32]int{}}),
+	)
+
+	want := api.PushLimitsResponse{Bandwidth: 1.5, TagPriorities: map[uint32]int{7: 2, 9: -1}}
+	jsonhttptest.Request(t, client, http.MethodPut, "/pushsync/limits", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(want),
+		jsonhttptest.WithExpectedJSONResponse(want),
+	)
+	if have := p.Limits(); have.Bandwidth != 1.5 || have.TagPriorities[7] != 2 || have.TagPriorities[9] != -1 {
+		t.Fatalf("limits not applied: %+v", have)
+	}
+
+	jsonhttptest.Request(t, client, http.MethodPut, "/pushsync/limits", http.StatusBadRequest,
+		jsonhttptest.WithJSONRequestBody(api.PushLimitsResponse{Bandwidth: -1}),
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message:   "invalid limits",
+			Code:      http.StatusBadRequest,
+			ErrorCode: "bad_request",
+		}),
+	)
+	jsonhttptest.Request(t, client, http.MethodPut, "/pushsync/limits", http.StatusBadRequest,
+		jsonhttptest.WithRequestBody(strings.NewReader(`{"tagPriorities":{"tag":1}}`)),
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message:   "invalid limits",
+			Code:      http.StatusBadRequest,
+			ErrorCode: "bad_request",
+		}),
+	)
+}
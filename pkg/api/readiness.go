@@ -5,16 +5,53 @@
 package api
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ethersphere/bee/v2"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/transaction"
 )
 
-type ReadyStatusResponse healthStatusResponse
+const (
+	// readinessChainMaxDelay is the maximum age of the latest block
+	// of the synced chain backend.
+	readinessChainMaxDelay = time.Minute
+	// readinessChainTimeout limits the time of the chain sync check.
+	readinessChainTimeout = 5 * time.Second
+)
+
+// ReadinessCriteria are the conditions, in addition to the node being
+// started, the node has to meet to be reported as ready.
+type ReadinessCriteria struct {
+	// MinPeers is the minimum number of the connected peers.
+	MinPeers int
+	// MinDepth is the minimum kademlia neighborhood depth.
+	MinDepth uint8
+	// BatchStoreSynced requires the postage batch store to be synced.
+	BatchStoreSynced bool
+	// ChainSynced requires the chain backend to be synced.
+	ChainSynced bool
+}
+
+// ReadinessFailure is the readiness criterion the node does not meet.
+type ReadinessFailure struct {
+	Criterion string `json:"criterion"`
+	Reason    string `json:"reason"`
+}
 
-func (s *Service) readinessHandler(w http.ResponseWriter, _ *http.Request) {
-	if s.probe.Ready() == ProbeStatusOK {
+type ReadyStatusResponse struct {
+	Status     string             `json:"status"`
+	Version    string             `json:"version"`
+	APIVersion string             `json:"apiVersion"`
+	Failures   []ReadinessFailure `json:"failures,omitempty"`
+}
+
+func (s *Service) readinessHandler(w http.ResponseWriter, r *http.Request) {
+	failures := s.readinessFailures(r.Context())
+	if len(failures) == 0 {
 		jsonhttp.OK(w, ReadyStatusResponse{
 			Status:     "ready",
 			Version:    bee.Version,
@@ -25,6 +62,63 @@ func (s *Service) readinessHandler(w http.ResponseWriter, _ *http.Request) {
 			Status:     "notReady",
 			Version:    bee.Version,
 			APIVersion: Version,
+			Failures:   failures,
 		})
 	}
 }
+
+// readinessFailures returns the readiness criteria the node does not meet.
+func (s *Service) readinessFailures(ctx context.Context) []ReadinessFailure {
+	if s.probe.Ready() != ProbeStatusOK {
+		return []ReadinessFailure{{Criterion: "started", Reason: "node is starting or shutting down"}}
+	}
+
+	var (
+		failures []ReadinessFailure
+		c        = s.readiness
+	)
+	fail := func(criterion, format string, a ...any) {
+		failures = append(failures, ReadinessFailure{Criterion: criterion, Reason: fmt.Sprintf(format, a...)})
+	}
+
+	if c.MinPeers > 0 || c.MinDepth > 0 {
+		if s.topologyDriver == nil {
+			fail("topology", "topology not available")
+		} else {
+			kad := s.topologyDriver.Snapshot()
+			if kad.Connected < c.MinPeers {
+				fail("peers", "%d connected peers, need at least %d", kad.Connected, c.MinPeers)
+			}
+			if kad.Depth < c.MinDepth {
+				fail("depth", "depth %d, need at least %d", kad.Depth, c.MinDepth)
+			}
+		}
+	}
+
+	if c.BatchStoreSynced {
+		if s.syncStatus == nil {
+			fail("batchstore", "batch store not available")
+		} else if synced, err := s.syncStatus(); err != nil {
+			fail("batchstore", "batch store syncing failed: %v", err)
+		} else if !synced {
+			fail("batchstore", "batch store syncing in progress")
+		}
+	}
+
+	if c.ChainSynced {
+		if s.chainBackend == nil {
+			fail("chain", "chain backend not available")
+		} else {
+			ctx, cancel := context.WithTimeout(ctx, readinessChainTimeout)
+			defer cancel()
+			synced, blockTime, err := transaction.IsSynced(ctx, s.chainBackend, readinessChainMaxDelay)
+			if err != nil {
+				fail("chain", "chain backend unavailable: %v", err)
+			} else if !synced {
+				fail("chain", "latest block from %s", blockTime.UTC().Format(time.RFC3339))
+			}
+		}
+	}
+
+	return failures
+}
@@ -5,11 +5,14 @@
 package api_test
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/ethersphere/bee/v2/pkg/api"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	topologymock "github.com/ethersphere/bee/v2/pkg/topology/mock"
 )
 
 func TestReadiness(t *testing.T) {
@@ -51,6 +54,96 @@ func TestReadiness(t *testing.T) {
 				Status:     "notReady",
 				Version:    "-dev",
 				APIVersion: "0.0.0",
+				Failures:   []api.ReadinessFailure{{Criterion: "started", Reason: "node is starting or shutting down"}},
 			}))
 	})
 }
+
+func TestReadinessCriteria(t *testing.T) {
+	t.Parallel()
+
+	notStarted := []api.ReadinessFailure{{Criterion: "started", Reason: "node is starting or shutting down"}}
+
+	for _, tc := range []struct {
+		name     string
+		opts     testServerOptions
+		failures []api.ReadinessFailure
+	}{
+		{
+			name: "no criteria",
+		},
+		{
+			name: "criteria met",
+			opts: testServerOptions{
+				TopologyOpts: []topologymock.Option{
+					topologymock.WithPeers(swarm.RandAddress(t), swarm.RandAddress(t)),
+					topologymock.WithNeighborhoodDepth(3),
+				},
+				Readiness: api.ReadinessCriteria{MinPeers: 2, MinDepth: 3, BatchStoreSynced: true},
+			},
+		},
+		{
+			name: "not enough peers",
+			opts: testServerOptions{
+				TopologyOpts: []topologymock.Option{topologymock.WithPeers(swarm.RandAddress(t))},
+				Readiness:    api.ReadinessCriteria{MinPeers: 2},
+			},
+			failures: []api.ReadinessFailure{{Criterion: "peers", Reason: "1 connected peers, need at least 2"}},
+		},
+		{
+			name: "shallow depth and batch store not synced",
+			opts: testServerOptions{
+				TopologyOpts: []topologymock.Option{topologymock.WithNeighborhoodDepth(1)},
+				SyncStatus:   func() (bool, error) { return false, nil },
+				Readiness:    api.ReadinessCriteria{MinDepth: 2, BatchStoreSynced: true},
+			},
+			failures: []api.ReadinessFailure{
+				{Criterion: "depth", Reason: "depth 1, need at least 2"},
+				{Criterion: "batchstore", Reason: "batch store syncing in progress"},
+			},
+		},
+		{
+			name: "batch store syncing failed",
+			opts: testServerOptions{
+				SyncStatus: func() (bool, error) { return false, errors.New("rpc error") },
+				Readiness:  api.ReadinessCriteria{BatchStoreSynced: true},
+			},
+			failures: []api.ReadinessFailure{{Criterion: "batchstore", Reason: "batch store syncing failed: rpc error"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			probe := api.NewProbe()
+			tc.opts.Probe = probe
+			testServer, _, _, _ := newTestServer(t, tc.opts)
+
+			jsonhttptest.Request(t, testServer, http.MethodGet, "/readiness", http.StatusBadRequest,
+				jsonhttptest.WithExpectedJSONResponse(api.ReadyStatusResponse{
+					Status:     "notReady",
+					Version:    "-dev",
+					APIVersion: "0.0.0",
+					Failures:   notStarted,
+				}))
+
+			probe.SetReady(api.ProbeStatusOK)
+
+			if len(tc.failures) == 0 {
+				jsonhttptest.Request(t, testServer, http.MethodGet, "/readiness", http.StatusOK,
+					jsonhttptest.WithExpectedJSONResponse(api.ReadyStatusResponse{
+						Status:     "ready",
+						Version:    "-dev",
+						APIVersion: "0.0.0",
+					}))
+				return
+			}
+			jsonhttptest.Request(t, testServer, http.MethodGet, "/readiness", http.StatusBadRequest,
+				jsonhttptest.WithExpectedJSONResponse(api.ReadyStatusResponse{
+					Status:     "notReady",
+					Version:    "-dev",
+					APIVersion: "0.0.0",
+					Failures:   tc.failures,
+				}))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+)
+
+// ReadOnlyResponse is the read-only state of the node.
+type ReadOnlyResponse struct {
+	ReadOnly bool `json:"readOnly"`
+}
+
+// readOnlyGetHandler gives back whether the node is in the read-only mode.
+func (s *Service) readOnlyGetHandler(w http.ResponseWriter, _ *http.Request) {
+	jsonhttp.OK(w, ReadOnlyResponse{ReadOnly: s.readOnly.Enabled()})
+}
+
+// readOnlyPutHandler places the node in or out of the read-only mode.
+func (s *Service) readOnlyPutHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_node_readonly").Build()
+
+	queries := struct {
+		Enabled *bool `map:"enabled" validate:"required"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	if s.readOnly.Set(*queries.Enabled) {
+		logger.Info("read-only mode changed", "enabled", *queries.Enabled)
+	}
+	jsonhttp.OK(w, ReadOnlyResponse{ReadOnly: *queries.Enabled})
+}
+
+// checkWritable rejects the requests of the uploads, pins, wallet and chain
+// transactions while the node is in the read-only mode.
+func (s *Service) checkWritable(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := s.readOnly.Check(); err != nil {
+			jsonhttp.ServiceUnavailable(w, jsonhttp.Coded(ErrorCodeReadOnly, err.Error()))
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
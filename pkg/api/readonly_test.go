@@ -0,0 +1,76 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+)
+
+func TestReadOnly(t *testing.T) {
+	t.Parallel()
+
+	const rootHash = "838d0a193ecd1152d1bb1432d5ecc02398533b2494889e23b8bd5ace30ac2aeb"
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer:   mockstorer.New(),
+		Post:     mockpost.New(mockpost.WithAcceptAll()),
+		ReadOnly: true,
+	})
+
+	readOnlyResponse := jsonhttp.StatusResponse{
+		Code:      http.StatusServiceUnavailable,
+		Message:   "node is in read-only mode",
+		ErrorCode: api.ErrorCodeReadOnly,
+	}
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/node/readonly", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.ReadOnlyResponse{ReadOnly: true}),
+	)
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusServiceUnavailable,
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(strings.NewReader("this is a simple text")),
+		jsonhttptest.WithExpectedJSONResponse(readOnlyResponse),
+	)
+	jsonhttptest.Request(t, client, http.MethodPost, "/pins/"+rootHash, http.StatusServiceUnavailable,
+		jsonhttptest.WithExpectedJSONResponse(readOnlyResponse),
+	)
+	jsonhttptest.Request(t, client, http.MethodPost, "/stamps/1000/24", http.StatusServiceUnavailable,
+		jsonhttptest.WithExpectedJSONResponse(readOnlyResponse),
+	)
+	// the downloads keep working
+	jsonhttptest.Request(t, client, http.MethodGet, "/bytes/"+rootHash, http.StatusNotFound)
+
+	jsonhttptest.Request(t, client, http.MethodPut, "/node/readonly?enabled=false", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.ReadOnlyResponse{ReadOnly: false}),
+	)
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(strings.NewReader("this is a simple text")),
+	)
+	jsonhttptest.Request(t, client, http.MethodGet, "/bytes/"+rootHash, http.StatusOK)
+
+	jsonhttptest.Request(t, client, http.MethodPut, "/node/readonly?enabled=true", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.ReadOnlyResponse{ReadOnly: true}),
+	)
+	jsonhttptest.Request(t, client, http.MethodPost, "/pins/"+rootHash, http.StatusServiceUnavailable,
+		jsonhttptest.WithExpectedJSONResponse(readOnlyResponse),
+	)
+
+	t.Run("invalid query", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPut, "/node/readonly", http.StatusBadRequest)
+		jsonhttptest.Request(t, client, http.MethodPut, "/node/readonly?enabled=maybe", http.StatusBadRequest)
+	})
+}
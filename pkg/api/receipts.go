@@ -0,0 +1,106 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+// ReceiptChallenger provides the kept receipts of the pushed chunks
+// and challenges their storers to produce the chunks.
+type ReceiptChallenger interface {
+	Receipt(swarm.Address) (*pushsync.StoredReceipt, error)
+	Challenge(context.Context, swarm.Address) (*pushsync.StoredReceipt, error)
+}
+
+type receiptResponse struct {
+	Address    swarm.Address `json:"address"`
+	Storer     swarm.Address `json:"storer"`
+	Signature  string        `json:"signature"`
+	Nonce      string        `json:"nonce"`
+	Commitment string        `json:"commitment,omitempty"`
+	DataHash   string        `json:"dataHash"`
+	Timestamp  time.Time     `json:"timestamp"`
+}
+
+type receiptChallengeResponse struct {
+	Address  swarm.Address `json:"address"`
+	Storer   swarm.Address `json:"storer"`
+	Verified bool          `json:"verified"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// receiptHandler returns the kept receipt of the pushed chunk.
+func (s *Service) receiptHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_receipt").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	receipt, err := s.receipts.Receipt(paths.Address)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			jsonhttp.NotFound(w, "receipt not found")
+			return
+		}
+		logger.Debug("get receipt failed", "chunk_address", paths.Address, "error", err)
+		logger.Error(nil, "get receipt failed")
+		jsonhttp.InternalServerError(w, "get receipt failed")
+		return
+	}
+
+	jsonhttp.OK(w, receiptResponse{
+		Address:    receipt.Address,
+		Storer:     receipt.Storer,
+		Signature:  hex.EncodeToString(receipt.Signature),
+		Nonce:      hex.EncodeToString(receipt.Nonce),
+		Commitment: hex.EncodeToString(receipt.Commitment),
+		DataHash:   hex.EncodeToString(receipt.DataHash),
+		Timestamp:  receipt.Timestamp,
+	})
+}
+
+// receiptChallengeHandler challenges the storer of the pushed chunk, as
+// identified by the kept receipt, to produce the chunk.
+func (s *Service) receiptChallengeHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_receipt_challenge").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	receipt, err := s.receipts.Challenge(r.Context(), paths.Address)
+	switch {
+	case err == nil:
+		jsonhttp.OK(w, receiptChallengeResponse{Address: receipt.Address, Storer: receipt.Storer, Verified: true})
+	case errors.Is(err, storage.ErrNotFound):
+		jsonhttp.NotFound(w, "receipt not found")
+	case errors.Is(err, pushsync.ErrChallengeFailed):
+		jsonhttp.OK(w, receiptChallengeResponse{Address: receipt.Address, Storer: receipt.Storer, Error: err.Error()})
+	default:
+		logger.Debug("receipt challenge failed", "chunk_address", paths.Address, "error", err)
+		logger.Error(nil, "receipt challenge failed")
+		jsonhttp.InternalServerError(w, "receipt challenge failed")
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+type mockReceipts struct {
+	receipts map[string]*pushsync.StoredReceipt
+	failed   map[string]bool
+}
+
+func (m *mockReceipts) Receipt(addr swarm.Address) (*pushsync.StoredReceipt, error) {
+	r, ok := m.receipts[addr.ByteString()]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return r, nil
+}
+
+func (m *mockReceipts) Challenge(_ context.Context, addr swarm.Address) (*pushsync.StoredReceipt, error) {
+	r, err := m.Receipt(addr)
+	if err != nil {
+		return nil, err
+	}
+	if m.failed[addr.ByteString()] {
+		return r, fmt.Errorf("%w: chunk not found", pushsync.ErrChallengeFailed)
+	}
+	return r, nil
+}
+
+func TestReceipts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		stored  = swarm.MustParseHexAddress("aa00000000000000000000000000000000000000000000000000000000000000")
+		lost    = swarm.MustParseHexAddress("bb00000000000000000000000000000000000000000000000000000000000000")
+		missing = swarm.MustParseHexAddress("cc00000000000000000000000000000000000000000000000000000000000000")
+		storer  = swarm.MustParseHexAddress("ab00000000000000000000000000000000000000000000000000000000000000")
+		ts      = time.Unix(1700000000, 0).UTC()
+	)
+
+	receipts := &mockReceipts{
+		receipts: map[string]*pushsync.StoredReceipt{
+			stored.ByteString(): {Address: stored, Storer: storer, Signature: []byte{1}, Nonce: []byte{2}, Commitment: []byte{3}, DataHash: []byte{4}, Timestamp: ts},
+			lost.ByteString():   {Address: lost, Storer: storer},
+		},
+		failed: map[string]bool{lost.ByteString(): true},
+	}
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Receipts: receipts,
+	})
+
+	t.Run("get", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/receipts/"+stored.String(), http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.ReceiptResponse{
+				Address:    stored,
+				Storer:     storer,
+				Signature:  "01",
+				Nonce:      "02",
+				Commitment: "03",
+				DataHash:   "04",
+				Timestamp:  ts,
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/receipts/"+missing.String(), http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message:   "receipt not found",
+				Code:      http.StatusNotFound,
+				ErrorCode: "not_found",
+			}),
+		)
+	})
+
+	t.Run("challenge", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/receipts/"+stored.String()+"/challenge", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.ReceiptChallengeResponse{
+				Address:  stored,
+				Storer:   storer,
+				Verified: true,
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodPost, "/receipts/"+lost.String()+"/challenge", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.ReceiptChallengeResponse{
+				Address: lost,
+				Storer:  storer,
+				Error:   "storage challenge failed: chunk not found",
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodPost, "/receipts/"+missing.String()+"/challenge", http.StatusNotFound)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodGet, "/receipts/"+stored.String(), http.StatusNotFound)
+	})
+}
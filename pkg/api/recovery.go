@@ -0,0 +1,81 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/recovery"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// recoveryInitiated is the response message of the downloads
+// which initiated the recovery of the missing content.
+const recoveryInitiated = "chunk recovery initiated. retry after sometime."
+
+// recoveryCallback returns the callback sending the recovery request to the
+// pinners registered in the recovery feed of the publisher, if the download
+// asks for the recovery, nil otherwise. The requests are stamped with the
+// batch of the request. The error response is written when it returns false.
+func (s *Service) recoveryCallback(w http.ResponseWriter, r *http.Request, logger log.Logger) (recovery.Callback, bool) {
+	headers := struct {
+		Recovery  bool            `map:"Swarm-Recovery"`
+		Publisher *common.Address `map:"Swarm-Recovery-Publisher"`
+		BatchID   []byte          `map:"Swarm-Postage-Batch-Id"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return nil, false
+	}
+	if !headers.Recovery {
+		return nil, true
+	}
+	if headers.Publisher == nil || len(headers.BatchID) == 0 {
+		jsonhttp.BadRequest(w, "recovery publisher and postage batch id required")
+		return nil, false
+	}
+
+	lookup, err := s.feedFactory.NewLookup(feeds.Sequence, feeds.New(recovery.FeedTopic, *headers.Publisher))
+	if err != nil {
+		logger.Debug("new recovery feed lookup failed", "publisher", headers.Publisher, "error", err)
+		logger.Error(nil, "new recovery feed lookup failed")
+		jsonhttp.InternalServerError(w, "new recovery feed lookup failed")
+		return nil, false
+	}
+
+	return func(ctx context.Context, root swarm.Address) error {
+		issuer, save, err := s.post.GetStampIssuer(headers.BatchID)
+		if err != nil {
+			return fmt.Errorf("get postage batch issuer: %w", err)
+		}
+		stamper := postage.NewStamper(s.stamperStore, issuer, s.signer)
+		err = recovery.NewCallback(s.pss, stamper, lookup)(ctx, root)
+		if serr := save(); serr != nil {
+			err = errors.Join(err, fmt.Errorf("save postage batch issuer: %w", serr))
+		}
+		return err
+	}, true
+}
+
+// recoverContent sends the recovery request of the content with the
+// callback, if any, and reports whether it was sent.
+func recoverContent(ctx context.Context, logger log.Logger, callback recovery.Callback, root swarm.Address) bool {
+	if callback == nil {
+		return false
+	}
+	if err := callback(ctx, root); err != nil {
+		logger.Debug("recovery request failed", "address", root, "error", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
+	"github.com/ethersphere/bee/v2/pkg/feeds/sequence"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/ethersphere/bee/v2/pkg/recovery"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestRecovery(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(privKey)
+	publisher, err := signer.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storer := mockstorer.New()
+	targets := pss.Targets{{0x12}}
+	payload, err := recovery.EncodeTargets(targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updater, err := sequence.NewUpdater(storer.Cache(), signer, recovery.FeedTopic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := updater.Update(context.Background(), time.Now().Unix(), payload); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu          sync.Mutex
+		sentTargets []pss.Targets
+	)
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: storer,
+		Feeds:  factory.New(storer.ChunkStore()),
+		Post:   mockpost.New(mockpost.WithIssuer(postage.NewStampIssuer("", "", batchOk, big.NewInt(3), 11, 10, 1000, true))),
+		Pss: newMockPss(func(_ context.Context, targets pss.Targets, _ swarm.Chunk) error {
+			mu.Lock()
+			defer mu.Unlock()
+			sentTargets = append(sentTargets, targets)
+			return nil
+		}),
+	})
+
+	recoveryHeaders := func(publisher string) []jsonhttptest.Option {
+		return []jsonhttptest.Option{
+			jsonhttptest.WithRequestHeader(api.SwarmRecoveryHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmRecoveryPublisherHeader, publisher),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		}
+	}
+
+	t.Run("chunk", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/chunks/"+swarm.RandAddress(t).String(), http.StatusAccepted,
+			append(recoveryHeaders(publisher.Hex()),
+				jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+					Code:    http.StatusAccepted,
+					Message: "chunk recovery initiated. retry after sometime.",
+				}),
+			)...,
+		)
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/bytes/"+swarm.RandAddress(t).String(), http.StatusAccepted,
+			recoveryHeaders(publisher.Hex())...,
+		)
+	})
+
+	mu.Lock()
+	if len(sentTargets) != 2 {
+		t.Fatalf("got %d recovery requests, want 2", len(sentTargets))
+	}
+	for _, got := range sentTargets {
+		if len(got) != 1 || !bytes.Equal(got[0], targets[0]) {
+			t.Fatalf("got targets %x, want %x", got, targets)
+		}
+	}
+	mu.Unlock()
+
+	t.Run("no targets", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/chunks/"+swarm.RandAddress(t).String(), http.StatusNotFound,
+			recoveryHeaders(swarm.RandAddress(t).String()[:40])...,
+		)
+	})
+
+	t.Run("missing publisher", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/chunks/"+swarm.RandAddress(t).String(), http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmRecoveryHeader, "true"),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusBadRequest,
+				Message:   "recovery publisher and postage batch id required",
+				ErrorCode: "bad_request",
+			}),
+		)
+	})
+
+	t.Run("no recovery", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/chunks/"+swarm.RandAddress(t).String(), http.StatusNotFound)
+	})
+}
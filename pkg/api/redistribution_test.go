@@ -70,8 +70,9 @@ func TestRedistributionStatus(t *testing.T) {
 		})
 		jsonhttptest.Request(t, srv, http.MethodGet, "/redistributionstate", http.StatusBadRequest,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: api.ErrOperationSupportedOnlyInFullMode.Error(),
-				Code:    http.StatusBadRequest,
+				Message:   api.ErrOperationSupportedOnlyInFullMode.Error(),
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
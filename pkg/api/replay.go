@@ -0,0 +1,286 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+)
+
+const (
+	// maxRequestNonceLength is the maximum length of the
+	// Swarm-Request-Nonce header value.
+	maxRequestNonceLength = 255
+	// maxRequestNonces is the maximum number of the nonces remembered
+	// within the window, the signed requests are rejected beyond it.
+	maxRequestNonces = 100_000
+	// defaultRequestSigningWindow is the window used when none is set.
+	defaultRequestSigningWindow = 5 * time.Minute
+	// requestNonceBuckets is the number of the buckets the window is
+	// divided in, the nonces expire together with their bucket.
+	requestNonceBuckets = 10
+	// maxSignedBodyMemory is the size of the signed request bodies kept in
+	// the memory while their hash is checked, the larger ones are kept in
+	// a temporary file.
+	maxSignedBodyMemory = 1 << 20
+)
+
+var (
+	errRequestUnsigned  = errors.New("request is not signed")
+	errRequestSignature = errors.New("invalid request signature")
+	errRequestBody      = errors.New("request body does not match the signature")
+	errRequestExpired   = errors.New("request timestamp out of the window")
+	errRequestReplayed  = errors.New("request replayed")
+	errRequestsTooMany  = errors.New("too many signed requests")
+)
+
+// emptyBodyHash is the hash of the body of the requests signed without
+// the Swarm-Request-Body-Hash header.
+var emptyBodyHash = sha256.Sum256(nil)
+
+// SignRequest returns the hex encoded HMAC-SHA256 with the secret of the
+// request timestamp, nonce, method, URI and the SHA-256 hash of the body,
+// as sent in the Swarm-Request-Signature header prefixed with "sha256=".
+// The timestamp is in the unix seconds and the URI includes the query. The
+// hex encoded body hash is sent in the Swarm-Request-Body-Hash header, it
+// may be omitted for the requests without the body.
+func SignRequest(secret []byte, timestamp, nonce, method, uri string, bodyHash []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(timestamp + "\n" + nonce + "\n" + method + "\n" + uri + "\n" + hex.EncodeToString(bodyHash)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// replayGuard verifies the signed requests and remembers their nonces for
+// the window, so that every signed request is accepted only once.
+type replayGuard struct {
+	secret []byte
+	window time.Duration
+	bucket time.Duration
+
+	mu      sync.Mutex
+	buckets map[int64]map[string]struct{} // nonces by the end of their expiry bucket
+	nonces  int
+}
+
+func newReplayGuard(secret string, window time.Duration) *replayGuard {
+	if window <= 0 {
+		window = defaultRequestSigningWindow
+	}
+	return &replayGuard{
+		secret:  []byte(secret),
+		window:  window,
+		bucket:  max(window/requestNonceBuckets, time.Second),
+		buckets: make(map[int64]map[string]struct{}),
+	}
+}
+
+// verify checks the signature and the freshness of the request and it
+// records its nonce. The body is read to check its hash against the
+// signed one and the request body is replaced with the read body, which
+// the caller must close.
+func (g *replayGuard) verify(r *http.Request, now time.Time) error {
+	var (
+		ts        = r.Header.Get(SwarmRequestTimestampHeader)
+		nonce     = r.Header.Get(SwarmRequestNonceHeader)
+		signature = r.Header.Get(SwarmRequestSignatureHeader)
+		bodyHash  = emptyBodyHash[:]
+	)
+	if ts == "" || nonce == "" || signature == "" {
+		return errRequestUnsigned
+	}
+	if len(nonce) > maxRequestNonceLength {
+		return errRequestSignature
+	}
+	if h := r.Header.Get(SwarmRequestBodyHashHeader); h != "" {
+		b, err := hex.DecodeString(h)
+		if err != nil || len(b) != sha256.Size {
+			return errRequestSignature
+		}
+		bodyHash = b
+	}
+
+	mac, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return errRequestSignature
+	}
+	got, err := hex.DecodeString(mac)
+	if err != nil {
+		return errRequestSignature
+	}
+	want, _ := hex.DecodeString(SignRequest(g.secret, ts, nonce, r.Method, r.URL.RequestURI(), bodyHash))
+	if !hmac.Equal(got, want) {
+		return errRequestSignature
+	}
+
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return errRequestSignature
+	}
+	if d := now.Sub(time.Unix(sec, 0)); d > g.window || d < -g.window {
+		return errRequestExpired
+	}
+
+	// the replayed requests are rejected before their bodies are read
+	if g.seen(nonce, now) {
+		return errRequestReplayed
+	}
+
+	if r.Body == nil {
+		r.Body = http.NoBody
+	}
+	sum, body, err := readBody(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body = body
+	if !bytes.Equal(sum, bodyHash) {
+		return errRequestBody
+	}
+
+	// the request is not accepted after its timestamp leaves the window
+	return g.record(nonce, time.Unix(sec, 0).Add(g.window), now)
+}
+
+// seen reports whether the nonce was recorded.
+func (g *replayGuard) seen(nonce string, now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.expire(now)
+	for _, nonces := range g.buckets {
+		if _, ok := nonces[nonce]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// record records the nonce until the end of the bucket of its expiry.
+func (g *replayGuard) record(nonce string, expiry, now time.Time) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.expire(now)
+	for _, nonces := range g.buckets {
+		if _, ok := nonces[nonce]; ok {
+			return errRequestReplayed
+		}
+	}
+	if g.nonces >= maxRequestNonces {
+		return errRequestsTooMany
+	}
+
+	end := expiry.UnixNano()/int64(g.bucket) + 1
+	nonces, ok := g.buckets[end]
+	if !ok {
+		nonces = make(map[string]struct{})
+		g.buckets[end] = nonces
+	}
+	nonces[nonce] = struct{}{}
+	g.nonces++
+	return nil
+}
+
+// expire removes the buckets which ended before now, so only the few
+// buckets of the window are kept and looked up.
+func (g *replayGuard) expire(now time.Time) {
+	for end, nonces := range g.buckets {
+		if now.UnixNano() >= end*int64(g.bucket) {
+			g.nonces -= len(nonces)
+			delete(g.buckets, end)
+		}
+	}
+}
+
+// readBody reads the body and returns its SHA-256 hash and the read body.
+func readBody(body io.Reader) ([]byte, io.ReadCloser, error) {
+	var (
+		h   = sha256.New()
+		buf bytes.Buffer
+	)
+	n, err := io.CopyN(io.MultiWriter(h, &buf), body, maxSignedBodyMemory+1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, err
+	}
+	if n <= maxSignedBodyMemory {
+		return h.Sum(nil), io.NopCloser(&buf), nil
+	}
+
+	f, err := os.CreateTemp("", "bee-signed-body-")
+	if err != nil {
+		return nil, nil, err
+	}
+	tb := &tempBody{File: f}
+	if _, err := buf.WriteTo(f); err != nil {
+		_ = tb.Close()
+		return nil, nil, err
+	}
+	if _, err := io.Copy(io.MultiWriter(h, f), body); err != nil {
+		_ = tb.Close()
+		return nil, nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		_ = tb.Close()
+		return nil, nil, err
+	}
+	return h.Sum(nil), tb, nil
+}
+
+// tempBody is the request body kept in a temporary
+// file, which is removed when the body is closed.
+type tempBody struct {
+	*os.File
+}
+
+func (b *tempBody) Close() error {
+	return errors.Join(b.File.Close(), os.Remove(b.Name()))
+}
+
+// replayProtection rejects the requests to the destructive endpoints which
+// are not signed with the request signing secret, or which were already
+// made, so that the leaked requests can not be replayed. The requests are
+// passed through when no secret is configured.
+func (s *Service) replayProtection(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.replayGuard == nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		err := s.replayGuard.verify(r, time.Now())
+		if body := r.Body; body != nil {
+			defer func() { _ = body.Close() }()
+		}
+
+		switch {
+		case err == nil:
+			h.ServeHTTP(w, r)
+		case errors.Is(err, errRequestsTooMany):
+			jsonhttp.TooManyRequests(w, err.Error())
+		case errors.Is(err, errRequestUnsigned),
+			errors.Is(err, errRequestSignature),
+			errors.Is(err, errRequestBody),
+			errors.Is(err, errRequestExpired),
+			errors.Is(err, errRequestReplayed):
+			s.logger.Debug("signed request rejected", "method", r.Method, "path", r.URL.Path, "error", err)
+			jsonhttp.Unauthorized(w, err.Error())
+		default:
+			s.logger.Debug("read signed request body failed", "method", r.Method, "path", r.URL.Path, "error", err)
+			jsonhttp.BadRequest(w, "read request body failed")
+		}
+	})
+}
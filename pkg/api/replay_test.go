@@ -0,0 +1,223 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/backup"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/modeswitch"
+	"github.com/ethersphere/bee/v2/pkg/statestore/leveldb"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+)
+
+func TestReplayProtection(t *testing.T) {
+	t.Parallel()
+
+	const secret = "request signing secret"
+
+	store, err := leveldb.NewInMemoryStateStore(log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	for _, k := range []string{"a", "b", "c"} {
+		if err := store.Put(k, rawValue(k)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backupStore, err := leveldbstore.New("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = backupStore.Close() })
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		StateStoreAPI:  store,
+		RequestSigning: secret,
+		ModeSwitcher:   modeswitch.New(modeswitch.Mode{}, true),
+		Reserve:        new(mockReserve),
+		Backup:         backup.New(log.Noop, backupStore, backupStore, "", nil),
+	})
+
+	path := func(k string) string { return "/statestore/" + hex.EncodeToString([]byte(k)) }
+
+	signedBody := func(secret, method, uri, nonce string, ts time.Time, body []byte) jsonhttptest.Option {
+		timestamp := strconv.FormatInt(ts.Unix(), 10)
+		bodyHash := sha256.Sum256(body)
+		return jsonhttptest.WithRequestHeader(api.SwarmRequestSignatureHeader, "sha256="+api.SignRequest([]byte(secret), timestamp, nonce, method, uri, bodyHash[:]))
+	}
+	signed := func(secret, method, uri, nonce string, ts time.Time) jsonhttptest.Option {
+		return signedBody(secret, method, uri, nonce, ts, nil)
+	}
+	headers := func(nonce string, ts time.Time) []jsonhttptest.Option {
+		return []jsonhttptest.Option{
+			jsonhttptest.WithRequestHeader(api.SwarmRequestTimestampHeader, strconv.FormatInt(ts.Unix(), 10)),
+			jsonhttptest.WithRequestHeader(api.SwarmRequestNonceHeader, nonce),
+		}
+	}
+	unauthorized := func(msg string) jsonhttptest.Option {
+		return jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message:   msg,
+			Code:      http.StatusUnauthorized,
+			ErrorCode: "unauthorized",
+		})
+	}
+
+	now := time.Now()
+
+	// the safe requests are not signed
+	jsonhttptest.Request(t, client, http.MethodGet, path("a"), http.StatusOK)
+
+	jsonhttptest.Request(t, client, http.MethodDelete, path("a"), http.StatusUnauthorized,
+		unauthorized("request is not signed"),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodDelete, path("a"), http.StatusOK,
+		append(headers("nonce-1", now), signed(secret, http.MethodDelete, path("a"), "nonce-1", now))...,
+	)
+
+	t.Run("replayed", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodDelete, path("a"), http.StatusUnauthorized,
+			append(headers("nonce-1", now), signed(secret, http.MethodDelete, path("a"), "nonce-1", now), unauthorized("request replayed"))...,
+		)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodDelete, path("b"), http.StatusUnauthorized,
+			append(headers("nonce-2", now), signed("other secret", http.MethodDelete, path("b"), "nonce-2", now), unauthorized("invalid request signature"))...,
+		)
+	})
+
+	t.Run("other request", func(t *testing.T) {
+		// the signature of the request to delete a is used to delete b
+		jsonhttptest.Request(t, client, http.MethodDelete, path("b"), http.StatusUnauthorized,
+			append(headers("nonce-3", now), signed(secret, http.MethodDelete, path("a"), "nonce-3", now), unauthorized("invalid request signature"))...,
+		)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		old := now.Add(-time.Hour)
+		jsonhttptest.Request(t, client, http.MethodDelete, path("c"), http.StatusUnauthorized,
+			append(headers("nonce-4", old), signed(secret, http.MethodDelete, path("c"), "nonce-4", old), unauthorized("request timestamp out of the window"))...,
+		)
+	})
+
+	t.Run("body", func(t *testing.T) {
+		body := []byte("signed body")
+		bodyHash := sha256.Sum256(body)
+		withBodyHash := jsonhttptest.WithRequestHeader(api.SwarmRequestBodyHashHeader, hex.EncodeToString(bodyHash[:]))
+
+		// the body of the request signed without the body
+		jsonhttptest.Request(t, client, http.MethodDelete, path("b"), http.StatusUnauthorized,
+			append(headers("nonce-5", now), signed(secret, http.MethodDelete, path("b"), "nonce-5", now),
+				jsonhttptest.WithRequestBody(bytes.NewReader(body)), unauthorized("request body does not match the signature"))...,
+		)
+		// the body other than the signed one
+		jsonhttptest.Request(t, client, http.MethodDelete, path("b"), http.StatusUnauthorized,
+			append(headers("nonce-6", now), signedBody(secret, http.MethodDelete, path("b"), "nonce-6", now, body), withBodyHash,
+				jsonhttptest.WithRequestBody(bytes.NewReader([]byte("other body"))), unauthorized("request body does not match the signature"))...,
+		)
+		// the hash of the other body
+		jsonhttptest.Request(t, client, http.MethodDelete, path("b"), http.StatusUnauthorized,
+			append(headers("nonce-7", now), signedBody(secret, http.MethodDelete, path("b"), "nonce-7", now, []byte("other body")), withBodyHash,
+				jsonhttptest.WithRequestBody(bytes.NewReader(body)), unauthorized("invalid request signature"))...,
+		)
+		jsonhttptest.Request(t, client, http.MethodDelete, path("b"), http.StatusOK,
+			append(headers("nonce-8", now), signedBody(secret, http.MethodDelete, path("b"), "nonce-8", now, body), withBodyHash,
+				jsonhttptest.WithRequestBody(bytes.NewReader(body)))...,
+		)
+	})
+
+	t.Run("large body", func(t *testing.T) {
+		// the body which is not kept in the memory
+		body := bytes.Repeat([]byte{0xaa}, 3<<20)
+		bodyHash := sha256.Sum256(body)
+		jsonhttptest.Request(t, client, http.MethodDelete, path("c"), http.StatusOK,
+			append(headers("nonce-9", now), signedBody(secret, http.MethodDelete, path("c"), "nonce-9", now, body),
+				jsonhttptest.WithRequestHeader(api.SwarmRequestBodyHashHeader, hex.EncodeToString(bodyHash[:])),
+				jsonhttptest.WithRequestBody(bytes.NewReader(body)))...,
+		)
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, path("b"), http.StatusNotFound)
+	jsonhttptest.Request(t, client, http.MethodGet, path("c"), http.StatusNotFound)
+
+	t.Run("protected routes", func(t *testing.T) {
+		batchID := hex.EncodeToString(make([]byte, 32))
+		for _, tc := range []struct {
+			method string
+			path   string
+		}{
+			{method: http.MethodPost, path: "/stamps/" + batchID + "/transfer"},
+			{method: http.MethodPut, path: "/node/mode"},
+			{method: http.MethodPut, path: "/node/readonly"},
+			{method: http.MethodPost, path: "/reserve/import"},
+			{method: http.MethodPost, path: "/backup"},
+		} {
+			jsonhttptest.Request(t, client, tc.method, tc.path, http.StatusUnauthorized,
+				unauthorized("request is not signed"),
+			)
+		}
+	})
+}
+
+// TestReplayGuardExpiry tests that the nonces are forgotten
+// once the window of their request timestamps passes.
+func TestReplayGuardExpiry(t *testing.T) {
+	t.Parallel()
+
+	const (
+		secret = "request signing secret"
+		window = time.Minute
+	)
+	g := api.NewReplayGuard(secret, window)
+
+	verify := func(nonce string, now time.Time) error {
+		t.Helper()
+		ts := strconv.FormatInt(now.Unix(), 10)
+		bodyHash := sha256.Sum256(nil)
+		r, err := http.NewRequest(http.MethodDelete, "/statestore/00", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set(api.SwarmRequestTimestampHeader, ts)
+		r.Header.Set(api.SwarmRequestNonceHeader, nonce)
+		r.Header.Set(api.SwarmRequestSignatureHeader, "sha256="+api.SignRequest([]byte(secret), ts, nonce, http.MethodDelete, "/statestore/00", bodyHash[:]))
+		return g.Verify(r, now)
+	}
+
+	now := time.Now()
+	for _, nonce := range []string{"nonce-1", "nonce-2", "nonce-3"} {
+		if err := verify(nonce, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := verify("nonce-1", now.Add(window/2)); err == nil {
+		t.Fatal("want replayed request rejected")
+	}
+	if got, want := g.Nonces(), 3; got != want {
+		t.Fatalf("got %d nonces, want %d", got, want)
+	}
+
+	later := now.Add(3 * window)
+	if err := verify("nonce-1", later); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := g.Nonces(), 1; got != want {
+		t.Fatalf("got %d nonces after the window, want %d", got, want)
+	}
+}
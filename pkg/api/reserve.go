@@ -0,0 +1,192 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/soc"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// The reserve import and export endpoints stream the chunks in a compact
+// binary framing. Each chunk is encoded as a frame consisting of:
+//
+//	address     32 bytes
+//	stamp       postage.StampSize bytes
+//	data length 2 bytes, big-endian
+//	data        data length bytes
+const reserveFrameHeaderSize = swarm.HashSize + postage.StampSize + 2
+
+const contentTypeReserveStream = "application/vnd.swarm.reserve-stream"
+
+// ReserveStore provides the reserve functionality
+// required for the reserve import and export.
+type ReserveStore interface {
+	storer.ReserveIterator
+	ReservePutter() storage.Putter
+}
+
+type reserveImportResponse struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+}
+
+var errInvalidReserveFrame = errors.New("invalid reserve frame")
+
+// writeReserveFrame writes a single chunk frame to w.
+func writeReserveFrame(w io.Writer, ch swarm.Chunk) error {
+	if ch.Stamp() == nil {
+		return fmt.Errorf("chunk %s: missing stamp", ch.Address())
+	}
+	if len(ch.Data()) > swarm.SocMaxChunkSize {
+		return fmt.Errorf("chunk %s: %w", ch.Address(), swarm.ErrInvalidChunk)
+	}
+
+	stamp, err := ch.Stamp().MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("chunk %s: marshal stamp: %w", ch.Address(), err)
+	}
+
+	hdr := make([]byte, 0, reserveFrameHeaderSize)
+	hdr = append(hdr, ch.Address().Bytes()...)
+	hdr = append(hdr, stamp...)
+	hdr = binary.BigEndian.AppendUint16(hdr, uint16(len(ch.Data())))
+
+	if _, err := w.Write(hdr); err != nil {
+		return err
+	}
+	_, err = w.Write(ch.Data())
+	return err
+}
+
+// readReserveFrame reads a single chunk frame from r. It
+// returns io.EOF if there are no more frames to read.
+func readReserveFrame(r io.Reader) (swarm.Chunk, error) {
+	hdr := make([]byte, reserveFrameHeaderSize)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errInvalidReserveFrame
+		}
+		return nil, err
+	}
+
+	stamp := new(postage.Stamp)
+	if err := stamp.UnmarshalBinary(hdr[swarm.HashSize : swarm.HashSize+postage.StampSize]); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidReserveFrame, err)
+	}
+
+	size := binary.BigEndian.Uint16(hdr[swarm.HashSize+postage.StampSize:])
+	if int(size) > swarm.SocMaxChunkSize {
+		return nil, errInvalidReserveFrame
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, errInvalidReserveFrame
+	}
+
+	addr := swarm.NewAddress(hdr[:swarm.HashSize])
+	return swarm.NewChunk(addr, data).WithStamp(stamp), nil
+}
+
+// reserveExportHandler streams all the chunks of the reserve.
+func (s *Service) reserveExportHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_reserve_export").Build()
+
+	w.Header().Set(ContentTypeHeader, contentTypeReserveStream)
+	w.WriteHeader(http.StatusOK)
+
+	var (
+		bw    = bufio.NewWriterSize(w, 1<<20)
+		count int
+	)
+	err := s.reserve.ReserveIterateChunks(func(ch swarm.Chunk) (bool, error) {
+		if err := r.Context().Err(); err != nil {
+			return true, err
+		}
+		if err := writeReserveFrame(bw, ch); err != nil {
+			return true, err
+		}
+		count++
+		return false, nil
+	})
+	if err = errors.Join(err, bw.Flush()); err != nil {
+		// The response is already being streamed, the client
+		// is notified about the failure by the truncated stream.
+		logger.Debug("reserve export failed", "exported", count, "error", err)
+		logger.Error(nil, "reserve export failed")
+		return
+	}
+
+	logger.Debug("reserve exported", "exported", count)
+}
+
+// reserveImportHandler stores the streamed chunks in the reserve. Chunks
+// which are invalid, outside of the storage radius, or have an invalid
+// stamp are skipped.
+func (s *Service) reserveImportHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_reserve_import").Build()
+
+	var (
+		br         = bufio.NewReaderSize(r.Body, 1<<20)
+		validStamp = postage.ValidStamp(s.batchStore)
+		putter     = s.reserve.ReservePutter()
+		resp       reserveImportResponse
+	)
+	for {
+		ch, err := readReserveFrame(br)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			logger.Debug("read reserve frame failed", "imported", resp.Imported, "error", err)
+			logger.Error(nil, "read reserve frame failed")
+			jsonhttp.BadRequest(w, fmt.Sprintf("invalid frame after %d imported chunks", resp.Imported))
+			return
+		}
+
+		if !s.storer.IsWithinStorageRadius(ch.Address()) {
+			resp.Skipped++
+			continue
+		}
+		stamped, err := validStamp(ch)
+		if err != nil {
+			logger.Debug("invalid stamp", "chunk_address", ch.Address(), "error", err)
+			resp.Skipped++
+			continue
+		}
+		ch = stamped
+		if !cac.Valid(ch) && !soc.Valid(ch) {
+			logger.Debug("invalid chunk", "chunk_address", ch.Address())
+			resp.Skipped++
+			continue
+		}
+
+		if err := putter.Put(r.Context(), ch); err != nil {
+			if errors.Is(err, storage.ErrOverwriteNewerChunk) {
+				resp.Skipped++
+				continue
+			}
+			logger.Debug("reserve put failed", "chunk_address", ch.Address(), "error", err)
+			logger.Error(nil, "reserve put failed")
+			jsonhttp.InternalServerError(w, fmt.Sprintf("reserve put failed after %d imported chunks", resp.Imported))
+			return
+		}
+		resp.Imported++
+	}
+
+	logger.Debug("reserve imported", "imported", resp.Imported, "skipped", resp.Skipped)
+	jsonhttp.OK(w, resp)
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	mockbatchstore "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	chunktesting "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+type mockReserve struct {
+	mu     sync.Mutex
+	chunks []swarm.Chunk
+}
+
+func (m *mockReserve) ReserveIterateChunks(cb func(swarm.Chunk) (bool, error)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, ch := range m.chunks {
+		if stop, err := cb(ch); stop || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockReserve) ReservePutter() storage.Putter {
+	return storage.PutterFunc(func(_ context.Context, ch swarm.Chunk) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.chunks = append(m.chunks, ch)
+		return nil
+	})
+}
+
+func TestReserveExportImport(t *testing.T) {
+	t.Parallel()
+
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(pk)
+	owner, err := signer.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batch := postagetesting.MustNewBatch(postagetesting.WithOwner(owner.Bytes()))
+	issuer := postage.NewStampIssuer("", "", batch.ID, big.NewInt(3), batch.Depth, batch.BucketDepth, 1000, true)
+	stamper := postage.NewStamper(inmemstore.New(), issuer, signer)
+
+	src := new(mockReserve)
+	for _, ch := range chunktesting.GenerateTestRandomChunks(5) {
+		stamp, err := stamper.Stamp(ch.Address(), ch.Address())
+		if err != nil {
+			t.Fatal(err)
+		}
+		src.chunks = append(src.chunks, ch.WithStamp(stamp))
+	}
+	invalid := chunktesting.GenerateTestRandomChunk().WithStamp(postagetesting.MustNewBatchStamp(batch.ID))
+
+	srcClient, _, _, _ := newTestServer(t, testServerOptions{
+		Storer:  mockstorer.New(),
+		Reserve: src,
+	})
+
+	var exported []byte
+	jsonhttptest.Request(t, srcClient, http.MethodGet, "/reserve/export", http.StatusOK,
+		jsonhttptest.WithPutResponseBody(&exported),
+	)
+
+	t.Run("import", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		buf.Write(exported)
+		if err := api.WriteReserveFrame(&buf, invalid); err != nil {
+			t.Fatal(err)
+		}
+
+		dst := new(mockReserve)
+		dstClient, _, _, _ := newTestServer(t, testServerOptions{
+			Storer:     mockstorer.New(),
+			BatchStore: mockbatchstore.New(mockbatchstore.WithBatch(batch)),
+			Reserve:    dst,
+		})
+
+		jsonhttptest.Request(t, dstClient, http.MethodPost, "/reserve/import", http.StatusOK,
+			jsonhttptest.WithRequestBody(&buf),
+			jsonhttptest.WithExpectedJSONResponse(api.ReserveImportResponse{
+				Imported: len(src.chunks),
+				Skipped:  1,
+			}),
+		)
+
+		if len(dst.chunks) != len(src.chunks) {
+			t.Fatalf("imported chunks: want %d, have %d", len(src.chunks), len(dst.chunks))
+		}
+		for i, ch := range dst.chunks {
+			if !ch.Equal(src.chunks[i]) {
+				t.Fatalf("imported chunk %d: want %s, have %s", i, src.chunks[i].Address(), ch.Address())
+			}
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		t.Parallel()
+
+		dstClient, _, _, _ := newTestServer(t, testServerOptions{
+			Storer:     mockstorer.New(),
+			BatchStore: mockbatchstore.New(mockbatchstore.WithBatch(batch)),
+			Reserve:    new(mockReserve),
+		})
+
+		jsonhttptest.Request(t, dstClient, http.MethodPost, "/reserve/import", http.StatusBadRequest,
+			jsonhttptest.WithRequestBody(bytes.NewReader(exported[:len(exported)-1])),
+		)
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/gorilla/mux"
+)
+
+// RouteGroup is the class of the routes which may be served
+// on its own listener.
+type RouteGroup int
+
+const (
+	// RouteGroupUser are the routes of the public data API.
+	RouteGroupUser RouteGroup = iota
+	// RouteGroupAdmin are the routes of the operator API.
+	RouteGroupAdmin
+	// RouteGroupDebug are the routes of the debugging and profiling.
+	RouteGroupDebug
+
+	numRouteGroups
+)
+
+// String implements the fmt.Stringer interface.
+func (g RouteGroup) String() string {
+	switch g {
+	case RouteGroupUser:
+		return "user"
+	case RouteGroupAdmin:
+		return "admin"
+	case RouteGroupDebug:
+		return "debug"
+	default:
+		return "unknown"
+	}
+}
+
+type routeGroupKey struct{}
+
+// SeparateRouteGroups moves the routes of the groups from the main handler
+// to their own handlers returned by the RouteGroupHandler, so that every
+// group may be served on its own listener. The routes of the separated
+// groups are not registered on the main router, which serves the remaining
+// groups. It must be called before the routes are mounted.
+func (s *Service) SeparateRouteGroups(groups ...RouteGroup) {
+	for _, g := range groups {
+		if g != RouteGroupUser && g < numRouteGroups {
+			s.separatedGroups[g] = true
+		}
+	}
+}
+
+// RouteGroupHandler returns the handler of the separated group routes.
+// The requests to the routes of the other groups are not found.
+func (s *Service) RouteGroupHandler(g RouteGroup) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), routeGroupKey{}, g)))
+	})
+}
+
+// groupRouter returns the router on which the routes of the group are
+// registered.
+func (s *Service) groupRouter(g RouteGroup) *mux.Router {
+	if r := s.groupRouters[g]; r != nil {
+		return r
+	}
+	return s.router
+}
+
+// newGroupRouters creates the routers of the separated groups.
+func (s *Service) newGroupRouters() {
+	s.groupRouters = [numRouteGroups]*mux.Router{}
+	for g, separated := range s.separatedGroups {
+		if !separated {
+			continue
+		}
+		router := mux.NewRouter()
+		router.NotFoundHandler = http.HandlerFunc(jsonhttp.NotFoundHandler)
+		s.groupRouters[g] = router
+	}
+}
+
+// routeRequest dispatches the request to the router of the listener it
+// was received on.
+func (s *Service) routeRequest(w http.ResponseWriter, r *http.Request) {
+	router := s.router
+	if g, ok := r.Context().Value(routeGroupKey{}).(RouteGroup); ok {
+		if router = s.groupRouters[g]; router == nil {
+			jsonhttp.NotFound(w, nil)
+			return
+		}
+	}
+	router.ServeHTTP(w, r)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+)
+
+func TestRouteGroups(t *testing.T) {
+	t.Parallel()
+
+	newService := func(t *testing.T, groups ...api.RouteGroup) *api.Service {
+		t.Helper()
+
+		pk, err := crypto.GenerateSecp256k1Key()
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := api.New(pk.PublicKey, pk.PublicKey, common.Address{}, nil, log.Noop, nil, nil, 1, false, false, nil, []string{"*"}, inmemstore.New())
+		s.SeparateRouteGroups(groups...)
+		s.Configure(crypto.NewDefaultSigner(pk), nil, api.Options{}, api.ExtraOptions{}, 1, nil)
+		s.Mount()
+		s.EnableFullAPI()
+		return s
+	}
+
+	// the routes of every group, the options are served without the dependencies;
+	// the technical routes changing the state of the node are operator routes
+	routes := map[api.RouteGroup][]string{
+		api.RouteGroupUser:  {"/bytes"},
+		api.RouteGroupAdmin: {"/peers", "/node/readonly", "/debug/bundle", "/loggers/a", "/loggers/a/1"},
+		api.RouteGroupDebug: {"/node", "/loggers", "/openapi.json", "/chainstate"},
+	}
+
+	check := func(t *testing.T, name string, h http.Handler, served ...api.RouteGroup) {
+		t.Helper()
+
+		for g, paths := range routes {
+			want := http.StatusNotFound
+			for _, s := range served {
+				if s == g {
+					want = http.StatusNoContent
+				}
+			}
+
+			for _, path := range paths {
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, httptest.NewRequest(http.MethodOptions, path, nil))
+				if got := w.Code; got != want {
+					t.Errorf("%s %s route %s: got status %d, want %d", name, g, path, got, want)
+				}
+			}
+		}
+	}
+
+	t.Run("not separated", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t)
+		check(t, "main", s, api.RouteGroupUser, api.RouteGroupAdmin, api.RouteGroupDebug)
+		check(t, "admin", s.RouteGroupHandler(api.RouteGroupAdmin))
+	})
+
+	t.Run("separated", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, api.RouteGroupAdmin, api.RouteGroupDebug)
+		check(t, "main", s, api.RouteGroupUser)
+		check(t, "admin", s.RouteGroupHandler(api.RouteGroupAdmin), api.RouteGroupAdmin)
+		check(t, "debug", s.RouteGroupHandler(api.RouteGroupDebug), api.RouteGroupDebug)
+	})
+
+	t.Run("separated debug", func(t *testing.T) {
+		t.Parallel()
+
+		s := newService(t, api.RouteGroupDebug)
+		check(t, "main", s, api.RouteGroupUser, api.RouteGroupAdmin)
+		check(t, "debug", s.RouteGroupHandler(api.RouteGroupDebug), api.RouteGroupDebug)
+	})
+}
@@ -36,6 +36,8 @@ func (s *Service) Mount() {
 	router.NotFoundHandler = http.HandlerFunc(jsonhttp.NotFoundHandler)
 
 	s.router = router
+	s.routes = nil
+	s.newGroupRouters()
 
 	s.mountTechnicalDebug()
 	s.mountBusinessDebug()
@@ -45,8 +47,12 @@ func (s *Service) Mount() {
 		httpaccess.NewHTTPAccessLogHandler(s.logger, s.tracer, "api access"),
 		handlers.CompressHandler,
 		s.corsHandler,
+		s.priorityHandler,
+		s.timeoutHandler,
+		s.originHintsHandler,
+		s.downloadModeHandler,
 		web.NoCacheHeadersHandler,
-		web.FinalHandler(router),
+		web.FinalHandlerFunc(s.routeRequest),
 	)
 }
 
@@ -100,31 +106,63 @@ func (s *Service) EnableFullAPI() {
 		s.responseCodeMetricsHandler,
 		s.pageviewMetricsHandler,
 		s.corsHandler,
-		web.FinalHandler(s.router),
+		s.priorityHandler,
+		s.timeoutHandler,
+		s.originHintsHandler,
+		s.downloadModeHandler,
+		web.FinalHandlerFunc(s.routeRequest),
 	)
 }
 
 func (s *Service) mountTechnicalDebug() {
-	s.router.Handle("/node", jsonhttp.MethodHandler{
+	s.handle("/openapi.json", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.openAPIHandler),
+	})
+
+	s.handle("/node", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.nodeGetHandler),
 	})
 
-	s.router.Handle("/addresses", jsonhttp.MethodHandler{
+	if s.modeSwitcher != nil {
+		s.handleAdmin("/node/mode", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.nodeModeGetHandler),
+			"PUT": web.ChainHandlers(
+				s.replayProtection,
+				web.FinalHandlerFunc(s.nodeModeSwitchHandler),
+			),
+		})
+	}
+
+	s.handleAdmin("/node/readonly", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.readOnlyGetHandler),
+		"PUT": web.ChainHandlers(
+			s.replayProtection,
+			web.FinalHandlerFunc(s.readOnlyPutHandler),
+		),
+	})
+
+	if s.configReloader != nil {
+		s.handleAdmin("/config/reload", jsonhttp.MethodHandler{
+			"POST": http.HandlerFunc(s.configReloadHandler),
+		})
+	}
+
+	s.handle("/addresses", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.addressesHandler),
 	})
 
-	s.router.Handle("/chainstate", jsonhttp.MethodHandler{
+	s.handle("/chainstate", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.chainStateHandler),
 	})
 
-	s.router.Handle("/debugstore", jsonhttp.MethodHandler{
+	s.handle("/debugstore", jsonhttp.MethodHandler{
 		"GET": web.ChainHandlers(
 			httpaccess.NewHTTPAccessSuppressLogHandler(),
 			web.FinalHandlerFunc(s.debugStorage),
 		),
 	})
 
-	s.router.Path("/metrics").Handler(web.ChainHandlers(
+	s.handle("/metrics", web.ChainHandlers(
 		httpaccess.NewHTTPAccessSuppressLogHandler(),
 		web.FinalHandler(promhttp.InstrumentMetricHandler(
 			s.metricsRegistry,
@@ -132,52 +170,72 @@ func (s *Service) mountTechnicalDebug() {
 		)),
 	))
 
-	s.router.Handle("/debug/pprof", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	s.handle("/debug/pprof", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		u := r.URL
 		u.Path += "/"
 		http.Redirect(w, r, u.String(), http.StatusPermanentRedirect)
 	}))
 
-	s.router.Handle("/debug/fgprof", fgprof.Handler())
-	s.router.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
-	s.router.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
-	s.router.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
-	s.router.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
-	s.router.PathPrefix("/debug/pprof/").Handler(http.HandlerFunc(pprof.Index))
-	s.router.Handle("/debug/vars", expvar.Handler())
+	s.handle("/debug/fgprof", fgprof.Handler())
+	s.handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
+	s.handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
+	s.handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
+	s.handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
+	s.groupRouter(RouteGroupDebug).PathPrefix("/debug/pprof/").Handler(http.HandlerFunc(pprof.Index))
+	s.handle("/debug/vars", expvar.Handler())
+
+	s.handleAdmin("/debug/bundle", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.debugBundleHandler),
+	})
 
-	s.router.Handle("/loggers", jsonhttp.MethodHandler{
+	s.handle("/loggers", jsonhttp.MethodHandler{
 		"GET": web.ChainHandlers(
 			httpaccess.NewHTTPAccessSuppressLogHandler(),
 			web.FinalHandlerFunc(s.loggerGetHandler),
 		),
 	})
 
-	s.router.Handle("/loggers/{exp}", jsonhttp.MethodHandler{
+	s.handleAdmin("/loggers/{exp}", jsonhttp.MethodHandler{
 		"GET": web.ChainHandlers(
 			httpaccess.NewHTTPAccessSuppressLogHandler(),
 			web.FinalHandlerFunc(s.loggerGetHandler),
 		),
+		"PUT": web.ChainHandlers(
+			httpaccess.NewHTTPAccessSuppressLogHandler(),
+			web.FinalHandlerFunc(s.loggerSetTemporaryVerbosityHandler),
+		),
 	})
 
-	s.router.Handle("/loggers/{exp}/{verbosity}", jsonhttp.MethodHandler{
+	s.handleAdmin("/loggers/{exp}/{verbosity}", jsonhttp.MethodHandler{
 		"PUT": web.ChainHandlers(
 			httpaccess.NewHTTPAccessSuppressLogHandler(),
 			web.FinalHandlerFunc(s.loggerSetVerbosityHandler),
 		),
 	})
 
-	s.router.Handle("/readiness", web.ChainHandlers(
+	s.handle("/readiness", web.ChainHandlers(
 		httpaccess.NewHTTPAccessSuppressLogHandler(),
 		web.FinalHandlerFunc(s.readinessHandler),
 	))
 
-	s.router.Handle("/health", web.ChainHandlers(
+	s.handle("/health", web.ChainHandlers(
 		httpaccess.NewHTTPAccessSuppressLogHandler(),
 		web.FinalHandlerFunc(s.healthHandler),
 	))
 }
 
+// handle registers the handler of the read-only technical debug route.
+func (s *Service) handle(path string, handler http.Handler) {
+	s.groupRouter(RouteGroupDebug).Handle(path, s.route(routeTagDebug, path, handler))
+}
+
+// handleAdmin registers the handler of the technical debug route which
+// changes the state of the node, so that it is served with the operator
+// routes and not with the debugging and profiling ones.
+func (s *Service) handleAdmin(path string, handler http.Handler) {
+	s.groupRouter(RouteGroupAdmin).Handle(path, s.route(routeTagDebug, path, handler))
+}
+
 func (s *Service) checkRouteAvailability(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !s.fullAPIEnabled {
@@ -227,19 +285,28 @@ func (s *Service) mountAPI() {
 
 	// handle is a helper closure which simplifies the router setup.
 	handle := func(path string, handler http.Handler) {
-		routeHandler := s.checkRouteAvailability(handler)
+		routeHandler := s.checkRouteAvailability(s.route(routeTagAPI, path, handler))
 		s.router.Handle(path, routeHandler)
 		s.router.Handle(rootPath+path, routeHandler)
 	}
 
 	handle("/bytes", jsonhttp.MethodHandler{
 		"POST": web.ChainHandlers(
+			s.checkWritable,
+			s.idempotencyMiddleware(),
 			s.contentLengthMetricMiddleware(),
 			s.newTracingHandler("bytes-upload"),
 			web.FinalHandlerFunc(s.bytesUploadHandler),
 		),
 	})
 
+	handle("/hash", jsonhttp.MethodHandler{
+		"POST": web.ChainHandlers(
+			s.contentLengthMetricMiddleware(),
+			web.FinalHandlerFunc(s.hashHandler),
+		),
+	})
+
 	handle("/bytes/{address}", jsonhttp.MethodHandler{
 		"GET": web.ChainHandlers(
 			s.contentLengthMetricMiddleware(),
@@ -255,14 +322,33 @@ func (s *Service) mountAPI() {
 		),
 	})
 
+	handle("/bytes/{address}/delta", jsonhttp.MethodHandler{
+		"POST": web.ChainHandlers(
+			s.checkWritable,
+			s.contentLengthMetricMiddleware(),
+			s.newTracingHandler("bytes-delta-upload"),
+			web.FinalHandlerFunc(s.bytesDeltaUploadHandler),
+		),
+	})
+
 	handle("/chunks", jsonhttp.MethodHandler{
 		"POST": web.ChainHandlers(
+			s.checkWritable,
+			s.idempotencyMiddleware(),
 			jsonhttp.NewMaxBodyBytesHandler(swarm.SocMaxChunkSize),
 			web.FinalHandlerFunc(s.chunkUploadHandler),
 		),
 	})
 
+	handle("/chunks/exists", jsonhttp.MethodHandler{
+		"POST": web.ChainHandlers(
+			jsonhttp.NewMaxBodyBytesHandler(maxExistReferences*(2*swarm.HashSize+8)),
+			web.FinalHandlerFunc(s.chunksExistHandler),
+		),
+	})
+
 	handle("/chunks/stream", web.ChainHandlers(
+		s.checkWritable,
 		s.newTracingHandler("chunks-stream-upload"),
 		web.FinalHandlerFunc(s.chunkUploadStreamHandler),
 	))
@@ -279,12 +365,26 @@ func (s *Service) mountAPI() {
 	})
 
 	handle("/envelope/{address}", jsonhttp.MethodHandler{
-		"POST": http.HandlerFunc(s.envelopePostHandler),
+		"POST": web.ChainHandlers(
+			s.checkWritable,
+			web.FinalHandlerFunc(s.envelopePostHandler),
+		),
+	})
+
+	handle("/soc/batch", jsonhttp.MethodHandler{
+		"POST": web.ChainHandlers(
+			s.checkWritable,
+			s.idempotencyMiddleware(),
+			jsonhttp.NewMaxBodyBytesHandler(maxSOCBatchChunks*maxSOCBatchEntrySize),
+			web.FinalHandlerFunc(s.socBatchUploadHandler),
+		),
 	})
 
 	handle("/soc/{owner}/{id}", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.socGetHandler),
 		"POST": web.ChainHandlers(
+			s.checkWritable,
+			s.idempotencyMiddleware(),
 			jsonhttp.NewMaxBodyBytesHandler(swarm.ChunkWithSpanSize),
 			web.FinalHandlerFunc(s.socUploadHandler),
 		),
@@ -293,26 +393,73 @@ func (s *Service) mountAPI() {
 	handle("/feeds/{owner}/{topic}", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.feedGetHandler),
 		"POST": web.ChainHandlers(
+			s.checkWritable,
+			s.idempotencyMiddleware(),
 			jsonhttp.NewMaxBodyBytesHandler(swarm.ChunkWithSpanSize),
 			web.FinalHandlerFunc(s.feedPostHandler),
 		),
 	})
 
+	handle("/feeds/{owner}/{topic}/snapshot", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.feedSnapshotGetHandler),
+	})
+
+	handle("/sites/{topic}", jsonhttp.MethodHandler{
+		"POST": web.ChainHandlers(
+			s.checkWritable,
+			s.idempotencyMiddleware(),
+			s.contentLengthMetricMiddleware(),
+			web.FinalHandlerFunc(s.siteUploadHandler),
+		),
+	})
+
 	handle("/bzz", jsonhttp.MethodHandler{
 		"POST": web.ChainHandlers(
+			s.checkWritable,
+			s.idempotencyMiddleware(),
 			s.contentLengthMetricMiddleware(),
 			s.newTracingHandler("bzz-upload"),
 			web.FinalHandlerFunc(s.bzzUploadHandler),
 		),
 	})
 
+	handle("/snapshots", jsonhttp.MethodHandler{
+		"POST": web.ChainHandlers(
+			s.checkWritable,
+			web.FinalHandlerFunc(s.snapshotUploadHandler),
+		),
+	})
+
+	handle("/snapshots/files", jsonhttp.MethodHandler{
+		"POST": web.ChainHandlers(
+			s.checkWritable,
+			s.contentLengthMetricMiddleware(),
+			s.newTracingHandler("snapshots-files-upload"),
+			web.FinalHandlerFunc(s.snapshotFileUploadHandler),
+		),
+	})
+
+	handle("/snapshots/{address}", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.snapshotListHandler),
+	})
+
+	handle("/snapshots/{address}/{path:.*}", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.snapshotFileHandler),
+	})
+
 	handle("/grantee", jsonhttp.MethodHandler{
-		"POST": http.HandlerFunc(s.actCreateGranteesHandler),
+		"POST": web.ChainHandlers(
+			s.checkWritable,
+			web.FinalHandlerFunc(s.actCreateGranteesHandler),
+		),
 	})
 
 	handle("/grantee/{address}", jsonhttp.MethodHandler{
-		"GET":   http.HandlerFunc(s.actListGranteesHandler),
-		"PATCH": http.HandlerFunc(s.actGrantRevokeHandler),
+		"GET": http.HandlerFunc(s.actListGranteesHandler),
+		"PATCH": web.ChainHandlers(
+			s.checkWritable,
+			web.FinalHandlerFunc(s.actGrantRevokeHandler),
+		),
 	})
 
 	handle("/bzz/{address}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -323,6 +470,7 @@ func (s *Service) mountAPI() {
 
 	handle("/bzz/{address}/{path:.*}", jsonhttp.MethodHandler{
 		"GET": web.ChainHandlers(
+			s.bzzAccessLogMiddleware(),
 			s.contentLengthMetricMiddleware(),
 			s.newTracingHandler("bzz-download"),
 			s.actDecryptionHandler(),
@@ -330,6 +478,7 @@ func (s *Service) mountAPI() {
 			web.FinalHandlerFunc(s.bzzDownloadHandler),
 		),
 		"HEAD": web.ChainHandlers(
+			s.bzzAccessLogMiddleware(),
 			s.actDecryptionHandler(),
 			web.FinalHandlerFunc(s.bzzHeadHandler),
 		),
@@ -374,23 +523,59 @@ func (s *Service) mountAPI() {
 	})
 
 	handle("/pins/{reference}", jsonhttp.MethodHandler{
-		"GET":    http.HandlerFunc(s.getPinnedRootHash),
-		"POST":   http.HandlerFunc(s.pinRootHash),
-		"DELETE": http.HandlerFunc(s.unpinRootHash),
+		"GET": http.HandlerFunc(s.getPinnedRootHash),
+		"POST": web.ChainHandlers(
+			s.checkWritable,
+			web.FinalHandlerFunc(s.pinRootHash),
+		),
+		"DELETE": web.ChainHandlers(
+			s.checkWritable,
+			web.FinalHandlerFunc(s.unpinRootHash),
+		),
 	},
 	)
 
 	handle("/stewardship/{address}", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.stewardshipGetHandler),
-		"PUT": http.HandlerFunc(s.stewardshipPutHandler),
+		"PUT": web.ChainHandlers(
+			s.checkWritable,
+			web.FinalHandlerFunc(s.stewardshipPutHandler),
+		),
 	})
+
+	handle("/cache/retain/{reference}", jsonhttp.MethodHandler{
+		"POST": http.HandlerFunc(s.cacheRetainHandler),
+	})
+
+	if s.availability != nil {
+		handle("/availability/{reference}", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.availabilityHandler),
+		})
+	}
+
+	if s.chunkPricer != nil && s.topologyDriver != nil {
+		handle("/price/{reference}", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.priceHandler),
+		})
+	}
+
+	if s.receipts != nil {
+		handle("/receipts/{address}", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.receiptHandler),
+		})
+
+		handle("/receipts/{address}/challenge", jsonhttp.MethodHandler{
+			"POST": http.HandlerFunc(s.receiptChallengeHandler),
+		})
+	}
 }
 
 func (s *Service) mountBusinessDebug() {
+	router := s.groupRouter(RouteGroupAdmin)
 	handle := func(path string, handler http.Handler) {
-		routeHandler := s.checkRouteAvailability(handler)
-		s.router.Handle(path, routeHandler)
-		s.router.Handle(rootPath+path, routeHandler)
+		routeHandler := s.checkRouteAvailability(s.route(routeTagDebug, path, handler))
+		router.Handle(path, routeHandler)
+		router.Handle(rootPath+path, routeHandler)
 	}
 
 	if s.transaction != nil {
@@ -399,9 +584,15 @@ func (s *Service) mountBusinessDebug() {
 		})
 
 		handle("/transactions/{hash}", jsonhttp.MethodHandler{
-			"GET":    http.HandlerFunc(s.transactionDetailHandler),
-			"POST":   http.HandlerFunc(s.transactionResendHandler),
-			"DELETE": http.HandlerFunc(s.transactionCancelHandler),
+			"GET": http.HandlerFunc(s.transactionDetailHandler),
+			"POST": web.ChainHandlers(
+				s.checkWritable,
+				web.FinalHandlerFunc(s.transactionResendHandler),
+			),
+			"DELETE": web.ChainHandlers(
+				s.checkWritable,
+				web.FinalHandlerFunc(s.transactionCancelHandler),
+			),
 		})
 	}
 
@@ -409,6 +600,19 @@ func (s *Service) mountBusinessDebug() {
 		"GET": http.HandlerFunc(s.peersHandler),
 	})
 
+	handle("/identity/attestation", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.identityAttestationHandler),
+	})
+
+	if s.keyRotator != nil {
+		handle("/keys/{name}/rotate", jsonhttp.MethodHandler{
+			"POST": web.ChainHandlers(
+				s.replayProtection,
+				web.FinalHandlerFunc(s.keyRotationHandler),
+			),
+		})
+	}
+
 	handle("/pingpong/{address}", jsonhttp.MethodHandler{
 		"POST": http.HandlerFunc(s.pingpongHandler),
 	})
@@ -417,6 +621,19 @@ func (s *Service) mountBusinessDebug() {
 		"GET": http.HandlerFunc(s.reserveStateHandler),
 	})
 
+	if s.reserve != nil {
+		handle("/reserve/export", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.reserveExportHandler),
+		})
+
+		handle("/reserve/import", jsonhttp.MethodHandler{
+			"POST": web.ChainHandlers(
+				s.replayProtection,
+				web.FinalHandlerFunc(s.reserveImportHandler),
+			),
+		})
+	}
+
 	handle("/connect/{multi-address:.+}", jsonhttp.MethodHandler{
 		"POST": http.HandlerFunc(s.peerConnectHandler),
 	})
@@ -425,6 +642,11 @@ func (s *Service) mountBusinessDebug() {
 		"GET": http.HandlerFunc(s.blocklistedPeersHandler),
 	})
 
+	handle("/blocklist/{address}", jsonhttp.MethodHandler{
+		"POST":   http.HandlerFunc(s.blocklistAddHandler),
+		"DELETE": http.HandlerFunc(s.blocklistRemoveHandler),
+	})
+
 	handle("/peers/{address}", jsonhttp.MethodHandler{
 		"DELETE": http.HandlerFunc(s.peerDisconnectHandler),
 	})
@@ -433,6 +655,96 @@ func (s *Service) mountBusinessDebug() {
 		"GET": http.HandlerFunc(s.topologyHandler),
 	})
 
+	if s.topologyEvents != nil {
+		handle("/topology/events", http.HandlerFunc(s.topologyEventsWsHandler))
+	}
+
+	if s.events != nil {
+		handle("/events", http.HandlerFunc(s.eventsWsHandler))
+	}
+
+	if s.scoreboard != nil {
+		handle("/scoreboard", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.scoreboardHandler),
+		})
+	}
+
+	if s.syncLimiter != nil {
+		handle("/pullsync/limits", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.syncLimitsGetHandler),
+			"PUT": web.ChainHandlers(
+				jsonhttp.NewMaxBodyBytesHandler(1024),
+				web.FinalHandlerFunc(s.syncLimitsPutHandler),
+			),
+		})
+	}
+
+	if s.maintenance != nil {
+		handle("/maintenance", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.maintenanceGetHandler),
+			"PUT": web.ChainHandlers(
+				jsonhttp.NewMaxBodyBytesHandler(1024),
+				web.FinalHandlerFunc(s.maintenancePutHandler),
+			),
+		})
+	}
+
+	if s.chaos != nil {
+		handle("/dev/chaos", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.chaosGetHandler),
+			"PUT": web.ChainHandlers(
+				jsonhttp.NewMaxBodyBytesHandler(1024),
+				web.FinalHandlerFunc(s.chaosPutHandler),
+			),
+		})
+	}
+
+	if s.chunkTracer != nil {
+		handle("/debug/chunk-trace/{address}", jsonhttp.MethodHandler{
+			"GET":    http.HandlerFunc(s.chunkTraceGetHandler),
+			"POST":   http.HandlerFunc(s.chunkTracePostHandler),
+			"DELETE": http.HandlerFunc(s.chunkTraceDeleteHandler),
+		})
+	}
+
+	if s.pushLimiter != nil {
+		handle("/pushsync/limits", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.pushLimitsGetHandler),
+			"PUT": web.ChainHandlers(
+				jsonhttp.NewMaxBodyBytesHandler(64*1024),
+				web.FinalHandlerFunc(s.pushLimitsPutHandler),
+			),
+		})
+	}
+
+	if s.syncProgress != nil {
+		handle("/pullsync/status", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.syncProgressHandler),
+		})
+	}
+
+	if s.selfTest != nil {
+		handle("/selftest", jsonhttp.MethodHandler{
+			"POST": http.HandlerFunc(s.selfTestHandler),
+		})
+	}
+
+	if s.peering != nil {
+		handle("/peering", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.peeringGetHandler),
+			"PUT": web.ChainHandlers(
+				jsonhttp.NewMaxBodyBytesHandler(64*1024),
+				web.FinalHandlerFunc(s.peeringPutHandler),
+			),
+		})
+	}
+
+	if s.traffic != nil {
+		handle("/traffic", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.trafficHandler),
+		})
+	}
+
 	handle("/welcome-message", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.getWelcomeMessageHandler),
 		"POST": web.ChainHandlers(
@@ -461,178 +773,213 @@ func (s *Service) mountBusinessDebug() {
 		"GET": http.HandlerFunc(s.settlementsHandlerPseudosettle),
 	})
 
-	handle("/settlements", web.ChainHandlers(
+	handle("/settlements", withMethods(web.ChainHandlers(
 		s.checkSwapAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET": http.HandlerFunc(s.settlementsHandler),
 		}),
-	))
+	), http.MethodGet))
 
-	handle("/settlements/{peer}", web.ChainHandlers(
+	handle("/settlements/{peer}", withMethods(web.ChainHandlers(
 		s.checkSwapAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET": http.HandlerFunc(s.peerSettlementsHandler),
 		}),
-	))
+	), http.MethodGet))
 
-	handle("/chequebook/cheque/{peer}", web.ChainHandlers(
+	handle("/chequebook/cheque/{peer}", withMethods(web.ChainHandlers(
 		s.checkSwapAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET": http.HandlerFunc(s.chequebookLastPeerHandler),
 		}),
-	))
+	), http.MethodGet))
 
-	handle("/chequebook/cheque", web.ChainHandlers(
+	handle("/chequebook/cheque", withMethods(web.ChainHandlers(
 		s.checkSwapAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET": http.HandlerFunc(s.chequebookAllLastHandler),
 		}),
-	))
+	), http.MethodGet))
 
-	handle("/chequebook/cashout/{peer}", web.ChainHandlers(
+	handle("/chequebook/cashout/{peer}", withMethods(web.ChainHandlers(
 		s.checkSwapAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET": http.HandlerFunc(s.swapCashoutStatusHandler),
 			"POST": web.ChainHandlers(
+				s.checkWritable,
 				s.gasConfigMiddleware("swap cashout"),
 				web.FinalHandlerFunc(s.swapCashoutHandler),
 			),
 		}),
-	))
+	), http.MethodGet, http.MethodPost))
 
-	handle("/chequebook/balance", web.ChainHandlers(
+	handle("/chequebook/balance", withMethods(web.ChainHandlers(
 		s.checkChequebookAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET": http.HandlerFunc(s.chequebookBalanceHandler),
 		}),
-	))
+	), http.MethodGet))
 
-	handle("/chequebook/address", web.ChainHandlers(
+	handle("/chequebook/address", withMethods(web.ChainHandlers(
 		s.checkChequebookAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET": http.HandlerFunc(s.chequebookAddressHandler),
 		}),
-	))
+	), http.MethodGet))
 
-	handle("/chequebook/deposit", web.ChainHandlers(
+	handle("/chequebook/deposit", withMethods(web.ChainHandlers(
 		s.checkChequebookAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"POST": web.ChainHandlers(
+				s.checkWritable,
 				s.gasConfigMiddleware("chequebook deposit"),
 				web.FinalHandlerFunc(s.chequebookDepositHandler),
 			),
 		}),
-	))
+	), http.MethodPost))
 
-	handle("/chequebook/withdraw", web.ChainHandlers(
+	handle("/chequebook/withdraw", withMethods(web.ChainHandlers(
 		s.checkChequebookAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"POST": web.ChainHandlers(
+				s.checkWritable,
+				s.replayProtection,
 				s.gasConfigMiddleware("chequebook withdraw"),
 				web.FinalHandlerFunc(s.chequebookWithdrawHandler),
 			),
 		}),
-	))
+	), http.MethodPost))
 
-	handle("/wallet", web.ChainHandlers(
+	handle("/wallet", withMethods(web.ChainHandlers(
 		s.checkChequebookAvailability,
 		s.checkSwapAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET": http.HandlerFunc(s.walletHandler),
 		}),
-	))
+	), http.MethodGet))
 
-	handle("/wallet/withdraw/{coin}", web.ChainHandlers(
+	handle("/wallet/withdraw/{coin}", withMethods(web.ChainHandlers(
 		s.checkChequebookAvailability,
 		s.checkSwapAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"POST": web.ChainHandlers(
+				s.checkWritable,
+				s.replayProtection,
 				s.gasConfigMiddleware("wallet withdraw"),
 				web.FinalHandlerFunc(s.walletWithdrawHandler),
 			),
 		}),
-	))
+	), http.MethodPost))
 
-	handle("/stamps", web.ChainHandlers(
+	handle("/stamps", withMethods(web.ChainHandlers(
 		s.postageSyncStatusCheckHandler,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET": http.HandlerFunc(s.postageGetStampsHandler),
-		})),
+		})), http.MethodGet),
 	)
 
-	handle("/stamps/{batch_id}", web.ChainHandlers(
+	handle("/stamps/{batch_id}", withMethods(web.ChainHandlers(
 		s.postageSyncStatusCheckHandler,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET": http.HandlerFunc(s.postageGetStampHandler),
-		})),
+		})), http.MethodGet),
 	)
 
-	handle("/stamps/{batch_id}/buckets", web.ChainHandlers(
+	handle("/stamps/{batch_id}/buckets", withMethods(web.ChainHandlers(
 		s.postageSyncStatusCheckHandler,
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET": http.HandlerFunc(s.postageGetStampBucketsHandler),
-		})),
+		})), http.MethodGet),
+	)
+
+	handle("/stamps/{batch_id}/transfer", withMethods(web.ChainHandlers(
+		s.checkWritable,
+		s.replayProtection,
+		s.postageAccessHandler,
+		s.postageSyncStatusCheckHandler,
+		s.gasConfigMiddleware("transfer batch"),
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"POST": http.HandlerFunc(s.postageTransferHandler),
+		})), http.MethodPost),
 	)
 
-	handle("/stamps/{amount}/{depth}", web.ChainHandlers(
+	handle("/stamps/{amount}/{depth}", withMethods(web.ChainHandlers(
+		s.checkWritable,
 		s.postageAccessHandler,
 		s.postageSyncStatusCheckHandler,
 		s.gasConfigMiddleware("create batch"),
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"POST": http.HandlerFunc(s.postageCreateHandler),
-		})),
+		})), http.MethodPost),
 	)
 
-	handle("/stamps/topup/{batch_id}/{amount}", web.ChainHandlers(
+	handle("/stamps/topup/{batch_id}/{amount}", withMethods(web.ChainHandlers(
+		s.checkWritable,
 		s.postageAccessHandler,
 		s.postageSyncStatusCheckHandler,
 		s.gasConfigMiddleware("topup batch"),
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"PATCH": http.HandlerFunc(s.postageTopUpHandler),
-		})),
+		})), http.MethodPatch),
 	)
 
-	handle("/stamps/dilute/{batch_id}/{depth}", web.ChainHandlers(
+	handle("/stamps/dilute/{batch_id}/{depth}", withMethods(web.ChainHandlers(
+		s.checkWritable,
 		s.postageAccessHandler,
 		s.postageSyncStatusCheckHandler,
 		s.gasConfigMiddleware("dilute batch"),
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"PATCH": http.HandlerFunc(s.postageDiluteHandler),
-		})),
+		})), http.MethodPatch),
 	)
 
 	handle("/batches", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.postageGetAllBatchesHandler),
 	})
 
+	if s.batchSnapshotter != nil {
+		handle("/batches/snapshot", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.batchSnapshotHandler),
+		})
+	}
+
 	handle("/accounting", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.accountingInfoHandler),
 	})
 
-	handle("/stake/withdrawable", web.ChainHandlers(
+	handle("/stake/withdrawable", withMethods(web.ChainHandlers(
 		s.stakingAccessHandler,
 		s.gasConfigMiddleware("get or withdraw withdrawable stake"),
 		web.FinalHandler(jsonhttp.MethodHandler{
-			"GET":    http.HandlerFunc(s.getWithdrawableStakeHandler),
-			"DELETE": http.HandlerFunc(s.withdrawStakeHandler),
-		})),
+			"GET": http.HandlerFunc(s.getWithdrawableStakeHandler),
+			"DELETE": web.ChainHandlers(
+				s.checkWritable,
+				s.replayProtection,
+				web.FinalHandlerFunc(s.withdrawStakeHandler),
+			),
+		})), http.MethodGet, http.MethodDelete),
 	)
 
-	handle("/stake/{amount}", web.ChainHandlers(
+	handle("/stake/{amount}", withMethods(web.ChainHandlers(
+		s.checkWritable,
 		s.stakingAccessHandler,
 		s.gasConfigMiddleware("deposit stake"),
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"POST": http.HandlerFunc(s.stakingDepositHandler),
 		}),
-	))
+	), http.MethodPost))
 
-	handle("/stake", web.ChainHandlers(
+	handle("/stake", withMethods(web.ChainHandlers(
 		s.stakingAccessHandler,
 		s.gasConfigMiddleware("get or migrate stake"),
 		web.FinalHandler(jsonhttp.MethodHandler{
-			"GET":    http.HandlerFunc(s.getPotentialStake),
-			"DELETE": http.HandlerFunc(s.migrateStakeHandler),
-		})),
+			"GET": http.HandlerFunc(s.getPotentialStake),
+			"DELETE": web.ChainHandlers(
+				s.checkWritable,
+				s.replayProtection,
+				web.FinalHandlerFunc(s.migrateStakeHandler),
+			),
+		})), http.MethodGet, http.MethodDelete),
 	)
 
 	handle("/redistributionstate", jsonhttp.MethodHandler{
@@ -665,4 +1012,56 @@ func (s *Service) mountBusinessDebug() {
 	handle("/rchash/{depth}/{anchor1}/{anchor2}", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.rchash),
 	})
+
+	if s.stateStore != nil {
+		handle("/statestore", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.stateStoreKeysHandler),
+		})
+
+		handle("/statestore/dump", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.stateStoreDumpHandler),
+			"POST": web.ChainHandlers(
+				s.replayProtection,
+				web.FinalHandlerFunc(s.stateStoreRestoreHandler),
+			),
+		})
+
+		handle("/statestore/{key}", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.stateStoreGetHandler),
+			"DELETE": web.ChainHandlers(
+				s.replayProtection,
+				web.FinalHandlerFunc(s.stateStoreDeleteHandler),
+			),
+		})
+	}
+
+	if s.backup != nil {
+		handle("/backup", jsonhttp.MethodHandler{
+			"POST": web.ChainHandlers(
+				s.replayProtection,
+				web.FinalHandlerFunc(s.backupHandler),
+			),
+		})
+	}
+
+	if s.mounter != nil {
+		handle("/mount", jsonhttp.MethodHandler{
+			"GET":    http.HandlerFunc(s.mountsHandler),
+			"POST":   http.HandlerFunc(s.mountHandler),
+			"DELETE": http.HandlerFunc(s.unmountHandler),
+		})
+	}
+
+	if s.stampProxy != nil {
+		handle("/stampproxy/clients", jsonhttp.MethodHandler{
+			"GET":  http.HandlerFunc(s.stampProxyClientsHandler),
+			"POST": http.HandlerFunc(s.stampProxyRegisterHandler),
+		})
+
+		handle("/stampproxy/clients/{id}", jsonhttp.MethodHandler{
+			"GET":    http.HandlerFunc(s.stampProxyClientHandler),
+			"PATCH":  http.HandlerFunc(s.stampProxyUpdateHandler),
+			"DELETE": http.HandlerFunc(s.stampProxyRemoveHandler),
+		})
+	}
 }
@@ -0,0 +1,48 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+type peerScoreResponse struct {
+	Address        swarm.Address `json:"address"`
+	Score          float64       `json:"score"`
+	SuccessRate    float64       `json:"successRate"`
+	LatencySeconds float64       `json:"latencySeconds"`
+	Bandwidth      float64       `json:"bandwidth"`
+	Samples        float64       `json:"samples"`
+	Updated        time.Time     `json:"updated"`
+}
+
+type scoreboardResponse struct {
+	Peers []peerScoreResponse `json:"peers"`
+}
+
+// scoreboardHandler returns the performance scores of the peers
+// used to bias the retrieval and pushsync peer selection.
+func (s *Service) scoreboardHandler(w http.ResponseWriter, _ *http.Request) {
+	scores := s.scoreboard.Snapshot()
+
+	peers := make([]peerScoreResponse, 0, len(scores))
+	for _, p := range scores {
+		peers = append(peers, peerScoreResponse{
+			Address:        p.Address,
+			Score:          p.Score,
+			SuccessRate:    p.SuccessRate,
+			LatencySeconds: p.Latency.Seconds(),
+			Bandwidth:      p.Bandwidth,
+			Samples:        p.Samples,
+			Updated:        p.Updated,
+		})
+	}
+
+	jsonhttp.OK(w, scoreboardResponse{Peers: peers})
+}
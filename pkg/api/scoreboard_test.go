@@ -0,0 +1,55 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/scoreboard"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestScoreboard(t *testing.T) {
+	t.Parallel()
+
+	var (
+		good = swarm.MustParseHexAddress("01")
+		bad  = swarm.MustParseHexAddress("02")
+	)
+
+	board := scoreboard.New(time.Hour)
+	board.Record(good, 100*time.Millisecond, 4096, nil)
+	board.Record(bad, time.Second, 0, errors.New("failed"))
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Scoreboard: board,
+	})
+
+	var resp api.ScoreboardResponse
+	jsonhttptest.Request(t, client, http.MethodGet, "/scoreboard", http.StatusOK,
+		jsonhttptest.WithUnmarshalJSONResponse(&resp),
+	)
+	if len(resp.Peers) != 2 {
+		t.Fatalf("want 2 peers, got %d", len(resp.Peers))
+	}
+	if !resp.Peers[0].Address.Equal(good) || !resp.Peers[1].Address.Equal(bad) {
+		t.Fatalf("unexpected peer order: %s, %s", resp.Peers[0].Address, resp.Peers[1].Address)
+	}
+	if resp.Peers[0].LatencySeconds != 0.1 {
+		t.Fatalf("want latency 0.1s, got %v", resp.Peers[0].LatencySeconds)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodGet, "/scoreboard", http.StatusNotFound)
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/selftest"
+)
+
+// SelfTester runs the active diagnosis of the node.
+type SelfTester interface {
+	Run(context.Context) (*selftest.Report, error)
+}
+
+type selfTestCheckResponse struct {
+	Name    string          `json:"name"`
+	Status  selftest.Status `json:"status"`
+	Details string          `json:"details"`
+	Hint    string          `json:"hint,omitempty"`
+}
+
+type selfTestResponse struct {
+	Status       selftest.Status `json:"status"`
+	Reachability string          `json:"reachability"`
+	Peers        int             `json:"peers"`
+	Volunteers   int             `json:"volunteers"`
+	// UploadThroughput and DownloadThroughput are in bytes per second.
+	UploadThroughput   float64                 `json:"uploadThroughput"`
+	DownloadThroughput float64                 `json:"downloadThroughput"`
+	ClockSkewSeconds   float64                 `json:"clockSkewSeconds"`
+	DurationSeconds    float64                 `json:"durationSeconds"`
+	Checks             []selfTestCheckResponse `json:"checks"`
+}
+
+// selfTestHandler runs the self-test and returns its report.
+func (s *Service) selfTestHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_selftest").Build()
+
+	report, err := s.selfTest.Run(r.Context())
+	if err != nil {
+		logger.Debug("self-test failed", "error", err)
+		if errors.Is(err, selftest.ErrRunning) {
+			jsonhttp.TooManyRequests(w, "self-test already running")
+			return
+		}
+		logger.Error(nil, "self-test failed")
+		jsonhttp.InternalServerError(w, "self-test failed")
+		return
+	}
+
+	resp := selfTestResponse{
+		Status:             report.Status,
+		Reachability:       report.Reachability,
+		Peers:              report.Peers,
+		Volunteers:         report.Volunteers,
+		UploadThroughput:   report.UploadThroughput,
+		DownloadThroughput: report.DownloadThroughput,
+		ClockSkewSeconds:   report.ClockSkew.Seconds(),
+		DurationSeconds:    report.Duration.Seconds(),
+		Checks:             make([]selfTestCheckResponse, 0, len(report.Checks)),
+	}
+	for _, c := range report.Checks {
+		resp.Checks = append(resp.Checks, selfTestCheckResponse{
+			Name:    c.Name,
+			Status:  c.Status,
+			Details: c.Details,
+			Hint:    c.Hint,
+		})
+	}
+
+	jsonhttp.OK(w, resp)
+}
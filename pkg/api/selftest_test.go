@@ -0,0 +1,84 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/selftest"
+)
+
+type mockSelfTester struct {
+	report *selftest.Report
+	err    error
+}
+
+func (m mockSelfTester) Run(context.Context) (*selftest.Report, error) { return m.report, m.err }
+
+func TestSelfTest(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		SelfTest: mockSelfTester{report: &selftest.Report{
+			Status:             selftest.StatusWarning,
+			Reachability:       "Public",
+			Peers:              3,
+			Volunteers:         2,
+			UploadThroughput:   1000,
+			DownloadThroughput: 2000,
+			ClockSkew:          500 * time.Millisecond,
+			Duration:           2 * time.Second,
+			Checks: []selftest.Check{
+				{Name: "reachability-status", Status: selftest.StatusOK, Details: "public"},
+				{Name: "peers", Status: selftest.StatusWarning, Details: "3 connected peers", Hint: "check the firewall"},
+			},
+		}},
+	})
+
+	jsonhttptest.Request(t, client, http.MethodPost, "/selftest", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.SelfTestResponse{
+			Status:             selftest.StatusWarning,
+			Reachability:       "Public",
+			Peers:              3,
+			Volunteers:         2,
+			UploadThroughput:   1000,
+			DownloadThroughput: 2000,
+			ClockSkewSeconds:   0.5,
+			DurationSeconds:    2,
+			Checks: []api.SelfTestCheckResponse{
+				{Name: "reachability-status", Status: selftest.StatusOK, Details: "public"},
+				{Name: "peers", Status: selftest.StatusWarning, Details: "3 connected peers", Hint: "check the firewall"},
+			},
+		}),
+	)
+
+	t.Run("running", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{
+			SelfTest: mockSelfTester{err: selftest.ErrRunning},
+		})
+		jsonhttptest.Request(t, client, http.MethodPost, "/selftest", http.StatusTooManyRequests,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusTooManyRequests,
+				Message:   "self-test already running",
+				ErrorCode: "too_many_requests",
+			}),
+		)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodPost, "/selftest", http.StatusNotFound)
+	})
+}
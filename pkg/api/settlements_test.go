@@ -93,8 +93,9 @@ func TestSettlementsError(t *testing.T) {
 
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/settlements", http.StatusInternalServerError,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrCantSettlements,
-			Code:    http.StatusInternalServerError,
+			Message:   api.ErrCantSettlements,
+			Code:      http.StatusInternalServerError,
+			ErrorCode: "internal_server_error",
 		}),
 	)
 }
@@ -190,6 +191,7 @@ func Test_peerSettlementsHandler_invalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name: "peer - invalid hex character",
@@ -203,6 +205,7 @@ func Test_peerSettlementsHandler_invalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -231,8 +234,9 @@ func TestSettlementsPeersError(t *testing.T) {
 
 	jsonhttptest.Request(t, testServer, http.MethodGet, "/settlements/"+peer, http.StatusInternalServerError,
 		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-			Message: api.ErrCantSettlementsPeer,
-			Code:    http.StatusInternalServerError,
+			Message:   api.ErrCantSettlementsPeer,
+			Code:      http.StatusInternalServerError,
+			ErrorCode: "internal_server_error",
 		}),
 	)
 }
@@ -0,0 +1,270 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"archive/tar"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/soc"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+type siteUploadResponse struct {
+	Reference    swarm.Address `json:"reference"`
+	FeedManifest swarm.Address `json:"feedManifest"`
+}
+
+// siteUploadHandler uploads a collection, publishes it as the next update of
+// the node owned feed with the given topic and returns both the reference of
+// the collection and the reference of the feed manifest which always resolves
+// to the latest published collection.
+func (s *Service) siteUploadHandler(w http.ResponseWriter, r *http.Request) {
+	span, logger, ctx := s.tracer.StartSpanFromContext(r.Context(), "post_sites", s.logger.WithName("post_sites").Build())
+	defer span.Finish()
+
+	paths := struct {
+		Topic []byte `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	headers := struct {
+		ContentType string           `map:"Content-Type,mimeMediaType" validate:"required"`
+		BatchID     []byte           `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		SwarmTag    uint64           `map:"Swarm-Tag"`
+		Pin         bool             `map:"Swarm-Pin"`
+		Deferred    *bool            `map:"Swarm-Deferred-Upload"`
+		Replication uint8            `map:"Swarm-Replication-Factor" validate:"lte=8"`
+		RLevel      redundancy.Level `map:"Swarm-Redundancy-Level"`
+		Snapshot    uint64           `map:"Swarm-Feed-Snapshot" validate:"lte=256"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	owner, err := s.signer.EthereumAddress()
+	if err != nil {
+		logger.Debug("get owner address failed", "error", err)
+		logger.Error(nil, "get owner address failed")
+		jsonhttp.InternalServerError(w, "get owner address failed")
+		return
+	}
+	feed := feeds.New(paths.Topic, owner)
+
+	lookup, err := s.feedFactory.NewLookup(feeds.Sequence, feed)
+	if err != nil {
+		logger.Debug("new lookup failed", "error", err)
+		logger.Error(nil, "new lookup failed")
+		jsonhttp.InternalServerError(w, "new lookup failed")
+		return
+	}
+	_, _, next, err := lookup.At(ctx, time.Now().Unix(), 0)
+	if err != nil {
+		logger.Debug("lookup at failed", "error", err)
+		logger.Error(nil, "lookup at failed")
+		jsonhttp.InternalServerError(w, "lookup at failed")
+		return
+	}
+
+	var (
+		tag      uint64
+		deferred = defaultUploadMethod(headers.Deferred)
+	)
+	if deferred || headers.Pin {
+		tag, err = s.getOrCreateSessionID(headers.SwarmTag)
+		if err != nil {
+			logger.Debug("get or create tag failed", "error", err)
+			logger.Error(nil, "get or create tag failed")
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not found"))
+			default:
+				jsonhttp.InternalServerError(w, "cannot get or create tag")
+			}
+			return
+		}
+	}
+
+	putter, err := s.newStamperPutter(ctx, putterOptions{
+		BatchID:           headers.BatchID,
+		TagID:             tag,
+		Pin:               headers.Pin,
+		Deferred:          deferred,
+		ReplicationFactor: headers.Replication,
+	})
+	if err != nil {
+		logger.Debug("get putter failed", "error", err)
+		logger.Error(nil, "get putter failed")
+		switch {
+		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
+		case errors.Is(err, postage.ErrNotFound):
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
+		case errors.Is(err, errInvalidPostageBatch):
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
+		case errors.Is(err, errUnsupportedDevNodeOperation):
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
+		default:
+			jsonhttp.BadRequest(w, nil)
+		}
+		return
+	}
+
+	ow := &cleanupOnErrWriter{
+		ResponseWriter: w,
+		onErr:          putter.Cleanup,
+		logger:         logger,
+	}
+
+	dReader, err := s.newDirReader(r, headers.ContentType)
+	if err != nil {
+		logger.Error(nil, "invalid content-type for site upload")
+		jsonhttp.BadRequest(ow, errInvalidContentType)
+		return
+	}
+	defer r.Body.Close()
+
+	reference, err := storeDir(
+		ctx,
+		false,
+		dReader,
+		logger,
+		putter,
+		s.storer.ChunkStore(),
+		r.Header.Get(SwarmIndexDocumentHeader),
+		r.Header.Get(SwarmErrorDocumentHeader),
+		headers.RLevel,
+	)
+	if err != nil {
+		logger.Debug("store dir failed", "error", err)
+		logger.Error(nil, "store dir failed")
+		switch {
+		case errors.Is(err, postage.ErrBucketFull):
+			jsonhttp.PaymentRequired(ow, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
+		case errors.Is(err, errEmptyDir):
+			jsonhttp.BadRequest(ow, errEmptyDir)
+		case errors.Is(err, tar.ErrHeader):
+			jsonhttp.BadRequest(ow, "invalid filename in tar archive")
+		default:
+			jsonhttp.InternalServerError(ow, errDirectoryStore)
+		}
+		return
+	}
+
+	// the feed update wraps the root chunk of the collection manifest
+	rootCh, err := s.storer.ChunkStore().Get(ctx, reference)
+	if err != nil {
+		logger.Debug("get manifest root chunk failed", "address", reference, "error", err)
+		logger.Error(nil, "get manifest root chunk failed")
+		jsonhttp.InternalServerError(ow, "get manifest root chunk failed")
+		return
+	}
+	update, err := newFeedUpdate(s.signer, feed, next, rootCh)
+	if err != nil {
+		logger.Debug("create feed update failed", "error", err)
+		logger.Error(nil, "create feed update failed")
+		jsonhttp.InternalServerError(ow, "create feed update failed")
+		return
+	}
+	if err := putter.Put(ctx, update); err != nil {
+		logger.Debug("put feed update failed", "error", err)
+		logger.Error(nil, "put feed update failed")
+		switch {
+		case errors.Is(err, postage.ErrBucketFull):
+			jsonhttp.PaymentRequired(ow, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
+		default:
+			jsonhttp.InternalServerError(ow, "put feed update failed")
+		}
+		return
+	}
+
+	l := loadsave.New(s.storer.ChunkStore(), s.storer.Cache(), requestPipelineFactory(ctx, putter, false, 0), redundancy.DefaultLevel)
+	if headers.Snapshot > 0 {
+		err := func() error {
+			index, err := feedIndex(next)
+			if err != nil {
+				return err
+			}
+			return s.storeFeedSnapshot(ctx, l, putter, feed, index, reference, headers.Snapshot)
+		}()
+		if err != nil {
+			logger.Debug("store feed snapshot failed", "error", err)
+			logger.Error(nil, "store feed snapshot failed")
+			switch {
+			case errors.Is(err, postage.ErrBucketFull):
+				jsonhttp.PaymentRequired(ow, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
+			default:
+				jsonhttp.InternalServerError(ow, "store feed snapshot failed")
+			}
+			return
+		}
+	}
+
+	feedManifest, err := storeFeedManifest(ctx, l, owner, paths.Topic)
+	if err != nil {
+		logger.Debug("store feed manifest failed", "error", err)
+		logger.Error(nil, "store feed manifest failed")
+		switch {
+		case errors.Is(err, postage.ErrBucketFull):
+			jsonhttp.PaymentRequired(ow, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
+		default:
+			jsonhttp.InternalServerError(ow, "store feed manifest failed")
+		}
+		return
+	}
+
+	if err := putter.Done(reference); err != nil {
+		logger.Debug("done split failed", "error", err)
+		logger.Error(nil, "done split failed")
+		jsonhttp.InternalServerError(ow, "done split failed")
+		return
+	}
+
+	nextBytes, err := next.MarshalBinary()
+	if err != nil {
+		logger.Debug("marshal feed index failed", "error", err)
+		logger.Error(nil, "marshal feed index failed")
+		jsonhttp.InternalServerError(w, "marshal feed index failed")
+		return
+	}
+
+	if tag != 0 {
+		w.Header().Set(SwarmTagHeader, fmt.Sprint(tag))
+	}
+	s.publishUpload(reference, tag)
+	w.Header().Set(SwarmFeedIndexHeader, hex.EncodeToString(nextBytes))
+	w.Header().Set(AccessControlExposeHeaders, SwarmTagHeader)
+	w.Header().Add(AccessControlExposeHeaders, SwarmFeedIndexHeader)
+	jsonhttp.Created(w, siteUploadResponse{
+		Reference:    reference,
+		FeedManifest: feedManifest,
+	})
+}
+
+// newFeedUpdate returns the feed update at the given index
+// wrapping the chunk and signed by the signer.
+func newFeedUpdate(signer crypto.Signer, feed *feeds.Feed, index feeds.Index, ch swarm.Chunk) (swarm.Chunk, error) {
+	id, err := feed.Update(index).Id()
+	if err != nil {
+		return nil, err
+	}
+	return soc.New(id, ch).Sign(signer)
+}
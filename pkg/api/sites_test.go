@@ -0,0 +1,194 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestSiteUpload(t *testing.T) {
+	t.Parallel()
+
+	const topic = "aa"
+
+	var (
+		storerMock      = mockstorer.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: storerMock,
+			Post:   mockpost.New(mockpost.WithAcceptAll()),
+			Feeds:  factory.New(storerMock.ChunkStore()),
+		})
+	)
+
+	type siteUploadResponse struct {
+		Reference    swarm.Address `json:"reference"`
+		FeedManifest swarm.Address `json:"feedManifest"`
+	}
+
+	deploy := func(t *testing.T, content, wantIndex string) siteUploadResponse {
+		t.Helper()
+
+		var resp siteUploadResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/sites/"+topic, http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+			jsonhttptest.WithRequestHeader(api.SwarmIndexDocumentHeader, "index.html"),
+			jsonhttptest.WithRequestBody(tarFiles(t, []f{{
+				data: []byte(content),
+				name: "index.html",
+			}})),
+			jsonhttptest.WithExpectedResponseHeader(api.SwarmFeedIndexHeader, wantIndex),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		return resp
+	}
+
+	first := deploy(t, "<h1>first", "0000000000000000")
+	jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+first.FeedManifest.String()+"/", http.StatusOK,
+		jsonhttptest.WithExpectedResponse([]byte("<h1>first")),
+	)
+
+	second := deploy(t, "<h1>second", "0000000000000001")
+	if !second.FeedManifest.Equal(first.FeedManifest) {
+		t.Fatalf("got feed manifest %s, want %s", second.FeedManifest, first.FeedManifest)
+	}
+	if second.Reference.Equal(first.Reference) {
+		t.Fatal("got the same reference for different content")
+	}
+	jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+second.FeedManifest.String()+"/", http.StatusOK,
+		jsonhttptest.WithExpectedResponse([]byte("<h1>second")),
+	)
+	jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+first.Reference.String()+"/", http.StatusOK,
+		jsonhttptest.WithExpectedResponse([]byte("<h1>first")),
+	)
+
+	t.Run("invalid content type", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/sites/"+topic, http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/plain"),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusBadRequest,
+				Message:   "invalid content-type",
+				ErrorCode: "bad_request",
+			}),
+		)
+	})
+}
+
+func TestSiteUploadSnapshot(t *testing.T) {
+	t.Parallel()
+
+	const topic = "aa"
+
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(pk)
+	owner, err := signer.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		storerMock      = mockstorer.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: storerMock,
+			Post:   mockpost.New(mockpost.WithAcceptAll()),
+			Feeds:  factory.New(storerMock.ChunkStore()),
+			Signer: signer,
+		})
+		snapshotURL = "/feeds/" + hex.EncodeToString(owner.Bytes()) + "/" + topic + "/snapshot"
+	)
+
+	type snapshotUpdate struct {
+		Index     uint64        `json:"index"`
+		Reference swarm.Address `json:"reference"`
+	}
+	type snapshotResponse struct {
+		Index   uint64           `json:"index"`
+		Size    uint64           `json:"size"`
+		Updates []snapshotUpdate `json:"updates"`
+	}
+
+	deploy := func(t *testing.T, content, snapshot string) swarm.Address {
+		t.Helper()
+
+		var resp struct {
+			Reference swarm.Address `json:"reference"`
+		}
+		jsonhttptest.Request(t, client, http.MethodPost, "/sites/"+topic, http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+			jsonhttptest.WithRequestHeader(api.SwarmFeedSnapshotHeader, snapshot),
+			jsonhttptest.WithRequestBody(tarFiles(t, []f{{
+				data: []byte(content),
+				name: "index.html",
+			}})),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		return resp.Reference
+	}
+
+	jsonhttptest.Request(t, client, http.MethodGet, snapshotURL, http.StatusNotFound,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:      http.StatusNotFound,
+			Message:   "no snapshot found",
+			ErrorCode: api.ErrorCodeFeedUpdateNotFound,
+		}),
+	)
+
+	var refs []swarm.Address
+	for _, content := range []string{"first", "second", "third"} {
+		refs = append(refs, deploy(t, content, "2"))
+	}
+
+	jsonhttptest.Request(t, client, http.MethodGet, snapshotURL, http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(snapshotResponse{
+			Index: 2,
+			Size:  2,
+			Updates: []snapshotUpdate{
+				{Index: 1, Reference: refs[1]},
+				{Index: 2, Reference: refs[2]},
+			},
+		}),
+	)
+
+	t.Run("too large", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/sites/"+topic, http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+			jsonhttptest.WithRequestHeader(api.SwarmFeedSnapshotHeader, "257"),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid header params",
+				Reasons: []jsonhttp.Reason{
+					{
+						Field: "swarm-feed-snapshot",
+						Error: "want lte:256",
+					},
+				},
+				ErrorCode: "bad_request",
+			}),
+		)
+	})
+}
@@ -0,0 +1,428 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/file/cdc"
+	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/snapshot"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+// maxExistReferences is the maximum number of the references
+// checked by a single existence request.
+const maxExistReferences = 10000
+
+type chunksExistRequest struct {
+	References []swarm.Address `json:"references"`
+}
+
+type chunksExistResponse struct {
+	Missing []swarm.Address `json:"missing"`
+}
+
+// chunksExistHandler checks which of the chunks are stored locally, so the
+// backup tools upload only the missing content of the incremental backups.
+func (s *Service) chunksExistHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_chunks_exists").Build()
+
+	var req chunksExistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid references")
+		return
+	}
+	if len(req.References) > maxExistReferences {
+		jsonhttp.BadRequest(w, fmt.Sprintf("more than %d references", maxExistReferences))
+		return
+	}
+
+	resp := chunksExistResponse{Missing: make([]swarm.Address, 0)}
+	for _, ref := range req.References {
+		has, err := s.storer.ChunkStore().Has(r.Context(), ref)
+		if err != nil {
+			logger.Debug("has chunk failed", "chunk_address", ref, "error", err)
+			logger.Error(nil, "has chunk failed")
+			jsonhttp.InternalServerError(w, "has chunk failed")
+			return
+		}
+		if !has {
+			resp.Missing = append(resp.Missing, ref)
+		}
+	}
+	jsonhttp.OK(w, resp)
+}
+
+type snapshotEntry struct {
+	Path      string         `json:"path"`
+	Mode      uint32         `json:"mode"`
+	ModTime   time.Time      `json:"modTime"`
+	UID       uint32         `json:"uid"`
+	GID       uint32         `json:"gid"`
+	Owner     string         `json:"owner,omitempty"`
+	Group     string         `json:"group,omitempty"`
+	Size      int64          `json:"size"`
+	Link      string         `json:"link,omitempty"`
+	Reference *swarm.Address `json:"reference,omitempty"`
+}
+
+func newSnapshotEntry(e snapshot.Entry) snapshotEntry {
+	se := snapshotEntry{
+		Path:    e.Path,
+		Mode:    e.Mode,
+		ModTime: e.ModTime,
+		UID:     e.UID,
+		GID:     e.GID,
+		Owner:   e.Owner,
+		Group:   e.Group,
+		Size:    e.Size,
+		Link:    e.Link,
+	}
+	if !e.Reference.IsZero() {
+		ref := e.Reference
+		se.Reference = &ref
+	}
+	return se
+}
+
+func (se snapshotEntry) entry() snapshot.Entry {
+	e := snapshot.Entry{
+		Path:    se.Path,
+		Mode:    se.Mode,
+		ModTime: se.ModTime,
+		UID:     se.UID,
+		GID:     se.GID,
+		Owner:   se.Owner,
+		Group:   se.Group,
+		Size:    se.Size,
+		Link:    se.Link,
+	}
+	if se.Reference != nil {
+		e.Reference = *se.Reference
+	}
+	return e
+}
+
+type snapshotRequest struct {
+	Base   *swarm.Address  `json:"base"`
+	Put    []snapshotEntry `json:"put"`
+	Remove []string        `json:"remove"`
+}
+
+type snapshotResponse struct {
+	Reference swarm.Address `json:"reference"`
+}
+
+type snapshotFileResponse struct {
+	Reference swarm.Address `json:"reference"`
+	Size      int64         `json:"size"`
+	Segments  int           `json:"segments"`
+}
+
+type snapshotEntriesResponse struct {
+	Entries []snapshotEntry `json:"entries"`
+}
+
+// snapshotFileUploadHandler uploads the file split into the content-defined
+// segments and returns the reference of its segment list.
+func (s *Service) snapshotFileUploadHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_snapshots_files").Build()
+
+	headers := struct {
+		BatchID  []byte `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		SwarmTag uint64 `map:"Swarm-Tag"`
+		Pin      bool   `map:"Swarm-Pin"`
+		Deferred *bool  `map:"Swarm-Deferred-Upload"`
+		Encrypt  bool   `map:"Swarm-Encrypt"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	ctx := r.Context()
+	putter, tag, ok := s.snapshotPutter(w, r, headers.BatchID, headers.SwarmTag, headers.Pin, headers.Deferred)
+	if !ok {
+		return
+	}
+	ow := &cleanupOnErrWriter{
+		ResponseWriter: w,
+		onErr:          putter.Cleanup,
+		logger:         logger,
+	}
+
+	ref, segments, err := snapshot.Upload(ctx, requestPipelineFactory(ctx, putter, headers.Encrypt, redundancy.NONE), r.Body, cdc.DefaultOptions)
+	if err != nil {
+		logger.Debug("upload segments failed", "error", err)
+		logger.Error(nil, "upload segments failed")
+		switch {
+		case errors.Is(err, postage.ErrBucketFull):
+			jsonhttp.PaymentRequired(ow, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
+		default:
+			jsonhttp.InternalServerError(ow, "upload segments failed")
+		}
+		return
+	}
+	if err := putter.Done(ref); err != nil {
+		logger.Debug("done split failed", "error", err)
+		logger.Error(nil, "done split failed")
+		jsonhttp.InternalServerError(ow, "done split failed")
+		return
+	}
+
+	if tag != 0 {
+		w.Header().Set(SwarmTagHeader, fmt.Sprint(tag))
+		w.Header().Set(AccessControlExposeHeaders, SwarmTagHeader)
+	}
+	var size int64
+	for _, segment := range segments {
+		size += segment.Size
+	}
+	jsonhttp.Created(w, snapshotFileResponse{
+		Reference: ref,
+		Size:      size,
+		Segments:  len(segments),
+	})
+}
+
+// snapshotUploadHandler creates the snapshot based on the previous one with
+// the changed entries put and the removed entries removed.
+func (s *Service) snapshotUploadHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_snapshots").Build()
+
+	headers := struct {
+		BatchID  []byte `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		SwarmTag uint64 `map:"Swarm-Tag"`
+		Pin      bool   `map:"Swarm-Pin"`
+		Deferred *bool  `map:"Swarm-Deferred-Upload"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	var req snapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid snapshot")
+		return
+	}
+
+	ctx := r.Context()
+	putter, tag, ok := s.snapshotPutter(w, r, headers.BatchID, headers.SwarmTag, headers.Pin, headers.Deferred)
+	if !ok {
+		return
+	}
+	ow := &cleanupOnErrWriter{
+		ResponseWriter: w,
+		onErr:          putter.Cleanup,
+		logger:         logger,
+	}
+
+	base := swarm.ZeroAddress
+	if req.Base != nil {
+		base = *req.Base
+	}
+	ls := loadsave.New(s.storer.Download(true), s.storer.Cache(), requestPipelineFactory(ctx, putter, false, redundancy.NONE), redundancy.DefaultLevel)
+	snap, err := snapshot.New(ls, base)
+	if err != nil {
+		logger.Debug("load base snapshot failed", "base", base, "error", err)
+		jsonhttp.NotFound(ow, "base snapshot not found")
+		return
+	}
+	for _, path := range req.Remove {
+		if err := snap.Remove(ctx, path); err != nil {
+			logger.Debug("remove snapshot entry failed", "path", path, "error", err)
+			if errors.Is(err, snapshot.ErrNotFound) {
+				jsonhttp.BadRequest(ow, fmt.Sprintf("removed path %q not found", path))
+				return
+			}
+			jsonhttp.InternalServerError(ow, "remove snapshot entry failed")
+			return
+		}
+	}
+	for _, e := range req.Put {
+		if err := snap.Put(ctx, e.entry()); err != nil {
+			logger.Debug("put snapshot entry failed", "path", e.Path, "error", err)
+			if errors.Is(err, snapshot.ErrInvalidEntry) {
+				jsonhttp.BadRequest(ow, fmt.Sprintf("invalid entry %q", e.Path))
+				return
+			}
+			jsonhttp.InternalServerError(ow, "put snapshot entry failed")
+			return
+		}
+	}
+
+	ref, err := snap.Save(ctx)
+	if err != nil {
+		logger.Debug("save snapshot failed", "error", err)
+		logger.Error(nil, "save snapshot failed")
+		switch {
+		case errors.Is(err, postage.ErrBucketFull):
+			jsonhttp.PaymentRequired(ow, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
+		default:
+			jsonhttp.InternalServerError(ow, "save snapshot failed")
+		}
+		return
+	}
+	if err := putter.Done(ref); err != nil {
+		logger.Debug("done split failed", "error", err)
+		logger.Error(nil, "done split failed")
+		jsonhttp.InternalServerError(ow, "done split failed")
+		return
+	}
+
+	if tag != 0 {
+		w.Header().Set(SwarmTagHeader, fmt.Sprint(tag))
+		w.Header().Set(AccessControlExposeHeaders, SwarmTagHeader)
+	}
+	jsonhttp.Created(w, snapshotResponse{Reference: ref})
+}
+
+// snapshotPutter returns the stamped putter of the snapshot uploads.
+// The error response is written when ok is false.
+func (s *Service) snapshotPutter(w http.ResponseWriter, r *http.Request, batchID []byte, swarmTag uint64, pin bool, deferredHeader *bool) (putter storer.PutterSession, tag uint64, ok bool) {
+	logger := s.logger.WithName("snapshot_putter").Build()
+
+	var err error
+	deferred := defaultUploadMethod(deferredHeader)
+	if deferred || pin {
+		tag, err = s.getOrCreateSessionID(swarmTag)
+		if err != nil {
+			logger.Debug("get or create tag failed", "error", err)
+			logger.Error(nil, "get or create tag failed")
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not found"))
+			default:
+				jsonhttp.InternalServerError(w, "cannot get or create tag")
+			}
+			return nil, 0, false
+		}
+	}
+
+	putter, err = s.newStamperPutter(r.Context(), putterOptions{
+		BatchID:  batchID,
+		TagID:    tag,
+		Pin:      pin,
+		Deferred: deferred,
+	})
+	if err != nil {
+		logger.Debug("get putter failed", "error", err)
+		logger.Error(nil, "get putter failed")
+		switch {
+		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
+		case errors.Is(err, postage.ErrNotFound):
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
+		case errors.Is(err, errInvalidPostageBatch):
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
+		case errors.Is(err, errUnsupportedDevNodeOperation):
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
+		default:
+			jsonhttp.BadRequest(w, nil)
+		}
+		return nil, 0, false
+	}
+	return putter, tag, true
+}
+
+// snapshotListHandler lists the entries of the snapshot.
+func (s *Service) snapshotListHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_snapshot").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address,resolve" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	snap, err := s.loadSnapshot(paths.Address)
+	if err != nil {
+		logger.Debug("load snapshot failed", "address", paths.Address, "error", err)
+		jsonhttp.NotFound(w, "snapshot not found")
+		return
+	}
+	resp := snapshotEntriesResponse{Entries: make([]snapshotEntry, 0)}
+	err = snap.Walk(r.Context(), func(e snapshot.Entry) error {
+		resp.Entries = append(resp.Entries, newSnapshotEntry(e))
+		return nil
+	})
+	if err != nil {
+		logger.Debug("walk snapshot failed", "address", paths.Address, "error", err)
+		logger.Error(nil, "walk snapshot failed")
+		jsonhttp.InternalServerError(w, "walk snapshot failed")
+		return
+	}
+	jsonhttp.OK(w, resp)
+}
+
+// snapshotFileHandler restores the file of the snapshot.
+func (s *Service) snapshotFileHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_snapshot_file").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address,resolve" validate:"required"`
+		Path    string        `map:"path" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	snap, err := s.loadSnapshot(paths.Address)
+	if err != nil {
+		logger.Debug("load snapshot failed", "address", paths.Address, "error", err)
+		jsonhttp.NotFound(w, "snapshot not found")
+		return
+	}
+	e, err := snap.Lookup(r.Context(), paths.Path)
+	if err != nil {
+		logger.Debug("lookup snapshot entry failed", "path", paths.Path, "error", err)
+		if errors.Is(err, snapshot.ErrNotFound) {
+			jsonhttp.NotFound(w, "path not found")
+			return
+		}
+		jsonhttp.InternalServerError(w, "lookup snapshot entry failed")
+		return
+	}
+	if e.Reference.IsZero() {
+		jsonhttp.BadRequest(w, "not a file")
+		return
+	}
+
+	reader, err := snapshot.NewReader(r.Context(), s.storer.Download(true), s.storer.Cache(), e.Reference)
+	if err != nil {
+		logger.Debug("load segments failed", "path", paths.Path, "error", err)
+		logger.Error(nil, "load segments failed")
+		jsonhttp.NotFound(w, "segments not found")
+		return
+	}
+	w.Header().Set(ContentTypeHeader, "application/octet-stream")
+	w.Header().Set(SwarmFileModeHeader, strconv.FormatUint(uint64(e.Mode), 8))
+	w.Header().Set(AccessControlExposeHeaders, SwarmFileModeHeader)
+	http.ServeContent(w, r, "", e.ModTime, reader)
+}
+
+// loadSnapshot loads the snapshot of the reference read only.
+func (s *Service) loadSnapshot(ref swarm.Address) (*snapshot.Snapshot, error) {
+	ls := loadsave.NewReadonly(s.storer.Download(true), s.storer.Cache(), redundancy.DefaultLevel)
+	return snapshot.New(ls, ref)
+}
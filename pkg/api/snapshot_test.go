@@ -0,0 +1,133 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestSnapshots(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+	})
+
+	data := make([]byte, 3*1024*1024)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	var file api.SnapshotFileResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/snapshots/files", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+		jsonhttptest.WithUnmarshalJSONResponse(&file),
+	)
+	if file.Size != int64(len(data)) || file.Segments < 2 {
+		t.Fatalf("got file %+v", file)
+	}
+
+	missing := swarm.RandAddress(t)
+	jsonhttptest.Request(t, client, http.MethodPost, "/chunks/exists", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(api.ChunksExistRequest{
+			References: []swarm.Address{file.Reference, missing},
+		}),
+		jsonhttptest.WithExpectedJSONResponse(api.ChunksExistResponse{
+			Missing: []swarm.Address{missing},
+		}),
+	)
+
+	modTime := time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC)
+	entries := []api.SnapshotEntry{
+		{Path: "docs/", Mode: 040755, ModTime: modTime, UID: 1000, GID: 1000},
+		{Path: "docs/data.bin", Mode: 0100644, ModTime: modTime, UID: 1000, GID: 1000, Owner: "alice", Size: file.Size, Reference: &file.Reference},
+	}
+	var base api.SnapshotResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/snapshots", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithJSONRequestBody(api.SnapshotRequest{Put: entries}),
+		jsonhttptest.WithUnmarshalJSONResponse(&base),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/snapshots/"+base.Reference.String(), http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.SnapshotEntriesResponse{Entries: entries}),
+	)
+	jsonhttptest.Request(t, client, http.MethodGet, "/snapshots/"+base.Reference.String()+"/docs/data.bin", http.StatusOK,
+		jsonhttptest.WithExpectedResponse(data),
+		jsonhttptest.WithExpectedResponseHeader(api.SwarmFileModeHeader, "100644"),
+		jsonhttptest.WithExpectedResponseHeader("Last-Modified", modTime.Format(http.TimeFormat)),
+	)
+	jsonhttptest.Request(t, client, http.MethodGet, "/snapshots/"+base.Reference.String()+"/docs/", http.StatusBadRequest,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message:   "not a file",
+			Code:      http.StatusBadRequest,
+			ErrorCode: "bad_request",
+		}),
+	)
+
+	t.Run("incremental", func(t *testing.T) {
+		t.Parallel()
+
+		var next api.SnapshotResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/snapshots", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(api.SnapshotRequest{
+				Base:   &base.Reference,
+				Put:    []api.SnapshotEntry{{Path: "link", Mode: 0120777, ModTime: modTime, Link: "docs/data.bin"}},
+				Remove: []string{"docs/"},
+			}),
+			jsonhttptest.WithUnmarshalJSONResponse(&next),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/snapshots/"+next.Reference.String(), http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.SnapshotEntriesResponse{
+				Entries: []api.SnapshotEntry{{Path: "link", Mode: 0120777, ModTime: modTime, Link: "docs/data.bin"}},
+			}),
+		)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/snapshots", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(api.SnapshotRequest{
+				Put: []api.SnapshotEntry{{Path: "file", Mode: 0100644}},
+			}),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message:   `invalid entry "file"`,
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodPost, "/snapshots", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(api.SnapshotRequest{
+				Base:   &base.Reference,
+				Remove: []string{"missing"},
+			}),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message:   `removed path "missing" not found`,
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
+			}),
+		)
+	})
+}
@@ -6,11 +6,13 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/accesscontrol"
 	"github.com/ethersphere/bee/v2/pkg/cac"
@@ -49,6 +51,7 @@ func (s *Service) socUploadHandler(w http.ResponseWriter, r *http.Request) {
 	headers := struct {
 		BatchID        []byte        `map:"Swarm-Postage-Batch-Id"`
 		StampSig       []byte        `map:"Swarm-Postage-Stamp"`
+		Replication    uint8         `map:"Swarm-Replication-Factor" validate:"lte=8"`
 		Act            bool          `map:"Swarm-Act"`
 		HistoryAddress swarm.Address `map:"Swarm-Act-History-Address"`
 	}{}
@@ -79,17 +82,19 @@ func (s *Service) socUploadHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		putter, err = s.newStampedPutter(r.Context(), putterOptions{
-			BatchID:  stamp.BatchID(),
-			TagID:    0,
-			Pin:      false,
-			Deferred: false,
+			BatchID:           stamp.BatchID(),
+			TagID:             0,
+			Pin:               false,
+			Deferred:          false,
+			ReplicationFactor: headers.Replication,
 		}, &stamp)
 	} else {
 		putter, err = s.newStamperPutter(r.Context(), putterOptions{
-			BatchID:  headers.BatchID,
-			TagID:    0,
-			Pin:      false,
-			Deferred: false,
+			BatchID:           headers.BatchID,
+			TagID:             0,
+			Pin:               false,
+			Deferred:          false,
+			ReplicationFactor: headers.Replication,
 		})
 	}
 	if err != nil {
@@ -97,11 +102,11 @@ func (s *Service) socUploadHandler(w http.ResponseWriter, r *http.Request) {
 		logger.Error(nil, "get putter failed")
 		switch {
 		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
 		case errors.Is(err, postage.ErrNotFound):
-			jsonhttp.NotFound(w, "batch with id not found")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
 		case errors.Is(err, errInvalidPostageBatch):
-			jsonhttp.BadRequest(w, "invalid batch id")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
 		case errors.Is(err, errUnsupportedDevNodeOperation):
 			jsonhttp.NotImplemented(w, "operation is not supported in dev mode")
 		default:
@@ -189,7 +194,7 @@ func (s *Service) socUploadHandler(w http.ResponseWriter, r *http.Request) {
 			logger.Error(nil, "access control upload failed")
 			switch {
 			case errors.Is(err, accesscontrol.ErrNotFound):
-				jsonhttp.NotFound(w, "act or history entry not found")
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeACTEntryNotFound, "act or history entry not found"))
 			case errors.Is(err, accesscontrol.ErrInvalidPublicKey) || errors.Is(err, accesscontrol.ErrSecretKeyInfinity):
 				jsonhttp.BadRequest(w, "invalid public key")
 			case errors.Is(err, accesscontrol.ErrUnexpectedType):
@@ -216,6 +221,22 @@ func (s *Service) socUploadHandler(w http.ResponseWriter, r *http.Request) {
 	jsonhttp.Created(w, socPostResponse{Reference: reference})
 }
 
+// noCache is the Swarm-Cache header value with which the single owner chunks
+// are retrieved from the network even if they are cached.
+const noCache = "no-cache"
+
+// mutableCacheContext returns the context with the caching rules of the
+// single owner chunks for the ttl and the Swarm-Cache header value.
+func mutableCacheContext(ctx context.Context, ttl time.Duration, cacheHeader string) context.Context {
+	if cacheHeader == noCache {
+		return storer.SetNoCache(ctx)
+	}
+	if ttl > 0 {
+		return storer.SetCacheTTL(ctx, ttl)
+	}
+	return ctx
+}
+
 func (s *Service) socGetHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("get_soc").Build()
 
@@ -229,7 +250,8 @@ func (s *Service) socGetHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	headers := struct {
-		OnlyRootChunk bool `map:"Swarm-Only-Root-Chunk"`
+		OnlyRootChunk bool   `map:"Swarm-Only-Root-Chunk"`
+		Cache         string `map:"Swarm-Cache" validate:"omitempty,oneof=true false no-cache"`
 	}{}
 	if response := s.mapStructure(r.Header, &headers); response != nil {
 		response("invalid header params", logger, w)
@@ -243,8 +265,8 @@ func (s *Service) socGetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	getter := s.storer.Download(true)
-	sch, err := getter.Get(r.Context(), address)
+	getter := s.storer.Download(headers.Cache != "false")
+	sch, err := getter.Get(mutableCacheContext(r.Context(), s.SOCCacheTTL, headers.Cache), address)
 	if err != nil {
 		logger.Error(err, "soc retrieval has been failed")
 		jsonhttp.NotFound(w, "requested chunk cannot be retrieved")
@@ -278,5 +300,10 @@ func (s *Service) socGetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the no-cache rule only applies to the mutable root chunk
+	if headers.Cache == noCache {
+		r.Header.Del(SwarmCacheHeader)
+	}
+
 	s.downloadHandler(logger, w, r, wc.Address(), additionalHeaders, true, false, wc)
 }
@@ -0,0 +1,218 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/soc"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const (
+	// maxSOCBatchChunks is the maximum number of the single owner
+	// chunks uploaded in a single batch.
+	maxSOCBatchChunks = 256
+	// maxSOCBatchEntrySize is the maximum size of the hex encoded entry.
+	maxSOCBatchEntrySize = 2*(swarm.HashSize+swarm.SocSignatureSize+swarm.ChunkWithSpanSize+20) + 64
+)
+
+// socBatchEntry is the hex encoded pre-signed single owner chunk
+// and the data of its wrapped chunk with the span.
+type socBatchEntry struct {
+	Owner     string `json:"owner"`
+	ID        string `json:"id"`
+	Signature string `json:"signature"`
+	Data      string `json:"data"`
+}
+
+type socBatchRequest struct {
+	Chunks []socBatchEntry `json:"chunks"`
+}
+
+type socBatchResponse struct {
+	References []swarm.Address `json:"references"`
+}
+
+// chunk returns the valid single owner chunk of the entry.
+func (e socBatchEntry) chunk() (swarm.Chunk, error) {
+	owner, err := hex.DecodeString(e.Owner)
+	if err != nil {
+		return nil, fmt.Errorf("owner: %w", err)
+	}
+	id, err := hex.DecodeString(e.ID)
+	if err != nil {
+		return nil, fmt.Errorf("id: %w", err)
+	}
+	sig, err := hex.DecodeString(e.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	data, err := hex.DecodeString(e.Data)
+	if err != nil {
+		return nil, fmt.Errorf("data: %w", err)
+	}
+	if len(data) < swarm.SpanSize || len(data) > swarm.ChunkWithSpanSize {
+		return nil, fmt.Errorf("data: invalid length %d", len(data))
+	}
+
+	ch, err := cac.NewWithDataSpan(data)
+	if err != nil {
+		return nil, fmt.Errorf("data: %w", err)
+	}
+	ss, err := soc.NewSigned(id, ch, owner, sig)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := ss.Chunk()
+	if err != nil {
+		return nil, err
+	}
+	if !soc.Valid(sch) {
+		return nil, errors.New("invalid signature")
+	}
+	return sch, nil
+}
+
+// socBatchUploadHandler stamps and stores the pre-signed single owner chunks
+// of the request. The chunks are validated before any of them is stored and
+// none of them is kept if any of them fails to be stored, so the batch is
+// uploaded either whole or not at all.
+func (s *Service) socBatchUploadHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_soc_batch").Build()
+
+	headers := struct {
+		BatchID     []byte `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		SwarmTag    uint64 `map:"Swarm-Tag"`
+		Deferred    *bool  `map:"Swarm-Deferred-Upload"`
+		Replication uint8  `map:"Swarm-Replication-Factor" validate:"lte=8"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	var req socBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if jsonhttp.HandleBodyReadError(err, w) {
+			return
+		}
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid chunks")
+		return
+	}
+	if len(req.Chunks) == 0 {
+		jsonhttp.BadRequest(w, "no chunks")
+		return
+	}
+	if len(req.Chunks) > maxSOCBatchChunks {
+		jsonhttp.BadRequest(w, fmt.Sprintf("more than %d chunks", maxSOCBatchChunks))
+		return
+	}
+
+	chunks := make([]swarm.Chunk, 0, len(req.Chunks))
+	for i, e := range req.Chunks {
+		ch, err := e.chunk()
+		if err != nil {
+			logger.Debug("invalid single owner chunk", "index", i, "error", err)
+			logger.Error(nil, "invalid single owner chunk")
+			jsonhttp.BadRequest(w, fmt.Sprintf("invalid chunk %d", i))
+			return
+		}
+		chunks = append(chunks, ch)
+	}
+
+	var (
+		tag      uint64
+		err      error
+		deferred = defaultUploadMethod(headers.Deferred)
+	)
+	if deferred {
+		tag, err = s.getOrCreateSessionID(headers.SwarmTag)
+		if err != nil {
+			logger.Debug("get or create tag failed", "error", err)
+			logger.Error(nil, "get or create tag failed")
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not found"))
+			default:
+				jsonhttp.InternalServerError(w, "cannot get or create tag")
+			}
+			return
+		}
+	}
+
+	putter, err := s.newStamperPutter(r.Context(), putterOptions{
+		BatchID:           headers.BatchID,
+		TagID:             tag,
+		Deferred:          deferred,
+		ReplicationFactor: headers.Replication,
+	})
+	if err != nil {
+		logger.Debug("get putter failed", "error", err)
+		logger.Error(nil, "get putter failed")
+		switch {
+		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
+		case errors.Is(err, postage.ErrNotFound):
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
+		case errors.Is(err, errInvalidPostageBatch):
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
+		case errors.Is(err, errUnsupportedDevNodeOperation):
+			jsonhttp.NotImplemented(w, "operation is not supported in dev mode")
+		default:
+			jsonhttp.BadRequest(w, nil)
+		}
+		return
+	}
+
+	ow := &cleanupOnErrWriter{
+		ResponseWriter: w,
+		onErr:          putter.Cleanup,
+		logger:         logger,
+	}
+
+	errs, err := storage.PutMany(r.Context(), putter, chunks)
+	if err == nil {
+		err = errors.Join(errs...)
+	}
+	if err != nil {
+		logger.Debug("write chunks failed", "error", err)
+		logger.Error(nil, "write chunks failed")
+		switch {
+		case errors.Is(err, postage.ErrBucketFull):
+			jsonhttp.PaymentRequired(ow, jsonhttp.Coded(ErrorCodeBucketFull, "batch is overissued"))
+		default:
+			jsonhttp.BadRequest(ow, "chunk write error")
+		}
+		return
+	}
+
+	resp := socBatchResponse{References: make([]swarm.Address, 0, len(chunks))}
+	for _, ch := range chunks {
+		resp.References = append(resp.References, ch.Address())
+	}
+
+	if err := putter.Done(swarm.ZeroAddress); err != nil {
+		logger.Debug("done split failed", "error", err)
+		logger.Error(nil, "done split failed")
+		jsonhttp.InternalServerError(ow, "done split failed")
+		return
+	}
+
+	if tag != 0 {
+		w.Header().Set(SwarmTagHeader, fmt.Sprint(tag))
+		w.Header().Set(AccessControlExposeHeaders, SwarmTagHeader)
+	}
+	jsonhttp.Created(w, resp)
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	testingsoc "github.com/ethersphere/bee/v2/pkg/soc/testing"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestSOCBatchUpload(t *testing.T) {
+	t.Parallel()
+
+	var (
+		storerMock      = mockstorer.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: storerMock,
+			Post:   mockpost.New(mockpost.WithAcceptAll()),
+		})
+	)
+
+	entry := func(s *testingsoc.MockSOC) api.SocBatchEntry {
+		return api.SocBatchEntry{
+			Owner:     hex.EncodeToString(s.Owner),
+			ID:        hex.EncodeToString(s.ID),
+			Signature: hex.EncodeToString(s.Signature),
+			Data:      hex.EncodeToString(s.WrappedChunk.Data()),
+		}
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		socs := []*testingsoc.MockSOC{
+			testingsoc.GenerateMockSOC(t, []byte("foo")),
+			testingsoc.GenerateMockSOC(t, []byte("bar")),
+		}
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/soc/batch", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(api.SocBatchRequest{
+				Chunks: []api.SocBatchEntry{entry(socs[0]), entry(socs[1])},
+			}),
+			jsonhttptest.WithExpectedJSONResponse(api.SocBatchResponse{
+				References: []swarm.Address{socs[0].Address(), socs[1].Address()},
+			}),
+		)
+
+		for _, s := range socs {
+			has, err := storerMock.ChunkStore().Has(context.Background(), s.Address())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !has {
+				t.Fatalf("chunk %s not stored", s.Address())
+			}
+		}
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		t.Parallel()
+
+		valid := testingsoc.GenerateMockSOC(t, []byte("baz"))
+		invalid := entry(testingsoc.GenerateMockSOC(t, []byte("qux")))
+		invalid.Data = hex.EncodeToString(valid.WrappedChunk.Data())
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/soc/batch", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(api.SocBatchRequest{
+				Chunks: []api.SocBatchEntry{entry(valid), invalid},
+			}),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusBadRequest,
+				Message:   "invalid chunk 1",
+				ErrorCode: "bad_request",
+			}),
+		)
+
+		has, err := storerMock.ChunkStore().Has(context.Background(), valid.Address())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if has {
+			t.Fatal("want no chunk of the invalid batch stored")
+		}
+	})
+
+	t.Run("no chunks", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/soc/batch", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(api.SocBatchRequest{}),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusBadRequest,
+				Message:   "no chunks",
+				ErrorCode: "bad_request",
+			}),
+		)
+	})
+}
@@ -42,8 +42,9 @@ func TestSOC(t *testing.T) {
 		jsonhttptest.Request(t, client, http.MethodPost, socResource("8d3766440f0d7b949a5e32995d09619a7f86e632", "bb", "cc"), http.StatusBadRequest,
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "short chunk data",
-				Code:    http.StatusBadRequest,
+				Message:   "short chunk data",
+				Code:      http.StatusBadRequest,
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
@@ -66,8 +67,9 @@ func TestSOC(t *testing.T) {
 			jsonhttptest.WithRequestBody(bytes.NewReader(s.WrappedChunk.Data())),
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "invalid chunk",
-				Code:    http.StatusUnauthorized,
+				Message:   "invalid chunk",
+				Code:      http.StatusUnauthorized,
+				ErrorCode: "unauthorized",
 			}),
 		)
 	})
@@ -112,9 +114,24 @@ func TestSOC(t *testing.T) {
 				jsonhttptest.WithExpectedContentLength(len(s.WrappedChunk.Data()[swarm.SpanSize:])),
 				jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.SwarmSocSignatureHeader),
 				jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.ContentDispositionHeader),
+				jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.SwarmDownloadSessionHeader),
 				jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "application/octet-stream"),
 			)
 		})
+		t.Run("soc fetch no-cache", func(t *testing.T) {
+			rsrc := fmt.Sprintf("/soc/%s/%s", hex.EncodeToString(s.Owner), hex.EncodeToString(s.ID))
+			jsonhttptest.Request(t, client, http.MethodGet, rsrc, http.StatusOK,
+				jsonhttptest.WithRequestHeader(api.SwarmCacheHeader, "no-cache"),
+				jsonhttptest.WithExpectedResponse(s.WrappedChunk.Data()[swarm.SpanSize:]),
+			)
+		})
+
+		t.Run("soc fetch invalid cache header", func(t *testing.T) {
+			rsrc := fmt.Sprintf("/soc/%s/%s", hex.EncodeToString(s.Owner), hex.EncodeToString(s.ID))
+			jsonhttptest.Request(t, client, http.MethodGet, rsrc, http.StatusBadRequest,
+				jsonhttptest.WithRequestHeader(api.SwarmCacheHeader, "stale"),
+			)
+		})
 	})
 
 	t.Run("postage", func(t *testing.T) {
@@ -138,6 +155,7 @@ func TestSOC(t *testing.T) {
 							Error: api.HexInvalidByteError('g').Error(),
 						},
 					},
+					ErrorCode: "bad_request",
 				}))
 		})
 
@@ -0,0 +1,197 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/stampproxy"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+type stampProxyClientRequest struct {
+	Name    string `json:"name"`
+	BatchID string `json:"batchID"`
+	Limit   uint64 `json:"limit"`
+}
+
+type stampProxyClientResponse struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	BatchID hexByte   `json:"batchID"`
+	Limit   uint64    `json:"limit"`
+	Used    uint64    `json:"used"`
+	Created time.Time `json:"created"`
+	// Token is only returned when the client is registered.
+	Token string `json:"token,omitempty"`
+}
+
+type stampProxyClientsResponse struct {
+	Clients []stampProxyClientResponse `json:"clients"`
+}
+
+func newStampProxyClientResponse(c stampproxy.Client) stampProxyClientResponse {
+	return stampProxyClientResponse{
+		ID:      c.ID,
+		Name:    c.Name,
+		BatchID: c.BatchID,
+		Limit:   c.Limit,
+		Used:    c.Used,
+		Created: c.Created,
+	}
+}
+
+// stampProxyRegisterHandler registers the client the node stamps the
+// uploads for with the batch. The token of the client is returned once.
+func (s *Service) stampProxyRegisterHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_stampproxy_client").Build()
+
+	req, batchID, ok := s.decodeStampProxyClientRequest(w, r)
+	if !ok {
+		return
+	}
+
+	c, token, err := s.stampProxy.Register(req.Name, batchID, req.Limit)
+	if err != nil {
+		logger.Debug("register client failed", "error", err)
+		logger.Error(nil, "register client failed")
+		jsonhttp.InternalServerError(w, "register client failed")
+		return
+	}
+
+	resp := newStampProxyClientResponse(c)
+	resp.Token = token
+	jsonhttp.Created(w, resp)
+}
+
+// stampProxyClientsHandler lists the clients with their consumption.
+func (s *Service) stampProxyClientsHandler(w http.ResponseWriter, _ *http.Request) {
+	clients := s.stampProxy.Clients()
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].Created.Before(clients[j].Created)
+	})
+
+	resp := stampProxyClientsResponse{Clients: make([]stampProxyClientResponse, 0, len(clients))}
+	for _, c := range clients {
+		resp.Clients = append(resp.Clients, newStampProxyClientResponse(c))
+	}
+	jsonhttp.OK(w, resp)
+}
+
+// stampProxyClientHandler returns the client with its consumption.
+func (s *Service) stampProxyClientHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_stampproxy_client").Build()
+
+	paths := struct {
+		ID string `map:"id" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	c, err := s.stampProxy.Client(paths.ID)
+	if err != nil {
+		if errors.Is(err, stampproxy.ErrNotFound) {
+			jsonhttp.NotFound(w, "client not found")
+			return
+		}
+		logger.Debug("get client failed", "id", paths.ID, "error", err)
+		logger.Error(nil, "get client failed")
+		jsonhttp.InternalServerError(w, "get client failed")
+		return
+	}
+	jsonhttp.OK(w, newStampProxyClientResponse(c))
+}
+
+// stampProxyUpdateHandler changes the batch and the limit of the client.
+func (s *Service) stampProxyUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("patch_stampproxy_client").Build()
+
+	paths := struct {
+		ID string `map:"id" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	req, batchID, ok := s.decodeStampProxyClientRequest(w, r)
+	if !ok {
+		return
+	}
+
+	c, err := s.stampProxy.Update(paths.ID, batchID, req.Limit)
+	if err != nil {
+		if errors.Is(err, stampproxy.ErrNotFound) {
+			jsonhttp.NotFound(w, "client not found")
+			return
+		}
+		logger.Debug("update client failed", "id", paths.ID, "error", err)
+		logger.Error(nil, "update client failed")
+		jsonhttp.InternalServerError(w, "update client failed")
+		return
+	}
+	jsonhttp.OK(w, newStampProxyClientResponse(c))
+}
+
+// stampProxyRemoveHandler removes the client.
+func (s *Service) stampProxyRemoveHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_stampproxy_client").Build()
+
+	paths := struct {
+		ID string `map:"id" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.stampProxy.Remove(paths.ID); err != nil {
+		if errors.Is(err, stampproxy.ErrNotFound) {
+			jsonhttp.NotFound(w, "client not found")
+			return
+		}
+		logger.Debug("remove client failed", "id", paths.ID, "error", err)
+		logger.Error(nil, "remove client failed")
+		jsonhttp.InternalServerError(w, "remove client failed")
+		return
+	}
+	jsonhttp.OK(w, nil)
+}
+
+// decodeStampProxyClientRequest decodes the client request and checks that
+// the batch exists. The error response is written when it returns false.
+func (s *Service) decodeStampProxyClientRequest(w http.ResponseWriter, r *http.Request) (stampProxyClientRequest, []byte, bool) {
+	var req stampProxyClientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonhttp.BadRequest(w, "invalid client request")
+		return req, nil, false
+	}
+
+	batchID, err := hex.DecodeString(req.BatchID)
+	if err != nil || len(batchID) != swarm.HashSize {
+		jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
+		return req, nil, false
+	}
+	exists, err := s.batchStore.Exists(batchID)
+	if err != nil {
+		s.logger.Debug("batch exists failed", "error", err)
+		jsonhttp.InternalServerError(w, "batch exists failed")
+		return req, nil, false
+	}
+	if !exists {
+		jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
+		return req, nil, false
+	}
+	return req, batchID, true
+}
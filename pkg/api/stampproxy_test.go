@@ -0,0 +1,116 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/stampproxy"
+	statestore "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+)
+
+func TestStampProxyClients(t *testing.T) {
+	t.Parallel()
+
+	proxy, err := stampproxy.New(statestore.NewStateStore(), log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		StampProxy: proxy,
+	})
+
+	type clientResponse struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		BatchID string `json:"batchID"`
+		Limit   uint64 `json:"limit"`
+		Used    uint64 `json:"used"`
+		Token   string `json:"token"`
+	}
+
+	var registered clientResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/stampproxy/clients", http.StatusCreated,
+		jsonhttptest.WithJSONRequestBody(map[string]any{
+			"name":    "alice",
+			"batchID": batchOkStr,
+			"limit":   100,
+		}),
+		jsonhttptest.WithUnmarshalJSONResponse(&registered),
+	)
+	if registered.ID == "" || registered.Token == "" {
+		t.Fatalf("missing id or token in %+v", registered)
+	}
+	if registered.BatchID != batchOkStr || registered.Limit != 100 {
+		t.Fatalf("unexpected client %+v", registered)
+	}
+	if _, err := proxy.Authenticate(registered.Token); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("invalid batch", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/stampproxy/clients", http.StatusBadRequest,
+			jsonhttptest.WithJSONRequestBody(map[string]any{"name": "bob", "batchID": "abcd"}),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusBadRequest,
+				Message:   "invalid batch id",
+				ErrorCode: api.ErrorCodeInvalidBatchID,
+			}),
+		)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+
+		var resp struct {
+			Clients []clientResponse `json:"clients"`
+		}
+		jsonhttptest.Request(t, client, http.MethodGet, "/stampproxy/clients", http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if len(resp.Clients) == 0 || resp.Clients[0].ID != registered.ID {
+			t.Fatalf("unexpected clients %+v", resp.Clients)
+		}
+		if resp.Clients[0].Token != "" {
+			t.Fatal("token listed")
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/stampproxy/clients/unknown", http.StatusNotFound)
+		jsonhttptest.Request(t, client, http.MethodDelete, "/stampproxy/clients/unknown", http.StatusNotFound)
+	})
+
+	t.Run("update and remove", func(t *testing.T) {
+		t.Parallel()
+
+		var c clientResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/stampproxy/clients", http.StatusCreated,
+			jsonhttptest.WithJSONRequestBody(map[string]any{"name": "carol", "batchID": batchOkStr}),
+			jsonhttptest.WithUnmarshalJSONResponse(&c),
+		)
+
+		var updated clientResponse
+		jsonhttptest.Request(t, client, http.MethodPatch, "/stampproxy/clients/"+c.ID, http.StatusOK,
+			jsonhttptest.WithJSONRequestBody(map[string]any{"batchID": batchOkStr, "limit": 5}),
+			jsonhttptest.WithUnmarshalJSONResponse(&updated),
+		)
+		if updated.Limit != 5 || updated.Name != "carol" {
+			t.Fatalf("unexpected updated client %+v", updated)
+		}
+
+		jsonhttptest.Request(t, client, http.MethodDelete, "/stampproxy/clients/"+c.ID, http.StatusOK)
+		jsonhttptest.Request(t, client, http.MethodGet, "/stampproxy/clients/"+c.ID, http.StatusNotFound)
+	})
+}
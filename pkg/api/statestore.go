@@ -0,0 +1,209 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/gorilla/mux"
+)
+
+// rawStateValue is a statestore value which
+// is stored and loaded without any encoding.
+type rawStateValue []byte
+
+func (v rawStateValue) MarshalBinary() ([]byte, error) {
+	return v, nil
+}
+
+func (v *rawStateValue) UnmarshalBinary(data []byte) error {
+	*v = append((*v)[:0], data...)
+	return nil
+}
+
+type stateStoreKey struct {
+	Key       string `json:"key"`
+	Printable string `json:"printable"`
+	Size      int    `json:"size"`
+}
+
+type stateStoreKeysResponse struct {
+	Keys []stateStoreKey `json:"keys"`
+}
+
+type stateStoreEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type stateStoreDump struct {
+	Entries []stateStoreEntry `json:"entries"`
+}
+
+type stateStoreRestoreResponse struct {
+	Restored int `json:"restored"`
+}
+
+// stateStoreKeysHandler lists the statestore keys with the given prefix.
+func (s *Service) stateStoreKeysHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_statestore").Build()
+
+	queries := struct {
+		Prefix string `map:"prefix"`
+		Limit  int    `map:"limit" validate:"min=0"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	resp := stateStoreKeysResponse{Keys: []stateStoreKey{}}
+	err := s.stateStore.Iterate(queries.Prefix, func(key, val []byte) (bool, error) {
+		resp.Keys = append(resp.Keys, stateStoreKey{
+			Key:       hex.EncodeToString(key),
+			Printable: strconv.QuoteToASCII(string(key)),
+			Size:      len(val),
+		})
+		return queries.Limit > 0 && len(resp.Keys) >= queries.Limit, nil
+	})
+	if err != nil {
+		logger.Debug("iterate statestore failed", "prefix", queries.Prefix, "error", err)
+		logger.Error(nil, "iterate statestore failed")
+		jsonhttp.InternalServerError(w, "iterate statestore failed")
+		return
+	}
+
+	jsonhttp.OK(w, resp)
+}
+
+// stateStoreGetHandler returns the raw value of a single statestore entry.
+func (s *Service) stateStoreGetHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_statestore_by_key").Build()
+
+	paths := struct {
+		Key []byte `map:"key" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	var val rawStateValue
+	if err := s.stateStore.Get(string(paths.Key), &val); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			jsonhttp.NotFound(w, "entry not found")
+			return
+		}
+		logger.Debug("get statestore entry failed", "key", paths.Key, "error", err)
+		logger.Error(nil, "get statestore entry failed")
+		jsonhttp.InternalServerError(w, "get statestore entry failed")
+		return
+	}
+
+	w.Header().Set(ContentTypeHeader, "application/octet-stream")
+	w.Header().Set(ContentLengthHeader, strconv.Itoa(len(val)))
+	_, _ = w.Write(val)
+}
+
+// stateStoreDeleteHandler deletes a single statestore entry.
+func (s *Service) stateStoreDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_statestore_by_key").Build()
+
+	paths := struct {
+		Key []byte `map:"key" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.stateStore.Delete(string(paths.Key)); err != nil {
+		logger.Debug("delete statestore entry failed", "key", paths.Key, "error", err)
+		logger.Error(nil, "delete statestore entry failed")
+		jsonhttp.InternalServerError(w, "delete statestore entry failed")
+		return
+	}
+
+	logger.Info("statestore entry deleted", "key", strconv.QuoteToASCII(string(paths.Key)))
+	jsonhttp.OK(w, nil)
+}
+
+// stateStoreDumpHandler returns all the statestore entries with the given prefix.
+func (s *Service) stateStoreDumpHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_statestore_dump").Build()
+
+	queries := struct {
+		Prefix string `map:"prefix"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	resp := stateStoreDump{Entries: []stateStoreEntry{}}
+	err := s.stateStore.Iterate(queries.Prefix, func(key, val []byte) (bool, error) {
+		resp.Entries = append(resp.Entries, stateStoreEntry{
+			Key:   hex.EncodeToString(key),
+			Value: hex.EncodeToString(val),
+		})
+		return false, nil
+	})
+	if err != nil {
+		logger.Debug("iterate statestore failed", "prefix", queries.Prefix, "error", err)
+		logger.Error(nil, "iterate statestore failed")
+		jsonhttp.InternalServerError(w, "iterate statestore failed")
+		return
+	}
+
+	jsonhttp.OK(w, resp)
+}
+
+// stateStoreRestoreHandler stores all the entries from a dump. Existing
+// entries with the same keys are overwritten.
+func (s *Service) stateStoreRestoreHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_statestore_dump").Build()
+
+	var dump stateStoreDump
+	if err := json.NewDecoder(r.Body).Decode(&dump); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid dump")
+		return
+	}
+
+	keys := make([][]byte, len(dump.Entries))
+	vals := make([][]byte, len(dump.Entries))
+	for i, e := range dump.Entries {
+		key, err := hex.DecodeString(e.Key)
+		if err != nil || len(key) == 0 {
+			jsonhttp.BadRequest(w, fmt.Sprintf("invalid key of entry %d", i))
+			return
+		}
+		val, err := hex.DecodeString(e.Value)
+		if err != nil {
+			jsonhttp.BadRequest(w, fmt.Sprintf("invalid value of entry %d", i))
+			return
+		}
+		keys[i], vals[i] = key, val
+	}
+
+	for i := range keys {
+		if err := s.stateStore.Put(string(keys[i]), rawStateValue(vals[i])); err != nil {
+			logger.Debug("put statestore entry failed", "key", keys[i], "error", err)
+			logger.Error(nil, "put statestore entry failed")
+			jsonhttp.InternalServerError(w, fmt.Sprintf("put statestore entry failed after %d restored entries", i))
+			return
+		}
+	}
+
+	logger.Info("statestore entries restored", "count", len(dump.Entries))
+	jsonhttp.OK(w, stateStoreRestoreResponse{Restored: len(dump.Entries)})
+}
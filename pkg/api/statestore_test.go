@@ -0,0 +1,101 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/statestore/leveldb"
+)
+
+type rawValue []byte
+
+func (v rawValue) MarshalBinary() ([]byte, error) { return v, nil }
+
+func TestStateStore(t *testing.T) {
+	t.Parallel()
+
+	store, err := leveldb.NewInMemoryStateStore(log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	for _, k := range []string{"swap_cheque_a", "swap_cheque_b", "batchstore_c"} {
+		if err := store.Put(k, rawValue(k)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		StateStoreAPI: store,
+	})
+
+	hexKey := func(k string) string { return hex.EncodeToString([]byte(k)) }
+
+	t.Run("keys", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/statestore?prefix=swap_cheque", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.StateStoreKeysResponse{
+				Keys: []api.StateStoreKey{
+					{Key: hexKey("swap_cheque_a"), Printable: `"swap_cheque_a"`, Size: 13},
+					{Key: hexKey("swap_cheque_b"), Printable: `"swap_cheque_b"`, Size: 13},
+				},
+			}),
+		)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/statestore/"+hexKey("batchstore_c"), http.StatusOK,
+			jsonhttptest.WithExpectedResponse([]byte("batchstore_c")),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/statestore/"+hexKey("missing"), http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message:   "entry not found",
+				Code:      http.StatusNotFound,
+				ErrorCode: "not_found",
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/statestore/zz", http.StatusBadRequest)
+	})
+
+	t.Run("dump, delete and restore", func(t *testing.T) {
+		t.Parallel()
+
+		var dump api.StateStoreDump
+		jsonhttptest.Request(t, client, http.MethodGet, "/statestore/dump?prefix=batchstore", http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&dump),
+		)
+		if len(dump.Entries) != 1 {
+			t.Fatalf("dump entries: want 1, have %d", len(dump.Entries))
+		}
+
+		jsonhttptest.Request(t, client, http.MethodDelete, "/statestore/"+hexKey("batchstore_c"), http.StatusOK)
+		jsonhttptest.Request(t, client, http.MethodGet, "/statestore/"+hexKey("batchstore_c"), http.StatusNotFound)
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/statestore/dump", http.StatusOK,
+			jsonhttptest.WithJSONRequestBody(dump),
+			jsonhttptest.WithExpectedJSONResponse(api.StateStoreRestoreResponse{Restored: 1}),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/statestore/"+hexKey("batchstore_c"), http.StatusOK,
+			jsonhttptest.WithExpectedResponse([]byte("batchstore_c")),
+		)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodGet, "/statestore", http.StatusNotFound)
+	})
+}
@@ -7,31 +7,37 @@ package api
 import (
 	"context"
 	"net/http"
+	"slices"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/status"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/topology"
 )
 
 type statusSnapshotResponse struct {
-	Overlay                 string  `json:"overlay"`
-	Proximity               uint    `json:"proximity"`
-	BeeMode                 string  `json:"beeMode"`
-	ReserveSize             uint64  `json:"reserveSize"`
-	ReserveSizeWithinRadius uint64  `json:"reserveSizeWithinRadius"`
-	PullsyncRate            float64 `json:"pullsyncRate"`
-	StorageRadius           uint8   `json:"storageRadius"`
-	ConnectedPeers          uint64  `json:"connectedPeers"`
-	NeighborhoodSize        uint64  `json:"neighborhoodSize"`
-	RequestFailed           bool    `json:"requestFailed,omitempty"`
-	BatchCommitment         uint64  `json:"batchCommitment"`
-	IsReachable             bool    `json:"isReachable"`
-	LastSyncedBlock         uint64  `json:"lastSyncedBlock"`
-	CommittedDepth          uint8   `json:"committedDepth"`
-	IsWarmingUp             bool    `json:"isWarmingUp"`
+	Overlay                 string   `json:"overlay"`
+	Proximity               uint     `json:"proximity"`
+	BeeMode                 string   `json:"beeMode"`
+	ReserveSize             uint64   `json:"reserveSize"`
+	ReserveCapacity         uint64   `json:"reserveCapacity"`
+	ReserveSizeWithinRadius uint64   `json:"reserveSizeWithinRadius"`
+	PullsyncRate            float64  `json:"pullsyncRate"`
+	StorageRadius           uint8    `json:"storageRadius"`
+	ConnectedPeers          uint64   `json:"connectedPeers"`
+	NeighborhoodSize        uint64   `json:"neighborhoodSize"`
+	RequestFailed           bool     `json:"requestFailed,omitempty"`
+	BatchCommitment         uint64   `json:"batchCommitment"`
+	IsReachable             bool     `json:"isReachable"`
+	LastSyncedBlock         uint64   `json:"lastSyncedBlock"`
+	CommittedDepth          uint8    `json:"committedDepth"`
+	IsWarmingUp             bool     `json:"isWarmingUp"`
+	PriceTable              []uint64 `json:"priceTable,omitempty"`
+	PartialReserve          bool     `json:"partialReserve"`
 }
 
 type statusResponse struct {
@@ -44,8 +50,20 @@ type statusNeighborhoodResponse struct {
 	Proximity               uint8  `json:"proximity"`
 }
 
+// statusNetworkResponse holds the medians of the values reported by the
+// full nodes among this node and its connected peers.
+type statusNetworkResponse struct {
+	Nodes           int      `json:"nodes"`
+	ReserveSize     uint64   `json:"reserveSize"`
+	ReserveCapacity uint64   `json:"reserveCapacity"`
+	StorageRadius   uint8    `json:"storageRadius"`
+	CommittedDepth  uint8    `json:"committedDepth"`
+	PriceTable      []uint64 `json:"priceTable,omitempty"`
+}
+
 type neighborhoodsResponse struct {
 	Neighborhoods []statusNeighborhoodResponse `json:"neighborhoods"`
+	Network       *statusNetworkResponse       `json:"network,omitempty"`
 }
 
 // statusAccessHandler is a middleware that limits the number of simultaneous
@@ -71,7 +89,7 @@ func (s *Service) statusGetHandler(w http.ResponseWriter, _ *http.Request) {
 
 	if s.beeMode == DevMode {
 		logger.Warning("status endpoint is disabled in dev mode")
-		jsonhttp.BadRequest(w, errUnsupportedDevNodeOperation)
+		jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
 		return
 	}
 
@@ -83,11 +101,19 @@ func (s *Service) statusGetHandler(w http.ResponseWriter, _ *http.Request) {
 		return
 	}
 
-	jsonhttp.OK(w, statusSnapshotResponse{
-		Proximity:               256,
-		Overlay:                 s.overlay.String(),
+	snapshot := newStatusSnapshotResponse(ss)
+	snapshot.Proximity = 256
+	snapshot.Overlay = s.overlay.String()
+	snapshot.IsWarmingUp = s.isWarmingUp
+	jsonhttp.OK(w, snapshot)
+}
+
+// newStatusSnapshotResponse maps the status snapshot to the response.
+func newStatusSnapshotResponse(ss *status.Snapshot) statusSnapshotResponse {
+	return statusSnapshotResponse{
 		BeeMode:                 ss.BeeMode,
 		ReserveSize:             ss.ReserveSize,
+		ReserveCapacity:         ss.ReserveCapacity,
 		ReserveSizeWithinRadius: ss.ReserveSizeWithinRadius,
 		PullsyncRate:            ss.PullsyncRate,
 		StorageRadius:           uint8(ss.StorageRadius),
@@ -97,8 +123,9 @@ func (s *Service) statusGetHandler(w http.ResponseWriter, _ *http.Request) {
 		IsReachable:             ss.IsReachable,
 		LastSyncedBlock:         ss.LastSyncedBlock,
 		CommittedDepth:          uint8(ss.CommittedDepth),
-		IsWarmingUp:             s.isWarmingUp,
-	})
+		PriceTable:              ss.PriceTable,
+		PartialReserve:          ss.PartialReserve,
+	}
 }
 
 // statusGetPeersHandler returns the status of currently connected peers.
@@ -107,10 +134,27 @@ func (s *Service) statusGetPeersHandler(w http.ResponseWriter, r *http.Request)
 
 	if s.beeMode == DevMode {
 		logger.Warning("status endpoint is disabled in dev mode")
-		jsonhttp.BadRequest(w, errUnsupportedDevNodeOperation)
+		jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
+		return
+	}
+
+	snapshots, err := s.peerStatusSnapshots(r.Context(), logger)
+	if err != nil {
+		logger.Debug("status snapshot", "error", err)
+		logger.Error(nil, "status snapshot")
+		jsonhttp.InternalServerError(w, err)
 		return
 	}
 
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Proximity < snapshots[j].Proximity
+	})
+	jsonhttp.OK(w, statusResponse{Snapshots: snapshots})
+}
+
+// peerStatusSnapshots requests the status snapshots of the connected peers.
+// The snapshots of the peers which failed to respond are marked as failed.
+func (s *Service) peerStatusSnapshots(ctx context.Context, logger log.Logger) ([]statusSnapshotResponse, error) {
 	var (
 		wg        sync.WaitGroup
 		mu        sync.Mutex // mu protects snapshots.
@@ -118,35 +162,23 @@ func (s *Service) statusGetPeersHandler(w http.ResponseWriter, r *http.Request)
 	)
 
 	peerFunc := func(address swarm.Address, po uint8) (bool, bool, error) {
-		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 
 		wg.Add(1)
 		go func() {
 			defer cancel()
 			defer wg.Done()
 
-			snapshot := statusSnapshotResponse{
-				Overlay:   address.String(),
-				Proximity: uint(po),
-			}
-
+			var snapshot statusSnapshotResponse
 			ss, err := s.statusService.PeerSnapshot(ctx, address)
 			if err != nil {
 				logger.Debug("unable to get status snapshot for peer", "peer_address", address, "error", err)
 				snapshot.RequestFailed = true
 			} else {
-				snapshot.BeeMode = ss.BeeMode
-				snapshot.ReserveSize = ss.ReserveSize
-				snapshot.ReserveSizeWithinRadius = ss.ReserveSizeWithinRadius
-				snapshot.PullsyncRate = ss.PullsyncRate
-				snapshot.StorageRadius = uint8(ss.StorageRadius)
-				snapshot.ConnectedPeers = ss.ConnectedPeers
-				snapshot.NeighborhoodSize = ss.NeighborhoodSize
-				snapshot.BatchCommitment = ss.BatchCommitment
-				snapshot.IsReachable = ss.IsReachable
-				snapshot.LastSyncedBlock = ss.LastSyncedBlock
-				snapshot.CommittedDepth = uint8(ss.CommittedDepth)
+				snapshot = newStatusSnapshotResponse(ss)
 			}
+			snapshot.Overlay = address.String()
+			snapshot.Proximity = uint(po)
 
 			mu.Lock()
 			snapshots = append(snapshots, snapshot)
@@ -160,19 +192,11 @@ func (s *Service) statusGetPeersHandler(w http.ResponseWriter, r *http.Request)
 		peerFunc,
 		topology.Select{},
 	)
+	wg.Wait()
 	if err != nil {
-		logger.Debug("status snapshot", "error", err)
-		logger.Error(nil, "status snapshot")
-		jsonhttp.InternalServerError(w, err)
-		return
+		return nil, err
 	}
-
-	wg.Wait()
-
-	sort.Slice(snapshots, func(i, j int) bool {
-		return snapshots[i].Proximity < snapshots[j].Proximity
-	})
-	jsonhttp.OK(w, statusResponse{Snapshots: snapshots})
+	return snapshots, nil
 }
 
 // statusGetHandler returns the current node status.
@@ -181,7 +205,7 @@ func (s *Service) statusGetNeighborhoods(w http.ResponseWriter, r *http.Request)
 
 	if s.beeMode == DevMode {
 		logger.Warning("status neighborhoods endpoint is disabled in dev mode")
-		jsonhttp.BadRequest(w, errUnsupportedDevNodeOperation)
+		jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeUnsupportedInDevMode, errUnsupportedDevNodeOperation))
 		return
 	}
 
@@ -203,5 +227,76 @@ func (s *Service) statusGetNeighborhoods(w http.ResponseWriter, r *http.Request)
 		})
 	}
 
-	jsonhttp.OK(w, neighborhoodsResponse{Neighborhoods: neighborhoods})
+	var network *statusNetworkResponse
+	if s.statusService != nil {
+		snapshots, err := s.peerStatusSnapshots(r.Context(), logger)
+		if err != nil {
+			logger.Debug("unable to get peers status", "error", err)
+			logger.Error(nil, "unable to get peers status")
+			jsonhttp.InternalServerError(w, "unable to get peers status")
+			return
+		}
+		ss, err := s.statusService.LocalSnapshot()
+		if err != nil {
+			logger.Debug("status snapshot", "error", err)
+			logger.Error(nil, "status snapshot")
+			jsonhttp.InternalServerError(w, "unable to get status snapshot")
+			return
+		}
+		network = networkMedians(append(snapshots, newStatusSnapshotResponse(ss)))
+	}
+
+	jsonhttp.OK(w, neighborhoodsResponse{Neighborhoods: neighborhoods, Network: network})
+}
+
+// networkMedians returns the medians of the values reported by the full
+// nodes among the snapshots. It returns nil if there are no such snapshots.
+func networkMedians(snapshots []statusSnapshotResponse) *statusNetworkResponse {
+	var (
+		reserveSizes      []uint64
+		reserveCapacities []uint64
+		storageRadii      []uint8
+		committedDepths   []uint8
+		prices            [swarm.MaxPO + 1][]uint64
+	)
+	for _, ss := range snapshots {
+		if ss.RequestFailed || ss.BeeMode != FullMode.String() {
+			continue
+		}
+		reserveSizes = append(reserveSizes, ss.ReserveSize)
+		reserveCapacities = append(reserveCapacities, ss.ReserveCapacity)
+		storageRadii = append(storageRadii, ss.StorageRadius)
+		committedDepths = append(committedDepths, ss.CommittedDepth)
+		for po, price := range ss.PriceTable {
+			if po < len(prices) {
+				prices[po] = append(prices[po], price)
+			}
+		}
+	}
+	if len(reserveSizes) == 0 {
+		return nil
+	}
+
+	network := &statusNetworkResponse{
+		Nodes:           len(reserveSizes),
+		ReserveSize:     median(reserveSizes),
+		ReserveCapacity: median(reserveCapacities),
+		StorageRadius:   median(storageRadii),
+		CommittedDepth:  median(committedDepths),
+	}
+	for _, p := range prices {
+		if len(p) == 0 {
+			break
+		}
+		network.PriceTable = append(network.PriceTable, median(p))
+	}
+	return network
+}
+
+// median returns the median of the non-empty values.
+// The values are sorted in place.
+func median[T uint8 | uint64](values []T) T {
+	slices.Sort(values)
+	lo, hi := values[(len(values)-1)/2], values[len(values)/2]
+	return lo + (hi-lo)/2
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/status"
 	"github.com/ethersphere/bee/v2/pkg/storer"
 	"github.com/ethersphere/bee/v2/pkg/topology"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestGetStatus(t *testing.T) {
@@ -32,6 +33,7 @@ func TestGetStatus(t *testing.T) {
 			Proximity:               256,
 			BeeMode:                 mode.String(),
 			ReserveSize:             128,
+			ReserveCapacity:         256,
 			ReserveSizeWithinRadius: 64,
 			PullsyncRate:            64,
 			StorageRadius:           8,
@@ -41,16 +43,19 @@ func TestGetStatus(t *testing.T) {
 			IsReachable:             true,
 			LastSyncedBlock:         6092500,
 			CommittedDepth:          1,
+			PartialReserve:          true,
 		}
 
 		ssMock := &statusSnapshotMock{
 			syncRate:                ssr.PullsyncRate,
 			reserveSize:             int(ssr.ReserveSize),
+			reserveCapacity:         int(ssr.ReserveCapacity),
 			reserveSizeWithinRadius: ssr.ReserveSizeWithinRadius,
 			storageRadius:           ssr.StorageRadius,
 			commitment:              ssr.BatchCommitment,
 			chainState:              &postage.ChainState{Block: ssr.LastSyncedBlock},
 			committedDepth:          ssr.CommittedDepth,
+			partialHistory:          ssr.PartialReserve,
 		}
 
 		statusSvc := status.NewService(
@@ -93,13 +98,55 @@ func TestGetStatus(t *testing.T) {
 
 		jsonhttptest.Request(t, client, http.MethodGet, url, http.StatusBadRequest,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: api.ErrUnsupportedDevNodeOperation.Error(),
-				Code:    http.StatusBadRequest,
+				Message:   api.ErrUnsupportedDevNodeOperation.Error(),
+				Code:      http.StatusBadRequest,
+				ErrorCode: api.ErrorCodeUnsupportedInDevMode,
 			}),
 		)
 	})
 }
 
+func TestNetworkMedians(t *testing.T) {
+	t.Parallel()
+
+	full := api.FullMode.String()
+
+	t.Run("no full nodes", func(t *testing.T) {
+		t.Parallel()
+
+		got := api.NetworkMedians([]api.StatusSnapshotResponse{
+			{BeeMode: api.LightMode.String(), ReserveSize: 10},
+			{BeeMode: full, RequestFailed: true},
+		})
+		if got != nil {
+			t.Fatalf("got %+v, want nil", got)
+		}
+	})
+
+	t.Run("medians", func(t *testing.T) {
+		t.Parallel()
+
+		got := api.NetworkMedians([]api.StatusSnapshotResponse{
+			{BeeMode: full, ReserveSize: 100, ReserveCapacity: 400, StorageRadius: 9, CommittedDepth: 10, PriceTable: []uint64{30, 20, 10}},
+			{BeeMode: full, ReserveSize: 300, ReserveCapacity: 200, StorageRadius: 10, CommittedDepth: 10, PriceTable: []uint64{60, 40, 20}},
+			{BeeMode: full, ReserveSize: 200, ReserveCapacity: 200, StorageRadius: 8, CommittedDepth: 9, PriceTable: []uint64{90, 60}},
+			{BeeMode: full, ReserveSize: 1000, RequestFailed: true},
+			{BeeMode: api.LightMode.String(), ReserveSize: 1000},
+		})
+		want := &api.StatusNetworkResponse{
+			Nodes:           3,
+			ReserveSize:     200,
+			ReserveCapacity: 200,
+			StorageRadius:   9,
+			CommittedDepth:  10,
+			PriceTable:      []uint64{60, 40, 15},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("unexpected network medians (-want +got):\n%s", diff)
+		}
+	})
+}
+
 // topologyPeersIterNoopMock is noop topology.PeerIterator.
 type topologyPeersIterNoopMock struct{}
 
@@ -121,16 +168,20 @@ func (m *topologyPeersIterNoopMock) IsReachable() bool {
 type statusSnapshotMock struct {
 	syncRate                float64
 	reserveSize             int
+	reserveCapacity         int
 	reserveSizeWithinRadius uint64
 	storageRadius           uint8
 	commitment              uint64
 	chainState              *postage.ChainState
 	neighborhoods           []*storer.NeighborhoodStat
 	committedDepth          uint8
+	partialHistory          bool
 }
 
 func (m *statusSnapshotMock) SyncRate() float64                  { return m.syncRate }
+func (m *statusSnapshotMock) PartialHistory() bool               { return m.partialHistory }
 func (m *statusSnapshotMock) ReserveSize() int                   { return m.reserveSize }
+func (m *statusSnapshotMock) ReserveCapacity() int               { return m.reserveCapacity }
 func (m *statusSnapshotMock) StorageRadius() uint8               { return m.storageRadius }
 func (m *statusSnapshotMock) Commitment() (uint64, error)        { return m.commitment, nil }
 func (m *statusSnapshotMock) GetChainState() *postage.ChainState { return m.chainState }
@@ -19,6 +19,7 @@ import (
 // StewardshipPutHandler re-uploads root hash and all of its underlying associated chunks to the network.
 func (s *Service) stewardshipPutHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("put_stewardship").Build()
+	w, r = withTraversalProgress(w, r)
 
 	paths := struct {
 		Address swarm.Address `map:"address,resolve" validate:"required"`
@@ -46,11 +47,11 @@ func (s *Service) stewardshipPutHandler(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		switch {
 		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+			jsonhttp.UnprocessableEntity(w, jsonhttp.Coded(ErrorCodeBatchNotUsable, "batch not usable yet or does not exist"))
 		case errors.Is(err, postage.ErrNotFound) || errors.Is(err, storage.ErrNotFound):
-			jsonhttp.NotFound(w, "batch with id not found")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeBatchNotFound, "batch with id not found"))
 		case errors.Is(err, errInvalidPostageBatch):
-			jsonhttp.BadRequest(w, "invalid batch id")
+			jsonhttp.BadRequest(w, jsonhttp.Coded(ErrorCodeInvalidBatchID, "invalid batch id"))
 		default:
 			jsonhttp.BadRequest(w, nil)
 		}
@@ -82,6 +83,7 @@ type isRetrievableResponse struct {
 // stewardshipGetHandler checks whether the content on the given address is retrievable.
 func (s *Service) stewardshipGetHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("get_stewardship").Build()
+	w, r = withTraversalProgress(w, r)
 
 	paths := struct {
 		Address swarm.Address `map:"address,resolve" validate:"required"`
@@ -62,8 +62,9 @@ func TestStewardship(t *testing.T) {
 		)
 		jsonhttptest.Request(t, client, http.MethodGet, "/v1/stewardship/"+hex.EncodeToString([]byte{}), http.StatusNotFound,
 			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
-				Code:    http.StatusNotFound,
-				Message: http.StatusText(http.StatusNotFound),
+				Code:      http.StatusNotFound,
+				Message:   http.StatusText(http.StatusNotFound),
+				ErrorCode: "not_found",
 			}),
 		)
 	})
@@ -144,6 +145,7 @@ func TestStewardshipInvalidInputs(t *testing.T) {
 					Error: api.ErrHexLength.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}, {
 		name:    "address - invalid hex character",
@@ -157,6 +159,7 @@ func TestStewardshipInvalidInputs(t *testing.T) {
 					Error: api.HexInvalidByteError('G').Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -178,8 +181,9 @@ func TestStewardshipInvalidInputs(t *testing.T) {
 		jsonhttptest.Request(t, client, http.MethodPut, "/stewardship/1234", http.StatusNotFound,
 			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, "1234"),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusNotFound,
-				Message: "batch with id not found",
+				Code:      http.StatusNotFound,
+				Message:   "batch with id not found",
+				ErrorCode: api.ErrorCodeBatchNotFound,
 			}),
 		)
 	})
@@ -197,6 +201,7 @@ func TestStewardshipInvalidInputs(t *testing.T) {
 						Error: api.HexInvalidByteError('G').Error(),
 					},
 				},
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
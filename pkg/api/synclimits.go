@@ -0,0 +1,71 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/puller"
+)
+
+// SyncLimiter gets and sets the runtime limits of the syncing.
+type SyncLimiter interface {
+	Limits() puller.Limits
+	SetLimits(puller.Limits) error
+}
+
+type syncLimitsResponse struct {
+	// Bandwidth is in megabytes per second.
+	Bandwidth       float64 `json:"bandwidth"`
+	HistoricalHours string  `json:"historicalHours"`
+}
+
+func newSyncLimitsResponse(l puller.Limits) syncLimitsResponse {
+	return syncLimitsResponse{
+		Bandwidth:       l.Bandwidth,
+		HistoricalHours: l.HistoricalHours.String(),
+	}
+}
+
+// syncLimitsGetHandler returns the current syncing limits.
+func (s *Service) syncLimitsGetHandler(w http.ResponseWriter, _ *http.Request) {
+	jsonhttp.OK(w, newSyncLimitsResponse(s.syncLimiter.Limits()))
+}
+
+// syncLimitsPutHandler replaces the syncing limits.
+func (s *Service) syncLimitsPutHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_pullsync_limits").Build()
+
+	var req syncLimitsResponse
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, "invalid limits")
+		return
+	}
+
+	hours, err := puller.ParseHourWindow(req.HistoricalHours)
+	if err != nil {
+		logger.Debug("invalid historical hours", "error", err)
+		jsonhttp.BadRequest(w, "invalid historical hours")
+		return
+	}
+
+	limits := puller.Limits{Bandwidth: req.Bandwidth, HistoricalHours: hours}
+	if err := s.syncLimiter.SetLimits(limits); err != nil {
+		logger.Debug("set limits failed", "error", err)
+		logger.Error(nil, "set limits failed")
+		if errors.Is(err, puller.ErrInvalidLimits) {
+			jsonhttp.BadRequest(w, "invalid limits")
+			return
+		}
+		jsonhttp.InternalServerError(w, "set limits failed")
+		return
+	}
+
+	jsonhttp.OK(w, newSyncLimitsResponse(s.syncLimiter.Limits()))
+}
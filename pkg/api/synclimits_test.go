@@ -0,0 +1,65 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/puller"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestSyncLimits(t *testing.T) {
+	t.Parallel()
+
+	p := puller.New(swarm.RandAddress(t), nil, nil, nil, nil, nil, log.Noop, puller.Options{
+		Limits: puller.Limits{Bandwidth: 4},
+	})
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		SyncLimiter: p,
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/pullsync/limits", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.SyncLimitsResponse{Bandwidth: 4}),
+	)
+
+	want := api.SyncLimitsResponse{Bandwidth: 1.5, HistoricalHours: "22-6"}
+	jsonhttptest.Request(t, client, http.MethodPut, "/pullsync/limits", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(want),
+		jsonhttptest.WithExpectedJSONResponse(want),
+	)
+	if have := p.Limits(); have.Bandwidth != 1.5 || have.HistoricalHours != (puller.HourWindow{From: 22, To: 6}) {
+		t.Fatalf("limits not applied: %+v", have)
+	}
+
+	jsonhttptest.Request(t, client, http.MethodPut, "/pullsync/limits", http.StatusBadRequest,
+		jsonhttptest.WithJSONRequestBody(api.SyncLimitsResponse{HistoricalHours: "25-1"}),
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message:   "invalid historical hours",
+			Code:      http.StatusBadRequest,
+			ErrorCode: "bad_request",
+		}),
+	)
+	jsonhttptest.Request(t, client, http.MethodPut, "/pullsync/limits", http.StatusBadRequest,
+		jsonhttptest.WithJSONRequestBody(api.SyncLimitsResponse{Bandwidth: -1}),
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message:   "invalid limits",
+			Code:      http.StatusBadRequest,
+			ErrorCode: "bad_request",
+		}),
+	)
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodGet, "/pullsync/limits", http.StatusNotFound)
+	})
+}
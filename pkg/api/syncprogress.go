@@ -0,0 +1,67 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/puller"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// SyncProgress reports the syncing progress of the bins of the peers.
+type SyncProgress interface {
+	Status() ([]puller.BinStatus, error)
+}
+
+type binSyncStatusResponse struct {
+	Peer           swarm.Address `json:"peer"`
+	ProximityOrder uint8         `json:"proximityOrder"`
+	Bin            uint8         `json:"bin"`
+	Cursor         uint64        `json:"cursor"`
+	Remaining      uint64        `json:"remaining"`
+	Rate           float64       `json:"rate"`
+	ETASeconds     float64       `json:"etaSeconds"`
+}
+
+type syncProgressResponse struct {
+	// Remaining is the total estimated number of the bin IDs not synced yet.
+	Remaining uint64 `json:"remaining"`
+	// ETASeconds is the estimate for the slowest of the bins, as they are synced in parallel.
+	ETASeconds float64                 `json:"etaSeconds"`
+	Bins       []binSyncStatusResponse `json:"bins"`
+}
+
+// syncProgressHandler returns the historical syncing
+// progress and its estimated completion time per bin.
+func (s *Service) syncProgressHandler(w http.ResponseWriter, _ *http.Request) {
+	logger := s.logger.WithName("get_pullsync_status").Build()
+
+	status, err := s.syncProgress.Status()
+	if err != nil {
+		logger.Debug("sync status failed", "error", err)
+		logger.Error(nil, "sync status failed")
+		jsonhttp.InternalServerError(w, "sync status failed")
+		return
+	}
+
+	resp := syncProgressResponse{Bins: make([]binSyncStatusResponse, 0, len(status))}
+	for _, v := range status {
+		resp.Bins = append(resp.Bins, binSyncStatusResponse{
+			Peer:           v.Peer,
+			ProximityOrder: v.PO,
+			Bin:            v.Bin,
+			Cursor:         v.Cursor,
+			Remaining:      v.Remaining,
+			Rate:           v.Rate,
+			ETASeconds:     v.ETA.Seconds(),
+		})
+		resp.Remaining += v.Remaining
+		resp.ETASeconds = max(resp.ETASeconds, v.ETA.Seconds())
+	}
+
+	jsonhttp.OK(w, resp)
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/puller"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+type mockSyncProgress []puller.BinStatus
+
+func (m mockSyncProgress) Status() ([]puller.BinStatus, error) { return m, nil }
+
+func TestSyncProgress(t *testing.T) {
+	t.Parallel()
+
+	peer := swarm.RandAddress(t)
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		SyncProgress: mockSyncProgress{
+			{Peer: peer, PO: 3, Bin: 3, Cursor: 100, Remaining: 0, Rate: 5},
+			{Peer: peer, PO: 3, Bin: 4, Cursor: 200, Remaining: 150, Rate: 10, ETA: 15 * time.Second},
+		},
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/pullsync/status", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.SyncProgressResponse{
+			Remaining:  150,
+			ETASeconds: 15,
+			Bins: []api.BinSyncStatusResponse{
+				{Peer: peer, ProximityOrder: 3, Bin: 3, Cursor: 100, Remaining: 0, Rate: 5},
+				{Peer: peer, ProximityOrder: 3, Bin: 4, Cursor: 200, Remaining: 150, Rate: 10, ETASeconds: 15},
+			},
+		}),
+	)
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodGet, "/pullsync/status", http.StatusNotFound)
+	})
+}
@@ -80,7 +80,7 @@ func (s *Service) getTagHandler(w http.ResponseWriter, r *http.Request) {
 		if errors.Is(err, storage.ErrNotFound) {
 			logger.Debug("tag not found", "tag_id", paths.TagID)
 			logger.Error(nil, "tag not found")
-			jsonhttp.NotFound(w, "tag not present")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not present"))
 			return
 		}
 		logger.Debug("get tag failed", "tag_id", paths.TagID, "error", err)
@@ -108,7 +108,7 @@ func (s *Service) deleteTagHandler(w http.ResponseWriter, r *http.Request) {
 		if errors.Is(err, storage.ErrNotFound) {
 			logger.Debug("tag not found", "tag_id", paths.TagID)
 			logger.Error(nil, "tag not found")
-			jsonhttp.NotFound(w, "tag not present")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not present"))
 			return
 		}
 		logger.Debug("get tag failed", "tag_id", paths.TagID, "error", err)
@@ -158,7 +158,7 @@ func (s *Service) doneSplitHandler(w http.ResponseWriter, r *http.Request) {
 		if errors.Is(err, storage.ErrNotFound) {
 			logger.Debug("tag not found", "tag_id", paths.TagID)
 			logger.Error(nil, "tag not found")
-			jsonhttp.NotFound(w, "tag not present")
+			jsonhttp.NotFound(w, jsonhttp.Coded(ErrorCodeTagNotFound, "tag not present"))
 			return
 		}
 		logger.Debug("get tag failed", "tag_id", paths.TagID, "error", err)
@@ -54,8 +54,9 @@ func TestTags(t *testing.T) {
 	t.Run("get non-existent tag", func(t *testing.T) {
 		jsonhttptest.Request(t, client, http.MethodGet, tagsWithIdResource(uint64(333)), http.StatusNotFound,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "tag not present",
-				Code:    http.StatusNotFound,
+				Message:   "tag not present",
+				Code:      http.StatusNotFound,
+				ErrorCode: api.ErrorCodeTagNotFound,
 			}),
 		)
 	})
@@ -100,8 +101,9 @@ func TestTags(t *testing.T) {
 		// try to delete non-existent tag
 		jsonhttptest.Request(t, client, http.MethodDelete, tagsWithIdResource(uint64(333)), http.StatusNotFound,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "tag not present",
-				Code:    http.StatusNotFound,
+				Message:   "tag not present",
+				Code:      http.StatusNotFound,
+				ErrorCode: api.ErrorCodeTagNotFound,
 			}),
 		)
 	})
@@ -125,8 +127,9 @@ func TestTags(t *testing.T) {
 		// try to get tag
 		jsonhttptest.Request(t, client, http.MethodGet, tagsWithIdResource(tRes.Uid), http.StatusNotFound,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "tag not present",
-				Code:    http.StatusNotFound,
+				Message:   "tag not present",
+				Code:      http.StatusNotFound,
+				ErrorCode: api.ErrorCodeTagNotFound,
 			}),
 		)
 	})
@@ -136,8 +139,9 @@ func TestTags(t *testing.T) {
 		jsonhttptest.Request(t, client, http.MethodPatch, tagsWithIdResource(uint64(333)), http.StatusNotFound,
 			jsonhttptest.WithJSONRequestBody(api.TagResponse{}),
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "tag not present",
-				Code:    http.StatusNotFound,
+				Message:   "tag not present",
+				Code:      http.StatusNotFound,
+				ErrorCode: api.ErrorCodeTagNotFound,
 			}),
 		)
 	})
@@ -217,6 +221,7 @@ func TestTagsHandlersInvalidInputs(t *testing.T) {
 					Error: strconv.ErrSyntax.Error(),
 				},
 			},
+			ErrorCode: "bad_request",
 		},
 	}}
 
@@ -0,0 +1,67 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// blockingChunkStore blocks the retrieval of the chunks
+// until the context of the request is done.
+type blockingChunkStore struct {
+	storage.ChunkStore
+}
+
+func (b blockingChunkStore) Get(ctx context.Context, _ swarm.Address) (swarm.Chunk, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestTimeoutHeader(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.NewWithChunkStore(blockingChunkStore{inmemchunkstore.New()}),
+	})
+	resource := "/chunks/" + swarm.RandAddress(t).String()
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, resource, http.StatusGatewayTimeout,
+			jsonhttptest.WithRequestHeader(api.SwarmTimeoutHeader, "50ms"),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusGatewayTimeout,
+				Message:   "request timed out",
+				ErrorCode: "gateway_timeout",
+			}),
+		)
+	})
+
+	for _, v := range []string{"soon", "0s", "-1s"} {
+		t.Run("invalid "+v, func(t *testing.T) {
+			t.Parallel()
+
+			jsonhttptest.Request(t, client, http.MethodGet, resource, http.StatusBadRequest,
+				jsonhttptest.WithRequestHeader(api.SwarmTimeoutHeader, v),
+				jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+					Code:      http.StatusBadRequest,
+					Message:   "invalid timeout",
+					ErrorCode: "bad_request",
+				}),
+			)
+		})
+	}
+}
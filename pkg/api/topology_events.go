@@ -0,0 +1,142 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	"github.com/gorilla/websocket"
+)
+
+type topologyEventResponse struct {
+	Type           topology.EventType `json:"type"`
+	Time           time.Time          `json:"time"`
+	Peer           *swarm.Address     `json:"peer,omitempty"`
+	ProximityOrder *uint8             `json:"proximityOrder,omitempty"`
+	Direction      string             `json:"direction,omitempty"`
+	Depth          uint8              `json:"depth"`
+	Reason         string             `json:"reason,omitempty"`
+	DurationSecs   float64            `json:"durationSeconds,omitempty"`
+}
+
+func newTopologyEventResponse(e topology.Event) topologyEventResponse {
+	resp := topologyEventResponse{
+		Type:         e.Type,
+		Time:         e.Time,
+		Direction:    e.Direction,
+		Depth:        e.Depth,
+		Reason:       e.Reason,
+		DurationSecs: e.Duration.Seconds(),
+	}
+	if !e.Peer.IsZero() {
+		resp.Peer = &e.Peer
+		resp.ProximityOrder = &e.ProximityOrder
+	}
+	return resp
+}
+
+// topologyEventsWsHandler streams the topology events over the websocket.
+func (s *Service) topologyEventsWsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("topology_events").Build()
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  swarm.ChunkSize,
+		WriteBufferSize: swarm.ChunkSize,
+		CheckOrigin:     s.checkOrigin,
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Debug("upgrade failed", "error", err)
+		logger.Error(nil, "upgrade failed")
+		jsonhttp.InternalServerError(w, "upgrade failed")
+		return
+	}
+
+	s.wsWg.Add(1)
+	go s.topologyEventsWs(conn)
+}
+
+func (s *Service) topologyEventsWs(conn *websocket.Conn) {
+	defer s.wsWg.Done()
+
+	var (
+		gone   = make(chan struct{})
+		ticker = time.NewTicker(s.WsPingPeriod)
+		err    error
+	)
+	defer func() {
+		ticker.Stop()
+		_ = conn.Close()
+	}()
+
+	events, unsubscribe := s.topologyEvents.SubscribeEvents()
+	defer unsubscribe()
+
+	conn.SetCloseHandler(func(code int, text string) error {
+		s.logger.Debug("topology events ws: client gone", "code", code, "message", text)
+		close(gone)
+		return nil
+	})
+
+	// the reads are needed to process the close messages of the client
+	go func() {
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("topology events ws: set write deadline failed", "error", err)
+				return
+			}
+
+			err = conn.WriteJSON(newTopologyEventResponse(e))
+			if err != nil {
+				s.logger.Debug("topology events ws: write message failed", "error", err)
+				return
+			}
+
+		case <-s.quit:
+			// shutdown
+			err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("topology events ws: set write deadline failed", "error", err)
+				return
+			}
+			err = conn.WriteMessage(websocket.CloseMessage, []byte{})
+			if err != nil {
+				s.logger.Debug("topology events ws: write close message failed", "error", err)
+			}
+			return
+		case <-gone:
+			// client gone
+			return
+		case <-ticker.C:
+			err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("topology events ws: set write deadline failed", "error", err)
+				return
+			}
+			if err = conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				// error encountered while pinging client. client probably gone
+				return
+			}
+		}
+	}
+}
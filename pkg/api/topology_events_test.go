@@ -0,0 +1,72 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	"github.com/google/go-cmp/cmp"
+)
+
+type mockEventSubscriber []topology.Event
+
+func (m mockEventSubscriber) SubscribeEvents() (<-chan topology.Event, func()) {
+	c := make(chan topology.Event, len(m))
+	for _, e := range m {
+		c <- e
+	}
+	return c, func() {}
+}
+
+func TestTopologyEvents(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peer = swarm.RandAddress(t)
+		now  = time.Now().UTC().Truncate(time.Second)
+		po   = uint8(3)
+	)
+
+	_, conn, _, _ := newTestServer(t, testServerOptions{
+		WsPath: "/topology/events",
+		TopologyEvents: mockEventSubscriber{
+			{Type: topology.EventPeerConnected, Time: now, Peer: peer, ProximityOrder: po, Direction: "inbound", Depth: 2},
+			{Type: topology.EventDepthChanged, Time: now, Depth: 3},
+			{Type: topology.EventPeerBlocklisted, Time: now, Peer: peer, ProximityOrder: po, Depth: 3, Reason: "misbehaving", Duration: time.Minute},
+		},
+	})
+
+	want := []api.TopologyEventResponse{
+		{Type: topology.EventPeerConnected, Time: now, Peer: &peer, ProximityOrder: &po, Direction: "inbound", Depth: 2},
+		{Type: topology.EventDepthChanged, Time: now, Depth: 3},
+		{Type: topology.EventPeerBlocklisted, Time: now, Peer: &peer, ProximityOrder: &po, Depth: 3, Reason: "misbehaving", DurationSecs: 60},
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range want {
+		var have api.TopologyEventResponse
+		if err := conn.ReadJSON(&have); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(w, have); diff != "" {
+			t.Fatalf("event mismatch (-want +have):\n%s", diff)
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodGet, "/topology/events", http.StatusNotFound)
+	})
+}
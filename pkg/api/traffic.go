@@ -0,0 +1,58 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/p2p/traffic"
+)
+
+// TrafficMeter reports the traffic of the p2p protocols.
+type TrafficMeter interface {
+	Snapshot() traffic.Snapshot
+}
+
+type trafficStatsResponse struct {
+	BytesIn  uint64 `json:"bytesIn"`
+	BytesOut uint64 `json:"bytesOut"`
+}
+
+type trafficResponse struct {
+	PeriodStart  time.Time                       `json:"periodStart"`
+	MonthlyCap   uint64                          `json:"monthlyCap"`
+	Total        trafficStatsResponse            `json:"total"`
+	Throttled    bool                            `json:"throttled"`
+	CapReached   bool                            `json:"capReached"`
+	NonEssential []string                        `json:"nonEssential"`
+	Protocols    map[string]trafficStatsResponse `json:"protocols"`
+	Peers        map[string]trafficStatsResponse `json:"peers"`
+}
+
+func mapTrafficStats(m map[string]traffic.Stats) map[string]trafficStatsResponse {
+	out := make(map[string]trafficStatsResponse, len(m))
+	for k, v := range m {
+		out[k] = trafficStatsResponse{BytesIn: v.In, BytesOut: v.Out}
+	}
+	return out
+}
+
+// trafficHandler returns the traffic of the current monthly period.
+func (s *Service) trafficHandler(w http.ResponseWriter, _ *http.Request) {
+	ss := s.traffic.Snapshot()
+
+	jsonhttp.OK(w, trafficResponse{
+		PeriodStart:  ss.PeriodStart,
+		MonthlyCap:   ss.Cap,
+		Total:        trafficStatsResponse{BytesIn: ss.Total.In, BytesOut: ss.Total.Out},
+		Throttled:    ss.Throttled,
+		CapReached:   ss.CapReached,
+		NonEssential: ss.NonEssential,
+		Protocols:    mapTrafficStats(ss.Protocols),
+		Peers:        mapTrafficStats(ss.Peers),
+	})
+}
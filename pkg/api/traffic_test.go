@@ -0,0 +1,55 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p/traffic"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestTraffic(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peer  = swarm.RandAddress(t)
+		meter = traffic.NewMeter(traffic.Options{MonthlyCap: 1000}, log.Noop)
+		now   = time.Now().UTC()
+	)
+	meter.Record("retrieval", peer, 100, 20)
+	meter.Record("pullsync", peer, 800, 0)
+
+	client, _, _, _ := newTestServer(t, testServerOptions{Traffic: meter})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/traffic", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.TrafficResponse{
+			PeriodStart:  time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+			MonthlyCap:   1000,
+			Total:        api.TrafficStatsResponse{BytesIn: 900, BytesOut: 20},
+			Throttled:    true,
+			NonEssential: traffic.DefaultNonEssential,
+			Protocols: map[string]api.TrafficStatsResponse{
+				"retrieval": {BytesIn: 100, BytesOut: 20},
+				"pullsync":  {BytesIn: 800},
+			},
+			Peers: map[string]api.TrafficStatsResponse{
+				peer.String(): {BytesIn: 900, BytesOut: 20},
+			},
+		}),
+	)
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, _, _, _ := newTestServer(t, testServerOptions{})
+		jsonhttptest.Request(t, client, http.MethodGet, "/traffic", http.StatusNotFound)
+	})
+}
@@ -92,8 +92,9 @@ func TestTransactionStoredTransaction(t *testing.T) {
 
 		jsonhttptest.Request(t, testServer, http.MethodGet, "/transactions/"+txHashStr, http.StatusNotFound,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: api.ErrUnknownTransaction,
-				Code:    http.StatusNotFound,
+				Message:   api.ErrUnknownTransaction,
+				Code:      http.StatusNotFound,
+				ErrorCode: "not_found",
 			}))
 	})
 
@@ -110,8 +111,9 @@ func TestTransactionStoredTransaction(t *testing.T) {
 
 		jsonhttptest.Request(t, testServer, http.MethodGet, "/transactions/"+txHashStr, http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: api.ErrCantGetTransaction,
-				Code:    http.StatusInternalServerError,
+				Message:   api.ErrCantGetTransaction,
+				Code:      http.StatusInternalServerError,
+				ErrorCode: "internal_server_error",
 			}))
 	})
 }
@@ -218,8 +220,9 @@ func TestTransactionListError(t *testing.T) {
 
 		jsonhttptest.Request(t, testServer, http.MethodGet, "/transactions", http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusInternalServerError,
-				Message: api.ErrCantGetTransaction,
+				Code:      http.StatusInternalServerError,
+				Message:   api.ErrCantGetTransaction,
+				ErrorCode: "internal_server_error",
 			}),
 		)
 	})
@@ -240,8 +243,9 @@ func TestTransactionListError(t *testing.T) {
 
 		jsonhttptest.Request(t, testServer, http.MethodGet, "/transactions", http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusInternalServerError,
-				Message: api.ErrCantGetTransaction,
+				Code:      http.StatusInternalServerError,
+				Message:   api.ErrCantGetTransaction,
+				ErrorCode: "internal_server_error",
 			}),
 		)
 	})
@@ -282,8 +286,9 @@ func TestTransactionResend(t *testing.T) {
 
 		jsonhttptest.Request(t, testServer, http.MethodPost, "/transactions/"+txHash.String(), http.StatusNotFound,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusNotFound,
-				Message: api.ErrUnknownTransaction,
+				Code:      http.StatusNotFound,
+				Message:   api.ErrUnknownTransaction,
+				ErrorCode: "not_found",
 			}),
 		)
 	})
@@ -301,8 +306,9 @@ func TestTransactionResend(t *testing.T) {
 
 		jsonhttptest.Request(t, testServer, http.MethodPost, "/transactions/"+txHash.String(), http.StatusBadRequest,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusBadRequest,
-				Message: api.ErrAlreadyImported,
+				Code:      http.StatusBadRequest,
+				Message:   api.ErrAlreadyImported,
+				ErrorCode: "bad_request",
 			}),
 		)
 	})
@@ -320,8 +326,9 @@ func TestTransactionResend(t *testing.T) {
 
 		jsonhttptest.Request(t, testServer, http.MethodPost, "/transactions/"+txHash.String(), http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Code:    http.StatusInternalServerError,
-				Message: api.ErrCantResendTransaction,
+				Code:      http.StatusInternalServerError,
+				Message:   api.ErrCantResendTransaction,
+				ErrorCode: "internal_server_error",
 			}),
 		)
 	})
@@ -62,8 +62,9 @@ func TestWallet(t *testing.T) {
 
 		jsonhttptest.Request(t, srv, http.MethodGet, "/wallet", http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "unable to acquire erc20 balance",
-				Code:    500,
+				Message:   "unable to acquire erc20 balance",
+				Code:      500,
+				ErrorCode: "internal_server_error",
 			}))
 	})
 
@@ -80,8 +81,9 @@ func TestWallet(t *testing.T) {
 
 		jsonhttptest.Request(t, srv, http.MethodGet, "/wallet", http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "unable to acquire balance from the chain backend",
-				Code:    500,
+				Message:   "unable to acquire balance from the chain backend",
+				Code:      500,
+				ErrorCode: "internal_server_error",
 			}))
 	})
 }
@@ -96,8 +98,9 @@ func TestWalletWithdraw(t *testing.T) {
 
 		jsonhttptest.Request(t, srv, http.MethodPost, "/wallet/withdraw/BZZ?address=0xaf&amount=99999999", http.StatusBadRequest,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "provided address not whitelisted",
-				Code:    400,
+				Message:   "provided address not whitelisted",
+				Code:      400,
+				ErrorCode: "bad_request",
 			}))
 	})
 
@@ -108,8 +111,9 @@ func TestWalletWithdraw(t *testing.T) {
 
 		jsonhttptest.Request(t, srv, http.MethodPost, "/wallet/withdraw/BTC?address=0xaf&amount=99999999", http.StatusBadRequest,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "only BZZ or NativeToken options are accepted",
-				Code:    400,
+				Message:   "only BZZ or NativeToken options are accepted",
+				Code:      400,
+				ErrorCode: "bad_request",
 			}))
 	})
 
@@ -122,8 +126,9 @@ func TestWalletWithdraw(t *testing.T) {
 
 		jsonhttptest.Request(t, srv, http.MethodPost, "/wallet/withdraw/BZZ?address=0xaf&amount=99999999", http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "unable to get balance",
-				Code:    500,
+				Message:   "unable to get balance",
+				Code:      500,
+				ErrorCode: "internal_server_error",
 			}))
 	})
 
@@ -141,8 +146,9 @@ func TestWalletWithdraw(t *testing.T) {
 
 		jsonhttptest.Request(t, srv, http.MethodPost, "/wallet/withdraw/BZZ?address=0xaf&amount=99999999", http.StatusBadRequest,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "not enough balance",
-				Code:    400,
+				Message:   "not enough balance",
+				Code:      400,
+				ErrorCode: "bad_request",
 			}))
 	})
 
@@ -160,8 +166,9 @@ func TestWalletWithdraw(t *testing.T) {
 
 		jsonhttptest.Request(t, srv, http.MethodPost, "/wallet/withdraw/BZZ?address=0xaf&amount=99999999", http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "unable to transfer amount",
-				Code:    500,
+				Message:   "unable to transfer amount",
+				Code:      500,
+				ErrorCode: "internal_server_error",
 			}))
 	})
 
@@ -203,8 +210,9 @@ func TestWalletWithdraw(t *testing.T) {
 
 		jsonhttptest.Request(t, srv, http.MethodPost, "/wallet/withdraw/NativeToken?address=0xaf&amount=99999999", http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "unable to acquire balance from the chain backend",
-				Code:    500,
+				Message:   "unable to acquire balance from the chain backend",
+				Code:      500,
+				ErrorCode: "internal_server_error",
 			}))
 	})
 
@@ -222,8 +230,9 @@ func TestWalletWithdraw(t *testing.T) {
 
 		jsonhttptest.Request(t, srv, http.MethodPost, "/wallet/withdraw/NativeToken?address=0xaf&amount=99999999", http.StatusBadRequest,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "not enough balance",
-				Code:    400,
+				Message:   "not enough balance",
+				Code:      400,
+				ErrorCode: "bad_request",
 			}))
 	})
 
@@ -241,8 +250,9 @@ func TestWalletWithdraw(t *testing.T) {
 
 		jsonhttptest.Request(t, srv, http.MethodPost, "/wallet/withdraw/NativeToken?address=0xaf&amount=99999999", http.StatusInternalServerError,
 			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
-				Message: "unable to transfer",
-				Code:    500,
+				Message:   "unable to transfer",
+				Code:      500,
+				ErrorCode: "internal_server_error",
 			}))
 	})
 
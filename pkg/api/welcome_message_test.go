@@ -85,8 +85,9 @@ func TestSetWelcomeMessage(t *testing.T) {
 			})
 			body := bytes.NewReader(data)
 			wantResponse := jsonhttp.StatusResponse{
-				Message: tC.wantMessage,
-				Code:    tC.wantStatus,
+				Message:   tC.wantMessage,
+				Code:      tC.wantStatus,
+				ErrorCode: jsonhttp.DefaultErrorCode(tC.wantStatus),
 			}
 			jsonhttptest.Request(t, srv, http.MethodPost, testURL, tC.wantStatus,
 				jsonhttptest.WithRequestBody(body),
@@ -125,8 +126,9 @@ func TestSetWelcomeMessageInternalServerError(t *testing.T) {
 
 		wantCode := http.StatusInternalServerError
 		wantResp := jsonhttp.StatusResponse{
-			Message: testError.Error(),
-			Code:    wantCode,
+			Message:   testError.Error(),
+			Code:      wantCode,
+			ErrorCode: jsonhttp.DefaultErrorCode(wantCode),
 		}
 		jsonhttptest.Request(t, srv, http.MethodPost, testURL, wantCode,
 			jsonhttptest.WithRequestBody(body),
@@ -0,0 +1,192 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package availability checks whether all the chunks of the content are
+// held on the network without downloading them.
+//
+// The content is traversed with only its intermediate chunks retrieved and
+// the closest peers of every chunk are asked with the existence protocol
+// whether they hold it, proving the possession of the chunks they claim to
+// hold. This is much cheaper than the full retrieval of every chunk.
+package availability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethersphere/bee/v2/pkg/existence"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	"github.com/ethersphere/bee/v2/pkg/traversal"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxConcurrentQueries is the number of the peers queried concurrently.
+const maxConcurrentQueries = 16
+
+// ErrNotFound is returned when the root chunk of the content cannot be retrieved.
+var ErrNotFound = errors.New("content not found")
+
+// Haser asks the peer whether it holds the chunks, with the proofs
+// of the possession of the chunks it claims to hold.
+type Haser interface {
+	HasWithProof(ctx context.Context, peer swarm.Address, addrs []swarm.Address) ([]bool, error)
+}
+
+// Bin is the availability of the chunks in the proximity order bin
+// of the node.
+type Bin struct {
+	Bin         uint8
+	Total       int
+	Available   int
+	Missing     int
+	Unreachable int
+}
+
+// Report is the availability of the chunks of the content.
+type Report struct {
+	Total     int
+	Available int
+	Missing   int
+	// Unreachable is the number of the chunks with no peer to ask or
+	// whose peer failed to respond.
+	Unreachable int
+	// Complete is false if some intermediate chunks could not be
+	// retrieved, so that not all the chunks of the content are counted.
+	Complete bool
+	// Bins are the counts per proximity order bin in ascending order,
+	// only the bins with chunks are included.
+	Bins []Bin
+}
+
+// Resolvable reports whether all the chunks of the content are held.
+func (r Report) Resolvable() bool {
+	return r.Complete && r.Available == r.Total
+}
+
+// Service checks the availability of the content.
+type Service struct {
+	base      swarm.Address
+	traverser traversal.Traverser
+	topology  topology.ClosestPeerer
+	haser     Haser
+}
+
+// New returns the service traversing the content with the traverser and
+// asking the closest peers in the topology with the haser.
+func New(base swarm.Address, traverser traversal.Traverser, topology topology.ClosestPeerer, haser Haser) *Service {
+	return &Service{
+		base:      base,
+		traverser: traverser,
+		topology:  topology,
+		haser:     haser,
+	}
+}
+
+// Check returns the availability report of the content with the root address.
+func (s *Service) Check(ctx context.Context, root swarm.Address) (*Report, error) {
+	var (
+		addrs []swarm.Address
+		seen  = make(map[string]struct{})
+	)
+	complete := true
+	err := s.traverser.Traverse(ctx, root, func(addr swarm.Address) error {
+		// the encrypted references are reported with their keys
+		addr = swarm.NewAddress(addr.Bytes()[:swarm.HashSize])
+		if _, ok := seen[addr.ByteString()]; !ok {
+			seen[addr.ByteString()] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+		return nil
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, storage.ErrNotFound) || errors.Is(err, topology.ErrNotFound):
+		if len(addrs) == 0 {
+			return nil, ErrNotFound
+		}
+		complete = false
+	default:
+		return nil, fmt.Errorf("traverse %s: %w", root, err)
+	}
+
+	// the chunks are grouped by the closest peer to be asked in batches,
+	// the chunks with no peer to ask are unreachable
+	byPeer := make(map[string][]swarm.Address)
+	for _, addr := range addrs {
+		peer, err := s.topology.ClosestPeer(addr, false, topology.Select{})
+		if err != nil {
+			continue
+		}
+		byPeer[peer.ByteString()] = append(byPeer[peer.ByteString()], addr)
+	}
+
+	var (
+		mu      sync.Mutex
+		results = make(map[string]bool, len(addrs))
+	)
+	eg, ectx := errgroup.WithContext(ctx)
+	eg.SetLimit(maxConcurrentQueries)
+	for peer, peerAddrs := range byPeer {
+		for i := 0; i < len(peerAddrs); i += existence.MaxAddresses {
+			batch := peerAddrs[i:min(i+existence.MaxAddresses, len(peerAddrs))]
+			eg.Go(func() error {
+				has, err := s.haser.HasWithProof(ectx, swarm.NewAddress([]byte(peer)), batch)
+				if err != nil {
+					if ectx.Err() != nil {
+						return ectx.Err()
+					}
+					// the chunks of the failed peer are unreachable
+					return nil
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				for j, addr := range batch {
+					results[addr.ByteString()] = has[j]
+				}
+				return nil
+			})
+		}
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	report := &Report{Complete: complete}
+	bins := make(map[uint8]*Bin)
+	for _, addr := range addrs {
+		po := swarm.Proximity(s.base.Bytes(), addr.Bytes())
+		b, ok := bins[po]
+		if !ok {
+			b = &Bin{Bin: po}
+			bins[po] = b
+		}
+		b.Total++
+		report.Total++
+
+		has, ok := results[addr.ByteString()]
+		switch {
+		case !ok:
+			b.Unreachable++
+			report.Unreachable++
+		case has:
+			b.Available++
+			report.Available++
+		default:
+			b.Missing++
+			report.Missing++
+		}
+	}
+	for _, b := range bins {
+		report.Bins = append(report.Bins, *b)
+	}
+	sort.Slice(report.Bins, func(i, j int) bool {
+		return report.Bins[i].Bin < report.Bins[j].Bin
+	})
+	return report, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package availability_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/availability"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	topologymock "github.com/ethersphere/bee/v2/pkg/topology/mock"
+	"github.com/ethersphere/bee/v2/pkg/traversal"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+type mockHaser struct {
+	missing map[string]bool
+	err     error
+}
+
+func (m *mockHaser) HasWithProof(_ context.Context, _ swarm.Address, addrs []swarm.Address) ([]bool, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	has := make([]bool, len(addrs))
+	for i, addr := range addrs {
+		has[i] = !m.missing[addr.ByteString()]
+	}
+	return has, nil
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := inmemchunkstore.New()
+	data := testutil.RandBytes(t, 5*swarm.ChunkSize)
+	root, err := builder.FeedPipeline(ctx, builder.NewPipelineBuilder(ctx, store, false, 0), bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	traverser := traversal.New(store, store, redundancy.NONE)
+
+	var chunks []swarm.Address
+	if err := traverser.Traverse(ctx, root, func(addr swarm.Address) error {
+		chunks = append(chunks, addr)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 6 {
+		t.Fatalf("got %d chunks, want 6", len(chunks))
+	}
+
+	base := swarm.RandAddress(t)
+	topology := topologymock.NewTopologyDriver(topologymock.WithPeers(swarm.RandAddress(t)))
+
+	t.Run("available", func(t *testing.T) {
+		t.Parallel()
+
+		s := availability.New(base, traverser, topology, &mockHaser{})
+		report, err := s.Check(ctx, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !report.Resolvable() || report.Total != 6 || report.Available != 6 {
+			t.Fatalf("unexpected report %+v", report)
+		}
+		total := 0
+		for i, b := range report.Bins {
+			if i > 0 && b.Bin <= report.Bins[i-1].Bin {
+				t.Fatalf("bins not in ascending order: %+v", report.Bins)
+			}
+			total += b.Total
+		}
+		if total != report.Total {
+			t.Fatalf("got %d chunks in bins, want %d", total, report.Total)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		s := availability.New(base, traverser, topology, &mockHaser{missing: map[string]bool{chunks[3].ByteString(): true}})
+		report, err := s.Check(ctx, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Resolvable() || report.Available != 5 || report.Missing != 1 {
+			t.Fatalf("unexpected report %+v", report)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		t.Parallel()
+
+		s := availability.New(base, traverser, topology, &mockHaser{err: errors.New("stream reset")})
+		report, err := s.Check(ctx, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Resolvable() || report.Unreachable != 6 {
+			t.Fatalf("unexpected report %+v", report)
+		}
+
+		s = availability.New(base, traverser, topologymock.NewTopologyDriver(), &mockHaser{})
+		report, err = s.Check(ctx, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Unreachable != 6 {
+			t.Fatalf("unexpected report %+v", report)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		s := availability.New(base, traverser, topology, &mockHaser{})
+		if _, err := s.Check(ctx, swarm.RandAddress(t)); !errors.Is(err, availability.ErrNotFound) {
+			t.Fatalf("got error %v, want %v", err, availability.ErrNotFound)
+		}
+	})
+}
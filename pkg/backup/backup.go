@@ -0,0 +1,334 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package backup provides online backups of the node state and the
+// restoration of the node data directory from such backups.
+//
+// A backup is a tar archive with the following entries:
+//
+//	backup.json           metadata describing the backup
+//	statestore/NNNNNNNN   segments of the statestore key-value pairs
+//	stamperstore/NNNNNNNN segments of the stamp issuers key-value pairs
+//	keys/<name>           keystore files, optionally encrypted
+//	reserve/NNNNNNNN      segments of the reserve manifest
+//
+// The statestore and the stamperstore are read from point-in-time snapshots,
+// so the backup is consistent even if it is taken while the node is running.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "backup"
+
+// Version is the version of the backup archive format.
+const Version = 1
+
+const (
+	metadataEntry     = "backup.json"
+	stateStoreEntry   = "statestore"
+	stamperStoreEntry = "stamperstore"
+	keysEntry         = "keys"
+	reserveEntry      = "reserve"
+
+	// keyFileExt is the extension of the files in the keystore directory.
+	keyFileExt = ".key"
+
+	// segmentSize is the approximate maximal size of a single archive
+	// segment. Segments are buffered in memory before they are written,
+	// as the size of a tar entry must be known in advance.
+	segmentSize = 4 * 1024 * 1024
+)
+
+// ErrInProgress is returned when a backup is requested while another one is being taken.
+var ErrInProgress = errors.New("backup in progress")
+
+// Metadata describes the content of a backup.
+type Metadata struct {
+	Version       int       `json:"version"`
+	CreatedAt     time.Time `json:"createdAt"`
+	KeysIncluded  bool      `json:"keysIncluded"`
+	KeysEncrypted bool      `json:"keysEncrypted"`
+	ReserveSize   int       `json:"reserveSize"`
+}
+
+// ReserveItem is a single entry of the reserve manifest.
+type ReserveItem struct {
+	Bin       uint8  `json:"bin"`
+	BinID     uint64 `json:"binID"`
+	Address   string `json:"address"`
+	BatchID   string `json:"batchID"`
+	StampHash string `json:"stampHash"`
+}
+
+// Reserve provides the items of the chunks stored in the reserve.
+type Reserve interface {
+	ReserveIterateChunkItems(cb func(storer.ReserveChunkItem) (bool, error)) error
+}
+
+// Options configure a single backup.
+type Options struct {
+	// IncludeKeys includes the keystore files in the backup.
+	IncludeKeys bool
+	// Password, if not empty, is used to encrypt the keystore files.
+	Password string
+}
+
+// Service takes backups of the node state.
+type Service struct {
+	logger       log.Logger
+	stateStore   leveldbstore.Storer
+	stamperStore leveldbstore.Storer
+	keysDir      string
+	reserve      Reserve
+
+	mu sync.Mutex // only a single backup is taken at a time
+}
+
+// New returns a new backup service. The keysDir may be empty if keys are
+// not persisted and the reserve may be nil if the node has no reserve.
+func New(
+	logger log.Logger,
+	stateStore, stamperStore leveldbstore.Storer,
+	keysDir string,
+	reserve Reserve,
+) *Service {
+	return &Service{
+		logger:       logger.WithName(loggerName).Register(),
+		stateStore:   stateStore,
+		stamperStore: stamperStore,
+		keysDir:      keysDir,
+		reserve:      reserve,
+	}
+}
+
+// Backup writes a consistent snapshot of the node state to w as a tar archive.
+func (s *Service) Backup(ctx context.Context, w io.Writer, o Options) (err error) {
+	if !s.mu.TryLock() {
+		return ErrInProgress
+	}
+	defer s.mu.Unlock()
+
+	start := time.Now()
+
+	stateSnap, err := s.stateStore.DB().GetSnapshot()
+	if err != nil {
+		return fmt.Errorf("statestore snapshot: %w", err)
+	}
+	defer stateSnap.Release()
+
+	stamperSnap, err := s.stamperStore.DB().GetSnapshot()
+	if err != nil {
+		return fmt.Errorf("stamperstore snapshot: %w", err)
+	}
+	defer stamperSnap.Release()
+
+	var reserveItems []ReserveItem
+	if s.reserve != nil {
+		err := s.reserve.ReserveIterateChunkItems(func(item storer.ReserveChunkItem) (bool, error) {
+			reserveItems = append(reserveItems, ReserveItem{
+				Bin:       item.Bin,
+				BinID:     item.BinID,
+				Address:   item.Address.String(),
+				BatchID:   hex.EncodeToString(item.BatchID),
+				StampHash: hex.EncodeToString(item.StampHash),
+			})
+			return ctx.Err() != nil, nil
+		})
+		if err != nil {
+			return fmt.Errorf("reserve manifest: %w", err)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	tw := tar.NewWriter(w)
+	defer func() {
+		err = errors.Join(err, tw.Close())
+	}()
+
+	md := Metadata{
+		Version:       Version,
+		CreatedAt:     start.UTC(),
+		KeysIncluded:  o.IncludeKeys && s.keysDir != "",
+		KeysEncrypted: o.IncludeKeys && s.keysDir != "" && o.Password != "",
+		ReserveSize:   len(reserveItems),
+	}
+	mdb, err := json.Marshal(md)
+	if err != nil {
+		return err
+	}
+	if err := writeEntry(tw, metadataEntry, mdb, start); err != nil {
+		return err
+	}
+
+	if err := writeSnapshot(ctx, tw, stateStoreEntry, stateSnap, start); err != nil {
+		return fmt.Errorf("statestore: %w", err)
+	}
+	if err := writeSnapshot(ctx, tw, stamperStoreEntry, stamperSnap, start); err != nil {
+		return fmt.Errorf("stamperstore: %w", err)
+	}
+
+	if md.KeysIncluded {
+		if err := s.writeKeys(tw, o.Password, start); err != nil {
+			return fmt.Errorf("keystore: %w", err)
+		}
+	}
+
+	sw := newSegmentWriter(tw, reserveEntry, start)
+	for _, item := range reserveItems {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if err := sw.write(append(b, '\n')); err != nil {
+			return fmt.Errorf("reserve manifest: %w", err)
+		}
+	}
+	if err := sw.flush(); err != nil {
+		return fmt.Errorf("reserve manifest: %w", err)
+	}
+
+	s.logger.Info("backup taken", "keys", md.KeysIncluded, "reserve_size", md.ReserveSize, "elapsed", time.Since(start))
+
+	return nil
+}
+
+// writeKeys writes all the keystore files to the archive. The files are
+// encrypted with the password, if it is not empty.
+func (s *Service) writeKeys(tw *tar.Writer, password string, modTime time.Time) error {
+	entries, err := os.ReadDir(s.keysDir)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), keyFileExt) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.keysDir, e.Name()))
+		if err != nil {
+			return err
+		}
+		if password != "" {
+			if data, err = seal(data, password); err != nil {
+				return err
+			}
+		}
+		if err := writeEntry(tw, path.Join(keysEntry, e.Name()), data, modTime); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeSnapshot writes all the key-value pairs from the snapshot
+// to the archive as segments of the given entry.
+func writeSnapshot(ctx context.Context, tw *tar.Writer, name string, snap *leveldb.Snapshot, modTime time.Time) error {
+	iter := snap.NewIterator(nil, nil)
+	defer iter.Release()
+
+	sw := newSegmentWriter(tw, name, modTime)
+	for iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := sw.write(encodeRecord(iter.Key(), iter.Value())); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	return sw.flush()
+}
+
+// writeEntry writes a single regular file entry to the archive.
+func writeEntry(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0600,
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+// segmentWriter splits the written data into numbered archive entries.
+type segmentWriter struct {
+	tw      *tar.Writer
+	name    string
+	modTime time.Time
+	buf     bytes.Buffer
+	n       int
+}
+
+func newSegmentWriter(tw *tar.Writer, name string, modTime time.Time) *segmentWriter {
+	return &segmentWriter{tw: tw, name: name, modTime: modTime}
+}
+
+// write buffers the data which is never split between two segments.
+func (sw *segmentWriter) write(data []byte) error {
+	if sw.buf.Len() > 0 && sw.buf.Len()+len(data) > segmentSize {
+		if err := sw.flush(); err != nil {
+			return err
+		}
+	}
+	_, _ = sw.buf.Write(data)
+	return nil
+}
+
+// flush writes the buffered data as a new segment.
+func (sw *segmentWriter) flush() error {
+	if sw.buf.Len() == 0 {
+		return nil
+	}
+	name := path.Join(sw.name, fmt.Sprintf("%08d", sw.n))
+	if err := writeEntry(sw.tw, name, sw.buf.Bytes(), sw.modTime); err != nil {
+		return err
+	}
+	sw.n++
+	sw.buf.Reset()
+	return nil
+}
+
+// encodeRecord encodes a key-value pair as
+// length prefixed key followed by length prefixed value.
+func encodeRecord(key, value []byte) []byte {
+	b := make([]byte, 0, 2*binary.MaxVarintLen64+len(key)+len(value))
+	b = binary.AppendUvarint(b, uint64(len(key)))
+	b = append(b, key...)
+	b = binary.AppendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
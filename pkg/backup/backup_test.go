@@ -0,0 +1,141 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/backup"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+type mockReserve []storer.ReserveChunkItem
+
+func (m mockReserve) ReserveIterateChunkItems(cb func(storer.ReserveChunkItem) (bool, error)) error {
+	for _, item := range m {
+		if stop, err := cb(item); stop || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newStore(t *testing.T, path string) *leveldbstore.Store {
+	t.Helper()
+
+	store, err := leveldbstore.New(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestBackupRestore(t *testing.T) {
+	t.Parallel()
+
+	var (
+		srcDir       = t.TempDir()
+		stateStore   = newStore(t, filepath.Join(srcDir, "statestore"))
+		stamperStore = newStore(t, filepath.Join(srcDir, "stamperstore"))
+		keysDir      = filepath.Join(srcDir, "keys")
+		keyData      = []byte(`{"address":"00"}`)
+		entries      = make(map[string]string)
+	)
+	t.Cleanup(func() {
+		_ = stateStore.Close()
+		_ = stamperStore.Close()
+	})
+
+	for i := 0; i < 1000; i++ {
+		k, v := fmt.Sprintf("ss/key-%d", i), fmt.Sprintf("value-%d", i)
+		if err := stateStore.DB().Put([]byte(k), []byte(v), nil); err != nil {
+			t.Fatal(err)
+		}
+		entries[k] = v
+	}
+	if err := stamperStore.DB().Put([]byte("stampIssuer/a"), []byte("issuer"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(keysDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(keysDir, "swarm.key"), keyData, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	reserve := mockReserve{{Bin: 1, BinID: 2, Address: swarm.RandAddress(t), BatchID: []byte{1}, StampHash: []byte{2}}}
+	svc := backup.New(log.Noop, stateStore, stamperStore, keysDir, reserve)
+
+	var buf bytes.Buffer
+	if err := svc.Backup(context.Background(), &buf, backup.Options{IncludeKeys: true, Password: "secret"}); err != nil {
+		t.Fatalf("Backup(...): unexpected error: %v", err)
+	}
+	archive := buf.Bytes()
+
+	t.Run("restore", func(t *testing.T) {
+		t.Parallel()
+
+		dstDir := t.TempDir()
+		md, err := backup.Restore(bytes.NewReader(archive), dstDir, "secret")
+		if err != nil {
+			t.Fatalf("Restore(...): unexpected error: %v", err)
+		}
+		if !md.KeysIncluded || !md.KeysEncrypted || md.ReserveSize != len(reserve) {
+			t.Fatalf("unexpected metadata: %+v", md)
+		}
+
+		restored := newStore(t, filepath.Join(dstDir, "statestore"))
+		defer restored.Close()
+		for k, v := range entries {
+			have, err := restored.DB().Get([]byte(k), nil)
+			if err != nil {
+				t.Fatalf("Get(%q): unexpected error: %v", k, err)
+			}
+			if string(have) != v {
+				t.Fatalf("Get(%q): want %q, have %q", k, v, have)
+			}
+		}
+
+		have, err := os.ReadFile(filepath.Join(dstDir, "keys", "swarm.key"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(have, keyData) {
+			t.Fatalf("key: want %q, have %q", keyData, have)
+		}
+
+		_, err = backup.Restore(bytes.NewReader(archive), dstDir, "secret")
+		if !errors.Is(err, backup.ErrStateExists) {
+			t.Fatalf("Restore(...): want error %v, have %v", backup.ErrStateExists, err)
+		}
+	})
+
+	t.Run("password required", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := backup.Restore(bytes.NewReader(archive), t.TempDir(), "")
+		if !errors.Is(err, backup.ErrPasswordRequired) {
+			t.Fatalf("Restore(...): want error %v, have %v", backup.ErrPasswordRequired, err)
+		}
+	})
+
+	t.Run("invalid password", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := backup.Restore(bytes.NewReader(archive), t.TempDir(), "wrong")
+		if !errors.Is(err, backup.ErrInvalidPassword) {
+			t.Fatalf("Restore(...): want error %v, have %v", backup.ErrInvalidPassword, err)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	scryptN    = 1 << 15
+	scryptR    = 8
+	scryptP    = 1
+	keyLength  = 32
+	saltLength = 32
+)
+
+// ErrInvalidPassword is returned when the encrypted data
+// can not be decrypted with the provided password.
+var ErrInvalidPassword = errors.New("invalid backup password")
+
+// seal encrypts the data with a key derived from the password.
+// The result is the salt, followed by the nonce and the ciphertext.
+func seal(data []byte, password string) ([]byte, error) {
+	salt := make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("read random data: %w", err)
+	}
+
+	aead, err := newAEAD(password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("read random data: %w", err)
+	}
+
+	out := append(salt, nonce...)
+	return aead.Seal(out, nonce, data, nil), nil
+}
+
+// open decrypts the data encrypted by seal.
+func open(data []byte, password string) ([]byte, error) {
+	if len(data) < saltLength {
+		return nil, ErrInvalidPassword
+	}
+
+	aead, err := newAEAD(password, data[:saltLength])
+	if err != nil {
+		return nil, err
+	}
+
+	data = data[saltLength:]
+	if len(data) < aead.NonceSize() {
+		return nil, ErrInvalidPassword
+	}
+
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrInvalidPassword
+	}
+	return plain, nil
+}
+
+func newAEAD(password string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, keyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
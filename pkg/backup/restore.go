@@ -0,0 +1,188 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"archive/tar"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+var (
+	// ErrStateExists is returned when the data directory
+	// already contains node state which would be overwritten.
+	ErrStateExists = errors.New("data directory already contains node state")
+	// ErrPasswordRequired is returned when the backup contains
+	// encrypted keys and no password is provided.
+	ErrPasswordRequired = errors.New("backup password required")
+
+	errInvalidArchive = errors.New("invalid backup archive")
+)
+
+// Restore restores the node state from the backup archive read from r into
+// the dataDir. The password is used to decrypt the keystore files, if they
+// were encrypted. The chunks of the reserve are not part of the backup and
+// have to be synced from the network again after the restoration.
+func Restore(r io.Reader, dataDir, password string) (md *Metadata, err error) {
+	for _, name := range []string{stateStoreEntry, stamperStoreEntry} {
+		if err := checkNotExists(filepath.Join(dataDir, name)); err != nil {
+			return nil, err
+		}
+	}
+
+	stores := make(map[string]*leveldbstore.Store)
+	defer func() {
+		for _, s := range stores {
+			err = errors.Join(err, s.Close())
+		}
+	}()
+	openStore := func(name string) (*leveldbstore.Store, error) {
+		if s, ok := stores[name]; ok {
+			return s, nil
+		}
+		s, err := leveldbstore.New(filepath.Join(dataDir, name), nil)
+		if err != nil {
+			return nil, err
+		}
+		stores[name] = s
+		return s, nil
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+
+		if md == nil {
+			if hdr.Name != metadataEntry {
+				return nil, fmt.Errorf("%w: missing metadata", errInvalidArchive)
+			}
+			md = new(Metadata)
+			if err := json.NewDecoder(tr).Decode(md); err != nil {
+				return nil, fmt.Errorf("%w: metadata: %w", errInvalidArchive, err)
+			}
+			if md.Version != Version {
+				return nil, fmt.Errorf("%w: unsupported version %d", errInvalidArchive, md.Version)
+			}
+			if md.KeysEncrypted && password == "" {
+				return nil, ErrPasswordRequired
+			}
+			continue
+		}
+
+		switch dir, name := path.Split(hdr.Name); strings.TrimSuffix(dir, "/") {
+		case stateStoreEntry, stamperStoreEntry:
+			store, err := openStore(path.Dir(hdr.Name))
+			if err != nil {
+				return nil, err
+			}
+			if err := restoreSegment(tr, store.DB()); err != nil {
+				return nil, fmt.Errorf("restore %s: %w", hdr.Name, err)
+			}
+		case keysEntry:
+			if err := restoreKey(tr, filepath.Join(dataDir, keysEntry), name, md.KeysEncrypted, password); err != nil {
+				return nil, fmt.Errorf("restore %s: %w", hdr.Name, err)
+			}
+		case reserveEntry:
+			// The reserve manifest is informational only.
+		default:
+			return nil, fmt.Errorf("%w: unexpected entry %q", errInvalidArchive, hdr.Name)
+		}
+	}
+
+	if md == nil {
+		return nil, fmt.Errorf("%w: empty archive", errInvalidArchive)
+	}
+
+	return md, nil
+}
+
+// restoreSegment writes all the key-value records from r to db.
+func restoreSegment(r io.Reader, db *leveldb.DB) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+	for len(data) > 0 {
+		var key, value []byte
+		if key, data, err = decodeField(data); err != nil {
+			return err
+		}
+		if value, data, err = decodeField(data); err != nil {
+			return err
+		}
+		batch.Put(key, value)
+	}
+
+	return db.Write(batch, nil)
+}
+
+// decodeField decodes a single length prefixed field
+// and returns it together with the remaining data.
+func decodeField(data []byte) ([]byte, []byte, error) {
+	l, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < l {
+		return nil, nil, fmt.Errorf("%w: malformed record", errInvalidArchive)
+	}
+	data = data[n:]
+	return data[:l], data[l:], nil
+}
+
+// restoreKey writes a single keystore file into the keysDir.
+func restoreKey(r io.Reader, keysDir, name string, encrypted bool, password string) error {
+	if name == "" || name != filepath.Base(name) || !strings.HasSuffix(name, keyFileExt) {
+		return fmt.Errorf("%w: invalid key file name %q", errInvalidArchive, name)
+	}
+
+	filename := filepath.Join(keysDir, name)
+	if err := checkNotExists(filename); err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if encrypted {
+		if data, err = open(data, password); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(keysDir, 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0600)
+}
+
+// checkNotExists returns ErrStateExists if the given path exists.
+func checkNotExists(p string) error {
+	_, err := os.Stat(p)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w: %s", ErrStateExists, p)
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	default:
+		return err
+	}
+}
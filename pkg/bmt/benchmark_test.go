@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/bmt"
 	"github.com/ethersphere/bee/v2/pkg/bmt/reference"
+	"github.com/ethersphere/bee/v2/pkg/keccak"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/util/testutil"
 	"golang.org/x/sync/errgroup"
@@ -29,6 +30,9 @@ func BenchmarkBMT(b *testing.B) {
 		b.Run(fmt.Sprintf("%v_size_%v", "BMT", size), func(b *testing.B) {
 			benchmarkBMT(b, size)
 		})
+		b.Run(fmt.Sprintf("%v_size_%v", "Sections", size), func(b *testing.B) {
+			benchmarkSections(b, size)
+		})
 	}
 }
 
@@ -100,6 +104,25 @@ func benchmarkBMT(b *testing.B, n int) {
 	}
 }
 
+// benchmarks BMT Hasher hashing the sections in batches
+func benchmarkSections(b *testing.B, n int) {
+	b.Helper()
+
+	testData := testutil.RandBytesWithSeed(b, 4096, seed)
+
+	pool := bmt.NewPool(bmt.NewConf(swarm.NewHasher, testSegmentCount, testPoolSize).WithSectionHasher(keccak.HashSections))
+	h := pool.Get()
+	defer pool.Put(h)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := syncHash(h, testData[:n]); err != nil {
+			b.Fatalf("seed %d: %v", seed, err)
+		}
+	}
+}
+
 // benchmarks 100 concurrent bmt hashes with pool capacity
 func benchmarkPool(b *testing.B, poolsize int) {
 	b.Helper()
@@ -97,6 +97,9 @@ func (h *Hasher) Hash(b []byte) ([]byte, error) {
 	if h.size == 0 {
 		return doHash(h.hasher(), h.span, h.zerohashes[h.depth])
 	}
+	if h.sections != nil {
+		return doHash(h.hasher(), h.span, h.hashSections())
+	}
 	copy(h.bmt.buffer[h.size:], zerosection)
 	// write the last section with final flag set to true
 	go h.processSection(h.pos, true)
@@ -123,6 +126,11 @@ func (h *Hasher) Write(b []byte) (int, error) {
 		l = maxVal
 	}
 	copy(h.bmt.buffer[h.size:], b)
+	if h.sections != nil {
+		// the sections are hashed in batches by Hash
+		h.size += l
+		return l, nil
+	}
 	secsize := 2 * h.segmentSize
 	from := h.size / secsize
 	h.size += l
@@ -144,6 +152,42 @@ func (h *Hasher) Reset() {
 	copy(h.span, zerospan)
 }
 
+// hashSections hashes the written sections and the levels of the tree above
+// them in batches with the section hasher and returns the root of the tree.
+// The children of the nodes are recorded for the proofs.
+func (h *Hasher) hashSections() []byte {
+	t := h.bmt
+	if t.hashes == nil {
+		t.hashes = make([][]byte, len(t.levels)+1)
+		for i := range t.hashes {
+			t.hashes[i] = make([]byte, max(len(t.leaves)>>i, 1)*h.segmentSize)
+		}
+	}
+
+	secsize := 2 * h.segmentSize
+	count := (h.size + secsize - 1) / secsize
+	// pad the last section with zeros
+	copy(t.buffer[h.size:count*secsize], zerosection)
+	h.sections(t.hashes[0][:count*h.segmentSize], t.buffer[:count*secsize])
+
+	for i, nodes := range t.levels {
+		level := t.hashes[i]
+		// the missing right sister is the hash of the all-zero subtree
+		if count%2 == 1 {
+			copy(level[count*h.segmentSize:], h.zerohashes[i+1])
+			count++
+		}
+		for j := 0; j < count; j += 2 {
+			n := nodes[j/2]
+			n.left = level[j*h.segmentSize : (j+1)*h.segmentSize]
+			n.right = level[(j+1)*h.segmentSize : (j+2)*h.segmentSize]
+		}
+		count /= 2
+		h.sections(t.hashes[i+1][:count*h.segmentSize], level[:2*count*h.segmentSize])
+	}
+	return t.hashes[len(t.levels)][:h.segmentSize]
+}
+
 // processSection writes the hash of i-th section into level 1 node of the BMT tree.
 func (h *Hasher) processSection(i int, final bool) {
 	secsize := 2 * h.segmentSize
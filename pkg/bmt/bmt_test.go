@@ -15,6 +15,7 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/bmt"
 	"github.com/ethersphere/bee/v2/pkg/bmt/reference"
+	"github.com/ethersphere/bee/v2/pkg/keccak"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/util/testutil"
 	"golang.org/x/sync/errgroup"
@@ -106,6 +107,27 @@ func TestSyncHasherCorrectness(t *testing.T) {
 	}
 }
 
+// tests the hashes of the trees hashed in batches with the section hasher
+func TestSectionHasherCorrectness(t *testing.T) {
+	t.Parallel()
+	testData := testutil.RandBytesWithSeed(t, 4096, seed)
+
+	for _, count := range testSegmentCounts {
+		t.Run(fmt.Sprintf("segments_%v", count), func(t *testing.T) {
+			t.Parallel()
+			pool := bmt.NewPool(bmt.NewConf(swarm.NewHasher, count, 1).WithSectionHasher(keccak.HashSections))
+			for n := 0; n <= count*hashSize; n++ {
+				h := pool.Get()
+				err := testHasherCorrectness(h, testData, n, count)
+				if err != nil {
+					t.Fatalf("seed %d: %v", seed, err)
+				}
+				pool.Put(h)
+			}
+		})
+	}
+}
+
 // tests that the BMT hasher can be synchronously reused with poolsizes 1 and testPoolSize
 func TestHasherReuse(t *testing.T) {
 	t.Parallel()
@@ -13,23 +13,38 @@ import (
 // implemented by Keccak256 SHA3 sha3.NewLegacyKeccak256
 type BaseHasherFunc func() hash.Hash
 
+// SectionHasherFunc writes the base hashes of the consecutive sections
+// (double segments) of the in to the consecutive segments of the out.
+type SectionHasherFunc func(out, in []byte)
+
 // configuration
 type Conf struct {
-	segmentSize  int            // size of leaf segments, stipulated to be = hash size
-	segmentCount int            // the number of segments on the base level of the BMT
-	capacity     int            // pool capacity, controls concurrency
-	depth        int            // depth of the bmt trees = int(log2(segmentCount))+1
-	maxSize      int            // the total length of the data (count * size)
-	zerohashes   [][]byte       // lookup table for predictable padding subtrees for all levels
-	hasher       BaseHasherFunc // base hasher to use for the BMT levels
+	segmentSize  int               // size of leaf segments, stipulated to be = hash size
+	segmentCount int               // the number of segments on the base level of the BMT
+	capacity     int               // pool capacity, controls concurrency
+	depth        int               // depth of the bmt trees = int(log2(segmentCount))+1
+	maxSize      int               // the total length of the data (count * size)
+	zerohashes   [][]byte          // lookup table for predictable padding subtrees for all levels
+	hasher       BaseHasherFunc    // base hasher to use for the BMT levels
+	sections     SectionHasherFunc // batch hasher of the sections, nil if the sections are hashed concurrently
+}
+
+// WithSectionHasher sets the function hashing the sections of the trees in
+// batches. It must produce the same hashes as the base hasher. The trees are
+// then hashed level by level once all the data is written, instead of every
+// section being hashed concurrently as soon as it is written, which trades
+// the latency of hashing a single chunk for the throughput of hashing many.
+func (c *Conf) WithSectionHasher(f SectionHasherFunc) *Conf {
+	c.sections = f
+	return c
 }
 
-// Pool provides a pool of trees used as resources by the BMT Hasher.
-// A tree popped from the pool is guaranteed to have a clean state ready
+// Pool provides a pool of hashers with their trees used as resources.
+// A hasher popped from the pool is guaranteed to have a clean state ready
 // for hashing a new chunk.
 type Pool struct {
-	c     chan *tree // the channel to obtain a resource from the pool
-	*Conf            // configuration
+	c     chan *Hasher // the channel to obtain a resource from the pool
+	*Conf              // configuration
 }
 
 func NewConf(hasher BaseHasherFunc, segmentCount, capacity int) *Conf {
@@ -62,29 +77,37 @@ func NewConf(hasher BaseHasherFunc, segmentCount, capacity int) *Conf {
 func NewPool(c *Conf) *Pool {
 	p := &Pool{
 		Conf: c,
-		c:    make(chan *tree, c.capacity),
+		c:    make(chan *Hasher, c.capacity),
 	}
 	for i := 0; i < c.capacity; i++ {
-		p.c <- newTree(p.maxSize, p.depth, p.hasher)
+		p.c <- &Hasher{
+			Conf:   p.Conf,
+			result: make(chan []byte),
+			errc:   make(chan error, 1),
+			span:   make([]byte, SpanSize),
+			bmt:    newTree(p.maxSize, p.depth, p.hasher),
+		}
 	}
 	return p
 }
 
-// Get returns a BMT hasher possibly reusing a tree from the pool
+// Get returns a BMT hasher from the pool, the hashers with their trees
+// are reused by all the users of the pool.
 func (p *Pool) Get() *Hasher {
-	t := <-p.c
-	return &Hasher{
-		Conf:   p.Conf,
-		result: make(chan []byte),
-		errc:   make(chan error, 1),
-		span:   make([]byte, SpanSize),
-		bmt:    t,
-	}
+	return <-p.c
 }
 
-// Put is called after using a bmt hasher to return the tree to a pool for reuse
+// Put is called after using a bmt hasher to return it to the pool for reuse
 func (p *Pool) Put(h *Hasher) {
-	p.c <- h.bmt
+	h.pos = 0
+	h.size = 0
+	// the span is referenced by the proofs of the previous user
+	h.span = make([]byte, SpanSize)
+	select {
+	case <-h.errc:
+	default:
+	}
+	p.c <- h
 }
 
 // tree is a reusable control structure representing a BMT
@@ -93,7 +116,9 @@ func (p *Pool) Put(h *Hasher) {
 // Hasher uses a Pool to obtain a tree for each chunk hash
 // the tree is 'locked' while not in the pool.
 type tree struct {
-	leaves []*node // leaf nodes of the tree, other nodes accessible via parent links
+	leaves []*node   // leaf nodes of the tree, other nodes accessible via parent links
+	levels [][]*node // nodes of the levels above the leaves, from the lowest to the root
+	hashes [][]byte  // hashes of the levels when hashed in batches, allocated on the first use
 	buffer []byte
 }
 
@@ -119,6 +144,7 @@ func newNode(index int, parent *node, hasher hash.Hash) *node {
 func newTree(maxsize, depth int, hashfunc func() hash.Hash) *tree {
 	n := newNode(0, nil, hashfunc())
 	prevlevel := []*node{n}
+	var levels [][]*node
 	// iterate over levels and creates 2^(depth-level) nodes
 	// the 0 level is on double segment sections so we start at depth - 2
 	count := 2
@@ -128,12 +154,14 @@ func newTree(maxsize, depth int, hashfunc func() hash.Hash) *tree {
 			parent := prevlevel[i/2]
 			nodes[i] = newNode(i, parent, hashfunc())
 		}
+		levels = append([][]*node{prevlevel}, levels...)
 		prevlevel = nodes
 		count *= 2
 	}
 	// the datanode level is the nodes on the last level
 	return &tree{
 		leaves: prevlevel,
+		levels: levels,
 		buffer: make([]byte, maxsize),
 	}
 }
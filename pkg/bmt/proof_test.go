@@ -10,9 +10,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"reflect"
 	"testing"
 
 	"github.com/ethersphere/bee/v2/pkg/bmt"
+	"github.com/ethersphere/bee/v2/pkg/keccak"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
@@ -180,6 +182,49 @@ func TestProofCorrectness(t *testing.T) {
 	})
 }
 
+// TestProofSectionHasher tests that the trees hashed in batches
+// give the same proofs as the trees hashed concurrently.
+func TestProofSectionHasher(t *testing.T) {
+	t.Parallel()
+
+	buf := make([]byte, 1000)
+	_, err := io.ReadFull(rand.Reader, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prove := func(conf *bmt.Conf) ([]byte, []bmt.Proof) {
+		t.Helper()
+
+		pool := bmt.NewPool(conf)
+		h := pool.Get()
+		defer pool.Put(h)
+		h.SetHeaderInt64(int64(len(buf)))
+		if _, err := h.Write(buf); err != nil {
+			t.Fatal(err)
+		}
+		pr := bmt.Prover{Hasher: h}
+		root, err := pr.Hash(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proofs := make([]bmt.Proof, 128)
+		for i := range proofs {
+			proofs[i] = pr.Proof(i)
+		}
+		return root, proofs
+	}
+
+	wantRoot, wantProofs := prove(bmt.NewConf(swarm.NewHasher, 128, 1))
+	root, proofs := prove(bmt.NewConf(swarm.NewHasher, 128, 1).WithSectionHasher(keccak.HashSections))
+	if !bytes.Equal(root, wantRoot) {
+		t.Fatalf("got root %x, want %x", root, wantRoot)
+	}
+	if !reflect.DeepEqual(proofs, wantProofs) {
+		t.Fatal("proofs do not match")
+	}
+}
+
 func TestProof(t *testing.T) {
 	t.Parallel()
 
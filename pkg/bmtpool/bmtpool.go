@@ -7,17 +7,32 @@
 package bmtpool
 
 import (
+	"runtime"
+
 	"github.com/ethersphere/bee/v2/pkg/bmt"
+	"github.com/ethersphere/bee/v2/pkg/keccak"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
-const Capacity = 32
+// minCapacity is the minimum number of the hashers in the pool.
+const minCapacity = 32
+
+// Capacity is the number of the hashers in the pool, shared by all the
+// pipeline writers. It grows with GOMAXPROCS, so that every processor
+// can hash the chunks of several concurrent uploads.
+var Capacity = max(4*runtime.GOMAXPROCS(0), minCapacity)
 
 var instance *bmt.Pool
 
 // nolint:gochecknoinits
 func init() {
-	instance = bmt.NewPool(bmt.NewConf(swarm.NewHasher, swarm.BmtBranches, Capacity))
+	conf := bmt.NewConf(swarm.NewHasher, swarm.BmtBranches, Capacity)
+	if keccak.Accelerated() {
+		// the sections are hashed four at a time with SIMD, which
+		// outperforms hashing every section in its own goroutine
+		conf = conf.WithSectionHasher(keccak.HashSections)
+	}
+	instance = bmt.NewPool(conf)
 }
 
 // Get a bmt Hasher instance.
@@ -0,0 +1,149 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package chaos injects the faults into the chunk retrievals and the push
+// syncing of the development node, so that the applications may be tested
+// against the failures of the real network.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// MaxPushSyncDelay is the upper bound of the push sync delay.
+const MaxPushSyncDelay = time.Minute
+
+// ErrInvalidConfig is returned when the chaos configuration is out of bounds.
+var ErrInvalidConfig = errors.New("invalid chaos config")
+
+// Config is the configuration of the injected faults.
+// The zero value injects no faults.
+type Config struct {
+	// RetrievalDropRate is the percentage of the chunk
+	// retrievals which fail as if the chunk was not found.
+	RetrievalDropRate float64
+	// PushSyncDelay is the delay of every pushed chunk.
+	PushSyncDelay time.Duration
+}
+
+// Validate checks that the configuration is within the bounds.
+func (c Config) Validate() error {
+	if c.RetrievalDropRate < 0 || c.RetrievalDropRate > 100 {
+		return ErrInvalidConfig
+	}
+	if c.PushSyncDelay < 0 || c.PushSyncDelay > MaxPushSyncDelay {
+		return ErrInvalidConfig
+	}
+	return nil
+}
+
+// Injector decides which operations fail or are delayed as per the config.
+// The decisions are taken from the seeded source so the runs are reproducible.
+type Injector struct {
+	mu     sync.Mutex
+	config Config
+	rand   *rand.Rand
+}
+
+// New returns the injector with no faults configured.
+func New(seed int64) *Injector {
+	return &Injector{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Config returns the current configuration.
+func (i *Injector) Config() Config {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.config
+}
+
+// SetConfig replaces the configuration.
+func (i *Injector) SetConfig(c Config) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.config = c
+	return nil
+}
+
+// drop reports whether the retrieval should fail.
+func (i *Injector) drop() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.config.RetrievalDropRate == 0 {
+		return false
+	}
+	return i.rand.Float64()*100 < i.config.RetrievalDropRate
+}
+
+// delay waits for the configured push sync delay
+// or until the context is done.
+func (i *Injector) delay(ctx context.Context) error {
+	d := i.Config().PushSyncDelay
+	if d == 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Getter returns the getter dropping the share of the retrievals.
+func (i *Injector) Getter(g storage.Getter) storage.Getter {
+	return storage.GetterFunc(func(ctx context.Context, addr swarm.Address) (swarm.Chunk, error) {
+		if i.drop() {
+			return nil, storage.ErrNotFound
+		}
+		return g.Get(ctx, addr)
+	})
+}
+
+// Putter returns the putter delaying every put.
+func (i *Injector) Putter(p storage.Putter) storage.Putter {
+	return storage.PutterFunc(func(ctx context.Context, ch swarm.Chunk) error {
+		if err := i.delay(ctx); err != nil {
+			return err
+		}
+		return p.Put(ctx, ch)
+	})
+}
+
+// PushSyncer returns the push syncer delaying every pushed chunk.
+func (i *Injector) PushSyncer(p pushsync.PushSyncer) pushsync.PushSyncer {
+	return pushSyncerFunc(func(ctx context.Context, ch swarm.Chunk) (*pushsync.Receipt, error) {
+		if err := i.delay(ctx); err != nil {
+			return nil, err
+		}
+		return p.PushChunkToClosest(ctx, ch)
+	})
+}
+
+type pushSyncerFunc func(context.Context, swarm.Chunk) (*pushsync.Receipt, error)
+
+func (f pushSyncerFunc) PushChunkToClosest(ctx context.Context, ch swarm.Chunk) (*pushsync.Receipt, error) {
+	return f(ctx, ch)
+}
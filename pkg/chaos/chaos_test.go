@@ -0,0 +1,164 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chaos_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/chaos"
+	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	mockPushsync "github.com/ethersphere/bee/v2/pkg/pushsync/mock"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
+	chunktest "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		config chaos.Config
+		valid  bool
+	}{
+		{name: "zero", valid: true},
+		{name: "all dropped", config: chaos.Config{RetrievalDropRate: 100}, valid: true},
+		{name: "max delay", config: chaos.Config{PushSyncDelay: chaos.MaxPushSyncDelay}, valid: true},
+		{name: "negative rate", config: chaos.Config{RetrievalDropRate: -1}},
+		{name: "rate over hundred", config: chaos.Config{RetrievalDropRate: 100.5}},
+		{name: "negative delay", config: chaos.Config{PushSyncDelay: -time.Second}},
+		{name: "delay too long", config: chaos.Config{PushSyncDelay: chaos.MaxPushSyncDelay + 1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := chaos.New(0).SetConfig(tc.config)
+			if tc.valid && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tc.valid && !errors.Is(err, chaos.ErrInvalidConfig) {
+				t.Fatalf("want error %v, got %v", chaos.ErrInvalidConfig, err)
+			}
+		})
+	}
+}
+
+func TestGetter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := inmemchunkstore.New()
+	ch := chunktest.GenerateTestRandomChunk()
+	if err := store.Put(ctx, ch); err != nil {
+		t.Fatal(err)
+	}
+
+	drops := func(seed int64, rate float64) []bool {
+		i := chaos.New(seed)
+		if err := i.SetConfig(chaos.Config{RetrievalDropRate: rate}); err != nil {
+			t.Fatal(err)
+		}
+		g := i.Getter(store)
+
+		dropped := make([]bool, 1000)
+		for n := range dropped {
+			_, err := g.Get(ctx, ch.Address())
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				dropped[n] = true
+			case err != nil:
+				t.Fatal(err)
+			}
+		}
+		return dropped
+	}
+
+	count := func(dropped []bool) (c int) {
+		for _, d := range dropped {
+			if d {
+				c++
+			}
+		}
+		return c
+	}
+
+	if c := count(drops(1, 0)); c != 0 {
+		t.Fatalf("got %d drops with no faults", c)
+	}
+	if c := count(drops(1, 100)); c != 1000 {
+		t.Fatalf("got %d drops of all retrievals, want 1000", c)
+	}
+	if c := count(drops(1, 25)); c < 150 || c > 350 {
+		t.Fatalf("got %d drops of 25%% of 1000 retrievals", c)
+	}
+
+	a, b := drops(42, 50), drops(42, 50)
+	for n := range a {
+		if a[n] != b[n] {
+			t.Fatalf("drops differ at %d with the same seed", n)
+		}
+	}
+}
+
+func TestDelay(t *testing.T) {
+	t.Parallel()
+
+	const delay = 50 * time.Millisecond
+
+	i := chaos.New(0)
+	if err := i.SetConfig(chaos.Config{PushSyncDelay: delay}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("putter", func(t *testing.T) {
+		t.Parallel()
+
+		store := inmemchunkstore.New()
+		ch := chunktest.GenerateTestRandomChunk()
+
+		start := time.Now()
+		if err := i.Putter(store).Put(context.Background(), ch); err != nil {
+			t.Fatal(err)
+		}
+		if d := time.Since(start); d < delay {
+			t.Fatalf("put took %s, want at least %s", d, delay)
+		}
+		if _, err := store.Get(context.Background(), ch.Address()); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("push syncer", func(t *testing.T) {
+		t.Parallel()
+
+		ps := i.PushSyncer(mockPushsync.New(func(_ context.Context, ch swarm.Chunk) (*pushsync.Receipt, error) {
+			return &pushsync.Receipt{Address: ch.Address()}, nil
+		}))
+
+		start := time.Now()
+		if _, err := ps.PushChunkToClosest(context.Background(), chunktest.GenerateTestRandomChunk()); err != nil {
+			t.Fatal(err)
+		}
+		if d := time.Since(start); d < delay {
+			t.Fatalf("push took %s, want at least %s", d, delay)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := i.Putter(inmemchunkstore.New()).Put(ctx, chunktest.GenerateTestRandomChunk())
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("want error %v, got %v", context.Canceled, err)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package chunktrace follows the chunks tagged for tracing through the
+// subsystems of the node. Every subsystem touching the tagged chunk records
+// the structured event which is logged and kept, so that the journey of the
+// chunk may be inspected afterwards. The events of the untagged chunks are
+// dropped at the cost of a single atomic load.
+package chunktrace
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "chunktrace"
+
+const (
+	// MaxTagged is the maximum number of the chunks traced at once.
+	MaxTagged = 64
+	// MaxEvents is the number of the kept events per chunk,
+	// the oldest events are dropped first.
+	MaxEvents = 256
+)
+
+// The subsystems recording the events.
+const (
+	SubsystemRetrieval = "retrieval"
+	SubsystemPushSync  = "pushsync"
+	SubsystemReserve   = "reserve"
+)
+
+// ErrTooManyTagged is returned when the maximum number of the chunks is
+// already traced.
+var ErrTooManyTagged = errors.New("too many traced chunks")
+
+// Event is the action of the subsystem on the traced chunk.
+type Event struct {
+	Time      time.Time         `json:"time"`
+	Subsystem string            `json:"subsystem"`
+	Action    string            `json:"action"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+type trace struct {
+	tagged  time.Time
+	events  []Event
+	dropped int
+}
+
+// Tracer collects the events of the tagged chunks.
+// The nil Tracer is valid and it records nothing.
+type Tracer struct {
+	logger log.Logger
+	count  atomic.Int32 // number of the tagged chunks
+
+	mu     sync.Mutex
+	traces map[string]*trace
+}
+
+// New returns the tracer with no chunks tagged.
+func New(logger log.Logger) *Tracer {
+	return &Tracer{
+		logger: logger.WithName(loggerName).Register(),
+		traces: make(map[string]*trace),
+	}
+}
+
+// Tag starts tracing the chunk. Tagging the traced chunk keeps its events.
+func (t *Tracer) Tag(addr swarm.Address) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.traces[addr.ByteString()]; ok {
+		return nil
+	}
+	if len(t.traces) >= MaxTagged {
+		return ErrTooManyTagged
+	}
+	t.traces[addr.ByteString()] = &trace{tagged: time.Now()}
+	t.count.Add(1)
+
+	t.logger.Info("chunk tagged for tracing", "chunk_address", addr)
+	return nil
+}
+
+// Untag stops tracing the chunk and drops its events.
+// It reports whether the chunk was traced.
+func (t *Tracer) Untag(addr swarm.Address) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.traces[addr.ByteString()]; !ok {
+		return false
+	}
+	delete(t.traces, addr.ByteString())
+	t.count.Add(-1)
+	return true
+}
+
+// Trace is the snapshot of the events of the traced chunk.
+type Trace struct {
+	Tagged time.Time
+	Events []Event
+	// Dropped is the number of the oldest events dropped
+	// when the number of the events exceeded the MaxEvents.
+	Dropped int
+}
+
+// Trace returns the events of the chunk in the order they were recorded.
+// It reports false if the chunk is not traced.
+func (t *Tracer) Trace(addr swarm.Address) (Trace, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tr, ok := t.traces[addr.ByteString()]
+	if !ok {
+		return Trace{}, false
+	}
+	return Trace{
+		Tagged:  tr.tagged,
+		Events:  append([]Event(nil), tr.events...),
+		Dropped: tr.dropped,
+	}, true
+}
+
+// Record logs and keeps the event of the subsystem if the chunk is traced.
+// The keysAndValues are the pairs of the fields of the event as accepted by
+// the logger.
+func (t *Tracer) Record(addr swarm.Address, subsystem, action string, keysAndValues ...any) {
+	if t == nil || t.count.Load() == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	tr, ok := t.traces[addr.ByteString()]
+	if !ok {
+		t.mu.Unlock()
+		return
+	}
+
+	e := Event{
+		Time:      time.Now(),
+		Subsystem: subsystem,
+		Action:    action,
+	}
+	if len(keysAndValues) > 0 {
+		e.Fields = make(map[string]string, len(keysAndValues)/2)
+		for i := 0; i+1 < len(keysAndValues); i += 2 {
+			e.Fields[fmt.Sprint(keysAndValues[i])] = fmt.Sprint(keysAndValues[i+1])
+		}
+	}
+	if len(tr.events) >= MaxEvents {
+		tr.events = append(tr.events[:0], tr.events[1:]...)
+		tr.dropped++
+	}
+	tr.events = append(tr.events, e)
+	t.mu.Unlock()
+
+	t.logger.Info("chunk trace", append([]any{"chunk_address", addr, "subsystem", subsystem, "action", action}, keysAndValues...)...)
+}
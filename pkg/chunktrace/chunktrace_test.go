@@ -0,0 +1,107 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chunktrace_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/chunktrace"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestTracer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tracer   = chunktrace.New(log.Noop)
+		tagged   = swarm.RandAddress(t)
+		untagged = swarm.RandAddress(t)
+		peer     = swarm.RandAddress(t)
+	)
+
+	if err := tracer.Tag(tagged); err != nil {
+		t.Fatal(err)
+	}
+
+	tracer.Record(tagged, chunktrace.SubsystemPushSync, "receive", "peer_address", peer)
+	tracer.Record(untagged, chunktrace.SubsystemPushSync, "receive", "peer_address", peer)
+	tracer.Record(tagged, chunktrace.SubsystemReserve, "put")
+
+	if _, ok := tracer.Trace(untagged); ok {
+		t.Fatal("want untagged chunk not traced")
+	}
+
+	tr, ok := tracer.Trace(tagged)
+	if !ok {
+		t.Fatal("want tagged chunk traced")
+	}
+	if len(tr.Events) != 2 {
+		t.Fatalf("got %d events, want 2", len(tr.Events))
+	}
+	if e := tr.Events[0]; e.Subsystem != chunktrace.SubsystemPushSync || e.Action != "receive" || e.Fields["peer_address"] != peer.String() {
+		t.Fatalf("got event %+v", e)
+	}
+	if e := tr.Events[1]; e.Subsystem != chunktrace.SubsystemReserve || e.Action != "put" || e.Fields != nil {
+		t.Fatalf("got event %+v", e)
+	}
+
+	if !tracer.Untag(tagged) {
+		t.Fatal("want tagged chunk untagged")
+	}
+	if tracer.Untag(tagged) {
+		t.Fatal("want untagged chunk not untagged again")
+	}
+	if _, ok := tracer.Trace(tagged); ok {
+		t.Fatal("want untagged chunk not traced")
+	}
+}
+
+func TestMaxEvents(t *testing.T) {
+	t.Parallel()
+
+	tracer := chunktrace.New(log.Noop)
+	addr := swarm.RandAddress(t)
+	if err := tracer.Tag(addr); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < chunktrace.MaxEvents+2; i++ {
+		tracer.Record(addr, chunktrace.SubsystemRetrieval, "request", "attempt", i)
+	}
+
+	tr, _ := tracer.Trace(addr)
+	if len(tr.Events) != chunktrace.MaxEvents {
+		t.Fatalf("got %d events, want %d", len(tr.Events), chunktrace.MaxEvents)
+	}
+	if tr.Dropped != 2 {
+		t.Fatalf("got %d dropped events, want 2", tr.Dropped)
+	}
+	if got := tr.Events[0].Fields["attempt"]; got != "2" {
+		t.Fatalf("got first kept attempt %s, want 2", got)
+	}
+}
+
+func TestMaxTagged(t *testing.T) {
+	t.Parallel()
+
+	tracer := chunktrace.New(log.Noop)
+	for i := 0; i < chunktrace.MaxTagged; i++ {
+		if err := tracer.Tag(swarm.RandAddress(t)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tracer.Tag(swarm.RandAddress(t)); !errors.Is(err, chunktrace.ErrTooManyTagged) {
+		t.Fatalf("want error %v, got %v", chunktrace.ErrTooManyTagged, err)
+	}
+}
+
+func TestNilTracer(t *testing.T) {
+	t.Parallel()
+
+	var tracer *chunktrace.Tracer
+	tracer.Record(swarm.RandAddress(t), chunktrace.SubsystemReserve, "put")
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package client provides the Go client of the Bee HTTP API.
+//
+// The methods mirror the API endpoints. The uploaded and the downloaded
+// data are streamed, nothing is buffered in memory by the client.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+)
+
+// Error is the error response of the API.
+type Error struct {
+	Code    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bee api: %d %s", e.Code, e.Message)
+}
+
+// Client is the client of the Bee HTTP API.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+}
+
+// New returns the client of the API served on the base URL. The default
+// HTTP client is used when the httpClient is nil.
+func New(baseURL string, httpClient *http.Client) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse base url: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base url %q", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: u, httpClient: httpClient}, nil
+}
+
+// request creates the request for the path relative to the base URL.
+func (c *Client) request(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	u := *c.baseURL
+	u.Path += path
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+	return req, nil
+}
+
+// do sends the request and returns the response with the successful status
+// code. The response body must be closed by the caller.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.Path, err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+	return resp, nil
+}
+
+// doJSON sends the request and decodes the response body into v,
+// unless v is nil.
+func (c *Client) doJSON(req *http.Request, v interface{}) error {
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if v == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	return decodeJSON(resp.Body, v)
+}
+
+func decodeJSON(r io.Reader, v interface{}) error {
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func responseError(resp *http.Response) error {
+	e := &Error{Code: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	var r jsonhttp.StatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err == nil && r.Message != "" {
+		e.Message = r.Message
+	}
+	return e
+}
@@ -0,0 +1,213 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/client"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func newClient(t *testing.T, handler http.Handler) *client.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := client.New(srv.URL+"/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestUploadDownload(t *testing.T) {
+	t.Parallel()
+
+	ref := swarm.RandAddress(t)
+	batchID := bytes.Repeat([]byte{1}, 32)
+	data := []byte("hello swarm")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /bytes", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(api.SwarmPostageBatchIdHeader); got != "0101010101010101010101010101010101010101010101010101010101010101" {
+			t.Errorf("got batch id %q", got)
+		}
+		if got := r.Header.Get(api.SwarmPinHeader); got != "true" {
+			t.Errorf("got pin %q", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !bytes.Equal(body, data) {
+			t.Errorf("got body %q", body)
+		}
+		w.Header().Set(api.SwarmTagHeader, "42")
+		jsonhttp.Created(w, struct {
+			Reference swarm.Address `json:"reference"`
+		}{ref})
+	})
+	mux.HandleFunc("GET /bytes/{ref}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("ref") != ref.String() {
+			jsonhttp.NotFound(w, "not found")
+			return
+		}
+		_, _ = w.Write(data)
+	})
+	c := newClient(t, mux)
+
+	res, err := c.UploadBytes(context.Background(), batchID, bytes.NewReader(data), client.UploadOptions{Pin: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Reference.Equal(ref) || res.Tag != 42 {
+		t.Fatalf("got result %+v", res)
+	}
+
+	rc, err := c.Download(context.Background(), ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("got data %q, want %q", got, data)
+	}
+
+	_, err = c.Download(context.Background(), swarm.RandAddress(t))
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound || apiErr.Message != "not found" {
+		t.Fatalf("got error %v", err)
+	}
+}
+
+func TestUploadDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.html":     "<html></html>",
+		"img/logo.svg":   "<svg></svg>",
+		"img/small.webp": "webp",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ref := swarm.RandAddress(t)
+	c := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(api.SwarmCollectionHeader); got != "true" {
+			t.Errorf("got collection %q", got)
+		}
+		if got := r.Header.Get(api.SwarmIndexDocumentHeader); got != "index.html" {
+			t.Errorf("got index document %q", got)
+		}
+		tr := tar.NewReader(r.Body)
+		got := make(map[string]string)
+		for {
+			h, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			b, _ := io.ReadAll(tr)
+			got[h.Name] = string(b)
+		}
+		if len(got) != len(files) {
+			t.Errorf("got %d files, want %d", len(got), len(files))
+		}
+		for name, content := range files {
+			if got[name] != content {
+				t.Errorf("got file %q content %q, want %q", name, got[name], content)
+			}
+		}
+		jsonhttp.Created(w, struct {
+			Reference swarm.Address `json:"reference"`
+		}{ref})
+	}))
+
+	res, err := c.UploadDir(context.Background(), make([]byte, 32), dir, client.DirOptions{IndexDocument: "index.html"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Reference.Equal(ref) {
+		t.Fatalf("got reference %s, want %s", res.Reference, ref)
+	}
+}
+
+func TestStampsCreate(t *testing.T) {
+	t.Parallel()
+
+	c := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stamps/1000/20" {
+			t.Errorf("got path %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("label"); got != "label" {
+			t.Errorf("got label %q", got)
+		}
+		if got := r.Header.Get("Immutable"); got != "false" {
+			t.Errorf("got immutable %q", got)
+		}
+		jsonhttp.Created(w, map[string]string{"batchID": "abcd", "txHash": "0x00"})
+	}))
+
+	batchID, err := c.StampsCreate(context.Background(), big.NewInt(1000), 20, "label", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(batchID, []byte{0xab, 0xcd}) {
+		t.Fatalf("got batch id %x", batchID)
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	if _, err := client.New("localhost:1633", nil); err == nil {
+		t.Fatal("want error for the url without the scheme")
+	}
+}
+
+func TestTag(t *testing.T) {
+	t.Parallel()
+
+	c := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tags/42" {
+			t.Errorf("got path %q", r.URL.Path)
+		}
+		jsonhttp.OK(w, map[string]uint64{"uid": 42, "split": 10, "synced": 7})
+	}))
+
+	tag, err := c.Tag(context.Background(), 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.UID != 42 || tag.Split != 10 || tag.Synced != 7 {
+		t.Fatalf("got tag %+v", tag)
+	}
+}
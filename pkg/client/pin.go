@@ -0,0 +1,46 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// Pin pins the content of the reference locally.
+func (c *Client) Pin(ctx context.Context, ref swarm.Address) error {
+	req, err := c.request(ctx, http.MethodPost, "/pins/"+ref.String(), nil, nil)
+	if err != nil {
+		return err
+	}
+	return c.doJSON(req, nil)
+}
+
+// Unpin removes the pin of the reference.
+func (c *Client) Unpin(ctx context.Context, ref swarm.Address) error {
+	req, err := c.request(ctx, http.MethodDelete, "/pins/"+ref.String(), nil, nil)
+	if err != nil {
+		return err
+	}
+	return c.doJSON(req, nil)
+}
+
+// Pins returns the pinned references.
+func (c *Client) Pins(ctx context.Context) ([]swarm.Address, error) {
+	req, err := c.request(ctx, http.MethodGet, "/pins", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		References []swarm.Address `json:"references"`
+	}
+	if err := c.doJSON(req, &r); err != nil {
+		return nil, err
+	}
+	return r.References, nil
+}
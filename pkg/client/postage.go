@@ -0,0 +1,138 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ethersphere/bee/v2/pkg/bigint"
+)
+
+// hexBytes is the hex encoded byte slice of the responses.
+type hexBytes []byte
+
+func (b *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// Stamp is the postage batch owned by the node.
+type Stamp struct {
+	BatchID     []byte
+	Utilization uint32
+	Usable      bool
+	Label       string
+	Depth       uint8
+	Amount      *big.Int
+	BucketDepth uint8
+	BlockNumber uint64
+	Immutable   bool
+	Exists      bool
+	// BatchTTL is the time to live of the batch in seconds.
+	BatchTTL int64
+}
+
+type stampResponse struct {
+	BatchID       hexBytes       `json:"batchID"`
+	Utilization   uint32         `json:"utilization"`
+	Usable        bool           `json:"usable"`
+	Label         string         `json:"label"`
+	Depth         uint8          `json:"depth"`
+	Amount        *bigint.BigInt `json:"amount"`
+	BucketDepth   uint8          `json:"bucketDepth"`
+	BlockNumber   uint64         `json:"blockNumber"`
+	ImmutableFlag bool           `json:"immutableFlag"`
+	Exists        bool           `json:"exists"`
+	BatchTTL      int64          `json:"batchTTL"`
+}
+
+func (r stampResponse) stamp() Stamp {
+	s := Stamp{
+		BatchID:     r.BatchID,
+		Utilization: r.Utilization,
+		Usable:      r.Usable,
+		Label:       r.Label,
+		Depth:       r.Depth,
+		BucketDepth: r.BucketDepth,
+		BlockNumber: r.BlockNumber,
+		Immutable:   r.ImmutableFlag,
+		Exists:      r.Exists,
+		BatchTTL:    r.BatchTTL,
+	}
+	if r.Amount != nil {
+		s.Amount = r.Amount.Int
+	}
+	return s
+}
+
+// StampsCreate buys the postage batch of the depth with the amount per chunk
+// and returns its id.
+func (c *Client) StampsCreate(ctx context.Context, amount *big.Int, depth uint8, label string, immutable bool) ([]byte, error) {
+	query := url.Values{}
+	if label != "" {
+		query.Set("label", label)
+	}
+	req, err := c.request(ctx, http.MethodPost, "/stamps/"+amount.String()+"/"+strconv.Itoa(int(depth)), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Immutable", strconv.FormatBool(immutable))
+
+	var r struct {
+		BatchID hexBytes `json:"batchID"`
+	}
+	if err := c.doJSON(req, &r); err != nil {
+		return nil, err
+	}
+	return r.BatchID, nil
+}
+
+// Stamps returns the postage batches owned by the node.
+func (c *Client) Stamps(ctx context.Context) ([]Stamp, error) {
+	req, err := c.request(ctx, http.MethodGet, "/stamps", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Stamps []stampResponse `json:"stamps"`
+	}
+	if err := c.doJSON(req, &r); err != nil {
+		return nil, err
+	}
+	stamps := make([]Stamp, 0, len(r.Stamps))
+	for _, s := range r.Stamps {
+		stamps = append(stamps, s.stamp())
+	}
+	return stamps, nil
+}
+
+// Stamp returns the postage batch owned by the node.
+func (c *Client) Stamp(ctx context.Context, batchID []byte) (Stamp, error) {
+	req, err := c.request(ctx, http.MethodGet, "/stamps/"+hex.EncodeToString(batchID), nil, nil)
+	if err != nil {
+		return Stamp{}, err
+	}
+
+	var r stampResponse
+	if err := c.doJSON(req, &r); err != nil {
+		return Stamp{}, err
+	}
+	return r.stamp(), nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+)
+
+// PSSSend sends the message on the topic to the recipient in the
+// neighbourhoods of the targets. The recipient derived from the topic
+// is used when it is nil.
+func (c *Client) PSSSend(ctx context.Context, batchID []byte, topic string, targets pss.Targets, recipient *ecdsa.PublicKey, msg io.Reader) error {
+	t := make([]string, 0, len(targets))
+	for _, v := range targets {
+		t = append(t, hex.EncodeToString(v))
+	}
+	query := url.Values{}
+	if recipient != nil {
+		query.Set("recipient", hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(recipient)))
+	}
+
+	req, err := c.request(ctx, http.MethodPost, "/pss/send/"+topic+"/"+strings.Join(t, ","), query, msg)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(api.SwarmPostageBatchIdHeader, hex.EncodeToString(batchID))
+	return c.doJSON(req, nil)
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// Tag is the progress of the upload tracked by the tag.
+type Tag struct {
+	UID       uint64        `json:"uid"`
+	Address   swarm.Address `json:"address"`
+	Split     uint64        `json:"split"`
+	Seen      uint64        `json:"seen"`
+	Stored    uint64        `json:"stored"`
+	Sent      uint64        `json:"sent"`
+	Synced    uint64        `json:"synced"`
+	StartedAt time.Time     `json:"startedAt"`
+}
+
+// Tag returns the upload progress of the tag.
+func (c *Client) Tag(ctx context.Context, uid uint64) (Tag, error) {
+	req, err := c.request(ctx, http.MethodGet, "/tags/"+strconv.FormatUint(uid, 10), nil, nil)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	var t Tag
+	if err := c.doJSON(req, &t); err != nil {
+		return Tag{}, err
+	}
+	return t, nil
+}
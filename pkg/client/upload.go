@@ -0,0 +1,205 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const contentTypeTar = "application/x-tar"
+
+// UploadOptions are the options of the uploads.
+type UploadOptions struct {
+	// Pin the uploaded content locally.
+	Pin bool
+	// Encrypt the uploaded content.
+	Encrypt bool
+	// Tag to track the upload progress with, a new one is created when zero.
+	Tag uint64
+	// Deferred uploads return before the content is synced to the network.
+	// The node default is used when nil.
+	Deferred *bool
+}
+
+// DirOptions are the options of the directory uploads.
+type DirOptions struct {
+	UploadOptions
+	// IndexDocument is served for the directory root.
+	IndexDocument string
+	// ErrorDocument is served for the missing paths.
+	ErrorDocument string
+}
+
+// UploadResult is the result of the upload.
+type UploadResult struct {
+	Reference swarm.Address
+	Tag       uint64
+}
+
+// UploadBytes uploads the raw data.
+func (c *Client) UploadBytes(ctx context.Context, batchID []byte, r io.Reader, o UploadOptions) (UploadResult, error) {
+	req, err := c.request(ctx, http.MethodPost, "/bytes", nil, r)
+	if err != nil {
+		return UploadResult{}, err
+	}
+	req.Header.Set(api.ContentTypeHeader, "application/octet-stream")
+	return c.upload(req, batchID, o)
+}
+
+// UploadFile uploads the file under the name with the content type.
+func (c *Client) UploadFile(ctx context.Context, batchID []byte, name, contentType string, r io.Reader, o UploadOptions) (UploadResult, error) {
+	query := url.Values{}
+	if name != "" {
+		query.Set("name", name)
+	}
+	req, err := c.request(ctx, http.MethodPost, "/bzz", query, r)
+	if err != nil {
+		return UploadResult{}, err
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	req.Header.Set(api.ContentTypeHeader, contentType)
+	return c.upload(req, batchID, o)
+}
+
+// UploadDir uploads the directory as the collection. The directory
+// is streamed to the node as the tar archive.
+func (c *Client) UploadDir(ctx context.Context, batchID []byte, dir string, o DirOptions) (UploadResult, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(writeTar(pw, dir))
+	}()
+	defer pr.Close()
+
+	req, err := c.request(ctx, http.MethodPost, "/bzz", nil, pr)
+	if err != nil {
+		return UploadResult{}, err
+	}
+	req.Header.Set(api.ContentTypeHeader, contentTypeTar)
+	req.Header.Set(api.SwarmCollectionHeader, "true")
+	if o.IndexDocument != "" {
+		req.Header.Set(api.SwarmIndexDocumentHeader, o.IndexDocument)
+	}
+	if o.ErrorDocument != "" {
+		req.Header.Set(api.SwarmErrorDocumentHeader, o.ErrorDocument)
+	}
+	return c.upload(req, batchID, o.UploadOptions)
+}
+
+// upload sets the upload headers on the request and sends it.
+func (c *Client) upload(req *http.Request, batchID []byte, o UploadOptions) (UploadResult, error) {
+	req.Header.Set(api.SwarmPostageBatchIdHeader, hex.EncodeToString(batchID))
+	if o.Pin {
+		req.Header.Set(api.SwarmPinHeader, "true")
+	}
+	if o.Encrypt {
+		req.Header.Set(api.SwarmEncryptHeader, "true")
+	}
+	if o.Tag != 0 {
+		req.Header.Set(api.SwarmTagHeader, strconv.FormatUint(o.Tag, 10))
+	}
+	if o.Deferred != nil {
+		req.Header.Set(api.SwarmDeferredUploadHeader, strconv.FormatBool(*o.Deferred))
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return UploadResult{}, err
+	}
+	defer resp.Body.Close()
+
+	var r struct {
+		Reference swarm.Address `json:"reference"`
+	}
+	if err := decodeJSON(resp.Body, &r); err != nil {
+		return UploadResult{}, err
+	}
+
+	res := UploadResult{Reference: r.Reference}
+	if tag := resp.Header.Get(api.SwarmTagHeader); tag != "" {
+		res.Tag, err = strconv.ParseUint(tag, 10, 64)
+		if err != nil {
+			return UploadResult{}, fmt.Errorf("parse tag: %w", err)
+		}
+	}
+	return res, nil
+}
+
+// writeTar writes the files of the directory as the tar archive.
+func writeTar(w io.Writer, dir string) error {
+	tw := tar.NewWriter(w)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name: filepath.ToSlash(name),
+			Mode: 0600,
+			Size: info.Size(),
+		}); err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("archive directory: %w", err)
+	}
+	return tw.Close()
+}
+
+// Download returns the raw data of the reference.
+func (c *Client) Download(ctx context.Context, ref swarm.Address) (io.ReadCloser, error) {
+	return c.download(ctx, "/bytes/"+ref.String())
+}
+
+// DownloadFile returns the file of the reference, or the file under the
+// path of the collection reference.
+func (c *Client) DownloadFile(ctx context.Context, ref swarm.Address, path string) (io.ReadCloser, error) {
+	return c.download(ctx, "/bzz/"+ref.String()+"/"+path)
+}
+
+func (c *Client) download(ctx context.Context, path string) (io.ReadCloser, error) {
+	req, err := c.request(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
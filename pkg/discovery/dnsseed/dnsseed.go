@@ -0,0 +1,56 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dnsseed resolves the seed lists published in the DNS TXT records,
+// which are used in place of the hard-coded bootnode multiaddresses.
+package dnsseed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// seedPrefix marks the TXT records of the domain listing the seed multiaddresses,
+// e.g. "bee=/ip4/10.0.0.1/tcp/1634/p2p/QmP9b7...".
+const seedPrefix = "bee="
+
+// Resolver looks up the DNS TXT records.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Resolve returns the seed multiaddresses listed in the TXT records of the
+// domains. The records without the seed prefix are ignored. The addresses
+// resolved from the rest of the domains are returned together with the
+// errors of the domains failed to resolve.
+func Resolve(ctx context.Context, resolver Resolver, domains []string) ([]ma.Multiaddr, error) {
+	var (
+		addrs []ma.Multiaddr
+		errs  []error
+	)
+	for _, domain := range domains {
+		records, err := resolver.LookupTXT(ctx, domain)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("lookup %s: %w", domain, err))
+			continue
+		}
+		for _, r := range records {
+			v, ok := strings.CutPrefix(r, seedPrefix)
+			if !ok {
+				continue
+			}
+			addr, err := ma.NewMultiaddr(strings.TrimSpace(v))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("seed %q of %s: %w", v, domain, err))
+				continue
+			}
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs, errors.Join(errs...)
+}
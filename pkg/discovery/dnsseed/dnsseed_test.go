@@ -0,0 +1,61 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dnsseed_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/discovery/dnsseed"
+)
+
+type resolverMock map[string][]string
+
+func (r resolverMock) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return records, nil
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	resolver := resolverMock{
+		"seeds.example.com": {
+			"v=spf1 -all",
+			"bee=/ip4/10.0.0.1/tcp/1634/p2p/QmP9b7MxjyMfLcmUGPHRZ5XjD5fGyfD5HKJU3D8eYEF2vr",
+			"bee= /ip4/10.0.0.2/tcp/1634 ",
+		},
+		"invalid.example.com": {"bee=not-a-multiaddr"},
+	}
+
+	addrs, err := dnsseed.Resolve(context.Background(), resolver, []string{"seeds.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"/ip4/10.0.0.1/tcp/1634/p2p/QmP9b7MxjyMfLcmUGPHRZ5XjD5fGyfD5HKJU3D8eYEF2vr",
+		"/ip4/10.0.0.2/tcp/1634",
+	}
+	if len(addrs) != len(want) {
+		t.Fatalf("addresses: want %v, have %v", want, addrs)
+	}
+	for i, a := range addrs {
+		if a.String() != want[i] {
+			t.Fatalf("address %d: want %s, have %s", i, want[i], a)
+		}
+	}
+
+	addrs, err = dnsseed.Resolve(context.Background(), resolver, []string{"missing.example.com", "invalid.example.com", "seeds.example.com"})
+	if err == nil {
+		t.Fatal("want error for the failed domains")
+	}
+	if len(addrs) != len(want) {
+		t.Fatalf("addresses of the resolved domain: want %d, have %d", len(want), len(addrs))
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mdns
+
+import "github.com/miekg/dns"
+
+var Query = query
+
+func (s *Service) Handle(msg *dns.Msg) *dns.Msg { return s.handle(msg) }
@@ -0,0 +1,315 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mdns implements the discovery of the peers on the local network
+// over the multicast DNS. The node answers the queries for the bee service
+// with its signed bzz address on the local network, and queries for the
+// other nodes periodically, letting the private swarms form without
+// the bootnodes.
+package mdns
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/bzz"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/miekg/dns"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "mdns"
+
+const (
+	serviceName     = "_swarm-bee._udp.local."
+	recordTTL       = 120
+	defaultInterval = time.Minute
+	maxPacketSize   = 9000
+)
+
+// multicastAddr is the IPv4 multicast DNS group address.
+var multicastAddr = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+// ErrNoLocalAddress is returned when the node has no local network address to advertise.
+var ErrNoLocalAddress = errors.New("no local network address")
+
+// Options are the mDNS discovery options.
+type Options struct {
+	Overlay   swarm.Address
+	NetworkID uint64
+	Nonce     []byte
+	Signer    crypto.Signer
+	// Addresses returns the underlay addresses of the node; the first
+	// local network one is advertised.
+	Addresses func() ([]ma.Multiaddr, error)
+	// Interval is the period of the queries for the other nodes.
+	Interval time.Duration
+}
+
+// Service advertises and discovers the nodes on the local network.
+type Service struct {
+	opts    Options
+	handler func(bzz.Address)
+	logger  log.Logger
+
+	mu   sync.Mutex
+	self *bzz.Address
+	seen map[string]string // underlays of the discovered overlays
+
+	conn net.PacketConn
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New returns the mDNS discovery service; the handler is called with the
+// verified address of each discovered node.
+func New(o Options, handler func(bzz.Address), logger log.Logger) *Service {
+	if o.Interval <= 0 {
+		o.Interval = defaultInterval
+	}
+	return &Service{
+		opts:    o,
+		handler: handler,
+		logger:  logger.WithName(loggerName).Register(),
+		seen:    make(map[string]string),
+		quit:    make(chan struct{}),
+	}
+}
+
+// Start joins the multicast group and starts answering and sending the queries.
+func (s *Service) Start() error {
+	conn, err := net.ListenMulticastUDP("udp4", nil, multicastAddr)
+	if err != nil {
+		return fmt.Errorf("listen multicast: %w", err)
+	}
+	s.conn = conn
+
+	s.wg.Add(2)
+	go s.read()
+	go s.queryLoop()
+	return nil
+}
+
+func (s *Service) read() {
+	defer s.wg.Done()
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, _, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			select {
+			case <-s.quit:
+				return
+			default:
+			}
+			s.logger.Debug("read failed", "error", err)
+			continue
+		}
+
+		msg := new(dns.Msg)
+		if err := msg.Unpack(buf[:n]); err != nil {
+			continue
+		}
+		if reply := s.handle(msg); reply != nil {
+			s.send(reply)
+		}
+	}
+}
+
+func (s *Service) queryLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.send(query())
+		select {
+		case <-s.quit:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) send(msg *dns.Msg) {
+	b, err := msg.Pack()
+	if err != nil {
+		s.logger.Debug("pack message failed", "error", err)
+		return
+	}
+	if _, err := s.conn.WriteTo(b, multicastAddr); err != nil {
+		s.logger.Debug("send message failed", "error", err)
+	}
+}
+
+// query returns the query for the bee service instances.
+func query() *dns.Msg {
+	msg := new(dns.Msg)
+	msg.SetQuestion(serviceName, dns.TypePTR)
+	msg.RecursionDesired = false
+	return msg
+}
+
+// handle returns the answer to the query for the bee service
+// and calls the handler for the discovered nodes in the responses.
+func (s *Service) handle(msg *dns.Msg) *dns.Msg {
+	if !msg.Response {
+		for _, q := range msg.Question {
+			if q.Name == serviceName && (q.Qtype == dns.TypePTR || q.Qtype == dns.TypeANY) {
+				reply, err := s.answer()
+				if err != nil {
+					s.logger.Debug("answer failed", "error", err)
+					return nil
+				}
+				return reply
+			}
+		}
+		return nil
+	}
+
+	for _, rr := range append(msg.Answer, msg.Extra...) {
+		txt, ok := rr.(*dns.TXT)
+		if !ok || !strings.HasSuffix(txt.Hdr.Name, "."+serviceName) {
+			continue
+		}
+		addr, err := s.parse(txt.Txt)
+		if err != nil {
+			s.logger.Debug("invalid record", "name", txt.Hdr.Name, "error", err)
+			continue
+		}
+		if addr.Overlay.Equal(s.opts.Overlay) || !s.discovered(addr) {
+			continue
+		}
+		s.handler(*addr)
+	}
+	return nil
+}
+
+// discovered reports whether the address of the node is newly discovered.
+func (s *Service) discovered(addr *bzz.Address) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	underlay := addr.Underlay.String()
+	if s.seen[addr.Overlay.ByteString()] == underlay {
+		return false
+	}
+	s.seen[addr.Overlay.ByteString()] = underlay
+	return true
+}
+
+// answer returns the response advertising the node.
+func (s *Service) answer() (*dns.Msg, error) {
+	self, err := s.address()
+	if err != nil {
+		return nil, err
+	}
+
+	instance := self.Overlay.String()[:16] + "." + serviceName
+	msg := new(dns.Msg)
+	msg.Response = true
+	msg.Authoritative = true
+	msg.Answer = []dns.RR{
+		&dns.PTR{
+			Hdr: dns.RR_Header{Name: serviceName, Rrtype: dns.TypePTR, Class: dns.ClassINET, Ttl: recordTTL},
+			Ptr: instance,
+		},
+		&dns.TXT{
+			Hdr: dns.RR_Header{Name: instance, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: recordTTL},
+			Txt: []string{
+				"network=" + strconv.FormatUint(s.opts.NetworkID, 10),
+				"overlay=" + self.Overlay.String(),
+				"underlay=" + self.Underlay.String(),
+				"signature=" + hex.EncodeToString(self.Signature),
+				"nonce=" + hex.EncodeToString(self.Nonce),
+			},
+		},
+	}
+	return msg, nil
+}
+
+// address returns the signed bzz address of the node with the local
+// network underlay, which is signed again only if the underlay changes.
+func (s *Service) address() (*bzz.Address, error) {
+	addrs, err := s.opts.Addresses()
+	if err != nil {
+		return nil, fmt.Errorf("addresses: %w", err)
+	}
+	var underlay ma.Multiaddr
+	for _, a := range addrs {
+		if manet.IsPrivateAddr(a) && !manet.IsIPLoopback(a) {
+			underlay = a
+			break
+		}
+	}
+	if underlay == nil {
+		return nil, ErrNoLocalAddress
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.self != nil && s.self.Underlay.Equal(underlay) {
+		return s.self, nil
+	}
+	self, err := bzz.NewAddress(s.opts.Signer, underlay, s.opts.Overlay, s.opts.NetworkID, s.opts.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("sign address: %w", err)
+	}
+	s.self = self
+	return self, nil
+}
+
+// parse returns the verified bzz address from the TXT record entries.
+func (s *Service) parse(entries []string) (*bzz.Address, error) {
+	fields := make(map[string]string, len(entries))
+	for _, e := range entries {
+		if k, v, ok := strings.Cut(e, "="); ok {
+			fields[k] = v
+		}
+	}
+
+	if fields["network"] != strconv.FormatUint(s.opts.NetworkID, 10) {
+		return nil, fmt.Errorf("network %q mismatch", fields["network"])
+	}
+	overlay, err := swarm.ParseHexAddress(fields["overlay"])
+	if err != nil {
+		return nil, fmt.Errorf("overlay: %w", err)
+	}
+	underlay, err := ma.NewMultiaddr(fields["underlay"])
+	if err != nil {
+		return nil, fmt.Errorf("underlay: %w", err)
+	}
+	signature, err := hex.DecodeString(fields["signature"])
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	nonce, err := hex.DecodeString(fields["nonce"])
+	if err != nil {
+		return nil, fmt.Errorf("nonce: %w", err)
+	}
+	return bzz.ParseAddress(underlay.Bytes(), overlay.Bytes(), signature, nonce, true, s.opts.NetworkID)
+}
+
+// Close stops the service.
+func (s *Service) Close() error {
+	close(s.quit)
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.wg.Wait()
+	return err
+}
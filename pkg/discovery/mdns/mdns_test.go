@@ -0,0 +1,93 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mdns_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/bzz"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/discovery/mdns"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func newService(t *testing.T, networkID uint64, underlay string, handler func(bzz.Address)) (*mdns.Service, bzz.Address) {
+	t.Helper()
+
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := common.HexToHash("0x1").Bytes()
+	overlay, err := crypto.NewOverlayAddress(key.PublicKey, networkID, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrs := []ma.Multiaddr{
+		ma.StringCast("/ip4/127.0.0.1/tcp/1634"),
+		ma.StringCast(underlay),
+	}
+	return mdns.New(mdns.Options{
+		Overlay:   overlay,
+		NetworkID: networkID,
+		Nonce:     nonce,
+		Signer:    crypto.NewDefaultSigner(key),
+		Addresses: func() ([]ma.Multiaddr, error) { return addrs, nil },
+	}, handler, log.Noop), bzz.Address{Overlay: overlay, Underlay: addrs[1]}
+}
+
+func TestDiscovery(t *testing.T) {
+	t.Parallel()
+
+	var discovered []bzz.Address
+	a, _ := newService(t, 1, "/ip4/192.168.1.10/tcp/1634", func(addr bzz.Address) {
+		discovered = append(discovered, addr)
+	})
+	b, bAddr := newService(t, 1, "/ip4/192.168.1.11/tcp/1634", nil)
+	other, _ := newService(t, 2, "/ip4/192.168.1.12/tcp/1634", nil)
+
+	reply := b.Handle(mdns.Query())
+	if reply == nil {
+		t.Fatal("want reply to the query")
+	}
+	if a.Handle(reply) != nil {
+		t.Fatal("want no reply to the response")
+	}
+	if len(discovered) != 1 {
+		t.Fatalf("discovered: want 1, have %d", len(discovered))
+	}
+	if !discovered[0].Overlay.Equal(bAddr.Overlay) || !discovered[0].Underlay.Equal(bAddr.Underlay) {
+		t.Fatalf("discovered: want %s %s, have %s %s", bAddr.Overlay, bAddr.Underlay, discovered[0].Overlay, discovered[0].Underlay)
+	}
+
+	// the already discovered node is not reported again
+	a.Handle(b.Handle(mdns.Query()))
+	if len(discovered) != 1 {
+		t.Fatalf("discovered again: want 1, have %d", len(discovered))
+	}
+
+	// the nodes of other networks are ignored
+	a.Handle(other.Handle(mdns.Query()))
+	if len(discovered) != 1 {
+		t.Fatalf("discovered from other network: want 1, have %d", len(discovered))
+	}
+
+	// the node does not discover itself
+	a.Handle(a.Handle(mdns.Query()))
+	if len(discovered) != 1 {
+		t.Fatalf("discovered self: want 1, have %d", len(discovered))
+	}
+}
+
+func TestNoLocalAddress(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newService(t, 1, "/ip4/1.1.1.1/tcp/1634", nil)
+	if reply := s.Handle(mdns.Query()); reply != nil {
+		t.Fatal("want no reply without a local network address")
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package events implements the bus of the node events which the
+// subsystems publish to and the API streams to the clients.
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/bigint"
+	m "github.com/ethersphere/bee/v2/pkg/metrics"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// bufferSize is the capacity of the channel of a subscription.
+const bufferSize = 128
+
+// Topic is the topic of the event.
+type Topic string
+
+// The topics of the events published by the subsystems.
+const (
+	TopicBatchExpired   Topic = "batchExpired"
+	TopicBatchExpiring  Topic = "batchExpiring"
+	TopicCashout        Topic = "cashout"
+	TopicChequeReceived Topic = "chequeReceived"
+	TopicDepth          Topic = "depth"
+	TopicRound          Topic = "round"
+	TopicUpload         Topic = "upload"
+)
+
+// Topics are all the known topics.
+var Topics = []Topic{
+	TopicBatchExpired,
+	TopicBatchExpiring,
+	TopicCashout,
+	TopicChequeReceived,
+	TopicDepth,
+	TopicRound,
+	TopicUpload,
+}
+
+// IsTopic reports whether the topic is known.
+func IsTopic(t Topic) bool {
+	for _, v := range Topics {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is the event published on the topic.
+type Event struct {
+	Topic Topic     `json:"topic"`
+	Time  time.Time `json:"time"`
+	Data  any       `json:"data"`
+}
+
+// BatchExpired is the data of the TopicBatchExpired events.
+type BatchExpired struct {
+	BatchID []byte `json:"batchID"`
+}
+
+// BatchExpiring is the data of the TopicBatchExpiring events
+// of the batches owned by the node.
+type BatchExpiring struct {
+	BatchID []byte `json:"batchID"`
+	// TTL is the estimated time to live of the batch in seconds.
+	TTL int64 `json:"batchTTL"`
+}
+
+// Cashout is the data of the TopicCashout events.
+type Cashout struct {
+	Peer            swarm.Address `json:"peer"`
+	TransactionHash common.Hash   `json:"transactionHash"`
+}
+
+// ChequeReceived is the data of the TopicChequeReceived events.
+type ChequeReceived struct {
+	Peer   swarm.Address  `json:"peer"`
+	Amount *bigint.BigInt `json:"amount"`
+}
+
+// Depth is the data of the TopicDepth events.
+type Depth struct {
+	Depth uint8 `json:"depth"`
+}
+
+// Round is the data of the TopicRound events with the outcome
+// of the storage incentives round the node participated in.
+type Round struct {
+	Round           uint64       `json:"round"`
+	Winner          bool         `json:"winner"`
+	TransactionHash *common.Hash `json:"transactionHash,omitempty"`
+	Error           string       `json:"error,omitempty"`
+}
+
+// Upload is the data of the TopicUpload events.
+type Upload struct {
+	Reference swarm.Address `json:"reference"`
+	Tag       uint64        `json:"tag,omitempty"`
+}
+
+// Publisher publishes the events.
+type Publisher interface {
+	// Publish sends the event with the data on the topic to the
+	// subscribers without blocking.
+	Publish(topic Topic, data any)
+}
+
+// Subscriber streams the events.
+type Subscriber interface {
+	// Subscribe returns the channel of the events on the topics, all the
+	// topics when none is given. The events are dropped if the channel is
+	// not drained timely. Returned function is safe to be called multiple
+	// times.
+	Subscribe(topics ...Topic) (c <-chan Event, unsubscribe func())
+}
+
+// Interface is the events bus.
+type Interface interface {
+	Publisher
+	Subscriber
+}
+
+var _ Interface = (*Bus)(nil)
+
+// subscription is the channel of the subscriber with its topics,
+// all the topics when nil.
+type subscription struct {
+	c      chan Event
+	topics map[Topic]struct{}
+}
+
+// Bus delivers the published events to the subscribers of their topics.
+type Bus struct {
+	mu      sync.Mutex
+	subs    map[*subscription]struct{}
+	metrics metrics
+}
+
+// New returns the new events bus.
+func New() *Bus {
+	return &Bus{
+		subs:    make(map[*subscription]struct{}),
+		metrics: newMetrics(),
+	}
+}
+
+// Publish implements the Publisher interface.
+func (b *Bus) Publish(topic Topic, data any) {
+	e := Event{Topic: topic, Time: time.Now(), Data: data}
+	b.metrics.PublishedEvents.WithLabelValues(string(topic)).Inc()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.subs {
+		if s.topics != nil {
+			if _, ok := s.topics[topic]; !ok {
+				continue
+			}
+		}
+		select {
+		case s.c <- e:
+		default:
+			b.metrics.DroppedEvents.WithLabelValues(string(topic)).Inc()
+		}
+	}
+}
+
+// Subscribe implements the Subscriber interface.
+func (b *Bus) Subscribe(topics ...Topic) (<-chan Event, func()) {
+	s := &subscription{c: make(chan Event, bufferSize)}
+	if len(topics) > 0 {
+		s.topics = make(map[Topic]struct{}, len(topics))
+		for _, t := range topics {
+			s.topics[t] = struct{}{}
+		}
+	}
+
+	b.mu.Lock()
+	b.subs[s] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return s.c, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subs, s)
+			close(s.c)
+		})
+	}
+}
+
+// Metrics returns the metrics of the bus.
+func (b *Bus) Metrics() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(b.metrics)
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/events"
+)
+
+func TestBus(t *testing.T) {
+	t.Parallel()
+
+	bus := events.New()
+
+	all, unsubscribeAll := bus.Subscribe()
+	defer unsubscribeAll()
+	depth, unsubscribeDepth := bus.Subscribe(events.TopicDepth)
+
+	bus.Publish(events.TopicUpload, events.Upload{Tag: 1})
+	bus.Publish(events.TopicDepth, events.Depth{Depth: 3})
+
+	receive := func(t *testing.T, c <-chan events.Event) events.Event {
+		t.Helper()
+		select {
+		case e := <-c:
+			return e
+		case <-time.After(time.Second):
+			t.Fatal("event not received")
+		}
+		return events.Event{}
+	}
+
+	if e := receive(t, all); e.Topic != events.TopicUpload || e.Data.(events.Upload).Tag != 1 {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	if e := receive(t, all); e.Topic != events.TopicDepth || e.Time.IsZero() {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	if e := receive(t, depth); e.Topic != events.TopicDepth || e.Data != (events.Depth{Depth: 3}) {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	select {
+	case e := <-depth:
+		t.Fatalf("unexpected event %+v", e)
+	default:
+	}
+
+	unsubscribeDepth()
+	unsubscribeDepth()
+	if _, ok := <-depth; ok {
+		t.Fatal("want closed channel")
+	}
+	bus.Publish(events.TopicDepth, events.Depth{Depth: 4})
+	if e := receive(t, all); e.Data != (events.Depth{Depth: 4}) {
+		t.Fatalf("unexpected event %+v", e)
+	}
+}
+
+func TestBusSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	bus := events.New()
+	c, unsubscribe := bus.Subscribe(events.TopicRound)
+	defer unsubscribe()
+
+	// publishing must not block on the subscriber that does not drain the channel
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			bus.Publish(events.TopicRound, events.Round{Round: uint64(i)})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publish blocked")
+	}
+
+	if e := <-c; e.Data != (events.Round{Round: 0}) {
+		t.Fatalf("unexpected event %+v", e)
+	}
+}
+
+func TestIsTopic(t *testing.T) {
+	t.Parallel()
+
+	for _, topic := range events.Topics {
+		if !events.IsTopic(topic) {
+			t.Errorf("want topic %q known", topic)
+		}
+	}
+	if events.IsTopic("unknown") {
+		t.Error("want unknown topic")
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events
+
+import "time"
+
+func SetWebhookBackoff(d time.Duration) func() {
+	prev := webhookBackoff
+	webhookBackoff = d
+	return func() { webhookBackoff = prev }
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events
+
+import (
+	m "github.com/ethersphere/bee/v2/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	PublishedEvents *prometheus.CounterVec
+	DroppedEvents   *prometheus.CounterVec
+}
+
+func newMetrics() metrics {
+	subsystem := "events"
+
+	return metrics{
+		PublishedEvents: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "published_total",
+				Help:      "Number of the events published by topic.",
+			},
+			[]string{"topic"},
+		),
+		DroppedEvents: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "dropped_total",
+				Help:      "Number of the events dropped for the slow subscribers by topic.",
+			},
+			[]string{"topic"},
+		),
+	}
+}
+
+type webhookMetrics struct {
+	Deliveries       prometheus.Counter
+	FailedDeliveries prometheus.Counter
+	Retries          prometheus.Counter
+}
+
+func newWebhookMetrics() webhookMetrics {
+	subsystem := "events_webhook"
+
+	return webhookMetrics{
+		Deliveries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "deliveries_total",
+			Help:      "Number of the events delivered to the webhook.",
+		}),
+		FailedDeliveries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "failed_deliveries_total",
+			Help:      "Number of the events not delivered to the webhook after the retries.",
+		}),
+		Retries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "retries_total",
+			Help:      "Number of the retried webhook deliveries.",
+		}),
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	m "github.com/ethersphere/bee/v2/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "events"
+
+// The headers of the webhook requests.
+const (
+	WebhookTopicHeader     = "Swarm-Event-Topic"
+	WebhookSignatureHeader = "Swarm-Event-Signature"
+)
+
+const (
+	// DefaultWebhookRetries is the default number of the delivery retries.
+	DefaultWebhookRetries = 5
+	// DefaultWebhookTimeout is the default timeout of the single delivery.
+	DefaultWebhookTimeout = 10 * time.Second
+
+	webhookMaxBackoff = 5 * time.Minute // maximum backoff between the retries
+	maxResponseBody   = 4 * 1024        // maximum size of the response body read
+)
+
+// webhookBackoff is the backoff before the first retry, doubled on every next one.
+var webhookBackoff = time.Second
+
+// ErrInvalidWebhook is returned when the webhook options are not valid.
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// WebhookOptions are the options of the webhook.
+type WebhookOptions struct {
+	// URL is the HTTP(S) endpoint the events are posted to.
+	URL string
+	// Topics are the topics of the events delivered, all the topics when empty.
+	Topics []Topic
+	// Secret signs the request bodies with HMAC-SHA256 when set.
+	Secret string
+	// Retries is the number of the delivery retries with the exponential
+	// backoff, the negative value disables the retries.
+	Retries int
+	// Timeout is the timeout of the single delivery.
+	Timeout time.Duration
+	// Client is the HTTP client the events are posted with.
+	Client *http.Client
+}
+
+// Webhook posts the events of the bus as JSON to the URL. The events are
+// delivered one by one in the order they were published, so the events
+// published while the delivery is retried may be dropped by the bus.
+type Webhook struct {
+	opts        WebhookOptions
+	logger      log.Logger
+	metrics     webhookMetrics
+	unsubscribe func()
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+// NewWebhook returns the webhook which delivers the events of the
+// subscriber and starts the delivery.
+func NewWebhook(sub Subscriber, o WebhookOptions, logger log.Logger) (*Webhook, error) {
+	u, err := url.Parse(o.URL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWebhook, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("%w: url %q", ErrInvalidWebhook, o.URL)
+	}
+	for _, t := range o.Topics {
+		if !IsTopic(t) {
+			return nil, fmt.Errorf("%w: unknown topic %q", ErrInvalidWebhook, t)
+		}
+	}
+	if o.Retries == 0 {
+		o.Retries = DefaultWebhookRetries
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultWebhookTimeout
+	}
+	if o.Client == nil {
+		o.Client = &http.Client{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c, unsubscribe := sub.Subscribe(o.Topics...)
+	w := &Webhook{
+		opts:        o,
+		logger:      logger.WithName(loggerName).Register(),
+		metrics:     newWebhookMetrics(),
+		unsubscribe: unsubscribe,
+		cancel:      cancel,
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		for e := range c {
+			if err := w.deliver(ctx, e); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				w.metrics.FailedDeliveries.Inc()
+				w.logger.Warning("webhook delivery failed", "topic", e.Topic, "error", err)
+			}
+		}
+	}()
+
+	return w, nil
+}
+
+// deliver posts the event retrying with the exponential backoff.
+func (w *Webhook) deliver(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	backoff := webhookBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(ctx, e.Topic, body)
+		if err == nil {
+			w.metrics.Deliveries.Inc()
+			return nil
+		}
+		if !retry || attempt >= w.opts.Retries {
+			return err
+		}
+		w.metrics.Retries.Inc()
+		w.logger.Debug("webhook delivery retried", "topic", e.Topic, "attempt", attempt+1, "backoff", backoff, "error", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff = min(2*backoff, webhookMaxBackoff)
+	}
+}
+
+// post sends the request with the body and reports whether
+// the failed delivery should be retried.
+func (w *Webhook) post(ctx context.Context, topic Topic, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTopicHeader, string(topic))
+	if w.opts.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, "sha256="+Sign([]byte(w.opts.Secret), body))
+	}
+
+	resp, err := w.opts.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("response status %s", resp.Status)
+	default:
+		return false, fmt.Errorf("response status %s", resp.Status)
+	}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the body with the secret,
+// as sent in the signature header prefixed with "sha256=".
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Close stops the delivery of the events.
+func (w *Webhook) Close() error {
+	w.cancel()
+	w.unsubscribe()
+	w.wg.Wait()
+	return nil
+}
+
+// Metrics returns the metrics of the webhook.
+func (w *Webhook) Metrics() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(w.metrics)
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/events"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+type delivery struct {
+	topic     string
+	signature string
+	event     events.Event
+	body      []byte
+}
+
+// newWebhookServer returns the server responding with the statuses in
+// order, the last one repeated, and the channel of the received requests.
+func newWebhookServer(t *testing.T, statuses ...int) (*httptest.Server, <-chan delivery) {
+	t.Helper()
+
+	c := make(chan delivery, 16)
+	var n int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		d := delivery{
+			topic:     r.Header.Get(events.WebhookTopicHeader),
+			signature: r.Header.Get(events.WebhookSignatureHeader),
+			body:      body,
+		}
+		if err := json.Unmarshal(body, &d.event); err != nil {
+			t.Error(err)
+		}
+		c <- d
+		w.WriteHeader(statuses[min(n, len(statuses)-1)])
+		n++
+	}))
+	t.Cleanup(srv.Close)
+	return srv, c
+}
+
+func receiveDelivery(t *testing.T, c <-chan delivery) delivery {
+	t.Helper()
+	select {
+	case d := <-c:
+		return d
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not called")
+	}
+	return delivery{}
+}
+
+// nolint:paralleltest
+func TestWebhook(t *testing.T) {
+	defer events.SetWebhookBackoff(10 * time.Millisecond)()
+
+	t.Run("signed and filtered", func(t *testing.T) {
+		srv, c := newWebhookServer(t, http.StatusOK)
+		bus := events.New()
+		w, err := events.NewWebhook(bus, events.WebhookOptions{
+			URL:    srv.URL,
+			Topics: []events.Topic{events.TopicRound},
+			Secret: "secret",
+		}, log.Noop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutil.CleanupCloser(t, w)
+
+		bus.Publish(events.TopicDepth, events.Depth{Depth: 1})
+		bus.Publish(events.TopicRound, events.Round{Round: 7, Winner: true})
+
+		d := receiveDelivery(t, c)
+		if d.topic != string(events.TopicRound) || d.event.Topic != events.TopicRound {
+			t.Fatalf("want topic %q, have header %q and event %q", events.TopicRound, d.topic, d.event.Topic)
+		}
+		if want := "sha256=" + events.Sign([]byte("secret"), d.body); d.signature != want {
+			t.Fatalf("want signature %q, have %q", want, d.signature)
+		}
+		data := d.event.Data.(map[string]any)
+		if data["round"] != float64(7) || data["winner"] != true {
+			t.Fatalf("unexpected event data %v", data)
+		}
+	})
+
+	t.Run("retried on server error", func(t *testing.T) {
+		srv, c := newWebhookServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK)
+		bus := events.New()
+		w, err := events.NewWebhook(bus, events.WebhookOptions{URL: srv.URL, Retries: 3}, log.Noop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutil.CleanupCloser(t, w)
+
+		bus.Publish(events.TopicDepth, events.Depth{Depth: 1})
+		bus.Publish(events.TopicDepth, events.Depth{Depth: 2})
+
+		for i, want := range []float64{1, 1, 1, 2} {
+			d := receiveDelivery(t, c)
+			if d.signature != "" {
+				t.Fatalf("want no signature, have %q", d.signature)
+			}
+			if have := d.event.Data.(map[string]any)["depth"]; have != want {
+				t.Fatalf("delivery %d: want depth %v, have %v", i, want, have)
+			}
+		}
+	})
+
+	t.Run("not retried on client error", func(t *testing.T) {
+		srv, c := newWebhookServer(t, http.StatusBadRequest, http.StatusOK)
+		bus := events.New()
+		w, err := events.NewWebhook(bus, events.WebhookOptions{URL: srv.URL}, log.Noop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutil.CleanupCloser(t, w)
+
+		bus.Publish(events.TopicDepth, events.Depth{Depth: 1})
+		bus.Publish(events.TopicDepth, events.Depth{Depth: 2})
+
+		for _, want := range []float64{1, 2} {
+			if have := receiveDelivery(t, c).event.Data.(map[string]any)["depth"]; have != want {
+				t.Fatalf("want depth %v, have %v", want, have)
+			}
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, o := range []events.WebhookOptions{
+			{URL: "ftp://example.com"},
+			{URL: "http://"},
+			{URL: "http://example.com", Topics: []events.Topic{"unknown"}},
+		} {
+			if _, err := events.NewWebhook(events.New(), o, log.Noop); !errors.Is(err, events.ErrInvalidWebhook) {
+				t.Fatalf("%+v: want error %v, have %v", o, events.ErrInvalidWebhook, err)
+			}
+		}
+	})
+}
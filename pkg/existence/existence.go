@@ -0,0 +1,297 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package existence exposes the lightweight protocol asking
+// the peers whether they hold the chunks, without retrieving them.
+//
+// The requester may send a random challenge, in which case the peer has to
+// prove the possession of every chunk it claims to hold with the inclusion
+// proof of the chunk data segment picked by the challenge, so that the
+// peer can not claim to hold the chunks it does not.
+//
+// The queries are accounting neutral: neither side is debited nor credited
+// for them, as they carry no chunk data and cost next to nothing to answer
+// compared to the retrievals. The peers are rate limited instead, with the
+// proofs weighing more than the plain answers.
+package existence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/bitvector"
+	"github.com/ethersphere/bee/v2/pkg/existence/pb"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/protobuf"
+	"github.com/ethersphere/bee/v2/pkg/ratelimit"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "existence"
+
+const (
+	protocolName    = "existence"
+	protocolVersion = "1.0.0"
+	streamName      = "has"
+)
+
+const (
+	// MaxAddresses is the maximum number of the chunks asked for in one request.
+	MaxAddresses = 128
+
+	requestTimeout = 10 * time.Second
+
+	// handleMaxChecksPerSecond is the number of the chunks
+	// every peer may ask for per second.
+	handleMaxChecksPerSecond = 1024
+	handleRequestsLimitRate  = time.Second / handleMaxChecksPerSecond
+	// proofWeight is the number of the checks the proven chunk counts for.
+	proofWeight = 4
+)
+
+var (
+	// ErrTooManyAddresses is returned when more than MaxAddresses chunks are asked for.
+	ErrTooManyAddresses = errors.New("too many addresses")
+	// ErrInvalidResponse is returned when the response does not cover the asked chunks.
+	ErrInvalidResponse = errors.New("invalid response")
+	// ErrInvalidChallenge is returned when the challenge of the request is malformed.
+	ErrInvalidChallenge = errors.New("invalid challenge")
+)
+
+// Checker reports whether the chunk is held locally and
+// returns it to prove its possession.
+type Checker interface {
+	Has(ctx context.Context, addr swarm.Address) (bool, error)
+	Get(ctx context.Context, addr swarm.Address) (swarm.Chunk, error)
+}
+
+// Service asks the peers and answers them whether the chunks are held.
+type Service struct {
+	streamer p2p.Streamer
+	checker  Checker
+	logger   log.Logger
+	metrics  metrics
+	limiter  *ratelimit.Limiter
+}
+
+// New returns the existence protocol service answering from the checker.
+func New(streamer p2p.Streamer, checker Checker, logger log.Logger) *Service {
+	return &Service{
+		streamer: streamer,
+		checker:  checker,
+		logger:   logger.WithName(loggerName).Register(),
+		metrics:  newMetrics(),
+		limiter:  ratelimit.New(handleRequestsLimitRate, MaxAddresses*proofWeight),
+	}
+}
+
+func (s *Service) Protocol() p2p.ProtocolSpec {
+	return p2p.ProtocolSpec{
+		Name:    protocolName,
+		Version: protocolVersion,
+		StreamSpecs: []p2p.StreamSpec{
+			{
+				Name:    streamName,
+				Handler: s.handler,
+			},
+		},
+		DisconnectOut: s.disconnect,
+		DisconnectIn:  s.disconnect,
+	}
+}
+
+func (s *Service) disconnect(peer p2p.Peer) error {
+	s.limiter.Clear(peer.Address.ByteString())
+	return nil
+}
+
+// Has asks the peer whether it holds the chunks. The returned
+// slice reports the existence of the chunk at the same index.
+func (s *Service) Has(ctx context.Context, peer swarm.Address, addrs []swarm.Address) ([]bool, error) {
+	return s.has(ctx, peer, addrs, nil)
+}
+
+// HasWithProof asks the peer whether it holds the chunks and requires the
+// proof of the possession of every chunk it claims to hold. The chunks
+// with an invalid proof are reported as not held.
+func (s *Service) HasWithProof(ctx context.Context, peer swarm.Address, addrs []swarm.Address) ([]bool, error) {
+	challenge, err := newChallenge()
+	if err != nil {
+		return nil, fmt.Errorf("challenge: %w", err)
+	}
+	return s.has(ctx, peer, addrs, challenge)
+}
+
+func (s *Service) has(ctx context.Context, peer swarm.Address, addrs []swarm.Address, challenge []byte) (_ []bool, err error) {
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	if len(addrs) > MaxAddresses {
+		return nil, ErrTooManyAddresses
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	stream, err := s.streamer.NewStream(ctx, peer, nil, protocolName, protocolVersion, streamName)
+	if err != nil {
+		return nil, fmt.Errorf("new stream: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = stream.Reset()
+		} else {
+			_ = stream.FullClose()
+		}
+	}()
+
+	req := &pb.Request{Addresses: make([][]byte, len(addrs)), Challenge: challenge}
+	for i, addr := range addrs {
+		req.Addresses[i] = addr.Bytes()
+	}
+
+	w, r := protobuf.NewWriterAndReader(stream)
+	if err := w.WriteMsgWithContext(ctx, req); err != nil {
+		return nil, fmt.Errorf("write request: %w", err)
+	}
+	s.metrics.RequestsSent.Inc()
+
+	var resp pb.Response
+	if err := r.ReadMsgWithContext(ctx, &resp); err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	bv, err := bitvector.NewFromBytes(resp.Bitvector, len(addrs))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+	has := make([]bool, len(addrs))
+	for i := range addrs {
+		has[i] = bv.Get(i)
+	}
+	if challenge == nil {
+		return has, nil
+	}
+
+	// the proofs are of the held chunks in the order of the addresses
+	var held int
+	for _, h := range has {
+		if h {
+			held++
+		}
+	}
+	if len(resp.Proofs) != held {
+		return nil, fmt.Errorf("%w: %d proofs of %d held chunks", ErrInvalidResponse, len(resp.Proofs), held)
+	}
+	proofs := resp.Proofs
+	for i, addr := range addrs {
+		if !has[i] {
+			continue
+		}
+		if !verifyProof(addr, challenge, proofs[0]) {
+			s.logger.Debug("invalid proof of possession", "peer_address", peer, "chunk_address", addr)
+			s.metrics.InvalidProofs.Inc()
+			has[i] = false
+		}
+		proofs = proofs[1:]
+	}
+	return has, nil
+}
+
+func (s *Service) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream) (err error) {
+	defer func() {
+		if err != nil {
+			_ = stream.Reset()
+		} else {
+			_ = stream.FullClose()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	w, r := protobuf.NewWriterAndReader(stream)
+
+	var req pb.Request
+	if err := r.ReadMsgWithContext(ctx, &req); err != nil {
+		return fmt.Errorf("read request: %w", err)
+	}
+	s.metrics.RequestsReceived.Inc()
+
+	if len(req.Addresses) == 0 || len(req.Addresses) > MaxAddresses {
+		return fmt.Errorf("peer %s: %w: %d", p.Address, ErrTooManyAddresses, len(req.Addresses))
+	}
+	proven := len(req.Challenge) != 0
+	if proven && len(req.Challenge) != challengeSize {
+		return fmt.Errorf("peer %s: %w", p.Address, ErrInvalidChallenge)
+	}
+
+	weight := len(req.Addresses)
+	if proven {
+		weight *= proofWeight
+	}
+	waitDur, err := s.limiter.Wait(ctx, p.Address.ByteString(), weight)
+	if err != nil {
+		return fmt.Errorf("rate limiter: %w", err)
+	}
+	if waitDur > 0 {
+		s.logger.Debug("rate limited peer", "wait_duration", waitDur, "peer_address", p.Address)
+	}
+
+	bv, err := bitvector.New(len(req.Addresses))
+	if err != nil {
+		return fmt.Errorf("bitvector: %w", err)
+	}
+	resp := new(pb.Response)
+	for i, b := range req.Addresses {
+		addr := swarm.NewAddress(b)
+		if !addr.IsValidLength() {
+			continue
+		}
+		if proven {
+			proof, err := s.prove(ctx, addr, req.Challenge)
+			if err != nil {
+				s.logger.Debug("proof of possession failed", "chunk_address", addr, "error", err)
+				continue
+			}
+			if proof != nil {
+				bv.Set(i)
+				resp.Proofs = append(resp.Proofs, proof)
+			}
+			continue
+		}
+		has, err := s.checker.Has(ctx, addr)
+		if err != nil {
+			s.logger.Debug("existence check failed", "chunk_address", addr, "error", err)
+			continue
+		}
+		if has {
+			bv.Set(i)
+		}
+	}
+	resp.Bitvector = bv.Bytes()
+
+	if err := w.WriteMsgWithContext(ctx, resp); err != nil {
+		return fmt.Errorf("write response: %w", err)
+	}
+	return nil
+}
+
+// prove returns the proof of the possession of the chunk for the
+// challenge, or nil if the chunk is not held.
+func (s *Service) prove(ctx context.Context, addr swarm.Address, challenge []byte) (*pb.Proof, error) {
+	ch, err := s.checker.Get(ctx, addr)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return makeProof(ch, challenge)
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package existence_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/existence"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p/streamtest"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
+	testingc "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestHas(t *testing.T) {
+	t.Parallel()
+
+	store := inmemchunkstore.New()
+	chunks := testingc.GenerateTestRandomChunks(10)
+	var (
+		addrs []swarm.Address
+		want  []bool
+	)
+	for i, ch := range chunks {
+		addrs = append(addrs, ch.Address())
+		want = append(want, i%3 == 0)
+		if i%3 == 0 {
+			if err := store.Put(context.Background(), ch); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	server := existence.New(nil, store, log.Noop)
+	recorder := streamtest.New(streamtest.WithProtocols(server.Protocol()))
+	client := existence.New(recorder, nil, log.Noop)
+
+	got, err := client.Has(context.Background(), swarm.RandAddress(t), addrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	tooMany := make([]swarm.Address, existence.MaxAddresses+1)
+	if _, err := client.Has(context.Background(), swarm.RandAddress(t), tooMany); !errors.Is(err, existence.ErrTooManyAddresses) {
+		t.Fatalf("got error %v, want %v", err, existence.ErrTooManyAddresses)
+	}
+}
+
+func TestHasWithProof(t *testing.T) {
+	t.Parallel()
+
+	store := inmemchunkstore.New()
+	chunks := testingc.GenerateTestRandomChunks(6)
+	chunks = append(chunks, testingc.GenerateTestRandomSoChunk(t, testingc.GenerateTestRandomChunk()))
+	var (
+		addrs []swarm.Address
+		want  []bool
+	)
+	for i, ch := range chunks {
+		addrs = append(addrs, ch.Address())
+		held := i%2 == 0
+		want = append(want, held)
+		if held {
+			if err := store.Put(context.Background(), ch); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	server := existence.New(nil, store, log.Noop)
+	recorder := streamtest.New(streamtest.WithProtocols(server.Protocol()))
+	client := existence.New(recorder, nil, log.Noop)
+
+	got, err := client.HasWithProof(context.Background(), swarm.RandAddress(t), addrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	t.Run("false claims", func(t *testing.T) {
+		t.Parallel()
+
+		// the peer claims to hold every chunk but holds none of them
+		server := existence.New(nil, &lyingStore{ChunkStore: store, chunk: testingc.GenerateTestRandomChunk()}, log.Noop)
+		recorder := streamtest.New(streamtest.WithProtocols(server.Protocol()))
+		client := existence.New(recorder, nil, log.Noop)
+
+		got, err := client.Has(context.Background(), swarm.RandAddress(t), addrs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if slices.Contains(got, false) {
+			t.Fatalf("got %v, want all held", got)
+		}
+
+		got, err = client.HasWithProof(context.Background(), swarm.RandAddress(t), addrs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if slices.Contains(got, true) {
+			t.Fatalf("got %v, want none held", got)
+		}
+	})
+}
+
+// lyingStore claims to hold every chunk and returns
+// the same chunk for every address.
+type lyingStore struct {
+	storage.ChunkStore
+	chunk swarm.Chunk
+}
+
+func (s *lyingStore) Has(context.Context, swarm.Address) (bool, error) {
+	return true, nil
+}
+
+func (s *lyingStore) Get(_ context.Context, addr swarm.Address) (swarm.Chunk, error) {
+	return swarm.NewChunk(addr, s.chunk.Data()), nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package existence
+
+import (
+	m "github.com/ethersphere/bee/v2/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	// all metrics fields must be exported
+	// to be able to return them by Metrics()
+	// using reflection
+	RequestsSent     prometheus.Counter
+	RequestsReceived prometheus.Counter
+	InvalidProofs    prometheus.Counter
+}
+
+func newMetrics() metrics {
+	subsystem := "existence"
+
+	return metrics{
+		RequestsSent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "requests_sent_count",
+			Help:      "Number of existence requests sent.",
+		}),
+		RequestsReceived: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "requests_received_count",
+			Help:      "Number of existence requests received.",
+		}),
+		InvalidProofs: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "invalid_proofs_count",
+			Help:      "Number of invalid proofs of possession received.",
+		}),
+	}
+}
+
+func (s *Service) Metrics() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(s.metrics)
+}
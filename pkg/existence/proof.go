@@ -0,0 +1,161 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package existence
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/ethersphere/bee/v2/pkg/bmt"
+	"github.com/ethersphere/bee/v2/pkg/bmtpool"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/existence/pb"
+	"github.com/ethersphere/bee/v2/pkg/soc"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const (
+	// challengeSize is the size of the random challenge of the proofs.
+	challengeSize = swarm.HashSize
+	// proofLength is the number of the sister segments in the inclusion
+	// proof, the sister of the proven segment and one per tree level.
+	proofLength = 7
+)
+
+var errInvalidChunk = errors.New("invalid chunk")
+
+// newChallenge returns the random challenge the proofs of possession are
+// made for, so that the peer can not answer with the precomputed proofs.
+func newChallenge() ([]byte, error) {
+	challenge := make([]byte, challengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// proofSegment returns the index of the data segment of the chunk
+// proven for the challenge.
+func proofSegment(challenge []byte, addr swarm.Address) int {
+	h := swarm.NewHasher()
+	_, _ = h.Write(challenge)
+	_, _ = h.Write(addr.Bytes())
+	sum := h.Sum(nil)
+	return int(sum[len(sum)-1]) % swarm.BmtBranches
+}
+
+// proveSegment returns the inclusion proof of the i-th segment of the
+// content addressed chunk data and the BMT hash of the data.
+func proveSegment(data []byte, i int) (bmt.Proof, []byte, error) {
+	if len(data) < swarm.SpanSize || len(data) > swarm.ChunkWithSpanSize {
+		return bmt.Proof{}, nil, errInvalidChunk
+	}
+
+	prover := bmt.Prover{Hasher: bmtpool.Get()}
+	defer bmtpool.Put(prover.Hasher)
+
+	prover.SetHeader(data[:swarm.SpanSize])
+	if _, err := prover.Write(data[swarm.SpanSize:]); err != nil {
+		return bmt.Proof{}, nil, err
+	}
+	root, err := prover.Hash(nil)
+	if err != nil {
+		return bmt.Proof{}, nil, err
+	}
+	proof := prover.Proof(i)
+	proof.Span = bytes.Clone(proof.Span)
+	return proof, root, nil
+}
+
+// makeProof returns the proof of the possession of the chunk for the
+// challenge. The single owner chunk is proven with the inclusion proof
+// of its wrapped chunk along with its id and signature.
+func makeProof(ch swarm.Chunk, challenge []byte) (*pb.Proof, error) {
+	var (
+		i    = proofSegment(challenge, ch.Address())
+		data = ch.Data()
+	)
+	proof, root, err := proveSegment(data, i)
+	if err == nil && bytes.Equal(root, ch.Address().Bytes()) {
+		return &pb.Proof{
+			ProveSegment:  proof.ProveSegment,
+			ProofSegments: proof.ProofSegments,
+			Span:          proof.Span,
+		}, nil
+	}
+
+	if len(data) < swarm.SocMinChunkSize {
+		return nil, errInvalidChunk
+	}
+	cursor := swarm.HashSize + swarm.SocSignatureSize
+	proof, _, err = proveSegment(data[cursor:], i)
+	if err != nil {
+		return nil, fmt.Errorf("wrapped chunk: %w", err)
+	}
+	return &pb.Proof{
+		ProveSegment:  proof.ProveSegment,
+		ProofSegments: proof.ProofSegments,
+		Span:          proof.Span,
+		ID:            bytes.Clone(data[:swarm.HashSize]),
+		Signature:     bytes.Clone(data[swarm.HashSize:cursor]),
+	}, nil
+}
+
+// verifyProof reports whether the proof proves the possession
+// of the chunk with the address for the challenge.
+func verifyProof(addr swarm.Address, challenge []byte, p *pb.Proof) bool {
+	if p == nil ||
+		len(p.ProveSegment) != swarm.SectionSize ||
+		len(p.ProofSegments) != proofLength ||
+		len(p.Span) != swarm.SpanSize {
+		return false
+	}
+	for _, s := range p.ProofSegments {
+		if len(s) != swarm.SectionSize {
+			return false
+		}
+	}
+
+	i := proofSegment(challenge, addr)
+	prover := bmt.Prover{Hasher: bmtpool.Get()}
+	root, err := prover.Verify(i, bmt.Proof{
+		ProveSegment:  p.ProveSegment,
+		ProofSegments: p.ProofSegments,
+		Span:          p.Span,
+		Index:         i,
+	})
+	bmtpool.Put(prover.Hasher)
+	if err != nil {
+		return false
+	}
+
+	if len(p.ID) == 0 && len(p.Signature) == 0 {
+		return bytes.Equal(root, addr.Bytes())
+	}
+
+	// the owner recovered from the signature of the wrapped chunk
+	// must be the one the address of the single owner chunk is of
+	if len(p.ID) != swarm.HashSize || len(p.Signature) != swarm.SocSignatureSize {
+		return false
+	}
+	h := swarm.NewHasher()
+	_, _ = h.Write(p.ID)
+	_, _ = h.Write(root)
+	pub, err := crypto.Recover(p.Signature, h.Sum(nil))
+	if err != nil {
+		return false
+	}
+	owner, err := crypto.NewEthereumAddress(*pub)
+	if err != nil {
+		return false
+	}
+	want, err := soc.CreateAddress(p.ID, owner)
+	if err != nil {
+		return false
+	}
+	return want.Equal(addr)
+}
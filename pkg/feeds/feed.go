@@ -71,6 +71,15 @@ func (i *id) MarshalBinary() ([]byte, error) {
 	return crypto.LegacyKeccak256(append(append([]byte{}, i.topic...), i.index...))
 }
 
+// snapshotTopicSuffix distinguishes the topic of the snapshot feed.
+const snapshotTopicSuffix = "snapshot"
+
+// SnapshotTopic returns the topic of the companion feed whose updates are the
+// snapshots of the recent updates of the feed with the given topic.
+func SnapshotTopic(topic []byte) ([]byte, error) {
+	return crypto.LegacyKeccak256(append(append([]byte{}, topic...), snapshotTopicSuffix...))
+}
+
 // Feed is representing an epoch based feed
 type Feed struct {
 	Topic []byte
@@ -0,0 +1,160 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cdc implements the content-defined chunking of the data streams.
+//
+// The boundaries of the chunks are found with the gear rolling hash of the
+// content, so the insertion or the removal of the data shifts only the
+// chunks around the change. The uploads of the chunks of the changed files
+// are then mostly deduplicated against the earlier uploads.
+package cdc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// maxSizeLimit is the upper bound of the maximum chunk size.
+const maxSizeLimit = 64 * 1024 * 1024
+
+// ErrInvalidOptions is returned when the chunk sizes are not consistent.
+var ErrInvalidOptions = errors.New("invalid chunking options")
+
+// Options are the chunk sizes of the chunker.
+type Options struct {
+	// MinSize is the minimum size of the chunk, except the last one.
+	MinSize int
+	// AvgSize is the expected size of the chunk, it must be the power of two.
+	AvgSize int
+	// MaxSize is the maximum size of the chunk.
+	MaxSize int
+}
+
+// DefaultOptions are the chunk sizes suited for the file backups.
+var DefaultOptions = Options{
+	MinSize: 256 * 1024,
+	AvgSize: 1024 * 1024,
+	MaxSize: 4 * 1024 * 1024,
+}
+
+// Validate checks the consistency of the chunk sizes.
+func (o Options) Validate() error {
+	switch {
+	case o.MinSize <= 0 || o.MinSize > o.AvgSize || o.AvgSize > o.MaxSize:
+		return fmt.Errorf("%w: sizes %d <= %d <= %d", ErrInvalidOptions, o.MinSize, o.AvgSize, o.MaxSize)
+	case o.AvgSize&(o.AvgSize-1) != 0 || o.AvgSize < 64:
+		return fmt.Errorf("%w: average size %d is not a power of two", ErrInvalidOptions, o.AvgSize)
+	case o.MaxSize > maxSizeLimit:
+		return fmt.Errorf("%w: maximum size %d over %d", ErrInvalidOptions, o.MaxSize, maxSizeLimit)
+	}
+	return nil
+}
+
+// gear is the table of the random values of the bytes for the rolling hash.
+// The values must never change, otherwise the chunks of the same content
+// differ between the versions.
+var gear = func() (t [256]uint64) {
+	// splitmix64 of the fixed seed
+	x := uint64(0x5357_4152_4d43_4443)
+	for i := range t {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		t[i] = z ^ (z >> 31)
+	}
+	return t
+}()
+
+// Chunker splits the data of the reader into the content-defined chunks.
+type Chunker struct {
+	r     io.Reader
+	o     Options
+	maskS uint64 // mask of the hash before the average size is reached
+	maskL uint64 // mask of the hash after the average size is reached
+	buf   []byte
+	start int
+	end   int
+	eof   bool
+}
+
+// New returns the chunker of the data of the reader.
+func New(r io.Reader, o Options) (*Chunker, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	// the chunk sizes are normalized around the average size by the
+	// harder and the easier boundary conditions
+	avgBits := bits.TrailingZeros(uint(o.AvgSize))
+	return &Chunker{
+		r:     r,
+		o:     o,
+		maskS: ^uint64(0) << (64 - (avgBits + 2)),
+		maskL: ^uint64(0) << (64 - (avgBits - 2)),
+		buf:   make([]byte, o.MaxSize),
+	}, nil
+}
+
+// Next returns the next chunk of the data or io.EOF when all the data is
+// chunked. The returned slice is valid only until the next call.
+func (c *Chunker) Next() ([]byte, error) {
+	if c.end-c.start < c.o.MaxSize && !c.eof {
+		if err := c.fill(); err != nil {
+			return nil, err
+		}
+	}
+	if c.start == c.end {
+		return nil, io.EOF
+	}
+	n := c.boundary(c.buf[c.start:c.end])
+	chunk := c.buf[c.start : c.start+n]
+	c.start += n
+	return chunk, nil
+}
+
+// fill moves the remaining data to the start of the buffer
+// and reads the data until the buffer is full.
+func (c *Chunker) fill() error {
+	c.end = copy(c.buf, c.buf[c.start:c.end])
+	c.start = 0
+	for c.end < len(c.buf) {
+		n, err := c.r.Read(c.buf[c.end:])
+		c.end += n
+		if errors.Is(err, io.EOF) {
+			c.eof = true
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read data: %w", err)
+		}
+	}
+	return nil
+}
+
+// boundary returns the length of the chunk at the start of the data.
+func (c *Chunker) boundary(data []byte) int {
+	n := len(data)
+	if n <= c.o.MinSize {
+		return n
+	}
+	normal := min(c.o.AvgSize, n)
+
+	var h uint64
+	i := c.o.MinSize
+	for ; i < normal; i++ {
+		h = h<<1 + gear[data[i]]
+		if h&c.maskS == 0 {
+			return i + 1
+		}
+	}
+	for ; i < n; i++ {
+		h = h<<1 + gear[data[i]]
+		if h&c.maskL == 0 {
+			return i + 1
+		}
+	}
+	return n
+}
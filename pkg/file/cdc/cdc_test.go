@@ -0,0 +1,111 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cdc_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/file/cdc"
+)
+
+var testOptions = cdc.Options{MinSize: 1024, AvgSize: 4096, MaxSize: 16384}
+
+func chunks(t *testing.T, data []byte) [][]byte {
+	t.Helper()
+
+	c, err := cdc.New(iotestReader{bytes.NewReader(data)}, testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var all [][]byte
+	for {
+		chunk, err := c.Next()
+		if errors.Is(err, io.EOF) {
+			return all
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		all = append(all, bytes.Clone(chunk))
+	}
+}
+
+// iotestReader reads the data in the small irregular parts.
+type iotestReader struct {
+	r io.Reader
+}
+
+func (r iotestReader) Read(p []byte) (int, error) {
+	if len(p) > 1000 {
+		p = p[:1000]
+	}
+	return r.r.Read(p)
+}
+
+func TestChunker(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 1<<20)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	all := chunks(t, data)
+	if got := bytes.Join(all, nil); !bytes.Equal(got, data) {
+		t.Fatal("chunks do not add up to the data")
+	}
+	for i, c := range all {
+		if len(c) > testOptions.MaxSize || (len(c) < testOptions.MinSize && i != len(all)-1) {
+			t.Fatalf("chunk %d of size %d out of bounds", i, len(c))
+		}
+	}
+	if n := len(all); n < 1<<20/testOptions.MaxSize || n > 1<<20/testOptions.MinSize {
+		t.Fatalf("got %d chunks", n)
+	}
+
+	// the insertion at the start changes only the chunks around it
+	changed := append([]byte("inserted"), data...)
+	seen := make(map[string]bool)
+	for _, c := range all {
+		seen[string(c)] = true
+	}
+	var same int
+	for _, c := range chunks(t, changed) {
+		if seen[string(c)] {
+			same++
+		}
+	}
+	if same < len(all)-2 {
+		t.Fatalf("got %d of %d same chunks after the insertion", same, len(all))
+	}
+}
+
+func TestChunkerEmpty(t *testing.T) {
+	t.Parallel()
+
+	if got := chunks(t, nil); len(got) != 0 {
+		t.Fatalf("got %d chunks of no data", len(got))
+	}
+}
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	for _, o := range []cdc.Options{
+		{MinSize: 0, AvgSize: 4096, MaxSize: 8192},
+		{MinSize: 1024, AvgSize: 3000, MaxSize: 8192},
+		{MinSize: 8192, AvgSize: 4096, MaxSize: 8192},
+		{MinSize: 1024, AvgSize: 4096, MaxSize: 1 << 30},
+	} {
+		if err := o.Validate(); !errors.Is(err, cdc.ErrInvalidOptions) {
+			t.Fatalf("options %+v: got error %v, want %v", o, err, cdc.ErrInvalidOptions)
+		}
+	}
+	if err := cdc.DefaultOptions.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -36,6 +36,7 @@ type joiner struct {
 	ctx         context.Context
 	decoders    *decoderCache
 	chunkToSpan func(data []byte) (redundancy.Level, int64) // returns parity and span value from chunkData
+	prefetch    *prefetcher                                 // prefetcher of the sequential reads, nil if disabled
 }
 
 // decoderCache is cache of decoders for intermediate chunks
@@ -163,6 +164,9 @@ func NewJoiner(ctx context.Context, g storage.Getter, putter storage.Putter, add
 		maxBranching: maxBranching,
 		chunkToSpan:  spanFn,
 	}
+	if window := getPrefetch(ctx); window > 0 {
+		j.prefetch = newPrefetcher(j, window)
+	}
 
 	return j, span, nil
 }
@@ -174,6 +178,9 @@ func (j *joiner) Read(b []byte) (n int, err error) {
 	if err != nil && !errors.Is(err, io.EOF) {
 		return read, err
 	}
+	if j.prefetch != nil && read > 0 {
+		j.prefetch.consumed(j.off, read)
+	}
 
 	j.off += int64(read)
 	return read, err
@@ -14,6 +14,7 @@ import (
 	"io"
 	mrand "math/rand"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/file/splitter"
 	filetest "github.com/ethersphere/bee/v2/pkg/file/testing"
 	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/spinlock"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
 	testingc "github.com/ethersphere/bee/v2/pkg/storage/testing"
@@ -645,6 +647,82 @@ func TestPrefetch(t *testing.T) {
 	}
 }
 
+// TestSequentialPrefetch tests that the chunks ahead of the sequential
+// reader are fetched when prefetching is enabled.
+func TestSequentialPrefetch(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chunks = 32
+		window = 8
+	)
+
+	store := inmemchunkstore.New()
+	testutil.CleanupCloser(t, store)
+
+	data := testutil.RandBytes(t, chunks*swarm.ChunkSize)
+	addr, err := splitter.NewSimpleSplitter(store).Split(context.Background(), io.NopCloser(bytes.NewReader(data)), int64(len(data)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		window   int
+		minGets  int
+		maxGets  int
+		waitGets bool
+	}{
+		// the root chunk, the two chunks read and the prefetched ones
+		{name: "enabled", window: window, minGets: 3 + 4, maxGets: 3 + window, waitGets: true},
+		{name: "disabled", minGets: 3, maxGets: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.window > 0 {
+				ctx = joiner.SetPrefetch(ctx, tc.window)
+			}
+
+			var gets atomic.Int64
+			g := storage.GetterFunc(func(ctx context.Context, addr swarm.Address) (swarm.Chunk, error) {
+				gets.Add(1)
+				return store.Get(ctx, addr)
+			})
+
+			j, _, err := joiner.New(ctx, g, store, addr, redundancy.NONE)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b := make([]byte, swarm.ChunkSize)
+			for i := range 2 {
+				if _, err := io.ReadFull(j, b); err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(b, data[i*swarm.ChunkSize:(i+1)*swarm.ChunkSize]) {
+					t.Fatal("read data does not match")
+				}
+			}
+
+			if tc.waitGets {
+				err := spinlock.Wait(time.Second, func() bool {
+					return gets.Load() >= int64(tc.minGets)
+				})
+				if err != nil {
+					t.Fatalf("got %d chunk retrievals, want at least %d", gets.Load(), tc.minGets)
+				}
+			} else {
+				time.Sleep(50 * time.Millisecond)
+			}
+			if n := gets.Load(); n < int64(tc.minGets) || n > int64(tc.maxGets) {
+				t.Fatalf("got %d chunk retrievals, want between %d and %d", n, tc.minGets, tc.maxGets)
+			}
+		})
+	}
+}
+
 func TestJoinerReadAt(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,119 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package joiner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const (
+	// DefaultPrefetchWindow is the default maximum number of the data
+	// chunks prefetched ahead of the sequential reader.
+	DefaultPrefetchWindow = 64
+
+	minPrefetchWindow = 4           // minimum number of the data chunks prefetched
+	sequentialReads   = 2           // number of the consecutive reads after which the prefetching starts
+	prefetchHorizon   = time.Second // the window covers the data the reader consumes in this time
+	rateSmoothing     = 0.3         // weight of the latest read in the consumption rate
+)
+
+type prefetchKey struct{}
+
+// SetPrefetch enables prefetching up to window data chunks ahead of the
+// sequential reads of the joiners created with the returned context.
+// The prefetched chunks are retrieved with the getter of the joiner, so
+// it should cache them for the prefetching to be useful.
+func SetPrefetch(ctx context.Context, window int) context.Context {
+	return context.WithValue(ctx, prefetchKey{}, window)
+}
+
+// getPrefetch returns the prefetch window set in the context, zero if none.
+func getPrefetch(ctx context.Context) int {
+	window, _ := ctx.Value(prefetchKey{}).(int)
+	return window
+}
+
+// prefetcher follows the reads of the joiner and, once they are sequential,
+// fetches the data chunks ahead of the reader. The number of the chunks
+// prefetched adapts to the rate the reader consumes the data at.
+type prefetcher struct {
+	j         *joiner
+	maxWindow int64
+
+	mu         sync.Mutex
+	sequential int       // number of the consecutive sequential reads
+	next       int64     // offset of the next sequential read
+	front      int64     // end of the prefetched data
+	busy       bool      // whether a prefetch is in progress
+	rate       float64   // consumption rate in bytes per second
+	last       time.Time // time of the last read
+}
+
+func newPrefetcher(j *joiner, window int) *prefetcher {
+	return &prefetcher{j: j, maxWindow: int64(max(window, minPrefetchWindow))}
+}
+
+// consumed records the read of n bytes at the offset
+// and starts prefetching the data ahead of it if needed.
+func (p *prefetcher) consumed(off int64, n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if off != p.next {
+		// the reader seeked, the prefetched data is of no use
+		p.sequential = 0
+		p.front = 0
+		p.rate = 0
+	} else {
+		p.sequential++
+		if dt := now.Sub(p.last).Seconds(); !p.last.IsZero() && dt > 0 {
+			rate := float64(n) / dt
+			if p.rate == 0 {
+				p.rate = rate
+			} else {
+				p.rate = (1-rateSmoothing)*p.rate + rateSmoothing*rate
+			}
+		}
+	}
+	p.last = now
+	p.next = off + int64(n)
+
+	if p.sequential < sequentialReads || p.busy {
+		return
+	}
+
+	start := max(p.front, p.next)
+	end := min(p.next+p.window()*swarm.ChunkSize, p.j.span)
+	if start >= end {
+		return
+	}
+	p.busy = true
+	p.front = end
+	go p.fetch(start, end)
+}
+
+// window returns the number of the data chunks to keep prefetched,
+// enough to cover the data consumed within the prefetch horizon.
+func (p *prefetcher) window() int64 {
+	window := int64(p.rate*prefetchHorizon.Seconds()) / swarm.ChunkSize
+	return min(max(window, minPrefetchWindow), p.maxWindow)
+}
+
+// fetch reads the data between the offsets, so that its chunks are retrieved.
+func (p *prefetcher) fetch(start, end int64) {
+	defer func() {
+		p.mu.Lock()
+		p.busy = false
+		p.mu.Unlock()
+	}()
+
+	// the errors are left for the reader to encounter
+	_, _ = p.j.ReadAt(make([]byte, end-start), start)
+}
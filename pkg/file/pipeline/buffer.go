@@ -0,0 +1,52 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pipeline
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		return &Buffer{data: make([]byte, swarm.ChunkWithSpanSize)}
+	},
+}
+
+// Buffer is a pooled chunk buffer the data of the pipeline writes is passed
+// in, so that the chunks are neither allocated nor copied by every writer.
+// The buffer is returned to the pool once released by all its holders.
+// The writers holding on to the data after ChainWrite returns must either
+// Retain the buffer and Release it when done with the data, or copy it.
+type Buffer struct {
+	data []byte
+	refs atomic.Int32
+}
+
+// NewBuffer returns a buffer from the pool held by the caller.
+func NewBuffer() *Buffer {
+	b := bufferPool.Get().(*Buffer)
+	b.refs.Store(1)
+	return b
+}
+
+// Bytes returns the ChunkWithSpanSize long data of the buffer.
+func (b *Buffer) Bytes() []byte {
+	return b.data
+}
+
+// Retain adds a holder of the buffer.
+func (b *Buffer) Retain() {
+	b.refs.Add(1)
+}
+
+// Release removes a holder of the buffer, the last one returns it to the pool.
+func (b *Buffer) Release() {
+	if b.refs.Add(-1) == 0 {
+		bufferPool.Put(b)
+	}
+}
@@ -34,9 +34,10 @@ func NewPipelineBuilder(ctx context.Context, s storage.Putter, encrypt bool, rLe
 // a merkle-tree of hashes that represent the given arbitrary size byte stream. Partial
 // writes are supported. The pipeline flow is: Data -> Feeder -> BMT -> Storage -> HashTrie.
 func newPipeline(ctx context.Context, s storage.Putter, rLevel redundancy.Level) pipeline.Interface {
-	pipeline := newShortPipelineFunc(ctx, s)
-	tw := hashtrie.NewHashTrieWriter(ctx, swarm.HashSize, redundancy.New(rLevel, false, pipeline), pipeline, s, rLevel)
-	lsw := store.NewStoreWriter(ctx, s, tw)
+	bp := store.NewBatchPutter(ctx, s)
+	pipeline := newShortPipelineFunc(ctx, bp)
+	tw := hashtrie.NewHashTrieWriter(ctx, swarm.HashSize, redundancy.New(rLevel, false, pipeline), pipeline, bp, rLevel)
+	lsw := store.NewBatchStoreWriter(ctx, bp, tw)
 	b := bmt.NewBmtWriter(lsw)
 	return feeder.NewChunkFeederWriter(swarm.ChunkSize, b)
 }
@@ -56,8 +57,9 @@ func newShortPipelineFunc(ctx context.Context, s storage.Putter) func() pipeline
 // Note that the encryption writer will mutate the data to contain the encrypted span, but the span field
 // with the unencrypted span is preserved.
 func newEncryptionPipeline(ctx context.Context, s storage.Putter, rLevel redundancy.Level) pipeline.Interface {
-	tw := hashtrie.NewHashTrieWriter(ctx, swarm.HashSize+encryption.KeyLength, redundancy.New(rLevel, true, newShortPipelineFunc(ctx, s)), newShortEncryptionPipelineFunc(ctx, s), s, rLevel)
-	lsw := store.NewStoreWriter(ctx, s, tw)
+	bp := store.NewBatchPutter(ctx, s)
+	tw := hashtrie.NewHashTrieWriter(ctx, swarm.HashSize+encryption.KeyLength, redundancy.New(rLevel, true, newShortPipelineFunc(ctx, bp)), newShortEncryptionPipelineFunc(ctx, bp), bp, rLevel)
+	lsw := store.NewBatchStoreWriter(ctx, bp, tw)
 	b := bmt.NewBmtWriter(lsw)
 	enc := enc.NewEncryptionWriter(encryption.NewChunkEncrypter(), b)
 	return feeder.NewChunkFeederWriter(swarm.ChunkSize, enc)
@@ -75,7 +77,16 @@ func newShortEncryptionPipelineFunc(ctx context.Context, s storage.Putter) func(
 
 // FeedPipeline feeds the pipeline with the given reader until EOF is reached.
 // It returns the cryptographic root hash of the content.
+// If the pipeline is an io.ReaderFrom, the data is read directly into its
+// chunk buffers.
 func FeedPipeline(ctx context.Context, pipeline pipeline.Interface, r io.Reader) (addr swarm.Address, err error) {
+	if rf, ok := pipeline.(io.ReaderFrom); ok {
+		if _, err := rf.ReadFrom(&ctxReader{ctx: ctx, r: r}); err != nil {
+			return swarm.ZeroAddress, err
+		}
+		return sumPipeline(ctx, pipeline)
+	}
+
 	data := make([]byte, swarm.ChunkSize)
 	for {
 		c, err := r.Read(data)
@@ -108,6 +119,12 @@ func FeedPipeline(ctx context.Context, pipeline pipeline.Interface, r io.Reader)
 		default:
 		}
 	}
+	return sumPipeline(ctx, pipeline)
+}
+
+// sumPipeline returns the root hash of the content written to the pipeline
+// unless the context is done.
+func sumPipeline(ctx context.Context, pipeline pipeline.Interface) (swarm.Address, error) {
 	select {
 	case <-ctx.Done():
 		return swarm.ZeroAddress, ctx.Err()
@@ -122,3 +139,16 @@ func FeedPipeline(ctx context.Context, pipeline pipeline.Interface, r io.Reader)
 	newAddress := swarm.NewAddress(sum)
 	return newAddress, nil
 }
+
+// ctxReader stops reading once the context is done.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
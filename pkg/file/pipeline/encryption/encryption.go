@@ -31,6 +31,7 @@ func (e *encryptionWriter) ChainWrite(p *pipeline.PipeWriteArgs) error {
 	copy(c[:8], encryptedSpan)
 	copy(c[8:], encryptedData)
 	p.Data = c // replace the verbatim data with the encrypted data
+	p.Buffer = nil
 	p.Key = key
 	return e.next.ChainWrite(p)
 }
@@ -6,6 +6,8 @@ package feeder
 
 import (
 	"encoding/binary"
+	"errors"
+	"io"
 
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -16,8 +18,9 @@ const span = swarm.SpanSize
 type chunkFeeder struct {
 	size      int
 	next      pipeline.ChainWriter
-	buffer    []byte
-	bufferIdx int
+	buffer    *pipeline.Buffer // pooled buffer of the current chunk, nil if not pooled
+	data      []byte           // span and data of the current chunk
+	bufferIdx int              // length of the data of the current chunk
 	wrote     int64
 }
 
@@ -26,9 +29,8 @@ type chunkFeeder struct {
 // subsequent writers when Sum() is called.
 func NewChunkFeederWriter(size int, next pipeline.ChainWriter) pipeline.Interface {
 	return &chunkFeeder{
-		size:   size,
-		next:   next,
-		buffer: make([]byte, size),
+		size: size,
+		next: next,
 	}
 }
 
@@ -37,55 +39,78 @@ func NewChunkFeederWriter(size int, next pipeline.ChainWriter) pipeline.Interfac
 // bytes were actually flushed to subsequent writers, since the feeder is buffered
 // and works in chunk-size quantiles.
 func (f *chunkFeeder) Write(b []byte) (int, error) {
-	l := len(b) // data length
-	w := 0      // written
-
-	if l+f.bufferIdx < f.size {
-		// write the data into the buffer and return
-		n := copy(f.buffer[f.bufferIdx:], b)
+	w := 0 // written
+	for len(b) > 0 {
+		f.chunk()
+		n := copy(f.data[span+f.bufferIdx:span+f.size], b)
 		f.bufferIdx += n
-		return n, nil
-	}
+		b = b[n:]
+		w += n
 
-	// if we are here it means we have to do at least one write
-	d := make([]byte, f.size+span)
-	var sp int // span of current write
+		if f.bufferIdx == f.size {
+			if err := f.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return w, nil
+}
 
-	//copy from existing buffer to this one
-	sp = copy(d[span:], f.buffer[:f.bufferIdx])
+// ReadFrom reads the data from the reader until EOF directly into the chunks
+// passed to the subsequent writers, without copying it from an intermediate
+// buffer. It returns the number of bytes read.
+func (f *chunkFeeder) ReadFrom(r io.Reader) (int64, error) {
+	var read int64
+	for {
+		f.chunk()
+		n, err := r.Read(f.data[span+f.bufferIdx : span+f.size])
+		f.bufferIdx += n
+		read += int64(n)
 
-	// don't account what was already in the buffer when returning
-	// number of written bytes
-	if sp > 0 {
-		w -= sp
+		if f.bufferIdx == f.size {
+			if err := f.flush(); err != nil {
+				return read, err
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return read, nil
+			}
+			return read, err
+		}
 	}
+}
 
-	var n int
-	for i := 0; i < len(b); {
-		// if we can't fill a whole write, buffer the rest and return
-		if sp+len(b[i:]) < f.size {
-			n = copy(f.buffer, b[i:])
-			f.bufferIdx = n
-			return w + n, nil
-		}
+// chunk makes sure there is a buffer for the current chunk,
+// pooled if the chunks fit the pooled buffers.
+func (f *chunkFeeder) chunk() {
+	if f.data != nil {
+		return
+	}
+	if f.size+span <= swarm.ChunkWithSpanSize {
+		f.buffer = pipeline.NewBuffer()
+		f.data = f.buffer.Bytes()[:f.size+span]
+		return
+	}
+	f.data = make([]byte, f.size+span)
+}
 
-		// fill stuff up from the incoming write
-		n = copy(d[span+f.bufferIdx:], b[i:])
-		i += n
-		sp += n
+// flush writes the current chunk to the subsequent writers
+// and releases its buffer.
+func (f *chunkFeeder) flush() error {
+	binary.LittleEndian.PutUint64(f.data[:span], uint64(f.bufferIdx))
+	d := f.data[:span+f.bufferIdx]
+	args := &pipeline.PipeWriteArgs{Data: d, Span: d[:span], Buffer: f.buffer}
+	err := f.next.ChainWrite(args)
 
-		binary.LittleEndian.PutUint64(d[:span], uint64(sp))
-		args := &pipeline.PipeWriteArgs{Data: d[:span+sp], Span: d[:span]}
-		err := f.next.ChainWrite(args)
-		if err != nil {
-			return 0, err
-		}
-		f.bufferIdx = 0
-		w += sp
-		sp = 0
+	if f.buffer != nil {
+		f.buffer.Release()
 	}
-	f.wrote += int64(w)
-	return w, nil
+	f.buffer = nil
+	f.data = nil
+	f.wrote += int64(f.bufferIdx)
+	f.bufferIdx = 0
+	return err
 }
 
 // Sum flushes any pending data to subsequent writers and returns
@@ -94,15 +119,9 @@ func (f *chunkFeeder) Write(b []byte) (int, error) {
 func (f *chunkFeeder) Sum() ([]byte, error) {
 	// flush existing data in the buffer
 	if f.bufferIdx > 0 {
-		d := make([]byte, f.bufferIdx+span)
-		copy(d[span:], f.buffer[:f.bufferIdx])
-		binary.LittleEndian.PutUint64(d[:span], uint64(f.bufferIdx))
-		args := &pipeline.PipeWriteArgs{Data: d, Span: d[:span]}
-		err := f.next.ChainWrite(args)
-		if err != nil {
+		if err := f.flush(); err != nil {
 			return nil, err
 		}
-		f.wrote += int64(len(d))
 	}
 
 	if f.wrote == 0 {
@@ -8,10 +8,14 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"io"
+	"reflect"
 	"testing"
+	"testing/iotest"
 
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline/feeder"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
 // TestFeeder tests that partial writes work correctly.
@@ -221,6 +225,62 @@ func TestFeederFlush(t *testing.T) {
 	}
 }
 
+// TestFeederReadFrom tests that the data read from the reader
+// is written in the same chunks as the data written.
+func TestFeederReadFrom(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 3*swarm.ChunkSize+100)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	collect := func(feed func(pipeline.Interface) error) [][]byte {
+		t.Helper()
+
+		w := &collectingWriter{}
+		cf := feeder.NewChunkFeederWriter(swarm.ChunkSize, w)
+		if err := feed(cf); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cf.Sum(); err != nil {
+			t.Fatal(err)
+		}
+		return w.chunks
+	}
+
+	want := collect(func(cf pipeline.Interface) error {
+		_, err := cf.Write(data)
+		return err
+	})
+	got := collect(func(cf pipeline.Interface) error {
+		// the reader returns less than a chunk at a time
+		n, err := cf.(io.ReaderFrom).ReadFrom(iotest.HalfReader(bytes.NewReader(data)))
+		if n != int64(len(data)) {
+			t.Fatalf("read %d bytes, want %d", n, len(data))
+		}
+		return err
+	})
+
+	if len(want) != 4 || !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %d chunks, want %d equal chunks", len(got), len(want))
+	}
+}
+
+// collectingWriter keeps the data of all the writes.
+type collectingWriter struct {
+	chunks [][]byte
+}
+
+func (w *collectingWriter) ChainWrite(p *pipeline.PipeWriteArgs) error {
+	w.chunks = append(w.chunks, bytes.Clone(p.Data))
+	return nil
+}
+
+func (w *collectingWriter) Sum() ([]byte, error) {
+	return nil, nil
+}
+
 // countingResultWriter counts how many writes were done to it
 // and passes the results to the caller using the pointer provided
 // in the constructor.
@@ -236,6 +296,8 @@ func newMockResultWriter(b *pipeline.PipeWriteArgs) *countingResultWriter {
 func (w *countingResultWriter) ChainWrite(p *pipeline.PipeWriteArgs) error {
 	w.count++
 	*w.target = *p
+	// the pooled buffer of the data is reused once the write returns
+	w.target.Data = bytes.Clone(p.Data)
 	return nil
 }
 
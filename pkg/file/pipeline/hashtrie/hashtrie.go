@@ -5,6 +5,7 @@
 package hashtrie
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"errors"
@@ -73,7 +74,12 @@ func (h *hashTrieWriter) ChainWrite(p *pipeline.PipeWriteArgs) error {
 	if h.rParams.Level() == redundancy.NONE {
 		return h.writeToIntermediateLevel(1, false, p.Span, p.Ref, p.Key)
 	} else {
-		return h.writeToDataLevel(p.Span, p.Ref, p.Key, p.Data)
+		data := p.Data
+		if p.Buffer != nil {
+			// the data chunks are held until their parities are encoded
+			data = bytes.Clone(data)
+		}
+		return h.writeToDataLevel(p.Span, p.Ref, p.Key, data)
 	}
 }
 
@@ -32,6 +32,9 @@ type PipeWriteArgs struct {
 	Key  []byte // encryption key
 	Span []byte // always unencrypted span uint64
 	Data []byte // data includes the span too, but it may be encrypted when the pipeline is encrypted
+	// Buffer is the pooled buffer holding the Data, nil if the Data is not pooled.
+	// It is reused once the write returns, unless retained.
+	Buffer *Buffer
 }
 
 type PipelineFunc func() ChainWriter
@@ -4,4 +4,7 @@
 
 package store
 
-var ErrInvalidData = errInvalidData
+var (
+	ErrInvalidData = errInvalidData
+	BatchSize      = batchSize
+)
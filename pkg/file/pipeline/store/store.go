@@ -5,20 +5,102 @@
 package store
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"sync"
 
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
+// batchSize is the number of the chunks buffered by the BatchPutter
+// before they are put at once.
+const batchSize = 64
+
 var errInvalidData = errors.New("store: invalid data")
 
+// BatchPutter buffers the chunks put to it and puts them in batches of
+// bounded size with storage.PutMany, so that a BatchPutter of the wrapped
+// putter stores them at once. The chunks are put in the order they were
+// buffered. The chunks buffered when the last batch is not full are put on
+// Flush. The pooled buffers of the chunks put with PutBuffer are retained
+// until their batch is put.
+type BatchPutter struct {
+	ctx     context.Context
+	l       storage.Putter
+	mu      sync.Mutex
+	chunks  []swarm.Chunk
+	buffers []*pipeline.Buffer
+}
+
+// NewBatchPutter returns a BatchPutter which puts the chunks to the putter.
+func NewBatchPutter(ctx context.Context, l storage.Putter) *BatchPutter {
+	return &BatchPutter{ctx: ctx, l: l}
+}
+
+// Put buffers the chunk and puts the batch when it is full.
+func (b *BatchPutter) Put(_ context.Context, ch swarm.Chunk) error {
+	return b.PutBuffer(ch, nil)
+}
+
+// PutBuffer buffers the chunk with the data in the pooled buffer, which is
+// retained until the chunk is put, and puts the batch when it is full.
+// The buffer may be nil if the data is not pooled.
+func (b *BatchPutter) PutBuffer(ch swarm.Chunk, buf *pipeline.Buffer) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if buf != nil {
+		buf.Retain()
+		b.buffers = append(b.buffers, buf)
+	}
+	b.chunks = append(b.chunks, ch)
+	if len(b.chunks) < batchSize {
+		return nil
+	}
+	return b.flush()
+}
+
+// ZeroCopy reports whether the wrapped putter is done with the chunk data
+// when PutMany returns. The data of the chunks put with PutBuffer is then
+// not copied, as their buffers are released only after the batch is put.
+func (b *BatchPutter) ZeroCopy() bool {
+	return storage.IsZeroCopy(b.l)
+}
+
+// Flush puts the buffered chunks.
+func (b *BatchPutter) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.flush()
+}
+
+func (b *BatchPutter) flush() error {
+	if len(b.chunks) == 0 {
+		return nil
+	}
+	errs, err := storage.PutMany(b.ctx, b.l, b.chunks)
+	clear(b.chunks)
+	b.chunks = b.chunks[:0]
+	for _, buf := range b.buffers {
+		buf.Release()
+	}
+	clear(b.buffers)
+	b.buffers = b.buffers[:0]
+	if err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
 type storeWriter struct {
-	l    storage.Putter
-	ctx  context.Context
-	next pipeline.ChainWriter
+	l     storage.Putter
+	ctx   context.Context
+	next  pipeline.ChainWriter
+	batch *BatchPutter
 }
 
 // NewStoreWriter returns a storeWriter. It just writes the given data
@@ -27,11 +109,32 @@ func NewStoreWriter(ctx context.Context, l storage.Putter, next pipeline.ChainWr
 	return &storeWriter{ctx: ctx, l: l, next: next}
 }
 
+// NewBatchStoreWriter returns a storeWriter which writes the given data to
+// the BatchPutter and flushes it after the Sum of the next writer, so that
+// the chunks put to the BatchPutter by the next writers on Sum are also
+// flushed.
+func NewBatchStoreWriter(ctx context.Context, b *BatchPutter, next pipeline.ChainWriter) pipeline.ChainWriter {
+	return &storeWriter{ctx: ctx, l: b, next: next, batch: b}
+}
+
 func (w *storeWriter) ChainWrite(p *pipeline.PipeWriteArgs) error {
 	if p.Ref == nil || p.Data == nil {
 		return errInvalidData
 	}
-	err := w.l.Put(w.ctx, swarm.NewChunk(swarm.NewAddress(p.Ref), p.Data))
+	data, buf := p.Data, p.Buffer
+	if buf != nil && !storage.IsZeroCopy(w.l) {
+		// the putter may hold on to the chunk after the buffer is reused
+		data, buf = bytes.Clone(data), nil
+	}
+	// the reference is the bmt hash of the data computed by the pipeline
+	ch := swarm.MarkValid(swarm.NewChunk(swarm.NewAddress(p.Ref), data), swarm.ChunkTypeContentAddressed)
+	var err error
+	if b, ok := w.l.(*BatchPutter); ok {
+		// the batched chunk is held until its batch is put
+		err = b.PutBuffer(ch, buf)
+	} else {
+		err = w.l.Put(w.ctx, ch)
+	}
 	if err != nil {
 		return err
 	}
@@ -43,5 +146,12 @@ func (w *storeWriter) ChainWrite(p *pipeline.PipeWriteArgs) error {
 }
 
 func (w *storeWriter) Sum() ([]byte, error) {
-	return w.next.Sum()
+	sum, err := w.next.Sum()
+	if err != nil || w.batch == nil {
+		return sum, err
+	}
+	if err := w.batch.Flush(); err != nil {
+		return nil, err
+	}
+	return sum, nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
 	mock "github.com/ethersphere/bee/v2/pkg/file/pipeline/mock"
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline/store"
+	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
@@ -52,7 +53,6 @@ func TestStoreWriter(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-
 		d, err := mockStore.Get(ctx, swarm.NewAddress(tc.ref))
 		if err != nil {
 			t.Fatal(err)
@@ -81,3 +81,241 @@ func TestSum(t *testing.T) {
 		t.Fatalf("wanted 1 Sum call but got %d", calls)
 	}
 }
+
+// TestStoreWriterBatch tests that the chunks of the batch store writer and
+// of the writers sharing its BatchPutter are put in order with PutMany in
+// batches of bounded size and the remaining ones after the Sum of the next
+// writer.
+func TestStoreWriterBatch(t *testing.T) {
+	t.Parallel()
+
+	session := &countingSession{Putter: inmemchunkstore.New()}
+	bp := store.NewBatchPutter(context.Background(), session)
+	short := store.NewStoreWriter(context.Background(), bp, nil)
+	next := &sumWriter{ChainWriter: mock.NewChainWriter(), sum: func() ([]byte, error) {
+		// the next writer puts the chunk of the root on Sum
+		args := pipeline.PipeWriteArgs{Ref: swarm.RandAddress(t).Bytes(), Data: []byte{0, 0, 0, 0, 0, 0, 0, 0, 0xff}}
+		return nil, short.ChainWrite(&args)
+	}}
+	writer := store.NewBatchStoreWriter(context.Background(), bp, next)
+
+	count := 2*store.BatchSize + 1
+	for i := range count {
+		buf := pipeline.NewBuffer()
+		data := buf.Bytes()[:swarm.SpanSize+1]
+		data[swarm.SpanSize] = byte(i)
+		args := pipeline.PipeWriteArgs{Ref: swarm.RandAddress(t).Bytes(), Data: data, Buffer: buf}
+		if err := writer.ChainWrite(&args); err != nil {
+			t.Fatal(err)
+		}
+		buf.Release()
+	}
+	if got, want := session.putManyCalls, 2; got != want {
+		t.Fatalf("got %d PutMany calls before Sum, want %d", got, want)
+	}
+
+	if _, err := writer.Sum(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := session.putManyCalls, 3; got != want {
+		t.Fatalf("got %d PutMany calls, want %d", got, want)
+	}
+	if got := session.putCalls; got != 0 {
+		t.Fatalf("got %d Put calls, want 0", got)
+	}
+	if got, want := len(session.chunks), count+1; got != want {
+		t.Fatalf("got %d chunks, want %d", got, want)
+	}
+	for i, ch := range session.chunks[:count] {
+		if got := ch.Data()[swarm.SpanSize]; got != byte(i) {
+			t.Fatalf("chunk %d: got data %d, want %d", i, got, byte(i))
+		}
+	}
+	if got := session.chunks[count].Data()[swarm.SpanSize]; got != 0xff {
+		t.Fatalf("got last chunk data %d, want the root chunk", got)
+	}
+	if got := swarm.ValidType(session.chunks[0]); got != swarm.ChunkTypeContentAddressed {
+		t.Fatalf("got valid type %s, want %s", got, swarm.ChunkTypeContentAddressed)
+	}
+
+	t.Run("rejected chunk", func(t *testing.T) {
+		t.Parallel()
+
+		session := &countingSession{Putter: inmemchunkstore.New(), reject: true}
+		bp := store.NewBatchPutter(context.Background(), session)
+		writer := store.NewBatchStoreWriter(context.Background(), bp, mock.NewChainWriter())
+		args := pipeline.PipeWriteArgs{Ref: swarm.RandAddress(t).Bytes(), Data: make([]byte, swarm.SpanSize+1)}
+		if err := writer.ChainWrite(&args); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Sum(); !errors.Is(err, storage.ErrOverwriteNewerChunk) {
+			t.Fatalf("got error %v, want %v", err, storage.ErrOverwriteNewerChunk)
+		}
+	})
+}
+
+// TestStoreWriterPooledBuffer tests that the data of the pooled buffers is
+// only passed to the putters which are done with it when Put returns and
+// that the chunks are marked as validated.
+func TestStoreWriterPooledBuffer(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		zeroCopy bool
+	}{
+		{name: "retaining putter"},
+		{name: "zero copy putter", zeroCopy: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &recordingPutter{zeroCopy: tc.zeroCopy}
+			writer := store.NewStoreWriter(context.Background(), p, nil)
+
+			buf := pipeline.NewBuffer()
+			defer buf.Release()
+			data := buf.Bytes()[:swarm.SpanSize+3]
+			args := pipeline.PipeWriteArgs{Ref: []byte{0xaa}, Data: data, Buffer: buf}
+			if err := writer.ChainWrite(&args); err != nil {
+				t.Fatal(err)
+			}
+
+			if shared := &p.data[0] == &data[0]; shared != tc.zeroCopy {
+				t.Fatalf("got shared buffer %t, want %t", shared, tc.zeroCopy)
+			}
+			if !bytes.Equal(p.data, data) {
+				t.Fatal("data mismatch")
+			}
+			if got := swarm.ValidType(p.chunk); got != swarm.ChunkTypeContentAddressed {
+				t.Fatalf("got valid type %s, want %s", got, swarm.ChunkTypeContentAddressed)
+			}
+		})
+	}
+}
+
+// TestStoreWriterBatchZeroCopy tests that the data of the pooled buffers is
+// not copied for the batched chunks of a zero copy session and that the
+// buffers are held until the chunks are put.
+func TestStoreWriterBatchZeroCopy(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		zeroCopy bool
+	}{
+		{name: "retaining session"},
+		{name: "zero copy session", zeroCopy: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			session := &recordingSession{zeroCopy: tc.zeroCopy}
+			bp := store.NewBatchPutter(context.Background(), session)
+			writer := store.NewBatchStoreWriter(context.Background(), bp, mock.NewChainWriter())
+
+			count := store.BatchSize + 1
+			bufs := make([]*pipeline.Buffer, count)
+			for i := range count {
+				bufs[i] = pipeline.NewBuffer()
+				data := bufs[i].Bytes()[:swarm.SpanSize+1]
+				data[swarm.SpanSize] = byte(i)
+				args := pipeline.PipeWriteArgs{Ref: swarm.RandAddress(t).Bytes(), Data: data, Buffer: bufs[i]}
+				if err := writer.ChainWrite(&args); err != nil {
+					t.Fatal(err)
+				}
+				// released by the feeder once written
+				bufs[i].Release()
+			}
+			if _, err := writer.Sum(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := len(session.data); got != count {
+				t.Fatalf("got %d chunks, want %d", got, count)
+			}
+			for i, data := range session.data {
+				if got := data[swarm.SpanSize]; got != byte(i) {
+					t.Fatalf("chunk %d: got data %d, want %d", i, got, byte(i))
+				}
+				if shared := session.shared[i] == &bufs[i].Bytes()[0]; shared != tc.zeroCopy {
+					t.Fatalf("chunk %d: got shared buffer %t, want %t", i, shared, tc.zeroCopy)
+				}
+			}
+		})
+	}
+}
+
+// recordingSession records the data of the chunks as it is when they are put.
+type recordingSession struct {
+	zeroCopy bool
+	shared   []*byte
+	data     [][]byte
+}
+
+func (s *recordingSession) Put(ctx context.Context, ch swarm.Chunk) error {
+	_, err := s.PutMany(ctx, []swarm.Chunk{ch})
+	return err
+}
+
+func (s *recordingSession) PutMany(_ context.Context, chunks []swarm.Chunk) ([]error, error) {
+	for _, ch := range chunks {
+		s.shared = append(s.shared, &ch.Data()[0])
+		s.data = append(s.data, bytes.Clone(ch.Data()))
+	}
+	return make([]error, len(chunks)), nil
+}
+
+func (s *recordingSession) ZeroCopy() bool { return s.zeroCopy }
+
+type recordingPutter struct {
+	zeroCopy bool
+	chunk    swarm.Chunk
+	data     []byte
+}
+
+func (p *recordingPutter) Put(_ context.Context, ch swarm.Chunk) error {
+	p.chunk = ch
+	p.data = ch.Data()
+	return nil
+}
+
+func (p *recordingPutter) ZeroCopy() bool { return p.zeroCopy }
+
+// sumWriter calls the sum function on Sum.
+type sumWriter struct {
+	pipeline.ChainWriter
+	sum func() ([]byte, error)
+}
+
+func (w *sumWriter) Sum() ([]byte, error) { return w.sum() }
+
+// countingSession counts the calls to the upload session.
+type countingSession struct {
+	storage.Putter
+	reject       bool
+	putCalls     int
+	putManyCalls int
+	chunks       []swarm.Chunk
+}
+
+func (s *countingSession) Put(ctx context.Context, ch swarm.Chunk) error {
+	s.putCalls++
+	return s.Putter.Put(ctx, ch)
+}
+
+func (s *countingSession) PutMany(ctx context.Context, chunks []swarm.Chunk) ([]error, error) {
+	s.putManyCalls++
+	errs := make([]error, len(chunks))
+	for i, ch := range chunks {
+		if s.reject {
+			errs[i] = storage.ErrOverwriteNewerChunk
+			continue
+		}
+		if err := s.Putter.Put(ctx, ch); err != nil {
+			return errs, err
+		}
+		s.chunks = append(s.chunks, ch)
+	}
+	return errs, nil
+}
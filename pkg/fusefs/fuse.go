@@ -0,0 +1,428 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package fusefs
+
+import (
+	"context"
+	"errors"
+	"io"
+	iofs "io/fs"
+	"mime"
+	"os"
+	"path"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+)
+
+// cacheTimeout is how long the kernel caches the entries and the attributes,
+// the feeds might be updated by the other mounts.
+const cacheTimeout = time.Second
+
+func mountFS(dir string, fsys *manifestfs.FS, readOnly bool, logger log.Logger) (unmounter, error) {
+	timeout := cacheTimeout
+	options := []string{}
+	if readOnly {
+		options = append(options, "ro")
+	}
+	root := &node{fsys: fsys, logger: logger}
+	return fs.Mount(dir, root, &fs.Options{
+		MountOptions: fuse.MountOptions{
+			FsName:      "swarm",
+			Name:        "bee",
+			Options:     options,
+			DirectMount: true,
+		},
+		EntryTimeout: &timeout,
+		AttrTimeout:  &timeout,
+	})
+}
+
+// errno returns the error number of the error of the file system.
+func errno(err error) syscall.Errno {
+	switch {
+	case err == nil:
+		return fs.OK
+	case errors.Is(err, iofs.ErrNotExist):
+		return syscall.ENOENT
+	case errors.Is(err, iofs.ErrExist):
+		return syscall.EEXIST
+	case errors.Is(err, manifestfs.ErrReadOnly):
+		return syscall.EROFS
+	case errors.Is(err, manifestfs.ErrIsDir):
+		return syscall.EISDIR
+	case errors.Is(err, iofs.ErrInvalid):
+		return syscall.EINVAL
+	case errors.Is(err, iofs.ErrPermission):
+		return syscall.EPERM
+	}
+	return syscall.EIO
+}
+
+// node is the file or the directory, its path is resolved from the tree
+// of the inodes, so the renames are reflected.
+type node struct {
+	fs.Inode
+	fsys   *manifestfs.FS
+	logger log.Logger
+}
+
+var (
+	_ fs.NodeLookuper  = (*node)(nil)
+	_ fs.NodeReaddirer = (*node)(nil)
+	_ fs.NodeGetattrer = (*node)(nil)
+	_ fs.NodeSetattrer = (*node)(nil)
+	_ fs.NodeOpener    = (*node)(nil)
+	_ fs.NodeCreater   = (*node)(nil)
+	_ fs.NodeMkdirer   = (*node)(nil)
+	_ fs.NodeUnlinker  = (*node)(nil)
+	_ fs.NodeRmdirer   = (*node)(nil)
+	_ fs.NodeRenamer   = (*node)(nil)
+)
+
+func (n *node) path() string {
+	return n.Path(nil)
+}
+
+func (n *node) childPath(name string) string {
+	return path.Join(n.path(), name)
+}
+
+// mode returns the file mode of the information.
+func (n *node) mode(fi iofs.FileInfo) uint32 {
+	perm := uint32(0644)
+	if fi.IsDir() {
+		perm = 0755
+	}
+	if !n.fsys.Writable() {
+		perm &^= 0222
+	}
+	if fi.IsDir() {
+		return syscall.S_IFDIR | perm
+	}
+	return syscall.S_IFREG | perm
+}
+
+func (n *node) fillAttr(fi iofs.FileInfo, out *fuse.Attr) {
+	out.Mode = n.mode(fi)
+	out.Size = uint64(fi.Size())
+	out.Blocks = (out.Size + 511) / 512
+	out.Nlink = 1
+	if t := fi.ModTime(); !t.IsZero() {
+		out.SetTimes(&t, &t, &t)
+	}
+}
+
+// child returns the inode of the child, the existing one is reused.
+func (n *node) child(ctx context.Context, name string, mode uint32) *fs.Inode {
+	if ch := n.GetChild(name); ch != nil && ch.Mode() == mode&syscall.S_IFMT {
+		return ch
+	}
+	return n.NewInode(ctx, &node{fsys: n.fsys, logger: n.logger}, fs.StableAttr{Mode: mode & syscall.S_IFMT})
+}
+
+func (n *node) Lookup(ctx context.Context, name string, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	fi, err := n.fsys.Stat(ctx, n.childPath(name))
+	if err != nil {
+		return nil, errno(err)
+	}
+	n.fillAttr(fi, &out.Attr)
+	return n.child(ctx, name, out.Mode), fs.OK
+}
+
+func (n *node) Readdir(ctx context.Context) (fs.DirStream, syscall.Errno) {
+	infos, err := n.fsys.ReadDir(ctx, n.path())
+	if err != nil {
+		return nil, errno(err)
+	}
+	entries := make([]fuse.DirEntry, 0, len(infos))
+	for _, fi := range infos {
+		entries = append(entries, fuse.DirEntry{Name: fi.Name(), Mode: n.mode(fi)})
+	}
+	return fs.NewListDirStream(entries), fs.OK
+}
+
+func (n *node) Getattr(ctx context.Context, fh fs.FileHandle, out *fuse.AttrOut) syscall.Errno {
+	if w, ok := fh.(*writeHandle); ok {
+		return w.Getattr(ctx, out)
+	}
+	fi, err := n.fsys.Stat(ctx, n.path())
+	if err != nil {
+		return errno(err)
+	}
+	n.fillAttr(fi, &out.Attr)
+	return fs.OK
+}
+
+// Setattr only supports the truncation, the other attributes are not kept.
+func (n *node) Setattr(ctx context.Context, fh fs.FileHandle, in *fuse.SetAttrIn, out *fuse.AttrOut) syscall.Errno {
+	size, ok := in.GetSize()
+	if !ok {
+		return n.Getattr(ctx, fh, out)
+	}
+	if w, ok := fh.(*writeHandle); ok {
+		if errNo := w.truncate(int64(size)); errNo != fs.OK {
+			return errNo
+		}
+		return w.Getattr(ctx, out)
+	}
+
+	// the file truncated by the path is stored right away
+	w, errNo := n.openWriter(ctx, false)
+	if errNo != fs.OK {
+		return errNo
+	}
+	errNo = w.truncate(int64(size))
+	if errNo == fs.OK {
+		errNo = w.Flush(ctx)
+	}
+	w.Release(ctx)
+	if errNo != fs.OK {
+		return errNo
+	}
+	return n.Getattr(ctx, nil, out)
+}
+
+func (n *node) Open(ctx context.Context, flags uint32) (fs.FileHandle, uint32, syscall.Errno) {
+	if flags&(syscall.O_WRONLY|syscall.O_RDWR) != 0 {
+		w, errNo := n.openWriter(ctx, flags&syscall.O_TRUNC != 0)
+		return w, 0, errNo
+	}
+	r, fi, err := n.fsys.Open(ctx, n.path())
+	if err != nil {
+		return nil, 0, errno(err)
+	}
+	return &readHandle{r: r, size: fi.Size()}, 0, fs.OK
+}
+
+// openWriter opens the file for writing, the content is copied unless
+// the file is truncated.
+func (n *node) openWriter(ctx context.Context, truncate bool) (*writeHandle, syscall.Errno) {
+	if !n.fsys.Writable() {
+		return nil, syscall.EROFS
+	}
+	w, err := newWriteHandle(n)
+	if err != nil {
+		return nil, errno(err)
+	}
+	if truncate {
+		w.dirty = true
+		return w, fs.OK
+	}
+	r, _, err := n.fsys.Open(ctx, n.path())
+	if err == nil {
+		_, err = io.Copy(w.tmp, r)
+	}
+	if err != nil {
+		w.Release(ctx)
+		return nil, errno(err)
+	}
+	return w, fs.OK
+}
+
+func (n *node) Create(ctx context.Context, name string, _, _ uint32, out *fuse.EntryOut) (*fs.Inode, fs.FileHandle, uint32, syscall.Errno) {
+	if !n.fsys.Writable() {
+		return nil, nil, 0, syscall.EROFS
+	}
+	ch := n.NewInode(ctx, &node{fsys: n.fsys, logger: n.logger}, fs.StableAttr{Mode: syscall.S_IFREG})
+	w, err := newWriteHandle(ch.Operations().(*node))
+	if err != nil {
+		return nil, nil, 0, errno(err)
+	}
+	// the empty file is stored even when nothing is written
+	w.dirty = true
+	out.Mode = syscall.S_IFREG | 0644
+	return ch, w, 0, fs.OK
+}
+
+func (n *node) Mkdir(ctx context.Context, name string, _ uint32, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	p := n.childPath(name)
+	if err := n.fsys.Mkdir(ctx, p); err != nil {
+		return nil, errno(err)
+	}
+	fi, err := n.fsys.Stat(ctx, p)
+	if err != nil {
+		return nil, errno(err)
+	}
+	n.fillAttr(fi, &out.Attr)
+	return n.child(ctx, name, out.Mode), fs.OK
+}
+
+func (n *node) Unlink(ctx context.Context, name string) syscall.Errno {
+	p := n.childPath(name)
+	fi, err := n.fsys.Stat(ctx, p)
+	if err != nil {
+		return errno(err)
+	}
+	if fi.IsDir() {
+		return syscall.EISDIR
+	}
+	return errno(n.fsys.RemoveAll(ctx, p))
+}
+
+func (n *node) Rmdir(ctx context.Context, name string) syscall.Errno {
+	p := n.childPath(name)
+	infos, err := n.fsys.ReadDir(ctx, p)
+	if err != nil {
+		if errors.Is(err, iofs.ErrInvalid) {
+			return syscall.ENOTDIR
+		}
+		return errno(err)
+	}
+	if len(infos) > 0 {
+		return syscall.ENOTEMPTY
+	}
+	return errno(n.fsys.RemoveAll(ctx, p))
+}
+
+func (n *node) Rename(ctx context.Context, name string, newParent fs.InodeEmbedder, newName string, flags uint32) syscall.Errno {
+	if flags != 0 {
+		return syscall.ENOTSUP
+	}
+	newPath := path.Join(newParent.EmbeddedInode().Path(nil), newName)
+	return errno(n.fsys.Rename(ctx, n.childPath(name), newPath))
+}
+
+// readHandle reads the file from the swarm.
+type readHandle struct {
+	mu   sync.Mutex
+	r    manifestfs.File
+	size int64
+}
+
+var _ fs.FileReader = (*readHandle)(nil)
+
+func (h *readHandle) Read(_ context.Context, dest []byte, off int64) (fuse.ReadResult, syscall.Errno) {
+	if off >= h.size {
+		return fuse.ReadResultData(nil), fs.OK
+	}
+	if rest := h.size - off; int64(len(dest)) > rest {
+		dest = dest[:rest]
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n, err := h.r.ReadAt(dest, off)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, syscall.EIO
+	}
+	return fuse.ReadResultData(dest[:n]), fs.OK
+}
+
+// writeHandle keeps the written file in the temporary file, which is
+// uploaded when the file is flushed.
+type writeHandle struct {
+	node *node
+
+	mu    sync.Mutex
+	tmp   *os.File
+	dirty bool
+}
+
+var (
+	_ fs.FileReader    = (*writeHandle)(nil)
+	_ fs.FileWriter    = (*writeHandle)(nil)
+	_ fs.FileGetattrer = (*writeHandle)(nil)
+	_ fs.FileFlusher   = (*writeHandle)(nil)
+	_ fs.FileFsyncer   = (*writeHandle)(nil)
+	_ fs.FileReleaser  = (*writeHandle)(nil)
+)
+
+func newWriteHandle(n *node) (*writeHandle, error) {
+	tmp, err := os.CreateTemp("", "bee-fuse-")
+	if err != nil {
+		return nil, err
+	}
+	return &writeHandle{node: n, tmp: tmp}, nil
+}
+
+func (h *writeHandle) Read(_ context.Context, dest []byte, off int64) (fuse.ReadResult, syscall.Errno) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n, err := h.tmp.ReadAt(dest, off)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, syscall.EIO
+	}
+	return fuse.ReadResultData(dest[:n]), fs.OK
+}
+
+func (h *writeHandle) Write(_ context.Context, data []byte, off int64) (uint32, syscall.Errno) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n, err := h.tmp.WriteAt(data, off)
+	h.dirty = true
+	if err != nil {
+		return uint32(n), syscall.EIO
+	}
+	return uint32(n), fs.OK
+}
+
+func (h *writeHandle) truncate(size int64) syscall.Errno {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := h.tmp.Truncate(size); err != nil {
+		return syscall.EIO
+	}
+	h.dirty = true
+	return fs.OK
+}
+
+func (h *writeHandle) Getattr(_ context.Context, out *fuse.AttrOut) syscall.Errno {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fi, err := h.tmp.Stat()
+	if err != nil {
+		return syscall.EIO
+	}
+	h.node.fillAttr(fi, &out.Attr)
+	return fs.OK
+}
+
+// Flush uploads the written file, which replaces the one in the manifest.
+func (h *writeHandle) Flush(ctx context.Context) syscall.Errno {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.dirty {
+		return fs.OK
+	}
+	fi, err := h.tmp.Stat()
+	if err != nil {
+		return syscall.EIO
+	}
+	name := h.node.path()
+	err = h.node.fsys.WriteFile(ctx, name, io.NewSectionReader(h.tmp, 0, fi.Size()), mime.TypeByExtension(path.Ext(name)))
+	if err != nil {
+		h.node.logger.Debug("write file failed", "path", name, "error", err)
+		return errno(err)
+	}
+	h.dirty = false
+	return fs.OK
+}
+
+func (h *writeHandle) Fsync(ctx context.Context, _ uint32) syscall.Errno {
+	return h.Flush(ctx)
+}
+
+func (h *writeHandle) Release(context.Context) syscall.Errno {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_ = h.tmp.Close()
+	_ = os.Remove(h.tmp.Name())
+	return fs.OK
+}
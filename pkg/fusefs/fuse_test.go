@@ -0,0 +1,126 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+
+package fusefs_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
+	"github.com/ethersphere/bee/v2/pkg/fusefs"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+)
+
+func TestMount(t *testing.T) {
+	t.Parallel()
+
+	st := mockstorer.New()
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := manifestfs.NewStore(manifestfs.Options{
+		Storer: st,
+		Feeds:  factory.New(st.Download(true)),
+		Signer: crypto.NewDefaultSigner(key),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the directories are removed after the file systems are unmounted
+	dir, roDir := t.TempDir(), t.TempDir()
+	m := fusefs.NewMounter(store, log.Noop)
+	t.Cleanup(func() {
+		if err := m.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	topic := make([]byte, 32)
+	info, err := m.MountFeed(dir, topic, func(ctx context.Context) (storer.PutterSession, error) {
+		return st.Upload(ctx, false, 0)
+	})
+	if err != nil {
+		t.Skipf("fuse not available: %v", err)
+	}
+	if !info.Writable || info.Path != dir {
+		t.Fatalf("got mount %+v", info)
+	}
+	if _, err := m.MountFeed(dir, topic, nil); !errors.Is(err, fusefs.ErrMounted) {
+		t.Fatalf("got error %v, want %v", err, fusefs.ErrMounted)
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join(dir, "docs", "a.txt")
+	if err := os.WriteFile(name, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(name, filepath.Join(dir, "docs", "b.txt")); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "docs", "b.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "hello" {
+		t.Fatalf("got file %q", b)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "docs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "b.txt" {
+		t.Fatalf("got entries %v", entries)
+	}
+	if err := os.Remove(filepath.Join(dir, "docs")); !errors.Is(err, syscall.ENOTEMPTY) {
+		t.Fatalf("got error %v, want %v", err, syscall.ENOTEMPTY)
+	}
+
+	// the written files are kept in the manifest of the feed
+	ref, err := store.Latest(context.Background(), topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.MountReference(roDir, ref); err != nil {
+		t.Fatal(err)
+	}
+	b, err = os.ReadFile(filepath.Join(roDir, "docs", "b.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "hello" {
+		t.Fatalf("got file %q", b)
+	}
+	if err := os.WriteFile(filepath.Join(roDir, "c.txt"), nil, 0644); err == nil {
+		t.Fatal("wrote to the read only mount")
+	}
+
+	var paths []string
+	for _, info := range m.Mounts() {
+		paths = append(paths, info.Path)
+	}
+	if got := len(paths); got != 2 {
+		t.Fatalf("got mounts %s", strings.Join(paths, ","))
+	}
+	if err := m.Unmount(roDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Unmount(roDir); !errors.Is(err, fusefs.ErrNotMounted) {
+		t.Fatalf("got error %v, want %v", err, fusefs.ErrNotMounted)
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux && !darwin
+
+package fusefs
+
+import (
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+)
+
+func mountFS(string, *manifestfs.FS, bool, log.Logger) (unmounter, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fusefs mounts the manifests as the FUSE file systems, so that the
+// tools expecting the file system can work with the content of the swarm.
+//
+// The manifest reference is mounted read only. The feed of the node is
+// mounted read-write when the changes are stamped, every written file
+// updates the feed to the new manifest.
+package fusefs
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "fusefs"
+
+var (
+	// ErrUnsupported is returned when FUSE is not supported on the platform.
+	ErrUnsupported = errors.New("fuse is not supported on this platform")
+	// ErrMounted is returned when the path is already mounted.
+	ErrMounted = errors.New("path already mounted")
+	// ErrNotMounted is returned when the path is not mounted.
+	ErrNotMounted = errors.New("path not mounted")
+)
+
+// Info describes the mount.
+type Info struct {
+	Path      string
+	Reference swarm.Address // zero for the feed mounts
+	Topic     []byte        // nil for the reference mounts
+	Writable  bool
+}
+
+// unmounter is the served file system.
+type unmounter interface {
+	Unmount() error
+}
+
+type mount struct {
+	info   Info
+	server unmounter
+}
+
+// Mounter keeps the mounted file systems.
+type Mounter struct {
+	store  *manifestfs.Store
+	logger log.Logger
+
+	mu     sync.Mutex
+	mounts map[string]*mount // by path
+}
+
+// NewMounter returns the mounter of the manifests of the store.
+func NewMounter(store *manifestfs.Store, logger log.Logger) *Mounter {
+	return &Mounter{
+		store:  store,
+		logger: logger.WithName(loggerName).Register(),
+		mounts: make(map[string]*mount),
+	}
+}
+
+// MountReference mounts the manifest of the reference read only.
+func (m *Mounter) MountReference(path string, ref swarm.Address) (Info, error) {
+	return m.mount(Info{Path: path, Reference: ref}, m.store.Reference(ref))
+}
+
+// MountFeed mounts the latest manifest of the feed of the topic owned by
+// the node. The file system is read-write when the putter is set.
+func (m *Mounter) MountFeed(path string, topic []byte, putter manifestfs.PutterFunc) (Info, error) {
+	fsys := m.store.Feed(topic)
+	if putter != nil {
+		fsys = fsys.WithPutter(putter)
+	}
+	return m.mount(Info{Path: path, Topic: topic}, fsys)
+}
+
+func (m *Mounter) mount(info Info, fsys *manifestfs.FS) (Info, error) {
+	path, err := filepath.Abs(info.Path)
+	if err != nil {
+		return Info{}, fmt.Errorf("mount path: %w", err)
+	}
+	info.Path = path
+	info.Writable = fsys.Writable()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.mounts[path]; ok {
+		return Info{}, ErrMounted
+	}
+	server, err := mountFS(path, fsys, !info.Writable, m.logger)
+	if err != nil {
+		return Info{}, err
+	}
+	m.mounts[path] = &mount{info: info, server: server}
+	m.logger.Info("mounted", "path", path, "writable", info.Writable)
+	return info, nil
+}
+
+// Unmount unmounts the file system of the path.
+func (m *Mounter) Unmount(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("mount path: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mnt, ok := m.mounts[path]
+	if !ok {
+		return ErrNotMounted
+	}
+	if err := mnt.server.Unmount(); err != nil {
+		return fmt.Errorf("unmount %s: %w", path, err)
+	}
+	delete(m.mounts, path)
+	m.logger.Info("unmounted", "path", path)
+	return nil
+}
+
+// Mounts returns the mounts sorted by path.
+func (m *Mounter) Mounts() []Info {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	infos := make([]Info, 0, len(m.mounts))
+	for _, mnt := range m.mounts {
+		infos = append(infos, mnt.info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Path < infos[j].Path })
+	return infos
+}
+
+// Close unmounts all the file systems.
+func (m *Mounter) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var errs error
+	for path, mnt := range m.mounts {
+		if err := mnt.server.Unmount(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("unmount %s: %w", path, err))
+			continue
+		}
+		delete(m.mounts, path)
+	}
+	return errs
+}
@@ -0,0 +1,39 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grpcapi
+
+import "fmt"
+
+// Codec marshals the gogo protobuf messages of the service. It has to be
+// forced on both the server and the Go clients of the service as the
+// messages do not implement the reflection based protobuf API.
+var Codec codec
+
+type message interface {
+	Marshal() ([]byte, error)
+	Unmarshal([]byte) error
+}
+
+type codec struct{}
+
+func (codec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(message)
+	if !ok {
+		return nil, fmt.Errorf("marshal unsupported type %T", v)
+	}
+	return m.Marshal()
+}
+
+func (codec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(message)
+	if !ok {
+		return fmt.Errorf("unmarshal unsupported type %T", v)
+	}
+	return m.Unmarshal(data)
+}
+
+func (codec) Name() string {
+	return "proto"
+}
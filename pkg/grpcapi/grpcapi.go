@@ -0,0 +1,218 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package grpcapi exposes the core operations of the node, the uploads,
+// the downloads, the pin management and the status, over gRPC alongside
+// the HTTP API.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethersphere/bee/v2/pkg/grpcapi/pb"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/readonly"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "grpcapi"
+
+// downloadBufferSize is the size of the data carried by a download message.
+const downloadBufferSize = 64 * 1024
+
+// UploadOptions are the options of the uploads.
+type UploadOptions struct {
+	Pin      bool
+	Encrypt  bool
+	Deferred bool
+}
+
+// Node is the node the operations are carried out by.
+type Node interface {
+	Upload(ctx context.Context, batchID []byte, r io.Reader, o UploadOptions) (swarm.Address, error)
+	Download(ctx context.Context, ref swarm.Address) (io.ReadSeeker, int64, error)
+	Pin(ctx context.Context, ref swarm.Address) error
+	Unpin(ctx context.Context, ref swarm.Address) error
+	Pins(ctx context.Context) ([]swarm.Address, error)
+}
+
+// Peerer returns the connected peers.
+type Peerer interface {
+	Peers() []p2p.Peer
+}
+
+// Service implements the gRPC API of the node.
+type Service struct {
+	node     Node
+	peerer   Peerer
+	overlay  swarm.Address
+	beeMode  string
+	readOnly *readonly.Mode
+	logger   log.Logger
+}
+
+// New returns the gRPC API service. The uploads and the pin changes are
+// rejected while the node is in the read-only mode.
+func New(node Node, peerer Peerer, overlay swarm.Address, beeMode string, readOnly *readonly.Mode, logger log.Logger) *Service {
+	return &Service{
+		node:     node,
+		peerer:   peerer,
+		overlay:  overlay,
+		beeMode:  beeMode,
+		readOnly: readOnly,
+		logger:   logger.WithName(loggerName).Register(),
+	}
+}
+
+// NewServer returns the gRPC server with the service registered.
+func NewServer(s *Service, opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(append([]grpc.ServerOption{grpc.ForceServerCodec(Codec)}, opts...)...)
+	pb.RegisterBeeServer(srv, s)
+	return srv
+}
+
+func (s *Service) Upload(stream pb.Bee_UploadServer) error {
+	if err := s.readOnly.Check(); err != nil {
+		return statusError("upload", err)
+	}
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	batchID := req.BatchID
+	o := UploadOptions{Pin: req.Pin, Encrypt: req.Encrypt, Deferred: req.Deferred}
+
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			if _, err := pw.Write(req.Data); err != nil {
+				return
+			}
+			var err error
+			req, err = stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	defer pr.Close()
+
+	ref, err := s.node.Upload(stream.Context(), batchID, pr, o)
+	if err != nil {
+		s.logger.Debug("upload failed", "error", err)
+		return statusError("upload", err)
+	}
+	return stream.SendAndClose(&pb.UploadResponse{Reference: ref.Bytes()})
+}
+
+func (s *Service) Download(req *pb.DownloadRequest, stream pb.Bee_DownloadServer) error {
+	ref := swarm.NewAddress(req.Reference)
+	r, size, err := s.node.Download(stream.Context(), ref)
+	if err != nil {
+		s.logger.Debug("download failed", "reference", ref, "error", err)
+		return statusError("download", err)
+	}
+
+	buf := make([]byte, downloadBufferSize)
+	msg := &pb.DownloadResponse{Length: size}
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			msg.Data = buf[:n]
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+			msg = &pb.DownloadResponse{}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			s.logger.Debug("download read failed", "reference", ref, "error", err)
+			return statusError("download", err)
+		}
+	}
+	if size == 0 {
+		// the length is sent even for the empty data
+		return stream.Send(msg)
+	}
+	return nil
+}
+
+func (s *Service) Pin(ctx context.Context, req *pb.PinRequest) (*pb.Empty, error) {
+	if err := s.readOnly.Check(); err != nil {
+		return nil, statusError("pin", err)
+	}
+	if err := s.node.Pin(ctx, swarm.NewAddress(req.Reference)); err != nil {
+		s.logger.Debug("pin failed", "error", err)
+		return nil, statusError("pin", err)
+	}
+	return &pb.Empty{}, nil
+}
+
+func (s *Service) Unpin(ctx context.Context, req *pb.PinRequest) (*pb.Empty, error) {
+	if err := s.readOnly.Check(); err != nil {
+		return nil, statusError("unpin", err)
+	}
+	if err := s.node.Unpin(ctx, swarm.NewAddress(req.Reference)); err != nil {
+		s.logger.Debug("unpin failed", "error", err)
+		return nil, statusError("unpin", err)
+	}
+	return &pb.Empty{}, nil
+}
+
+func (s *Service) Pins(ctx context.Context, _ *pb.Empty) (*pb.PinsResponse, error) {
+	pins, err := s.node.Pins(ctx)
+	if err != nil {
+		s.logger.Debug("list pins failed", "error", err)
+		return nil, statusError("list pins", err)
+	}
+	resp := &pb.PinsResponse{References: make([][]byte, 0, len(pins))}
+	for _, p := range pins {
+		resp.References = append(resp.References, p.Bytes())
+	}
+	return resp, nil
+}
+
+func (s *Service) Status(_ context.Context, _ *pb.Empty) (*pb.StatusResponse, error) {
+	return &pb.StatusResponse{
+		Overlay:        s.overlay.Bytes(),
+		BeeMode:        s.beeMode,
+		ConnectedPeers: uint64(len(s.peerer.Peers())),
+	}, nil
+}
+
+// statusError maps the error of the operation to the gRPC status error.
+func statusError(op string, err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, storage.ErrNotFound), errors.Is(err, postage.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, postage.ErrNotUsable):
+		code = codes.FailedPrecondition
+	case errors.Is(err, postage.ErrBucketFull):
+		code = codes.ResourceExhausted
+	case errors.Is(err, readonly.ErrReadOnly):
+		code = codes.Unavailable
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, fmt.Sprintf("%s: %v", op, err))
+}
@@ -0,0 +1,268 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grpcapi_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/grpcapi"
+	"github.com/ethersphere/bee/v2/pkg/grpcapi/pb"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/readonly"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type mockNode struct {
+	mu   sync.Mutex
+	data map[string][]byte
+	opts grpcapi.UploadOptions
+	pins []swarm.Address
+}
+
+func (m *mockNode) Upload(_ context.Context, batchID []byte, r io.Reader, o grpcapi.UploadOptions) (swarm.Address, error) {
+	if len(batchID) == 0 {
+		return swarm.ZeroAddress, postage.ErrNotUsable
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return swarm.ZeroAddress, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ref := swarm.NewAddress(bytes.Repeat([]byte{byte(len(m.data) + 1)}, swarm.HashSize))
+	m.data[ref.ByteString()] = b
+	m.opts = o
+	return ref, nil
+}
+
+func (m *mockNode) Download(_ context.Context, ref swarm.Address) (io.ReadSeeker, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.data[ref.ByteString()]
+	if !ok {
+		return nil, 0, storage.ErrNotFound
+	}
+	return bytes.NewReader(b), int64(len(b)), nil
+}
+
+func (m *mockNode) Pin(_ context.Context, ref swarm.Address) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pins = append(m.pins, ref)
+	return nil
+}
+
+func (m *mockNode) Unpin(_ context.Context, ref swarm.Address) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, p := range m.pins {
+		if p.Equal(ref) {
+			m.pins = append(m.pins[:i], m.pins[i+1:]...)
+		}
+	}
+	return nil
+}
+
+func (m *mockNode) Pins(_ context.Context) ([]swarm.Address, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]swarm.Address(nil), m.pins...), nil
+}
+
+type peerer []p2p.Peer
+
+func (p peerer) Peers() []p2p.Peer { return p }
+
+func newClient(t *testing.T, node grpcapi.Node, overlay swarm.Address, readOnly *readonly.Mode) pb.BeeClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpcapi.NewServer(grpcapi.New(node, peerer{{}, {}}, overlay, "light", readOnly, log.Noop))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(grpcapi.Codec)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewBeeClient(conn)
+}
+
+func TestUploadDownload(t *testing.T) {
+	t.Parallel()
+
+	node := &mockNode{data: make(map[string][]byte)}
+	client := newClient(t, node, swarm.RandAddress(t), nil)
+	ctx := context.Background()
+
+	data := testutil.RandBytes(t, 200*1024)
+	up, err := client.Upload(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := up.Send(&pb.UploadRequest{BatchID: []byte{1}, Pin: true, Data: data[:1000]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := up.Send(&pb.UploadRequest{Data: data[1000:]}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := up.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !node.opts.Pin || node.opts.Encrypt {
+		t.Fatalf("got upload options %+v", node.opts)
+	}
+
+	down, err := client.Download(ctx, &pb.DownloadRequest{Reference: resp.Reference})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		got    []byte
+		length int64
+	)
+	for {
+		msg, err := down.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.Length != 0 {
+			length = msg.Length
+		}
+		got = append(got, msg.Data...)
+	}
+	if length != int64(len(data)) {
+		t.Fatalf("got length %d, want %d", length, len(data))
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("downloaded data differs from the uploaded")
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		down, err := client.Download(ctx, &pb.DownloadRequest{Reference: swarm.RandAddress(t).Bytes()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := down.Recv(); status.Code(err) != codes.NotFound {
+			t.Fatalf("got error %v, want not found", err)
+		}
+	})
+
+	t.Run("batch unusable", func(t *testing.T) {
+		t.Parallel()
+
+		up, err := client.Upload(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := up.Send(&pb.UploadRequest{Data: []byte("data")}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := up.CloseAndRecv(); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got error %v, want failed precondition", err)
+		}
+	})
+}
+
+func TestPinsStatus(t *testing.T) {
+	t.Parallel()
+
+	overlay := swarm.RandAddress(t)
+	client := newClient(t, &mockNode{data: make(map[string][]byte)}, overlay, nil)
+	ctx := context.Background()
+
+	ref := swarm.RandAddress(t)
+	if _, err := client.Pin(ctx, &pb.PinRequest{Reference: ref.Bytes()}); err != nil {
+		t.Fatal(err)
+	}
+	pins, err := client.Pins(ctx, &pb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pins.References) != 1 || !bytes.Equal(pins.References[0], ref.Bytes()) {
+		t.Fatalf("got pins %x", pins.References)
+	}
+	if _, err := client.Unpin(ctx, &pb.PinRequest{Reference: ref.Bytes()}); err != nil {
+		t.Fatal(err)
+	}
+	if pins, _ := client.Pins(ctx, &pb.Empty{}); len(pins.References) != 0 {
+		t.Fatalf("got pins %x after unpin", pins.References)
+	}
+
+	st, err := client.Status(ctx, &pb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(st.Overlay, overlay.Bytes()) || st.BeeMode != "light" || st.ConnectedPeers != 2 {
+		t.Fatalf("got status %+v", st)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	t.Parallel()
+
+	node := &mockNode{data: make(map[string][]byte)}
+	mode := readonly.New(true)
+	client := newClient(t, node, swarm.RandAddress(t), mode)
+	ctx := context.Background()
+	ref := swarm.RandAddress(t)
+
+	up, err := client.Upload(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the stream may be closed by the server before the request is sent
+	_ = up.Send(&pb.UploadRequest{BatchID: []byte{1}, Data: []byte("data")})
+	if _, err := up.CloseAndRecv(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("upload: got error %v, want unavailable", err)
+	}
+	if _, err := client.Pin(ctx, &pb.PinRequest{Reference: ref.Bytes()}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("pin: got error %v, want unavailable", err)
+	}
+	if _, err := client.Unpin(ctx, &pb.PinRequest{Reference: ref.Bytes()}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("unpin: got error %v, want unavailable", err)
+	}
+	if len(node.data) != 0 || len(node.pins) != 0 {
+		t.Fatal("node modified in the read-only mode")
+	}
+
+	// the reads are served in the read-only mode
+	if _, err := client.Pins(ctx, &pb.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+
+	mode.Set(false)
+	if _, err := client.Pin(ctx, &pb.PinRequest{Reference: ref.Bytes()}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:generate sh -c "protoc -I . -I \"$(go list -f '{{ .Dir }}' -m github.com/gogo/protobuf)/protobuf\" --gogofaster_out=plugins=grpc:. api.proto"
+
+// Package pb holds only Protocol Buffer definitions and generated code.
+package pb
@@ -4,5 +4,16 @@
 
 package hive
 
+import (
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/hive/pb"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
 var MaxBatchSize = maxBatchSize
 var LimitBurst = limitBurst
+
+func (s *Service) VerifyRecords(issuer swarm.Address, records *pb.Records, now time.Time) pb.Peers {
+	return s.verifyRecords(issuer, records, now)
+}
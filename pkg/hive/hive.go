@@ -7,7 +7,9 @@
 // informed about other peers in the network. It gossips
 // about all peers by default and performs no specific
 // prioritization about which peers are gossipped to
-// others.
+// others. The peers supporting the peer records receive
+// the addresses with the signed hints of the gossiping
+// node, which are used to prioritize the peers to dial.
 package hive
 
 import (
@@ -22,6 +24,7 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/addressbook"
 	"github.com/ethersphere/bee/v2/pkg/bzz"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/hive/pb"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
@@ -67,6 +70,9 @@ type Service struct {
 	sem               *semaphore.Weighted
 	bootnode          bool
 	allowPrivateCIDRs bool
+	signer            crypto.Signer
+	nonce             []byte
+	hints             HintsFunc
 }
 
 func New(streamer p2p.StreamerPinger, addressbook addressbook.GetPutter, networkID uint64, bootnode bool, allowPrivateCIDRs bool, logger log.Logger) *Service {
@@ -101,6 +107,10 @@ func (s *Service) Protocol() p2p.ProtocolSpec {
 				Name:    peersStreamName,
 				Handler: s.peersHandler,
 			},
+			{
+				Name:    recordsStreamName,
+				Handler: s.recordsHandler,
+			},
 		},
 		DisconnectIn:  s.disconnect,
 		DisconnectOut: s.disconnect,
@@ -130,7 +140,7 @@ func (s *Service) BroadcastPeers(ctx context.Context, addressee swarm.Address, p
 		default:
 		}
 
-		if err := s.sendPeers(ctx, addressee, peers[:maxSize]); err != nil {
+		if err := s.send(ctx, addressee, peers[:maxSize]); err != nil {
 			return err
 		}
 
@@ -161,6 +171,21 @@ func (s *Service) Close() error {
 	}
 }
 
+// send sends the peer records to the addressee if they are enabled
+// and supported by the addressee, otherwise the plain addresses.
+func (s *Service) send(ctx context.Context, addressee swarm.Address, peers []swarm.Address) error {
+	if s.signer == nil {
+		return s.sendPeers(ctx, addressee, peers)
+	}
+
+	err := s.sendRecords(ctx, addressee, peers)
+	var ise *p2p.IncompatibleStreamError
+	if errors.As(err, &ise) {
+		return s.sendPeers(ctx, addressee, peers)
+	}
+	return err
+}
+
 func (s *Service) sendPeers(ctx context.Context, peer swarm.Address, peers []swarm.Address) (err error) {
 	s.metrics.BroadcastPeersSends.Inc()
 	stream, err := s.streamer.NewStream(ctx, peer, nil, protocolName, protocolVersion, peersStreamName)
@@ -277,11 +302,11 @@ func (s *Service) startCheckPeersHandler() {
 }
 
 func (s *Service) checkAndAddPeers(ctx context.Context, peers pb.Peers) {
-	var peersToAdd []swarm.Address
-	mtx := sync.Mutex{}
+	// the reachable peers are added in the order of the batch
+	reachable := make([]swarm.Address, len(peers.Peers))
 	wg := sync.WaitGroup{}
 
-	addPeer := func(newPeer *pb.BzzAddress, multiUnderlay ma.Multiaddr) {
+	addPeer := func(i int, newPeer *pb.BzzAddress, multiUnderlay ma.Multiaddr) {
 		err := s.sem.Acquire(ctx, 1)
 		if err != nil {
 			return
@@ -326,13 +351,11 @@ func (s *Service) checkAndAddPeers(ctx context.Context, peers pb.Peers) {
 				return
 			}
 
-			mtx.Lock()
-			peersToAdd = append(peersToAdd, bzzAddress.Overlay)
-			mtx.Unlock()
+			reachable[i] = bzzAddress.Overlay
 		}()
 	}
 
-	for _, p := range peers.Peers {
+	for i, p := range peers.Peers {
 
 		multiUnderlay, err := ma.NewMultiaddrBytes(p.Underlay)
 		if err != nil {
@@ -349,10 +372,17 @@ func (s *Service) checkAndAddPeers(ctx context.Context, peers pb.Peers) {
 		}
 
 		// add peer does not exist in the addressbook
-		addPeer(p, multiUnderlay)
+		addPeer(i, p, multiUnderlay)
 	}
 	wg.Wait()
 
+	var peersToAdd []swarm.Address
+	for _, addr := range reachable {
+		if !addr.IsZero() {
+			peersToAdd = append(peersToAdd, addr)
+		}
+	}
+
 	if s.addPeersHandler != nil && len(peersToAdd) > 0 {
 		s.addPeersHandler(peersToAdd...)
 	}
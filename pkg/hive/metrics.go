@@ -18,6 +18,10 @@ type metrics struct {
 	PeersHandlerPeers prometheus.Counter
 	UnreachablePeers  prometheus.Counter
 
+	RecordsHandler prometheus.Counter
+	InvalidRecords prometheus.Counter
+	StaleRecords   prometheus.Counter
+
 	PingTime        prometheus.Histogram
 	PingFailureTime prometheus.Histogram
 
@@ -67,6 +71,24 @@ func newMetrics() metrics {
 			Name:      "unreachable_peers_count",
 			Help:      "Number of peers that are unreachable.",
 		}),
+		RecordsHandler: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "records_handler_count",
+			Help:      "Number of peer record messages received.",
+		}),
+		InvalidRecords: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "invalid_records_count",
+			Help:      "Number of received peer records with an invalid signature.",
+		}),
+		StaleRecords: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "stale_records_count",
+			Help:      "Number of received stale peer records.",
+		}),
 		PingTime: prometheus.NewHistogram(prometheus.HistogramOpts{
 			Namespace: m.Namespace,
 			Subsystem: subsystem,
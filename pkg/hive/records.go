@@ -0,0 +1,239 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hive
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/addressbook"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/hive/pb"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/protobuf"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+const (
+	recordsStreamName = "records"
+	// recordMaxAge is the age after which the record is considered stale.
+	recordMaxAge = 24 * time.Hour
+	// recordMaxSkew is the tolerated clock difference of the record issuer.
+	recordMaxSkew = time.Minute
+)
+
+// PeerHints are the observations about the peer gossiped in the peer record.
+type PeerHints struct {
+	// LastSeen is the time the peer was last connected.
+	LastSeen time.Time
+	// Latency is the observed latency of the peer.
+	Latency time.Duration
+	// FullNode reports whether the peer is a full node.
+	FullNode bool
+	// Radius is the storage radius of the peer, valid if HasRadius is set.
+	Radius    uint8
+	HasRadius bool
+}
+
+// HintsFunc returns the hints about the peer, if there are any.
+type HintsFunc func(swarm.Address) (PeerHints, bool)
+
+// EnableRecords makes the service gossip the peer records signed with the
+// signer, whose overlay is derived with the nonce, to the peers supporting
+// them; the hints of the records are provided by the hints function.
+func (s *Service) EnableRecords(signer crypto.Signer, nonce []byte, hints HintsFunc) {
+	s.signer = signer
+	s.nonce = nonce
+	s.hints = hints
+}
+
+// recordData returns the data of the record covered by the signature.
+func recordData(r *pb.PeerRecord) []byte {
+	b := make([]byte, 0, len(r.Address.Overlay)+len(r.Address.Underlay)+21)
+	b = append(b, r.Address.Overlay...)
+	b = append(b, r.Address.Underlay...)
+	b = binary.BigEndian.AppendUint64(b, uint64(r.Timestamp))
+	b = binary.BigEndian.AppendUint64(b, uint64(r.Latency))
+	if r.FullNode {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	return binary.BigEndian.AppendUint32(b, r.Radius)
+}
+
+// sendRecords sends the signed records of the peers to the addressee.
+func (s *Service) sendRecords(ctx context.Context, addressee swarm.Address, peers []swarm.Address) (err error) {
+	s.metrics.BroadcastPeersSends.Inc()
+	stream, err := s.streamer.NewStream(ctx, addressee, nil, protocolName, protocolVersion, recordsStreamName)
+	if err != nil {
+		return fmt.Errorf("new stream: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = stream.Reset()
+		} else {
+			_ = stream.Close()
+		}
+	}()
+
+	records := pb.Records{Nonce: s.nonce}
+	for _, p := range peers {
+		addr, err := s.addressBook.Get(p)
+		if err != nil {
+			if errors.Is(err, addressbook.ErrNotFound) {
+				s.logger.Debug("broadcast records; peer not found in the addressbook, skipping...", "peer_address", p)
+				continue
+			}
+			return err
+		}
+
+		if !s.allowPrivateCIDRs && manet.IsPrivateAddr(addr.Underlay) {
+			continue // Don't advertise private CIDRs to the public network.
+		}
+
+		record := &pb.PeerRecord{
+			Address: &pb.BzzAddress{
+				Overlay:   addr.Overlay.Bytes(),
+				Underlay:  addr.Underlay.Bytes(),
+				Signature: addr.Signature,
+				Nonce:     addr.Nonce,
+			},
+		}
+		if s.hints != nil {
+			if h, ok := s.hints(p); ok {
+				if !h.LastSeen.IsZero() {
+					record.Timestamp = h.LastSeen.UnixNano()
+				}
+				record.Latency = int64(h.Latency)
+				record.FullNode = h.FullNode
+				if h.HasRadius {
+					record.Radius = uint32(h.Radius) + 1
+				}
+			}
+		}
+		record.Signature, err = s.signer.Sign(recordData(record))
+		if err != nil {
+			return fmt.Errorf("sign record: %w", err)
+		}
+		records.Records = append(records.Records, record)
+	}
+
+	w, _ := protobuf.NewWriterAndReader(stream)
+	if err := w.WriteMsgWithContext(ctx, &records); err != nil {
+		return fmt.Errorf("write Records message: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) recordsHandler(ctx context.Context, peer p2p.Peer, stream p2p.Stream) error {
+	s.metrics.RecordsHandler.Inc()
+	_, r := protobuf.NewWriterAndReader(stream)
+	ctx, cancel := context.WithTimeout(ctx, messageTimeout)
+	defer cancel()
+	var records pb.Records
+	if err := r.ReadMsgWithContext(ctx, &records); err != nil {
+		_ = stream.Reset()
+		return fmt.Errorf("read Records message: %w", err)
+	}
+
+	s.metrics.PeersHandlerPeers.Add(float64(len(records.Records)))
+
+	if !s.inLimiter.Allow(peer.Address.ByteString(), len(records.Records)) {
+		_ = stream.Reset()
+		return ErrRateLimitExceeded
+	}
+
+	// close the stream before processing in order to unblock the sending side
+	go stream.FullClose()
+
+	if s.bootnode {
+		return nil
+	}
+
+	peers := s.verifyRecords(peer.Address, &records, time.Now())
+
+	select {
+	case s.peersChan <- peers:
+	case <-s.quit:
+		return errors.New("failed to process peers, shutting down hive")
+	}
+
+	return nil
+}
+
+// verifyRecords discards the records which are not signed by the issuer
+// or are stale and returns the addresses of the remaining ones in the
+// order in which they should be dialed.
+func (s *Service) verifyRecords(issuer swarm.Address, records *pb.Records, now time.Time) pb.Peers {
+	valid := make([]*pb.PeerRecord, 0, len(records.Records))
+	for _, r := range records.Records {
+		if r.Address == nil {
+			s.metrics.InvalidRecords.Inc()
+			continue
+		}
+		pubKey, err := crypto.Recover(r.Signature, recordData(r))
+		if err != nil {
+			s.metrics.InvalidRecords.Inc()
+			continue
+		}
+		overlay, err := crypto.NewOverlayAddress(*pubKey, s.networkID, records.Nonce)
+		if err != nil || !overlay.Equal(issuer) {
+			s.metrics.InvalidRecords.Inc()
+			continue
+		}
+		if r.Timestamp != 0 {
+			seen := time.Unix(0, r.Timestamp)
+			if now.Sub(seen) > recordMaxAge || seen.Sub(now) > recordMaxSkew {
+				s.metrics.StaleRecords.Inc()
+				continue
+			}
+		}
+		valid = append(valid, r)
+	}
+
+	slices.SortStableFunc(valid, compareRecords)
+
+	peers := pb.Peers{Peers: make([]*pb.BzzAddress, 0, len(valid))}
+	for _, r := range valid {
+		peers.Peers = append(peers.Peers, r.Address)
+	}
+	return peers
+}
+
+// compareRecords orders the records by their dial priority: the full nodes
+// first, then the ones with the lower latency and the fresher ones.
+func compareRecords(a, b *pb.PeerRecord) int {
+	if a.FullNode != b.FullNode {
+		if a.FullNode {
+			return -1
+		}
+		return 1
+	}
+	if (a.Latency > 0) != (b.Latency > 0) {
+		if a.Latency > 0 {
+			return -1
+		}
+		return 1
+	}
+	if a.Latency != b.Latency {
+		if a.Latency < b.Latency {
+			return -1
+		}
+		return 1
+	}
+	switch {
+	case a.Timestamp > b.Timestamp:
+		return -1
+	case a.Timestamp < b.Timestamp:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hive_test
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	ab "github.com/ethersphere/bee/v2/pkg/addressbook"
+	"github.com/ethersphere/bee/v2/pkg/bzz"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/hive"
+	"github.com/ethersphere/bee/v2/pkg/hive/pb"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/protobuf"
+	"github.com/ethersphere/bee/v2/pkg/p2p/streamtest"
+	"github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestBroadcastRecords(t *testing.T) {
+	t.Parallel()
+
+	networkID := uint64(1)
+	addressbook := ab.New(mock.NewStateStore())
+
+	peers := make([]swarm.Address, 4)
+	for i := range peers {
+		underlay, err := ma.NewMultiaddr("/ip4/127.0.0.1/udp/" + strconv.Itoa(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk, err := crypto.GenerateSecp256k1Key()
+		if err != nil {
+			t.Fatal(err)
+		}
+		overlay, err := crypto.NewOverlayAddress(pk.PublicKey, networkID, block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bzzAddr, err := bzz.NewAddress(crypto.NewDefaultSigner(pk), underlay, overlay, networkID, nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := addressbook.Put(bzzAddr.Overlay, *bzzAddr); err != nil {
+			t.Fatal(err)
+		}
+		peers[i] = bzzAddr.Overlay
+	}
+
+	hints := map[string]hive.PeerHints{
+		peers[0].ByteString(): {LastSeen: time.Now()},
+		peers[1].ByteString(): {LastSeen: time.Now(), Latency: 20 * time.Millisecond, FullNode: true},
+		peers[2].ByteString(): {LastSeen: time.Now(), Latency: 5 * time.Millisecond, FullNode: true, Radius: 8, HasRadius: true},
+		peers[3].ByteString(): {LastSeen: time.Now().Add(-48 * time.Hour), FullNode: true},
+	}
+	hintsFunc := func(addr swarm.Address) (hive.PeerHints, bool) {
+		h, ok := hints[addr.ByteString()]
+		return h, ok
+	}
+
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientOverlay, err := crypto.NewOverlayAddress(key.PublicKey, networkID, block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("records", func(t *testing.T) {
+		t.Parallel()
+
+		server := hive.New(streamtest.New(), ab.New(mock.NewStateStore()), networkID, false, true, log.Noop)
+		testutil.CleanupCloser(t, server)
+		added := make(chan []swarm.Address, 1)
+		server.SetAddPeersHandler(func(addrs ...swarm.Address) { added <- addrs })
+
+		recorder := streamtest.New(
+			streamtest.WithProtocols(server.Protocol()),
+			streamtest.WithBaseAddr(clientOverlay),
+		)
+		client := hive.New(recorder, addressbook, networkID, false, true, log.Noop)
+		testutil.CleanupCloser(t, client)
+		client.EnableRecords(crypto.NewDefaultSigner(key), block, hintsFunc)
+
+		addressee := swarm.RandAddress(t)
+		if err := client.BroadcastPeers(context.Background(), addressee, peers...); err != nil {
+			t.Fatal(err)
+		}
+
+		// the stale record is discarded and the rest are ordered by priority
+		select {
+		case have := <-added:
+			want := []swarm.Address{peers[2], peers[1], peers[0]}
+			if len(have) != len(want) {
+				t.Fatalf("added peers: want %v, have %v", want, have)
+			}
+			for i := range want {
+				if !have[i].Equal(want[i]) {
+					t.Fatalf("added peers: want %v, have %v", want, have)
+				}
+			}
+		case <-time.After(spinTimeout):
+			t.Fatal("timed out waiting for the added peers")
+		}
+
+		rec, err := recorder.Records(addressee, "hive", "1.1.0", "records")
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages, err := protobuf.ReadMessages(bytes.NewReader(rec[0].In()), func() protobuf.Message { return new(pb.Records) })
+		if err != nil {
+			t.Fatal(err)
+		}
+		records := messages[0].(*pb.Records)
+		if len(records.Records) != len(peers) {
+			t.Fatalf("records: want %d, have %d", len(peers), len(records.Records))
+		}
+
+		if have := server.VerifyRecords(swarm.RandAddress(t), records, time.Now()); len(have.Peers) != 0 {
+			t.Fatalf("records of another issuer: want none accepted, have %d", len(have.Peers))
+		}
+		if have := server.VerifyRecords(clientOverlay, records, time.Now().Add(25*time.Hour)); len(have.Peers) != 0 {
+			t.Fatalf("stale records: want none accepted, have %d", len(have.Peers))
+		}
+
+		records.Records[0].Latency++
+		if have := server.VerifyRecords(clientOverlay, records, time.Now()); len(have.Peers) != 2 {
+			t.Fatalf("tampered record: want 2 accepted, have %d", len(have.Peers))
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		t.Parallel()
+
+		server := hive.New(streamtest.New(), ab.New(mock.NewStateStore()), networkID, false, true, log.Noop)
+		testutil.CleanupCloser(t, server)
+
+		recorder := streamtest.New(
+			streamtest.WithProtocols(server.Protocol()),
+			streamtest.WithBaseAddr(clientOverlay),
+			streamtest.WithStreamError(func(_ swarm.Address, _, _, stream string) error {
+				if stream == "records" {
+					return p2p.NewIncompatibleStreamError(streamtest.ErrStreamNotSupported)
+				}
+				return nil
+			}),
+		)
+		client := hive.New(recorder, addressbook, networkID, false, true, log.Noop)
+		testutil.CleanupCloser(t, client)
+		client.EnableRecords(crypto.NewDefaultSigner(key), block, hintsFunc)
+
+		addressee := swarm.RandAddress(t)
+		if err := client.BroadcastPeers(context.Background(), addressee, peers...); err != nil {
+			t.Fatal(err)
+		}
+		rec, err := recorder.Records(addressee, "hive", "1.1.0", "peers")
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages, err := readAndAssertPeersMsgs(rec[0].In(), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have := len(messages[0].Peers); have != len(peers) {
+			t.Fatalf("peers: want %d, have %d", len(peers), have)
+		}
+	})
+}
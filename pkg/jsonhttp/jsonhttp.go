@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 var (
@@ -37,10 +38,45 @@ type Reason struct {
 //
 // If response is string, error or Stringer type the string will be set as
 // value to the Message field.
+//
+// ErrorCode field is a machine-readable identifier of the error that clients
+// can branch on instead of matching the Message. It is set for all error
+// responses, either explicitly with the CodedResponse or derived from the
+// HTTP status code by the DefaultErrorCode function.
 type StatusResponse struct {
-	Code    int      `json:"code,omitempty"`
-	Message string   `json:"message,omitempty"`
-	Reasons []Reason `json:"reasons,omitempty"`
+	Code      int      `json:"code,omitempty"`
+	Message   string   `json:"message,omitempty"`
+	ErrorCode string   `json:"errorCode,omitempty"`
+	Reasons   []Reason `json:"reasons,omitempty"`
+}
+
+// CodedResponse is a response with an explicit machine-readable error code.
+// Message can be nil, string, error or Stringer type and is set to the
+// Message field of the StatusResponse the same way as the plain response.
+type CodedResponse struct {
+	ErrorCode string
+	Message   interface{}
+}
+
+// Coded returns a response with the message and the machine-readable error
+// code.
+func Coded(errorCode string, message interface{}) CodedResponse {
+	return CodedResponse{ErrorCode: errorCode, Message: message}
+}
+
+// DefaultErrorCode returns the machine-readable error code derived from the
+// HTTP status code, for example "not_found" for 404 status code. It returns
+// an empty string for status codes that do not indicate an error.
+func DefaultErrorCode(statusCode int) string {
+	if statusCode < http.StatusBadRequest {
+		return ""
+	}
+	text := http.StatusText(statusCode)
+	if text == "" {
+		return ""
+	}
+	text = strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text)
+	return strings.ToLower(text)
 }
 
 // Respond writes a JSON-encoded body to http.ResponseWriter.
@@ -48,32 +84,7 @@ func Respond(w http.ResponseWriter, statusCode int, response interface{}) {
 	if statusCode == 0 {
 		statusCode = http.StatusOK
 	}
-	if response == nil {
-		response = &StatusResponse{
-			Message: http.StatusText(statusCode),
-			Code:    statusCode,
-		}
-	} else {
-		switch message := response.(type) {
-		case string:
-			response = &StatusResponse{
-				Message: message,
-				Code:    statusCode,
-			}
-		case error:
-			response = &StatusResponse{
-				Message: message.Error(),
-				Code:    statusCode,
-			}
-		case interface {
-			String() string
-		}:
-			response = &StatusResponse{
-				Message: message.String(),
-				Code:    statusCode,
-			}
-		}
-	}
+	response = statusResponse(statusCode, response)
 	var b bytes.Buffer
 	enc := json.NewEncoder(&b)
 	enc.SetEscapeHTML(EscapeHTML)
@@ -87,6 +98,62 @@ func Respond(w http.ResponseWriter, statusCode int, response interface{}) {
 	fmt.Fprintln(w, b.String())
 }
 
+// statusResponse converts the response to the StatusResponse if it is nil,
+// string, error, Stringer or CodedResponse type, and sets the default error
+// code to the error status responses that do not have one.
+func statusResponse(statusCode int, response interface{}) interface{} {
+	switch message := response.(type) {
+	case nil:
+		return &StatusResponse{
+			Message:   http.StatusText(statusCode),
+			Code:      statusCode,
+			ErrorCode: DefaultErrorCode(statusCode),
+		}
+	case CodedResponse:
+		r, ok := statusResponse(statusCode, message.Message).(*StatusResponse)
+		if !ok {
+			return message.Message
+		}
+		if message.ErrorCode != "" {
+			r.ErrorCode = message.ErrorCode
+		}
+		return r
+	case StatusResponse:
+		if message.ErrorCode == "" {
+			message.ErrorCode = DefaultErrorCode(statusCode)
+		}
+		return &message
+	case *StatusResponse:
+		if message != nil && message.ErrorCode == "" {
+			r := *message
+			r.ErrorCode = DefaultErrorCode(statusCode)
+			return &r
+		}
+		return message
+	case string:
+		return &StatusResponse{
+			Message:   message,
+			Code:      statusCode,
+			ErrorCode: DefaultErrorCode(statusCode),
+		}
+	case error:
+		return &StatusResponse{
+			Message:   message.Error(),
+			Code:      statusCode,
+			ErrorCode: DefaultErrorCode(statusCode),
+		}
+	case interface {
+		String() string
+	}:
+		return &StatusResponse{
+			Message:   message.String(),
+			Code:      statusCode,
+			ErrorCode: DefaultErrorCode(statusCode),
+		}
+	}
+	return response
+}
+
 // Continue writes a response with status code 100.
 func Continue(w http.ResponseWriter, response interface{}) {
 	Respond(w, http.StatusContinue, response)
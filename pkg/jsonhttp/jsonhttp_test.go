@@ -311,10 +311,94 @@ func TestStandardHTTPResponds(t *testing.T) {
 			t.Errorf("expected message message \"%s\", got \"%s\"", http.StatusText(tc.code), m.Message)
 		}
 
+		if want := jsonhttp.DefaultErrorCode(tc.code); m.ErrorCode != want {
+			t.Errorf("expected error code %q, got %q", want, m.ErrorCode)
+		}
+
 		testContentType(t, w)
 	}
 }
 
+func TestRespond_errorCode(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name          string
+		code          int
+		response      interface{}
+		wantMessage   string
+		wantErrorCode string
+	}{
+		{
+			name:          "success",
+			code:          http.StatusOK,
+			response:      "ok",
+			wantMessage:   "ok",
+			wantErrorCode: "",
+		},
+		{
+			name:          "default",
+			code:          http.StatusInternalServerError,
+			response:      errors.New("failed"),
+			wantMessage:   "failed",
+			wantErrorCode: "internal_server_error",
+		},
+		{
+			name:          "coded",
+			code:          http.StatusPaymentRequired,
+			response:      jsonhttp.Coded("bucket_full", "batch is overissued"),
+			wantMessage:   "batch is overissued",
+			wantErrorCode: "bucket_full",
+		},
+		{
+			name:          "coded nil message",
+			code:          http.StatusNotFound,
+			response:      jsonhttp.Coded("chunk_not_found", nil),
+			wantMessage:   http.StatusText(http.StatusNotFound),
+			wantErrorCode: "chunk_not_found",
+		},
+		{
+			name: "status response",
+			code: http.StatusBadRequest,
+			response: jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid",
+			},
+			wantMessage:   "invalid",
+			wantErrorCode: "bad_request",
+		},
+		{
+			name:          "teapot",
+			code:          http.StatusTeapot,
+			response:      nil,
+			wantMessage:   http.StatusText(http.StatusTeapot),
+			wantErrorCode: "im_a_teapot",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			jsonhttp.Respond(w, tc.code, tc.response)
+
+			var m *jsonhttp.StatusResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &m); err != nil {
+				t.Fatalf("json unmarshal response body: %s", err)
+			}
+
+			if m.Code != tc.code {
+				t.Errorf("got message code %d, want %d", m.Code, tc.code)
+			}
+			if m.Message != tc.wantMessage {
+				t.Errorf("got message %q, want %q", m.Message, tc.wantMessage)
+			}
+			if m.ErrorCode != tc.wantErrorCode {
+				t.Errorf("got error code %q, want %q", m.ErrorCode, tc.wantErrorCode)
+			}
+		})
+	}
+}
+
 func TestPanicRespond(t *testing.T) {
 	t.Parallel()
 
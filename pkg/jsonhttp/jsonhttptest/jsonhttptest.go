@@ -41,6 +41,11 @@ func Request(tb testing.TB, client *http.Client, method, url string, responseCod
 		tb.Fatal(err)
 	}
 	req.Header = o.requestHeaders
+	if o.requestTrailers != nil {
+		// the trailers are only sent with the chunked transfer encoding
+		req.Trailer = o.requestTrailers
+		req.ContentLength = -1
+	}
 	if o.ctx != nil {
 		req = req.WithContext(o.ctx)
 	}
@@ -215,6 +220,18 @@ func WithRequestHeader(key, value string) Option {
 	})
 }
 
+// WithRequestTrailer adds a single trailer to the request in the Request
+// function. The request body is sent with the chunked transfer encoding.
+func WithRequestTrailer(key, value string) Option {
+	return optionFunc(func(o *options) error {
+		if o.requestTrailers == nil {
+			o.requestTrailers = make(http.Header)
+		}
+		o.requestTrailers.Add(key, value)
+		return nil
+	})
+}
+
 // WithExpectedResponse validates that the response from the request in the
 // Request function matches completely bytes provided here.
 func WithExpectedResponse(response []byte) Option {
@@ -300,6 +317,7 @@ type options struct {
 	ctx                     context.Context
 	requestBody             io.Reader
 	requestHeaders          http.Header
+	requestTrailers         http.Header
 	expectedResponseHeaders http.Header
 	nonEmptyResponseHeaders []string
 	expectedResponse        []byte
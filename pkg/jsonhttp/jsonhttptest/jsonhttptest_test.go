@@ -268,6 +268,31 @@ func TestWithRequestHeader(t *testing.T) {
 	}
 }
 
+func TestWithRequestTrailer(t *testing.T) {
+	t.Parallel()
+
+	trailerName := "Swarm-Trailer"
+	trailerValue := "somevalue"
+	var gotValue string
+
+	c, endpoint := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		gotValue = r.Trailer.Get(trailerName)
+	}))
+
+	assert(t, testResult{}, func(m *mock) {
+		jsonhttptest.Request(m, c, http.MethodPost, endpoint, http.StatusOK,
+			jsonhttptest.WithRequestBody(bytes.NewReader([]byte("somebody"))),
+			jsonhttptest.WithRequestTrailer(trailerName, trailerValue),
+		)
+	})
+	if gotValue != trailerValue {
+		t.Errorf("got trailer %q, want %q", gotValue, trailerValue)
+	}
+}
+
 func TestWithExpectedContentLength(t *testing.T) {
 	t.Parallel()
 
@@ -361,6 +386,37 @@ func TestWithExpectedJSONResponse(t *testing.T) {
 	})
 }
 
+func TestWithExpectedJSONResponseErrorCode(t *testing.T) {
+	t.Parallel()
+
+	c, endpoint := newClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jsonhttp.NotFound(w, jsonhttp.Coded("chunk_not_found", "chunk not found"))
+	}))
+
+	assert(t, testResult{}, func(m *mock) {
+		jsonhttptest.Request(m, c, http.MethodGet, endpoint, http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusNotFound,
+				Message:   "chunk not found",
+				ErrorCode: "chunk_not_found",
+			}),
+		)
+	})
+
+	tr := testResult{
+		errors: []string{`got json response "{\"code\":404,\"message\":\"chunk not found\",\"errorCode\":\"chunk_not_found\"}", want "{\"code\":404,\"message\":\"chunk not found\",\"errorCode\":\"not_found\"}"`},
+	}
+	assert(t, tr, func(m *mock) {
+		jsonhttptest.Request(m, c, http.MethodGet, endpoint, http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:      http.StatusNotFound,
+				Message:   "chunk not found",
+				ErrorCode: "not_found",
+			}),
+		)
+	})
+}
+
 func TestWithUnmarhalJSONResponse(t *testing.T) {
 	t.Parallel()
 
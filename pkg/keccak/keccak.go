@@ -0,0 +1,50 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package keccak hashes the fixed size sections of the binary merkle trees
+// with Keccak-256 in batches.
+//
+// On amd64 with AVX2 the sections are hashed four at a time with the SIMD
+// backend. The backend is left out of the build with the purego build tag,
+// the sections are then hashed one by one with golang.org/x/crypto/sha3.
+package keccak
+
+import (
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// SectionSize is the size of the hashed sections.
+	SectionSize = 64
+	// HashSize is the size of the section hashes.
+	HashSize = 32
+
+	rate = 136 // rate of Keccak-256 in bytes
+)
+
+// HashSections writes the Keccak-256 hashes of the consecutive sections
+// of the in to the consecutive hashes of the out. The length of the in
+// must be a multiple of SectionSize and the out must fit all the hashes.
+func HashSections(out, in []byte) {
+	n := len(in) / SectionSize
+	if len(in)%SectionSize != 0 || len(out) < n*HashSize {
+		panic("keccak: invalid section buffers")
+	}
+	hashSections(out, in, n)
+}
+
+// Accelerated reports whether the sections are hashed with the SIMD backend.
+func Accelerated() bool {
+	return accelerated
+}
+
+// hashSectionsGeneric hashes the sections one by one.
+func hashSectionsGeneric(out, in []byte, n int) {
+	h := sha3.NewLegacyKeccak256()
+	for i := 0; i < n; i++ {
+		h.Reset()
+		_, _ = h.Write(in[i*SectionSize : (i+1)*SectionSize])
+		h.Sum(out[i*HashSize : i*HashSize])
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build amd64 && !purego
+
+package keccak
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/sys/cpu"
+)
+
+var accelerated = cpu.X86.HasAVX2
+
+// roundConstants are the iota constants of the Keccak-f[1600] rounds.
+var roundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// keccakF1600x4 applies the Keccak-f[1600] permutation to the four
+// interleaved states of the a, using the b as the scratch space.
+//
+//go:noescape
+func keccakF1600x4(a *[100]uint64, b *[100]uint64, rc *[24]uint64)
+
+func hashSections(out, in []byte, n int) {
+	if !accelerated {
+		hashSectionsGeneric(out, in, n)
+		return
+	}
+
+	var a, b [100]uint64
+	for i := 0; i < n; i += 4 {
+		lanes := min(n-i, 4)
+
+		// absorb the single padded block of every section
+		clear(a[:])
+		for j := 0; j < lanes; j++ {
+			section := in[(i+j)*SectionSize:]
+			for k := 0; k < SectionSize/8; k++ {
+				a[k*4+j] = binary.LittleEndian.Uint64(section[k*8:])
+			}
+			a[SectionSize/8*4+j] = 0x01
+			a[(rate/8-1)*4+j] = 0x80 << 56
+		}
+
+		keccakF1600x4(&a, &b, &roundConstants)
+
+		for j := 0; j < lanes; j++ {
+			hash := out[(i+j)*HashSize:]
+			for k := 0; k < HashSize/8; k++ {
+				binary.LittleEndian.PutUint64(hash[k*8:], a[k*4+j])
+			}
+		}
+	}
+}
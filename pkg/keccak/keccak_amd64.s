@@ -0,0 +1,251 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// The four states are interleaved, the i-th lane of the j-th state is the
+// j-th quadword of the i-th 32 byte row, so that every row is one YMM register
+// and the four permutations run in parallel. Rows are indexed as x+5*y.
+
+// ROL rotates the quadwords of the src left by n into the dst using the tmp.
+#define ROL(n, src, dst, tmp) \
+	VPSLLQ $n, src, tmp; \
+	VPSRLQ $(64-n), src, dst; \
+	VPOR   tmp, dst, dst
+
+// func keccakF1600x4(a *[100]uint64, b *[100]uint64, rc *[24]uint64)
+TEXT ·keccakF1600x4(SB), NOSPLIT, $0-24
+	MOVQ a+0(FP), DI
+	MOVQ b+8(FP), BX
+	MOVQ rc+16(FP), SI
+	MOVQ $24, CX
+
+round:
+	// theta: the column parities into Y0-Y4
+	VMOVDQU 0(DI), Y0
+	VPXOR   160(DI), Y0, Y0
+	VPXOR   320(DI), Y0, Y0
+	VPXOR   480(DI), Y0, Y0
+	VPXOR   640(DI), Y0, Y0
+	VMOVDQU 32(DI), Y1
+	VPXOR   192(DI), Y1, Y1
+	VPXOR   352(DI), Y1, Y1
+	VPXOR   512(DI), Y1, Y1
+	VPXOR   672(DI), Y1, Y1
+	VMOVDQU 64(DI), Y2
+	VPXOR   224(DI), Y2, Y2
+	VPXOR   384(DI), Y2, Y2
+	VPXOR   544(DI), Y2, Y2
+	VPXOR   704(DI), Y2, Y2
+	VMOVDQU 96(DI), Y3
+	VPXOR   256(DI), Y3, Y3
+	VPXOR   416(DI), Y3, Y3
+	VPXOR   576(DI), Y3, Y3
+	VPXOR   736(DI), Y3, Y3
+	VMOVDQU 128(DI), Y4
+	VPXOR   288(DI), Y4, Y4
+	VPXOR   448(DI), Y4, Y4
+	VPXOR   608(DI), Y4, Y4
+	VPXOR   768(DI), Y4, Y4
+
+	// theta: the column effects into Y5-Y9
+	ROL(1, Y1, Y5, Y10)
+	VPXOR   Y4, Y5, Y5
+	ROL(1, Y2, Y6, Y10)
+	VPXOR   Y0, Y6, Y6
+	ROL(1, Y3, Y7, Y10)
+	VPXOR   Y1, Y7, Y7
+	ROL(1, Y4, Y8, Y10)
+	VPXOR   Y2, Y8, Y8
+	ROL(1, Y0, Y9, Y10)
+	VPXOR   Y3, Y9, Y9
+
+	// rho and pi: the rotated rows into b
+	VPXOR   0(DI), Y5, Y10
+	VMOVDQU Y10, 0(BX)
+	VPXOR   32(DI), Y6, Y10
+	ROL(1, Y10, Y10, Y11)
+	VMOVDQU Y10, 320(BX)
+	VPXOR   64(DI), Y7, Y10
+	ROL(62, Y10, Y10, Y11)
+	VMOVDQU Y10, 640(BX)
+	VPXOR   96(DI), Y8, Y10
+	ROL(28, Y10, Y10, Y11)
+	VMOVDQU Y10, 160(BX)
+	VPXOR   128(DI), Y9, Y10
+	ROL(27, Y10, Y10, Y11)
+	VMOVDQU Y10, 480(BX)
+	VPXOR   160(DI), Y5, Y10
+	ROL(36, Y10, Y10, Y11)
+	VMOVDQU Y10, 512(BX)
+	VPXOR   192(DI), Y6, Y10
+	ROL(44, Y10, Y10, Y11)
+	VMOVDQU Y10, 32(BX)
+	VPXOR   224(DI), Y7, Y10
+	ROL(6, Y10, Y10, Y11)
+	VMOVDQU Y10, 352(BX)
+	VPXOR   256(DI), Y8, Y10
+	ROL(55, Y10, Y10, Y11)
+	VMOVDQU Y10, 672(BX)
+	VPXOR   288(DI), Y9, Y10
+	ROL(20, Y10, Y10, Y11)
+	VMOVDQU Y10, 192(BX)
+	VPXOR   320(DI), Y5, Y10
+	ROL(3, Y10, Y10, Y11)
+	VMOVDQU Y10, 224(BX)
+	VPXOR   352(DI), Y6, Y10
+	ROL(10, Y10, Y10, Y11)
+	VMOVDQU Y10, 544(BX)
+	VPXOR   384(DI), Y7, Y10
+	ROL(43, Y10, Y10, Y11)
+	VMOVDQU Y10, 64(BX)
+	VPXOR   416(DI), Y8, Y10
+	ROL(25, Y10, Y10, Y11)
+	VMOVDQU Y10, 384(BX)
+	VPXOR   448(DI), Y9, Y10
+	ROL(39, Y10, Y10, Y11)
+	VMOVDQU Y10, 704(BX)
+	VPXOR   480(DI), Y5, Y10
+	ROL(41, Y10, Y10, Y11)
+	VMOVDQU Y10, 736(BX)
+	VPXOR   512(DI), Y6, Y10
+	ROL(45, Y10, Y10, Y11)
+	VMOVDQU Y10, 256(BX)
+	VPXOR   544(DI), Y7, Y10
+	ROL(15, Y10, Y10, Y11)
+	VMOVDQU Y10, 576(BX)
+	VPXOR   576(DI), Y8, Y10
+	ROL(21, Y10, Y10, Y11)
+	VMOVDQU Y10, 96(BX)
+	VPXOR   608(DI), Y9, Y10
+	ROL(8, Y10, Y10, Y11)
+	VMOVDQU Y10, 416(BX)
+	VPXOR   640(DI), Y5, Y10
+	ROL(18, Y10, Y10, Y11)
+	VMOVDQU Y10, 448(BX)
+	VPXOR   672(DI), Y6, Y10
+	ROL(2, Y10, Y10, Y11)
+	VMOVDQU Y10, 768(BX)
+	VPXOR   704(DI), Y7, Y10
+	ROL(61, Y10, Y10, Y11)
+	VMOVDQU Y10, 288(BX)
+	VPXOR   736(DI), Y8, Y10
+	ROL(56, Y10, Y10, Y11)
+	VMOVDQU Y10, 608(BX)
+	VPXOR   768(DI), Y9, Y10
+	ROL(14, Y10, Y10, Y11)
+	VMOVDQU Y10, 128(BX)
+
+	// chi and iota: the rows of b back into a
+	VPBROADCASTQ (SI), Y15
+	VMOVDQU 0(BX), Y0
+	VMOVDQU 32(BX), Y1
+	VMOVDQU 64(BX), Y2
+	VMOVDQU 96(BX), Y3
+	VMOVDQU 128(BX), Y4
+	VPANDN  Y2, Y1, Y10
+	VPXOR   Y0, Y10, Y10
+	VPXOR   Y15, Y10, Y10
+	VMOVDQU Y10, 0(DI)
+	VPANDN  Y3, Y2, Y10
+	VPXOR   Y1, Y10, Y10
+	VMOVDQU Y10, 32(DI)
+	VPANDN  Y4, Y3, Y10
+	VPXOR   Y2, Y10, Y10
+	VMOVDQU Y10, 64(DI)
+	VPANDN  Y0, Y4, Y10
+	VPXOR   Y3, Y10, Y10
+	VMOVDQU Y10, 96(DI)
+	VPANDN  Y1, Y0, Y10
+	VPXOR   Y4, Y10, Y10
+	VMOVDQU Y10, 128(DI)
+	VMOVDQU 160(BX), Y0
+	VMOVDQU 192(BX), Y1
+	VMOVDQU 224(BX), Y2
+	VMOVDQU 256(BX), Y3
+	VMOVDQU 288(BX), Y4
+	VPANDN  Y2, Y1, Y10
+	VPXOR   Y0, Y10, Y10
+	VMOVDQU Y10, 160(DI)
+	VPANDN  Y3, Y2, Y10
+	VPXOR   Y1, Y10, Y10
+	VMOVDQU Y10, 192(DI)
+	VPANDN  Y4, Y3, Y10
+	VPXOR   Y2, Y10, Y10
+	VMOVDQU Y10, 224(DI)
+	VPANDN  Y0, Y4, Y10
+	VPXOR   Y3, Y10, Y10
+	VMOVDQU Y10, 256(DI)
+	VPANDN  Y1, Y0, Y10
+	VPXOR   Y4, Y10, Y10
+	VMOVDQU Y10, 288(DI)
+	VMOVDQU 320(BX), Y0
+	VMOVDQU 352(BX), Y1
+	VMOVDQU 384(BX), Y2
+	VMOVDQU 416(BX), Y3
+	VMOVDQU 448(BX), Y4
+	VPANDN  Y2, Y1, Y10
+	VPXOR   Y0, Y10, Y10
+	VMOVDQU Y10, 320(DI)
+	VPANDN  Y3, Y2, Y10
+	VPXOR   Y1, Y10, Y10
+	VMOVDQU Y10, 352(DI)
+	VPANDN  Y4, Y3, Y10
+	VPXOR   Y2, Y10, Y10
+	VMOVDQU Y10, 384(DI)
+	VPANDN  Y0, Y4, Y10
+	VPXOR   Y3, Y10, Y10
+	VMOVDQU Y10, 416(DI)
+	VPANDN  Y1, Y0, Y10
+	VPXOR   Y4, Y10, Y10
+	VMOVDQU Y10, 448(DI)
+	VMOVDQU 480(BX), Y0
+	VMOVDQU 512(BX), Y1
+	VMOVDQU 544(BX), Y2
+	VMOVDQU 576(BX), Y3
+	VMOVDQU 608(BX), Y4
+	VPANDN  Y2, Y1, Y10
+	VPXOR   Y0, Y10, Y10
+	VMOVDQU Y10, 480(DI)
+	VPANDN  Y3, Y2, Y10
+	VPXOR   Y1, Y10, Y10
+	VMOVDQU Y10, 512(DI)
+	VPANDN  Y4, Y3, Y10
+	VPXOR   Y2, Y10, Y10
+	VMOVDQU Y10, 544(DI)
+	VPANDN  Y0, Y4, Y10
+	VPXOR   Y3, Y10, Y10
+	VMOVDQU Y10, 576(DI)
+	VPANDN  Y1, Y0, Y10
+	VPXOR   Y4, Y10, Y10
+	VMOVDQU Y10, 608(DI)
+	VMOVDQU 640(BX), Y0
+	VMOVDQU 672(BX), Y1
+	VMOVDQU 704(BX), Y2
+	VMOVDQU 736(BX), Y3
+	VMOVDQU 768(BX), Y4
+	VPANDN  Y2, Y1, Y10
+	VPXOR   Y0, Y10, Y10
+	VMOVDQU Y10, 640(DI)
+	VPANDN  Y3, Y2, Y10
+	VPXOR   Y1, Y10, Y10
+	VMOVDQU Y10, 672(DI)
+	VPANDN  Y4, Y3, Y10
+	VPXOR   Y2, Y10, Y10
+	VMOVDQU Y10, 704(DI)
+	VPANDN  Y0, Y4, Y10
+	VPXOR   Y3, Y10, Y10
+	VMOVDQU Y10, 736(DI)
+	VPANDN  Y1, Y0, Y10
+	VPXOR   Y4, Y10, Y10
+	VMOVDQU Y10, 768(DI)
+
+	ADDQ $8, SI
+	DECQ CX
+	JNZ  round
+
+	VZEROUPPER
+	RET
@@ -0,0 +1,13 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !amd64 || purego
+
+package keccak
+
+const accelerated = false
+
+func hashSections(out, in []byte, n int) {
+	hashSectionsGeneric(out, in, n)
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package keccak_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/keccak"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestHashSections(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 7, 8, 9, 64} {
+		t.Run(fmt.Sprintf("%d sections", n), func(t *testing.T) {
+			t.Parallel()
+
+			in := testutil.RandBytes(t, n*keccak.SectionSize)
+			out := make([]byte, n*keccak.HashSize)
+			keccak.HashSections(out, in)
+
+			for i := 0; i < n; i++ {
+				h := sha3.NewLegacyKeccak256()
+				_, _ = h.Write(in[i*keccak.SectionSize : (i+1)*keccak.SectionSize])
+				want := h.Sum(nil)
+				if got := out[i*keccak.HashSize : (i+1)*keccak.HashSize]; !bytes.Equal(got, want) {
+					t.Fatalf("section %d: got hash %x, want %x", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHashSectionsInvalid(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	keccak.HashSections(make([]byte, keccak.HashSize), make([]byte, keccak.SectionSize+1))
+}
+
+func BenchmarkHashSections(b *testing.B) {
+	in := make([]byte, 64*keccak.SectionSize)
+	out := make([]byte, 64*keccak.HashSize)
+	b.SetBytes(int64(len(in)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		keccak.HashSections(out, in)
+	}
+}
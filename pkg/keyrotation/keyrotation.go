@@ -0,0 +1,128 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package keyrotation rotates the keys of the node kept in the keystore.
+//
+// The rotation generates the new key and replaces the key in the keystore
+// atomically, keeping the replaced key under the keystore.PreviousName. The
+// new key is then read back from the keystore to verify it is persisted
+// before the node switches to it. The node may keep accepting the replaced
+// key during a grace period, e.g. for the pss messages sent to the replaced
+// key, while other keys, e.g. the libp2p key which determines the identity
+// of the node in the network, take effect only after the restart.
+package keyrotation
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/keystore"
+	"github.com/ethersphere/bee/v2/pkg/log"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "keyrotation"
+
+// ErrUnknownKey is returned when the key to be rotated is not registered.
+var ErrUnknownKey = errors.New("unknown key")
+
+// Key is the key of the node which can be rotated.
+type Key struct {
+	// Filename is the name of the key in the keystore.
+	Filename string
+	// EDG is the encoder/decoder/generator of the key.
+	EDG keystore.EDG
+	// Apply switches the node to the new key keeping the replaced key valid
+	// for the grace period. If nil, the key takes effect after the restart.
+	Apply func(key *ecdsa.PrivateKey, grace time.Duration)
+}
+
+// Rotation is the result of the key rotation.
+type Rotation struct {
+	// Name is the name of the rotated key.
+	Name string
+	// PublicKey is the public key of the new key.
+	PublicKey *ecdsa.PublicKey
+	// RestartRequired is whether the node must be restarted for the new key
+	// to take effect.
+	RestartRequired bool
+	// GraceUntil is the time until which the replaced key is still accepted,
+	// zero if the restart is required.
+	GraceUntil time.Time
+}
+
+// Service rotates the registered keys.
+type Service struct {
+	logger   log.Logger
+	keystore keystore.Service
+	password string
+
+	mu   sync.Mutex
+	keys map[string]Key
+}
+
+// New returns the key rotation service of the keys in the keystore
+// encrypted with the password.
+func New(logger log.Logger, ks keystore.Service, password string) *Service {
+	return &Service{
+		logger:   logger.WithName(loggerName).Register(),
+		keystore: ks,
+		password: password,
+		keys:     make(map[string]Key),
+	}
+}
+
+// Register adds the key which can be rotated under the name.
+func (s *Service) Register(name string, k Key) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys[name] = k
+}
+
+// Rotate replaces the key with the name with a newly generated one. The
+// replaced key is accepted for the grace period, if the key is applied
+// without the restart. The rotations are carried out one at a time.
+func (s *Service) Rotate(name string, grace time.Duration) (Rotation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k, ok := s.keys[name]
+	if !ok {
+		return Rotation{}, fmt.Errorf("%w: %s", ErrUnknownKey, name)
+	}
+
+	s.logger.Info("rotating key", "name", name)
+
+	pk, err := s.keystore.Rotate(k.Filename, s.password, k.EDG)
+	if err != nil {
+		return Rotation{}, fmt.Errorf("rotate %s key: %w", name, err)
+	}
+
+	stored, _, err := s.keystore.Key(k.Filename, s.password, k.EDG)
+	if err != nil {
+		return Rotation{}, fmt.Errorf("verify %s key: %w", name, err)
+	}
+	if !stored.Equal(pk) {
+		return Rotation{}, fmt.Errorf("verify %s key: stored key differs from the rotated one", name)
+	}
+
+	r := Rotation{
+		Name:            name,
+		PublicKey:       &pk.PublicKey,
+		RestartRequired: k.Apply == nil,
+	}
+	if k.Apply != nil {
+		k.Apply(pk, grace)
+		r.GraceUntil = time.Now().Add(grace)
+		s.logger.Info("key rotated", "name", name, "grace_until", r.GraceUntil)
+	} else {
+		s.logger.Warning("key rotated, restart the node for the new key to take effect", "name", name)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package keyrotation_test
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/keyrotation"
+	"github.com/ethersphere/bee/v2/pkg/keystore"
+	"github.com/ethersphere/bee/v2/pkg/keystore/mem"
+	"github.com/ethersphere/bee/v2/pkg/log"
+)
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+
+	const password = "password"
+
+	ks := mem.New()
+	pssKey, _, err := ks.Key("pss", password, crypto.EDGSecp256_K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ks.Key("libp2p_v2", password, crypto.EDGSecp256_R1); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		applied      *ecdsa.PrivateKey
+		appliedGrace time.Duration
+	)
+	s := keyrotation.New(log.Noop, ks, password)
+	s.Register("pss", keyrotation.Key{
+		Filename: "pss",
+		EDG:      crypto.EDGSecp256_K1,
+		Apply: func(key *ecdsa.PrivateKey, grace time.Duration) {
+			applied, appliedGrace = key, grace
+		},
+	})
+	s.Register("libp2p", keyrotation.Key{
+		Filename: "libp2p_v2",
+		EDG:      crypto.EDGSecp256_R1,
+	})
+
+	t.Run("applied", func(t *testing.T) {
+		r, err := s.Rotate("pss", time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.RestartRequired {
+			t.Fatal("restart required")
+		}
+		if r.GraceUntil.IsZero() {
+			t.Fatal("grace period not set")
+		}
+		if applied == nil || !applied.PublicKey.Equal(r.PublicKey) {
+			t.Fatal("new key not applied")
+		}
+		if appliedGrace != time.Hour {
+			t.Fatalf("got grace %s, want %s", appliedGrace, time.Hour)
+		}
+
+		previous, _, err := ks.Key(keystore.PreviousName("pss"), password, crypto.EDGSecp256_K1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !previous.Equal(pssKey) {
+			t.Fatal("replaced key not kept")
+		}
+	})
+
+	t.Run("restart required", func(t *testing.T) {
+		r, err := s.Rotate("libp2p", time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !r.RestartRequired {
+			t.Fatal("restart not required")
+		}
+
+		stored, _, err := ks.Key("libp2p_v2", password, crypto.EDGSecp256_R1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !stored.PublicKey.Equal(r.PublicKey) {
+			t.Fatal("new key not stored")
+		}
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		if _, err := s.Rotate("swarm", time.Hour); !errors.Is(err, keyrotation.ErrUnknownKey) {
+			t.Fatalf("got error %v, want %v", err, keyrotation.ErrUnknownKey)
+		}
+	})
+}
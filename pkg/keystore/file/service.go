@@ -13,24 +13,37 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/keystore"
 )
 
+// Storage keeps the encrypted private keys by their names.
+type Storage interface {
+	// Read returns the encrypted private key with the name
+	// or nil if the key does not exist.
+	Read(name string) ([]byte, error)
+	// Write atomically replaces the encrypted private key with the name.
+	Write(name string, data []byte) error
+}
+
 // Service is the file-based keystore.Service implementation.
 //
 // Keys are stored in directory where each private key is stored in a file,
 // which is encrypted with symmetric key using some password.
 type Service struct {
-	dir string
+	storage Storage
 }
 
 // New creates new file-based keystore.Service implementation.
 func New(dir string) *Service {
-	return &Service{dir: dir}
+	return NewWithStorage(&dirStorage{dir: dir})
 }
 
-func (s *Service) Exists(name string) (bool, error) {
-	filename := s.keyFilename(name)
+// NewWithStorage creates new keystore.Service implementation keeping the
+// private keys encrypted in the key file format in the storage.
+func NewWithStorage(storage Storage) *Service {
+	return &Service{storage: storage}
+}
 
-	data, err := os.ReadFile(filename)
-	if err != nil && !os.IsNotExist(err) {
+func (s *Service) Exists(name string) (bool, error) {
+	data, err := s.storage.Read(name)
+	if err != nil {
 		return false, fmt.Errorf("read private key: %w", err)
 	}
 	if len(data) == 0 {
@@ -51,13 +64,42 @@ func (s *Service) SetKey(name, password string, edg keystore.EDG) (*ecdsa.Privat
 		return nil, err
 	}
 
-	filename := s.keyFilename(name)
+	if err := s.storage.Write(name, d); err != nil {
+		return nil, err
+	}
 
-	if err := os.MkdirAll(filepath.Dir(filename), 0700); err != nil {
+	return pk, nil
+}
+
+func (s *Service) Rotate(name, password string, edg keystore.EDG) (*ecdsa.PrivateKey, error) {
+	data, err := s.storage.Read(name)
+	if err != nil {
+		return nil, fmt.Errorf("read private key: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, keystore.ErrKeyNotFound
+	}
+
+	// the key is rotated only by the one knowing the password
+	if _, err := decryptKey(data, password, edg); err != nil {
+		return nil, err
+	}
+
+	pk, err := edg.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+
+	d, err := encryptKey(pk, password, edg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.Write(keystore.PreviousName(name), data); err != nil {
 		return nil, err
 	}
 
-	if err := os.WriteFile(filename, d, 0600); err != nil {
+	if err := s.storage.Write(name, d); err != nil {
 		return nil, err
 	}
 
@@ -65,10 +107,8 @@ func (s *Service) SetKey(name, password string, edg keystore.EDG) (*ecdsa.Privat
 }
 
 func (s *Service) Key(name, password string, edg keystore.EDG) (pk *ecdsa.PrivateKey, created bool, err error) {
-	filename := s.keyFilename(name)
-
-	data, err := os.ReadFile(filename)
-	if err != nil && !os.IsNotExist(err) {
+	data, err := s.storage.Read(name)
+	if err != nil {
 		return nil, false, fmt.Errorf("read private key: %w", err)
 	}
 	if len(data) == 0 {
@@ -83,6 +123,49 @@ func (s *Service) Key(name, password string, edg keystore.EDG) (pk *ecdsa.Privat
 	return pk, false, nil
 }
 
-func (s *Service) keyFilename(name string) string {
+// dirStorage keeps each encrypted private key in a file in the directory.
+type dirStorage struct {
+	dir string
+}
+
+func (s *dirStorage) Read(name string) ([]byte, error) {
+	data, err := os.ReadFile(s.keyFilename(name))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Write writes the data to the temporary file which is then renamed to
+// the key file, so that the file is replaced atomically.
+func (s *dirStorage) Write(name string, data []byte) error {
+	filename := s.keyFilename(name)
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), filename)
+}
+
+func (s *dirStorage) keyFilename(name string) string {
 	return filepath.Join(s.dir, fmt.Sprintf("%s.key", name))
 }
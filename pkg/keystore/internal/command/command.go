@@ -0,0 +1,39 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package command runs the external tools the keystore backends
+// use to access the keychains and the security tokens.
+package command
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Func runs the named program with the arguments and the standard input and
+// returns its standard output and the exit code. The error is returned when
+// the program could not be run or it exited with the non-zero code.
+type Func func(stdin []byte, name string, args ...string) (stdout []byte, code int, err error)
+
+// Run runs the program in the operating system.
+func Run(stdin []byte, name string, args ...string) ([]byte, int, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return stdout.Bytes(), exitErr.ExitCode(), fmt.Errorf("%s: exit code %d: %s", name, exitErr.ExitCode(), strings.TrimSpace(stderr.String()))
+	}
+	if err != nil {
+		return nil, -1, fmt.Errorf("%s: %w", name, err)
+	}
+	return stdout.Bytes(), 0, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package keychain provides the keystore.Service keeping the private keys in
+// the keychain of the operating system instead of the data directory. The
+// keys are kept encrypted with the password in the same format as in the
+// file keystore. The keychain is accessed with the security tool on macOS
+// and with the secret-tool of the libsecret on Linux.
+package keychain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/ethersphere/bee/v2/pkg/keystore/file"
+	"github.com/ethersphere/bee/v2/pkg/keystore/internal/command"
+)
+
+// ErrUnsupported is returned when the keychain of the
+// operating system is not supported.
+var ErrUnsupported = errors.New("keychain not supported on this operating system")
+
+// securityItemNotFound is the exit code of the security tool
+// when the item is not found in the keychain.
+const securityItemNotFound = 44
+
+// New returns the keystore keeping the keys in the keychain under the service
+// name, which distinguishes the keys of the nodes running on the same host.
+func New(service string) (*file.Service, error) {
+	s, err := newStorage(runtime.GOOS, service, command.Run)
+	if err != nil {
+		return nil, err
+	}
+	return file.NewWithStorage(s), nil
+}
+
+func newStorage(goos, service string, run command.Func) (file.Storage, error) {
+	switch goos {
+	case "darwin":
+		return &securityStorage{service: service, run: run}, nil
+	case "linux":
+		return &secretToolStorage{service: service, run: run}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, goos)
+	}
+}
+
+// securityStorage keeps the keys as the generic passwords
+// in the macOS keychain using the security tool.
+type securityStorage struct {
+	service string
+	run     command.Func
+}
+
+func (s *securityStorage) Read(name string) ([]byte, error) {
+	out, code, err := s.run(nil, "security", "find-generic-password", "-s", s.service, "-a", name, "-w")
+	if code == securityItemNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(out, "\n"), nil
+}
+
+// Write adds or updates the item in place, the update is atomic.
+func (s *securityStorage) Write(name string, data []byte) error {
+	_, _, err := s.run(nil, "security", "add-generic-password", "-U", "-s", s.service, "-a", name, "-l", s.label(name), "-w", string(data))
+	return err
+}
+
+func (s *securityStorage) label(name string) string {
+	return fmt.Sprintf("%s %s key", s.service, name)
+}
+
+// secretToolStorage keeps the keys as the secrets in the
+// Secret Service, e.g. the GNOME Keyring, using the secret-tool.
+type secretToolStorage struct {
+	service string
+	run     command.Func
+}
+
+func (s *secretToolStorage) Read(name string) ([]byte, error) {
+	out, code, err := s.run(nil, "secret-tool", "lookup", "service", s.service, "account", name)
+	// the secret-tool exits with the code 1 and no output when the secret is not found
+	if code == 1 && len(out) == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(out, "\n"), nil
+}
+
+// Write stores the secret replacing the one with the same attributes, the
+// replacement is atomic. The secret is passed on the standard input.
+func (s *secretToolStorage) Write(name string, data []byte) error {
+	_, _, err := s.run(data, "secret-tool", "store", "--label", s.label(name), "service", s.service, "account", name)
+	return err
+}
+
+func (s *secretToolStorage) label(name string) string {
+	return fmt.Sprintf("%s %s key", s.service, name)
+}
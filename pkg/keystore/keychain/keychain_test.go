@@ -0,0 +1,68 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package keychain_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/keystore/file"
+	"github.com/ethersphere/bee/v2/pkg/keystore/keychain"
+	"github.com/ethersphere/bee/v2/pkg/keystore/test"
+)
+
+// fakeKeychain emulates the security tool and the secret-tool.
+type fakeKeychain map[string]string
+
+func (k fakeKeychain) run(stdin []byte, name string, args ...string) ([]byte, int, error) {
+	switch {
+	case name == "security" && len(args) == 6 && args[0] == "find-generic-password":
+		v, ok := k[args[2]+"/"+args[4]]
+		if !ok {
+			return nil, 44, errors.New("security: item not found")
+		}
+		return []byte(v + "\n"), 0, nil
+	case name == "security" && len(args) == 10 && args[0] == "add-generic-password":
+		k[args[3]+"/"+args[5]] = args[9]
+		return nil, 0, nil
+	case name == "secret-tool" && len(args) == 5 && args[0] == "lookup":
+		v, ok := k[args[2]+"/"+args[4]]
+		if !ok {
+			return nil, 1, errors.New("secret-tool: exit code 1")
+		}
+		return []byte(v), 0, nil
+	case name == "secret-tool" && len(args) == 7 && args[0] == "store":
+		k[args[4]+"/"+args[6]] = string(stdin)
+		return nil, 0, nil
+	}
+	return nil, 2, fmt.Errorf("unexpected command %s %v", name, args)
+}
+
+func TestService(t *testing.T) {
+	t.Parallel()
+
+	for _, goos := range []string{"darwin", "linux"} {
+		t.Run(goos, func(t *testing.T) {
+			t.Parallel()
+
+			storage, err := keychain.NewStorage(goos, "bee", fakeKeychain{}.run)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.Service(t, file.NewWithStorage(storage), crypto.EDGSecp256_K1)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := keychain.NewStorage("plan9", "bee", fakeKeychain{}.run)
+		if !errors.Is(err, keychain.ErrUnsupported) {
+			t.Fatalf("got error %v, want %v", err, keychain.ErrUnsupported)
+		}
+	})
+}
@@ -13,6 +13,15 @@ import (
 // private key is stored is not valid.
 var ErrInvalidPassword = errors.New("invalid password")
 
+// ErrKeyNotFound is returned when the private key to be rotated does not exist.
+var ErrKeyNotFound = errors.New("key not found")
+
+// PreviousName returns the name under which the private key replaced by the
+// rotation of the key with the specified name is kept.
+func PreviousName(name string) string {
+	return name + ".previous"
+}
+
 // EDG represents and encoder/decoder/generator for ECDSA private keys
 type EDG interface {
 	Generate() (*ecdsa.PrivateKey, error)
@@ -31,4 +40,9 @@ type Service interface {
 	Exists(name string) (bool, error)
 	// SetKey generates and persists a new private key
 	SetKey(name, password string, edg EDG) (*ecdsa.PrivateKey, error)
+	// Rotate replaces the existing private key for a specified name with
+	// a newly generated one. The replaced key is kept under the PreviousName.
+	// The key is replaced atomically, so that either the replaced or the new
+	// key is stored under the name at any time.
+	Rotate(name, password string, edg EDG) (*ecdsa.PrivateKey, error)
 }
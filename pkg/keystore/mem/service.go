@@ -55,6 +55,33 @@ func (s *Service) SetKey(name, password string, edg keystore.EDG) (*ecdsa.Privat
 	return pk, nil
 }
 
+func (s *Service) Rotate(name, password string, edg keystore.EDG) (*ecdsa.PrivateKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k, ok := s.m[name]
+	if !ok {
+		return nil, keystore.ErrKeyNotFound
+	}
+
+	if k.password != password {
+		return nil, keystore.ErrInvalidPassword
+	}
+
+	pk, err := edg.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+
+	s.m[keystore.PreviousName(name)] = k
+	s.m[name] = key{
+		pk:       pk,
+		password: password,
+	}
+
+	return pk, nil
+}
+
 func (s *Service) Key(name, password string, edg keystore.EDG) (pk *ecdsa.PrivateKey, created bool, err error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
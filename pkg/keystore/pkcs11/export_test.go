@@ -0,0 +1,14 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pkcs11
+
+import (
+	"github.com/ethersphere/bee/v2/pkg/keystore/file"
+	"github.com/ethersphere/bee/v2/pkg/keystore/internal/command"
+)
+
+func NewStorage(o Options, run command.Func) file.Storage {
+	return newStorage(o, run)
+}
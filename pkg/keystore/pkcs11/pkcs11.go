@@ -0,0 +1,147 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pkcs11 provides the keystore.Service keeping the private keys on
+// the PKCS#11 token, e.g. the hardware security module, instead of the data
+// directory. The keys are kept encrypted with the password in the same
+// format as in the file keystore as the private data objects on the token,
+// which are accessed with the pkcs11-tool of the OpenSC.
+//
+// The token objects cannot be replaced in place, so each write creates the
+// object with the next version in the label and removes the older versions
+// afterwards. The read uses the latest version, so that the key is replaced
+// atomically.
+package pkcs11
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/keystore/file"
+	"github.com/ethersphere/bee/v2/pkg/keystore/internal/command"
+)
+
+// Options are the options of the access to the token.
+type Options struct {
+	// Module is the path of the PKCS#11 module library of the token.
+	Module string
+	// Slot is the slot of the token, the first slot with the token if empty.
+	Slot string
+	// PIN is the user PIN of the token.
+	PIN string
+	// Namespace prefixes the labels of the objects, which distinguishes
+	// the keys of the nodes sharing the same token.
+	Namespace string
+}
+
+// New returns the keystore keeping the keys on the token.
+func New(o Options) *file.Service {
+	return file.NewWithStorage(newStorage(o, command.Run))
+}
+
+type storage struct {
+	o   Options
+	run command.Func
+}
+
+func newStorage(o Options, run command.Func) *storage {
+	return &storage{o: o, run: run}
+}
+
+func (s *storage) Read(name string) ([]byte, error) {
+	versions, err := s.versions(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	out, _, err := s.tool(nil, "--read-object", "--type", "data", "--label", s.label(name, versions[len(versions)-1]))
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *storage) Write(name string, data []byte) error {
+	versions, err := s.versions(name)
+	if err != nil {
+		return err
+	}
+	next := 1
+	if len(versions) > 0 {
+		next = versions[len(versions)-1] + 1
+	}
+
+	f, err := os.CreateTemp("", "bee-pkcs11-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if _, _, err := s.tool(nil, "--write-object", f.Name(), "--type", "data", "--label", s.label(name, next), "--private"); err != nil {
+		return err
+	}
+
+	// the older versions which could not be removed
+	// now are removed on the next write
+	for _, v := range versions {
+		_, _, _ = s.tool(nil, "--delete-object", "--type", "data", "--label", s.label(name, v))
+	}
+	return nil
+}
+
+// versions returns the sorted versions of the objects of the key with the name.
+func (s *storage) versions(name string) ([]int, error) {
+	out, _, err := s.tool(nil, "--list-objects", "--type", "data")
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := s.label(name, 0)
+	prefix = prefix[:len(prefix)-1]
+
+	var versions []int
+	for _, line := range strings.Split(string(out), "\n") {
+		label, ok := strings.CutPrefix(strings.TrimSpace(line), "label:")
+		if !ok {
+			continue
+		}
+		label = strings.Trim(strings.TrimSpace(label), "'")
+		v, ok := strings.CutPrefix(label, prefix)
+		if !ok {
+			continue
+		}
+		version, err := strconv.Atoi(v)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+	return versions, nil
+}
+
+func (s *storage) label(name string, version int) string {
+	return fmt.Sprintf("%s/%s#%d", s.o.Namespace, name, version)
+}
+
+func (s *storage) tool(stdin []byte, args ...string) ([]byte, int, error) {
+	a := []string{"--module", s.o.Module, "--login", "--pin", s.o.PIN}
+	if s.o.Slot != "" {
+		a = append(a, "--slot", s.o.Slot)
+	}
+	return s.run(stdin, "pkcs11-tool", append(a, args...)...)
+}
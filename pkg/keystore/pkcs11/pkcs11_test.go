@@ -0,0 +1,85 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pkcs11_test
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/keystore/file"
+	"github.com/ethersphere/bee/v2/pkg/keystore/pkcs11"
+	"github.com/ethersphere/bee/v2/pkg/keystore/test"
+)
+
+// fakeToken emulates the pkcs11-tool accessing the token.
+type fakeToken struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (k *fakeToken) run(_ []byte, name string, args ...string) ([]byte, int, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if name != "pkcs11-tool" || len(args) < 5 || args[0] != "--module" || args[2] != "--login" || args[3] != "--pin" || args[4] != "1234" {
+		return nil, 1, fmt.Errorf("unexpected command %s %v", name, args)
+	}
+	args = args[5:]
+
+	switch args[0] {
+	case "--list-objects":
+		var out strings.Builder
+		for label := range k.objects {
+			fmt.Fprintf(&out, "Data object 1\n  label:          '%s'\n  flags:          modifiable private\n", label)
+		}
+		return []byte(out.String()), 0, nil
+	case "--read-object":
+		data, ok := k.objects[args[4]]
+		if !ok {
+			return nil, 1, fmt.Errorf("object %s not found", args[4])
+		}
+		return data, 0, nil
+	case "--write-object":
+		data, err := os.ReadFile(args[1])
+		if err != nil {
+			return nil, 1, err
+		}
+		k.objects[args[5]] = data
+		return nil, 0, nil
+	case "--delete-object":
+		delete(k.objects, args[4])
+		return nil, 0, nil
+	}
+	return nil, 1, fmt.Errorf("unexpected command %s %v", name, args)
+}
+
+func TestService(t *testing.T) {
+	t.Parallel()
+
+	token := &fakeToken{objects: make(map[string][]byte)}
+	storage := pkcs11.NewStorage(pkcs11.Options{
+		Module:    "/usr/lib/softhsm/libsofthsm2.so",
+		PIN:       "1234",
+		Namespace: "bee",
+	}, token.run)
+
+	test.Service(t, file.NewWithStorage(storage), crypto.EDGSecp256_K1)
+
+	// only the latest version of the rotated key is kept
+	labels := make([]string, 0, len(token.objects))
+	for label := range token.objects {
+		labels = append(labels, label)
+	}
+	slices.Sort(labels)
+	want := []string{"bee/libp2p#2", "bee/libp2p.previous#1", "bee/swarm#1"}
+	if !slices.Equal(labels, want) {
+		t.Fatalf("got objects %v, want %v", labels, want)
+	}
+}
@@ -85,4 +85,47 @@ func Service(t *testing.T, s keystore.Service, edg keystore.EDG) {
 	if !bytes.Equal(k3.D.Bytes(), k4.D.Bytes()) {
 		t.Fatal("two keys are not equal")
 	}
+
+	// rotate libp2p key
+	k5, err := s.Rotate("libp2p", "p2p pass", edg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(k3.D.Bytes(), k5.D.Bytes()) {
+		t.Fatal("rotated key is equal to the replaced one")
+	}
+
+	k6, created, err := s.Key("libp2p", "p2p pass", edg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created {
+		t.Fatal("key is created, but should not be")
+	}
+	if !bytes.Equal(k5.D.Bytes(), k6.D.Bytes()) {
+		t.Fatal("rotated key is not stored")
+	}
+
+	k7, created, err := s.Key(keystore.PreviousName("libp2p"), "p2p pass", edg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created {
+		t.Fatal("previous key is created, but should be kept")
+	}
+	if !bytes.Equal(k3.D.Bytes(), k7.D.Bytes()) {
+		t.Fatal("replaced key is not kept")
+	}
+
+	// rotate with invalid password
+	_, err = s.Rotate("libp2p", "invalid password", edg)
+	if !errors.Is(err, keystore.ErrInvalidPassword) {
+		t.Fatal(err)
+	}
+
+	// rotate missing key
+	_, err = s.Rotate("pss", "pss pass", edg)
+	if !errors.Is(err, keystore.ErrKeyNotFound) {
+		t.Fatal(err)
+	}
 }
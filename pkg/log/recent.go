@@ -0,0 +1,67 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// RecentSink is the sink which keeps the most recent log lines in memory,
+// so they can be inspected on the running node. It is meant to be combined
+// with the primary sink by the io.MultiWriter.
+type RecentSink struct {
+	mu    sync.Mutex
+	lines [][]byte
+	next  int
+	full  bool
+}
+
+// NewRecentSink returns the sink which keeps the last n lines.
+func NewRecentSink(n int) *RecentSink {
+	return &RecentSink{lines: make([][]byte, max(n, 1))}
+}
+
+// Write implements the io.Writer interface.
+func (s *RecentSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, line := range bytes.SplitAfter(p, []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		s.lines[s.next] = append(s.lines[s.next][:0], line...)
+		s.next++
+		if s.next == len(s.lines) {
+			s.next = 0
+			s.full = true
+		}
+	}
+	return len(p), nil
+}
+
+// WriteTo writes the kept lines from the oldest to the newest to w.
+func (s *RecentSink) WriteTo(w io.Writer) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var lines [][]byte
+	if s.full {
+		lines = append(lines, s.lines[s.next:]...)
+	}
+	lines = append(lines, s.lines[:s.next]...)
+
+	var total int64
+	for _, line := range lines {
+		n, err := w.Write(line)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+)
+
+func TestRecentSink(t *testing.T) {
+	t.Parallel()
+
+	s := log.NewRecentSink(3)
+
+	read := func(t *testing.T) string {
+		t.Helper()
+		buf := new(bytes.Buffer)
+		if _, err := s.WriteTo(buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	if have := read(t); have != "" {
+		t.Fatalf("want no lines, have %q", have)
+	}
+
+	_, _ = s.Write([]byte("one\n"))
+	_, _ = s.Write([]byte("two\nthree\n"))
+	if have, want := read(t), "one\ntwo\nthree\n"; have != want {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+
+	_, _ = s.Write([]byte("four\n"))
+	_, _ = s.Write([]byte("five\n"))
+	if have, want := read(t), "three\nfour\nfive\n"; have != want {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+
+	// the line passed to the sink is not retained
+	line := []byte("six\n")
+	_, _ = s.Write(line)
+	copy(line, "xxx")
+	if have, want := read(t), "four\nfive\nsix\n"; have != want {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+}
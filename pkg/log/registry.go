@@ -10,6 +10,7 @@ import (
 	"os"
 	"regexp"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 )
@@ -114,6 +115,7 @@ func SetVerbosity(l Logger, v Level) error {
 func SetVerbosityByExp(e string, v Level) error {
 	val, ok := loggers.Load(e)
 	if ok {
+		cancelRevert(e)
 		val.(*logger).setVerbosity(v)
 		return nil
 	}
@@ -126,6 +128,7 @@ func SetVerbosityByExp(e string, v Level) error {
 	var merr *multierror.Error
 	loggers.Range(func(key, val interface{}) bool {
 		if rex.MatchString(key.(string)) {
+			cancelRevert(key.(string))
 			merr = multierror.Append(merr, SetVerbosity(val.(*logger), v))
 		}
 		return true
@@ -133,6 +136,96 @@ func SetVerbosityByExp(e string, v Level) error {
 	return merr.ErrorOrNil()
 }
 
+// reverts holds the pending reverts of the temporary
+// verbosity changes keyed by the logger id.
+var reverts = struct {
+	sync.Mutex
+	m map[string]*revert
+}{m: make(map[string]*revert)}
+
+// revert restores the verbosity of the logger
+// before its temporary verbosity change.
+type revert struct {
+	timer *time.Timer
+	at    time.Time
+	prev  Level // verbosity before the first temporary change.
+	set   Level // verbosity set by the last temporary change.
+}
+
+// cancelRevert cancels the pending revert of the logger with the id.
+func cancelRevert(id string) {
+	reverts.Lock()
+	defer reverts.Unlock()
+	if r, ok := reverts.m[id]; ok {
+		r.timer.Stop()
+		delete(reverts.m, id)
+	}
+}
+
+// SetVerbosityByExpFor sets all loggers that match the given expression e,
+// which can be a logger id or a regular expression, to the given verbosity
+// level v for the duration d. After the duration, the loggers are reverted
+// to the verbosity they had before, unless it was changed in the meantime.
+// An error is returned if e fails to compile.
+func SetVerbosityByExpFor(e string, v Level, d time.Duration) error {
+	var matched []*logger
+	if val, ok := loggers.Load(e); ok {
+		matched = append(matched, val.(*logger))
+	} else {
+		rex, err := regexp.Compile(e)
+		if err != nil {
+			return err
+		}
+		loggers.Range(func(key, val interface{}) bool {
+			if rex.MatchString(key.(string)) {
+				matched = append(matched, val.(*logger))
+			}
+			return true
+		})
+	}
+
+	reverts.Lock()
+	defer reverts.Unlock()
+
+	var merr *multierror.Error
+	for _, l := range matched {
+		prev := l.verbosity.get()
+		if r, ok := reverts.m[l.id]; ok {
+			r.timer.Stop()
+			prev = r.prev
+		}
+		if err := SetVerbosity(l, v); err != nil {
+			merr = multierror.Append(merr, err)
+			continue
+		}
+		r := &revert{at: time.Now().Add(d), prev: prev, set: l.verbosity.get()}
+		r.timer = time.AfterFunc(d, func() {
+			reverts.Lock()
+			defer reverts.Unlock()
+			if reverts.m[l.id] != r {
+				return
+			}
+			delete(reverts.m, l.id)
+			if l.verbosity.get() == r.set {
+				l.setVerbosity(r.prev)
+			}
+		})
+		reverts.m[l.id] = r
+	}
+	return merr.ErrorOrNil()
+}
+
+// VerbosityRevertTime returns the time when the temporary verbosity
+// of the logger with the given id is reverted, if there is one.
+func VerbosityRevertTime(id string) (time.Time, bool) {
+	reverts.Lock()
+	defer reverts.Unlock()
+	if r, ok := reverts.m[id]; ok {
+		return r.at, true
+	}
+	return time.Time{}, false
+}
+
 // RegistryIterate iterates through all registered loggers.
 func RegistryIterate(fn func(id, path string, verbosity Level, v uint) (next bool)) {
 	loggers.Range(func(_, val interface{}) bool {
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -168,6 +169,98 @@ func TestSetVerbosity(t *testing.T) {
 	}
 }
 
+func TestSetVerbosityByExpFor(t *testing.T) {
+	l, o := loggers, defaults.options
+	t.Cleanup(func() {
+		loggers = l
+		defaults.pin = sync.Once{}
+		defaults.options = o
+	})
+
+	loggers = new(sync.Map)
+	defaults.options = new(Options)
+	ModifyDefaults(opts...)
+
+	root := NewLogger("root").Register().(*logger)
+	child := NewLogger("root").WithName("child1").Register().(*logger)
+	other := NewLogger("other").Register().(*logger)
+
+	waitVerbosity := func(t *testing.T, l *logger, want Level) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for l.verbosity.get() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("want verbosity: %q; have: %q", want, l.verbosity.get())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	t.Run("revert after timeout", func(t *testing.T) {
+		if err := SetVerbosityByExp(".*", VerbosityWarning); err != nil {
+			t.Fatal(err)
+		}
+		if err := SetVerbosityByExpFor("^root", VerbosityDebug, 100*time.Millisecond); err != nil {
+			t.Fatalf("SetVerbosityByExpFor(...) unexpected error %v", err)
+		}
+		for _, l := range []*logger{root, child} {
+			if want, have := VerbosityDebug, l.verbosity.get(); want != have {
+				t.Errorf("SetVerbosityByExpFor(...) want verbosity: %q; have: %q", want, have)
+			}
+			if _, ok := VerbosityRevertTime(l.id); !ok {
+				t.Errorf("VerbosityRevertTime(%q) want pending revert", l.id)
+			}
+		}
+		if want, have := VerbosityWarning, other.verbosity.get(); want != have {
+			t.Errorf("SetVerbosityByExpFor(...) want verbosity: %q; have: %q", want, have)
+		}
+
+		waitVerbosity(t, root, VerbosityWarning)
+		waitVerbosity(t, child, VerbosityWarning)
+		if _, ok := VerbosityRevertTime(root.id); ok {
+			t.Errorf("VerbosityRevertTime(%q) want no pending revert", root.id)
+		}
+	})
+
+	t.Run("overlapping changes revert to original", func(t *testing.T) {
+		if err := SetVerbosityByExp(".*", VerbosityError); err != nil {
+			t.Fatal(err)
+		}
+		if err := SetVerbosityByExpFor(other.id, VerbosityInfo, time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		if err := SetVerbosityByExpFor(other.id, VerbosityDebug, 100*time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+		waitVerbosity(t, other, VerbosityError)
+	})
+
+	t.Run("permanent change cancels revert", func(t *testing.T) {
+		if err := SetVerbosityByExp(".*", VerbosityError); err != nil {
+			t.Fatal(err)
+		}
+		if err := SetVerbosityByExpFor(other.id, VerbosityDebug, 50*time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+		if err := SetVerbosityByExp(other.id, VerbosityInfo); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := VerbosityRevertTime(other.id); ok {
+			t.Errorf("VerbosityRevertTime(%q) want no pending revert", other.id)
+		}
+		time.Sleep(150 * time.Millisecond)
+		if want, have := VerbosityInfo, other.verbosity.get(); want != have {
+			t.Errorf("want verbosity: %q; have: %q", want, have)
+		}
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		if err := SetVerbosityByExpFor("[", VerbosityDebug, time.Minute); err == nil {
+			t.Error("SetVerbosityByExpFor(...) want error")
+		}
+	})
+}
+
 func TestRegistryRange(t *testing.T) {
 	l, o := loggers, defaults.options
 	t.Cleanup(func() {
@@ -0,0 +1,291 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package maintenance schedules the maintenance windows of the node. During
+// a window the registered activities, e.g. the pullsync and the cashouts,
+// are paused and they are resumed after the window closes. The maintenance
+// may also be entered and left manually through the API, regardless of the
+// configured windows.
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "maintenance"
+
+// checkInterval is the interval in which the windows are checked
+// for the maintenance to be entered or left.
+const checkInterval = 10 * time.Second
+
+// ErrInvalidWindow is returned when the maintenance window is malformed.
+var ErrInvalidWindow = errors.New("invalid maintenance window")
+
+// Activity is the activity of the node which is
+// paused during the maintenance windows.
+type Activity interface {
+	Pause()
+	Resume()
+}
+
+// Funcs is the activity calling the functions on the pause and the resume.
+type Funcs struct {
+	OnPause  func()
+	OnResume func()
+}
+
+// Pause implements the Activity interface.
+func (f Funcs) Pause() { f.OnPause() }
+
+// Resume implements the Activity interface.
+func (f Funcs) Resume() { f.OnResume() }
+
+// Window is a daily window of the local time starting at the From offset
+// and ending at the To offset from the midnight. The window may wrap around
+// midnight.
+type Window struct {
+	From time.Duration
+	To   time.Duration
+}
+
+// ParseWindows parses the comma separated windows in the "hh:mm-hh:mm"
+// format, e.g. "02:00-04:30,23:00-01:00". The empty string is parsed as no
+// windows.
+func ParseWindows(s string) ([]Window, error) {
+	var windows []Window
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(v, "-")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidWindow, v)
+		}
+		f, err := parseClock(from)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidWindow, v)
+		}
+		t, err := parseClock(to)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidWindow, v)
+		}
+		if f == t {
+			return nil, fmt.Errorf("%w: %q is empty", ErrInvalidWindow, v)
+		}
+		windows = append(windows, Window{From: f, To: t})
+	}
+	return windows, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// String returns the window in the format accepted by the ParseWindows.
+func (w Window) String() string {
+	return fmt.Sprintf("%s-%s", formatClock(w.From), formatClock(w.To))
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// Contains reports whether the given time is within the window.
+func (w Window) Contains(t time.Time) bool {
+	d := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if w.From < w.To {
+		return d >= w.From && d < w.To
+	}
+	return d >= w.From || d < w.To
+}
+
+// FormatWindows returns the windows in the format accepted by the ParseWindows.
+func FormatWindows(windows []Window) string {
+	s := make([]string, len(windows))
+	for i, w := range windows {
+		s[i] = w.String()
+	}
+	return strings.Join(s, ",")
+}
+
+// Status is the maintenance state of the node.
+type Status struct {
+	// Active is whether the node is in the maintenance.
+	Active bool
+	// Override is the manually requested maintenance state
+	// which takes precedence over the windows, nil if unset.
+	Override *bool
+	// Windows are the configured maintenance windows.
+	Windows []Window
+	// Activities are the names of the activities
+	// paused during the maintenance.
+	Activities []string
+}
+
+// Scheduler enters and leaves the maintenance as per the windows.
+type Scheduler struct {
+	logger log.Logger
+	now    func() time.Time
+
+	mu         sync.Mutex
+	windows    []Window
+	override   *bool
+	active     bool
+	activities map[string]Activity
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New returns the scheduler of the maintenance windows.
+func New(logger log.Logger, windows []Window) *Scheduler {
+	return &Scheduler{
+		logger:     logger.WithName(loggerName).Register(),
+		now:        time.Now,
+		windows:    windows,
+		activities: make(map[string]Activity),
+		quit:       make(chan struct{}),
+	}
+}
+
+// Register adds the activity paused during the maintenance. The activity
+// is paused immediately if the node is already in the maintenance.
+func (s *Scheduler) Register(name string, a Activity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.activities[name] = a
+	if s.active {
+		a.Pause()
+	}
+}
+
+// Start starts checking the windows.
+func (s *Scheduler) Start() {
+	s.update()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.quit:
+				return
+			case <-ticker.C:
+				s.update()
+			}
+		}
+	}()
+}
+
+// Status returns the current maintenance state.
+func (s *Scheduler) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := Status{
+		Active:     s.active,
+		Windows:    slices.Clone(s.windows),
+		Activities: make([]string, 0, len(s.activities)),
+	}
+	if s.override != nil {
+		v := *s.override
+		st.Override = &v
+	}
+	for name := range s.activities {
+		st.Activities = append(st.Activities, name)
+	}
+	slices.Sort(st.Activities)
+	return st
+}
+
+// SetWindows replaces the maintenance windows.
+func (s *Scheduler) SetWindows(windows []Window) {
+	s.mu.Lock()
+	s.windows = slices.Clone(windows)
+	s.mu.Unlock()
+
+	s.update()
+}
+
+// SetOverride manually enters or leaves the maintenance regardless of
+// the windows. The nil override returns the control to the windows.
+func (s *Scheduler) SetOverride(override *bool) {
+	s.mu.Lock()
+	s.override = override
+	s.mu.Unlock()
+
+	s.update()
+}
+
+// update enters or leaves the maintenance as per
+// the override and the windows at the current time.
+func (s *Scheduler) update() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active := false
+	if s.override != nil {
+		active = *s.override
+	} else {
+		now := s.now()
+		for _, w := range s.windows {
+			if w.Contains(now) {
+				active = true
+				break
+			}
+		}
+	}
+	if active == s.active {
+		return
+	}
+
+	s.active = active
+	for _, a := range s.activities {
+		if active {
+			a.Pause()
+		} else {
+			a.Resume()
+		}
+	}
+	if active {
+		s.logger.Info("maintenance entered")
+	} else {
+		s.logger.Info("maintenance left")
+	}
+}
+
+// Close stops checking the windows and resumes the paused activities.
+func (s *Scheduler) Close() error {
+	close(s.quit)
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active {
+		s.active = false
+		for _, a := range s.activities {
+			a.Resume()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintenance_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/maintenance"
+)
+
+type activity struct {
+	paused  bool
+	changes int
+}
+
+func (a *activity) Pause()  { a.paused = true; a.changes++ }
+func (a *activity) Resume() { a.paused = false; a.changes++ }
+
+func TestParseWindows(t *testing.T) {
+	t.Parallel()
+
+	windows, err := maintenance.ParseWindows(" 02:00-04:30, 23:00-01:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := maintenance.FormatWindows(windows), "02:00-04:30,23:00-01:00"; have != want {
+		t.Fatalf("have windows %q, want %q", have, want)
+	}
+
+	if windows, err := maintenance.ParseWindows(""); err != nil || len(windows) != 0 {
+		t.Fatalf("empty windows: have %v %v", windows, err)
+	}
+
+	for _, s := range []string{"02:00", "2-4", "02:00-24:00", "02:00-02:00", "02:00-04:00,x"} {
+		if _, err := maintenance.ParseWindows(s); !errors.Is(err, maintenance.ErrInvalidWindow) {
+			t.Fatalf("%q: want error %v, have %v", s, maintenance.ErrInvalidWindow, err)
+		}
+	}
+}
+
+func TestWindowContains(t *testing.T) {
+	t.Parallel()
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 1, 1, hour, minute, 0, 0, time.Local)
+	}
+	windows, err := maintenance.ParseWindows("02:00-04:30,23:00-01:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		window int
+		time   time.Time
+		want   bool
+	}{
+		{0, at(1, 59), false},
+		{0, at(2, 0), true},
+		{0, at(4, 29), true},
+		{0, at(4, 30), false},
+		{1, at(22, 59), false},
+		{1, at(23, 0), true},
+		{1, at(0, 30), true},
+		{1, at(1, 0), false},
+	} {
+		if have := windows[tc.window].Contains(tc.time); have != tc.want {
+			t.Errorf("window %s at %s: have %v, want %v", windows[tc.window], tc.time.Format("15:04"), have, tc.want)
+		}
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 1, 0, 0, 0, time.Local)
+	windows, err := maintenance.ParseWindows("02:00-04:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := maintenance.New(log.Noop, windows)
+	s.SetNow(func() time.Time { return now })
+
+	pullsync := new(activity)
+	s.Register("pullsync", pullsync)
+	s.Start()
+
+	if st := s.Status(); st.Active || pullsync.paused {
+		t.Fatalf("before window: have status %+v, paused %v", st, pullsync.paused)
+	}
+
+	now = now.Add(time.Hour)
+	s.Update()
+	if st := s.Status(); !st.Active || !pullsync.paused {
+		t.Fatalf("within window: have status %+v, paused %v", st, pullsync.paused)
+	}
+
+	// the activities registered during the maintenance are paused immediately
+	cashout := new(activity)
+	s.Register("cashout", cashout)
+	if !cashout.paused {
+		t.Fatal("want cashout paused")
+	}
+	if have := s.Status().Activities; len(have) != 2 || have[0] != "cashout" || have[1] != "pullsync" {
+		t.Fatalf("have activities %v", have)
+	}
+
+	off := false
+	s.SetOverride(&off)
+	if st := s.Status(); st.Active || pullsync.paused || cashout.paused {
+		t.Fatalf("override off: have status %+v", st)
+	}
+
+	s.SetOverride(nil)
+	if !s.Status().Active || !pullsync.paused {
+		t.Fatal("want maintenance back as per window")
+	}
+
+	s.SetWindows(nil)
+	if s.Status().Active || pullsync.paused {
+		t.Fatal("want maintenance left without windows")
+	}
+
+	on := true
+	s.SetOverride(&on)
+	if !s.Status().Active || !pullsync.paused {
+		t.Fatal("want maintenance entered by override")
+	}
+	changes := pullsync.changes
+	s.Update()
+	if pullsync.changes != changes {
+		t.Fatal("want no change without state change")
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if pullsync.paused || cashout.paused {
+		t.Fatal("want activities resumed on close")
+	}
+}
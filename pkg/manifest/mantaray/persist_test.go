@@ -174,6 +174,41 @@ func TestPersistRemove(t *testing.T) {
 	}
 }
 
+func TestPersistAddAfterLookup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var ls mantaray.LoadSaver = newMockLoadSaver()
+	n := mantaray.New()
+	if err := n.Add(ctx, []byte("a"), bytes.Repeat([]byte{1}, 32), nil, ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := n.Save(ctx, ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	ref := n.Reference()
+
+	// the lookup loads the forks of the root before the addition
+	nn := mantaray.NewNodeRef(ref)
+	if _, err := nn.Lookup(ctx, []byte("a"), ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := nn.Add(ctx, []byte("b"), bytes.Repeat([]byte{2}, 32), nil, ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := nn.Save(ctx, ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if bytes.Equal(nn.Reference(), ref) {
+		t.Fatal("expected the reference to change")
+	}
+
+	nnn := mantaray.NewNodeRef(nn.Reference())
+	if _, err := nnn.Lookup(ctx, []byte("b"), ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
 type addr [32]byte
 type mockLoadSaver struct {
 	mtx   sync.Mutex
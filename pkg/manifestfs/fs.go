@@ -0,0 +1,474 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package manifestfs implements the file system over the mantaray manifests.
+//
+// The file system of the manifest reference is read only. The file system
+// backed by the feed of the node can be modified, every change is stored as
+// the new manifest the feed is updated to. The directories are implied by
+// the paths of the entries, the empty directories are kept as the entries
+// with the trailing separator and the empty reference.
+package manifestfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/file"
+	"github.com/ethersphere/bee/v2/pkg/file/joiner"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const (
+	// MetadataSizeKey is the manifest metadata key of the file size.
+	MetadataSizeKey = "Content-Length"
+	// MetadataModifiedKey is the manifest metadata key of the time
+	// the file was stored.
+	MetadataModifiedKey = "Last-Modified"
+
+	separator = "/"
+)
+
+var (
+	// ErrReadOnly is returned when the read only file system is modified.
+	ErrReadOnly = errors.New("read only file system")
+	// ErrIsDir is returned when the directory is written as the file.
+	ErrIsDir = errors.New("is a directory")
+)
+
+// EmptyReference is the reference of the manifest entries carrying
+// only the metadata.
+var EmptyReference = swarm.NewAddress(make([]byte, swarm.HashSize))
+
+// FileInfo describes the file or the directory.
+type FileInfo struct {
+	name        string
+	size        int64
+	modTime     time.Time
+	dir         bool
+	contentType string
+	ref         swarm.Address
+}
+
+var _ fs.FileInfo = (*FileInfo)(nil)
+
+func (fi *FileInfo) Name() string       { return fi.name }
+func (fi *FileInfo) Size() int64        { return fi.size }
+func (fi *FileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *FileInfo) IsDir() bool        { return fi.dir }
+func (fi *FileInfo) Sys() any           { return nil }
+
+func (fi *FileInfo) Mode() fs.FileMode {
+	if fi.dir {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+
+// ContentType returns the content type of the file.
+func (fi *FileInfo) ContentType() string { return fi.contentType }
+
+// Reference returns the reference of the file data.
+func (fi *FileInfo) Reference() swarm.Address { return fi.ref }
+
+func fileInfo(name string, e manifest.Entry) *FileInfo {
+	metadata := e.Metadata()
+	size, _ := strconv.ParseInt(metadata[MetadataSizeKey], 10, 64)
+	modTime, _ := time.Parse(time.RFC3339, metadata[MetadataModifiedKey])
+	return &FileInfo{
+		name:        path.Base(name),
+		size:        size,
+		modTime:     modTime,
+		contentType: metadata[manifest.EntryMetadataContentTypeKey],
+		ref:         e.Reference(),
+	}
+}
+
+func dirInfo(name string) *FileInfo {
+	if name == "" {
+		name = separator
+	}
+	return &FileInfo{name: path.Base(name), dir: true}
+}
+
+// FS is the file system over the manifest.
+type FS struct {
+	store  *Store
+	ref    swarm.Address
+	topic  []byte
+	putter PutterFunc
+}
+
+// Reference returns the read only file system of the manifest reference.
+func (s *Store) Reference(ref swarm.Address) *FS {
+	return &FS{store: s, ref: ref}
+}
+
+// Feed returns the file system of the latest manifest of the feed of the
+// topic, which can be modified when the store is writable.
+func (s *Store) Feed(topic []byte) *FS {
+	return &FS{store: s, topic: topic}
+}
+
+// WithPutter returns the file system uploading the changes with the sessions
+// of the putter instead of the ones of the store.
+func (f *FS) WithPutter(putter PutterFunc) *FS {
+	c := *f
+	c.putter = putter
+	return &c
+}
+
+// Writable reports whether the file system can be modified.
+func (f *FS) Writable() bool {
+	return f.topic != nil && (f.putter != nil || f.store.Writable())
+}
+
+func (f *FS) newSession(ctx context.Context) (storer.PutterSession, error) {
+	if f.putter != nil {
+		return f.putter(ctx)
+	}
+	return f.store.NewSession(ctx)
+}
+
+// clean returns the path of the name in the manifest, the root is empty.
+func clean(name string) string {
+	return strings.Trim(path.Clean(separator+name), separator)
+}
+
+func (f *FS) manifest(ctx context.Context) (*Manifest, error) {
+	ref := f.ref
+	if f.topic != nil {
+		var err error
+		if ref, err = f.store.Latest(ctx, f.topic); err != nil {
+			return nil, err
+		}
+	}
+	return f.store.Load(ctx, ref)
+}
+
+// stat returns the information of the path in the manifest.
+func stat(ctx context.Context, m *Manifest, name string) (*FileInfo, error) {
+	if name == "" {
+		return dirInfo(name), nil
+	}
+	e, err := m.Lookup(ctx, name)
+	if err == nil && !e.Reference().Equal(EmptyReference) {
+		return fileInfo(name, e), nil
+	}
+	if err != nil && !errors.Is(err, manifest.ErrNotFound) {
+		return nil, err
+	}
+	ok, err := m.HasPrefix(ctx, name+separator)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return dirInfo(name), nil
+}
+
+// Stat returns the information of the file or the directory.
+func (f *FS) Stat(ctx context.Context, name string) (*FileInfo, error) {
+	m, err := f.manifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return stat(ctx, m, clean(name))
+}
+
+// ReadDir returns the entries of the directory sorted by name.
+func (f *FS) ReadDir(ctx context.Context, name string) ([]*FileInfo, error) {
+	m, err := f.manifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name = clean(name)
+	fi, err := stat(ctx, m, name)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.dir {
+		return nil, fmt.Errorf("%s: %w", name, fs.ErrInvalid)
+	}
+
+	prefix := name
+	if prefix != "" {
+		prefix += separator
+	}
+	var (
+		infos []*FileInfo
+		dirs  = make(map[string]bool)
+	)
+	err = m.Walk(ctx, func(p string, e manifest.Entry) error {
+		if !strings.HasPrefix(p, prefix) {
+			return nil
+		}
+		rest := p[len(prefix):]
+		if rest == "" {
+			return nil
+		}
+		if child, _, ok := strings.Cut(rest, separator); ok {
+			if !dirs[child] {
+				dirs[child] = true
+				infos = append(infos, dirInfo(child))
+			}
+			return nil
+		}
+		infos = append(infos, fileInfo(p, e))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].name < infos[j].name })
+	return infos, nil
+}
+
+// File is the opened file.
+type File interface {
+	io.ReadSeeker
+	io.ReaderAt
+}
+
+// fileReader limits the reads of the joiner to the length of the buffers,
+// the joiner reads up to their capacity.
+type fileReader struct {
+	file.Joiner
+}
+
+func (r fileReader) Read(p []byte) (int, error) {
+	return r.Joiner.Read(p[:len(p):len(p)])
+}
+
+func (r fileReader) ReadAt(p []byte, off int64) (int, error) {
+	return r.Joiner.ReadAt(p[:len(p):len(p)], off)
+}
+
+// Open opens the file for reading.
+func (f *FS) Open(ctx context.Context, name string) (File, *FileInfo, error) {
+	fi, err := f.Stat(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if fi.dir {
+		return nil, nil, fmt.Errorf("%s: %w", name, ErrIsDir)
+	}
+	j, _, err := joiner.New(ctx, f.store.storer.Download(true), f.store.storer.Cache(), fi.ref, redundancy.DefaultLevel)
+	if err != nil {
+		return nil, nil, fmt.Errorf("join: %w", err)
+	}
+	return fileReader{j}, fi, nil
+}
+
+// update applies the change to the manifest of the feed.
+func (f *FS) update(ctx context.Context, change func(*Manifest) error) (err error) {
+	if !f.Writable() {
+		return ErrReadOnly
+	}
+	session, err := f.newSession(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, session.Cleanup())
+		}
+	}()
+	return f.commit(ctx, session, change)
+}
+
+// commit applies the change to the manifest of the feed and finishes
+// the session with the reference of the stored manifest.
+func (f *FS) commit(ctx context.Context, session storer.PutterSession, change func(*Manifest) error) error {
+	ref, err := f.store.Update(ctx, session, f.topic, change)
+	if err != nil {
+		return err
+	}
+	return session.Done(ref)
+}
+
+// WriteFile stores the data as the file of the name, replacing
+// the existing one.
+func (f *FS) WriteFile(ctx context.Context, name string, r io.Reader, contentType string) (err error) {
+	name = clean(name)
+	if name == "" {
+		return ErrIsDir
+	}
+	if !f.Writable() {
+		return ErrReadOnly
+	}
+	session, err := f.newSession(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, session.Cleanup())
+		}
+	}()
+
+	// the data is uploaded before the updates of the feed are locked
+	c := &countingReader{r: r}
+	ref, err := builder.FeedPipeline(ctx, builder.NewPipelineBuilder(ctx, session, false, redundancy.NONE), c)
+	if err != nil {
+		return fmt.Errorf("upload data: %w", err)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	entry := manifest.NewEntry(ref, map[string]string{
+		manifest.EntryMetadataContentTypeKey: contentType,
+		manifest.EntryMetadataFilenameKey:    path.Base(name),
+		MetadataSizeKey:                      strconv.FormatInt(c.n, 10),
+		MetadataModifiedKey:                  time.Now().UTC().Format(time.RFC3339),
+	})
+
+	return f.commit(ctx, session, func(m *Manifest) error {
+		fi, err := stat(ctx, m, name)
+		switch {
+		case err == nil && fi.dir:
+			return fmt.Errorf("%s: %w", name, ErrIsDir)
+		case err != nil && !errors.Is(err, fs.ErrNotExist):
+			return err
+		}
+		if err := f.checkParent(ctx, m, name); err != nil {
+			return err
+		}
+		return m.Add(ctx, name, entry)
+	})
+}
+
+// checkParent checks that the parent of the name is the directory.
+func (f *FS) checkParent(ctx context.Context, m *Manifest, name string) error {
+	parent := path.Dir(name)
+	if parent == "." {
+		return nil
+	}
+	fi, err := stat(ctx, m, parent)
+	if err != nil {
+		return fmt.Errorf("%s: %w", parent, err)
+	}
+	if !fi.dir {
+		return fmt.Errorf("%s: %w", parent, fs.ErrInvalid)
+	}
+	return nil
+}
+
+// countingReader counts the read bytes.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Mkdir creates the empty directory.
+func (f *FS) Mkdir(ctx context.Context, name string) error {
+	name = clean(name)
+	return f.update(ctx, func(m *Manifest) error {
+		if _, err := stat(ctx, m, name); err == nil {
+			return fmt.Errorf("%s: %w", name, fs.ErrExist)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if err := f.checkParent(ctx, m, name); err != nil {
+			return err
+		}
+		return m.Add(ctx, name+separator, manifest.NewEntry(EmptyReference, map[string]string{
+			MetadataModifiedKey: time.Now().UTC().Format(time.RFC3339),
+		}))
+	})
+}
+
+// entries returns the entries of the file or the directory of the name.
+func entries(ctx context.Context, m *Manifest, name string) (map[string]manifest.Entry, error) {
+	found := make(map[string]manifest.Entry)
+	err := m.Walk(ctx, func(p string, e manifest.Entry) error {
+		if p == name || strings.HasPrefix(p, name+separator) {
+			found[p] = e
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+	}
+	return found, nil
+}
+
+// RemoveAll removes the file or the directory with all its content.
+func (f *FS) RemoveAll(ctx context.Context, name string) error {
+	name = clean(name)
+	if name == "" {
+		return fmt.Errorf("remove root: %w", fs.ErrPermission)
+	}
+	return f.update(ctx, func(m *Manifest) error {
+		found, err := entries(ctx, m, name)
+		if err != nil {
+			return err
+		}
+		for p := range found {
+			if err := m.Remove(ctx, p); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Rename moves the file or the directory with all its content,
+// replacing the existing file of the new name.
+func (f *FS) Rename(ctx context.Context, oldName, newName string) error {
+	oldName, newName = clean(oldName), clean(newName)
+	if oldName == "" || newName == "" {
+		return fmt.Errorf("rename root: %w", fs.ErrPermission)
+	}
+	if strings.HasPrefix(newName+separator, oldName+separator) {
+		return fmt.Errorf("rename %s into itself: %w", oldName, fs.ErrInvalid)
+	}
+	return f.update(ctx, func(m *Manifest) error {
+		found, err := entries(ctx, m, oldName)
+		if err != nil {
+			return err
+		}
+		if fi, err := stat(ctx, m, newName); err == nil && fi.dir {
+			return fmt.Errorf("%s: %w", newName, fs.ErrExist)
+		}
+		if err := f.checkParent(ctx, m, newName); err != nil {
+			return err
+		}
+		for p, e := range found {
+			if err := m.Remove(ctx, p); err != nil {
+				return err
+			}
+			metadata := e.Metadata()
+			if p == oldName {
+				metadata[manifest.EntryMetadataFilenameKey] = path.Base(newName)
+			}
+			if err := m.Add(ctx, newName+strings.TrimPrefix(p, oldName), manifest.NewEntry(e.Reference(), metadata)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,260 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package manifestfs_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+)
+
+type uploadStorer interface {
+	manifestfs.Storer
+	Upload(ctx context.Context, pin bool, tagID uint64) (storer.PutterSession, error)
+}
+
+func newStore(t *testing.T, st uploadStorer, signer crypto.Signer, writable bool) *manifestfs.Store {
+	t.Helper()
+
+	o := manifestfs.Options{
+		Storer: st,
+		Feeds:  factory.New(st.Download(true)),
+		Signer: signer,
+	}
+	if writable {
+		o.Putter = func(ctx context.Context) (storer.PutterSession, error) {
+			return st.Upload(ctx, false, 0)
+		}
+	}
+	s, err := manifestfs.NewStore(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func newSigner(t *testing.T) crypto.Signer {
+	t.Helper()
+
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crypto.NewDefaultSigner(key)
+}
+
+func readFile(t *testing.T, f *manifestfs.FS, name string) string {
+	t.Helper()
+
+	r, _, err := f.Open(context.Background(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func listDir(t *testing.T, f *manifestfs.FS, name string) string {
+	t.Helper()
+
+	infos, err := f.ReadDir(context.Background(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(infos))
+	for _, fi := range infos {
+		n := fi.Name()
+		if fi.IsDir() {
+			n += "/"
+		}
+		names = append(names, n)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestFS(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	st := mockstorer.New()
+	signer := newSigner(t)
+	f := newStore(t, st, signer, true).Feed([]byte("topic"))
+
+	if got := listDir(t, f, "/"); got != "" {
+		t.Fatalf("got root %q, want empty", got)
+	}
+	if !f.Writable() {
+		t.Fatal("feed file system is not writable")
+	}
+
+	if err := f.Mkdir(ctx, "docs"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Mkdir(ctx, "docs"); !errors.Is(err, fs.ErrExist) {
+		t.Fatalf("got error %v, want %v", err, fs.ErrExist)
+	}
+	if err := f.WriteFile(ctx, "missing/a.txt", strings.NewReader("a"), ""); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("got error %v, want %v", err, fs.ErrNotExist)
+	}
+	for name, data := range map[string]string{
+		"docs/a.txt":  "first",
+		"docs/b.txt":  "second",
+		"readme.md":   "readme",
+		"docs/a.txt~": "backup",
+	} {
+		if err := f.WriteFile(ctx, name, strings.NewReader(data), "text/plain"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.WriteFile(ctx, "docs", strings.NewReader("x"), ""); !errors.Is(err, manifestfs.ErrIsDir) {
+		t.Fatalf("got error %v, want %v", err, manifestfs.ErrIsDir)
+	}
+
+	if got, want := listDir(t, f, "/"), "docs/,readme.md"; got != want {
+		t.Fatalf("got root %q, want %q", got, want)
+	}
+	if got, want := listDir(t, f, "docs"), "a.txt,a.txt~,b.txt"; got != want {
+		t.Fatalf("got docs %q, want %q", got, want)
+	}
+	fi, err := f.Stat(ctx, "/docs/b.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.IsDir() || fi.Size() != 6 || fi.ContentType() != "text/plain" || fi.ModTime().IsZero() {
+		t.Fatalf("got file info %+v", fi)
+	}
+	if got := readFile(t, f, "docs/b.txt"); got != "second" {
+		t.Fatalf("got file %q", got)
+	}
+
+	t.Run("rename", func(t *testing.T) {
+		if err := f.Rename(ctx, "docs", "docs/inner"); !errors.Is(err, fs.ErrInvalid) {
+			t.Fatalf("got error %v, want %v", err, fs.ErrInvalid)
+		}
+		if err := f.Mkdir(ctx, "archive"); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Rename(ctx, "docs", "archive/2024"); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Rename(ctx, "readme.md", "archive/readme.txt"); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := listDir(t, f, "/"), "archive/"; got != want {
+			t.Fatalf("got root %q, want %q", got, want)
+		}
+		if got, want := listDir(t, f, "archive/2024"), "a.txt,a.txt~,b.txt"; got != want {
+			t.Fatalf("got archive %q, want %q", got, want)
+		}
+		if got := readFile(t, f, "archive/readme.txt"); got != "readme" {
+			t.Fatalf("got file %q", got)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		if err := f.RemoveAll(ctx, "archive/2024"); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.RemoveAll(ctx, "archive/2024"); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("got error %v, want %v", err, fs.ErrNotExist)
+		}
+		if _, err := f.Stat(ctx, "archive/2024/a.txt"); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("got error %v, want %v", err, fs.ErrNotExist)
+		}
+		if got, want := listDir(t, f, "archive"), "readme.txt"; got != want {
+			t.Fatalf("got archive %q, want %q", got, want)
+		}
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		// the new store of the same owner looks the feed up
+		s := newStore(t, st, signer, false)
+		f := s.Feed([]byte("topic"))
+		if f.Writable() {
+			t.Fatal("read only store is writable")
+		}
+		if got := readFile(t, f, "archive/readme.txt"); got != "readme" {
+			t.Fatalf("got file %q", got)
+		}
+		if err := f.Mkdir(ctx, "new"); !errors.Is(err, manifestfs.ErrReadOnly) {
+			t.Fatalf("got error %v, want %v", err, manifestfs.ErrReadOnly)
+		}
+		w := f.WithPutter(func(ctx context.Context) (storer.PutterSession, error) {
+			return st.Upload(ctx, false, 0)
+		})
+		if err := w.Mkdir(ctx, "new"); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := listDir(t, f, "/"), "archive/,new/"; got != want {
+			t.Fatalf("got root %q, want %q", got, want)
+		}
+
+		ref, err := s.Latest(ctx, []byte("topic"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := listDir(t, s.Reference(ref), "archive"), "readme.txt"; got != want {
+			t.Fatalf("got archive %q, want %q", got, want)
+		}
+	})
+}
+
+func TestFSReference(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	st := mockstorer.New()
+	s := newStore(t, st, newSigner(t), true)
+	for _, dir := range []string{"a", "a/b"} {
+		if err := s.Feed([]byte("topic")).Mkdir(ctx, dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Feed([]byte("topic")).WriteFile(ctx, "a/b/c.txt", strings.NewReader("data"), ""); err != nil {
+		t.Fatal(err)
+	}
+	ref, err := s.Latest(ctx, []byte("topic"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := s.Reference(ref)
+	if f.Writable() {
+		t.Fatal("reference file system is writable")
+	}
+	if err := f.WriteFile(ctx, "x", strings.NewReader("x"), ""); !errors.Is(err, manifestfs.ErrReadOnly) {
+		t.Fatalf("got error %v, want %v", err, manifestfs.ErrReadOnly)
+	}
+	if got, want := listDir(t, f, "a"), "b/"; got != want {
+		t.Fatalf("got dir %q, want %q", got, want)
+	}
+	if _, _, err := f.Open(ctx, "a/b"); !errors.Is(err, manifestfs.ErrIsDir) {
+		t.Fatalf("got error %v, want %v", err, manifestfs.ErrIsDir)
+	}
+
+	r, _, err := f.Open(ctx, "a/b/c.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Seek(2, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 1, 16)
+	if _, err := io.ReadFull(r, buf); err != nil || string(buf) != "t" {
+		t.Fatalf("got %q, %v", buf, err)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package manifestfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethersphere/bee/v2/pkg/file"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
+	"github.com/ethersphere/bee/v2/pkg/manifest/mantaray"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// ErrStopWalk stops the walk of the manifest without an error.
+var ErrStopWalk = errors.New("stop walk")
+
+// mantarayManifest is the manifest whose nodes can be walked.
+type mantarayManifest interface {
+	manifest.Interface
+	Root() *mantaray.Node
+}
+
+// Manifest is the mantaray manifest whose entries can be walked.
+type Manifest struct {
+	mantarayManifest
+	ls file.LoadSaver
+}
+
+// LoadManifest loads the manifest of the reference, or creates
+// the new one when the reference is zero.
+func LoadManifest(ref swarm.Address, ls file.LoadSaver) (*Manifest, error) {
+	var (
+		m   manifest.Interface
+		err error
+	)
+	if ref.IsZero() {
+		m, err = manifest.NewMantarayManifest(ls, false)
+	} else {
+		m, err = manifest.NewMantarayManifestReference(ref, ls)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load manifest: %w", err)
+	}
+	mm, ok := m.(mantarayManifest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected manifest type %T", m)
+	}
+	return &Manifest{mantarayManifest: mm, ls: ls}, nil
+}
+
+// Walk calls fn for the entries of the manifest in the lexicographic order
+// of their paths, until fn returns ErrStopWalk.
+func (m *Manifest) Walk(ctx context.Context, fn func(path string, entry manifest.Entry) error) error {
+	return m.WalkPrefix(ctx, "", fn)
+}
+
+// WalkPrefix is like Walk for the entries of the paths with the prefix.
+// It returns manifest.ErrNotFound when no path has the prefix.
+func (m *Manifest) WalkPrefix(ctx context.Context, prefix string, fn func(path string, entry manifest.Entry) error) error {
+	err := m.Root().WalkNode(ctx, []byte(prefix), m.ls, func(path []byte, node *mantaray.Node, err error) error {
+		if err != nil {
+			return err
+		}
+		if node == nil || !node.IsValueType() {
+			return nil
+		}
+		return fn(string(path), manifest.NewEntry(swarm.NewAddress(node.Entry()), node.Metadata()))
+	})
+	switch {
+	case err == nil, errors.Is(err, ErrStopWalk):
+		return nil
+	case errors.Is(err, mantaray.ErrNotFound):
+		return manifest.ErrNotFound
+	default:
+		return fmt.Errorf("walk manifest: %w", err)
+	}
+}
+
+// Remove removes the entry of the path. The mantaray node of the path holds
+// the entries of the paths it prefixes, they are added back.
+func (m *Manifest) Remove(ctx context.Context, path string) error {
+	var kept []manifest.Entry
+	var paths []string
+	err := m.Root().WalkNode(ctx, []byte(path), m.ls, func(p []byte, node *mantaray.Node, err error) error {
+		if err != nil {
+			return err
+		}
+		if node == nil || !node.IsValueType() || string(p) == path {
+			return nil
+		}
+		paths = append(paths, string(p))
+		kept = append(kept, manifest.NewEntry(swarm.NewAddress(node.Entry()), node.Metadata()))
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, mantaray.ErrNotFound) {
+			return manifest.ErrNotFound
+		}
+		return fmt.Errorf("walk manifest: %w", err)
+	}
+	if err := m.mantarayManifest.Remove(ctx, path); err != nil {
+		return err
+	}
+	for i, p := range paths {
+		if err := m.mantarayManifest.Add(ctx, p, kept[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
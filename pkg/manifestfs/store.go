@@ -0,0 +1,202 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package manifestfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// ErrInvalidUpdate is returned when the feed update does not carry
+// the manifest reference.
+var ErrInvalidUpdate = errors.New("invalid feed update")
+
+// Storer is the storage the content is read from.
+type Storer interface {
+	Download(cache bool) storage.Getter
+	Cache() storage.Putter
+}
+
+// PutterFunc returns the session the new content is stamped and uploaded with.
+type PutterFunc func(ctx context.Context) (storer.PutterSession, error)
+
+// Options are the options of the store.
+type Options struct {
+	Storer Storer
+	// Putter is nil for the read only store.
+	Putter PutterFunc
+	Feeds  feeds.Factory
+	// Signer signs the feed updates, its address owns the feeds.
+	Signer crypto.Signer
+}
+
+// Store keeps the manifests behind the sequence feeds of the signer. The
+// update of the feed carries the reference of the latest manifest.
+type Store struct {
+	storer Storer
+	putter PutterFunc
+	feeds  feeds.Factory
+	signer crypto.Signer
+	owner  common.Address
+
+	writeMu sync.Mutex // serializes the updates of the feeds
+
+	mu     sync.Mutex
+	states map[string]feedState // latest updates of the feeds by topic
+}
+
+// feedState is the latest update of the feed.
+type feedState struct {
+	ref  swarm.Address // reference of the manifest, zero before the first update
+	next feeds.Index
+}
+
+// NewStore returns the store of the feed-backed manifests.
+func NewStore(o Options) (*Store, error) {
+	owner, err := o.Signer.EthereumAddress()
+	if err != nil {
+		return nil, fmt.Errorf("owner address: %w", err)
+	}
+	return &Store{
+		storer: o.Storer,
+		putter: o.Putter,
+		feeds:  o.Feeds,
+		signer: o.Signer,
+		owner:  owner,
+		states: make(map[string]feedState),
+	}, nil
+}
+
+// Owner returns the owner of the feeds.
+func (s *Store) Owner() common.Address {
+	return s.owner
+}
+
+// Writable reports whether the store can update the feeds.
+func (s *Store) Writable() bool {
+	return s.putter != nil
+}
+
+// NewSession returns the session the new content is uploaded with.
+func (s *Store) NewSession(ctx context.Context) (storer.PutterSession, error) {
+	if s.putter == nil {
+		return nil, ErrReadOnly
+	}
+	return s.putter(ctx)
+}
+
+// Latest returns the reference of the latest manifest of the feed of the
+// topic, which is zero before the first update. The updates are looked up
+// on the network only once, the store is the only writer of its feeds.
+func (s *Store) Latest(ctx context.Context, topic []byte) (swarm.Address, error) {
+	st, err := s.latest(ctx, topic)
+	if err != nil {
+		return swarm.ZeroAddress, err
+	}
+	return st.ref, nil
+}
+
+func (s *Store) latest(ctx context.Context, topic []byte) (feedState, error) {
+	s.mu.Lock()
+	st, ok := s.states[string(topic)]
+	s.mu.Unlock()
+	if ok {
+		return st, nil
+	}
+
+	lookup, err := s.feeds.NewLookup(feeds.Sequence, feeds.New(topic, s.owner))
+	if err != nil {
+		return feedState{}, fmt.Errorf("new lookup: %w", err)
+	}
+	ch, _, next, err := lookup.At(ctx, time.Now().Unix(), 0)
+	if err != nil {
+		return feedState{}, fmt.Errorf("lookup: %w", err)
+	}
+	if next == nil {
+		return feedState{}, fmt.Errorf("lookup: %w", ErrInvalidUpdate)
+	}
+	st = feedState{ref: swarm.ZeroAddress, next: next}
+	if ch != nil {
+		wc, err := feeds.FromChunk(ch)
+		if err != nil {
+			return feedState{}, fmt.Errorf("wrapped chunk: %w", err)
+		}
+		data := wc.Data()
+		if len(data) != swarm.SpanSize+swarm.HashSize {
+			return feedState{}, ErrInvalidUpdate
+		}
+		st.ref = swarm.NewAddress(data[swarm.SpanSize:])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// the update made in the meantime takes precedence
+	if cur, ok := s.states[string(topic)]; ok {
+		return cur, nil
+	}
+	s.states[string(topic)] = st
+	return st, nil
+}
+
+// Load loads the manifest of the reference read only, or returns
+// the new empty manifest when the reference is zero.
+func (s *Store) Load(ctx context.Context, ref swarm.Address) (*Manifest, error) {
+	return LoadManifest(ref, loadsave.NewReadonly(s.storer.Download(true), s.storer.Cache(), redundancy.DefaultLevel))
+}
+
+// Update applies the change to the latest manifest of the feed of the topic,
+// stores the manifest with the session and points the feed to it. The updates
+// are serialized, so the change sees the effects of all the previous ones.
+// The session is not done, it is left to the caller.
+func (s *Store) Update(ctx context.Context, session storer.PutterSession, topic []byte, change func(*Manifest) error) (swarm.Address, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	st, err := s.latest(ctx, topic)
+	if err != nil {
+		return swarm.ZeroAddress, err
+	}
+	ls := loadsave.New(s.storer.Download(true), s.storer.Cache(), func() pipeline.Interface {
+		return builder.NewPipelineBuilder(ctx, session, false, redundancy.NONE)
+	}, redundancy.DefaultLevel)
+	m, err := LoadManifest(st.ref, ls)
+	if err != nil {
+		return swarm.ZeroAddress, err
+	}
+	if err := change(m); err != nil {
+		return swarm.ZeroAddress, err
+	}
+	ref, err := m.Store(ctx)
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("store manifest: %w", err)
+	}
+
+	p, err := feeds.NewPutter(session, s.signer, topic)
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("feed putter: %w", err)
+	}
+	if err := p.Put(ctx, st.next, ref.Bytes()); err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("feed update: %w", err)
+	}
+
+	s.mu.Lock()
+	s.states[string(topic)] = feedState{ref: ref, next: st.next.Next(time.Now().Unix(), 0)}
+	s.mu.Unlock()
+	return ref, nil
+}
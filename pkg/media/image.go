@@ -0,0 +1,172 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register the webp decoder
+)
+
+const (
+	// MaxImageDimension is the maximum width and height of the transformed image.
+	MaxImageDimension = 4096
+	// DefaultImageQuality is the default quality of the JPEG images.
+	DefaultImageQuality = 85
+
+	maxImagePixels = 50_000_000 // maximum number of the pixels of the image decoded
+)
+
+// The formats of the transformed images.
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatGIF  = "gif"
+	FormatWebP = "webp"
+)
+
+var imageContentTypes = map[string]string{
+	FormatJPEG: "image/jpeg",
+	FormatPNG:  "image/png",
+	FormatGIF:  "image/gif",
+	FormatWebP: "image/webp",
+}
+
+var (
+	// ErrInvalidImageOptions is returned when the transform options are not valid.
+	ErrInvalidImageOptions = errors.New("invalid image options")
+	// ErrUnsupportedImage is returned when the image cannot be decoded.
+	ErrUnsupportedImage = errors.New("unsupported image")
+	// ErrImageTooLarge is returned when the image has too many pixels to be decoded.
+	ErrImageTooLarge = errors.New("image too large")
+)
+
+// IsImage reports whether the content type is of the image that can be transformed.
+func IsImage(contentType string) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	for _, v := range imageContentTypes {
+		if v == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// ImageOptions are the options of the image transform.
+type ImageOptions struct {
+	// Width and Height bound the size of the image which keeps its aspect
+	// ratio, zero leaves the dimension unbound. The image is not enlarged.
+	Width  int
+	Height int
+	// Format is the format of the transformed image, the format of the
+	// original image when empty.
+	Format string
+	// Quality is the quality of the JPEG image from 1 to 100.
+	Quality int
+}
+
+// Validate returns an error when the options are not valid.
+func (o ImageOptions) Validate() error {
+	switch {
+	case o.Width < 0 || o.Width > MaxImageDimension:
+		return fmt.Errorf("%w: width %d", ErrInvalidImageOptions, o.Width)
+	case o.Height < 0 || o.Height > MaxImageDimension:
+		return fmt.Errorf("%w: height %d", ErrInvalidImageOptions, o.Height)
+	case o.Quality < 0 || o.Quality > 100:
+		return fmt.Errorf("%w: quality %d", ErrInvalidImageOptions, o.Quality)
+	}
+	if _, ok := imageContentTypes[o.Format]; o.Format != "" && !ok {
+		return fmt.Errorf("%w: format %q", ErrInvalidImageOptions, o.Format)
+	}
+	return nil
+}
+
+// String returns the canonical form of the options.
+func (o ImageOptions) String() string {
+	return fmt.Sprintf("%dx%d,%s,%d", o.Width, o.Height, o.Format, o.Quality)
+}
+
+// TransformImage scales the image down to fit the width and the height of
+// the options and encodes it in the format of the options. It returns the
+// encoded image and its content type.
+func TransformImage(r io.Reader, o ImageOptions) ([]byte, string, error) {
+	if err := o.Validate(); err != nil {
+		return nil, "", err
+	}
+	buf := new(bytes.Buffer)
+	cfg, format, err := image.DecodeConfig(io.TeeReader(r, buf))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrUnsupportedImage, err)
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > maxImagePixels {
+		return nil, "", fmt.Errorf("%w: %dx%d", ErrImageTooLarge, cfg.Width, cfg.Height)
+	}
+	src, _, err := image.Decode(io.MultiReader(buf, r))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrUnsupportedImage, err)
+	}
+
+	if o.Format == "" {
+		o.Format = format
+	}
+	contentType, ok := imageContentTypes[o.Format]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: format %q", ErrUnsupportedImage, o.Format)
+	}
+
+	img := src
+	bounds := src.Bounds()
+	if w, h := fit(bounds.Dx(), bounds.Dy(), o.Width, o.Height); w != bounds.Dx() || h != bounds.Dy() {
+		dst := image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+		img = dst
+	}
+
+	out := new(bytes.Buffer)
+	switch o.Format {
+	case FormatJPEG:
+		quality := o.Quality
+		if quality == 0 {
+			quality = DefaultImageQuality
+		}
+		err = jpeg.Encode(out, img, &jpeg.Options{Quality: quality})
+	case FormatPNG:
+		err = png.Encode(out, img)
+	case FormatGIF:
+		err = gif.Encode(out, img, nil)
+	case FormatWebP:
+		err = nativewebp.Encode(out, img, nil)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("encode %s: %w", o.Format, err)
+	}
+	return out.Bytes(), contentType, nil
+}
+
+// fit returns the size of the image scaled down to fit the bounds,
+// keeping its aspect ratio.
+func fit(w, h, maxW, maxH int) (int, int) {
+	scale := 1.0
+	if maxW > 0 && w > maxW {
+		scale = float64(maxW) / float64(w)
+	}
+	if maxH > 0 && h > maxH {
+		scale = min(scale, float64(maxH)/float64(h))
+	}
+	if scale == 1 {
+		return w, h
+	}
+	return max(1, int(float64(w)*scale+0.5)), max(1, int(float64(h)*scale+0.5))
+}
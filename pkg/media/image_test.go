@@ -0,0 +1,134 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/file/joiner"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/media"
+	"github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	_ "golang.org/x/image/webp"
+)
+
+func newPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestTransformImage(t *testing.T) {
+	t.Parallel()
+
+	original := newPNG(t, 200, 100)
+	for _, tc := range []struct {
+		name        string
+		opts        media.ImageOptions
+		format      string
+		contentType string
+		width       int
+		height      int
+	}{
+		{name: "width", opts: media.ImageOptions{Width: 50}, format: "png", contentType: "image/png", width: 50, height: 25},
+		{name: "height", opts: media.ImageOptions{Height: 10, Format: media.FormatJPEG}, format: "jpeg", contentType: "image/jpeg", width: 20, height: 10},
+		{name: "box", opts: media.ImageOptions{Width: 40, Height: 40, Format: media.FormatWebP}, format: "webp", contentType: "image/webp", width: 40, height: 20},
+		{name: "not enlarged", opts: media.ImageOptions{Width: 400, Format: media.FormatGIF}, format: "gif", contentType: "image/gif", width: 200, height: 100},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, contentType, err := media.TransformImage(bytes.NewReader(original), tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if contentType != tc.contentType {
+				t.Fatalf("got content type %q, want %q", contentType, tc.contentType)
+			}
+			cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != tc.format || cfg.Width != tc.width || cfg.Height != tc.height {
+				t.Fatalf("got %s %dx%d, want %s %dx%d", format, cfg.Width, cfg.Height, tc.format, tc.width, tc.height)
+			}
+		})
+	}
+
+	if _, _, err := media.TransformImage(strings.NewReader("not an image"), media.ImageOptions{Width: 10}); !errors.Is(err, media.ErrUnsupportedImage) {
+		t.Fatalf("got error %v, want %v", err, media.ErrUnsupportedImage)
+	}
+	for _, o := range []media.ImageOptions{{Width: -1}, {Height: media.MaxImageDimension + 1}, {Quality: 101}, {Format: "bmp"}} {
+		if _, _, err := media.TransformImage(bytes.NewReader(original), o); !errors.Is(err, media.ErrInvalidImageOptions) {
+			t.Fatalf("options %+v: got error %v, want %v", o, err, media.ErrInvalidImageOptions)
+		}
+	}
+	if !media.IsImage("image/png") || !media.IsImage("IMAGE/JPEG; q=1") || media.IsImage("image/svg+xml") {
+		t.Fatal("unexpected image detection")
+	}
+}
+
+func TestImageTransformer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storer := mockstorer.New()
+	pipe := builder.NewPipelineBuilder(ctx, storer.Cache(), false, redundancy.NONE)
+	ref, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(newPNG(t, 64, 64)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr := media.NewImageTransformer(storer, mock.NewStateStore(), log.Noop)
+	o := media.ImageOptions{Width: 16, Format: media.FormatJPEG}
+	derived, contentType, err := tr.Transform(ctx, ref, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "image/jpeg" {
+		t.Fatalf("got content type %q", contentType)
+	}
+	reader, _, err := joiner.New(ctx, storer.ChunkStore(), storer.Cache(), derived, redundancy.DefaultLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil || cfg.Width != 16 {
+		t.Fatalf("got width %d and error %v", cfg.Width, err)
+	}
+
+	// the derived image is transformed only once
+	again, _, err := tr.Transform(ctx, ref, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Equal(derived) {
+		t.Fatalf("got reference %s, want %s", again, derived)
+	}
+}
@@ -0,0 +1,363 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package media
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxMovieSize is the maximum size of the movie box read into the memory.
+const maxMovieSize = 64 * 1024 * 1024
+
+var (
+	// ErrInvalidMP4 is returned when the MP4 file cannot be parsed.
+	ErrInvalidMP4 = errors.New("invalid mp4")
+	// ErrNoTrack is returned when the movie has no track with the samples.
+	ErrNoTrack = errors.New("mp4 has no track")
+)
+
+// IsMP4 reports whether the content of the type or the name is the MP4 file.
+func IsMP4(contentType, name string) bool {
+	switch strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0])) {
+	case "video/mp4", "audio/mp4", "video/quicktime", "video/x-m4v":
+		return true
+	}
+	switch strings.ToLower(path.Ext(name)) {
+	case ".mp4", ".m4v", ".m4a", ".mov":
+		return true
+	}
+	return false
+}
+
+// box is the MP4 box in the memory.
+type box struct {
+	typ  string
+	data []byte // without the header
+}
+
+// parseBoxes splits the data into the boxes.
+func parseBoxes(data []byte) ([]box, error) {
+	var boxes []box
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, fmt.Errorf("%w: truncated box header", ErrInvalidMP4)
+		}
+		size, header := uint64(binary.BigEndian.Uint32(data)), uint64(8)
+		typ := string(data[4:8])
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return nil, fmt.Errorf("%w: truncated box header", ErrInvalidMP4)
+			}
+			size, header = binary.BigEndian.Uint64(data[8:]), 16
+		}
+		if size < header || size > uint64(len(data)) {
+			return nil, fmt.Errorf("%w: box %s of size %d", ErrInvalidMP4, typ, size)
+		}
+		boxes = append(boxes, box{typ: typ, data: data[header:size]})
+		data = data[size:]
+	}
+	return boxes, nil
+}
+
+// child returns the first child box of the type along the path of the types.
+func child(data []byte, types ...string) ([]byte, bool) {
+	for _, typ := range types {
+		boxes, err := parseBoxes(data)
+		if err != nil {
+			return nil, false
+		}
+		found := false
+		for _, b := range boxes {
+			if b.typ == typ {
+				data, found = b.data, true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return data, true
+}
+
+// readMovie finds the movie box among the top level boxes of the file.
+func readMovie(r io.ReaderAt, size int64) ([]byte, error) {
+	header := make([]byte, 16)
+	for offset := int64(0); offset+8 <= size; {
+		if _, err := r.ReadAt(header[:8:8], offset); err != nil {
+			return nil, fmt.Errorf("read box header: %w", err)
+		}
+		boxSize, headerSize := int64(binary.BigEndian.Uint32(header)), int64(8)
+		typ := string(header[4:8])
+		switch boxSize {
+		case 0:
+			boxSize = size - offset
+		case 1:
+			if _, err := r.ReadAt(header[8:16], offset+8); err != nil {
+				return nil, fmt.Errorf("read box header: %w", err)
+			}
+			boxSize, headerSize = int64(binary.BigEndian.Uint64(header[8:])), 16
+		}
+		if boxSize < headerSize || boxSize > size-offset {
+			return nil, fmt.Errorf("%w: box %s of size %d", ErrInvalidMP4, typ, boxSize)
+		}
+		if typ == "moov" {
+			if boxSize-headerSize > maxMovieSize {
+				return nil, fmt.Errorf("%w: movie box of size %d", ErrInvalidMP4, boxSize)
+			}
+			data := make([]byte, boxSize-headerSize)
+			if _, err := r.ReadAt(data, offset+headerSize); err != nil && !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("read movie box: %w", err)
+			}
+			return data, nil
+		}
+		offset += boxSize
+	}
+	return nil, fmt.Errorf("%w: no movie box", ErrInvalidMP4)
+}
+
+// sampleTable is the sample table of the track.
+type sampleTable struct {
+	timescale   uint32
+	timeToCount [][2]uint32 // sample count and delta
+	syncSamples []uint32    // one based
+	sampleChunk [][2]uint32 // first chunk and samples per chunk
+	sampleSizes []uint32
+	fixedSize   uint32
+	sampleCount uint32
+	chunkOffset []uint64
+}
+
+// entries returns the entries of the full box table of the entry size.
+func entries(data []byte, skip, entrySize int) ([]byte, uint32, error) {
+	if len(data) < 8+skip {
+		return nil, 0, fmt.Errorf("%w: truncated table", ErrInvalidMP4)
+	}
+	count := binary.BigEndian.Uint32(data[4+skip:])
+	data = data[8+skip:]
+	if uint64(len(data)) < uint64(count)*uint64(entrySize) {
+		return nil, 0, fmt.Errorf("%w: truncated table", ErrInvalidMP4)
+	}
+	return data, count, nil
+}
+
+func parseSampleTable(mdia []byte) (*sampleTable, error) {
+	mdhd, ok := child(mdia, "mdhd")
+	if !ok || len(mdhd) < 24 {
+		return nil, fmt.Errorf("%w: missing media header", ErrInvalidMP4)
+	}
+	st := &sampleTable{timescale: binary.BigEndian.Uint32(mdhd[12:])}
+	if mdhd[0] == 1 {
+		if len(mdhd) < 32 {
+			return nil, fmt.Errorf("%w: truncated media header", ErrInvalidMP4)
+		}
+		st.timescale = binary.BigEndian.Uint32(mdhd[20:])
+	}
+	if st.timescale == 0 {
+		return nil, fmt.Errorf("%w: zero timescale", ErrInvalidMP4)
+	}
+
+	stbl, ok := child(mdia, "minf", "stbl")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing sample table", ErrInvalidMP4)
+	}
+	stts, ok := child(stbl, "stts")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing time to sample table", ErrInvalidMP4)
+	}
+	data, count, err := entries(stts, 0, 8)
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < count; i++ {
+		st.timeToCount = append(st.timeToCount, [2]uint32{binary.BigEndian.Uint32(data[i*8:]), binary.BigEndian.Uint32(data[i*8+4:])})
+	}
+
+	if stss, ok := child(stbl, "stss"); ok {
+		data, count, err := entries(stss, 0, 4)
+		if err != nil {
+			return nil, err
+		}
+		for i := uint32(0); i < count; i++ {
+			st.syncSamples = append(st.syncSamples, binary.BigEndian.Uint32(data[i*4:]))
+		}
+	}
+
+	stsc, ok := child(stbl, "stsc")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing sample to chunk table", ErrInvalidMP4)
+	}
+	data, count, err = entries(stsc, 0, 12)
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < count; i++ {
+		st.sampleChunk = append(st.sampleChunk, [2]uint32{binary.BigEndian.Uint32(data[i*12:]), binary.BigEndian.Uint32(data[i*12+4:])})
+	}
+
+	stsz, ok := child(stbl, "stsz")
+	if !ok || len(stsz) < 12 {
+		return nil, fmt.Errorf("%w: missing sample size table", ErrInvalidMP4)
+	}
+	st.fixedSize = binary.BigEndian.Uint32(stsz[4:])
+	st.sampleCount = binary.BigEndian.Uint32(stsz[8:])
+	if st.fixedSize == 0 {
+		data, count, err := entries(stsz, 4, 4)
+		if err != nil {
+			return nil, err
+		}
+		for i := uint32(0); i < count; i++ {
+			st.sampleSizes = append(st.sampleSizes, binary.BigEndian.Uint32(data[i*4:]))
+		}
+	}
+
+	if stco, ok := child(stbl, "stco"); ok {
+		data, count, err := entries(stco, 0, 4)
+		if err != nil {
+			return nil, err
+		}
+		for i := uint32(0); i < count; i++ {
+			st.chunkOffset = append(st.chunkOffset, uint64(binary.BigEndian.Uint32(data[i*4:])))
+		}
+	} else if co64, ok := child(stbl, "co64"); ok {
+		data, count, err := entries(co64, 0, 8)
+		if err != nil {
+			return nil, err
+		}
+		for i := uint32(0); i < count; i++ {
+			st.chunkOffset = append(st.chunkOffset, binary.BigEndian.Uint64(data[i*8:]))
+		}
+	} else {
+		return nil, fmt.Errorf("%w: missing chunk offset table", ErrInvalidMP4)
+	}
+	return st, nil
+}
+
+// sampleAt returns the zero based index of the sample playing at the time.
+func (st *sampleTable) sampleAt(t time.Duration) uint32 {
+	units := uint64(math.Max(0, t.Seconds()*float64(st.timescale)))
+	var sample uint32
+	var acc uint64
+	for _, e := range st.timeToCount {
+		count, delta := e[0], e[1]
+		if span := uint64(count) * uint64(delta); units < acc+span {
+			if delta == 0 {
+				return sample
+			}
+			return sample + uint32((units-acc)/uint64(delta))
+		} else {
+			acc += span
+		}
+		sample += count
+	}
+	if sample == 0 {
+		return 0
+	}
+	return sample - 1
+}
+
+// syncSample returns the sync sample at or before the sample.
+func (st *sampleTable) syncSample(sample uint32) uint32 {
+	if len(st.syncSamples) == 0 {
+		return sample
+	}
+	i := sort.Search(len(st.syncSamples), func(i int) bool { return st.syncSamples[i]-1 > sample })
+	if i == 0 {
+		return st.syncSamples[0] - 1
+	}
+	return st.syncSamples[i-1] - 1
+}
+
+func (st *sampleTable) sampleSize(sample uint32) uint64 {
+	if st.fixedSize != 0 {
+		return uint64(st.fixedSize)
+	}
+	if int(sample) < len(st.sampleSizes) {
+		return uint64(st.sampleSizes[sample])
+	}
+	return 0
+}
+
+// offset returns the byte offset of the sample in the file.
+func (st *sampleTable) offset(sample uint32) (int64, error) {
+	var first uint32 // first sample of the run of the chunks
+	for i, e := range st.sampleChunk {
+		firstChunk, perChunk := e[0], e[1]
+		if firstChunk == 0 || perChunk == 0 {
+			return 0, fmt.Errorf("%w: invalid sample to chunk entry", ErrInvalidMP4)
+		}
+		endChunk := uint32(len(st.chunkOffset)) + 1
+		if i+1 < len(st.sampleChunk) {
+			endChunk = st.sampleChunk[i+1][0]
+		}
+		if endChunk < firstChunk {
+			return 0, fmt.Errorf("%w: unordered sample to chunk entries", ErrInvalidMP4)
+		}
+		samples := uint64(endChunk-firstChunk) * uint64(perChunk)
+		if uint64(sample) >= uint64(first)+samples {
+			first += uint32(samples)
+			continue
+		}
+		chunk := firstChunk - 1 + (sample-first)/perChunk
+		if int(chunk) >= len(st.chunkOffset) {
+			break
+		}
+		offset := st.chunkOffset[chunk]
+		for s := first + (sample-first)/perChunk*perChunk; s < sample; s++ {
+			offset += st.sampleSize(s)
+		}
+		return int64(offset), nil
+	}
+	return 0, fmt.Errorf("%w: sample %d not in chunks", ErrInvalidMP4, sample)
+}
+
+// MP4Offset returns the byte offset of the sync sample of the first video
+// track, or of the first track, playing at or before the start time.
+func MP4Offset(r io.ReaderAt, size int64, start time.Duration) (int64, error) {
+	moov, err := readMovie(r, size)
+	if err != nil {
+		return 0, err
+	}
+	boxes, err := parseBoxes(moov)
+	if err != nil {
+		return 0, err
+	}
+	var mdia []byte
+	for _, b := range boxes {
+		if b.typ != "trak" {
+			continue
+		}
+		m, ok := child(b.data, "mdia")
+		if !ok {
+			continue
+		}
+		if mdia == nil {
+			mdia = m
+		}
+		if hdlr, ok := child(m, "hdlr"); ok && len(hdlr) >= 12 && string(hdlr[8:12]) == "vide" {
+			mdia = m
+			break
+		}
+	}
+	if mdia == nil {
+		return 0, ErrNoTrack
+	}
+	st, err := parseSampleTable(mdia)
+	if err != nil {
+		return 0, err
+	}
+	return st.offset(st.syncSample(st.sampleAt(start)))
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package media_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/media"
+)
+
+func mp4Box(typ string, payload ...[]byte) []byte {
+	data := bytes.Join(payload, nil)
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(data)))
+	return append(append(b, typ...), data...)
+}
+
+func uint32s(values ...uint32) []byte {
+	var b []byte
+	for _, v := range values {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	return b
+}
+
+// newMP4 returns the file of ten one second samples of 100 bytes in the
+// chunks of two samples, with the sync samples of the one based indexes.
+func newMP4(sync ...uint32) ([]byte, int64) {
+	ftyp := mp4Box("ftyp", []byte("isom"), make([]byte, 4))
+	mdat := mp4Box("mdat", make([]byte, 1000))
+	base := uint32(len(ftyp) + 8)
+
+	fullBox := func(count uint32, entries ...uint32) []byte {
+		return append(uint32s(0, count), uint32s(entries...)...)
+	}
+	stbl := [][]byte{
+		mp4Box("stts", fullBox(1, 10, 1000)),
+		mp4Box("stsc", fullBox(1, 1, 2, 1)),
+		mp4Box("stsz", uint32s(0, 0, 10), uint32s(100, 100, 100, 100, 100, 100, 100, 100, 100, 100)),
+		mp4Box("stco", fullBox(5, base, base+200, base+400, base+600, base+800)),
+	}
+	if len(sync) > 0 {
+		stbl = append(stbl, mp4Box("stss", fullBox(uint32(len(sync)), sync...)))
+	}
+	sound := mp4Box("trak", mp4Box("mdia",
+		mp4Box("mdhd", uint32s(0, 0, 0, 44100, 0, 0)),
+		mp4Box("hdlr", uint32s(0, 0), []byte("soun"), make([]byte, 12)),
+	))
+	video := mp4Box("trak", mp4Box("mdia",
+		mp4Box("mdhd", uint32s(0, 0, 0, 1000, 10000, 0)),
+		mp4Box("hdlr", uint32s(0, 0), []byte("vide"), make([]byte, 12)),
+		mp4Box("minf", mp4Box("stbl", stbl...)),
+	))
+	moov := mp4Box("moov", mp4Box("mvhd", make([]byte, 100)), sound, video)
+	return bytes.Join([][]byte{ftyp, mdat, moov}, nil), int64(base)
+}
+
+func TestMP4Offset(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		sync  []uint32
+		start time.Duration
+		want  int64
+	}{
+		{name: "start", sync: []uint32{1, 5, 9}, start: 0, want: 0},
+		{name: "sync sample", sync: []uint32{1, 5, 9}, start: 6500 * time.Millisecond, want: 400},
+		{name: "after end", sync: []uint32{1, 5, 9}, start: time.Hour, want: 800},
+		{name: "all sync", start: 3500 * time.Millisecond, want: 300},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, base := newMP4(tc.sync...)
+			got, err := media.MP4Offset(bytes.NewReader(data), int64(len(data)), tc.start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != base+tc.want {
+				t.Fatalf("got offset %d, want %d", got, base+tc.want)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		data := []byte("not an mp4 file at all")
+		if _, err := media.MP4Offset(bytes.NewReader(data), int64(len(data)), 0); !errors.Is(err, media.ErrInvalidMP4) {
+			t.Fatalf("got error %v, want %v", err, media.ErrInvalidMP4)
+		}
+	})
+}
+
+func TestIsMP4(t *testing.T) {
+	t.Parallel()
+
+	if !media.IsMP4("video/mp4; codecs=avc1", "") || !media.IsMP4("", "movie.M4V") || media.IsMP4("text/plain", "a.txt") {
+		t.Fatal("unexpected mp4 detection")
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package media implements the helpers of the media hosting: the parsing
+// of the HLS and DASH playlists for the prefetching of the upcoming
+// segments, the mapping of the MP4 playback times to the byte offsets and
+// the transforms of the images cached in the local store.
+package media
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxTrackSegments is the maximum number of the segments of the track
+// expanded from the DASH segment template.
+const maxTrackSegments = 100000
+
+// ErrInvalidPlaylist is returned when the playlist cannot be parsed.
+var ErrInvalidPlaylist = errors.New("invalid playlist")
+
+// Track is the ordered list of the segment URIs of the single rendition.
+type Track []string
+
+// IsPlaylist reports whether the name is of the HLS or the DASH playlist.
+func IsPlaylist(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".m3u8", ".mpd":
+		return true
+	}
+	return false
+}
+
+// IsSegment reports whether the name is of the media segment.
+func IsSegment(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".ts", ".m4s", ".mp4", ".m4v", ".m4a", ".aac", ".cmfv", ".cmfa", ".webm", ".vtt":
+		return true
+	}
+	return false
+}
+
+// ParsePlaylist parses the HLS or the DASH playlist by the extension of the name.
+func ParsePlaylist(name string, r io.Reader) ([]Track, error) {
+	if strings.EqualFold(path.Ext(name), ".mpd") {
+		return ParseDASH(r)
+	}
+	return ParseHLS(r)
+}
+
+// ParseHLS returns the segments of the HLS media playlist. The master
+// playlist has no segments, its variants are the media playlists.
+func ParseHLS(r io.Reader) ([]Track, error) {
+	sc := bufio.NewScanner(r)
+	if !sc.Scan() || strings.TrimSpace(sc.Text()) != "#EXTM3U" {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidPlaylist)
+	}
+	var (
+		track   Track
+		variant bool
+	)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXT-X-STREAM-INF"):
+			variant = true
+		case strings.HasPrefix(line, "#"):
+		case variant:
+			variant = false
+		default:
+			track = append(track, line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read playlist: %w", err)
+	}
+	if len(track) == 0 {
+		return nil, nil
+	}
+	return []Track{track}, nil
+}
+
+type mpd struct {
+	Duration string      `xml:"mediaPresentationDuration,attr"`
+	Periods  []mpdPeriod `xml:"Period"`
+}
+
+type mpdPeriod struct {
+	Duration       string             `xml:"duration,attr"`
+	Template       *mpdTemplate       `xml:"SegmentTemplate"`
+	AdaptationSets []mpdAdaptationSet `xml:"AdaptationSet"`
+}
+
+type mpdAdaptationSet struct {
+	Template        *mpdTemplate        `xml:"SegmentTemplate"`
+	List            *mpdList            `xml:"SegmentList"`
+	Representations []mpdRepresentation `xml:"Representation"`
+}
+
+type mpdRepresentation struct {
+	ID        string       `xml:"id,attr"`
+	Bandwidth string       `xml:"bandwidth,attr"`
+	Template  *mpdTemplate `xml:"SegmentTemplate"`
+	List      *mpdList     `xml:"SegmentList"`
+}
+
+type mpdTemplate struct {
+	Media       string       `xml:"media,attr"`
+	StartNumber *int64       `xml:"startNumber,attr"`
+	Timescale   *int64       `xml:"timescale,attr"`
+	Duration    int64        `xml:"duration,attr"`
+	Timeline    *mpdTimeline `xml:"SegmentTimeline"`
+}
+
+type mpdTimeline struct {
+	S []struct {
+		T *int64 `xml:"t,attr"`
+		D int64  `xml:"d,attr"`
+		R int64  `xml:"r,attr"`
+	} `xml:"S"`
+}
+
+type mpdList struct {
+	URLs []struct {
+		Media string `xml:"media,attr"`
+	} `xml:"SegmentURL"`
+}
+
+// ParseDASH returns the segments of the representations of the DASH
+// manifest. The segment lists and the segment templates with the timeline
+// or the fixed segment duration are supported.
+func ParseDASH(r io.Reader) ([]Track, error) {
+	var m mpd
+	if err := xml.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPlaylist, err)
+	}
+	var tracks []Track
+	for _, p := range m.Periods {
+		duration := p.Duration
+		if duration == "" {
+			duration = m.Duration
+		}
+		seconds, _ := parseDuration(duration)
+		for _, as := range p.AdaptationSets {
+			for _, rep := range as.Representations {
+				var (
+					track Track
+					err   error
+				)
+				switch {
+				case rep.List != nil:
+					track = rep.List.track()
+				case as.List != nil:
+					track = as.List.track()
+				case rep.Template != nil:
+					track, err = rep.Template.track(rep, seconds)
+				case as.Template != nil:
+					track, err = as.Template.track(rep, seconds)
+				case p.Template != nil:
+					track, err = p.Template.track(rep, seconds)
+				}
+				if err != nil {
+					return nil, err
+				}
+				if len(track) > 0 {
+					tracks = append(tracks, track)
+				}
+			}
+		}
+	}
+	return tracks, nil
+}
+
+func (l *mpdList) track() Track {
+	track := make(Track, 0, len(l.URLs))
+	for _, u := range l.URLs {
+		if u.Media != "" {
+			track = append(track, u.Media)
+		}
+	}
+	return track
+}
+
+// track expands the template for the segments of the representation.
+func (t *mpdTemplate) track(rep mpdRepresentation, seconds float64) (Track, error) {
+	if t.Media == "" {
+		return nil, nil
+	}
+	number := int64(1)
+	if t.StartNumber != nil {
+		number = *t.StartNumber
+	}
+	var track Track
+	add := func(time int64) error {
+		if len(track) >= maxTrackSegments {
+			return fmt.Errorf("%w: more than %d segments", ErrInvalidPlaylist, maxTrackSegments)
+		}
+		track = append(track, expandTemplate(t.Media, rep, number, time))
+		number++
+		return nil
+	}
+
+	if t.Timeline != nil {
+		var time int64
+		for _, s := range t.Timeline.S {
+			if s.T != nil {
+				time = *s.T
+			}
+			if s.D <= 0 {
+				return nil, fmt.Errorf("%w: segment duration %d", ErrInvalidPlaylist, s.D)
+			}
+			// the open repeat of the live streams is not expanded
+			for i := int64(0); i <= max(s.R, 0); i++ {
+				if err := add(time); err != nil {
+					return nil, err
+				}
+				time += s.D
+			}
+		}
+		return track, nil
+	}
+
+	if t.Duration <= 0 || seconds <= 0 {
+		return nil, nil
+	}
+	timescale := int64(1)
+	if t.Timescale != nil && *t.Timescale > 0 {
+		timescale = *t.Timescale
+	}
+	count := int64(math.Ceil(seconds * float64(timescale) / float64(t.Duration)))
+	for i := int64(0); i < count; i++ {
+		if err := add(i * t.Duration); err != nil {
+			return nil, err
+		}
+	}
+	return track, nil
+}
+
+var templateIdentifier = regexp.MustCompile(`\$(RepresentationID|Number|Time|Bandwidth)(%0(\d+)d)?\$|\$\$`)
+
+// expandTemplate substitutes the identifiers of the segment template.
+func expandTemplate(media string, rep mpdRepresentation, number, time int64) string {
+	return templateIdentifier.ReplaceAllStringFunc(media, func(s string) string {
+		m := templateIdentifier.FindStringSubmatch(s)
+		var v string
+		switch m[1] {
+		case "":
+			return "$"
+		case "RepresentationID":
+			return rep.ID
+		case "Bandwidth":
+			v = rep.Bandwidth
+		case "Number":
+			v = strconv.FormatInt(number, 10)
+		case "Time":
+			v = strconv.FormatInt(time, 10)
+		}
+		if width, err := strconv.Atoi(m[3]); err == nil && len(v) < width {
+			v = strings.Repeat("0", width-len(v)) + v
+		}
+		return v
+	})
+}
+
+var isoDuration = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// parseDuration parses the ISO 8601 duration in seconds.
+func parseDuration(s string) (float64, error) {
+	m := isoDuration.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	var seconds float64
+	for i, unit := range []float64{86400, 3600, 60, 1} {
+		if m[i+1] == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(m[i+1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		seconds += v * unit
+	}
+	return seconds, nil
+}
+
+// Next returns up to n segments following the segment in its track.
+func Next(tracks []Track, segment string, n int) []string {
+	for _, track := range tracks {
+		for i, s := range track {
+			if s == segment {
+				return track[i+1 : min(i+1+n, len(track))]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package media_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/media"
+)
+
+func TestParseHLS(t *testing.T) {
+	t.Parallel()
+
+	const playlist = `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-TARGETDURATION:10
+
+#EXTINF:10.0,
+seg0.ts
+#EXTINF:10.0,
+seg1.ts
+#EXTINF:4.2,
+seg2.ts
+#EXT-X-ENDLIST
+`
+	tracks, err := media.ParsePlaylist("index.m3u8", strings.NewReader(playlist))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []media.Track{{"seg0.ts", "seg1.ts", "seg2.ts"}}
+	if !reflect.DeepEqual(tracks, want) {
+		t.Fatalf("got tracks %v, want %v", tracks, want)
+	}
+
+	// the variants of the master playlist are not the segments
+	const master = `#EXTM3U
+#EXT-X-STREAM-INF:BANDWIDTH=800000
+low/index.m3u8
+#EXT-X-STREAM-INF:BANDWIDTH=2000000
+high/index.m3u8
+`
+	if tracks, err := media.ParseHLS(strings.NewReader(master)); err != nil || tracks != nil {
+		t.Fatalf("got tracks %v and error %v", tracks, err)
+	}
+
+	if _, err := media.ParseHLS(strings.NewReader("seg0.ts\n")); !errors.Is(err, media.ErrInvalidPlaylist) {
+		t.Fatalf("got error %v, want %v", err, media.ErrInvalidPlaylist)
+	}
+}
+
+func TestParseDASH(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		mpd  string
+		want []media.Track
+	}{
+		{
+			name: "template duration",
+			mpd: `<MPD mediaPresentationDuration="PT9.5S"><Period>
+<AdaptationSet><SegmentTemplate media="$RepresentationID$/seg-$Number%03d$.m4s" startNumber="0" timescale="1000" duration="4000"/>
+<Representation id="v1" bandwidth="1000"/><Representation id="v2" bandwidth="2000"/>
+</AdaptationSet></Period></MPD>`,
+			want: []media.Track{
+				{"v1/seg-000.m4s", "v1/seg-001.m4s", "v1/seg-002.m4s"},
+				{"v2/seg-000.m4s", "v2/seg-001.m4s", "v2/seg-002.m4s"},
+			},
+		},
+		{
+			name: "template timeline",
+			mpd: `<MPD><Period><AdaptationSet><Representation id="a" bandwidth="64000">
+<SegmentTemplate media="$Bandwidth$-$Time$.m4s"><SegmentTimeline><S t="100" d="10" r="1"/><S d="5"/></SegmentTimeline></SegmentTemplate>
+</Representation></AdaptationSet></Period></MPD>`,
+			want: []media.Track{{"64000-100.m4s", "64000-110.m4s", "64000-120.m4s"}},
+		},
+		{
+			name: "list",
+			mpd: `<MPD><Period><AdaptationSet><Representation id="a">
+<SegmentList><SegmentURL media="a.m4s"/><SegmentURL media="b.m4s"/></SegmentList>
+</Representation></AdaptationSet></Period></MPD>`,
+			want: []media.Track{{"a.m4s", "b.m4s"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tracks, err := media.ParsePlaylist("stream.mpd", strings.NewReader(tc.mpd))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tracks, tc.want) {
+				t.Fatalf("got tracks %v, want %v", tracks, tc.want)
+			}
+		})
+	}
+
+	if _, err := media.ParseDASH(strings.NewReader("<MPD>")); !errors.Is(err, media.ErrInvalidPlaylist) {
+		t.Fatalf("got error %v, want %v", err, media.ErrInvalidPlaylist)
+	}
+}
+
+func TestNext(t *testing.T) {
+	t.Parallel()
+
+	tracks := []media.Track{{"a/0.ts", "a/1.ts", "a/2.ts"}, {"b/0.ts", "b/1.ts"}}
+	for _, tc := range []struct {
+		segment string
+		want    []string
+	}{
+		{segment: "a/0.ts", want: []string{"a/1.ts", "a/2.ts"}},
+		{segment: "b/0.ts", want: []string{"b/1.ts"}},
+		{segment: "b/1.ts", want: []string{}},
+		{segment: "c/0.ts"},
+	} {
+		if got := media.Next(tracks, tc.segment, 2); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("segment %s: got %v, want %v", tc.segment, got, tc.want)
+		}
+	}
+	if !media.IsSegment("a/0.TS") || media.IsSegment("index.m3u8") || !media.IsPlaylist("x.mpd") {
+		t.Fatal("unexpected name detection")
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/file"
+	"github.com/ethersphere/bee/v2/pkg/file/joiner"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "media"
+
+const (
+	// DefaultSegments is the default number of the upcoming segments prefetched.
+	DefaultSegments = 3
+	// DefaultWorkers is the default number of the concurrent prefetches.
+	DefaultWorkers = 8
+
+	maxPlaylists      = 64              // maximum number of the playlists of the manifest parsed
+	maxPlaylistSize   = 4 * 1024 * 1024 // maximum size of the playlist read
+	maxCachedManifest = 128             // maximum number of the manifests with the cached tracks
+	maxRecent         = 4096            // maximum number of the recently prefetched segments
+	prefetchTimeout   = 2 * time.Minute // timeout of the single prefetch
+)
+
+// Options are the options of the prefetcher.
+type Options struct {
+	// Segments is the number of the upcoming segments prefetched.
+	Segments int
+	// Workers is the number of the concurrent prefetches, the requests
+	// above it are dropped.
+	Workers int
+}
+
+// Prefetcher fetches the upcoming segments of the HLS and DASH streams
+// hosted in the manifests, so they are in the local store when the player
+// requests them.
+type Prefetcher struct {
+	getter  storage.Getter
+	cache   storage.Putter
+	logger  log.Logger
+	opts    Options
+	sem     chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	tracks  map[string][]Track  // manifest reference to its tracks
+	recent  map[string]struct{} // recently prefetched segment references
+	closeMu sync.RWMutex
+	closed  bool
+}
+
+// NewPrefetcher returns the prefetcher fetching the segments with the getter
+// which stores the retrieved chunks in the local store.
+func NewPrefetcher(getter storage.Getter, cache storage.Putter, logger log.Logger, o Options) *Prefetcher {
+	if o.Segments <= 0 {
+		o.Segments = DefaultSegments
+	}
+	if o.Workers <= 0 {
+		o.Workers = DefaultWorkers
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Prefetcher{
+		getter: getter,
+		cache:  cache,
+		logger: logger.WithName(loggerName).Register(),
+		opts:   o,
+		sem:    make(chan struct{}, o.Workers),
+		ctx:    ctx,
+		cancel: cancel,
+		tracks: make(map[string][]Track),
+		recent: make(map[string]struct{}),
+	}
+}
+
+// Prefetch fetches in the background the segments following the segment of
+// the manifest in the tracks of the playlists of the manifest. The request is
+// dropped when all the workers are busy.
+func (p *Prefetcher) Prefetch(ls file.LoadSaver, ref swarm.Address, segment string) {
+	p.closeMu.RLock()
+	defer p.closeMu.RUnlock()
+	if p.closed {
+		return
+	}
+	select {
+	case p.sem <- struct{}{}:
+	default:
+		p.logger.Debug("prefetch dropped", "reference", ref, "segment", segment)
+		return
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer func() { <-p.sem }()
+
+		ctx, cancel := context.WithTimeout(p.ctx, prefetchTimeout)
+		defer cancel()
+		if err := p.prefetch(ctx, ls, ref, segment); err != nil && !errors.Is(err, context.Canceled) {
+			p.logger.Debug("prefetch failed", "reference", ref, "segment", segment, "error", err)
+		}
+	}()
+}
+
+func (p *Prefetcher) prefetch(ctx context.Context, ls file.LoadSaver, ref swarm.Address, segment string) error {
+	m, err := manifestfs.LoadManifest(ref, ls)
+	if err != nil {
+		return err
+	}
+	tracks, err := p.manifestTracks(ctx, m, ref)
+	if err != nil {
+		return err
+	}
+	for _, next := range Next(tracks, segment, p.opts.Segments) {
+		e, err := m.Lookup(ctx, next)
+		if err != nil {
+			if errors.Is(err, manifest.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if !p.markRecent(e.Reference()) {
+			continue
+		}
+		if err := p.fetch(ctx, e.Reference()); err != nil {
+			p.forgetRecent(e.Reference())
+			return err
+		}
+		p.logger.Debug("prefetched segment", "reference", ref, "segment", next)
+	}
+	return nil
+}
+
+// manifestTracks returns the tracks of the playlists of the manifest with
+// the segment paths relative to the manifest root.
+func (p *Prefetcher) manifestTracks(ctx context.Context, m *manifestfs.Manifest, ref swarm.Address) ([]Track, error) {
+	key := ref.ByteString()
+	p.mu.Lock()
+	tracks, ok := p.tracks[key]
+	p.mu.Unlock()
+	if ok {
+		return tracks, nil
+	}
+
+	playlists := make(map[string]swarm.Address)
+	err := m.Walk(ctx, func(name string, e manifest.Entry) error {
+		if IsPlaylist(name) {
+			playlists[name] = e.Reference()
+			if len(playlists) >= maxPlaylists {
+				return manifestfs.ErrStopWalk
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, manifest.ErrNotFound) {
+		return nil, err
+	}
+
+	for name, pref := range playlists {
+		j, _, err := joiner.New(ctx, p.getter, p.cache, pref, redundancy.DefaultLevel)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := ParsePlaylist(name, io.LimitReader(j, maxPlaylistSize))
+		if err != nil {
+			p.logger.Debug("invalid playlist", "reference", ref, "playlist", name, "error", err)
+			continue
+		}
+		for _, t := range parsed {
+			track := make(Track, 0, len(t))
+			for _, uri := range t {
+				if s, ok := resolve(name, uri); ok {
+					track = append(track, s)
+				}
+			}
+			tracks = append(tracks, track)
+		}
+	}
+
+	p.mu.Lock()
+	if len(p.tracks) >= maxCachedManifest {
+		clear(p.tracks)
+	}
+	p.tracks[key] = tracks
+	p.mu.Unlock()
+	return tracks, nil
+}
+
+// resolve returns the manifest path of the segment URI of the playlist.
+func resolve(playlist, uri string) (string, bool) {
+	if strings.Contains(uri, "://") {
+		return "", false
+	}
+	if i := strings.IndexAny(uri, "?#"); i >= 0 {
+		uri = uri[:i]
+	}
+	if strings.HasPrefix(uri, "/") {
+		return strings.TrimPrefix(path.Clean(uri), "/"), true
+	}
+	return path.Join(path.Dir(playlist), uri), true
+}
+
+// markRecent records the segment reference as prefetched and reports
+// whether it was not prefetched recently.
+func (p *Prefetcher) markRecent(ref swarm.Address) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.recent[ref.ByteString()]; ok {
+		return false
+	}
+	if len(p.recent) >= maxRecent {
+		clear(p.recent)
+	}
+	p.recent[ref.ByteString()] = struct{}{}
+	return true
+}
+
+func (p *Prefetcher) forgetRecent(ref swarm.Address) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.recent, ref.ByteString())
+}
+
+// fetch retrieves all the chunks of the file of the reference.
+func (p *Prefetcher) fetch(ctx context.Context, ref swarm.Address) error {
+	j, _, err := joiner.New(ctx, p.getter, p.cache, ref, redundancy.DefaultLevel)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(io.Discard, j)
+	return err
+}
+
+// Close cancels the prefetches in progress and waits for them to return.
+func (p *Prefetcher) Close() error {
+	p.closeMu.Lock()
+	p.closed = true
+	p.closeMu.Unlock()
+
+	p.cancel()
+	p.wg.Wait()
+	return nil
+}
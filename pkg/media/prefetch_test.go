@@ -0,0 +1,96 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package media_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/ethersphere/bee/v2/pkg/media"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestPrefetcher(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := inmemchunkstore.New()
+	newPipeline := func() pipeline.Interface {
+		return builder.NewPipelineBuilder(ctx, store, false, redundancy.NONE)
+	}
+	ls := loadsave.New(store, store, newPipeline, redundancy.DefaultLevel)
+
+	files := map[string]string{
+		"video/index.m3u8": "#EXTM3U\n#EXTINF:4,\nseg0.ts\n#EXTINF:4,\nseg1.ts\n#EXTINF:4,\n/video/seg2.ts\n#EXTINF:4,\nseg3.ts\n",
+		"video/seg0.ts":    "segment 0",
+		"video/seg1.ts":    "segment 1",
+		"video/seg2.ts":    "segment 2",
+		"video/seg3.ts":    "segment 3",
+	}
+	refs := make(map[string]swarm.Address)
+	m, err := manifestfs.LoadManifest(swarm.ZeroAddress, ls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		ref, err := builder.FeedPipeline(ctx, newPipeline(), strings.NewReader(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		refs[name] = ref
+		if err := m.Add(ctx, name, manifest.NewEntry(ref, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root, err := m.Store(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu      sync.Mutex
+		fetched = make(map[string]bool)
+	)
+	getter := storage.GetterFunc(func(ctx context.Context, addr swarm.Address) (swarm.Chunk, error) {
+		mu.Lock()
+		fetched[addr.ByteString()] = true
+		mu.Unlock()
+		return store.Get(ctx, addr)
+	})
+
+	p := media.NewPrefetcher(getter, store, log.Noop, media.Options{Segments: 2})
+	t.Cleanup(func() { _ = p.Close() })
+	p.Prefetch(ls, root, "video/seg0.ts")
+
+	isFetched := func(name string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return fetched[refs[name].ByteString()]
+	}
+	for deadline := time.Now().Add(10 * time.Second); !isFetched("video/seg1.ts") || !isFetched("video/seg2.ts"); {
+		if time.Now().After(deadline) {
+			t.Fatal("upcoming segments not prefetched")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if isFetched("video/seg3.ts") {
+		t.Fatal("segment after the prefetched ones fetched")
+	}
+}
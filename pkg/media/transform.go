@@ -0,0 +1,130 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+
+	"github.com/ethersphere/bee/v2/pkg/file/joiner"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"resenje.org/singleflight"
+)
+
+const (
+	// derivedKeyPrefix is the state store key prefix of the derived images.
+	derivedKeyPrefix = "media_derived_image_"
+
+	maxImageSize = 64 * 1024 * 1024 // maximum size of the original image read
+)
+
+// Storer is the storage the images are read from and the derived ones cached in.
+type Storer interface {
+	Download(cache bool) storage.Getter
+	Cache() storage.Putter
+	ChunkStore() storage.ReadOnlyChunkStore
+}
+
+// derivedImage is the transformed image kept in the local cache.
+type derivedImage struct {
+	Reference   swarm.Address `json:"reference"`
+	ContentType string        `json:"contentType"`
+}
+
+// ImageTransformer transforms the images of the references and caches the
+// transformed images in the local store, so they are derived only once for
+// the same options while their chunks are not evicted.
+type ImageTransformer struct {
+	storer     Storer
+	stateStore storage.StateStorer
+	logger     log.Logger
+	sem        chan struct{}
+	flight     singleflight.Group[string, derivedImage]
+}
+
+// NewImageTransformer returns the image transformer which records the
+// derived images in the state store.
+func NewImageTransformer(storer Storer, stateStore storage.StateStorer, logger log.Logger) *ImageTransformer {
+	return &ImageTransformer{
+		storer:     storer,
+		stateStore: stateStore,
+		logger:     logger.WithName(loggerName).Register(),
+		sem:        make(chan struct{}, runtime.NumCPU()),
+	}
+}
+
+func derivedKey(ref swarm.Address, o ImageOptions) string {
+	return derivedKeyPrefix + ref.String() + "_" + o.String()
+}
+
+// Transform returns the reference and the content type of the image of the
+// reference transformed with the options.
+func (t *ImageTransformer) Transform(ctx context.Context, ref swarm.Address, o ImageOptions) (swarm.Address, string, error) {
+	if err := o.Validate(); err != nil {
+		return swarm.ZeroAddress, "", err
+	}
+	key := derivedKey(ref, o)
+	d, _, err := t.flight.Do(ctx, key, func(ctx context.Context) (derivedImage, error) {
+		var d derivedImage
+		switch err := t.stateStore.Get(key, &d); {
+		case err == nil:
+			// the chunks of the derived image may have been evicted from the cache
+			if has, err := t.storer.ChunkStore().Has(ctx, d.Reference); err == nil && has {
+				return d, nil
+			}
+		case !errors.Is(err, storage.ErrNotFound):
+			return d, fmt.Errorf("get derived image: %w", err)
+		}
+
+		select {
+		case t.sem <- struct{}{}:
+		case <-ctx.Done():
+			return d, ctx.Err()
+		}
+		defer func() { <-t.sem }()
+
+		d, err := t.transform(ctx, ref, o)
+		if err != nil {
+			return d, err
+		}
+		if err := t.stateStore.Put(key, d); err != nil {
+			return d, fmt.Errorf("put derived image: %w", err)
+		}
+		t.logger.Debug("image transformed", "reference", ref, "options", o, "derived", d.Reference)
+		return d, nil
+	})
+	if err != nil {
+		return swarm.ZeroAddress, "", err
+	}
+	return d.Reference, d.ContentType, nil
+}
+
+func (t *ImageTransformer) transform(ctx context.Context, ref swarm.Address, o ImageOptions) (derivedImage, error) {
+	reader, size, err := joiner.New(ctx, t.storer.Download(true), t.storer.Cache(), ref, redundancy.DefaultLevel)
+	if err != nil {
+		return derivedImage{}, fmt.Errorf("join image: %w", err)
+	}
+	if size > maxImageSize {
+		return derivedImage{}, fmt.Errorf("%w: size %d", ErrImageTooLarge, size)
+	}
+	data, contentType, err := TransformImage(io.LimitReader(reader, maxImageSize), o)
+	if err != nil {
+		return derivedImage{}, err
+	}
+	pipe := builder.NewPipelineBuilder(ctx, t.storer.Cache(), false, redundancy.NONE)
+	derived, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(data))
+	if err != nil {
+		return derivedImage{}, fmt.Errorf("store derived image: %w", err)
+	}
+	return derivedImage{Reference: derived, ContentType: contentType}, nil
+}
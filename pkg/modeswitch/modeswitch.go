@@ -0,0 +1,93 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package modeswitch coordinates the switching of the running node between
+// the light and the full mode, and the reserve on and off. The switch is
+// requested through the API and carried out by the node runner which tears
+// the node down and starts it again in the requested mode, warming up or
+// tearing down the pullsync, the reserve and the storage incentives agent.
+package modeswitch
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrPending is returned when a switch is requested before the previous one is carried out.
+	ErrPending = errors.New("mode switch pending")
+	// ErrFullModeUnavailable is returned when the full mode is requested
+	// for the node that cannot run in it, e.g. without the blockchain endpoint.
+	ErrFullModeUnavailable = errors.New("full mode unavailable")
+	// ErrInvalidMode is returned when the reserve is requested in the light mode.
+	ErrInvalidMode = errors.New("reserve requires full mode")
+)
+
+// Mode is the mode of the node.
+type Mode struct {
+	FullNode bool
+	Reserve  bool
+}
+
+// String returns the name of the node mode.
+func (m Mode) String() string {
+	if m.FullNode {
+		return "full"
+	}
+	return "light"
+}
+
+// Switcher accepts the mode switch requests of the running node.
+type Switcher struct {
+	mu            sync.Mutex
+	current       Mode
+	fullAvailable bool
+	pending       bool
+	switched      chan Mode
+}
+
+// New returns the switcher of the node running in the current mode.
+func New(current Mode, fullAvailable bool) *Switcher {
+	return &Switcher{
+		current:       current,
+		fullAvailable: fullAvailable,
+		switched:      make(chan Mode, 1),
+	}
+}
+
+// Mode returns the current mode of the node.
+func (s *Switcher) Mode() Mode {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current
+}
+
+// Switch requests the switch to the mode. It reports whether the
+// mode differs from the current one and the node is switched.
+func (s *Switcher) Switch(m Mode) (bool, error) {
+	if m.Reserve && !m.FullNode {
+		return false, ErrInvalidMode
+	}
+	if m.FullNode && !s.fullAvailable {
+		return false, ErrFullModeUnavailable
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending {
+		return false, ErrPending
+	}
+	if m == s.current {
+		return false, nil
+	}
+	s.pending = true
+	s.switched <- m
+	return true, nil
+}
+
+// Switched returns the channel receiving the requested mode.
+func (s *Switcher) Switched() <-chan Mode {
+	return s.switched
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package modeswitch_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/modeswitch"
+)
+
+func TestSwitch(t *testing.T) {
+	t.Parallel()
+
+	light := modeswitch.Mode{}
+	full := modeswitch.Mode{FullNode: true, Reserve: true}
+
+	s := modeswitch.New(light, true)
+
+	if switched, err := s.Switch(light); err != nil || switched {
+		t.Fatalf("same mode: want no switch, have %v %v", switched, err)
+	}
+	if _, err := s.Switch(modeswitch.Mode{Reserve: true}); !errors.Is(err, modeswitch.ErrInvalidMode) {
+		t.Fatalf("want error %v, have %v", modeswitch.ErrInvalidMode, err)
+	}
+
+	switched, err := s.Switch(full)
+	if err != nil || !switched {
+		t.Fatalf("want switch, have %v %v", switched, err)
+	}
+	if have := <-s.Switched(); have != full {
+		t.Fatalf("switched mode: want %+v, have %+v", full, have)
+	}
+	if _, err := s.Switch(light); !errors.Is(err, modeswitch.ErrPending) {
+		t.Fatalf("want error %v, have %v", modeswitch.ErrPending, err)
+	}
+	if have := s.Mode(); have != light {
+		t.Fatalf("mode before the restart: want %+v, have %+v", light, have)
+	}
+
+	if _, err := modeswitch.New(light, false).Switch(full); !errors.Is(err, modeswitch.ErrFullModeUnavailable) {
+		t.Fatalf("want error %v, have %v", modeswitch.ErrFullModeUnavailable, err)
+	}
+}
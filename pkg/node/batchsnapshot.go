@@ -0,0 +1,76 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/transaction"
+)
+
+const batchSnapshotFetchTimeout = 5 * time.Minute
+
+// fetchBatchSnapshot fetches the batch store snapshot from the first of the
+// trusted node API endpoints that serves a valid snapshot. A snapshot is
+// rejected if its digest does not match its content or if it is taken at a
+// block which the chain backend has not yet seen.
+func fetchBatchSnapshot(ctx context.Context, logger log.Logger, endpoints []string, chainBackend transaction.Backend) (*postage.BatchSnapshot, error) {
+	head, err := chainBackend.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("block number: %w", err)
+	}
+
+	var errs error
+	for _, endpoint := range endpoints {
+		snapshot, err := fetchBatchSnapshotFrom(ctx, endpoint)
+		if err == nil {
+			if _, err = snapshot.DecodeBatches(); err == nil && snapshot.Block > head {
+				err = fmt.Errorf("snapshot block %d ahead of the chain head %d", snapshot.Block, head)
+			}
+		}
+		if err != nil {
+			logger.Warning("batch snapshot from trusted node rejected", "endpoint", endpoint, "error", err)
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", endpoint, err))
+			continue
+		}
+		logger.Info("batch snapshot fetched", "endpoint", endpoint, "block", snapshot.Block, "batches", len(snapshot.Batches))
+		return snapshot, nil
+	}
+	return nil, errs
+}
+
+func fetchBatchSnapshotFrom(ctx context.Context, endpoint string) (*postage.BatchSnapshot, error) {
+	ctx, cancel := context.WithTimeout(ctx, batchSnapshotFetchTimeout)
+	defer cancel()
+
+	url := strings.TrimSuffix(endpoint, "/") + "/batches/snapshot"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	snapshot := new(postage.BatchSnapshot)
+	if err := json.NewDecoder(resp.Body).Decode(snapshot); err != nil {
+		return nil, fmt.Errorf("decode snapshot: %w", err)
+	}
+	return snapshot, nil
+}
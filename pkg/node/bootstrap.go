@@ -95,13 +95,22 @@ func bootstrapNode(
 		retErr = multierror.Append(new(multierror.Error), retErr, b.Shutdown()).ErrorOrNil()
 	}()
 
+	networkAllowlist, err := parseNetworkAllowlist(o.NetworkAllowlist)
+	if err != nil {
+		return nil, err
+	}
+
 	p2ps, err := libp2p.New(p2pCtx, signer, networkID, swarmAddress, addr, addressbook, stateStore, lightNodes, logger, tracer, libp2p.Options{
-		PrivateKey:     libp2pPrivateKey,
-		NATAddr:        o.NATAddr,
-		EnableWS:       o.EnableWS,
-		WelcomeMessage: o.WelcomeMessage,
-		FullNode:       false,
-		Nonce:          nonce,
+		PrivateKey:         libp2pPrivateKey,
+		NATAddr:            o.NATAddr,
+		EnableWS:           o.EnableWS,
+		EnableQUIC:         o.EnableQUIC,
+		EnableWebTransport: o.EnableWebTransport,
+		WelcomeMessage:     o.WelcomeMessage,
+		NetworkKey:         networkKey(o.NetworkKey),
+		NetworkAllowlist:   networkAllowlist,
+		FullNode:           false,
+		Nonce:              nonce,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("p2p service: %w", err)
@@ -174,7 +183,7 @@ func bootstrapNode(
 
 	radiusF := func() (uint8, error) { return swarm.MaxBins, nil }
 
-	retrieve := retrieval.New(swarmAddress, radiusF, localStore, p2ps, kad, logger, acc, pricer, tracer, o.RetrievalCaching)
+	retrieve := retrieval.New(swarmAddress, radiusF, localStore, p2ps, kad, logger, acc, pricer, tracer, o.RetrievalCaching, nil)
 	if err = p2ps.AddProtocol(retrieve.Protocol()); err != nil {
 		return nil, fmt.Errorf("retrieval service: %w", err)
 	}
@@ -29,8 +29,10 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap/erc20"
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap/priceoracle"
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap/swapprotocol"
+	"github.com/ethersphere/bee/v2/pkg/statestore/leveldb"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/transaction"
+	"github.com/ethersphere/bee/v2/pkg/transaction/cached"
 	"github.com/ethersphere/bee/v2/pkg/transaction/wrapped"
 	"github.com/ethersphere/go-sw3-abi/sw3abi"
 	"github.com/prometheus/client_golang/prometheus"
@@ -53,6 +55,7 @@ func InitChain(
 	signer crypto.Signer,
 	pollingInterval time.Duration,
 	chainEnabled bool,
+	proxyOptions cached.Options,
 ) (transaction.Backend, common.Address, int64, transaction.Monitor, transaction.Service, error) {
 	var backend transaction.Backend = &noOpChainBackend{
 		chainID: oChainID,
@@ -75,6 +78,14 @@ func InitChain(
 		logger.Info("connected to blockchain backend", "version", versionString)
 
 		backend = wrapped.NewBackend(ethclient.NewClient(rpcClient))
+
+		if proxyOptions.Cache || proxyOptions.HourlyBudget > 0 {
+			if proxyOptions.BlockTime == 0 {
+				proxyOptions.BlockTime = pollingInterval
+			}
+			backend = cached.NewBackend(backend, proxyOptions)
+			logger.Info("using blockchain backend proxy", "cache", proxyOptions.Cache, "hourly_budget", proxyOptions.HourlyBudget)
+		}
 	}
 
 	chainID, err := backend.ChainID(ctx)
@@ -120,6 +131,67 @@ func InitChequebookFactory(logger log.Logger, backend transaction.Backend, chain
 	return chequebook.NewFactory(backend, transactionService, currentFactory), nil
 }
 
+// InitChequebookSponsor initializes the sponsor which deploys the chequebook
+// of the node through the chequebook factory from the account of the hex
+// encoded funder key, so that the funder pays the gas of the deployment
+// instead of the node. The returned function releases the transaction
+// service of the funder and must be called once the chequebook is
+// initialized.
+func InitChequebookSponsor(
+	logger log.Logger,
+	backend transaction.Backend,
+	chainID int64,
+	pollingInterval time.Duration,
+	factoryAddress string,
+	funderKey string,
+) (*chequebook.Sponsor, func(), error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(funderKey, "0x"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode funder key: %w", err)
+	}
+	key, err := crypto.DecodeSecp256k1PrivateKey(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode funder key: %w", err)
+	}
+	signer := crypto.NewDefaultSigner(key)
+
+	funderAddress, err := signer.EthereumAddress()
+	if err != nil {
+		return nil, nil, fmt.Errorf("funder address: %w", err)
+	}
+
+	// the transactions of the funder are tracked only for the deployment
+	stateStore, err := leveldb.NewInMemoryStateStore(logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("funder state store: %w", err)
+	}
+
+	transactionMonitor := transaction.NewMonitor(logger, backend, funderAddress, pollingInterval, cancellationDepth)
+
+	transactionService, err := transaction.NewService(logger, funderAddress, backend, signer, stateStore, big.NewInt(chainID), transactionMonitor)
+	if err != nil {
+		_ = transactionMonitor.Close()
+		_ = stateStore.Close()
+		return nil, nil, fmt.Errorf("funder transaction service: %w", err)
+	}
+
+	closeFunc := func() {
+		_ = transactionService.Close()
+		_ = transactionMonitor.Close()
+		_ = stateStore.Close()
+	}
+
+	factory, err := InitChequebookFactory(logger, backend, chainID, transactionService, factoryAddress)
+	if err != nil {
+		closeFunc()
+		return nil, nil, err
+	}
+
+	logger.Info("chequebook deployment sponsored", "funder_address", funderAddress)
+
+	return &chequebook.Sponsor{Address: funderAddress, Factory: factory}, closeFunc, nil
+}
+
 // InitChequebookService will initialize the chequebook service with the given
 // chequebook factory and chain backend.
 func InitChequebookService(
@@ -134,6 +206,7 @@ func InitChequebookService(
 	chequebookFactory chequebook.Factory,
 	initialDeposit string,
 	erc20Service erc20.Service,
+	sponsor *chequebook.Sponsor,
 ) (chequebook.Service, error) {
 	chequeSigner := chequebook.NewChequeSigner(signer, chainID)
 
@@ -154,6 +227,7 @@ func InitChequebookService(
 		overlayEthAddress,
 		chequeSigner,
 		erc20Service,
+		sponsor,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("chequebook init: %w", err)
@@ -200,23 +274,8 @@ func InitSwap(
 	chequeStore chequebook.ChequeStore,
 	cashoutService chequebook.CashoutService,
 	accounting settlement.Accounting,
-	priceOracleAddress string,
-	chainID int64,
-	transactionService transaction.Service,
-) (*swap.Service, priceoracle.Service, error) {
-	var currentPriceOracleAddress common.Address
-	if priceOracleAddress == "" {
-		chainCfg, found := config.GetByChainID(chainID)
-		currentPriceOracleAddress = chainCfg.SwapPriceOracleAddress
-		if !found {
-			return nil, nil, errors.New("no known price oracle address for this network")
-		}
-	} else {
-		currentPriceOracleAddress = common.HexToAddress(priceOracleAddress)
-	}
-
-	priceOracle := priceoracle.New(logger, currentPriceOracleAddress, transactionService, 300)
-	priceOracle.Start()
+	priceOracle priceoracle.Service,
+) (*swap.Service, error) {
 	swapProtocol := swapprotocol.New(p2ps, logger, overlayEthAddress, priceOracle)
 	swapAddressBook := swap.NewAddressbook(stateStore)
 
@@ -242,10 +301,55 @@ func InitSwap(
 
 	err := p2ps.AddProtocol(swapProtocol.Protocol())
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+
+	return swapService, nil
+}
+
+// InitPriceOracle will initialize and start the price oracle of the swap
+// exchange rate. The rates are static if the exchange rate is set, for the
+// private swarms without the price oracle contract, otherwise they are read
+// from the contract.
+func InitPriceOracle(
+	logger log.Logger,
+	priceOracleAddress string,
+	exchangeRate string,
+	deduction string,
+	chainID int64,
+	transactionService transaction.Service,
+) (priceoracle.Service, error) {
+	if exchangeRate != "" {
+		rate, ok := new(big.Int).SetString(exchangeRate, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid exchange rate: %s", exchangeRate)
+		}
+		deduct, ok := new(big.Int).SetString(deduction, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid deduction: %s", deduction)
+		}
+		priceOracle, err := priceoracle.NewStatic(rate, deduct)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("using static exchange rate", "exchange_rate", rate, "deduction", deduct)
+		return priceOracle, nil
+	}
+
+	var currentPriceOracleAddress common.Address
+	if priceOracleAddress == "" {
+		chainCfg, found := config.GetByChainID(chainID)
+		currentPriceOracleAddress = chainCfg.SwapPriceOracleAddress
+		if !found {
+			return nil, errors.New("no known price oracle address for this network")
+		}
+	} else {
+		currentPriceOracleAddress = common.HexToAddress(priceOracleAddress)
 	}
 
-	return swapService, priceOracle, nil
+	priceOracle := priceoracle.New(logger, currentPriceOracleAddress, transactionService, 300)
+	priceOracle.Start()
+	return priceOracle, nil
 }
 
 func GetTxHash(stateStore storage.StateStorer, logger log.Logger, trxString string) ([]byte, error) {
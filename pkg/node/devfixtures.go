@@ -0,0 +1,218 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/chaos"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// devFixturesFile is the name of the file in the fixtures directory
+// describing the batches created and the content uploaded on the start.
+const devFixturesFile = "fixtures.json"
+
+// devFixtures are the batches and the content preloaded by the dev node.
+type devFixtures struct {
+	Batches []devFixtureBatch   `json:"batches"`
+	Content []devFixtureContent `json:"content"`
+}
+
+// devFixtureBatch is the preloaded postage batch. The id is hex encoded
+// and it is generated when missing. The amount is a decimal string.
+type devFixtureBatch struct {
+	ID        string `json:"id"`
+	Label     string `json:"label"`
+	Amount    string `json:"amount"`
+	Depth     uint8  `json:"depth"`
+	Immutable bool   `json:"immutable"`
+}
+
+// devFixtureContent is the file uploaded as raw bytes. The file path is
+// relative to the fixtures directory and the batch is either the label
+// or the hex encoded id of one of the fixture batches.
+type devFixtureContent struct {
+	File  string `json:"file"`
+	Batch string `json:"batch"`
+	Pin   bool   `json:"pin"`
+}
+
+// devBatchCreator saves the batch and adds its stamp issuer.
+type devBatchCreator func(id []byte, amount *big.Int, depth uint8, immutable bool, label string) error
+
+// loadDevFixtures creates the batches and uploads the content
+// described by the fixtures file in the given directory.
+func loadDevFixtures(ctx context.Context, logger log.Logger, dir string, entropy io.Reader, createBatch devBatchCreator, upload func(ctx context.Context, batchID []byte, pin bool, r io.Reader) (swarm.Address, error)) error {
+	data, err := os.ReadFile(filepath.Join(dir, devFixturesFile))
+	if err != nil {
+		return fmt.Errorf("read fixtures: %w", err)
+	}
+
+	var fixtures devFixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return fmt.Errorf("decode fixtures: %w", err)
+	}
+
+	batches := make(map[string][]byte)
+	for _, b := range fixtures.Batches {
+		var id []byte
+		if b.ID != "" {
+			id, err = hex.DecodeString(b.ID)
+			if err != nil || len(id) != 32 {
+				return fmt.Errorf("batch %q: invalid id", b.ID)
+			}
+		} else {
+			id = make([]byte, 32)
+			if _, err := io.ReadFull(entropy, id); err != nil {
+				return fmt.Errorf("batch id: %w", err)
+			}
+		}
+
+		amount, ok := new(big.Int).SetString(b.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			return fmt.Errorf("batch %x: invalid amount %q", id, b.Amount)
+		}
+		if b.Depth <= postage.BucketDepth {
+			return fmt.Errorf("batch %x: depth %d too small", id, b.Depth)
+		}
+
+		if err := createBatch(id, amount, b.Depth, b.Immutable, b.Label); err != nil {
+			return fmt.Errorf("batch %x: %w", id, err)
+		}
+		batches[hex.EncodeToString(id)] = id
+		if b.Label != "" {
+			batches[b.Label] = id
+		}
+		logger.Info("fixture batch created", "batch_id", hex.EncodeToString(id), "label", b.Label, "depth", b.Depth)
+	}
+
+	for _, c := range fixtures.Content {
+		id, ok := batches[c.Batch]
+		if !ok {
+			return fmt.Errorf("content %q: unknown batch %q", c.File, c.Batch)
+		}
+
+		ref, err := uploadDevFixture(ctx, filepath.Join(dir, c.File), id, c.Pin, upload)
+		if err != nil {
+			return fmt.Errorf("content %q: %w", c.File, err)
+		}
+		logger.Info("fixture content uploaded", "file", c.File, "reference", ref)
+	}
+
+	return nil
+}
+
+func uploadDevFixture(ctx context.Context, path string, batchID []byte, pin bool, upload func(context.Context, []byte, bool, io.Reader) (swarm.Address, error)) (swarm.Address, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return swarm.ZeroAddress, err
+	}
+	defer f.Close()
+
+	return upload(ctx, batchID, pin, f)
+}
+
+// devUploader returns the function uploading the raw bytes to the local
+// store, the chunks are stamped with the given batch.
+func devUploader(store api.Storer, post postage.Service, stamperStore storage.Store, signer crypto.Signer) func(ctx context.Context, batchID []byte, pin bool, r io.Reader) (swarm.Address, error) {
+	return func(ctx context.Context, batchID []byte, pin bool, r io.Reader) (ref swarm.Address, err error) {
+		issuer, save, err := post.GetStampIssuer(batchID)
+		if err != nil {
+			return swarm.ZeroAddress, fmt.Errorf("stamp issuer: %w", err)
+		}
+		defer func() { err = errors.Join(err, save()) }()
+
+		tag, err := store.NewSession()
+		if err != nil {
+			return swarm.ZeroAddress, fmt.Errorf("new session: %w", err)
+		}
+		session, err := store.Upload(ctx, pin, tag.TagID)
+		if err != nil {
+			return swarm.ZeroAddress, fmt.Errorf("upload session: %w", err)
+		}
+
+		stamper := postage.NewStamper(stamperStore, issuer, signer)
+		putter := storage.PutterFunc(func(ctx context.Context, ch swarm.Chunk) error {
+			idAddress, err := storage.IdentityAddress(ch)
+			if err != nil {
+				return err
+			}
+			stamp, err := stamper.Stamp(ch.Address(), idAddress)
+			if err != nil {
+				return err
+			}
+			return session.Put(ctx, ch.WithStamp(stamp))
+		})
+
+		ref, err = builder.FeedPipeline(ctx, builder.NewPipelineBuilder(ctx, putter, false, redundancy.NONE), r)
+		if err != nil {
+			return swarm.ZeroAddress, errors.Join(err, session.Cleanup())
+		}
+		if err := session.Done(ref); err != nil {
+			return swarm.ZeroAddress, fmt.Errorf("done: %w", err)
+		}
+		return ref, nil
+	}
+}
+
+// chaosStorer injects the faults into the retrievals and the uploads of the
+// dev node. The uploads stand in for the push sync as the dev node has no
+// peers to push the chunks to.
+type chaosStorer struct {
+	api.Storer
+	chaos *chaos.Injector
+}
+
+func (s chaosStorer) Download(cache bool) storage.Getter {
+	return s.chaos.Getter(s.Storer.Download(cache))
+}
+
+func (s chaosStorer) Upload(ctx context.Context, pin bool, tagID uint64) (storer.PutterSession, error) {
+	session, err := s.Storer.Upload(ctx, pin, tagID)
+	if err != nil {
+		return nil, err
+	}
+	return chaosPutterSession{PutterSession: session, putter: s.chaos.Putter(session)}, nil
+}
+
+type chaosPutterSession struct {
+	storer.PutterSession
+	putter storage.Putter
+}
+
+func (s chaosPutterSession) Put(ctx context.Context, ch swarm.Chunk) error {
+	return s.putter.Put(ctx, ch)
+}
+
+// lockedReader makes the reader safe for the concurrent use.
+type lockedReader struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+func (l *lockedReader) Read(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.r.Read(p)
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node_test
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/node"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestLoadDevFixtures(t *testing.T) {
+	t.Parallel()
+
+	const fixedID = "1111111111111111111111111111111111111111111111111111111111111111"
+
+	writeFixtures := func(t *testing.T, fixtures string) string {
+		t.Helper()
+
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "fixtures.json"), []byte(fixtures), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello swarm"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+
+	type batch struct {
+		id        string
+		amount    string
+		depth     uint8
+		immutable bool
+		label     string
+	}
+
+	load := func(t *testing.T, dir string, seed int64) ([]batch, map[string]string, error) {
+		t.Helper()
+
+		var batches []batch
+		uploads := make(map[string]string)
+		err := node.LoadDevFixtures(context.Background(), log.Noop, dir, rand.New(rand.NewSource(seed)),
+			func(id []byte, amount *big.Int, depth uint8, immutable bool, label string) error {
+				batches = append(batches, batch{hex.EncodeToString(id), amount.String(), depth, immutable, label})
+				return nil
+			},
+			func(_ context.Context, batchID []byte, _ bool, r io.Reader) (swarm.Address, error) {
+				data, err := io.ReadAll(r)
+				if err != nil {
+					return swarm.ZeroAddress, err
+				}
+				uploads[string(data)] = hex.EncodeToString(batchID)
+				return swarm.RandAddress(t), nil
+			},
+		)
+		return batches, uploads, err
+	}
+
+	dir := writeFixtures(t, `{
+		"batches": [
+			{"id": "`+fixedID+`", "amount": "1000", "depth": 20, "immutable": true},
+			{"label": "generated", "amount": "500", "depth": 17}
+		],
+		"content": [
+			{"file": "hello.txt", "batch": "generated", "pin": true}
+		]
+	}`)
+
+	batches, uploads, err := load(t, dir, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) != 2 {
+		t.Fatalf("got %d batches, want 2", len(batches))
+	}
+	if want := (batch{fixedID, "1000", 20, true, ""}); batches[0] != want {
+		t.Fatalf("got batch %+v, want %+v", batches[0], want)
+	}
+	generated := batches[1]
+	if generated.label != "generated" || generated.amount != "500" || generated.depth != 17 {
+		t.Fatalf("unexpected batch %+v", generated)
+	}
+	if got := uploads["hello swarm"]; got != generated.id {
+		t.Fatalf("content uploaded with batch %q, want %q", got, generated.id)
+	}
+
+	// the same seed generates the same batch ids
+	again, _, err := load(t, dir, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again[1].id != generated.id {
+		t.Fatalf("got batch id %s, want %s", again[1].id, generated.id)
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, fixtures := range []string{
+			`not json`,
+			`{"batches": [{"id": "abcd", "amount": "1", "depth": 17}]}`,
+			`{"batches": [{"amount": "zero", "depth": 17}]}`,
+			`{"batches": [{"amount": "1", "depth": 16}]}`,
+			`{"content": [{"file": "hello.txt", "batch": "missing"}]}`,
+			`{"batches": [{"label": "b", "amount": "1", "depth": 17}], "content": [{"file": "missing.txt", "batch": "b"}]}`,
+		} {
+			if _, _, err := load(t, writeFixtures(t, fixtures), 1); err == nil {
+				t.Fatalf("want error for fixtures %s", fixtures)
+			}
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		if _, _, err := load(t, t.TempDir(), 1); err == nil {
+			t.Fatal("want error for missing fixtures")
+		}
+	})
+}
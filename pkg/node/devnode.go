@@ -12,6 +12,7 @@ import (
 	"io"
 	stdlog "log"
 	"math/big"
+	mrand "math/rand"
 	"net"
 	"net/http"
 	"time"
@@ -21,6 +22,7 @@ import (
 	mockAccounting "github.com/ethersphere/bee/v2/pkg/accounting/mock"
 	"github.com/ethersphere/bee/v2/pkg/api"
 	"github.com/ethersphere/bee/v2/pkg/bzz"
+	"github.com/ethersphere/bee/v2/pkg/chaos"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
 	"github.com/ethersphere/bee/v2/pkg/gsoc"
@@ -32,7 +34,6 @@ import (
 	mockPost "github.com/ethersphere/bee/v2/pkg/postage/mock"
 	"github.com/ethersphere/bee/v2/pkg/postage/postagecontract"
 	mockPostContract "github.com/ethersphere/bee/v2/pkg/postage/postagecontract/mock"
-	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
 	"github.com/ethersphere/bee/v2/pkg/pss"
 	"github.com/ethersphere/bee/v2/pkg/pushsync"
 	mockPushsync "github.com/ethersphere/bee/v2/pkg/pushsync/mock"
@@ -81,6 +82,12 @@ type DevOptions struct {
 	DBWriteBufferSize        uint64
 	DBBlockCacheCapacity     uint64
 	DBDisableSeeksCompaction bool
+	// Seed makes the keys, the overlay address and the batch ids of the
+	// node and the injected faults deterministic when it is not zero.
+	Seed int64
+	// FixturesDir is the directory with the batches and the content
+	// preloaded on the start, none are preloaded when it is empty.
+	FixturesDir string
 }
 
 // NewDevBee starts the bee instance in 'development' mode
@@ -109,7 +116,15 @@ func NewDevBee(logger log.Logger, o *DevOptions) (b *DevBee, err error) {
 	}
 	b.stateStoreCloser = stateStore
 
-	swarmAddress, err := randomAddress()
+	var entropy io.Reader = rand.Reader
+	chaosSeed := time.Now().UnixNano()
+	if o.Seed != 0 {
+		entropy = &lockedReader{r: mrand.New(mrand.NewSource(o.Seed))}
+		chaosSeed = o.Seed
+	}
+	chaosInjector := chaos.New(chaosSeed)
+
+	swarmAddress, err := randomAddress(entropy)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +142,11 @@ func NewDevBee(logger log.Logger, o *DevOptions) (b *DevBee, err error) {
 		return nil, fmt.Errorf("batchstore: %w", err)
 	}
 
-	mockKey, err := crypto.GenerateSecp256k1Key()
+	keyData := make([]byte, 32)
+	if _, err := io.ReadFull(entropy, keyData); err != nil {
+		return nil, err
+	}
+	mockKey, err := crypto.DecodeSecp256k1PrivateKey(keyData)
 	if err != nil {
 		return nil, err
 	}
@@ -190,6 +209,7 @@ func NewDevBee(logger log.Logger, o *DevOptions) (b *DevBee, err error) {
 		return nil, fmt.Errorf("localstore: %w", err)
 	}
 	b.localstoreCloser = localStore
+	chaosStore := chaosStorer{Storer: localStore, chaos: chaosInjector}
 
 	session := accesscontrol.NewDefaultSession(mockKey)
 	actLogic := accesscontrol.NewLogic(session)
@@ -199,32 +219,40 @@ func NewDevBee(logger log.Logger, o *DevOptions) (b *DevBee, err error) {
 	pssService := pss.New(mockKey, logger)
 	b.pssCloser = pssService
 
-	pssService.SetPushSyncer(mockPushsync.New(func(ctx context.Context, chunk swarm.Chunk) (*pushsync.Receipt, error) {
+	pssService.SetPushSyncer(chaosInjector.PushSyncer(mockPushsync.New(func(ctx context.Context, chunk swarm.Chunk) (*pushsync.Receipt, error) {
 		pssService.TryUnwrap(chunk)
 		return &pushsync.Receipt{}, nil
-	}))
+	})))
 
 	post := mockPost.New()
+	createBatch := func(id []byte, amount *big.Int, depth uint8, immutable bool, label string) error {
+		batch := &postage.Batch{
+			ID:        id,
+			Owner:     overlayEthAddress.Bytes(),
+			Value:     big.NewInt(0).Mul(amount, big.NewInt(int64(1<<depth))),
+			Depth:     depth,
+			Immutable: immutable,
+		}
+
+		err := batchStore.Save(batch)
+		if err != nil {
+			return err
+		}
+
+		stampIssuer := postage.NewStampIssuer(label, string(overlayEthAddress.Bytes()), id, amount, batch.Depth, 0, 0, immutable)
+		_ = post.Add(stampIssuer)
+		return nil
+	}
 	postageContract := mockPostContract.New(
 		mockPostContract.WithCreateBatchFunc(
 			func(ctx context.Context, amount *big.Int, depth uint8, immutable bool, label string) (common.Hash, []byte, error) {
-				id := postagetesting.MustNewID()
-				batch := &postage.Batch{
-					ID:        id,
-					Owner:     overlayEthAddress.Bytes(),
-					Value:     big.NewInt(0).Mul(amount, big.NewInt(int64(1<<depth))),
-					Depth:     depth,
-					Immutable: immutable,
+				id := make([]byte, 32)
+				if _, err := io.ReadFull(entropy, id); err != nil {
+					return common.Hash{}, nil, err
 				}
-
-				err := batchStore.Save(batch)
-				if err != nil {
+				if err := createBatch(id, amount, depth, immutable, label); err != nil {
 					return common.Hash{}, nil, err
 				}
-
-				stampIssuer := postage.NewStampIssuer(label, string(overlayEthAddress.Bytes()), id, amount, batch.Depth, 0, 0, immutable)
-				_ = post.Add(stampIssuer)
-
 				return common.Hash{}, id, nil
 			},
 		),
@@ -310,7 +338,7 @@ func NewDevBee(logger log.Logger, o *DevOptions) (b *DevBee, err error) {
 		return true, nil
 	}
 
-	mockFeeds := factory.New(localStore.Download(true))
+	mockFeeds := factory.New(chaosStore.Download(true))
 	mockResolver := resolverMock.NewResolver()
 	mockSteward := new(mockSteward.Steward)
 
@@ -338,7 +366,7 @@ func NewDevBee(logger log.Logger, o *DevOptions) (b *DevBee, err error) {
 		Swap:            mockSwap,
 		Chequebook:      mockChequebook,
 		BlockTime:       time.Second * 2,
-		Storer:          localStore,
+		Storer:          chaosStore,
 		Resolver:        mockResolver,
 		Pss:             pssService,
 		Gsoc:            gsoc.New(logger),
@@ -349,6 +377,7 @@ func NewDevBee(logger log.Logger, o *DevOptions) (b *DevBee, err error) {
 		Staking:         mockStaking,
 		Steward:         mockSteward,
 		SyncStatus:      syncStatusFn,
+		Chaos:           chaosInjector,
 	}
 
 	erc20 := erc20mock.New(
@@ -360,13 +389,21 @@ func NewDevBee(logger log.Logger, o *DevOptions) (b *DevBee, err error) {
 		}),
 	)
 
-	apiService := api.New(mockKey.PublicKey, mockKey.PublicKey, overlayEthAddress, nil, logger, mockTransaction, batchStore, api.DevMode, true, true, chainBackend, o.CORSAllowedOrigins, inmemstore.New())
+	stamperStore := inmemstore.New()
+	apiService := api.New(mockKey.PublicKey, mockKey.PublicKey, overlayEthAddress, nil, logger, mockTransaction, batchStore, api.DevMode, true, true, chainBackend, o.CORSAllowedOrigins, stamperStore)
 
 	apiService.Configure(signer, tracer, api.Options{
 		CORSAllowedOrigins: o.CORSAllowedOrigins,
 		WsPingPeriod:       60 * time.Second,
 	}, debugOpts, 1, erc20)
 
+	if o.FixturesDir != "" {
+		err = loadDevFixtures(context.Background(), logger, o.FixturesDir, entropy, createBatch, devUploader(localStore, post, stamperStore, signer))
+		if err != nil {
+			return nil, fmt.Errorf("fixtures: %w", err)
+		}
+	}
+
 	apiService.Mount()
 	apiService.EnableFullAPI()
 	apiService.SetProbe(probe)
@@ -442,10 +479,10 @@ func pong(_ context.Context, _ swarm.Address, _ ...string) (rtt time.Duration, e
 	return time.Millisecond, nil
 }
 
-func randomAddress() (swarm.Address, error) {
+func randomAddress(r io.Reader) (swarm.Address, error) {
 	b := make([]byte, 32)
 
-	_, err := rand.Read(b)
+	_, err := io.ReadFull(r, b)
 	if err != nil {
 		return swarm.ZeroAddress, err
 	}
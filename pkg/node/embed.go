@@ -0,0 +1,270 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethersphere/bee/v2/pkg/accesscontrol"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/file/joiner"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/grpcapi"
+	"github.com/ethersphere/bee/v2/pkg/keystore"
+	memkeystore "github.com/ethersphere/bee/v2/pkg/keystore/mem"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/traversal"
+)
+
+// ErrBatchUnusable is returned when the upload is stamped with
+// the postage batch that does not exist or is not usable yet.
+var ErrBatchUnusable = fmt.Errorf("batch %w", postage.ErrNotUsable)
+
+// Embedded is the facade of the light node embedded in the process of a
+// Go application. The uploads and the downloads go directly through the
+// storer and the joiner, without the HTTP API.
+type Embedded interface {
+	// Overlay returns the overlay address of the node.
+	Overlay() swarm.Address
+	// Upload splits the data into chunks stamped with the postage batch
+	// and uploads them to the network. It returns the root reference.
+	Upload(ctx context.Context, batchID []byte, r io.Reader, o UploadOptions) (swarm.Address, error)
+	// Download returns the reader of the data of the reference and its size.
+	Download(ctx context.Context, ref swarm.Address) (io.ReadSeeker, int64, error)
+	// Pin pins all of the chunks of the reference locally.
+	Pin(ctx context.Context, ref swarm.Address) error
+	// Unpin removes the pin of the reference.
+	Unpin(ctx context.Context, ref swarm.Address) error
+	// Pins returns the pinned references.
+	Pins(ctx context.Context) ([]swarm.Address, error)
+	// Shutdown stops the node.
+	Shutdown() error
+}
+
+// UploadOptions are the options of the embedded node uploads.
+type UploadOptions struct {
+	// Pin the uploaded data locally.
+	Pin bool
+	// Encrypt the uploaded data.
+	Encrypt bool
+	// Deferred uploads return before the data is synced to the network.
+	Deferred bool
+	// RLevel is the redundancy level of the uploaded data.
+	RLevel redundancy.Level
+}
+
+// EmbedOptions are the options of the embedded node.
+type EmbedOptions struct {
+	Options
+	// P2PAddr is the address the p2p service listens on.
+	P2PAddr   string
+	NetworkID uint64
+	// Keystore holds the keys of the node, the keys are kept in memory when nil.
+	Keystore keystore.Service
+	Password string
+	// Logger is the logger of the node, nothing is logged when nil.
+	Logger log.Logger
+}
+
+// Embed starts the light node in the process. The node does not serve
+// the HTTP API, it is used through the returned facade instead.
+func Embed(ctx context.Context, o EmbedOptions) (Embedded, error) {
+	if o.Keystore == nil {
+		o.Keystore = memkeystore.New()
+	}
+	if o.Logger == nil {
+		o.Logger = log.Noop
+	}
+	o.APIAddr = ""
+	o.FullNodeMode = false
+
+	swarmKey, _, err := o.Keystore.Key("swarm", o.Password, crypto.EDGSecp256_K1)
+	if err != nil {
+		return nil, fmt.Errorf("swarm key: %w", err)
+	}
+	libp2pKey, _, err := o.Keystore.Key("libp2p_v2", o.Password, crypto.EDGSecp256_R1)
+	if err != nil {
+		return nil, fmt.Errorf("libp2p key: %w", err)
+	}
+	pssKey, _, err := o.Keystore.Key("pss", o.Password, crypto.EDGSecp256_K1)
+	if err != nil {
+		return nil, fmt.Errorf("pss key: %w", err)
+	}
+
+	b, err := NewBee(ctx, o.P2PAddr, &swarmKey.PublicKey, crypto.NewDefaultSigner(swarmKey), o.NetworkID, o.Logger, libp2pKey, pssKey, accesscontrol.NewDefaultSession(swarmKey), &o.Options)
+	if err != nil {
+		return nil, err
+	}
+	return b.embedded, nil
+}
+
+// embeddedStorer is the part of the storer used by the embedded node.
+type embeddedStorer interface {
+	Upload(ctx context.Context, pin bool, tagID uint64) (storer.PutterSession, error)
+	NewSession() (storer.SessionInfo, error)
+	DirectUpload() storer.PutterSession
+	Download(cache bool) storage.Getter
+	Cache() storage.Putter
+	NewCollection(ctx context.Context) (storer.PutterSession, error)
+	HasPin(root swarm.Address) (bool, error)
+	DeletePin(ctx context.Context, root swarm.Address) error
+	Pins() ([]swarm.Address, error)
+}
+
+type embedded struct {
+	overlay      swarm.Address
+	storer       embeddedStorer
+	post         postage.Service
+	batchStore   postage.Storer
+	stamperStore storage.Store
+	signer       crypto.Signer
+	shutdown     func() error
+}
+
+func (e *embedded) Overlay() swarm.Address {
+	return e.overlay
+}
+
+func (e *embedded) Upload(ctx context.Context, batchID []byte, r io.Reader, o UploadOptions) (swarm.Address, error) {
+	putter, err := e.session(ctx, batchID, o.Deferred, o.Pin)
+	if err != nil {
+		return swarm.ZeroAddress, err
+	}
+	ref, err := builder.FeedPipeline(ctx, builder.NewPipelineBuilder(ctx, putter, o.Encrypt, o.RLevel), r)
+	if err != nil {
+		return swarm.ZeroAddress, errors.Join(fmt.Errorf("split: %w", err), putter.Cleanup())
+	}
+	if err := putter.Done(ref); err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("upload done: %w", err)
+	}
+	return ref, nil
+}
+
+// session returns the upload session stamping the chunks with the batch.
+// The stamp issuer is saved when the session is done or cleaned up.
+func (e *embedded) session(ctx context.Context, batchID []byte, deferred, pin bool) (storer.PutterSession, error) {
+	exists, err := e.batchStore.Exists(batchID)
+	if err != nil {
+		return nil, fmt.Errorf("batch exists: %w", err)
+	}
+	issuer, save, err := e.post.GetStampIssuer(batchID)
+	if err != nil {
+		return nil, fmt.Errorf("stamp issuer: %w", err)
+	}
+	if !exists || !e.post.IssuerUsable(issuer) {
+		return nil, errors.Join(ErrBatchUnusable, save())
+	}
+
+	var session storer.PutterSession
+	if deferred || pin {
+		var info storer.SessionInfo
+		info, err = e.storer.NewSession()
+		if err == nil {
+			session, err = e.storer.Upload(ctx, pin, info.TagID)
+		}
+	} else {
+		session = e.storer.DirectUpload()
+	}
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("upload session: %w", err), save())
+	}
+
+	return &stampedPutter{
+		PutterSession: session,
+		stamper:       postage.NewStamper(e.stamperStore, issuer, e.signer),
+		save:          save,
+	}, nil
+}
+
+func (e *embedded) Download(ctx context.Context, ref swarm.Address) (io.ReadSeeker, int64, error) {
+	reader, size, err := joiner.New(ctx, e.storer.Download(true), e.storer.Cache(), ref, redundancy.DefaultLevel)
+	if err != nil {
+		return nil, 0, fmt.Errorf("join: %w", err)
+	}
+	return reader, size, nil
+}
+
+func (e *embedded) Pin(ctx context.Context, ref swarm.Address) error {
+	has, err := e.storer.HasPin(ref)
+	if err != nil {
+		return fmt.Errorf("has pin: %w", err)
+	}
+	if has {
+		return nil
+	}
+
+	putter, err := e.storer.NewCollection(ctx)
+	if err != nil {
+		return fmt.Errorf("new collection: %w", err)
+	}
+	getter := e.storer.Download(true)
+	err = traversal.New(getter, e.storer.Cache(), redundancy.DefaultLevel).Traverse(ctx, ref, func(address swarm.Address) error {
+		chunk, err := getter.Get(ctx, address)
+		if err != nil {
+			return err
+		}
+		return putter.Put(ctx, chunk)
+	})
+	if err != nil {
+		return errors.Join(fmt.Errorf("traverse: %w", err), putter.Cleanup())
+	}
+	return putter.Done(ref)
+}
+
+func (e *embedded) Unpin(ctx context.Context, ref swarm.Address) error {
+	return e.storer.DeletePin(ctx, ref)
+}
+
+func (e *embedded) Pins(_ context.Context) ([]swarm.Address, error) {
+	return e.storer.Pins()
+}
+
+func (e *embedded) Shutdown() error {
+	return e.shutdown()
+}
+
+// grpcNode adapts the embedded node to the gRPC API.
+type grpcNode struct {
+	*embedded
+}
+
+func (n grpcNode) Upload(ctx context.Context, batchID []byte, r io.Reader, o grpcapi.UploadOptions) (swarm.Address, error) {
+	return n.embedded.Upload(ctx, batchID, r, UploadOptions{Pin: o.Pin, Encrypt: o.Encrypt, Deferred: o.Deferred})
+}
+
+// stampedPutter stamps the chunks before they are put to the session.
+type stampedPutter struct {
+	storer.PutterSession
+	stamper postage.Stamper
+	save    func() error
+}
+
+func (p *stampedPutter) Put(ctx context.Context, chunk swarm.Chunk) error {
+	idAddress, err := storage.IdentityAddress(chunk)
+	if err != nil {
+		return err
+	}
+	stamp, err := p.stamper.Stamp(chunk.Address(), idAddress)
+	if err != nil {
+		return err
+	}
+	return p.PutterSession.Put(ctx, chunk.WithStamp(stamp))
+}
+
+func (p *stampedPutter) Done(ref swarm.Address) error {
+	return errors.Join(p.PutterSession.Done(ref), p.save())
+}
+
+func (p *stampedPutter) Cleanup() error {
+	return errors.Join(p.PutterSession.Cleanup(), p.save())
+}
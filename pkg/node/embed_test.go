@@ -0,0 +1,126 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/node"
+	mockbatchstore "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+func TestEmbedded(t *testing.T) {
+	t.Parallel()
+
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlay := swarm.RandAddress(t)
+	batchID := testutil.RandBytes(t, 32)
+
+	newEmbedded := func(t *testing.T, exists bool) node.Embedded {
+		t.Helper()
+
+		st := mockstorer.New()
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		// direct uploads are pushed to the network, store them locally instead
+		go func() {
+			for {
+				select {
+				case op := <-st.PusherFeed():
+					op.Err <- st.Cache().Put(ctx, op.Chunk)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		return node.NewEmbedded(
+			overlay,
+			st,
+			mockpost.New(mockpost.WithAcceptAll()),
+			mockbatchstore.New(mockbatchstore.WithExistsFunc(func([]byte) (bool, error) { return exists, nil })),
+			inmemstore.New(),
+			crypto.NewDefaultSigner(pk),
+		)
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts node.UploadOptions
+	}{
+		{name: "direct"},
+		{name: "deferred pinned", opts: node.UploadOptions{Deferred: true, Pin: true}},
+		{name: "encrypted", opts: node.UploadOptions{Encrypt: true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := newEmbedded(t, true)
+			if !e.Overlay().Equal(overlay) {
+				t.Fatalf("got overlay %s, want %s", e.Overlay(), overlay)
+			}
+
+			data := testutil.RandBytes(t, 3*swarm.ChunkSize+17)
+			ref, err := e.Upload(context.Background(), batchID, bytes.NewReader(data), tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, size, err := e.Download(context.Background(), ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if size != int64(len(data)) {
+				t.Fatalf("got size %d, want %d", size, len(data))
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatal("downloaded data differs from the uploaded")
+			}
+
+			if err := e.Pin(context.Background(), ref); err != nil {
+				t.Fatal(err)
+			}
+			pins, err := e.Pins(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pins) != 1 || !pins[0].Equal(ref) {
+				t.Fatalf("got pins %v, want %s", pins, ref)
+			}
+			if err := e.Unpin(context.Background(), ref); err != nil {
+				t.Fatal(err)
+			}
+			if pins, _ := e.Pins(context.Background()); len(pins) != 0 {
+				t.Fatalf("got pins %v after unpin", pins)
+			}
+		})
+	}
+
+	t.Run("batch unusable", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newEmbedded(t, false).Upload(context.Background(), batchID, bytes.NewReader([]byte("data")), node.UploadOptions{})
+		if !errors.Is(err, node.ErrBatchUnusable) {
+			t.Fatalf("got error %v, want %v", err, node.ErrBatchUnusable)
+		}
+	})
+}
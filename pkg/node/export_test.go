@@ -0,0 +1,82 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/accounting"
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p/traffic"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/pricing"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func NewEmbedded(overlay swarm.Address, storer embeddedStorer, post postage.Service, batchStore postage.Storer, stamperStore storage.Store, signer crypto.Signer) Embedded {
+	return &embedded{
+		overlay:      overlay,
+		storer:       storer,
+		post:         post,
+		batchStore:   batchStore,
+		stamperStore: stamperStore,
+		signer:       signer,
+		shutdown:     func() error { return nil },
+	}
+}
+
+// NewReloadableBee returns the node which reloads the options
+// of the traffic meter, the pricing and the accounting.
+func NewReloadableBee(current ReloadOptions, load func() (ReloadOptions, error), meter *traffic.Meter, pricing *pricing.Service, acc *accounting.Accounting) *Bee {
+	return &Bee{
+		reloader: &reloader{
+			current:    current,
+			load:       load,
+			fullNode:   true,
+			logger:     log.Noop,
+			traffic:    meter,
+			pricing:    pricing,
+			accounting: acc,
+		},
+	}
+}
+
+// NewServingBee returns the node serving the api server
+// which is drained on shutdown.
+func NewServingBee(server *http.Server, probe *api.Probe, drainDelay, timeout time.Duration) *Bee {
+	return &Bee{
+		ctxCancel:          func() {},
+		apiServer:          server,
+		probe:              probe,
+		shutdownDrainDelay: drainDelay,
+		shutdownTimeout:    timeout,
+		logger:             log.Noop,
+	}
+}
+
+var (
+	ActivatedListener = activatedListener
+	Listen            = listen
+	LoadDevFixtures   = loadDevFixtures
+)
+
+func SetListenFDsStart(t interface{ Cleanup(func()) }, fd int) {
+	start := listenFDsStart
+	listenFDsStart = fd
+	t.Cleanup(func() { listenFDsStart = start })
+}
+
+// ResetActivatedListeners forgets the sockets taken over from the
+// environment, so that the next listener takes them over again.
+func ResetActivatedListeners() {
+	activated.mu.Lock()
+	defer activated.mu.Unlock()
+	activated.loaded = false
+	activated.files = nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The names of the socket activated api listeners, set with the
+// FileDescriptorName option of the systemd socket unit. The only unnamed
+// socket is passed as the api listener.
+const (
+	apiListenerName      = "api"
+	apiAdminListenerName = "api-admin"
+	apiDebugListenerName = "api-debug"
+)
+
+// listenFDsStart is the first file descriptor passed by systemd.
+var listenFDsStart = 3
+
+// errReusePortUnsupported is returned when the SO_REUSEPORT socket
+// option is not supported on the platform.
+var errReusePortUnsupported = errors.New("reuse port not supported on this platform")
+
+// activated holds the sockets passed by the systemd socket activation by
+// their names. The sockets are taken over from the environment on the first
+// use and are kept open for the lifetime of the process, so that the node
+// rebuilt in the same process, as after the switch of the node mode, gets
+// the listeners on the same sockets again.
+var activated struct {
+	mu     sync.Mutex
+	loaded bool
+	files  map[string]*os.File
+}
+
+// loadActivated takes over the sockets passed by the systemd socket
+// activation to the process. The environment variables of the activation
+// are unset, so that they are not applied to the descriptors again.
+// It must be called with the activated mutex held.
+func loadActivated() {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n <= 0 {
+		return
+	}
+	var names []string
+	if v := os.Getenv("LISTEN_FDNAMES"); v != "" {
+		names = strings.Split(v, ":")
+	}
+	_ = os.Unsetenv("LISTEN_PID")
+	_ = os.Unsetenv("LISTEN_FDS")
+	_ = os.Unsetenv("LISTEN_FDNAMES")
+
+	activated.loaded = true
+	activated.files = make(map[string]*os.File)
+	switch {
+	case len(names) == n:
+		for i, name := range names {
+			activated.files[name] = os.NewFile(uintptr(listenFDsStart+i), name)
+		}
+	case n == 1:
+		activated.files[apiListenerName] = os.NewFile(uintptr(listenFDsStart), apiListenerName)
+	}
+}
+
+// activatedListener returns the listener with the name passed by the
+// systemd socket activation. The only passed listener is returned as the
+// api listener if the listeners are not named. It returns nil if the process was not
+// socket activated.
+func activatedListener(name string) (net.Listener, error) {
+	activated.mu.Lock()
+	defer activated.mu.Unlock()
+
+	if !activated.loaded {
+		loadActivated()
+	}
+	if !activated.loaded {
+		return nil, nil
+	}
+	f, ok := activated.files[name]
+	if !ok {
+		return nil, fmt.Errorf("no socket activated listener named %q", name)
+	}
+
+	// the listener is created on the duplicate of the descriptor,
+	// the inherited one is kept for the listeners of the rebuilt node
+	l, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("socket activated listener %q: %w", name, err)
+	}
+	return l, nil
+}
+
+// listen returns the socket activated listener with the name, or the tcp
+// listener on the address. The address may be bound by other processes at
+// the same time when reusePort is set, the kernel then distributes the
+// connections between them so that the replacing process can take over
+// while this one is drained.
+func listen(name, addr string, reusePort bool) (net.Listener, error) {
+	l, err := activatedListener(name)
+	if err != nil || l != nil {
+		return l, err
+	}
+
+	var lc net.ListenConfig
+	if reusePort {
+		lc.Control = reusePortControl
+	}
+	return lc.Listen(context.Background(), "tcp", addr)
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+
+package node_test
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/node"
+	"golang.org/x/sys/unix"
+)
+
+func TestActivatedListener(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+
+	// activate passes the duplicate of the listener descriptor
+	// as the descriptor passed by systemd
+	activate := func(t *testing.T, pid, names string) int {
+		t.Helper()
+
+		rc, err := l.(*net.TCPListener).SyscallConn()
+		if err != nil {
+			t.Fatal(err)
+		}
+		fd := -1
+		if err := rc.Control(func(s uintptr) {
+			fd, err = unix.Dup(int(s))
+		}); err != nil || fd < 0 {
+			t.Fatal(err)
+		}
+		node.SetListenFDsStart(t, fd)
+		node.ResetActivatedListeners()
+		t.Cleanup(node.ResetActivatedListeners)
+
+		t.Setenv("LISTEN_PID", pid)
+		t.Setenv("LISTEN_FDS", "1")
+		t.Setenv("LISTEN_FDNAMES", names)
+		return fd
+	}
+	pid := strconv.Itoa(os.Getpid())
+
+	t.Run("not activated", func(t *testing.T) {
+		activate(t, "1", "")
+
+		al, err := node.ActivatedListener("api")
+		if err != nil || al != nil {
+			t.Fatalf("got listener %v, error %v, want none", al, err)
+		}
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		activate(t, pid, "debug")
+
+		if _, err := node.ActivatedListener("api"); err == nil {
+			t.Fatal("want error for the missing listener name")
+		}
+	})
+
+	t.Run("unnamed", func(t *testing.T) {
+		activate(t, pid, "")
+
+		// the only unnamed socket is not passed as the admin listener
+		if _, err := node.ActivatedListener("api-admin"); err == nil {
+			t.Fatal("want error for the unnamed admin listener")
+		}
+	})
+
+	t.Run("activated", func(t *testing.T) {
+		fd := activate(t, pid, "api")
+
+		// the node rebuilt in the same process listens again on the same socket
+		for i := 0; i < 2; i++ {
+			al, err := node.Listen("api", "127.0.0.1:0", false)
+			if err != nil {
+				t.Fatalf("listen %d: %v", i, err)
+			}
+			if al.Addr().String() != l.Addr().String() {
+				t.Fatalf("listen %d: got address %s, want %s", i, al.Addr(), l.Addr())
+			}
+			if err := al.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := unix.FcntlInt(uintptr(fd), unix.F_GETFD, 0); err != nil {
+				t.Fatalf("listen %d: want the passed descriptor kept open: %v", i, err)
+			}
+		}
+
+		for _, v := range []string{"LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES"} {
+			if _, ok := os.LookupEnv(v); ok {
+				t.Fatalf("want %s unset", v)
+			}
+		}
+	})
+}
+
+func TestListenReusePort(t *testing.T) {
+	t.Parallel()
+
+	l1, err := node.Listen("api", "127.0.0.1:0", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l1.Close()
+
+	if _, err := node.Listen("api", l1.Addr().String(), false); err == nil {
+		t.Fatal("want error binding the address without reuse port")
+	}
+
+	l2, err := node.Listen("api", l1.Addr().String(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l2.Close()
+}
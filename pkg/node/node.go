@@ -29,15 +29,33 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/accounting"
 	"github.com/ethersphere/bee/v2/pkg/addressbook"
 	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/availability"
+	"github.com/ethersphere/bee/v2/pkg/backup"
+	"github.com/ethersphere/bee/v2/pkg/bzz"
+	"github.com/ethersphere/bee/v2/pkg/chunktrace"
 	"github.com/ethersphere/bee/v2/pkg/config"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/discovery/dnsseed"
+	"github.com/ethersphere/bee/v2/pkg/discovery/mdns"
+	"github.com/ethersphere/bee/v2/pkg/events"
+	"github.com/ethersphere/bee/v2/pkg/existence"
 	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/fusefs"
+	"github.com/ethersphere/bee/v2/pkg/grpcapi"
 	"github.com/ethersphere/bee/v2/pkg/gsoc"
 	"github.com/ethersphere/bee/v2/pkg/hive"
+	"github.com/ethersphere/bee/v2/pkg/keyrotation"
 	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/maintenance"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/ethersphere/bee/v2/pkg/media"
 	"github.com/ethersphere/bee/v2/pkg/metrics"
+	"github.com/ethersphere/bee/v2/pkg/modeswitch"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/peering"
+	"github.com/ethersphere/bee/v2/pkg/p2p/traffic"
 	"github.com/ethersphere/bee/v2/pkg/pingpong"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/postage/batchservice"
@@ -51,16 +69,26 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/pullsync"
 	"github.com/ethersphere/bee/v2/pkg/pusher"
 	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	"github.com/ethersphere/bee/v2/pkg/readonly"
+	"github.com/ethersphere/bee/v2/pkg/recovery"
+	"github.com/ethersphere/bee/v2/pkg/repair"
 	"github.com/ethersphere/bee/v2/pkg/resolver/multiresolver"
 	"github.com/ethersphere/bee/v2/pkg/retrieval"
+	"github.com/ethersphere/bee/v2/pkg/retrieval/upstream"
+	"github.com/ethersphere/bee/v2/pkg/s3gateway"
 	"github.com/ethersphere/bee/v2/pkg/salud"
+	"github.com/ethersphere/bee/v2/pkg/scoreboard"
+	"github.com/ethersphere/bee/v2/pkg/selftest"
 	"github.com/ethersphere/bee/v2/pkg/settlement/pseudosettle"
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap"
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap/chequebook"
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap/erc20"
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap/priceoracle"
+	"github.com/ethersphere/bee/v2/pkg/stampproxy"
 	"github.com/ethersphere/bee/v2/pkg/status"
 	"github.com/ethersphere/bee/v2/pkg/steward"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
 	"github.com/ethersphere/bee/v2/pkg/storageincentives"
 	"github.com/ethersphere/bee/v2/pkg/storageincentives/redistribution"
 	"github.com/ethersphere/bee/v2/pkg/storageincentives/staking"
@@ -71,16 +99,20 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/topology/lightnode"
 	"github.com/ethersphere/bee/v2/pkg/tracing"
 	"github.com/ethersphere/bee/v2/pkg/transaction"
+	"github.com/ethersphere/bee/v2/pkg/transaction/cached"
+	"github.com/ethersphere/bee/v2/pkg/traversal"
 	"github.com/ethersphere/bee/v2/pkg/util/abiutil"
 	"github.com/ethersphere/bee/v2/pkg/util/ioutil"
 	"github.com/ethersphere/bee/v2/pkg/util/nbhdutil"
 	"github.com/ethersphere/bee/v2/pkg/util/syncutil"
+	"github.com/ethersphere/bee/v2/pkg/webdav"
 	"github.com/hashicorp/go-multierror"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prometheus/client_golang/prometheus"
 	promc "github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/crypto/sha3"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 )
 
 // LoggerName is the tree path name of the logger for this package.
@@ -92,6 +124,16 @@ type Bee struct {
 	ctxCancel                context.CancelFunc
 	apiCloser                io.Closer
 	apiServer                *http.Server
+	apiGroupServers          []*http.Server
+	probe                    *api.Probe
+	shutdownDrainDelay       time.Duration
+	shutdownTimeout          time.Duration
+	logger                   log.Logger
+	grpcServer               *grpc.Server
+	s3Server                 *http.Server
+	webdavServer             *http.Server
+	stampProxyServer         *http.Server
+	fuseCloser               io.Closer
 	resolverCloser           io.Closer
 	errorLogWriter           io.Writer
 	tracerCloser             io.Closer
@@ -114,6 +156,7 @@ type Bee struct {
 	priceOracleCloser        io.Closer
 	hiveCloser               io.Closer
 	saludCloser              io.Closer
+	repairCloser             io.Closer
 	storageIncetivesCloser   io.Closer
 	pushSyncCloser           io.Closer
 	retrievalCloser          io.Closer
@@ -121,22 +164,67 @@ type Bee struct {
 	shutdownMutex            sync.Mutex
 	syncingStopped           *syncutil.Signaler
 	accesscontrolCloser      io.Closer
+	trafficCloser            io.Closer
+	mdnsCloser               io.Closer
+	expiryNotifierCloser     io.Closer
+	maintenanceCloser        io.Closer
+	webhookCloser            io.Closer
+	modeSwitcher             *modeswitch.Switcher
+	embedded                 *embedded
+	reloader                 *reloader
 }
 
 type Options struct {
 	DataDir                       string
 	CacheCapacity                 uint64
+	SOCCacheTTL                   time.Duration
+	FeedCacheTTL                  time.Duration
 	DBOpenFilesLimit              uint64
 	DBWriteBufferSize             uint64
 	DBBlockCacheCapacity          uint64
 	DBDisableSeeksCompaction      bool
+	DBIndexStoreBackend           string
 	APIAddr                       string
+	APIReusePort                  bool
+	APIAdminAddr                  string
+	APIDebugAddr                  string
+	GRPCAddr                      string
+	S3Addr                        string
+	S3BatchID                     string
+	S3RootTopic                   string
+	WebDAVAddr                    string
+	WebDAVBatchID                 string
+	StampProxyAddr                string
+	FUSEEnable                    bool
+	ImageTransformEnable          bool
 	Addr                          string
 	NATAddr                       string
 	EnableWS                      bool
+	EnableQUIC                    bool
+	EnableWebTransport            bool
+	P2PDialPreference             string
+	EnableRelayService            bool
+	EnableRelayClient             bool
+	StaticRelays                  []string
+	EnableP2PCompression          bool
+	TrafficMonthlyCap             uint64
+	TrafficThrottleRate           int
 	WelcomeMessage                string
 	Bootnodes                     []string
+	NetworkKey                    string
+	NetworkAllowlist              []string
+	DNSSeeds                      []string
+	EnableMDNS                    bool
 	CORSAllowedOrigins            []string
+	APIValidateRequests           bool
+	APIIdempotencyWindow          time.Duration
+	APIBzzAccessLog               string
+	APIBzzAccessLogMaxSize        int64
+	APIBzzAccessLogMaxBackups     int
+	APIReadOnly                   bool
+	APIRequestSigningSecret       string
+	APIRequestSigningWindow       time.Duration
+	ChunkValidationOffload        bool
 	Logger                        log.Logger
 	TracingEnabled                bool
 	TracingEndpoint               string
@@ -146,17 +234,25 @@ type Options struct {
 	PaymentEarly                  int64
 	ResolverConnectionCfgs        []multiresolver.ConnectionConfig
 	RetrievalCaching              bool
+	UpstreamGateway               string
+	UpstreamGatewayTimeout        time.Duration
 	BootnodeMode                  bool
 	BlockchainRpcEndpoint         string
 	SwapFactoryAddress            string
 	SwapInitialDeposit            string
+	SwapChequebookFunderKey       string
 	SwapEnable                    bool
 	ChequebookEnable              bool
 	FullNodeMode                  bool
+	DisableReserve                bool
+	Ephemeral                     bool
 	PostageContractAddress        string
 	PostageContractStartBlock     uint64
 	StakingContractAddress        string
 	PriceOracleAddress            string
+	SwapExchangeRate              string
+	SwapDeduction                 string
+	PostagePrice                  string
 	RedistributionContractAddress string
 	BlockTime                     time.Duration
 	WarmupTime                    time.Duration
@@ -175,6 +271,41 @@ type Options struct {
 	TrxDebugMode                  bool
 	MinimumStorageRadius          uint
 	ReserveCapacityDoubling       int
+	StateStoreAPIEnable           bool
+	PostageSnapshotTrustedNodes   []string
+	BlockchainRpcCacheEnable      bool
+	BlockchainRpcHourlyBudget     uint64
+	PushSyncReceiptsEnable        bool
+	PushSyncReplicationFactor     uint8
+	PullSyncBandwidthLimit        float64
+	PullSyncHistoricalHours       string
+	PullSyncHistoryDays           uint
+	PullSyncHistoryBins           string
+	MaintenanceWindows            string
+	KeyRotation                   *keyrotation.Service
+	PushSyncBandwidthLimit        float64
+	ReplicationRepairEnable       bool
+	ReplicationRepairInterval     time.Duration
+	RecoveryBatchID               string
+	KademliaPrunePolicy           string
+	PeeringPinned                 []string
+	PeeringDenied                 []string
+	PeeringGroups                 []string
+	WebhookURL                    string
+	WebhookTopics                 []string
+	WebhookSecret                 string
+	ShutdownDrainDelay            time.Duration
+	ShutdownTimeout               time.Duration
+	ReadinessMinPeers             int
+	ReadinessMinDepth             uint8
+	ReadinessBatchStoreSynced     bool
+	ReadinessChainSynced          bool
+	RecentLogs                    io.WriterTo
+	Config                        map[string]any
+	Verbosity                     log.Level
+	// ConfigLoader loads the reload options from the configuration,
+	// the config reload is not supported if it is not set.
+	ConfigLoader func() (ReloadOptions, error)
 }
 
 const (
@@ -191,6 +322,9 @@ const (
 	reserveMinEvictCount          = 1_000
 	cacheMinEvictCount            = 10_000
 	maxAllowedDoubling            = 1
+	dnsSeedsTimeout               = 10 * time.Second // time to wait for the dns seed lists to resolve
+	batchExpiryCheckInterval      = 5 * time.Minute  // interval of the checks of the batches about to expire
+	defaultShutdownTimeout        = 15 * time.Second // time to wait for the in-flight api requests on shutdown
 )
 
 func NewBee(
@@ -205,6 +339,11 @@ func NewBee(
 	session accesscontrol.Session,
 	o *Options,
 ) (b *Bee, err error) {
+	o, err = resolveSecrets(ctx, o)
+	if err != nil {
+		return nil, fmt.Errorf("resolve secrets: %w", err)
+	}
+
 	tracer, tracerCloser, err := tracing.NewTracer(&tracing.Options{
 		Enabled:     o.TracingEnabled,
 		Endpoint:    o.TracingEndpoint,
@@ -236,10 +375,13 @@ func NewBee(
 	})
 
 	b = &Bee{
-		ctxCancel:      ctxCancel,
-		errorLogWriter: sink,
-		tracerCloser:   tracerCloser,
-		syncingStopped: syncutil.NewSignaler(),
+		ctxCancel:          ctxCancel,
+		errorLogWriter:     sink,
+		tracerCloser:       tracerCloser,
+		syncingStopped:     syncutil.NewSignaler(),
+		shutdownDrainDelay: o.ShutdownDrainDelay,
+		shutdownTimeout:    o.ShutdownTimeout,
+		logger:             logger,
 	}
 
 	defer func(b *Bee) {
@@ -262,7 +404,7 @@ func NewBee(
 
 	reserveCapacity := (1 << o.ReserveCapacityDoubling) * storer.DefaultReserveCapacity
 
-	stateStore, stateStoreMetrics, err := InitStateStore(logger, o.DataDir, o.StatestoreCacheCapacity)
+	stateStore, stateStoreMetrics, stateStoreLDB, err := initStateStore(logger, o.DataDir, o.StatestoreCacheCapacity)
 	if err != nil {
 		return nil, fmt.Errorf("init state store: %w", err)
 	}
@@ -363,6 +505,9 @@ func NewBee(
 	)
 
 	chainEnabled := isChainEnabled(o, o.BlockchainRpcEndpoint, logger)
+	// the reserve, pullsync and the storage incentives run only for full nodes with the reserve enabled
+	reserveEnabled := o.FullNodeMode && !o.BootnodeMode && !o.DisableReserve
+	b.modeSwitcher = modeswitch.New(modeswitch.Mode{FullNode: o.FullNodeMode, Reserve: reserveEnabled}, chainEnabled && !o.Ephemeral)
 
 	var batchStore postage.Storer = new(postage.NoOpBatchStore)
 	var evictFn func([]byte) error
@@ -389,7 +534,11 @@ func NewBee(
 		o.ChainID,
 		signer,
 		o.BlockTime,
-		chainEnabled)
+		chainEnabled,
+		cached.Options{
+			Cache:        o.BlockchainRpcCacheEnable,
+			HourlyBudget: o.BlockchainRpcHourlyBudget,
+		})
 	if err != nil {
 		return nil, fmt.Errorf("init chain: %w", err)
 	}
@@ -414,6 +563,7 @@ func NewBee(
 	// Create api.Probe in healthy state and switch to ready state after all components have been constructed
 	probe := api.NewProbe()
 	probe.SetHealthy(api.ProbeStatusOK)
+	b.probe = probe
 	defer func(probe *api.Probe) {
 		if err != nil {
 			probe.SetHealthy(api.ProbeStatusNOK)
@@ -430,6 +580,9 @@ func NewBee(
 
 	var apiService *api.Service
 
+	// the read-only mode is shared by all the frontends of the node
+	readOnlyMode := readonly.New(o.APIReadOnly)
+
 	if o.APIAddr != "" {
 		if o.MutexProfile {
 			_ = runtime.SetMutexProfileFraction(1)
@@ -438,7 +591,7 @@ func NewBee(
 			runtime.SetBlockProfileRate(1)
 		}
 
-		apiListener, err := net.Listen("tcp", o.APIAddr)
+		apiListener, err := listen(apiListenerName, o.APIAddr, o.APIReusePort)
 		if err != nil {
 			return nil, fmt.Errorf("api listener: %w", err)
 		}
@@ -459,6 +612,33 @@ func NewBee(
 			stamperStore,
 		)
 
+		// the route groups with their own address are served only on
+		// their own listeners, so that every class of the routes may be
+		// firewalled separately
+		type groupListener struct {
+			group    api.RouteGroup
+			listener net.Listener
+		}
+		var groupListeners []groupListener
+		for _, g := range []struct {
+			group api.RouteGroup
+			name  string
+			addr  string
+		}{
+			{api.RouteGroupAdmin, apiAdminListenerName, o.APIAdminAddr},
+			{api.RouteGroupDebug, apiDebugListenerName, o.APIDebugAddr},
+		} {
+			if g.addr == "" {
+				continue
+			}
+			l, err := listen(g.name, g.addr, o.APIReusePort)
+			if err != nil {
+				return nil, fmt.Errorf("api %s listener: %w", g.group, err)
+			}
+			apiService.SeparateRouteGroups(g.group)
+			groupListeners = append(groupListeners, groupListener{group: g.group, listener: l})
+		}
+
 		apiService.Mount()
 		apiService.SetProbe(probe)
 		apiService.SetIsWarmingUp(true)
@@ -480,8 +660,28 @@ func NewBee(
 			}
 		}()
 
+		for _, gl := range groupListeners {
+			server := &http.Server{
+				IdleTimeout:       30 * time.Second,
+				ReadHeaderTimeout: 3 * time.Second,
+				Handler:           apiService.RouteGroupHandler(gl.group),
+				ErrorLog:          stdlog.New(b.errorLogWriter, "", 0),
+			}
+
+			go func() {
+				logger.Info("starting api server", "group", gl.group, "address", gl.listener.Addr())
+
+				if err := server.Serve(gl.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Debug("api server failed to start", "group", gl.group, "error", err)
+					logger.Error(nil, "api server failed to start", "group", gl.group)
+				}
+			}()
+
+			b.apiGroupServers = append(b.apiGroupServers, server)
+		}
+
 		b.apiServer = apiServer
-		b.apiCloser = apiServer
+		b.apiCloser = apiService
 	}
 
 	// Sync the with the given Ethereum backend:
@@ -512,6 +712,17 @@ func NewBee(
 		erc20Service = erc20.New(transactionService, erc20Address)
 
 		if o.ChequebookEnable && chainEnabled {
+			var (
+				sponsor      *chequebook.Sponsor
+				closeSponsor = func() {}
+			)
+			if o.SwapChequebookFunderKey != "" {
+				sponsor, closeSponsor, err = InitChequebookSponsor(logger, chainBackend, chainID, o.BlockTime, o.SwapFactoryAddress, o.SwapChequebookFunderKey)
+				if err != nil {
+					return nil, fmt.Errorf("init chequebook sponsor: %w", err)
+				}
+			}
+
 			chequebookService, err = InitChequebookService(
 				ctx,
 				logger,
@@ -524,7 +735,9 @@ func NewBee(
 				chequebookFactory,
 				o.SwapInitialDeposit,
 				erc20Service,
+				sponsor,
 			)
+			closeSponsor()
 			if err != nil {
 				return nil, fmt.Errorf("init chequebook service: %w", err)
 			}
@@ -555,6 +768,17 @@ func NewBee(
 		bootnodes = append(bootnodes, addr)
 	}
 
+	if len(o.DNSSeeds) > 0 {
+		seedsCtx, cancel := context.WithTimeout(ctx, dnsSeedsTimeout)
+		seeds, err := dnsseed.Resolve(seedsCtx, net.DefaultResolver, o.DNSSeeds)
+		cancel()
+		if err != nil {
+			logger.Warning("resolve dns seeds failed", "error", err)
+		}
+		logger.Info("dns seeds resolved", "count", len(seeds))
+		bootnodes = append(bootnodes, seeds...)
+	}
+
 	// Perform checks related to payment threshold calculations here to not duplicate
 	// the checks in bootstrap process
 	paymentThreshold, ok := new(big.Int).SetString(o.PaymentThreshold, 10)
@@ -611,15 +835,53 @@ func NewBee(
 		registry = apiService.MetricsRegistry()
 	}
 
+	peeringConfig, err := peering.ParseConfig(o.PeeringPinned, o.PeeringDenied, o.PeeringGroups)
+	if err != nil {
+		return nil, fmt.Errorf("peering: %w", err)
+	}
+	peeringPolicy := peering.NewPolicy()
+	if err := peeringPolicy.Set(peeringConfig); err != nil {
+		return nil, fmt.Errorf("peering: %w", err)
+	}
+
+	trafficMeter := traffic.NewMeter(traffic.Options{
+		MonthlyCap:   o.TrafficMonthlyCap,
+		ThrottleRate: o.TrafficThrottleRate,
+		StateStore:   stateStore,
+	}, logger)
+	b.trafficCloser = trafficMeter
+
+	dialPreference, err := libp2p.ParseDialPreference(o.P2PDialPreference)
+	if err != nil {
+		return nil, fmt.Errorf("p2p dial preference: %w", err)
+	}
+
+	networkAllowlist, err := parseNetworkAllowlist(o.NetworkAllowlist)
+	if err != nil {
+		return nil, err
+	}
+
 	p2ps, err := libp2p.New(ctx, signer, networkID, swarmAddress, addr, addressbook, stateStore, lightNodes, logger, tracer, libp2p.Options{
-		PrivateKey:      libp2pPrivateKey,
-		NATAddr:         o.NATAddr,
-		EnableWS:        o.EnableWS,
-		WelcomeMessage:  o.WelcomeMessage,
-		FullNode:        o.FullNodeMode,
-		Nonce:           nonce,
-		ValidateOverlay: chainEnabled,
-		Registry:        registry,
+		PrivateKey:         libp2pPrivateKey,
+		NATAddr:            o.NATAddr,
+		EnableWS:           o.EnableWS,
+		EnableQUIC:         o.EnableQUIC,
+		EnableWebTransport: o.EnableWebTransport,
+		DialPreference:     dialPreference,
+		EnableRelayService: o.EnableRelayService,
+		EnableRelayClient:  o.EnableRelayClient,
+		StaticRelays:       o.StaticRelays,
+		EnableCompression:  o.EnableP2PCompression,
+		NetworkKey:         networkKey(o.NetworkKey),
+		NetworkAllowlist:   networkAllowlist,
+		Capabilities:       p2p.CapabilityPeerRecords,
+		Traffic:            trafficMeter,
+		WelcomeMessage:     o.WelcomeMessage,
+		FullNode:           o.FullNodeMode,
+		Nonce:              nonce,
+		ValidateOverlay:    chainEnabled,
+		Registry:           registry,
+		Peering:            peeringPolicy,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("p2p service: %w", err)
@@ -637,9 +899,35 @@ func NewBee(
 	b.postageServiceCloser = post
 	batchStore.SetBatchExpiryHandler(post)
 
+	eventBus := events.New()
+	batchStore.SetEventPublisher(eventBus)
+
+	if chainEnabled {
+		expiryNotifier := postage.NewExpiryNotifier(post, batchStore, eventBus, o.BlockTime, postage.DefaultExpiryThreshold, logger)
+		expiryNotifier.Start(batchExpiryCheckInterval)
+		b.expiryNotifierCloser = expiryNotifier
+	}
+
+	var webhook *events.Webhook
+	if o.WebhookURL != "" {
+		topics := make([]events.Topic, 0, len(o.WebhookTopics))
+		for _, t := range o.WebhookTopics {
+			topics = append(topics, events.Topic(t))
+		}
+		webhook, err = events.NewWebhook(eventBus, events.WebhookOptions{
+			URL:    o.WebhookURL,
+			Topics: topics,
+			Secret: o.WebhookSecret,
+		}, logger)
+		if err != nil {
+			return nil, fmt.Errorf("webhook: %w", err)
+		}
+		b.webhookCloser = webhook
+	}
+
 	var (
 		postageStampContractService postagecontract.Interface
-		batchSvc                    postage.EventUpdater
+		batchSvc                    batchservice.Interface
 		eventListener               postage.Listener
 	)
 
@@ -685,6 +973,34 @@ func NewBee(
 		return nil, fmt.Errorf("init batch service: %w", err)
 	}
 
+	if o.PostagePrice != "" {
+		price, ok := new(big.Int).SetString(o.PostagePrice, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid postage price: %s", o.PostagePrice)
+		}
+		priceOracle, err := postage.NewStaticPriceOracle(price)
+		if err != nil {
+			return nil, fmt.Errorf("postage price oracle: %w", err)
+		}
+		batchSvc.SetPriceOracle(priceOracle)
+		logger.Info("using static postage price", "price", price)
+	}
+
+	// Bootstrap the batch store from a snapshot of a trusted node only
+	// if it is a fresh install or explicitly asked by user to resync.
+	if chainEnabled && len(o.PostageSnapshotTrustedNodes) > 0 && (!batchStoreExists || o.Resync) {
+		snapshot, err := fetchBatchSnapshot(ctx, logger, o.PostageSnapshotTrustedNodes, chainBackend)
+		if err == nil {
+			err = batchSvc.ApplySnapshot(snapshot)
+		}
+		if err != nil {
+			logger.Error(err, "batch snapshot bootstrap failed, syncing postage data from the chain")
+		} else {
+			// The postage events snapshot precedes the applied state.
+			initBatchState = nil
+		}
+	}
+
 	// Construct protocols.
 	pingPong := pingpong.New(p2ps, logger, tracer)
 
@@ -701,14 +1017,40 @@ func NewBee(
 
 	var swapService *swap.Service
 
+	prunePolicy, err := kademlia.ParsePrunePolicy(o.KademliaPrunePolicy)
+	if err != nil {
+		return nil, fmt.Errorf("kademlia prune policy: %w", err)
+	}
+
 	kad, err := kademlia.New(swarmAddress, addressbook, hive, p2ps, logger,
-		kademlia.Options{Bootnodes: bootnodes, BootnodeMode: o.BootnodeMode, StaticNodes: o.StaticNodes, DataDir: o.DataDir})
+		kademlia.Options{Bootnodes: bootnodes, BootnodeMode: o.BootnodeMode, StaticNodes: o.StaticNodes, DataDir: o.DataDir, PrunePolicy: prunePolicy, Peering: peeringPolicy, Events: eventBus})
 	if err != nil {
 		return nil, fmt.Errorf("unable to create kademlia: %w", err)
 	}
 	b.topologyCloser = kad
 	b.topologyHalter = kad
 	hive.SetAddPeersHandler(kad.AddPeers)
+	hive.EnableRecords(signer, nonce, peerHints(kad))
+
+	if o.EnableMDNS {
+		mdnsService := mdns.New(mdns.Options{
+			Overlay:   swarmAddress,
+			NetworkID: networkID,
+			Nonce:     nonce,
+			Signer:    signer,
+			Addresses: p2ps.Addresses,
+		}, func(addr bzz.Address) {
+			if err := addressbook.Put(addr.Overlay, addr); err != nil {
+				logger.Debug("mdns: store discovered peer failed", "peer_address", addr.Overlay, "error", err)
+				return
+			}
+			kad.AddPeers(addr.Overlay)
+		}, logger)
+		if err := mdnsService.Start(); err != nil {
+			return nil, fmt.Errorf("mdns discovery: %w", err)
+		}
+		b.mdnsCloser = mdnsService
+	}
 	p2ps.SetPickyNotifier(kad)
 
 	var path string
@@ -718,6 +1060,8 @@ func NewBee(
 		path = filepath.Join(o.DataDir, ioutil.DataPathLocalstore)
 	}
 
+	chunkTracer := chunktrace.New(logger)
+
 	lo := &storer.Options{
 		Address:                   swarmAddress,
 		CacheCapacity:             o.CacheCapacity,
@@ -725,17 +1069,19 @@ func NewBee(
 		LdbBlockCacheCapacity:     o.DBBlockCacheCapacity,
 		LdbWriteBufferSize:        o.DBWriteBufferSize,
 		LdbDisableSeeksCompaction: o.DBDisableSeeksCompaction,
+		IndexStoreBackend:         o.DBIndexStoreBackend,
 		Batchstore:                batchStore,
 		StateStore:                stateStore,
 		RadiusSetter:              kad,
 		WarmupDuration:            warmupTime,
 		Logger:                    logger,
 		Tracer:                    tracer,
+		ChunkTracer:               chunkTracer,
 		CacheMinEvictCount:        cacheMinEvictCount,
 		MinimumStorageRadius:      o.MinimumStorageRadius,
 	}
 
-	if o.FullNodeMode && !o.BootnodeMode {
+	if reserveEnabled {
 		// configure reserve only for full node
 		lo.ReserveCapacity = reserveCapacity
 		lo.ReserveWakeUpDuration = reserveWakeUpDuration
@@ -875,10 +1221,24 @@ func NewBee(
 	}
 
 	acc.SetRefreshFunc(pseudosettleService.Pay)
+	acc.SetDisputeFunc(kad.RecordDispute)
 
+	var priceOracle priceoracle.Service
 	if o.SwapEnable && chainEnabled {
-		var priceOracle priceoracle.Service
-		swapService, priceOracle, err = InitSwap(
+		priceOracle, err = InitPriceOracle(
+			logger,
+			o.PriceOracleAddress,
+			o.SwapExchangeRate,
+			o.SwapDeduction,
+			chainID,
+			transactionService,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("init price oracle: %w", err)
+		}
+		b.priceOracleCloser = priceOracle
+
+		swapService, err = InitSwap(
 			p2ps,
 			logger,
 			stateStore,
@@ -888,18 +1248,16 @@ func NewBee(
 			chequeStore,
 			cashoutService,
 			acc,
-			o.PriceOracleAddress,
-			chainID,
-			transactionService,
+			priceOracle,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("init swap service: %w", err)
 		}
-		b.priceOracleCloser = priceOracle
 
 		if o.ChequebookEnable {
 			acc.SetPayFunc(swapService.Pay)
 		}
+		swapService.SetEventPublisher(eventBus)
 	}
 
 	pricing.SetPaymentThresholdObserver(acc)
@@ -921,10 +1279,16 @@ func NewBee(
 	}
 
 	nodeStatus := status.NewService(logger, p2ps, kad, beeNodeMode.String(), batchStore, localStore, statusMetricsRegistry)
+	nodeStatus.SetPricer(pricer)
 	if err = p2ps.AddProtocol(nodeStatus.Protocol()); err != nil {
 		return nil, fmt.Errorf("status service: %w", err)
 	}
 
+	selfTest := selftest.New(p2ps, kad, logger)
+	if err = p2ps.AddProtocol(selfTest.Protocol()); err != nil {
+		return nil, fmt.Errorf("selftest service: %w", err)
+	}
+
 	saludService := salud.New(nodeStatus, kad, localStore, logger, warmupTime, api.FullMode.String(), salud.DefaultMinPeersPerBin, salud.DefaultDurPercentile, salud.DefaultConnsPercentile)
 	b.saludCloser = saludService
 
@@ -942,7 +1306,7 @@ func NewBee(
 				if prev == uint32(swarm.MaxBins) {
 					close(initialRadiusC)
 				}
-				if !o.FullNodeMode { // light and ultra-light nodes do not have a reserve worker to set the radius.
+				if !o.FullNodeMode || o.DisableReserve { // light, ultra-light and reserveless nodes do not have a reserve worker to set the radius.
 					kad.SetStorageRadius(r)
 				}
 			case <-ctx.Done():
@@ -969,19 +1333,41 @@ func NewBee(
 		}
 	}
 
-	pushSyncProtocol := pushsync.New(swarmAddress, networkID, nonce, p2ps, localStore, waitNetworkRFunc, kad, o.FullNodeMode && !o.BootnodeMode, pssService.TryUnwrap, gsocService.Handle, validStamp, logger, acc, pricer, signer, tracer, warmupTime, uint8(shallowReceiptTolerance))
+	// the scoreboard is shared by the retrieval and pushsync protocols so that
+	// the peer performance observed in either of them biases the selection in both
+	scores := scoreboard.New(scoreboard.DefaultHalfLife)
+
+	pushSyncProtocol := pushsync.New(swarmAddress, networkID, nonce, p2ps, localStore, waitNetworkRFunc, kad, reserveEnabled, pssService.TryUnwrap, gsocService.Handle, validStamp, logger, acc, pricer, signer, tracer, warmupTime, uint8(shallowReceiptTolerance), o.PushSyncReplicationFactor, scores)
 	b.pushSyncCloser = pushSyncProtocol
+	pushSyncProtocol.SetChunkTracer(chunkTracer)
+
+	if o.PushSyncReceiptsEnable {
+		pushSyncProtocol.SetReceiptStore(stateStore)
+	}
 
 	// set the pushSyncer in the PSS
 	pssService.SetPushSyncer(pushSyncProtocol)
 
-	retrieval := retrieval.New(swarmAddress, waitNetworkRFunc, localStore, p2ps, kad, logger, acc, pricer, tracer, o.RetrievalCaching)
-	localStore.SetRetrievalService(retrieval)
+	retrieval := retrieval.New(swarmAddress, waitNetworkRFunc, localStore, p2ps, kad, logger, acc, pricer, tracer, o.RetrievalCaching, scores)
+	retrieval.SetChunkTracer(chunkTracer)
+	var upstreamRetriever *upstream.Retriever
+	if o.UpstreamGateway != "" {
+		upstreamRetriever, err = upstream.New(retrieval, o.UpstreamGateway, o.UpstreamGatewayTimeout, logger)
+		if err != nil {
+			return nil, fmt.Errorf("upstream gateway: %w", err)
+		}
+		localStore.SetRetrievalService(upstreamRetriever)
+	} else {
+		localStore.SetRetrievalService(retrieval)
+	}
 
 	statusMetricsRegistry.MustRegister(retrieval.StatusMetrics()...)
 
 	pusherService := pusher.New(networkID, localStore, pushSyncProtocol, batchStore, logger, warmupTime, pusher.DefaultRetryCount)
 	b.pusherCloser = pusherService
+	if err := pusherService.SetLimits(pusher.Limits{Bandwidth: o.PushSyncBandwidthLimit}); err != nil {
+		return nil, fmt.Errorf("pushsync limits: %w", err)
+	}
 
 	pusherService.AddFeed(localStore.PusherFeed())
 
@@ -992,7 +1378,7 @@ func NewBee(
 	pushSyncProtocolSpec := pushSyncProtocol.Protocol()
 	pullSyncProtocolSpec := pullSyncProtocol.Protocol()
 
-	if o.FullNodeMode && !o.BootnodeMode {
+	if reserveEnabled {
 		logger.Info("starting in full mode")
 	} else {
 		if chainEnabled {
@@ -1015,6 +1401,11 @@ func NewBee(
 		return nil, fmt.Errorf("pullsync protocol: %w", err)
 	}
 
+	existenceService := existence.New(p2ps, localStore.ChunkStore(), logger)
+	if err = p2ps.AddProtocol(existenceService.Protocol()); err != nil {
+		return nil, fmt.Errorf("existence protocol: %w", err)
+	}
+
 	time.AfterFunc(warmupTime, func() {
 		apiService.SetIsWarmingUp(false)
 	})
@@ -1066,16 +1457,40 @@ func NewBee(
 
 	var (
 		pullerService *puller.Puller
+		repairService *repair.Service
 		agent         *storageincentives.Agent
 	)
 
-	if o.FullNodeMode && !o.BootnodeMode {
-		pullerService = puller.New(swarmAddress, stateStore, kad, localStore, pullSyncProtocol, p2ps, logger, puller.Options{})
+	if reserveEnabled {
+		historicalHours, err := puller.ParseHourWindow(o.PullSyncHistoricalHours)
+		if err != nil {
+			return nil, fmt.Errorf("pullsync historical hours: %w", err)
+		}
+		historyBins, err := puller.ParseBins(o.PullSyncHistoryBins)
+		if err != nil {
+			return nil, fmt.Errorf("pullsync history bins: %w", err)
+		}
+		pullerService = puller.New(swarmAddress, stateStore, kad, localStore, pullSyncProtocol, p2ps, logger, puller.Options{
+			Limits: puller.Limits{
+				Bandwidth:       o.PullSyncBandwidthLimit,
+				HistoricalHours: historicalHours,
+			},
+			HistoryPeriod: time.Duration(o.PullSyncHistoryDays) * 24 * time.Hour,
+			HistoryBins:   historyBins,
+		})
 		b.pullerCloser = pullerService
 
 		localStore.StartReserveWorker(ctx, pullerService, waitNetworkRFunc)
 		nodeStatus.SetSync(pullerService)
 
+		if o.ReplicationRepairEnable {
+			repairService = repair.New(swarmAddress, localStore, kad, existenceService, pushSyncProtocol, logger, warmupTime, repair.Options{
+				Interval:    o.ReplicationRepairInterval,
+				MinReplicas: max(int(o.PushSyncReplicationFactor)-1, 1),
+			})
+			b.repairCloser = repairService
+		}
+
 		if o.EnableStorageIncentives {
 
 			redistributionContractAddress := chainCfg.RedistributionAddress
@@ -1091,7 +1506,7 @@ func NewBee(
 			startWarmupPeriod := time.Now()
 			isFullySynced := func() bool {
 				reserveTreshold := reserveCapacity * 5 / 10
-				return localStore.ReserveSize() >= reserveTreshold && pullerService.SyncRate() == 0 && time.Now().After(startWarmupPeriod.Add(warmupTime))
+				return localStore.ReserveSize() >= reserveTreshold && pullerService.SyncRate() == 0 && !pullerService.PartialHistory() && time.Now().After(startWarmupPeriod.Add(warmupTime))
 			}
 
 			agent, err = storageincentives.New(
@@ -1111,6 +1526,7 @@ func NewBee(
 				erc20Service,
 				transactionService,
 				saludService,
+				eventBus,
 				logger,
 			)
 			if err != nil {
@@ -1128,7 +1544,77 @@ func NewBee(
 	b.resolverCloser = multiResolver
 
 	feedFactory := factory.New(localStore.Download(true))
-	steward := steward.New(localStore, retrieval, localStore.Cache())
+	availabilityService := availability.New(
+		swarmAddress,
+		traversal.New(localStore.Download(true), localStore.Cache(), redundancy.DefaultLevel),
+		kad,
+		existenceService,
+	)
+	steward := steward.New(localStore, retrieval, localStore.Cache(), steward.WithAvailability(availabilityService))
+
+	if o.RecoveryBatchID != "" {
+		recoveryBatchID, err := hex.DecodeString(o.RecoveryBatchID)
+		if err != nil || len(recoveryBatchID) != swarm.HashSize {
+			return nil, errors.New("malformed recovery batch id")
+		}
+		recoveryStamper := func() (postage.Stamper, func() error, error) {
+			issuer, save, err := post.GetStampIssuer(recoveryBatchID)
+			if err != nil {
+				return nil, nil, err
+			}
+			return postage.NewStamper(stamperStore, issuer, signer), save, nil
+		}
+		pssService.Register(recovery.Topic, recovery.NewRepairHandler(recovery.NewPinRepairer(localStore, steward, recoveryStamper), logger))
+	}
+
+	var backupService *backup.Service
+	if ldbStamperStore, ok := stamperStore.(leveldbstore.Storer); ok {
+		var keysDir string
+		if o.DataDir != "" {
+			keysDir = filepath.Join(o.DataDir, "keys")
+		}
+		backupService = backup.New(logger, stateStoreLDB, ldbStamperStore, keysDir, localStore)
+	}
+
+	var reserveStore api.ReserveStore
+	if o.FullNodeMode && !o.DisableReserve {
+		reserveStore = localStore
+	}
+
+	var stateStoreAPI storage.StateStorer
+	if o.StateStoreAPIEnable {
+		stateStoreAPI = stateStore
+	}
+
+	var mounter *fusefs.Mounter
+	if o.FUSEEnable {
+		mountStore, err := manifestfs.NewStore(manifestfs.Options{
+			Storer: localStore,
+			Feeds:  feedFactory,
+			Signer: signer,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fuse: %w", err)
+		}
+		mounter = fusefs.NewMounter(mountStore, logger)
+		b.fuseCloser = mounter
+	}
+
+	var images *media.ImageTransformer
+	if o.ImageTransformEnable {
+		images = media.NewImageTransformer(localStore, stateStore, logger)
+	}
+
+	var stampProxy *stampproxy.Service
+	if o.StampProxyAddr != "" {
+		if apiService == nil {
+			return nil, errors.New("stamp proxy requires the api")
+		}
+		stampProxy, err = stampproxy.New(stateStore, logger)
+		if err != nil {
+			return nil, fmt.Errorf("stamp proxy: %w", err)
+		}
+	}
 
 	extraOpts := api.ExtraOptions{
 		Pingpong:        pingPong,
@@ -1152,15 +1638,121 @@ func NewBee(
 		SyncStatus:      syncStatusFn,
 		NodeStatus:      nodeStatus,
 		PinIntegrity:    localStore.PinIntegrity(),
+		Backup:          backupService,
+		Mounter:         mounter,
+		StampProxy:      stampProxy,
+		Images:          images,
+		Reserve:         reserveStore,
+		StateStore:      stateStoreAPI,
+		Scoreboard:      scores,
+		ChunkTracer:     chunkTracer,
+		ChunkPricer:     pricer,
+		ExchangeRater:   priceOracle,
+		Availability:    availabilityService,
+		Readiness: api.ReadinessCriteria{
+			MinPeers:         o.ReadinessMinPeers,
+			MinDepth:         o.ReadinessMinDepth,
+			BatchStoreSynced: o.ReadinessBatchStoreSynced,
+			ChainSynced:      o.ReadinessChainSynced,
+		},
+	}
+
+	if chainEnabled {
+		extraOpts.BatchSnapshot = batchSvc
+	}
+
+	if o.PushSyncReceiptsEnable {
+		extraOpts.Receipts = pushSyncProtocol
+	}
+
+	extraOpts.SelfTest = selfTest
+	extraOpts.Peering = peeringPolicy
+	extraOpts.TopologyEvents = kad
+	extraOpts.Events = eventBus
+	extraOpts.Traffic = trafficMeter
+	extraOpts.ModeSwitcher = b.modeSwitcher
+	extraOpts.RecentLogs = o.RecentLogs
+	extraOpts.Config = o.Config
+
+	extraOpts.PushLimiter = pusherService
+	if pullerService != nil {
+		extraOpts.SyncLimiter = pullerService
+		extraOpts.SyncProgress = pullerService
+	}
+
+	maintenanceWindows, err := maintenance.ParseWindows(o.MaintenanceWindows)
+	if err != nil {
+		return nil, fmt.Errorf("maintenance windows: %w", err)
+	}
+	maintenanceScheduler := maintenance.New(logger, maintenanceWindows)
+	if pullerService != nil {
+		maintenanceScheduler.Register("pullsync", pullerService)
+	}
+	if swapService != nil {
+		maintenanceScheduler.Register("cashout", maintenance.Funcs{
+			OnPause:  swapService.PauseCashouts,
+			OnResume: swapService.ResumeCashouts,
+		})
+	}
+	maintenanceScheduler.Start()
+	b.maintenanceCloser = maintenanceScheduler
+	extraOpts.Maintenance = maintenanceScheduler
+
+	if o.KeyRotation != nil {
+		o.KeyRotation.Register("pss", keyrotation.Key{
+			Filename: "pss",
+			EDG:      crypto.EDGSecp256_K1,
+			Apply: func(key *ecdsa.PrivateKey, grace time.Duration) {
+				pssService.RotateKey(key, grace)
+				apiService.SetPSSPublicKey(key.PublicKey)
+			},
+		})
+		// the libp2p key determines the identity of the node
+		// in the network, so it is used only after the restart
+		o.KeyRotation.Register("libp2p", keyrotation.Key{
+			Filename: "libp2p_v2",
+			EDG:      crypto.EDGSecp256_R1,
+		})
+		extraOpts.KeyRotator = o.KeyRotation
+	}
+
+	b.reloader = &reloader{
+		current: ReloadOptions{
+			CORSAllowedOrigins:     o.CORSAllowedOrigins,
+			Verbosity:              o.Verbosity,
+			TrafficThrottleRate:    o.TrafficThrottleRate,
+			PullSyncBandwidthLimit: o.PullSyncBandwidthLimit,
+			PushSyncBandwidthLimit: o.PushSyncBandwidthLimit,
+			PaymentThreshold:       o.PaymentThreshold,
+			NATAddr:                o.NATAddr,
+		},
+		load:       o.ConfigLoader,
+		fullNode:   o.FullNodeMode,
+		logger:     logger,
+		api:        apiService,
+		traffic:    trafficMeter,
+		puller:     pullerService,
+		pusher:     pusherService,
+		pricing:    pricing,
+		accounting: acc,
+		p2p:        p2ps,
+	}
+	if o.ConfigLoader != nil {
+		extraOpts.ConfigReloader = b
 	}
 
 	if o.APIAddr != "" {
 		// register metrics from components
 		apiService.MustRegisterMetrics(p2ps.Metrics()...)
+		apiService.MustRegisterMetrics(trafficMeter.Metrics()...)
 		apiService.MustRegisterMetrics(pingPong.Metrics()...)
 		apiService.MustRegisterMetrics(acc.Metrics()...)
 		apiService.MustRegisterMetrics(localStore.Metrics()...)
 		apiService.MustRegisterMetrics(kad.Metrics()...)
+		apiService.MustRegisterMetrics(eventBus.Metrics()...)
+		if webhook != nil {
+			apiService.MustRegisterMetrics(webhook.Metrics()...)
+		}
 		apiService.MustRegisterMetrics(saludService.Metrics()...)
 		apiService.MustRegisterMetrics(stateStoreMetrics.Metrics()...)
 
@@ -1168,6 +1760,10 @@ func NewBee(
 			apiService.MustRegisterMetrics(pullerService.Metrics()...)
 		}
 
+		if repairService != nil {
+			apiService.MustRegisterMetrics(repairService.Metrics()...)
+		}
+
 		if agent != nil {
 			apiService.MustRegisterMetrics(agent.Metrics()...)
 		}
@@ -1175,7 +1771,11 @@ func NewBee(
 		apiService.MustRegisterMetrics(pushSyncProtocol.Metrics()...)
 		apiService.MustRegisterMetrics(pusherService.Metrics()...)
 		apiService.MustRegisterMetrics(pullSyncProtocol.Metrics()...)
+		apiService.MustRegisterMetrics(existenceService.Metrics()...)
 		apiService.MustRegisterMetrics(retrieval.Metrics()...)
+		if upstreamRetriever != nil {
+			apiService.MustRegisterMetrics(upstreamRetriever.Metrics()...)
+		}
 		apiService.MustRegisterMetrics(lightNodes.Metrics()...)
 		apiService.MustRegisterMetrics(hive.Metrics()...)
 
@@ -1201,10 +1801,25 @@ func NewBee(
 		}
 
 		apiService.Configure(signer, tracer, api.Options{
-			CORSAllowedOrigins: o.CORSAllowedOrigins,
-			WsPingPeriod:       60 * time.Second,
+			CORSAllowedOrigins:     o.CORSAllowedOrigins,
+			WsPingPeriod:           60 * time.Second,
+			ValidateRequests:       o.APIValidateRequests,
+			ValidationOffload:      o.ChunkValidationOffload,
+			SOCCacheTTL:            o.SOCCacheTTL,
+			FeedCacheTTL:           o.FeedCacheTTL,
+			IdempotencyWindow:      o.APIIdempotencyWindow,
+			BzzAccessLogPath:       o.APIBzzAccessLog,
+			BzzAccessLogMaxSize:    o.APIBzzAccessLogMaxSize,
+			BzzAccessLogMaxBackups: o.APIBzzAccessLogMaxBackups,
+			ReadOnly:               readOnlyMode,
+			RequestSigningSecret:   o.APIRequestSigningSecret,
+			RequestSigningWindow:   o.APIRequestSigningWindow,
 		}, extraOpts, chainID, erc20Service)
 
+		// mount again so that the routes of the services configured
+		// above are registered
+		apiService.Mount()
+
 		apiService.EnableFullAPI()
 
 		apiService.SetRedistributionAgent(agent)
@@ -1221,6 +1836,141 @@ func NewBee(
 		return nil, fmt.Errorf("p2ps ready: %w", err)
 	}
 
+	b.embedded = &embedded{
+		overlay:      swarmAddress,
+		storer:       localStore,
+		post:         post,
+		batchStore:   batchStore,
+		stamperStore: stamperStore,
+		signer:       signer,
+		shutdown:     b.Shutdown,
+	}
+
+	if o.GRPCAddr != "" {
+		grpcListener, err := net.Listen("tcp", o.GRPCAddr)
+		if err != nil {
+			return nil, fmt.Errorf("grpc listener: %w", err)
+		}
+
+		grpcServer := grpcapi.NewServer(grpcapi.New(grpcNode{b.embedded}, p2ps, swarmAddress, beeNodeMode.String(), readOnlyMode, logger))
+		go func() {
+			logger.Info("starting grpc server", "address", grpcListener.Addr())
+			if err := grpcServer.Serve(grpcListener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+				logger.Debug("grpc server failed", "error", err)
+				logger.Error(nil, "unable to serve grpc")
+			}
+		}()
+		b.grpcServer = grpcServer
+	}
+
+	if o.S3Addr != "" {
+		s3BatchID, err := hex.DecodeString(o.S3BatchID)
+		if err != nil || len(s3BatchID) != swarm.HashSize {
+			return nil, fmt.Errorf("invalid s3 batch id %q", o.S3BatchID)
+		}
+		s3RootTopic, err := crypto.LegacyKeccak256([]byte(o.S3RootTopic))
+		if err != nil {
+			return nil, fmt.Errorf("s3 root topic: %w", err)
+		}
+		gateway, err := s3gateway.New(s3gateway.Options{
+			RootTopic: s3RootTopic,
+			Storer:    localStore,
+			Putter: func(ctx context.Context) (storer.PutterSession, error) {
+				return b.embedded.session(ctx, s3BatchID, true, false)
+			},
+			Feeds:    feedFactory,
+			Signer:   signer,
+			ReadOnly: readOnlyMode,
+			Logger:   logger,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("s3 gateway: %w", err)
+		}
+
+		s3Listener, err := net.Listen("tcp", o.S3Addr)
+		if err != nil {
+			return nil, fmt.Errorf("s3 listener: %w", err)
+		}
+		s3Server := &http.Server{
+			IdleTimeout:       30 * time.Second,
+			ReadHeaderTimeout: 3 * time.Second,
+			Handler:           gateway,
+			ErrorLog:          stdlog.New(b.errorLogWriter, "", 0),
+		}
+		go func() {
+			logger.Info("starting s3 gateway", "address", s3Listener.Addr())
+			if err := s3Server.Serve(s3Listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Debug("s3 gateway failed", "error", err)
+				logger.Error(nil, "unable to serve s3 gateway")
+			}
+		}()
+		b.s3Server = s3Server
+	}
+
+	if o.WebDAVAddr != "" {
+		var putter manifestfs.PutterFunc
+		if o.WebDAVBatchID != "" {
+			webdavBatchID, err := hex.DecodeString(o.WebDAVBatchID)
+			if err != nil || len(webdavBatchID) != swarm.HashSize {
+				return nil, fmt.Errorf("invalid webdav batch id %q", o.WebDAVBatchID)
+			}
+			putter = func(ctx context.Context) (storer.PutterSession, error) {
+				return b.embedded.session(ctx, webdavBatchID, true, false)
+			}
+		}
+		webdavHandler, err := webdav.New(webdav.Options{
+			Storer:   localStore,
+			Putter:   putter,
+			Feeds:    feedFactory,
+			Signer:   signer,
+			ReadOnly: readOnlyMode,
+			Logger:   logger,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("webdav: %w", err)
+		}
+
+		webdavListener, err := net.Listen("tcp", o.WebDAVAddr)
+		if err != nil {
+			return nil, fmt.Errorf("webdav listener: %w", err)
+		}
+		webdavServer := &http.Server{
+			IdleTimeout:       30 * time.Second,
+			ReadHeaderTimeout: 3 * time.Second,
+			Handler:           webdavHandler,
+			ErrorLog:          stdlog.New(b.errorLogWriter, "", 0),
+		}
+		go func() {
+			logger.Info("starting webdav server", "address", webdavListener.Addr())
+			if err := webdavServer.Serve(webdavListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Debug("webdav server failed", "error", err)
+				logger.Error(nil, "unable to serve webdav")
+			}
+		}()
+		b.webdavServer = webdavServer
+	}
+
+	if stampProxy != nil {
+		stampProxyListener, err := net.Listen("tcp", o.StampProxyAddr)
+		if err != nil {
+			return nil, fmt.Errorf("stamp proxy listener: %w", err)
+		}
+		stampProxyServer := &http.Server{
+			IdleTimeout:       30 * time.Second,
+			ReadHeaderTimeout: 3 * time.Second,
+			Handler:           stampProxy.Handler(apiService),
+			ErrorLog:          stdlog.New(b.errorLogWriter, "", 0),
+		}
+		go func() {
+			logger.Info("starting stamp proxy", "address", stampProxyListener.Addr())
+			if err := stampProxyServer.Serve(stampProxyListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Debug("stamp proxy failed", "error", err)
+				logger.Error(nil, "unable to serve stamp proxy")
+			}
+		}()
+		b.stampProxyServer = stampProxyServer
+	}
+
 	return b, nil
 }
 
@@ -1228,6 +1978,12 @@ func (b *Bee) SyncingStopped() chan struct{} {
 	return b.syncingStopped.C
 }
 
+// ModeSwitched returns the channel receiving the mode the node
+// is requested to be switched to through the API.
+func (b *Bee) ModeSwitched() <-chan modeswitch.Mode {
+	return b.modeSwitcher.Switched()
+}
+
 func (b *Bee) Shutdown() error {
 	var mErr error
 
@@ -1240,17 +1996,22 @@ func (b *Bee) Shutdown() error {
 	b.shutdownInProgress = true
 	b.shutdownMutex.Unlock()
 
-	// halt kademlia while shutting down other
-	// components.
-	if b.topologyHalter != nil {
-		b.topologyHalter.Halt()
+	// the readiness is flipped first so that the load balancers stop
+	// routing the new requests to the node while the api is drained
+	if b.probe != nil && b.probe.Ready() == api.ProbeStatusOK {
+		b.probe.SetReady(api.ProbeStatusNOK)
+		if b.apiServer != nil {
+			b.apiServer.SetKeepAlivesEnabled(false)
+			for _, s := range b.apiGroupServers {
+				s.SetKeepAlivesEnabled(false)
+			}
+			if b.shutdownDrainDelay > 0 {
+				b.logger.Info("draining api requests", "delay", b.shutdownDrainDelay)
+				time.Sleep(b.shutdownDrainDelay)
+			}
+		}
 	}
 
-	// halt p2p layer from accepting new connections
-	// while shutting down other components
-	if b.p2pHalter != nil {
-		b.p2pHalter.Halt()
-	}
 	// tryClose is a convenient closure which decrease
 	// repetitive io.Closer tryClose procedure.
 	tryClose := func(c io.Closer, errMsg string) {
@@ -1262,16 +2023,51 @@ func (b *Bee) Shutdown() error {
 		}
 	}
 
-	tryClose(b.apiCloser, "api")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	shutdownTimeout := b.shutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
+	// the servers stop accepting the new connections and the in-flight
+	// requests are finished while the protocols they depend on still run,
+	// the requests still running after the timeout are aborted
+	shutdownServer := func(s *http.Server, name string) error {
+		if err := s.Shutdown(ctx); err != nil {
+			_ = s.Close()
+			return fmt.Errorf("%s server: %w", name, err)
+		}
+		return nil
+	}
+
 	var eg errgroup.Group
 	if b.apiServer != nil {
+		eg.Go(func() error { return shutdownServer(b.apiServer, "api") })
+	}
+	for _, s := range b.apiGroupServers {
+		eg.Go(func() error { return shutdownServer(s, "api") })
+	}
+	if b.s3Server != nil {
+		eg.Go(func() error { return shutdownServer(b.s3Server, "s3") })
+	}
+	if b.webdavServer != nil {
+		eg.Go(func() error { return shutdownServer(b.webdavServer, "webdav") })
+	}
+	if b.stampProxyServer != nil {
+		eg.Go(func() error { return shutdownServer(b.stampProxyServer, "stamp proxy") })
+	}
+	if b.grpcServer != nil {
 		eg.Go(func() error {
-			if err := b.apiServer.Shutdown(ctx); err != nil {
-				return fmt.Errorf("api server: %w", err)
+			stopped := make(chan struct{})
+			go func() {
+				b.grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				b.grpcServer.Stop()
 			}
 			return nil
 		})
@@ -1280,8 +2076,26 @@ func (b *Bee) Shutdown() error {
 		mErr = multierror.Append(mErr, err)
 	}
 
+	tryClose(b.apiCloser, "api")
+	tryClose(b.fuseCloser, "fuse")
+	tryClose(b.webhookCloser, "webhook")
+	tryClose(b.expiryNotifierCloser, "batch expiry notifier")
+	tryClose(b.maintenanceCloser, "maintenance")
+
+	// halt kademlia while shutting down other
+	// components.
+	if b.topologyHalter != nil {
+		b.topologyHalter.Halt()
+	}
+
+	// halt p2p layer from accepting new connections
+	// while shutting down other components
+	if b.p2pHalter != nil {
+		b.p2pHalter.Halt()
+	}
+
 	var wg sync.WaitGroup
-	wg.Add(8)
+	wg.Add(10)
 	go func() {
 		defer wg.Done()
 		tryClose(b.pssCloser, "pss")
@@ -1312,14 +2126,23 @@ func (b *Bee) Shutdown() error {
 		defer wg.Done()
 		tryClose(b.hiveCloser, "hive")
 	}()
+	go func() {
+		defer wg.Done()
+		tryClose(b.mdnsCloser, "mdns discovery")
+	}()
 	go func() {
 		defer wg.Done()
 		tryClose(b.saludCloser, "salud")
 	}()
+	go func() {
+		defer wg.Done()
+		tryClose(b.repairCloser, "replication repair")
+	}()
 
 	wg.Wait()
 
 	tryClose(b.p2pService, "p2p server")
+	tryClose(b.trafficCloser, "traffic meter")
 	tryClose(b.priceOracleCloser, "price oracle service")
 
 	wg.Add(3)
@@ -1369,3 +2192,43 @@ func isChainEnabled(o *Options, swapEndpoint string, logger log.Logger) bool {
 	logger.Info("starting with an enabled chain backend")
 	return true // all other modes operate require chain enabled
 }
+
+// peerHints returns the hints of the hive peer records based on the
+// metrics collected by the kademlia, which only gossips the full nodes.
+func peerHints(kad *kademlia.Kad) hive.HintsFunc {
+	return func(addr swarm.Address) (hive.PeerHints, bool) {
+		ss, ok := kad.PeerMetrics(addr)
+		if !ok {
+			return hive.PeerHints{}, false
+		}
+		h := hive.PeerHints{
+			Latency:  time.Duration(ss.LatencyEWMA) * time.Millisecond,
+			FullNode: true,
+		}
+		if ss.LastSeenTimestamp > 0 {
+			h.LastSeen = time.Unix(ss.LastSeenTimestamp, 0)
+		}
+		return h, true
+	}
+}
+
+// networkKey returns the key of the private swarm, if the secret is set.
+func networkKey(secret string) []byte {
+	if secret == "" {
+		return nil
+	}
+	return []byte(secret)
+}
+
+// parseNetworkAllowlist parses the overlays allowed in the private swarm.
+func parseNetworkAllowlist(overlays []string) ([]swarm.Address, error) {
+	allowlist := make([]swarm.Address, 0, len(overlays))
+	for _, v := range overlays {
+		addr, err := swarm.ParseHexAddress(v)
+		if err != nil {
+			return nil, fmt.Errorf("network allowlist overlay %q: %w", v, err)
+		}
+		allowlist = append(allowlist, addr)
+	}
+	return allowlist, nil
+}
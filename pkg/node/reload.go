@@ -0,0 +1,215 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethersphere/bee/v2/pkg/accounting"
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/traffic"
+	"github.com/ethersphere/bee/v2/pkg/pricing"
+	"github.com/ethersphere/bee/v2/pkg/puller"
+	"github.com/ethersphere/bee/v2/pkg/pusher"
+)
+
+// ErrReloadUnsupported is returned when the node was started without the
+// loader of the configuration.
+var ErrReloadUnsupported = errors.New("config reload not supported")
+
+// ReloadOptions are the options which can be changed on the running node
+// without dropping the peers or restarting the API.
+type ReloadOptions struct {
+	CORSAllowedOrigins     []string
+	Verbosity              log.Level
+	TrafficThrottleRate    int
+	PullSyncBandwidthLimit float64
+	PushSyncBandwidthLimit float64
+	PaymentThreshold       string
+	NATAddr                string
+}
+
+// reloader applies the changed reload options to the running services.
+type reloader struct {
+	mu         sync.Mutex
+	current    ReloadOptions
+	load       func() (ReloadOptions, error)
+	fullNode   bool
+	logger     log.Logger
+	api        *api.Service
+	traffic    *traffic.Meter
+	puller     *puller.Puller
+	pusher     *pusher.Service
+	pricing    *pricing.Service
+	accounting *accounting.Accounting
+	p2p        *libp2p.Service
+}
+
+// ReloadConfig loads the configuration again and applies the changes of the
+// reload options. It returns the config option names of the changed options.
+func (b *Bee) ReloadConfig() ([]string, error) {
+	if b.reloader == nil || b.reloader.load == nil {
+		return nil, ErrReloadUnsupported
+	}
+	o, err := b.reloader.load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	return b.reloader.apply(o)
+}
+
+// apply validates the reload options and applies the ones which differ from
+// the currently applied options. The changed options are applied only if all
+// of them are valid.
+func (r *reloader) apply(o ReloadOptions) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		changed []string
+		apply   []func() error
+	)
+
+	if o.NATAddr != r.current.NATAddr {
+		changed = append(changed, "nat-addr")
+		// the nat address is applied first as it is validated while being set
+		apply = append(apply, func() error {
+			if err := r.p2p.SetNATAddr(o.NATAddr); err != nil {
+				return fmt.Errorf("set nat address: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if !slices.Equal(o.CORSAllowedOrigins, r.current.CORSAllowedOrigins) {
+		changed = append(changed, "cors-allowed-origins")
+		apply = append(apply, func() error {
+			if r.api != nil {
+				r.api.SetCORSAllowedOrigins(o.CORSAllowedOrigins)
+			}
+			return nil
+		})
+	}
+
+	if o.Verbosity != r.current.Verbosity {
+		changed = append(changed, "verbosity")
+		apply = append(apply, func() error {
+			// the loggers are collected first, the registry
+			// is not modified while being iterated over
+			var ids []string
+			log.RegistryIterate(func(id, _ string, _ log.Level, _ uint) bool {
+				ids = append(ids, id)
+				return true
+			})
+			for _, id := range ids {
+				if err := log.SetVerbosityByExp(id, o.Verbosity); err != nil {
+					return fmt.Errorf("set verbosity: %w", err)
+				}
+			}
+			return nil
+		})
+	}
+
+	if o.TrafficThrottleRate != r.current.TrafficThrottleRate {
+		changed = append(changed, "traffic-throttle-rate")
+		apply = append(apply, func() error {
+			r.traffic.SetThrottleRate(o.TrafficThrottleRate)
+			return nil
+		})
+	}
+
+	if o.PullSyncBandwidthLimit != r.current.PullSyncBandwidthLimit {
+		if o.PullSyncBandwidthLimit < 0 {
+			return nil, fmt.Errorf("invalid pullsync bandwidth limit: %v", o.PullSyncBandwidthLimit)
+		}
+		changed = append(changed, "pullsync-bandwidth-limit")
+		apply = append(apply, func() error {
+			if r.puller == nil {
+				return nil
+			}
+			// the historical syncing limit may have been changed by the API
+			limits := r.puller.Limits()
+			limits.Bandwidth = o.PullSyncBandwidthLimit
+			if err := r.puller.SetLimits(limits); err != nil {
+				return fmt.Errorf("set pullsync limits: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if o.PushSyncBandwidthLimit != r.current.PushSyncBandwidthLimit {
+		if o.PushSyncBandwidthLimit < 0 {
+			return nil, fmt.Errorf("invalid pushsync bandwidth limit: %v", o.PushSyncBandwidthLimit)
+		}
+		changed = append(changed, "pushsync-bandwidth-limit")
+		apply = append(apply, func() error {
+			if r.pusher == nil {
+				return nil
+			}
+			// the tag priorities may have been set by the API
+			limits := r.pusher.Limits()
+			limits.Bandwidth = o.PushSyncBandwidthLimit
+			if err := r.pusher.SetLimits(limits); err != nil {
+				return fmt.Errorf("set pushsync limits: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if o.PaymentThreshold != r.current.PaymentThreshold {
+		paymentThreshold, err := r.validatePaymentThreshold(o.PaymentThreshold)
+		if err != nil {
+			return nil, err
+		}
+		changed = append(changed, "payment-threshold")
+		apply = append(apply, func() error {
+			lightPaymentThreshold := new(big.Int).Div(paymentThreshold, big.NewInt(lightFactor))
+			r.pricing.SetPaymentThreshold(paymentThreshold, lightPaymentThreshold)
+			r.accounting.SetPaymentThreshold(paymentThreshold)
+			return nil
+		})
+	}
+
+	for _, f := range apply {
+		if err := f(); err != nil {
+			return nil, err
+		}
+	}
+	r.current = o
+
+	if len(changed) > 0 {
+		r.logger.Info("config reloaded", "changed", changed)
+	}
+	return changed, nil
+}
+
+// validatePaymentThreshold parses the payment threshold and checks it
+// against the generally accepted bounds.
+func (r *reloader) validatePaymentThreshold(s string) (*big.Int, error) {
+	paymentThreshold, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid payment threshold: %s", s)
+	}
+
+	minThreshold := big.NewInt(2 * refreshRate)
+	if !r.fullNode {
+		minThreshold = big.NewInt(2 * lightRefreshRate)
+	}
+	maxThreshold := big.NewInt(24 * refreshRate)
+
+	if paymentThreshold.Cmp(minThreshold) < 0 {
+		return nil, fmt.Errorf("payment threshold below minimum generally accepted value, need at least %s", minThreshold)
+	}
+	if paymentThreshold.Cmp(maxThreshold) > 0 {
+		return nil, fmt.Errorf("payment threshold above maximum generally accepted value, needs to be reduced to at most %s", maxThreshold)
+	}
+	return paymentThreshold, nil
+}
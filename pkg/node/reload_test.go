@@ -0,0 +1,108 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node_test
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/accounting"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/node"
+	p2pmock "github.com/ethersphere/bee/v2/pkg/p2p/mock"
+	"github.com/ethersphere/bee/v2/pkg/p2p/streamtest"
+	"github.com/ethersphere/bee/v2/pkg/p2p/traffic"
+	"github.com/ethersphere/bee/v2/pkg/pricing"
+	"github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestReloadConfig(t *testing.T) {
+	t.Parallel()
+
+	const paymentThreshold = 13_500_000
+
+	current := node.ReloadOptions{
+		Verbosity:           log.VerbosityInfo,
+		TrafficThrottleRate: traffic.DefaultThrottleRate,
+		PaymentThreshold:    "13500000",
+	}
+
+	store := mock.NewStateStore()
+	t.Cleanup(func() { _ = store.Close() })
+
+	prices := pricing.New(streamtest.New(), log.Noop, big.NewInt(paymentThreshold), big.NewInt(paymentThreshold/10), big.NewInt(0))
+	acc, err := accounting.NewAccounting(big.NewInt(paymentThreshold), 25, 50, log.Noop, store, prices, big.NewInt(4_500_000), 10, p2pmock.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := current
+	b := node.NewReloadableBee(current, func() (node.ReloadOptions, error) {
+		return loaded, nil
+	}, traffic.NewMeter(traffic.Options{}, log.Noop), prices, acc)
+
+	t.Run("unchanged", func(t *testing.T) {
+		changed, err := b.ReloadConfig()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changed) != 0 {
+			t.Fatalf("got changed %v, want none", changed)
+		}
+	})
+
+	t.Run("invalid payment threshold", func(t *testing.T) {
+		loaded.TrafficThrottleRate = 1024
+		loaded.PaymentThreshold = "1"
+
+		if _, err := b.ReloadConfig(); err == nil {
+			t.Fatal("expected error for the payment threshold below minimum")
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		loaded.PaymentThreshold = "27000000"
+
+		changed, err := b.ReloadConfig()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"traffic-throttle-rate", "payment-threshold"}; !slices.Equal(changed, want) {
+			t.Fatalf("got changed %v, want %v", changed, want)
+		}
+
+		peer := swarm.RandAddress(t)
+		acc.Connect(peer, true)
+		peers, err := acc.PeerAccounting()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := peers[peer.String()].ThresholdGiven, big.NewInt(27_000_000); got.Cmp(want) != 0 {
+			t.Fatalf("got threshold %d, want %d", got, want)
+		}
+
+		changed, err = b.ReloadConfig()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changed) != 0 {
+			t.Fatalf("got changed %v after reload of the same config, want none", changed)
+		}
+	})
+
+	t.Run("load error", func(t *testing.T) {
+		errLoad := errors.New("load")
+		b := node.NewReloadableBee(current, func() (node.ReloadOptions, error) {
+			return node.ReloadOptions{}, errLoad
+		}, nil, nil, nil)
+
+		if _, err := b.ReloadConfig(); !errors.Is(err, errLoad) {
+			t.Fatalf("got error %v, want %v", err, errLoad)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ethersphere/bee/v2/pkg/secrets"
+)
+
+// resolveSecrets returns the copy of the options with the secret
+// references in the endpoints and the webhook options resolved,
+// so that the embedding applications can pass the references too.
+func resolveSecrets(ctx context.Context, o *Options) (*Options, error) {
+	resolved := *o
+	resolved.ResolverConnectionCfgs = slices.Clone(o.ResolverConnectionCfgs)
+
+	values := []*string{
+		&resolved.BlockchainRpcEndpoint,
+		&resolved.WebhookURL,
+		&resolved.WebhookSecret,
+	}
+	for i := range resolved.ResolverConnectionCfgs {
+		values = append(values, &resolved.ResolverConnectionCfgs[i].Endpoint)
+	}
+
+	if err := secrets.NewResolver().ResolveAll(ctx, values...); err != nil {
+		return nil, err
+	}
+	return &resolved, nil
+}
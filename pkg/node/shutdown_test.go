@@ -0,0 +1,132 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package node_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/node"
+)
+
+// serve starts the server with the handler blocking until the release
+// channel is closed and returns the address of the server.
+func serve(t *testing.T, started chan<- struct{}, release <-chan struct{}) (*http.Server, string) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			_, _ = io.WriteString(w, "done")
+		}),
+	}
+	go func() { _ = srv.Serve(l) }()
+	t.Cleanup(func() { _ = srv.Close() })
+	return srv, "http://" + l.Addr().String()
+}
+
+func TestShutdownDrain(t *testing.T) {
+	t.Parallel()
+
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	srv, url := serve(t, started, release)
+
+	probe := api.NewProbe()
+	probe.SetReady(api.ProbeStatusOK)
+	b := node.NewServingBee(srv, probe, 100*time.Millisecond, 5*time.Second)
+
+	type result struct {
+		body string
+		err  error
+	}
+	resC := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err != nil {
+			resC <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		resC <- result{body: string(body), err: err}
+	}()
+	<-started
+
+	shutdownC := make(chan error, 1)
+	go func() { shutdownC <- b.Shutdown() }()
+
+	// the readiness is flipped before the in-flight request finishes
+	deadline := time.Now().Add(5 * time.Second)
+	for probe.Ready() != api.ProbeStatusNOK {
+		if time.Now().After(deadline) {
+			t.Fatal("readiness not flipped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case err := <-shutdownC:
+		t.Fatalf("shutdown finished before the in-flight request: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	close(release)
+
+	res := <-resC
+	if res.err != nil || res.body != "done" {
+		t.Fatalf("got in-flight response %q, error %v", res.body, res.err)
+	}
+	if err := <-shutdownC; err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if _, err := http.Get(url); err == nil {
+		t.Fatal("want the new requests refused after shutdown")
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	t.Parallel()
+
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	srv, url := serve(t, started, release)
+	t.Cleanup(func() { close(release) })
+
+	b := node.NewServingBee(srv, api.NewProbe(), 0, 100*time.Millisecond)
+
+	errC := make(chan error, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err == nil {
+			_, err = io.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+		errC <- err
+	}()
+	<-started
+
+	if err := b.Shutdown(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got shutdown error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := <-errC; err == nil {
+		t.Fatal("want the request running past the timeout aborted")
+	}
+}
@@ -22,6 +22,13 @@ import (
 // data directory. When given an empty directory path, the function will instead
 // initialize an in-memory state store that will not be persisted.
 func InitStateStore(logger log.Logger, dataDir string, cacheCapacity uint64) (storage.StateStorerManager, metrics.Collector, error) {
+	stateStore, caching, _, err := initStateStore(logger, dataDir, cacheCapacity)
+	return stateStore, caching, err
+}
+
+// initStateStore is like InitStateStore, but it
+// also returns the underlying levelDB store.
+func initStateStore(logger log.Logger, dataDir string, cacheCapacity uint64) (storage.StateStorerManager, metrics.Collector, *leveldbstore.Store, error) {
 	if dataDir == "" {
 		logger.Warning("using in-mem state store, no node state will be persisted")
 	} else {
@@ -29,17 +36,17 @@ func InitStateStore(logger log.Logger, dataDir string, cacheCapacity uint64) (st
 	}
 	ldb, err := leveldbstore.New(dataDir, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	caching, err := cache.Wrap(ldb, int(cacheCapacity))
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	stateStore, err := storeadapter.NewStateStorerAdapter(caching)
 
-	return stateStore, caching, err
+	return stateStore, caching, ldb, err
 }
 
 // InitStamperStore will create new stamper store with the given path to the
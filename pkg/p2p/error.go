@@ -20,6 +20,8 @@ var (
 	ErrDialLightNode = errors.New("target peer is a light node")
 	// ErrPeerBlocklisted is returned if peer is on blocklist
 	ErrPeerBlocklisted = errors.New("peer blocklisted")
+	// ErrPeerDenied is returned if peer is denied by the peering policy
+	ErrPeerDenied = errors.New("peer denied")
 )
 
 const (
@@ -0,0 +1,61 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestPeerCapabilities(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s1, overlay1 := newService(t, 1, libp2pServiceOpts{libp2pOpts: libp2p.Options{
+		FullNode:          true,
+		EnableCompression: true,
+		Capabilities:      p2p.CapabilityPeerRecords,
+	}})
+	s2, overlay2 := newService(t, 1, libp2pServiceOpts{})
+
+	if _, err := s2.Connect(ctx, serviceUnderlayAddress(t, s1)); err != nil {
+		t.Fatal(err)
+	}
+	expectPeersEventually(t, s1, overlay2)
+
+	caps, err := s2.PeerCapabilities(overlay1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := p2p.CapabilityCompression | p2p.CapabilityPeerRecords; caps != want {
+		t.Fatalf("capabilities: want %b, have %b", want, caps)
+	}
+
+	caps, err = s1.PeerCapabilities(overlay2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caps != 0 {
+		t.Fatalf("capabilities: want none, have %b", caps)
+	}
+
+	if _, err := s1.PeerCapabilities(swarm.RandAddress(t)); !errors.Is(err, p2p.ErrPeerNotFound) {
+		t.Fatalf("want error %v, have %v", p2p.ErrPeerNotFound, err)
+	}
+
+	if err := s2.Disconnect(overlay1, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s2.PeerCapabilities(overlay1); !errors.Is(err, p2p.ErrPeerNotFound) {
+		t.Fatalf("disconnected: want error %v, have %v", p2p.ErrPeerNotFound, err)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/golang/snappy"
+	"github.com/libp2p/go-libp2p/core/network"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+)
+
+// compressionSnappy is the name of the snappy framed stream compression.
+const compressionSnappy = "snappy"
+
+// supportedCompressions are the stream compressions offered to the peers
+// in the order of preference.
+var supportedCompressions = []string{compressionSnappy}
+
+// compressible reports whether the stream of the protocol negotiates the compression.
+func (s *Service) compressible(protocolName, streamName string) bool {
+	if !s.compression {
+		return false
+	}
+
+	s.protocolsmu.RLock()
+	defer s.protocolsmu.RUnlock()
+
+	for _, p := range s.protocols {
+		if p.Name != protocolName {
+			continue
+		}
+		for _, ss := range p.StreamSpecs {
+			if ss.Name == streamName {
+				return ss.Compressible
+			}
+		}
+	}
+	return false
+}
+
+// peerCapable reports whether the connected peer advertised the capabilities.
+func (s *Service) peerCapable(peerID libp2ppeer.ID, c p2p.Capabilities) bool {
+	caps, _ := s.peers.capabilities(peerID)
+	return caps.Has(c)
+}
+
+// compressionHeadler wraps the headler of the compressible stream to
+// respond with the first compression offered by the peer which is supported.
+func compressionHeadler(headler p2p.HeadlerFunc) p2p.HeadlerFunc {
+	return func(h p2p.Headers, peer swarm.Address) p2p.Headers {
+		var resp p2p.Headers
+		if headler != nil {
+			resp = headler(h, peer)
+		}
+
+		offered := strings.Split(string(h[p2p.HeaderNameCompression]), ",")
+		for _, c := range offered {
+			if slices.Contains(supportedCompressions, c) {
+				if resp == nil {
+					resp = make(p2p.Headers)
+				}
+				resp[p2p.HeaderNameCompression] = []byte(c)
+				break
+			}
+		}
+		return resp
+	}
+}
+
+// offerCompression adds the supported compressions to the request headers.
+func offerCompression(h p2p.Headers) {
+	h[p2p.HeaderNameCompression] = []byte(strings.Join(supportedCompressions, ","))
+}
+
+// compress wraps the stream in the compression selected in the headers, if any.
+func (s *Service) compress(st *stream, h p2p.Headers) {
+	if string(h[p2p.HeaderNameCompression]) != compressionSnappy {
+		return
+	}
+	st.Stream = newSnappyStream(st.Stream)
+	s.metrics.CompressedStreamCount.Inc()
+}
+
+// snappyStream compresses the data written to the
+// stream and decompresses the data read from it.
+type snappyStream struct {
+	network.Stream
+	r *snappy.Reader
+	w *snappy.Writer
+}
+
+func newSnappyStream(s network.Stream) *snappyStream {
+	return &snappyStream{
+		Stream: s,
+		r:      snappy.NewReader(s),
+		w:      snappy.NewBufferedWriter(s),
+	}
+}
+
+func (s *snappyStream) Read(b []byte) (int, error) {
+	return s.r.Read(b)
+}
+
+func (s *snappyStream) Write(b []byte) (int, error) {
+	n, err := s.w.Write(b)
+	if err != nil {
+		return n, err
+	}
+	return n, s.w.Flush()
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p"
+)
+
+func TestCompression(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("swarm chunk data "), 1024)
+
+	for _, tc := range []struct {
+		name         string
+		client       bool
+		server       bool
+		compressible bool
+		want         string
+	}{
+		{name: "negotiated", client: true, server: true, compressible: true, want: "snappy"},
+		{name: "client disabled", server: true, compressible: true},
+		{name: "server disabled", client: true, compressible: true},
+		{name: "not compressible", client: true, server: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			s1, overlay1 := newService(t, 1, libp2pServiceOpts{libp2pOpts: libp2p.Options{
+				FullNode:          true,
+				EnableCompression: tc.server,
+			}})
+			s2, _ := newService(t, 1, libp2pServiceOpts{libp2pOpts: libp2p.Options{
+				EnableCompression: tc.client,
+			}})
+
+			handled := make(chan string, 1)
+			echo := func(_ context.Context, _ p2p.Peer, stream p2p.Stream) error {
+				defer stream.Close()
+				handled <- string(stream.ResponseHeaders()[p2p.HeaderNameCompression])
+				data := make([]byte, len(payload))
+				if _, err := io.ReadFull(stream, data); err != nil {
+					return err
+				}
+				_, err := stream.Write(data)
+				return err
+			}
+			spec := newTestProtocol(echo)
+			spec.StreamSpecs[0].Compressible = tc.compressible
+			if err := s1.AddProtocol(spec); err != nil {
+				t.Fatal(err)
+			}
+			spec = newTestProtocol(echo)
+			spec.StreamSpecs[0].Compressible = tc.compressible
+			if err := s2.AddProtocol(spec); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := s2.Connect(ctx, serviceUnderlayAddress(t, s1)); err != nil {
+				t.Fatal(err)
+			}
+
+			stream, err := s2.NewStream(ctx, overlay1, nil, testProtocolName, testProtocolVersion, testStreamName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer stream.Close()
+
+			if have := string(stream.Headers()[p2p.HeaderNameCompression]); have != tc.want {
+				t.Fatalf("client compression: want %q, have %q", tc.want, have)
+			}
+			if have := <-handled; have != tc.want {
+				t.Fatalf("server compression: want %q, have %q", tc.want, have)
+			}
+
+			if _, err := stream.Write(payload); err != nil {
+				t.Fatal(err)
+			}
+			data := make([]byte, len(payload))
+			if _, err := io.ReadFull(stream, data); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, payload) {
+				t.Fatal("echoed data mismatch")
+			}
+		})
+	}
+}
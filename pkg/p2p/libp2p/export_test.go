@@ -32,9 +32,14 @@ func (s *Service) Host() host.Host {
 
 type StaticAddressResolver = staticAddressResolver
 
+func (r *StaticAddressResolver) Set(addr string) error {
+	return r.set(addr)
+}
+
 var (
 	NewStaticAddressResolver = newStaticAddressResolver
 	UserAgent                = userAgent
+	DialRanker               = dialRanker
 )
 
 func WithHostFactory(factory func(...libp2pm.Option) (host.Host, error)) Options {
@@ -42,3 +47,13 @@ func WithHostFactory(factory func(...libp2pm.Option) (host.Host, error)) Options
 		hostFactory: factory,
 	}
 }
+
+func NewRelayAddressResolver(r handshake.AdvertisableAddressResolver, h host.Host, reachability func() network.Reachability) handshake.AdvertisableAddressResolver {
+	return &relayAddressResolver{
+		AdvertisableAddressResolver: r,
+		host:                        h,
+		reachability:                reachability,
+	}
+}
+
+var ParseStaticRelays = parseStaticRelays
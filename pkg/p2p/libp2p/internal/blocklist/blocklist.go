@@ -70,7 +70,13 @@ func (b *Blocklist) Add(overlay swarm.Address, duration time.Duration, reason st
 		duration = d
 	}
 
-	return b.store.Put(key, &entry{
+	return b.Set(overlay, duration, reason, full)
+}
+
+// Set puts the peer on the blocklist for the given duration replacing
+// the existing entry. Duration 0 is treated as an infinite duration.
+func (b *Blocklist) Set(overlay swarm.Address, duration time.Duration, reason string, full bool) error {
+	return b.store.Put(generateKey(overlay), &entry{
 		Timestamp: b.currentTimeFn(),
 		Duration:  duration.String(),
 		Reason:    reason,
@@ -78,6 +84,19 @@ func (b *Blocklist) Add(overlay swarm.Address, duration time.Duration, reason st
 	})
 }
 
+// Remove removes the peer from the blocklist. It returns
+// storage.ErrNotFound if the peer is not blocklisted.
+func (b *Blocklist) Remove(overlay swarm.Address) error {
+	exists, err := b.Exists(overlay)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return storage.ErrNotFound
+	}
+	return b.store.Delete(generateKey(overlay))
+}
+
 // Peers returns all currently blocklisted peers.
 func (b *Blocklist) Peers() ([]p2p.BlockListedPeer, error) {
 	var peers []p2p.BlockListedPeer
@@ -95,18 +114,25 @@ func (b *Blocklist) Peers() ([]p2p.BlockListedPeer, error) {
 			return true, err
 		}
 
-		if b.currentTimeFn().Sub(entry.Timestamp) > d && d != 0 {
+		elapsed := b.currentTimeFn().Sub(entry.Timestamp)
+		if elapsed > d && d != 0 {
 			// skip to the next item
 			return false, nil
 		}
 
+		var remaining time.Duration
+		if d != 0 {
+			remaining = d - elapsed
+		}
+
 		p := p2p.BlockListedPeer{
 			Peer: p2p.Peer{
 				Address:  addr,
 				FullNode: entry.Full,
 			},
-			Duration: d,
-			Reason:   entry.Reason,
+			Duration:  d,
+			Remaining: remaining,
+			Reason:    entry.Reason,
 		}
 		peers = append(peers, p)
 		return false, nil
@@ -5,12 +5,14 @@
 package blocklist_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p/internal/blocklist"
 	"github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
@@ -109,6 +111,49 @@ func TestPeers(t *testing.T) {
 	}
 }
 
+func TestSetRemove(t *testing.T) {
+	t.Parallel()
+
+	addr := swarm.NewAddress([]byte{0, 1, 2, 3})
+	now := time.Now()
+	ctMock := &currentTimeMock{time: now}
+
+	bl := blocklist.NewBlocklistWithCurrentTimeFn(mock.NewStateStore(), ctMock.Time)
+
+	if err := bl.Remove(addr); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("want error %v, have %v", storage.ErrNotFound, err)
+	}
+
+	// add forever, then shorten the duration
+	if err := bl.Add(addr, 0, "r1", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := bl.Set(addr, time.Minute, "r2", false); err != nil {
+		t.Fatal(err)
+	}
+
+	ctMock.SetTime(now.Add(20 * time.Second))
+
+	peers, err := bl.Peers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 1 || peers[0].Reason != "r2" || peers[0].Duration != time.Minute || peers[0].Remaining != 40*time.Second {
+		t.Fatalf("unexpected peers %+v", peers)
+	}
+
+	if err := bl.Remove(addr); err != nil {
+		t.Fatal(err)
+	}
+	exists, err := bl.Exists(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Fatal("got exists, expected not exists")
+	}
+}
+
 func isIn(p swarm.Address, peers []p2p.BlockListedPeer, reason string, f bool) bool {
 	for _, v := range peers {
 		if v.Address.Equal(p) && v.Reason == reason && v.Peer.FullNode == f {
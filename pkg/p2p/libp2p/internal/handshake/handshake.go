@@ -6,6 +6,8 @@ package handshake
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"sync/atomic"
@@ -36,6 +38,8 @@ const (
 	// MaxWelcomeMessageLength is maximum number of characters allowed in the welcome message.
 	MaxWelcomeMessageLength = 140
 	handshakeTimeout        = 15 * time.Second
+	// networkAuthDomain separates the network key proofs from other uses of the key.
+	networkAuthDomain = "bee-network-auth"
 )
 
 var (
@@ -53,6 +57,10 @@ var (
 
 	// ErrPicker is returned if the picker (kademlia) rejects the peer
 	ErrPicker = errors.New("picker rejection")
+
+	// ErrNetworkAuth is returned if the peer does not prove the knowledge
+	// of the network key or is not allowlisted in the private swarm.
+	ErrNetworkAuth = errors.New("network authentication failed")
 )
 
 // AdvertisableAddressResolver can Resolve a Multiaddress.
@@ -74,12 +82,16 @@ type Service struct {
 	libp2pID              libp2ppeer.ID
 	metrics               metrics
 	picker                p2p.Picker
+	networkKey            []byte
+	allowlist             map[string]struct{}
+	capabilities          p2p.Capabilities
 }
 
 // Info contains the information received from the handshake.
 type Info struct {
-	BzzAddress *bzz.Address
-	FullNode   bool
+	BzzAddress   *bzz.Address
+	FullNode     bool
+	Capabilities p2p.Capabilities
 }
 
 func (i *Info) LightString() string {
@@ -117,6 +129,25 @@ func (s *Service) SetPicker(n p2p.Picker) {
 	s.picker = n
 }
 
+// SetCapabilities sets the capabilities advertised to the peers.
+func (s *Service) SetCapabilities(c p2p.Capabilities) {
+	s.capabilities = c
+}
+
+// SetNetworkAuth restricts the handshakes to the peers proving the knowledge
+// of the network key, if it is set, and to the allowlisted overlays, if there
+// are any, which makes the swarm private.
+func (s *Service) SetNetworkAuth(key []byte, allowlist []swarm.Address) {
+	s.networkKey = key
+	s.allowlist = nil
+	if len(allowlist) > 0 {
+		s.allowlist = make(map[string]struct{}, len(allowlist))
+		for _, addr := range allowlist {
+			s.allowlist[addr.ByteString()] = struct{}{}
+		}
+	}
+}
+
 // Handshake initiates a handshake with a peer.
 func (s *Service) Handshake(ctx context.Context, stream p2p.Stream, peerMultiaddr ma.Multiaddr, peerID libp2ppeer.ID) (i *Info, err error) {
 	loggerV1 := s.logger.V(1).Register()
@@ -180,6 +211,10 @@ func (s *Service) Handshake(ctx context.Context, stream p2p.Stream, peerMultiadd
 		return nil, ErrNetworkIDIncompatible
 	}
 
+	if err := s.checkNetworkAuth(resp.Ack, peerID); err != nil {
+		return nil, err
+	}
+
 	remoteBzzAddress, err := s.parseCheckAck(resp.Ack)
 	if err != nil {
 		return nil, err
@@ -196,6 +231,8 @@ func (s *Service) Handshake(ctx context.Context, stream p2p.Stream, peerMultiadd
 		NetworkID:      s.networkID,
 		FullNode:       s.fullNode,
 		Nonce:          s.nonce,
+		NetworkAuth:    s.networkAuth(s.libp2pID, peerID, bzzAddress.Overlay.Bytes()),
+		Capabilities:   uint64(s.capabilities),
 		WelcomeMessage: welcomeMessage,
 	}
 
@@ -209,8 +246,9 @@ func (s *Service) Handshake(ctx context.Context, stream p2p.Stream, peerMultiadd
 	}
 
 	return &Info{
-		BzzAddress: remoteBzzAddress,
-		FullNode:   resp.Ack.FullNode,
+		BzzAddress:   remoteBzzAddress,
+		FullNode:     resp.Ack.FullNode,
+		Capabilities: p2p.Capabilities(resp.Ack.Capabilities),
 	}, nil
 }
 
@@ -274,6 +312,8 @@ func (s *Service) Handle(ctx context.Context, stream p2p.Stream, remoteMultiaddr
 			NetworkID:      s.networkID,
 			FullNode:       s.fullNode,
 			Nonce:          s.nonce,
+			NetworkAuth:    s.networkAuth(s.libp2pID, remotePeerID, bzzAddress.Overlay.Bytes()),
+			Capabilities:   uint64(s.capabilities),
 			WelcomeMessage: welcomeMessage,
 		},
 	}); err != nil {
@@ -293,6 +333,10 @@ func (s *Service) Handle(ctx context.Context, stream p2p.Stream, remoteMultiaddr
 		return nil, ErrNetworkIDIncompatible
 	}
 
+	if err := s.checkNetworkAuth(&ack, remotePeerID); err != nil {
+		return nil, err
+	}
+
 	overlay := swarm.NewAddress(ack.Address.Overlay)
 
 	if s.picker != nil {
@@ -312,8 +356,9 @@ func (s *Service) Handle(ctx context.Context, stream p2p.Stream, remoteMultiaddr
 	}
 
 	return &Info{
-		BzzAddress: remoteBzzAddress,
-		FullNode:   ack.FullNode,
+		BzzAddress:   remoteBzzAddress,
+		FullNode:     ack.FullNode,
+		Capabilities: p2p.Capabilities(ack.Capabilities),
 	}, nil
 }
 
@@ -341,5 +386,39 @@ func (s *Service) parseCheckAck(ack *pb.Ack) (*bzz.Address, error) {
 		return nil, ErrInvalidAck
 	}
 
+	if s.allowlist != nil {
+		if _, ok := s.allowlist[bzzAddress.Overlay.ByteString()]; !ok {
+			s.metrics.NetworkAuthFailed.Inc()
+			return nil, ErrNetworkAuth
+		}
+	}
+
 	return bzzAddress, nil
 }
+
+// networkAuth returns the proof of the knowledge of the network key by the
+// sender of the ack, bound to the peer IDs of the connection and the overlay
+// of the sender, or nil if the network key is not set.
+func (s *Service) networkAuth(from, to libp2ppeer.ID, overlay []byte) []byte {
+	if s.networkKey == nil {
+		return nil
+	}
+	mac := hmac.New(sha256.New, s.networkKey)
+	_, _ = mac.Write([]byte(networkAuthDomain))
+	_, _ = mac.Write([]byte(from))
+	_, _ = mac.Write([]byte(to))
+	_, _ = mac.Write(overlay)
+	return mac.Sum(nil)
+}
+
+// checkNetworkAuth verifies the network key proof of the ack sent by the peer.
+func (s *Service) checkNetworkAuth(ack *pb.Ack, from libp2ppeer.ID) error {
+	if s.networkKey == nil {
+		return nil
+	}
+	if ack.Address == nil || !hmac.Equal(ack.NetworkAuth, s.networkAuth(from, s.libp2pID, ack.Address.Overlay)) {
+		s.metrics.NetworkAuthFailed.Inc()
+		return ErrNetworkAuth
+	}
+	return nil
+}
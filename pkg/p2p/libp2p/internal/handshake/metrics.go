@@ -17,6 +17,8 @@ type metrics struct {
 	SynAckTxFailed prometheus.Counter
 	AckRx          prometheus.Counter
 	AckRxFailed    prometheus.Counter
+
+	NetworkAuthFailed prometheus.Counter
 }
 
 // newMetrics is a convenient constructor for creating new metrics.
@@ -24,6 +26,12 @@ func newMetrics() metrics {
 	const subsystem = "handshake"
 
 	return metrics{
+		NetworkAuthFailed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "network_auth_failed",
+			Help:      "The number of handshakes with the peers failed to authenticate to the private swarm.",
+		}),
 		SynRx: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: m.Namespace,
 			Subsystem: subsystem,
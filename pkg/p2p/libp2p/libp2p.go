@@ -27,6 +27,8 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p/internal/breaker"
 	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p/internal/handshake"
 	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p/internal/reacher"
+	"github.com/ethersphere/bee/v2/pkg/p2p/peering"
+	"github.com/ethersphere/bee/v2/pkg/p2p/traffic"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/topology"
@@ -45,9 +47,12 @@ import (
 	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
 	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
 	lp2pswarm "github.com/libp2p/go-libp2p/p2p/net/swarm"
+	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/relay"
 	libp2pping "github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	quic "github.com/libp2p/go-libp2p/p2p/transport/quic"
 	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
 	ws "github.com/libp2p/go-libp2p/p2p/transport/websocket"
+	webtransport "github.com/libp2p/go-libp2p/p2p/transport/webtransport"
 
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/multiformats/go-multistream"
@@ -69,6 +74,10 @@ var (
 	// reachabilityOverridePublic overrides autonat to simply report
 	// public reachability status, it is set in the makefile.
 	reachabilityOverridePublic = "false"
+
+	// ErrNATAddrNotStatic is returned when the NAT address is changed
+	// on the node which was not started with the static NAT address.
+	ErrNATAddrNotStatic = errors.New("nat address not set at startup")
 )
 
 const (
@@ -109,6 +118,9 @@ type Service struct {
 	networkStatus     atomic.Int32
 	HeadersRWTimeout  time.Duration
 	autoNAT           autonat.AutoNAT
+	peering           *peering.Policy
+	traffic           *traffic.Meter
+	compression       bool
 }
 
 type lightnodes interface {
@@ -120,9 +132,35 @@ type lightnodes interface {
 }
 
 type Options struct {
-	PrivateKey       *ecdsa.PrivateKey
-	NATAddr          string
-	EnableWS         bool
+	PrivateKey *ecdsa.PrivateKey
+	NATAddr    string
+	EnableWS   bool
+	EnableQUIC bool
+	// EnableWebTransport enables the WebTransport transport which runs over QUIC.
+	EnableWebTransport bool
+	DialPreference     DialPreference
+	// EnableRelayService makes the publicly reachable node relay
+	// the connections of the unreachable ones.
+	EnableRelayService bool
+	// EnableRelayClient makes the unreachable node reserve a slot on the
+	// relays and advertise the relayed address. The StaticRelays are used
+	// if set, otherwise the connected full nodes are the relay candidates.
+	EnableRelayClient bool
+	StaticRelays      []string
+	// NetworkKey is the pre-shared secret of the private swarm which the
+	// peers prove the knowledge of in the handshake; the peers outside
+	// of the NetworkAllowlist are rejected if it is not empty.
+	NetworkKey       []byte
+	NetworkAllowlist []swarm.Address
+	// Capabilities are advertised to the peers in the handshake in addition
+	// to the ones of the enabled features of the service.
+	Capabilities p2p.Capabilities
+	// EnableCompression enables the compression of the compressible
+	// protocol streams with the peers supporting it.
+	EnableCompression bool
+	// Traffic accounts the traffic of the protocols; a meter
+	// without the monthly cap is used if not set.
+	Traffic          *traffic.Meter
 	FullNode         bool
 	LightNodeLimit   int
 	WelcomeMessage   string
@@ -131,6 +169,7 @@ type Options struct {
 	hostFactory      func(...libp2p.Option) (host.Host, error)
 	HeadersRWTimeout time.Duration
 	Registry         *prometheus.Registry
+	Peering          *peering.Policy
 }
 
 func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay swarm.Address, addr string, ab addressbook.Putter, storer storage.StateStorer, lightNodes *lightnode.Container, logger log.Logger, tracer *tracing.Tracer, o Options) (*Service, error) {
@@ -159,6 +198,12 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		if o.EnableWS {
 			listenAddrs = append(listenAddrs, fmt.Sprintf("/ip4/%s/tcp/%s/ws", ip4Addr, port))
 		}
+		if o.EnableQUIC {
+			listenAddrs = append(listenAddrs, fmt.Sprintf("/ip4/%s/udp/%s/quic-v1", ip4Addr, port))
+		}
+		if o.EnableWebTransport {
+			listenAddrs = append(listenAddrs, fmt.Sprintf("/ip4/%s/udp/%s/quic-v1/webtransport", ip4Addr, port))
+		}
 	}
 
 	if ip6Addr != "" {
@@ -166,6 +211,12 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		if o.EnableWS {
 			listenAddrs = append(listenAddrs, fmt.Sprintf("/ip6/%s/tcp/%s/ws", ip6Addr, port))
 		}
+		if o.EnableQUIC {
+			listenAddrs = append(listenAddrs, fmt.Sprintf("/ip6/%s/udp/%s/quic-v1", ip6Addr, port))
+		}
+		if o.EnableWebTransport {
+			listenAddrs = append(listenAddrs, fmt.Sprintf("/ip6/%s/udp/%s/quic-v1/webtransport", ip6Addr, port))
+		}
 	}
 
 	security := libp2p.DefaultSecurity
@@ -213,6 +264,8 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 
 	var natManager basichost.NATManager
 
+	peerRegistry := newPeerRegistry()
+
 	opts := []libp2p.Option{
 		libp2p.ShareTCPListener(),
 		libp2p.ListenAddrStrings(listenAddrs...),
@@ -250,8 +303,41 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		transports = append(transports, libp2p.Transport(ws.New))
 	}
 
+	if o.EnableQUIC {
+		transports = append(transports, libp2p.Transport(quic.NewTransport))
+	}
+
+	if o.EnableWebTransport {
+		transports = append(transports, libp2p.Transport(webtransport.New))
+	}
+
+	if ranker := dialRanker(o.DialPreference); ranker != nil {
+		transports = append(transports, libp2p.SwarmOpts(lp2pswarm.WithDialRanker(ranker)))
+	}
+
 	opts = append(opts, transports...)
 
+	if o.EnableRelayService {
+		relayOpts := []relay.Option{relay.WithResources(relayResources())}
+		if o.Registry != nil {
+			relayOpts = append(relayOpts, relay.WithMetricsTracer(relay.NewMetricsTracer(relay.WithRegisterer(o.Registry))))
+		}
+		opts = append(opts, libp2p.EnableRelayService(relayOpts...))
+	}
+
+	if o.EnableRelayClient {
+		staticRelays, err := parseStaticRelays(o.StaticRelays)
+		if err != nil {
+			return nil, fmt.Errorf("static relays: %w", err)
+		}
+		if len(staticRelays) > 0 {
+			opts = append(opts, libp2p.EnableAutoRelayWithStaticRelays(staticRelays))
+		} else {
+			opts = append(opts, libp2p.EnableAutoRelayWithPeerSource(relayPeerSource(peerRegistry, libp2pPeerstore)))
+		}
+		opts = append(opts, libp2p.EnableHolePunching())
+	}
+
 	if o.hostFactory == nil {
 		// Use the default libp2p host creation
 		o.hostFactory = libp2p.New
@@ -305,10 +391,24 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		advertisableAddresser = natAddrResolver
 	}
 
+	if o.EnableRelayClient {
+		advertisableAddresser = &relayAddressResolver{
+			AdvertisableAddressResolver: advertisableAddresser,
+			host:                        h,
+			reachability:                autoNAT.Status,
+		}
+	}
+
 	handshakeService, err := handshake.New(signer, advertisableAddresser, overlay, networkID, o.FullNode, o.Nonce, o.WelcomeMessage, o.ValidateOverlay, h.ID(), logger)
 	if err != nil {
 		return nil, fmt.Errorf("handshake service: %w", err)
 	}
+	handshakeService.SetNetworkAuth(o.NetworkKey, o.NetworkAllowlist)
+	capabilities := o.Capabilities
+	if o.EnableCompression {
+		capabilities |= p2p.CapabilityCompression
+	}
+	handshakeService.SetCapabilities(capabilities)
 
 	// Create a new dialer for libp2p ping protocol. This ensures that the protocol
 	// uses a different set of keys to do ping. It prevents inconsistencies in peerstore as
@@ -320,7 +420,6 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		return nil, err
 	}
 
-	peerRegistry := newPeerRegistry()
 	s := &Service{
 		ctx:               ctx,
 		host:              h,
@@ -343,6 +442,13 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		lightNodes:        lightNodes,
 		HeadersRWTimeout:  o.HeadersRWTimeout,
 		autoNAT:           autoNAT,
+		peering:           o.Peering,
+		traffic:           o.Traffic,
+		compression:       o.EnableCompression,
+	}
+
+	if s.traffic == nil {
+		s.traffic = traffic.NewMeter(traffic.Options{}, logger)
 	}
 
 	peerRegistry.setDisconnecter(s)
@@ -438,7 +544,14 @@ func (s *Service) handleIncoming(stream network.Stream) {
 		return
 	}
 
-	if exists := s.peers.addIfNotExists(stream.Conn(), overlay, i.FullNode); exists {
+	if s.denied(overlay, stream.Conn().RemoteMultiaddr()) {
+		s.logger.Debug("stream handler: denied connection from peer", "peer_address", overlay)
+		_ = handshakeStream.Reset()
+		_ = s.host.Network().ClosePeer(peerID)
+		return
+	}
+
+	if exists := s.peers.addIfNotExists(stream.Conn(), overlay, i.FullNode, i.Capabilities); exists {
 		s.logger.Debug("stream handler: peer already exists", "peer_address", overlay)
 		if err = handshakeStream.FullClose(); err != nil {
 			s.logger.Debug("stream handler: could not close stream", "peer_address", overlay, "error", err)
@@ -563,6 +676,11 @@ func (s *Service) AddProtocol(p p2p.ProtocolSpec) (err error) {
 			return fmt.Errorf("protocol version match %s: %w", id, err)
 		}
 
+		headler := ss.Headler
+		if s.compression && ss.Compressible {
+			headler = compressionHeadler(ss.Headler)
+		}
+
 		s.host.SetStreamHandlerMatch(id, matcher, func(streamlibp2p network.Stream) {
 			peerID := streamlibp2p.Conn().RemotePeer()
 			overlay, found := s.peers.overlay(peerID)
@@ -577,18 +695,24 @@ func (s *Service) AddProtocol(p p2p.ProtocolSpec) (err error) {
 				s.logger.Debug("fullnode info for peer not found", "peer_id", peerID)
 				return
 			}
+			if err := s.traffic.Allow(p.Name); err != nil {
+				_ = streamlibp2p.Reset()
+				s.logger.Debug("handle protocol: stream refused", "protocol", p.Name, "peer", overlay, "error", err)
+				return
+			}
 
-			stream := newStream(streamlibp2p, s.metrics)
+			stream := newStream(s.newMeteredStream(streamlibp2p, p.Name, overlay), s.metrics)
 
 			// exchange headers
 			headersStartTime := time.Now()
 			ctx, cancel := context.WithTimeout(s.ctx, s.HeadersRWTimeout)
 			defer cancel()
-			if err := handleHeaders(ctx, ss.Headler, stream, overlay); err != nil {
+			if err := handleHeaders(ctx, headler, stream, overlay); err != nil {
 				s.logger.Debug("handle protocol: handle headers failed", "protocol", p.Name, "version", p.Version, "stream", ss.Name, "peer", overlay, "error", err)
 				_ = stream.Reset()
 				return
 			}
+			s.compress(stream, stream.responseHeaders)
 			s.metrics.HeadersExchangeDuration.Observe(time.Since(headersStartTime).Seconds())
 
 			ctx, cancel = context.WithCancel(s.ctx)
@@ -630,6 +754,11 @@ func (s *Service) AddProtocol(p p2p.ProtocolSpec) (err error) {
 				if errors.Is(err, p2p.ErrUnexpected) {
 					s.metrics.UnexpectedProtocolReqCount.Inc()
 				}
+				if de != nil || bpe != nil || errors.Is(err, p2p.ErrUnexpected) {
+					if n, ok := s.notifier.(p2p.ProtocolErrorNotifier); ok {
+						n.RecordProtocolError(overlay)
+					}
+				}
 				if errors.Is(err, network.ErrReset) {
 					s.metrics.StreamHandlerErrResetCount.Inc()
 				}
@@ -645,6 +774,18 @@ func (s *Service) AddProtocol(p p2p.ProtocolSpec) (err error) {
 	return nil
 }
 
+// denied reports whether the peering policy denies the connection with the
+// overlay or the underlay; either of them is not checked if not provided.
+func (s *Service) denied(overlay swarm.Address, underlay ma.Multiaddr) bool {
+	if s.peering == nil {
+		return false
+	}
+	if !overlay.IsZero() && s.peering.DeniedOverlay(overlay) {
+		return true
+	}
+	return underlay != nil && s.peering.DeniedMultiaddr(underlay)
+}
+
 func (s *Service) Addresses() (addresses []ma.Multiaddr, err error) {
 	for _, addr := range s.host.Addrs() {
 		a, err := buildUnderlayAddress(addr, s.host.ID())
@@ -665,6 +806,19 @@ func (s *Service) Addresses() (addresses []ma.Multiaddr, err error) {
 	return addresses, nil
 }
 
+// PeerCapabilities returns the capabilities advertised by the connected peer.
+func (s *Service) PeerCapabilities(overlay swarm.Address) (p2p.Capabilities, error) {
+	peerID, found := s.peers.peerID(overlay)
+	if !found {
+		return 0, p2p.ErrPeerNotFound
+	}
+	caps, found := s.peers.capabilities(peerID)
+	if !found {
+		return 0, p2p.ErrPeerNotFound
+	}
+	return caps, nil
+}
+
 func (s *Service) NATManager() basichost.NATManager {
 	return s.natManager
 }
@@ -691,6 +845,10 @@ func (s *Service) Blocklist(overlay swarm.Address, duration time.Duration, reaso
 	}
 	s.metrics.BlocklistedPeerCount.Inc()
 
+	if n, ok := s.notifier.(p2p.BlocklistNotifier); ok {
+		n.PeerBlocklisted(overlay, duration, reason)
+	}
+
 	_ = s.Disconnect(overlay, reason)
 	return nil
 }
@@ -729,6 +887,10 @@ func (s *Service) Connect(ctx context.Context, addr ma.Multiaddr) (address *bzz.
 
 	remoteAddr := addr.Decapsulate(hostAddr)
 
+	if s.denied(swarm.ZeroAddress, remoteAddr) {
+		return nil, p2p.ErrPeerDenied
+	}
+
 	if overlay, found := s.peers.isConnected(info.ID, remoteAddr); found {
 		address = &bzz.Address{
 			Overlay:  overlay,
@@ -783,7 +945,13 @@ func (s *Service) Connect(ctx context.Context, addr ma.Multiaddr) (address *bzz.
 		return nil, p2p.ErrPeerBlocklisted
 	}
 
-	if exists := s.peers.addIfNotExists(stream.Conn(), overlay, i.FullNode); exists {
+	if s.denied(overlay, nil) {
+		_ = handshakeStream.Reset()
+		_ = s.host.Network().ClosePeer(info.ID)
+		return nil, p2p.ErrPeerDenied
+	}
+
+	if exists := s.peers.addIfNotExists(stream.Conn(), overlay, i.FullNode, i.Capabilities); exists {
 		if err := handshakeStream.FullClose(); err != nil {
 			_ = s.Disconnect(overlay, "failed closing handshake stream after connect")
 			return nil, fmt.Errorf("peer exists, full close: %w", err)
@@ -921,6 +1089,40 @@ func (s *Service) BlocklistedPeers() ([]p2p.BlockListedPeer, error) {
 	return s.blocklist.Peers()
 }
 
+// AddBlocklist implements the p2p.BlocklistManager interface.
+func (s *Service) AddBlocklist(overlay swarm.Address, duration time.Duration, reason string) error {
+	var full bool
+	if id, ok := s.peers.peerID(overlay); ok {
+		full, _ = s.peers.fullnode(id)
+	}
+
+	s.logger.Debug("libp2p blocklisting peer on demand", "peer_address", overlay, "duration", duration, "reason", reason)
+	if err := s.blocklist.Set(overlay, duration, reason, full); err != nil {
+		s.metrics.BlocklistedPeerErrCount.Inc()
+		return fmt.Errorf("blocklist peer %s: %w", overlay, err)
+	}
+	s.metrics.BlocklistedPeerCount.Inc()
+
+	if n, ok := s.notifier.(p2p.BlocklistNotifier); ok {
+		n.PeerBlocklisted(overlay, duration, reason)
+	}
+
+	_ = s.Disconnect(overlay, reason)
+	return nil
+}
+
+// RemoveBlocklist implements the p2p.BlocklistManager interface.
+func (s *Service) RemoveBlocklist(overlay swarm.Address) error {
+	if err := s.blocklist.Remove(overlay); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return p2p.ErrPeerNotFound
+		}
+		return fmt.Errorf("remove peer %s from blocklist: %w", overlay, err)
+	}
+	s.logger.Debug("libp2p peer removed from blocklist", "peer_address", overlay)
+	return nil
+}
+
 func (s *Service) NewStream(ctx context.Context, overlay swarm.Address, headers p2p.Headers, protocolName, protocolVersion, streamName string) (p2p.Stream, error) {
 	select {
 	case <-ctx.Done():
@@ -933,12 +1135,16 @@ func (s *Service) NewStream(ctx context.Context, overlay swarm.Address, headers
 		return nil, p2p.ErrPeerNotFound
 	}
 
+	if err := s.traffic.Allow(protocolName); err != nil {
+		return nil, err
+	}
+
 	streamlibp2p, err := s.newStreamForPeerID(ctx, peerID, protocolName, protocolVersion, streamName)
 	if err != nil {
 		return nil, fmt.Errorf("new stream for peerid: %w", err)
 	}
 
-	stream := newStream(streamlibp2p, s.metrics)
+	stream := newStream(s.newMeteredStream(streamlibp2p, protocolName, overlay), s.metrics)
 
 	// tracing: add span context header
 	if headers == nil {
@@ -949,6 +1155,11 @@ func (s *Service) NewStream(ctx context.Context, overlay swarm.Address, headers
 		return nil, fmt.Errorf("new stream add context header fail: %w", err)
 	}
 
+	compressible := s.compressible(protocolName, streamName) && s.peerCapable(peerID, p2p.CapabilityCompression)
+	if compressible {
+		offerCompression(headers)
+	}
+
 	// exchange headers
 	ctx, cancel := context.WithTimeout(ctx, s.HeadersRWTimeout)
 	defer cancel()
@@ -957,11 +1168,17 @@ func (s *Service) NewStream(ctx context.Context, overlay swarm.Address, headers
 		return nil, fmt.Errorf("send headers: %w", err)
 	}
 
+	if compressible {
+		s.compress(stream, stream.headers)
+	}
+
 	return stream, nil
 }
 
 func (s *Service) newStreamForPeerID(ctx context.Context, peerID libp2ppeer.ID, protocolName, protocolVersion, streamName string) (network.Stream, error) {
 	swarmStreamName := p2p.NewSwarmStreamName(protocolName, protocolVersion, streamName)
+	// the relayed connections are used the same way as the direct ones
+	ctx = network.WithAllowLimitedConn(ctx, swarmStreamName)
 	st, err := s.host.NewStream(ctx, peerID, protocol.ID(swarmStreamName))
 	if err != nil {
 		if st != nil {
@@ -1020,6 +1237,20 @@ func (s *Service) GetWelcomeMessage() string {
 	return s.handshakeService.GetWelcomeMessage()
 }
 
+// SetNATAddr replaces the static NAT address advertised to the peers. The
+// connected peers learn the new address on the next handshake. The address
+// can be changed only if the node was started with the static NAT address,
+// the switching from and to the NAT port mapping requires the restart.
+func (s *Service) SetNATAddr(addr string) error {
+	if s.natAddrResolver == nil || addr == "" {
+		return ErrNATAddrNotStatic
+	}
+	if err := s.natAddrResolver.set(addr); err != nil {
+		return fmt.Errorf("static nat: %w", err)
+	}
+	return nil
+}
+
 func (s *Service) Ready() error {
 	if err := s.reachabilityWorker(); err != nil {
 		return fmt.Errorf("reachability worker: %w", err)
@@ -1146,8 +1377,11 @@ type connectionNotifier struct {
 	network.Notifiee
 }
 
-func (c *connectionNotifier) Connected(_ network.Network, _ network.Conn) {
+func (c *connectionNotifier) Connected(_ network.Network, conn network.Conn) {
 	c.metrics.HandledConnectionCount.Inc()
+	if isRelayedAddr(conn.RemoteMultiaddr()) {
+		c.metrics.RelayedConnectionCount.Inc()
+	}
 }
 
 // isNetworkOrHostUnreachableError determines based on the
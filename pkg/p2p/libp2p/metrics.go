@@ -15,6 +15,8 @@ type metrics struct {
 	// using reflection
 	CreatedConnectionCount     prometheus.Counter
 	HandledConnectionCount     prometheus.Counter
+	RelayedConnectionCount     prometheus.Counter
+	CompressedStreamCount      prometheus.Counter
 	CreatedStreamCount         prometheus.Counter
 	ClosedStreamCount          prometheus.Counter
 	StreamResetCount           prometheus.Counter
@@ -45,6 +47,18 @@ func newMetrics() metrics {
 			Name:      "handled_connection_count",
 			Help:      "Number of handled incoming libp2p connections.",
 		}),
+		RelayedConnectionCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "relayed_connection_count",
+			Help:      "Number of libp2p connections established through a circuit relay.",
+		}),
+		CompressedStreamCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "compressed_stream_count",
+			Help:      "Number of streams with the negotiated compression.",
+		}),
 		CreatedStreamCount: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: m.Namespace,
 			Subsystem: subsystem,
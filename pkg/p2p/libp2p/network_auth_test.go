@@ -0,0 +1,77 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestNetworkAuth(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("private swarm secret")
+
+	for _, tc := range []struct {
+		name          string
+		serverKey     []byte
+		clientKey     []byte
+		allowlist     func(client swarm.Address) []swarm.Address
+		wantConnected bool
+	}{
+		{name: "same key", serverKey: key, clientKey: key, wantConnected: true},
+		{name: "different key", serverKey: key, clientKey: []byte("other secret")},
+		{name: "missing key", serverKey: key},
+		{name: "public server", clientKey: key},
+		{
+			name:          "allowlisted",
+			serverKey:     key,
+			clientKey:     key,
+			allowlist:     func(client swarm.Address) []swarm.Address { return []swarm.Address{client} },
+			wantConnected: true,
+		},
+		{
+			name:      "not allowlisted",
+			serverKey: key,
+			clientKey: key,
+			allowlist: func(swarm.Address) []swarm.Address { return []swarm.Address{swarm.RandAddress(t)} },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			s2, overlay2 := newService(t, 1, libp2pServiceOpts{libp2pOpts: libp2p.Options{
+				NetworkKey: tc.clientKey,
+			}})
+
+			serverOpts := libp2p.Options{FullNode: true, NetworkKey: tc.serverKey}
+			if tc.allowlist != nil {
+				serverOpts.NetworkAllowlist = tc.allowlist(overlay2)
+			}
+			s1, overlay1 := newService(t, 1, libp2pServiceOpts{libp2pOpts: serverOpts})
+
+			_, err := s2.Connect(ctx, serviceUnderlayAddress(t, s1))
+			if tc.wantConnected {
+				if err != nil {
+					t.Fatal(err)
+				}
+				expectPeers(t, s2, overlay1)
+				expectPeersEventually(t, s1, overlay2)
+				return
+			}
+			if err == nil {
+				t.Fatal("want connection rejected")
+			}
+			expectPeers(t, s2)
+			expectPeersEventually(t, s1)
+		})
+	}
+}
@@ -21,6 +21,7 @@ type peerRegistry struct {
 	underlays   map[string]libp2ppeer.ID                    // map overlay address to underlay peer id
 	overlays    map[libp2ppeer.ID]swarm.Address             // map underlay peer id to overlay address
 	full        map[libp2ppeer.ID]bool                      // map to track whether a node is full or light node (true=full)
+	caps        map[libp2ppeer.ID]p2p.Capabilities          // capabilities advertised by the peer in the handshake
 	connections map[libp2ppeer.ID]map[network.Conn]struct{} // list of connections for safe removal on Disconnect notification
 	streams     map[libp2ppeer.ID]map[network.Stream]context.CancelFunc
 	mu          sync.RWMutex
@@ -39,6 +40,7 @@ func newPeerRegistry() *peerRegistry {
 		underlays:   make(map[string]libp2ppeer.ID),
 		overlays:    make(map[libp2ppeer.ID]swarm.Address),
 		full:        make(map[libp2ppeer.ID]bool),
+		caps:        make(map[libp2ppeer.ID]p2p.Capabilities),
 		connections: make(map[libp2ppeer.ID]map[network.Conn]struct{}),
 		streams:     make(map[libp2ppeer.ID]map[network.Stream]context.CancelFunc),
 
@@ -81,6 +83,7 @@ func (r *peerRegistry) Disconnected(_ network.Network, c network.Conn) {
 	}
 	delete(r.streams, peerID)
 	delete(r.full, peerID)
+	delete(r.caps, peerID)
 	r.mu.Unlock()
 	r.disconnecter.disconnected(overlay)
 
@@ -131,7 +134,24 @@ func (r *peerRegistry) peers() []p2p.Peer {
 	return peers
 }
 
-func (r *peerRegistry) addIfNotExists(c network.Conn, overlay swarm.Address, full bool) (exists bool) {
+// fullNodes returns the peer ids of up to limit connected full nodes.
+func (r *peerRegistry) fullNodes(limit int) []libp2ppeer.ID {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]libp2ppeer.ID, 0, limit)
+	for id, full := range r.full {
+		if len(ids) == limit {
+			break
+		}
+		if full {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (r *peerRegistry) addIfNotExists(c network.Conn, overlay swarm.Address, full bool, caps p2p.Capabilities) (exists bool) {
 	peerID := c.RemotePeer()
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -151,6 +171,7 @@ func (r *peerRegistry) addIfNotExists(c network.Conn, overlay swarm.Address, ful
 	r.underlays[overlay.ByteString()] = peerID
 	r.overlays[peerID] = overlay
 	r.full[peerID] = full
+	r.caps[peerID] = caps
 	return false
 
 }
@@ -176,6 +197,13 @@ func (r *peerRegistry) fullnode(peerID libp2ppeer.ID) (bool, bool) {
 	return full, found
 }
 
+func (r *peerRegistry) capabilities(peerID libp2ppeer.ID) (p2p.Capabilities, bool) {
+	r.mu.RLock()
+	caps, found := r.caps[peerID]
+	r.mu.RUnlock()
+	return caps, found
+}
+
 func (r *peerRegistry) isConnected(peerID libp2ppeer.ID, remoteAddr ma.Multiaddr) (swarm.Address, bool) {
 	if remoteAddr == nil {
 		return swarm.ZeroAddress, false
@@ -217,6 +245,7 @@ func (r *peerRegistry) remove(overlay swarm.Address) (found, full bool, peerID l
 	delete(r.streams, peerID)
 	full = r.full[peerID]
 	delete(r.full, peerID)
+	delete(r.caps, peerID)
 	r.mu.Unlock()
 
 	return found, full, peerID
@@ -0,0 +1,80 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p
+
+import (
+	"context"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p/internal/handshake"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/p2p/host/autorelay"
+	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/relay"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// relayResources returns the resources of the relay service. The relayed
+// connections are not limited in duration nor in the transferred data, as
+// the swarm protocols exceed the default limits meant for the hole punching.
+func relayResources() relay.Resources {
+	rc := relay.DefaultResources()
+	rc.Limit = nil
+	return rc
+}
+
+// relayPeerSource returns the autorelay peer source offering
+// the connected full nodes as the relay candidates.
+func relayPeerSource(peers *peerRegistry, ps peerstore.Peerstore) autorelay.PeerSource {
+	return func(_ context.Context, num int) <-chan libp2ppeer.AddrInfo {
+		ids := peers.fullNodes(num)
+		c := make(chan libp2ppeer.AddrInfo, len(ids))
+		for _, id := range ids {
+			c <- ps.PeerInfo(id)
+		}
+		close(c)
+		return c
+	}
+}
+
+// parseStaticRelays parses the multiaddresses of the static relays.
+func parseStaticRelays(addrs []string) ([]libp2ppeer.AddrInfo, error) {
+	infos := make([]libp2ppeer.AddrInfo, 0, len(addrs))
+	for _, a := range addrs {
+		info, err := libp2ppeer.AddrInfoFromString(a)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, *info)
+	}
+	return infos, nil
+}
+
+// isRelayedAddr reports whether the multiaddress is a circuit relay address.
+func isRelayedAddr(addr ma.Multiaddr) bool {
+	_, err := addr.ValueForProtocol(ma.P_CIRCUIT)
+	return err == nil
+}
+
+// relayAddressResolver advertises the relayed address of the node when it
+// is not publicly reachable and holds a relay reservation, otherwise it
+// falls back to the wrapped resolver.
+type relayAddressResolver struct {
+	handshake.AdvertisableAddressResolver
+	host         host.Host
+	reachability func() network.Reachability
+}
+
+func (r *relayAddressResolver) Resolve(observedAddress ma.Multiaddr) (ma.Multiaddr, error) {
+	if r.reachability() == network.ReachabilityPrivate {
+		for _, addr := range r.host.Addrs() {
+			if isRelayedAddr(addr) {
+				return buildUnderlayAddress(addr, r.host.ID())
+			}
+		}
+	}
+	return r.AdvertisableAddressResolver.Resolve(observedAddress)
+}
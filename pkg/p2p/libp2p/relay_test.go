@@ -0,0 +1,111 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+type relayedHost struct {
+	host.Host
+	id    libp2ppeer.ID
+	addrs []ma.Multiaddr
+}
+
+func (h *relayedHost) ID() libp2ppeer.ID     { return h.id }
+func (h *relayedHost) Addrs() []ma.Multiaddr { return h.addrs }
+
+func TestRelayAddressResolver(t *testing.T) {
+	t.Parallel()
+
+	const (
+		relayID = "16Uiu2HAkyyGKpjBiCkVqCKoJa6RzzZw9Nr7hGogsMPcdad1KyMmd"
+		hostID  = "16Uiu2HAm8sHcAVczrjFFrQJVyHAbHm3kZcUnTWkm3UGxvNzGs8AE"
+	)
+
+	id, err := libp2ppeer.Decode(hostID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &relayedHost{
+		id: id,
+		addrs: []ma.Multiaddr{
+			ma.StringCast("/ip4/10.0.0.2/tcp/1634"),
+			ma.StringCast("/ip4/1.2.3.4/tcp/1634/p2p/" + relayID + "/p2p-circuit"),
+		},
+	}
+
+	static, err := libp2p.NewStaticAddressResolver(":30123", net.LookupIP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	observed := ma.StringCast("/ip4/5.6.7.8/tcp/7071/p2p/" + hostID)
+
+	for _, tc := range []struct {
+		reachability network.Reachability
+		want         string
+	}{
+		{network.ReachabilityPrivate, "/ip4/1.2.3.4/tcp/1634/p2p/" + relayID + "/p2p-circuit/p2p/" + hostID},
+		{network.ReachabilityPublic, "/ip4/5.6.7.8/tcp/30123/p2p/" + hostID},
+	} {
+		r := libp2p.NewRelayAddressResolver(static, h, func() network.Reachability { return tc.reachability })
+		have, err := r.Resolve(observed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.String() != tc.want {
+			t.Fatalf("reachability %s: want %s, have %s", tc.reachability, tc.want, have)
+		}
+	}
+}
+
+func TestParseStaticRelays(t *testing.T) {
+	t.Parallel()
+
+	infos, err := libp2p.ParseStaticRelays([]string{"/ip4/1.2.3.4/tcp/1634/p2p/16Uiu2HAkyyGKpjBiCkVqCKoJa6RzzZw9Nr7hGogsMPcdad1KyMmd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 || len(infos[0].Addrs) != 1 {
+		t.Fatalf("unexpected relays %v", infos)
+	}
+
+	if _, err := libp2p.ParseStaticRelays([]string{"/ip4/1.2.3.4/tcp/1634"}); err == nil {
+		t.Fatal("want error for the relay address without the peer id")
+	}
+}
+
+func TestConnectWithEnabledRelay(t *testing.T) {
+	t.Parallel()
+
+	s1, overlay1 := newService(t, 1, libp2pServiceOpts{
+		libp2pOpts: libp2p.Options{
+			EnableRelayService: true,
+			FullNode:           true,
+		},
+	})
+
+	s2, overlay2 := newService(t, 1, libp2pServiceOpts{
+		libp2pOpts: libp2p.Options{
+			EnableRelayClient: true,
+			FullNode:          true,
+		},
+	})
+
+	if _, err := s2.Connect(context.Background(), serviceUnderlayAddress(t, s1)); err != nil {
+		t.Fatal(err)
+	}
+
+	expectPeers(t, s2, overlay1)
+	expectPeersEventually(t, s1, overlay2)
+}
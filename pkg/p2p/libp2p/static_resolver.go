@@ -9,34 +9,48 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"sync"
 
 	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 )
 
 type staticAddressResolver struct {
+	mu         sync.RWMutex
 	multiProto string
 	port       string
+	lookupIP   func(host string) ([]net.IP, error)
 }
 
 func newStaticAddressResolver(addr string, lookupIP func(host string) ([]net.IP, error)) (*staticAddressResolver, error) {
+	r := &staticAddressResolver{lookupIP: lookupIP}
+	if err := r.set(addr); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// set replaces the address which the observed addresses are resolved to.
+func (r *staticAddressResolver) set(addr string) error {
 	host, port, err := net.SplitHostPort(addr)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	var multiProto string
 	if host != "" {
-		multiProto, err = getMultiProto(host, lookupIP)
+		multiProto, err = getMultiProto(host, r.lookupIP)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return &staticAddressResolver{
-		multiProto: multiProto,
-		port:       port,
-	}, nil
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.multiProto = multiProto
+	r.port = port
+	return nil
 }
 
 func (r *staticAddressResolver) Resolve(observedAddress ma.Multiaddr) (ma.Multiaddr, error) {
@@ -56,20 +70,22 @@ func (r *staticAddressResolver) Resolve(observedAddress ma.Multiaddr) (ma.Multia
 		return observedAddress, nil
 	}
 
-	var multiProto string
-	if r.multiProto != "" {
-		multiProto = r.multiProto
-	} else {
+	r.mu.RLock()
+	multiProto, port := r.multiProto, r.port
+	r.mu.RUnlock()
+
+	if multiProto == "" {
 		multiProto = strings.Join(observedAddrSplit[:3], "/")
 	}
-
-	var port string
-	if r.port != "" {
-		port = r.port
-	} else {
+	if port == "" {
 		port = observedAddrSplit[4]
 	}
-	a, err := ma.NewMultiaddr(multiProto + "/" + observedAddrSplit[3] + "/" + port)
+	addr := multiProto + "/" + observedAddrSplit[3] + "/" + port
+	// the bare udp address is not dialable, keep the quic and webtransport components
+	if observedAddrSplit[3] == "udp" && len(observedAddrSplit) > 5 {
+		addr += "/" + strings.Join(observedAddrSplit[5:], "/")
+	}
+	a, err := ma.NewMultiaddr(addr)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +81,12 @@ func TestStaticAddressResolver(t *testing.T) {
 			observableAddress: "/ip4/127.0.0.1/tcp/7071/p2p/16Uiu2HAkyyGKpjBiCkVqCKoJa6RzzZw9Nr7hGogsMPcdad1KyMmd",
 			want:              "/dns/ipv4and6.com/tcp/30777/p2p/16Uiu2HAkyyGKpjBiCkVqCKoJa6RzzZw9Nr7hGogsMPcdad1KyMmd",
 		},
+		{
+			name:              "replace ip and port of quic address",
+			natAddr:           "192.168.1.34:30777",
+			observableAddress: "/ip4/127.0.0.1/udp/7071/quic-v1/p2p/16Uiu2HAkyyGKpjBiCkVqCKoJa6RzzZw9Nr7hGogsMPcdad1KyMmd",
+			want:              "/ip4/192.168.1.34/udp/30777/quic-v1/p2p/16Uiu2HAkyyGKpjBiCkVqCKoJa6RzzZw9Nr7hGogsMPcdad1KyMmd",
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
@@ -115,3 +121,38 @@ func TestStaticAddressResolver(t *testing.T) {
 		})
 	}
 }
+
+func TestStaticAddressResolverSet(t *testing.T) {
+	t.Parallel()
+
+	r, err := libp2p.NewStaticAddressResolver("192.168.1.34:30123", net.LookupIP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	observableAddress, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/7071/p2p/16Uiu2HAkyyGKpjBiCkVqCKoJa6RzzZw9Nr7hGogsMPcdad1KyMmd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Set("10.0.0.1:"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.Resolve(observableAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/ip4/10.0.0.1/tcp/7071/p2p/16Uiu2HAkyyGKpjBiCkVqCKoJa6RzzZw9Nr7hGogsMPcdad1KyMmd"; got.String() != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if err := r.Set("invalid"); err == nil {
+		t.Fatal("expected error for invalid address")
+	}
+	got, err = r.Resolve(observableAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/ip4/10.0.0.1/tcp/7071/p2p/16Uiu2HAkyyGKpjBiCkVqCKoJa6RzzZw9Nr7hGogsMPcdad1KyMmd"; got.String() != want {
+		t.Errorf("got %s after invalid address, want %s", got, want)
+	}
+}
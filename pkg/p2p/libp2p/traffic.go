@@ -0,0 +1,51 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p
+
+import (
+	"context"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p/traffic"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/libp2p/go-libp2p/core/network"
+)
+
+// meteredStream accounts the traffic of the protocol stream
+// and throttles it when the traffic nears the monthly cap.
+type meteredStream struct {
+	network.Stream
+	ctx      context.Context
+	meter    *traffic.Meter
+	protocol string
+	peer     swarm.Address
+}
+
+func (s *Service) newMeteredStream(stream network.Stream, protocol string, peer swarm.Address) network.Stream {
+	return &meteredStream{
+		Stream:   stream,
+		ctx:      s.ctx,
+		meter:    s.traffic,
+		protocol: protocol,
+		peer:     peer,
+	}
+}
+
+func (s *meteredStream) Read(b []byte) (int, error) {
+	n, err := s.Stream.Read(b)
+	s.meter.Record(s.protocol, s.peer, n, 0)
+	if werr := s.meter.Wait(s.ctx, s.protocol, n); werr != nil && err == nil {
+		err = werr
+	}
+	return n, err
+}
+
+func (s *meteredStream) Write(b []byte) (int, error) {
+	if err := s.meter.Wait(s.ctx, s.protocol, len(b)); err != nil {
+		return 0, err
+	}
+	n, err := s.Stream.Write(b)
+	s.meter.Record(s.protocol, s.peer, 0, n)
+	return n, err
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// DialPreference decides which transport is dialed first
+// when a peer is reachable over more than one.
+type DialPreference string
+
+const (
+	// DialPreferenceAuto leaves the ordering of the dials to libp2p.
+	DialPreferenceAuto DialPreference = ""
+	// DialPreferenceTCP dials the TCP addresses first.
+	DialPreferenceTCP DialPreference = "tcp"
+	// DialPreferenceQUIC dials the QUIC addresses first.
+	DialPreferenceQUIC DialPreference = "quic"
+)
+
+// fallbackDialDelay is the delay of dialing the addresses
+// of the transports which are not preferred.
+const fallbackDialDelay = 300 * time.Millisecond
+
+// ErrInvalidDialPreference is returned for an unknown dial preference.
+var ErrInvalidDialPreference = errors.New("invalid dial preference")
+
+// ParseDialPreference parses the name of the dial preference.
+func ParseDialPreference(s string) (DialPreference, error) {
+	switch p := DialPreference(s); p {
+	case DialPreferenceAuto, DialPreferenceTCP, DialPreferenceQUIC:
+		return p, nil
+	case "auto":
+		return DialPreferenceAuto, nil
+	}
+	return "", fmt.Errorf("%q: %w", s, ErrInvalidDialPreference)
+}
+
+// dialRanker returns the ranker which dials the addresses of the preferred
+// transport immediately and the remaining ones after the fallbackDialDelay.
+// It returns nil for the DialPreferenceAuto.
+func dialRanker(p DialPreference) network.DialRanker {
+	var preferred func(ma.Multiaddr) bool
+	switch p {
+	case DialPreferenceTCP:
+		preferred = isTCPAddr
+	case DialPreferenceQUIC:
+		preferred = isQUICAddr
+	default:
+		return nil
+	}
+
+	return func(addrs []ma.Multiaddr) []network.AddrDelay {
+		res := make([]network.AddrDelay, 0, len(addrs))
+		for _, addr := range addrs {
+			delay := fallbackDialDelay
+			if preferred(addr) {
+				delay = 0
+			}
+			res = append(res, network.AddrDelay{Addr: addr, Delay: delay})
+		}
+		return res
+	}
+}
+
+func isTCPAddr(addr ma.Multiaddr) bool {
+	_, err := addr.ValueForProtocol(ma.P_TCP)
+	return err == nil
+}
+
+func isQUICAddr(addr ma.Multiaddr) bool {
+	_, err := addr.ValueForProtocol(ma.P_QUIC_V1)
+	return err == nil
+}
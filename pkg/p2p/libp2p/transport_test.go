@@ -0,0 +1,64 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package libp2p_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestParseDialPreference(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]libp2p.DialPreference{
+		"":     libp2p.DialPreferenceAuto,
+		"auto": libp2p.DialPreferenceAuto,
+		"tcp":  libp2p.DialPreferenceTCP,
+		"quic": libp2p.DialPreferenceQUIC,
+	} {
+		have, err := libp2p.ParseDialPreference(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != want {
+			t.Fatalf("%q: want %q, have %q", in, want, have)
+		}
+	}
+
+	if _, err := libp2p.ParseDialPreference("udp"); !errors.Is(err, libp2p.ErrInvalidDialPreference) {
+		t.Fatalf("want error %v, have %v", libp2p.ErrInvalidDialPreference, err)
+	}
+}
+
+func TestDialRanker(t *testing.T) {
+	t.Parallel()
+
+	if libp2p.DialRanker(libp2p.DialPreferenceAuto) != nil {
+		t.Fatal("want the default ranker for the auto preference")
+	}
+
+	var (
+		tcp  = ma.StringCast("/ip4/10.0.0.1/tcp/1634")
+		quic = ma.StringCast("/ip4/10.0.0.1/udp/1634/quic-v1")
+		wt   = ma.StringCast("/ip4/10.0.0.1/udp/1634/quic-v1/webtransport")
+	)
+
+	for _, tc := range []struct {
+		preference libp2p.DialPreference
+		immediate  map[string]bool
+	}{
+		{preference: libp2p.DialPreferenceTCP, immediate: map[string]bool{tcp.String(): true}},
+		{preference: libp2p.DialPreferenceQUIC, immediate: map[string]bool{quic.String(): true, wt.String(): true}},
+	} {
+		for _, d := range libp2p.DialRanker(tc.preference)([]ma.Multiaddr{tcp, quic, wt}) {
+			if immediate := d.Delay == 0; immediate != tc.immediate[d.Addr.String()] {
+				t.Fatalf("preference %q: address %s dialed with delay %v", tc.preference, d.Addr, d.Delay)
+			}
+		}
+	}
+}
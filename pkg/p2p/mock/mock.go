@@ -27,6 +27,9 @@ type Service struct {
 	setWelcomeMessageFunc func(string) error
 	getWelcomeMessageFunc func() string
 	blocklistFunc         func(swarm.Address, time.Duration, string) error
+	addBlocklistFunc      func(swarm.Address, time.Duration, string) error
+	removeBlocklistFunc   func(swarm.Address) error
+	capabilitiesFunc      func(swarm.Address) (p2p.Capabilities, error)
 	welcomeMessage        string
 }
 
@@ -92,6 +95,27 @@ func WithBlocklistFunc(f func(swarm.Address, time.Duration, string) error) Optio
 	})
 }
 
+// WithAddBlocklistFunc sets the mock implementation of the AddBlocklist function
+func WithAddBlocklistFunc(f func(swarm.Address, time.Duration, string) error) Option {
+	return optionFunc(func(s *Service) {
+		s.addBlocklistFunc = f
+	})
+}
+
+// WithRemoveBlocklistFunc sets the mock implementation of the RemoveBlocklist function
+func WithRemoveBlocklistFunc(f func(swarm.Address) error) Option {
+	return optionFunc(func(s *Service) {
+		s.removeBlocklistFunc = f
+	})
+}
+
+// WithPeerCapabilitiesFunc sets the mock implementation of the PeerCapabilities function
+func WithPeerCapabilitiesFunc(f func(swarm.Address) (p2p.Capabilities, error)) Option {
+	return optionFunc(func(s *Service) {
+		s.capabilitiesFunc = f
+	})
+}
+
 // New will create a new mock P2P Service with the given options
 func New(opts ...Option) *Service {
 	s := new(Service)
@@ -177,6 +201,27 @@ func (s *Service) Blocklist(overlay swarm.Address, duration time.Duration, reaso
 	return s.blocklistFunc(overlay, duration, reason)
 }
 
+func (s *Service) AddBlocklist(overlay swarm.Address, duration time.Duration, reason string) error {
+	if s.addBlocklistFunc == nil {
+		return errors.New("function AddBlocklist not configured")
+	}
+	return s.addBlocklistFunc(overlay, duration, reason)
+}
+
+func (s *Service) RemoveBlocklist(overlay swarm.Address) error {
+	if s.removeBlocklistFunc == nil {
+		return errors.New("function RemoveBlocklist not configured")
+	}
+	return s.removeBlocklistFunc(overlay)
+}
+
+func (s *Service) PeerCapabilities(overlay swarm.Address) (p2p.Capabilities, error) {
+	if s.capabilitiesFunc == nil {
+		return 0, errors.New("function PeerCapabilities not configured")
+	}
+	return s.capabilitiesFunc(overlay)
+}
+
 func (s *Service) SetPickyNotifier(f p2p.PickyNotifier) {
 	s.notifierFunc = f
 }
@@ -66,12 +66,39 @@ type Service interface {
 	Peers() []Peer
 	Blocklisted(swarm.Address) (bool, error)
 	BlocklistedPeers() ([]BlockListedPeer, error)
+	BlocklistManager
+	CapabilitiesQuerier
 	Addresses() ([]ma.Multiaddr, error)
 	SetPickyNotifier(PickyNotifier)
 	Halter
 	NetworkStatuser
 }
 
+// Capabilities is the bitfield of the optional features supported by
+// the node, advertised in the handshake. The features are rolled out
+// incrementally by checking the capabilities of the peer instead of
+// bumping the protocol versions in lockstep.
+type Capabilities uint64
+
+const (
+	// CapabilityCompression is the support of the stream compression.
+	CapabilityCompression Capabilities = 1 << iota
+	// CapabilityPeerRecords is the support of the signed hive peer records.
+	CapabilityPeerRecords
+)
+
+// Has reports whether all the capabilities c are set.
+func (c Capabilities) Has(capabilities Capabilities) bool {
+	return c&capabilities == capabilities
+}
+
+// CapabilitiesQuerier returns the capabilities advertised by the peers.
+type CapabilitiesQuerier interface {
+	// PeerCapabilities returns the capabilities of the connected peer.
+	// It returns ErrPeerNotFound if the peer is not connected.
+	PeerCapabilities(overlay swarm.Address) (Capabilities, error)
+}
+
 // NetworkStatuser handles bookkeeping of the network availability status.
 type NetworkStatuser interface {
 	// NetworkStatus returns current network availability status.
@@ -91,6 +118,17 @@ type Blocklister interface {
 	Blocklist(overlay swarm.Address, duration time.Duration, reason string) error
 }
 
+// BlocklistManager manages the blocklist entries on demand.
+type BlocklistManager interface {
+	// AddBlocklist puts the peer on the blocklist for the provided duration
+	// replacing the existing entry, whether the peer is connected or not.
+	// Duration 0 is treated as an infinite duration.
+	AddBlocklist(overlay swarm.Address, duration time.Duration, reason string) error
+	// RemoveBlocklist removes the peer from the blocklist. It returns
+	// ErrPeerNotFound if the peer is not blocklisted.
+	RemoveBlocklist(overlay swarm.Address) error
+}
+
 type Halter interface {
 	// Halt new incoming connections while shutting down
 	Halt()
@@ -118,6 +156,16 @@ type Reacher interface {
 	Close() error
 }
 
+// ProtocolErrorNotifier is notified about the peers violating the protocols.
+type ProtocolErrorNotifier interface {
+	RecordProtocolError(swarm.Address)
+}
+
+// BlocklistNotifier is notified about the blocklisted peers.
+type BlocklistNotifier interface {
+	PeerBlocklisted(swarm.Address, time.Duration, string)
+}
+
 type ReachabilityUpdater interface {
 	UpdateReachability(ReachabilityStatus)
 }
@@ -184,6 +232,9 @@ type StreamSpec struct {
 	Name    string
 	Handler HandlerFunc
 	Headler HeadlerFunc
+	// Compressible enables the negotiation of the stream
+	// compression with the peers supporting it.
+	Compressible bool
 }
 
 // Peer holds information about a Peer.
@@ -198,6 +249,8 @@ type BlockListedPeer struct {
 	Peer
 	Reason   string
 	Duration time.Duration
+	// Remaining is the time left until the entry expires, zero for the infinite duration.
+	Remaining time.Duration
 }
 
 // HandlerFunc handles a received Stream from a Peer.
@@ -216,6 +269,7 @@ type Headers map[string][]byte
 // Common header names.
 const (
 	HeaderNameTracingSpanContext = "tracing-span-context"
+	HeaderNameCompression        = "compression"
 )
 
 // NewSwarmStreamName constructs a libp2p compatible stream name out of
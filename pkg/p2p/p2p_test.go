@@ -36,3 +36,18 @@ func TestReachabilityStatus_String(t *testing.T) {
 		}
 	}
 }
+
+func TestCapabilitiesHas(t *testing.T) {
+	t.Parallel()
+
+	c := p2p.CapabilityCompression | p2p.CapabilityPeerRecords
+	if !c.Has(p2p.CapabilityCompression) || !c.Has(p2p.CapabilityPeerRecords) {
+		t.Fatalf("capabilities %b: want both set", c)
+	}
+	if !c.Has(p2p.CapabilityCompression | p2p.CapabilityPeerRecords) {
+		t.Fatalf("capabilities %b: want the combination set", c)
+	}
+	if p2p.CapabilityCompression.Has(p2p.CapabilityCompression | p2p.CapabilityPeerRecords) {
+		t.Fatal("want the combination not set")
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package peering implements the static peering policy of the node.
+// The pinned peers and the members of the private peering groups are
+// always kept connected and are never pruned nor garbage collected, while
+// the denied overlays and IP ranges are never connected.
+package peering
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// ErrInvalidConfig is returned for an invalid peering configuration.
+var ErrInvalidConfig = errors.New("invalid peering configuration")
+
+// Config is the peering configuration.
+type Config struct {
+	// Pinned are the overlays of the peers always kept connected.
+	Pinned []swarm.Address
+	// Denied are the overlays in hex or the IP ranges in
+	// the CIDR notation which are never connected.
+	Denied []string
+	// Groups are the private peering groups by name. The members of
+	// a group are treated as pinned peers.
+	Groups map[string][]swarm.Address
+}
+
+// ParseConfig parses the peering configuration options. The groups are
+// in the form of the group name followed by the member overlays, all
+// separated by colons, e.g. "name:overlay1:overlay2".
+func ParseConfig(pinned, denied, groups []string) (Config, error) {
+	c := Config{Denied: denied}
+
+	for _, v := range pinned {
+		addr, err := swarm.ParseHexAddress(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("pinned peer %q: %w", v, ErrInvalidConfig)
+		}
+		c.Pinned = append(c.Pinned, addr)
+	}
+
+	for _, v := range groups {
+		name, members, _ := strings.Cut(v, ":")
+		if name == "" || members == "" {
+			return Config{}, fmt.Errorf("peering group %q: %w", v, ErrInvalidConfig)
+		}
+		if c.Groups == nil {
+			c.Groups = make(map[string][]swarm.Address)
+		}
+		for _, m := range strings.Split(members, ":") {
+			addr, err := swarm.ParseHexAddress(m)
+			if err != nil {
+				return Config{}, fmt.Errorf("peering group %q member %q: %w", name, m, ErrInvalidConfig)
+			}
+			c.Groups[name] = append(c.Groups[name], addr)
+		}
+	}
+
+	return c, nil
+}
+
+// Policy enforces the peering configuration. It is safe for concurrent use.
+type Policy struct {
+	mu         sync.RWMutex
+	config     Config
+	protected  map[string]swarm.Address
+	denied     map[string]struct{}
+	deniedNets []*net.IPNet
+	onChange   []func()
+}
+
+// NewPolicy returns a policy with an empty configuration.
+func NewPolicy() *Policy {
+	return &Policy{
+		protected: make(map[string]swarm.Address),
+		denied:    make(map[string]struct{}),
+	}
+}
+
+// Config returns the current peering configuration.
+func (p *Policy) Config() Config {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	c := Config{
+		Pinned: slices.Clone(p.config.Pinned),
+		Denied: slices.Clone(p.config.Denied),
+		Groups: make(map[string][]swarm.Address, len(p.config.Groups)),
+	}
+	for name, members := range p.config.Groups {
+		c.Groups[name] = slices.Clone(members)
+	}
+	return c
+}
+
+// Set validates and replaces the peering configuration
+// and notifies the change subscribers.
+func (p *Policy) Set(c Config) error {
+	protected := make(map[string]swarm.Address)
+	for _, addr := range c.Pinned {
+		protected[addr.ByteString()] = addr
+	}
+	for name, members := range c.Groups {
+		if name == "" {
+			return fmt.Errorf("empty group name: %w", ErrInvalidConfig)
+		}
+		for _, addr := range members {
+			protected[addr.ByteString()] = addr
+		}
+	}
+
+	denied := make(map[string]struct{})
+	var deniedNets []*net.IPNet
+	for _, v := range c.Denied {
+		if _, n, err := net.ParseCIDR(v); err == nil {
+			deniedNets = append(deniedNets, n)
+			continue
+		}
+		addr, err := swarm.ParseHexAddress(v)
+		if err != nil {
+			return fmt.Errorf("denied entry %q is neither an overlay nor an ip range: %w", v, ErrInvalidConfig)
+		}
+		if _, ok := protected[addr.ByteString()]; ok {
+			return fmt.Errorf("overlay %s both denied and pinned: %w", addr, ErrInvalidConfig)
+		}
+		denied[addr.ByteString()] = struct{}{}
+	}
+
+	p.mu.Lock()
+	p.config = c
+	p.protected = protected
+	p.denied = denied
+	p.deniedNets = deniedNets
+	onChange := slices.Clone(p.onChange)
+	p.mu.Unlock()
+
+	for _, f := range onChange {
+		f()
+	}
+	return nil
+}
+
+// OnChange registers the function called after the configuration is changed.
+func (p *Policy) OnChange(f func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onChange = append(p.onChange, f)
+}
+
+// Protected reports whether the peer is pinned or a member of a peering group.
+func (p *Policy) Protected(addr swarm.Address) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	_, ok := p.protected[addr.ByteString()]
+	return ok
+}
+
+// Protectees returns the pinned peers and the members of the peering groups.
+func (p *Policy) Protectees() []swarm.Address {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	addrs := make([]swarm.Address, 0, len(p.protected))
+	for _, addr := range p.protected {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// DeniedOverlay reports whether the connection with the overlay is denied.
+func (p *Policy) DeniedOverlay(addr swarm.Address) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	_, ok := p.denied[addr.ByteString()]
+	return ok
+}
+
+// DeniedIP reports whether the connection with the IP address is denied.
+func (p *Policy) DeniedIP(ip net.IP) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, n := range p.deniedNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeniedMultiaddr reports whether the connection with the multiaddress is
+// denied. The multiaddresses without an IP address, e.g. the DNS ones,
+// are never denied.
+func (p *Policy) DeniedMultiaddr(addr ma.Multiaddr) bool {
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return false
+	}
+	return p.DeniedIP(ip)
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package peering_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p/peering"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pinned = swarm.RandAddress(t)
+		member = swarm.RandAddress(t)
+		denied = swarm.RandAddress(t)
+		other  = swarm.RandAddress(t)
+	)
+
+	config, err := peering.ParseConfig(
+		[]string{pinned.String()},
+		[]string{denied.String(), "10.1.0.0/16"},
+		[]string{"friends:" + member.String()},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := peering.NewPolicy()
+	changes := 0
+	p.OnChange(func() { changes++ })
+	if err := p.Set(config); err != nil {
+		t.Fatal(err)
+	}
+	if changes != 1 {
+		t.Fatalf("changes: want 1, have %d", changes)
+	}
+
+	for _, addr := range []swarm.Address{pinned, member} {
+		if !p.Protected(addr) {
+			t.Fatalf("want %s protected", addr)
+		}
+	}
+	if p.Protected(other) {
+		t.Fatalf("want %s not protected", other)
+	}
+	if have := len(p.Protectees()); have != 2 {
+		t.Fatalf("protectees: want 2, have %d", have)
+	}
+
+	if !p.DeniedOverlay(denied) {
+		t.Fatalf("want %s denied", denied)
+	}
+	if p.DeniedOverlay(other) {
+		t.Fatalf("want %s not denied", other)
+	}
+	if !p.DeniedIP(net.ParseIP("10.1.2.3")) {
+		t.Fatal("want ip in the denied range denied")
+	}
+	if p.DeniedIP(net.ParseIP("10.2.2.3")) {
+		t.Fatal("want ip out of the denied range not denied")
+	}
+	if !p.DeniedMultiaddr(ma.StringCast("/ip4/10.1.0.1/tcp/1634")) {
+		t.Fatal("want multiaddress in the denied range denied")
+	}
+	if p.DeniedMultiaddr(ma.StringCast("/dns4/example.com/tcp/1634")) {
+		t.Fatal("want dns multiaddress not denied")
+	}
+
+	if have := p.Config(); len(have.Groups["friends"]) != 1 || !have.Groups["friends"][0].Equal(member) {
+		t.Fatalf("groups: have %v", have.Groups)
+	}
+
+	// invalid configurations are not applied
+	for _, c := range []peering.Config{
+		{Denied: []string{"not-an-overlay"}},
+		{Pinned: []swarm.Address{denied}, Denied: []string{denied.String()}},
+		{Groups: map[string][]swarm.Address{"": {other}}},
+	} {
+		if err := p.Set(c); !errors.Is(err, peering.ErrInvalidConfig) {
+			t.Fatalf("config %+v: want error %v, have %v", c, peering.ErrInvalidConfig, err)
+		}
+	}
+	if !p.DeniedOverlay(denied) || changes != 1 {
+		t.Fatal("invalid configuration applied")
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		pinned, groups []string
+	}{
+		{pinned: []string{"zz"}},
+		{groups: []string{"name"}},
+		{groups: []string{":" + swarm.RandAddress(t).String()}},
+		{groups: []string{"name:zz"}},
+	} {
+		if _, err := peering.ParseConfig(tc.pinned, nil, tc.groups); !errors.Is(err, peering.ErrInvalidConfig) {
+			t.Fatalf("%+v: want error %v, have %v", tc, peering.ErrInvalidConfig, err)
+		}
+	}
+}
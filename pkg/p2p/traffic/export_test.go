@@ -0,0 +1,13 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traffic
+
+import "time"
+
+func (m *Meter) SetTimeFunc(f func() time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = f
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traffic
+
+import (
+	m2 "github.com/ethersphere/bee/v2/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Bytes          *prometheus.CounterVec
+	RefusedStreams *prometheus.CounterVec
+}
+
+func newMetrics() metrics {
+	subsystem := "traffic"
+
+	return metrics{
+		Bytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m2.Namespace,
+				Subsystem: subsystem,
+				Name:      "bytes",
+				Help:      "Bytes transferred by the p2p protocols.",
+			},
+			[]string{"protocol", "direction"},
+		),
+		RefusedStreams: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m2.Namespace,
+				Subsystem: subsystem,
+				Name:      "refused_streams",
+				Help:      "Streams of the non-essential protocols refused due to the reached monthly cap.",
+			},
+			[]string{"protocol"},
+		),
+	}
+}
+
+func (m *Meter) Metrics() []prometheus.Collector {
+	return m2.PrometheusCollectorsFromFields(m.metrics)
+}
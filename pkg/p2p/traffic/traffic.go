@@ -0,0 +1,299 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package traffic accounts the bytes transferred by the p2p protocols per
+// protocol and per peer in the monthly periods and enforces the optional
+// monthly traffic cap. When the traffic nears the cap, the non-essential
+// protocols are throttled, and once the cap is reached, their new streams
+// are refused until the next period starts.
+package traffic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"golang.org/x/time/rate"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "traffic"
+
+const (
+	// throttleThreshold is the fraction of the cap
+	// after which the non-essential protocols are throttled.
+	throttleThreshold = 0.9
+	// saveInterval is the interval of persisting the period totals.
+	saveInterval = time.Minute
+	// periodKey is the state store key of the period totals.
+	periodKey = "traffic_period"
+	// DefaultThrottleRate is the default transfer rate of
+	// the throttled protocols in bytes per second.
+	DefaultThrottleRate = 256 * 1024
+)
+
+// DefaultNonEssential are the protocols throttled by default when the traffic nears the cap.
+var DefaultNonEssential = []string{"pss", "pullsync"}
+
+// ErrCapReached is returned when a stream of a non-essential
+// protocol is refused because the monthly cap is reached.
+var ErrCapReached = errors.New("monthly traffic cap reached")
+
+// Stats are the bytes transferred in both directions.
+type Stats struct {
+	In  uint64 `json:"in"`
+	Out uint64 `json:"out"`
+}
+
+// Total returns the sum of the transferred bytes.
+func (s Stats) Total() uint64 { return s.In + s.Out }
+
+// Snapshot is the traffic of the current period.
+type Snapshot struct {
+	PeriodStart  time.Time
+	Cap          uint64
+	Total        Stats
+	Throttled    bool
+	CapReached   bool
+	NonEssential []string
+	Protocols    map[string]Stats
+	Peers        map[string]Stats
+}
+
+// Options are the traffic meter options.
+type Options struct {
+	// MonthlyCap is the cap of the bytes transferred in a calendar
+	// month in both directions; zero disables the cap.
+	MonthlyCap uint64
+	// ThrottleRate is the transfer rate in bytes per second of the
+	// non-essential protocols when the traffic nears the cap.
+	ThrottleRate int
+	// NonEssential are the names of the protocols which are throttled.
+	NonEssential []string
+	// StateStore persists the totals of the current period, if set.
+	StateStore storage.StateStorer
+}
+
+// period are the persisted totals of a period.
+type period struct {
+	Start     time.Time        `json:"start"`
+	Total     Stats            `json:"total"`
+	Protocols map[string]Stats `json:"protocols"`
+}
+
+// Meter accounts the traffic. It is safe for concurrent use.
+type Meter struct {
+	logger       log.Logger
+	metrics      metrics
+	store        storage.StateStorer
+	cap          uint64
+	nonEssential map[string]struct{}
+	limiter      *rate.Limiter
+	now          func() time.Time
+
+	mu        sync.Mutex
+	period    period
+	peers     map[string]Stats
+	lastSaved time.Time
+}
+
+// NewMeter returns a new traffic meter. The totals of the
+// current period are loaded from the state store, if set.
+func NewMeter(o Options, logger log.Logger) *Meter {
+	if o.ThrottleRate <= 0 {
+		o.ThrottleRate = DefaultThrottleRate
+	}
+	if o.NonEssential == nil {
+		o.NonEssential = DefaultNonEssential
+	}
+
+	m := &Meter{
+		logger:       logger.WithName(loggerName).Register(),
+		metrics:      newMetrics(),
+		store:        o.StateStore,
+		cap:          o.MonthlyCap,
+		nonEssential: make(map[string]struct{}, len(o.NonEssential)),
+		limiter:      rate.NewLimiter(rate.Limit(o.ThrottleRate), o.ThrottleRate),
+		now:          time.Now,
+		peers:        make(map[string]Stats),
+	}
+	for _, p := range o.NonEssential {
+		m.nonEssential[p] = struct{}{}
+	}
+
+	m.period = period{Start: periodStart(m.now()), Protocols: make(map[string]Stats)}
+	if m.store != nil {
+		var p period
+		err := m.store.Get(periodKey, &p)
+		switch {
+		case err == nil && p.Start.Equal(m.period.Start):
+			if p.Protocols == nil {
+				p.Protocols = make(map[string]Stats)
+			}
+			m.period = p
+		case err != nil && !errors.Is(err, storage.ErrNotFound):
+			m.logger.Warning("loading the traffic period failed", "error", err)
+		}
+	}
+	return m
+}
+
+// periodStart returns the start of the calendar month of the time in UTC.
+func periodStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Record accounts the bytes received from and sent to the peer by the protocol.
+func (m *Meter) Record(protocol string, peer swarm.Address, in, out int) {
+	if in <= 0 && out <= 0 {
+		return
+	}
+	m.metrics.Bytes.WithLabelValues(protocol, "in").Add(float64(max(in, 0)))
+	m.metrics.Bytes.WithLabelValues(protocol, "out").Add(float64(max(out, 0)))
+
+	m.mu.Lock()
+	now := m.now()
+	m.rollover(now)
+
+	add := func(s Stats) Stats {
+		s.In += uint64(max(in, 0))
+		s.Out += uint64(max(out, 0))
+		return s
+	}
+	m.period.Total = add(m.period.Total)
+	m.period.Protocols[protocol] = add(m.period.Protocols[protocol])
+	m.peers[peer.ByteString()] = add(m.peers[peer.ByteString()])
+
+	var save *period
+	if m.store != nil && now.Sub(m.lastSaved) >= saveInterval {
+		m.lastSaved = now
+		save = m.periodCopy()
+	}
+	m.mu.Unlock()
+
+	if save != nil {
+		m.save(save)
+	}
+}
+
+// rollover starts a new period if the current one has ended. Must be called under lock.
+func (m *Meter) rollover(now time.Time) {
+	if start := periodStart(now); start.After(m.period.Start) {
+		m.period = period{Start: start, Protocols: make(map[string]Stats)}
+		m.peers = make(map[string]Stats)
+	}
+}
+
+// periodCopy returns a copy of the current period. Must be called under lock.
+func (m *Meter) periodCopy() *period {
+	return &period{
+		Start:     m.period.Start,
+		Total:     m.period.Total,
+		Protocols: maps.Clone(m.period.Protocols),
+	}
+}
+
+func (m *Meter) save(p *period) {
+	if err := m.store.Put(periodKey, p); err != nil {
+		m.logger.Warning("saving the traffic period failed", "error", err)
+	}
+}
+
+// usage returns the fraction of the cap used in the current period.
+func (m *Meter) usage() float64 {
+	if m.cap == 0 {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rollover(m.now())
+	return float64(m.period.Total.Total()) / float64(m.cap)
+}
+
+// NonEssential reports whether the protocol is throttled when the traffic nears the cap.
+func (m *Meter) NonEssential(protocol string) bool {
+	_, ok := m.nonEssential[protocol]
+	return ok
+}
+
+// Allow returns ErrCapReached if the protocol is non-essential and the
+// monthly cap is reached.
+func (m *Meter) Allow(protocol string) error {
+	if m.NonEssential(protocol) && m.usage() >= 1 {
+		m.metrics.RefusedStreams.WithLabelValues(protocol).Inc()
+		return fmt.Errorf("protocol %s: %w", protocol, ErrCapReached)
+	}
+	return nil
+}
+
+// Wait blocks until the transfer of n bytes by the protocol is allowed.
+// The non-essential protocols are rate limited when the traffic nears the cap.
+func (m *Meter) Wait(ctx context.Context, protocol string, n int) error {
+	if n <= 0 || !m.NonEssential(protocol) || m.usage() < throttleThreshold {
+		return nil
+	}
+	for n > 0 {
+		k := min(n, m.limiter.Burst())
+		if err := m.limiter.WaitN(ctx, k); err != nil {
+			return err
+		}
+		n -= k
+	}
+	return nil
+}
+
+// SetThrottleRate changes the transfer rate in bytes per second of the
+// non-essential protocols. The default rate is used if r is not positive.
+func (m *Meter) SetThrottleRate(r int) {
+	if r <= 0 {
+		r = DefaultThrottleRate
+	}
+	m.limiter.SetLimit(rate.Limit(r))
+	m.limiter.SetBurst(r)
+}
+
+// Snapshot returns the traffic of the current period.
+func (m *Meter) Snapshot() Snapshot {
+	usage := m.usage()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	peers := make(map[string]Stats, len(m.peers))
+	for k, v := range m.peers {
+		peers[swarm.NewAddress([]byte(k)).String()] = v
+	}
+
+	nonEssential := slices.Sorted(maps.Keys(m.nonEssential))
+
+	return Snapshot{
+		PeriodStart:  m.period.Start,
+		Cap:          m.cap,
+		Total:        m.period.Total,
+		Throttled:    m.cap > 0 && usage >= throttleThreshold,
+		CapReached:   m.cap > 0 && usage >= 1,
+		NonEssential: nonEssential,
+		Protocols:    maps.Clone(m.period.Protocols),
+		Peers:        peers,
+	}
+}
+
+// Close persists the totals of the current period.
+func (m *Meter) Close() error {
+	if m.store == nil {
+		return nil
+	}
+	m.mu.Lock()
+	p := m.periodCopy()
+	m.mu.Unlock()
+	return m.store.Put(periodKey, p)
+}
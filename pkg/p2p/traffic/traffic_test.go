@@ -0,0 +1,92 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traffic_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p/traffic"
+	"github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestMeter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peer1 = swarm.RandAddress(t)
+		peer2 = swarm.RandAddress(t)
+		store = mock.NewStateStore()
+		now   = time.Now()
+	)
+
+	m := traffic.NewMeter(traffic.Options{MonthlyCap: 1000, StateStore: store}, log.Noop)
+	m.SetTimeFunc(func() time.Time { return now })
+
+	m.Record("retrieval", peer1, 100, 50)
+	m.Record("pullsync", peer2, 600, 0)
+
+	ss := m.Snapshot()
+	if ss.Total != (traffic.Stats{In: 700, Out: 50}) {
+		t.Fatalf("total: have %+v", ss.Total)
+	}
+	if ss.Protocols["retrieval"] != (traffic.Stats{In: 100, Out: 50}) {
+		t.Fatalf("retrieval: have %+v", ss.Protocols["retrieval"])
+	}
+	if ss.Peers[peer2.String()] != (traffic.Stats{In: 600}) {
+		t.Fatalf("peer: have %+v", ss.Peers[peer2.String()])
+	}
+	if ss.Throttled || ss.CapReached {
+		t.Fatal("want traffic neither throttled nor capped")
+	}
+
+	// nearing the cap throttles the non-essential protocols only
+	m.Record("retrieval", peer1, 200, 0)
+	if ss = m.Snapshot(); !ss.Throttled || ss.CapReached {
+		t.Fatalf("want traffic throttled, have %+v", ss)
+	}
+	if err := m.Allow("pullsync"); err != nil {
+		t.Fatal(err)
+	}
+
+	// reaching the cap refuses the non-essential protocols
+	m.Record("retrieval", peer1, 100, 0)
+	if err := m.Allow("pullsync"); !errors.Is(err, traffic.ErrCapReached) {
+		t.Fatalf("want error %v, have %v", traffic.ErrCapReached, err)
+	}
+	if err := m.Allow("retrieval"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := m.Wait(ctx, "retrieval", 1<<20); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Wait(ctx, "pss", 1<<20); err == nil {
+		t.Fatal("want throttled protocol to wait")
+	}
+
+	// the totals of the period survive the restart
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	m = traffic.NewMeter(traffic.Options{MonthlyCap: 1000, StateStore: store}, log.Noop)
+	if ss = m.Snapshot(); ss.Total.Total() != 1050 || ss.Protocols["pullsync"].In != 600 {
+		t.Fatalf("want the period loaded, have %+v", ss)
+	}
+
+	// the next month starts a new period
+	m.SetTimeFunc(func() time.Time { return now.AddDate(0, 1, 0) })
+	if ss = m.Snapshot(); ss.Total.Total() != 0 || ss.CapReached {
+		t.Fatalf("want new period, have %+v", ss)
+	}
+	if err := m.Allow("pullsync"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -40,10 +40,16 @@ type batchService struct {
 
 	checksum hash.Hash // checksum hasher
 	resync   bool
+
+	priceOracle postage.PriceOracle
 }
 
 type Interface interface {
 	postage.EventUpdater
+	Snapshotter
+	// SetPriceOracle overrides the price updates of the postage contract
+	// with the price of the oracle. It must be called before the Start.
+	SetPriceOracle(postage.PriceOracle)
 }
 
 // New will create a new BatchService.
@@ -95,7 +101,31 @@ func New(
 		}
 	}
 
-	return &batchService{stateStore, storer, logger.WithName(loggerName).Register(), listener, owner, batchListener, sum, resync}, nil
+	return &batchService{
+		stateStore:    stateStore,
+		storer:        storer,
+		logger:        logger.WithName(loggerName).Register(),
+		listener:      listener,
+		owner:         owner,
+		batchListener: batchListener,
+		checksum:      sum,
+		resync:        resync,
+	}, nil
+}
+
+// SetPriceOracle implements the Interface.
+func (svc *batchService) SetPriceOracle(o postage.PriceOracle) {
+	svc.priceOracle = o
+}
+
+// price returns the price of the oracle if set, or the contract price.
+func (svc *batchService) price(contractPrice *big.Int) *big.Int {
+	if svc.priceOracle != nil {
+		if p, ok := svc.priceOracle.Price(); ok {
+			return p
+		}
+	}
+	return contractPrice
 }
 
 // Create will create a new batch with the given ID, owner value and depth and
@@ -193,9 +223,53 @@ func (svc *batchService) UpdateDepth(id []byte, depth uint8, normalisedBalance *
 	return nil
 }
 
+// TransferOwnership implements the EventUpdater interface. It sets the new
+// owner of the batch with the given ID. The stamps of the batch are issued
+// by the node it is transferred to and no longer by the one it is
+// transferred from.
+func (svc *batchService) TransferOwnership(id, newOwner []byte, txHash common.Hash) error {
+	b, err := svc.storer.Get(id)
+	if err != nil {
+		return fmt.Errorf("get: %w", err)
+	}
+
+	previousOwner := b.Owner
+	b.Owner = newOwner
+	err = svc.storer.Update(b, b.Value, b.Depth)
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	if svc.batchListener != nil && !bytes.Equal(previousOwner, newOwner) {
+		switch {
+		case bytes.Equal(svc.owner, newOwner):
+			// the amount of the issuer is the remaining balance per chunk
+			amount := new(big.Int).Sub(b.Value, svc.storer.GetChainState().TotalAmount)
+			if amount.Sign() < 0 {
+				amount.SetInt64(0)
+			}
+			if err := svc.batchListener.HandleTransferIn(b, amount); err != nil {
+				return fmt.Errorf("transfer batch: %w", err)
+			}
+		case bytes.Equal(svc.owner, previousOwner):
+			svc.batchListener.HandleTransferOut(id)
+		}
+	}
+
+	cs, err := svc.updateChecksum(txHash)
+	if err != nil {
+		return fmt.Errorf("update checksum: %w", err)
+	}
+
+	svc.logger.Debug("transferred batch", "batch_id", hex.EncodeToString(b.ID), "previous_owner", hex.EncodeToString(previousOwner), "new_owner", hex.EncodeToString(newOwner), "tx", txHash, "tx_checksum", cs)
+	return nil
+}
+
 // UpdatePrice implements the EventUpdater interface. It sets the current
-// price from the chain in the service chain state.
+// price from the chain in the service chain state, unless the price is set
+// by the price oracle.
 func (svc *batchService) UpdatePrice(price *big.Int, txHash common.Hash) error {
+	price = svc.price(price)
 	cs := svc.storer.GetChainState()
 	cs.CurrentPrice = price
 	if err := svc.storer.PutChainState(cs); err != nil {
@@ -264,6 +338,13 @@ func (svc *batchService) Start(ctx context.Context, startBlock uint64, initState
 	}
 
 	cs := svc.storer.GetChainState()
+	if p := svc.price(cs.CurrentPrice); p.Cmp(cs.CurrentPrice) != 0 {
+		cs.CurrentPrice = p
+		if err := svc.storer.PutChainState(cs); err != nil {
+			return fmt.Errorf("put chain state: %w", err)
+		}
+		svc.logger.Info("postage price set by the price oracle", "price", p)
+	}
 	if cs.Block > startBlock {
 		startBlock = cs.Block
 	}
@@ -44,9 +44,11 @@ func newMockListener() *mockListener {
 }
 
 type mockBatchListener struct {
-	createCount int
-	topupCount  int
-	diluteCount int
+	createCount      int
+	topupCount       int
+	diluteCount      int
+	transferInCount  int
+	transferOutCount int
 }
 
 func (m *mockBatchListener) HandleCreate(b *postage.Batch, _ *big.Int) error {
@@ -62,6 +64,15 @@ func (m *mockBatchListener) HandleDepthIncrease(_ []byte, _ uint8) {
 	m.diluteCount++
 }
 
+func (m *mockBatchListener) HandleTransferIn(_ *postage.Batch, _ *big.Int) error {
+	m.transferInCount++
+	return nil
+}
+
+func (m *mockBatchListener) HandleTransferOut(_ []byte) {
+	m.transferOutCount++
+}
+
 var _ postage.BatchEventListener = (*mockBatchListener)(nil)
 
 func TestBatchServiceCreate(t *testing.T) {
@@ -448,6 +459,81 @@ func TestBatchServiceUpdateDepth(t *testing.T) {
 	})
 }
 
+func TestBatchServiceTransferOwnership(t *testing.T) {
+	t.Parallel()
+
+	t.Run("expect get error", func(t *testing.T) {
+		testBatch := postagetesting.MustNewBatch()
+		testBatchListener := &mockBatchListener{}
+		svc, _, _ := newTestStoreAndServiceWithListener(
+			t,
+			testBatch.Owner,
+			testBatchListener,
+			mock.WithGetErr(errTest, 0),
+		)
+
+		if err := svc.TransferOwnership(testBatch.ID, testutil.RandBytes(t, 20), testTxHash); err == nil {
+			t.Fatal("expected error")
+		}
+		if testBatchListener.transferOutCount != 0 {
+			t.Fatalf("unexpected batch listener count, exp %d found %d", 0, testBatchListener.transferOutCount)
+		}
+	})
+
+	for _, tc := range []struct {
+		name        string
+		node        func(batch *postage.Batch, newOwner []byte) []byte
+		transferIn  int
+		transferOut int
+	}{
+		{
+			name:        "outgoing",
+			node:        func(batch *postage.Batch, _ []byte) []byte { return batch.Owner },
+			transferOut: 1,
+		},
+		{
+			name:       "incoming",
+			node:       func(_ *postage.Batch, newOwner []byte) []byte { return newOwner },
+			transferIn: 1,
+		},
+		{
+			name: "other owners",
+			node: func(*postage.Batch, []byte) []byte { return make([]byte, 20) },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testBatch := postagetesting.MustNewBatch()
+			newOwner := testutil.RandBytes(t, 20)
+			testBatchListener := &mockBatchListener{}
+			svc, batchStore, _ := newTestStoreAndServiceWithListener(
+				t,
+				tc.node(testBatch, newOwner),
+				testBatchListener,
+				mock.WithChainState(postagetesting.NewChainState()),
+			)
+			createBatch(t, batchStore, testBatch)
+
+			if err := svc.TransferOwnership(testBatch.ID, newOwner, testTxHash); err != nil {
+				t.Fatalf("transfer ownership: %v", err)
+			}
+
+			got, err := batchStore.Get(testBatch.ID)
+			if err != nil {
+				t.Fatalf("batch store get: %v", err)
+			}
+			if !bytes.Equal(got.Owner, newOwner) {
+				t.Fatalf("batch owner: want %x, got %x", newOwner, got.Owner)
+			}
+			if testBatchListener.transferInCount != tc.transferIn {
+				t.Fatalf("unexpected transfer in count, exp %d found %d", tc.transferIn, testBatchListener.transferInCount)
+			}
+			if testBatchListener.transferOutCount != tc.transferOut {
+				t.Fatalf("unexpected transfer out count, exp %d found %d", tc.transferOut, testBatchListener.transferOutCount)
+			}
+		})
+	}
+}
+
 func TestBatchServiceUpdatePrice(t *testing.T) {
 	t.Parallel()
 
@@ -484,6 +570,46 @@ func TestBatchServiceUpdatePrice(t *testing.T) {
 		}
 	})
 }
+func TestBatchServicePriceOracle(t *testing.T) {
+	t.Parallel()
+
+	fixedPrice := big.NewInt(1000)
+	oracle, err := postage.NewStaticPriceOracle(fixedPrice)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testChainState := postagetesting.NewChainState()
+	testChainState.CurrentPrice = big.NewInt(100000)
+
+	store := mock.New(mock.WithChainState(testChainState))
+	svc, err := batchservice.New(mocks.NewStateStore(), store, testLog, newMockListener(), nil, nil, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.SetPriceOracle(oracle)
+
+	// the price of the oracle replaces the stored contract price on start
+	if err := svc.Start(context.Background(), 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if cs := store.GetChainState(); cs.CurrentPrice.Cmp(fixedPrice) != 0 {
+		t.Fatalf("bad price: want %v, got %v", fixedPrice, cs.CurrentPrice)
+	}
+
+	// the contract price updates are ignored
+	if err := svc.UpdatePrice(big.NewInt(20000000), testTxHash); err != nil {
+		t.Fatalf("update price: %v", err)
+	}
+	if cs := store.GetChainState(); cs.CurrentPrice.Cmp(fixedPrice) != 0 {
+		t.Fatalf("bad price: want %v, got %v", fixedPrice, cs.CurrentPrice)
+	}
+
+	if _, err := postage.NewStaticPriceOracle(big.NewInt(0)); !errors.Is(err, postage.ErrInvalidPrice) {
+		t.Fatalf("want error %v, got %v", postage.ErrInvalidPrice, err)
+	}
+}
+
 func TestBatchServiceUpdateBlockNumber(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,142 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package batchservice
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+)
+
+// ErrSnapshotUnavailable is returned when a consistent snapshot can not be
+// taken because the postage events are being processed at the moment.
+var ErrSnapshotUnavailable = errors.New("batch snapshot unavailable")
+
+// snapshotAttempts is the number of attempts to take
+// a snapshot that is not interleaved with event processing.
+const snapshotAttempts = 3
+
+// Snapshotter takes and applies snapshots of the batch store state.
+type Snapshotter interface {
+	// Snapshot returns a snapshot of the current batch store state.
+	Snapshot() (*postage.BatchSnapshot, error)
+	// ApplySnapshot replaces the batch store state with the given snapshot.
+	ApplySnapshot(*postage.BatchSnapshot) error
+}
+
+// Snapshot implements the Snapshotter interface.
+func (svc *batchService) Snapshot() (*postage.BatchSnapshot, error) {
+	for i := 0; i < snapshotAttempts; i++ {
+		s, err := svc.snapshot()
+		if errors.Is(err, ErrSnapshotUnavailable) {
+			continue
+		}
+		return s, err
+	}
+	return nil, ErrSnapshotUnavailable
+}
+
+// snapshot takes a snapshot of the batch store state. The snapshot is
+// rejected if the postage events were processed while it was being taken.
+func (svc *batchService) snapshot() (*postage.BatchSnapshot, error) {
+	if dirty, err := svc.isDirty(); err != nil || dirty {
+		return nil, errors.Join(ErrSnapshotUnavailable, err)
+	}
+
+	cs := svc.storer.GetChainState()
+
+	var checksum string
+	if err := svc.stateStore.Get(checksumDBKey, &checksum); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("get checksum: %w", err)
+	}
+
+	var batches []*postage.Batch
+	if err := svc.storer.Iterate(func(b *postage.Batch) (bool, error) {
+		batches = append(batches, b)
+		return false, nil
+	}); err != nil {
+		return nil, fmt.Errorf("iterate batches: %w", err)
+	}
+
+	if dirty, err := svc.isDirty(); err != nil || dirty || svc.storer.GetChainState().Block != cs.Block {
+		return nil, errors.Join(ErrSnapshotUnavailable, err)
+	}
+
+	return postage.NewBatchSnapshot(cs, checksum, batches)
+}
+
+// ApplySnapshot implements the Snapshotter interface. It must be called
+// before the Start method. The batch store is marked dirty for the duration
+// of the import, so an interrupted import is rolled back on the next start.
+func (svc *batchService) ApplySnapshot(s *postage.BatchSnapshot) error {
+	batches, err := s.DecodeBatches()
+	if err != nil {
+		return err
+	}
+
+	if err := svc.TransactionStart(); err != nil {
+		return err
+	}
+	if err := svc.storer.Reset(); err != nil {
+		return fmt.Errorf("reset batch store: %w", err)
+	}
+
+	cs := s.ChainState()
+	cs.CurrentPrice = svc.price(cs.CurrentPrice)
+	if err := svc.storer.PutChainState(cs); err != nil {
+		return fmt.Errorf("put chain state: %w", err)
+	}
+	for _, b := range batches {
+		if err := svc.storer.Save(b); err != nil {
+			return fmt.Errorf("save batch %x: %w", b.ID, err)
+		}
+		if bytes.Equal(svc.owner, b.Owner) && svc.batchListener != nil {
+			amount := new(big.Int).Sub(b.Value, cs.TotalAmount)
+			if err := svc.batchListener.HandleCreate(b, amount); err != nil {
+				return fmt.Errorf("create batch %x: %w", b.ID, err)
+			}
+		}
+	}
+
+	if err := svc.stateStore.Put(checksumDBKey, s.Checksum); err != nil {
+		return fmt.Errorf("put checksum: %w", err)
+	}
+	if err := svc.resetChecksum(s.Checksum); err != nil {
+		return err
+	}
+	if err := svc.TransactionEnd(); err != nil {
+		return err
+	}
+
+	// The imported state supersedes a requested resync.
+	svc.resync = false
+
+	svc.logger.Info("batch snapshot applied", "block", s.Block, "batches", len(batches), "checksum", s.Checksum)
+	return nil
+}
+
+// resetChecksum replaces the state of the checksum hasher.
+func (svc *batchService) resetChecksum(checksum string) error {
+	b, err := hex.DecodeString(checksum)
+	if err != nil {
+		return fmt.Errorf("decode checksum: %w", err)
+	}
+	svc.checksum.Reset()
+	_, err = svc.checksum.Write(b)
+	return err
+}
+
+func (svc *batchService) isDirty() (bool, error) {
+	dirty := false
+	if err := svc.stateStore.Get(dirtyDBKey, &dirty); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return false, err
+	}
+	return dirty, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package batchservice_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/postage/batchservice"
+	"github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+	mocks "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	testBatch := postagetesting.MustNewBatch()
+	testChainState := postagetesting.NewChainState()
+
+	src, err := batchservice.New(mocks.NewStateStore(), mock.New(
+		mock.WithChainState(testChainState),
+		mock.WithBatch(testBatch),
+	), testLog, newMockListener(), nil, nil, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.TopUp(testBatch.ID, big.NewInt(0), testBatch.Value, testTxHash); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := src.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("apply", func(t *testing.T) {
+		t.Parallel()
+
+		batchListener := &mockBatchListener{}
+		store := mock.New()
+		dst, err := batchservice.New(mocks.NewStateStore(), store, testLog, newMockListener(), testBatch.Owner, batchListener, nil, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dst.ApplySnapshot(snapshot); err != nil {
+			t.Fatal(err)
+		}
+
+		if c := store.ResetCalls(); c != 1 {
+			t.Fatalf("reset calls: want %d, have %d", 1, c)
+		}
+		postagetesting.CompareChainState(t, testChainState, store.GetChainState())
+		got, err := store.Get(testBatch.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		postagetesting.CompareBatches(t, testBatch, got)
+		if batchListener.createCount != 1 {
+			t.Fatalf("create count: want %d, have %d", 1, batchListener.createCount)
+		}
+
+		applied, err := dst.Snapshot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if applied.Checksum != snapshot.Checksum || applied.Digest != snapshot.Digest {
+			t.Fatalf("snapshot of applied state: want %s, have %s", snapshot.Digest, applied.Digest)
+		}
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		t.Parallel()
+
+		tampered := *snapshot
+		tampered.Block++
+
+		store := mock.New()
+		dst, err := batchservice.New(mocks.NewStateStore(), store, testLog, newMockListener(), nil, nil, nil, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dst.ApplySnapshot(&tampered); !errors.Is(err, postage.ErrInvalidBatchSnapshot) {
+			t.Fatalf("want %v, have %v", postage.ErrInvalidBatchSnapshot, err)
+		}
+		if c := store.ResetCalls(); c != 0 {
+			t.Fatalf("reset calls: want %d, have %d", 0, c)
+		}
+	})
+
+	t.Run("unavailable during transaction", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _ := newTestStoreAndService(t)
+		if err := svc.TransactionStart(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.(batchservice.Interface).Snapshot(); !errors.Is(err, batchservice.ErrSnapshotUnavailable) {
+			t.Fatalf("want %v, have %v", batchservice.ErrSnapshotUnavailable, err)
+		}
+	})
+}
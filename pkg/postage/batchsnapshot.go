@@ -0,0 +1,131 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// batchSize is the size of the binary serialised batch.
+const batchSize = 95
+
+// ErrInvalidBatchSnapshot is returned when a batch snapshot is malformed
+// or its digest does not match its content.
+var ErrInvalidBatchSnapshot = errors.New("invalid batch snapshot")
+
+// BatchSnapshot is a point in time copy of the batch store state at the
+// given block. Unlike the ChainSnapshot, which holds the postage events that
+// need to be replayed, the batch snapshot holds the resulting state, so a
+// node can be bootstrapped from it without processing the event history.
+type BatchSnapshot struct {
+	Block        uint64   `json:"block"`
+	TotalAmount  *big.Int `json:"totalAmount"`
+	CurrentPrice *big.Int `json:"currentPrice"`
+	// Checksum is the batch service checksum of all
+	// the postage events processed up to the Block.
+	Checksum string `json:"checksum"`
+	// Batches are the hex encoded binary serialised
+	// batches, ordered by their IDs.
+	Batches []string `json:"batches"`
+	Digest  string   `json:"digest"`
+}
+
+// NewBatchSnapshot creates a batch snapshot from the given chain state,
+// checksum and batches. The batches must be ordered by their IDs.
+func NewBatchSnapshot(cs *ChainState, checksum string, batches []*Batch) (*BatchSnapshot, error) {
+	s := &BatchSnapshot{
+		Block:        cs.Block,
+		TotalAmount:  new(big.Int).Set(cs.TotalAmount),
+		CurrentPrice: new(big.Int).Set(cs.CurrentPrice),
+		Checksum:     checksum,
+		Batches:      make([]string, 0, len(batches)),
+	}
+	for _, b := range batches {
+		buf, err := b.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("marshal batch %x: %w", b.ID, err)
+		}
+		s.Batches = append(s.Batches, hex.EncodeToString(buf))
+	}
+
+	digest, err := s.digest()
+	if err != nil {
+		return nil, err
+	}
+	s.Digest = hex.EncodeToString(digest)
+	return s, nil
+}
+
+// ChainState returns the chain state of the snapshot.
+func (s *BatchSnapshot) ChainState() *ChainState {
+	return &ChainState{
+		Block:        s.Block,
+		TotalAmount:  new(big.Int).Set(s.TotalAmount),
+		CurrentPrice: new(big.Int).Set(s.CurrentPrice),
+	}
+}
+
+// DecodeBatches verifies the snapshot and returns its batches.
+func (s *BatchSnapshot) DecodeBatches() ([]*Batch, error) {
+	digest, err := s.digest()
+	if err != nil {
+		return nil, err
+	}
+	if want, err := hex.DecodeString(s.Digest); err != nil || !bytes.Equal(want, digest) {
+		return nil, fmt.Errorf("%w: digest mismatch", ErrInvalidBatchSnapshot)
+	}
+
+	batches := make([]*Batch, 0, len(s.Batches))
+	var prev []byte
+	for i, v := range s.Batches {
+		buf, _ := hex.DecodeString(v) // Validated by the digest.
+		b := new(Batch)
+		if err := b.UnmarshalBinary(buf); err != nil {
+			return nil, fmt.Errorf("%w: batch %d: %w", ErrInvalidBatchSnapshot, i, err)
+		}
+		if prev != nil && bytes.Compare(prev, b.ID) >= 0 {
+			return nil, fmt.Errorf("%w: batch %x out of order", ErrInvalidBatchSnapshot, b.ID)
+		}
+		prev = b.ID
+		batches = append(batches, b)
+	}
+	return batches, nil
+}
+
+// digest returns the keccak256 hash of the snapshot content.
+func (s *BatchSnapshot) digest() ([]byte, error) {
+	for _, v := range []*big.Int{s.TotalAmount, s.CurrentPrice} {
+		if v == nil || v.Sign() < 0 || v.BitLen() > 256 {
+			return nil, fmt.Errorf("%w: malformed chain state", ErrInvalidBatchSnapshot)
+		}
+	}
+
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write(binary.BigEndian.AppendUint64(nil, s.Block))
+	_, _ = h.Write(s.TotalAmount.FillBytes(make([]byte, 32)))
+	_, _ = h.Write(s.CurrentPrice.FillBytes(make([]byte, 32)))
+
+	checksum, err := hex.DecodeString(s.Checksum)
+	if err != nil {
+		return nil, fmt.Errorf("%w: checksum: %w", ErrInvalidBatchSnapshot, err)
+	}
+	_, _ = h.Write(checksum)
+
+	for i, v := range s.Batches {
+		buf, err := hex.DecodeString(v)
+		if err != nil || len(buf) != batchSize {
+			return nil, fmt.Errorf("%w: batch %d: malformed", ErrInvalidBatchSnapshot, i)
+		}
+		_, _ = h.Write(buf)
+	}
+	return h.Sum(nil), nil
+}
@@ -10,6 +10,7 @@ import (
 	"math/big"
 	"sync"
 
+	"github.com/ethersphere/bee/v2/pkg/events"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/postage/batchstore"
 	"github.com/ethersphere/bee/v2/pkg/storage"
@@ -38,6 +39,8 @@ type BatchStore struct {
 
 func (bs *BatchStore) SetBatchExpiryHandler(eh postage.BatchExpiryHandler) {}
 
+func (bs *BatchStore) SetEventPublisher(events.Publisher) {}
+
 // Option is an option passed to New.
 type Option func(*BatchStore)
 
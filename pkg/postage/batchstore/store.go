@@ -14,6 +14,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/ethersphere/bee/v2/pkg/events"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/storage"
@@ -48,6 +49,7 @@ type store struct {
 	logger  log.Logger
 
 	batchExpiry postage.BatchExpiryHandler
+	events      events.Publisher
 
 	mtx sync.RWMutex
 }
@@ -340,6 +342,9 @@ func (s *store) cleanup() error {
 		if err != nil {
 			return fmt.Errorf("delete batch %x: %w", b.ID, err)
 		}
+		if s.events != nil {
+			s.events.Publish(events.TopicBatchExpired, events.BatchExpired{BatchID: b.ID})
+		}
 	}
 
 	return nil
@@ -408,3 +413,7 @@ func valueKeyToID(key []byte) []byte {
 func (s *store) SetBatchExpiryHandler(be postage.BatchExpiryHandler) {
 	s.batchExpiry = be
 }
+
+func (s *store) SetEventPublisher(p events.Publisher) {
+	s.events = p
+}
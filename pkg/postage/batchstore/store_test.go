@@ -5,11 +5,13 @@
 package batchstore_test
 
 import (
+	"bytes"
 	"errors"
 	"math/big"
 	"math/rand"
 	"testing"
 
+	"github.com/ethersphere/bee/v2/pkg/events"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/postage/batchstore"
@@ -504,6 +506,11 @@ func TestBatchExpiry(t *testing.T) {
 	emp := mockpost.New(mockpost.WithIssuer(esi))
 	store.SetBatchExpiryHandler(emp)
 
+	bus := events.New()
+	store.SetEventPublisher(bus)
+	expired, unsubscribe := bus.Subscribe(events.TopicBatchExpired)
+	defer unsubscribe()
+
 	// update chain state
 	err := store.PutChainState(&postage.ChainState{
 		Block:        0,
@@ -517,6 +524,15 @@ func TestBatchExpiry(t *testing.T) {
 	if exists, err := store.Exists(esi.ID()); err != nil || exists {
 		t.Fatalf("Want %v, got %v, error %v", false, exists, err)
 	}
+
+	select {
+	case e := <-expired:
+		if have := e.Data.(events.BatchExpired).BatchID; !bytes.Equal(have, batch.ID) {
+			t.Fatalf("want expired batch %x, got %x", batch.ID, have)
+		}
+	default:
+		t.Fatal("batch expired event not published")
+	}
 }
 
 func TestUnexpiredBatch(t *testing.T) {
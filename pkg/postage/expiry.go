@@ -0,0 +1,120 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/events"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+)
+
+// DefaultExpiryThreshold is the default time to live of the batch
+// below which the batch is reported as about to expire.
+const DefaultExpiryThreshold = 24 * time.Hour
+
+// BatchTTL estimates the time remaining until the batch expires in seconds.
+// The -1 signals that the batch never expires.
+func BatchTTL(b *Batch, cs *ChainState, blockTime time.Duration) int64 {
+	if len(cs.CurrentPrice.Bits()) == 0 {
+		return -1
+	}
+	ttl := new(big.Int).Sub(b.Value, cs.TotalAmount)
+	ttl = ttl.Mul(ttl, big.NewInt(int64(blockTime/time.Second)))
+	ttl = ttl.Div(ttl, cs.CurrentPrice)
+	return ttl.Int64()
+}
+
+// ExpiryNotifier publishes the events of the batches owned by the node
+// which are estimated to expire within the threshold. The batch is
+// reported again only after its time to live was extended above the
+// threshold.
+type ExpiryNotifier struct {
+	issuers   Service
+	store     Storer
+	publisher events.Publisher
+	blockTime time.Duration
+	threshold time.Duration
+	logger    log.Logger
+	notified  map[string]struct{}
+	quit      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewExpiryNotifier returns the notifier of the batches of the
+// stamp issuers of the service.
+func NewExpiryNotifier(issuers Service, store Storer, publisher events.Publisher, blockTime, threshold time.Duration, logger log.Logger) *ExpiryNotifier {
+	if threshold <= 0 {
+		threshold = DefaultExpiryThreshold
+	}
+	return &ExpiryNotifier{
+		issuers:   issuers,
+		store:     store,
+		publisher: publisher,
+		blockTime: blockTime,
+		threshold: threshold,
+		logger:    logger.WithName(loggerName).Register(),
+		notified:  make(map[string]struct{}),
+		quit:      make(chan struct{}),
+	}
+}
+
+// Start checks the batches periodically in the interval.
+func (n *ExpiryNotifier) Start(interval time.Duration) {
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			n.check()
+			select {
+			case <-ticker.C:
+			case <-n.quit:
+				return
+			}
+		}
+	}()
+}
+
+// check publishes the events of the batches about to expire.
+func (n *ExpiryNotifier) check() {
+	cs := n.store.GetChainState()
+	for _, st := range n.issuers.StampIssuers() {
+		b, err := n.store.Get(st.ID())
+		if err != nil {
+			if !errors.Is(err, storage.ErrNotFound) {
+				n.logger.Debug("get batch failed", "batch_id", hex.EncodeToString(st.ID()), "error", err)
+			}
+			continue
+		}
+
+		key := string(b.ID)
+		ttl := BatchTTL(b, cs, n.blockTime)
+		if ttl < 0 || time.Duration(ttl)*time.Second > n.threshold {
+			delete(n.notified, key)
+			continue
+		}
+		if _, ok := n.notified[key]; ok {
+			continue
+		}
+		n.notified[key] = struct{}{}
+		n.logger.Info("batch about to expire", "batch_id", hex.EncodeToString(b.ID), "ttl", time.Duration(ttl)*time.Second)
+		n.publisher.Publish(events.TopicBatchExpiring, events.BatchExpiring{BatchID: b.ID, TTL: ttl})
+	}
+}
+
+// Close stops the periodic checks.
+func (n *ExpiryNotifier) Close() error {
+	close(n.quit)
+	n.wg.Wait()
+	return nil
+}
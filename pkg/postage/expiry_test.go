@@ -0,0 +1,85 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/events"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	pstoremock "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+)
+
+func TestBatchTTL(t *testing.T) {
+	t.Parallel()
+
+	b := postagetesting.MustNewBatch(postagetesting.WithValue(1000))
+	cs := &postage.ChainState{TotalAmount: big.NewInt(900), CurrentPrice: big.NewInt(10)}
+	if have, want := postage.BatchTTL(b, cs, 5*time.Second), int64(50); have != want {
+		t.Fatalf("want ttl %d, have %d", want, have)
+	}
+
+	cs.CurrentPrice = big.NewInt(0)
+	if have, want := postage.BatchTTL(b, cs, 5*time.Second), int64(-1); have != want {
+		t.Fatalf("want ttl %d, have %d", want, have)
+	}
+}
+
+func TestExpiryNotifier(t *testing.T) {
+	t.Parallel()
+
+	b := postagetesting.MustNewBatch(postagetesting.WithValue(1000))
+	cs := &postage.ChainState{TotalAmount: big.NewInt(900), CurrentPrice: big.NewInt(10)}
+	store := pstoremock.New(pstoremock.WithBatch(b), pstoremock.WithChainState(cs))
+	issuer := postage.NewStampIssuer("", "", b.ID, big.NewInt(1000), 17, 16, 0, true)
+	service := mockpost.New(mockpost.WithIssuer(issuer))
+
+	bus := events.New()
+	c, unsubscribe := bus.Subscribe(events.TopicBatchExpiring)
+	defer unsubscribe()
+
+	n := postage.NewExpiryNotifier(service, store, bus, 5*time.Second, time.Hour, log.Noop)
+	expectEvent := func(t *testing.T) {
+		t.Helper()
+		select {
+		case e := <-c:
+			data := e.Data.(events.BatchExpiring)
+			if !bytes.Equal(data.BatchID, b.ID) || data.TTL != 50 {
+				t.Fatalf("unexpected event %+v", data)
+			}
+		default:
+			t.Fatal("batch expiring event not published")
+		}
+	}
+	expectNoEvent := func(t *testing.T) {
+		t.Helper()
+		select {
+		case e := <-c:
+			t.Fatalf("unexpected event %+v", e)
+		default:
+		}
+	}
+
+	n.Check()
+	expectEvent(t)
+
+	// the batch is reported only once
+	n.Check()
+	expectNoEvent(t)
+
+	// the batch is reported again after it was topped up above the threshold
+	b.Value = big.NewInt(1_000_000)
+	n.Check()
+	expectNoEvent(t)
+	b.Value = big.NewInt(1000)
+	n.Check()
+	expectEvent(t)
+}
@@ -51,3 +51,7 @@ func ModifyBuckets(st *StampIssuer, buckets []uint32) {
 func (si *StampIssuer) Increment(addr swarm.Address) ([]byte, []byte, error) {
 	return si.increment(addr)
 }
+
+func (n *ExpiryNotifier) Check() {
+	n.check()
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethersphere/bee/v2/pkg/events"
 )
 
 // EventUpdater interface definitions reflect the updates triggered by events
@@ -19,6 +20,7 @@ type EventUpdater interface {
 	Create(id []byte, owner []byte, totalAmount, normalisedBalance *big.Int, depth, bucketDepth uint8, immutable bool, txHash common.Hash) error
 	TopUp(id []byte, topUpAmount, normalisedBalance *big.Int, txHash common.Hash) error
 	UpdateDepth(id []byte, depth uint8, normalisedBalance *big.Int, txHash common.Hash) error
+	TransferOwnership(id []byte, newOwner []byte, txHash common.Hash) error
 	UpdatePrice(price *big.Int, txHash common.Hash) error
 	UpdateBlockNumber(blockNumber uint64) error
 	Start(ctx context.Context, startBlock uint64, initState *ChainSnapshot) error
@@ -69,6 +71,9 @@ type Storer interface {
 	Reset() error
 
 	SetBatchExpiryHandler(BatchExpiryHandler)
+
+	// SetEventPublisher sets the publisher of the batch expiry events.
+	SetEventPublisher(events.Publisher)
 }
 
 type BatchExist interface {
@@ -97,6 +102,8 @@ type BatchEventListener interface {
 	HandleCreate(*Batch, *big.Int) error
 	HandleTopUp(id []byte, newBalance *big.Int)
 	HandleDepthIncrease(id []byte, newDepth uint8)
+	HandleTransferIn(b *Batch, amount *big.Int) error
+	HandleTransferOut(id []byte)
 }
 
 type BatchExpiryHandler interface {
@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/postage/batchservice"
@@ -68,6 +69,9 @@ type listener struct {
 	batchDepthIncreaseTopic common.Hash
 	priceUpdateTopic        common.Hash
 	pausedTopic             common.Hash
+	// batchOwnershipTransferredTopic is zero if the
+	// contract ABI has no batch ownership transfer.
+	batchOwnershipTransferredTopic common.Hash
 }
 
 func New(
@@ -97,25 +101,29 @@ func New(
 		batchDepthIncreaseTopic: postageStampContractABI.Events["BatchDepthIncrease"].ID,
 		priceUpdateTopic:        postageStampContractABI.Events["PriceUpdate"].ID,
 		pausedTopic:             postageStampContractABI.Events["Paused"].ID,
+
+		batchOwnershipTransferredTopic: postageStampContractABI.Events["BatchOwnershipTransferred"].ID,
 	}
 }
 
 func (l *listener) filterQuery(from, to *big.Int) ethereum.FilterQuery {
+	topics := []common.Hash{
+		l.batchCreatedTopic,
+		l.batchTopUpTopic,
+		l.batchDepthIncreaseTopic,
+		l.priceUpdateTopic,
+		l.pausedTopic,
+	}
+	if l.batchOwnershipTransferredTopic != (common.Hash{}) {
+		topics = append(topics, l.batchOwnershipTransferredTopic)
+	}
 	return ethereum.FilterQuery{
 		FromBlock: from,
 		ToBlock:   to,
 		Addresses: []common.Address{
 			l.postageStampContractAddress,
 		},
-		Topics: [][]common.Hash{
-			{
-				l.batchCreatedTopic,
-				l.batchTopUpTopic,
-				l.batchDepthIncreaseTopic,
-				l.priceUpdateTopic,
-				l.pausedTopic,
-			},
-		},
+		Topics: [][]common.Hash{topics},
 	}
 }
 
@@ -179,6 +187,18 @@ func (l *listener) processEvent(e types.Log, updater postage.EventUpdater) error
 	case l.pausedTopic:
 		l.logger.Warning("Postage contract is paused.")
 		return ErrPostagePaused
+	case l.batchOwnershipTransferredTopic:
+		c := &batchOwnershipTransferredEvent{}
+		err := transaction.ParseEvent(&l.postageStampContractABI, "BatchOwnershipTransferred", c, e)
+		if err != nil {
+			return err
+		}
+		l.metrics.TransferCounter.Inc()
+		return updater.TransferOwnership(
+			c.BatchId[:],
+			c.NewOwner.Bytes(),
+			e.TxHash,
+		)
 	default:
 		l.metrics.EventErrors.Inc()
 		return errors.New("unknown event")
@@ -247,9 +267,28 @@ func (l *listener) Listen(ctx context.Context, from uint64, updater postage.Even
 	lastProgress := time.Now()
 	lastConfirmedBlock := uint64(0)
 
+	// When the chain backend is able to push the postage contract logs, they
+	// are buffered by the subscription and the backend is only queried for
+	// the logs of the blocks before the subscription was established. The
+	// head of the chain is still polled every batchFactor blocks.
+	var (
+		sub                     *logSubscription
+		subscriptionUnsupported bool
+	)
+	dropSubscription := func(err error) {
+		l.logger.Warning("postage events subscription failed, falling back to polling", "error", err)
+		sub.close()
+		sub = nil
+	}
+
 	l.wg.Add(1)
 	listenf := func() error {
 		defer l.wg.Done()
+		defer func() {
+			if sub != nil {
+				sub.close()
+			}
+		}()
 		for {
 			// if for whatever reason we are stuck for too long we terminate
 			// this can happen because of rpc errors but also because of a stalled backend node
@@ -264,6 +303,21 @@ func (l *listener) Listen(ctx context.Context, from uint64, updater postage.Even
 			default:
 			}
 
+			if sub == nil && !subscriptionUnsupported {
+				s, err := l.subscribe(ctx)
+				switch {
+				case errors.Is(err, rpc.ErrNotificationsUnsupported):
+					l.logger.Debug("chain backend does not support log subscriptions, polling for postage events")
+					subscriptionUnsupported = true
+				case err != nil:
+					l.metrics.BackendErrors.Inc()
+					l.logger.Warning("could not subscribe to postage events, polling", "error", err)
+				default:
+					l.logger.Debug("subscribed to postage events", "from_block", s.from)
+					sub = s
+				}
+			}
+
 			// if we have a last blocknumber from the backend we can make a good estimate on when we need to requery
 			// otherwise we just use the backoff time
 			var expectedWaitTime time.Duration
@@ -277,10 +331,19 @@ func (l *listener) Listen(ctx context.Context, from uint64, updater postage.Even
 
 			if !paged {
 				l.logger.Debug("sleeping until next block batch", "duration", expectedWaitTime)
-				select {
-				case <-time.After(expectedWaitTime):
-				case <-ctx.Done():
-					return ctx.Err()
+				if sub != nil {
+					if err := sub.wait(ctx, expectedWaitTime); err != nil {
+						dropSubscription(err)
+					}
+					if err := ctx.Err(); err != nil {
+						return err
+					}
+				} else {
+					select {
+					case <-time.After(expectedWaitTime):
+					case <-ctx.Done():
+						return ctx.Err()
+					}
 				}
 			}
 			paged = false
@@ -324,14 +387,27 @@ func (l *listener) Listen(ctx context.Context, from uint64, updater postage.Even
 			} else {
 				closeOnce.Do(func() { synced <- nil })
 			}
-			l.metrics.BackendCalls.Inc()
 
-			events, err := l.ev.FilterLogs(ctx, l.filterQuery(big.NewInt(int64(from)), big.NewInt(int64(to))))
-			if err != nil {
-				l.metrics.BackendErrors.Inc()
-				l.logger.Warning("could not get blockchain log", "error", err)
-				lastConfirmedBlock = 0
-				continue
+			var (
+				events     []types.Log
+				subscribed bool
+			)
+			if sub != nil {
+				if err := sub.drain(); err != nil {
+					dropSubscription(err)
+				} else {
+					events, subscribed = sub.take(from, to)
+				}
+			}
+			if !subscribed {
+				l.metrics.BackendCalls.Inc()
+				events, err = l.ev.FilterLogs(ctx, l.filterQuery(big.NewInt(int64(from)), big.NewInt(int64(to))))
+				if err != nil {
+					l.metrics.BackendErrors.Inc()
+					l.logger.Warning("could not get blockchain log", "error", err)
+					lastConfirmedBlock = 0
+					continue
+				}
 			}
 
 			if err := processEvents(events, to); err != nil {
@@ -403,6 +479,12 @@ type batchDepthIncreaseEvent struct {
 	NormalisedBalance *big.Int
 }
 
+type batchOwnershipTransferredEvent struct {
+	BatchId       [32]byte
+	PreviousOwner common.Address
+	NewOwner      common.Address
+}
+
 type priceUpdateEvent struct {
 	Price *big.Int
 }
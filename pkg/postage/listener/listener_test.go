@@ -20,6 +20,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/postage/listener"
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
 	"github.com/ethersphere/bee/v2/pkg/util/abiutil"
 	"github.com/ethersphere/bee/v2/pkg/util/syncutil"
 	"github.com/ethersphere/bee/v2/pkg/util/testutil"
@@ -32,7 +33,7 @@ var (
 
 var (
 	postageStampContractAddress = common.HexToAddress("eeee")
-	postageStampContractABI     = abiutil.MustParseABI(chaincfg.Testnet.PostageStampABI)
+	postageStampContractABI     = postagetesting.WithBatchOwnershipTransfer(abiutil.MustParseABI(chaincfg.Testnet.PostageStampABI))
 )
 
 const (
@@ -181,6 +182,48 @@ func TestListener(t *testing.T) {
 		}
 	})
 
+	t.Run("ownershipTransfer event", func(t *testing.T) {
+		transfer := transferArgs{
+			id:            hash[:],
+			previousOwner: addr.Bytes(),
+			newOwner:      common.HexToAddress("fedcba").Bytes(),
+		}
+
+		ev := newEventUpdaterMock()
+		mf := newMockFilterer(
+			WithFilterLogEvents(
+				transfer.toLog(496),
+			),
+		)
+		l := listener.New(
+			nil,
+			log.Noop,
+			mf,
+			postageStampContractAddress,
+			postageStampContractABI,
+			1,
+			stallingTimeout,
+			backoffTime,
+		)
+		testutil.CleanupCloser(t, l)
+
+		<-l.Listen(context.Background(), 0, ev, nil)
+
+		select {
+		case e := <-ev.eventC:
+			e.(blockNumberCall).compareF(t, blockNumber-uint64(listener.TailSize)) // event args should be equal
+		case <-time.After(timeout):
+			t.Fatal("timed out waiting for block number update")
+		}
+
+		select {
+		case e := <-ev.eventC:
+			e.(transferArgs).compareF(t, transfer) // event args should be equal
+		case <-time.After(timeout):
+			t.Fatal("timed out waiting for event")
+		}
+	})
+
 	t.Run("priceUpdate event", func(t *testing.T) {
 		priceUpdate := priceArgs{
 			price: big.NewInt(500),
@@ -585,6 +628,14 @@ func (u *updater) UpdateDepth(id []byte, depth uint8, normalisedBalance *big.Int
 	return nil
 }
 
+func (u *updater) TransferOwnership(id, newOwner []byte, _ common.Hash) error {
+	u.eventC <- transferArgs{
+		id:       id,
+		newOwner: newOwner,
+	}
+	return nil
+}
+
 func (u *updater) UpdatePrice(price *big.Int, _ common.Hash) error {
 	u.eventC <- priceArgs{price}
 	return nil
@@ -812,6 +863,43 @@ func (d depthArgs) toLog(blockNumber uint64) types.Log {
 	}
 }
 
+type transferArgs struct {
+	id            []byte
+	previousOwner []byte
+	newOwner      []byte
+}
+
+func (ta transferArgs) compare(want transferArgs) error {
+	if !bytes.Equal(ta.id, want.id) {
+		return fmt.Errorf("id mismatch. got %v want %v", ta.id, want.id)
+	}
+	if !bytes.Equal(ta.newOwner, want.newOwner) {
+		return fmt.Errorf("new owner mismatch. got %v want %v", ta.newOwner, want.newOwner)
+	}
+	return nil
+}
+
+func (ta transferArgs) compareF(t *testing.T, want transferArgs) {
+	t.Helper()
+	err := ta.compare(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (ta transferArgs) toLog(blockNumber uint64) types.Log {
+	event := postageStampContractABI.Events["BatchOwnershipTransferred"]
+	return types.Log{
+		BlockNumber: blockNumber,
+		Topics: []common.Hash{
+			event.ID,
+			common.BytesToHash(ta.id),
+			common.BytesToHash(ta.previousOwner),
+			common.BytesToHash(ta.newOwner),
+		}, // all the arguments are indexed
+	}
+}
+
 type priceArgs struct {
 	price *big.Int
 }
@@ -16,10 +16,11 @@ type metrics struct {
 	PagesProcessed  prometheus.Counter
 
 	// individual event counters
-	CreatedCounter prometheus.Counter
-	TopupCounter   prometheus.Counter
-	DepthCounter   prometheus.Counter
-	PriceCounter   prometheus.Counter
+	CreatedCounter  prometheus.Counter
+	TopupCounter    prometheus.Counter
+	DepthCounter    prometheus.Counter
+	PriceCounter    prometheus.Counter
+	TransferCounter prometheus.Counter
 
 	// total calls to chain backend
 	BackendCalls  prometheus.Counter
@@ -83,6 +84,13 @@ func newMetrics() metrics {
 			Help:      "total price change events handled",
 		}),
 
+		TransferCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "transfer_events",
+			Help:      "total batch ownership transfer events handled",
+		}),
+
 		// total call
 		BackendCalls: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: m.Namespace,
@@ -0,0 +1,162 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package listener
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// subscriptionBufferSize is the size of the channel
+// to which the subscribed logs are delivered.
+const subscriptionBufferSize = 256
+
+var errSubscriptionClosed = errors.New("log subscription closed")
+
+// LogSubscriber is implemented by the chain backends which are able to push
+// the new contract logs, e.g. the ones connected over a WebSocket endpoint.
+type LogSubscriber interface {
+	SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+}
+
+// logSubscription buffers the postage contract logs pushed by the chain
+// backend, so the block ranges covered by the subscription can be processed
+// without querying the backend for the logs.
+type logSubscription struct {
+	sub  ethereum.Subscription
+	logs chan types.Log
+	// from is the first block whose logs are all delivered by the subscription.
+	from   uint64
+	blocks map[uint64][]types.Log
+}
+
+// subscribe subscribes to the postage contract logs. It returns
+// rpc.ErrNotificationsUnsupported if the chain backend is not
+// able to push the logs.
+func (l *listener) subscribe(ctx context.Context) (*logSubscription, error) {
+	s, ok := l.ev.(LogSubscriber)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+
+	logs := make(chan types.Log, subscriptionBufferSize)
+	l.metrics.BackendCalls.Inc()
+	sub, err := s.SubscribeFilterLogs(ctx, l.filterQuery(nil, nil), logs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The block number is queried after the subscription is established, so
+	// none of the logs of the blocks after it can be missed.
+	l.metrics.BackendCalls.Inc()
+	head, err := l.ev.BlockNumber(ctx)
+	if err != nil {
+		sub.Unsubscribe()
+		return nil, err
+	}
+
+	return &logSubscription{
+		sub:    sub,
+		logs:   logs,
+		from:   head + 1,
+		blocks: make(map[uint64][]types.Log),
+	}, nil
+}
+
+// add buffers the delivered log. Logs removed
+// due to a chain reorganisation are dropped.
+func (s *logSubscription) add(lg types.Log) {
+	if !lg.Removed {
+		s.blocks[lg.BlockNumber] = append(s.blocks[lg.BlockNumber], lg)
+		return
+	}
+
+	logs := s.blocks[lg.BlockNumber]
+	for i, v := range logs {
+		if v.TxHash == lg.TxHash && v.Index == lg.Index {
+			s.blocks[lg.BlockNumber] = append(logs[:i], logs[i+1:]...)
+			break
+		}
+	}
+}
+
+// drain buffers all the logs delivered so far. It returns
+// an error if the subscription has failed or was closed.
+func (s *logSubscription) drain() error {
+	for {
+		select {
+		case err := <-s.sub.Err():
+			if err == nil {
+				err = errSubscriptionClosed
+			}
+			return err
+		case lg := <-s.logs:
+			s.add(lg)
+		default:
+			return nil
+		}
+	}
+}
+
+// wait waits for the duration d or until the context is done, buffering
+// the delivered logs meanwhile. It returns early with an error if the
+// subscription has failed or was closed.
+func (s *logSubscription) wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case lg := <-s.logs:
+			s.add(lg)
+		case err := <-s.sub.Err():
+			if err == nil {
+				err = errSubscriptionClosed
+			}
+			return err
+		}
+	}
+}
+
+// take removes and returns the buffered logs of the block range [from, to],
+// ordered as they were emitted. It reports false if the range is not
+// covered by the subscription.
+func (s *logSubscription) take(from, to uint64) ([]types.Log, bool) {
+	if from < s.from {
+		return nil, false
+	}
+
+	var logs []types.Log
+	for block, v := range s.blocks {
+		if block > to {
+			continue
+		}
+		if block >= from {
+			logs = append(logs, v...)
+		}
+		delete(s.blocks, block)
+	}
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+	return logs, true
+}
+
+func (s *logSubscription) close() {
+	s.sub.Unsubscribe()
+}
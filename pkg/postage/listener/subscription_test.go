@@ -0,0 +1,193 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package listener_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage/listener"
+	"github.com/ethersphere/bee/v2/pkg/spinlock"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+// countingFilterer counts the calls to the chain backend.
+type countingFilterer struct {
+	blockNumber      atomic.Uint64
+	blockNumberCalls atomic.Int32
+	filterCalls      atomic.Int32
+	subscribeCalls   atomic.Int32
+}
+
+func (m *countingFilterer) FilterLogs(context.Context, ethereum.FilterQuery) ([]types.Log, error) {
+	m.filterCalls.Add(1)
+	return nil, nil
+}
+
+func (m *countingFilterer) BlockNumber(context.Context) (uint64, error) {
+	m.blockNumberCalls.Add(1)
+	return m.blockNumber.Load(), nil
+}
+
+func (m *countingFilterer) calls() int32 {
+	return m.blockNumberCalls.Load() + m.filterCalls.Load() + m.subscribeCalls.Load()
+}
+
+type subscribingFilterer struct {
+	countingFilterer
+
+	mu   sync.Mutex
+	logs chan<- types.Log
+	sub  *sub
+}
+
+func (m *subscribingFilterer) SubscribeFilterLogs(_ context.Context, _ ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	m.subscribeCalls.Add(1)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.logs = ch
+	m.sub = newSub()
+	return m.sub, nil
+}
+
+func (m *subscribingFilterer) push(t *testing.T, lg types.Log) {
+	t.Helper()
+
+	err := spinlock.Wait(time.Second, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.logs != nil
+	})
+	if err != nil {
+		t.Fatal("timed out waiting for subscription")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs <- lg
+}
+
+func TestListenerSubscription(t *testing.T) {
+	t.Parallel()
+
+	c := createArgs{
+		id:               hash[:],
+		owner:            addr[:],
+		amount:           big.NewInt(42),
+		normalisedAmount: big.NewInt(43),
+		depth:            100,
+	}
+
+	ev := newEventUpdaterMock()
+	mf := new(subscribingFilterer)
+	mf.blockNumber.Store(500)
+
+	l := listener.New(
+		nil,
+		log.Noop,
+		mf,
+		postageStampContractAddress,
+		postageStampContractABI,
+		time.Millisecond,
+		stallingTimeout,
+		backoffTime,
+	)
+	testutil.CleanupCloser(t, l)
+
+	synced := l.Listen(context.Background(), 501, ev, nil)
+	go func() { <-synced }()
+
+	mf.push(t, c.toLog(502))
+	mf.blockNumber.Store(505 + uint64(listener.TailSize))
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case e := <-ev.eventC:
+			if created, ok := e.(createArgs); ok {
+				created.compareF(t, c)
+				if n := mf.filterCalls.Load(); n != 0 {
+					t.Fatalf("filter logs calls: want 0, have %d", n)
+				}
+				return
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for event")
+		}
+	}
+}
+
+// TestListenerSubscriptionBackendCalls tests that the subscribed listener
+// polls the chain head as often as the polling one, so it makes fewer
+// backend calls as it does not query the logs.
+func TestListenerSubscriptionBackendCalls(t *testing.T) {
+	t.Parallel()
+
+	const blockTime = 10 * time.Millisecond
+
+	listen := func(ev listener.BlockHeightContractFilterer) {
+		updater := newEventUpdaterMock()
+		done := make(chan struct{})
+		t.Cleanup(func() { close(done) })
+		go func() {
+			for {
+				select {
+				case <-updater.eventC:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		l := listener.New(
+			nil,
+			log.Noop,
+			ev,
+			postageStampContractAddress,
+			postageStampContractABI,
+			blockTime,
+			stallingTimeout,
+			backoffTime,
+		)
+		testutil.CleanupCloser(t, l)
+
+		synced := l.Listen(context.Background(), 0, updater, nil)
+		go func() { <-synced }()
+	}
+
+	polling := new(countingFilterer)
+	polling.blockNumber.Store(500)
+	subscribed := new(subscribingFilterer)
+	subscribed.blockNumber.Store(500)
+
+	listen(polling)
+	listen(subscribed)
+
+	// the chain advances a block every block time
+	ticker := time.NewTicker(blockTime)
+	defer ticker.Stop()
+	for range 100 {
+		<-ticker.C
+		polling.blockNumber.Add(1)
+		subscribed.blockNumber.Add(1)
+	}
+
+	if n := subscribed.filterCalls.Load(); n > 2 {
+		// only the blocks up to the head at the subscription are queried
+		t.Fatalf("filter logs calls: want at most 2, have %d", n)
+	}
+	if p, s := polling.calls(), subscribed.calls(); s >= p {
+		t.Fatalf("backend calls: want fewer than the %d of the polling listener, have %d", p, s)
+	}
+}
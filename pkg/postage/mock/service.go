@@ -105,6 +105,10 @@ func (m *mockPostage) HandleTopUp(_ []byte, _ *big.Int) {}
 
 func (m *mockPostage) HandleDepthIncrease(_ []byte, _ uint8) {}
 
+func (m *mockPostage) HandleTransferIn(_ *postage.Batch, _ *big.Int) error { return nil }
+
+func (m *mockPostage) HandleTransferOut(_ []byte) {}
+
 func (m *mockPostage) Close() error {
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"math/big"
 
+	"github.com/ethersphere/bee/v2/pkg/events"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
@@ -20,6 +21,8 @@ type NoOpBatchStore struct{}
 
 func (b *NoOpBatchStore) SetBatchExpiryHandler(BatchExpiryHandler) {}
 
+func (b *NoOpBatchStore) SetEventPublisher(events.Publisher) {}
+
 func (b *NoOpBatchStore) Get([]byte) (*Batch, error) { return nil, ErrChainDisabled }
 
 func (b *NoOpBatchStore) Exists([]byte) (bool, error) { return false, nil }
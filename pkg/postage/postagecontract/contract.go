@@ -45,6 +45,7 @@ type Interface interface {
 	CreateBatch(ctx context.Context, initialBalance *big.Int, depth uint8, immutable bool, label string) (common.Hash, []byte, error)
 	TopUpBatch(ctx context.Context, batchID []byte, topupBalance *big.Int) (common.Hash, error)
 	DiluteBatch(ctx context.Context, batchID []byte, newDepth uint8) (common.Hash, error)
+	TransferBatch(ctx context.Context, batchID []byte, newOwner common.Address) (common.Hash, error)
 	Paused(ctx context.Context) (bool, error)
 	PostageBatchExpirer
 }
@@ -67,6 +68,8 @@ type postageContract struct {
 	batchTopUpTopic         common.Hash
 	batchDepthIncreaseTopic common.Hash
 
+	batchOwnershipTransferredTopic common.Hash
+
 	gasLimit uint64
 }
 
@@ -103,6 +106,8 @@ func New(
 		batchTopUpTopic:         postageStampContractABI.Events["BatchTopUp"].ID,
 		batchDepthIncreaseTopic: postageStampContractABI.Events["BatchDepthIncrease"].ID,
 
+		batchOwnershipTransferredTopic: postageStampContractABI.Events[batchOwnershipTransferredEvent].ID,
+
 		gasLimit: gasLimit,
 	}
 }
@@ -540,6 +545,9 @@ func (m *noOpPostageContract) TopUpBatch(context.Context, []byte, *big.Int) (com
 func (m *noOpPostageContract) DiluteBatch(context.Context, []byte, uint8) (common.Hash, error) {
 	return common.Hash{}, ErrChainDisabled
 }
+func (m *noOpPostageContract) TransferBatch(context.Context, []byte, common.Address) (common.Hash, error) {
+	return common.Hash{}, ErrChainDisabled
+}
 
 func (m *noOpPostageContract) Paused(context.Context) (bool, error) {
 	return false, nil
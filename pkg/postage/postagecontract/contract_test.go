@@ -12,6 +12,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	chaincfg "github.com/ethersphere/bee/v2/pkg/config"
@@ -624,6 +625,157 @@ func newDiluteEvent(postageContractAddress common.Address, batch *postage.Batch)
 	}
 }
 
+func TestTransferBatch(t *testing.T) {
+	t.Parallel()
+
+	owner := common.HexToAddress("abcd")
+	newOwner := common.HexToAddress("dcba")
+	postageStampAddress := common.HexToAddress("ffff")
+	bzzTokenAddress := common.HexToAddress("eeee")
+	contractABI := postagetesting.WithBatchOwnershipTransfer(postageStampContractABI)
+	ctx := context.Background()
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		txHashTransfer := common.HexToHash("c3a7")
+		batch := postagetesting.MustNewBatch(postagetesting.WithOwner(owner.Bytes()))
+
+		expectedCallData, err := contractABI.Pack("transferBatchOwnership", common.BytesToHash(batch.ID), newOwner)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		contract := postagecontract.New(
+			owner,
+			postageStampAddress,
+			contractABI,
+			bzzTokenAddress,
+			transactionMock.New(
+				transactionMock.WithSendFunc(func(ctx context.Context, request *transaction.TxRequest, boost int) (txHash common.Hash, err error) {
+					if *request.To != postageStampAddress {
+						return common.Hash{}, errors.New("sent to wrong contract")
+					}
+					if !bytes.Equal(expectedCallData, request.Data) {
+						return common.Hash{}, fmt.Errorf("got wrong call data. wanted %x, got %x", expectedCallData, request.Data)
+					}
+					return txHashTransfer, nil
+				}),
+				transactionMock.WithWaitForReceiptFunc(func(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+					if txHash != txHashTransfer {
+						return nil, errors.New("unknown tx hash")
+					}
+					return &types.Receipt{
+						TxHash: txHashTransfer,
+						Logs: []*types.Log{
+							newTransferEvent(contractABI, postageStampAddress, batch, newOwner),
+						},
+						Status: 1,
+					}, nil
+				}),
+			),
+			postageMock.New(),
+			postagestoreMock.New(postagestoreMock.WithBatch(batch)),
+			true,
+			false,
+		)
+
+		txHash, err := contract.TransferBatch(ctx, batch.ID, newOwner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if txHash != txHashTransfer {
+			t.Fatalf("got tx hash %s, want %s", txHash, txHashTransfer)
+		}
+	})
+
+	t.Run("not batch owner", func(t *testing.T) {
+		t.Parallel()
+
+		batch := postagetesting.MustNewBatch(postagetesting.WithOwner(newOwner.Bytes()))
+		contract := postagecontract.New(
+			owner,
+			postageStampAddress,
+			contractABI,
+			bzzTokenAddress,
+			transactionMock.New(),
+			postageMock.New(),
+			postagestoreMock.New(postagestoreMock.WithBatch(batch)),
+			true,
+			false,
+		)
+
+		_, err := contract.TransferBatch(ctx, batch.ID, newOwner)
+		if !errors.Is(err, postagecontract.ErrNotBatchOwner) {
+			t.Fatalf("expected error %v. got %v", postagecontract.ErrNotBatchOwner, err)
+		}
+	})
+
+	t.Run("invalid new owner", func(t *testing.T) {
+		t.Parallel()
+
+		batch := postagetesting.MustNewBatch(postagetesting.WithOwner(owner.Bytes()))
+		contract := postagecontract.New(
+			owner,
+			postageStampAddress,
+			contractABI,
+			bzzTokenAddress,
+			transactionMock.New(),
+			postageMock.New(),
+			postagestoreMock.New(postagestoreMock.WithBatch(batch)),
+			true,
+			false,
+		)
+
+		for _, to := range []common.Address{{}, owner} {
+			_, err := contract.TransferBatch(ctx, batch.ID, to)
+			if !errors.Is(err, postagecontract.ErrInvalidNewOwner) {
+				t.Fatalf("expected error %v. got %v", postagecontract.ErrInvalidNewOwner, err)
+			}
+		}
+	})
+
+	t.Run("not supported by the contract", func(t *testing.T) {
+		t.Parallel()
+
+		if postagecontract.SupportsBatchTransfer(postageStampContractABI) {
+			t.Skip("contract supports the batch transfer")
+		}
+
+		batch := postagetesting.MustNewBatch(postagetesting.WithOwner(owner.Bytes()))
+		contract := postagecontract.New(
+			owner,
+			postageStampAddress,
+			postageStampContractABI,
+			bzzTokenAddress,
+			transactionMock.New(),
+			postageMock.New(),
+			postagestoreMock.New(postagestoreMock.WithBatch(batch)),
+			true,
+			false,
+		)
+
+		_, err := contract.TransferBatch(ctx, batch.ID, newOwner)
+		if !errors.Is(err, postagecontract.ErrNotImplemented) {
+			t.Fatalf("expected error %v. got %v", postagecontract.ErrNotImplemented, err)
+		}
+	})
+}
+
+func newTransferEvent(contractABI abi.ABI, postageContractAddress common.Address, batch *postage.Batch, newOwner common.Address) *types.Log {
+	event := contractABI.Events["BatchOwnershipTransferred"]
+	return &types.Log{
+		Address: postageContractAddress,
+		Topics: []common.Hash{
+			event.ID,
+			common.BytesToHash(batch.ID),
+			common.BytesToHash(batch.Owner),
+			common.BytesToHash(newOwner.Bytes()),
+		},
+		BlockNumber: batch.Start + 1,
+	}
+}
+
 func TestBatchExpirer(t *testing.T) {
 	t.Parallel()
 
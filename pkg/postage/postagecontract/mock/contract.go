@@ -16,6 +16,7 @@ type contractMock struct {
 	createBatch   func(ctx context.Context, initialBalance *big.Int, depth uint8, immutable bool, label string) (common.Hash, []byte, error)
 	topupBatch    func(ctx context.Context, id []byte, amount *big.Int) (common.Hash, error)
 	diluteBatch   func(ctx context.Context, id []byte, newDepth uint8) (common.Hash, error)
+	transferBatch func(ctx context.Context, id []byte, newOwner common.Address) (common.Hash, error)
 	expireBatches func(ctx context.Context) error
 	paused        func(ctx context.Context) (bool, error)
 }
@@ -32,6 +33,10 @@ func (c *contractMock) DiluteBatch(ctx context.Context, batchID []byte, newDepth
 	return c.diluteBatch(ctx, batchID, newDepth)
 }
 
+func (c *contractMock) TransferBatch(ctx context.Context, batchID []byte, newOwner common.Address) (common.Hash, error) {
+	return c.transferBatch(ctx, batchID, newOwner)
+}
+
 func (c *contractMock) ExpireBatches(ctx context.Context) error {
 	return c.expireBatches(ctx)
 }
@@ -72,6 +77,12 @@ func WithDiluteBatchFunc(f func(ctx context.Context, batchID []byte, newDepth ui
 	}
 }
 
+func WithTransferBatchFunc(f func(ctx context.Context, batchID []byte, newOwner common.Address) (common.Hash, error)) Option {
+	return func(m *contractMock) {
+		m.transferBatch = f
+	}
+}
+
 func WithExpiresBatchesFunc(f func(ctx context.Context) error) Option {
 	return func(m *contractMock) {
 		m.expireBatches = f
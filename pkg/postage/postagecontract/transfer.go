@@ -0,0 +1,81 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postagecontract
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	transferBatchOwnershipMethod   = "transferBatchOwnership"
+	batchOwnershipTransferredEvent = "BatchOwnershipTransferred"
+)
+
+var (
+	ErrBatchTransfer   = errors.New("batch transfer failed")
+	ErrNotBatchOwner   = errors.New("not batch owner")
+	ErrInvalidNewOwner = errors.New("invalid new owner")
+
+	transferBatchDescription = "Postage batch ownership transfer"
+)
+
+// SupportsBatchTransfer reports whether the postage stamp contract of the
+// ABI supports the transfer of the batch ownership. The versions of the
+// contract without the transfer function and event do not support it.
+func SupportsBatchTransfer(a abi.ABI) bool {
+	_, method := a.Methods[transferBatchOwnershipMethod]
+	_, event := a.Events[batchOwnershipTransferredEvent]
+	return method && event
+}
+
+// TransferBatch transfers the ownership of the batch of the node to the new
+// owner. The node stops issuing the stamps of the batch once the transfer
+// event is seen by the batch service. ErrNotImplemented is returned if the
+// contract does not support the transfer.
+func (c *postageContract) TransferBatch(ctx context.Context, batchID []byte, newOwner common.Address) (txHash common.Hash, err error) {
+	if !SupportsBatchTransfer(c.postageStampContractABI) {
+		err = ErrNotImplemented
+		return
+	}
+
+	batch, err := c.postageStorer.Get(batchID)
+	if err != nil {
+		return
+	}
+
+	if !bytes.Equal(batch.Owner, c.owner.Bytes()) {
+		err = ErrNotBatchOwner
+		return
+	}
+	if newOwner == (common.Address{}) || newOwner == c.owner {
+		err = ErrInvalidNewOwner
+		return
+	}
+
+	callData, err := c.postageStampContractABI.Pack(transferBatchOwnershipMethod, common.BytesToHash(batch.ID), newOwner)
+	if err != nil {
+		return
+	}
+
+	receipt, err := c.sendTransaction(ctx, callData, transferBatchDescription)
+	if err != nil {
+		err = fmt.Errorf("transfer batch: new owner %s: %w", newOwner, err)
+		return
+	}
+	txHash = receipt.TxHash
+	for _, ev := range receipt.Logs {
+		if ev.Address == c.postageStampContractAddress && len(ev.Topics) > 0 && ev.Topics[0] == c.batchOwnershipTransferredTopic {
+			return
+		}
+	}
+	err = ErrBatchTransfer
+	return
+}
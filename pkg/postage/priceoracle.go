@@ -0,0 +1,42 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrInvalidPrice is returned when the static postage price is not positive.
+var ErrInvalidPrice = errors.New("invalid static postage price")
+
+// PriceOracle is the source of the postage price per chunk per block which
+// overrides the price updates of the postage contract.
+type PriceOracle interface {
+	// Price returns the price, or false if the price of the postage
+	// contract is used.
+	Price() (*big.Int, bool)
+}
+
+// StaticPriceOracle is the PriceOracle with the administratively set price,
+// used by the private swarms without the postage price oracle contract.
+type StaticPriceOracle struct {
+	price *big.Int
+}
+
+// NewStaticPriceOracle returns the PriceOracle which always reports the
+// given price. All the nodes of the swarm must be configured with the same
+// price, so that they agree on the balances of the batches.
+func NewStaticPriceOracle(price *big.Int) (*StaticPriceOracle, error) {
+	if price == nil || price.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+	return &StaticPriceOracle{price: new(big.Int).Set(price)}, nil
+}
+
+// Price implements the PriceOracle interface.
+func (o *StaticPriceOracle) Price() (*big.Int, bool) {
+	return new(big.Int).Set(o.price), true
+}
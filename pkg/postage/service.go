@@ -132,6 +132,69 @@ func (ps *service) HandleDepthIncrease(batchID []byte, newDepth uint8) {
 	}
 }
 
+// HandleTransferIn implements the BatchEventListener interface. This is fired
+// on receiving the event of the transfer of the batch ownership to the node.
+// The stamp issuer is usable after the threshold blocks since the transfer,
+// as with the created batches. The bucket counts of the former owners are not
+// known, so all the buckets start full, otherwise the issued stamps would
+// reuse the indices of the stamps they issued and take over their chunks.
+// The stamps of the immutable batch are then only issued after its depth
+// is increased, the mutable batch overwrites its oldest stamps as usual.
+func (ps *service) HandleTransferIn(b *Batch, amount *big.Int) error {
+	blockNumber := ps.postageStore.GetChainState().Block
+
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	for _, v := range ps.issuers {
+		if bytes.Equal(b.ID, v.data.BatchID) {
+			// the batch was transferred back to the node
+			v.mtx.Lock()
+			v.data.Transferred = false
+			v.data.BlockNumber = blockNumber
+			v.fillBuckets()
+			v.mtx.Unlock()
+			return ps.save(v)
+		}
+	}
+
+	st := NewStampIssuer(
+		"transferred",
+		string(b.Owner),
+		b.ID,
+		amount,
+		b.Depth,
+		b.BucketDepth,
+		blockNumber,
+		b.Immutable,
+	)
+	st.fillBuckets()
+	if !ps.add(st) {
+		return nil
+	}
+	return ps.save(st)
+}
+
+// HandleTransferOut implements the BatchEventListener interface. This is fired
+// on receiving the event of the transfer of the batch ownership from the node.
+// The stamp issuer is kept, but it is no longer usable.
+func (ps *service) HandleTransferOut(batchID []byte) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	for _, v := range ps.issuers {
+		if bytes.Equal(batchID, v.data.BatchID) {
+			v.mtx.Lock()
+			v.data.Transferred = true
+			v.mtx.Unlock()
+			if err := ps.save(v); err != nil {
+				ps.logger.Error(err, "save transferred stamp issuer", "batch_id", hex.EncodeToString(batchID))
+			}
+			return
+		}
+	}
+}
+
 // StampIssuers returns the currently active stamp issuers.
 func (ps *service) StampIssuers() []*StampIssuer {
 	ps.mtx.Lock()
@@ -140,6 +203,10 @@ func (ps *service) StampIssuers() []*StampIssuer {
 }
 
 func (ps *service) IssuerUsable(st *StampIssuer) bool {
+	if st.Transferred() {
+		return false
+	}
+
 	cs := ps.postageStore.GetChainState()
 
 	// this checks at least threshold blocks are seen on the blockchain after
@@ -13,6 +13,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	pstoremock "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
@@ -209,6 +210,109 @@ func TestGetStampIssuer(t *testing.T) {
 			t.Fatalf("expected depth %d got %d", 17, stampIssuer.Depth())
 		}
 	})
+	t.Run("transfer out", func(t *testing.T) {
+		ps.HandleTransferOut(ids[3])
+		_, _, err := ps.GetStampIssuer(ids[3])
+		if !errors.Is(err, postage.ErrNotUsable) {
+			t.Fatalf("expected ErrNotUsable, got %v", err)
+		}
+
+		stampIssuerItem := postage.NewStampIssuerItem(ids[3])
+		if err := store.Get(stampIssuerItem); err != nil {
+			t.Fatal(err)
+		}
+		if !stampIssuerItem.Issuer.Transferred() {
+			t.Fatal("expected the persisted issuer to be transferred")
+		}
+	})
+	t.Run("transfer in", func(t *testing.T) {
+		b := postagetesting.MustNewBatch()
+		if err := ps.HandleTransferIn(b, big.NewInt(1)); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		// the batch transferred out is transferred back
+		if err := ps.HandleTransferIn(&postage.Batch{ID: ids[3]}, big.NewInt(1)); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		// the issuers are usable only after the threshold blocks since the transfer
+		for _, id := range [][]byte{b.ID, ids[3]} {
+			_, _, err := ps.GetStampIssuer(id)
+			if !errors.Is(err, postage.ErrNotUsable) {
+				t.Fatalf("expected ErrNotUsable, got %v", err)
+			}
+		}
+
+		testChainState.Block += uint64(postage.BlockThreshold)
+		for _, id := range [][]byte{b.ID, ids[3]} {
+			st, save, err := ps.GetStampIssuer(id)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			_ = save()
+			if st.Transferred() {
+				t.Fatal("expected the issuer not to be transferred")
+			}
+		}
+	})
+}
+
+func TestTransferInBuckets(t *testing.T) {
+	t.Parallel()
+
+	store := inmemstore.New()
+	testutil.CleanupCloser(t, store)
+	testChainState := postagetesting.NewChainState()
+	pstore := pstoremock.New(pstoremock.WithChainState(testChainState))
+	ps, err := postage.NewService(log.Noop, store, pstore, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	immutable := postagetesting.MustNewBatch()
+	immutable.Immutable = true
+	mutable := postagetesting.MustNewBatch()
+	mutable.Immutable = false
+	for _, b := range []*postage.Batch{immutable, mutable} {
+		if err := ps.HandleTransferIn(b, big.NewInt(1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testChainState.Block += uint64(postage.BlockThreshold) + 1
+
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(privKey)
+	for _, b := range []*postage.Batch{immutable, mutable} {
+		st, save, err := ps.GetStampIssuer(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the bucket counts of the former owner are not known
+		for i, c := range st.Buckets() {
+			if c != st.BucketUpperBound() {
+				t.Fatalf("bucket %d: got count %d, want %d", i, c, st.BucketUpperBound())
+			}
+		}
+
+		stamp, err := postage.NewStamper(inmemstore.New(), st, signer).Stamp(swarm.RandAddress(t), swarm.RandAddress(t))
+		if b.Immutable {
+			if !errors.Is(err, postage.ErrBucketFull) {
+				t.Fatalf("immutable batch: got error %v, want %v", err, postage.ErrBucketFull)
+			}
+		} else {
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the oldest stamp of the bucket is overwritten
+			if _, index := postage.BucketIndexFromBytes(stamp.Index()); index != 0 {
+				t.Fatalf("mutable batch: got index %d, want 0", index)
+			}
+		}
+		_ = save()
+	}
 }
 
 func TestSetExpired(t *testing.T) {
@@ -130,6 +130,7 @@ type stampIssuerData struct {
 	MaxBucketCount uint32   `msgpack:"maxBucketCount"` // the count of the fullest bucket
 	BlockNumber    uint64   `msgpack:"blockNumber"`    // BlockNumber when this batch was created
 	ImmutableFlag  bool     `msgpack:"immutableFlag"`  // Specifies immutability of the created batch.
+	Transferred    bool     `msgpack:"transferred"`    // Set when the ownership of the batch is transferred away.
 }
 
 // Clone returns a deep copy of the stampIssuerData.
@@ -144,6 +145,7 @@ func (s stampIssuerData) Clone() stampIssuerData {
 		Buckets:       append([]uint32(nil), s.Buckets...),
 		BlockNumber:   s.BlockNumber,
 		ImmutableFlag: s.ImmutableFlag,
+		Transferred:   s.Transferred,
 	}
 }
 
@@ -199,6 +201,16 @@ func (si *StampIssuer) increment(addr swarm.Address) (batchIndex []byte, batchTi
 	return indexToBytes(bIdx, bCnt), unixTime(), nil
 }
 
+// fillBuckets sets the counts of all the buckets to the upper bound.
+// Must be mutex locked before usage.
+func (si *StampIssuer) fillBuckets() {
+	upperBound := si.BucketUpperBound()
+	for i := range si.data.Buckets {
+		si.data.Buckets[i] = upperBound
+	}
+	si.data.MaxBucketCount = upperBound
+}
+
 // Label returns the label of the issuer.
 func (si *StampIssuer) Label() string {
 	return si.data.Label
@@ -260,6 +272,14 @@ func (si *StampIssuer) ImmutableFlag() bool {
 	return si.data.ImmutableFlag
 }
 
+// Transferred reports whether the ownership of the batch was transferred
+// away, so that the stamps of the batch can no longer be issued.
+func (si *StampIssuer) Transferred() bool {
+	si.mtx.Lock()
+	defer si.mtx.Unlock()
+	return si.data.Transferred
+}
+
 func (si *StampIssuer) Buckets() []uint32 {
 	si.mtx.Lock()
 	defer si.mtx.Unlock()
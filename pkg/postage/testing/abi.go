@@ -0,0 +1,33 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testing
+
+import (
+	"maps"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethersphere/bee/v2/pkg/util/abiutil"
+)
+
+// batchOwnershipTransferABI is the batch ownership transfer function and
+// event of the postage stamp contract as assumed by the tests, the released
+// versions of the contract do not have them yet.
+const batchOwnershipTransferABI = `[
+	{"inputs":[{"internalType":"bytes32","name":"_batchId","type":"bytes32"},{"internalType":"address","name":"_newOwner","type":"address"}],"name":"transferBatchOwnership","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"batchId","type":"bytes32"},{"indexed":true,"internalType":"address","name":"previousOwner","type":"address"},{"indexed":true,"internalType":"address","name":"newOwner","type":"address"}],"name":"BatchOwnershipTransferred","type":"event"}
+]`
+
+// WithBatchOwnershipTransfer returns the postage stamp contract ABI extended
+// with the batch ownership transfer, to test the support of the transfer
+// before the contract bindings include it.
+func WithBatchOwnershipTransfer(a abi.ABI) abi.ABI {
+	ext := abiutil.MustParseABI(batchOwnershipTransferABI)
+
+	a.Methods = maps.Clone(a.Methods)
+	a.Events = maps.Clone(a.Events)
+	maps.Copy(a.Methods, ext.Methods)
+	maps.Copy(a.Events, ext.Events)
+	return a
+}
@@ -39,3 +39,13 @@ func (pricer *FixedPricer) PeerPrice(peer, chunk swarm.Address) uint64 {
 func (pricer *FixedPricer) Price(chunk swarm.Address) uint64 {
 	return pricer.PeerPrice(pricer.overlay, chunk)
 }
+
+// PriceTable returns the prices we charge for the chunks
+// indexed by their proximity order to our overlay.
+func (pricer *FixedPricer) PriceTable() []uint64 {
+	prices := make([]uint64, swarm.MaxPO+1)
+	for po := range prices {
+		prices[po] = uint64(int(swarm.MaxPO)-po+1) * pricer.poPrice
+	}
+	return prices
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/log"
@@ -47,6 +48,7 @@ type PaymentThresholdObserver interface {
 type Service struct {
 	streamer                 p2p.Streamer
 	logger                   log.Logger
+	thresholdMu              sync.RWMutex
 	paymentThreshold         *big.Int
 	lightPaymentThreshold    *big.Int
 	minPaymentThreshold      *big.Int
@@ -112,10 +114,12 @@ func (s *Service) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream) (e
 
 func (s *Service) init(ctx context.Context, p p2p.Peer) error {
 
+	s.thresholdMu.RLock()
 	threshold := s.paymentThreshold
 	if !p.FullNode {
 		threshold = s.lightPaymentThreshold
 	}
+	s.thresholdMu.RUnlock()
 
 	err := s.AnnouncePaymentThreshold(ctx, p.Address, threshold)
 	if err != nil {
@@ -152,6 +156,16 @@ func (s *Service) AnnouncePaymentThreshold(ctx context.Context, peer swarm.Addre
 	return err
 }
 
+// SetPaymentThreshold replaces the payment thresholds announced
+// to the full and the light node peers on connect.
+func (s *Service) SetPaymentThreshold(paymentThreshold, lightPaymentThreshold *big.Int) {
+	s.thresholdMu.Lock()
+	defer s.thresholdMu.Unlock()
+
+	s.paymentThreshold = new(big.Int).Set(paymentThreshold)
+	s.lightPaymentThreshold = new(big.Int).Set(lightPaymentThreshold)
+}
+
 // SetPaymentThresholdObserver sets the PaymentThresholdObserver to be used when receiving a new payment threshold
 func (s *Service) SetPaymentThresholdObserver(observer PaymentThresholdObserver) {
 	s.paymentThresholdObserver = observer
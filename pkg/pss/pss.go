@@ -45,11 +45,17 @@ type Interface interface {
 	TryUnwrap(swarm.Chunk)
 
 	SetPushSyncer(pushSyncer pushsync.PushSyncer)
+	// RotateKey replaces the key the messages are unwrapped with. The messages
+	// wrapped for the replaced key are still unwrapped during the grace period.
+	RotateKey(key *ecdsa.PrivateKey, grace time.Duration)
 	io.Closer
 }
 
 type pss struct {
+	keyMu      sync.RWMutex
 	key        *ecdsa.PrivateKey
+	prevKey    *ecdsa.PrivateKey
+	prevUntil  time.Time
 	pusher     pushsync.PushSyncer
 	handlers   map[Topic][]*Handler
 	handlersMu sync.Mutex
@@ -79,6 +85,25 @@ func (ps *pss) Close() error {
 	return nil
 }
 
+func (ps *pss) RotateKey(key *ecdsa.PrivateKey, grace time.Duration) {
+	ps.keyMu.Lock()
+	defer ps.keyMu.Unlock()
+
+	ps.prevKey, ps.prevUntil = ps.key, time.Now().Add(grace)
+	ps.key = key
+}
+
+// keys returns the keys the messages are unwrapped with.
+func (ps *pss) keys() []*ecdsa.PrivateKey {
+	ps.keyMu.RLock()
+	defer ps.keyMu.RUnlock()
+
+	if ps.prevKey != nil && time.Now().Before(ps.prevUntil) {
+		return []*ecdsa.PrivateKey{ps.key, ps.prevKey}
+	}
+	return []*ecdsa.PrivateKey{ps.key}
+}
+
 func (ps *pss) SetPushSyncer(pushSyncer pushsync.PushSyncer) {
 	ps.pusher = pushSyncer
 }
@@ -157,8 +182,19 @@ func (p *pss) TryUnwrap(c swarm.Chunk) {
 		return // chunk not full
 	}
 	ctx := context.Background()
-	topic, msg, err := Unwrap(ctx, p.key, c, p.topics())
-	if err != nil {
+	var (
+		topic Topic
+		msg   []byte
+		err   error
+	)
+	topics := p.topics()
+	for _, key := range p.keys() {
+		topic, msg, err = Unwrap(ctx, key, c, topics)
+		if err == nil && msg != nil {
+			break
+		}
+	}
+	if err != nil || msg == nil {
 		return // cannot unwrap
 	}
 	h := p.getHandlers(topic)
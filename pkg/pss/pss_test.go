@@ -7,6 +7,7 @@ package pss_test
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
 	"testing"
 	"time"
 
@@ -248,3 +249,67 @@ func (s *stamper) Stamp(_, _ swarm.Address) (*postage.Stamp, error) {
 func (s *stamper) BatchId() []byte {
 	return s.stamp.BatchID()
 }
+
+// TestRotateKey verifies that the messages wrapped for the replaced key
+// are delivered only during the grace period of the key rotation.
+func TestRotateKey(t *testing.T) {
+	t.Parallel()
+
+	oldKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := pss.New(oldKey, log.Noop)
+
+	topic := pss.NewTopic("topic")
+	targets := pss.Targets([]pss.Target{pss.Target([]byte{1})})
+
+	msgChan := make(chan []byte, 1)
+	p.Register(topic, func(_ context.Context, m []byte) {
+		msgChan <- m
+	})
+
+	wrap := func(t *testing.T, key *ecdsa.PrivateKey, payload []byte) swarm.Chunk {
+		t.Helper()
+
+		chunk, err := pss.Wrap(context.Background(), topic, payload, &key.PublicKey, targets)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return chunk
+	}
+
+	delivered := func(t *testing.T, chunk swarm.Chunk, payload []byte) bool {
+		t.Helper()
+
+		p.TryUnwrap(chunk)
+		select {
+		case m := <-msgChan:
+			if !bytes.Equal(m, payload) {
+				t.Fatalf("message mismatch: expected %x, got %x", payload, m)
+			}
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}
+
+	p.RotateKey(newKey, time.Minute)
+
+	if !delivered(t, wrap(t, oldKey, []byte("old key")), []byte("old key")) {
+		t.Fatal("message for the replaced key not delivered during the grace period")
+	}
+	if !delivered(t, wrap(t, newKey, []byte("new key")), []byte("new key")) {
+		t.Fatal("message for the new key not delivered")
+	}
+
+	p.RotateKey(newKey, 0)
+
+	if delivered(t, wrap(t, oldKey, []byte("old key")), []byte("old key")) {
+		t.Fatal("message for the replaced key delivered after the grace period")
+	}
+}
@@ -4,9 +4,42 @@
 
 package puller
 
-import "github.com/ethersphere/bee/v2/pkg/swarm"
+import (
+	"time"
 
-var PeerIntervalKey = peerIntervalKey
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// PeerIntervals returns the stored intervals of the bin of the peer.
+func PeerIntervals(s storage.StateStorer, addr swarm.Address, bin uint8) (string, error) {
+	r := newPeerIntervals()
+	if err := s.Get(peerIntervalsKey(addr), r); err != nil {
+		return "", err
+	}
+	i, ok := r.bins[bin]
+	if !ok {
+		return "", storage.ErrNotFound
+	}
+	return i.String(), nil
+}
+
+// PeerEpoch returns the stored epoch of the peer.
+func PeerEpoch(s storage.StateStorer, addr swarm.Address) (uint64, error) {
+	r := newPeerIntervals()
+	if err := s.Get(peerIntervalsKey(addr), r); err != nil {
+		return 0, err
+	}
+	return r.epoch, nil
+}
+
+func (p *Puller) SetNow(now func() time.Time) {
+	p.now = now
+}
+
+func (p *Puller) AddPeerInterval(addr swarm.Address, bin uint8, start, end uint64) error {
+	return p.addPeerInterval(addr, bin, start, end)
+}
 
 func (p *Puller) IsSyncing(addr swarm.Address) bool {
 	p.syncPeersMtx.Lock()
@@ -0,0 +1,123 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package puller
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// partialHistoryKey stores the bins synced with the partial history
+// so that the bins switched back to the full history are synced again.
+const partialHistoryKey = "puller_partial_history"
+
+// ErrInvalidBins is returned when the list of the bins is malformed.
+var ErrInvalidBins = errors.New("invalid bins")
+
+// ParseBins parses the comma separated list of the bins, e.g. "0,1,2".
+// The empty string is parsed as the empty list.
+func ParseBins(s string) ([]uint8, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var bins []uint8
+	for _, v := range strings.Split(s, ",") {
+		bin, err := strconv.ParseUint(strings.TrimSpace(v), 10, 8)
+		if err != nil || bin >= uint64(swarm.MaxBins) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidBins, v)
+		}
+		bins = append(bins, uint8(bin))
+	}
+	return bins, nil
+}
+
+// PartialHistory reports whether the historical syncing of any bin is
+// limited to the recent chunks, so the reserve may be missing older chunks.
+func (p *Puller) PartialHistory() bool {
+	return p.historyPeriod > 0
+}
+
+// partialBin reports whether the bin is synced with the limited history.
+func (p *Puller) partialBin(bin uint8) bool {
+	if p.historyPeriod <= 0 {
+		return false
+	}
+	if p.historyBins == nil {
+		return true
+	}
+	_, ok := p.historyBins[bin]
+	return ok
+}
+
+// updatePartialHistory resets the intervals of the bins which were synced
+// with the limited history and are now synced fully, and it stores the
+// current set of the partially synced bins.
+func (p *Puller) updatePartialHistory() error {
+	var prev []uint8
+	if err := p.statestore.Get(partialHistoryKey, &prev); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	reset := make(map[uint8]struct{})
+	for _, bin := range prev {
+		if !p.partialBin(bin) {
+			reset[bin] = struct{}{}
+		}
+	}
+	if len(reset) > 0 {
+		if err := p.resetBins(reset); err != nil {
+			return err
+		}
+		p.logger.Info("full history syncing enabled, bins are synced again", "bins", len(reset))
+	}
+
+	var cur []uint8
+	for bin := uint8(0); bin < p.bins; bin++ {
+		if p.partialBin(bin) {
+			cur = append(cur, bin)
+		}
+	}
+	if len(cur) == 0 {
+		return p.statestore.Delete(partialHistoryKey)
+	}
+	return p.statestore.Put(partialHistoryKey, cur)
+}
+
+// resetBins removes the intervals of the bins of all the peers.
+func (p *Puller) resetBins(bins map[uint8]struct{}) error {
+	p.intervalMtx.Lock()
+	defer p.intervalMtx.Unlock()
+
+	updated := make(map[string]*peerIntervals)
+
+	err := p.statestore.Iterate(peerIntervalsPrefix, func(key, val []byte) (stop bool, err error) {
+		r := newPeerIntervals()
+		if err := r.UnmarshalBinary(val); err != nil {
+			// the corrupted records are removed by the compaction
+			return false, nil
+		}
+
+		n := len(r.bins)
+		for bin := range bins {
+			delete(r.bins, bin)
+		}
+		if len(r.bins) != n {
+			updated[string(key)] = r
+		}
+		return false, nil
+	})
+
+	for k, r := range updated {
+		err = errors.Join(err, p.statestore.Put(k, r))
+	}
+
+	return err
+}
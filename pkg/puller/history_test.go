@@ -0,0 +1,146 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package puller_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/puller"
+	mockps "github.com/ethersphere/bee/v2/pkg/pullsync/mock"
+	"github.com/ethersphere/bee/v2/pkg/spinlock"
+	"github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	resMock "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	kadMock "github.com/ethersphere/bee/v2/pkg/topology/kademlia/mock"
+)
+
+// test that only the historical syncing of the partial bins is limited
+func TestPartialHistory(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr    = swarm.RandAddress(t)
+		cursors = []uint64{1000, 1000, 1000}
+		replies = []mockps.SyncReply{
+			{Bin: 1, Start: 1, Topmost: 1000, Peer: addr},
+			{Bin: 2, Start: 1, Topmost: 1000, Peer: addr},
+		}
+	)
+
+	p, _, kad, pullsync := newPuller(t, opts{
+		kad: []kadMock.Option{
+			kadMock.WithEachPeerRevCalls(
+				kadMock.AddrTuple{Addr: addr, PO: 1},
+			),
+		},
+		pullSync:      []mockps.Option{mockps.WithCursors(cursors, 0), mockps.WithReplies(replies...)},
+		bins:          3,
+		rs:            resMock.NewReserve(resMock.WithRadius(1)),
+		historyPeriod: 24 * time.Hour,
+		historyBins:   []uint8{2},
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	kad.Trigger()
+
+	waitSyncCalledBins(t, pullsync, addr, 1, 2)
+
+	if !p.PartialHistory() {
+		t.Fatal("want partial history")
+	}
+
+	err := spinlock.Wait(time.Second, func() bool {
+		return len(pullsync.SinceCalls(addr)) > 0
+	})
+	if err != nil {
+		t.Fatal("timed out waiting for the partial sync")
+	}
+
+	for _, c := range pullsync.SinceCalls(addr) {
+		if c.Bin != 2 {
+			t.Fatalf("got partial sync of bin %d, want bin 2", c.Bin)
+		}
+		if d := time.Since(c.Since); d < 24*time.Hour || d > 25*time.Hour {
+			t.Fatalf("got partial sync since %s ago, want 24h", d)
+		}
+	}
+}
+
+// test that the bins switched back to the full history are synced again
+func TestPartialHistoryReset(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s    = mock.NewStateStore()
+		addr = swarm.RandAddress(t)
+	)
+
+	p, _, _ := newPullerWithState(t, s, opts{
+		rs:            resMock.NewReserve(),
+		historyPeriod: 24 * time.Hour,
+		historyBins:   []uint8{1, 2},
+	})
+	for bin := uint8(0); bin < 3; bin++ {
+		if err := p.AddPeerInterval(addr, bin, 1, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, _ = newPullerWithState(t, s, opts{
+		rs:            resMock.NewReserve(),
+		historyPeriod: 24 * time.Hour,
+		historyBins:   []uint8{2},
+	})
+
+	for bin, want := range []string{"[[1 10]]", "", "[[1 10]]"} {
+		got, err := puller.PeerIntervals(s, addr, uint8(bin))
+		if want == "" {
+			if !errors.Is(err, storage.ErrNotFound) {
+				t.Fatalf("bin %d: want error %v, got %v", bin, storage.ErrNotFound, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("bin %d: got intervals %s, want %s", bin, got, want)
+		}
+	}
+}
+
+func TestParseBins(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		bins string
+		want []uint8
+	}{
+		{bins: "", want: nil},
+		{bins: "0", want: []uint8{0}},
+		{bins: "0, 1,31", want: []uint8{0, 1, 31}},
+	} {
+		got, err := puller.ParseBins(tc.bins)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Fatalf("bins %q: got %v, want %v", tc.bins, got, tc.want)
+		}
+	}
+
+	for _, v := range []string{"a", "-1", "32", "1,,2"} {
+		if _, err := puller.ParseBins(v); !errors.Is(err, puller.ErrInvalidBins) {
+			t.Fatalf("bins %q: want error %v, have %v", v, puller.ErrInvalidBins, err)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package puller
+
+import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/puller/intervalstore"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const (
+	// IntervalRetention is the period after which the intervals of the
+	// peer that is not connected are removed by the compaction.
+	IntervalRetention = 7 * 24 * time.Hour
+	// MaxIntervalPeers is the number of the not connected peers whose
+	// intervals are kept by the compaction, the least recently synced
+	// peers are removed first.
+	MaxIntervalPeers = 1024
+	// MaxIntervalRanges is the number of the ranges kept per bin by the
+	// compaction, the dropped ranges are synced again.
+	MaxIntervalRanges = 64
+
+	compactIntervalsDur = time.Hour
+
+	peerIntervalsVersion = 1
+)
+
+var (
+	// peerIntervalsPrefix is the key prefix of the per peer records.
+	peerIntervalsPrefix = IntervalPrefix + "_peer_"
+
+	errPeerIntervalsVersion = errors.New("unsupported peer intervals version")
+	errPeerIntervalsFormat  = errors.New("malformed peer intervals")
+)
+
+// peerIntervals is the syncing state of a peer, stored as a single record.
+// Only the bins that were synced are present, so the size of the record is
+// bounded by the number of the bins and the number of the ranges per bin.
+type peerIntervals struct {
+	epoch    uint64
+	lastSeen int64 // unix time of the last update
+	bins     map[uint8]*intervalstore.Intervals
+}
+
+func newPeerIntervals() *peerIntervals {
+	return &peerIntervals{bins: make(map[uint8]*intervalstore.Intervals)}
+}
+
+// interval returns the intervals of the bin, it creates them if missing.
+func (r *peerIntervals) interval(bin uint8) *intervalstore.Intervals {
+	i, ok := r.bins[bin]
+	if !ok {
+		// key interval values are ALWAYS > 0
+		i = intervalstore.NewIntervals(1)
+		r.bins[bin] = i
+	}
+	return i
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The version byte is followed by the epoch, the last seen time and the
+// number of the bins as varints, then every bin and its compact intervals.
+func (r *peerIntervals) MarshalBinary() ([]byte, error) {
+	bins := make([]uint8, 0, len(r.bins))
+	for bin := range r.bins {
+		bins = append(bins, bin)
+	}
+	slices.Sort(bins)
+
+	b := []byte{peerIntervalsVersion}
+	b = binary.AppendUvarint(b, r.epoch)
+	b = binary.AppendVarint(b, r.lastSeen)
+	b = binary.AppendUvarint(b, uint64(len(bins)))
+	for _, bin := range bins {
+		b = append(b, bin)
+		b = r.bins[bin].AppendCompact(b)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (r *peerIntervals) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errPeerIntervalsFormat
+	}
+	if data[0] != peerIntervalsVersion {
+		return fmt.Errorf("%w: %d", errPeerIntervalsVersion, data[0])
+	}
+	data = data[1:]
+
+	epoch, n := binary.Uvarint(data)
+	if n <= 0 {
+		return errPeerIntervalsFormat
+	}
+	data = data[n:]
+	lastSeen, n := binary.Varint(data)
+	if n <= 0 {
+		return errPeerIntervalsFormat
+	}
+	data = data[n:]
+	count, n := binary.Uvarint(data)
+	if n <= 0 || count > uint64(swarm.MaxBins) {
+		return errPeerIntervalsFormat
+	}
+	data = data[n:]
+
+	bins := make(map[uint8]*intervalstore.Intervals, count)
+	for j := uint64(0); j < count; j++ {
+		if len(data) == 0 {
+			return errPeerIntervalsFormat
+		}
+		bin := data[0]
+		i := new(intervalstore.Intervals)
+		n, err := i.DecodeCompact(data[1:])
+		if err != nil {
+			return fmt.Errorf("bin %d: %w", bin, err)
+		}
+		bins[bin] = i
+		data = data[1+n:]
+	}
+	if len(data) != 0 {
+		return errPeerIntervalsFormat
+	}
+
+	r.epoch = epoch
+	r.lastSeen = lastSeen
+	r.bins = bins
+	return nil
+}
+
+func peerIntervalsKey(peer swarm.Address) string {
+	return peerIntervalsPrefix + peer.ByteString()
+}
+
+func addressFromKey(key []byte) swarm.Address {
+	return swarm.NewAddress(key[len(peerIntervalsPrefix):])
+}
+
+// loadIntervals returns the record of the peer, or the empty one if missing.
+// Must be called under lock.
+func (p *Puller) loadIntervals(peer swarm.Address) (*peerIntervals, error) {
+	r := newPeerIntervals()
+	if err := p.statestore.Get(peerIntervalsKey(peer), r); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return newPeerIntervals(), nil
+		}
+		return nil, err
+	}
+	return r, nil
+}
+
+// saveIntervals stores the record of the peer and marks the peer as seen.
+// Must be called under lock.
+func (p *Puller) saveIntervals(peer swarm.Address, r *peerIntervals) error {
+	r.lastSeen = p.now().Unix()
+	return p.statestore.Put(peerIntervalsKey(peer), r)
+}
+
+// compactIntervals runs the compaction on start and periodically afterwards.
+func (p *Puller) compactIntervals(ctx context.Context) {
+	defer p.wg.Done()
+
+	tick := time.NewTicker(compactIntervalsDur)
+	defer tick.Stop()
+
+	for {
+		if err := p.Compact(); err != nil {
+			p.logger.Debug("compact sync intervals failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+// Compact bounds the size of the stored intervals. It removes the intervals
+// of the not connected peers which were not synced within the retention
+// period or which exceed the maximum number of the kept peers, and it
+// truncates the bins with too many ranges. The removed intervals are synced
+// again when the peer connects.
+func (p *Puller) Compact() error {
+	p.syncPeersMtx.Lock()
+	connected := make(map[string]struct{}, len(p.syncPeers))
+	for k := range p.syncPeers {
+		connected[k] = struct{}{}
+	}
+	p.syncPeersMtx.Unlock()
+
+	p.intervalMtx.Lock()
+	defer p.intervalMtx.Unlock()
+
+	type entry struct {
+		key     string
+		record  *peerIntervals
+		corrupt bool
+	}
+
+	var entries []entry
+	err := p.statestore.Iterate(peerIntervalsPrefix, func(key, val []byte) (bool, error) {
+		r := newPeerIntervals()
+		corrupt := r.UnmarshalBinary(val) != nil
+		entries = append(entries, entry{key: string(key), record: r, corrupt: corrupt})
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("iterate intervals: %w", err)
+	}
+
+	// the most recently synced peers first
+	slices.SortFunc(entries, func(a, b entry) int {
+		return cmp.Compare(b.record.lastSeen, a.record.lastSeen)
+	})
+
+	var (
+		expiry  = p.now().Add(-IntervalRetention).Unix()
+		kept    int
+		removed int
+	)
+	for _, e := range entries {
+		_, isConnected := connected[e.key[len(peerIntervalsPrefix):]]
+		if e.corrupt || (!isConnected && (e.record.lastSeen < expiry || kept >= MaxIntervalPeers)) {
+			err = errors.Join(err, p.statestore.Delete(e.key))
+			removed++
+			continue
+		}
+		if !isConnected {
+			kept++
+		}
+
+		truncated := false
+		for _, i := range e.record.bins {
+			if i.Len() > MaxIntervalRanges {
+				i.Truncate(MaxIntervalRanges)
+				truncated = true
+			}
+		}
+		if truncated {
+			err = errors.Join(err, p.statestore.Put(e.key, e.record))
+		}
+	}
+
+	p.metrics.IntervalPeers.Set(float64(len(entries) - removed))
+	p.metrics.IntervalsPruned.Add(float64(removed))
+	if removed > 0 {
+		p.logger.Debug("sync intervals compacted", "removed_peers", removed, "kept_peers", len(entries)-removed)
+	}
+
+	return err
+}
+
+// MigrateIntervals converts the intervals stored by the former versions as
+// a record per bin and an epoch record per peer into the per peer records.
+func MigrateIntervals(s storage.StateStorer) error {
+	var (
+		epochPrefix = IntervalPrefix + "_epoch_"
+		binPrefix   = IntervalPrefix + "_"
+		records     = make(map[string]*peerIntervals)
+		legacyKeys  []string
+	)
+
+	record := func(addr string) *peerIntervals {
+		r, ok := records[addr]
+		if !ok {
+			r = newPeerIntervals()
+			records[addr] = r
+		}
+		return r
+	}
+
+	err := s.Iterate(binPrefix, func(key, val []byte) (bool, error) {
+		k := string(key)
+		switch {
+		case strings.HasPrefix(k, peerIntervalsPrefix):
+			return false, nil
+		case strings.HasPrefix(k, epochPrefix):
+			var epoch uint64
+			if err := json.Unmarshal(val, &epoch); err != nil {
+				return true, fmt.Errorf("epoch of %x: %w", k[len(epochPrefix):], err)
+			}
+			record(k[len(epochPrefix):]).epoch = epoch
+		default:
+			// the bin key is the prefix followed by the three digits bin and the address
+			rest := k[len(binPrefix):]
+			if len(rest) < 4 || rest[3] != '_' {
+				return false, nil
+			}
+			bin, err := strconv.ParseUint(rest[:3], 10, 8)
+			if err != nil {
+				return false, nil
+			}
+			i := new(intervalstore.Intervals)
+			if err := i.UnmarshalBinary(val); err != nil {
+				return true, fmt.Errorf("intervals of %x bin %d: %w", rest[4:], bin, err)
+			}
+			record(rest[4:]).bins[uint8(bin)] = i
+		}
+		legacyKeys = append(legacyKeys, k)
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	for addr, r := range records {
+		r.lastSeen = now
+		if err := s.Put(peerIntervalsKey(swarm.NewAddress([]byte(addr))), r); err != nil {
+			return err
+		}
+	}
+	for _, k := range legacyKeys {
+		if err := s.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
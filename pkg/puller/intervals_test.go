@@ -0,0 +1,163 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package puller_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/puller"
+	"github.com/ethersphere/bee/v2/pkg/puller/intervalstore"
+	mockps "github.com/ethersphere/bee/v2/pkg/pullsync/mock"
+	"github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	resMock "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	kadMock "github.com/ethersphere/bee/v2/pkg/topology/kademlia/mock"
+)
+
+func TestCompact(t *testing.T) {
+	t.Parallel()
+
+	s := mock.NewStateStore()
+	p := puller.New(swarm.RandAddress(t), s, kadMock.NewMockKademlia(), resMock.NewReserve(), mockps.NewPullSync(), nil, log.Noop, puller.Options{})
+
+	now := time.Now()
+	p.SetNow(func() time.Time { return now })
+
+	var (
+		stale  = swarm.RandAddress(t)
+		recent = swarm.RandAddress(t)
+		gaps   = swarm.RandAddress(t)
+	)
+
+	if err := p.AddPeerInterval(stale, 1, 1, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(puller.IntervalRetention)
+	if err := p.AddPeerInterval(recent, 1, 1, 10); err != nil {
+		t.Fatal(err)
+	}
+	for i := uint64(0); i < puller.MaxIntervalRanges+10; i++ {
+		if err := p.AddPeerInterval(gaps, 2, i*10+1, i*10+5); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now = now.Add(time.Minute)
+	if err := p.Compact(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := puller.PeerIntervals(s, stale, 1); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
+	}
+	checkIntervals(t, s, recent, "[[1 10]]", 1)
+
+	v, err := puller.PeerIntervals(s, gaps, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := intervalstore.NewIntervals(1)
+	for i := uint64(0); i < puller.MaxIntervalRanges; i++ {
+		want.Add(i*10+1, i*10+5)
+	}
+	if v != want.String() {
+		t.Fatalf("got intervals %s, want %s", v, want)
+	}
+}
+
+func TestCompactMaxPeers(t *testing.T) {
+	t.Parallel()
+
+	s := mock.NewStateStore()
+	p := puller.New(swarm.RandAddress(t), s, kadMock.NewMockKademlia(), resMock.NewReserve(), mockps.NewPullSync(), nil, log.Noop, puller.Options{})
+
+	now := time.Now()
+	p.SetNow(func() time.Time { return now })
+
+	peers := make([]swarm.Address, puller.MaxIntervalPeers+1)
+	for i := range peers {
+		peers[i] = swarm.RandAddress(t)
+		now = now.Add(time.Second)
+		if err := p.AddPeerInterval(peers[i], 0, 1, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := p.Compact(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the least recently synced peer is removed
+	if _, err := puller.PeerIntervals(s, peers[0], 0); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
+	}
+	for _, addr := range peers[1:] {
+		checkIntervals(t, s, addr, "[[1 1]]", 0)
+	}
+}
+
+func TestMigrateIntervals(t *testing.T) {
+	t.Parallel()
+
+	s := mock.NewStateStore()
+
+	var (
+		addr  = swarm.RandAddress(t)
+		other = swarm.RandAddress(t)
+	)
+
+	legacy := func(addr swarm.Address, bin uint8, ranges ...[2]uint64) {
+		t.Helper()
+		i := intervalstore.NewIntervals(1)
+		for _, r := range ranges {
+			i.Add(r[0], r[1])
+		}
+		if err := s.Put(fmt.Sprintf("%s_%03d_%s", puller.IntervalPrefix, bin, addr.ByteString()), i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	legacy(addr, 0, [2]uint64{1, 100})
+	legacy(addr, 3, [2]uint64{1, 10}, [2]uint64{20, 30})
+	legacy(other, 1, [2]uint64{1, 5})
+	if err := s.Put(fmt.Sprintf("%s_epoch_%s", puller.IntervalPrefix, addr.ByteString()), uint64(42)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := puller.MigrateIntervals(s); err != nil {
+		t.Fatal(err)
+	}
+
+	checkIntervals(t, s, addr, "[[1 100]]", 0)
+	checkIntervals(t, s, addr, "[[1 10] [20 30]]", 3)
+	checkIntervals(t, s, other, "[[1 5]]", 1)
+
+	if epoch, err := puller.PeerEpoch(s, addr); err != nil || epoch != 42 {
+		t.Fatalf("got epoch %d with error %v, want 42", epoch, err)
+	}
+
+	// only the migrated records are left
+	var keys int
+	if err := s.Iterate(puller.IntervalPrefix, func(_, _ []byte) (bool, error) {
+		keys++
+		return false, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if keys != 2 {
+		t.Fatalf("got %d keys, want 2", keys)
+	}
+
+	// the migration is idempotent
+	if err := puller.MigrateIntervals(s); err != nil {
+		t.Fatal(err)
+	}
+	checkIntervals(t, s, addr, "[[1 10] [20 30]]", 3)
+}
@@ -23,12 +23,16 @@ package intervalstore
 
 import (
 	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
 	"sync"
 )
 
+var errCompactEncoding = errors.New("malformed compact intervals encoding")
+
 // Intervals store a list of intervals. Its purpose is to provide
 // methods to add new intervals and retrieve missing intervals that
 // need to be added.
@@ -177,6 +181,21 @@ func (i *Intervals) Last() (end uint64) {
 	return i.ranges[l-1][1]
 }
 
+// Covered returns the number of the values between the start bound
+// and the ceiling, both inclusive, that are covered by the intervals.
+func (i *Intervals) Covered(ceiling uint64) (n uint64) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	for _, r := range i.ranges {
+		if r[0] > ceiling {
+			break
+		}
+		n += min(r[1], ceiling) - r[0] + 1
+	}
+	return n
+}
+
 // String returns a descriptive representation of range intervals
 // in [] notation, as a list of two element vectors.
 func (i *Intervals) String() string {
@@ -232,3 +251,97 @@ func (i *Intervals) UnmarshalBinary(data []byte) (err error) {
 
 	return nil
 }
+
+// Len returns the number of the ranges.
+func (i *Intervals) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return len(i.ranges)
+}
+
+// Truncate drops the ranges after the first n ranges. The values of the
+// dropped ranges are no longer covered, so they are returned by Next again.
+func (i *Intervals) Truncate(n int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if n < 0 {
+		n = 0
+	}
+	if len(i.ranges) > n {
+		i.ranges = i.ranges[:n:n]
+	}
+}
+
+// AppendCompact appends the compact binary encoding of the Intervals to b.
+// The start bound and the number of the ranges are followed by the gap
+// from the end of the previous range and the length of every range, all
+// encoded as unsigned varints. As the ranges are ordered and do not overlap,
+// the values stay small regardless of the magnitude of the bounds.
+func (i *Intervals) AppendCompact(b []byte) []byte {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	b = binary.AppendUvarint(b, i.start)
+	b = binary.AppendUvarint(b, uint64(len(i.ranges)))
+	prev := i.start
+	for _, r := range i.ranges {
+		b = binary.AppendUvarint(b, r[0]-prev)
+		b = binary.AppendUvarint(b, r[1]-r[0])
+		prev = r[1]
+	}
+	return b
+}
+
+// DecodeCompact decodes the Intervals encoded by AppendCompact at the
+// beginning of data and returns the number of the bytes read.
+func (i *Intervals) DecodeCompact(data []byte) (int, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var (
+		offset int
+		values [2]uint64
+	)
+	next := func() (uint64, error) {
+		v, n := binary.Uvarint(data[offset:])
+		if n <= 0 {
+			return 0, errCompactEncoding
+		}
+		offset += n
+		return v, nil
+	}
+
+	start, err := next()
+	if err != nil {
+		return 0, err
+	}
+	count, err := next()
+	if err != nil {
+		return 0, err
+	}
+	// every range takes two bytes at least
+	if count > uint64(len(data)-offset)/2 {
+		return 0, errCompactEncoding
+	}
+
+	i.start = start
+	i.ranges = make([][2]uint64, 0, count)
+	prev := start
+	for j := uint64(0); j < count; j++ {
+		for k := range values {
+			if values[k], err = next(); err != nil {
+				return 0, err
+			}
+		}
+		r0 := prev + values[0]
+		r1 := r0 + values[1]
+		if r0 < prev || r1 < r0 || (j > 0 && values[0] < 2) {
+			return 0, errCompactEncoding
+		}
+		i.ranges = append(i.ranges, [2]uint64{r0, r1})
+		prev = r1
+	}
+	return offset, nil
+}
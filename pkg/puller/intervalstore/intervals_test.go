@@ -479,6 +479,29 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestCovered(t *testing.T) {
+	t.Parallel()
+
+	intervals := NewIntervals(1)
+	intervals.Add(1, 10)
+	intervals.Add(21, 30)
+
+	for _, tc := range []struct {
+		ceiling uint64
+		want    uint64
+	}{
+		{ceiling: 0, want: 0},
+		{ceiling: 5, want: 5},
+		{ceiling: 15, want: 10},
+		{ceiling: 25, want: 15},
+		{ceiling: 100, want: 20},
+	} {
+		if got := intervals.Covered(tc.ceiling); got != tc.want {
+			t.Errorf("ceiling %d: expected %d, got %d", tc.ceiling, tc.want, got)
+		}
+	}
+}
+
 // TestMaxUint64 is a regression test to verify that interval
 // is handled correctly at the edges.
 func TestMaxUint64(t *testing.T) {
@@ -513,3 +536,78 @@ func TestEdgeBugUnmarshal(t *testing.T) {
 		t.Fatalf("got interval string '%s' want '%s'", s, wantstr)
 	}
 }
+
+func TestCompactEncoding(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		start  uint64
+		ranges [][2]uint64
+	}{
+		{name: "empty", start: 1},
+		{name: "single", start: 1, ranges: [][2]uint64{{1, 1000}}},
+		{name: "gaps", start: 5, ranges: [][2]uint64{{10, 20}, {22, 22}, {1 << 40, 1<<40 + 7}}},
+		{name: "max", start: 1, ranges: [][2]uint64{{math.MaxUint64 - 5, math.MaxUint64}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			want := NewIntervals(tc.start)
+			for _, r := range tc.ranges {
+				want.Add(r[0], r[1])
+			}
+
+			data := want.AppendCompact([]byte{0xff})
+			got := new(Intervals)
+			n, err := got.DecodeCompact(data[1:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(data)-1 {
+				t.Fatalf("got %d bytes read, want %d", n, len(data)-1)
+			}
+			if got.String() != want.String() || got.start != want.start {
+				t.Fatalf("got %v from %d, want %v from %d", got, got.start, want, want.start)
+			}
+		})
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+
+		for _, data := range [][]byte{
+			{},
+			{1},
+			{1, 1, 0},
+			{1, 2, 0, 5, 1, 5}, // adjacent ranges
+			{1, 200, 0, 5},
+		} {
+			if _, err := new(Intervals).DecodeCompact(data); err == nil {
+				t.Fatalf("decoding %v: want error", data)
+			}
+		}
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	intervals := NewIntervals(1)
+	intervals.Add(1, 10)
+	intervals.Add(21, 30)
+	intervals.Add(41, 50)
+
+	intervals.Truncate(2)
+	if got, want := intervals.String(), "[[1 10] [21 30]]"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got := intervals.Len(); got != 2 {
+		t.Fatalf("got %d ranges, want 2", got)
+	}
+
+	intervals.Add(41, 50)
+	if got, want := intervals.String(), "[[1 10] [21 30] [41 50]]"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package puller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	ratelimit "golang.org/x/time/rate"
+)
+
+// scheduleRecheck is the maximum duration the historical syncing waits for
+// its scheduling window before the possibly changed limits are checked again.
+const scheduleRecheck = time.Minute
+
+// ErrInvalidLimits is returned when the syncing limits are malformed.
+var ErrInvalidLimits = errors.New("invalid syncing limits")
+
+// Limits are the runtime adjustable limits of the syncing.
+type Limits struct {
+	// Bandwidth is the maximum rate of the pulled chunk
+	// data in megabytes per second. Zero means unlimited.
+	Bandwidth float64
+	// HistoricalHours is the daily window within which the historical
+	// syncing is allowed to run. The live syncing is not restricted.
+	HistoricalHours HourWindow
+}
+
+func (l Limits) validate() error {
+	if l.Bandwidth < 0 || math.IsNaN(l.Bandwidth) || math.IsInf(l.Bandwidth, 0) {
+		return fmt.Errorf("%w: bandwidth %v", ErrInvalidLimits, l.Bandwidth)
+	}
+	if l.HistoricalHours.From > 23 || l.HistoricalHours.To > 23 {
+		return fmt.Errorf("%w: historical hours %s", ErrInvalidLimits, l.HistoricalHours)
+	}
+	return nil
+}
+
+// HourWindow is a daily window of the local time hours starting at the
+// beginning of the From hour and ending at the beginning of the To hour.
+// The window may wrap around midnight. The zero value, and any window with
+// the equal bounds, spans the whole day.
+type HourWindow struct {
+	From uint8
+	To   uint8
+}
+
+// ParseHourWindow parses the window in the "from-to" format, e.g. "22-6".
+// The empty string is parsed as the window spanning the whole day.
+func ParseHourWindow(s string) (HourWindow, error) {
+	if s == "" {
+		return HourWindow{}, nil
+	}
+
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return HourWindow{}, fmt.Errorf("%w: hour window %q", ErrInvalidLimits, s)
+	}
+	f, err := strconv.ParseUint(strings.TrimSpace(from), 10, 8)
+	if err != nil || f > 23 {
+		return HourWindow{}, fmt.Errorf("%w: hour window %q", ErrInvalidLimits, s)
+	}
+	t, err := strconv.ParseUint(strings.TrimSpace(to), 10, 8)
+	if err != nil || t > 23 {
+		return HourWindow{}, fmt.Errorf("%w: hour window %q", ErrInvalidLimits, s)
+	}
+	return HourWindow{From: uint8(f), To: uint8(t)}, nil
+}
+
+// String returns the window in the format accepted by the ParseHourWindow.
+func (w HourWindow) String() string {
+	if w.From == w.To {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d", w.From, w.To)
+}
+
+// Contains reports whether the given time is within the window.
+func (w HourWindow) Contains(t time.Time) bool {
+	if w.From == w.To {
+		return true
+	}
+	h := uint8(t.Hour())
+	if w.From < w.To {
+		return h >= w.From && h < w.To
+	}
+	return h >= w.From || h < w.To
+}
+
+// Next returns the time the window opens next after the given time.
+func (w HourWindow) Next(t time.Time) time.Time {
+	next := time.Date(t.Year(), t.Month(), t.Day(), int(w.From), 0, 0, 0, t.Location())
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// Limits returns the current syncing limits.
+func (p *Puller) Limits() Limits {
+	p.limitsMtx.Lock()
+	defer p.limitsMtx.Unlock()
+	return p.limits
+}
+
+// SetLimits replaces the syncing limits. The new limits
+// apply to the already running syncing as well.
+func (p *Puller) SetLimits(l Limits) error {
+	if err := l.validate(); err != nil {
+		return err
+	}
+
+	p.limitsMtx.Lock()
+	defer p.limitsMtx.Unlock()
+
+	p.limits = l
+	if l.Bandwidth == 0 {
+		p.bandwidth.SetLimit(ratelimit.Inf)
+	} else {
+		// the limit is applied to the number of the pulled chunks
+		p.bandwidth.SetLimit(ratelimit.Limit(l.Bandwidth * 1e6 / swarm.ChunkWithSpanSize))
+	}
+	p.logger.Debug("syncing limits set", "bandwidth_mbps", l.Bandwidth, "historical_hours", l.HistoricalHours.String())
+	return nil
+}
+
+// waitBandwidth blocks until the pulling of the given
+// number of chunks is permitted by the bandwidth limit.
+func (p *Puller) waitBandwidth(ctx context.Context, count int) error {
+	for count > 0 {
+		n := min(count, p.bandwidth.Burst())
+		if err := p.bandwidth.WaitN(ctx, n); err != nil {
+			return err
+		}
+		count -= n
+	}
+	return nil
+}
+
+// waitHistoricalWindow blocks until the historical
+// syncing is allowed by the scheduling window.
+func (p *Puller) waitHistoricalWindow(ctx context.Context) error {
+	for {
+		w := p.Limits().HistoricalHours
+		now := time.Now()
+		if w.Contains(now) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(min(w.Next(now).Sub(now), scheduleRecheck)):
+		}
+	}
+}
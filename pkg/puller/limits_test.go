@@ -0,0 +1,96 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package puller_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/puller"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestHourWindow(t *testing.T) {
+	t.Parallel()
+
+	at := func(hour int) time.Time { return time.Date(2024, 5, 1, hour, 30, 0, 0, time.UTC) }
+
+	for _, tc := range []struct {
+		window   string
+		inside   []int
+		outside  []int
+		next     int // hour of the next opening after 12:30
+		nextDays int
+	}{
+		{window: "", inside: []int{0, 12, 23}},
+		{window: "1-5", inside: []int{1, 4}, outside: []int{0, 5, 12}, next: 1, nextDays: 1},
+		{window: "22-6", inside: []int{22, 23, 0, 5}, outside: []int{6, 12, 21}, next: 22},
+	} {
+		w, err := puller.ParseHourWindow(tc.window)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w.String() != tc.window {
+			t.Fatalf("window %q: string: have %q", tc.window, w.String())
+		}
+		for _, h := range tc.inside {
+			if !w.Contains(at(h)) {
+				t.Fatalf("window %q: want %d:30 inside", tc.window, h)
+			}
+		}
+		for _, h := range tc.outside {
+			if w.Contains(at(h)) {
+				t.Fatalf("window %q: want %d:30 outside", tc.window, h)
+			}
+		}
+		if len(tc.outside) == 0 {
+			continue
+		}
+		want := time.Date(2024, 5, 1+tc.nextDays, tc.next, 0, 0, 0, time.UTC)
+		if next := w.Next(at(12)); !next.Equal(want) {
+			t.Fatalf("window %q: next: want %v, have %v", tc.window, want, next)
+		}
+	}
+
+	for _, v := range []string{"5", "24-1", "a-b", "-1-2"} {
+		if _, err := puller.ParseHourWindow(v); !errors.Is(err, puller.ErrInvalidLimits) {
+			t.Fatalf("window %q: want error %v, have %v", v, puller.ErrInvalidLimits, err)
+		}
+	}
+}
+
+func TestSetLimits(t *testing.T) {
+	t.Parallel()
+
+	p := puller.New(swarm.RandAddress(t), nil, nil, nil, nil, nil, log.Noop, puller.Options{
+		Limits: puller.Limits{Bandwidth: 2},
+	})
+
+	if have := p.Limits(); have.Bandwidth != 2 {
+		t.Fatalf("bandwidth: want 2, have %v", have.Bandwidth)
+	}
+
+	want := puller.Limits{Bandwidth: 0.5, HistoricalHours: puller.HourWindow{From: 22, To: 6}}
+	if err := p.SetLimits(want); err != nil {
+		t.Fatal(err)
+	}
+	if have := p.Limits(); have != want {
+		t.Fatalf("limits: want %+v, have %+v", want, have)
+	}
+
+	for _, l := range []puller.Limits{
+		{Bandwidth: -1},
+		{HistoricalHours: puller.HourWindow{From: 24}},
+	} {
+		if err := p.SetLimits(l); !errors.Is(err, puller.ErrInvalidLimits) {
+			t.Fatalf("limits %+v: want error %v, have %v", l, puller.ErrInvalidLimits, err)
+		}
+	}
+	if have := p.Limits(); have != want {
+		t.Fatalf("limits: want %+v, have %+v", want, have)
+	}
+}
@@ -15,6 +15,8 @@ type metrics struct {
 	SyncedCounter         *prometheus.CounterVec // number of synced chunks
 	SyncWorkerErrCounter  prometheus.Counter     // count number of errors
 	MaxUintErrCounter     prometheus.Counter     // how many times we got maxuint as topmost
+	IntervalPeers         prometheus.Gauge       // number of peers with the stored intervals
+	IntervalsPruned       prometheus.Counter     // number of peers whose intervals were removed by compaction
 }
 
 func newMetrics() metrics {
@@ -51,6 +53,18 @@ func newMetrics() metrics {
 			Name:      "max_uint_errors",
 			Help:      "Total max uint errors.",
 		}),
+		IntervalPeers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "interval_peers",
+			Help:      "Number of peers with the stored sync intervals.",
+		}),
+		IntervalsPruned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "intervals_pruned",
+			Help:      "Total peers whose sync intervals were removed by the compaction.",
+		}),
 	}
 }
 
@@ -0,0 +1,49 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package puller
+
+import "context"
+
+// Pause stops the historical and the live syncing until the Resume is
+// called. The intervals being synced are finished before the pause.
+func (p *Puller) Pause() {
+	p.pauseMtx.Lock()
+	defer p.pauseMtx.Unlock()
+
+	if p.resumed == nil {
+		p.resumed = make(chan struct{})
+		p.logger.Info("syncing paused")
+	}
+}
+
+// Resume continues the syncing paused by the Pause.
+func (p *Puller) Resume() {
+	p.pauseMtx.Lock()
+	defer p.pauseMtx.Unlock()
+
+	if p.resumed != nil {
+		close(p.resumed)
+		p.resumed = nil
+		p.logger.Info("syncing resumed")
+	}
+}
+
+// waitResumed blocks until the syncing is not paused.
+func (p *Puller) waitResumed(ctx context.Context) error {
+	p.pauseMtx.Lock()
+	resumed := p.resumed
+	p.pauseMtx.Unlock()
+
+	if resumed == nil {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-resumed:
+		return nil
+	}
+}
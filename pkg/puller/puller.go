@@ -18,7 +18,6 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
-	"github.com/ethersphere/bee/v2/pkg/puller/intervalstore"
 	"github.com/ethersphere/bee/v2/pkg/pullsync"
 	"github.com/ethersphere/bee/v2/pkg/rate"
 	"github.com/ethersphere/bee/v2/pkg/storage"
@@ -45,7 +44,14 @@ const (
 )
 
 type Options struct {
-	Bins uint8
+	Bins   uint8
+	Limits Limits
+	// HistoryPeriod limits the historical syncing to the chunks stamped
+	// within the period, the full history is synced when it is zero.
+	HistoryPeriod time.Duration
+	// HistoryBins are the bins synced with the limited history,
+	// all the bins are when it is empty.
+	HistoryBins []uint8
 }
 
 type Puller struct {
@@ -75,6 +81,21 @@ type Puller struct {
 	start sync.Once
 
 	limiter *ratelimit.Limiter
+
+	limitsMtx sync.Mutex
+	limits    Limits
+	bandwidth *ratelimit.Limiter // limits the pulled chunks as per the bandwidth limit
+
+	pauseMtx sync.Mutex
+	resumed  chan struct{} // closed on resume, nil when the syncing is not paused
+
+	progressMtx sync.Mutex
+	progress    map[progressKey]*binProgress
+
+	historyPeriod time.Duration
+	historyBins   map[uint8]struct{} // nil when all the bins are synced partially
+
+	now func() time.Time
 }
 
 func New(
@@ -105,6 +126,22 @@ func New(
 		rate:        rate.New(DefaultHistRateWindow),
 		cancel:      func() { /* Noop, since the context is initialized in the Start(). */ },
 		limiter:     ratelimit.NewLimiter(ratelimit.Every(time.Second/maxChunksPerSecond), maxChunksPerSecond),
+		bandwidth:   ratelimit.NewLimiter(ratelimit.Inf, maxChunksPerSecond),
+		progress:    make(map[progressKey]*binProgress),
+		now:         time.Now,
+
+		historyPeriod: o.HistoryPeriod,
+	}
+
+	if err := p.SetLimits(o.Limits); err != nil {
+		p.logger.Error(err, "syncing limits ignored")
+	}
+
+	if o.HistoryPeriod > 0 && len(o.HistoryBins) > 0 {
+		p.historyBins = make(map[uint8]struct{}, len(o.HistoryBins))
+		for _, bin := range o.HistoryBins {
+			p.historyBins[bin] = struct{}{}
+		}
 	}
 
 	return p
@@ -112,11 +149,16 @@ func New(
 
 func (p *Puller) Start(ctx context.Context) {
 	p.start.Do(func() {
+		if err := p.updatePartialHistory(); err != nil {
+			p.logger.Error(err, "partial history update failed")
+		}
+
 		cctx, cancel := context.WithCancel(ctx)
 		p.cancel = cancel
 
-		p.wg.Add(1)
+		p.wg.Add(2)
 		go p.manage(cctx)
+		go p.compactIntervals(cctx)
 	})
 }
 
@@ -305,6 +347,8 @@ func (p *Puller) syncPeerBin(parentCtx context.Context, peer *syncPeer, bin uint
 	ctx, cancel := context.WithCancel(parentCtx)
 	peer.setBinCancel(cancel, bin)
 
+	progress := p.startProgress(peer, bin, cursor)
+
 	sync := func(isHistorical bool, address swarm.Address, start uint64) {
 		p.metrics.SyncWorkerCounter.Inc()
 
@@ -315,7 +359,17 @@ func (p *Puller) syncPeerBin(parentCtx context.Context, peer *syncPeer, bin uint
 		var err error
 
 		for {
+			if err := p.waitResumed(ctx); err != nil {
+				loggerV2.Debug("syncWorker context cancelled", "peer_address", address, "bin", bin)
+				return
+			}
+
 			if isHistorical { // override start with the next interval if historical syncing
+				if err := p.waitHistoricalWindow(ctx); err != nil {
+					loggerV2.Debug("syncWorker context cancelled", "peer_address", address, "bin", bin)
+					return
+				}
+
 				start, err = p.nextPeerInterval(address, bin)
 				if err != nil {
 					p.metrics.SyncWorkerErrCounter.Inc()
@@ -339,7 +393,15 @@ func (p *Puller) syncPeerBin(parentCtx context.Context, peer *syncPeer, bin uint
 			p.metrics.SyncWorkerIterCounter.Inc()
 
 			syncStart := time.Now()
-			top, count, err := p.syncer.Sync(ctx, address, bin, start)
+			var (
+				top   uint64
+				count int
+			)
+			if isHistorical && p.partialBin(bin) {
+				top, count, err = p.syncer.SyncSince(ctx, address, bin, start, p.now().Add(-p.historyPeriod))
+			} else {
+				top, count, err = p.syncer.Sync(ctx, address, bin, start)
+			}
 
 			if top == math.MaxUint64 {
 				p.metrics.MaxUintErrCounter.Inc()
@@ -357,6 +419,9 @@ func (p *Puller) syncPeerBin(parentCtx context.Context, peer *syncPeer, bin uint
 			}
 
 			_ = p.limiter.WaitN(ctx, count)
+			_ = p.waitBandwidth(ctx, count)
+
+			progress.rate.Add(count)
 
 			if isHistorical {
 				p.metrics.SyncedCounter.WithLabelValues("historical").Add(float64(count))
@@ -386,7 +451,11 @@ func (p *Puller) syncPeerBin(parentCtx context.Context, peer *syncPeer, bin uint
 
 	peer.wg.Add(1)
 	p.wg.Add(1)
-	go sync(false, peer.address, cursor+1)
+	go func() {
+		// the live syncing runs until the bin syncing is cancelled
+		defer p.stopProgress(progress)
+		sync(false, peer.address, cursor+1)
+	}()
 }
 
 func (p *Puller) Close() error {
@@ -406,83 +475,91 @@ func (p *Puller) Close() error {
 	return nil
 }
 
-func (p *Puller) addPeerInterval(peer swarm.Address, bin uint8, start, end uint64) (err error) {
+func (p *Puller) addPeerInterval(peer swarm.Address, bin uint8, start, end uint64) error {
 	p.intervalMtx.Lock()
 	defer p.intervalMtx.Unlock()
 
-	peerStreamKey := peerIntervalKey(peer, bin)
-	i, err := p.getOrCreateInterval(peer, bin)
+	r, err := p.loadIntervals(peer)
 	if err != nil {
 		return err
 	}
 
-	i.Add(start, end)
+	r.interval(bin).Add(start, end)
 
-	return p.statestore.Put(peerStreamKey, i)
+	return p.saveIntervals(peer, r)
 }
 
 func (p *Puller) getPeerEpoch(peer swarm.Address) (uint64, error) {
 	p.intervalMtx.Lock()
 	defer p.intervalMtx.Unlock()
 
-	var epoch uint64
-	err := p.statestore.Get(peerEpochKey(peer), &epoch)
+	r, err := p.loadIntervals(peer)
 	if err != nil {
-		if errors.Is(err, storage.ErrNotFound) {
-			return 0, nil
-		}
 		return 0, err
 	}
 
-	return epoch, nil
+	return r.epoch, nil
 }
 
 func (p *Puller) setPeerEpoch(peer swarm.Address, epoch uint64) error {
 	p.intervalMtx.Lock()
 	defer p.intervalMtx.Unlock()
 
-	return p.statestore.Put(peerEpochKey(peer), epoch)
+	r, err := p.loadIntervals(peer)
+	if err != nil {
+		return err
+	}
+
+	r.epoch = epoch
+
+	return p.saveIntervals(peer, r)
 }
 
-func (p *Puller) resetPeerIntervals(peer swarm.Address) (err error) {
+func (p *Puller) resetPeerIntervals(peer swarm.Address) error {
 	p.intervalMtx.Lock()
 	defer p.intervalMtx.Unlock()
 
-	for bin := uint8(0); bin < p.bins; bin++ {
-		err = errors.Join(err, p.statestore.Delete(peerIntervalKey(peer, bin)))
+	r, err := p.loadIntervals(peer)
+	if err != nil {
+		return err
 	}
 
-	return
+	clear(r.bins)
+
+	return p.saveIntervals(peer, r)
 }
 
-func (p *Puller) resetIntervals(oldRadius uint8) (err error) {
+func (p *Puller) resetIntervals(oldRadius uint8) error {
 	p.intervalMtx.Lock()
 	defer p.intervalMtx.Unlock()
 
-	var deleteKeys []string
+	updated := make(map[string]*peerIntervals)
 
-	for bin := uint8(0); bin < p.bins; bin++ {
-		err = errors.Join(err,
-			p.statestore.Iterate(binIntervalKey(bin), func(key, _ []byte) (stop bool, err error) {
+	err := p.statestore.Iterate(peerIntervalsPrefix, func(key, val []byte) (stop bool, err error) {
+		r := newPeerIntervals()
+		if err := r.UnmarshalBinary(val); err != nil {
+			// the corrupted records are removed by the compaction
+			return false, nil
+		}
 
-				po := swarm.Proximity(addressFromKey(key).Bytes(), p.base.Bytes())
+		po := swarm.Proximity(addressFromKey(key).Bytes(), p.base.Bytes())
 
-				// 1. for neighbor peers, only reset the bins below the current radius
-				// 2. for non-neighbor peers, we must reset the entire history
-				if po >= oldRadius {
-					if bin < oldRadius {
-						deleteKeys = append(deleteKeys, string(key))
-					}
-				} else {
-					deleteKeys = append(deleteKeys, string(key))
-				}
-				return false, nil
-			}),
-		)
-	}
+		n := len(r.bins)
+		for bin := range r.bins {
+			// 1. for neighbor peers, only reset the bins below the current radius
+			// 2. for non-neighbor peers, we must reset the entire history
+			if po < oldRadius || bin < oldRadius {
+				delete(r.bins, bin)
+			}
+		}
+		if len(r.bins) != n {
+			updated[string(key)] = r
+		}
+		return false, nil
+	})
 
-	for _, k := range deleteKeys {
-		err = errors.Join(err, p.statestore.Delete(k))
+	for k, r := range updated {
+		err = errors.Join(err, p.statestore.Put(k, r))
 	}
 
 	return err
@@ -492,51 +569,15 @@ func (p *Puller) nextPeerInterval(peer swarm.Address, bin uint8) (uint64, error)
 	p.intervalMtx.Lock()
 	defer p.intervalMtx.Unlock()
 
-	i, err := p.getOrCreateInterval(peer, bin)
+	r, err := p.loadIntervals(peer)
 	if err != nil {
 		return 0, err
 	}
 
-	start, _, _ := i.Next(0)
+	start, _, _ := r.interval(bin).Next(0)
 	return start, nil
 }
 
-// Must be called underlock.
-func (p *Puller) getOrCreateInterval(peer swarm.Address, bin uint8) (*intervalstore.Intervals, error) {
-	// check that an interval entry exists
-	key := peerIntervalKey(peer, bin)
-	itv := &intervalstore.Intervals{}
-	if err := p.statestore.Get(key, itv); err != nil {
-		if errors.Is(err, storage.ErrNotFound) {
-			// key interval values are ALWAYS > 0
-			itv = intervalstore.NewIntervals(1)
-			if err := p.statestore.Put(key, itv); err != nil {
-				return nil, err
-			}
-		} else {
-			return nil, err
-		}
-	}
-	return itv, nil
-}
-
-func peerEpochKey(peer swarm.Address) string {
-	return fmt.Sprintf("%s_epoch_%s", IntervalPrefix, peer.ByteString())
-}
-
-func peerIntervalKey(peer swarm.Address, bin uint8) string {
-	return fmt.Sprintf("%s_%03d_%s", IntervalPrefix, bin, peer.ByteString())
-}
-
-func binIntervalKey(bin uint8) string {
-	return fmt.Sprintf("%s_%03d", IntervalPrefix, bin)
-}
-
-func addressFromKey(key []byte) swarm.Address {
-	addr := key[len(fmt.Sprintf("%s_%03d_", IntervalPrefix, 0)):]
-	return swarm.NewAddress(addr)
-}
-
 type syncPeer struct {
 	address        swarm.Address
 	binCancelFuncs map[uint8]func() // slice of context cancel funcs for historical sync. index is bin
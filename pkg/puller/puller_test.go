@@ -7,13 +7,11 @@ package puller_test
 import (
 	"context"
 	"errors"
-	"fmt"
 	"testing"
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/puller"
-	"github.com/ethersphere/bee/v2/pkg/puller/intervalstore"
 	mockps "github.com/ethersphere/bee/v2/pkg/pullsync/mock"
 	"github.com/ethersphere/bee/v2/pkg/spinlock"
 	"github.com/ethersphere/bee/v2/pkg/statestore/leveldb"
@@ -57,6 +55,61 @@ func TestOneSync(t *testing.T) {
 	waitSyncCalledBins(t, pullsync, addr, 1, 2)
 }
 
+// test that the syncing progress is reported per bin
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr    = swarm.RandAddress(t)
+		cursors = []uint64{1000, 1000, 1000}
+		replies = []mockps.SyncReply{
+			{Bin: 1, Start: 1, Topmost: 1000, Count: 1000, Peer: addr},
+			{Bin: 2, Start: 1, Topmost: 600, Count: 600, Peer: addr},
+		}
+	)
+
+	p, _, kad, pullsync := newPuller(t, opts{
+		kad: []kadMock.Option{
+			kadMock.WithEachPeerRevCalls(
+				kadMock.AddrTuple{Addr: addr, PO: 1},
+			),
+		},
+		pullSync: []mockps.Option{mockps.WithCursors(cursors, 0), mockps.WithReplies(replies...)},
+		bins:     3,
+		rs:       resMock.NewReserve(resMock.WithRadius(1)),
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	kad.Trigger()
+
+	waitSyncCalledBins(t, pullsync, addr, 1, 2)
+
+	var status []puller.BinStatus
+	err := spinlock.Wait(time.Second, func() bool {
+		var err error
+		status, err = p.Status()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(status) == 2 && status[0].Remaining == 0 && status[1].Remaining == 400
+	})
+	if err != nil {
+		t.Fatalf("unexpected status %+v", status)
+	}
+
+	for i, s := range status {
+		if !s.Peer.Equal(addr) || s.PO != 1 || s.Bin != uint8(i+1) || s.Cursor != 1000 {
+			t.Fatalf("unexpected bin status %+v", s)
+		}
+		if s.Rate <= 0 {
+			t.Fatalf("bin %d: want positive rate, have %v", s.Bin, s.Rate)
+		}
+	}
+	if status[0].ETA != 0 || status[1].ETA <= 0 {
+		t.Fatalf("unexpected eta %v, %v", status[0].ETA, status[1].ETA)
+	}
+}
+
 func TestSyncOutsideDepth(t *testing.T) {
 	t.Parallel()
 
@@ -320,11 +373,9 @@ func TestBinReset(t *testing.T) {
 	kad.Trigger()
 	time.Sleep(100 * time.Millisecond)
 
-	if err := s.Get(fmt.Sprintf("sync|001|%s", addr.ByteString()), nil); !errors.Is(err, storage.ErrNotFound) {
-		t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
-	}
+	checkIntervals(t, s, addr, "[[1 1]]", 1)
 
-	if err := s.Get(fmt.Sprintf("sync|000|%s", addr.ByteString()), nil); !errors.Is(err, storage.ErrNotFound) {
+	if _, err := puller.PeerIntervals(s, addr, 0); !errors.Is(err, storage.ErrNotFound) {
 		t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
 	}
 }
@@ -507,6 +558,39 @@ func TestContinueSyncing(t *testing.T) {
 	}
 }
 
+// test that the paused puller does not sync until it is resumed
+func TestPauseResume(t *testing.T) {
+	t.Parallel()
+
+	addr := swarm.RandAddress(t)
+
+	p, _, kad, pullsync := newPuller(t, opts{
+		kad: []kadMock.Option{
+			kadMock.WithEachPeerRevCalls(kadMock.AddrTuple{Addr: addr, PO: 0}),
+		},
+		pullSync: []mockps.Option{
+			mockps.WithCursors([]uint64{1}, 0),
+			mockps.WithReplies(mockps.SyncReply{Start: 1, Topmost: 1, Peer: addr}),
+		},
+		bins: 1,
+		rs:   resMock.NewReserve(resMock.WithRadius(0)),
+	})
+
+	p.Pause()
+
+	time.Sleep(100 * time.Millisecond)
+	kad.Trigger()
+
+	waitCursorsCalled(t, pullsync, addr)
+	time.Sleep(100 * time.Millisecond)
+	if calls := pullsync.SyncCalls(addr); len(calls) != 0 {
+		t.Fatalf("want no sync calls while paused, got %d", len(calls))
+	}
+
+	p.Resume()
+	waitSync(t, pullsync, addr)
+}
+
 func TestPeerGone(t *testing.T) {
 	t.Parallel()
 
@@ -559,13 +643,11 @@ func TestPeerGone(t *testing.T) {
 
 func checkIntervals(t *testing.T, s storage.StateStorer, addr swarm.Address, expInterval string, bin uint8) {
 	t.Helper()
-	key := puller.PeerIntervalKey(addr, bin)
-	i := &intervalstore.Intervals{}
-	err := s.Get(key, i)
+	v, err := puller.PeerIntervals(s, addr, bin)
 	if err != nil {
 		t.Fatalf("error getting interval for bin %d: %v", bin, err)
 	}
-	if v := i.String(); v != expInterval {
+	if v != expInterval {
 		t.Fatalf("got unexpected interval: %s, want %s bin %d", v, expInterval, bin)
 	}
 }
@@ -635,11 +717,13 @@ func waitSyncCalledBins(t *testing.T, ps *mockps.PullSyncMock, addr swarm.Addres
 }
 
 type opts struct {
-	pullSync     []mockps.Option
-	kad          []kadMock.Option
-	rs           *resMock.ReserveStore
-	bins         uint8
-	syncSleepDur time.Duration
+	pullSync      []mockps.Option
+	kad           []kadMock.Option
+	rs            *resMock.ReserveStore
+	bins          uint8
+	syncSleepDur  time.Duration
+	historyPeriod time.Duration
+	historyBins   []uint8
 }
 
 func newPuller(t *testing.T, ops opts) (*puller.Puller, storage.StateStorer, *kadMock.Mock, *mockps.PullSyncMock) {
@@ -656,7 +740,9 @@ func newPuller(t *testing.T, ops opts) (*puller.Puller, storage.StateStorer, *ka
 	kad := kadMock.NewMockKademlia(ops.kad...)
 
 	o := puller.Options{
-		Bins: ops.bins,
+		Bins:          ops.bins,
+		HistoryPeriod: ops.historyPeriod,
+		HistoryBins:   ops.historyBins,
 	}
 	p := puller.New(swarm.RandAddress(t), s, kad, ops.rs, ps, nil, logger, o)
 	p.Start(context.Background())
@@ -680,7 +766,9 @@ func newPulleAddr(t *testing.T, addr swarm.Address, ops opts) (*puller.Puller, s
 	kad := kadMock.NewMockKademlia(ops.kad...)
 
 	o := puller.Options{
-		Bins: ops.bins,
+		Bins:          ops.bins,
+		HistoryPeriod: ops.historyPeriod,
+		HistoryBins:   ops.historyBins,
 	}
 	p := puller.New(addr, s, kad, ops.rs, ps, nil, logger, o)
 	p.Start(context.Background())
@@ -698,7 +786,9 @@ func newPullerWithState(t *testing.T, s storage.StateStorer, ops opts) (*puller.
 	logger := log.Noop
 
 	o := puller.Options{
-		Bins: ops.bins,
+		Bins:          ops.bins,
+		HistoryPeriod: ops.historyPeriod,
+		HistoryBins:   ops.historyBins,
 	}
 	p := puller.New(swarm.RandAddress(t), s, kad, ops.rs, ps, nil, logger, o)
 	p.Start(context.Background())
@@ -0,0 +1,135 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package puller
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/rate"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// progressRateWindow is the window of the per bin syncing throughput.
+const progressRateWindow = time.Minute
+
+// BinStatus is the syncing progress of a bin of a peer.
+type BinStatus struct {
+	Peer swarm.Address
+	// PO is the proximity order of the peer.
+	PO  uint8
+	Bin uint8
+	// Cursor is the bin ID of the peer up to which the historical syncing runs.
+	Cursor uint64
+	// Remaining is the estimated number of the bin IDs
+	// up to the cursor which are not synced yet.
+	Remaining uint64
+	// Rate is the number of the pulled chunks per second.
+	Rate float64
+	// ETA is the estimated duration until the historical syncing is complete.
+	// It is zero when the syncing is complete or the estimate is not available.
+	ETA time.Duration
+}
+
+// binProgress tracks the syncing of a bin of a peer.
+type binProgress struct {
+	peer   swarm.Address
+	po     uint8
+	bin    uint8
+	cursor uint64
+	rate   *rate.Rate
+}
+
+type progressKey struct {
+	peer string
+	bin  uint8
+}
+
+// startProgress starts tracking the syncing of the bin of the peer.
+func (p *Puller) startProgress(peer *syncPeer, bin uint8, cursor uint64) *binProgress {
+	bp := &binProgress{
+		peer:   peer.address,
+		po:     peer.po,
+		bin:    bin,
+		cursor: cursor,
+		rate:   rate.New(progressRateWindow),
+	}
+
+	p.progressMtx.Lock()
+	defer p.progressMtx.Unlock()
+	p.progress[progressKey{peer.address.ByteString(), bin}] = bp
+	return bp
+}
+
+// stopProgress stops tracking the syncing of the bin of the peer.
+func (p *Puller) stopProgress(bp *binProgress) {
+	p.progressMtx.Lock()
+	defer p.progressMtx.Unlock()
+
+	key := progressKey{bp.peer.ByteString(), bp.bin}
+	if p.progress[key] == bp {
+		delete(p.progress, key)
+	}
+}
+
+// Status returns the syncing progress of all the bins being synced,
+// ordered by the proximity order of the peers, nearest first.
+func (p *Puller) Status() ([]BinStatus, error) {
+	p.progressMtx.Lock()
+	progress := make([]*binProgress, 0, len(p.progress))
+	for _, bp := range p.progress {
+		progress = append(progress, bp)
+	}
+	p.progressMtx.Unlock()
+
+	status := make([]BinStatus, 0, len(progress))
+	for _, bp := range progress {
+		covered, err := p.coveredInterval(bp.peer, bp.bin, bp.cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		s := BinStatus{
+			Peer:      bp.peer,
+			PO:        bp.po,
+			Bin:       bp.bin,
+			Cursor:    bp.cursor,
+			Remaining: bp.cursor - min(covered, bp.cursor),
+			Rate:      bp.rate.Rate(),
+		}
+		if s.Remaining > 0 && s.Rate > 0 {
+			s.ETA = time.Duration(float64(s.Remaining) / s.Rate * float64(time.Second)).Round(time.Second)
+		}
+		status = append(status, s)
+	}
+
+	slices.SortFunc(status, func(a, b BinStatus) int {
+		if a.PO != b.PO {
+			return int(b.PO) - int(a.PO)
+		}
+		if c := a.Peer.Compare(b.Peer); c != 0 {
+			return c
+		}
+		return int(a.Bin) - int(b.Bin)
+	})
+	return status, nil
+}
+
+// coveredInterval returns the number of the synced bin IDs up to the cursor.
+func (p *Puller) coveredInterval(peer swarm.Address, bin uint8, cursor uint64) (uint64, error) {
+	p.intervalMtx.Lock()
+	defer p.intervalMtx.Unlock()
+
+	r, err := p.loadIntervals(peer)
+	if err != nil {
+		return 0, err
+	}
+
+	i, ok := r.bins[bin]
+	if !ok {
+		return 0, nil
+	}
+	return i.Covered(cursor), nil
+}
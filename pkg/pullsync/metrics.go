@@ -20,6 +20,7 @@ type metrics struct {
 	SentWanted           prometheus.Counter     // number of chunks wanted
 	Sent                 prometheus.Counter     // number of chunks sent
 	DuplicateRuid        prometheus.Counter     // number of duplicate RUID requests we got
+	SkippedOld           prometheus.Counter     // number of chunks skipped as older than the partial history
 	LastReceived         *prometheus.CounterVec // last timestamp of the received chunks per bin
 }
 
@@ -27,6 +28,12 @@ func newMetrics() metrics {
 	subsystem := "pullsync"
 
 	return metrics{
+		SkippedOld: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "chunks_skipped_old",
+			Help:      "Total chunks skipped as stamped before the partial history period.",
+		}),
 		Offered: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: m.Namespace,
 			Subsystem: subsystem,
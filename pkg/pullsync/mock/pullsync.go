@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/pullsync"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -48,6 +49,13 @@ type SyncReply struct {
 	Count   int
 }
 
+// SinceCall is the recorded call of the SyncSince.
+type SinceCall struct {
+	Peer  swarm.Address
+	Bin   uint8
+	Since time.Time
+}
+
 type PullSyncMock struct {
 	mtx             sync.Mutex
 	syncCalls       []SyncReply
@@ -56,6 +64,7 @@ type PullSyncMock struct {
 	epoch           uint64
 	getCursorsPeers []swarm.Address
 	replies         map[string][]SyncReply
+	sinceCalls      []SinceCall
 
 	quit chan struct{}
 }
@@ -90,6 +99,14 @@ func (p *PullSyncMock) Sync(ctx context.Context, peer swarm.Address, bin uint8,
 	return 0, 0, ctx.Err()
 }
 
+func (p *PullSyncMock) SyncSince(ctx context.Context, peer swarm.Address, bin uint8, start uint64, since time.Time) (topmost uint64, count int, err error) {
+	p.mtx.Lock()
+	p.sinceCalls = append(p.sinceCalls, SinceCall{Peer: peer, Bin: bin, Since: since})
+	p.mtx.Unlock()
+
+	return p.Sync(ctx, peer, bin, start)
+}
+
 func (p *PullSyncMock) GetCursors(_ context.Context, peer swarm.Address) ([]uint64, uint64, error) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
@@ -115,6 +132,19 @@ func (p *PullSyncMock) SyncCalls(peer swarm.Address) (res []SyncReply) {
 	return res
 }
 
+// SinceCalls returns the calls of the SyncSince for the peer.
+func (p *PullSyncMock) SinceCalls(peer swarm.Address) (res []SinceCall) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for _, v := range p.sinceCalls {
+		if v.Peer.Equal(peer) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
 func (p *PullSyncMock) CursorsCalls(peer swarm.Address) bool {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
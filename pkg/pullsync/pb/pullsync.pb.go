@@ -113,6 +113,7 @@ func (m *Ack) GetEpoch() uint64 {
 type Get struct {
 	Bin   int32  `protobuf:"varint,1,opt,name=Bin,proto3" json:"Bin,omitempty"`
 	Start uint64 `protobuf:"varint,2,opt,name=Start,proto3" json:"Start,omitempty"`
+	Since uint64 `protobuf:"varint,3,opt,name=Since,proto3" json:"Since,omitempty"`
 }
 
 func (m *Get) Reset()         { *m = Get{} }
@@ -162,6 +163,13 @@ func (m *Get) GetStart() uint64 {
 	return 0
 }
 
+func (m *Get) GetSince() uint64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
 type Chunk struct {
 	Address   []byte `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
 	BatchID   []byte `protobuf:"bytes,2,opt,name=BatchID,proto3" json:"BatchID,omitempty"`
@@ -391,27 +399,28 @@ func init() {
 func init() { proto.RegisterFile("pullsync.proto", fileDescriptor_d1dee042cf9c065c) }
 
 var fileDescriptor_d1dee042cf9c065c = []byte{
-	// 319 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x91, 0xbb, 0x4e, 0xc3, 0x30,
-	0x14, 0x86, 0xeb, 0x3a, 0x29, 0xe5, 0x50, 0x01, 0xb2, 0x18, 0x32, 0x54, 0x51, 0x64, 0x21, 0x91,
-	0x85, 0x0e, 0x20, 0x1e, 0xa0, 0x69, 0x11, 0x97, 0x01, 0x24, 0x17, 0x81, 0x60, 0x73, 0xd3, 0x54,
-	0xa9, 0x68, 0x63, 0xcb, 0x76, 0x91, 0xfa, 0x16, 0x3c, 0x16, 0x63, 0x47, 0x46, 0xd4, 0xbc, 0x08,
-	0x8a, 0x1b, 0xd3, 0x8d, 0xed, 0x7c, 0xe7, 0xf6, 0xfb, 0x3f, 0x86, 0x43, 0xb9, 0x9c, 0xcf, 0xf5,
-	0xaa, 0x48, 0x7b, 0x52, 0x09, 0x23, 0x48, 0xdb, 0x31, 0xf5, 0x01, 0x8f, 0x56, 0x05, 0xbd, 0x02,
-	0xdc, 0x4f, 0xdf, 0x49, 0x00, 0x7b, 0x83, 0xa5, 0xd2, 0x42, 0xe9, 0x00, 0x45, 0x38, 0xf6, 0x98,
-	0x43, 0x72, 0x02, 0xfe, 0xb5, 0x14, 0x69, 0x1e, 0x34, 0x23, 0x14, 0x7b, 0x6c, 0x0b, 0xf4, 0x1c,
-	0xf0, 0x4d, 0x66, 0xc8, 0x31, 0xe0, 0x64, 0x56, 0x04, 0x28, 0x42, 0xb1, 0xcf, 0xaa, 0xb0, 0x6a,
-	0x1f, 0x19, 0xae, 0x8c, 0x6b, 0xb7, 0x40, 0x5f, 0xc1, 0x1f, 0xe4, 0xcb, 0xc2, 0xea, 0xf4, 0x27,
-	0x13, 0x95, 0x69, 0x6d, 0x87, 0x3a, 0xcc, 0x61, 0x55, 0x49, 0xb8, 0x49, 0xf3, 0xbb, 0xa1, 0x1d,
-	0xed, 0x30, 0x87, 0xa4, 0x0b, 0xfb, 0x23, 0xc3, 0x17, 0xf2, 0x96, 0xeb, 0x3c, 0xc0, 0xb6, 0xb6,
-	0x4b, 0xd0, 0x7b, 0xf0, 0x1f, 0xa7, 0xd3, 0x4c, 0x55, 0x0b, 0x9e, 0x84, 0x5c, 0x08, 0x6d, 0xec,
-	0x6a, 0x8f, 0x39, 0x24, 0x67, 0xd0, 0xb2, 0xea, 0x3a, 0x68, 0x46, 0x38, 0x3e, 0xb8, 0x38, 0xea,
-	0xfd, 0x5d, 0xc5, 0xe6, 0x59, 0x5d, 0xa6, 0xa7, 0xe0, 0xbd, 0xf0, 0xc2, 0x54, 0x8a, 0xc9, 0xcc,
-	0x3c, 0x67, 0xa9, 0x11, 0xaa, 0x7e, 0xe7, 0x2e, 0x41, 0x1f, 0xa0, 0x3d, 0xcc, 0xe6, 0xb3, 0x8f,
-	0x4c, 0xad, 0xfe, 0xf1, 0x43, 0xc0, 0x1b, 0x72, 0xc3, 0x6b, 0x33, 0x36, 0xae, 0x8f, 0xb3, 0x90,
-	0xb5, 0x8b, 0x2d, 0x24, 0xdd, 0xaf, 0x4d, 0x88, 0xd6, 0x9b, 0x10, 0xfd, 0x6c, 0x42, 0xf4, 0x59,
-	0x86, 0x8d, 0x75, 0x19, 0x36, 0xbe, 0xcb, 0xb0, 0xf1, 0xd6, 0x94, 0xe3, 0x71, 0xcb, 0x7e, 0xdc,
-	0xe5, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x02, 0x2f, 0x9a, 0xca, 0x01, 0x00, 0x00,
+	// 326 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x91, 0xbf, 0x4e, 0xf3, 0x30,
+	0x14, 0xc5, 0xeb, 0x3a, 0xe9, 0xd7, 0xef, 0x52, 0x01, 0xb2, 0x18, 0x32, 0x54, 0x51, 0x64, 0x21,
+	0x91, 0xa9, 0x03, 0x88, 0x07, 0x68, 0x5a, 0xc4, 0x9f, 0x01, 0x24, 0x17, 0x81, 0x60, 0x73, 0x53,
+	0x57, 0x89, 0x68, 0x63, 0xcb, 0x76, 0x91, 0xfa, 0x16, 0x3c, 0x16, 0x63, 0x47, 0x46, 0xd4, 0xbc,
+	0x08, 0x8a, 0x9b, 0xd0, 0x8d, 0xed, 0xfe, 0xce, 0xbd, 0x3a, 0xd7, 0xe7, 0x1a, 0x0e, 0xd5, 0x6a,
+	0xb1, 0x30, 0xeb, 0x22, 0x1d, 0x28, 0x2d, 0xad, 0x24, 0xdd, 0x86, 0xa9, 0x0f, 0x78, 0xb2, 0x2e,
+	0xe8, 0x25, 0xe0, 0x61, 0xfa, 0x46, 0x02, 0xf8, 0x37, 0x5a, 0x69, 0x23, 0xb5, 0x09, 0x50, 0x84,
+	0x63, 0x8f, 0x35, 0x48, 0x4e, 0xc0, 0xbf, 0x52, 0x32, 0xcd, 0x82, 0x76, 0x84, 0x62, 0x8f, 0xed,
+	0x80, 0x8e, 0x00, 0x5f, 0x0b, 0x4b, 0x8e, 0x01, 0x27, 0x79, 0x11, 0xa0, 0x08, 0xc5, 0x3e, 0xab,
+	0xca, 0x6a, 0x7c, 0x62, 0xb9, 0xb6, 0xcd, 0xb8, 0x03, 0xa7, 0xe6, 0x45, 0x2a, 0x02, 0x5c, 0xab,
+	0x15, 0xd0, 0x17, 0xf0, 0x47, 0xd9, 0xaa, 0x70, 0xdb, 0x87, 0xb3, 0x99, 0x16, 0xc6, 0x38, 0xab,
+	0x1e, 0x6b, 0xb0, 0xea, 0x24, 0xdc, 0xa6, 0xd9, 0xed, 0xd8, 0x19, 0xf6, 0x58, 0x83, 0xa4, 0x0f,
+	0xff, 0x27, 0x96, 0x2f, 0xd5, 0x0d, 0x37, 0x99, 0xb3, 0xed, 0xb1, 0xbd, 0x40, 0xef, 0xc0, 0x7f,
+	0x98, 0xcf, 0x85, 0xae, 0x0c, 0x1e, 0xa5, 0x5a, 0x4a, 0x63, 0x9d, 0xb5, 0xc7, 0x1a, 0x24, 0x67,
+	0xd0, 0x71, 0xdb, 0x4d, 0xd0, 0x8e, 0x70, 0x7c, 0x70, 0x7e, 0x34, 0xf8, 0xbd, 0x95, 0xd3, 0x59,
+	0xdd, 0xa6, 0xa7, 0xe0, 0x3d, 0xf3, 0xc2, 0x56, 0x1b, 0x93, 0xdc, 0x3e, 0x89, 0xd4, 0x4a, 0x5d,
+	0xbf, 0x73, 0x2f, 0xd0, 0x7b, 0xe8, 0x8e, 0xc5, 0x22, 0x7f, 0x17, 0x7a, 0xfd, 0x47, 0x1e, 0x02,
+	0xde, 0x98, 0x5b, 0x5e, 0x87, 0x71, 0x75, 0x7d, 0xb2, 0xa5, 0xaa, 0x53, 0xec, 0x20, 0xe9, 0x7f,
+	0x6e, 0x43, 0xb4, 0xd9, 0x86, 0xe8, 0x7b, 0x1b, 0xa2, 0x8f, 0x32, 0x6c, 0x6d, 0xca, 0xb0, 0xf5,
+	0x55, 0x86, 0xad, 0xd7, 0xb6, 0x9a, 0x4e, 0x3b, 0xee, 0x3b, 0x2f, 0x7e, 0x06, 0x00, 0x30, 0xbe,
+	0x8e, 0xc9, 0xe0, 0x01, 0x00, 0x00,
 }
 
 func (m *Syn) Marshal() (dAtA []byte, err error) {
@@ -503,6 +512,11 @@ func (m *Get) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Since != 0 {
+		i = encodeVarintPullsync(dAtA, i, uint64(m.Since))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Start != 0 {
 		i = encodeVarintPullsync(dAtA, i, uint64(m.Start))
 		i--
@@ -727,6 +741,9 @@ func (m *Get) Size() (n int) {
 	if m.Start != 0 {
 		n += 1 + sovPullsync(uint64(m.Start))
 	}
+	if m.Since != 0 {
+		n += 1 + sovPullsync(uint64(m.Since))
+	}
 	return n
 }
 
@@ -1077,6 +1094,25 @@ func (m *Get) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
+			}
+			m.Since = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPullsync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Since |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPullsync(dAtA[iNdEx:])
@@ -8,6 +8,7 @@ package pullsync
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -60,6 +61,9 @@ type Interface interface {
 	// It returns the BinID of highest chunk that was synced from the given
 	// batch and the total number of chunks the downstream peer has sent.
 	Sync(ctx context.Context, peer swarm.Address, bin uint8, start uint64) (topmost uint64, count int, err error)
+	// SyncSince is like Sync, but only the chunks stamped at or after the
+	// since time are synced. The topmost BinID covers the skipped chunks.
+	SyncSince(ctx context.Context, peer swarm.Address, bin uint8, start uint64, since time.Time) (topmost uint64, count int, err error)
 	// GetCursors retrieves all cursors from a downstream peer.
 	GetCursors(ctx context.Context, peer swarm.Address) ([]uint64, uint64, error)
 }
@@ -114,8 +118,9 @@ func (s *Syncer) Protocol() p2p.ProtocolSpec {
 		Version: protocolVersion,
 		StreamSpecs: []p2p.StreamSpec{
 			{
-				Name:    streamName,
-				Handler: s.handler,
+				Name:         streamName,
+				Handler:      s.handler,
+				Compressible: true,
 			},
 			{
 				Name:    cursorStreamName,
@@ -229,6 +234,12 @@ func (s *Syncer) handler(streamCtx context.Context, p p2p.Peer, stream p2p.Strea
 // It returns the BinID of highest chunk that was synced from the given
 // batch and the total number of chunks the downstream peer has sent.
 func (s *Syncer) Sync(ctx context.Context, peer swarm.Address, bin uint8, start uint64) (uint64, int, error) {
+	return s.SyncSince(ctx, peer, bin, start, time.Time{})
+}
+
+// SyncSince syncs a batch of chunks stamped at or after the since time.
+// The zero since time syncs all the chunks.
+func (s *Syncer) SyncSince(ctx context.Context, peer swarm.Address, bin uint8, start uint64, since time.Time) (uint64, int, error) {
 
 	stream, err := s.streamer.NewStream(ctx, peer, nil, protocolName, protocolVersion, streamName)
 	if err != nil {
@@ -245,7 +256,12 @@ func (s *Syncer) Sync(ctx context.Context, peer swarm.Address, bin uint8, start
 
 	w, r := protobuf.NewWriterAndReader(stream)
 
-	rangeMsg := &pb.Get{Bin: int32(bin), Start: start}
+	var sinceNano uint64
+	if !since.IsZero() {
+		sinceNano = uint64(since.UnixNano())
+	}
+
+	rangeMsg := &pb.Get{Bin: int32(bin), Start: start, Since: sinceNano}
 	if err = w.WriteMsgWithContext(ctx, rangeMsg); err != nil {
 		return 0, 0, fmt.Errorf("write get range: %w", err)
 	}
@@ -350,6 +366,12 @@ func (s *Syncer) Sync(ctx context.Context, peer swarm.Address, bin uint8, start
 
 		delete(wantChunks, wantChunkID)
 
+		// the peers not supporting the partial history offer all the chunks
+		if sinceNano > 0 && binary.BigEndian.Uint64(stamp.Timestamp()) < sinceNano {
+			s.metrics.SkippedOld.Inc()
+			continue
+		}
+
 		chunk, err := s.validStamp(newChunk.WithStamp(stamp))
 		if err != nil {
 			s.logger.Debug("unverified stamp", "error", err, "peer_address", peer, "chunk_address", newChunk)
@@ -382,16 +404,17 @@ func (s *Syncer) Sync(ctx context.Context, peer swarm.Address, bin uint8, start
 		s.metrics.Delivered.Add(float64(len(chunksToPut)))
 		s.metrics.LastReceived.WithLabelValues(fmt.Sprintf("%d", bin)).Add(float64(len(chunksToPut)))
 
-		for _, c := range chunksToPut {
-			if err := s.store.ReservePutter().Put(ctx, c); err != nil {
-				// in case of these errors, no new items are added to the storage, so it
-				// is safe to continue with the next chunk
-				if errors.Is(err, storage.ErrOverwriteNewerChunk) {
-					s.logger.Debug("overwrite newer chunk", "error", err, "peer_address", peer, "chunk", c)
-					chunkErr = errors.Join(chunkErr, err)
-					continue
-				}
-				return 0, 0, errors.Join(chunkErr, err)
+		errs, err := storage.PutMany(ctx, s.store.ReservePutter(), chunksToPut)
+		if err != nil {
+			return 0, 0, errors.Join(chunkErr, err)
+		}
+		for i, err := range errs {
+			// in case of these errors, no new items are added to the storage
+			// and the other chunks are stored
+			if err != nil {
+				s.logger.Debug("overwrite newer chunk", "error", err, "peer_address", peer, "chunk", chunksToPut[i])
+				chunkErr = errors.Join(chunkErr, err)
+				continue
 			}
 			chunksPut++
 		}
@@ -415,6 +438,16 @@ func (s *Syncer) makeOffer(ctx context.Context, rn pb.Get) (*pb.Offer, error) {
 	o.Topmost = top
 	o.Chunks = make([]*pb.Chunk, 0, len(addrs))
 	for _, v := range addrs {
+		if rn.Since > 0 {
+			stamp, err := s.store.ReserveGetStamp(v.Address, v.BatchID, v.StampHash)
+			if err != nil {
+				// the chunk is no longer in the reserve
+				continue
+			}
+			if binary.BigEndian.Uint64(stamp.Timestamp()) < rn.Since {
+				continue
+			}
+		}
 		o.Chunks = append(o.Chunks, &pb.Chunk{Address: v.Address.Bytes(), BatchID: v.BatchID, StampHash: v.StampHash})
 	}
 	return o, nil
@@ -149,6 +149,56 @@ func TestIncoming_WantAll(t *testing.T) {
 	}
 }
 
+func TestIncoming_Since(t *testing.T) {
+	t.Parallel()
+
+	var (
+		since   = time.Now().Add(-time.Hour)
+		chunks  = make([]swarm.Chunk, 4)
+		results = make([]*storer.BinC, 4)
+	)
+	for i := range chunks {
+		ts := since.Add(time.Minute)
+		if i%2 == 0 {
+			ts = since.Add(-time.Minute)
+		}
+		ch := testingc.GenerateTestRandomChunk()
+		chunks[i] = ch.WithStamp(postagetesting.MustNewStampWithTimestamp(uint64(ts.UnixNano())))
+		stampHash, _ := chunks[i].Stamp().Hash()
+		results[i] = &storer.BinC{
+			Address:   chunks[i].Address(),
+			BatchID:   chunks[i].Stamp().BatchID(),
+			BinID:     uint64(i),
+			StampHash: stampHash,
+		}
+	}
+
+	var (
+		topMost            = uint64(3)
+		ps, _              = newPullSync(t, nil, 5, mock.WithSubscribeResp(results, nil), mock.WithChunks(chunks...))
+		recorder           = streamtest.New(streamtest.WithProtocols(ps.Protocol()))
+		psClient, clientDb = newPullSync(t, recorder, 0)
+	)
+
+	topmost, count, err := psClient.SyncSince(context.Background(), swarm.ZeroAddress, 0, 0, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if topmost != topMost {
+		t.Fatalf("got offer topmost %d but want %d", topmost, topMost)
+	}
+	if count != 2 {
+		t.Fatalf("got %d chunks but want 2", count)
+	}
+
+	// only the recently stamped chunks are synced
+	haveChunks(t, clientDb, chunks[1], chunks[3])
+	if p := clientDb.PutCalls(); p != 2 {
+		t.Fatalf("want 2 puts but got %d", p)
+	}
+}
+
 func TestIncoming_WantErrors(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,69 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pusher
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	ratelimit "golang.org/x/time/rate"
+)
+
+// ErrInvalidLimits is returned when the pushing limits are malformed.
+var ErrInvalidLimits = errors.New("invalid pushing limits")
+
+// Limits are the runtime adjustable limits of the pushing of the deferred
+// uploads. The direct uploads are never limited.
+type Limits struct {
+	// Bandwidth is the maximum rate of the pushed deferred chunk
+	// data in megabytes per second. Zero means unlimited.
+	Bandwidth float64
+	// TagPriorities are the priorities of the deferred uploads by their tag.
+	// The chunks of the tags with the higher priority are pushed first, the
+	// tags without the priority have the zero priority.
+	TagPriorities map[uint32]int
+}
+
+func (l Limits) validate() error {
+	if l.Bandwidth < 0 || math.IsNaN(l.Bandwidth) || math.IsInf(l.Bandwidth, 0) {
+		return fmt.Errorf("%w: bandwidth %v", ErrInvalidLimits, l.Bandwidth)
+	}
+	return nil
+}
+
+// Limits returns the current pushing limits.
+func (s *Service) Limits() Limits {
+	s.scheduler.mu.Lock()
+	defer s.scheduler.mu.Unlock()
+
+	l := s.scheduler.limits
+	l.TagPriorities = maps.Clone(l.TagPriorities)
+	return l
+}
+
+// SetLimits replaces the pushing limits. The new limits apply
+// to the already scheduled deferred chunks as well.
+func (s *Service) SetLimits(l Limits) error {
+	if err := l.validate(); err != nil {
+		return err
+	}
+	l.TagPriorities = maps.Clone(l.TagPriorities)
+
+	s.scheduler.setLimits(l)
+	s.logger.Debug("pushing limits set", "bandwidth_mbps", l.Bandwidth, "tag_priorities", len(l.TagPriorities))
+	return nil
+}
+
+// bandwidthLimit returns the rate of the pushed
+// chunks permitted by the bandwidth limit.
+func bandwidthLimit(bandwidth float64) ratelimit.Limit {
+	if bandwidth == 0 {
+		return ratelimit.Inf
+	}
+	return ratelimit.Limit(bandwidth * 1e6 / swarm.ChunkWithSpanSize)
+}
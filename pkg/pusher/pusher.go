@@ -18,6 +18,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	"github.com/ethersphere/bee/v2/pkg/qos"
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/topology"
@@ -35,8 +36,14 @@ type Op struct {
 	Err    chan error
 	Direct bool
 	Span   opentracing.Span
+	// ReplicationFactor is the number of the neighborhood peers
+	// the direct upload is replicated to. Zero means the default.
+	ReplicationFactor uint8
+	// Class is the priority class of the direct upload.
+	Class qos.Class
 
 	identityAddress swarm.Address
+	release         func() // releases the place of the push in the gate
 }
 
 type OpChan <-chan *Op
@@ -59,6 +66,8 @@ type Service struct {
 	inflight          *inflight
 	attempts          *attempts
 	smuggler          chan OpChan
+	scheduler         *scheduler
+	gate              *qos.Gate
 }
 
 const (
@@ -88,6 +97,8 @@ func New(
 		inflight:          newInflight(),
 		attempts:          &attempts{retryCount: retryCount, attempts: make(map[string]int)},
 		smuggler:          make(chan OpChan),
+		scheduler:         newScheduler(),
+		gate:              qos.NewGate(ConcurrentPushes),
 	}
 	go p.chunksWorker(warmupTime)
 	return p
@@ -105,7 +116,6 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 
 	var (
 		ctx, cancel = context.WithCancel(context.Background())
-		sem         = make(chan struct{}, ConcurrentPushes)
 		cc          = make(chan *Op)
 	)
 
@@ -136,12 +146,9 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 			}
 
 			wg.Done()
-			<-sem
+			op.release()
 			if doRepeat {
-				select {
-				case cc <- op:
-				case <-s.quit:
-				}
+				s.scheduler.requeue(op)
 			}
 		}()
 
@@ -156,9 +163,9 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 		}
 
 		if op.Direct {
-			err = s.pushDirect(spanCtx, s.logger, op)
+			err = s.pushDirect(qos.WithClass(spanCtx, op.Class), s.logger, op)
 		} else {
-			doRepeat, err = s.pushDeferred(spanCtx, s.logger, op)
+			doRepeat, err = s.pushDeferred(qos.WithClass(spanCtx, qos.ClassBackground), s.logger, op)
 		}
 
 		if err != nil {
@@ -181,9 +188,8 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 					chunks = nil
 					continue
 				}
-				select {
-				case cc <- &Op{Chunk: ch, Direct: false}:
-				case <-s.quit:
+				// the deferred chunks are handed out by the scheduler
+				if !s.scheduler.add(&Op{Chunk: ch, Direct: false}, s.quit) {
 					return
 				}
 			case apiC := <-s.smuggler:
@@ -191,9 +197,7 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 					for {
 						select {
 						case op := <-apiC:
-							select {
-							case cc <- op:
-							case <-s.quit:
+							if !s.admit(ctx, op, op.Class, cc) {
 								return
 							}
 						case <-s.quit:
@@ -207,6 +211,20 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 		}
 	}()
 
+	go func() {
+		for {
+			op, err := s.scheduler.next(ctx)
+			if err != nil {
+				return
+			}
+			// the deferred chunks are pushed in the background class,
+			// the gate keeps the capacity for the direct uploads
+			if !s.admit(ctx, op, qos.ClassBackground, cc) {
+				return
+			}
+		}
+	}()
+
 	defer wg.Wait()
 
 	for {
@@ -214,11 +232,13 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 		case op := <-cc:
 			idAddress, err := storage.IdentityAddress(op.Chunk)
 			if err != nil {
+				op.release()
 				op.Err <- err
 				continue
 			}
 			op.identityAddress = idAddress
 			if s.inflight.set(idAddress, op.Chunk.Stamp().BatchID()) {
+				op.release()
 				if op.Direct {
 					select {
 					case op.Err <- nil:
@@ -228,13 +248,8 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 				}
 				continue
 			}
-			select {
-			case sem <- struct{}{}:
-				wg.Add(1)
-				go push(op)
-			case <-s.quit:
-				return
-			}
+			wg.Add(1)
+			go push(op)
 		case <-s.quit:
 			return
 		}
@@ -242,6 +257,23 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 
 }
 
+// admit waits for the place of the push of the class in the gate
+// and passes the op on. It returns false if the pusher is closed.
+func (s *Service) admit(ctx context.Context, op *Op, class qos.Class, cc chan<- *Op) bool {
+	release, err := s.gate.Acquire(ctx, class)
+	if err != nil {
+		return false
+	}
+	op.release = release
+	select {
+	case cc <- op:
+		return true
+	case <-s.quit:
+		release()
+		return false
+	}
+}
+
 func (s *Service) pushDeferred(ctx context.Context, logger log.Logger, op *Op) (bool, error) {
 	loggerV1 := logger.V(1).Build()
 
@@ -318,7 +350,7 @@ func (s *Service) pushDirect(ctx context.Context, logger log.Logger, op *Op) err
 		return err
 	}
 
-	switch _, err = s.pushSyncer.PushChunkToClosest(ctx, op.Chunk); {
+	switch _, err = s.pushSyncer.PushChunkToClosest(pushsync.SetReplicationFactor(ctx, op.ReplicationFactor), op.Chunk); {
 	case errors.Is(err, topology.ErrWantSelf):
 		// store the chunk
 		loggerV1.Debug("chunk stays here, i'm the closest node", "chunk_address", op.Chunk.Address())
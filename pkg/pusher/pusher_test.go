@@ -7,6 +7,7 @@ package pusher_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -410,6 +411,103 @@ func TestChunkWithInvalidStampSkipped(t *testing.T) {
 	})
 }
 
+func TestDeferredBandwidthLimit(t *testing.T) {
+	t.Parallel()
+
+	pushSyncService := pushsyncmock.New(func(ctx context.Context, chunk swarm.Chunk) (*pushsync.Receipt, error) {
+		return &pushsync.Receipt{Address: chunk.Address()}, nil
+	})
+	storer := &mockStorer{
+		chunks: make(chan swarm.Chunk),
+	}
+	pusherSvc := createPusher(t, storer, pushSyncService, defaultMockBatchStore, defaultRetryCount)
+
+	if err := pusherSvc.SetLimits(pusher.Limits{Bandwidth: -1}); !errors.Is(err, pusher.ErrInvalidLimits) {
+		t.Fatalf("got error %v, want %v", err, pusher.ErrInvalidLimits)
+	}
+
+	// ten chunks per second
+	if err := pusherSvc.SetLimits(pusher.Limits{Bandwidth: 10 * swarm.ChunkWithSpanSize / 1e6}); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	chunks := testingc.GenerateTestRandomChunks(5)
+	for _, ch := range chunks {
+		storer.chunks <- ch
+	}
+	err := spinlock.Wait(spinTimeout, func() bool {
+		for _, ch := range chunks {
+			if !storer.isReported(ch, storage.ChunkSynced) {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 350*time.Millisecond {
+		t.Fatalf("chunks pushed in %s, want the pushing spread over time", elapsed)
+	}
+}
+
+func TestDeferredTagPriorities(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		pushed []swarm.Address
+	)
+	pushSyncService := pushsyncmock.New(func(ctx context.Context, chunk swarm.Chunk) (*pushsync.Receipt, error) {
+		mu.Lock()
+		pushed = append(pushed, chunk.Address())
+		mu.Unlock()
+		return &pushsync.Receipt{Address: chunk.Address()}, nil
+	})
+	storer := &mockStorer{
+		chunks: make(chan swarm.Chunk),
+	}
+	pusherSvc := createPusher(t, storer, pushSyncService, defaultMockBatchStore, defaultRetryCount)
+
+	err := pusherSvc.SetLimits(pusher.Limits{
+		Bandwidth:     10 * swarm.ChunkWithSpanSize / 1e6,
+		TagPriorities: map[uint32]int{2: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pusherSvc.Limits().TagPriorities[2]; got != 1 {
+		t.Fatalf("got tag priority %d, want 1", got)
+	}
+
+	var chunks []swarm.Chunk
+	for _, ch := range testingc.GenerateTestRandomChunks(3) {
+		chunks = append(chunks, ch.WithTagID(1))
+	}
+	urgent := testingc.GenerateTestRandomChunk().WithTagID(2)
+	chunks = append(chunks, urgent)
+	for _, ch := range chunks {
+		storer.chunks <- ch
+	}
+
+	err = spinlock.Wait(spinTimeout, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(pushed) == len(chunks)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// the first chunk may be pushed before the others are scheduled
+	if !pushed[0].Equal(urgent.Address()) && !pushed[1].Equal(urgent.Address()) {
+		t.Fatalf("chunk of the priority tag pushed at position %d", slices.IndexFunc(pushed, urgent.Address().Equal))
+	}
+}
+
 func createPusher(
 	t *testing.T,
 	storer pusher.Storer,
@@ -0,0 +1,161 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pusher
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+
+	ratelimit "golang.org/x/time/rate"
+)
+
+// schedulerCapacity is the number of the deferred chunks held by the
+// scheduler ahead of pushing. The tag priorities reorder the chunks
+// within this window.
+const schedulerCapacity = 4 * ConcurrentPushes
+
+// scheduler spreads the pushing of the deferred chunks over time as per the
+// bandwidth limit, so that the large uploads do not starve the retrieval
+// traffic, and hands out the chunks of the higher priority tags first.
+type scheduler struct {
+	mu        sync.Mutex
+	limits    Limits
+	bandwidth *ratelimit.Limiter
+	queue     opQueue
+	seq       uint64
+
+	added   chan struct{} // signals that a chunk was added to the queue
+	removed chan struct{} // signals that a chunk was removed from the queue
+}
+
+func newScheduler() *scheduler {
+	return &scheduler{
+		bandwidth: ratelimit.NewLimiter(ratelimit.Inf, 1),
+		added:     make(chan struct{}, 1),
+		removed:   make(chan struct{}, 1),
+	}
+}
+
+// setLimits applies the limits, also to the already queued chunks.
+func (s *scheduler) setLimits(l Limits) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.limits = l
+	s.bandwidth.SetLimit(bandwidthLimit(l.Bandwidth))
+	for _, so := range s.queue {
+		so.priority = l.TagPriorities[so.op.Chunk.TagID()]
+	}
+	heap.Init(&s.queue)
+}
+
+// add queues the deferred chunk, blocking while the scheduler is full.
+// It returns false if the quit channel is closed before that.
+func (s *scheduler) add(op *Op, quit <-chan struct{}) bool {
+	for {
+		s.mu.Lock()
+		if s.queue.Len() < schedulerCapacity {
+			s.push(op)
+			s.mu.Unlock()
+			return true
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.removed:
+		case <-quit:
+			return false
+		}
+	}
+}
+
+// requeue queues the chunk to be pushed again regardless of the capacity,
+// the number of the retried chunks is bounded by the concurrent pushes.
+func (s *scheduler) requeue(op *Op) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.push(op)
+}
+
+// push must be called with the mutex held.
+func (s *scheduler) push(op *Op) {
+	s.seq++
+	heap.Push(&s.queue, &scheduledOp{
+		op:       op,
+		priority: s.limits.TagPriorities[op.Chunk.TagID()],
+		seq:      s.seq,
+	})
+	notify(s.added)
+}
+
+// next blocks until a chunk is queued and its pushing is permitted by the
+// bandwidth limit, then it returns the chunk with the highest priority.
+// It must not be called concurrently.
+func (s *scheduler) next(ctx context.Context) (*Op, error) {
+	for {
+		s.mu.Lock()
+		n := s.queue.Len()
+		s.mu.Unlock()
+		if n > 0 {
+			break
+		}
+
+		select {
+		case <-s.added:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := s.bandwidth.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	so := heap.Pop(&s.queue).(*scheduledOp)
+	s.mu.Unlock()
+	notify(s.removed)
+	return so.op, nil
+}
+
+func notify(c chan<- struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
+
+type scheduledOp struct {
+	op       *Op
+	priority int
+	seq      uint64
+}
+
+// opQueue orders the chunks by the descending priority
+// and the order in which they were queued.
+type opQueue []*scheduledOp
+
+func (q opQueue) Len() int { return len(q) }
+
+func (q opQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q opQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *opQueue) Push(x any) { *q = append(*q, x.(*scheduledOp)) }
+
+func (q *opQueue) Pop() any {
+	old := *q
+	n := len(old)
+	so := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return so
+}
@@ -4,8 +4,16 @@
 
 package pushsync
 
+import "time"
+
 var (
 	ProtocolName    = protocolName
 	ProtocolVersion = protocolVersion
 	StreamName      = streamName
 )
+
+const ReceiptTTL = receiptTTL
+
+func (ps *PushSync) PruneReceipts(now time.Time) (int, error) {
+	return ps.pruneReceipts(now)
+}
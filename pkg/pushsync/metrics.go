@@ -28,6 +28,11 @@ type metrics struct {
 	ReceiptDepth        *prometheus.CounterVec
 	ShallowReceiptDepth *prometheus.CounterVec
 	ShallowReceipt      prometheus.Counter
+
+	ChallengesSent     prometheus.Counter
+	ChallengesFailed   prometheus.Counter
+	ChallengesReceived prometheus.Counter
+	ChallengesServed   prometheus.Counter
 }
 
 func newMetrics() metrics {
@@ -146,6 +151,30 @@ func newMetrics() metrics {
 			},
 			[]string{"depth"},
 		),
+		ChallengesSent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "challenges_sent",
+			Help:      "Total storage challenges sent to the storers of the pushed chunks.",
+		}),
+		ChallengesFailed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "challenges_failed",
+			Help:      "Total storage challenges the storers failed to answer with the committed chunk.",
+		}),
+		ChallengesReceived: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "challenges_received",
+			Help:      "Total storage challenges received.",
+		}),
+		ChallengesServed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "challenges_served",
+			Help:      "Total challenged chunks served from the reserve.",
+		}),
 	}
 }
 
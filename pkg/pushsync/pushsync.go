@@ -11,10 +11,12 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/accounting"
 	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/chunktrace"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
@@ -22,6 +24,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/pricer"
 	"github.com/ethersphere/bee/v2/pkg/pushsync/pb"
+	"github.com/ethersphere/bee/v2/pkg/scoreboard"
 	"github.com/ethersphere/bee/v2/pkg/skippeers"
 	"github.com/ethersphere/bee/v2/pkg/soc"
 	"github.com/ethersphere/bee/v2/pkg/storage"
@@ -50,8 +53,7 @@ const (
 )
 
 const (
-	maxMultiplexForwards = 2 // number of extra peers to forward the request from the multiplex node
-	maxPushErrors        = 32
+	maxPushErrors = 32
 )
 
 var (
@@ -74,6 +76,7 @@ type Receipt struct {
 type Storer interface {
 	storage.PushReporter
 	ReservePutter() storage.Putter
+	ReserveGet(ctx context.Context, addr swarm.Address, batchID []byte, stampHash []byte) (swarm.Chunk, error)
 }
 
 type PushSync struct {
@@ -96,8 +99,14 @@ type PushSync struct {
 	fullNode       bool
 	errSkip        *skippeers.List
 	warmupPeriod   time.Time
+	scores         *scoreboard.Board
+	receipts       storage.StateStorer
+	chunkTracer    *chunktrace.Tracer
+	quit           chan struct{}
+	wg             sync.WaitGroup
 
 	shallowReceiptTolerance uint8
+	replicationDefault      uint8
 }
 
 type receiptResult struct {
@@ -126,7 +135,13 @@ func New(
 	tracer *tracing.Tracer,
 	warmupTime time.Duration,
 	shallowReceiptTolerance uint8,
+	replicationFactor uint8,
+	scores *scoreboard.Board,
 ) *PushSync {
+	if replicationFactor == 0 {
+		replicationFactor = DefaultReplicationFactor
+	}
+
 	ps := &PushSync{
 		address:                 address,
 		radius:                  radius,
@@ -146,13 +161,21 @@ func New(
 		signer:                  signer,
 		errSkip:                 skippeers.NewList(time.Minute),
 		warmupPeriod:            time.Now().Add(warmupTime),
+		scores:                  scores,
 		shallowReceiptTolerance: shallowReceiptTolerance,
+		replicationDefault:      replicationFactor,
+		quit:                    make(chan struct{}),
 	}
 
 	ps.validStamp = ps.validStampWrapper(validStamp)
 	return ps
 }
 
+// SetChunkTracer enables recording the push syncing of the traced chunks.
+func (ps *PushSync) SetChunkTracer(t *chunktrace.Tracer) {
+	ps.chunkTracer = t
+}
+
 func (s *PushSync) Protocol() p2p.ProtocolSpec {
 	return p2p.ProtocolSpec{
 		Name:    protocolName,
@@ -162,6 +185,10 @@ func (s *PushSync) Protocol() p2p.ProtocolSpec {
 				Name:    streamName,
 				Handler: s.handler,
 			},
+			{
+				Name:    challengeStreamName,
+				Handler: s.challengeHandler,
+			},
 		},
 	}
 }
@@ -201,6 +228,16 @@ func (ps *PushSync) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream)
 	chunk := swarm.NewChunk(swarm.NewAddress(ch.Address), ch.Data)
 	chunkAddress := chunk.Address()
 
+	ps.chunkTracer.Record(chunkAddress, chunktrace.SubsystemPushSync, "receive", "peer_address", p.Address)
+	defer func() {
+		if err != nil {
+			ps.chunkTracer.Record(chunkAddress, chunktrace.SubsystemPushSync, "receive failed", "peer_address", p.Address, "error", err)
+		}
+	}()
+
+	// the replication factor requested by the origin is passed on along the forwarding path
+	ctx = SetReplicationFactor(ctx, uint8(min(ch.Replicas, MaxReplicationFactor)))
+
 	span, _, ctx := ps.tracer.StartSpanFromContext(ctx, "pushsync-handler", ps.logger, opentracing.Tag{Key: "address", Value: chunkAddress.String()}, opentracing.Tag{Key: "tagID", Value: chunk.TagID()}, opentracing.Tag{Key: "sender_address", Value: p.Address.String()})
 
 	var (
@@ -262,12 +299,22 @@ func (ps *PushSync) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream)
 		if err != nil {
 			return fmt.Errorf("reserve put: %w", err)
 		}
+		ps.chunkTracer.Record(chunkAddress, chunktrace.SubsystemPushSync, "stored", "reason", reason)
 
 		signature, err := ps.signer.Sign(chunkToPut.Address().Bytes())
 		if err != nil {
 			return fmt.Errorf("receipt signature: %w", err)
 		}
 
+		dataHash, err := crypto.LegacyKeccak256(chunkToPut.Data())
+		if err != nil {
+			return fmt.Errorf("receipt commitment: %w", err)
+		}
+		commitment, err := ps.signer.Sign(commitmentData(chunkToPut.Address(), dataHash))
+		if err != nil {
+			return fmt.Errorf("receipt commitment: %w", err)
+		}
+
 		// return back receipt
 		debit, err := ps.accounting.PrepareDebit(ctx, p.Address, price)
 		if err != nil {
@@ -277,7 +324,7 @@ func (ps *PushSync) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream)
 
 		attemptedWrite = true
 
-		receipt := pb.Receipt{Address: chunkToPut.Address().Bytes(), Signature: signature, Nonce: ps.nonce, StorageRadius: uint32(rad)}
+		receipt := pb.Receipt{Address: chunkToPut.Address().Bytes(), Signature: signature, Nonce: ps.nonce, StorageRadius: uint32(rad), Commitment: commitment}
 		if err := w.WriteMsgWithContext(ctx, &receipt); err != nil {
 			return fmt.Errorf("send receipt to peer %s: %w", p.Address.String(), err)
 		}
@@ -333,7 +380,8 @@ func (ps *PushSync) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream)
 
 // PushChunkToClosest sends chunk to the closest peer by opening a stream. It then waits for
 // a receipt from that peer and returns error or nil based on the receiving and
-// the validity of the receipt.
+// the validity of the receipt. The number of the neighborhood peers the chunk is
+// replicated to may be requested with the SetReplicationFactor context.
 func (ps *PushSync) PushChunkToClosest(ctx context.Context, ch swarm.Chunk) (*Receipt, error) {
 	ps.metrics.TotalOutgoing.Inc()
 	r, err := ps.pushToClosest(ctx, ch, true)
@@ -355,7 +403,9 @@ func (ps *PushSync) PushChunkToClosest(ctx context.Context, ch swarm.Chunk) (*Re
 	}, nil
 }
 
-// pushToClosest attempts to push the chunk into the network.
+// pushToClosest attempts to push the chunk into the network. Once the
+// neighborhood of the chunk can be reached, the chunk is pushed in parallel
+// to as many peers as requested by the replication factor of the context.
 func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bool) (*pb.Receipt, error) {
 
 	if !ps.warmedUp() {
@@ -371,7 +421,9 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 		sentErrorsLeft   = 1
 		preemptiveTicker <-chan time.Time
 		inflight         int
-		parallelForwards = maxMultiplexForwards
+		replicas         = GetReplicationFactor(ctx)
+		parallelForwards = ps.replicationFactor(replicas) - 1
+		multiplexed      bool
 	)
 
 	if origin {
@@ -426,7 +478,7 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 				if skip.PruneExpiresAfter(idAddress, overDraftRefresh) == 0 { //no overdraft peers, we have depleted ALL peers
 					if inflight == 0 {
 						if ps.fullNode {
-							if cac.Valid(ch) {
+							if swarm.ValidType(ch) == swarm.ChunkTypeContentAddressed || cac.Valid(ch) {
 								go ps.unwrap(ch)
 							}
 							return nil, topology.ErrWantSelf
@@ -459,12 +511,18 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 
 			// since we can reach into the neighborhood of the chunk
 			// act as the multiplexer and push the chunk in parallel to multiple peers
+			// the neighborhood peers store the chunk without replicating it further
 			if swarm.Proximity(peer.Bytes(), ch.Address().Bytes()) >= rad {
+				multiplexed = true
 				for ; parallelForwards > 0; parallelForwards-- {
 					retry()
 					sentErrorsLeft++
 				}
 			}
+			forwardReplicas := replicas
+			if multiplexed {
+				forwardReplicas = 1
+			}
 
 			action, err := ps.prepareCredit(ctx, peer, ch, origin)
 			if err != nil {
@@ -477,7 +535,9 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 			ps.metrics.TotalSendAttempts.Inc()
 			inflight++
 
-			go ps.push(ctx, resultChan, peer, ch, action)
+			ps.chunkTracer.Record(ch.Address(), chunktrace.SubsystemPushSync, "push", "peer_address", peer, "origin", origin)
+
+			go ps.push(ctx, resultChan, peer, ch, action, forwardReplicas)
 
 		case result := <-resultChan:
 			inflight--
@@ -485,6 +545,7 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 			ps.measurePushPeer(result.pushTime, result.err)
 
 			if result.err == nil {
+				ps.chunkTracer.Record(ch.Address(), chunktrace.SubsystemPushSync, "receipt", "peer_address", result.peer)
 
 				if !origin { // forwarder nodes do not need to check the receipt
 					return result.receipt, nil
@@ -492,6 +553,7 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 
 				switch err := ps.checkReceipt(result.receipt); {
 				case err == nil:
+					ps.keepReceipt(ch, result.receipt)
 					return result.receipt, nil
 				case errors.Is(err, ErrShallowReceipt):
 					ps.errSkip.Add(idAddress, result.peer, skiplistDur)
@@ -500,6 +562,7 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 			}
 
 			ps.metrics.TotalFailedSendAttempts.Inc()
+			ps.chunkTracer.Record(ch.Address(), chunktrace.SubsystemPushSync, "push failed", "peer_address", result.peer, "error", result.err)
 			ps.logger.Debug("could not push to peer", "chunk_address", ch.Address(), "id_address", idAddress, "peer_address", result.peer, "error", result.err)
 
 			sentErrorsLeft--
@@ -516,19 +579,30 @@ func (ps *PushSync) closestPeer(chunkAddress swarm.Address, origin bool, skipLis
 
 	includeSelf := ps.fullNode && !origin
 
-	peer, err := ps.topologyDriver.ClosestPeer(chunkAddress, includeSelf, topology.Select{Reachable: true, Healthy: true}, skipList...)
-	if errors.Is(err, topology.ErrNotFound) {
-		peer, err := ps.topologyDriver.ClosestPeer(chunkAddress, includeSelf, topology.Select{Reachable: true}, skipList...)
-		if errors.Is(err, topology.ErrNotFound) {
-			return ps.topologyDriver.ClosestPeer(chunkAddress, includeSelf, topology.Select{}, skipList...)
+	var (
+		peer   swarm.Address
+		filter topology.Select
+		err    error
+	)
+
+	for _, filter = range []topology.Select{{Reachable: true, Healthy: true}, {Reachable: true}, {}} {
+		peer, err = ps.topologyDriver.ClosestPeer(chunkAddress, includeSelf, filter, skipList...)
+		if !errors.Is(err, topology.ErrNotFound) {
+			break
 		}
+	}
+
+	if err != nil {
 		return peer, err
 	}
 
-	return peer, err
+	// prefer the better performing peer among the equally close ones
+	return ps.scores.Select(chunkAddress, peer, func(skip []swarm.Address) (swarm.Address, error) {
+		return ps.topologyDriver.ClosestPeer(chunkAddress, false, filter, append(skip, skipList...)...)
+	}), nil
 }
 
-func (ps *PushSync) push(parentCtx context.Context, resultChan chan<- receiptResult, peer swarm.Address, ch swarm.Chunk, action accounting.Action) {
+func (ps *PushSync) push(parentCtx context.Context, resultChan chan<- receiptResult, peer swarm.Address, ch swarm.Chunk, action accounting.Action, replicas uint8) {
 
 	// here we use a background timeout context because we do not want another push attempt to cancel this one
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTTL)
@@ -550,6 +624,7 @@ func (ps *PushSync) push(parentCtx context.Context, resultChan chan<- receiptRes
 			spanInner.LogFields(olog.Bool("success", true))
 		}
 		spanInner.Finish()
+		ps.scores.Record(peer, time.Since(now), len(ch.Data()), err)
 		select {
 		case resultChan <- receiptResult{pushTime: now, peer: peer, err: err, receipt: receipt}:
 		case <-parentCtx.Done():
@@ -560,7 +635,7 @@ func (ps *PushSync) push(parentCtx context.Context, resultChan chan<- receiptRes
 
 	spanInner.LogFields(olog.String("peer_address", peer.String()))
 
-	receipt, err = ps.pushChunkToPeer(tracing.WithContext(ctx, spanInner.Context()), peer, ch)
+	receipt, err = ps.pushChunkToPeer(tracing.WithContext(ctx, spanInner.Context()), peer, ch, replicas)
 	if err != nil {
 		return
 	}
@@ -570,16 +645,29 @@ func (ps *PushSync) push(parentCtx context.Context, resultChan chan<- receiptRes
 	err = action.Apply()
 }
 
+// PushChunkToPeer pushes the chunk directly to the peer which stores it
+// without replicating it further. It is used to repair the replication
+// of the chunk in its neighborhood.
+func (ps *PushSync) PushChunkToPeer(ctx context.Context, peer swarm.Address, ch swarm.Chunk) error {
+	action, err := ps.prepareCredit(ctx, peer, ch, true)
+	if err != nil {
+		return err
+	}
+	defer action.Cleanup()
+
+	if _, err := ps.pushChunkToPeer(ctx, peer, ch, 1); err != nil {
+		return err
+	}
+	ps.metrics.TotalSent.Inc()
+
+	return action.Apply()
+}
+
 func (ps *PushSync) checkReceipt(receipt *pb.Receipt) error {
 
 	addr := swarm.NewAddress(receipt.Address)
 
-	publicKey, err := crypto.Recover(receipt.Signature, addr.Bytes())
-	if err != nil {
-		return fmt.Errorf("pushsync: receipt recover: %w", err)
-	}
-
-	peer, err := crypto.NewOverlayAddress(*publicKey, ps.networkID, receipt.Nonce)
+	peer, err := ps.recoverStorer(receipt.Signature, addr.Bytes(), receipt.Nonce)
 	if err != nil {
 		return fmt.Errorf("pushsync: receipt storer address: %w", err)
 	}
@@ -609,7 +697,7 @@ func (ps *PushSync) checkReceipt(receipt *pb.Receipt) error {
 	return nil
 }
 
-func (ps *PushSync) pushChunkToPeer(ctx context.Context, peer swarm.Address, ch swarm.Chunk) (receipt *pb.Receipt, err error) {
+func (ps *PushSync) pushChunkToPeer(ctx context.Context, peer swarm.Address, ch swarm.Chunk, replicas uint8) (receipt *pb.Receipt, err error) {
 
 	streamer, err := ps.streamer.NewStream(ctx, peer, nil, protocolName, protocolVersion, streamName)
 	if err != nil {
@@ -630,9 +718,10 @@ func (ps *PushSync) pushChunkToPeer(ctx context.Context, peer swarm.Address, ch
 		return nil, err
 	}
 	err = w.WriteMsgWithContext(ctx, &pb.Delivery{
-		Address: ch.Address().Bytes(),
-		Data:    ch.Data(),
-		Stamp:   stamp,
+		Address:  ch.Address().Bytes(),
+		Data:     ch.Data(),
+		Stamp:    stamp,
+		Replicas: uint32(replicas),
 	})
 	if err != nil {
 		return nil, err
@@ -696,6 +785,8 @@ func (ps *PushSync) validStampWrapper(f postage.ValidStampFn) postage.ValidStamp
 }
 
 func (s *PushSync) Close() error {
+	close(s.quit)
+	s.wg.Wait()
 	return s.errSkip.Close()
 }
 
@@ -26,6 +26,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/pushsync"
 	"github.com/ethersphere/bee/v2/pkg/pushsync/pb"
 	"github.com/ethersphere/bee/v2/pkg/soc"
+	"github.com/ethersphere/bee/v2/pkg/spinlock"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	testingc "github.com/ethersphere/bee/v2/pkg/storage/testing"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -117,6 +118,43 @@ func TestPushClosest(t *testing.T) {
 	}
 }
 
+func TestPushChunkToPeer(t *testing.T) {
+	t.Parallel()
+
+	chunk := testingc.FixtureChunk("7000")
+
+	pivotNode := swarm.MustParseHexAddress("0000000000000000000000000000000000000000000000000000000000000000")
+	peer := swarm.MustParseHexAddress("6000000000000000000000000000000000000000000000000000000000000000")
+
+	// the peer stores the chunk without forwarding it
+	psPeer, _, peerAccounting := createPushSyncNode(t, peer, defaultPrices, nil, nil, defaultSigner(chunk), mock.WithClosestPeerErr(topology.ErrWantSelf))
+	recorder := streamtest.New(streamtest.WithProtocols(psPeer.Protocol()), streamtest.WithBaseAddr(pivotNode))
+
+	// the pivot does not know the peer as the closest one
+	psPivot, _, pivotAccounting := createPushSyncNode(t, pivotNode, defaultPrices, recorder, nil, defaultSigner(chunk), mock.WithClosestPeerErr(topology.ErrNotFound))
+
+	if err := psPivot.PushChunkToPeer(context.Background(), peer, chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	waitOnRecordAndTest(t, peer, recorder, chunk.Address(), chunk.Data())
+
+	balance, err := pivotAccounting.Balance(peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Int64() != -int64(fixedPrice) {
+		t.Fatalf("unexpected balance on pivot. want %d got %d", -int64(fixedPrice), balance)
+	}
+	balance, err = peerAccounting.Balance(pivotNode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Int64() != int64(fixedPrice) {
+		t.Fatalf("unexpected balance on peer. want %d got %d", int64(fixedPrice), balance)
+	}
+}
+
 // TestSocListener listens all payload of a SOC. This triggers sending a chunk to the closest node
 // and expects a receipt. The message is intercepted in the outgoing stream to check for correctness.
 func TestSocListener(t *testing.T) {
@@ -914,6 +952,71 @@ func TestMultiplePushesAsForwarder(t *testing.T) {
 	}
 }
 
+// TestReplicationFactor tests that the chunk is pushed to the requested number
+// of the neighborhood peers, which do not replicate the chunk any further.
+func TestReplicationFactor(t *testing.T) {
+	t.Parallel()
+
+	chunk := testingc.FixtureChunk("7000")
+
+	pivotNode := swarm.MustParseHexAddress("0000000000000000000000000000000000000000000000000000000000000000")
+
+	peers := []swarm.Address{
+		swarm.MustParseHexAddress("5000000000000000000000000000000000000000000000000000000000000000"),
+		swarm.MustParseHexAddress("4000000000000000000000000000000000000000000000000000000000000000"),
+		swarm.MustParseHexAddress("3000000000000000000000000000000000000000000000000000000000000000"),
+		swarm.MustParseHexAddress("2000000000000000000000000000000000000000000000000000000000000000"),
+	}
+
+	protocols := make(map[string]p2p.ProtocolSpec)
+	storers := make([]*testStorer, 0, len(peers))
+	for _, peer := range peers {
+		ps, storer, _ := createPushSyncNode(t, peer, defaultPrices, nil, nil, defaultSigner(chunk), mock.WithClosestPeerErr(topology.ErrWantSelf))
+		protocols[peer.String()] = ps.Protocol()
+		storers = append(storers, storer)
+	}
+
+	recorder := streamtest.New(
+		streamtest.WithPeerProtocols(protocols),
+		streamtest.WithBaseAddr(pivotNode),
+	)
+
+	psPivot, _, _ := createPushSyncNode(t, pivotNode, defaultPrices, recorder, nil, defaultSigner(chunk), mock.WithPeers(peers...))
+
+	ctx := pushsync.SetReplicationFactor(context.Background(), 2)
+	if _, err := psPivot.PushChunkToClosest(ctx, chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := func() (n int) {
+		for _, s := range storers {
+			if s.hasChunk(t, chunk.Address()) {
+				n++
+			}
+		}
+		return n
+	}
+	if err := spinlock.Wait(time.Second, func() bool { return stored() == 2 }); err != nil {
+		t.Fatalf("stored replicas: want 2, have %d", stored())
+	}
+
+	for _, peer := range peers {
+		records, _ := recorder.Records(peer, pushsync.ProtocolName, pushsync.ProtocolVersion, pushsync.StreamName)
+		for _, record := range records {
+			messages, err := protobuf.ReadMessages(
+				bytes.NewReader(record.In()),
+				func() protobuf.Message { return new(pb.Delivery) },
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := messages[0].(*pb.Delivery).Replicas; got != 1 {
+				t.Fatalf("forwarded replicas: want 1, have %d", got)
+			}
+		}
+	}
+}
+
 type testStorer struct {
 	chunksMu       sync.Mutex
 	chunksPut      map[string]swarm.Chunk
@@ -951,6 +1054,19 @@ func (ts *testStorer) Report(ctx context.Context, chunk swarm.Chunk, state stora
 	return nil
 }
 
+func (ts *testStorer) ReserveGet(ctx context.Context, addr swarm.Address, batchID []byte, stampHash []byte) (swarm.Chunk, error) {
+	ts.chunksMu.Lock()
+	defer ts.chunksMu.Unlock()
+	ch, ok := ts.chunksPut[addr.ByteString()]
+	if !ok || !bytes.Equal(ch.Stamp().BatchID(), batchID) {
+		return nil, storage.ErrNotFound
+	}
+	if h, err := ch.Stamp().Hash(); err != nil || !bytes.Equal(h, stampHash) {
+		return nil, storage.ErrNotFound
+	}
+	return ch, nil
+}
+
 func (ts *testStorer) IsWithinStorageRadius(address swarm.Address) bool { return true }
 
 func (ts *testStorer) StorageRadius() uint8 { return 0 }
@@ -1036,7 +1152,7 @@ func createPushSyncNodeWithRadius(
 
 	radiusFunc := func() (uint8, error) { return radius, nil }
 
-	ps := pushsync.New(addr, 1, blockHash.Bytes(), recorderDisconnecter, storer, radiusFunc, mockTopology, true, unwrap, func(*soc.SOC) {}, validStamp, log.Noop, accountingmock.NewAccounting(), mockPricer, signer, nil, -1, shallowReceiptTolerance)
+	ps := pushsync.New(addr, 1, blockHash.Bytes(), recorderDisconnecter, storer, radiusFunc, mockTopology, true, unwrap, func(*soc.SOC) {}, validStamp, log.Noop, accountingmock.NewAccounting(), mockPricer, signer, nil, -1, shallowReceiptTolerance, 0, nil)
 	t.Cleanup(func() { ps.Close() })
 
 	return ps, storer
@@ -1077,7 +1193,7 @@ func createPushSyncNodeWithAccounting(
 
 	radiusFunc := func() (uint8, error) { return 0, nil }
 
-	ps := pushsync.New(addr, 1, blockHash.Bytes(), recorderDisconnecter, storer, radiusFunc, mockTopology, true, unwrap, gsocListener, validStamp, logger, acct, mockPricer, signer, nil, -1, 0)
+	ps := pushsync.New(addr, 1, blockHash.Bytes(), recorderDisconnecter, storer, radiusFunc, mockTopology, true, unwrap, gsocListener, validStamp, logger, acct, mockPricer, signer, nil, -1, 0, 0, nil)
 	t.Cleanup(func() { ps.Close() })
 
 	return ps, storer
@@ -1112,7 +1228,7 @@ func waitOnRecordAndTest(t *testing.T, peer swarm.Address, recorder *streamtest.
 		}
 	} else {
 		messages, err := protobuf.ReadMessages(
-			bytes.NewReader(records[0].In()),
+			bytes.NewReader(records[0].Out()),
 			func() protobuf.Message { return new(pb.Receipt) },
 		)
 		if err != nil {
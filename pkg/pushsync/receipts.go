@@ -0,0 +1,295 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pushsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/protobuf"
+	"github.com/ethersphere/bee/v2/pkg/pushsync/pb"
+	"github.com/ethersphere/bee/v2/pkg/soc"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const (
+	challengeStreamName = "challenge"
+	receiptKeyPrefix    = "pushsync_receipt_"
+
+	// receiptTTL is the time the receipts are kept for.
+	receiptTTL = 7 * 24 * time.Hour
+	// receiptPruneInterval is the interval of the pruning of the expired receipts.
+	receiptPruneInterval = time.Hour
+)
+
+// ErrChallengeFailed is returned when the storer of a chunk is not able
+// to produce the chunk committed to in its receipt.
+var ErrChallengeFailed = errors.New("storage challenge failed")
+
+// StoredReceipt is a receipt of a pushed chunk kept by the uploader,
+// so the storer can later be challenged to produce the chunk.
+type StoredReceipt struct {
+	Address   swarm.Address `json:"address"`
+	Storer    swarm.Address `json:"storer"`
+	Signature []byte        `json:"signature"`
+	Nonce     []byte        `json:"nonce"`
+	// BatchID and StampHash identify the stamped chunk
+	// in the reserve of the storer.
+	BatchID   []byte `json:"batchID"`
+	StampHash []byte `json:"stampHash"`
+	// Commitment is the storer signature over the chunk
+	// address and the DataHash. Older storers do not sign it.
+	Commitment []byte    `json:"commitment,omitempty"`
+	DataHash   []byte    `json:"dataHash"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// SetReceiptStore enables keeping the receipts of the chunks
+// pushed by this node in the given state store. The receipts
+// are removed from the store once they are older than receiptTTL.
+func (ps *PushSync) SetReceiptStore(store storage.StateStorer) {
+	ps.receipts = store
+
+	ps.wg.Add(1)
+	go ps.receiptsPruner()
+}
+
+// receiptsPruner prunes the expired receipts periodically.
+func (ps *PushSync) receiptsPruner() {
+	defer ps.wg.Done()
+
+	ticker := time.NewTicker(receiptPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := ps.pruneReceipts(time.Now()); err != nil {
+			ps.logger.Debug("prune receipts failed", "error", err)
+		} else if n > 0 {
+			ps.logger.Debug("expired receipts pruned", "count", n)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ps.quit:
+			return
+		}
+	}
+}
+
+// pruneReceipts removes the receipts which are older than receiptTTL at
+// the given time and returns the number of the removed receipts.
+func (ps *PushSync) pruneReceipts(now time.Time) (int, error) {
+	var expired []string
+	err := ps.receipts.Iterate(receiptKeyPrefix, func(key, val []byte) (bool, error) {
+		r := new(StoredReceipt)
+		// the receipts which can not be read are of no use either
+		if err := json.Unmarshal(val, r); err != nil || now.Sub(r.Timestamp) > receiptTTL {
+			expired = append(expired, string(key))
+		}
+		return false, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i, key := range expired {
+		if err := ps.receipts.Delete(key); err != nil {
+			return i, fmt.Errorf("delete receipt %s: %w", key, err)
+		}
+	}
+	return len(expired), nil
+}
+
+// Receipt returns the kept receipt of the chunk with the given address.
+func (ps *PushSync) Receipt(addr swarm.Address) (*StoredReceipt, error) {
+	if ps.receipts == nil {
+		return nil, storage.ErrNotFound
+	}
+	r := new(StoredReceipt)
+	if err := ps.receipts.Get(receiptKey(addr), r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Challenge asks the storer of the chunk, as identified by the kept
+// receipt, to produce the chunk from its reserve. The served chunk is
+// paid for as a retrieved one. It returns ErrChallengeFailed if the
+// storer is not able to produce the chunk it has committed to.
+func (ps *PushSync) Challenge(ctx context.Context, addr swarm.Address) (*StoredReceipt, error) {
+	r, err := ps.Receipt(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.metrics.ChallengesSent.Inc()
+
+	if err := ps.challenge(ctx, r); err != nil {
+		ps.metrics.ChallengesFailed.Inc()
+		ps.logger.Debug("storage challenge failed", "chunk_address", addr, "storer_address", r.Storer, "error", err)
+		return r, err
+	}
+	return r, nil
+}
+
+func (ps *PushSync) challenge(ctx context.Context, r *StoredReceipt) (err error) {
+	if len(r.Commitment) > 0 {
+		storer, err := ps.recoverStorer(r.Commitment, commitmentData(r.Address, r.DataHash), r.Nonce)
+		if err != nil || !storer.Equal(r.Storer) {
+			return fmt.Errorf("%w: invalid commitment", ErrChallengeFailed)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTTL)
+	defer cancel()
+
+	creditAction, err := ps.accounting.PrepareCredit(ctx, r.Storer, ps.pricer.PeerPrice(r.Storer, r.Address), true)
+	if err != nil {
+		return fmt.Errorf("%w: prepare credit to peer %s: %w", ErrChallengeFailed, r.Storer, err)
+	}
+	defer creditAction.Cleanup()
+
+	stream, err := ps.streamer.NewStream(ctx, r.Storer, nil, protocolName, protocolVersion, challengeStreamName)
+	if err != nil {
+		return fmt.Errorf("%w: new stream for peer %s: %w", ErrChallengeFailed, r.Storer, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = stream.Reset()
+		} else {
+			_ = stream.FullClose()
+		}
+	}()
+
+	w, rd := protobuf.NewWriterAndReader(stream)
+	if err := w.WriteMsgWithContext(ctx, &pb.Challenge{Address: r.Address.Bytes(), BatchID: r.BatchID, StampHash: r.StampHash}); err != nil {
+		return fmt.Errorf("%w: write challenge: %w", ErrChallengeFailed, err)
+	}
+
+	var resp pb.ChallengeResponse
+	if err := rd.ReadMsgWithContext(ctx, &resp); err != nil {
+		return fmt.Errorf("%w: read response: %w", ErrChallengeFailed, err)
+	}
+	if resp.Err != "" {
+		return fmt.Errorf("%w: %w", ErrChallengeFailed, p2p.NewChunkDeliveryError(resp.Err))
+	}
+
+	ch := swarm.NewChunk(r.Address, resp.Data)
+	if !cac.Valid(ch) && !soc.Valid(ch) {
+		return fmt.Errorf("%w: %w", ErrChallengeFailed, swarm.ErrInvalidChunk)
+	}
+	if h, err := crypto.LegacyKeccak256(resp.Data); err != nil || !bytes.Equal(h, r.DataHash) {
+		return fmt.Errorf("%w: chunk data does not match the receipt", ErrChallengeFailed)
+	}
+	return creditAction.Apply()
+}
+
+// challengeHandler produces the requested chunk from the reserve and
+// charges the challenger for it as for a retrieved chunk.
+func (ps *PushSync) challengeHandler(ctx context.Context, p p2p.Peer, stream p2p.Stream) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTTL)
+	defer cancel()
+
+	w, r := protobuf.NewWriterAndReader(stream)
+	defer func() {
+		if err != nil {
+			_ = w.WriteMsgWithContext(ctx, &pb.ChallengeResponse{Err: err.Error()})
+			_ = stream.Reset()
+		} else {
+			_ = stream.FullClose()
+		}
+	}()
+
+	var req pb.Challenge
+	if err := r.ReadMsgWithContext(ctx, &req); err != nil {
+		return fmt.Errorf("read challenge from peer %s: %w", p.Address, err)
+	}
+
+	ps.metrics.ChallengesReceived.Inc()
+
+	addr := swarm.NewAddress(req.Address)
+	ch, err := ps.store.ReserveGet(ctx, addr, req.BatchID, req.StampHash)
+	if err != nil {
+		return fmt.Errorf("challenged chunk %s: %w", addr, err)
+	}
+
+	debit, err := ps.accounting.PrepareDebit(ctx, p.Address, ps.pricer.Price(addr))
+	if err != nil {
+		return fmt.Errorf("prepare debit to peer %s: %w", p.Address, err)
+	}
+	defer debit.Cleanup()
+
+	if err := w.WriteMsgWithContext(ctx, &pb.ChallengeResponse{Data: ch.Data()}); err != nil {
+		return fmt.Errorf("send challenged chunk to peer %s: %w", p.Address, err)
+	}
+
+	ps.metrics.ChallengesServed.Inc()
+	return debit.Apply()
+}
+
+// keepReceipt stores the receipt of the pushed chunk if keeping of
+// the receipts is enabled.
+func (ps *PushSync) keepReceipt(ch swarm.Chunk, receipt *pb.Receipt) {
+	if ps.receipts == nil {
+		return
+	}
+
+	storer, err := ps.recoverStorer(receipt.Signature, receipt.Address, receipt.Nonce)
+	if err != nil {
+		return
+	}
+
+	dataHash, err := crypto.LegacyKeccak256(ch.Data())
+	if err != nil {
+		return
+	}
+
+	stampHash, err := ch.Stamp().Hash()
+	if err != nil {
+		return
+	}
+
+	r := &StoredReceipt{
+		Address:    ch.Address(),
+		Storer:     storer,
+		Signature:  receipt.Signature,
+		Nonce:      receipt.Nonce,
+		BatchID:    ch.Stamp().BatchID(),
+		StampHash:  stampHash,
+		Commitment: receipt.Commitment,
+		DataHash:   dataHash,
+		Timestamp:  time.Now(),
+	}
+	if err := ps.receipts.Put(receiptKey(ch.Address()), r); err != nil {
+		ps.logger.Debug("keep receipt failed", "chunk_address", ch.Address(), "error", err)
+	}
+}
+
+// recoverStorer returns the overlay address of the signer of the data.
+func (ps *PushSync) recoverStorer(signature, data, nonce []byte) (swarm.Address, error) {
+	publicKey, err := crypto.Recover(signature, data)
+	if err != nil {
+		return swarm.ZeroAddress, err
+	}
+	return crypto.NewOverlayAddress(*publicKey, ps.networkID, nonce)
+}
+
+// commitmentData returns the data signed by the storer to commit
+// to storing the chunk with the given address and data hash.
+func commitmentData(addr swarm.Address, dataHash []byte) []byte {
+	return append(append(make([]byte, 0, len(addr.Bytes())+len(dataHash)), addr.Bytes()...), dataHash...)
+}
+
+func receiptKey(addr swarm.Address) string {
+	return receiptKeyPrefix + addr.String()
+}
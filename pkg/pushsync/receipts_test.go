@@ -0,0 +1,143 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pushsync_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/p2p/streamtest"
+	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	statestore "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	testingc "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	"github.com/ethersphere/bee/v2/pkg/topology/mock"
+)
+
+func TestReceiptChallenge(t *testing.T) {
+	t.Parallel()
+
+	chunk := testingc.FixtureChunk("7000")
+
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	storerAddr, err := crypto.NewOverlayAddress(key.PublicKey, 1, blockHash.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pivotAddr := swarm.MustParseHexAddress("0000000000000000000000000000000000000000000000000000000000000000")
+
+	psStorer, storerStore, storerAccounting := createPushSyncNode(t, storerAddr, defaultPrices, nil, nil, crypto.NewDefaultSigner(key), mock.WithClosestPeerErr(topology.ErrWantSelf))
+
+	recorder := streamtest.New(streamtest.WithProtocols(psStorer.Protocol()), streamtest.WithBaseAddr(pivotAddr))
+	psPivot, _, pivotAccounting := createPushSyncNode(t, pivotAddr, defaultPrices, recorder, nil, defaultSigner(chunk), mock.WithClosestPeer(storerAddr))
+	psPivot.SetReceiptStore(statestore.NewStateStore())
+
+	if _, err := psPivot.Receipt(chunk.Address()); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("want %v, got %v", storage.ErrNotFound, err)
+	}
+
+	if _, err := psPivot.PushChunkToClosest(context.Background(), chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	receipt, err := psPivot.Receipt(chunk.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !receipt.Storer.Equal(storerAddr) {
+		t.Fatalf("want storer %s, got %s", storerAddr, receipt.Storer)
+	}
+	if len(receipt.Commitment) == 0 {
+		t.Fatal("want receipt commitment")
+	}
+	if !bytes.Equal(receipt.BatchID, chunk.Stamp().BatchID()) {
+		t.Fatalf("want batch id %x, got %x", chunk.Stamp().BatchID(), receipt.BatchID)
+	}
+
+	if _, err := psPivot.Challenge(context.Background(), chunk.Address()); err != nil {
+		t.Fatalf("challenge: %v", err)
+	}
+
+	// the challenged chunk is paid for as the pushed one
+	balance, err := pivotAccounting.Balance(storerAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Int64() != -2*int64(fixedPrice) {
+		t.Fatalf("unexpected balance on pivot. want %d got %d", -2*int64(fixedPrice), balance)
+	}
+	balance, err = storerAccounting.Balance(pivotAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Int64() != 2*int64(fixedPrice) {
+		t.Fatalf("unexpected balance on storer. want %d got %d", 2*int64(fixedPrice), balance)
+	}
+
+	storerStore.chunksMu.Lock()
+	delete(storerStore.chunksPut, chunk.Address().ByteString())
+	storerStore.chunksMu.Unlock()
+
+	if _, err := psPivot.Challenge(context.Background(), chunk.Address()); !errors.Is(err, pushsync.ErrChallengeFailed) {
+		t.Fatalf("want %v, got %v", pushsync.ErrChallengeFailed, err)
+	}
+}
+
+func TestReceiptPrune(t *testing.T) {
+	t.Parallel()
+
+	chunk := testingc.FixtureChunk("7000")
+
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	storerAddr, err := crypto.NewOverlayAddress(key.PublicKey, 1, blockHash.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pivotAddr := swarm.MustParseHexAddress("0000000000000000000000000000000000000000000000000000000000000000")
+
+	psStorer, _, _ := createPushSyncNode(t, storerAddr, defaultPrices, nil, nil, crypto.NewDefaultSigner(key), mock.WithClosestPeerErr(topology.ErrWantSelf))
+
+	recorder := streamtest.New(streamtest.WithProtocols(psStorer.Protocol()), streamtest.WithBaseAddr(pivotAddr))
+	psPivot, _, _ := createPushSyncNode(t, pivotAddr, defaultPrices, recorder, nil, defaultSigner(chunk), mock.WithClosestPeer(storerAddr))
+	psPivot.SetReceiptStore(statestore.NewStateStore())
+
+	if _, err := psPivot.PushChunkToClosest(context.Background(), chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := psPivot.PruneReceipts(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Fatalf("want 0 pruned receipts, got %d", n)
+	}
+	if _, err := psPivot.Receipt(chunk.Address()); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err = psPivot.PruneReceipts(time.Now().Add(pushsync.ReceiptTTL + time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("want 1 pruned receipt, got %d", n)
+	}
+	if _, err := psPivot.Receipt(chunk.Address()); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("want %v, got %v", storage.ErrNotFound, err)
+	}
+}
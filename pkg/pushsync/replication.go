@@ -0,0 +1,41 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pushsync
+
+import "context"
+
+const (
+	// DefaultReplicationFactor is the default number of the neighborhood
+	// peers the chunk is pushed to by the first node on the forwarding
+	// path which is able to reach the neighborhood of the chunk.
+	DefaultReplicationFactor = 3
+	// MaxReplicationFactor is the maximum number of
+	// the neighborhood peers the chunk is pushed to.
+	MaxReplicationFactor = 8
+)
+
+type replicationFactorKey struct{}
+
+// SetReplicationFactor returns a new context which requests the chunks
+// pushed with it to be replicated to the given number of the neighborhood
+// peers. The zero value leaves the choice to the nodes on the path.
+func SetReplicationFactor(ctx context.Context, factor uint8) context.Context {
+	return context.WithValue(ctx, replicationFactorKey{}, factor)
+}
+
+// GetReplicationFactor returns the replication factor
+// requested with the context, or zero if it is not set.
+func GetReplicationFactor(ctx context.Context) uint8 {
+	factor, _ := ctx.Value(replicationFactorKey{}).(uint8)
+	return factor
+}
+
+// replicationFactor resolves the requested replication factor.
+func (ps *PushSync) replicationFactor(requested uint8) int {
+	if requested == 0 {
+		requested = ps.replicationDefault
+	}
+	return int(min(max(requested, 1), MaxReplicationFactor))
+}
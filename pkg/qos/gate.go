@@ -0,0 +1,115 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package qos
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Gate limits the number of the concurrent operations. The lower classes
+// may occupy only a share of the capacity, the rest is kept for the higher
+// classes, and the waiting operations are admitted in the class order.
+type Gate struct {
+	mu       sync.Mutex
+	capacity int
+	inUse    int
+	waiting  [classCount][]chan struct{}
+}
+
+// NewGate returns the gate admitting up to capacity concurrent operations.
+func NewGate(capacity int) *Gate {
+	return &Gate{capacity: max(capacity, 1)}
+}
+
+// limit returns the number of the operations in use up to
+// which the operations of the class are admitted.
+func (g *Gate) limit(c Class) int {
+	switch c {
+	case ClassInteractive:
+		return g.capacity
+	case ClassPrefetch:
+		return max(g.capacity*3/4, 1)
+	default:
+		return max(g.capacity/2, 1)
+	}
+}
+
+// Acquire blocks until the operation of the class is admitted
+// and returns the function releasing its place.
+func (g *Gate) Acquire(ctx context.Context, c Class) (release func(), err error) {
+	if int(c) >= classCount {
+		c = ClassBackground
+	}
+
+	g.mu.Lock()
+	if g.admissible(c) {
+		g.inUse++
+		g.mu.Unlock()
+		return g.releaseFunc(), nil
+	}
+	admitted := make(chan struct{})
+	g.waiting[c] = append(g.waiting[c], admitted)
+	g.mu.Unlock()
+
+	select {
+	case <-admitted:
+		return g.releaseFunc(), nil
+	case <-ctx.Done():
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if i := slices.Index(g.waiting[c], admitted); i >= 0 {
+			g.waiting[c] = slices.Delete(g.waiting[c], i, i+1)
+		} else {
+			// admitted concurrently with the cancellation
+			g.inUse--
+			g.admit()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// InUse returns the number of the admitted operations.
+func (g *Gate) InUse() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.inUse
+}
+
+// admissible reports whether the operation of the class may be admitted
+// without waiting. It must be called with the mutex held.
+func (g *Gate) admissible(c Class) bool {
+	for i := 0; i <= int(c); i++ {
+		if len(g.waiting[i]) > 0 {
+			return false
+		}
+	}
+	return g.inUse < g.limit(c)
+}
+
+// admit admits the waiting operations in the class order.
+// It must be called with the mutex held.
+func (g *Gate) admit() {
+	for c := range g.waiting {
+		for len(g.waiting[c]) > 0 && g.inUse < g.limit(Class(c)) {
+			close(g.waiting[c][0])
+			g.waiting[c] = g.waiting[c][1:]
+			g.inUse++
+		}
+	}
+}
+
+func (g *Gate) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			g.mu.Lock()
+			defer g.mu.Unlock()
+			g.inUse--
+			g.admit()
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package qos provides the priority classes of the chunk operations.
+//
+// The class is carried by the context of the operation and it is applied
+// by the internal queues of the retrieval and the pushing, so that the
+// background maintenance never takes the capacity needed by the user
+// facing operations.
+package qos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidClass is returned when the class name is not known.
+var ErrInvalidClass = errors.New("invalid priority class")
+
+// Class is the priority class of the chunk operation.
+// The zero value is the highest priority class.
+type Class uint8
+
+const (
+	// ClassInteractive is the class of the operations the user waits for,
+	// like the downloads and the direct uploads.
+	ClassInteractive Class = iota
+	// ClassPrefetch is the class of the operations done ahead of the
+	// user needing them.
+	ClassPrefetch
+	// ClassBackground is the class of the maintenance operations,
+	// like the syncing of the deferred uploads and the resyncing.
+	ClassBackground
+
+	classCount = int(ClassBackground) + 1
+)
+
+var classNames = [classCount]string{
+	ClassInteractive: "interactive",
+	ClassPrefetch:    "prefetch",
+	ClassBackground:  "background",
+}
+
+// String returns the name of the class.
+func (c Class) String() string {
+	if int(c) < classCount {
+		return classNames[c]
+	}
+	return fmt.Sprintf("Class(%d)", c)
+}
+
+// ParseClass returns the class with the given name.
+func ParseClass(s string) (Class, error) {
+	for c, name := range classNames {
+		if name == s {
+			return Class(c), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidClass, s)
+}
+
+type classKey struct{}
+
+// WithClass returns the context carrying the priority class.
+func WithClass(ctx context.Context, c Class) context.Context {
+	return context.WithValue(ctx, classKey{}, c)
+}
+
+// ClassFrom returns the priority class carried by the context,
+// the interactive class if there is none.
+func ClassFrom(ctx context.Context) Class {
+	if c, ok := ctx.Value(classKey{}).(Class); ok {
+		return c
+	}
+	return ClassInteractive
+}
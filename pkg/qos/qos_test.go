@@ -0,0 +1,119 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package qos_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/qos"
+)
+
+func TestParseClass(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []qos.Class{qos.ClassInteractive, qos.ClassPrefetch, qos.ClassBackground} {
+		got, err := qos.ParseClass(c.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c {
+			t.Fatalf("got class %s, want %s", got, c)
+		}
+	}
+	if _, err := qos.ParseClass("urgent"); !errors.Is(err, qos.ErrInvalidClass) {
+		t.Fatalf("got error %v, want %v", err, qos.ErrInvalidClass)
+	}
+}
+
+func TestClassFrom(t *testing.T) {
+	t.Parallel()
+
+	if got := qos.ClassFrom(context.Background()); got != qos.ClassInteractive {
+		t.Fatalf("got default class %s, want %s", got, qos.ClassInteractive)
+	}
+	ctx := qos.WithClass(context.Background(), qos.ClassBackground)
+	if got := qos.ClassFrom(ctx); got != qos.ClassBackground {
+		t.Fatalf("got class %s, want %s", got, qos.ClassBackground)
+	}
+}
+
+func TestGate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	g := qos.NewGate(4)
+
+	// the background operations take only half of the capacity
+	var releases []func()
+	for range 2 {
+		release, err := g.Acquire(ctx, qos.ClassBackground)
+		if err != nil {
+			t.Fatal(err)
+		}
+		releases = append(releases, release)
+	}
+	if _, err := acquireTimeout(g, qos.ClassBackground); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// the kept capacity is available to the interactive operations
+	for range 2 {
+		release, err := acquireTimeout(g, qos.ClassInteractive)
+		if err != nil {
+			t.Fatal(err)
+		}
+		releases = append(releases, release)
+	}
+	if got := g.InUse(); got != 4 {
+		t.Fatalf("got %d in use, want 4", got)
+	}
+
+	// the waiting operations are admitted in the class order
+	admitted := make(chan qos.Class, 2)
+	for _, c := range []qos.Class{qos.ClassBackground, qos.ClassInteractive} {
+		go func() {
+			release, err := g.Acquire(ctx, c)
+			if err != nil {
+				return
+			}
+			admitted <- c
+			t.Cleanup(release)
+		}()
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	releases[3]()
+	releases[3]() // released only once
+	if got := <-admitted; got != qos.ClassInteractive {
+		t.Fatalf("got admitted class %s, want %s", got, qos.ClassInteractive)
+	}
+	select {
+	case c := <-admitted:
+		t.Fatalf("class %s admitted over the capacity", c)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// the background operation waits until the background share frees up
+	releases[2]()
+	releases[1]()
+	select {
+	case c := <-admitted:
+		t.Fatalf("class %s admitted over its share", c)
+	case <-time.After(50 * time.Millisecond):
+	}
+	releases[0]()
+	if got := <-admitted; got != qos.ClassBackground {
+		t.Fatalf("got admitted class %s, want %s", got, qos.ClassBackground)
+	}
+}
+
+func acquireTimeout(g *qos.Gate, c qos.Class) (func(), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	return g.Acquire(ctx, c)
+}
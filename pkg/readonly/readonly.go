@@ -0,0 +1,48 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package readonly holds the read-only mode of the node, which is shared by
+// all the frontends of the node, the HTTP API, the gRPC API, the S3 gateway
+// and the WebDAV server, so that the mode set over one of them is enforced
+// by all of them.
+package readonly
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrReadOnly is returned when the state of the node is to be modified
+// while the node is in the read-only mode.
+var ErrReadOnly = errors.New("node is in read-only mode")
+
+// Mode is the read-only mode of the node. The nil mode is never enabled.
+type Mode struct {
+	enabled atomic.Bool
+}
+
+// New returns the mode, enabled or not.
+func New(enabled bool) *Mode {
+	m := new(Mode)
+	m.enabled.Store(enabled)
+	return m
+}
+
+// Enabled reports whether the node is in the read-only mode.
+func (m *Mode) Enabled() bool {
+	return m != nil && m.enabled.Load()
+}
+
+// Set enables or disables the mode and reports whether it changed.
+func (m *Mode) Set(enabled bool) bool {
+	return m.enabled.Swap(enabled) != enabled
+}
+
+// Check returns ErrReadOnly if the node is in the read-only mode.
+func (m *Mode) Check() error {
+	if m.Enabled() {
+		return ErrReadOnly
+	}
+	return nil
+}
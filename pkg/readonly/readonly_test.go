@@ -0,0 +1,38 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package readonly_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/readonly"
+)
+
+func TestMode(t *testing.T) {
+	t.Parallel()
+
+	var nilMode *readonly.Mode
+	if nilMode.Enabled() || nilMode.Check() != nil {
+		t.Fatal("nil mode enabled")
+	}
+
+	m := readonly.New(true)
+	if !m.Enabled() {
+		t.Fatal("mode not enabled")
+	}
+	if err := m.Check(); !errors.Is(err, readonly.ErrReadOnly) {
+		t.Fatalf("got error %v, want %v", err, readonly.ErrReadOnly)
+	}
+	if m.Set(true) {
+		t.Fatal("mode changed by the same value")
+	}
+	if !m.Set(false) {
+		t.Fatal("mode not changed")
+	}
+	if m.Enabled() || m.Check() != nil {
+		t.Fatal("mode enabled")
+	}
+}
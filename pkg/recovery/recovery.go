@@ -0,0 +1,190 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package recovery implements the prompt repair of the content that cannot
+// be retrieved from the network.
+//
+// The publishers register the overlay prefixes of the pinning services
+// holding their content in the recovery feed. When the content cannot be
+// retrieved, the downloader looks up the targets in the recovery feed of the
+// publisher and sends them the recovery request over pss. The pinning
+// services respond to the request by re-uploading the pinned content, so
+// that the download can be retried.
+package recovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/ethersphere/bee/v2/pkg/steward"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "recovery"
+
+var (
+	// Topic is the pss topic of the recovery requests.
+	Topic = pss.NewTopic("RECOVERY")
+	// FeedTopic is the topic of the feed the publishers register the
+	// recovery targets in.
+	FeedTopic = Topic[:]
+
+	// ErrNoTargets is returned when the publisher registered no targets.
+	ErrNoTargets = errors.New("no recovery targets")
+	// ErrInvalidTargets is returned when the recovery feed update is malformed.
+	ErrInvalidTargets = errors.New("invalid recovery targets")
+)
+
+// recipient is the key the requests are encrypted for. It is derived from
+// the topic, so that every target is able to decrypt the requests.
+var recipient = &crypto.Secp256k1PrivateKeyFromBytes(Topic[:]).PublicKey
+
+// EncodeTargets returns the payload of the recovery feed update with the
+// targets. The targets are encoded as their length followed by the
+// concatenated targets.
+func EncodeTargets(targets pss.Targets) ([]byte, error) {
+	if len(targets) == 0 {
+		return nil, ErrNoTargets
+	}
+	size := len(targets[0])
+	if size == 0 || size > swarm.HashSize {
+		return nil, ErrInvalidTargets
+	}
+	b := make([]byte, 1, 1+len(targets)*size)
+	b[0] = byte(size)
+	for _, t := range targets {
+		if len(t) != size {
+			return nil, ErrInvalidTargets
+		}
+		b = append(b, t...)
+	}
+	if len(b) > swarm.ChunkSize {
+		return nil, ErrInvalidTargets
+	}
+	return b, nil
+}
+
+// DecodeTargets returns the targets of the recovery feed update payload.
+func DecodeTargets(b []byte) (pss.Targets, error) {
+	if len(b) < 2 {
+		return nil, ErrInvalidTargets
+	}
+	size := int(b[0])
+	b = b[1:]
+	if size == 0 || size > swarm.HashSize || len(b)%size != 0 {
+		return nil, ErrInvalidTargets
+	}
+	targets := make(pss.Targets, 0, len(b)/size)
+	for i := 0; i < len(b); i += size {
+		targets = append(targets, pss.Target(b[i:i+size]))
+	}
+	return targets, nil
+}
+
+// Targets returns the targets registered in the latest update of the
+// recovery feed looked up with the lookup.
+func Targets(ctx context.Context, lookup feeds.Lookup) (pss.Targets, error) {
+	ch, err := feeds.Latest(ctx, lookup, 0)
+	if err != nil {
+		return nil, fmt.Errorf("recovery feed lookup: %w", err)
+	}
+	if ch == nil {
+		return nil, ErrNoTargets
+	}
+	wc, err := feeds.FromChunk(ch)
+	if err != nil {
+		return nil, fmt.Errorf("recovery feed update: %w", err)
+	}
+	return DecodeTargets(wc.Data()[swarm.SpanSize:])
+}
+
+// Callback sends the recovery request of the content.
+type Callback func(ctx context.Context, root swarm.Address) error
+
+// NewCallback returns the callback sending the recovery requests stamped
+// with the stamper to the targets of the recovery feed looked up with the lookup.
+func NewCallback(sender pss.Sender, stamper postage.Stamper, lookup feeds.Lookup) Callback {
+	return func(ctx context.Context, root swarm.Address) error {
+		targets, err := Targets(ctx, lookup)
+		if err != nil {
+			return err
+		}
+		if err := sender.Send(ctx, Topic, root.Bytes(), stamper, recipient, targets); err != nil {
+			return fmt.Errorf("send recovery request: %w", err)
+		}
+		return nil
+	}
+}
+
+// Repairer re-uploads the content the recovery request is for.
+type Repairer interface {
+	Repair(ctx context.Context, root swarm.Address) error
+}
+
+// RepairFunc is the function implementing the Repairer interface.
+type RepairFunc func(ctx context.Context, root swarm.Address) error
+
+// Repair calls f(ctx, root).
+func (f RepairFunc) Repair(ctx context.Context, root swarm.Address) error {
+	return f(ctx, root)
+}
+
+// NewRepairHandler returns the pss handler with which the pinning services
+// respond to the recovery requests with the repairer.
+func NewRepairHandler(r Repairer, logger log.Logger) pss.Handler {
+	logger = logger.WithName(loggerName).Register()
+	return func(ctx context.Context, msg []byte) {
+		if len(msg) < swarm.HashSize {
+			logger.Debug("invalid recovery request", "size", len(msg))
+			return
+		}
+		addr := swarm.NewAddress(msg[:swarm.HashSize])
+		if err := r.Repair(ctx, addr); err != nil {
+			logger.Debug("content repair failed", "address", addr, "error", err)
+			return
+		}
+		logger.Debug("content repaired", "address", addr)
+	}
+}
+
+// StamperFunc returns the stamper of the re-uploaded chunks and the
+// function saving the state of its issuer.
+type StamperFunc func() (postage.Stamper, func() error, error)
+
+// Pins reports whether the content is pinned by the node.
+type Pins interface {
+	HasPin(root swarm.Address) (bool, error)
+}
+
+// NewPinRepairer returns the repairer re-uploading the content pinned by
+// the node stamped with the stamper. The requests for the content not
+// pinned by the node are ignored.
+func NewPinRepairer(pins Pins, s steward.Interface, stamper StamperFunc) Repairer {
+	return RepairFunc(func(ctx context.Context, root swarm.Address) error {
+		pinned, err := pins.HasPin(root)
+		if err != nil {
+			return fmt.Errorf("has pin: %w", err)
+		}
+		if !pinned {
+			return nil
+		}
+
+		st, save, err := stamper()
+		if err != nil {
+			return fmt.Errorf("stamper: %w", err)
+		}
+		err = s.Reupload(ctx, root, st)
+		if serr := save(); serr != nil {
+			err = errors.Join(err, fmt.Errorf("save issuer: %w", serr))
+		}
+		return err
+	})
+}
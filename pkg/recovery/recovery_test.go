@@ -0,0 +1,156 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package recovery_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/feeds/sequence"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	postagemock "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/ethersphere/bee/v2/pkg/recovery"
+	stewardmock "github.com/ethersphere/bee/v2/pkg/steward/mock"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+type sentRequest struct {
+	topic   pss.Topic
+	payload []byte
+	targets pss.Targets
+}
+
+type mockSender struct {
+	sent []sentRequest
+}
+
+func (s *mockSender) Send(_ context.Context, topic pss.Topic, payload []byte, _ postage.Stamper, _ *ecdsa.PublicKey, targets pss.Targets) error {
+	s.sent = append(s.sent, sentRequest{topic: topic, payload: payload, targets: targets})
+	return nil
+}
+
+func TestTargets(t *testing.T) {
+	t.Parallel()
+
+	targets := pss.Targets{{1, 2}, {3, 4}, {5, 6}}
+	b, err := recovery.EncodeTargets(targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := recovery.DecodeTargets(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(targets) {
+		t.Fatalf("got %d targets, want %d", len(got), len(targets))
+	}
+	for i := range targets {
+		if !bytes.Equal(got[i], targets[i]) {
+			t.Fatalf("got target %x, want %x", got[i], targets[i])
+		}
+	}
+
+	if _, err := recovery.EncodeTargets(nil); !errors.Is(err, recovery.ErrNoTargets) {
+		t.Fatalf("got error %v, want %v", err, recovery.ErrNoTargets)
+	}
+	if _, err := recovery.EncodeTargets(pss.Targets{{1}, {2, 3}}); !errors.Is(err, recovery.ErrInvalidTargets) {
+		t.Fatalf("got error %v, want %v", err, recovery.ErrInvalidTargets)
+	}
+	if _, err := recovery.DecodeTargets([]byte{2, 1, 2, 3}); !errors.Is(err, recovery.ErrInvalidTargets) {
+		t.Fatalf("got error %v, want %v", err, recovery.ErrInvalidTargets)
+	}
+}
+
+func TestCallback(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(privKey)
+	owner, err := signer.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := inmemchunkstore.New()
+	lookup := sequence.NewFinder(store, feeds.New(recovery.FeedTopic, owner))
+	sender := new(mockSender)
+	callback := recovery.NewCallback(sender, postagemock.NewStamper(), lookup)
+	root := swarm.RandAddress(t)
+
+	// without the registered targets the request cannot be sent
+	if err := callback(context.Background(), root); !errors.Is(err, recovery.ErrNoTargets) {
+		t.Fatalf("got error %v, want %v", err, recovery.ErrNoTargets)
+	}
+
+	targets := pss.Targets{{1, 2}, {3, 4}}
+	payload, err := recovery.EncodeTargets(targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updater, err := sequence.NewUpdater(store, signer, recovery.FeedTopic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := updater.Update(context.Background(), time.Now().Unix(), payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := callback(context.Background(), root); err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 1 {
+		t.Fatalf("got %d sent requests, want 1", len(sender.sent))
+	}
+	req := sender.sent[0]
+	if req.topic != recovery.Topic {
+		t.Fatalf("got topic %x, want %x", req.topic, recovery.Topic)
+	}
+	if !bytes.Equal(req.payload, root.Bytes()) {
+		t.Fatalf("got payload %x, want %x", req.payload, root.Bytes())
+	}
+	if len(req.targets) != len(targets) || !bytes.Equal(req.targets[1], targets[1]) {
+		t.Fatalf("got targets %x, want %x", req.targets, targets)
+	}
+}
+
+type mockPins map[string]bool
+
+func (m mockPins) HasPin(root swarm.Address) (bool, error) {
+	return m[root.ByteString()], nil
+}
+
+func TestRepairHandler(t *testing.T) {
+	t.Parallel()
+
+	pinned := swarm.RandAddress(t)
+	s := new(stewardmock.Steward)
+	stamper := func() (postage.Stamper, func() error, error) {
+		return postagemock.NewStamper(), func() error { return nil }, nil
+	}
+	handler := recovery.NewRepairHandler(recovery.NewPinRepairer(mockPins{pinned.ByteString(): true}, s, stamper), log.Noop)
+
+	handler(context.Background(), []byte{1})
+	handler(context.Background(), swarm.RandAddress(t).Bytes())
+	if !s.LastAddress().IsZero() {
+		t.Fatalf("content not pinned re-uploaded: %s", s.LastAddress())
+	}
+
+	handler(context.Background(), pinned.Bytes())
+	if !s.LastAddress().Equal(pinned) {
+		t.Fatalf("got re-uploaded %s, want %s", s.LastAddress(), pinned)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package repair
+
+import (
+	m "github.com/ethersphere/bee/v2/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	Rounds                prometheus.Counter
+	SampledChunks         prometheus.Counter
+	UnderReplicatedChunks prometheus.Counter
+	RepairedReplicas      prometheus.Counter
+	RepairErrors          prometheus.Counter
+	CheckErrors           prometheus.Counter
+	ReplicationHealth     prometheus.Gauge
+	AverageReplicas       prometheus.Gauge
+}
+
+func newMetrics() metrics {
+	subsystem := "repair"
+
+	return metrics{
+		Rounds: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "rounds",
+			Help:      "Number of the repair rounds.",
+		}),
+		SampledChunks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "sampled_chunks",
+			Help:      "Number of the reserve chunks checked for their replication.",
+		}),
+		UnderReplicatedChunks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "under_replicated_chunks",
+			Help:      "Number of the checked chunks held by too few neighborhood peers.",
+		}),
+		RepairedReplicas: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "repaired_replicas",
+			Help:      "Number of the chunks pushed to the neighborhood peers missing them.",
+		}),
+		RepairErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "repair_errors",
+			Help:      "Number of the failed repair pushes.",
+		}),
+		CheckErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "check_errors",
+			Help:      "Number of the failed existence checks.",
+		}),
+		ReplicationHealth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "replication_health",
+			Help:      "Ratio of the chunks sampled in the last round held by enough neighborhood peers.",
+		}),
+		AverageReplicas: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "average_replicas",
+			Help:      "Average number of the neighborhood peers holding the chunks sampled in the last round.",
+		}),
+	}
+}
+
+func (s *Service) Metrics() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(s.metrics)
+}
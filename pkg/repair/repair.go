@@ -0,0 +1,305 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package repair keeps the chunks of the reserve replicated in the
+// neighborhood. It periodically samples the reserve, asks the neighborhood
+// peers which of the sampled chunks they hold, and pushes the chunks held
+// by too few of them to the peers missing them.
+package repair
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/existence"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "repair"
+
+const (
+	DefaultInterval    = 10 * time.Minute
+	DefaultSampleSize  = 256
+	DefaultMinReplicas = 2
+
+	roundTimeout = 5 * time.Minute
+)
+
+// Reserve is the reserve the chunks are sampled from.
+type Reserve interface {
+	ReserveIterateChunkItems(cb func(storer.ReserveChunkItem) (bool, error)) error
+	ReserveGet(ctx context.Context, addr swarm.Address, batchID []byte, stampHash []byte) (swarm.Chunk, error)
+	StorageRadius() uint8
+}
+
+// Existence asks the peers whether they hold the chunks, with the proofs
+// of the possession of the chunks they claim to hold, so that the chunks
+// are not left unrepaired on the false claims.
+type Existence interface {
+	HasWithProof(ctx context.Context, peer swarm.Address, addrs []swarm.Address) ([]bool, error)
+}
+
+// Pusher pushes the chunk to the peer missing it.
+type Pusher interface {
+	PushChunkToPeer(ctx context.Context, peer swarm.Address, ch swarm.Chunk) error
+}
+
+// Options are the options of the repair.
+type Options struct {
+	// Interval is the time between the repair rounds.
+	Interval time.Duration
+	// SampleSize is the number of the reserve chunks checked in every round.
+	SampleSize int
+	// MinReplicas is the number of the neighborhood peers, apart from
+	// this node, expected to hold every chunk of the reserve.
+	MinReplicas int
+}
+
+// Service runs the repair rounds in the background.
+type Service struct {
+	base      swarm.Address
+	reserve   Reserve
+	topology  topology.PeerIterator
+	existence Existence
+	pusher    Pusher
+	opts      Options
+	logger    log.Logger
+	metrics   metrics
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New starts the repair after the warmup.
+func New(
+	base swarm.Address,
+	reserve Reserve,
+	topology topology.PeerIterator,
+	existence Existence,
+	pusher Pusher,
+	logger log.Logger,
+	warmup time.Duration,
+	opts Options,
+) *Service {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.SampleSize <= 0 {
+		opts.SampleSize = DefaultSampleSize
+	}
+	if opts.MinReplicas <= 0 {
+		opts.MinReplicas = DefaultMinReplicas
+	}
+
+	s := &Service{
+		base:      base,
+		reserve:   reserve,
+		topology:  topology,
+		existence: existence,
+		pusher:    pusher,
+		opts:      opts,
+		logger:    logger.WithName(loggerName).Register(),
+		metrics:   newMetrics(),
+		quit:      make(chan struct{}),
+	}
+
+	s.wg.Add(1)
+	go s.worker(warmup)
+
+	return s
+}
+
+func (s *Service) worker(warmup time.Duration) {
+	defer s.wg.Done()
+
+	select {
+	case <-s.quit:
+		return
+	case <-time.After(warmup):
+	}
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), roundTimeout)
+		go func() {
+			select {
+			case <-s.quit:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		if err := s.round(ctx); err != nil {
+			s.logger.Debug("repair round failed", "error", err)
+		}
+		cancel()
+
+		select {
+		case <-s.quit:
+			return
+		case <-time.After(s.opts.Interval):
+		}
+	}
+}
+
+// Result is the outcome of the repair round.
+type Result struct {
+	Sampled         int
+	Neighbors       int
+	UnderReplicated int
+	Repaired        int
+}
+
+// round checks the replication of the sampled reserve chunks
+// and pushes the under-replicated ones to the peers missing them.
+func (s *Service) round(ctx context.Context) error {
+	res, err := s.Repair(ctx)
+	if err != nil {
+		return err
+	}
+	s.logger.Debug("repair round done", "sampled", res.Sampled, "neighbors", res.Neighbors, "under_replicated", res.UnderReplicated, "repaired", res.Repaired)
+	return nil
+}
+
+// Repair runs a single repair round.
+func (s *Service) Repair(ctx context.Context) (Result, error) {
+	s.metrics.Rounds.Inc()
+
+	radius := s.reserve.StorageRadius()
+	neighbors := s.neighbors(radius)
+
+	sample, err := s.sample(radius)
+	if err != nil {
+		return Result{}, err
+	}
+	res := Result{Sampled: len(sample), Neighbors: len(neighbors)}
+	if len(sample) == 0 || len(neighbors) == 0 {
+		return res, nil
+	}
+	s.metrics.SampledChunks.Add(float64(len(sample)))
+
+	addrs := make([]swarm.Address, len(sample))
+	for i, item := range sample {
+		addrs[i] = item.Address
+	}
+
+	// holders[i] are the neighbors holding the i-th sampled chunk,
+	// the neighbors which could not be asked are assumed to hold it
+	holders := make([][]bool, len(neighbors))
+	for i, peer := range neighbors {
+		holders[i] = s.peerHolds(ctx, peer, addrs)
+	}
+
+	var replicated, totalReplicas int
+	for j, item := range sample {
+		var missing []swarm.Address
+		for i, peer := range neighbors {
+			if !holders[i][j] {
+				missing = append(missing, peer)
+			}
+		}
+		replicas := len(neighbors) - len(missing)
+		totalReplicas += replicas
+		want := min(s.opts.MinReplicas, len(neighbors))
+		if replicas >= want {
+			replicated++
+			continue
+		}
+
+		res.UnderReplicated++
+		s.metrics.UnderReplicatedChunks.Inc()
+		res.Repaired += s.repairChunk(ctx, item, missing[:want-replicas])
+	}
+
+	s.metrics.ReplicationHealth.Set(float64(replicated) / float64(len(sample)))
+	s.metrics.AverageReplicas.Set(float64(totalReplicas) / float64(len(sample)))
+	return res, nil
+}
+
+// neighbors returns the connected peers within the storage radius.
+func (s *Service) neighbors(radius uint8) []swarm.Address {
+	var peers []swarm.Address
+	_ = s.topology.EachConnectedPeer(func(addr swarm.Address, _ uint8) (bool, bool, error) {
+		if swarm.Proximity(s.base.Bytes(), addr.Bytes()) >= radius {
+			peers = append(peers, addr)
+		}
+		return false, false, nil
+	}, topology.Select{})
+	return peers
+}
+
+// sample returns the uniform random sample of the
+// reserve chunks within the storage radius.
+func (s *Service) sample(radius uint8) ([]storer.ReserveChunkItem, error) {
+	var (
+		sample []storer.ReserveChunkItem
+		seen   int
+	)
+	err := s.reserve.ReserveIterateChunkItems(func(item storer.ReserveChunkItem) (bool, error) {
+		if item.Bin < radius {
+			return false, nil
+		}
+		seen++
+		if len(sample) < s.opts.SampleSize {
+			sample = append(sample, item)
+		} else if i := rand.IntN(seen); i < s.opts.SampleSize {
+			sample[i] = item
+		}
+		return false, nil
+	})
+	return sample, err
+}
+
+// peerHolds asks the peer which of the chunks it holds.
+func (s *Service) peerHolds(ctx context.Context, peer swarm.Address, addrs []swarm.Address) []bool {
+	holds := make([]bool, 0, len(addrs))
+	for start := 0; start < len(addrs); start += existence.MaxAddresses {
+		batch := addrs[start:min(start+existence.MaxAddresses, len(addrs))]
+		has, err := s.existence.HasWithProof(ctx, peer, batch)
+		if err != nil {
+			s.logger.Debug("existence check failed", "peer_address", peer, "error", err)
+			s.metrics.CheckErrors.Inc()
+			// the peer is not pushed to without knowing it misses the chunks
+			has = make([]bool, len(batch))
+			for i := range has {
+				has[i] = true
+			}
+		}
+		holds = append(holds, has...)
+	}
+	return holds
+}
+
+// repairChunk pushes the chunk to the peers and returns
+// the number of the peers it was successfully pushed to.
+func (s *Service) repairChunk(ctx context.Context, item storer.ReserveChunkItem, peers []swarm.Address) int {
+	ch, err := s.reserve.ReserveGet(ctx, item.Address, item.BatchID, item.StampHash)
+	if err != nil {
+		// the chunk may have been evicted since it was sampled
+		s.logger.Debug("reserve get failed", "chunk_address", item.Address, "error", err)
+		return 0
+	}
+
+	var pushed int
+	for _, peer := range peers {
+		if err := s.pusher.PushChunkToPeer(ctx, peer, ch); err != nil {
+			s.logger.Debug("repair push failed", "chunk_address", item.Address, "peer_address", peer, "error", err)
+			s.metrics.RepairErrors.Inc()
+			continue
+		}
+		pushed++
+		s.metrics.RepairedReplicas.Inc()
+	}
+	return pushed
+}
+
+func (s *Service) Close() error {
+	close(s.quit)
+	s.wg.Wait()
+	return nil
+}
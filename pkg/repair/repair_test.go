@@ -0,0 +1,168 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package repair_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/repair"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	chunktesting "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	topMock "github.com/ethersphere/bee/v2/pkg/topology/mock"
+)
+
+func TestRepair(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chunks  = make([]swarm.Chunk, 4)
+		reserve = &reserveMock{chunks: make(map[string]swarm.Chunk)}
+		full    = swarm.RandAddress(t)
+		partial = swarm.RandAddress(t)
+		empty   = swarm.RandAddress(t)
+	)
+	for i := range chunks {
+		chunks[i] = chunktesting.GenerateTestRandomChunk()
+		reserve.chunks[chunks[i].Address().ByteString()] = chunks[i]
+	}
+
+	// full holds all the chunks, partial only the first one and empty none
+	existence := existenceFunc(func(peer swarm.Address, addrs []swarm.Address) ([]bool, error) {
+		has := make([]bool, len(addrs))
+		for i, addr := range addrs {
+			switch {
+			case peer.Equal(full):
+				has[i] = true
+			case peer.Equal(partial):
+				has[i] = addr.Equal(chunks[0].Address())
+			}
+		}
+		return has, nil
+	})
+	pusher := &pusherMock{}
+
+	s := repair.New(
+		swarm.RandAddress(t),
+		reserve,
+		topMock.NewTopologyDriver(topMock.WithPeers(full, partial, empty)),
+		existence,
+		pusher,
+		log.Noop,
+		time.Hour,
+		repair.Options{MinReplicas: 2},
+	)
+	t.Cleanup(func() { _ = s.Close() })
+
+	res, err := s.Repair(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := repair.Result{Sampled: 4, Neighbors: 3, UnderReplicated: 3, Repaired: 3}
+	if res != want {
+		t.Fatalf("got result %+v, want %+v", res, want)
+	}
+
+	pusher.mu.Lock()
+	defer pusher.mu.Unlock()
+	if len(pusher.pushed) != 3 {
+		t.Fatalf("got %d pushes, want 3", len(pusher.pushed))
+	}
+	for _, p := range pusher.pushed {
+		if p.peer.Equal(full) {
+			t.Fatalf("chunk %s pushed to the peer holding it", p.chunk)
+		}
+		if p.chunk.Equal(chunks[0].Address()) {
+			t.Fatalf("replicated chunk %s pushed", p.chunk)
+		}
+	}
+}
+
+func TestRepairCheckError(t *testing.T) {
+	t.Parallel()
+
+	ch := chunktesting.GenerateTestRandomChunk()
+	reserve := &reserveMock{chunks: map[string]swarm.Chunk{ch.Address().ByteString(): ch}}
+	existence := existenceFunc(func(swarm.Address, []swarm.Address) ([]bool, error) {
+		return nil, errors.New("peer unavailable")
+	})
+	pusher := &pusherMock{}
+
+	s := repair.New(
+		swarm.RandAddress(t),
+		reserve,
+		topMock.NewTopologyDriver(topMock.WithPeers(swarm.RandAddress(t), swarm.RandAddress(t))),
+		existence,
+		pusher,
+		log.Noop,
+		time.Hour,
+		repair.Options{MinReplicas: 2},
+	)
+	t.Cleanup(func() { _ = s.Close() })
+
+	res, err := s.Repair(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the chunks are not pushed to the peers which could not be asked
+	if res.UnderReplicated != 0 || len(pusher.pushed) != 0 {
+		t.Fatalf("got result %+v with %d pushes, want none", res, len(pusher.pushed))
+	}
+}
+
+type reserveMock struct {
+	chunks map[string]swarm.Chunk
+}
+
+func (r *reserveMock) ReserveIterateChunkItems(cb func(storer.ReserveChunkItem) (bool, error)) error {
+	for _, ch := range r.chunks {
+		stop, err := cb(storer.ReserveChunkItem{Address: ch.Address()})
+		if err != nil || stop {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reserveMock) ReserveGet(_ context.Context, addr swarm.Address, _ []byte, _ []byte) (swarm.Chunk, error) {
+	ch, ok := r.chunks[addr.ByteString()]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return ch, nil
+}
+
+func (r *reserveMock) StorageRadius() uint8 { return 0 }
+
+type existenceFunc func(peer swarm.Address, addrs []swarm.Address) ([]bool, error)
+
+func (f existenceFunc) HasWithProof(_ context.Context, peer swarm.Address, addrs []swarm.Address) ([]bool, error) {
+	return f(peer, addrs)
+}
+
+type push struct {
+	peer  swarm.Address
+	chunk swarm.Address
+}
+
+type pusherMock struct {
+	mu     sync.Mutex
+	pushed []push
+}
+
+func (p *pusherMock) PushChunkToPeer(_ context.Context, peer swarm.Address, ch swarm.Chunk) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pushed = append(p.pushed, push{peer: peer, chunk: ch.Address()})
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
+	dto "github.com/prometheus/client_model/go"
 )
 
 func (s *Service) Handler(ctx context.Context, p p2p.Peer, stream p2p.Stream) error {
@@ -18,3 +19,9 @@ func (s *Service) Handler(ctx context.Context, p p2p.Peer, stream p2p.Stream) er
 func (s *Service) ClosestPeer(addr swarm.Address, skipPeers []swarm.Address, allowUpstream bool) (swarm.Address, error) {
 	return s.closestPeer(addr, skipPeers, allowUpstream)
 }
+
+func (s *Service) RequestDeduplicatedCount() float64 {
+	var m dto.Metric
+	_ = s.metrics.RequestDeduplicated.Write(&m)
+	return m.GetCounter().GetValue()
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package retrieval
+
+import (
+	"context"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// MaxOriginHints is the maximum number of the origin hints tried per chunk.
+const MaxOriginHints = 8
+
+type originHintsKey struct{}
+
+// SetOriginHints returns a new context with the overlays of the connected
+// peers likely to hold the retrieved content, e.g. its uploader. The origin
+// retrieval requests the chunks from them directly before the forwarding,
+// so that the content which is not yet synced to its neighborhood is found.
+func SetOriginHints(ctx context.Context, hints []swarm.Address) context.Context {
+	return context.WithValue(ctx, originHintsKey{}, hints)
+}
+
+// GetOriginHints returns the origin hints set with the context.
+func GetOriginHints(ctx context.Context) []swarm.Address {
+	hints, _ := ctx.Value(originHintsKey{}).([]swarm.Address)
+	if len(hints) > MaxOriginHints {
+		hints = hints[:MaxOriginHints]
+	}
+	return hints
+}
@@ -18,9 +18,11 @@ type metrics struct {
 	RequestCounter        prometheus.Counter
 	RequestSuccessCounter prometheus.Counter
 	RequestFailureCounter prometheus.Counter
+	RequestDeduplicated   prometheus.Counter
 	RequestDurationTime   prometheus.Histogram
 	RequestAttempts       prometheus.Histogram
 	PeerRequestCounter    prometheus.Counter
+	HintRequestCounter    prometheus.Counter
 	TotalRetrieved        prometheus.Counter
 	InvalidChunkRetrieved prometheus.Counter
 	ChunkPrice            prometheus.Summary
@@ -50,6 +52,12 @@ func newMetrics() metrics {
 			Name:      "request_failure_count",
 			Help:      "Number of requests which failed to retrieve chunk.",
 		}),
+		RequestDeduplicated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "request_deduplicated_count",
+			Help:      "Number of requests served by joining an in-flight retrieval of the same chunk.",
+		}),
 		RequestDurationTime: prometheus.NewHistogram(prometheus.HistogramOpts{
 			Namespace: m.Namespace,
 			Subsystem: subsystem,
@@ -68,6 +76,12 @@ func newMetrics() metrics {
 			Name:      "peer_request_count",
 			Help:      "Number of request to single peer.",
 		}),
+		HintRequestCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "hint_request_count",
+			Help:      "Number of request to the origin hint peers.",
+		}),
 		TotalRetrieved: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: m.Namespace,
 			Subsystem: subsystem,
@@ -12,15 +12,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/accounting"
 	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/chunktrace"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/p2p/protobuf"
 	"github.com/ethersphere/bee/v2/pkg/pricer"
+	"github.com/ethersphere/bee/v2/pkg/qos"
 	pb "github.com/ethersphere/bee/v2/pkg/retrieval/pb"
+	"github.com/ethersphere/bee/v2/pkg/scoreboard"
 	"github.com/ethersphere/bee/v2/pkg/skippeers"
 	"github.com/ethersphere/bee/v2/pkg/soc"
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
@@ -78,6 +82,9 @@ type Service struct {
 	tracer        *tracing.Tracer
 	caching       bool
 	errSkip       *skippeers.List
+	scores        *scoreboard.Board
+	gate          *qos.Gate
+	chunkTracer   *chunktrace.Tracer
 }
 
 func New(
@@ -91,6 +98,7 @@ func New(
 	pricer pricer.Interface,
 	tracer *tracing.Tracer,
 	forwarderCaching bool,
+	scores *scoreboard.Board,
 ) *Service {
 	return &Service{
 		addr:          addr,
@@ -105,6 +113,8 @@ func New(
 		tracer:        tracer,
 		caching:       forwarderCaching,
 		errSkip:       skippeers.NewList(time.Minute),
+		scores:        scores,
+		gate:          qos.NewGate(maxOriginRetrievals),
 	}
 }
 
@@ -114,8 +124,9 @@ func (s *Service) Protocol() p2p.ProtocolSpec {
 		Version: protocolVersion,
 		StreamSpecs: []p2p.StreamSpec{
 			{
-				Name:    streamName,
-				Handler: s.handler,
+				Name:         streamName,
+				Handler:      s.handler,
+				Compressible: true,
 			},
 		},
 	}
@@ -129,6 +140,7 @@ const (
 	originSuffix         = "_origin"
 	maxOriginErrors      = 32
 	maxMultiplexForwards = 2
+	maxOriginRetrievals  = 512 // concurrent origin retrievals, shared by the priority classes
 )
 
 func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr swarm.Address) (swarm.Chunk, error) {
@@ -147,16 +159,40 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 		flightRoute = chunkAddr.String() + originSuffix
 	}
 
-	totalRetrieveAttempts := 0
+	// executed is set only for the caller which initiated the network
+	// retrieval, the concurrent callers for the same chunk join it
+	var executed atomic.Bool
+
+	// the attempts are counted by the retrieval which may outlive the caller
+	var totalRetrieveAttempts atomic.Int64
 	requestStartTime := time.Now()
 	defer func() {
 		s.metrics.RequestDurationTime.Observe(time.Since(requestStartTime).Seconds())
-		s.metrics.RequestAttempts.Observe(float64(totalRetrieveAttempts))
+		if executed.Load() {
+			s.metrics.RequestAttempts.Observe(float64(totalRetrieveAttempts.Load()))
+		}
 	}()
 
 	spanCtx := context.WithoutCancel(ctx)
+	class := qos.ClassFrom(ctx)
 
-	v, _, err := s.singleflight.Do(ctx, flightRoute, func(ctx context.Context) (swarm.Chunk, error) {
+	var hints []swarm.Address
+	if origin {
+		hints = GetOriginHints(ctx)
+	}
+
+	v, shared, err := s.singleflight.Do(ctx, flightRoute, func(ctx context.Context) (swarm.Chunk, error) {
+		executed.Store(true)
+
+		// the lower priority origin retrievals wait
+		// for the capacity kept for the higher ones
+		if origin {
+			release, err := s.gate.Acquire(ctx, class)
+			if err != nil {
+				return nil, err
+			}
+			defer release()
+		}
 
 		skip := skippeers.NewList(0)
 		defer skip.Close()
@@ -170,6 +206,13 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 		quit := make(chan struct{})
 		defer close(quit)
 
+		// the in-flight requests to the peers are detached from the callers
+		// so they are not aborted when another peer delivers the chunk first,
+		// but they are canceled once all the callers have given up on it
+		peerCtx, cancelPeers := context.WithCancel(spanCtx)
+		stopCancel := context.AfterFunc(ctx, cancelPeers)
+		defer stopCancel()
+
 		var forwards = maxMultiplexForwards
 
 		// if we are the origin node, allow many preemptive retries to speed up the retrieval of the chunk.
@@ -205,11 +248,21 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 				retry()
 			case <-retryC:
 
-				totalRetrieveAttempts++
+				totalRetrieveAttempts.Add(1)
 				s.metrics.PeerRequestCounter.Inc()
 
-				fullSkip := append(skip.ChunkPeers(chunkAddr), s.errSkip.ChunkPeers(chunkAddr)...)
-				peer, err := s.closestPeer(chunkAddr, fullSkip, origin)
+				var (
+					peer swarm.Address
+					err  error
+				)
+				// the hinted peers are tried first, before the forwarding
+				if len(hints) > 0 {
+					peer, hints = hints[0], hints[1:]
+					s.metrics.HintRequestCounter.Inc()
+				} else {
+					fullSkip := append(skip.ChunkPeers(chunkAddr), s.errSkip.ChunkPeers(chunkAddr)...)
+					peer, err = s.closestPeer(chunkAddr, fullSkip, origin)
+				}
 
 				if errors.Is(err, topology.ErrNotFound) {
 					if skip.PruneExpiresAfter(chunkAddr, overDraftRefresh) == 0 { //no overdraft peers, we have depleted ALL peers
@@ -260,8 +313,10 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 
 				inflight++
 
+				s.chunkTracer.Record(chunkAddr, chunktrace.SubsystemRetrieval, "request", "peer_address", peer, "origin", origin)
+
 				go func() {
-					span, _, ctx := s.tracer.FollowSpanFromContext(spanCtx, "retrieve-chunk", s.logger, opentracing.Tag{Key: "address", Value: chunkAddr.String()})
+					span, _, ctx := s.tracer.FollowSpanFromContext(peerCtx, "retrieve-chunk", s.logger, opentracing.Tag{Key: "address", Value: chunkAddr.String()})
 					defer span.Finish()
 					s.retrieveChunk(ctx, quit, chunkAddr, peer, resultC, action, span)
 				}()
@@ -272,9 +327,12 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 
 				if res.err == nil {
 					loggerV1.Debug("retrieved chunk", "chunk_address", chunkAddr, "peer_address", res.peer, "peer_proximity", swarm.Proximity(res.peer.Bytes(), chunkAddr.Bytes()))
+					s.chunkTracer.Record(chunkAddr, chunktrace.SubsystemRetrieval, "delivered", "peer_address", res.peer)
 					return res.chunk, nil
 				}
 
+				s.chunkTracer.Record(chunkAddr, chunktrace.SubsystemRetrieval, "request failed", "peer_address", res.peer, "error", res.err)
+
 				loggerV1.Debug("failed to get chunk", "chunk_address", chunkAddr, "peer_address", res.peer,
 					"peer_proximity", swarm.Proximity(res.peer.Bytes(), chunkAddr.Bytes()), "error", res.err)
 
@@ -286,6 +344,9 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 
 		return nil, storage.ErrNotFound
 	})
+	if shared && !executed.Load() {
+		s.metrics.RequestDeduplicated.Inc()
+	}
 	if err != nil {
 		s.metrics.RequestFailureCounter.Inc()
 		s.logger.Debug("retrieval failed", "chunk_address", chunkAddr, "error", err)
@@ -313,6 +374,14 @@ func (s *Service) retrieveChunk(ctx context.Context, quit chan struct{}, chunkAd
 		} else {
 			span.LogFields(olog.Bool("success", true))
 		}
+		var size int
+		if chunk != nil {
+			size = len(chunk.Data())
+		}
+		// the requests canceled by the callers do not reflect on the peer
+		if !errors.Is(err, context.Canceled) {
+			s.scores.Record(peer, time.Since(startTime), size, err)
+		}
 		select {
 		case result <- retrievalResult{err: err, chunk: chunk, peer: peer}:
 		case <-quit:
@@ -391,14 +460,14 @@ func (s *Service) closestPeer(addr swarm.Address, skipPeers []swarm.Address, all
 
 	var (
 		closest swarm.Address
+		filter  topology.Select
 		err     error
 	)
 
-	closest, err = s.peerSuggester.ClosestPeer(addr, false, topology.Select{Reachable: true, Healthy: true}, skipPeers...)
-	if errors.Is(err, topology.ErrNotFound) {
-		closest, err = s.peerSuggester.ClosestPeer(addr, false, topology.Select{Reachable: true}, skipPeers...)
-		if errors.Is(err, topology.ErrNotFound) {
-			closest, err = s.peerSuggester.ClosestPeer(addr, false, topology.Select{}, skipPeers...)
+	for _, filter = range []topology.Select{{Reachable: true, Healthy: true}, {Reachable: true}, {}} {
+		closest, err = s.peerSuggester.ClosestPeer(addr, false, filter, skipPeers...)
+		if !errors.Is(err, topology.ErrNotFound) {
+			break
 		}
 	}
 
@@ -406,19 +475,36 @@ func (s *Service) closestPeer(addr swarm.Address, skipPeers []swarm.Address, all
 		return swarm.Address{}, err
 	}
 
+	if err := s.checkUpstream(addr, closest, allowUpstream); err != nil {
+		return swarm.Address{}, err
+	}
+
+	// prefer the better performing peer among the equally close ones
+	return s.scores.Select(addr, closest, func(skip []swarm.Address) (swarm.Address, error) {
+		peer, err := s.peerSuggester.ClosestPeer(addr, false, filter, append(skip, skipPeers...)...)
+		if err != nil {
+			return swarm.Address{}, err
+		}
+		return peer, s.checkUpstream(addr, peer, allowUpstream)
+	}), nil
+}
+
+// checkUpstream returns topology.ErrNotFound if the upstream requests are
+// not allowed and the peer is not closer to the chunk than this node is.
+func (s *Service) checkUpstream(addr, peer swarm.Address, allowUpstream bool) error {
 	if allowUpstream {
-		return closest, nil
+		return nil
 	}
 
-	closer, err := closest.Closer(addr, s.addr)
+	closer, err := peer.Closer(addr, s.addr)
 	if err != nil {
-		return swarm.Address{}, fmt.Errorf("distance compare addr %s closest %s base address %s: %w", addr.String(), closest.String(), s.addr.String(), err)
+		return fmt.Errorf("distance compare addr %s closest %s base address %s: %w", addr.String(), peer.String(), s.addr.String(), err)
 	}
 	if !closer {
-		return swarm.Address{}, topology.ErrNotFound
+		return topology.ErrNotFound
 	}
 
-	return closest, nil
+	return nil
 }
 
 func (s *Service) handler(p2pctx context.Context, p p2p.Peer, stream p2p.Stream) (err error) {
@@ -466,8 +552,10 @@ func (s *Service) handler(p2pctx context.Context, p p2p.Peer, stream p2p.Stream)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			// forward the request
+			s.chunkTracer.Record(addr, chunktrace.SubsystemRetrieval, "forward", "peer_address", p.Address)
 			chunk, err = s.RetrieveChunk(ctx, addr, p.Address)
 			if err != nil {
+				s.chunkTracer.Record(addr, chunktrace.SubsystemRetrieval, "forward failed", "peer_address", p.Address, "error", err)
 				return fmt.Errorf("retrieve chunk: %w", err)
 			}
 			forwarded = true
@@ -475,6 +563,7 @@ func (s *Service) handler(p2pctx context.Context, p p2p.Peer, stream p2p.Stream)
 			return fmt.Errorf("get from store: %w", err)
 		}
 	}
+	s.chunkTracer.Record(addr, chunktrace.SubsystemRetrieval, "serve", "peer_address", p.Address, "forwarded", forwarded)
 
 	chunkPrice := s.pricer.Price(chunk.Address())
 	debit, err := s.accounting.PrepareDebit(ctx, p.Address, chunkPrice)
@@ -506,6 +595,11 @@ func (s *Service) handler(p2pctx context.Context, p p2p.Peer, stream p2p.Stream)
 	return nil
 }
 
+// SetChunkTracer enables recording the retrievals of the traced chunks.
+func (s *Service) SetChunkTracer(t *chunktrace.Tracer) {
+	s.chunkTracer = t
+}
+
 func (s *Service) Close() error {
 	return s.errSkip.Close()
 }
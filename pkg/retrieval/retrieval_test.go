@@ -17,6 +17,7 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/accounting"
 	accountingmock "github.com/ethersphere/bee/v2/pkg/accounting/mock"
+	"github.com/ethersphere/bee/v2/pkg/chunktrace"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/p2p/protobuf"
@@ -150,6 +151,71 @@ func TestDelivery(t *testing.T) {
 	}
 }
 
+// TestChunkTrace tests that the retrieval of the traced chunk is recorded
+// on both the requesting and the serving node.
+func TestChunkTrace(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chunk         = testingc.FixtureChunk("0033")
+		logger        = log.Noop
+		serverStorer  = &testStorer{ChunkStore: inmemchunkstore.New()}
+		clientAddr    = swarm.MustParseHexAddress("9ee7add8")
+		serverAddr    = swarm.MustParseHexAddress("9ee7add7")
+		pricerMock    = pricermock.NewMockService(defaultPrice, defaultPrice)
+		clientTracer  = chunktrace.New(logger)
+		serverTracer  = chunktrace.New(logger)
+		untracedChunk = testingc.FixtureChunk("02c2")
+	)
+	if err := serverStorer.Put(context.Background(), chunk); err != nil {
+		t.Fatal(err)
+	}
+	if err := serverStorer.Put(context.Background(), untracedChunk); err != nil {
+		t.Fatal(err)
+	}
+	for _, tracer := range []*chunktrace.Tracer{clientTracer, serverTracer} {
+		if err := tracer.Tag(chunk.Address()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	server := createRetrieval(t, swarm.MustParseHexAddress("0034"), serverStorer, nil, nil, logger, accountingmock.NewAccounting(), pricerMock, nil, false)
+	server.SetChunkTracer(serverTracer)
+	recorder := streamtest.New(
+		streamtest.WithProtocols(server.Protocol()),
+		streamtest.WithBaseAddr(clientAddr),
+	)
+
+	mt := topologymock.NewTopologyDriver(topologymock.WithClosestPeer(serverAddr))
+	client := createRetrieval(t, clientAddr, &testStorer{ChunkStore: inmemchunkstore.New()}, recorder, mt, logger, accountingmock.NewAccounting(), pricerMock, nil, false)
+	client.SetChunkTracer(clientTracer)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	for _, ch := range []swarm.Chunk{chunk, untracedChunk} {
+		if _, err := client.RetrieveChunk(ctx, ch.Address(), swarm.ZeroAddress); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	actions := func(tracer *chunktrace.Tracer) (actions []string) {
+		tr, _ := tracer.Trace(chunk.Address())
+		for _, e := range tr.Events {
+			actions = append(actions, e.Action)
+		}
+		return actions
+	}
+	if got, want := strings.Join(actions(clientTracer), ","), "request,delivered"; got != want {
+		t.Fatalf("got client events %s, want %s", got, want)
+	}
+	if got, want := strings.Join(actions(serverTracer), ","), "serve"; got != want {
+		t.Fatalf("got server events %s, want %s", got, want)
+	}
+	if _, ok := clientTracer.Trace(untracedChunk.Address()); ok {
+		t.Fatal("want untagged chunk not traced")
+	}
+}
+
 func TestWaitForInflight(t *testing.T) {
 	t.Parallel()
 
@@ -228,6 +294,87 @@ func TestWaitForInflight(t *testing.T) {
 	}
 }
 
+// TestDeduplicateConcurrentRequests tests that the concurrent requests for
+// the same chunk are served by a single network retrieval.
+func TestDeduplicateConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chunk      = testingc.FixtureChunk("0033")
+		logger     = log.Noop
+		pricerMock = pricermock.NewMockService(defaultPrice, defaultPrice)
+		serverAddr = swarm.MustParseHexAddress("9ee7add7")
+		clientAddr = swarm.MustParseHexAddress("9ee7add8")
+		mockStorer = &testStorer{ChunkStore: inmemchunkstore.New()}
+		requests   = 10
+	)
+
+	if err := mockStorer.Put(context.Background(), chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	server := createRetrieval(t, serverAddr, mockStorer, nil, nil, logger, accountingmock.NewAccounting(), pricerMock, nil, false)
+
+	started := make(chan struct{}, requests)
+	release := make(chan struct{})
+	recorder := streamtest.New(
+		streamtest.WithBaseAddr(clientAddr),
+		streamtest.WithProtocols(server.Protocol()),
+		streamtest.WithMiddlewares(func(h p2p.HandlerFunc) p2p.HandlerFunc {
+			return func(ctx context.Context, p p2p.Peer, s p2p.Stream) error {
+				started <- struct{}{}
+				<-release
+				return h(ctx, p, s)
+			}
+		}),
+	)
+
+	mt := topologymock.NewTopologyDriver(topologymock.WithClosestPeer(serverAddr))
+	client := createRetrieval(t, clientAddr, &testStorer{ChunkStore: inmemchunkstore.New()}, recorder, mt, logger, accountingmock.NewAccounting(), pricerMock, nil, false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	errC := make(chan error, requests)
+	retrieve := func() {
+		v, err := client.RetrieveChunk(ctx, chunk.Address(), swarm.ZeroAddress)
+		if err == nil && !bytes.Equal(v.Data(), chunk.Data()) {
+			err = errors.New("retrieved chunk data mismatch")
+		}
+		errC <- err
+	}
+
+	go retrieve()
+	select {
+	case <-started:
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+	for i := 1; i < requests; i++ {
+		go retrieve()
+	}
+	// give the concurrent requests time to join the in-flight retrieval
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < requests; i++ {
+		if err := <-errC; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := recorder.Records(serverAddr, "retrieval", "1.4.0", "retrieval")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(records); l != 1 {
+		t.Fatalf("got %d network requests, want 1", l)
+	}
+	if got, want := client.RequestDeduplicatedCount(), float64(requests-1); got != want {
+		t.Fatalf("got %v deduplicated requests, want %v", got, want)
+	}
+}
+
 func TestRetrieveChunk(t *testing.T) {
 	t.Parallel()
 
@@ -654,6 +801,52 @@ func TestRetrievePreemptiveRetry(t *testing.T) {
 	})
 }
 
+func TestRetrieveOriginHints(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logger = log.Noop
+		pricer = pricermock.NewMockService(defaultPrice, defaultPrice)
+		chunk  = testingc.FixtureChunk("0025")
+	)
+
+	uploaderAddress := swarm.MustParseHexAddress("0100000000000000000000000000000000000000000000000000000000000000")
+	clientAddress := swarm.MustParseHexAddress("0200000000000000000000000000000000000000000000000000000000000000")
+
+	// the chunk is only at the uploader which is not
+	// known to the topology as the closest peer
+	uploaderStorer := &testStorer{ChunkStore: inmemchunkstore.New()}
+	if err := uploaderStorer.Put(context.Background(), chunk); err != nil {
+		t.Fatal(err)
+	}
+	uploader := createRetrieval(t, uploaderAddress, uploaderStorer, nil, topologymock.NewTopologyDriver(), logger, accountingmock.NewAccounting(), pricer, nil, false)
+
+	client := createRetrieval(t,
+		clientAddress,
+		storemock.New(),
+		streamtest.New(streamtest.WithProtocols(uploader.Protocol())),
+		topologymock.NewTopologyDriver(),
+		logger,
+		accountingmock.NewAccounting(),
+		pricer,
+		nil,
+		false,
+	)
+
+	if _, err := client.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress); !errors.Is(err, topology.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, topology.ErrNotFound)
+	}
+
+	ctx := retrieval.SetOriginHints(context.Background(), []swarm.Address{uploaderAddress})
+	got, err := client.RetrieveChunk(ctx, chunk.Address(), swarm.ZeroAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Data(), chunk.Data()) {
+		t.Fatalf("got data %x, want %x", got.Data(), chunk.Data())
+	}
+}
+
 func TestClosestPeer(t *testing.T) {
 	t.Parallel()
 
@@ -715,7 +908,7 @@ func createRetrieval(
 
 	radiusF := func() (uint8, error) { return swarm.MaxBins, nil }
 
-	ret := retrieval.New(addr, radiusF, storer, streamer, chunkPeerer, logger, accounting, pricer, tracer, forwarderCaching)
+	ret := retrieval.New(addr, radiusF, storer, streamer, chunkPeerer, logger, accounting, pricer, tracer, forwarderCaching, nil)
 	t.Cleanup(func() { ret.Close() })
 	return ret
 }
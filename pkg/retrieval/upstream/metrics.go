@@ -0,0 +1,50 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package upstream
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	m "github.com/ethersphere/bee/v2/pkg/metrics"
+)
+
+type metrics struct {
+	// all metrics fields must be exported
+	// to be able to return them by Metrics()
+	// using reflection
+
+	Hits   prometheus.Counter
+	Misses prometheus.Counter
+	Errors prometheus.Counter
+}
+
+func newMetrics() metrics {
+	subsystem := "upstream_retrieval"
+
+	return metrics{
+		Hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "hits",
+			Help:      "Number of chunks retrieved from the upstream gateway.",
+		}),
+		Misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "misses",
+			Help:      "Number of chunks not found on the upstream gateway.",
+		}),
+		Errors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "errors",
+			Help:      "Number of failed requests to the upstream gateway.",
+		}),
+	}
+}
+
+func (r *Retriever) Metrics() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(r.metrics)
+}
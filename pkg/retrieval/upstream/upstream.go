@@ -0,0 +1,137 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package upstream provides the retrieval of the chunks from the HTTP API of
+// an upstream bee gateway before falling back to the retrieval from the
+// network, enabling the hierarchical gateway deployments where the edge nodes
+// serve the cache misses from the shared upstream node.
+package upstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/retrieval"
+	"github.com/ethersphere/bee/v2/pkg/soc"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "upstream"
+
+var (
+	// ErrNotFound is returned when the upstream gateway does not have the chunk.
+	ErrNotFound = errors.New("upstream: chunk not found")
+	// ErrInvalidChunk is returned when the upstream gateway
+	// returns data which is not a valid chunk of the address.
+	ErrInvalidChunk = errors.New("upstream: invalid chunk")
+)
+
+var _ retrieval.Interface = (*Retriever)(nil)
+
+// Retriever retrieves the chunks requested by the node from the upstream
+// gateway, and the ones which the upstream does not have, or which are
+// requested by the other peers, by the next retrieval.
+type Retriever struct {
+	next    retrieval.Interface
+	base    string
+	client  *http.Client
+	logger  log.Logger
+	metrics metrics
+}
+
+// New returns a Retriever which requests the chunks from the API of the
+// upstream gateway at the URL with the timeout before using the next
+// retrieval.
+func New(next retrieval.Interface, upstreamURL string, timeout time.Duration, logger log.Logger) (*Retriever, error) {
+	u, err := url.Parse(upstreamURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse upstream url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid upstream url %q", upstreamURL)
+	}
+	return &Retriever{
+		next:    next,
+		base:    strings.TrimSuffix(u.String(), "/"),
+		client:  &http.Client{Timeout: timeout},
+		logger:  logger.WithName(loggerName).Register(),
+		metrics: newMetrics(),
+	}, nil
+}
+
+// RetrieveChunk implements the retrieval.Interface.
+func (r *Retriever) RetrieveChunk(ctx context.Context, address, sourcePeerAddr swarm.Address) (swarm.Chunk, error) {
+	// only the requests of this node are served from the upstream,
+	// the forwarded ones are left for the network to resolve
+	if !sourcePeerAddr.IsZero() {
+		return r.next.RetrieveChunk(ctx, address, sourcePeerAddr)
+	}
+
+	ch, err := r.fetch(ctx, address)
+	if err == nil {
+		r.metrics.Hits.Inc()
+		return ch, nil
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if errors.Is(err, ErrNotFound) {
+		r.metrics.Misses.Inc()
+	} else {
+		r.metrics.Errors.Inc()
+		r.logger.Debug("upstream retrieval failed", "chunk_address", address, "error", err)
+	}
+	return r.next.RetrieveChunk(ctx, address, sourcePeerAddr)
+}
+
+// fetch requests the chunk from the upstream gateway and validates that the
+// returned data is the chunk with the address, as the chunks are immutable
+// under their addresses the ETag of the response must match it too.
+func (r *Retriever) fetch(ctx context.Context, address swarm.Address) (swarm.Chunk, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.base+"/chunks/"+address.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("upstream: unexpected status %s", resp.Status)
+	}
+
+	if etag := resp.Header.Get("ETag"); etag != "" && strings.Trim(etag, `"`) != address.String() {
+		return nil, fmt.Errorf("%w: etag %s", ErrInvalidChunk, etag)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, swarm.SocMaxChunkSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read chunk: %w", err)
+	}
+	if len(data) > swarm.SocMaxChunkSize {
+		return nil, fmt.Errorf("%w: chunk too large", ErrInvalidChunk)
+	}
+
+	ch := swarm.NewChunk(address, data)
+	if !cac.Valid(ch) && !soc.Valid(ch) {
+		return nil, ErrInvalidChunk
+	}
+	return ch, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package upstream_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/retrieval/upstream"
+	testingc "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+var errNext = errors.New("next retrieval")
+
+type retrievalFunc func(ctx context.Context, address, sourcePeerAddr swarm.Address) (swarm.Chunk, error)
+
+func (f retrievalFunc) RetrieveChunk(ctx context.Context, address, sourcePeerAddr swarm.Address) (swarm.Chunk, error) {
+	return f(ctx, address, sourcePeerAddr)
+}
+
+func TestRetriever(t *testing.T) {
+	t.Parallel()
+
+	chunk := testingc.GenerateTestRandomChunk()
+	invalid := testingc.GenerateTestRandomChunk()
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/chunks/") {
+		case chunk.Address().String():
+			w.Header().Set("ETag", `"`+chunk.Address().String()+`"`)
+			_, _ = w.Write(chunk.Data())
+		case invalid.Address().String():
+			_, _ = w.Write(chunk.Data())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(gateway.Close)
+
+	var nextCalls int
+	next := retrievalFunc(func(_ context.Context, _, _ swarm.Address) (swarm.Chunk, error) {
+		nextCalls++
+		return nil, errNext
+	})
+
+	r, err := upstream.New(next, gateway.URL+"/", time.Second, log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := r.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(chunk) {
+		t.Fatal("got different chunk")
+	}
+	if nextCalls != 0 {
+		t.Fatalf("got %d next retrieval calls, want 0", nextCalls)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		address swarm.Address
+		source  swarm.Address
+	}{
+		{name: "not found", address: swarm.RandAddress(t), source: swarm.ZeroAddress},
+		{name: "invalid chunk", address: invalid.Address(), source: swarm.ZeroAddress},
+		{name: "forwarded", address: chunk.Address(), source: swarm.RandAddress(t)},
+	} {
+		nextCalls = 0
+		if _, err := r.RetrieveChunk(context.Background(), tc.address, tc.source); !errors.Is(err, errNext) {
+			t.Fatalf("%s: got error %v, want %v", tc.name, err, errNext)
+		}
+		if nextCalls != 1 {
+			t.Fatalf("%s: got %d next retrieval calls, want 1", tc.name, nextCalls)
+		}
+	}
+}
+
+func TestNewInvalidURL(t *testing.T) {
+	t.Parallel()
+
+	for _, u := range []string{"", "localhost:1633", "ftp://localhost", "http://"} {
+		if _, err := upstream.New(nil, u, time.Second, log.Noop); err == nil {
+			t.Errorf("got no error for url %q", u)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package s3gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// metadataCreatedKey is the manifest metadata key of the creation
+	// time of the bucket.
+	metadataCreatedKey = "Creation-Date"
+
+	// maxKeys is the maximum number of the keys listed at once.
+	maxKeys = 1000
+)
+
+// bucketNameRe matches the valid bucket names.
+var bucketNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// bucketTopic returns the topic of the feed of the bucket.
+func (g *Gateway) bucketTopic(name string) ([]byte, error) {
+	return crypto.LegacyKeccak256(append(append([]byte{}, g.rootTopic...), name...))
+}
+
+// checkBucket returns errNoSuchBucket when the bucket does not exist.
+func (g *Gateway) checkBucket(ctx context.Context, name string) error {
+	root, err := g.store.Latest(ctx, g.rootTopic)
+	if err != nil {
+		return err
+	}
+	if root.IsZero() {
+		return errNoSuchBucket
+	}
+	m, err := g.store.Load(ctx, root)
+	if err != nil {
+		return err
+	}
+	if _, err := m.Lookup(ctx, name); err != nil {
+		if errors.Is(err, manifest.ErrNotFound) {
+			return errNoSuchBucket
+		}
+		return err
+	}
+	return nil
+}
+
+// bucket returns the topic of the feed of the bucket and the reference
+// of its latest manifest.
+func (g *Gateway) bucket(ctx context.Context, name string) ([]byte, swarm.Address, error) {
+	if err := g.checkBucket(ctx, name); err != nil {
+		return nil, swarm.ZeroAddress, err
+	}
+	topic, err := g.bucketTopic(name)
+	if err != nil {
+		return nil, swarm.ZeroAddress, err
+	}
+	ref, err := g.store.Latest(ctx, topic)
+	if err != nil {
+		return nil, swarm.ZeroAddress, err
+	}
+	return topic, ref, nil
+}
+
+// fail writes the error response, the errors other than
+// the API errors are logged and reported as internal.
+func (g *Gateway) fail(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	var e apiError
+	if errors.As(err, &e) {
+		writeError(w, r, e)
+		return
+	}
+	g.logger.Debug(msg, "path", r.URL.Path, "error", err)
+	g.logger.Error(nil, msg, "path", r.URL.Path)
+	writeError(w, r, errInternal)
+}
+
+func (g *Gateway) listBucketsHandler(w http.ResponseWriter, r *http.Request) {
+	res := listAllMyBucketsResult{
+		Xmlns:   xmlns,
+		Owner:   owner{ID: g.store.Owner().Hex()},
+		Buckets: []bucketInfo{},
+	}
+
+	root, err := g.store.Latest(r.Context(), g.rootTopic)
+	if err != nil {
+		g.fail(w, r, "list buckets failed", err)
+		return
+	}
+	if !root.IsZero() {
+		m, err := g.store.Load(r.Context(), root)
+		if err != nil {
+			g.fail(w, r, "list buckets failed", err)
+			return
+		}
+		err = m.Walk(r.Context(), func(path string, e manifest.Entry) error {
+			res.Buckets = append(res.Buckets, bucketInfo{
+				Name:         path,
+				CreationDate: e.Metadata()[metadataCreatedKey],
+			})
+			return nil
+		})
+		if err != nil {
+			g.fail(w, r, "list buckets failed", err)
+			return
+		}
+	}
+
+	writeXML(w, http.StatusOK, res)
+}
+
+func (g *Gateway) headBucketHandler(w http.ResponseWriter, r *http.Request) {
+	if _, _, err := g.bucket(r.Context(), mux.Vars(r)["bucket"]); err != nil {
+		g.fail(w, r, "head bucket failed", err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (g *Gateway) createBucketHandler(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["bucket"]
+	if !bucketNameRe.MatchString(name) {
+		writeError(w, r, errInvalidBucketName)
+		return
+	}
+
+	err := g.updateRoot(r.Context(), func(m *manifestfs.Manifest) error {
+		_, err := m.Lookup(r.Context(), name)
+		switch {
+		case err == nil:
+			return errBucketAlreadyOwnedByYou
+		case !errors.Is(err, manifest.ErrNotFound):
+			return err
+		}
+		return m.Add(r.Context(), name, manifest.NewEntry(manifestfs.EmptyReference, map[string]string{
+			metadataCreatedKey: time.Now().UTC().Format(time.RFC3339),
+		}))
+	})
+	if err != nil {
+		g.fail(w, r, "create bucket failed", err)
+		return
+	}
+
+	w.Header().Set("Location", "/"+name)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (g *Gateway) deleteBucketHandler(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["bucket"]
+
+	err := g.updateRoot(r.Context(), func(m *manifestfs.Manifest) error {
+		if _, err := m.Lookup(r.Context(), name); err != nil {
+			if errors.Is(err, manifest.ErrNotFound) {
+				return errNoSuchBucket
+			}
+			return err
+		}
+		// the updates are serialized, no object is added in the meantime
+		empty, err := g.bucketEmpty(r.Context(), name)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return errBucketNotEmpty
+		}
+		return m.Remove(r.Context(), name)
+	})
+	if err != nil {
+		g.fail(w, r, "delete bucket failed", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// bucketEmpty reports whether the bucket has no objects.
+func (g *Gateway) bucketEmpty(ctx context.Context, name string) (bool, error) {
+	topic, err := g.bucketTopic(name)
+	if err != nil {
+		return false, err
+	}
+	ref, err := g.store.Latest(ctx, topic)
+	if err != nil || ref.IsZero() {
+		return true, err
+	}
+	m, err := g.store.Load(ctx, ref)
+	if err != nil {
+		return false, err
+	}
+	empty := true
+	err = m.Walk(ctx, func(string, manifest.Entry) error {
+		empty = false
+		return manifestfs.ErrStopWalk
+	})
+	return empty, err
+}
+
+// updateRoot applies the change to the manifest of the buckets and points
+// the root feed to the stored manifest.
+func (g *Gateway) updateRoot(ctx context.Context, change func(*manifestfs.Manifest) error) error {
+	return g.updateFeed(ctx, g.rootTopic, change)
+}
+
+// updateFeed applies the change to the manifest of the feed of the topic
+// with the new session.
+func (g *Gateway) updateFeed(ctx context.Context, topic []byte, change func(*manifestfs.Manifest) error) (err error) {
+	session, err := g.store.NewSession(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, session.Cleanup())
+		}
+	}()
+
+	ref, err := g.store.Update(ctx, session, topic, change)
+	if err != nil {
+		return err
+	}
+	return session.Done(ref)
+}
+
+func (g *Gateway) listObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["bucket"]
+	query := r.URL.Query()
+
+	_, ref, err := g.bucket(r.Context(), name)
+	if err != nil {
+		g.fail(w, r, "list objects failed", err)
+		return
+	}
+
+	if _, ok := query["location"]; ok {
+		writeXML(w, http.StatusOK, locationConstraint{Xmlns: xmlns})
+		return
+	}
+
+	limit := maxKeys
+	if v := query.Get("max-keys"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, r, errInvalidArgument)
+			return
+		}
+		limit = min(n, maxKeys)
+	}
+
+	res := listBucketResult{
+		Xmlns:     xmlns,
+		Name:      name,
+		Prefix:    query.Get("prefix"),
+		Delimiter: query.Get("delimiter"),
+		MaxKeys:   limit,
+	}
+
+	// after is the key or the common prefix the listing continues after
+	var after string
+	v2 := query.Get("list-type") == "2"
+	if v2 {
+		res.ContinuationToken = query.Get("continuation-token")
+		res.StartAfter = query.Get("start-after")
+		after = res.StartAfter
+		if res.ContinuationToken != "" {
+			b, err := base64.RawURLEncoding.DecodeString(res.ContinuationToken)
+			if err != nil {
+				writeError(w, r, errInvalidArgument)
+				return
+			}
+			after = string(b)
+		}
+	} else {
+		marker := query.Get("marker")
+		res.Marker = &marker
+		after = marker
+	}
+
+	if !ref.IsZero() {
+		m, err := g.store.Load(r.Context(), ref)
+		if err != nil {
+			g.fail(w, r, "list objects failed", err)
+			return
+		}
+		var last string
+		err = m.Walk(r.Context(), func(path string, e manifest.Entry) error {
+			if path <= after || !strings.HasPrefix(path, res.Prefix) {
+				return nil
+			}
+			// the keys under the common prefix listed on the previous page
+			if res.Delimiter != "" && strings.HasSuffix(after, res.Delimiter) && strings.HasPrefix(path, after) {
+				return nil
+			}
+
+			var cp string
+			if res.Delimiter != "" {
+				if i := strings.Index(path[len(res.Prefix):], res.Delimiter); i >= 0 {
+					cp = path[:len(res.Prefix)+i+len(res.Delimiter)]
+					if cp == last {
+						return nil
+					}
+				}
+			}
+			if len(res.Contents)+len(res.CommonPrefixes) == limit {
+				res.IsTruncated = true
+				return manifestfs.ErrStopWalk
+			}
+
+			if cp != "" {
+				res.CommonPrefixes = append(res.CommonPrefixes, commonPrefix{Prefix: cp})
+				last = cp
+				return nil
+			}
+			res.Contents = append(res.Contents, objectInfoOf(path, e.Metadata()))
+			last = path
+			return nil
+		})
+		if err != nil {
+			g.fail(w, r, "list objects failed", err)
+			return
+		}
+		if res.IsTruncated {
+			if v2 {
+				res.NextContinuationToken = base64.RawURLEncoding.EncodeToString([]byte(last))
+			} else {
+				res.NextMarker = last
+			}
+		}
+	}
+
+	if v2 {
+		count := len(res.Contents) + len(res.CommonPrefixes)
+		res.KeyCount = &count
+	}
+	writeXML(w, http.StatusOK, res)
+}
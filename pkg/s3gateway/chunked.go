@@ -0,0 +1,92 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package s3gateway
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// errMalformedChunk is returned when the aws-chunked body is malformed.
+var errMalformedChunk = errors.New("malformed aws-chunked body")
+
+// isChunked reports whether the body of the request is aws-chunked encoded,
+// which the clients use for the streaming uploads.
+func isChunked(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") ||
+		strings.Contains(r.Header.Get("Content-Encoding"), "aws-chunked")
+}
+
+// chunkedReader decodes the aws-chunked body. The chunk signatures and
+// the trailers are dropped, the requests are not authenticated.
+type chunkedReader struct {
+	r    *bufio.Reader
+	n    int64 // remaining bytes of the current chunk
+	done bool
+}
+
+func newChunkedReader(r io.Reader) *chunkedReader {
+	return &chunkedReader{r: bufio.NewReader(r)}
+}
+
+func (c *chunkedReader) Read(p []byte) (int, error) {
+	if c.n == 0 {
+		if c.done {
+			return 0, io.EOF
+		}
+		if err := c.header(); err != nil {
+			return 0, err
+		}
+		if c.done {
+			return 0, io.EOF
+		}
+	}
+
+	if int64(len(p)) > c.n {
+		p = p[:c.n]
+	}
+	n, err := c.r.Read(p)
+	c.n -= int64(n)
+	if c.n > 0 && errors.Is(err, io.EOF) {
+		return n, io.ErrUnexpectedEOF
+	}
+	if c.n == 0 && err == nil {
+		err = c.crlf()
+	}
+	return n, err
+}
+
+// header reads the header of the next chunk.
+func (c *chunkedReader) header() error {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("read chunk header: %w", io.ErrUnexpectedEOF)
+	}
+	size, _, _ := strings.Cut(strings.TrimRight(line, "\r\n"), ";")
+	n, err := strconv.ParseInt(size, 16, 64)
+	if err != nil || n < 0 {
+		return errMalformedChunk
+	}
+	c.n = n
+	c.done = n == 0
+	return nil
+}
+
+// crlf reads the line break closing the chunk data.
+func (c *chunkedReader) crlf() error {
+	var b [2]byte
+	if _, err := io.ReadFull(c.r, b[:]); err != nil {
+		return fmt.Errorf("read chunk end: %w", io.ErrUnexpectedEOF)
+	}
+	if b != [2]byte{'\r', '\n'} {
+		return errMalformedChunk
+	}
+	return nil
+}
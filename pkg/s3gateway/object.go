@@ -0,0 +1,236 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package s3gateway
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/file/joiner"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/langos"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// metadataETagKey is the manifest metadata key of the MD5 digest of
+	// the object, which the S3 clients verify the uploads with.
+	metadataETagKey = "ETag"
+
+	// lookaheadBufferSize is the size of the buffer the object data
+	// is read ahead with.
+	lookaheadBufferSize = 8 * swarm.ChunkSize
+)
+
+// objectInfoOf returns the listing information of the object.
+func objectInfoOf(key string, metadata map[string]string) objectInfo {
+	size, _ := strconv.ParseInt(metadata[manifestfs.MetadataSizeKey], 10, 64)
+	return objectInfo{
+		Key:          key,
+		LastModified: metadata[manifestfs.MetadataModifiedKey],
+		ETag:         strconv.Quote(metadata[metadataETagKey]),
+		Size:         size,
+		StorageClass: "STANDARD",
+	}
+}
+
+// countingReader counts and hashes the read data.
+type countingReader struct {
+	r    io.Reader
+	n    int64
+	hash hash.Hash
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	_, _ = c.hash.Write(p[:n])
+	return n, err
+}
+
+func (g *Gateway) putObjectHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bucket, key := vars["bucket"], vars["key"]
+
+	if r.Header.Get("X-Amz-Copy-Source") != "" || r.URL.Query().Has("uploadId") {
+		writeError(w, r, errNotImplemented)
+		return
+	}
+	if err := g.checkBucket(r.Context(), bucket); err != nil {
+		g.fail(w, r, "put object failed", err)
+		return
+	}
+
+	var body io.Reader = r.Body
+	if isChunked(r) {
+		body = newChunkedReader(r.Body)
+	}
+	etag, err := g.putObject(r.Context(), bucket, key, r.Header.Get("Content-Type"), body)
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, errMalformedChunk) {
+			err = errIncompleteBody
+		}
+		g.fail(w, r, "put object failed", err)
+		return
+	}
+
+	w.Header().Set("ETag", strconv.Quote(etag))
+	w.WriteHeader(http.StatusOK)
+}
+
+// putObject uploads the object data and adds it to the manifest of the
+// bucket. It returns the ETag of the object.
+func (g *Gateway) putObject(ctx context.Context, bucket, key, contentType string, r io.Reader) (etag string, err error) {
+	session, err := g.store.NewSession(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, session.Cleanup())
+		}
+	}()
+
+	// the data is uploaded before the feed of the bucket is locked
+	data := &countingReader{r: r, hash: md5.New()}
+	ref, err := builder.FeedPipeline(ctx, builder.NewPipelineBuilder(ctx, session, false, redundancy.NONE), data)
+	if err != nil {
+		return "", fmt.Errorf("upload data: %w", err)
+	}
+	etag = hex.EncodeToString(data.hash.Sum(nil))
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	metadata := map[string]string{
+		manifest.EntryMetadataContentTypeKey: contentType,
+		manifest.EntryMetadataFilenameKey:    path.Base(key),
+		manifestfs.MetadataSizeKey:           strconv.FormatInt(data.n, 10),
+		manifestfs.MetadataModifiedKey:       time.Now().UTC().Format(time.RFC3339),
+		metadataETagKey:                      etag,
+	}
+
+	err = g.updateBucket(ctx, session, bucket, func(m *manifestfs.Manifest) error {
+		return m.Add(ctx, key, manifest.NewEntry(ref, metadata))
+	})
+	if err != nil {
+		return "", err
+	}
+	return etag, nil
+}
+
+// updateBucket applies the change to the manifest of the bucket and points
+// the feed of the bucket to the stored manifest. The session is done with
+// the reference of the manifest.
+func (g *Gateway) updateBucket(ctx context.Context, session storer.PutterSession, bucket string, change func(*manifestfs.Manifest) error) error {
+	topic, err := g.bucketTopic(bucket)
+	if err != nil {
+		return err
+	}
+	ref, err := g.store.Update(ctx, session, topic, func(m *manifestfs.Manifest) error {
+		// the bucket might have been deleted in the meantime
+		if err := g.checkBucket(ctx, bucket); err != nil {
+			return err
+		}
+		return change(m)
+	})
+	if err != nil {
+		return err
+	}
+	return session.Done(ref)
+}
+
+func (g *Gateway) getObjectHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bucket, key := vars["bucket"], vars["key"]
+
+	_, ref, err := g.bucket(r.Context(), bucket)
+	if err != nil {
+		g.fail(w, r, "get object failed", err)
+		return
+	}
+	if ref.IsZero() {
+		writeError(w, r, errNoSuchKey)
+		return
+	}
+	m, err := g.store.Load(r.Context(), ref)
+	if err != nil {
+		g.fail(w, r, "get object failed", err)
+		return
+	}
+	entry, err := m.Lookup(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, manifest.ErrNotFound) {
+			err = errNoSuchKey
+		}
+		g.fail(w, r, "get object failed", err)
+		return
+	}
+
+	reader, _, err := joiner.New(r.Context(), g.storer.Download(true), g.storer.Cache(), entry.Reference(), redundancy.DefaultLevel)
+	if err != nil {
+		g.fail(w, r, "get object failed", err)
+		return
+	}
+
+	metadata := entry.Metadata()
+	modified, _ := time.Parse(time.RFC3339, metadata[manifestfs.MetadataModifiedKey])
+	w.Header().Set("Content-Type", metadata[manifest.EntryMetadataContentTypeKey])
+	w.Header().Set("ETag", strconv.Quote(metadata[metadataETagKey]))
+	http.ServeContent(w, r, "", modified, langos.NewBufferedLangos(reader, lookaheadBufferSize))
+}
+
+func (g *Gateway) deleteObjectHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bucket, key := vars["bucket"], vars["key"]
+
+	_, ref, err := g.bucket(r.Context(), bucket)
+	if err != nil {
+		g.fail(w, r, "delete object failed", err)
+		return
+	}
+	if ref.IsZero() {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if err := g.deleteObject(r.Context(), bucket, key); err != nil && !errors.Is(err, manifest.ErrNotFound) {
+		g.fail(w, r, "delete object failed", err)
+		return
+	}
+	// the deletion of the missing key succeeds too
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deleteObject removes the object from the manifest of the bucket.
+func (g *Gateway) deleteObject(ctx context.Context, bucket, key string) (err error) {
+	session, err := g.store.NewSession(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, session.Cleanup())
+		}
+	}()
+
+	return g.updateBucket(ctx, session, bucket, func(m *manifestfs.Manifest) error {
+		return m.Remove(ctx, key)
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package s3gateway
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+// xmlns is the namespace of the S3 responses.
+const xmlns = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+// apiError is the error of the S3 API.
+type apiError struct {
+	code    string
+	status  int
+	message string
+}
+
+func (e apiError) Error() string {
+	return e.code + ": " + e.message
+}
+
+var (
+	errNoSuchBucket            = apiError{"NoSuchBucket", http.StatusNotFound, "The specified bucket does not exist."}
+	errNoSuchKey               = apiError{"NoSuchKey", http.StatusNotFound, "The specified key does not exist."}
+	errBucketAlreadyOwnedByYou = apiError{"BucketAlreadyOwnedByYou", http.StatusConflict, "The bucket already exists."}
+	errBucketNotEmpty          = apiError{"BucketNotEmpty", http.StatusConflict, "The bucket you tried to delete is not empty."}
+	errInvalidBucketName       = apiError{"InvalidBucketName", http.StatusBadRequest, "The specified bucket is not valid."}
+	errInvalidArgument         = apiError{"InvalidArgument", http.StatusBadRequest, "Invalid argument."}
+	errIncompleteBody          = apiError{"IncompleteBody", http.StatusBadRequest, "The request body is malformed or incomplete."}
+	errMethodNotAllowed        = apiError{"MethodNotAllowed", http.StatusMethodNotAllowed, "The specified method is not allowed against this resource."}
+	errNotImplemented          = apiError{"NotImplemented", http.StatusNotImplemented, "The requested functionality is not implemented."}
+	errInternal                = apiError{"InternalError", http.StatusInternalServerError, "We encountered an internal error. Please try again."}
+	errReadOnly                = apiError{"ServiceUnavailable", http.StatusServiceUnavailable, "The node is in read-only mode."}
+)
+
+type errorResponse struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string   `xml:"Code"`
+	Message  string   `xml:"Message"`
+	Resource string   `xml:"Resource"`
+}
+
+type owner struct {
+	ID string `xml:"ID"`
+}
+
+type bucketInfo struct {
+	Name         string `xml:"Name"`
+	CreationDate string `xml:"CreationDate"`
+}
+
+type listAllMyBucketsResult struct {
+	XMLName xml.Name     `xml:"ListAllMyBucketsResult"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Owner   owner        `xml:"Owner"`
+	Buckets []bucketInfo `xml:"Buckets>Bucket"`
+}
+
+type objectInfo struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type commonPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name       `xml:"ListBucketResult"`
+	Xmlns                 string         `xml:"xmlns,attr"`
+	Name                  string         `xml:"Name"`
+	Prefix                string         `xml:"Prefix"`
+	Delimiter             string         `xml:"Delimiter,omitempty"`
+	Marker                *string        `xml:"Marker,omitempty"`
+	NextMarker            string         `xml:"NextMarker,omitempty"`
+	ContinuationToken     string         `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+	StartAfter            string         `xml:"StartAfter,omitempty"`
+	KeyCount              *int           `xml:"KeyCount,omitempty"`
+	MaxKeys               int            `xml:"MaxKeys"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	Contents              []objectInfo   `xml:"Contents"`
+	CommonPrefixes        []commonPrefix `xml:"CommonPrefixes"`
+}
+
+type locationConstraint struct {
+	XMLName xml.Name `xml:"LocationConstraint"`
+	Xmlns   string   `xml:"xmlns,attr"`
+}
+
+// writeXML writes the response encoded as XML.
+func writeXML(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+// writeError writes the error response. The body is omitted
+// for the HEAD requests.
+func writeError(w http.ResponseWriter, r *http.Request, e apiError) {
+	if r.Method == http.MethodHead {
+		w.WriteHeader(e.status)
+		return
+	}
+	writeXML(w, e.status, errorResponse{
+		Code:     e.code,
+		Message:  e.message,
+		Resource: r.URL.Path,
+	})
+}
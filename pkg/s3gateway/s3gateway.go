@@ -0,0 +1,122 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package s3gateway implements the subset of the Amazon S3 API, so that the
+// existing S3 tooling can store the objects on the node.
+//
+// Every bucket is the sequence feed of the node, its updates point to the
+// mantaray manifest of the objects of the bucket. The buckets themselves are
+// listed in the manifest of the root feed. Only the path-style requests are
+// supported and the signatures of the requests are not verified, the gateway
+// should only listen on the trusted interface.
+package s3gateway
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/ethersphere/bee/v2/pkg/readonly"
+	"github.com/gorilla/mux"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "s3gateway"
+
+// Storer is the storage the content is read from.
+type Storer = manifestfs.Storer
+
+// PutterFunc returns the session the new content is stamped and uploaded with.
+type PutterFunc = manifestfs.PutterFunc
+
+// Options are the options of the gateway.
+type Options struct {
+	// RootTopic is the topic of the feed listing the buckets.
+	RootTopic []byte
+	Storer    Storer
+	Putter    PutterFunc
+	Feeds     feeds.Factory
+	// Signer signs the feed updates, its address owns the feeds.
+	Signer crypto.Signer
+	// ReadOnly rejects the changes of the buckets and the objects
+	// while the node is in the read-only mode.
+	ReadOnly *readonly.Mode
+	Logger   log.Logger
+}
+
+// Gateway serves the S3 API.
+type Gateway struct {
+	rootTopic []byte
+	storer    Storer
+	store     *manifestfs.Store
+	readOnly  *readonly.Mode
+	logger    log.Logger
+	router    *mux.Router
+}
+
+// New returns the gateway.
+func New(o Options) (*Gateway, error) {
+	store, err := manifestfs.NewStore(manifestfs.Options{
+		Storer: o.Storer,
+		Putter: o.Putter,
+		Feeds:  o.Feeds,
+		Signer: o.Signer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("manifest store: %w", err)
+	}
+
+	g := &Gateway{
+		rootTopic: o.RootTopic,
+		storer:    o.Storer,
+		store:     store,
+		readOnly:  o.ReadOnly,
+		logger:    o.Logger.WithName(loggerName).Register(),
+	}
+	g.mount()
+	return g, nil
+}
+
+func (g *Gateway) mount() {
+	r := mux.NewRouter()
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, errNotImplemented)
+	})
+	r.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, errMethodNotAllowed)
+	})
+
+	r.Path("/").Methods(http.MethodGet).HandlerFunc(g.listBucketsHandler)
+
+	bucket := r.Path("/{bucket:[^/]+}{slash:/?}").Subrouter()
+	bucket.Methods(http.MethodGet).HandlerFunc(g.listObjectsHandler)
+	bucket.Methods(http.MethodHead).HandlerFunc(g.headBucketHandler)
+	bucket.Methods(http.MethodPut).HandlerFunc(g.checkWritable(g.createBucketHandler))
+	bucket.Methods(http.MethodDelete).HandlerFunc(g.checkWritable(g.deleteBucketHandler))
+
+	object := r.Path("/{bucket:[^/]+}/{key:.+}").Subrouter()
+	object.Methods(http.MethodGet, http.MethodHead).HandlerFunc(g.getObjectHandler)
+	object.Methods(http.MethodPut).HandlerFunc(g.checkWritable(g.putObjectHandler))
+	object.Methods(http.MethodDelete).HandlerFunc(g.checkWritable(g.deleteObjectHandler))
+
+	g.router = r
+}
+
+// checkWritable rejects the request while the node is in the read-only mode.
+func (g *Gateway) checkWritable(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if g.readOnly.Enabled() {
+			writeError(w, r, errReadOnly)
+			return
+		}
+		h(w, r)
+	}
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.router.ServeHTTP(w, r)
+}
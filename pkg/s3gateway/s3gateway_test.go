@@ -0,0 +1,269 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package s3gateway_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/readonly"
+	"github.com/ethersphere/bee/v2/pkg/s3gateway"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+)
+
+type listBucketResult struct {
+	Contents []struct {
+		Key  string `xml:"Key"`
+		Size int64  `xml:"Size"`
+		ETag string `xml:"ETag"`
+	} `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+type uploadStorer interface {
+	s3gateway.Storer
+	Upload(ctx context.Context, pin bool, tagID uint64) (storer.PutterSession, error)
+}
+
+func newGateway(t *testing.T, st uploadStorer) *httptest.Server {
+	t.Helper()
+
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newGatewayWithSigner(t, st, crypto.NewDefaultSigner(key), nil)
+}
+
+func newGatewayWithSigner(t *testing.T, st uploadStorer, signer crypto.Signer, readOnly *readonly.Mode) *httptest.Server {
+	t.Helper()
+
+	g, err := s3gateway.New(s3gateway.Options{
+		RootTopic: []byte("s3"),
+		Storer:    st,
+		Putter: func(ctx context.Context) (storer.PutterSession, error) {
+			return st.Upload(ctx, false, 0)
+		},
+		Feeds:    factory.New(st.Download(true)),
+		Signer:   signer,
+		ReadOnly: readOnly,
+		Logger:   log.Noop,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(g)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func request(t *testing.T, method, url string, body io.Reader, header http.Header, wantStatus int) []byte {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("%s %s: got status %d, want %d: %s", method, url, resp.StatusCode, wantStatus, b)
+	}
+	return b
+}
+
+func TestGateway(t *testing.T) {
+	t.Parallel()
+
+	st := mockstorer.New()
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(key)
+	srv := newGatewayWithSigner(t, st, signer, nil)
+
+	request(t, http.MethodGet, srv.URL+"/photos", nil, nil, http.StatusNotFound)
+	request(t, http.MethodPut, srv.URL+"/Invalid_Name", nil, nil, http.StatusBadRequest)
+	request(t, http.MethodPut, srv.URL+"/photos", nil, nil, http.StatusOK)
+	request(t, http.MethodPut, srv.URL+"/photos", nil, nil, http.StatusConflict)
+	request(t, http.MethodHead, srv.URL+"/photos", nil, nil, http.StatusOK)
+
+	objects := map[string]string{
+		"2024/01/a.jpg": "first",
+		"2024/01/b.jpg": "second",
+		"2024/02/c.jpg": "third",
+		"readme.txt":    "readme",
+	}
+	for key, data := range objects {
+		request(t, http.MethodPut, srv.URL+"/photos/"+key, strings.NewReader(data), http.Header{"Content-Type": {"image/jpeg"}}, http.StatusOK)
+	}
+
+	got := request(t, http.MethodGet, srv.URL+"/photos/2024/01/b.jpg", nil, nil, http.StatusOK)
+	if string(got) != "second" {
+		t.Fatalf("got object %q", got)
+	}
+	got = request(t, http.MethodGet, srv.URL+"/photos/2024/01/b.jpg", nil, http.Header{"Range": {"bytes=1-3"}}, http.StatusPartialContent)
+	if string(got) != "eco" {
+		t.Fatalf("got range %q", got)
+	}
+	request(t, http.MethodGet, srv.URL+"/photos/missing", nil, nil, http.StatusNotFound)
+
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+
+		var res listBucketResult
+		body := request(t, http.MethodGet, srv.URL+"/photos?list-type=2&prefix=2024/&delimiter=/", nil, nil, http.StatusOK)
+		if err := xml.Unmarshal(body, &res); err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Contents) != 0 || len(res.CommonPrefixes) != 2 ||
+			res.CommonPrefixes[0].Prefix != "2024/01/" || res.CommonPrefixes[1].Prefix != "2024/02/" {
+			t.Fatalf("got listing %+v", res)
+		}
+
+		var keys []string
+		token := ""
+		for {
+			var res listBucketResult
+			body := request(t, http.MethodGet, srv.URL+"/photos?list-type=2&max-keys=3&continuation-token="+token, nil, nil, http.StatusOK)
+			if err := xml.Unmarshal(body, &res); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range res.Contents {
+				keys = append(keys, c.Key)
+				if want := int64(len(objects[c.Key])); c.Size != want {
+					t.Fatalf("got size %d of %q, want %d", c.Size, c.Key, want)
+				}
+			}
+			if !res.IsTruncated {
+				break
+			}
+			token = res.NextContinuationToken
+		}
+		if want := "2024/01/a.jpg,2024/01/b.jpg,2024/02/c.jpg,readme.txt"; strings.Join(keys, ",") != want {
+			t.Fatalf("got keys %v, want %s", keys, want)
+		}
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		t.Parallel()
+
+		// the new gateway of the same owner looks the feeds up
+		srv := newGatewayWithSigner(t, st, signer, nil)
+		got := request(t, http.MethodGet, srv.URL+"/photos/readme.txt", nil, nil, http.StatusOK)
+		if string(got) != "readme" {
+			t.Fatalf("got object %q", got)
+		}
+	})
+
+	t.Run("other owner", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newGateway(t, st)
+		request(t, http.MethodHead, srv.URL+"/photos", nil, nil, http.StatusNotFound)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	srv := newGateway(t, mockstorer.New())
+
+	request(t, http.MethodPut, srv.URL+"/bucket", nil, nil, http.StatusOK)
+	request(t, http.MethodPut, srv.URL+"/bucket/key", strings.NewReader("data"), nil, http.StatusOK)
+	request(t, http.MethodPut, srv.URL+"/bucket/key.bak", strings.NewReader("backup"), nil, http.StatusOK)
+	request(t, http.MethodDelete, srv.URL+"/bucket", nil, nil, http.StatusConflict)
+	request(t, http.MethodDelete, srv.URL+"/bucket/key", nil, nil, http.StatusNoContent)
+	request(t, http.MethodDelete, srv.URL+"/bucket/key", nil, nil, http.StatusNoContent)
+	request(t, http.MethodGet, srv.URL+"/bucket/key", nil, nil, http.StatusNotFound)
+	// the key prefixed by the deleted one is kept
+	if got := request(t, http.MethodGet, srv.URL+"/bucket/key.bak", nil, nil, http.StatusOK); string(got) != "backup" {
+		t.Fatalf("got object %q", got)
+	}
+	request(t, http.MethodDelete, srv.URL+"/bucket/key.bak", nil, nil, http.StatusNoContent)
+	request(t, http.MethodDelete, srv.URL+"/bucket", nil, nil, http.StatusNoContent)
+
+	body := request(t, http.MethodGet, srv.URL+"/", nil, nil, http.StatusOK)
+	if bytes.Contains(body, []byte("<Name>bucket</Name>")) {
+		t.Fatalf("got deleted bucket in %s", body)
+	}
+}
+
+func TestStreamingUpload(t *testing.T) {
+	t.Parallel()
+
+	srv := newGateway(t, mockstorer.New())
+	request(t, http.MethodPut, srv.URL+"/bucket", nil, nil, http.StatusOK)
+
+	data := "hello streaming world"
+	var body strings.Builder
+	for _, part := range []string{data[:5], data[5:]} {
+		fmt.Fprintf(&body, "%x;chunk-signature=abcd\r\n%s\r\n", len(part), part)
+	}
+	body.WriteString("0;chunk-signature=abcd\r\n\r\n")
+
+	header := http.Header{"X-Amz-Content-Sha256": {"STREAMING-AWS4-HMAC-SHA256-PAYLOAD"}}
+	request(t, http.MethodPut, srv.URL+"/bucket/object", strings.NewReader(body.String()), header, http.StatusOK)
+	if got := request(t, http.MethodGet, srv.URL+"/bucket/object", nil, nil, http.StatusOK); string(got) != data {
+		t.Fatalf("got object %q, want %q", got, data)
+	}
+
+	request(t, http.MethodPut, srv.URL+"/bucket/broken", strings.NewReader("5\r\nhel"), header, http.StatusBadRequest)
+}
+
+func TestGatewayReadOnly(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mode := readonly.New(false)
+	srv := newGatewayWithSigner(t, mockstorer.New(), crypto.NewDefaultSigner(key), mode)
+
+	request(t, http.MethodPut, srv.URL+"/photos", nil, nil, http.StatusOK)
+	request(t, http.MethodPut, srv.URL+"/photos/a.jpg", strings.NewReader("first"), nil, http.StatusOK)
+
+	mode.Set(true)
+	request(t, http.MethodPut, srv.URL+"/photos/b.jpg", strings.NewReader("second"), nil, http.StatusServiceUnavailable)
+	request(t, http.MethodDelete, srv.URL+"/photos/a.jpg", nil, nil, http.StatusServiceUnavailable)
+	request(t, http.MethodPut, srv.URL+"/videos", nil, nil, http.StatusServiceUnavailable)
+	request(t, http.MethodDelete, srv.URL+"/photos", nil, nil, http.StatusServiceUnavailable)
+
+	// the reads are served in the read-only mode
+	if got := request(t, http.MethodGet, srv.URL+"/photos/a.jpg", nil, nil, http.StatusOK); string(got) != "first" {
+		t.Fatalf("got object %q", got)
+	}
+	request(t, http.MethodGet, srv.URL+"/photos/b.jpg", nil, nil, http.StatusNotFound)
+
+	mode.Set(false)
+	request(t, http.MethodPut, srv.URL+"/photos/b.jpg", strings.NewReader("second"), nil, http.StatusOK)
+}
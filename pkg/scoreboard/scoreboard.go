@@ -0,0 +1,242 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package scoreboard keeps track of the performance of the peers in the
+// chunk retrieval and push requests. The observations decay over time, so
+// the scores reflect the recent behaviour of the peers. The scores are used
+// to bias the forwarding peer selection towards the better performing peers.
+package scoreboard
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const (
+	// DefaultHalfLife is the period after which
+	// the weight of an observation is halved.
+	DefaultHalfLife = 10 * time.Minute
+	// Candidates is the maximum number of peers
+	// considered by the Select method.
+	Candidates = 3
+	// referenceLatency is the latency at which
+	// the latency factor of the score is halved.
+	referenceLatency = time.Second
+	// staleHalfLives is the number of half-lives after
+	// which the peer without new observations is forgotten.
+	staleHalfLives = 10
+	// pruneSize is the number of tracked peers
+	// above which the stale peers are pruned.
+	pruneSize = 4096
+)
+
+// PeerScore is the performance summary of a peer.
+type PeerScore struct {
+	Address     swarm.Address
+	Score       float64
+	SuccessRate float64
+	Latency     time.Duration
+	// Bandwidth is the average transfer rate in bytes per second.
+	Bandwidth float64
+	// Samples is the decayed number of the observations.
+	Samples float64
+	Updated time.Time
+}
+
+// entry holds the exponentially decayed observations of a peer.
+type entry struct {
+	successes    float64
+	failures     float64
+	latencySum   float64 // seconds
+	latencyW     float64
+	bandwidthSum float64 // bytes per second
+	bandwidthW   float64
+	updated      time.Time
+}
+
+// decay ages the observations to the time t.
+func (e *entry) decay(t time.Time, halfLife time.Duration) {
+	elapsed := t.Sub(e.updated)
+	if elapsed <= 0 {
+		return
+	}
+	f := math.Exp2(-float64(elapsed) / float64(halfLife))
+	e.successes *= f
+	e.failures *= f
+	e.latencySum *= f
+	e.latencyW *= f
+	e.bandwidthSum *= f
+	e.bandwidthW *= f
+	e.updated = t
+}
+
+// score combines the smoothed success rate with the latency factor. The
+// peers without any observations score the same as the ones with the
+// success rate of one half and the reference latency.
+func (e *entry) score() float64 {
+	rate := (e.successes + 1) / (e.successes + e.failures + 2)
+	latency := referenceLatency.Seconds()
+	if e.latencyW > 0 {
+		latency = e.latencySum / e.latencyW
+	}
+	return rate * referenceLatency.Seconds() / (referenceLatency.Seconds() + latency)
+}
+
+// Board is the peer performance scoreboard. All methods
+// are safe to call on a nil Board, which tracks nothing.
+type Board struct {
+	halfLife time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	peers map[string]*entry
+}
+
+// New creates a new scoreboard with the given half-life of the observations.
+func New(halfLife time.Duration) *Board {
+	if halfLife <= 0 {
+		halfLife = DefaultHalfLife
+	}
+	return &Board{
+		halfLife: halfLife,
+		now:      time.Now,
+		peers:    make(map[string]*entry),
+	}
+}
+
+// Record records the outcome of a request to the peer which took
+// the duration d and transferred the given number of bytes.
+func (b *Board) Record(peer swarm.Address, d time.Duration, bytes int, err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	e, ok := b.peers[peer.ByteString()]
+	if !ok {
+		if len(b.peers) >= pruneSize {
+			b.prune(now)
+		}
+		e = &entry{updated: now}
+		b.peers[peer.ByteString()] = e
+	}
+	e.decay(now, b.halfLife)
+
+	if err != nil {
+		e.failures++
+		return
+	}
+	e.successes++
+	e.latencySum += d.Seconds()
+	e.latencyW++
+	if bytes > 0 && d > 0 {
+		e.bandwidthSum += float64(bytes) / d.Seconds()
+		e.bandwidthW++
+	}
+}
+
+// Score returns the current score of the peer in the range (0, 1).
+func (b *Board) Score(peer swarm.Address) float64 {
+	if b == nil {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e, ok := b.peers[peer.ByteString()]
+	if !ok {
+		return (&entry{}).score()
+	}
+	e.decay(b.now(), b.halfLife)
+	return e.score()
+}
+
+// Select returns the best scoring peer among the closest peer and the next
+// closest peers with the same proximity order to the address. The next
+// function returns the closest peer to the address which is not among the
+// given peers. At most Candidates peers are considered.
+func (b *Board) Select(addr, closest swarm.Address, next func(skip []swarm.Address) (swarm.Address, error)) swarm.Address {
+	if b == nil {
+		return closest
+	}
+
+	po := swarm.Proximity(addr.Bytes(), closest.Bytes())
+	candidates := []swarm.Address{closest}
+	for len(candidates) < Candidates {
+		peer, err := next(slices.Clip(candidates))
+		if err != nil || swarm.Proximity(addr.Bytes(), peer.Bytes()) != po {
+			break
+		}
+		candidates = append(candidates, peer)
+	}
+	if len(candidates) == 1 {
+		return closest
+	}
+
+	best, bestScore := closest, b.Score(closest)
+	for _, peer := range candidates[1:] {
+		if score := b.Score(peer); score > bestScore {
+			best, bestScore = peer, score
+		}
+	}
+	return best
+}
+
+// Snapshot returns the scores of all tracked peers, best scoring first.
+func (b *Board) Snapshot() []PeerScore {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.prune(now)
+
+	scores := make([]PeerScore, 0, len(b.peers))
+	for k, e := range b.peers {
+		e.decay(now, b.halfLife)
+		s := PeerScore{
+			Address:     swarm.NewAddress([]byte(k)),
+			Score:       e.score(),
+			SuccessRate: (e.successes + 1) / (e.successes + e.failures + 2),
+			Samples:     e.successes + e.failures,
+			Updated:     e.updated,
+		}
+		if e.latencyW > 0 {
+			s.Latency = time.Duration(e.latencySum / e.latencyW * float64(time.Second)).Round(time.Microsecond)
+		}
+		if e.bandwidthW > 0 {
+			s.Bandwidth = e.bandwidthSum / e.bandwidthW
+		}
+		scores = append(scores, s)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Address.Compare(scores[j].Address) < 0
+	})
+	return scores
+}
+
+// prune forgets the peers without recent observations.
+// It must be called with the mutex locked.
+func (b *Board) prune(now time.Time) {
+	for k, e := range b.peers {
+		if now.Sub(e.updated) > staleHalfLives*b.halfLife {
+			delete(b.peers, k)
+		}
+	}
+}
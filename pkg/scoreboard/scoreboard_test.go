@@ -0,0 +1,111 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scoreboard_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/scoreboard"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+)
+
+var errFailed = errors.New("failed")
+
+func TestScore(t *testing.T) {
+	t.Parallel()
+
+	var (
+		b    = scoreboard.New(time.Minute)
+		now  = time.Unix(1000, 0)
+		fast = swarm.MustParseHexAddress("01")
+		slow = swarm.MustParseHexAddress("02")
+		bad  = swarm.MustParseHexAddress("03")
+		unk  = swarm.MustParseHexAddress("04")
+	)
+	b.SetNow(func() time.Time { return now })
+
+	for i := 0; i < 10; i++ {
+		b.Record(fast, 50*time.Millisecond, 4096, nil)
+		b.Record(slow, 2*time.Second, 4096, nil)
+		b.Record(bad, 50*time.Millisecond, 0, errFailed)
+	}
+
+	if !(b.Score(fast) > b.Score(slow) && b.Score(slow) > b.Score(bad) && b.Score(unk) > b.Score(bad)) {
+		t.Fatalf("unexpected score order: fast %v, unknown %v, slow %v, bad %v", b.Score(fast), b.Score(unk), b.Score(slow), b.Score(bad))
+	}
+
+	scores := b.Snapshot()
+	if len(scores) != 3 {
+		t.Fatalf("want 3 scores, got %d", len(scores))
+	}
+	if !scores[0].Address.Equal(fast) {
+		t.Fatalf("want best peer %s, got %s", fast, scores[0].Address)
+	}
+	if scores[0].Latency != 50*time.Millisecond {
+		t.Fatalf("want latency 50ms, got %s", scores[0].Latency)
+	}
+	if want := 4096 / 0.05; scores[0].Bandwidth < want*0.99 || scores[0].Bandwidth > want*1.01 {
+		t.Fatalf("want bandwidth %v, got %v", want, scores[0].Bandwidth)
+	}
+
+	// The failures decay, so the peer recovers after it starts succeeding.
+	now = now.Add(10 * time.Minute)
+	b.Record(bad, 50*time.Millisecond, 4096, nil)
+	if b.Score(bad) <= b.Score(unk) {
+		t.Fatalf("want recovered peer to score above unknown peer, got %v", b.Score(bad))
+	}
+
+	// Peers without recent observations are forgotten.
+	now = now.Add(20 * time.Minute)
+	if got := len(b.Snapshot()); got != 0 {
+		t.Fatalf("want stale peers pruned, got %d", got)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	var (
+		b     = scoreboard.New(time.Minute)
+		chunk = swarm.MustParseHexAddress("0000")
+		first = swarm.MustParseHexAddress("0100")
+		other = swarm.MustParseHexAddress("0180")
+		far   = swarm.MustParseHexAddress("8000")
+	)
+
+	next := func(peers ...swarm.Address) func([]swarm.Address) (swarm.Address, error) {
+		return func(skip []swarm.Address) (swarm.Address, error) {
+			for _, p := range peers {
+				if !swarm.ContainsAddress(skip, p) {
+					return p, nil
+				}
+			}
+			return swarm.ZeroAddress, topology.ErrNotFound
+		}
+	}
+
+	if got := b.Select(chunk, first, next(first, other)); !got.Equal(first) {
+		t.Fatalf("want %s without observations, got %s", first, got)
+	}
+
+	b.Record(first, time.Second, 0, errFailed)
+	b.Record(other, 100*time.Millisecond, 4096, nil)
+	b.Record(far, 10*time.Millisecond, 4096, nil)
+
+	if got := b.Select(chunk, first, next(first, other)); !got.Equal(other) {
+		t.Fatalf("want better scoring peer %s, got %s", other, got)
+	}
+	if got := b.Select(chunk, first, next(first, far)); !got.Equal(first) {
+		t.Fatalf("want %s, peers with lower proximity order must not be selected, got %s", first, got)
+	}
+
+	var nilBoard *scoreboard.Board
+	if got := nilBoard.Select(chunk, first, next(first, other)); !got.Equal(first) {
+		t.Fatalf("want %s from nil board, got %s", first, got)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package secrets resolves the references to the secrets in the
+// configuration values, so that the passwords and the API keys do not
+// have to be kept in the configuration in plain text.
+//
+// A reference has the form ${provider:ref} and can make up the whole value
+// or a part of it, for example the API key in the RPC endpoint URL:
+//
+//	https://mainnet.example.com/v3/${env:RPC_API_KEY}
+//
+// The built-in providers are:
+//
+//	env    the environment variable with the name ref
+//	file   the content of the file at the path ref without the trailing newlines
+//	vault  the field of the HashiCorp Vault secret, ref is in the path#field form
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrUnknownProvider is returned for the reference to the provider which is not registered.
+	ErrUnknownProvider = errors.New("unknown secret provider")
+	// ErrNotFound is returned when the referenced secret does not exist.
+	ErrNotFound = errors.New("secret not found")
+)
+
+// reference matches the ${provider:ref} secret references.
+var reference = regexp.MustCompile(`\$\{([a-z][a-z0-9]*):([^}]+)\}`)
+
+// Provider looks up the secrets.
+type Provider interface {
+	// Secret returns the value of the secret identified by the reference.
+	Secret(ctx context.Context, ref string) (string, error)
+}
+
+// ProviderFunc is the function which implements the Provider interface.
+type ProviderFunc func(ctx context.Context, ref string) (string, error)
+
+// Secret implements the Provider interface.
+func (f ProviderFunc) Secret(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+// Resolver replaces the secret references with the values
+// looked up from the registered providers.
+type Resolver struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+}
+
+// NewResolver returns the resolver with the built-in env, file and vault providers.
+// The vault provider is configured from the VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE
+// environment variables.
+func NewResolver() *Resolver {
+	return &Resolver{
+		providers: map[string]Provider{
+			"env":   Env,
+			"file":  File,
+			"vault": NewVaultFromEnv(),
+		},
+	}
+}
+
+// Register adds the provider under the name, replacing the
+// provider with the same name. It allows to plug in the
+// secret managers like the cloud KMS services.
+func (r *Resolver) Register(name string, p Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.providers[name] = p
+}
+
+// Resolve returns the value with all the secret references replaced.
+// The value without the references is returned unchanged.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+
+	var resolveErr error
+	resolved := reference.ReplaceAllStringFunc(value, func(m string) string {
+		if resolveErr != nil {
+			return m
+		}
+		sub := reference.FindStringSubmatch(m)
+		name, ref := sub[1], sub[2]
+
+		r.mu.RLock()
+		p, ok := r.providers[name]
+		r.mu.RUnlock()
+		if !ok {
+			resolveErr = fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+			return m
+		}
+
+		secret, err := p.Secret(ctx, ref)
+		if err != nil {
+			resolveErr = fmt.Errorf("%s secret %q: %w", name, ref, err)
+			return m
+		}
+		return secret
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return resolved, nil
+}
+
+// ResolveAll resolves the secret references of the values in place.
+func (r *Resolver) ResolveAll(ctx context.Context, values ...*string) error {
+	for _, v := range values {
+		resolved, err := r.Resolve(ctx, *v)
+		if err != nil {
+			return err
+		}
+		*v = resolved
+	}
+	return nil
+}
+
+// Env is the provider of the environment variables.
+var Env = ProviderFunc(func(_ context.Context, name string) (string, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+})
+
+// File is the provider of the file contents without the trailing newlines.
+var File = ProviderFunc(func(_ context.Context, path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+})
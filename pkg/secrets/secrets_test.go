@@ -0,0 +1,173 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package secrets_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/secrets"
+)
+
+func TestResolve(t *testing.T) {
+	t.Setenv("BEE_TEST_API_KEY", "apikey")
+
+	file := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(file, []byte("filepassword\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := secrets.NewResolver()
+	r.Register("static", secrets.ProviderFunc(func(_ context.Context, ref string) (string, error) {
+		if ref == "missing" {
+			return "", secrets.ErrNotFound
+		}
+		return "static-" + ref, nil
+	}))
+
+	for _, tc := range []struct {
+		name    string
+		value   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "plain",
+			value: "https://mainnet.example.com/v3/plain",
+			want:  "https://mainnet.example.com/v3/plain",
+		},
+		{
+			name:  "env",
+			value: "${env:BEE_TEST_API_KEY}",
+			want:  "apikey",
+		},
+		{
+			name:  "inline",
+			value: "https://mainnet.example.com/v3/${env:BEE_TEST_API_KEY}",
+			want:  "https://mainnet.example.com/v3/apikey",
+		},
+		{
+			name:  "file",
+			value: "${file:" + file + "}",
+			want:  "filepassword",
+		},
+		{
+			name:  "multiple",
+			value: "${static:user}:${static:pass}",
+			want:  "static-user:static-pass",
+		},
+		{
+			name:    "missing env",
+			value:   "${env:BEE_TEST_MISSING}",
+			wantErr: secrets.ErrNotFound,
+		},
+		{
+			name:    "missing file",
+			value:   "${file:" + filepath.Join(t.TempDir(), "missing") + "}",
+			wantErr: secrets.ErrNotFound,
+		},
+		{
+			name:    "missing",
+			value:   "${static:user}${static:missing}",
+			wantErr: secrets.ErrNotFound,
+		},
+		{
+			name:    "unknown provider",
+			value:   "${kms:key}",
+			wantErr: secrets.ErrUnknownProvider,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := r.Resolve(context.Background(), tc.value)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolveAll(t *testing.T) {
+	t.Setenv("BEE_TEST_PASSWORD", "password")
+
+	a, b := "${env:BEE_TEST_PASSWORD}", "plain"
+	if err := secrets.NewResolver().ResolveAll(context.Background(), &a, &b); err != nil {
+		t.Fatal(err)
+	}
+	if a != "password" || b != "plain" {
+		t.Fatalf("got %q and %q, want %q and %q", a, b, "password", "plain")
+	}
+}
+
+func TestVault(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/bee":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"v2password"},"metadata":{"version":1}}}`))
+		case "/v1/kv/bee":
+			_, _ = w.Write([]byte(`{"data":{"password":"v1password","data":"v1data"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	v := &secrets.Vault{Addr: srv.URL, Token: "token", Client: srv.Client()}
+
+	for _, tc := range []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr error
+	}{
+		{name: "kv version 2", ref: "secret/data/bee#password", want: "v2password"},
+		{name: "kv version 1", ref: "kv/bee#password", want: "v1password"},
+		{name: "kv version 1 data field", ref: "kv/bee#data", want: "v1data"},
+		{name: "missing field", ref: "secret/data/bee#token", wantErr: secrets.ErrNotFound},
+		{name: "missing path", ref: "secret/data/other#password", wantErr: secrets.ErrNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := v.Secret(context.Background(), tc.ref)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+
+		v := &secrets.Vault{Addr: srv.URL, Client: srv.Client()}
+		if _, err := v.Secret(context.Background(), "secret/data/bee#password"); err == nil {
+			t.Fatal("expected error without the token")
+		}
+	})
+
+	t.Run("invalid reference", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := v.Secret(context.Background(), "secret/data/bee"); err == nil {
+			t.Fatal("expected error for the reference without the field")
+		}
+	})
+}
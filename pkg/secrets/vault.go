@@ -0,0 +1,111 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultVaultTimeout is the timeout of the secret requests to the Vault.
+const defaultVaultTimeout = 10 * time.Second
+
+// errVaultNotConfigured is returned when the Vault address is not set.
+var errVaultNotConfigured = errors.New("vault address not configured")
+
+// Vault is the provider of the HashiCorp Vault secrets. The reference is
+// in the path#field form, where the path is the API path of the secret
+// without the /v1/ prefix, for example secret/data/bee#password. Both the
+// version 1 and 2 key-value secret engines are supported.
+type Vault struct {
+	Addr      string
+	Token     string
+	Namespace string
+	Client    *http.Client
+}
+
+// NewVaultFromEnv returns the Vault provider configured
+// with the standard Vault environment variables.
+func NewVaultFromEnv() *Vault {
+	return &Vault{
+		Addr:      os.Getenv("VAULT_ADDR"),
+		Token:     os.Getenv("VAULT_TOKEN"),
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+		Client:    &http.Client{Timeout: defaultVaultTimeout},
+	}
+}
+
+// Secret implements the Provider interface.
+func (v *Vault) Secret(ctx context.Context, ref string) (string, error) {
+	if v.Addr == "" {
+		return "", errVaultNotConfigured
+	}
+	path, field, ok := strings.Cut(ref, "#")
+	if !ok || path == "" || field == "" {
+		return "", fmt.Errorf("invalid vault reference %q, want path#field", ref)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(v.Addr, "/")+"/v1/"+strings.TrimLeft(path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	if v.Token != "" {
+		req.Header.Set("X-Vault-Token", v.Token)
+	}
+	if v.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.Namespace)
+	}
+
+	client := v.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("vault response status %s", resp.Status)
+	}
+
+	var body struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode vault response: %w", err)
+	}
+
+	data := body.Data
+	// the version 2 engine nests the secret data with the metadata
+	if nested, ok := data["data"]; ok {
+		if _, meta := data["metadata"]; meta {
+			data = nil
+			if err := json.Unmarshal(nested, &data); err != nil {
+				return "", fmt.Errorf("decode vault secret data: %w", err)
+			}
+		}
+	}
+
+	raw, ok := data[field]
+	if !ok {
+		return "", ErrNotFound
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("vault secret field %s is not a string", field)
+	}
+	return value, nil
+}
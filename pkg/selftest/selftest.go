@@ -0,0 +1,420 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package selftest provides the active diagnosis of the node. It measures
+// the chunk throughput with the connected peers volunteering for the probes,
+// validates the clock skew against the peers, reports the reachability
+// status last determined by the p2p service and reports the findings with
+// the remediation hints. The reachability is not probed by the self-test.
+package selftest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/protobuf"
+	"github.com/ethersphere/bee/v2/pkg/ratelimit"
+	"github.com/ethersphere/bee/v2/pkg/selftest/pb"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "selftest"
+
+const (
+	protocolName    = "selftest"
+	protocolVersion = "1.0.0"
+	streamName      = "probe"
+)
+
+const (
+	// probeSize is the size of the payload of a single probe message.
+	probeSize = 25 * swarm.ChunkSize
+	// probeRounds is the number of the probe messages sent in each direction.
+	probeRounds = 4
+	// maxProbes is the maximum number of the probes served on a stream.
+	maxProbes = 1 + 2*probeRounds
+	// volunteers is the number of the peers the throughput is measured with.
+	volunteers = 3
+	// probeTimeout is the time limit of the probes with a single peer.
+	probeTimeout = 30 * time.Second
+
+	// servedProbesRate limits the number of the probe streams served per peer.
+	servedProbesRate  = time.Minute
+	servedProbesBurst = 3
+)
+
+// Thresholds of the checks.
+const (
+	minThroughput   = 256 * 1024 // bytes per second
+	warnClockSkew   = time.Second
+	failClockSkew   = 10 * time.Second
+	minPeers        = 8
+	minVolunteerNum = 1
+)
+
+var (
+	// ErrRunning is returned when a self-test is already running.
+	ErrRunning = errors.New("self-test already running")
+
+	errRateLimited  = errors.New("probe rate limited")
+	errInvalidProbe = errors.New("invalid probe")
+)
+
+// Topology is the view of the topology the self-test relies on.
+type Topology interface {
+	topology.PeerIterator
+	Snapshot() *topology.KadParams
+}
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	StatusOK      Status = "ok"
+	StatusWarning Status = "warning"
+	StatusFailed  Status = "failed"
+)
+
+func (s Status) severity() int {
+	switch s {
+	case StatusWarning:
+		return 1
+	case StatusFailed:
+		return 2
+	}
+	return 0
+}
+
+// Check is the result of a single diagnosis.
+type Check struct {
+	Name    string
+	Status  Status
+	Details string
+	// Hint suggests how to remedy a failed or a warning check.
+	Hint string
+}
+
+// Report is the result of the self-test.
+type Report struct {
+	// Status is the worst status of the checks.
+	Status       Status
+	Reachability string
+	Peers        int
+	Volunteers   int
+	// UploadThroughput and DownloadThroughput are
+	// the medians of the volunteers in bytes per second.
+	UploadThroughput   float64
+	DownloadThroughput float64
+	// ClockSkew is the median offset of the peers' clocks from the local clock.
+	ClockSkew time.Duration
+	Checks    []Check
+	Duration  time.Duration
+}
+
+func (r *Report) add(c Check) {
+	r.Checks = append(r.Checks, c)
+	if c.Status.severity() > r.Status.severity() {
+		r.Status = c.Status
+	}
+}
+
+type Service struct {
+	streamer p2p.Streamer
+	topology Topology
+	logger   log.Logger
+	limiter  *ratelimit.Limiter
+	running  sync.Mutex
+}
+
+func New(streamer p2p.Streamer, topology Topology, logger log.Logger) *Service {
+	return &Service{
+		streamer: streamer,
+		topology: topology,
+		logger:   logger.WithName(loggerName).Register(),
+		limiter:  ratelimit.New(servedProbesRate, servedProbesBurst),
+	}
+}
+
+func (s *Service) Protocol() p2p.ProtocolSpec {
+	return p2p.ProtocolSpec{
+		Name:    protocolName,
+		Version: protocolVersion,
+		StreamSpecs: []p2p.StreamSpec{
+			{
+				Name:    streamName,
+				Handler: s.handler,
+			},
+		},
+		DisconnectOut: s.disconnect,
+		DisconnectIn:  s.disconnect,
+	}
+}
+
+func (s *Service) disconnect(peer p2p.Peer) error {
+	s.limiter.Clear(peer.Address.ByteString())
+	return nil
+}
+
+// probeResult is the outcome of the probes with a volunteer.
+type probeResult struct {
+	rtt      time.Duration
+	skew     time.Duration
+	upload   float64
+	download float64
+}
+
+// Run runs the self-test and returns its report.
+func (s *Service) Run(ctx context.Context) (*Report, error) {
+	if !s.running.TryLock() {
+		return nil, ErrRunning
+	}
+	defer s.running.Unlock()
+
+	start := time.Now()
+	report := &Report{Status: StatusOK}
+
+	snapshot := s.topology.Snapshot()
+	report.Reachability = snapshot.Reachability
+	report.Peers = snapshot.Connected
+	report.add(reachabilityStatusCheck(snapshot.Reachability))
+	report.add(peersCheck(snapshot.Connected))
+
+	results := s.probeVolunteers(ctx)
+	report.Volunteers = len(results)
+	if len(results) < minVolunteerNum {
+		report.add(Check{
+			Name:    "throughput",
+			Status:  StatusFailed,
+			Details: "no peer volunteered for the throughput probes",
+			Hint:    "wait for the node to connect to the network and retry",
+		})
+		report.add(Check{
+			Name:    "clock",
+			Status:  StatusWarning,
+			Details: "no peer to compare the clock with",
+			Hint:    "wait for the node to connect to the network and retry",
+		})
+	} else {
+		report.UploadThroughput = median(results, func(r probeResult) float64 { return r.upload })
+		report.DownloadThroughput = median(results, func(r probeResult) float64 { return r.download })
+		report.ClockSkew = time.Duration(median(results, func(r probeResult) float64 { return float64(r.skew) }))
+		report.add(throughputCheck(report.UploadThroughput, report.DownloadThroughput))
+		report.add(clockCheck(report.ClockSkew))
+	}
+
+	report.Duration = time.Since(start)
+	s.logger.Debug("self-test complete", "status", report.Status, "duration", report.Duration)
+	return report, nil
+}
+
+// probeVolunteers probes the connected peers, nearest first,
+// until the required number of the volunteers is reached.
+func (s *Service) probeVolunteers(ctx context.Context) []probeResult {
+	var peers []swarm.Address
+	_ = s.topology.EachConnectedPeer(func(addr swarm.Address, _ uint8) (bool, bool, error) {
+		peers = append(peers, addr)
+		return false, false, nil
+	}, topology.Select{Reachable: true})
+
+	var results []probeResult
+	for _, peer := range peers {
+		if len(results) == volunteers {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		r, err := s.probe(ctx, peer)
+		if err != nil {
+			s.logger.Debug("probe failed", "peer_address", peer, "error", err)
+			continue
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// probe measures the round trip time, the clock skew and
+// the throughput in both directions with the given peer.
+func (s *Service) probe(ctx context.Context, peer swarm.Address) (r probeResult, err error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	stream, err := s.streamer.NewStream(ctx, peer, nil, protocolName, protocolVersion, streamName)
+	if err != nil {
+		return r, fmt.Errorf("new stream: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = stream.Reset()
+		} else {
+			_ = stream.FullClose()
+		}
+	}()
+
+	w, rd := protobuf.NewWriterAndReader(stream)
+
+	// round trip time and clock skew
+	var resp pb.ProbeResponse
+	t0 := time.Now()
+	if err := w.WriteMsgWithContext(ctx, &pb.Probe{}); err != nil {
+		return r, fmt.Errorf("write probe: %w", err)
+	}
+	if err := rd.ReadMsgWithContext(ctx, &resp); err != nil {
+		return r, fmt.Errorf("read probe response: %w", err)
+	}
+	r.rtt = time.Since(t0)
+	r.skew = time.Unix(0, resp.Timestamp).Sub(t0.Add(r.rtt / 2))
+
+	// upload throughput
+	payload := make([]byte, probeSize)
+	t0 = time.Now()
+	for i := 0; i < probeRounds; i++ {
+		if err := w.WriteMsgWithContext(ctx, &pb.Probe{Upload: payload}); err != nil {
+			return r, fmt.Errorf("write upload probe: %w", err)
+		}
+	}
+	for i := 0; i < probeRounds; i++ {
+		if err := rd.ReadMsgWithContext(ctx, &resp); err != nil {
+			return r, fmt.Errorf("read upload probe response: %w", err)
+		}
+	}
+	r.upload = throughput(probeRounds*probeSize, time.Since(t0)-r.rtt)
+
+	// download throughput
+	t0 = time.Now()
+	for i := 0; i < probeRounds; i++ {
+		if err := w.WriteMsgWithContext(ctx, &pb.Probe{DownloadSize: probeSize}); err != nil {
+			return r, fmt.Errorf("write download probe: %w", err)
+		}
+	}
+	for i := 0; i < probeRounds; i++ {
+		if err := rd.ReadMsgWithContext(ctx, &resp); err != nil {
+			return r, fmt.Errorf("read download probe response: %w", err)
+		}
+		if len(resp.Download) != probeSize {
+			return r, fmt.Errorf("download probe response: %w", errInvalidProbe)
+		}
+	}
+	r.download = throughput(probeRounds*probeSize, time.Since(t0)-r.rtt)
+
+	return r, nil
+}
+
+// handler serves the probes of a peer running the self-test.
+func (s *Service) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream) (err error) {
+	defer func() {
+		if err != nil {
+			_ = stream.Reset()
+		} else {
+			_ = stream.FullClose()
+		}
+	}()
+
+	if !s.limiter.Allow(p.Address.ByteString(), 1) {
+		return errRateLimited
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	w, r := protobuf.NewWriterAndReader(stream)
+	for i := 0; i < maxProbes; i++ {
+		var probe pb.Probe
+		if err := r.ReadMsgWithContext(ctx, &probe); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("read probe: %w", err)
+		}
+		if probe.DownloadSize > probeSize {
+			return errInvalidProbe
+		}
+
+		resp := &pb.ProbeResponse{Timestamp: time.Now().UnixNano()}
+		if probe.DownloadSize > 0 {
+			resp.Download = make([]byte, probe.DownloadSize)
+		}
+		if err := w.WriteMsgWithContext(ctx, resp); err != nil {
+			return fmt.Errorf("write probe response: %w", err)
+		}
+	}
+	return nil
+}
+
+func throughput(bytes int, d time.Duration) float64 {
+	return float64(bytes) / max(d, time.Millisecond).Seconds()
+}
+
+func median(results []probeResult, f func(probeResult) float64) float64 {
+	v := make([]float64, 0, len(results))
+	for _, r := range results {
+		v = append(v, f(r))
+	}
+	slices.Sort(v)
+	if len(v)%2 == 1 {
+		return v[len(v)/2]
+	}
+	return (v[len(v)/2-1] + v[len(v)/2]) / 2
+}
+
+// reachabilityStatusCheck checks the cached reachability status, it does not dial back.
+func reachabilityStatusCheck(reachability string) Check {
+	c := Check{Name: "reachability-status", Details: "reachability status last reported by the p2p service, not probed by the self-test: " + reachability}
+	switch reachability {
+	case p2p.ReachabilityStatusPublic.String():
+		c.Status = StatusOK
+	case p2p.ReachabilityStatusPrivate.String():
+		c.Status = StatusFailed
+		c.Hint = "forward the p2p port on the router or set the public address with the nat-addr option"
+	default:
+		c.Status = StatusWarning
+		c.Hint = "the reachability is not determined yet, retry after a few minutes"
+	}
+	return c
+}
+
+func peersCheck(connected int) Check {
+	c := Check{Name: "peers", Status: StatusOK, Details: fmt.Sprintf("%d connected peers", connected)}
+	if connected < minPeers {
+		c.Status = StatusWarning
+		c.Hint = "check the bootnode and the p2p address options and the outbound firewall rules"
+	}
+	return c
+}
+
+func throughputCheck(upload, download float64) Check {
+	c := Check{
+		Name:    "throughput",
+		Status:  StatusOK,
+		Details: fmt.Sprintf("upload %.0f B/s, download %.0f B/s", upload, download),
+	}
+	if min(upload, download) < minThroughput {
+		c.Status = StatusWarning
+		c.Hint = "the connection is slow, check the bandwidth and consider limiting the pullsync bandwidth"
+	}
+	return c
+}
+
+func clockCheck(skew time.Duration) Check {
+	c := Check{Name: "clock", Status: StatusOK, Details: fmt.Sprintf("clock skew %v", skew)}
+	switch abs := max(skew, -skew); {
+	case abs >= failClockSkew:
+		c.Status = StatusFailed
+		c.Hint = "synchronize the system clock, e.g. with an NTP service"
+	case abs >= warnClockSkew:
+		c.Status = StatusWarning
+		c.Hint = "synchronize the system clock, e.g. with an NTP service"
+	}
+	return c
+}
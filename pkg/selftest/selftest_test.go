@@ -0,0 +1,98 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package selftest_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/streamtest"
+	"github.com/ethersphere/bee/v2/pkg/selftest"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	"github.com/ethersphere/bee/v2/pkg/topology/mock"
+)
+
+type topologyMock struct {
+	topology.PeerIterator
+	params topology.KadParams
+}
+
+func (t *topologyMock) Snapshot() *topology.KadParams { return &t.params }
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	server := selftest.New(nil, &topologyMock{PeerIterator: mock.NewTopologyDriver()}, log.Noop)
+	recorder := streamtest.New(streamtest.WithProtocols(server.Protocol()))
+
+	peers := []swarm.Address{swarm.RandAddress(t), swarm.RandAddress(t)}
+
+	t.Run("healthy", func(t *testing.T) {
+		t.Parallel()
+
+		client := selftest.New(recorder, &topologyMock{
+			PeerIterator: mock.NewTopologyDriver(mock.WithPeers(peers...)),
+			params: topology.KadParams{
+				Reachability: p2p.ReachabilityStatusPublic.String(),
+				Connected:    10,
+			},
+		}, log.Noop)
+
+		report, err := client.Run(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if report.Volunteers != len(peers) {
+			t.Fatalf("volunteers: want %d, have %d", len(peers), report.Volunteers)
+		}
+		if report.UploadThroughput <= 0 || report.DownloadThroughput <= 0 {
+			t.Fatalf("invalid throughput: upload %v, download %v", report.UploadThroughput, report.DownloadThroughput)
+		}
+		for _, c := range report.Checks {
+			if c.Status != selftest.StatusOK {
+				t.Fatalf("check %s: want status %s, have %s: %s", c.Name, selftest.StatusOK, c.Status, c.Details)
+			}
+		}
+		if report.Status != selftest.StatusOK {
+			t.Fatalf("status: want %s, have %s", selftest.StatusOK, report.Status)
+		}
+		if c := report.Checks[0]; c.Name != "reachability-status" || !strings.HasSuffix(c.Details, p2p.ReachabilityStatusPublic.String()) {
+			t.Fatalf("reachability status check: have %s: %s", c.Name, c.Details)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		t.Parallel()
+
+		client := selftest.New(recorder, &topologyMock{
+			PeerIterator: mock.NewTopologyDriver(),
+			params: topology.KadParams{
+				Reachability: p2p.ReachabilityStatusPrivate.String(),
+			},
+		}, log.Noop)
+
+		report, err := client.Run(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if report.Status != selftest.StatusFailed {
+			t.Fatalf("status: want %s, have %s", selftest.StatusFailed, report.Status)
+		}
+		if report.Volunteers != 0 {
+			t.Fatalf("volunteers: want 0, have %d", report.Volunteers)
+		}
+		for _, c := range report.Checks {
+			if c.Status != selftest.StatusOK && c.Hint == "" {
+				t.Fatalf("check %s: missing hint", c.Name)
+			}
+		}
+	})
+}
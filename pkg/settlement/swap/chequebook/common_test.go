@@ -25,6 +25,7 @@ type factoryMock struct {
 	deploy           func(ctx context.Context, issuer common.Address, defaultHardDepositTimeoutDuration *big.Int, nonce common.Hash) (common.Hash, error)
 	waitDeployed     func(ctx context.Context, txHash common.Hash) (common.Address, error)
 	verifyChequebook func(ctx context.Context, chequebook common.Address) error
+	address          func(ctx context.Context, deployer common.Address, nonce common.Hash) (common.Address, error)
 }
 
 // ERC20Address returns the token for which this factory deploys chequebooks.
@@ -44,3 +45,8 @@ func (m *factoryMock) WaitDeployed(ctx context.Context, txHash common.Hash) (com
 func (m *factoryMock) VerifyChequebook(ctx context.Context, chequebook common.Address) error {
 	return m.verifyChequebook(ctx, chequebook)
 }
+
+// Address returns the predetermined address of the chequebook deployed by the deployer with the nonce.
+func (m *factoryMock) Address(ctx context.Context, deployer common.Address, nonce common.Hash) (common.Address, error) {
+	return m.address(ctx, deployer, nonce)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethersphere/bee/v2/pkg/sctx"
 	"github.com/ethersphere/bee/v2/pkg/transaction"
 	"github.com/ethersphere/bee/v2/pkg/util/abiutil"
@@ -37,6 +38,8 @@ type Factory interface {
 	WaitDeployed(ctx context.Context, txHash common.Hash) (common.Address, error)
 	// VerifyChequebook checks that the supplied chequebook has been deployed by this factory.
 	VerifyChequebook(ctx context.Context, chequebook common.Address) error
+	// Address returns the predetermined address of the chequebook deployed by the deployer with the nonce.
+	Address(ctx context.Context, deployer common.Address, nonce common.Hash) (common.Address, error)
 }
 
 type factory struct {
@@ -173,3 +176,47 @@ func (c *factory) ERC20Address(ctx context.Context) (common.Address, error) {
 	}
 	return *erc20Address, nil
 }
+
+// Address returns the predetermined address of the chequebook deployed by the deployer with the nonce.
+func (c *factory) Address(ctx context.Context, deployer common.Address, nonce common.Hash) (common.Address, error) {
+	callData, err := factoryABI.Pack("master")
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	output, err := c.transactionService.Call(ctx, &transaction.TxRequest{
+		To:   &c.address,
+		Data: callData,
+	})
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	results, err := factoryABI.Unpack("master", output)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	if len(results) != 1 {
+		return common.Address{}, errDecodeABI
+	}
+
+	master, ok := abi.ConvertType(results[0], new(common.Address)).(*common.Address)
+	if !ok || master == nil {
+		return common.Address{}, errDecodeABI
+	}
+	return chequebookAddress(c.address, *master, deployer, nonce), nil
+}
+
+// chequebookAddress computes the CREATE2 address of the minimal proxy of
+// the master chequebook which the factory deploys with the salt derived
+// from the deployer and the nonce.
+func chequebookAddress(factory, master, deployer common.Address, nonce common.Hash) common.Address {
+	initCode := make([]byte, 0, 55)
+	initCode = append(initCode, common.FromHex("3d602d80600a3d3981f3363d3d373d3d3d363d73")...)
+	initCode = append(initCode, master.Bytes()...)
+	initCode = append(initCode, common.FromHex("5af43d82803e903d91602b57fd5bf3")...)
+
+	salt := ethcrypto.Keccak256Hash(common.LeftPadBytes(deployer.Bytes(), 32), nonce.Bytes())
+	return ethcrypto.CreateAddress2(factory, salt, ethcrypto.Keccak256(initCode))
+}
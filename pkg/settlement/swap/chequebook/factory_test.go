@@ -12,6 +12,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethersphere/bee/v2/pkg/settlement/swap/chequebook"
 	"github.com/ethersphere/bee/v2/pkg/transaction"
 	"github.com/ethersphere/bee/v2/pkg/transaction/backendmock"
@@ -153,3 +154,35 @@ func TestFactoryDeployReverted(t *testing.T) {
 		t.Fatalf("wrong error. wanted %v, got %v", transaction.ErrTransactionReverted, err)
 	}
 }
+
+func TestFactoryAddress(t *testing.T) {
+	t.Parallel()
+
+	factoryAddress := common.HexToAddress("0xabcd")
+	masterAddress := common.HexToAddress("0xeeee")
+	deployerAddress := common.HexToAddress("0xefff")
+	nonce := common.HexToHash("eeff")
+
+	factory := chequebook.NewFactory(backendmock.New(), transactionmock.New(
+		transactionmock.WithABICall(
+			&factoryABI,
+			factoryAddress,
+			common.BytesToHash(masterAddress.Bytes()).Bytes(),
+			"master",
+		),
+	), factoryAddress)
+
+	addr, err := factory.Address(context.Background(), deployerAddress, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the factory deploys the minimal proxy of the master with the salt of the deployer and the nonce
+	initCode := common.FromHex("3d602d80600a3d3981f3363d3d373d3d3d363d73" + masterAddress.Hex()[2:] + "5af43d82803e903d91602b57fd5bf3")
+	salt := ethcrypto.Keccak256Hash(common.LeftPadBytes(deployerAddress.Bytes(), 32), nonce.Bytes())
+	want := ethcrypto.CreateAddress2(factoryAddress, salt, ethcrypto.Keccak256(initCode))
+
+	if addr != want {
+		t.Fatalf("wrong chequebook address. wanted %x, got %x", want, addr)
+	}
+}
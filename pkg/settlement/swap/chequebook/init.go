@@ -6,13 +6,13 @@ package chequebook
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	chaincfg "github.com/ethersphere/bee/v2/pkg/config"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/sctx"
@@ -29,6 +29,10 @@ const (
 	balanceCheckMaxRetries      = 10
 )
 
+// ErrWrongIssuer is returned when the chequebook found at the
+// predetermined address is not issued by the node.
+var ErrWrongIssuer = errors.New("chequebook not issued by node")
+
 const (
 	erc20SmallUnitStr = "10000000000000000"
 	ethSmallUnitStr   = "1000000000000000000"
@@ -117,7 +121,25 @@ func checkBalance(
 	}
 }
 
-// Init initialises the chequebook service.
+// Sponsor is the account paying the gas for the chequebook deployment
+// instead of the node, e.g. the treasury provisioning a fleet of nodes.
+type Sponsor struct {
+	// Address is the ethereum address of the sponsor.
+	Address common.Address
+	// Factory is the factory sending the deployment transactions from the sponsor.
+	Factory Factory
+}
+
+// deploymentNonce returns the nonce of the chequebook deployment of the node.
+// It is derived from the node address so that the chequebook address is known
+// before the deployment and may be funded in advance.
+func deploymentNonce(overlayEthAddress common.Address) common.Hash {
+	return ethcrypto.Keccak256Hash(overlayEthAddress.Bytes())
+}
+
+// Init initialises the chequebook service. If the sponsor is not nil, the
+// chequebook is deployed by the sponsor and the node pays only for the
+// deposit, if the chequebook was not already funded in advance.
 func Init(
 	ctx context.Context,
 	chequebookFactory Factory,
@@ -130,6 +152,7 @@ func Init(
 	overlayEthAddress common.Address,
 	chequeSigner ChequeSigner,
 	erc20Service erc20.Service,
+	sponsor *Sponsor,
 ) (chequebookService Service, err error) {
 	logger = logger.WithName(loggerName).Register()
 
@@ -146,37 +169,16 @@ func Init(
 			return nil, err
 		}
 		if errors.Is(err, storage.ErrNotFound) {
-			logger.Info("no chequebook found, deploying new one.")
-			err = checkBalance(ctx, logger, swapInitialDeposit, swapBackend, chainId, overlayEthAddress, erc20Service)
-			if err != nil {
-				return nil, err
-			}
-
-			nonce := make([]byte, 32)
-			_, err = rand.Read(nonce)
+			chequebookAddress, err = deployChequebook(ctx, chequebookFactory, stateStore, logger, swapInitialDeposit, transactionService, swapBackend, chainId, overlayEthAddress, erc20Service, sponsor)
 			if err != nil {
 				return nil, err
 			}
-
-			// if we don't yet have a chequebook, deploy a new one
-			txHash, err = chequebookFactory.Deploy(ctx, overlayEthAddress, big.NewInt(0), common.BytesToHash(nonce))
-			if err != nil {
-				return nil, err
-			}
-
-			logger.Info("deploying new chequebook", "tx", txHash)
-
-			err = stateStore.Put(ChequebookDeploymentKey, txHash)
+		} else {
+			logger.Info("waiting for chequebook deployment", "tx", txHash)
+			chequebookAddress, err = chequebookFactory.WaitDeployed(ctx, txHash)
 			if err != nil {
 				return nil, err
 			}
-		} else {
-			logger.Info("waiting for chequebook deployment", "tx", txHash)
-		}
-
-		chequebookAddress, err = chequebookFactory.WaitDeployed(ctx, txHash)
-		if err != nil {
-			return nil, err
 		}
 
 		logger.Info("chequebook deployed", "chequebook_address", chequebookAddress)
@@ -192,9 +194,15 @@ func Init(
 			return nil, err
 		}
 
-		if swapInitialDeposit.Cmp(big.NewInt(0)) != 0 {
-			logger.Info("depositing token into new chequebook", "amount", swapInitialDeposit)
-			depositHash, err := chequebookService.Deposit(ctx, swapInitialDeposit)
+		balance, err := chequebookService.Balance(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// deposit only what is missing to the initial deposit as the chequebook might have been funded in advance
+		if deposit := new(big.Int).Sub(swapInitialDeposit, balance); deposit.Sign() > 0 {
+			logger.Info("depositing token into new chequebook", "amount", deposit)
+			depositHash, err := chequebookService.Deposit(ctx, deposit)
 			if err != nil {
 				return nil, err
 			}
@@ -224,3 +232,90 @@ func Init(
 
 	return chequebookService, nil
 }
+
+// deployChequebook deploys the chequebook of the node at the predetermined
+// address unless it is already deployed there. The funds already sent to the
+// predetermined address are deducted from the balance the node must hold for
+// the initial deposit.
+func deployChequebook(
+	ctx context.Context,
+	chequebookFactory Factory,
+	stateStore storage.StateStorer,
+	logger log.Logger,
+	swapInitialDeposit *big.Int,
+	transactionService transaction.Service,
+	swapBackend transaction.Backend,
+	chainId int64,
+	overlayEthAddress common.Address,
+	erc20Service erc20.Service,
+	sponsor *Sponsor,
+) (common.Address, error) {
+	deployer, factory := overlayEthAddress, chequebookFactory
+	if sponsor != nil {
+		deployer, factory = sponsor.Address, sponsor.Factory
+	}
+
+	nonce := deploymentNonce(overlayEthAddress)
+	chequebookAddress, err := factory.Address(ctx, deployer, nonce)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("chequebook address: %w", err)
+	}
+
+	code, err := swapBackend.CodeAt(ctx, chequebookAddress, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(code) > 0 {
+		issuer, err := newChequebookContract(chequebookAddress, transactionService).Issuer(ctx)
+		if err != nil {
+			return common.Address{}, err
+		}
+		if issuer != overlayEthAddress {
+			return common.Address{}, fmt.Errorf("%w: chequebook %s issued by %s", ErrWrongIssuer, chequebookAddress, issuer)
+		}
+		logger.Info("found chequebook at predetermined address", "chequebook_address", chequebookAddress)
+		return chequebookAddress, nil
+	}
+
+	funded, err := erc20Service.BalanceOf(ctx, chequebookAddress)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if funded.Sign() > 0 {
+		logger.Info("predetermined chequebook address is already funded", "chequebook_address", chequebookAddress, "amount", funded)
+	}
+
+	required := new(big.Int).Sub(swapInitialDeposit, funded)
+	if required.Sign() < 0 {
+		required.SetInt64(0)
+	}
+
+	logger.Info("no chequebook found, deploying new one.", "chequebook_address", chequebookAddress, "deployer", deployer)
+	// with the sponsor the node pays only for the deposit, if any is required
+	if sponsor == nil || required.Sign() > 0 {
+		err = checkBalance(ctx, logger, required, swapBackend, chainId, overlayEthAddress, erc20Service)
+		if err != nil {
+			return common.Address{}, err
+		}
+	}
+
+	// if we don't yet have a chequebook, deploy a new one
+	txHash, err := factory.Deploy(ctx, overlayEthAddress, big.NewInt(0), nonce)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	logger.Info("deploying new chequebook", "tx", txHash)
+
+	// the transaction sent by the sponsor is not known to the transaction
+	// service of the node, so it cannot be awaited after a restart; the
+	// chequebook is then found at the predetermined address instead
+	if sponsor == nil {
+		err = stateStore.Put(ChequebookDeploymentKey, txHash)
+		if err != nil {
+			return common.Address{}, err
+		}
+	}
+
+	return factory.WaitDeployed(ctx, txHash)
+}
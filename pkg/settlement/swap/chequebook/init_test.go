@@ -0,0 +1,210 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chequebook_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/settlement/swap/chequebook"
+	erc20mock "github.com/ethersphere/bee/v2/pkg/settlement/swap/erc20/mock"
+	storemock "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/transaction/backendmock"
+	transactionmock "github.com/ethersphere/bee/v2/pkg/transaction/mock"
+)
+
+func TestInit(t *testing.T) {
+	t.Parallel()
+
+	ownerAddress := common.HexToAddress("0xfff")
+	sponsorAddress := common.HexToAddress("0xaaaa")
+	chequebookAddress := common.HexToAddress("0xabcd")
+	deployTransactionHash := common.HexToHash("0xffff")
+	deposit := big.NewInt(100)
+
+	noDeploy := func(context.Context, common.Address, *big.Int, common.Hash) (common.Hash, error) {
+		return common.Hash{}, errors.New("unexpected deployment")
+	}
+
+	t.Run("already deployed", func(t *testing.T) {
+		t.Parallel()
+
+		store := storemock.NewStateStore()
+		factory := &factoryMock{
+			address: func(_ context.Context, deployer common.Address, _ common.Hash) (common.Address, error) {
+				if deployer != ownerAddress {
+					t.Fatalf("wrong deployer. wanted %x, got %x", ownerAddress, deployer)
+				}
+				return chequebookAddress, nil
+			},
+			deploy: noDeploy,
+			verifyChequebook: func(context.Context, common.Address) error {
+				return nil
+			},
+		}
+
+		chequebookService, err := chequebook.Init(
+			context.Background(),
+			factory,
+			store,
+			log.Noop,
+			deposit,
+			transactionmock.New(
+				transactionmock.WithABICallSequence(
+					transactionmock.ABICall(&chequebookABI, chequebookAddress, common.BytesToHash(ownerAddress.Bytes()).Bytes(), "issuer"),
+					transactionmock.ABICall(&chequebookABI, chequebookAddress, deposit.FillBytes(make([]byte, 32)), "balance"),
+				),
+			),
+			backendmock.New(
+				backendmock.WithCodeAtFunc(func(_ context.Context, contract common.Address, _ *big.Int) ([]byte, error) {
+					if contract != chequebookAddress {
+						t.Fatalf("wrong contract. wanted %x, got %x", chequebookAddress, contract)
+					}
+					return []byte{1}, nil
+				}),
+			),
+			1,
+			ownerAddress,
+			&chequeSignerMock{},
+			erc20mock.New(),
+			nil,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if chequebookService.Address() != chequebookAddress {
+			t.Fatalf("wrong chequebook address. wanted %x, got %x", chequebookAddress, chequebookService.Address())
+		}
+
+		var stored common.Address
+		if err := store.Get("swap_chequebook", &stored); err != nil {
+			t.Fatal(err)
+		}
+		if stored != chequebookAddress {
+			t.Fatalf("wrong stored chequebook address. wanted %x, got %x", chequebookAddress, stored)
+		}
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		t.Parallel()
+
+		factory := &factoryMock{
+			address: func(context.Context, common.Address, common.Hash) (common.Address, error) {
+				return chequebookAddress, nil
+			},
+			deploy: noDeploy,
+		}
+
+		_, err := chequebook.Init(
+			context.Background(),
+			factory,
+			storemock.NewStateStore(),
+			log.Noop,
+			deposit,
+			transactionmock.New(
+				transactionmock.WithABICall(&chequebookABI, chequebookAddress, common.BytesToHash(sponsorAddress.Bytes()).Bytes(), "issuer"),
+			),
+			backendmock.New(
+				backendmock.WithCodeAtFunc(func(context.Context, common.Address, *big.Int) ([]byte, error) {
+					return []byte{1}, nil
+				}),
+			),
+			1,
+			ownerAddress,
+			&chequeSignerMock{},
+			erc20mock.New(),
+			nil,
+		)
+		if !errors.Is(err, chequebook.ErrWrongIssuer) {
+			t.Fatalf("wrong error. wanted %v, got %v", chequebook.ErrWrongIssuer, err)
+		}
+	})
+
+	t.Run("pre-funded with sponsor", func(t *testing.T) {
+		t.Parallel()
+
+		store := storemock.NewStateStore()
+		factory := &factoryMock{
+			deploy: noDeploy,
+			verifyChequebook: func(_ context.Context, chequebook common.Address) error {
+				if chequebook != chequebookAddress {
+					t.Fatalf("verifying wrong chequebook. wanted %x, got %x", chequebookAddress, chequebook)
+				}
+				return nil
+			},
+		}
+
+		var nonce common.Hash
+		sponsorFactory := &factoryMock{
+			address: func(_ context.Context, deployer common.Address, n common.Hash) (common.Address, error) {
+				if deployer != sponsorAddress {
+					t.Fatalf("wrong deployer. wanted %x, got %x", sponsorAddress, deployer)
+				}
+				nonce = n
+				return chequebookAddress, nil
+			},
+			deploy: func(_ context.Context, issuer common.Address, _ *big.Int, n common.Hash) (common.Hash, error) {
+				if issuer != ownerAddress {
+					t.Fatalf("wrong issuer. wanted %x, got %x", ownerAddress, issuer)
+				}
+				if n != nonce {
+					t.Fatalf("wrong nonce. wanted %x, got %x", nonce, n)
+				}
+				return deployTransactionHash, nil
+			},
+			waitDeployed: func(_ context.Context, txHash common.Hash) (common.Address, error) {
+				if txHash != deployTransactionHash {
+					t.Fatalf("waiting for wrong transaction. wanted %x, got %x", deployTransactionHash, txHash)
+				}
+				return chequebookAddress, nil
+			},
+		}
+
+		chequebookService, err := chequebook.Init(
+			context.Background(),
+			factory,
+			store,
+			log.Noop,
+			deposit,
+			transactionmock.New(
+				transactionmock.WithABICall(&chequebookABI, chequebookAddress, deposit.FillBytes(make([]byte, 32)), "balance"),
+			),
+			backendmock.New(
+				backendmock.WithCodeAtFunc(func(context.Context, common.Address, *big.Int) ([]byte, error) {
+					return nil, nil
+				}),
+			),
+			1,
+			ownerAddress,
+			&chequeSignerMock{},
+			erc20mock.New(
+				erc20mock.WithBalanceOfFunc(func(_ context.Context, address common.Address) (*big.Int, error) {
+					if address != chequebookAddress {
+						t.Fatalf("wrong balance address. wanted %x, got %x", chequebookAddress, address)
+					}
+					return deposit, nil
+				}),
+			),
+			&chequebook.Sponsor{Address: sponsorAddress, Factory: sponsorFactory},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if chequebookService.Address() != chequebookAddress {
+			t.Fatalf("wrong chequebook address. wanted %x, got %x", chequebookAddress, chequebookService.Address())
+		}
+
+		var txHash common.Hash
+		if err := store.Get(chequebook.ChequebookDeploymentKey, &txHash); err == nil {
+			t.Fatal("sponsored deployment transaction stored")
+		}
+	})
+}
@@ -6,6 +6,7 @@ package priceoracle_test
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -60,3 +61,37 @@ func TestExchangeGetPrice(t *testing.T) {
 		t.Fatalf("got wrong deduce. wanted %d, got %d", expectedDeduce, deduce)
 	}
 }
+
+func TestStatic(t *testing.T) {
+	t.Parallel()
+
+	ex, err := priceoracle.NewStatic(big.NewInt(100), big.NewInt(200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex.Start()
+	defer ex.Close()
+
+	exchangeRate, deduce, err := ex.CurrentRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exchangeRate.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("got wrong exchange rate. wanted %d, got %d", 100, exchangeRate)
+	}
+	if deduce.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("got wrong deduction. wanted %d, got %d", 200, deduce)
+	}
+
+	for _, tc := range []struct {
+		exchangeRate, deduction *big.Int
+	}{
+		{big.NewInt(0), big.NewInt(0)},
+		{big.NewInt(1), big.NewInt(-1)},
+		{nil, big.NewInt(0)},
+	} {
+		if _, err := priceoracle.NewStatic(tc.exchangeRate, tc.deduction); !errors.Is(err, priceoracle.ErrInvalidRates) {
+			t.Fatalf("rates %v %v: want error %v, got %v", tc.exchangeRate, tc.deduction, priceoracle.ErrInvalidRates, err)
+		}
+	}
+}
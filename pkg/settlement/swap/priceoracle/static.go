@@ -0,0 +1,49 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package priceoracle
+
+import (
+	"context"
+	"errors"
+	"math/big"
+)
+
+// ErrInvalidRates is returned when the static exchange rate is not positive
+// or the deduction is negative.
+var ErrInvalidRates = errors.New("invalid static exchange rate or deduction")
+
+// staticService is the price oracle with the administratively set rates,
+// used by the private swarms without the price oracle contract.
+type staticService struct {
+	exchangeRate *big.Int
+	deduction    *big.Int
+}
+
+// NewStatic returns the price oracle which always reports the given
+// exchange rate and deduction. All the nodes exchanging the cheques must
+// be configured with the same rates.
+func NewStatic(exchangeRate, deduction *big.Int) (Service, error) {
+	if exchangeRate == nil || exchangeRate.Sign() <= 0 || deduction == nil || deduction.Sign() < 0 {
+		return nil, ErrInvalidRates
+	}
+	return &staticService{
+		exchangeRate: new(big.Int).Set(exchangeRate),
+		deduction:    new(big.Int).Set(deduction),
+	}, nil
+}
+
+func (s *staticService) Start() {}
+
+func (s *staticService) GetPrice(context.Context) (*big.Int, *big.Int, error) {
+	return s.CurrentRates()
+}
+
+func (s *staticService) CurrentRates() (exchangeRate, deduction *big.Int, err error) {
+	return new(big.Int).Set(s.exchangeRate), new(big.Int).Set(s.deduction), nil
+}
+
+func (s *staticService) Close() error {
+	return nil
+}
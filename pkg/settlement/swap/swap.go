@@ -9,8 +9,11 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/bigint"
+	"github.com/ethersphere/bee/v2/pkg/events"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage/postagecontract"
 	"github.com/ethersphere/bee/v2/pkg/settlement"
@@ -31,6 +34,8 @@ var (
 	// ErrChequeValueTooLow is the error a peer issued a cheque not covering 1 accounting credit
 	ErrChequeValueTooLow = errors.New("cheque value too low")
 	ErrNoChequebook      = errors.New("no chequebook")
+	// ErrCashoutPaused is the error if a cheque is cashed while the cashouts are paused.
+	ErrCashoutPaused = errors.New("cashouts paused")
 )
 
 type Interface interface {
@@ -62,6 +67,8 @@ type Service struct {
 	addressbook    Addressbook
 	networkID      uint64
 	cashoutAddress common.Address
+	events         events.Publisher
+	cashoutPaused  atomic.Bool
 }
 
 // New creates a new swap Service.
@@ -118,6 +125,9 @@ func (s *Service) ReceiveCheque(ctx context.Context, peer swarm.Address, cheque
 	tot, _ := big.NewFloat(0).SetInt(receivedAmount).Float64()
 	s.metrics.TotalReceived.Add(tot)
 	s.metrics.ChequesReceived.Inc()
+	if s.events != nil {
+		s.events.Publish(events.TopicChequeReceived, events.ChequeReceived{Peer: peer, Amount: bigint.Wrap(amount)})
+	}
 
 	return s.accounting.NotifyPaymentReceived(peer, amount)
 }
@@ -161,6 +171,11 @@ func (s *Service) SetAccounting(accounting settlement.Accounting) {
 	s.accounting = accounting
 }
 
+// SetEventPublisher sets the publisher of the cheque and the cashout events.
+func (s *Service) SetEventPublisher(p events.Publisher) {
+	s.events = p
+}
+
 // TotalSent returns the total amount sent to a peer
 func (s *Service) TotalSent(peer swarm.Address) (totalSent *big.Int, err error) {
 	beneficiary, known, err := s.addressbook.Beneficiary(peer)
@@ -353,6 +368,9 @@ func (s *Service) LastReceivedCheques() (map[string]*chequebook.SignedCheque, er
 
 // CashCheque sends a cashing transaction for the last cheque of the peer
 func (s *Service) CashCheque(ctx context.Context, peer swarm.Address) (common.Hash, error) {
+	if s.cashoutPaused.Load() {
+		return common.Hash{}, ErrCashoutPaused
+	}
 	chequebookAddress, known, err := s.addressbook.Chequebook(peer)
 	if err != nil {
 		return common.Hash{}, err
@@ -360,7 +378,24 @@ func (s *Service) CashCheque(ctx context.Context, peer swarm.Address) (common.Ha
 	if !known {
 		return common.Hash{}, chequebook.ErrNoCheque
 	}
-	return s.cashout.CashCheque(ctx, chequebookAddress, s.cashoutAddress)
+	txHash, err := s.cashout.CashCheque(ctx, chequebookAddress, s.cashoutAddress)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if s.events != nil {
+		s.events.Publish(events.TopicCashout, events.Cashout{Peer: peer, TransactionHash: txHash})
+	}
+	return txHash, nil
+}
+
+// PauseCashouts rejects the cashing of the cheques until the ResumeCashouts is called.
+func (s *Service) PauseCashouts() {
+	s.cashoutPaused.Store(true)
+}
+
+// ResumeCashouts allows the cashing of the cheques paused by the PauseCashouts.
+func (s *Service) ResumeCashouts() {
+	s.cashoutPaused.Store(false)
 }
 
 // CashoutStatus gets the status of the latest cashout transaction for the peers chequebook
@@ -0,0 +1,19 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simulator
+
+import (
+	"context"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// AddNode registers the protocols of the node and
+// it returns the streamer opening the streams from it.
+func (n *Network) AddNode(overlay swarm.Address, protocols ...p2p.ProtocolSpec) p2p.Streamer {
+	n.addNode(overlay, protocols...)
+	return &streamer{network: n, base: overlay, ctx: context.Background()}
+}
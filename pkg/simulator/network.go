@@ -0,0 +1,346 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simulator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+var (
+	// ErrStreamLost is returned when the stream is lost as per the link model.
+	ErrStreamLost = errors.New("stream lost")
+	// ErrInvalidLinkModel is returned when the link model is out of bounds.
+	ErrInvalidLinkModel = errors.New("invalid link model")
+)
+
+// LinkModel is the model of the link between two nodes of the virtual network.
+type LinkModel struct {
+	// Latency is the delay of every message sent over the link.
+	Latency time.Duration
+	// Jitter is the upper bound of the random delay added to the latency.
+	Jitter time.Duration
+	// Loss is the probability in the range [0,1] of the stream failing to
+	// open, as the protocols do not retransmit the lost messages.
+	Loss float64
+}
+
+// Validate checks that the link model is within the bounds.
+func (m LinkModel) Validate() error {
+	if m.Latency < 0 || m.Jitter < 0 || m.Loss < 0 || m.Loss > 1 {
+		return ErrInvalidLinkModel
+	}
+	return nil
+}
+
+type link struct {
+	from, to string
+}
+
+// Network is the virtual network connecting the nodes in the same process.
+// The streams between the nodes are delayed and lost as per the link models.
+type Network struct {
+	mu        sync.Mutex
+	rand      *rand.Rand
+	model     LinkModel
+	links     map[link]LinkModel
+	protocols map[string][]p2p.ProtocolSpec
+	blocked   map[link]struct{}
+}
+
+// NewNetwork returns the network with the given default link model.
+// The seed makes the losses and the jitter reproducible.
+func NewNetwork(model LinkModel, seed int64) (*Network, error) {
+	if err := model.Validate(); err != nil {
+		return nil, err
+	}
+	return &Network{
+		rand:      rand.New(rand.NewSource(seed)),
+		model:     model,
+		links:     make(map[link]LinkModel),
+		protocols: make(map[string][]p2p.ProtocolSpec),
+		blocked:   make(map[link]struct{}),
+	}, nil
+}
+
+// SetLink overrides the default link model of the link between
+// the two nodes, the model applies in both directions.
+func (n *Network) SetLink(a, b swarm.Address, model LinkModel) error {
+	if err := model.Validate(); err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.links[link{a.ByteString(), b.ByteString()}] = model
+	n.links[link{b.ByteString(), a.ByteString()}] = model
+	return nil
+}
+
+// Partition disconnects the two nodes until Heal is called.
+func (n *Network) Partition(a, b swarm.Address) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.blocked[link{a.ByteString(), b.ByteString()}] = struct{}{}
+	n.blocked[link{b.ByteString(), a.ByteString()}] = struct{}{}
+}
+
+// Heal reconnects the two partitioned nodes.
+func (n *Network) Heal(a, b swarm.Address) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.blocked, link{a.ByteString(), b.ByteString()})
+	delete(n.blocked, link{b.ByteString(), a.ByteString()})
+}
+
+// connected reports whether the stream from one node to the other may be opened.
+func (n *Network) connected(from, to swarm.Address) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.protocols[to.ByteString()]; !ok {
+		return false
+	}
+	_, blocked := n.blocked[link{from.ByteString(), to.ByteString()}]
+	return !blocked
+}
+
+// addNode registers the protocols handled by the node.
+func (n *Network) addNode(overlay swarm.Address, protocols ...p2p.ProtocolSpec) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.protocols[overlay.ByteString()] = protocols
+}
+
+// removeNode makes the node unreachable.
+func (n *Network) removeNode(overlay swarm.Address) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.protocols, overlay.ByteString())
+}
+
+// delay returns the delay of the message sent over the link.
+func (n *Network) delay(from, to swarm.Address) time.Duration {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	m := n.linkModel(from, to)
+	d := m.Latency
+	if m.Jitter > 0 {
+		d += time.Duration(n.rand.Int63n(int64(m.Jitter) + 1))
+	}
+	return d
+}
+
+// lost reports whether the stream opened over the link is lost.
+func (n *Network) lost(from, to swarm.Address) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	m := n.linkModel(from, to)
+	return m.Loss > 0 && n.rand.Float64() < m.Loss
+}
+
+// linkModel must be called with the lock held.
+func (n *Network) linkModel(from, to swarm.Address) LinkModel {
+	if m, ok := n.links[link{from.ByteString(), to.ByteString()}]; ok {
+		return m
+	}
+	return n.model
+}
+
+// handler returns the handler of the stream of the node.
+func (n *Network) handler(to swarm.Address, protocolName, protocolVersion, streamName string) (p2p.StreamSpec, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, p := range n.protocols[to.ByteString()] {
+		if p.Name != protocolName || p.Version != protocolVersion {
+			continue
+		}
+		for _, s := range p.StreamSpecs {
+			if s.Name == streamName {
+				return s, true
+			}
+		}
+	}
+	return p2p.StreamSpec{}, false
+}
+
+// streamer opens the streams of the node to the other nodes of the network.
+type streamer struct {
+	network *Network
+	base    swarm.Address
+	ctx     context.Context
+}
+
+var _ p2p.StreamerDisconnecter = (*streamer)(nil)
+
+// NewStream implements the p2p.Streamer interface.
+func (s *streamer) NewStream(ctx context.Context, addr swarm.Address, h p2p.Headers, protocolName, protocolVersion, streamName string) (p2p.Stream, error) {
+	if !s.network.connected(s.base, addr) {
+		return nil, p2p.ErrPeerNotFound
+	}
+	spec, ok := s.network.handler(addr, protocolName, protocolVersion, streamName)
+	if !ok {
+		return nil, p2p.NewIncompatibleStreamError(errors.New("stream not supported"))
+	}
+
+	if err := sleep(ctx, s.network.delay(s.base, addr)); err != nil {
+		return nil, err
+	}
+	if s.network.lost(s.base, addr) {
+		return nil, ErrStreamLost
+	}
+
+	outgoing := newPipe(func() time.Duration { return s.network.delay(s.base, addr) })
+	incoming := newPipe(func() time.Duration { return s.network.delay(addr, s.base) })
+
+	var responseHeaders p2p.Headers
+	if spec.Headler != nil {
+		responseHeaders = spec.Headler(h, s.base)
+	}
+
+	local := &stream{r: incoming, w: outgoing, headers: h, responseHeaders: responseHeaders}
+	remote := &stream{r: outgoing, w: incoming, headers: h, responseHeaders: responseHeaders}
+
+	go func() {
+		defer func() { _ = remote.Close() }()
+		_ = spec.Handler(s.ctx, p2p.Peer{Address: s.base, FullNode: true}, remote)
+	}()
+
+	return local, nil
+}
+
+// Disconnect implements the p2p.Disconnecter interface.
+func (s *streamer) Disconnect(overlay swarm.Address, _ string) error {
+	return nil
+}
+
+// Blocklist implements the p2p.Blocklister interface.
+func (s *streamer) Blocklist(overlay swarm.Address, _ time.Duration, _ string) error {
+	return nil
+}
+
+// NetworkStatus implements the p2p.NetworkStatuser interface.
+func (s *streamer) NetworkStatus() p2p.NetworkStatus {
+	return p2p.NetworkStatusAvailable
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// stream is the end of the bidirectional stream between two nodes.
+type stream struct {
+	r, w            *pipe
+	headers         p2p.Headers
+	responseHeaders p2p.Headers
+}
+
+func (s *stream) Read(p []byte) (int, error)  { return s.r.Read(p) }
+func (s *stream) Write(p []byte) (int, error) { return s.w.Write(p) }
+func (s *stream) Headers() p2p.Headers        { return s.headers }
+
+func (s *stream) ResponseHeaders() p2p.Headers { return s.responseHeaders }
+
+// Close closes the writing side of the stream.
+func (s *stream) Close() error {
+	s.w.close(io.EOF)
+	return nil
+}
+
+// FullClose closes both sides of the stream.
+func (s *stream) FullClose() error {
+	s.w.close(io.EOF)
+	s.r.close(io.EOF)
+	return nil
+}
+
+// Reset aborts the stream in both directions.
+func (s *stream) Reset() error {
+	s.w.close(io.ErrClosedPipe)
+	s.r.close(io.ErrClosedPipe)
+	return nil
+}
+
+// pipe is the buffered one way connection delaying every written message.
+type pipe struct {
+	delay func() time.Duration
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	buf  []byte
+	err  error
+}
+
+func newPipe(delay func() time.Duration) *pipe {
+	p := &pipe{delay: delay}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+func (p *pipe) Read(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.buf) == 0 && p.err == nil {
+		p.cond.Wait()
+	}
+	if len(p.buf) == 0 {
+		return 0, p.err
+	}
+	n := copy(b, p.buf)
+	p.buf = p.buf[n:]
+	return n, nil
+}
+
+func (p *pipe) Write(b []byte) (int, error) {
+	time.Sleep(p.delay())
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil {
+		return 0, io.ErrClosedPipe
+	}
+	p.buf = append(p.buf, b...)
+	p.cond.Broadcast()
+	return len(b), nil
+}
+
+func (p *pipe) close(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err == nil {
+		p.err = err
+	}
+	p.cond.Broadcast()
+}
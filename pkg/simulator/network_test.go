@@ -0,0 +1,105 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simulator_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/simulator"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// echoProtocol writes back everything read from the stream.
+func echoProtocol() p2p.ProtocolSpec {
+	return p2p.ProtocolSpec{
+		Name:    "echo",
+		Version: "1.0.0",
+		StreamSpecs: []p2p.StreamSpec{{
+			Name: "echo",
+			Handler: func(_ context.Context, _ p2p.Peer, s p2p.Stream) error {
+				defer s.Close()
+				_, err := io.Copy(s, s)
+				return err
+			},
+		}},
+	}
+}
+
+func echo(t *testing.T, s p2p.Streamer, to swarm.Address) error {
+	t.Helper()
+
+	stream, err := s.NewStream(context.Background(), to, nil, "echo", "1.0.0", "echo")
+	if err != nil {
+		return err
+	}
+	if _, err := stream.Write([]byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "ping" {
+		t.Fatalf("got %q, want %q", got, "ping")
+	}
+	return nil
+}
+
+func TestNetwork(t *testing.T) {
+	t.Parallel()
+
+	const latency = 20 * time.Millisecond
+
+	n, err := simulator.NewNetwork(simulator.LinkModel{Latency: latency}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b, c := swarm.RandAddress(t), swarm.RandAddress(t), swarm.RandAddress(t)
+	sa := n.AddNode(a, echoProtocol())
+	n.AddNode(b, echoProtocol())
+	n.AddNode(c)
+
+	// the stream is opened and the message is sent and echoed
+	start := time.Now()
+	if err := echo(t, sa, b); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < 3*latency {
+		t.Fatalf("echo took %s, want at least %s", d, 3*latency)
+	}
+
+	if err := echo(t, sa, c); !errors.As(err, new(*p2p.IncompatibleStreamError)) {
+		t.Fatalf("want incompatible stream error, got %v", err)
+	}
+	if err := echo(t, sa, swarm.RandAddress(t)); !errors.Is(err, p2p.ErrPeerNotFound) {
+		t.Fatalf("want error %v, got %v", p2p.ErrPeerNotFound, err)
+	}
+
+	n.Partition(a, b)
+	if err := echo(t, sa, b); !errors.Is(err, p2p.ErrPeerNotFound) {
+		t.Fatalf("want error %v, got %v", p2p.ErrPeerNotFound, err)
+	}
+	n.Heal(a, b)
+
+	if err := n.SetLink(a, b, simulator.LinkModel{Loss: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := echo(t, sa, b); !errors.Is(err, simulator.ErrStreamLost) {
+		t.Fatalf("want error %v, got %v", simulator.ErrStreamLost, err)
+	}
+
+	if err := n.SetLink(a, b, simulator.LinkModel{Loss: -1}); !errors.Is(err, simulator.ErrInvalidLinkModel) {
+		t.Fatalf("want error %v, got %v", simulator.ErrInvalidLinkModel, err)
+	}
+}
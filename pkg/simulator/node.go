@@ -0,0 +1,229 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simulator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io"
+	stdlog "log"
+	"math/big"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/accesscontrol"
+	mockAccounting "github.com/ethersphere/bee/v2/pkg/accounting/mock"
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
+	"github.com/ethersphere/bee/v2/pkg/gsoc"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	mockPost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/postage/postagecontract"
+	mockPostContract "github.com/ethersphere/bee/v2/pkg/postage/postagecontract/mock"
+	"github.com/ethersphere/bee/v2/pkg/pricer"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/ethersphere/bee/v2/pkg/pusher"
+	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	resolverMock "github.com/ethersphere/bee/v2/pkg/resolver/mock"
+	"github.com/ethersphere/bee/v2/pkg/retrieval"
+	"github.com/ethersphere/bee/v2/pkg/scoreboard"
+	"github.com/ethersphere/bee/v2/pkg/statestore/leveldb"
+	mockSteward "github.com/ethersphere/bee/v2/pkg/steward/mock"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/tracing"
+	"github.com/ethersphere/bee/v2/pkg/util/ioutil"
+)
+
+// basePrice is the price of the chunk at the farthest proximity.
+const basePrice = 10_000
+
+// Node is the in-process node of the cluster serving the normal API.
+type Node struct {
+	overlay  swarm.Address
+	key      *ecdsa.PrivateKey
+	storer   *storer.DB
+	post     postage.Service
+	apiURL   string
+	closers  []io.Closer
+	server   *http.Server
+	ctxClose context.CancelFunc
+}
+
+// Overlay returns the overlay address of the node.
+func (n *Node) Overlay() swarm.Address { return n.overlay }
+
+// PrivateKey returns the key of the node.
+func (n *Node) PrivateKey() *ecdsa.PrivateKey { return n.key }
+
+// Storer returns the local store of the node.
+func (n *Node) Storer() *storer.DB { return n.storer }
+
+// APIURL returns the base URL of the API of the node.
+func (n *Node) APIURL() string { return n.apiURL }
+
+// newNode starts the node with the in-memory stores connected to the network.
+func newNode(logger log.Logger, c *Cluster, key *ecdsa.PrivateKey) (_ *Node, err error) {
+	signer := crypto.NewDefaultSigner(key)
+	overlay, err := crypto.NewOverlayAddress(key.PublicKey, networkID, make([]byte, 32))
+	if err != nil {
+		return nil, fmt.Errorf("overlay address: %w", err)
+	}
+	ethAddress, err := signer.EthereumAddress()
+	if err != nil {
+		return nil, fmt.Errorf("ethereum address: %w", err)
+	}
+	logger = logger.WithValues("node", overlay.String()).Build()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n := &Node{overlay: overlay, key: key, ctxClose: cancel}
+	defer func() {
+		if err != nil {
+			_ = n.close()
+		}
+	}()
+
+	tracer, tracerCloser, err := tracing.NewTracer(&tracing.Options{Enabled: false})
+	if err != nil {
+		return nil, fmt.Errorf("tracer: %w", err)
+	}
+	n.closers = append(n.closers, tracerCloser)
+
+	stateStore, err := leveldb.NewInMemoryStateStore(logger)
+	if err != nil {
+		return nil, fmt.Errorf("statestore: %w", err)
+	}
+	n.closers = append(n.closers, stateStore)
+
+	topology := newMeshTopology(overlay, c.network, c.overlays)
+
+	localStore, err := storer.New(ctx, "", &storer.Options{
+		Logger:          logger,
+		Address:         overlay,
+		Batchstore:      c.batchStore,
+		RadiusSetter:    topology,
+		StateStore:      stateStore,
+		ReserveCapacity: c.reserveCapacity,
+		CacheCapacity:   uint64(c.reserveCapacity),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("localstore: %w", err)
+	}
+	n.storer = localStore
+	n.closers = append(n.closers, localStore)
+
+	radius := func() (uint8, error) { return localStore.StorageRadius(), nil }
+	streamer := &streamer{network: c.network, base: overlay, ctx: ctx}
+	acc := mockAccounting.NewAccounting()
+	prices := pricer.NewFixedPricer(overlay, basePrice)
+	scores := scoreboard.New(scoreboard.DefaultHalfLife)
+
+	pssService := pss.New(key, logger)
+	n.closers = append(n.closers, pssService)
+	gsocService := gsoc.New(logger)
+
+	pushSync := pushsync.New(overlay, networkID, make([]byte, 32), streamer, localStore, radius, topology, true, pssService.TryUnwrap, gsocService.Handle, postage.ValidStamp(c.batchStore), logger, acc, prices, signer, tracer, 0, 0, 0, scores)
+	n.closers = append(n.closers, pushSync)
+	pssService.SetPushSyncer(pushSync)
+
+	retrieve := retrieval.New(overlay, radius, localStore, streamer, topology, logger, acc, prices, tracer, false, scores)
+	n.closers = append(n.closers, retrieve)
+	localStore.SetRetrievalService(retrieve)
+
+	pusherService := pusher.New(networkID, localStore, pushSync, c.batchStore, logger, 0, pusher.DefaultRetryCount)
+	n.closers = append(n.closers, pusherService)
+	pusherService.AddFeed(localStore.PusherFeed())
+
+	c.network.addNode(overlay, pushSync.Protocol(), retrieve.Protocol())
+
+	n.post = mockPost.New()
+	postageContract := mockPostContract.New(
+		mockPostContract.WithCreateBatchFunc(
+			func(ctx context.Context, amount *big.Int, depth uint8, immutable bool, label string) (common.Hash, []byte, error) {
+				id, err := c.CreateBatch(n, amount, depth, immutable, label)
+				return common.Hash{}, id, err
+			},
+		),
+		mockPostContract.WithTopUpBatchFunc(
+			func(ctx context.Context, batchID []byte, topupAmount *big.Int) (common.Hash, error) {
+				return common.Hash{}, postagecontract.ErrNotImplemented
+			},
+		),
+		mockPostContract.WithDiluteBatchFunc(
+			func(ctx context.Context, batchID []byte, newDepth uint8) (common.Hash, error) {
+				return common.Hash{}, postagecontract.ErrNotImplemented
+			},
+		),
+	)
+
+	accessControl := accesscontrol.NewController(accesscontrol.NewLogic(accesscontrol.NewDefaultSession(key)))
+	n.closers = append(n.closers, accessControl)
+
+	apiService := api.New(key.PublicKey, key.PublicKey, ethAddress, nil, logger, nil, c.batchStore, api.FullMode, false, false, nil, nil, inmemstore.New())
+	apiService.Configure(signer, tracer, api.Options{
+		WsPingPeriod: 60 * time.Second,
+	}, api.ExtraOptions{
+		TopologyDriver:  topology,
+		Accounting:      acc,
+		Storer:          localStore,
+		Resolver:        resolverMock.NewResolver(),
+		Pss:             pssService,
+		Gsoc:            gsocService,
+		FeedFactory:     factory.New(localStore.Download(true)),
+		Post:            n.post,
+		AccessControl:   accessControl,
+		PostageContract: postageContract,
+		Steward:         new(mockSteward.Steward),
+		SyncStatus:      func() (bool, error) { return true, nil },
+	}, chainID, nil)
+	apiService.Mount()
+	apiService.EnableFullAPI()
+	apiService.SetSwarmAddress(&overlay)
+	n.closers = append(n.closers, apiService)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("api listener: %w", err)
+	}
+	n.apiURL = "http://" + listener.Addr().String()
+	n.server = &http.Server{
+		IdleTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 3 * time.Second,
+		Handler:           apiService,
+		ErrorLog: stdlog.New(ioutil.WriterFunc(func(p []byte) (int, error) {
+			logger.Debug(string(p))
+			return len(p), nil
+		}), "", 0),
+	}
+	go func() {
+		if err := n.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(err, "api server failed")
+		}
+	}()
+
+	return n, nil
+}
+
+// close stops the API server and the services of the node.
+func (n *Node) close() error {
+	var errs []error
+	if n.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		errs = append(errs, n.server.Shutdown(ctx))
+	}
+	n.ctxClose()
+	for i := len(n.closers) - 1; i >= 0; i-- {
+		errs = append(errs, n.closers[i].Close())
+	}
+	return errors.Join(errs...)
+}
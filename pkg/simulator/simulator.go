@@ -0,0 +1,254 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package simulator runs the cluster of the in-process nodes connected by
+// the virtual network, so that the applications and the CI may test the
+// behaviors spanning multiple nodes without running the docker clusters.
+//
+// Every node has the in-memory stores and it serves the normal API on the
+// local address. The chunks are spread by the push sync and they are fetched
+// by the retrieval protocol over the virtual network, whose latency and loss
+// are set by the link models. The nodes share the batch store standing in
+// for the chain, the postage batches bought through the API of any node are
+// usable on all the nodes. The pull sync between the neighbours, the
+// settlements and the storage incentives are not simulated.
+package simulator
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	mrand "math/rand"
+	"sync"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/postage/batchstore"
+	"github.com/ethersphere/bee/v2/pkg/statestore/leveldb"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "simulator"
+
+const (
+	networkID = 1
+	chainID   = 1
+
+	// defaultReserveCapacity is the reserve capacity of the nodes
+	// in chunks, smaller than the one of the real nodes as the
+	// cluster is kept in memory.
+	defaultReserveCapacity = 1 << 16
+)
+
+// ErrInvalidOptions is returned when the cluster options are malformed.
+var ErrInvalidOptions = errors.New("invalid simulator options")
+
+// Options are the options of the cluster.
+type Options struct {
+	// Nodes is the number of the nodes in the cluster.
+	Nodes int
+	// Link is the default model of the links between the nodes.
+	Link LinkModel
+	// Seed makes the keys of the nodes and the losses and the jitter of the
+	// network deterministic when it is not zero.
+	Seed int64
+	// ReserveCapacity is the reserve capacity of every node in chunks.
+	ReserveCapacity int
+	Logger          log.Logger
+}
+
+// Cluster is the set of the nodes connected by the virtual network.
+type Cluster struct {
+	logger          log.Logger
+	network         *Network
+	batchStore      postage.Storer
+	reserveCapacity int
+	entropy         io.Reader
+	closers         []io.Closer
+
+	mu    sync.Mutex
+	nodes []*Node
+}
+
+// New starts the cluster of the given number of nodes.
+func New(o Options) (_ *Cluster, err error) {
+	if o.Nodes < 1 {
+		return nil, fmt.Errorf("%w: at least one node is required", ErrInvalidOptions)
+	}
+	if o.Logger == nil {
+		o.Logger = log.Noop
+	}
+	if o.ReserveCapacity == 0 {
+		o.ReserveCapacity = defaultReserveCapacity
+	}
+
+	var entropy io.Reader = rand.Reader
+	if o.Seed != 0 {
+		entropy = mrand.New(mrand.NewSource(o.Seed))
+	}
+
+	network, err := NewNetwork(o.Link, o.Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cluster{
+		logger:          o.Logger.WithName(loggerName).Register(),
+		network:         network,
+		reserveCapacity: o.ReserveCapacity,
+		entropy:         entropy,
+	}
+	defer func() {
+		if err != nil {
+			_ = c.Close()
+		}
+	}()
+
+	stateStore, err := leveldb.NewInMemoryStateStore(c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("statestore: %w", err)
+	}
+	c.closers = append(c.closers, stateStore)
+
+	c.batchStore, err = batchstore.New(stateStore, func([]byte) error { return nil }, 1_000_000, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("batchstore: %w", err)
+	}
+	err = c.batchStore.PutChainState(&postage.ChainState{
+		CurrentPrice: big.NewInt(1),
+		TotalAmount:  big.NewInt(1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("batchstore: %w", err)
+	}
+
+	for i := 0; i < o.Nodes; i++ {
+		if _, err := c.AddNode(); err != nil {
+			return nil, fmt.Errorf("node %d: %w", i, err)
+		}
+	}
+
+	return c, nil
+}
+
+// AddNode starts a new node and connects it to the cluster.
+func (c *Cluster) AddNode() (*Node, error) {
+	c.mu.Lock()
+	data := make([]byte, 32)
+	_, err := io.ReadFull(c.entropy, data)
+	c.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("key: %w", err)
+	}
+	key, err := crypto.DecodeSecp256k1PrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("key: %w", err)
+	}
+
+	n, err := newNode(c.logger, c, key)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.nodes = append(c.nodes, n)
+	c.mu.Unlock()
+
+	c.logger.Debug("node started", "overlay", n.Overlay(), "api", n.APIURL())
+	return n, nil
+}
+
+// RemoveNode stops the node and disconnects it from the cluster.
+func (c *Cluster) RemoveNode(n *Node) error {
+	c.mu.Lock()
+	for i, node := range c.nodes {
+		if node == n {
+			c.nodes = append(c.nodes[:i], c.nodes[i+1:]...)
+			break
+		}
+	}
+	c.mu.Unlock()
+
+	c.network.removeNode(n.overlay)
+	return n.close()
+}
+
+// Nodes returns the running nodes of the cluster.
+func (c *Cluster) Nodes() []*Node {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nodes := make([]*Node, len(c.nodes))
+	copy(nodes, c.nodes)
+	return nodes
+}
+
+// Network returns the virtual network connecting the nodes.
+func (c *Cluster) Network() *Network {
+	return c.network
+}
+
+// overlays returns the overlay addresses of the running nodes.
+func (c *Cluster) overlays() []swarm.Address {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	overlays := make([]swarm.Address, len(c.nodes))
+	for i, n := range c.nodes {
+		overlays[i] = n.overlay
+	}
+	return overlays
+}
+
+// CreateBatch creates the postage batch owned by the node, as if it was
+// bought on the chain, and it returns its id. The batch is usable for the
+// uploads through the API of the owner.
+func (c *Cluster) CreateBatch(owner *Node, amount *big.Int, depth uint8, immutable bool, label string) ([]byte, error) {
+	c.mu.Lock()
+	id := make([]byte, 32)
+	_, err := io.ReadFull(c.entropy, id)
+	c.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("batch id: %w", err)
+	}
+
+	ethAddress, err := crypto.NewEthereumAddress(owner.key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("owner: %w", err)
+	}
+
+	batch := &postage.Batch{
+		ID:          id,
+		Owner:       ethAddress,
+		Value:       new(big.Int).Mul(amount, big.NewInt(int64(1)<<depth)),
+		Depth:       depth,
+		BucketDepth: postage.BucketDepth,
+		Immutable:   immutable,
+	}
+	if err := c.batchStore.Save(batch); err != nil {
+		return nil, fmt.Errorf("save batch: %w", err)
+	}
+
+	issuer := postage.NewStampIssuer(label, string(ethAddress), id, amount, depth, postage.BucketDepth, 0, immutable)
+	if err := owner.post.Add(issuer); err != nil {
+		return nil, fmt.Errorf("add stamp issuer: %w", err)
+	}
+	return id, nil
+}
+
+// Close stops all the nodes of the cluster.
+func (c *Cluster) Close() error {
+	var errs []error
+	for _, n := range c.Nodes() {
+		errs = append(errs, c.RemoveNode(n))
+	}
+	for i := len(c.closers) - 1; i >= 0; i-- {
+		errs = append(errs, c.closers[i].Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simulator_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/simulator"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+func newCluster(t *testing.T, o simulator.Options) *simulator.Cluster {
+	t.Helper()
+
+	c, err := simulator.New(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, c)
+	return c
+}
+
+func upload(t *testing.T, n *simulator.Node, batchID []byte, deferred bool, data []byte) string {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, n.APIURL()+"/bytes", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Swarm-Postage-Batch-Id", hex.EncodeToString(batchID))
+	req.Header.Set("Swarm-Deferred-Upload", strconv.FormatBool(deferred))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("upload: got status %d: %s", resp.StatusCode, body)
+	}
+
+	var r struct {
+		Reference string `json:"reference"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		t.Fatal(err)
+	}
+	return r.Reference
+}
+
+func download(t *testing.T, n *simulator.Node, ref string) ([]byte, int) {
+	t.Helper()
+
+	resp, err := http.Get(n.APIURL() + "/bytes/" + ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data, resp.StatusCode
+}
+
+func TestCluster(t *testing.T) {
+	t.Parallel()
+
+	c := newCluster(t, simulator.Options{
+		Nodes: 4,
+		Seed:  1,
+		Link:  simulator.LinkModel{Latency: time.Millisecond},
+	})
+
+	nodes := c.Nodes()
+	if len(nodes) != 4 {
+		t.Fatalf("got %d nodes, want 4", len(nodes))
+	}
+
+	batchID, err := c.CreateBatch(nodes[0], big.NewInt(100_000_000), 20, false, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("swarm simulator "), 1024)
+	ref := upload(t, nodes[0], batchID, false, data)
+
+	for _, n := range nodes[1:] {
+		got, status := download(t, n, ref)
+		if status != http.StatusOK {
+			t.Fatalf("download from %s: got status %d", n.Overlay(), status)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("download from %s: data mismatch", n.Overlay())
+		}
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+		t.Parallel()
+
+		other := newCluster(t, simulator.Options{Nodes: 1, Seed: 1})
+		if got, want := other.Nodes()[0].Overlay(), nodes[0].Overlay(); !got.Equal(want) {
+			t.Fatalf("got overlay %s, want %s", got, want)
+		}
+	})
+}
+
+func TestClusterPartition(t *testing.T) {
+	t.Parallel()
+
+	c := newCluster(t, simulator.Options{Nodes: 2, Seed: 2})
+	nodes := c.Nodes()
+
+	batchID, err := c.CreateBatch(nodes[0], big.NewInt(100_000_000), 20, false, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the deferred upload is kept by the node until it reaches the peers
+	c.Network().Partition(nodes[0].Overlay(), nodes[1].Overlay())
+	data := []byte("partitioned")
+	ref := upload(t, nodes[0], batchID, true, data)
+
+	if _, status := download(t, nodes[1], ref); status == http.StatusOK {
+		t.Fatal("want download failing across the partition")
+	}
+
+	c.Network().Heal(nodes[0].Overlay(), nodes[1].Overlay())
+	got, status := download(t, nodes[1], ref)
+	if status != http.StatusOK {
+		t.Fatalf("got status %d after heal", status)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("data mismatch")
+	}
+}
+
+func TestInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	if _, err := simulator.New(simulator.Options{}); !errors.Is(err, simulator.ErrInvalidOptions) {
+		t.Fatalf("want error %v, got %v", simulator.ErrInvalidOptions, err)
+	}
+	if _, err := simulator.New(simulator.Options{Nodes: 1, Link: simulator.LinkModel{Loss: 2}}); !errors.Is(err, simulator.ErrInvalidLinkModel) {
+		t.Fatalf("want error %v, got %v", simulator.ErrInvalidLinkModel, err)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simulator
+
+import (
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	mockTopology "github.com/ethersphere/bee/v2/pkg/topology/mock"
+)
+
+// meshTopology is the topology of the node connected to all the other
+// nodes of the cluster. Unlike the kademlia, the peers are not limited
+// by the bins, the closest peer is chosen among all the connected nodes.
+type meshTopology struct {
+	topology.Driver
+	base    swarm.Address
+	network *Network
+	peers   func() []swarm.Address
+}
+
+func newMeshTopology(base swarm.Address, network *Network, peers func() []swarm.Address) *meshTopology {
+	return &meshTopology{
+		Driver:  mockTopology.NewTopologyDriver(),
+		base:    base,
+		network: network,
+		peers:   peers,
+	}
+}
+
+// connectedPeers returns the nodes reachable from the base.
+func (t *meshTopology) connectedPeers() []swarm.Address {
+	var peers []swarm.Address
+	for _, p := range t.peers() {
+		if !p.Equal(t.base) && t.network.connected(t.base, p) {
+			peers = append(peers, p)
+		}
+	}
+	return peers
+}
+
+// ClosestPeer implements the topology.ClosestPeerer interface.
+func (t *meshTopology) ClosestPeer(addr swarm.Address, includeSelf bool, _ topology.Select, skipPeers ...swarm.Address) (swarm.Address, error) {
+	closest := swarm.ZeroAddress
+	for _, p := range t.connectedPeers() {
+		if swarm.ContainsAddress(skipPeers, p) {
+			continue
+		}
+		if closest.IsZero() {
+			closest = p
+			continue
+		}
+		if closer, _ := p.Closer(addr, closest); closer {
+			closest = p
+		}
+	}
+
+	if includeSelf {
+		if closest.IsZero() {
+			return swarm.ZeroAddress, topology.ErrWantSelf
+		}
+		if closer, _ := t.base.Closer(addr, closest); closer {
+			return swarm.ZeroAddress, topology.ErrWantSelf
+		}
+	}
+	if closest.IsZero() {
+		return swarm.ZeroAddress, topology.ErrNotFound
+	}
+	return closest, nil
+}
+
+// EachConnectedPeer implements the topology.PeerIterator interface.
+func (t *meshTopology) EachConnectedPeer(f topology.EachPeerFunc, _ topology.Select) error {
+	for _, p := range t.connectedPeers() {
+		stop, _, err := f(p, swarm.Proximity(t.base.Bytes(), p.Bytes()))
+		if err != nil {
+			return err
+		}
+		if stop {
+			return nil
+		}
+	}
+	return nil
+}
+
+// EachConnectedPeerRev implements the topology.PeerIterator interface.
+func (t *meshTopology) EachConnectedPeerRev(f topology.EachPeerFunc, _ topology.Select) error {
+	peers := t.connectedPeers()
+	for i := len(peers) - 1; i >= 0; i-- {
+		stop, _, err := f(peers[i], swarm.Proximity(t.base.Bytes(), peers[i].Bytes()))
+		if err != nil {
+			return err
+		}
+		if stop {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ethersphere/bee/v2/pkg/encryption"
+	"github.com/ethersphere/bee/v2/pkg/file"
+	"github.com/ethersphere/bee/v2/pkg/file/cdc"
+	"github.com/ethersphere/bee/v2/pkg/file/joiner"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// sizeLength is the length of the size of the segment in the segment list.
+const sizeLength = 8
+
+// ErrInvalidSegments is returned when the segment list is malformed.
+var ErrInvalidSegments = errors.New("invalid segment list")
+
+// Segment is the content-defined part of the file uploaded on its own.
+type Segment struct {
+	Reference swarm.Address
+	Size      int64
+}
+
+// MarshalSegments encodes the segment list. The list starts with the length
+// of the references followed by the size and the reference of every segment.
+func MarshalSegments(segments []Segment) ([]byte, error) {
+	refLength := swarm.HashSize
+	if len(segments) > 0 {
+		refLength = len(segments[0].Reference.Bytes())
+	}
+	if refLength != swarm.HashSize && refLength != encryption.ReferenceSize {
+		return nil, fmt.Errorf("%w: reference length %d", ErrInvalidSegments, refLength)
+	}
+	buf := make([]byte, 1, 1+len(segments)*(sizeLength+refLength))
+	buf[0] = byte(refLength)
+	for i, s := range segments {
+		if len(s.Reference.Bytes()) != refLength || s.Size < 0 {
+			return nil, fmt.Errorf("%w: segment %d", ErrInvalidSegments, i)
+		}
+		buf = binary.BigEndian.AppendUint64(buf, uint64(s.Size))
+		buf = append(buf, s.Reference.Bytes()...)
+	}
+	return buf, nil
+}
+
+// UnmarshalSegments decodes the segment list.
+func UnmarshalSegments(data []byte) ([]Segment, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidSegments
+	}
+	refLength := int(data[0])
+	if refLength != swarm.HashSize && refLength != encryption.ReferenceSize {
+		return nil, fmt.Errorf("%w: reference length %d", ErrInvalidSegments, refLength)
+	}
+	data = data[1:]
+	if len(data)%(sizeLength+refLength) != 0 {
+		return nil, fmt.Errorf("%w: length %d", ErrInvalidSegments, len(data))
+	}
+	segments := make([]Segment, 0, len(data)/(sizeLength+refLength))
+	for ; len(data) > 0; data = data[sizeLength+refLength:] {
+		size := binary.BigEndian.Uint64(data)
+		if size > 1<<62 {
+			return nil, fmt.Errorf("%w: size %d", ErrInvalidSegments, size)
+		}
+		segments = append(segments, Segment{
+			Reference: swarm.NewAddress(bytes.Clone(data[sizeLength : sizeLength+refLength])),
+			Size:      int64(size),
+		})
+	}
+	return segments, nil
+}
+
+// Upload splits the data of the reader into the content-defined segments,
+// uploads every segment and the segment list with the new pipelines.
+// It returns the reference of the segment list and the segments.
+func Upload(ctx context.Context, newPipeline func() pipeline.Interface, r io.Reader, o cdc.Options) (swarm.Address, []Segment, error) {
+	chunker, err := cdc.New(r, o)
+	if err != nil {
+		return swarm.ZeroAddress, nil, err
+	}
+	var segments []Segment
+	for {
+		data, err := chunker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return swarm.ZeroAddress, nil, err
+		}
+		ref, err := builder.FeedPipeline(ctx, newPipeline(), bytes.NewReader(data))
+		if err != nil {
+			return swarm.ZeroAddress, nil, fmt.Errorf("upload segment %d: %w", len(segments), err)
+		}
+		segments = append(segments, Segment{Reference: ref, Size: int64(len(data))})
+	}
+
+	list, err := MarshalSegments(segments)
+	if err != nil {
+		return swarm.ZeroAddress, nil, err
+	}
+	ref, err := builder.FeedPipeline(ctx, newPipeline(), bytes.NewReader(list))
+	if err != nil {
+		return swarm.ZeroAddress, nil, fmt.Errorf("upload segment list: %w", err)
+	}
+	return ref, segments, nil
+}
+
+// LoadSegments downloads the segment list of the reference.
+func LoadSegments(ctx context.Context, getter storage.Getter, cache storage.Putter, ref swarm.Address) ([]Segment, error) {
+	j, _, err := joiner.New(ctx, getter, cache, ref, redundancy.DefaultLevel)
+	if err != nil {
+		return nil, fmt.Errorf("segment list: %w", err)
+	}
+	data, err := io.ReadAll(j)
+	if err != nil {
+		return nil, fmt.Errorf("read segment list: %w", err)
+	}
+	return UnmarshalSegments(data)
+}
+
+// Reader reads the file from its segments.
+type Reader struct {
+	ctx      context.Context
+	getter   storage.Getter
+	cache    storage.Putter
+	segments []Segment
+	offsets  []int64 // of the segments in the file
+	size     int64
+	pos      int64
+
+	current int // index of the open segment
+	j       file.Joiner
+}
+
+// NewReader returns the reader of the file of the segment list reference.
+func NewReader(ctx context.Context, getter storage.Getter, cache storage.Putter, ref swarm.Address) (*Reader, error) {
+	segments, err := LoadSegments(ctx, getter, cache, ref)
+	if err != nil {
+		return nil, err
+	}
+	r := &Reader{
+		ctx:      ctx,
+		getter:   getter,
+		cache:    cache,
+		segments: segments,
+		offsets:  make([]int64, len(segments)),
+		current:  -1,
+	}
+	for i, s := range segments {
+		r.offsets[i] = r.size
+		r.size += s.Size
+	}
+	return r, nil
+}
+
+// Size returns the size of the file.
+func (r *Reader) Size() int64 {
+	return r.size
+}
+
+// Read implements the io.Reader interface.
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.pos >= r.size {
+		return 0, io.EOF
+	}
+	i := sort.Search(len(r.offsets), func(i int) bool { return r.offsets[i] > r.pos }) - 1
+	if i != r.current {
+		j, _, err := joiner.New(r.ctx, r.getter, r.cache, r.segments[i].Reference, redundancy.DefaultLevel)
+		if err != nil {
+			return 0, fmt.Errorf("segment %d: %w", i, err)
+		}
+		r.j, r.current = j, i
+	}
+	if rest := r.offsets[i] + r.segments[i].Size - r.pos; int64(len(p)) > rest {
+		p = p[:rest]
+	}
+	// the joiner reads up to the capacity of the buffer
+	n, err := r.j.ReadAt(p[:len(p):len(p)], r.pos-r.offsets[i])
+	r.pos += int64(n)
+	if errors.Is(err, io.EOF) && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+// Seek implements the io.Seeker interface.
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("seek: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("seek: negative position")
+	}
+	r.pos = offset
+	return offset, nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package snapshot implements the manifests of the file tree backups.
+//
+// The files are split into the content-defined segments which are uploaded
+// on their own, so the unchanged parts of the changed files are deduplicated
+// between the backups. The manifest entry of the file references its segment
+// list and keeps the file metadata: the mode, the modification time and the
+// ownership. The directories are the entries of the paths ending with the
+// slash. The next snapshot is based on the previous one, only the changed
+// entries are put and the removed ones are removed.
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/file"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// The metadata keys of the snapshot entries, next to the size and the
+// modification time keys of the manifestfs package.
+const (
+	MetadataModeKey  = "Mode"
+	MetadataUIDKey   = "Uid"
+	MetadataGIDKey   = "Gid"
+	MetadataOwnerKey = "Owner"
+	MetadataGroupKey = "Group"
+	MetadataLinkKey  = "Link"
+)
+
+// separator is the separator of the path elements.
+const separator = "/"
+
+var (
+	// ErrNotFound is returned when the path is not in the snapshot.
+	ErrNotFound = errors.New("snapshot: not found")
+	// ErrInvalidEntry is returned when the entry is not consistent.
+	ErrInvalidEntry = errors.New("snapshot: invalid entry")
+)
+
+// Entry is the file, the directory or the symbolic link of the snapshot.
+type Entry struct {
+	// Path is relative to the root, the directory paths end with the slash.
+	Path string
+	// Mode is the unix mode with the file type bits.
+	Mode    uint32
+	ModTime time.Time
+	UID     uint32
+	GID     uint32
+	Owner   string
+	Group   string
+	// Size is the size of the file.
+	Size int64
+	// Link is the target of the symbolic link.
+	Link string
+	// Reference is the segment list of the file.
+	Reference swarm.Address
+}
+
+// IsDir reports whether the entry is the directory.
+func (e Entry) IsDir() bool {
+	return strings.HasSuffix(e.Path, separator)
+}
+
+func (e Entry) validate() error {
+	switch {
+	case e.Path == "" || e.Path == separator || strings.HasPrefix(e.Path, separator):
+		return fmt.Errorf("%w: path %q", ErrInvalidEntry, e.Path)
+	case e.Size < 0:
+		return fmt.Errorf("%w: %s size %d", ErrInvalidEntry, e.Path, e.Size)
+	case e.IsDir() || e.Link != "":
+		if !e.Reference.IsZero() {
+			return fmt.Errorf("%w: %s has reference", ErrInvalidEntry, e.Path)
+		}
+	case e.Reference.IsZero():
+		return fmt.Errorf("%w: %s has no reference", ErrInvalidEntry, e.Path)
+	}
+	return nil
+}
+
+func (e Entry) metadata() map[string]string {
+	metadata := map[string]string{
+		MetadataModeKey:                strconv.FormatUint(uint64(e.Mode), 8),
+		manifestfs.MetadataModifiedKey: e.ModTime.UTC().Format(time.RFC3339Nano),
+		MetadataUIDKey:                 strconv.FormatUint(uint64(e.UID), 10),
+		MetadataGIDKey:                 strconv.FormatUint(uint64(e.GID), 10),
+	}
+	if !e.IsDir() && e.Link == "" {
+		metadata[manifestfs.MetadataSizeKey] = strconv.FormatInt(e.Size, 10)
+	}
+	for k, v := range map[string]string{
+		MetadataOwnerKey: e.Owner,
+		MetadataGroupKey: e.Group,
+		MetadataLinkKey:  e.Link,
+	} {
+		if v != "" {
+			metadata[k] = v
+		}
+	}
+	return metadata
+}
+
+func newEntry(path string, me manifest.Entry) Entry {
+	metadata := me.Metadata()
+	mode, _ := strconv.ParseUint(metadata[MetadataModeKey], 8, 32)
+	modTime, _ := time.Parse(time.RFC3339Nano, metadata[manifestfs.MetadataModifiedKey])
+	uid, _ := strconv.ParseUint(metadata[MetadataUIDKey], 10, 32)
+	gid, _ := strconv.ParseUint(metadata[MetadataGIDKey], 10, 32)
+	size, _ := strconv.ParseInt(metadata[manifestfs.MetadataSizeKey], 10, 64)
+	e := Entry{
+		Path:    path,
+		Mode:    uint32(mode),
+		ModTime: modTime,
+		UID:     uint32(uid),
+		GID:     uint32(gid),
+		Owner:   metadata[MetadataOwnerKey],
+		Group:   metadata[MetadataGroupKey],
+		Size:    size,
+		Link:    metadata[MetadataLinkKey],
+	}
+	if ref := me.Reference(); !ref.Equal(manifestfs.EmptyReference) {
+		e.Reference = ref
+	}
+	return e
+}
+
+// Snapshot is the manifest of the backup of the file tree.
+type Snapshot struct {
+	m *manifestfs.Manifest
+}
+
+// New returns the snapshot based on the snapshot of the base reference,
+// or the empty one when the base is zero.
+func New(ls file.LoadSaver, base swarm.Address) (*Snapshot, error) {
+	m, err := manifestfs.LoadManifest(base, ls)
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{m: m}, nil
+}
+
+// Put adds or replaces the entry.
+func (s *Snapshot) Put(ctx context.Context, e Entry) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+	ref := e.Reference
+	if ref.IsZero() {
+		ref = manifestfs.EmptyReference
+	}
+	if err := s.m.Add(ctx, e.Path, manifest.NewEntry(ref, e.metadata())); err != nil {
+		return fmt.Errorf("put %s: %w", e.Path, err)
+	}
+	return nil
+}
+
+// Remove removes the entry of the path, the directory
+// is removed with all its entries.
+func (s *Snapshot) Remove(ctx context.Context, path string) error {
+	var paths []string
+	collect := func(p string, _ manifest.Entry) error {
+		if p == path || strings.HasSuffix(path, separator) {
+			paths = append(paths, p)
+		}
+		return nil
+	}
+	if err := s.m.WalkPrefix(ctx, path, collect); err != nil {
+		if errors.Is(err, manifest.ErrNotFound) {
+			return fmt.Errorf("%s: %w", path, ErrNotFound)
+		}
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("%s: %w", path, ErrNotFound)
+	}
+	// the paths are removed in the reverse order, so none of them
+	// prefixes the ones still in the manifest
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	for _, p := range paths {
+		if err := s.m.Remove(ctx, p); err != nil {
+			return fmt.Errorf("remove %s: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the entry of the path.
+func (s *Snapshot) Lookup(ctx context.Context, path string) (Entry, error) {
+	me, err := s.m.Lookup(ctx, path)
+	if err != nil {
+		if errors.Is(err, manifest.ErrNotFound) {
+			return Entry{}, fmt.Errorf("%s: %w", path, ErrNotFound)
+		}
+		return Entry{}, fmt.Errorf("lookup %s: %w", path, err)
+	}
+	return newEntry(path, me), nil
+}
+
+// Walk calls fn for the entries in the lexicographic order of their paths.
+func (s *Snapshot) Walk(ctx context.Context, fn func(Entry) error) error {
+	return s.m.Walk(ctx, func(path string, me manifest.Entry) error {
+		return fn(newEntry(path, me))
+	})
+}
+
+// Save stores the snapshot and returns its reference.
+func (s *Snapshot) Save(ctx context.Context) (swarm.Address, error) {
+	ref, err := s.m.Store(ctx)
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("store snapshot: %w", err)
+	}
+	return ref, nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package snapshot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/file/cdc"
+	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/snapshot"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+var testOptions = cdc.Options{MinSize: 4096, AvgSize: 8192, MaxSize: 32768}
+
+func TestSegments(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := inmemchunkstore.New()
+	newPipeline := func() pipeline.Interface {
+		return builder.NewPipelineBuilder(ctx, store, false, redundancy.NONE)
+	}
+
+	data := make([]byte, 200000)
+	rand.New(rand.NewSource(1)).Read(data)
+	ref, segments, err := snapshot.Upload(ctx, newPipeline, bytes.NewReader(data), testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) < 2 {
+		t.Fatalf("got %d segments", len(segments))
+	}
+
+	r, err := snapshot.NewReader(ctx, store, nil, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Size() != int64(len(data)) {
+		t.Fatalf("got size %d, want %d", r.Size(), len(data))
+	}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("read data differs")
+	}
+	if _, err := r.Seek(-10, io.SeekEnd); err != nil {
+		t.Fatal(err)
+	}
+	got, err = io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data[len(data)-10:]) {
+		t.Fatal("read data of the end differs")
+	}
+
+	// the changed file shares most of the segments
+	changed := append(bytes.Clone(data[:100]), data[150:]...)
+	_, changedSegments, err := snapshot.Upload(ctx, newPipeline, bytes.NewReader(changed), testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[string]bool)
+	for _, s := range segments {
+		seen[s.Reference.ByteString()] = true
+	}
+	var same int
+	for _, s := range changedSegments {
+		if seen[s.Reference.ByteString()] {
+			same++
+		}
+	}
+	if same < len(segments)-2 {
+		t.Fatalf("got %d of %d same segments", same, len(segments))
+	}
+
+	if _, err := snapshot.UnmarshalSegments([]byte{32, 1, 2}); !errors.Is(err, snapshot.ErrInvalidSegments) {
+		t.Fatalf("got error %v, want %v", err, snapshot.ErrInvalidSegments)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := inmemchunkstore.New()
+	ls := loadsave.New(store, store, func() pipeline.Interface {
+		return builder.NewPipelineBuilder(ctx, store, false, redundancy.NONE)
+	}, redundancy.DefaultLevel)
+
+	ref := swarm.RandAddress(t)
+	modTime := time.Date(2024, 5, 1, 10, 20, 30, 123456789, time.UTC)
+	entries := []snapshot.Entry{
+		{Path: "docs/", Mode: 040755, ModTime: modTime, UID: 1000, GID: 1000, Owner: "alice", Group: "staff"},
+		{Path: "docs/a.txt", Mode: 0100644, ModTime: modTime, UID: 1000, GID: 1000, Size: 5, Reference: ref},
+		{Path: "docs/a.txt~", Mode: 0100600, ModTime: modTime, Size: 5, Reference: ref},
+		{Path: "link", Mode: 0120777, ModTime: modTime, Link: "docs/a.txt"},
+	}
+
+	s, err := snapshot.New(ls, swarm.ZeroAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if err := s.Put(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Put(ctx, snapshot.Entry{Path: "file"}); !errors.Is(err, snapshot.ErrInvalidEntry) {
+		t.Fatalf("got error %v, want %v", err, snapshot.ErrInvalidEntry)
+	}
+	base, err := s.Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = snapshot.New(ls, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []snapshot.Entry
+	if err := s.Walk(ctx, func(e snapshot.Entry) error {
+		got = append(got, e)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(entries) {
+		t.Fatalf("got %d entries, want %d", len(got), len(entries))
+	}
+	for i, e := range entries {
+		if !got[i].ModTime.Equal(e.ModTime) {
+			t.Fatalf("entry %s: got time %v, want %v", e.Path, got[i].ModTime, e.ModTime)
+		}
+		got[i].ModTime = e.ModTime
+		if got[i].Path != e.Path || got[i].Mode != e.Mode || got[i].Owner != e.Owner ||
+			got[i].UID != e.UID || got[i].Size != e.Size || got[i].Link != e.Link || !got[i].Reference.Equal(e.Reference) {
+			t.Fatalf("got entry %+v, want %+v", got[i], e)
+		}
+	}
+
+	// the incremental snapshot removes the file and the directory
+	if err := s.Remove(ctx, "docs/a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Lookup(ctx, "docs/a.txt~"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove(ctx, "docs/"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove(ctx, "docs/"); !errors.Is(err, snapshot.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, snapshot.ErrNotFound)
+	}
+	var paths []string
+	if err := s.Walk(ctx, func(e snapshot.Entry) error {
+		paths = append(paths, e.Path)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(paths, ","); got != "link" {
+		t.Fatalf("got paths %q", got)
+	}
+	if _, err := s.Save(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
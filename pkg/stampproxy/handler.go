@@ -0,0 +1,68 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stampproxy
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	batchIDHeader       = "Swarm-Postage-Batch-Id"
+	stampHeader         = "Swarm-Postage-Stamp"
+)
+
+// uploadPaths are the api endpoints the clients may upload with.
+var uploadPaths = map[string]struct{}{
+	"/bytes":  {},
+	"/bzz":    {},
+	"/chunks": {},
+}
+
+// Handler returns the handler passing the uploads of the authenticated
+// clients to the next handler, the node api, with the batch of the client.
+// The batch headers of the requests are replaced and all the other
+// endpoints are not found.
+func (s *Service) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := uploadPaths[r.URL.Path]; !ok {
+			jsonhttp.NotFound(w, nil)
+			return
+		}
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			jsonhttp.MethodNotAllowed(w, nil)
+			return
+		}
+
+		token, ok := bearerToken(r.Header.Get(authorizationHeader))
+		if !ok {
+			jsonhttp.Unauthorized(w, "missing bearer token")
+			return
+		}
+		c, err := s.Authenticate(token)
+		if err != nil {
+			if !errors.Is(err, ErrUnauthorized) {
+				s.logger.Debug("authenticate client failed", "error", err)
+			}
+			jsonhttp.Unauthorized(w, "invalid bearer token")
+			return
+		}
+		if remaining, limited := c.Remaining(); limited && remaining == 0 {
+			jsonhttp.PaymentRequired(w, ErrLimitExceeded.Error())
+			return
+		}
+
+		r = r.Clone(WithClientID(r.Context(), c.ID))
+		r.Header.Del(authorizationHeader)
+		r.Header.Del(stampHeader)
+		r.Header.Set(batchIDHeader, hex.EncodeToString(c.BatchID))
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,323 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package stampproxy lets the node stamp the uploads of the trusted
+// sub-clients with the postage batches of the node.
+//
+// Every client is registered with the batch its uploads are stamped with and
+// the optional limit of the stamped chunks. The clients authenticate with the
+// bearer token issued at the registration, only the hash of the token is
+// stored. The number of the chunks stamped on behalf of the client is tracked
+// in the state store.
+package stampproxy
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "stampproxy"
+
+const (
+	keyPrefix   = "stampproxy_client_"
+	idLength    = 8
+	tokenLength = 32
+)
+
+var (
+	// ErrNotFound is returned when the client is not registered.
+	ErrNotFound = errors.New("client not found")
+	// ErrUnauthorized is returned when the token does not belong to any client.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrLimitExceeded is returned when the client has stamped all the chunks
+	// it is allowed to. It wraps postage.ErrBucketFull, so that the uploads
+	// fail the same way as with the full batch.
+	ErrLimitExceeded = fmt.Errorf("stamping limit exceeded: %w", postage.ErrBucketFull)
+	// ErrInvalidBatchID is returned when the batch id is not a swarm hash.
+	ErrInvalidBatchID = errors.New("invalid batch id")
+)
+
+// Client is the sub-client the node stamps the uploads for.
+type Client struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	BatchID []byte `json:"batchID"`
+	// Limit is the maximum number of the stamped chunks, zero for no limit.
+	Limit uint64 `json:"limit"`
+	// Used is the number of the chunks stamped on behalf of the client.
+	Used      uint64    `json:"used"`
+	Created   time.Time `json:"created"`
+	TokenHash []byte    `json:"tokenHash"`
+}
+
+// Remaining returns the number of the chunks the client may still stamp
+// and false if there is no limit.
+func (c Client) Remaining() (uint64, bool) {
+	if c.Limit == 0 {
+		return 0, false
+	}
+	if c.Used >= c.Limit {
+		return 0, true
+	}
+	return c.Limit - c.Used, true
+}
+
+// Service manages the clients and their consumption.
+type Service struct {
+	store  storage.StateStorer
+	logger log.Logger
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// New returns the service with the clients loaded from the state store.
+func New(store storage.StateStorer, logger log.Logger) (*Service, error) {
+	s := &Service{
+		store:   store,
+		logger:  logger.WithName(loggerName).Register(),
+		clients: make(map[string]*Client),
+	}
+	err := store.Iterate(keyPrefix, func(key, _ []byte) (bool, error) {
+		c := new(Client)
+		if err := store.Get(string(key), c); err != nil {
+			return true, fmt.Errorf("get client %s: %w", key, err)
+		}
+		s.clients[c.ID] = c
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load clients: %w", err)
+	}
+	return s, nil
+}
+
+// Register registers the new client stamping with the batch and returns
+// the client with the token it authenticates with.
+func (s *Service) Register(name string, batchID []byte, limit uint64) (Client, string, error) {
+	if len(batchID) != swarm.HashSize {
+		return Client{}, "", ErrInvalidBatchID
+	}
+
+	id, err := randomHex(idLength)
+	if err != nil {
+		return Client{}, "", fmt.Errorf("client id: %w", err)
+	}
+	token, err := randomHex(tokenLength)
+	if err != nil {
+		return Client{}, "", fmt.Errorf("client token: %w", err)
+	}
+
+	c := &Client{
+		ID:        id,
+		Name:      name,
+		BatchID:   batchID,
+		Limit:     limit,
+		Created:   time.Now().UTC(),
+		TokenHash: hashToken(token),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.store.Put(keyPrefix+c.ID, c); err != nil {
+		return Client{}, "", fmt.Errorf("store client: %w", err)
+	}
+	s.clients[c.ID] = c
+	s.logger.Info("stamping client registered", "id", c.ID, "name", name, "batch_id", hex.EncodeToString(batchID))
+	return *c, token, nil
+}
+
+// Update changes the batch and the limit of the client.
+func (s *Service) Update(id string, batchID []byte, limit uint64) (Client, error) {
+	if len(batchID) != swarm.HashSize {
+		return Client{}, ErrInvalidBatchID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.clients[id]
+	if !ok {
+		return Client{}, ErrNotFound
+	}
+	updated := *c
+	updated.BatchID = batchID
+	updated.Limit = limit
+	if err := s.store.Put(keyPrefix+id, &updated); err != nil {
+		return Client{}, fmt.Errorf("store client: %w", err)
+	}
+	*c = updated
+	return updated, nil
+}
+
+// Remove removes the client, its token is no longer accepted.
+func (s *Service) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.clients[id]; !ok {
+		return ErrNotFound
+	}
+	if err := s.store.Delete(keyPrefix + id); err != nil {
+		return fmt.Errorf("delete client: %w", err)
+	}
+	delete(s.clients, id)
+	return nil
+}
+
+// Client returns the client with the id.
+func (s *Service) Client(id string) (Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.clients[id]
+	if !ok {
+		return Client{}, ErrNotFound
+	}
+	return *c, nil
+}
+
+// Clients returns all the registered clients.
+func (s *Service) Clients() []Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clients := make([]Client, 0, len(s.clients))
+	for _, c := range s.clients {
+		clients = append(clients, *c)
+	}
+	return clients
+}
+
+// Authenticate returns the client the token belongs to.
+func (s *Service) Authenticate(token string) (Client, error) {
+	h := hashToken(token)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.clients {
+		if subtle.ConstantTimeCompare(c.TokenHash, h) == 1 {
+			return *c, nil
+		}
+	}
+	return Client{}, ErrUnauthorized
+}
+
+// Stamper returns the stamper counting the chunks stamped on behalf of
+// the client and failing with ErrLimitExceeded once the limit is reached.
+// The returned save function persists the consumption of the client.
+func (s *Service) Stamper(id string, stamper postage.Stamper) (postage.Stamper, func() error) {
+	return &clientStamper{Stamper: stamper, service: s, id: id}, func() error { return s.save(id) }
+}
+
+// consume counts the chunk to be stamped if the client is within its limit.
+func (s *Service) consume(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.clients[id]
+	if !ok {
+		return ErrUnauthorized
+	}
+	if c.Limit > 0 && c.Used >= c.Limit {
+		return ErrLimitExceeded
+	}
+	c.Used++
+	return nil
+}
+
+// refund uncounts the chunk which failed to be stamped.
+func (s *Service) refund(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.clients[id]; ok && c.Used > 0 {
+		c.Used--
+	}
+}
+
+// save persists the consumption of the client.
+func (s *Service) save(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.clients[id]
+	if !ok {
+		// the client was removed during the upload
+		return nil
+	}
+	if err := s.store.Put(keyPrefix+id, c); err != nil {
+		return fmt.Errorf("store client: %w", err)
+	}
+	return nil
+}
+
+type clientStamper struct {
+	postage.Stamper
+	service *Service
+	id      string
+}
+
+func (st *clientStamper) Stamp(addr, idAddr swarm.Address) (*postage.Stamp, error) {
+	if err := st.service.consume(st.id); err != nil {
+		return nil, err
+	}
+	stamp, err := st.Stamper.Stamp(addr, idAddr)
+	if err != nil {
+		st.service.refund(st.id)
+		return nil, err
+	}
+	return stamp, nil
+}
+
+type clientIDKey struct{}
+
+// WithClientID returns the context of the upload stamped on behalf of the client.
+func WithClientID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, clientIDKey{}, id)
+}
+
+// ClientID returns the id of the client the upload is stamped for, if any.
+func ClientID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(clientIDKey{}).(string)
+	return id, ok
+}
+
+// bearerToken returns the token of the bearer authorization header value.
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+func hashToken(token string) []byte {
+	h := sha256.Sum256([]byte(token))
+	return h[:]
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stampproxy_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	postagemock "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/stampproxy"
+	statestore "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	store := statestore.NewStateStore()
+	s, err := stampproxy.New(store, log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batchID := swarm.RandAddress(t).Bytes()
+	c, token, err := s.Register("alice", batchID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Authenticate(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != c.ID || got.Name != "alice" {
+		t.Fatalf("got client %+v, want %+v", got, c)
+	}
+	if _, err := s.Authenticate("invalid"); !errors.Is(err, stampproxy.ErrUnauthorized) {
+		t.Fatalf("got error %v, want %v", err, stampproxy.ErrUnauthorized)
+	}
+	if _, _, err := s.Register("bob", []byte{1}, 0); !errors.Is(err, stampproxy.ErrInvalidBatchID) {
+		t.Fatalf("got error %v, want %v", err, stampproxy.ErrInvalidBatchID)
+	}
+
+	// the clients are loaded from the state store
+	s, err = stampproxy.New(store, log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Authenticate(token); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Remove(c.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Authenticate(token); !errors.Is(err, stampproxy.ErrUnauthorized) {
+		t.Fatalf("got error %v, want %v", err, stampproxy.ErrUnauthorized)
+	}
+	if err := s.Remove(c.ID); !errors.Is(err, stampproxy.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, stampproxy.ErrNotFound)
+	}
+}
+
+func TestStamperLimit(t *testing.T) {
+	t.Parallel()
+
+	store := statestore.NewStateStore()
+	s, err := stampproxy.New(store, log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _, err := s.Register("alice", swarm.RandAddress(t).Bytes(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stamper, save := s.Stamper(c.ID, postagemock.NewStamper())
+	for range 2 {
+		if _, err := stamper.Stamp(swarm.RandAddress(t), swarm.RandAddress(t)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = stamper.Stamp(swarm.RandAddress(t), swarm.RandAddress(t))
+	if !errors.Is(err, stampproxy.ErrLimitExceeded) || !errors.Is(err, postage.ErrBucketFull) {
+		t.Fatalf("got error %v, want %v", err, stampproxy.ErrLimitExceeded)
+	}
+	if err := save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the consumption is persisted
+	s, err = stampproxy.New(store, log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Client(c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Used != 2 {
+		t.Fatalf("got %d used chunks, want 2", got.Used)
+	}
+	if remaining, limited := got.Remaining(); !limited || remaining != 0 {
+		t.Fatalf("got remaining %d %t, want 0 true", remaining, limited)
+	}
+
+	// raising the limit allows stamping again
+	if _, err := s.Update(c.ID, c.BatchID, 3); err != nil {
+		t.Fatal(err)
+	}
+	stamper, _ = s.Stamper(c.ID, postagemock.NewStamper())
+	if _, err := stamper.Stamp(swarm.RandAddress(t), swarm.RandAddress(t)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	s, err := stampproxy.New(statestore.NewStateStore(), log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batchID := swarm.RandAddress(t).Bytes()
+	c, token, err := s.Register("alice", batchID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, exhaustedToken, err := s.Register("bob", batchID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exhausted, err := s.Authenticate(exhaustedToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stamper, _ := s.Stamper(exhausted.ID, postagemock.NewStamper())
+	if _, err := stamper.Stamp(swarm.RandAddress(t), swarm.RandAddress(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		gotClientID string
+		gotHeader   http.Header
+	)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotClientID, _ = stampproxy.ClientID(r.Context())
+		gotHeader = r.Header
+		w.WriteHeader(http.StatusCreated)
+	})
+	h := s.Handler(next)
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		{name: "upload", method: http.MethodPost, path: "/bytes", token: token, want: http.StatusCreated},
+		{name: "other endpoint", method: http.MethodPost, path: "/stamps", token: token, want: http.StatusNotFound},
+		{name: "download", method: http.MethodGet, path: "/bytes", token: token, want: http.StatusMethodNotAllowed},
+		{name: "missing token", method: http.MethodPost, path: "/bzz", want: http.StatusUnauthorized},
+		{name: "invalid token", method: http.MethodPost, path: "/bzz", token: "invalid", want: http.StatusUnauthorized},
+		{name: "limit exceeded", method: http.MethodPost, path: "/chunks", token: exhaustedToken, want: http.StatusPaymentRequired},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			gotClientID, gotHeader = "", nil
+
+			r := httptest.NewRequest(tc.method, tc.path, nil)
+			r.Header.Set("Swarm-Postage-Batch-Id", hex.EncodeToString(swarm.RandAddress(t).Bytes()))
+			r.Header.Set("Swarm-Postage-Stamp", "stamp")
+			if tc.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tc.want {
+				t.Fatalf("got status %d, want %d", w.Code, tc.want)
+			}
+			if tc.want != http.StatusCreated {
+				if gotHeader != nil {
+					t.Fatal("request passed to the next handler")
+				}
+				return
+			}
+			if gotClientID != c.ID {
+				t.Fatalf("got client id %q, want %q", gotClientID, c.ID)
+			}
+			if got, want := gotHeader.Get("Swarm-Postage-Batch-Id"), hex.EncodeToString(batchID); got != want {
+				t.Fatalf("got batch id %q, want %q", got, want)
+			}
+			if gotHeader.Get("Swarm-Postage-Stamp") != "" || gotHeader.Get("Authorization") != "" {
+				t.Fatal("stamp and authorization headers passed to the next handler")
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ func allSteps(st storage.Store) migration.Steps {
 		6: deletePrefix(st, puller.IntervalPrefix),
 		7: deletePrefix(st, puller.IntervalPrefix),
 		8: deletePrefix(st, puller.IntervalPrefix),
+		9: migrateIntervals(st),
 	}
 }
 
@@ -34,6 +35,13 @@ func deletePrefix(s storage.Store, prefix string) migration.StepFn {
 	}
 }
 
+// migrateIntervals converts the pull sync intervals into the per peer records.
+func migrateIntervals(s storage.Store) migration.StepFn {
+	return func() error {
+		return puller.MigrateIntervals(&StateStorerAdapter{s})
+	}
+}
+
 func epochMigration(s storage.Store) migration.StepFn {
 
 	return func() error {
@@ -76,6 +76,9 @@ type Snapshot struct {
 	LastSyncedBlock         uint64            `protobuf:"varint,10,opt,name=LastSyncedBlock,proto3" json:"LastSyncedBlock,omitempty"`
 	CommittedDepth          uint32            `protobuf:"varint,11,opt,name=CommittedDepth,proto3" json:"CommittedDepth,omitempty"`
 	Metrics                 map[string]string `protobuf:"bytes,12,rep,name=Metrics,proto3" json:"Metrics,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ReserveCapacity         uint64            `protobuf:"varint,13,opt,name=ReserveCapacity,proto3" json:"ReserveCapacity,omitempty"`
+	PriceTable              []uint64          `protobuf:"varint,14,rep,packed,name=PriceTable,proto3" json:"PriceTable,omitempty"`
+	PartialReserve          bool              `protobuf:"varint,15,opt,name=PartialReserve,proto3" json:"PartialReserve,omitempty"`
 }
 
 func (m *Snapshot) Reset()         { *m = Snapshot{} }
@@ -195,6 +198,27 @@ func (m *Snapshot) GetMetrics() map[string]string {
 	return nil
 }
 
+func (m *Snapshot) GetReserveCapacity() uint64 {
+	if m != nil {
+		return m.ReserveCapacity
+	}
+	return 0
+}
+
+func (m *Snapshot) GetPriceTable() []uint64 {
+	if m != nil {
+		return m.PriceTable
+	}
+	return nil
+}
+
+func (m *Snapshot) GetPartialReserve() bool {
+	if m != nil {
+		return m.PartialReserve
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*Get)(nil), "status.Get")
 	proto.RegisterType((*Snapshot)(nil), "status.Snapshot")
@@ -204,32 +228,35 @@ func init() {
 func init() { proto.RegisterFile("status.proto", fileDescriptor_dfe4fce6682daf5b) }
 
 var fileDescriptor_dfe4fce6682daf5b = []byte{
-	// 400 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcf, 0x8a, 0xd4, 0x40,
-	0x10, 0xc6, 0xa7, 0xe7, 0xff, 0xd4, 0xcc, 0xea, 0xd2, 0x08, 0x36, 0xa2, 0x21, 0x0c, 0x22, 0xc1,
-	0xc3, 0x1c, 0xf4, 0xe0, 0xb2, 0xc7, 0x59, 0x45, 0x04, 0x57, 0x96, 0x9e, 0x83, 0xe0, 0xad, 0x93,
-	0x14, 0x9b, 0xb0, 0x99, 0xee, 0x90, 0xae, 0x2c, 0xc4, 0xa7, 0xf0, 0x55, 0x7c, 0x0b, 0x8f, 0x7b,
-	0xf4, 0x28, 0x33, 0x2f, 0x22, 0xe9, 0xcc, 0x40, 0x26, 0xe2, 0xad, 0xeb, 0x57, 0x45, 0xf5, 0xd7,
-	0xdf, 0xd7, 0xb0, 0xb0, 0xa4, 0xa8, 0xb4, 0xab, 0xbc, 0x30, 0x64, 0xf8, 0xb8, 0xa9, 0x96, 0x23,
-	0x18, 0x7c, 0x44, 0x5a, 0xfe, 0x1c, 0xc2, 0x74, 0xa3, 0x55, 0x6e, 0x13, 0x43, 0xdc, 0x87, 0xb9,
-	0x44, 0x8b, 0xc5, 0x3d, 0x6e, 0xd2, 0xef, 0x28, 0x98, 0xcf, 0x82, 0xa1, 0x6c, 0x23, 0xbe, 0x84,
-	0xc5, 0x4d, 0x99, 0x65, 0xb6, 0xd2, 0x91, 0x54, 0x84, 0xa2, 0xef, 0xb3, 0x80, 0xc9, 0x13, 0xc6,
-	0x5f, 0xc2, 0xd9, 0x86, 0x4c, 0xa1, 0x6e, 0x51, 0xaa, 0x38, 0x2d, 0xad, 0x18, 0xf8, 0x2c, 0x38,
-	0x93, 0xa7, 0x90, 0xbf, 0x82, 0x47, 0x57, 0x46, 0x6b, 0x8c, 0x08, 0xe3, 0x1b, 0xc4, 0xc2, 0x8a,
-	0xa1, 0xbb, 0xae, 0x43, 0xf9, 0x6b, 0x38, 0xff, 0x82, 0xe9, 0x6d, 0x12, 0x9a, 0x22, 0x31, 0x26,
-	0x76, 0xc2, 0x46, 0x6e, 0xf2, 0x1f, 0xce, 0x05, 0x4c, 0xd6, 0x88, 0xd7, 0x26, 0x46, 0x31, 0xf6,
-	0x59, 0x30, 0x93, 0xc7, 0x92, 0x07, 0xf0, 0x78, 0xad, 0x28, 0x4a, 0xae, 0xcc, 0x76, 0x9b, 0xd2,
-	0x16, 0x35, 0x89, 0x89, 0x5b, 0xd2, 0xc5, 0xb5, 0x07, 0x9f, 0xac, 0x44, 0x15, 0x25, 0x2a, 0xcc,
-	0x50, 0x4c, 0x7d, 0x16, 0x4c, 0x65, 0x1b, 0xf1, 0x0b, 0x78, 0xda, 0xb2, 0xe4, 0x6b, 0x4a, 0x49,
-	0xaa, 0x0f, 0x2f, 0x9d, 0xb9, 0x9d, 0xff, 0x6b, 0xd7, 0x2a, 0x3e, 0x2b, 0x4b, 0x9b, 0x4a, 0x47,
-	0x18, 0xaf, 0x33, 0x13, 0xdd, 0x09, 0x68, 0x54, 0x74, 0x70, 0xe3, 0x4e, 0xad, 0x89, 0x30, 0x7e,
-	0x8f, 0x39, 0x25, 0x62, 0xee, 0x4c, 0xec, 0x50, 0xfe, 0x0e, 0x26, 0xd7, 0x48, 0x45, 0x1a, 0x59,
-	0xb1, 0xf0, 0x07, 0xc1, 0xfc, 0xcd, 0x8b, 0xd5, 0x21, 0xed, 0x63, 0xa8, 0xab, 0x43, 0xff, 0x83,
-	0xa6, 0xa2, 0x92, 0xc7, 0xe9, 0x67, 0x97, 0xb0, 0x68, 0x37, 0xf8, 0x39, 0x0c, 0xee, 0xb0, 0x72,
-	0x91, 0xcf, 0x64, 0x7d, 0xe4, 0x4f, 0x60, 0x74, 0xaf, 0xb2, 0xb2, 0xc9, 0x78, 0x26, 0x9b, 0xe2,
-	0xb2, 0x7f, 0xc1, 0xd6, 0xcf, 0x7f, 0xed, 0x3c, 0xf6, 0xb0, 0xf3, 0xd8, 0x9f, 0x9d, 0xc7, 0x7e,
-	0xec, 0xbd, 0xde, 0xc3, 0xde, 0xeb, 0xfd, 0xde, 0x7b, 0xbd, 0x6f, 0xfd, 0x3c, 0x0c, 0xc7, 0xee,
-	0x9f, 0xbd, 0xfd, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x23, 0x3c, 0xa8, 0x9b, 0x77, 0x02, 0x00, 0x00,
+	// 442 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xdf, 0x8a, 0xd3, 0x40,
+	0x14, 0x87, 0x3b, 0x4d, 0xff, 0x4e, 0xdb, 0xdd, 0x65, 0x10, 0x1c, 0x44, 0x43, 0x28, 0x22, 0xc1,
+	0x8b, 0x5e, 0xe8, 0x85, 0xcb, 0x5e, 0xb6, 0x8a, 0x08, 0xae, 0x94, 0xa9, 0x20, 0x78, 0x37, 0x4d,
+	0x0e, 0x9b, 0x61, 0xd3, 0x4c, 0x98, 0x39, 0x5d, 0x88, 0x4f, 0xe1, 0x3b, 0xf8, 0x32, 0x5e, 0xee,
+	0xa5, 0x97, 0xd2, 0xbe, 0x88, 0x64, 0x92, 0x42, 0x36, 0xb2, 0x77, 0x39, 0xdf, 0x4c, 0x4e, 0xbe,
+	0xfc, 0xce, 0xa1, 0x53, 0x8b, 0x12, 0xf7, 0x76, 0x91, 0x1b, 0x8d, 0x9a, 0x0d, 0xaa, 0x6a, 0xde,
+	0xa7, 0xde, 0x47, 0xc0, 0xf9, 0xaf, 0x3e, 0x1d, 0x6d, 0x32, 0x99, 0xdb, 0x44, 0x23, 0x0b, 0xe8,
+	0x44, 0x80, 0x05, 0x73, 0x07, 0x1b, 0xf5, 0x03, 0x38, 0x09, 0x48, 0xd8, 0x13, 0x4d, 0xc4, 0xe6,
+	0x74, 0xba, 0xde, 0xa7, 0xa9, 0x2d, 0xb2, 0x48, 0x48, 0x04, 0xde, 0x0d, 0x48, 0x48, 0xc4, 0x03,
+	0xc6, 0x5e, 0xd2, 0xd9, 0x06, 0xb5, 0x91, 0x37, 0x20, 0x64, 0xac, 0xf6, 0x96, 0x7b, 0x01, 0x09,
+	0x67, 0xe2, 0x21, 0x64, 0xaf, 0xe8, 0xd9, 0x4a, 0x67, 0x19, 0x44, 0x08, 0xf1, 0x1a, 0xc0, 0x58,
+	0xde, 0x73, 0x9f, 0x6b, 0x51, 0xf6, 0x9a, 0x5e, 0x7c, 0x01, 0x75, 0x93, 0x6c, 0xb5, 0x49, 0xb4,
+	0x8e, 0x9d, 0x58, 0xdf, 0xdd, 0xfc, 0x8f, 0x33, 0x4e, 0x87, 0x4b, 0x80, 0x6b, 0x1d, 0x03, 0x1f,
+	0x04, 0x24, 0x1c, 0x8b, 0x53, 0xc9, 0x42, 0x7a, 0xbe, 0x94, 0x18, 0x25, 0x2b, 0xbd, 0xdb, 0x29,
+	0xdc, 0x41, 0x86, 0x7c, 0xe8, 0x9a, 0xb4, 0x71, 0x99, 0xc1, 0x27, 0x2b, 0x40, 0x46, 0x89, 0xdc,
+	0xa6, 0xc0, 0x47, 0x01, 0x09, 0x47, 0xa2, 0x89, 0xd8, 0x25, 0x7d, 0xda, 0x88, 0xe4, 0x9b, 0xc2,
+	0x44, 0x65, 0xf5, 0x9f, 0x8e, 0x5d, 0xcf, 0xc7, 0x8e, 0x4b, 0x8b, 0xcf, 0xd2, 0xe2, 0xa6, 0xc8,
+	0x22, 0x88, 0x97, 0xa9, 0x8e, 0x6e, 0x39, 0xad, 0x2c, 0x5a, 0xb8, 0x4a, 0xa7, 0x74, 0x42, 0x88,
+	0xdf, 0x43, 0x8e, 0x09, 0x9f, 0xb8, 0x10, 0x5b, 0x94, 0xbd, 0xa3, 0xc3, 0x6b, 0x40, 0xa3, 0x22,
+	0xcb, 0xa7, 0x81, 0x17, 0x4e, 0xde, 0xbc, 0x58, 0xd4, 0xd3, 0x3e, 0x0d, 0x75, 0x51, 0x9f, 0x7f,
+	0xc8, 0xd0, 0x14, 0xe2, 0x74, 0xbb, 0x54, 0xa9, 0x2d, 0x57, 0x32, 0x97, 0x91, 0xc2, 0x82, 0xcf,
+	0x2a, 0x95, 0x16, 0x66, 0x3e, 0xa5, 0x6b, 0xa3, 0x22, 0xf8, 0xea, 0xf2, 0x38, 0x0b, 0xbc, 0xb0,
+	0x27, 0x1a, 0xa4, 0x54, 0x5d, 0x4b, 0x83, 0x4a, 0xa6, 0xf5, 0x9b, 0xfc, 0xdc, 0x65, 0xd6, 0xa2,
+	0xcf, 0xae, 0xe8, 0xb4, 0xa9, 0xc2, 0x2e, 0xa8, 0x77, 0x0b, 0x85, 0x5b, 0xb2, 0xb1, 0x28, 0x1f,
+	0xd9, 0x13, 0xda, 0xbf, 0x93, 0xe9, 0xbe, 0xda, 0xaa, 0xb1, 0xa8, 0x8a, 0xab, 0xee, 0x25, 0x59,
+	0x3e, 0xff, 0x7d, 0xf0, 0xc9, 0xfd, 0xc1, 0x27, 0x7f, 0x0f, 0x3e, 0xf9, 0x79, 0xf4, 0x3b, 0xf7,
+	0x47, 0xbf, 0xf3, 0xe7, 0xe8, 0x77, 0xbe, 0x77, 0xf3, 0xed, 0x76, 0xe0, 0x36, 0xfb, 0xed, 0xbf,
+	0x01, 0x00, 0xbf, 0x5f, 0x1d, 0x0e, 0xe9, 0x02, 0x00, 0x00,
 }
 
 func (m *Get) Marshal() (dAtA []byte, err error) {
@@ -275,6 +302,39 @@ func (m *Snapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.PartialReserve {
+		i--
+		if m.PartialReserve {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x78
+	}
+	if len(m.PriceTable) > 0 {
+		dAtA2 := make([]byte, len(m.PriceTable)*10)
+		var j1 int
+		for _, num := range m.PriceTable {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintStatus(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.ReserveCapacity != 0 {
+		i = encodeVarintStatus(dAtA, i, uint64(m.ReserveCapacity))
+		i--
+		dAtA[i] = 0x68
+	}
 	if len(m.Metrics) > 0 {
 		for k := range m.Metrics {
 			v := m.Metrics[k]
@@ -428,6 +488,19 @@ func (m *Snapshot) Size() (n int) {
 			n += mapEntrySize + 1 + sovStatus(uint64(mapEntrySize))
 		}
 	}
+	if m.ReserveCapacity != 0 {
+		n += 1 + sovStatus(uint64(m.ReserveCapacity))
+	}
+	if len(m.PriceTable) > 0 {
+		l = 0
+		for _, e := range m.PriceTable {
+			l += sovStatus(uint64(e))
+		}
+		n += 1 + sovStatus(uint64(l)) + l
+	}
+	if m.PartialReserve {
+		n += 2
+	}
 	return n
 }
 
@@ -858,6 +931,121 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 			}
 			m.Metrics[mapkey] = mapvalue
 			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReserveCapacity", wireType)
+			}
+			m.ReserveCapacity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReserveCapacity |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowStatus
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.PriceTable = append(m.PriceTable, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowStatus
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthStatus
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthStatus
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.PriceTable) == 0 {
+					m.PriceTable = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowStatus
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.PriceTable = append(m.PriceTable, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field PriceTable", wireType)
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartialReserve", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PartialReserve = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStatus(dAtA[iNdEx:])
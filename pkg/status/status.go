@@ -35,16 +35,27 @@ type Snapshot pb.Snapshot
 // SyncReporter defines the interface to report syncing rate.
 type SyncReporter interface {
 	SyncRate() float64
+	// PartialHistory reports whether the historical syncing is restricted
+	// to the recent chunks, so the reserve holds only the part of the history.
+	PartialHistory() bool
 }
 
 // Reserve defines the reserve storage related information required.
 type Reserve interface {
 	ReserveSize() int
+	ReserveCapacity() int
 	ReserveSizeWithinRadius() uint64
 	StorageRadius() uint8
 	CommittedDepth() uint8
 }
 
+// Pricer defines the interface to report the current prices.
+type Pricer interface {
+	// PriceTable returns the prices charged for the chunk
+	// indexed by the proximity order of the chunk.
+	PriceTable() []uint64
+}
+
 type topologyDriver interface {
 	topology.PeerIterator
 	IsReachable() bool
@@ -59,6 +70,7 @@ type Service struct {
 	beeMode         string
 	reserve         Reserve
 	sync            SyncReporter
+	pricer          Pricer
 	chainState      postage.ChainStateGetter
 	metricsRegistry *prometheus.Registry
 }
@@ -96,20 +108,29 @@ func (s *Service) LocalSnapshot() (*Snapshot, error) {
 		syncRate                float64
 		reserveSize             uint64
 		reserveSizeWithinRadius uint64
+		reserveCapacity         uint64
+		priceTable              []uint64
 		connectedPeers          uint64
 		neighborhoodSize        uint64
 		committedDepth          uint8
+		partialReserve          bool
 	)
 
 	if s.reserve != nil {
 		storageRadius = s.reserve.StorageRadius()
 		reserveSize = uint64(s.reserve.ReserveSize())
 		reserveSizeWithinRadius = s.reserve.ReserveSizeWithinRadius()
+		reserveCapacity = uint64(s.reserve.ReserveCapacity())
 		committedDepth = s.reserve.CommittedDepth()
 	}
 
 	if s.sync != nil {
 		syncRate = s.sync.SyncRate()
+		partialReserve = s.sync.PartialHistory()
+	}
+
+	if s.pricer != nil {
+		priceTable = s.pricer.PriceTable()
 	}
 
 	commitment, err := s.chainState.Commitment()
@@ -149,6 +170,9 @@ func (s *Service) LocalSnapshot() (*Snapshot, error) {
 		LastSyncedBlock:         s.chainState.GetChainState().Block,
 		CommittedDepth:          uint32(committedDepth),
 		Metrics:                 metrics,
+		ReserveCapacity:         reserveCapacity,
+		PriceTable:              priceTable,
+		PartialReserve:          partialReserve,
 	}, nil
 }
 
@@ -222,6 +246,10 @@ func (s *Service) SetSync(sync SyncReporter) {
 	s.sync = sync
 }
 
+func (s *Service) SetPricer(pricer Pricer) {
+	s.pricer = pricer
+}
+
 func (s *Service) encodeMetrics() (map[string]string, error) {
 	if s.metricsRegistry == nil {
 		return nil, nil
@@ -35,6 +35,9 @@ func TestStatus(t *testing.T) {
 		IsReachable:      true,
 		LastSyncedBlock:  6092500,
 		CommittedDepth:   1,
+		ReserveCapacity:  256,
+		PriceTable:       []uint64{3, 2, 1},
+		PartialReserve:   true,
 		Metrics: map[string]string{
 			"test_response_duration_seconds": `# HELP test_response_duration_seconds Histogram of API response durations.
 # TYPE test_response_duration_seconds histogram
@@ -104,6 +107,7 @@ test_upload_count_total 12
 	)
 
 	peer1.SetSync(sssMock)
+	peer1.SetPricer(sssMock)
 
 	recorder := streamtest.New(streamtest.WithProtocols(peer1.Protocol()))
 
@@ -245,12 +249,16 @@ func (m *topologyPeersIterNoopMock) IsReachable() bool {
 // statusSnapshotMock satisfies the following interfaces:
 //   - Reserve
 //   - SyncReporter
+//   - Pricer
 type statusSnapshotMock struct {
 	*pb.Snapshot
 }
 
 func (m *statusSnapshotMock) SyncRate() float64           { return m.Snapshot.PullsyncRate }
+func (m *statusSnapshotMock) PartialHistory() bool        { return m.Snapshot.PartialReserve }
 func (m *statusSnapshotMock) ReserveSize() int            { return int(m.Snapshot.ReserveSize) }
+func (m *statusSnapshotMock) ReserveCapacity() int        { return int(m.Snapshot.ReserveCapacity) }
+func (m *statusSnapshotMock) PriceTable() []uint64        { return m.Snapshot.PriceTable }
 func (m *statusSnapshotMock) StorageRadius() uint8        { return uint8(m.Snapshot.StorageRadius) }
 func (m *statusSnapshotMock) Commitment() (uint64, error) { return m.Snapshot.BatchCommitment, nil }
 func (m *statusSnapshotMock) GetChainState() *postage.ChainState {
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ethersphere/bee/v2/pkg/availability"
 	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/retrieval"
@@ -31,20 +32,43 @@ type Interface interface {
 	IsRetrievable(context.Context, swarm.Address) (bool, error)
 }
 
+// AvailabilityChecker checks whether the chunks of the content are held
+// on the network without retrieving them.
+type AvailabilityChecker interface {
+	Check(context.Context, swarm.Address) (*availability.Report, error)
+}
+
+// Option is the option of the steward.
+type Option func(*steward)
+
+// WithAvailability makes the steward check whether the content is
+// retrievable by asking the peers whether they hold its chunks, and
+// retrieve the chunks only if that is not conclusive.
+func WithAvailability(a AvailabilityChecker) Option {
+	return func(s *steward) {
+		s.availability = a
+	}
+}
+
 type steward struct {
 	netStore     storer.NetStore
 	traverser    traversal.Traverser
 	netTraverser traversal.Traverser
 	netGetter    retrieval.Interface
+	availability AvailabilityChecker
 }
 
-func New(ns storer.NetStore, r retrieval.Interface, joinerPutter storage.Putter) Interface {
-	return &steward{
+func New(ns storer.NetStore, r retrieval.Interface, joinerPutter storage.Putter, opts ...Option) Interface {
+	s := &steward{
 		netStore:     ns,
 		traverser:    traversal.New(ns.Download(true), joinerPutter, redundancy.DefaultLevel),
 		netTraverser: traversal.New(&netGetter{r}, joinerPutter, redundancy.DefaultLevel),
 		netGetter:    r,
 	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
 }
 
 // Reupload content with the given root hash to the network.
@@ -81,7 +105,20 @@ func (s *steward) Reupload(ctx context.Context, root swarm.Address, stamper post
 }
 
 // IsRetrievable implements Interface.IsRetrievable method.
+// The content whose chunks are all held by their closest peers is
+// retrievable, otherwise the chunks are retrieved, as they may be
+// held by the other peers of their neighborhoods.
 func (s *steward) IsRetrievable(ctx context.Context, root swarm.Address) (bool, error) {
+	if s.availability != nil {
+		report, err := s.availability.Check(ctx, root)
+		if err == nil && report.Resolvable() {
+			return true, nil
+		}
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+	}
+
 	fn := func(a swarm.Address) error {
 		_, err := s.netGetter.RetrieveChunk(ctx, a, swarm.ZeroAddress)
 		return err
@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/availability"
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
 	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
 	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/mock"
@@ -118,6 +119,76 @@ func TestSteward(t *testing.T) {
 	}
 }
 
+func TestIsRetrievableWithAvailability(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx        = context.Background()
+		chunkStore = inmemchunkstore.New()
+		store      = mockstorer.NewWithChunkStore(chunkStore)
+		data       = make([]byte, 10*swarm.ChunkSize)
+	)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	pipe := builder.NewPipelineBuilder(ctx, chunkStore, false, redundancy.NONE)
+	addr, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		report    *availability.Report
+		err       error
+		retrieved bool
+	}{
+		{
+			name:   "available",
+			report: &availability.Report{Total: 11, Available: 11, Complete: true},
+		},
+		{
+			name:      "missing",
+			report:    &availability.Report{Total: 11, Available: 10, Missing: 1, Complete: true},
+			retrieved: true,
+		},
+		{
+			name:      "failed",
+			err:       availability.ErrNotFound,
+			retrieved: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				localRetrieval = &localRetriever{ChunkStore: chunkStore}
+				checker        = availabilityFunc(func(context.Context, swarm.Address) (*availability.Report, error) {
+					return tc.report, tc.err
+				})
+				s = steward.New(store, localRetrieval, chunkStore, steward.WithAvailability(checker))
+			)
+
+			isRetrievable, err := s.IsRetrievable(ctx, addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !isRetrievable {
+				t.Fatalf("content on %q should be retrievable", addr)
+			}
+			if retrieved := len(localRetrieval.retrievedChunks) > 0; retrieved != tc.retrieved {
+				t.Fatalf("retrieved: want %t have %t", tc.retrieved, retrieved)
+			}
+		})
+	}
+}
+
+type availabilityFunc func(context.Context, swarm.Address) (*availability.Report, error)
+
+func (f availabilityFunc) Check(ctx context.Context, root swarm.Address) (*availability.Report, error) {
+	return f(ctx, root)
+}
+
 type localRetriever struct {
 	storage.ChunkStore
 	mu              sync.Mutex
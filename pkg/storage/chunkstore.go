@@ -6,6 +6,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
@@ -27,6 +28,53 @@ type Putter interface {
 	Put(context.Context, swarm.Chunk) error
 }
 
+// ZeroCopyPutter is implemented by the putters which are done with the
+// data of the chunks by the time Put returns, for example by writing it
+// to the disk. The data buffers of the chunks put to them may be reused.
+type ZeroCopyPutter interface {
+	Putter
+	// ZeroCopy reports whether the putter is done with the chunk data
+	// when Put returns, the wrappers of the putters report the wrapped.
+	ZeroCopy() bool
+}
+
+// IsZeroCopy reports whether the putter is done with
+// the data of the chunks by the time Put returns.
+func IsZeroCopy(p Putter) bool {
+	zp, ok := p.(ZeroCopyPutter)
+	return ok && zp.ZeroCopy()
+}
+
+// BatchPutter is implemented by the putters which store many chunks at once
+// more efficiently than one by one.
+type BatchPutter interface {
+	Putter
+	// PutMany stores the chunks. The returned slice, indexed as the chunks,
+	// holds the errors of the chunks which were rejected without failing the
+	// others. The returned error fails the chunks not stored before it.
+	PutMany(context.Context, []swarm.Chunk) ([]error, error)
+}
+
+// PutMany stores the chunks with the putter, at once if it is a BatchPutter.
+// Otherwise the chunks are put one by one and the chunks rejected with
+// ErrOverwriteNewerChunk do not fail the others.
+func PutMany(ctx context.Context, p Putter, chunks []swarm.Chunk) ([]error, error) {
+	if bp, ok := p.(BatchPutter); ok {
+		return bp.PutMany(ctx, chunks)
+	}
+	errs := make([]error, len(chunks))
+	for i, ch := range chunks {
+		if err := p.Put(ctx, ch); err != nil {
+			if errors.Is(err, ErrOverwriteNewerChunk) {
+				errs[i] = err
+				continue
+			}
+			return errs, err
+		}
+	}
+	return errs, nil
+}
+
 // Deleter is the interface that wraps the basic Delete method.
 type Deleter interface {
 	// Delete a chunk by the given swarm.Address.
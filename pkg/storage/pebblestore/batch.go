@@ -0,0 +1,81 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pebblestore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+)
+
+// Batch implements storage.BatchedStore interface Batch method.
+func (s *Store) Batch(ctx context.Context) storage.Batch {
+	return &Batch{
+		ctx:   ctx,
+		batch: s.db.NewBatch(),
+	}
+}
+
+type Batch struct {
+	ctx context.Context
+
+	mu    sync.Mutex // mu guards batch and done.
+	batch *pebble.Batch
+	done  bool
+}
+
+// Put implements storage.Batch interface Put method.
+func (i *Batch) Put(item storage.Item) error {
+	if err := i.ctx.Err(); err != nil {
+		return err
+	}
+
+	val, err := item.Marshal()
+	if err != nil {
+		return fmt.Errorf("unable to marshal item: %w", err)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.batch.Set(key(item), val, nil)
+}
+
+// Delete implements storage.Batch interface Delete method.
+func (i *Batch) Delete(item storage.Item) error {
+	if err := i.ctx.Err(); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.batch.Delete(key(item), nil)
+}
+
+// Commit implements storage.Batch interface Commit method.
+func (i *Batch) Commit() error {
+	if err := i.ctx.Err(); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.done {
+		return storage.ErrBatchCommitted
+	}
+
+	if err := i.batch.Commit(pebble.NoSync); err != nil {
+		return fmt.Errorf("unable to commit batch: %w", err)
+	}
+
+	i.done = true
+
+	return i.batch.Close()
+}
@@ -0,0 +1,316 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pebblestore provides an implementation of the storage.BatchStore
+// backed by the Pebble key-value store.
+package pebblestore
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+)
+
+const separator = "/"
+
+// key returns the Item identifier for the pebble storage.
+func key(item storage.Key) []byte {
+	return []byte(item.Namespace() + separator + item.ID())
+}
+
+// filters is a decorator for a slice of storage.Filters
+// that helps with its evaluation.
+type filters []storage.Filter
+
+// matchAny returns true if any of the filters match the item.
+func (f filters) matchAny(k string, v []byte) bool {
+	for _, filter := range f {
+		if filter(k, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// upperBound returns the smallest key that is greater
+// than all the keys starting with the given prefix.
+// A nil slice is returned if no such key exists.
+func upperBound(prefix []byte) []byte {
+	limit := make([]byte, len(prefix))
+	copy(limit, prefix)
+	for i := len(limit) - 1; i >= 0; i-- {
+		if limit[i] < 0xff {
+			limit[i]++
+			return limit[:i+1]
+		}
+	}
+	return nil
+}
+
+// prefixIterOptions returns iterator options which
+// restrict the iteration to the keys with the given prefix.
+func prefixIterOptions(prefix []byte) *pebble.IterOptions {
+	if len(prefix) == 0 {
+		return nil
+	}
+	return &pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: upperBound(prefix),
+	}
+}
+
+// Storer returns the underlying db store.
+type Storer interface {
+	DB() *pebble.DB
+}
+
+var (
+	_ Storer             = (*Store)(nil)
+	_ storage.BatchStore = (*Store)(nil)
+)
+
+type Store struct {
+	db     *pebble.DB
+	path   string
+	closed atomic.Bool
+}
+
+// New returns a new store the backed by pebble.
+// If path == "", the pebble will run with in memory backend storage.
+func New(path string, opts *pebble.Options) (*Store, error) {
+	if opts == nil {
+		opts = new(pebble.Options)
+	}
+	if path == "" {
+		opts.FS = vfs.NewMem()
+	}
+
+	db, err := pebble.Open(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		db:   db,
+		path: path,
+	}, nil
+}
+
+// DB implements the Storer interface.
+func (s *Store) DB() *pebble.DB {
+	return s.db
+}
+
+// Close implements the storage.Store interface.
+// Unlike pebble, which panics, it returns pebble.ErrClosed
+// when the store is closed more than once.
+func (s *Store) Close() error {
+	if !s.closed.CompareAndSwap(false, true) {
+		return pebble.ErrClosed
+	}
+	return s.db.Close()
+}
+
+// get returns a copy of the value stored under the given key.
+func (s *Store) get(k []byte) ([]byte, error) {
+	val, closer, err := s.db.Get(k)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = closer.Close() }()
+
+	buf := make([]byte, len(val))
+	copy(buf, val)
+	return buf, nil
+}
+
+// Get implements the storage.Store interface.
+func (s *Store) Get(item storage.Item) error {
+	val, err := s.get(key(item))
+	if err != nil {
+		return err
+	}
+
+	if err = item.Unmarshal(val); err != nil {
+		return fmt.Errorf("failed decoding value %w", err)
+	}
+
+	return nil
+}
+
+// Has implements the storage.Store interface.
+func (s *Store) Has(k storage.Key) (bool, error) {
+	switch _, err := s.get(key(k)); {
+	case errors.Is(err, storage.ErrNotFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
+// GetSize implements the storage.Store interface.
+func (s *Store) GetSize(k storage.Key) (int, error) {
+	val, err := s.get(key(k))
+	if err != nil {
+		return 0, err
+	}
+	return len(val), nil
+}
+
+// Iterate implements the storage.Store interface.
+func (s *Store) Iterate(q storage.Query, fn storage.IterateFn) error {
+	if err := q.Validate(); err != nil {
+		return fmt.Errorf("failed iteration: %w", err)
+	}
+
+	var (
+		retErr error
+		prefix string
+	)
+
+	if q.PrefixAtStart {
+		prefix = q.Factory().Namespace()
+	} else if q.Factory().Namespace() != "" {
+		// an empty namespace results in a full iteration
+		// in order to stay compatible with the leveldb store.
+		prefix = q.Factory().Namespace() + separator + q.Prefix
+	}
+
+	iter, err := s.db.NewIter(prefixIterOptions([]byte(prefix)))
+	if err != nil {
+		return fmt.Errorf("failed creating iterator: %w", err)
+	}
+	defer iter.Close()
+
+	if q.PrefixAtStart && !iter.SeekGE([]byte(prefix+separator+q.Prefix)) {
+		return iter.Error()
+	}
+
+	var nextF func() bool
+	switch {
+	case q.Order == storage.KeyDescendingOrder:
+		nextF = func() bool {
+			nextF = iter.Prev
+			return iter.Last()
+		}
+	case q.PrefixAtStart:
+		nextF = func() bool {
+			nextF = iter.Next
+			return iter.Valid()
+		}
+	default:
+		nextF = func() bool {
+			nextF = iter.Next
+			return iter.First()
+		}
+	}
+
+	firstSkipped := !q.SkipFirst
+
+	for nextF() {
+		keyRaw := iter.Key()
+		nextKey := make([]byte, len(keyRaw))
+		copy(nextKey, keyRaw)
+
+		valRaw := iter.Value()
+		nextVal := make([]byte, len(valRaw))
+		copy(nextVal, valRaw)
+
+		key := strings.TrimPrefix(string(nextKey), prefix)
+
+		if filters(q.Filters).matchAny(key, nextVal) {
+			continue
+		}
+
+		if q.SkipFirst && !firstSkipped {
+			firstSkipped = true
+			continue
+		}
+
+		var (
+			res *storage.Result
+			err error
+		)
+
+		switch q.ItemProperty {
+		case storage.QueryItemID, storage.QueryItemSize:
+			res = &storage.Result{ID: key, Size: len(nextVal)}
+		case storage.QueryItem:
+			newItem := q.Factory()
+			err = newItem.Unmarshal(nextVal)
+			res = &storage.Result{ID: key, Entry: newItem}
+		}
+
+		if err != nil {
+			retErr = errors.Join(retErr, fmt.Errorf("failed unmarshaling: %w", err))
+			break
+		}
+
+		if res == nil {
+			retErr = errors.Join(retErr, fmt.Errorf("unknown object attribute type: %v", q.ItemProperty))
+			break
+		}
+
+		if stop, err := fn(*res); err != nil {
+			retErr = errors.Join(retErr, fmt.Errorf("iterate callback function errored: %w", err))
+			break
+		} else if stop {
+			break
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		retErr = errors.Join(retErr, err)
+	}
+
+	return retErr
+}
+
+// Count implements the storage.Store interface.
+func (s *Store) Count(key storage.Key) (int, error) {
+	iter, err := s.db.NewIter(prefixIterOptions([]byte(key.Namespace() + separator)))
+	if err != nil {
+		return 0, fmt.Errorf("failed creating iterator: %w", err)
+	}
+
+	var c int
+	for valid := iter.First(); valid; valid = iter.Next() {
+		c++
+	}
+
+	return c, errors.Join(iter.Error(), iter.Close())
+}
+
+// Put implements the storage.Store interface.
+func (s *Store) Put(item storage.Item) error {
+	value, err := item.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed serializing: %w", err)
+	}
+
+	return s.db.Set(key(item), value, pebble.NoSync)
+}
+
+// Delete implements the storage.Store interface.
+func (s *Store) Delete(item storage.Item) error {
+	// entries without a namespace are stored
+	// under the plain ID, see the leveldb store.
+	var k []byte
+	if item.Namespace() == "" {
+		k = []byte(item.ID())
+	} else {
+		k = key(item)
+	}
+
+	return s.db.Delete(k, pebble.NoSync)
+}
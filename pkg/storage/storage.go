@@ -287,6 +287,9 @@ type Batcher interface {
 }
 
 func ChunkType(ch swarm.Chunk) swarm.ChunkType {
+	if t := swarm.ValidType(ch); t != swarm.ChunkTypeUnspecified {
+		return t
+	}
 	if cac.Valid(ch) {
 		return swarm.ChunkTypeContentAddressed
 	} else if soc.Valid(ch) {
@@ -301,7 +304,7 @@ func ChunkType(ch swarm.Chunk) swarm.ChunkType {
 // it is used in the reserve sampling and other places where a key is needed to represent a chunk.
 func IdentityAddress(chunk swarm.Chunk) (swarm.Address, error) {
 
-	if cac.Valid(chunk) {
+	if swarm.ValidType(chunk) == swarm.ChunkTypeContentAddressed || cac.Valid(chunk) {
 		return chunk.Address(), nil
 	}
 
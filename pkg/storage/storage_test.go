@@ -79,3 +79,30 @@ func TestIdentityAddress(t *testing.T) {
 		}
 	})
 }
+
+func TestValidatedChunkType(t *testing.T) {
+	t.Parallel()
+
+	// the marked chunks are not validated again
+	ch := swarm.NewChunk(swarm.RandAddress(t), []byte("not a valid chunk"))
+	if got := storage.ChunkType(ch); got != swarm.ChunkTypeUnspecified {
+		t.Fatalf("got chunk type %s, want %s", got, swarm.ChunkTypeUnspecified)
+	}
+
+	swarm.MarkValid(ch, swarm.ChunkTypeContentAddressed)
+	if got := storage.ChunkType(ch); got != swarm.ChunkTypeContentAddressed {
+		t.Fatalf("got chunk type %s, want %s", got, swarm.ChunkTypeContentAddressed)
+	}
+	addr, err := storage.IdentityAddress(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !addr.Equal(ch.Address()) {
+		t.Fatalf("got address %s, want %s", addr, ch.Address())
+	}
+
+	swarm.MarkValid(ch, swarm.ChunkTypeUnspecified)
+	if got := storage.ChunkType(ch); got != swarm.ChunkTypeUnspecified {
+		t.Fatalf("got chunk type %s, want %s", got, swarm.ChunkTypeUnspecified)
+	}
+}
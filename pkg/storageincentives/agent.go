@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
+	nodeevents "github.com/ethersphere/bee/v2/pkg/events"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/postage/postagecontract"
@@ -70,6 +71,7 @@ type Agent struct {
 	chainStateGetter       postage.ChainStateGetter
 	commitLock             sync.Mutex
 	health                 Health
+	publisher              nodeevents.Publisher
 }
 
 func New(overlay swarm.Address,
@@ -88,6 +90,7 @@ func New(overlay swarm.Address,
 	erc20Service erc20.Service,
 	tranService transaction.Service,
 	health Health,
+	publisher nodeevents.Publisher,
 	logger log.Logger,
 ) (*Agent, error) {
 	a := &Agent{
@@ -104,6 +107,7 @@ func New(overlay swarm.Address,
 		redistributionStatuser: redistributionStatuser,
 		health:                 health,
 		chainStateGetter:       chainStateGetter,
+		publisher:              publisher,
 	}
 
 	state, err := NewRedistributionState(logger, ethAddress, stateStore, erc20Service, tranService)
@@ -333,6 +337,7 @@ func (a *Agent) handleClaim(ctx context.Context, round uint64) error {
 
 	if !isWinner {
 		a.logger.Info("not a winner")
+		a.publishRound(nodeevents.Round{Round: round})
 		// When there is nothing to claim (node is not a winner), phase is played
 		return nil
 	}
@@ -372,10 +377,12 @@ func (a *Agent) handleClaim(ctx context.Context, round uint64) error {
 	txHash, err := a.contract.Claim(ctx, proofs)
 	if err != nil {
 		a.metrics.ErrClaim.Inc()
+		a.publishRound(nodeevents.Round{Round: round, Winner: true, Error: err.Error()})
 		return fmt.Errorf("claiming win: %w", err)
 	}
 
 	a.logger.Info("claimed win")
+	a.publishRound(nodeevents.Round{Round: round, Winner: true, TransactionHash: &txHash})
 
 	if errBalance == nil {
 		errReward := a.state.CalculateWinnerReward(ctx)
@@ -389,6 +396,13 @@ func (a *Agent) handleClaim(ctx context.Context, round uint64) error {
 	return nil
 }
 
+// publishRound publishes the outcome of the round if the publisher is set.
+func (a *Agent) publishRound(r nodeevents.Round) {
+	if a.publisher != nil {
+		a.publisher.Publish(nodeevents.TopicRound, r)
+	}
+}
+
 func (a *Agent) handleSample(ctx context.Context, round uint64) (bool, error) {
 	// minimum proximity between the anchor and the stored chunks
 	committedDepth := a.store.CommittedDepth()
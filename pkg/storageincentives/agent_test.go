@@ -201,6 +201,7 @@ func createService(
 		erc20mock.New(),
 		transactionmock.New(),
 		&mockHealth{},
+		nil,
 		log.Noop,
 	)
 }
@@ -0,0 +1,338 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/cache"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstamp"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/reserve"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// AgeBuckets are the upper bounds of the age histogram buckets. The
+// ages above the last bound are counted in the additional last bucket.
+var AgeBuckets = []time.Duration{
+	time.Hour,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+}
+
+// Analysis is the result of the offline analysis of the localstore.
+type Analysis struct {
+	Time        time.Time
+	Reserve     ReserveAnalysis
+	Cache       CacheAnalysis
+	Sharky      SharkyAnalysis
+	Consistency ConsistencyAnalysis
+}
+
+// ReserveAnalysis is the distribution of the reserve chunks.
+type ReserveAnalysis struct {
+	Chunks int
+	// Bins is the number of the chunks per proximity order bin.
+	Bins [swarm.MaxBins]int
+	// Batches is the number of the chunks per hex encoded batch id.
+	Batches map[string]int
+	// StampAges is the histogram of the stamp ages over the AgeBuckets.
+	StampAges []int
+}
+
+// CacheAnalysis is the distribution of the cache entries.
+type CacheAnalysis struct {
+	Entries int
+	// AccessAges is the histogram of the last access ages over the AgeBuckets.
+	AccessAges []int
+}
+
+// SharkyAnalysis is the slot usage of the sharky shards.
+type SharkyAnalysis struct {
+	Shards []ShardAnalysis
+}
+
+// ShardAnalysis is the slot usage of the sharky shard.
+type ShardAnalysis struct {
+	Shard uint8
+	// Slots is the number of the slots the shard file can hold.
+	Slots int
+	// Used is the number of the slots referenced by the retrieval index.
+	Used int
+	// Free is the number of the slots marked free by sharky.
+	Free int
+	// Holes is the number of the free slots below the last used slot.
+	Holes int
+	// LastUsed is the last used slot, -1 when no slot is used.
+	LastUsed int
+}
+
+// Fragmentation returns the fraction of the free slots among
+// the slots up to the last used slot.
+func (s ShardAnalysis) Fragmentation() float64 {
+	if s.LastUsed < 0 {
+		return 0
+	}
+	return float64(s.Holes) / float64(s.LastUsed+1)
+}
+
+// ConsistencyAnalysis are the counts of the inconsistencies between
+// the indexes and sharky.
+type ConsistencyAnalysis struct {
+	// MissingReserveChunks are the reserve entries without the chunk.
+	MissingReserveChunks int
+	// MissingReserveStamps are the reserve entries without the stamp.
+	MissingReserveStamps int
+	// MissingBinEntries are the reserve entries without the bin entry.
+	MissingBinEntries int
+	// DanglingBinEntries are the bin entries without the reserve entry.
+	DanglingBinEntries int
+	// MissingCacheChunks are the cache entries without the chunk.
+	MissingCacheChunks int
+	// SlotConflicts are the retrieval index entries sharing the slot.
+	SlotConflicts int
+	// FreeUsedSlots are the slots referenced by the retrieval
+	// index which are marked free by sharky.
+	FreeUsedSlots int
+	// OutOfRangeSlots are the slots referenced by the retrieval
+	// index which are beyond the end of the shard file.
+	OutOfRangeSlots int
+	// LeakedSlots are the slots which are neither used nor free.
+	LeakedSlots int
+}
+
+// Total returns the number of all the inconsistencies.
+func (c ConsistencyAnalysis) Total() int {
+	return c.MissingReserveChunks + c.MissingReserveStamps + c.MissingBinEntries +
+		c.DanglingBinEntries + c.MissingCacheChunks + c.SlotConflicts +
+		c.FreeUsedSlots + c.OutOfRangeSlots + c.LeakedSlots
+}
+
+// Analyze collects the distribution statistics of the reserve, the cache and
+// the sharky slots and checks the consistency between the indexes and sharky.
+// The localstore must not be in use by a running node.
+func Analyze(ctx context.Context, basePath string, opts *Options) (*Analysis, error) {
+	logger := opts.Logger
+
+	store, err := initStore(basePath, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating index store: %w", err)
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			logger.Error(err, "failed closing store")
+		}
+	}()
+
+	a := &Analysis{
+		Time: time.Now(),
+		Reserve: ReserveAnalysis{
+			Batches:   make(map[string]int),
+			StampAges: make([]int, len(AgeBuckets)+1),
+		},
+		Cache: CacheAnalysis{
+			AccessAges: make([]int, len(AgeBuckets)+1),
+		},
+	}
+
+	logger.Info("analyzing reserve")
+	if err := a.analyzeReserve(ctx, store); err != nil {
+		return nil, fmt.Errorf("analyze reserve: %w", err)
+	}
+
+	logger.Info("analyzing cache")
+	if err := a.analyzeCache(ctx, store); err != nil {
+		return nil, fmt.Errorf("analyze cache: %w", err)
+	}
+
+	logger.Info("analyzing sharky")
+	if err := a.analyzeSharky(ctx, store, path.Join(basePath, sharkyPath)); err != nil {
+		return nil, fmt.Errorf("analyze sharky: %w", err)
+	}
+
+	return a, nil
+}
+
+func (a *Analysis) analyzeReserve(ctx context.Context, store storage.Store) error {
+	// the bin entries are keyed by the bin and the bin id
+	binIDs := make(map[[2]uint64]struct{})
+
+	err := store.Iterate(storage.Query{
+		Factory: func() storage.Item { return new(reserve.BatchRadiusItem) },
+	}, func(res storage.Result) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return true, err
+		}
+		item := res.Entry.(*reserve.BatchRadiusItem)
+
+		a.Reserve.Chunks++
+		a.Reserve.Bins[item.Bin]++
+		a.Reserve.Batches[hex.EncodeToString(item.BatchID)]++
+		binIDs[[2]uint64{uint64(item.Bin), item.BinID}] = struct{}{}
+
+		has, err := store.Has(&chunkstore.RetrievalIndexItem{Address: item.Address})
+		if err != nil {
+			return true, err
+		}
+		if !has {
+			a.Consistency.MissingReserveChunks++
+		}
+
+		stamp, err := chunkstamp.LoadWithStampHash(store, "reserve", item.Address, item.StampHash)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			a.Consistency.MissingReserveStamps++
+		case err != nil:
+			return true, err
+		case len(stamp.Timestamp()) == 8:
+			ts := int64(binary.BigEndian.Uint64(stamp.Timestamp()))
+			a.Reserve.StampAges[ageBucket(a.Time.Sub(time.Unix(0, ts)))]++
+		}
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = store.Iterate(storage.Query{
+		Factory: func() storage.Item { return new(reserve.ChunkBinItem) },
+	}, func(res storage.Result) (bool, error) {
+		item := res.Entry.(*reserve.ChunkBinItem)
+		key := [2]uint64{uint64(item.Bin), item.BinID}
+		if _, ok := binIDs[key]; !ok {
+			a.Consistency.DanglingBinEntries++
+			return false, nil
+		}
+		delete(binIDs, key)
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+	a.Consistency.MissingBinEntries = len(binIDs)
+	return nil
+}
+
+func (a *Analysis) analyzeCache(ctx context.Context, store storage.Store) error {
+	return store.Iterate(storage.Query{
+		Factory: func() storage.Item { return new(cache.CacheEntryItem) },
+	}, func(res storage.Result) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return true, err
+		}
+		item := res.Entry.(*cache.CacheEntryItem)
+
+		a.Cache.Entries++
+		a.Cache.AccessAges[ageBucket(a.Time.Sub(time.Unix(0, item.AccessTimestamp)))]++
+
+		has, err := store.Has(&chunkstore.RetrievalIndexItem{Address: item.Address})
+		if err != nil {
+			return true, err
+		}
+		if !has {
+			a.Consistency.MissingCacheChunks++
+		}
+		return false, nil
+	})
+}
+
+func (a *Analysis) analyzeSharky(ctx context.Context, store storage.Store, sharkyBasePath string) error {
+	shards := make([]struct {
+		slots int
+		free  []byte
+		used  []bool
+	}, sharkyNoOfShards)
+
+	for i := range shards {
+		info, err := os.Stat(path.Join(sharkyBasePath, fmt.Sprintf("shard_%03d", i)))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return err
+		default:
+			// the data of the last slot may be shorter than the slot
+			shards[i].slots = int((info.Size() + swarm.SocMaxChunkSize - 1) / swarm.SocMaxChunkSize)
+		}
+		// the free slots are the set bits of the bit vector
+		shards[i].free, err = os.ReadFile(path.Join(sharkyBasePath, fmt.Sprintf("free_%03d", i)))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		shards[i].used = make([]bool, shards[i].slots)
+	}
+
+	isFree := func(free []byte, slot int) bool {
+		return slot/8 < len(free) && free[slot/8]&(1<<(slot%8)) > 0
+	}
+
+	err := chunkstore.IterateItems(store, func(item *chunkstore.RetrievalIndexItem) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if int(item.Location.Shard) >= len(shards) {
+			a.Consistency.OutOfRangeSlots++
+			return nil
+		}
+		sh := &shards[item.Location.Shard]
+		slot := int(item.Location.Slot)
+		switch {
+		case slot >= sh.slots:
+			a.Consistency.OutOfRangeSlots++
+		case sh.used[slot]:
+			a.Consistency.SlotConflicts++
+		default:
+			sh.used[slot] = true
+			if isFree(sh.free, slot) {
+				a.Consistency.FreeUsedSlots++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, sh := range shards {
+		s := ShardAnalysis{Shard: uint8(i), Slots: sh.slots, LastUsed: -1}
+		for slot, used := range sh.used {
+			if used {
+				s.Used++
+				s.LastUsed = slot
+			}
+		}
+		for slot := 0; slot < sh.slots; slot++ {
+			switch {
+			case isFree(sh.free, slot):
+				s.Free++
+				if slot < s.LastUsed {
+					s.Holes++
+				}
+			case !sh.used[slot]:
+				a.Consistency.LeakedSlots++
+			}
+		}
+		a.Sharky.Shards = append(a.Sharky.Shards, s)
+	}
+	return nil
+}
+
+// ageBucket returns the index of the AgeBuckets histogram bucket of the age.
+func ageBucket(age time.Duration) int {
+	for i, max := range AgeBuckets {
+		if age <= max {
+			return i
+		}
+	}
+	return len(AgeBuckets)
+}
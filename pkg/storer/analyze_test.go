@@ -0,0 +1,105 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+	pullerMock "github.com/ethersphere/bee/v2/pkg/puller/mock"
+	chunk "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+
+	baseAddr := swarm.RandAddress(t)
+	ctx := context.Background()
+	basePath := t.TempDir()
+
+	opts := dbTestOps(baseAddr, 10_000, nil, nil, time.Minute)
+	opts.CacheCapacity = 100
+
+	st, err := storer.New(ctx, basePath, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.StartReserveWorker(ctx, pullerMock.NewMockRateReporter(0), networkRadiusFunc(0))
+
+	batches := []*postage.Batch{postagetesting.MustNewBatch(), postagetesting.MustNewBatch()}
+	evictBatch := batches[1]
+
+	putter := st.ReservePutter()
+	for _, b := range batches {
+		for i := 0; i < 50; i++ {
+			ch := chunk.GenerateTestRandomChunk().WithStamp(postagetesting.MustNewBatchStamp(b.ID))
+			if err := putter.Put(ctx, ch); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	for i := 0; i < 10; i++ {
+		if err := st.Cache().Put(ctx, chunk.GenerateTestRandomChunk()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, unsub := st.Events().Subscribe("batchExpiryDone")
+	t.Cleanup(unsub)
+	if err := st.EvictBatch(ctx, evictBatch.ID); err != nil {
+		t.Fatal(err)
+	}
+	<-c
+
+	if err := st.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := storer.Analyze(ctx, basePath, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Reserve.Chunks != 50 {
+		t.Fatalf("got %d reserve chunks, want 50", a.Reserve.Chunks)
+	}
+	binned := 0
+	for _, n := range a.Reserve.Bins {
+		binned += n
+	}
+	if binned != 50 {
+		t.Fatalf("got %d chunks in bins, want 50", binned)
+	}
+	if got := a.Reserve.Batches[hex.EncodeToString(batches[0].ID)]; got != 50 || len(a.Reserve.Batches) != 1 {
+		t.Fatalf("got batches %v", a.Reserve.Batches)
+	}
+	if a.Cache.Entries != 10 {
+		t.Fatalf("got %d cache entries, want 10", a.Cache.Entries)
+	}
+	if got := a.Cache.AccessAges[0]; got != 10 {
+		t.Fatalf("got %d recently accessed cache entries, want 10", got)
+	}
+
+	used, holes := 0, 0
+	for _, s := range a.Sharky.Shards {
+		used += s.Used
+		holes += s.Holes
+	}
+	if used != 60 {
+		t.Fatalf("got %d used slots, want 60", used)
+	}
+	if holes == 0 {
+		t.Fatal("want holes of the evicted batch chunks")
+	}
+	if a.Consistency.Total() != 0 {
+		t.Fatalf("got inconsistencies %+v", a.Consistency)
+	}
+}
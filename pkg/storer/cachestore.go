@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/cac"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -54,6 +55,12 @@ func (db *DB) cacheWorker(ctx context.Context) {
 				db.logger.Debug("cache eviction finished", "evicted", evict, "duration_sec", dur())
 				db.metrics.MethodCalls.WithLabelValues("cachestore", "RemoveOldest", "success").Inc()
 			}
+			if db.cacheObj.Size() >= size {
+				// nothing was evicted as the remaining chunks are retained,
+				// the next cached chunk triggers the eviction again
+				db.metrics.CacheSize.Set(float64(db.cacheObj.Size()))
+				continue
+			}
 			db.triggerCacheEviction()
 		case <-db.quit:
 			return
@@ -61,6 +68,55 @@ func (db *DB) cacheWorker(ctx context.Context) {
 	}
 }
 
+type cacheTTLKey struct{}
+type noCacheKey struct{}
+
+// SetCacheTTL returns the context with which the Download getter retrieves
+// the mutable chunks again from the network if their cached copies are older
+// than the ttl. The cached copies are refreshed with the retrieved chunks.
+func SetCacheTTL(ctx context.Context, ttl time.Duration) context.Context {
+	return context.WithValue(ctx, cacheTTLKey{}, ttl)
+}
+
+func getCacheTTL(ctx context.Context) time.Duration {
+	ttl, _ := ctx.Value(cacheTTLKey{}).(time.Duration)
+	return ttl
+}
+
+// SetNoCache returns the context with which the Download getter retrieves the
+// mutable chunks from the network even if they are found locally and refreshes
+// the cached copies with them.
+func SetNoCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+func getNoCache(ctx context.Context) bool {
+	v, _ := ctx.Value(noCacheKey{}).(bool)
+	return v
+}
+
+// stale reports whether the chunk found locally should be retrieved again
+// from the network under the caching rules of the context. Only the mutable
+// chunks, that are single owner chunks, may become stale.
+func (db *DB) stale(ctx context.Context, ch swarm.Chunk) bool {
+	noCache, ttl := getNoCache(ctx), getCacheTTL(ctx)
+	if !noCache && ttl <= 0 {
+		return false
+	}
+	if cac.Valid(ch) {
+		return false
+	}
+	if noCache {
+		return true
+	}
+	storedAt, cached, err := db.cacheObj.StoredAt(db.storage, ch.Address())
+	if err != nil {
+		db.logger.Debug("cached chunk stored at failed", "chunk_address", ch.Address(), "error", err)
+		return false
+	}
+	return cached && time.Since(storedAt) > ttl
+}
+
 // Lookup is the implementation of the CacheStore.Lookup method.
 func (db *DB) Lookup() storage.Getter {
 	return getterWithMetrics{
@@ -99,6 +155,19 @@ func (db *DB) Cache() storage.Putter {
 	}
 }
 
+// Retain is the implementation of the CacheStore.Retain method.
+func (db *DB) Retain(ctx context.Context, ttl time.Duration, addrs ...swarm.Address) (int, error) {
+	dur := captureDuration(time.Now())
+	retained, err := db.cacheObj.Retain(ctx, db.storage, ttl, addrs...)
+	db.metrics.MethodCallsDuration.WithLabelValues("cachestore", "Retain").Observe(dur())
+	if err != nil {
+		db.metrics.MethodCalls.WithLabelValues("cachestore", "Retain", "failure").Inc()
+		return retained, fmt.Errorf("cache retain: %w", err)
+	}
+	db.metrics.MethodCalls.WithLabelValues("cachestore", "Retain", "success").Inc()
+	return retained, nil
+}
+
 // CacheShallowCopy creates cache entries with the expectation that the chunk already exists in the chunkstore.
 func (db *DB) CacheShallowCopy(ctx context.Context, store transaction.Storage, addrs ...swarm.Address) error {
 	defer db.triggerCacheEviction()
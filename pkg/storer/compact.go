@@ -24,7 +24,7 @@ func Compact(ctx context.Context, basePath string, opts *Options, validate bool)
 
 	store, err := initStore(basePath, opts)
 	if err != nil {
-		return fmt.Errorf("failed creating levelDB index store: %w", err)
+		return fmt.Errorf("failed creating index store: %w", err)
 	}
 	defer func() {
 		if err := store.Close(); err != nil {
@@ -5,6 +5,7 @@
 package storer
 
 import (
+	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/events"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/reserve"
 )
@@ -35,3 +36,7 @@ func (db *DB) WaitForBgCacheWorkers() (unblock func()) {
 func DefaultOptions() *Options {
 	return defaultOptions()
 }
+
+func PebbleLoggerFatalf(logger log.Logger, format string, args ...interface{}) {
+	pebbleLogger{logger}.Fatalf(format, args...)
+}
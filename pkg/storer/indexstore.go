@@ -0,0 +1,338 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	"github.com/ethersphere/bee/v2/pkg/storage/pebblestore"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/filter"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// Supported backends of the index store.
+const (
+	IndexStoreLevelDB = "leveldb"
+	IndexStorePebble  = "pebble"
+)
+
+const (
+	pebbleIndexPath = "indexstore-pebble"
+
+	// migratingSuffix marks the index store which is being filled by an
+	// unfinished migration. Such a store is discarded on the next start.
+	migratingSuffix = ".migrating"
+
+	// indexStoreMigrationBatchSize is the number of entries
+	// written in a single batch during the index store migration.
+	indexStoreMigrationBatchSize = 10_000
+)
+
+var errUnknownIndexStoreBackend = errors.New("unknown index store backend")
+
+// exists reports whether the given path exists.
+func exists(p string) (bool, error) {
+	_, err := os.Stat(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// indexStoreBackend returns the index store backend configured in the options.
+// If the backend is not configured, the backend of the index store found at
+// the basePath is returned, falling back to the levelDB backend.
+func indexStoreBackend(basePath string, opts *Options) (string, error) {
+	if opts.IndexStoreBackend != "" {
+		return opts.IndexStoreBackend, nil
+	}
+
+	found, err := exists(path.Join(basePath, pebbleIndexPath))
+	if err != nil {
+		return "", err
+	}
+	if found {
+		return IndexStorePebble, nil
+	}
+	return IndexStoreLevelDB, nil
+}
+
+func openLevelDBStore(storePath string, opts *Options) (*leveldbstore.Store, error) {
+	if err := os.MkdirAll(storePath, 0777); err != nil {
+		return nil, err
+	}
+
+	store, err := leveldbstore.New(storePath, &opt.Options{
+		OpenFilesCacheCapacity: int(opts.LdbOpenFilesLimit),
+		BlockCacheCapacity:     int(opts.LdbBlockCacheCapacity),
+		WriteBuffer:            int(opts.LdbWriteBufferSize),
+		DisableSeeksCompaction: opts.LdbDisableSeeksCompaction,
+		CompactionL0Trigger:    8,
+		Filter:                 filter.NewBloomFilter(64),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed creating levelDB index store: %w", err)
+	}
+
+	return store, nil
+}
+
+func openPebbleStore(storePath string, opts *Options) (*pebblestore.Store, error) {
+	cache := pebble.NewCache(int64(opts.LdbBlockCacheCapacity))
+	defer cache.Unref()
+
+	pebbleOpts := &pebble.Options{
+		Cache:                 cache,
+		MaxOpenFiles:          int(opts.LdbOpenFilesLimit),
+		MemTableSize:          opts.LdbWriteBufferSize,
+		L0CompactionThreshold: 8,
+		Levels:                []pebble.LevelOptions{{FilterPolicy: bloom.FilterPolicy(10)}},
+		Logger:                pebbleLogger{opts.Logger},
+	}
+
+	store, err := pebblestore.New(storePath, pebbleOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating pebble index store: %w", err)
+	}
+
+	return store, nil
+}
+
+// initStore opens the index store with the configured backend. If the index
+// store exists only in the other backend, its content is migrated first.
+func initStore(basePath string, opts *Options) (storage.BatchStore, error) {
+	backend, err := indexStoreBackend(basePath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ldbPath := path.Join(basePath, indexPath)
+	pebblePath := path.Join(basePath, pebbleIndexPath)
+
+	openLevelDB := func(p string) (rawIndexStore, error) {
+		s, err := openLevelDBStore(p, opts)
+		return levelDBRawStore{s}, err
+	}
+	openPebble := func(p string) (rawIndexStore, error) {
+		s, err := openPebbleStore(p, opts)
+		return pebbleRawStore{s}, err
+	}
+
+	switch backend {
+	case IndexStoreLevelDB:
+		if err := migrateIndexStore(pebblePath, ldbPath, opts, openPebble, openLevelDB); err != nil {
+			return nil, fmt.Errorf("migrate index store to %s: %w", backend, err)
+		}
+		store, err := openLevelDBStore(ldbPath, opts)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case IndexStorePebble:
+		if err := migrateIndexStore(ldbPath, pebblePath, opts, openLevelDB, openPebble); err != nil {
+			return nil, fmt.Errorf("migrate index store to %s: %w", backend, err)
+		}
+		store, err := openPebbleStore(pebblePath, opts)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownIndexStoreBackend, backend)
+	}
+}
+
+// rawIndexStore provides access to the raw key-value
+// pairs of an index store and is used during migration.
+type rawIndexStore interface {
+	// iterate calls fn for every key-value pair in the store.
+	iterate(fn func(key, value []byte) error) error
+	// write stores the given key-value pairs atomically.
+	write(keys, values [][]byte) error
+	Close() error
+}
+
+// migrateIndexStore copies all the entries from the index store at srcPath to
+// a newly created index store at dstPath. The migration is skipped if there is
+// nothing to migrate or the destination store already exists. On success, the
+// source store is removed.
+func migrateIndexStore(
+	srcPath, dstPath string,
+	opts *Options,
+	openSrc, openDst func(string) (rawIndexStore, error),
+) error {
+	tmpPath := dstPath + migratingSuffix
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("remove unfinished migration: %w", err)
+	}
+
+	srcFound, err := exists(srcPath)
+	if err != nil {
+		return err
+	}
+	dstFound, err := exists(dstPath)
+	if err != nil {
+		return err
+	}
+	if !srcFound || dstFound {
+		return nil
+	}
+
+	logger := opts.Logger.WithName(loggerName).Register()
+	logger.Info("migrating index store", "from", srcPath, "to", dstPath)
+	start := time.Now()
+
+	src, err := openSrc(srcPath)
+	if err != nil {
+		return err
+	}
+	dst, err := openDst(tmpPath)
+	if err != nil {
+		return errors.Join(err, src.Close())
+	}
+
+	count, err := copyIndexStore(src, dst)
+	if err = errors.Join(err, dst.Close(), src.Close()); err != nil {
+		return errors.Join(err, os.RemoveAll(tmpPath))
+	}
+
+	if err := os.Rename(tmpPath, dstPath); err != nil {
+		return err
+	}
+
+	logger.Info("index store migrated", "entries", count, "elapsed", time.Since(start))
+
+	return os.RemoveAll(srcPath)
+}
+
+// copyIndexStore copies all the entries from src to dst in batches
+// and returns the number of copied entries.
+func copyIndexStore(src, dst rawIndexStore) (int, error) {
+	var (
+		count  int
+		keys   = make([][]byte, 0, indexStoreMigrationBatchSize)
+		values = make([][]byte, 0, indexStoreMigrationBatchSize)
+	)
+
+	flush := func() error {
+		if err := dst.write(keys, values); err != nil {
+			return err
+		}
+		count += len(keys)
+		keys, values = keys[:0], values[:0]
+		return nil
+	}
+
+	err := src.iterate(func(key, value []byte) error {
+		keys = append(keys, key)
+		values = append(values, value)
+		if len(keys) < indexStoreMigrationBatchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return count, err
+	}
+
+	return count, flush()
+}
+
+type levelDBRawStore struct {
+	*leveldbstore.Store
+}
+
+func (s levelDBRawStore) iterate(fn func(key, value []byte) error) error {
+	iter := s.DB().NewIterator(nil, &opt.ReadOptions{DontFillCache: true})
+	defer iter.Release()
+
+	for iter.Next() {
+		key := make([]byte, len(iter.Key()))
+		copy(key, iter.Key())
+		value := make([]byte, len(iter.Value()))
+		copy(value, iter.Value())
+
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+func (s levelDBRawStore) write(keys, values [][]byte) error {
+	batch := new(leveldb.Batch)
+	for i := range keys {
+		batch.Put(keys[i], values[i])
+	}
+	return s.DB().Write(batch, nil)
+}
+
+type pebbleRawStore struct {
+	*pebblestore.Store
+}
+
+func (s pebbleRawStore) iterate(fn func(key, value []byte) error) (err error) {
+	iter, err := s.DB().NewIter(nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, iter.Close())
+	}()
+
+	for valid := iter.First(); valid; valid = iter.Next() {
+		key := make([]byte, len(iter.Key()))
+		copy(key, iter.Key())
+		value := make([]byte, len(iter.Value()))
+		copy(value, iter.Value())
+
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+func (s pebbleRawStore) write(keys, values [][]byte) error {
+	batch := s.DB().NewBatch()
+	defer func() { _ = batch.Close() }()
+
+	for i := range keys {
+		if err := batch.Set(keys[i], values[i], nil); err != nil {
+			return err
+		}
+	}
+	return batch.Commit(pebble.Sync)
+}
+
+// pebbleLogger adapts the log.Logger to the pebble.Logger interface.
+type pebbleLogger struct {
+	logger log.Logger
+}
+
+func (l pebbleLogger) Infof(format string, args ...interface{}) {
+	l.logger.Debug(fmt.Sprintf(format, args...))
+}
+
+func (l pebbleLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Error(nil, fmt.Sprintf(format, args...))
+}
+
+// Fatalf logs the message and panics, as pebble expects it not to return.
+func (l pebbleLogger) Fatalf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	l.logger.Error(nil, msg)
+	panic(msg)
+}
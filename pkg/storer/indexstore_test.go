@@ -0,0 +1,112 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	chunktesting "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestIndexStoreBackend(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		dir      = t.TempDir()
+		baseAddr = swarm.RandAddress(t)
+		chunks   = chunktesting.GenerateTestRandomChunks(10)
+	)
+
+	open := func(t *testing.T, backend string) *storer.DB {
+		t.Helper()
+
+		opts := dbTestOps(baseAddr, 0, nil, nil, time.Second)
+		opts.IndexStoreBackend = backend
+
+		db, err := storer.New(ctx, dir, opts)
+		if err != nil {
+			t.Fatalf("New(...): unexpected error: %v", err)
+		}
+		return db
+	}
+
+	assertIndexStore := func(t *testing.T, name string, want bool) {
+		t.Helper()
+
+		_, err := os.Stat(path.Join(dir, name))
+		if have := !errors.Is(err, os.ErrNotExist); have != want {
+			t.Fatalf("index store %q exists: want %t, have %t", name, want, have)
+		}
+	}
+
+	assertChunks := func(t *testing.T, db *storer.DB) {
+		t.Helper()
+
+		for _, ch := range chunks {
+			has, err := db.ChunkStore().Has(ctx, ch.Address())
+			if err != nil {
+				t.Fatalf("ChunkStore.Has(...): unexpected error: %v", err)
+			}
+			if !has {
+				t.Fatalf("chunk %s not found", ch.Address())
+			}
+		}
+	}
+
+	db := open(t, "")
+	for _, ch := range chunks {
+		if err := db.Cache().Put(ctx, ch); err != nil {
+			t.Fatalf("Cache.Put(...): unexpected error: %v", err)
+		}
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close(): unexpected error: %v", err)
+	}
+	assertIndexStore(t, "indexstore", true)
+	assertIndexStore(t, "indexstore-pebble", false)
+
+	for _, tc := range []struct {
+		backend       string
+		levelDBExists bool
+	}{
+		{backend: storer.IndexStorePebble},
+		{backend: ""},
+		{backend: storer.IndexStoreLevelDB, levelDBExists: true},
+	} {
+		db := open(t, tc.backend)
+		assertChunks(t, db)
+		if err := db.Close(); err != nil {
+			t.Fatalf("Close(): unexpected error: %v", err)
+		}
+		assertIndexStore(t, "indexstore", tc.levelDBExists)
+		assertIndexStore(t, "indexstore-pebble", !tc.levelDBExists)
+	}
+
+	opts := dbTestOps(baseAddr, 0, nil, nil, time.Second)
+	opts.IndexStoreBackend = "unknown"
+	if _, err := storer.New(ctx, dir, opts); err == nil {
+		t.Fatal("New(...): expected error for an unknown backend")
+	}
+}
+
+func TestPebbleLoggerFatalf(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r != "index store corrupted: 1" {
+			t.Fatalf("want panic %q, have %v", "index store corrupted: 1", r)
+		}
+	}()
+	storer.PebbleLoggerFatalf(log.Noop, "index store corrupted: %d", 1)
+}
@@ -5,6 +5,7 @@
 package cache
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"errors"
@@ -14,7 +15,9 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/cac"
 	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"golang.org/x/sync/errgroup"
@@ -89,7 +92,14 @@ func (c *Cache) Putter(store transaction.Storage) storage.Putter {
 
 		// if chunk is already part of cache, return found.
 		if found {
-			return nil
+			// the data of the mutable chunks is refreshed
+			if cac.Valid(chunk) {
+				return nil
+			}
+			if err := refresh(ctx, trx, chunk); err != nil {
+				return fmt.Errorf("failed refreshing cached chunk: %w", err)
+			}
+			return trx.Commit()
 		}
 
 		newEntry.AccessTimestamp = now().UnixNano()
@@ -121,6 +131,38 @@ func (c *Cache) Putter(store transaction.Storage) storage.Putter {
 	})
 }
 
+// refresh replaces the data of the cached chunk if it changed and renews the
+// time the data was stored at.
+func refresh(ctx context.Context, trx transaction.Transaction, chunk swarm.Chunk) error {
+	old, err := trx.ChunkStore().Get(ctx, chunk.Address())
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(old.Data(), chunk.Data()) {
+		return trx.ChunkStore().Replace(ctx, chunk, false)
+	}
+	rIdx := &chunkstore.RetrievalIndexItem{Address: chunk.Address()}
+	if err := trx.IndexStore().Get(rIdx); err != nil {
+		return err
+	}
+	rIdx.Timestamp = uint64(now().Unix())
+	return trx.IndexStore().Put(rIdx)
+}
+
+// StoredAt returns the time the data of the cached chunk was stored at and
+// false if the chunk is not in the cache.
+func (c *Cache) StoredAt(store transaction.ReadOnlyStore, address swarm.Address) (time.Time, bool, error) {
+	found, err := store.IndexStore().Has(&cacheEntry{Address: address})
+	if err != nil || !found {
+		return time.Time{}, false, err
+	}
+	rIdx := &chunkstore.RetrievalIndexItem{Address: address}
+	if err := store.IndexStore().Get(rIdx); err != nil {
+		return time.Time{}, false, err
+	}
+	return time.Unix(int64(rIdx.Timestamp), 0), true, nil
+}
+
 // Getter returns a Storage.Getter instance which checks if the chunks accessed are
 // part of cache it will update the cache indexes. If the operation to update the
 // cache indexes fail, we need to fail the operation as this should signal the user
@@ -200,6 +242,13 @@ func (c *Cache) RemoveOldest(ctx context.Context, st transaction.Storage, count
 			if err != nil {
 				return false, fmt.Errorf("failed to parse cache order index %s: %w", res.ID, err)
 			}
+			retained, err := isRetained(st.IndexStore(), addr)
+			if err != nil {
+				return false, fmt.Errorf("failed checking retention of %s: %w", addr, err)
+			}
+			if retained {
+				return false, nil
+			}
 			entry := &cacheEntry{
 				Address:         addr,
 				AccessTimestamp: accessTime,
@@ -228,6 +277,7 @@ func (c *Cache) RemoveOldest(ctx context.Context, st transaction.Storage, count
 							Address:         item.Address,
 							AccessTimestamp: item.AccessTimestamp,
 						}),
+						s.IndexStore().Delete(&retentionEntry{Address: item.Address}),
 						s.ChunkStore().Delete(ctx, item.Address),
 					)
 				})
@@ -243,6 +293,65 @@ func (c *Cache) RemoveOldest(ctx context.Context, st transaction.Storage, count
 	return eg.Wait()
 }
 
+// Retain protects the cached chunks of the addresses from the eviction until
+// the ttl elapses. The retention of a chunk is only ever extended and the
+// addresses that are not cached are skipped. It returns the number of the
+// retained chunks.
+func (c *Cache) Retain(ctx context.Context, st transaction.Storage, ttl time.Duration, addrs ...swarm.Address) (int, error) {
+	until := now().Add(ttl).UnixNano()
+
+	retained := 0
+	for _, addr := range addrs {
+		found, err := c.retain(ctx, st, addr, until)
+		if err != nil {
+			return retained, fmt.Errorf("failed retaining %s: %w", addr, err)
+		}
+		if found {
+			retained++
+		}
+	}
+	return retained, nil
+}
+
+func (c *Cache) retain(ctx context.Context, st transaction.Storage, addr swarm.Address, until int64) (bool, error) {
+	c.glock.Lock(addr.ByteString())
+	defer c.glock.Unlock(addr.ByteString())
+
+	found := false
+	err := st.Run(ctx, func(s transaction.Store) error {
+		has, err := s.IndexStore().Has(&cacheEntry{Address: addr})
+		if err != nil || !has {
+			return err
+		}
+		found = true
+
+		entry := &retentionEntry{Address: addr}
+		switch err := s.IndexStore().Get(entry); {
+		case errors.Is(err, storage.ErrNotFound):
+		case err != nil:
+			return err
+		case entry.Until >= until:
+			return nil
+		}
+		entry.Until = until
+		return s.IndexStore().Put(entry)
+	})
+	return found, err
+}
+
+// isRetained reports whether the cached chunk is protected from the eviction.
+func isRetained(store storage.Reader, addr swarm.Address) (bool, error) {
+	entry := &retentionEntry{Address: addr}
+	err := store.Get(entry)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return entry.Until > now().UnixNano(), nil
+}
+
 // ShallowCopy creates cache entries with the expectation that the chunk already exists in the chunkstore.
 func (c *Cache) ShallowCopy(
 	ctx context.Context,
@@ -369,6 +478,59 @@ func (c cacheEntry) String() string {
 	)
 }
 
+var _ storage.Item = (*retentionEntry)(nil)
+
+const retentionEntrySize = swarm.HashSize + 8
+
+var errUnmarshalRetentionEntryInvalidSize = errors.New("unmarshal retentionEntry: invalid size")
+
+// retentionEntry protects the cached chunk from the eviction until the time.
+type retentionEntry struct {
+	Address swarm.Address
+	Until   int64
+}
+
+func (r *retentionEntry) ID() string { return r.Address.ByteString() }
+
+func (retentionEntry) Namespace() string { return "cacheRetention" }
+
+func (r *retentionEntry) Marshal() ([]byte, error) {
+	if r.Address.IsZero() {
+		return nil, errMarshalCacheEntryInvalidAddress
+	}
+	buf := make([]byte, retentionEntrySize)
+	copy(buf[:swarm.HashSize], r.Address.Bytes())
+	binary.LittleEndian.PutUint64(buf[swarm.HashSize:], uint64(r.Until))
+	return buf, nil
+}
+
+func (r *retentionEntry) Unmarshal(buf []byte) error {
+	if len(buf) != retentionEntrySize {
+		return errUnmarshalRetentionEntryInvalidSize
+	}
+	r.Address = swarm.NewAddress(append(make([]byte, 0, swarm.HashSize), buf[:swarm.HashSize]...))
+	r.Until = int64(binary.LittleEndian.Uint64(buf[swarm.HashSize:]))
+	return nil
+}
+
+func (r *retentionEntry) Clone() storage.Item {
+	if r == nil {
+		return nil
+	}
+	return &retentionEntry{
+		Address: r.Address.Clone(),
+		Until:   r.Until,
+	}
+}
+
+func (r retentionEntry) String() string {
+	return fmt.Sprintf(
+		"retentionEntry { Address: %s Until: %s }",
+		r.Address,
+		time.Unix(0, r.Until).UTC().Format(time.RFC3339),
+	)
+}
+
 var _ storage.Item = (*cacheOrderIndex)(nil)
 
 type cacheOrderIndex struct {
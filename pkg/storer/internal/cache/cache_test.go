@@ -329,6 +329,53 @@ func TestRemoveOldest(t *testing.T) {
 	verifyChunksDeleted(t, st.ChunkStore(), chunks...)
 }
 
+func TestRetain(t *testing.T) {
+	t.Parallel()
+
+	st := newTestStorage(t)
+	c, err := cache.New(context.Background(), st.IndexStore(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := chunktest.GenerateTestRandomChunks(10)
+	for _, ch := range chunks {
+		err = c.Putter(st).Put(context.Background(), ch)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	retained, err := c.Retain(context.Background(), st, time.Hour, chunks[0].Address(), chunks[1].Address(), swarm.RandAddress(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retained != 2 {
+		t.Fatalf("got %d retained chunks, want 2", retained)
+	}
+
+	// the retention is not shortened
+	_, err = c.Retain(context.Background(), st, time.Nanosecond, chunks[0].Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the retention expires
+	_, err = c.Retain(context.Background(), st, time.Nanosecond, chunks[2].Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.RemoveOldest(context.Background(), st, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifyCacheState(t, st.IndexStore(), c, chunks[0].Address(), chunks[1].Address(), 2)
+	verifyChunksExist(t, st.ChunkStore(), chunks[:2]...)
+	verifyChunksDeleted(t, st.ChunkStore(), chunks[2:]...)
+}
+
 func TestShallowCopy(t *testing.T) {
 	t.Parallel()
 
@@ -627,3 +674,6 @@ func (t *inmemStorage) Run(ctx context.Context, f func(s transaction.Store) erro
 	defer done()
 	return f(trx)
 }
+func (t *inmemStorage) RunBatch(ctx context.Context, f func(s transaction.Store) error) error {
+	return t.Run(ctx, f)
+}
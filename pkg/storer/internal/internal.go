@@ -83,3 +83,6 @@ func (t *inmemStorage) Run(ctx context.Context, f func(s transaction.Store) erro
 	defer done()
 	return f(trx)
 }
+func (t *inmemStorage) RunBatch(ctx context.Context, f func(s transaction.Store) error) error {
+	return t.Run(ctx, f)
+}
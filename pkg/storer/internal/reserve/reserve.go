@@ -10,11 +10,14 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"maps"
 	"runtime"
+	"slices"
 	"strconv"
 	"sync/atomic"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/chunktrace"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/storage"
@@ -40,6 +43,8 @@ type Reserve struct {
 
 	multx *multex.Multex
 	st    transaction.Storage
+
+	chunkTracer *chunktrace.Tracer
 }
 
 func New(
@@ -117,8 +122,6 @@ func (r *Reserve) Put(ctx context.Context, chunk swarm.Chunk) error {
 		return nil
 	}
 
-	chunkType := storage.ChunkType(chunk)
-
 	bin := swarm.Proximity(r.baseAddr.Bytes(), chunk.Address().Bytes())
 
 	// bin lock
@@ -128,174 +131,262 @@ func (r *Reserve) Put(ctx context.Context, chunk swarm.Chunk) error {
 	var shouldIncReserveSize bool
 
 	err = r.st.Run(ctx, func(s transaction.Store) error {
+		shouldIncReserveSize, err = r.put(ctx, s, chunk, bin, stampHash)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if shouldIncReserveSize {
+		r.size.Add(1)
+	}
+	return nil
+}
+
+// PutMany puts the chunks to the reserve like Put, but with as few
+// transactions as possible. The chunks sharing the stamp index with a chunk
+// put earlier in the same call are put in a new transaction.
+// The returned slice holds the errors of the chunks which were rejected
+// because a newer chunk with the same stamp index is stored, without failing
+// the other chunks. Any other error fails the transaction of the chunk and
+// the remaining chunks.
+func (r *Reserve) PutMany(ctx context.Context, chunks []swarm.Chunk) ([]error, error) {
+	type putItem struct {
+		chunk     swarm.Chunk
+		bin       uint8
+		stampHash []byte
+	}
 
-		oldStampIndex, loadedStampIndex, err := stampindex.LoadOrStore(s.IndexStore(), reserveScope, chunk)
+	items := make([]putItem, 0, len(chunks))
+	batchIDs := make(map[string]struct{})
+	bins := make(map[uint8]struct{})
+	for _, chunk := range chunks {
+		stampHash, err := chunk.Stamp().Hash()
 		if err != nil {
-			return fmt.Errorf("load or store stamp index for chunk %v has fail: %w", chunk, err)
+			return nil, err
 		}
+		bin := swarm.Proximity(r.baseAddr.Bytes(), chunk.Address().Bytes())
+		items = append(items, putItem{chunk, bin, stampHash})
+		batchIDs[string(chunk.Stamp().BatchID())] = struct{}{}
+		bins[bin] = struct{}{}
+	}
 
-		// index collision
-		if loadedStampIndex {
-
-			prev := binary.BigEndian.Uint64(oldStampIndex.StampTimestamp)
-			curr := binary.BigEndian.Uint64(chunk.Stamp().Timestamp())
-			if prev >= curr {
-				return fmt.Errorf("overwrite same chunk. prev %d cur %d batch %s: %w", prev, curr, hex.EncodeToString(chunk.Stamp().BatchID()), storage.ErrOverwriteNewerChunk)
-			}
-
-			r.logger.Debug(
-				"replacing chunk stamp index",
-				"old_chunk", oldStampIndex.ChunkAddress,
-				"new_chunk", chunk.Address(),
-				"batch_id", hex.EncodeToString(chunk.Stamp().BatchID()),
-			)
-
-			// same chunk address
-			if oldStampIndex.ChunkAddress.Equal(chunk.Address()) {
+	// lock the batches and then the bins in order, same as Put
+	for _, batchID := range slices.Sorted(maps.Keys(batchIDs)) {
+		r.multx.Lock(batchID)
+		defer r.multx.Unlock(batchID)
+	}
+	for _, bin := range slices.Sorted(maps.Keys(bins)) {
+		r.multx.Lock(strconv.Itoa(int(bin)))
+		defer r.multx.Unlock(strconv.Itoa(int(bin)))
+	}
 
-				oldStamp, err := chunkstamp.LoadWithStampHash(s.IndexStore(), reserveScope, oldStampIndex.ChunkAddress, oldStampIndex.StampHash)
-				if err != nil {
-					return err
+	errs := make([]error, len(items))
+	for i := 0; i < len(items); {
+		var added int64
+		err := r.st.RunBatch(ctx, func(s transaction.Store) error {
+			// the stamp index collisions are resolved with the committed items
+			stampIndexes := make(map[string]struct{})
+			for ; i < len(items); i++ {
+				item := items[i]
+				stamp := item.chunk.Stamp()
+				key := string(stamp.BatchID()) + string(stamp.Index())
+				if _, ok := stampIndexes[key]; ok {
+					return nil
 				}
+				stampIndexes[key] = struct{}{}
 
-				oldBatchRadiusItem := &BatchRadiusItem{
-					Bin:       bin,
-					Address:   oldStampIndex.ChunkAddress,
-					BatchID:   oldStampIndex.BatchID,
-					StampHash: oldStampIndex.StampHash,
-				}
-				// load item to get the binID
-				err = s.IndexStore().Get(oldBatchRadiusItem)
+				// check if the chunk with the same batch, stamp timestamp and index is already stored
+				has, err := s.IndexStore().Has(&BatchRadiusItem{Bin: item.bin, BatchID: stamp.BatchID(), Address: item.chunk.Address(), StampHash: item.stampHash})
 				if err != nil {
 					return err
 				}
-
-				// delete old chunk index items
-				err = errors.Join(
-					s.IndexStore().Delete(oldBatchRadiusItem),
-					s.IndexStore().Delete(&ChunkBinItem{Bin: oldBatchRadiusItem.Bin, BinID: oldBatchRadiusItem.BinID}),
-					stampindex.Delete(s.IndexStore(), reserveScope, oldStamp),
-					chunkstamp.DeleteWithStamp(s.IndexStore(), reserveScope, oldBatchRadiusItem.Address, oldStamp),
-				)
-				if err != nil {
-					return err
+				if has {
+					continue
 				}
 
-				binID, err := r.IncBinID(s.IndexStore(), bin)
+				inc, err := r.put(ctx, s, item.chunk, item.bin, item.stampHash)
 				if err != nil {
+					if errors.Is(err, storage.ErrOverwriteNewerChunk) {
+						errs[i] = err
+						continue
+					}
 					return err
 				}
-
-				err = errors.Join(
-					stampindex.Store(s.IndexStore(), reserveScope, chunk),
-					chunkstamp.Store(s.IndexStore(), reserveScope, chunk),
-					s.IndexStore().Put(&BatchRadiusItem{
-						Bin:       bin,
-						BinID:     binID,
-						Address:   chunk.Address(),
-						BatchID:   chunk.Stamp().BatchID(),
-						StampHash: stampHash,
-					}),
-					s.IndexStore().Put(&ChunkBinItem{
-						Bin:       bin,
-						BinID:     binID,
-						Address:   chunk.Address(),
-						BatchID:   chunk.Stamp().BatchID(),
-						ChunkType: chunkType,
-						StampHash: stampHash,
-					}),
-				)
-				if err != nil {
-					return err
+				if inc {
+					added++
 				}
+			}
+			return nil
+		})
+		if err != nil {
+			return errs, err
+		}
+		r.size.Add(added)
+	}
+	return errs, nil
+}
 
-				if chunkType == swarm.ChunkTypeSingleOwner {
-					r.logger.Debug("replacing soc in chunkstore", "address", chunk.Address())
-					return s.ChunkStore().Replace(ctx, chunk, false)
-				}
+// put stores the chunk in the reserve with the transaction and
+// reports whether the reserve size increased.
+func (r *Reserve) put(ctx context.Context, s transaction.Store, chunk swarm.Chunk, bin uint8, stampHash []byte) (bool, error) {
+	chunkType := storage.ChunkType(chunk)
 
-				return nil
-			}
+	oldStampIndex, loadedStampIndex, err := stampindex.LoadOrStore(s.IndexStore(), reserveScope, chunk)
+	if err != nil {
+		return false, fmt.Errorf("load or store stamp index for chunk %v has fail: %w", chunk, err)
+	}
+
+	// index collision
+	if loadedStampIndex {
+
+		prev := binary.BigEndian.Uint64(oldStampIndex.StampTimestamp)
+		curr := binary.BigEndian.Uint64(chunk.Stamp().Timestamp())
+		if prev >= curr {
+			return false, fmt.Errorf("overwrite same chunk. prev %d cur %d batch %s: %w", prev, curr, hex.EncodeToString(chunk.Stamp().BatchID()), storage.ErrOverwriteNewerChunk)
+		}
+
+		r.logger.Debug(
+			"replacing chunk stamp index",
+			"old_chunk", oldStampIndex.ChunkAddress,
+			"new_chunk", chunk.Address(),
+			"batch_id", hex.EncodeToString(chunk.Stamp().BatchID()),
+		)
 
-			// An older and different chunk with the same batchID and stamp index has been previously
-			// saved to the reserve. We must do the below before saving the new chunk:
-			// 1. Delete the old chunk from the chunkstore.
-			// 2. Delete the old chunk's stamp data.
-			// 3. Delete ALL old chunk related items from the reserve.
-			// 4. Update the stamp index.
+		// same chunk address
+		if oldStampIndex.ChunkAddress.Equal(chunk.Address()) {
 
-			err = r.removeChunk(ctx, s, oldStampIndex.ChunkAddress, oldStampIndex.BatchID, oldStampIndex.StampHash)
+			oldStamp, err := chunkstamp.LoadWithStampHash(s.IndexStore(), reserveScope, oldStampIndex.ChunkAddress, oldStampIndex.StampHash)
 			if err != nil {
-				return fmt.Errorf("failed removing older chunk %s: %w", oldStampIndex.ChunkAddress, err)
+				return false, err
 			}
 
-			// replace old stamp index.
-			err = stampindex.Store(s.IndexStore(), reserveScope, chunk)
+			oldBatchRadiusItem := &BatchRadiusItem{
+				Bin:       bin,
+				Address:   oldStampIndex.ChunkAddress,
+				BatchID:   oldStampIndex.BatchID,
+				StampHash: oldStampIndex.StampHash,
+			}
+			// load item to get the binID
+			err = s.IndexStore().Get(oldBatchRadiusItem)
 			if err != nil {
-				return fmt.Errorf("failed updating stamp index: %w", err)
+				return false, err
 			}
-		}
 
-		binID, err := r.IncBinID(s.IndexStore(), bin)
-		if err != nil {
-			return err
-		}
+			// delete old chunk index items
+			err = errors.Join(
+				s.IndexStore().Delete(oldBatchRadiusItem),
+				s.IndexStore().Delete(&ChunkBinItem{Bin: oldBatchRadiusItem.Bin, BinID: oldBatchRadiusItem.BinID}),
+				stampindex.Delete(s.IndexStore(), reserveScope, oldStamp),
+				chunkstamp.DeleteWithStamp(s.IndexStore(), reserveScope, oldBatchRadiusItem.Address, oldStamp),
+			)
+			if err != nil {
+				return false, err
+			}
 
-		err = errors.Join(
-			chunkstamp.Store(s.IndexStore(), reserveScope, chunk),
-			s.IndexStore().Put(&BatchRadiusItem{
-				Bin:       bin,
-				BinID:     binID,
-				Address:   chunk.Address(),
-				BatchID:   chunk.Stamp().BatchID(),
-				StampHash: stampHash,
-			}),
-			s.IndexStore().Put(&ChunkBinItem{
-				Bin:       bin,
-				BinID:     binID,
-				Address:   chunk.Address(),
-				BatchID:   chunk.Stamp().BatchID(),
-				ChunkType: chunkType,
-				StampHash: stampHash,
-			}),
-		)
-		if err != nil {
-			return err
-		}
+			binID, err := r.IncBinID(s.IndexStore(), bin)
+			if err != nil {
+				return false, err
+			}
 
-		var has bool
-		if chunkType == swarm.ChunkTypeSingleOwner {
-			has, err = s.ChunkStore().Has(ctx, chunk.Address())
+			err = errors.Join(
+				stampindex.Store(s.IndexStore(), reserveScope, chunk),
+				chunkstamp.Store(s.IndexStore(), reserveScope, chunk),
+				s.IndexStore().Put(&BatchRadiusItem{
+					Bin:       bin,
+					BinID:     binID,
+					Address:   chunk.Address(),
+					BatchID:   chunk.Stamp().BatchID(),
+					StampHash: stampHash,
+				}),
+				s.IndexStore().Put(&ChunkBinItem{
+					Bin:       bin,
+					BinID:     binID,
+					Address:   chunk.Address(),
+					BatchID:   chunk.Stamp().BatchID(),
+					ChunkType: chunkType,
+					StampHash: stampHash,
+				}),
+			)
 			if err != nil {
-				return err
+				return false, err
 			}
-			if has {
+
+			if chunkType == swarm.ChunkTypeSingleOwner {
 				r.logger.Debug("replacing soc in chunkstore", "address", chunk.Address())
-				err = s.ChunkStore().Replace(ctx, chunk, true)
-			} else {
-				err = s.ChunkStore().Put(ctx, chunk)
+				return false, s.ChunkStore().Replace(ctx, chunk, false)
 			}
-		} else {
-			err = s.ChunkStore().Put(ctx, chunk)
+
+			return false, nil
 		}
 
+		// An older and different chunk with the same batchID and stamp index has been previously
+		// saved to the reserve. We must do the below before saving the new chunk:
+		// 1. Delete the old chunk from the chunkstore.
+		// 2. Delete the old chunk's stamp data.
+		// 3. Delete ALL old chunk related items from the reserve.
+		// 4. Update the stamp index.
+
+		err = r.removeChunk(ctx, s, oldStampIndex.ChunkAddress, oldStampIndex.BatchID, oldStampIndex.StampHash)
 		if err != nil {
-			return err
+			return false, fmt.Errorf("failed removing older chunk %s: %w", oldStampIndex.ChunkAddress, err)
 		}
 
-		if !loadedStampIndex {
-			shouldIncReserveSize = true
+		// replace old stamp index.
+		err = stampindex.Store(s.IndexStore(), reserveScope, chunk)
+		if err != nil {
+			return false, fmt.Errorf("failed updating stamp index: %w", err)
 		}
+	}
 
-		return nil
-	})
+	binID, err := r.IncBinID(s.IndexStore(), bin)
 	if err != nil {
-		return err
+		return false, err
 	}
-	if shouldIncReserveSize {
-		r.size.Add(1)
+
+	err = errors.Join(
+		chunkstamp.Store(s.IndexStore(), reserveScope, chunk),
+		s.IndexStore().Put(&BatchRadiusItem{
+			Bin:       bin,
+			BinID:     binID,
+			Address:   chunk.Address(),
+			BatchID:   chunk.Stamp().BatchID(),
+			StampHash: stampHash,
+		}),
+		s.IndexStore().Put(&ChunkBinItem{
+			Bin:       bin,
+			BinID:     binID,
+			Address:   chunk.Address(),
+			BatchID:   chunk.Stamp().BatchID(),
+			ChunkType: chunkType,
+			StampHash: stampHash,
+		}),
+	)
+	if err != nil {
+		return false, err
 	}
-	return nil
+
+	var has bool
+	if chunkType == swarm.ChunkTypeSingleOwner {
+		has, err = s.ChunkStore().Has(ctx, chunk.Address())
+		if err != nil {
+			return false, err
+		}
+		if has {
+			r.logger.Debug("replacing soc in chunkstore", "address", chunk.Address())
+			err = s.ChunkStore().Replace(ctx, chunk, true)
+		} else {
+			err = s.ChunkStore().Put(ctx, chunk)
+		}
+	} else {
+		err = s.ChunkStore().Put(ctx, chunk)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return !loadedStampIndex, nil
 }
 
 func (r *Reserve) Has(addr swarm.Address, batchID []byte, stampHash []byte) (bool, error) {
@@ -326,6 +417,21 @@ func (r *Reserve) Get(ctx context.Context, addr swarm.Address, batchID []byte, s
 	return ch.WithStamp(stamp), nil
 }
 
+// Stamp returns the stamp of the chunk without loading the chunk data.
+func (r *Reserve) Stamp(addr swarm.Address, batchID []byte, stampHash []byte) (swarm.Stamp, error) {
+	item := &BatchRadiusItem{Bin: swarm.Proximity(r.baseAddr.Bytes(), addr.Bytes()), BatchID: batchID, Address: addr, StampHash: stampHash}
+	if err := r.st.IndexStore().Get(item); err != nil {
+		return nil, err
+	}
+
+	return chunkstamp.LoadWithStampHash(r.st.IndexStore(), reserveScope, addr, stampHash)
+}
+
+// SetChunkTracer enables recording the evictions of the traced chunks.
+func (r *Reserve) SetChunkTracer(t *chunktrace.Tracer) {
+	r.chunkTracer = t
+}
+
 // EvictBatchBin evicts all chunks from bins upto the bin provided.
 func (r *Reserve) EvictBatchBin(
 	ctx context.Context,
@@ -375,6 +481,7 @@ func (r *Reserve) EvictBatchBin(
 				if err != nil {
 					return err
 				}
+				r.chunkTracer.Record(item.Address, chunktrace.SubsystemReserve, "evicted", "batch_id", hex.EncodeToString(item.BatchID), "bin", item.Bin)
 				evicted.Add(1)
 				return nil
 			})
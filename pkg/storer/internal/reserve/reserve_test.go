@@ -8,8 +8,11 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io/fs"
 	"math"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -17,8 +20,10 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+	"github.com/ethersphere/bee/v2/pkg/sharky"
 	soctesting "github.com/ethersphere/bee/v2/pkg/soc/testing"
 	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
 	chunk "github.com/ethersphere/bee/v2/pkg/storage/testing"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstamp"
@@ -528,6 +533,109 @@ func TestReplaceOldIndex(t *testing.T) {
 	}
 }
 
+func TestPutMany(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	baseAddr := swarm.RandAddress(t)
+
+	// the transactions of the inmem storage are not batched
+	sharkyStore, err := sharky.New(&dirFS{basedir: t.TempDir()}, 1, swarm.SocMaxChunkSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := leveldbstore.New("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := transaction.NewStorage(sharkyStore, store)
+	t.Cleanup(func() {
+		if err := ts.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	r, err := reserve.New(baseAddr, ts, 0, kademlia.NewTopologyDriver(), log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batch := postagetesting.MustNewBatch()
+	stored := chunk.GenerateTestRandomChunkAt(t, baseAddr, 0).WithStamp(postagetesting.MustNewFields(batch.ID, 100, 10))
+	if err := r.Put(ctx, stored); err != nil {
+		t.Fatal(err)
+	}
+
+	var chunks []swarm.Chunk
+	for b := 0; b < 2; b++ {
+		for i := 0; i < 10; i++ {
+			chunks = append(chunks, chunk.GenerateTestRandomChunkAt(t, baseAddr, b))
+		}
+	}
+	older := chunk.GenerateTestRandomChunkAt(t, baseAddr, 0).WithStamp(postagetesting.MustNewFields(batch.ID, 100, 5))
+	replaced := chunk.GenerateTestRandomChunkAt(t, baseAddr, 1).WithStamp(postagetesting.MustNewFields(batch.ID, 7, 1))
+	replacing := chunk.GenerateTestRandomChunkAt(t, baseAddr, 1).WithStamp(postagetesting.MustNewFields(batch.ID, 7, 2))
+	chunks = append(chunks, older, replaced, replacing, chunks[0])
+
+	errs, err := r.PutMany(ctx, chunks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, err := range errs {
+		if chunks[i] == older {
+			if !errors.Is(err, storage.ErrOverwriteNewerChunk) {
+				t.Fatalf("got error %v, want %v", err, storage.ErrOverwriteNewerChunk)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("chunk %d: %v", i, err)
+		}
+	}
+
+	for _, ch := range append(chunks[:20], stored, replacing) {
+		checkChunk(t, ts, ch, false)
+		stampHash, err := ch.Stamp().Hash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		bin := swarm.Proximity(baseAddr.Bytes(), ch.Address().Bytes())
+		checkStore(t, ts.IndexStore(), &reserve.BatchRadiusItem{Bin: bin, BatchID: ch.Stamp().BatchID(), Address: ch.Address(), StampHash: stampHash}, false)
+	}
+	checkChunk(t, ts, older, true)
+	checkChunk(t, ts, replaced, true)
+
+	// every chunk has got its own bin id
+	for bin, want := range []uint64{11, 12} {
+		binIDs := make(map[uint64]struct{})
+		err := r.IterateBin(uint8(bin), 0, func(_ swarm.Address, binID uint64, _, _ []byte) (bool, error) {
+			binIDs[binID] = struct{}{}
+			return false, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := uint64(len(binIDs)); got != 11 {
+			t.Fatalf("bin %d: got %d chunks, want 11", bin, got)
+		}
+		if _, ok := binIDs[want]; !ok {
+			t.Fatalf("bin %d: missing bin id %d", bin, want)
+		}
+	}
+
+	if got, want := r.Size(), 22; got != want {
+		t.Fatalf("got reserve size %d, want %d", got, want)
+	}
+}
+
+type dirFS struct {
+	basedir string
+}
+
+func (d *dirFS) Open(path string) (fs.File, error) {
+	return os.OpenFile(filepath.Join(d.basedir, path), os.O_RDWR|os.O_CREATE, 0644)
+}
+
 func TestEvict(t *testing.T) {
 	t.Parallel()
 
@@ -16,6 +16,10 @@ The rules of the transaction is as follows:
 					-> if batch_commit fails or is not called, release all sharky_write location from the disk, do nothing for sharky_release
 
 See the NewTransaction method for more details.
+
+The RunBatch method runs the transaction in which the indexstore reads of the single items (Get, Has and GetSize)
+see the writes made earlier in the same transaction, so that many chunks can be written with one batch commit.
+Iterate and Count only see the committed items.
 */
 
 package transaction
@@ -54,6 +58,7 @@ type Storage interface {
 	ReadOnlyStore
 	NewTransaction(context.Context) (Transaction, func())
 	Run(context.Context, func(Store) error) error
+	RunBatch(context.Context, func(Store) error) error
 	Close() error
 }
 
@@ -85,10 +90,17 @@ type transaction struct {
 // By design, it is best to not batch too many writes to a single transaction, including multiple chunks writes.
 // Calls made to the transaction are NOT thread-safe.
 func (s *store) NewTransaction(ctx context.Context) (Transaction, func()) {
+	return s.newTransaction(ctx, false)
+}
+
+func (s *store) newTransaction(ctx context.Context, readPending bool) (*transaction, func()) {
 
 	b := s.bstore.Batch(ctx)
 
-	index := &indexTrx{s.bstore, b, s.metrics}
+	index := &indexTrx{s.bstore, b, s.metrics, nil}
+	if readPending {
+		index.pending = make(map[string][]byte)
+	}
 	sharky := &sharkyTrx{s.sharky, s.metrics, nil, nil}
 
 	t := &transaction{
@@ -116,11 +128,11 @@ func (s *store) NewTransaction(ctx context.Context) (Transaction, func()) {
 }
 
 func (s *store) IndexStore() storage.Reader {
-	return &indexTrx{s.bstore, nil, s.metrics}
+	return &indexTrx{s.bstore, nil, s.metrics, nil}
 }
 
 func (s *store) ChunkStore() storage.ReadOnlyChunkStore {
-	indexStore := &indexTrx{s.bstore, nil, s.metrics}
+	indexStore := &indexTrx{s.bstore, nil, s.metrics, nil}
 	sharyTrx := &sharkyTrx{s.sharky, s.metrics, nil, nil}
 	return &chunkStoreTrx{indexStore, sharyTrx, s.chunkLocker, nil, s.metrics, true}
 }
@@ -141,6 +153,20 @@ func (s *store) Run(ctx context.Context, f func(Store) error) error {
 	return trx.Commit()
 }
 
+// RunBatch is like Run, but the reads of the single items from the index
+// store of the transaction see the items written or deleted earlier in the
+// same transaction. It is meant for writing many chunks in one transaction.
+func (s *store) RunBatch(ctx context.Context, f func(Store) error) error {
+	trx, done := s.newTransaction(ctx, true)
+	defer done()
+
+	err := f(trx)
+	if err != nil {
+		return err
+	}
+	return trx.Commit()
+}
+
 // Metrics returns set of prometheus collectors.
 func (s *store) Metrics() []prometheus.Collector {
 	return m.PrometheusCollectorsFromFields(s.metrics)
@@ -276,18 +302,70 @@ type indexTrx struct {
 	store   storage.Reader
 	batch   storage.Batch
 	metrics metrics
+	// pending holds the marshaled items written in the transaction and
+	// nil values for the deleted ones, if the reads should see them.
+	pending map[string][]byte
+}
+
+func pendingKey(k storage.Key) string { return k.Namespace() + "/" + k.ID() }
+
+func (s *indexTrx) Get(i storage.Item) error {
+	if buf, ok := s.pending[pendingKey(i)]; ok {
+		if buf == nil {
+			return storage.ErrNotFound
+		}
+		return i.Unmarshal(buf)
+	}
+	return s.store.Get(i)
+}
+
+func (s *indexTrx) Has(k storage.Key) (bool, error) {
+	if buf, ok := s.pending[pendingKey(k)]; ok {
+		return buf != nil, nil
+	}
+	return s.store.Has(k)
+}
+
+func (s *indexTrx) GetSize(k storage.Key) (int, error) {
+	if buf, ok := s.pending[pendingKey(k)]; ok {
+		if buf == nil {
+			return 0, storage.ErrNotFound
+		}
+		return len(buf), nil
+	}
+	return s.store.GetSize(k)
 }
 
-func (s *indexTrx) Get(i storage.Item) error           { return s.store.Get(i) }
-func (s *indexTrx) Has(k storage.Key) (bool, error)    { return s.store.Has(k) }
-func (s *indexTrx) GetSize(k storage.Key) (int, error) { return s.store.GetSize(k) }
 func (s *indexTrx) Iterate(q storage.Query, f storage.IterateFn) (err error) {
 	defer handleMetric("iterate", s.metrics)(&err)
 	return s.store.Iterate(q, f)
 }
 func (s *indexTrx) Count(k storage.Key) (int, error) { return s.store.Count(k) }
-func (s *indexTrx) Put(i storage.Item) error         { return s.batch.Put(i) }
-func (s *indexTrx) Delete(i storage.Item) error      { return s.batch.Delete(i) }
+
+func (s *indexTrx) Put(i storage.Item) error {
+	if s.pending == nil {
+		return s.batch.Put(i)
+	}
+	buf, err := i.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := s.batch.Put(i); err != nil {
+		return err
+	}
+	s.pending[pendingKey(i)] = buf
+	return nil
+}
+
+func (s *indexTrx) Delete(i storage.Item) error {
+	if err := s.batch.Delete(i); err != nil {
+		return err
+	}
+	if s.pending != nil {
+		s.pending[pendingKey(i)] = nil
+	}
+	return nil
+}
 
 type sharkyTrx struct {
 	sharky       *sharky.Store
@@ -167,6 +167,42 @@ func Test_TransactionStorage(t *testing.T) {
 		}
 	})
 
+	t.Run("run-batch", func(t *testing.T) {
+		t.Parallel()
+
+		ch1 := test.GenerateTestRandomChunk()
+		ch2 := test.GenerateTestRandomChunk()
+
+		err := st.RunBatch(context.Background(), func(s transaction.Store) error {
+			item := &cache.CacheEntryItem{Address: ch1.Address(), AccessTimestamp: 1}
+			assert.NoError(t, s.IndexStore().Put(item))
+			got := &cache.CacheEntryItem{Address: ch1.Address()}
+			assert.NoError(t, s.IndexStore().Get(got))
+			assert.Equal(t, item, got)
+			assert.NoError(t, s.IndexStore().Delete(item))
+			assert.ErrorIs(t, s.IndexStore().Get(got), storage.ErrNotFound)
+
+			// the same chunk is stored once
+			assert.NoError(t, s.ChunkStore().Put(context.Background(), ch2))
+			assert.NoError(t, s.ChunkStore().Put(context.Background(), ch2))
+			has, err := s.ChunkStore().Has(context.Background(), ch2.Address())
+			assert.NoError(t, err)
+			assert.True(t, has)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, st.IndexStore().Get(&cache.CacheEntryItem{Address: ch1.Address()}), storage.ErrNotFound)
+		assert.NoError(t, st.Run(context.Background(), func(s transaction.Store) error {
+			return s.ChunkStore().Delete(context.Background(), ch2.Address())
+		}))
+		has, err := st.ChunkStore().Has(context.Background(), ch2.Address())
+		assert.NoError(t, err)
+		if !has {
+			t.Fatal("should have chunk stored twice")
+		}
+	})
+
 	t.Run("put-delete-chunk-twice", func(t *testing.T) {
 		t.Parallel()
 
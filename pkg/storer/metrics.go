@@ -166,7 +166,7 @@ func newMetrics() metrics {
 	}
 }
 
-var _ storage.Putter = (*putterWithMetrics)(nil)
+var _ storage.BatchPutter = (*putterWithMetrics)(nil)
 
 // putterWithMetrics wraps storage.Putter and adds metrics.
 type putterWithMetrics struct {
@@ -188,6 +188,18 @@ func (m putterWithMetrics) Put(ctx context.Context, chunk swarm.Chunk) error {
 	return err
 }
 
+func (m putterWithMetrics) PutMany(ctx context.Context, chunks []swarm.Chunk) ([]error, error) {
+	dur := captureDuration(time.Now())
+	errs, err := storage.PutMany(ctx, m.Putter, chunks)
+	m.metrics.MethodCallsDuration.WithLabelValues(m.component, "PutMany").Observe(dur())
+	if err == nil {
+		m.metrics.MethodCalls.WithLabelValues(m.component, "PutMany", "success").Inc()
+	} else {
+		m.metrics.MethodCalls.WithLabelValues(m.component, "PutMany", "failure").Inc()
+	}
+	return errs, err
+}
+
 var _ storage.Getter = (*getterWithMetrics)(nil)
 
 // getterWithMetrics wraps storage.Getter and adds metrics.
@@ -215,6 +215,17 @@ func (s *ReserveStore) ReserveGet(ctx context.Context, addr swarm.Address, batch
 	return nil, storage.ErrNotFound
 }
 
+func (s *ReserveStore) ReserveGetStamp(addr swarm.Address, batchID []byte, stampHash []byte) (swarm.Stamp, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if v, ok := s.chunks[addr.String()+string(batchID)+string(stampHash)]; ok {
+		return v.Stamp(), nil
+	}
+
+	return nil, storage.ErrNotFound
+}
+
 // Put chunks.
 func (s *ReserveStore) ReservePutter() storage.Putter {
 	return storage.PutterFunc(
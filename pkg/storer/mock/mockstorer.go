@@ -28,6 +28,7 @@ type mockStorer struct {
 	activeSessions map[uint64]*storer.SessionInfo
 	chunkPushC     chan *pusher.Op
 	debugInfo      storer.Info
+	retained       map[string]time.Time
 }
 
 type putterSession struct {
@@ -192,6 +193,36 @@ func (m *mockStorer) Cache() storage.Putter {
 	return m.chunkStore
 }
 
+func (m *mockStorer) Retain(ctx context.Context, ttl time.Duration, addrs ...swarm.Address) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.retained == nil {
+		m.retained = make(map[string]time.Time)
+	}
+	retained := 0
+	for _, addr := range addrs {
+		has, err := m.chunkStore.Has(ctx, addr)
+		if err != nil {
+			return retained, err
+		}
+		if has {
+			m.retained[addr.ByteString()] = now().Add(ttl)
+			retained++
+		}
+	}
+	return retained, nil
+}
+
+// RetainedUntil returns the time until which the chunk is retained.
+func (m *mockStorer) RetainedUntil(addr swarm.Address) (time.Time, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	until, ok := m.retained[addr.ByteString()]
+	return until, ok
+}
+
 func (m *mockStorer) DirectUpload() storer.PutterSession {
 	return &putterSession{chunkStore: storage.PutterFunc(
 		func(ctx context.Context, ch swarm.Chunk) error {
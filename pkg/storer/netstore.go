@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/pusher"
 	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	"github.com/ethersphere/bee/v2/pkg/qos"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/topology"
@@ -40,7 +41,7 @@ func (db *DB) DirectUpload() PutterSession {
 					}()
 
 					for {
-						op := &pusher.Op{Chunk: ch, Err: make(chan error, 1), Direct: true, Span: span}
+						op := &pusher.Op{Chunk: ch, Err: make(chan error, 1), Direct: true, Span: span, ReplicationFactor: pushsync.GetReplicationFactor(ctx), Class: qos.ClassFrom(ctx)}
 						select {
 						case <-ctx.Done():
 							return ctx.Err()
@@ -78,6 +79,33 @@ func (db *DB) DirectUpload() PutterSession {
 	}
 }
 
+// DownloadMode is the source of the chunks of the Download getter.
+type DownloadMode int
+
+const (
+	// DownloadPreferLocal serves the chunks from the local store and
+	// retrieves the missing ones from the network. It is the default mode.
+	DownloadPreferLocal DownloadMode = iota
+	// DownloadLocalOnly serves the chunks only from the local store.
+	DownloadLocalOnly
+	// DownloadNetwork retrieves the chunks from the network
+	// even if they are stored locally.
+	DownloadNetwork
+)
+
+type downloadModeKey struct{}
+
+// SetDownloadMode returns the context with which the Download getter
+// serves the chunks from the source of the mode.
+func SetDownloadMode(ctx context.Context, mode DownloadMode) context.Context {
+	return context.WithValue(ctx, downloadModeKey{}, mode)
+}
+
+func getDownloadMode(ctx context.Context) DownloadMode {
+	mode, _ := ctx.Value(downloadModeKey{}).(DownloadMode)
+	return mode
+}
+
 // Download is the implementation of the NetStore.Download method.
 func (db *DB) Download(cache bool) storage.Getter {
 	return getterWithMetrics{
@@ -93,11 +121,25 @@ func (db *DB) Download(cache bool) storage.Getter {
 				span.Finish()
 			}()
 
-			ch, err = db.Lookup().Get(ctx, address)
+			// the stale chunk is returned if it cannot be retrieved again
+			var stale swarm.Chunk
+
+			mode := getDownloadMode(ctx)
+			if mode == DownloadNetwork {
+				err = storage.ErrNotFound
+			} else {
+				ch, err = db.Lookup().Get(ctx, address)
+				if err == nil && mode != DownloadLocalOnly && db.stale(ctx, ch) {
+					span.LogFields(olog.String("step", "chunk found locally is stale"))
+					stale, err = ch, storage.ErrNotFound
+				}
+			}
 			switch {
 			case err == nil:
 				span.LogFields(olog.String("step", "chunk found locally"))
 				return ch, nil
+			case errors.Is(err, storage.ErrNotFound) && mode == DownloadLocalOnly:
+				span.LogFields(olog.String("step", "chunk not found locally"))
 			case errors.Is(err, storage.ErrNotFound):
 				span.LogFields(olog.String("step", "retrieve chunk from network"))
 				if db.retrieval != nil {
@@ -125,6 +167,10 @@ func (db *DB) Download(cache bool) storage.Getter {
 				}
 			}
 			if err != nil {
+				if stale != nil {
+					logger.Debug("refreshing stale chunk failed", "chunk_address", address, "error", err)
+					return stale, nil
+				}
 				return nil, err
 			}
 			return ch, nil
@@ -8,11 +8,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/pushsync"
 	"github.com/ethersphere/bee/v2/pkg/retrieval"
+	soctesting "github.com/ethersphere/bee/v2/pkg/soc/testing"
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
 	chunktesting "github.com/ethersphere/bee/v2/pkg/storage/testing"
 	storer "github.com/ethersphere/bee/v2/pkg/storer"
@@ -280,6 +283,112 @@ func testNetStore(t *testing.T, newStorer func(r retrieval.Interface) (*storer.D
 				// After download is complete all chunks should be in the local storage.
 				verifyChunks(t, lstore.Storage(), chunks, true)
 			})
+
+			t.Run("mutable chunks", func(t *testing.T) {
+				t.Parallel()
+
+				privKey, err := crypto.GenerateSecp256k1Key()
+				if err != nil {
+					t.Fatal(err)
+				}
+				cached := soctesting.GenerateMockSOCWithKey(t, []byte("cached"), privKey).Chunk()
+				updated := soctesting.GenerateMockSOCWithKey(t, []byte("updated"), privKey).Chunk()
+
+				var (
+					retrieved atomic.Int32
+					failing   atomic.Bool
+				)
+				lstore, err := newStorer(&testRetrieval{fn: func(address swarm.Address) (swarm.Chunk, error) {
+					retrieved.Add(1)
+					if failing.Load() || !address.Equal(updated.Address()) {
+						return nil, storage.ErrNotFound
+					}
+					return updated, nil
+				}})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := lstore.Cache().Put(context.Background(), cached); err != nil {
+					t.Fatalf("cache.Put(...): unexpected error: %v", err)
+				}
+
+				get := func(ctx context.Context, want swarm.Chunk, wantRetrieved int32) {
+					t.Helper()
+
+					retrieved.Store(0)
+					ch, err := lstore.Download(true).Get(ctx, cached.Address())
+					if err != nil {
+						t.Fatalf("download.Get(...): unexpected error: %v", err)
+					}
+					if !ch.Equal(want) {
+						t.Fatalf("got chunk data %x, want %x", ch.Data(), want.Data())
+					}
+					if got := retrieved.Load(); got != wantRetrieved {
+						t.Fatalf("got %d retrievals, want %d", got, wantRetrieved)
+					}
+					lstore.WaitForBgCacheWorkers()()
+				}
+
+				// the fresh cached chunk is not retrieved again
+				get(context.Background(), cached, 0)
+				get(storer.SetCacheTTL(context.Background(), time.Hour), cached, 0)
+
+				// the chunk is retrieved again and the cache refreshed
+				get(storer.SetNoCache(context.Background()), updated, 1)
+				get(context.Background(), updated, 0)
+
+				// the stale chunk is served if it cannot be retrieved
+				failing.Store(true)
+				get(storer.SetNoCache(context.Background()), updated, 1)
+			})
+
+			t.Run("modes", func(t *testing.T) {
+				t.Parallel()
+
+				var (
+					chunks    = chunktesting.GenerateTestRandomChunks(2)
+					local     = chunks[0]
+					remote    = chunks[1]
+					retrieved atomic.Int32
+				)
+				lstore, err := newStorer(&testRetrieval{fn: func(address swarm.Address) (swarm.Chunk, error) {
+					retrieved.Add(1)
+					if address.Equal(remote.Address()) {
+						return remote, nil
+					}
+					return nil, storage.ErrNotFound
+				}})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := lstore.Cache().Put(context.Background(), local); err != nil {
+					t.Fatalf("cache.Put(...): unexpected error: %v", err)
+				}
+
+				get := func(mode storer.DownloadMode, ch swarm.Chunk, wantErr error, wantRetrieved int32) {
+					t.Helper()
+
+					retrieved.Store(0)
+					ctx := storer.SetDownloadMode(context.Background(), mode)
+					got, err := lstore.Download(false).Get(ctx, ch.Address())
+					if !errors.Is(err, wantErr) {
+						t.Fatalf("download.Get(...): got error %v, want %v", err, wantErr)
+					}
+					if err == nil && !got.Equal(ch) {
+						t.Fatalf("incorrect chunk read: address %s", got.Address())
+					}
+					if n := retrieved.Load(); n != wantRetrieved {
+						t.Fatalf("got %d retrievals, want %d", n, wantRetrieved)
+					}
+				}
+
+				get(storer.DownloadPreferLocal, local, nil, 0)
+				get(storer.DownloadPreferLocal, remote, nil, 1)
+				get(storer.DownloadLocalOnly, local, nil, 0)
+				get(storer.DownloadLocalOnly, remote, storage.ErrNotFound, 0)
+				get(storer.DownloadNetwork, local, storage.ErrNotFound, 1)
+				get(storer.DownloadNetwork, remote, nil, 1)
+			})
 		})
 
 		t.Run("no cache", func(t *testing.T) {
@@ -16,6 +16,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/chunktrace"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storage/storageutil"
@@ -281,6 +282,22 @@ func (db *DB) ReserveGet(ctx context.Context, addr swarm.Address, batchID []byte
 	return db.reserve.Get(ctx, addr, batchID, stampHash)
 }
 
+// ReserveGetStamp returns the stamp of the chunk in the reserve.
+func (db *DB) ReserveGetStamp(addr swarm.Address, batchID []byte, stampHash []byte) (stamp swarm.Stamp, err error) {
+	dur := captureDuration(time.Now())
+	defer func() {
+		db.metrics.MethodCallsDuration.WithLabelValues("reserve", "ReserveGetStamp").Observe(dur())
+		if err == nil || errors.Is(err, storage.ErrNotFound) {
+			db.metrics.MethodCalls.WithLabelValues("reserve", "ReserveGetStamp", "success").Inc()
+		} else {
+			db.metrics.MethodCalls.WithLabelValues("reserve", "ReserveGetStamp", "failure").Inc()
+			db.logger.Debug("reserve get stamp error", "error", err)
+		}
+	}()
+
+	return db.reserve.Stamp(addr, batchID, stampHash)
+}
+
 func (db *DB) ReserveHas(addr swarm.Address, batchID []byte, stampHash []byte) (has bool, err error) {
 	dur := captureDuration(time.Now())
 	defer func() {
@@ -297,28 +314,61 @@ func (db *DB) ReserveHas(addr swarm.Address, batchID []byte, stampHash []byte) (
 }
 
 // ReservePutter returns a Putter for inserting chunks into the reserve.
+// It is also a storage.BatchPutter inserting many chunks at once.
 func (db *DB) ReservePutter() storage.Putter {
 	return putterWithMetrics{
-		storage.PutterFunc(
-			func(ctx context.Context, chunk swarm.Chunk) error {
-				err := db.reserve.Put(ctx, chunk)
-				if err != nil {
-					db.logger.Debug("reserve put error", "error", err)
-					return fmt.Errorf("reserve putter.Put: %w", err)
-				}
-				db.reserveBinEvents.Trigger(string(db.po(chunk.Address())))
-				if !db.reserve.IsWithinCapacity() {
-					db.events.Trigger(reserveOverCapacity)
-				}
-				db.metrics.ReserveSize.Set(float64(db.reserve.Size()))
-				return nil
-			},
-		),
+		reservePutter{db},
 		db.metrics,
 		"reserve",
 	}
 }
 
+type reservePutter struct {
+	db *DB
+}
+
+func (p reservePutter) Put(ctx context.Context, chunk swarm.Chunk) error {
+	err := p.db.reserve.Put(ctx, chunk)
+	if err != nil {
+		p.db.logger.Debug("reserve put error", "error", err)
+		p.db.chunkTracer.Record(chunk.Address(), chunktrace.SubsystemReserve, "put failed", "error", err)
+		return fmt.Errorf("reserve putter.Put: %w", err)
+	}
+	p.db.chunkTracer.Record(chunk.Address(), chunktrace.SubsystemReserve, "put", "batch_id", hex.EncodeToString(chunk.Stamp().BatchID()))
+	p.db.reserveBinEvents.Trigger(string(p.db.po(chunk.Address())))
+	p.reserveUpdated()
+	return nil
+}
+
+func (p reservePutter) PutMany(ctx context.Context, chunks []swarm.Chunk) ([]error, error) {
+	errs, err := p.db.reserve.PutMany(ctx, chunks)
+	if err != nil {
+		p.db.logger.Debug("reserve put many error", "error", err)
+		err = fmt.Errorf("reserve putter.PutMany: %w", err)
+	}
+	bins := make(map[uint8]struct{})
+	for i, chunk := range chunks {
+		bins[p.db.po(chunk.Address())] = struct{}{}
+		if i < len(errs) && errs[i] != nil {
+			p.db.chunkTracer.Record(chunk.Address(), chunktrace.SubsystemReserve, "put failed", "error", errs[i])
+		} else if err == nil {
+			p.db.chunkTracer.Record(chunk.Address(), chunktrace.SubsystemReserve, "put", "batch_id", hex.EncodeToString(chunk.Stamp().BatchID()))
+		}
+	}
+	for bin := range bins {
+		p.db.reserveBinEvents.Trigger(string(bin))
+	}
+	p.reserveUpdated()
+	return errs, err
+}
+
+func (p reservePutter) reserveUpdated() {
+	if !p.db.reserve.IsWithinCapacity() {
+		p.db.events.Trigger(reserveOverCapacity)
+	}
+	p.db.metrics.ReserveSize.Set(float64(p.db.reserve.Size()))
+}
+
 func (db *DB) unreserve(ctx context.Context) (err error) {
 	dur := captureDuration(time.Now())
 	defer func() {
@@ -409,6 +459,33 @@ func (db *DB) ReserveIterateChunks(cb func(swarm.Chunk) (bool, error)) error {
 	return db.reserve.IterateChunks(0, cb)
 }
 
+// ReserveChunkItem describes a chunk stored in the reserve.
+type ReserveChunkItem struct {
+	Bin       uint8
+	BinID     uint64
+	Address   swarm.Address
+	BatchID   []byte
+	StampHash []byte
+}
+
+// ReserveIterateChunkItems iterates over the items of all the chunks in the
+// reserve without loading the chunk data.
+func (db *DB) ReserveIterateChunkItems(cb func(ReserveChunkItem) (bool, error)) error {
+	if db.reserve == nil {
+		return nil
+	}
+
+	return db.reserve.IterateChunksItems(0, func(item *reserve.ChunkBinItem) (bool, error) {
+		return cb(ReserveChunkItem{
+			Bin:       item.Bin,
+			BinID:     item.BinID,
+			Address:   item.Address,
+			BatchID:   item.BatchID,
+			StampHash: item.StampHash,
+		})
+	})
+}
+
 func (db *DB) StorageRadius() uint8 {
 	if db.reserve == nil {
 		return 0
@@ -424,6 +501,14 @@ func (db *DB) CommittedDepth() uint8 {
 	return uint8(db.reserveOptions.capacityDoubling) + db.reserve.Radius()
 }
 
+// ReserveCapacity returns the number of the chunks the reserve is committed to hold.
+func (db *DB) ReserveCapacity() int {
+	if db.reserve == nil {
+		return 0
+	}
+	return db.reserve.Capacity()
+}
+
 func (db *DB) ReserveSize() int {
 	if db.reserve == nil {
 		return 0
@@ -65,6 +65,14 @@ func TestIndexCollision(t *testing.T) {
 			t.Fatal(err)
 		}
 
+		gotStamp, err := storer.ReserveGetStamp(ch1.Address(), ch1.Stamp().BatchID(), ch2StampHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(gotStamp.Timestamp(), stamp.Timestamp()) {
+			t.Fatalf("got stamp timestamp %x, want %x", gotStamp.Timestamp(), stamp.Timestamp())
+		}
+
 		t.Run("reserve size", reserveSizeTest(storer.Reserve(), 1))
 	}
 
@@ -18,6 +18,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/chunktrace"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 
@@ -42,8 +43,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/afero"
 	"github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/filter"
-	"github.com/syndtr/goleveldb/leveldb/opt"
 	"resenje.org/multex"
 )
 
@@ -114,6 +113,10 @@ type CacheStore interface {
 	// This will add the chunk to underlying store as well as new indexes which
 	// will keep track of the chunk in the cache.
 	Cache() storage.Putter
+	// Retain protects the cached chunks of the addresses from the cache
+	// eviction until the ttl elapses and returns the number of the chunks
+	// that were found in the cache.
+	Retain(ctx context.Context, ttl time.Duration, addrs ...swarm.Address) (int, error)
 }
 
 // NetStore is a logical component of the storer that deals with network. It will
@@ -152,6 +155,7 @@ type ReserveIterator interface {
 // content. It will implement all the core functionality required for the protocols.
 type ReserveStore interface {
 	ReserveGet(ctx context.Context, addr swarm.Address, batchID []byte, stampHash []byte) (swarm.Chunk, error)
+	ReserveGetStamp(addr swarm.Address, batchID []byte, stampHash []byte) (swarm.Stamp, error)
 	ReserveHas(addr swarm.Address, batchID []byte, stampHash []byte) (bool, error)
 	ReservePutter() storage.Putter
 	SubscribeBin(ctx context.Context, bin uint8, start uint64) (<-chan *BinC, func(), <-chan error)
@@ -250,30 +254,6 @@ const (
 	sharkyPath = "sharky"
 )
 
-func initStore(basePath string, opts *Options) (*leveldbstore.Store, error) {
-	ldbBasePath := path.Join(basePath, indexPath)
-
-	if _, err := os.Stat(ldbBasePath); os.IsNotExist(err) {
-		err := os.MkdirAll(ldbBasePath, 0777)
-		if err != nil {
-			return nil, err
-		}
-	}
-	store, err := leveldbstore.New(path.Join(basePath, "indexstore"), &opt.Options{
-		OpenFilesCacheCapacity: int(opts.LdbOpenFilesLimit),
-		BlockCacheCapacity:     int(opts.LdbBlockCacheCapacity),
-		WriteBuffer:            int(opts.LdbWriteBufferSize),
-		DisableSeeksCompaction: opts.LdbDisableSeeksCompaction,
-		CompactionL0Trigger:    8,
-		Filter:                 filter.NewBloomFilter(64),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed creating levelDB index store: %w", err)
-	}
-
-	return store, nil
-}
-
 func initDiskRepository(
 	ctx context.Context,
 	basePath string,
@@ -281,7 +261,7 @@ func initDiskRepository(
 ) (transaction.Storage, *PinIntegrity, io.Closer, error) {
 	store, err := initStore(basePath, opts)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed creating levelDB index store: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed creating index store: %w", err)
 	}
 
 	err = migration.Migrate(store, "core-migration", localmigration.BeforeInitSteps(store, opts.Logger))
@@ -289,7 +269,7 @@ func initDiskRepository(
 		return nil, nil, nil, errors.Join(store.Close(), fmt.Errorf("failed core migration: %w", err))
 	}
 
-	if opts.LdbStats.Load() != nil {
+	if ldbStore, ok := store.(leveldbstore.Storer); ok && opts.LdbStats.Load() != nil {
 		go func() {
 			ldbStats := opts.LdbStats.Load()
 			logger := log.NewLogger(loggerName).Register()
@@ -302,7 +282,7 @@ func initDiskRepository(
 					return
 				case <-ticker.C:
 					stats := new(leveldb.DBStats)
-					switch err := store.DB().Stats(stats); {
+					switch err := ldbStore.DB().Stats(stats); {
 					case errors.Is(err, leveldb.ErrClosed):
 						return
 					case err != nil:
@@ -368,7 +348,13 @@ const lockKeyNewSession string = "new_session"
 
 // Options provides a container to configure different things in the storer.
 type Options struct {
-	// These are options related to levelDB. Currently, the underlying storage used is levelDB.
+	// IndexStoreBackend selects the key-value store used for the indexes,
+	// see IndexStoreLevelDB and IndexStorePebble. If empty, the backend of
+	// the existing index store is used, falling back to levelDB.
+	IndexStoreBackend string
+
+	// These are options related to the index store. The options are named
+	// after levelDB, but they are applied to the pebble backend as well.
 	LdbStats                  atomic.Pointer[prometheus.HistogramVec]
 	LdbOpenFilesLimit         uint64
 	LdbBlockCacheCapacity     uint64
@@ -376,6 +362,9 @@ type Options struct {
 	LdbDisableSeeksCompaction bool
 	Logger                    log.Logger
 	Tracer                    *tracing.Tracer
+	// ChunkTracer records the reserve puts and
+	// the evictions of the traced chunks.
+	ChunkTracer *chunktrace.Tracer
 
 	Address        swarm.Address
 	WarmupDuration time.Duration
@@ -419,8 +408,9 @@ type cacheLimiter struct {
 
 // DB implements all the component stores described above.
 type DB struct {
-	logger log.Logger
-	tracer *tracing.Tracer
+	logger      log.Logger
+	tracer      *tracing.Tracer
+	chunkTracer *chunktrace.Tracer
 
 	metrics             metrics
 	storage             transaction.Storage
@@ -521,16 +511,17 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 
 	clCtx, clCancel := context.WithCancel(ctx)
 	db := &DB{
-		metrics:    metrics,
-		storage:    st,
-		logger:     logger,
-		tracer:     opts.Tracer,
-		baseAddr:   opts.Address,
-		multex:     lock,
-		cacheObj:   cacheObj,
-		retrieval:  noopRetrieval{},
-		pusherFeed: make(chan *pusher.Op),
-		quit:       make(chan struct{}),
+		metrics:     metrics,
+		storage:     st,
+		logger:      logger,
+		tracer:      opts.Tracer,
+		chunkTracer: opts.ChunkTracer,
+		baseAddr:    opts.Address,
+		multex:      lock,
+		cacheObj:    cacheObj,
+		retrieval:   noopRetrieval{},
+		pusherFeed:  make(chan *pusher.Op),
+		quit:        make(chan struct{}),
 		cacheLimiter: cacheLimiter{
 			sem:    make(chan struct{}, defaultBgCacheWorkers),
 			ctx:    clCtx,
@@ -568,6 +559,7 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 		if err != nil {
 			return nil, err
 		}
+		rs.SetChunkTracer(opts.ChunkTracer)
 		db.reserve = rs
 
 		db.metrics.StorageRadius.Set(float64(rs.Radius()))
@@ -708,8 +700,15 @@ func (db *DB) Storage() transaction.Storage {
 
 type putterSession struct {
 	storage.Putter
-	done    func(swarm.Address) error
-	cleanup func() error
+	done     func(swarm.Address) error
+	cleanup  func() error
+	zeroCopy bool
+}
+
+func (p *putterSession) ZeroCopy() bool { return p.zeroCopy }
+
+func (p *putterSession) PutMany(ctx context.Context, chunks []swarm.Chunk) ([]error, error) {
+	return storage.PutMany(ctx, p.Putter, chunks)
 }
 
 func (p *putterSession) Done(addr swarm.Address) error { return p.done(addr) }
@@ -69,24 +69,55 @@ func (db *DB) Upload(ctx context.Context, pin bool, tagID uint64) (PutterSession
 	}
 
 	return &putterSession{
+		// the chunks are written to the store before Put returns
+		zeroCopy: true,
 		Putter: putterWithMetrics{
-			storage.PutterFunc(func(ctx context.Context, chunk swarm.Chunk) error {
-				unlock := db.Lock(uploadsLock)
-				defer unlock()
-				return errors.Join(
-					db.storage.Run(ctx, func(s transaction.Store) error {
-						return uploadPutter.Put(ctx, s, chunk)
-					}),
-					func() error {
-						if pinningPutter != nil {
-							return db.storage.Run(ctx, func(s transaction.Store) error {
-								return pinningPutter.Put(ctx, s, chunk)
-							})
-						}
-						return nil
-					}(),
-				)
-			}),
+			batchPutterFunc{
+				put: func(ctx context.Context, chunk swarm.Chunk) error {
+					unlock := db.Lock(uploadsLock)
+					defer unlock()
+					return errors.Join(
+						db.storage.Run(ctx, func(s transaction.Store) error {
+							return uploadPutter.Put(ctx, s, chunk)
+						}),
+						func() error {
+							if pinningPutter != nil {
+								return db.storage.Run(ctx, func(s transaction.Store) error {
+									return pinningPutter.Put(ctx, s, chunk)
+								})
+							}
+							return nil
+						}(),
+					)
+				},
+				putMany: func(ctx context.Context, chunks []swarm.Chunk) ([]error, error) {
+					unlock := db.Lock(uploadsLock)
+					defer unlock()
+					return nil, errors.Join(
+						db.storage.RunBatch(ctx, func(s transaction.Store) error {
+							for _, chunk := range chunks {
+								if err := uploadPutter.Put(ctx, s, chunk); err != nil {
+									return err
+								}
+							}
+							return nil
+						}),
+						func() error {
+							if pinningPutter != nil {
+								return db.storage.RunBatch(ctx, func(s transaction.Store) error {
+									for _, chunk := range chunks {
+										if err := pinningPutter.Put(ctx, s, chunk); err != nil {
+											return err
+										}
+									}
+									return nil
+								})
+							}
+							return nil
+						}(),
+					)
+				},
+			},
 			db.metrics,
 			"uploadstore",
 		},
@@ -130,6 +161,20 @@ func (db *DB) Upload(ctx context.Context, pin bool, tagID uint64) (PutterSession
 	}, nil
 }
 
+// batchPutterFunc is the storage.BatchPutter of the put functions.
+type batchPutterFunc struct {
+	put     func(context.Context, swarm.Chunk) error
+	putMany func(context.Context, []swarm.Chunk) ([]error, error)
+}
+
+func (p batchPutterFunc) Put(ctx context.Context, chunk swarm.Chunk) error {
+	return p.put(ctx, chunk)
+}
+
+func (p batchPutterFunc) PutMany(ctx context.Context, chunks []swarm.Chunk) ([]error, error) {
+	return p.putMany(ctx, chunks)
+}
+
 // NewSession is the implementation of UploadStore.NewSession method.
 func (db *DB) NewSession() (SessionInfo, error) {
 	unlock := db.Lock(lockKeyNewSession)
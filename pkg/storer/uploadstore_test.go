@@ -147,6 +147,50 @@ func testUploadStore(t *testing.T, newStorer func() (*storer.DB, error)) {
 		})
 	}
 
+	t.Run("put many", func(t *testing.T) {
+		t.Parallel()
+
+		lstore, err := newStorer()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tag, err := lstore.NewSession()
+		if err != nil {
+			t.Fatalf("NewSession(): unexpected error: %v", err)
+		}
+
+		session, err := lstore.Upload(context.TODO(), true, tag.TagID)
+		if err != nil {
+			t.Fatalf("Upload(...): unexpected error: %v", err)
+		}
+
+		chunks := chunktesting.GenerateTestRandomChunks(10)
+		errs, err := storage.PutMany(context.TODO(), session, append(chunks, chunks...))
+		if err != nil {
+			t.Fatalf("PutMany(...): unexpected error: %v", err)
+		}
+		for _, err := range errs {
+			if err != nil {
+				t.Fatalf("PutMany(...): unexpected chunk error: %v", err)
+			}
+		}
+
+		if err := session.Done(chunks[0].Address()); err != nil {
+			t.Fatalf("session.Done(...): unexpected error: %v", err)
+		}
+
+		verifyPinCollection(t, lstore.Storage(), chunks[0], chunks, true)
+
+		sessionInfo, err := lstore.Session(tag.TagID)
+		if err != nil {
+			t.Fatalf("Session(...): unexpected error: %v", err)
+		}
+		if sessionInfo.Split != 20 || sessionInfo.Seen != 10 {
+			t.Fatalf("unexpected split and seen counts: want 20 10 have %d %d", sessionInfo.Split, sessionInfo.Seen)
+		}
+	})
+
 	t.Run("get session info", func(t *testing.T) {
 		t.Parallel()
 
@@ -31,7 +31,7 @@ func ValidateReserve(ctx context.Context, basePath string, opts *Options) error
 
 	store, err := initStore(basePath, opts)
 	if err != nil {
-		return fmt.Errorf("failed creating levelDB index store: %w", err)
+		return fmt.Errorf("failed creating index store: %w", err)
 	}
 	defer func() {
 		if err := store.Close(); err != nil {
@@ -64,7 +64,7 @@ func ValidateRetrievalIndex(ctx context.Context, basePath string, opts *Options)
 
 	store, err := initStore(basePath, opts)
 	if err != nil {
-		return fmt.Errorf("failed creating levelDB index store: %w", err)
+		return fmt.Errorf("failed creating index store: %w", err)
 	}
 	defer func() {
 		if err := store.Close(); err != nil {
@@ -186,7 +186,7 @@ func ValidatePinCollectionChunks(ctx context.Context, basePath, pin, location st
 
 	store, err := initStore(basePath, opts)
 	if err != nil {
-		return fmt.Errorf("failed creating levelDB index store: %w", err)
+		return fmt.Errorf("failed creating index store: %w", err)
 	}
 	defer func() {
 		if err := store.Close(); err != nil {
@@ -231,6 +231,7 @@ type chunk struct {
 	depth       uint8
 	bucketDepth uint8
 	immutable   bool
+	validType   ChunkType
 }
 
 func NewChunk(addr Address, data []byte) Chunk {
@@ -293,6 +294,26 @@ func (c *chunk) Equal(cp Chunk) bool {
 	return c.Address().Equal(cp.Address()) && bytes.Equal(c.Data(), cp.Data())
 }
 
+// MarkValid marks the chunk as already validated to be of the chunk type,
+// so that the validation of the locally created chunks is not repeated.
+// ChunkTypeUnspecified removes the mark. The chunks received from the
+// network must never be marked.
+func MarkValid(ch Chunk, t ChunkType) Chunk {
+	if c, ok := ch.(*chunk); ok {
+		c.validType = t
+	}
+	return ch
+}
+
+// ValidType returns the chunk type the chunk was marked as valid with,
+// ChunkTypeUnspecified if it was not marked.
+func ValidType(ch Chunk) ChunkType {
+	if c, ok := ch.(*chunk); ok {
+		return c.validType
+	}
+	return ChunkTypeUnspecified
+}
+
 var errBadCharacter = errors.New("bad character in binary address")
 
 // ParseBitStrAddress parses overlay addresses in binary format (eg: 111101101) to it's corresponding overlay address.
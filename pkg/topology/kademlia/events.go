@@ -0,0 +1,76 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	im "github.com/ethersphere/bee/v2/pkg/topology/kademlia/internal/metrics"
+)
+
+// eventsBufferSize is the capacity of the channel of an events subscription.
+const eventsBufferSize = 128
+
+var _ topology.EventSubscriber = (*Kad)(nil)
+
+// SubscribeEvents implements the topology.EventSubscriber interface.
+func (k *Kad) SubscribeEvents() (<-chan topology.Event, func()) {
+	c := make(chan topology.Event, eventsBufferSize)
+
+	k.eventsMtx.Lock()
+	k.eventSubs[c] = struct{}{}
+	k.eventsMtx.Unlock()
+
+	var once sync.Once
+	return c, func() {
+		once.Do(func() {
+			k.eventsMtx.Lock()
+			defer k.eventsMtx.Unlock()
+			delete(k.eventSubs, c)
+			close(c)
+		})
+	}
+}
+
+// emitEvent sends the event to the subscribers without blocking.
+func (k *Kad) emitEvent(e topology.Event) {
+	e.Time = time.Now()
+
+	k.eventsMtx.Lock()
+	defer k.eventsMtx.Unlock()
+
+	for c := range k.eventSubs {
+		select {
+		case c <- e:
+		default:
+			k.metrics.DroppedTopologyEvents.Inc()
+		}
+	}
+}
+
+func (k *Kad) emitPeerEvent(t topology.EventType, peer swarm.Address, dir im.PeerConnectionDirection) {
+	k.emitEvent(topology.Event{
+		Type:           t,
+		Peer:           peer,
+		ProximityOrder: swarm.Proximity(k.base.Bytes(), peer.Bytes()),
+		Direction:      string(dir),
+		Depth:          k.neighborhoodDepth(),
+	})
+}
+
+// PeerBlocklisted implements the p2p.BlocklistNotifier interface.
+func (k *Kad) PeerBlocklisted(peer swarm.Address, duration time.Duration, reason string) {
+	k.emitEvent(topology.Event{
+		Type:           topology.EventPeerBlocklisted,
+		Peer:           peer,
+		ProximityOrder: swarm.Proximity(k.base.Bytes(), peer.Bytes()),
+		Depth:          k.neighborhoodDepth(),
+		Reason:         reason,
+		Duration:       duration,
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology"
+	"github.com/ethersphere/bee/v2/pkg/topology/kademlia"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	t.Parallel()
+
+	var (
+		conns                    int32
+		base, kad, ab, _, signer = newTestKademlia(t, &conns, nil, kademlia.Options{})
+		peer                     = swarm.RandAddressAt(t, base, 1)
+	)
+
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	events, unsubscribe := kad.SubscribeEvents()
+
+	next := func(want topology.EventType) topology.Event {
+		t.Helper()
+		for {
+			select {
+			case e := <-events:
+				if e.Type == want {
+					return e
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out waiting for the %s event", want)
+			}
+		}
+	}
+
+	connectOne(t, signer, kad, ab, peer, nil)
+	e := next(topology.EventPeerConnected)
+	if !e.Peer.Equal(peer) || e.ProximityOrder != 1 || e.Direction != "inbound" {
+		t.Fatalf("unexpected event %+v", e)
+	}
+
+	kad.PeerBlocklisted(peer, time.Minute, "test")
+	e = next(topology.EventPeerBlocklisted)
+	if !e.Peer.Equal(peer) || e.Duration != time.Minute || e.Reason != "test" {
+		t.Fatalf("unexpected event %+v", e)
+	}
+
+	kad.Disconnected(p2p.Peer{Address: peer})
+	if e = next(topology.EventPeerDisconnected); !e.Peer.Equal(peer) {
+		t.Fatalf("unexpected event %+v", e)
+	}
+
+	unsubscribe()
+	unsubscribe()
+	for range events {
+	}
+}
@@ -15,6 +15,9 @@ var (
 		return k.pruneOversaturatedBins
 	}
 	GenerateCommonBinPrefixes = generateCommonBinPrefixes
+	PruneCandidateFunc        = func(k *Kad) func([]swarm.Address) swarm.Address {
+		return k.pruneCandidate
+	}
 )
 
 const (
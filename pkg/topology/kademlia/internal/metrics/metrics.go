@@ -137,6 +137,26 @@ func PeerHealth(isHealty bool) RecordOp {
 	}
 }
 
+// IncProtocolErrors increments the counter of the
+// protocol violations of the peer by 1.
+func IncProtocolErrors() RecordOp {
+	return func(cs *Counters) {
+		cs.Lock()
+		defer cs.Unlock()
+		cs.protocolErrors++
+	}
+}
+
+// IncDisputes increments the counter of the
+// accounting disputes with the peer by 1.
+func IncDisputes() RecordOp {
+	return func(cs *Counters) {
+		cs.Lock()
+		defer cs.Unlock()
+		cs.disputes++
+	}
+}
+
 // Snapshot represents a snapshot of peers' metrics counters.
 type Snapshot struct {
 	LastSeenTimestamp          int64
@@ -148,6 +168,8 @@ type Snapshot struct {
 	Reachability               p2p.ReachabilityStatus
 	Healthy                    bool
 	IsBootnode                 bool
+	ProtocolErrors             uint64
+	Disputes                   uint64
 }
 
 // persistentCounters is a helper struct used for persisting selected counters.
@@ -175,6 +197,8 @@ type Counters struct {
 	sessionConnDuration  time.Duration
 	sessionConnDirection PeerConnectionDirection
 	latencyEWMA          time.Duration
+	protocolErrors       uint64
+	disputes             uint64
 	ReachabilityStatus   p2p.ReachabilityStatus
 	Healthy              bool
 }
@@ -229,6 +253,8 @@ func (cs *Counters) snapshot(t time.Time) *Snapshot {
 		Reachability:               cs.ReachabilityStatus,
 		Healthy:                    cs.Healthy,
 		IsBootnode:                 cs.IsBootnode,
+		ProtocolErrors:             cs.protocolErrors,
+		Disputes:                   cs.disputes,
 	}
 }
 
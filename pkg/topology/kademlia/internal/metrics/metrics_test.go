@@ -167,6 +167,16 @@ func TestPeerMetricsCollector(t *testing.T) {
 		t.Fatalf("Snapshot(%q, ...): has health status mismatch: have %v; want %v", addr, have, want)
 	}
 
+	// Protocol errors and disputes.
+	mc.Record(addr, metrics.IncProtocolErrors(), metrics.IncProtocolErrors(), metrics.IncDisputes())
+	ss = snapshot(t, mc, t2, addr)
+	if have, want := ss.ProtocolErrors, uint64(2); have != want {
+		t.Fatalf("Snapshot(%q, ...): protocol errors mismatch: have %d; want %d", addr, have, want)
+	}
+	if have, want := ss.Disputes, uint64(1); have != want {
+		t.Fatalf("Snapshot(%q, ...): disputes mismatch: have %d; want %d", addr, have, want)
+	}
+
 	// Inspect.
 	have := mc.Inspect(addr)
 	want := ss
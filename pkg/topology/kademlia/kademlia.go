@@ -11,15 +11,17 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"math/rand"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/addressbook"
 	"github.com/ethersphere/bee/v2/pkg/discovery"
+	"github.com/ethersphere/bee/v2/pkg/events"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/peering"
 	"github.com/ethersphere/bee/v2/pkg/shed"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/topology"
@@ -89,6 +91,9 @@ type Options struct {
 	StaticNodes    []swarm.Address
 	ExcludeFunc    excludeFunc
 	DataDir        string
+	PrunePolicy    PrunePolicy
+	Peering        *peering.Policy
+	Events         events.Publisher
 
 	BitSuffixLength             *int
 	TimeToRetry                 *time.Duration
@@ -110,6 +115,9 @@ type kadOptions struct {
 	PruneFunc      pruneFunc
 	StaticNodes    []swarm.Address
 	ExcludeFunc    excludeFunc
+	PrunePolicy    PrunePolicy
+	Peering        *peering.Policy
+	Events         events.Publisher
 
 	TimeToRetry                 time.Duration
 	ShortRetry                  time.Duration
@@ -131,6 +139,9 @@ func newKadOptions(o Options) kadOptions {
 		PruneFunc:      o.PruneFunc,
 		StaticNodes:    o.StaticNodes,
 		ExcludeFunc:    o.ExcludeFunc,
+		PrunePolicy:    o.PrunePolicy,
+		Peering:        o.Peering,
+		Events:         o.Events,
 		// copy or use default
 		TimeToRetry:                 defaultValDuration(o.TimeToRetry, defaultTimeToRetry),
 		ShortRetry:                  defaultValDuration(o.ShortRetry, defaultShortRetry),
@@ -143,9 +154,15 @@ func newKadOptions(o Options) kadOptions {
 		LowWaterMark:                defaultValInt(o.LowWaterMark, defaultLowWaterMark),
 	}
 
+	if ko.Peering == nil {
+		ko.Peering = peering.NewPolicy()
+	}
 	if ko.SaturationFunc == nil {
 		ko.SaturationFunc = makeSaturationFunc(ko)
 	}
+	if ko.PrunePolicy == "" {
+		ko.PrunePolicy = defaultPrunePolicy
+	}
 
 	return ko
 }
@@ -169,7 +186,7 @@ func makeSaturationFunc(o kadOptions) binSaturationFunc {
 	if o.BootnodeMode {
 		os = o.BootnodeOverSaturationPeers
 	}
-	return binSaturated(os, isStaticPeer(o.StaticNodes))
+	return binSaturated(os, isStaticPeer(o.StaticNodes, o.Peering))
 }
 
 // Kad is the Swarm forwarding kademlia implementation.
@@ -201,6 +218,8 @@ type Kad struct {
 	bgBroadcastCtx    context.Context
 	bgBroadcastCancel context.CancelFunc
 	reachability      p2p.ReachabilityStatus
+	eventsMtx         sync.Mutex
+	eventSubs         map[chan topology.Event]struct{}
 }
 
 // New returns a new Kademlia.
@@ -248,8 +267,9 @@ func New(
 		halt:              make(chan struct{}),
 		done:              make(chan struct{}),
 		metrics:           newMetrics(),
-		staticPeer:        isStaticPeer(opt.StaticNodes),
+		staticPeer:        isStaticPeer(opt.StaticNodes, opt.Peering),
 		storageRadius:     swarm.MaxPO,
+		eventSubs:         make(map[chan topology.Event]struct{}),
 	}
 
 	if k.opt.PruneFunc == nil {
@@ -260,7 +280,7 @@ func New(
 	if k.opt.BootnodeMode {
 		os = k.opt.BootnodeOverSaturationPeers
 	}
-	k.opt.PruneCountFunc = binPruneCount(os, isStaticPeer(k.opt.StaticNodes))
+	k.opt.PruneCountFunc = binPruneCount(os, k.staticPeer)
 
 	if k.opt.ExcludeFunc == nil {
 		k.opt.ExcludeFunc = func(f ...im.ExcludeOp) peerExcludeFunc {
@@ -276,6 +296,8 @@ func New(
 
 	k.bgBroadcastCtx, k.bgBroadcastCancel = context.WithCancel(context.Background())
 
+	k.opt.Peering.OnChange(k.peeringChanged)
+
 	k.metrics.ReachabilityStatus.WithLabelValues(p2p.ReachabilityStatusUnknown.String()).Set(0)
 	return k, nil
 }
@@ -333,7 +355,7 @@ func (k *Kad) connectBalanced(wg *sync.WaitGroup, peerConnChan chan<- *peerConnI
 			if err != nil {
 				k.logger.Warning("peer blocklist check failed", "error", err)
 			}
-			if blocklisted {
+			if blocklisted || k.opt.Peering.DeniedOverlay(closestKnownPeer) {
 				continue
 			}
 
@@ -378,7 +400,7 @@ func (k *Kad) connectNeighbours(wg *sync.WaitGroup, peerConnChan chan<- *peerCon
 		if err != nil {
 			k.logger.Warning("peer blocklist check failed", "error", err)
 		}
-		if blocklisted {
+		if blocklisted || k.opt.Peering.DeniedOverlay(addr) {
 			return false, false, nil
 		}
 
@@ -403,6 +425,46 @@ func (k *Kad) connectNeighbours(wg *sync.WaitGroup, peerConnChan chan<- *peerCon
 	})
 }
 
+// connectProtected attempts to connect to the peers
+// protected by the peering policy regardless of the saturation.
+func (k *Kad) connectProtected(wg *sync.WaitGroup, peerConnChan chan<- *peerConnInfo) {
+	for _, addr := range k.opt.Peering.Protectees() {
+		if k.connectedPeers.Exists(addr) || k.waitNext.Waiting(addr) {
+			continue
+		}
+
+		blocklisted, err := k.p2p.Blocklisted(addr)
+		if err != nil {
+			k.logger.Warning("peer blocklist check failed", "error", err)
+		}
+		if blocklisted {
+			continue
+		}
+
+		wg.Add(1)
+		select {
+		case peerConnChan <- &peerConnInfo{po: swarm.Proximity(k.base.Bytes(), addr.Bytes()), addr: addr}:
+		case <-k.quit:
+			wg.Done()
+			return
+		}
+	}
+}
+
+// peeringChanged disconnects the peers denied by the changed
+// peering policy and triggers the connection to the protected ones.
+func (k *Kad) peeringChanged() {
+	_ = k.connectedPeers.EachBin(func(addr swarm.Address, _ uint8) (bool, bool, error) {
+		if k.opt.Peering.DeniedOverlay(addr) {
+			if err := k.p2p.Disconnect(addr, "denied by peering policy"); err != nil {
+				k.logger.Debug("denied peer disconnect failed", "peer_address", addr, "error", err)
+			}
+		}
+		return false, false, nil
+	})
+	k.notifyManageLoop()
+}
+
 // connectionAttemptsHandler handles the connection attempts
 // to peers sent by the producers to the peerConnChan.
 func (k *Kad) connectionAttemptsHandler(ctx context.Context, wg *sync.WaitGroup, neighbourhoodChan, balanceChan <-chan *peerConnInfo) {
@@ -441,6 +503,10 @@ func (k *Kad) connectionAttemptsHandler(ctx context.Context, wg *sync.WaitGroup,
 		case errors.Is(err, p2p.ErrPeerBlocklisted):
 			k.logger.Debug("peer still in blocklist", "peer_address", bzzAddr)
 			return
+		case errors.Is(err, p2p.ErrPeerDenied):
+			k.logger.Debug("peer denied by peering policy", "peer_address", bzzAddr)
+			remove(peer)
+			return
 		case err != nil:
 			k.logger.Debug("peer not reachable from kademlia", "peer_address", bzzAddr, "error", err)
 			return
@@ -456,6 +522,7 @@ func (k *Kad) connectionAttemptsHandler(ctx context.Context, wg *sync.WaitGroup,
 		k.recalcDepth()
 
 		k.logger.Debug("connected to peer", "peer_address", peer.addr, "proximity_order", peer.po)
+		k.emitPeerEvent(topology.EventPeerConnected, peer.addr, im.PeerConnectionDirectionOutbound)
 		k.notifyManageLoop()
 		k.notifyPeerSig()
 	}
@@ -626,6 +693,7 @@ func (k *Kad) manage() {
 			}
 
 			oldDepth := k.neighborhoodDepth()
+			k.connectProtected(&wg, neighbourhoodChan)
 			k.connectBalanced(&wg, balanceChan)
 			k.connectNeighbours(&wg, neighbourhoodChan)
 			wg.Wait()
@@ -668,7 +736,8 @@ func (k *Kad) manage() {
 }
 
 // pruneOversaturatedBins disconnects out of depth peers from oversaturated bins
-// while maintaining the balance of the bin and favoring healthy and reachable peers.
+// while maintaining the balance of the bin and disconnecting the peers picked
+// by the prune policy, by default the ones with the lowest quality score.
 func (k *Kad) pruneOversaturatedBins(depth uint8) {
 
 	for i := range k.commonBinPrefixes {
@@ -692,32 +761,12 @@ func (k *Kad) pruneOversaturatedBins(depth uint8) {
 			}
 
 			binPeers := k.connectedPeers.BinPeers(uint8(i))
-			peers := k.balancedSlotPeers(k.commonBinPrefixes[i][j], binPeers, i)
+			peers := slices.DeleteFunc(k.balancedSlotPeers(k.commonBinPrefixes[i][j], binPeers, i), k.staticPeer)
 			if len(peers) <= 1 {
 				continue
 			}
 
-			var disconnectPeer = swarm.ZeroAddress
-			var unreachablePeer = swarm.ZeroAddress
-			for _, peer := range peers {
-				if ss := k.collector.Inspect(peer); ss != nil {
-					if !ss.Healthy {
-						disconnectPeer = peer
-						break
-					}
-					if ss.Reachability != p2p.ReachabilityStatusPublic {
-						unreachablePeer = peer
-					}
-				}
-			}
-
-			if disconnectPeer.IsZero() {
-				if unreachablePeer.IsZero() {
-					disconnectPeer = peers[rand.Intn(len(peers))]
-				} else {
-					disconnectPeer = unreachablePeer // pick unreachable peer
-				}
-			}
+			disconnectPeer := k.pruneCandidate(peers)
 
 			err := k.p2p.Disconnect(disconnectPeer, "pruned from oversaturated bin")
 			if err != nil {
@@ -899,6 +948,16 @@ func (k *Kad) recalcDepth() {
 	k.depthMu.Lock()
 	defer k.depthMu.Unlock()
 
+	oldDepth := k.depth
+	defer func() {
+		if k.depth != oldDepth {
+			k.emitEvent(topology.Event{Type: topology.EventDepthChanged, Depth: k.depth})
+			if k.opt.Events != nil {
+				k.opt.Events.Publish(events.TopicDepth, events.Depth{Depth: k.depth})
+			}
+		}
+	}()
+
 	var (
 		peers                 = k.connectedPeers
 		exclude               = k.opt.ExcludeFunc(im.Reachability(false))
@@ -988,7 +1047,7 @@ func (k *Kad) connect(ctx context.Context, peer swarm.Address, ma ma.Multiaddr)
 		return nil
 	case errors.Is(err, context.Canceled):
 		return err
-	case errors.Is(err, p2p.ErrPeerBlocklisted):
+	case errors.Is(err, p2p.ErrPeerBlocklisted), errors.Is(err, p2p.ErrPeerDenied):
 		return err
 	case err != nil:
 		k.logger.Debug("could not connect to peer", "peer_address", peer, "error", err)
@@ -1011,7 +1070,8 @@ func (k *Kad) connect(ctx context.Context, peer swarm.Address, ma ma.Multiaddr)
 			maxAttempts = maxNeighborAttempts
 		}
 
-		if failedAttempts >= maxAttempts {
+		// the protected peers are never garbage collected
+		if failedAttempts >= maxAttempts && !k.staticPeer(peer) {
 			k.waitNext.Remove(peer)
 			k.knownPeers.Remove(peer)
 			if err := k.addressBook.Remove(peer); err != nil {
@@ -1126,8 +1186,24 @@ func (k *Kad) AddPeers(addrs ...swarm.Address) {
 	k.notifyManageLoop()
 }
 
+// PeerMetrics returns the metrics snapshot of the peer if it is known.
+func (k *Kad) PeerMetrics(addr swarm.Address) (*topology.MetricSnapshotView, bool) {
+	ss := k.collector.Inspect(addr)
+	if ss == nil {
+		return nil, false
+	}
+	return createMetricsSnapshotView(ss), true
+}
+
 func (k *Kad) Pick(peer p2p.Peer) bool {
 	k.metrics.PickCalls.Inc()
+	if k.opt.Peering.DeniedOverlay(peer.Address) {
+		k.metrics.PickCallsFalse.Inc()
+		return false
+	}
+	if k.staticPeer(peer.Address) {
+		return true
+	}
 	if k.bootnode || !peer.FullNode {
 		// shortcircuit for bootnode mode AND light node peers - always accept connections,
 		// at least until we find a better solution.
@@ -1163,9 +1239,11 @@ func (k *Kad) binPeers(bin uint8, reachable bool) (peers []swarm.Address) {
 	return
 }
 
-func isStaticPeer(staticNodes []swarm.Address) func(overlay swarm.Address) bool {
+// isStaticPeer reports whether the peer is a static node or
+// protected by the peering policy, in which case it is never pruned.
+func isStaticPeer(staticNodes []swarm.Address, policy *peering.Policy) func(overlay swarm.Address) bool {
 	return func(overlay swarm.Address) bool {
-		return swarm.ContainsAddress(staticNodes, overlay)
+		return swarm.ContainsAddress(staticNodes, overlay) || policy.Protected(overlay)
 	}
 }
 
@@ -1191,7 +1269,7 @@ func (k *Kad) Connected(ctx context.Context, peer p2p.Peer, forceConnection bool
 			_ = k.p2p.Disconnect(randPeer, "kicking out random peer to accommodate node")
 			return k.onConnected(ctx, address)
 		}
-		if !forceConnection {
+		if !forceConnection && !k.staticPeer(address) {
 			return topology.ErrOversaturated
 		}
 	}
@@ -1214,6 +1292,8 @@ func (k *Kad) onConnected(ctx context.Context, addr swarm.Address) error {
 	k.notifyManageLoop()
 	k.notifyPeerSig()
 
+	k.emitPeerEvent(topology.EventPeerConnected, addr, im.PeerConnectionDirectionInbound)
+
 	return nil
 }
 
@@ -1232,6 +1312,8 @@ func (k *Kad) Disconnected(peer p2p.Peer) {
 
 	k.notifyManageLoop()
 	k.notifyPeerSig()
+
+	k.emitPeerEvent(topology.EventPeerDisconnected, peer.Address, "")
 }
 
 func (k *Kad) notifyPeerSig() {
@@ -1650,5 +1732,8 @@ func createMetricsSnapshotView(ss *im.Snapshot) *topology.MetricSnapshotView {
 		LatencyEWMA:                ss.LatencyEWMA.Milliseconds(),
 		Reachability:               ss.Reachability.String(),
 		Healthy:                    ss.Healthy,
+		ProtocolErrors:             ss.ProtocolErrors,
+		Disputes:                   ss.Disputes,
+		Score:                      peerScore(ss),
 	}
 }
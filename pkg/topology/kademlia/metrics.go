@@ -31,6 +31,7 @@ type metrics struct {
 	Blocklist                             prometheus.Counter
 	ReachabilityStatus                    *prometheus.GaugeVec
 	PeersReachabilityStatus               *prometheus.GaugeVec
+	DroppedTopologyEvents                 prometheus.Counter
 }
 
 // newMetrics is a convenient constructor for creating new metrics.
@@ -164,6 +165,12 @@ func newMetrics() metrics {
 			},
 			[]string{"peers_reachability_status"},
 		),
+		DroppedTopologyEvents: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "dropped_topology_events",
+			Help:      "The number of topology events dropped for slow subscribers.",
+		}),
 	}
 }
 
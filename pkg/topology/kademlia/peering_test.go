@@ -0,0 +1,86 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/bzz"
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/p2p/peering"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology/kademlia"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestPeeringPolicy(t *testing.T) {
+	t.Parallel()
+
+	var (
+		conns                    int32
+		policy                   = peering.NewPolicy()
+		base, kad, ab, _, signer = newTestKademlia(t, &conns, nil, kademlia.Options{
+			ExcludeFunc: defaultExcludeFunc,
+			Peering:     policy,
+		})
+		pinned = swarm.RandAddressAt(t, base, 0)
+		denied = swarm.RandAddressAt(t, base, 0)
+	)
+
+	if err := policy.Set(peering.Config{Pinned: []swarm.Address{pinned}, Denied: []string{denied.String()}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	var connected []swarm.Address
+	for i := 0; i < kademlia.DefaultOverSaturationPeers; i++ {
+		addr := swarm.RandAddressAt(t, base, 0)
+		connectOne(t, signer, kad, ab, addr, nil)
+		connected = append(connected, addr)
+	}
+
+	// the pinned peer is accepted into the oversaturated bin, the denied one never
+	if !kad.Pick(p2p.Peer{Address: pinned, FullNode: true}) {
+		t.Fatal("should pick the pinned peer")
+	}
+	connectOne(t, signer, kad, ab, pinned, nil)
+	if kad.Pick(p2p.Peer{Address: denied, FullNode: true}) {
+		t.Fatal("should not pick the denied peer")
+	}
+
+	// the peers added to the peering groups are dialed regardless of the saturation
+	member := swarm.RandAddressAt(t, base, 0)
+	multiaddr, err := ma.NewMultiaddr(underlayBase + member.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bzzAddr, err := bzz.NewAddress(signer, multiaddr, member, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ab.Put(member, *bzzAddr); err != nil {
+		t.Fatal(err)
+	}
+	if err := policy.Set(peering.Config{
+		Pinned: []swarm.Address{pinned},
+		Denied: []string{denied.String()},
+		Groups: map[string][]swarm.Address{"friends": {member}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	waitConn(t, &conns)
+
+	// the connected peers denied by a policy change are disconnected
+	if err := policy.Set(peering.Config{Denied: []string{connected[0].String()}}); err != nil {
+		t.Fatal(err)
+	}
+	waitCounter(t, &conns, -1)
+}
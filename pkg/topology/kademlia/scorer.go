@@ -0,0 +1,150 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	im "github.com/ethersphere/bee/v2/pkg/topology/kademlia/internal/metrics"
+)
+
+// PrunePolicy decides which peer is disconnected from an oversaturated bin.
+type PrunePolicy string
+
+const (
+	// PrunePolicyScore prunes the peer with the lowest quality score.
+	PrunePolicyScore PrunePolicy = "score"
+	// PrunePolicyLatency prunes the peer with the highest latency.
+	PrunePolicyLatency PrunePolicy = "latency"
+	// PrunePolicyRandom prunes an unhealthy or an unreachable peer,
+	// or a random one if all the peers are healthy and reachable.
+	PrunePolicyRandom PrunePolicy = "random"
+)
+
+const defaultPrunePolicy = PrunePolicyScore
+
+// ErrInvalidPrunePolicy is returned for an unknown prune policy.
+var ErrInvalidPrunePolicy = errors.New("invalid prune policy")
+
+// ParsePrunePolicy parses the name of the prune policy.
+func ParsePrunePolicy(s string) (PrunePolicy, error) {
+	switch p := PrunePolicy(s); p {
+	case PrunePolicyScore, PrunePolicyLatency, PrunePolicyRandom:
+		return p, nil
+	case "":
+		return defaultPrunePolicy, nil
+	}
+	return "", fmt.Errorf("%q: %w", s, ErrInvalidPrunePolicy)
+}
+
+// Weights of the components of the peer score.
+const (
+	latencyWeight       = 0.3
+	uptimeWeight        = 0.3
+	protocolErrorWeight = 0.2
+	disputeWeight       = 0.2
+
+	unhealthyPenalty   = 1
+	unreachablePenalty = 0.5
+
+	// latencyReference is the latency scoring a half of its weight.
+	latencyReference = 200 * time.Millisecond
+	// uptimeReference is the connected time scoring the full weight.
+	uptimeReference = time.Hour
+	// protocolErrorsLimit and disputesLimit are the
+	// counts at which the respective weight is lost entirely.
+	protocolErrorsLimit = 10
+	disputesLimit       = 3
+)
+
+// peerScore returns the quality score of the peer based on its latency,
+// uptime, protocol errors and accounting disputes. The higher the score
+// the better the peer; the score of a healthy reachable peer is in [0, 1].
+func peerScore(ss *im.Snapshot) float64 {
+	if ss == nil {
+		return 0
+	}
+
+	latency := 0.5
+	if ss.LatencyEWMA > 0 {
+		latency = 1 / (1 + float64(ss.LatencyEWMA)/float64(latencyReference))
+	}
+	uptime := min(1, float64(ss.SessionConnectionDuration)/float64(uptimeReference))
+	protocolErrors := 1 - min(1, float64(ss.ProtocolErrors)/protocolErrorsLimit)
+	disputes := 1 - min(1, float64(ss.Disputes)/disputesLimit)
+
+	score := latencyWeight*latency +
+		uptimeWeight*uptime +
+		protocolErrorWeight*protocolErrors +
+		disputeWeight*disputes
+
+	if !ss.Healthy {
+		score -= unhealthyPenalty
+	}
+	if ss.Reachability != p2p.ReachabilityStatusPublic {
+		score -= unreachablePenalty
+	}
+	return score
+}
+
+// pruneCandidate picks the peer to disconnect out of the given peers
+// according to the prune policy.
+func (k *Kad) pruneCandidate(peers []swarm.Address) swarm.Address {
+	switch k.opt.PrunePolicy {
+	case PrunePolicyScore:
+		return worstPeer(peers, func(peer swarm.Address) float64 {
+			return peerScore(k.collector.Inspect(peer))
+		})
+	case PrunePolicyLatency:
+		return worstPeer(peers, func(peer swarm.Address) float64 {
+			if ss := k.collector.Inspect(peer); ss != nil {
+				return -float64(ss.LatencyEWMA)
+			}
+			return 0
+		})
+	}
+
+	var unreachablePeer = swarm.ZeroAddress
+	for _, peer := range peers {
+		if ss := k.collector.Inspect(peer); ss != nil {
+			if !ss.Healthy {
+				return peer
+			}
+			if ss.Reachability != p2p.ReachabilityStatusPublic {
+				unreachablePeer = peer
+			}
+		}
+	}
+	if !unreachablePeer.IsZero() {
+		return unreachablePeer
+	}
+	return peers[rand.Intn(len(peers))]
+}
+
+// worstPeer returns the peer with the lowest value of the quality function.
+func worstPeer(peers []swarm.Address, quality func(swarm.Address) float64) swarm.Address {
+	worst, lowest := peers[0], quality(peers[0])
+	for _, peer := range peers[1:] {
+		if q := quality(peer); q < lowest {
+			worst, lowest = peer, q
+		}
+	}
+	return worst
+}
+
+// RecordProtocolError records a protocol violation of the peer which lowers its score.
+func (k *Kad) RecordProtocolError(peer swarm.Address) {
+	k.collector.Record(peer, im.IncProtocolErrors())
+}
+
+// RecordDispute records an accounting dispute with the peer which lowers its score.
+func (k *Kad) RecordDispute(peer swarm.Address) {
+	k.collector.Record(peer, im.IncDisputes())
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/p2p"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/topology/kademlia"
+)
+
+func TestPruneCandidate(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, policy kademlia.PrunePolicy) (*kademlia.Kad, []swarm.Address) {
+		t.Helper()
+
+		_, kad, _, _, _ := newTestKademlia(t, nil, nil, kademlia.Options{PrunePolicy: policy})
+		peers := []swarm.Address{swarm.RandAddress(t), swarm.RandAddress(t), swarm.RandAddress(t)}
+		for _, peer := range peers {
+			kad.UpdatePeerHealth(peer, true, 10*time.Millisecond)
+			kad.Reachable(peer, p2p.ReachabilityStatusPublic)
+		}
+		return kad, peers
+	}
+
+	t.Run("score", func(t *testing.T) {
+		t.Parallel()
+
+		kad, peers := setup(t, kademlia.PrunePolicyScore)
+		prune := kademlia.PruneCandidateFunc(kad)
+
+		kad.RecordDispute(peers[2])
+		if have := prune(peers); !have.Equal(peers[2]) {
+			t.Fatalf("want disputed peer %s pruned, have %s", peers[2], have)
+		}
+
+		for i := 0; i < 5; i++ {
+			kad.RecordProtocolError(peers[1])
+		}
+		if have := prune(peers); !have.Equal(peers[1]) {
+			t.Fatalf("want misbehaving peer %s pruned, have %s", peers[1], have)
+		}
+
+		kad.UpdatePeerHealth(peers[0], false, 10*time.Millisecond)
+		if have := prune(peers); !have.Equal(peers[0]) {
+			t.Fatalf("want unhealthy peer %s pruned, have %s", peers[0], have)
+		}
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		t.Parallel()
+
+		kad, peers := setup(t, kademlia.PrunePolicyLatency)
+		prune := kademlia.PruneCandidateFunc(kad)
+
+		kad.RecordProtocolError(peers[2])
+		kad.UpdatePeerHealth(peers[1], true, time.Second)
+		if have := prune(peers); !have.Equal(peers[1]) {
+			t.Fatalf("want slow peer %s pruned, have %s", peers[1], have)
+		}
+	})
+
+	t.Run("random", func(t *testing.T) {
+		t.Parallel()
+
+		kad, peers := setup(t, kademlia.PrunePolicyRandom)
+		prune := kademlia.PruneCandidateFunc(kad)
+
+		kad.Reachable(peers[1], p2p.ReachabilityStatusPrivate)
+		if have := prune(peers); !have.Equal(peers[1]) {
+			t.Fatalf("want unreachable peer %s pruned, have %s", peers[1], have)
+		}
+	})
+}
+
+func TestParsePrunePolicy(t *testing.T) {
+	t.Parallel()
+
+	for s, want := range map[string]kademlia.PrunePolicy{
+		"":        kademlia.PrunePolicyScore,
+		"score":   kademlia.PrunePolicyScore,
+		"latency": kademlia.PrunePolicyLatency,
+		"random":  kademlia.PrunePolicyRandom,
+	} {
+		have, err := kademlia.ParsePrunePolicy(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != want {
+			t.Fatalf("policy %q: want %q, have %q", s, want, have)
+		}
+	}
+
+	if _, err := kademlia.ParsePrunePolicy("oldest"); !errors.Is(err, kademlia.ErrInvalidPrunePolicy) {
+		t.Fatalf("want error %v, have %v", kademlia.ErrInvalidPrunePolicy, err)
+	}
+}
@@ -225,7 +225,13 @@ func (d *mock) EachConnectedPeerRev(f topology.EachPeerFunc, _ topology.Select)
 }
 
 func (d *mock) Snapshot() *topology.KadParams {
-	return new(topology.KadParams)
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	return &topology.KadParams{
+		Connected: len(d.peers),
+		Depth:     d.depth,
+	}
 }
 
 func (d *mock) Halt()        {}
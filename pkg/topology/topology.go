@@ -84,6 +84,9 @@ type MetricSnapshotView struct {
 	LatencyEWMA                int64   `json:"latencyEWMA"`
 	Reachability               string  `json:"reachability"`
 	Healthy                    bool    `json:"healthy"`
+	ProtocolErrors             uint64  `json:"protocolErrors"`
+	Disputes                   uint64  `json:"disputes"`
+	Score                      float64 `json:"score"`
 }
 
 type BinInfo struct {
@@ -154,3 +157,37 @@ type SetStorageRadiuser interface {
 type PeersCounter interface {
 	PeersCount(Select) int
 }
+
+// EventType is the type of the topology event.
+type EventType string
+
+const (
+	EventPeerConnected    EventType = "peerConnected"
+	EventPeerDisconnected EventType = "peerDisconnected"
+	EventDepthChanged     EventType = "depthChanged"
+	EventPeerBlocklisted  EventType = "peerBlocklisted"
+)
+
+// Event is a change of the topology.
+type Event struct {
+	Type EventType
+	Time time.Time
+	// Peer and ProximityOrder are set for the peer events.
+	Peer           swarm.Address
+	ProximityOrder uint8
+	// Direction is set for the connect events.
+	Direction string
+	// Depth is the neighborhood depth after the event.
+	Depth uint8
+	// Reason and Duration are set for the blocklist events.
+	Reason   string
+	Duration time.Duration
+}
+
+// EventSubscriber streams the topology events.
+type EventSubscriber interface {
+	// SubscribeEvents returns the channel of the topology events. The events are
+	// dropped if the channel is not drained timely. Returned function is safe to
+	// be called multiple times.
+	SubscribeEvents() (c <-chan Event, unsubscribe func())
+}
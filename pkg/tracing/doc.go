@@ -24,6 +24,12 @@ To use the tracing package a Tracer instance must be created:
 	defer tracerCloser.Close()
 	// ...
 
+The endpoint of the host and the port sends the spans to the Jaeger agent.
+The endpoint URL sends them to the OpenTelemetry collector instead, over the
+OTLP gRPC exporter for the grpc:// and grpcs:// schemes and over the OTLP HTTP
+exporter for the http:// and https:// schemes. The span contexts of such tracer
+are propagated in the W3C trace context headers, both HTTP and p2p ones.
+
 The tracer instance contains functions for starting new span contexts, injecting
 them in other data, and extracting the active span them from the context:
 
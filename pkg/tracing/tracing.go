@@ -9,8 +9,11 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/log"
@@ -18,6 +21,14 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/uber/jaeger-client-go"
 	"github.com/uber/jaeger-client-go/config"
+	"go.opentelemetry.io/otel/attribute"
+	otbridge "go.opentelemetry.io/otel/bridge/opentracing"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -25,6 +36,9 @@ var (
 	// in p2p Headers or context.
 	ErrContextNotFound = errors.New("tracing context not found")
 
+	// ErrInvalidEndpoint is returned when the tracing endpoint cannot be parsed.
+	ErrInvalidEndpoint = errors.New("invalid tracing endpoint")
+
 	// noopTracer is the tracer that does nothing to handle a nil Tracer usage.
 	noopTracer = &Tracer{tracer: new(opentracing.NoopTracer)}
 )
@@ -43,15 +57,25 @@ const (
 	TraceBaggageHeaderPrefix = "swarmctx-"
 )
 
+// shutdownTimeout is the time given to the OpenTelemetry exporter
+// to flush the remaining spans on close.
+const shutdownTimeout = 5 * time.Second
+
 // Tracer connect to a tracing server and handles tracing spans and contexts
 // by using opentracing Tracer.
 type Tracer struct {
 	tracer opentracing.Tracer
+	// propagator is set when the tracer is bridged to OpenTelemetry, the
+	// span contexts are then propagated in the W3C trace context headers.
+	propagator propagation.TextMapPropagator
 }
 
 // Options are optional parameters for Tracer constructor.
 type Options struct {
-	Enabled     bool
+	Enabled bool
+	// Endpoint is the host and the port of the Jaeger agent, or the URL of
+	// the OpenTelemetry collector: grpc:// or grpcs:// for the OTLP gRPC
+	// exporter, http:// or https:// for the OTLP HTTP exporter.
 	Endpoint    string
 	ServiceName string
 }
@@ -63,6 +87,10 @@ func NewTracer(o *Options) (*Tracer, io.Closer, error) {
 		o = new(Options)
 	}
 
+	if o.Enabled && strings.Contains(o.Endpoint, "://") {
+		return newOTelTracer(o)
+	}
+
 	cfg := config.Configuration{
 		Disabled:    !o.Enabled,
 		ServiceName: o.ServiceName,
@@ -88,6 +116,56 @@ func NewTracer(o *Options) (*Tracer, io.Closer, error) {
 	return &Tracer{tracer: t}, closer, nil
 }
 
+// newOTelTracer returns the tracer bridged to the OpenTelemetry SDK
+// exporting the spans with the OTLP exporter of the endpoint scheme.
+func newOTelTracer(o *Options) (*Tracer, io.Closer, error) {
+	u, err := url.Parse(o.Endpoint)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidEndpoint, err)
+	}
+
+	var exporter sdktrace.SpanExporter
+	ctx := context.Background()
+	switch u.Scheme {
+	case "grpc":
+		exporter, err = otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(u.Host), otlptracegrpc.WithInsecure())
+	case "grpcs":
+		exporter, err = otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(u.Host))
+	case "http", "https":
+		if u.Path == "" || u.Path == "/" {
+			u.Path = "/v1/traces"
+		}
+		exporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(u.String()))
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported scheme %q", ErrInvalidEndpoint, u.Scheme)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("otlp exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(time.Second)),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", o.ServiceName))),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+	propagator := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	bridge := otbridge.NewBridgeTracer()
+	bridge.SetOpenTelemetryTracer(provider.Tracer(o.ServiceName))
+	bridge.SetTextMapPropagator(propagator)
+	bridge.SetWarningHandler(func(string) {})
+
+	closer := closerFunc(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return provider.Shutdown(ctx)
+	})
+	return &Tracer{tracer: bridge, propagator: propagator}, closer, nil
+}
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
+
 // StartSpanFromContext starts a new tracing span that is either a root one or a
 // child of existing one from the provided Context. If logger is provided, a new
 // log Entry will be returned with "traceID" log field.
@@ -139,6 +217,17 @@ func (t *Tracer) AddContextHeader(ctx context.Context, headers p2p.Headers) erro
 		return ErrContextNotFound
 	}
 
+	if t.propagator != nil {
+		carrier := opentracing.TextMapCarrier{}
+		if err := t.tracer.Inject(c, opentracing.TextMap, carrier); err != nil {
+			return err
+		}
+		for k, v := range carrier {
+			headers[k] = []byte(v)
+		}
+		return nil
+	}
+
 	var b bytes.Buffer
 	w := bufio.NewWriter(&b)
 	if err := t.tracer.Inject(c, opentracing.Binary, w); err != nil {
@@ -160,6 +249,26 @@ func (t *Tracer) FromHeaders(headers p2p.Headers) (opentracing.SpanContext, erro
 		t = noopTracer
 	}
 
+	if t.propagator != nil {
+		carrier := opentracing.TextMapCarrier{}
+		for _, k := range t.propagator.Fields() {
+			if v, ok := headers[k]; ok {
+				carrier[k] = string(v)
+			}
+		}
+		if len(carrier) == 0 {
+			return nil, ErrContextNotFound
+		}
+		c, err := t.tracer.Extract(opentracing.TextMap, carrier)
+		if err != nil {
+			if errors.Is(err, opentracing.ErrSpanContextNotFound) {
+				return nil, ErrContextNotFound
+			}
+			return nil, err
+		}
+		return c, nil
+	}
+
 	v := headers[p2p.HeaderNameTracingSpanContext]
 	if v == nil {
 		return nil, ErrContextNotFound
@@ -267,13 +376,19 @@ func loggerWithTraceID(sc opentracing.SpanContext, l log.Logger) log.Logger {
 	if l == nil {
 		return nil
 	}
-	jsc, ok := sc.(jaeger.SpanContext)
-	if !ok {
-		return l
-	}
-	traceID := jsc.TraceID()
-	if !traceID.IsValid() {
-		return l
+	switch c := sc.(type) {
+	case jaeger.SpanContext:
+		traceID := c.TraceID()
+		if !traceID.IsValid() {
+			return l
+		}
+		return l.WithValues(LogField, traceID).Build()
+	case interface{ TraceID() oteltrace.TraceID }:
+		traceID := c.TraceID()
+		if !traceID.IsValid() {
+			return l
+		}
+		return l.WithValues(LogField, traceID).Build()
 	}
-	return l.WithValues(LogField, traceID).Build()
+	return l
 }
@@ -8,7 +8,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/ethersphere/bee/v2/pkg/log"
@@ -241,3 +246,70 @@ func newTracer(t *testing.T) *tracing.Tracer {
 
 	return tracer
 }
+
+func TestOpenTelemetryTracer(t *testing.T) {
+	t.Parallel()
+
+	var exported atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			exported.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(collector.Close)
+
+	tracer, closer, err := tracing.NewTracer(&tracing.Options{
+		Enabled:     true,
+		Endpoint:    collector.URL,
+		ServiceName: "test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	span, _, ctx := tracer.StartSpanFromContext(context.Background(), "some-operation", nil)
+
+	// the span context is propagated in the w3c trace context header
+	headers := make(p2p.Headers)
+	if err := tracer.AddContextHeader(ctx, headers); err != nil {
+		t.Fatal(err)
+	}
+	traceParent := string(headers["traceparent"])
+	if traceParent == "" {
+		t.Fatalf("got headers %v, want traceparent", headers)
+	}
+	ctx, err = tracer.WithContextFromHeaders(context.Background(), headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote, _, _ := tracer.StartSpanFromContext(ctx, "remote-operation", nil)
+	remote.Finish()
+	span.Finish()
+
+	buf := new(bytes.Buffer)
+	tracing.NewLoggerWithTraceID(ctx, log.NewLogger("test", log.WithSink(buf), log.WithJSONOutput())).Info("msg")
+	if !strings.Contains(buf.String(), strings.Split(traceParent, "-")[1]) {
+		t.Fatalf("got log %q without the trace id of %q", buf.String(), traceParent)
+	}
+
+	if _, err := tracer.FromHeaders(make(p2p.Headers)); !errors.Is(err, tracing.ErrContextNotFound) {
+		t.Fatalf("got error %v, want %v", err, tracing.ErrContextNotFound)
+	}
+
+	if err := closer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if exported.Load() == 0 {
+		t.Fatal("spans not exported")
+	}
+}
+
+func TestNewTracerInvalidEndpoint(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := tracing.NewTracer(&tracing.Options{Enabled: true, Endpoint: "udp://127.0.0.1:6831"})
+	if !errors.Is(err, tracing.ErrInvalidEndpoint) {
+		t.Fatalf("got error %v, want %v", err, tracing.ErrInvalidEndpoint)
+	}
+}
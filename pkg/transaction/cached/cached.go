@@ -0,0 +1,393 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cached provides a chain backend proxy which caches the responses
+// of the blockchain backend, coalesces the concurrent identical requests and
+// limits the number of requests sent to the backend per hour.
+package cached
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethersphere/bee/v2/pkg/transaction"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// budgetWindow is the period the request budget applies to.
+	budgetWindow = time.Hour
+	// reorgDepth is the number of blocks after which the block headers and
+	// the transaction receipts are considered final and may be cached.
+	reorgDepth = 12
+	// cacheSize is the number of cached block headers and receipts.
+	cacheSize = 1024
+	// requestTimeout bounds the coalesced requests, which are
+	// not canceled with the context of any one of their callers.
+	requestTimeout = time.Minute
+)
+
+// ErrBudgetExceeded is returned when the hourly request
+// budget of the chain backend is exhausted.
+var ErrBudgetExceeded = errors.New("chain backend request budget exceeded")
+
+var _ transaction.Backend = (*Backend)(nil)
+
+// Options configures the Backend.
+type Options struct {
+	// Cache enables caching of the backend responses.
+	Cache bool
+	// BlockTime is the duration for which the latest block number is cached.
+	BlockTime time.Duration
+	// HourlyBudget is the maximum number of requests sent to the
+	// backend per hour. Zero value means the number is not limited.
+	HourlyBudget uint64
+}
+
+// Backend is a transaction.Backend proxy which caches
+// the responses and enforces the request budget.
+type Backend struct {
+	backend transaction.Backend
+	opts    Options
+	metrics metrics
+	group   singleflight.Group
+	now     func() time.Time
+
+	mu            sync.Mutex
+	blockNumber   uint64
+	blockNumberAt time.Time
+	headers       *lru.Cache[uint64, *types.Header]
+	receipts      *lru.Cache[common.Hash, *types.Receipt]
+	calls         *lru.Cache[common.Hash, []byte]
+	chainID       *big.Int
+
+	budgetMu    sync.Mutex
+	windowStart time.Time
+	spent       uint64
+}
+
+// NewBackend wraps the given backend.
+func NewBackend(backend transaction.Backend, o Options) *Backend {
+	headers, _ := lru.New[uint64, *types.Header](cacheSize)
+	receipts, _ := lru.New[common.Hash, *types.Receipt](cacheSize)
+	calls, _ := lru.New[common.Hash, []byte](cacheSize)
+
+	return &Backend{
+		backend:  backend,
+		opts:     o,
+		metrics:  newMetrics(),
+		now:      time.Now,
+		headers:  headers,
+		receipts: receipts,
+		calls:    calls,
+	}
+}
+
+// spend takes a request from the budget.
+func (b *Backend) spend() error {
+	if b.opts.HourlyBudget == 0 {
+		return nil
+	}
+
+	b.budgetMu.Lock()
+	defer b.budgetMu.Unlock()
+
+	if now := b.now(); now.Sub(b.windowStart) >= budgetWindow {
+		b.windowStart = now
+		b.spent = 0
+	}
+	if b.spent >= b.opts.HourlyBudget {
+		b.metrics.BudgetExceeded.Inc()
+		return ErrBudgetExceeded
+	}
+	b.spent++
+	b.metrics.BudgetRemaining.Set(float64(b.opts.HourlyBudget - b.spent))
+	return nil
+}
+
+// final reports whether the given block is deep enough
+// in the chain to cache the data related to it.
+func (b *Backend) final(block uint64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.blockNumber >= reorgDepth && block <= b.blockNumber-reorgDepth
+}
+
+// do executes fn once for the concurrent callers with the same key. The
+// request is detached from the context of the caller which started it, so
+// that its cancellation does not fail the other callers, and every caller
+// returns once its own context is done.
+func (b *Backend) do(ctx context.Context, key string, fn func(context.Context) (interface{}, error)) (interface{}, error) {
+	ch := b.group.DoChan(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), requestTimeout)
+		defer cancel()
+		return fn(ctx)
+	})
+	select {
+	case r := <-ch:
+		return r.Val, r.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *Backend) hit(method string)  { b.metrics.CacheHits.WithLabelValues(method).Inc() }
+func (b *Backend) miss(method string) { b.metrics.CacheMisses.WithLabelValues(method).Inc() }
+
+func (b *Backend) BlockNumber(ctx context.Context) (uint64, error) {
+	if b.opts.Cache {
+		b.mu.Lock()
+		if !b.blockNumberAt.IsZero() && b.now().Sub(b.blockNumberAt) < b.opts.BlockTime {
+			n := b.blockNumber
+			b.mu.Unlock()
+			b.hit("block_number")
+			return n, nil
+		}
+		b.mu.Unlock()
+		b.miss("block_number")
+	}
+
+	v, err := b.do(ctx, "block_number", func(ctx context.Context) (interface{}, error) {
+		if err := b.spend(); err != nil {
+			return nil, err
+		}
+		n, err := b.backend.BlockNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b.mu.Lock()
+		b.blockNumber = n
+		b.blockNumberAt = b.now()
+		b.mu.Unlock()
+		return n, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return v.(uint64), nil
+}
+
+func (b *Backend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	cacheable := b.opts.Cache && number != nil && number.IsUint64()
+	if cacheable {
+		if h, ok := b.headers.Get(number.Uint64()); ok {
+			b.hit("header")
+			return h, nil
+		}
+		b.miss("header")
+	}
+
+	key := "header_latest"
+	if number != nil {
+		key = "header_" + number.String()
+	}
+	v, err := b.do(ctx, key, func(ctx context.Context) (interface{}, error) {
+		if err := b.spend(); err != nil {
+			return nil, err
+		}
+		h, err := b.backend.HeaderByNumber(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		if cacheable && b.final(number.Uint64()) {
+			b.headers.Add(number.Uint64(), h)
+		}
+		return h, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*types.Header), nil
+}
+
+func (b *Backend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if b.opts.Cache {
+		if r, ok := b.receipts.Get(txHash); ok {
+			b.hit("receipt")
+			return r, nil
+		}
+		b.miss("receipt")
+	}
+
+	v, err := b.do(ctx, "receipt_"+txHash.Hex(), func(ctx context.Context) (interface{}, error) {
+		if err := b.spend(); err != nil {
+			return nil, err
+		}
+		r, err := b.backend.TransactionReceipt(ctx, txHash)
+		if err != nil {
+			return nil, err
+		}
+		if b.opts.Cache && r.BlockNumber != nil && r.BlockNumber.IsUint64() && b.final(r.BlockNumber.Uint64()) {
+			b.receipts.Add(txHash, r)
+		}
+		return r, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*types.Receipt), nil
+}
+
+func (b *Backend) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	if err := b.spend(); err != nil {
+		return nil, false, err
+	}
+	return b.backend.TransactionByHash(ctx, hash)
+}
+
+func (b *Backend) BalanceAt(ctx context.Context, address common.Address, block *big.Int) (*big.Int, error) {
+	if err := b.spend(); err != nil {
+		return nil, err
+	}
+	return b.backend.BalanceAt(ctx, address, block)
+}
+
+func (b *Backend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	if err := b.spend(); err != nil {
+		return 0, err
+	}
+	return b.backend.NonceAt(ctx, account, blockNumber)
+}
+
+func (b *Backend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if err := b.spend(); err != nil {
+		return nil, err
+	}
+	return b.backend.CodeAt(ctx, contract, blockNumber)
+}
+
+// CallContract executes the contract call. The results of the calls at
+// the final blocks, e.g. the batch lookups at a block, are cached.
+func (b *Backend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	cacheable := b.opts.Cache && blockNumber != nil && blockNumber.IsUint64() && b.final(blockNumber.Uint64())
+	var key common.Hash
+	if cacheable {
+		key = callKey(call, blockNumber)
+		if v, ok := b.calls.Get(key); ok {
+			b.hit("call")
+			return v, nil
+		}
+		b.miss("call")
+	}
+
+	if err := b.spend(); err != nil {
+		return nil, err
+	}
+	v, err := b.backend.CallContract(ctx, call, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable {
+		b.calls.Add(key, v)
+	}
+	return v, nil
+}
+
+// callKey identifies the result of a read only contract call at the block.
+func callKey(call ethereum.CallMsg, blockNumber *big.Int) common.Hash {
+	var to []byte
+	if call.To != nil {
+		to = call.To.Bytes()
+	}
+	return crypto.Keccak256Hash(call.From.Bytes(), to, call.Data, blockNumber.Bytes())
+}
+
+func (b *Backend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if err := b.spend(); err != nil {
+		return 0, err
+	}
+	return b.backend.PendingNonceAt(ctx, account)
+}
+
+func (b *Backend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if err := b.spend(); err != nil {
+		return nil, err
+	}
+	return b.backend.SuggestGasPrice(ctx)
+}
+
+func (b *Backend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	if err := b.spend(); err != nil {
+		return nil, err
+	}
+	return b.backend.SuggestGasTipCap(ctx)
+}
+
+func (b *Backend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	if err := b.spend(); err != nil {
+		return 0, err
+	}
+	return b.backend.EstimateGas(ctx, call)
+}
+
+func (b *Backend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := b.spend(); err != nil {
+		return err
+	}
+	return b.backend.SendTransaction(ctx, tx)
+}
+
+func (b *Backend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	if err := b.spend(); err != nil {
+		return nil, err
+	}
+	return b.backend.FilterLogs(ctx, query)
+}
+
+// SubscribeFilterLogs subscribes to the logs matching the query. It returns
+// rpc.ErrNotificationsUnsupported if the backend is not able to push the logs.
+// The pushed logs are not counted against the request budget.
+func (b *Backend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	s, ok := b.backend.(ethereum.LogFilterer)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	if err := b.spend(); err != nil {
+		return nil, err
+	}
+	return s.SubscribeFilterLogs(ctx, query, ch)
+}
+
+func (b *Backend) ChainID(ctx context.Context) (*big.Int, error) {
+	b.mu.Lock()
+	chainID := b.chainID
+	b.mu.Unlock()
+	if chainID != nil {
+		b.hit("chain_id")
+		return new(big.Int).Set(chainID), nil
+	}
+
+	v, err := b.do(ctx, "chain_id", func(ctx context.Context) (interface{}, error) {
+		if err := b.spend(); err != nil {
+			return nil, err
+		}
+		id, err := b.backend.ChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if b.opts.Cache {
+			b.mu.Lock()
+			b.chainID = new(big.Int).Set(id)
+			b.mu.Unlock()
+		}
+		return id, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(v.(*big.Int)), nil
+}
+
+func (b *Backend) Close() {
+	b.backend.Close()
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cached_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethersphere/bee/v2/pkg/transaction/backendmock"
+	"github.com/ethersphere/bee/v2/pkg/transaction/cached"
+)
+
+func TestBlockNumberCache(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	backend := cached.NewBackend(backendmock.New(
+		backendmock.WithBlockNumberFunc(func(context.Context) (uint64, error) {
+			return uint64(100 + calls.Add(1)), nil
+		}),
+	), cached.Options{Cache: true, BlockTime: 5 * time.Second})
+
+	now := time.Unix(0, 0)
+	cached.SetNow(backend, func() time.Time { return now })
+
+	for i := 0; i < 3; i++ {
+		n, err := backend.BlockNumber(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 101 {
+			t.Fatalf("block number: want %d, have %d", 101, n)
+		}
+	}
+
+	now = now.Add(5 * time.Second)
+	n, err := backend.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 102 {
+		t.Fatalf("block number: want %d, have %d", 102, n)
+	}
+	if c := calls.Load(); c != 2 {
+		t.Fatalf("backend calls: want %d, have %d", 2, c)
+	}
+}
+
+func TestReceiptCache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls       atomic.Int32
+		blockNumber atomic.Uint64
+	)
+	blockNumber.Store(100)
+	backend := cached.NewBackend(backendmock.New(
+		backendmock.WithBlockNumberFunc(func(context.Context) (uint64, error) {
+			return blockNumber.Load(), nil
+		}),
+		backendmock.WithTransactionReceiptFunc(func(context.Context, common.Hash) (*types.Receipt, error) {
+			calls.Add(1)
+			return &types.Receipt{BlockNumber: big.NewInt(95)}, nil
+		}),
+	), cached.Options{Cache: true})
+
+	receipt := func() {
+		t.Helper()
+		if _, err := backend.TransactionReceipt(context.Background(), common.Hash{1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The receipt is not final yet, so it is not cached.
+	if _, err := backend.BlockNumber(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	receipt()
+	receipt()
+	if c := calls.Load(); c != 2 {
+		t.Fatalf("backend calls: want %d, have %d", 2, c)
+	}
+
+	blockNumber.Store(120)
+	if _, err := backend.BlockNumber(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	receipt()
+	receipt()
+	if c := calls.Load(); c != 3 {
+		t.Fatalf("backend calls: want %d, have %d", 3, c)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	t.Parallel()
+
+	backend := cached.NewBackend(backendmock.New(
+		backendmock.WithSuggestGasPriceFunc(func(context.Context) (*big.Int, error) {
+			return big.NewInt(1), nil
+		}),
+	), cached.Options{HourlyBudget: 2})
+
+	now := time.Unix(0, 0)
+	cached.SetNow(backend, func() time.Time { return now })
+
+	for i := 0; i < 2; i++ {
+		if _, err := backend.SuggestGasPrice(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := backend.SuggestGasPrice(context.Background()); !errors.Is(err, cached.ErrBudgetExceeded) {
+		t.Fatalf("want %v, have %v", cached.ErrBudgetExceeded, err)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := backend.SuggestGasPrice(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestCoalescedCancel tests that the coalesced request, which the other
+// callers may share, is not canceled with the caller which started it.
+func TestCoalescedCancel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+		result  = make(chan error, 1)
+	)
+	backend := cached.NewBackend(backendmock.New(
+		backendmock.WithBlockNumberFunc(func(ctx context.Context) (uint64, error) {
+			close(started)
+			<-release
+			result <- ctx.Err()
+			return 100, nil
+		}),
+	), cached.Options{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	caller := make(chan error, 1)
+	go func() {
+		_, err := backend.BlockNumber(ctx)
+		caller <- err
+	}()
+	<-started
+
+	cancel()
+	if err := <-caller; !errors.Is(err, context.Canceled) {
+		t.Fatalf("caller: want %v, have %v", context.Canceled, err)
+	}
+
+	close(release)
+	if err := <-result; err != nil {
+		t.Fatalf("request canceled with the caller: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cached
+
+import (
+	m "github.com/ethersphere/bee/v2/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	CacheHits       *prometheus.CounterVec
+	CacheMisses     *prometheus.CounterVec
+	BudgetExceeded  prometheus.Counter
+	BudgetRemaining prometheus.Gauge
+}
+
+func newMetrics() metrics {
+	subsystem := "eth_backend_proxy"
+
+	return metrics{
+		CacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "cache_hits",
+			Help:      "Count of chain backend requests served from the cache.",
+		}, []string{"method"}),
+		CacheMisses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "cache_misses",
+			Help:      "Count of chain backend requests not found in the cache.",
+		}, []string{"method"}),
+		BudgetExceeded: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "budget_exceeded",
+			Help:      "Count of chain backend requests rejected due to the exhausted request budget.",
+		}),
+		BudgetRemaining: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "budget_remaining",
+			Help:      "Number of remaining chain backend requests in the current budget window.",
+		}),
+	}
+}
+
+// Metrics returns the metrics of the proxy and of the wrapped backend.
+func (b *Backend) Metrics() []prometheus.Collector {
+	cs := m.PrometheusCollectorsFromFields(b.metrics)
+	if c, ok := b.backend.(m.Collector); ok {
+		cs = append(cs, c.Metrics()...)
+	}
+	return cs
+}
@@ -26,6 +26,7 @@ type metrics struct {
 	EstimateGasCalls        prometheus.Counter
 	SendTransactionCalls    prometheus.Counter
 	FilterLogsCalls         prometheus.Counter
+	SubscribeLogsCalls      prometheus.Counter
 	ChainIDCalls            prometheus.Counter
 }
 
@@ -123,6 +124,12 @@ func newMetrics() metrics {
 			Name:      "calls_filter_logs",
 			Help:      "Count of eth_getLogs rpc calls",
 		}),
+		SubscribeLogsCalls: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "calls_subscribe_logs",
+			Help:      "Count of eth_subscribe logs rpc calls",
+		}),
 		ChainIDCalls: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: m.Namespace,
 			Subsystem: subsystem,
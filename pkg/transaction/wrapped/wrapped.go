@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethersphere/bee/v2/pkg/transaction"
 )
 
@@ -191,6 +192,26 @@ func (b *wrappedBackend) FilterLogs(ctx context.Context, query ethereum.FilterQu
 	return logs, nil
 }
 
+// SubscribeFilterLogs subscribes to the logs matching the query. It returns
+// rpc.ErrNotificationsUnsupported if the backend is not able to push the logs.
+func (b *wrappedBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	s, ok := b.backend.(ethereum.LogFilterer)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+
+	b.metrics.TotalRPCCalls.Inc()
+	b.metrics.SubscribeLogsCalls.Inc()
+	sub, err := s.SubscribeFilterLogs(ctx, query, ch)
+	if err != nil {
+		if !errors.Is(err, rpc.ErrNotificationsUnsupported) {
+			b.metrics.TotalRPCErrors.Inc()
+		}
+		return nil, err
+	}
+	return sub, nil
+}
+
 func (b *wrappedBackend) ChainID(ctx context.Context) (*big.Int, error) {
 	b.metrics.TotalRPCCalls.Inc()
 	b.metrics.ChainIDCalls.Inc()
@@ -6,12 +6,17 @@
 // needed to traverse all chunks below a given root hash.
 // It tries to parse all manifests and collections in its
 // attempt to log all chunk addresses on the way.
+//
+// The files referenced by the manifests are traversed by a bounded number
+// of concurrent workers and the progress of the traversal is reported to the
+// function attached to the context with WithProgress.
 package traversal
 
 import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/ethersphere/bee/v2/pkg/file/joiner"
 	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
@@ -21,6 +26,15 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/soc"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultWorkers is the default number of the files traversed concurrently.
+	DefaultWorkers = 8
+	// progressInterval is the number of the iterated chunk addresses after
+	// which the progress is reported.
+	progressInterval = 256
 )
 
 // Traverser represents service which traverse through address dependent chunks.
@@ -29,29 +43,95 @@ type Traverser interface {
 	Traverse(context.Context, swarm.Address, swarm.AddressIterFunc) error
 }
 
+// Progress is the progress of the traversal.
+type Progress struct {
+	// Chunks is the number of the iterated chunk addresses.
+	Chunks int
+	// Files is the number of the traversed files and manifest nodes.
+	Files int
+}
+
+// ProgressFunc is called with the progress of the traversal.
+type ProgressFunc func(Progress)
+
+type progressKey struct{}
+
+// WithProgress returns the context with which the traversal reports its
+// progress to the fn. It is called after every traversed file and
+// periodically while the chunk addresses of a file are iterated.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// Option configures the Traverser.
+type Option func(*service)
+
+// WithWorkers sets the number of the files traversed concurrently.
+func WithWorkers(n int) Option {
+	return func(s *service) {
+		if n > 0 {
+			s.workers = n
+		}
+	}
+}
+
 // New constructs for a new Traverser.
-func New(getter storage.Getter, putter storage.Putter, rLevel redundancy.Level) Traverser {
-	return &service{getter: getter, putter: putter, rLevel: rLevel}
+func New(getter storage.Getter, putter storage.Putter, rLevel redundancy.Level, opts ...Option) Traverser {
+	s := &service{getter: getter, putter: putter, rLevel: rLevel, workers: DefaultWorkers}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
 }
 
 // service is implementation of Traverser using storage.Storer as its storage.
 type service struct {
-	getter storage.Getter
-	putter storage.Putter
-	rLevel redundancy.Level
+	getter  storage.Getter
+	putter  storage.Putter
+	rLevel  redundancy.Level
+	workers int
 }
 
 // Traverse implements Traverser.Traverse method.
+// The iterFn is never called concurrently.
 func (s *service) Traverse(ctx context.Context, addr swarm.Address, iterFn swarm.AddressIterFunc) error {
-	processBytes := func(ref swarm.Address) error {
+	var (
+		mu       sync.Mutex // mu serializes the iterFn calls and guards progress.
+		progress Progress
+	)
+	report, _ := ctx.Value(progressKey{}).(ProgressFunc)
+
+	iter := func(addr swarm.Address) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err := iterFn(addr); err != nil {
+			return err
+		}
+		progress.Chunks++
+		if report != nil && progress.Chunks%progressInterval == 0 {
+			report(progress)
+		}
+		return nil
+	}
+
+	processBytes := func(ctx context.Context, ref swarm.Address) error {
 		j, _, err := joiner.New(ctx, s.getter, s.putter, ref, s.rLevel)
 		if err != nil {
 			return fmt.Errorf("traversal: joiner error on %q: %w", ref, err)
 		}
-		err = j.IterateChunkAddresses(iterFn)
+		err = j.IterateChunkAddresses(iter)
 		if err != nil {
 			return fmt.Errorf("traversal: iterate chunk address error for %q: %w", ref, err)
 		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		progress.Files++
+		if report != nil {
+			report(progress)
+		}
 		return nil
 	}
 
@@ -63,7 +143,7 @@ func (s *service) Traverse(ctx context.Context, addr swarm.Address, iterFn swarm
 		}
 		if soc.Valid(ch) {
 			// if this is a SOC, the traversal will be just be the single chunk
-			return iterFn(addr)
+			return iter(addr)
 		}
 	}
 
@@ -84,7 +164,15 @@ func (s *service) Traverse(ctx context.Context, addr swarm.Address, iterFn swarm
 		case err != nil:
 			return fmt.Errorf("traversal: unable to create manifest reference for %q: %w", addr, err)
 		default:
-			err := mf.IterateAddresses(ctx, processBytes)
+			eg, ectx := errgroup.WithContext(ctx)
+			eg.SetLimit(s.workers)
+			err := mf.IterateAddresses(ectx, func(ref swarm.Address) error {
+				eg.Go(func() error { return processBytes(ectx, ref) })
+				return nil
+			})
+			if werr := eg.Wait(); werr != nil {
+				return fmt.Errorf("traversal: unable to process bytes for %q: %w", addr, werr)
+			}
 			if errors.Is(err, mantaray.ErrTooShort) || errors.Is(err, mantaray.ErrInvalidVersionHash) {
 				// Based on the returned errors we conclude that it might
 				// not be a manifest, so we try non-manifest processing.
@@ -98,7 +186,7 @@ func (s *service) Traverse(ctx context.Context, addr swarm.Address, iterFn swarm
 	}
 
 	// Non-manifest processing.
-	if err := processBytes(addr); err != nil {
+	if err := processBytes(ctx, addr); err != nil {
 		return fmt.Errorf("traversal: unable to process bytes for %q: %w", addr, err)
 	}
 	return nil
@@ -502,6 +502,70 @@ func TestTraversalSOC(t *testing.T) {
 	}
 }
 
+func TestTraversalProgress(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	store := inmemchunkstore.New()
+	ls := loadsave.New(store, store, pipelineFactory(store, false), redundancy.DefaultLevel)
+	dirManifest, err := manifest.NewMantarayManifest(ls, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		pipe := builder.NewPipelineBuilder(ctx, store, false, 0)
+		fr, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(generateSample(swarm.ChunkSize*(i+2))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dirManifest.Add(ctx, fmt.Sprintf("file-%d.txt", i), manifest.NewEntry(fr, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	address, err := dirManifest.Store(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, workers := range []int{1, traversal.DefaultWorkers} {
+		t.Run(fmt.Sprintf("workers-%d", workers), func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				iter     = newAddressIterator(true)
+				reported []traversal.Progress
+			)
+			ctx := traversal.WithProgress(ctx, func(p traversal.Progress) {
+				reported = append(reported, p)
+			})
+
+			err := traversal.New(store, store, redundancy.DefaultLevel, traversal.WithWorkers(workers)).Traverse(ctx, address, iter.Next)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(reported) == 0 {
+				t.Fatal("no progress reported")
+			}
+			for i := 1; i < len(reported); i++ {
+				if reported[i].Chunks < reported[i-1].Chunks || reported[i].Files < reported[i-1].Files {
+					t.Fatalf("progress decreased: %+v after %+v", reported[i], reported[i-1])
+				}
+			}
+			last := reported[len(reported)-1]
+			if last.Chunks != iter.cnt {
+				t.Fatalf("reported chunks: have %d; want %d", last.Chunks, iter.cnt)
+			}
+			// every file is counted along with its manifest entry nodes
+			if last.Files < 20 {
+				t.Fatalf("reported files: have %d; want at least 20", last.Files)
+			}
+		})
+	}
+}
+
 func pipelineFactory(s storage.Putter, encrypt bool) func() pipeline.Interface {
 	return func() pipeline.Interface {
 		return builder.NewPipelineBuilder(context.Background(), s, encrypt, 0)
@@ -0,0 +1,75 @@
+// Copyright 2025 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ioutil_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/util/ioutil"
+)
+
+func TestRotatingFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "logs", "access.log")
+
+	f, err := ioutil.NewRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	} {
+		got, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("got %q in %s, want %q", got, name, want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got error %v for the removed backup, want %v", err, os.ErrNotExist)
+	}
+
+	t.Run("append to existing", func(t *testing.T) {
+		f, err := ioutil.NewRotatingFile(path, 10, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte("fifth\n")); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte("closed\n")); !errors.Is(err, os.ErrClosed) {
+			t.Fatalf("got error %v, want %v", err, os.ErrClosed)
+		}
+
+		got, err := os.ReadFile(path + ".1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "fourth\n" {
+			t.Errorf("got %q, want %q", got, "fourth\n")
+		}
+	})
+}
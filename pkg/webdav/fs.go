@@ -0,0 +1,238 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package webdav
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	xwebdav "golang.org/x/net/webdav"
+)
+
+var (
+	errNotWritable = errors.New("file opened for reading")
+	errNotReadable = errors.New("file opened for writing")
+)
+
+// fsError returns the error the WebDAV handler maps to the status,
+// it only recognizes the unwrapped os errors.
+func fsError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, fs.ErrNotExist):
+		return os.ErrNotExist
+	case errors.Is(err, fs.ErrExist):
+		return os.ErrExist
+	case errors.Is(err, fs.ErrPermission), errors.Is(err, manifestfs.ErrReadOnly):
+		return os.ErrPermission
+	}
+	return err
+}
+
+// fileSystem adapts the manifest file system to the WebDAV handler.
+type fileSystem struct {
+	fs *manifestfs.FS
+}
+
+var _ xwebdav.FileSystem = (*fileSystem)(nil)
+
+func (f *fileSystem) Mkdir(ctx context.Context, name string, _ os.FileMode) error {
+	return fsError(f.fs.Mkdir(ctx, name))
+}
+
+func (f *fileSystem) RemoveAll(ctx context.Context, name string) error {
+	return fsError(f.fs.RemoveAll(ctx, name))
+}
+
+func (f *fileSystem) Rename(ctx context.Context, oldName, newName string) error {
+	return fsError(f.fs.Rename(ctx, oldName, newName))
+}
+
+func (f *fileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	fi, err := f.fs.Stat(ctx, name)
+	if err != nil {
+		return nil, fsError(err)
+	}
+	return fileInfo{fi}, nil
+}
+
+// OpenFile opens the file or the directory for reading, or the file for
+// writing. The written file replaces the existing one when it is closed.
+func (f *fileSystem) OpenFile(ctx context.Context, name string, flag int, _ os.FileMode) (xwebdav.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		fi, err := f.fs.Stat(ctx, name)
+		if err != nil {
+			return nil, fsError(err)
+		}
+		if fi.IsDir() {
+			return &dir{fs: f.fs, ctx: ctx, name: name, info: fi}, nil
+		}
+		r, fi, err := f.fs.Open(ctx, name)
+		if err != nil {
+			return nil, fsError(err)
+		}
+		return &file{File: r, info: fi}, nil
+	}
+
+	if !f.fs.Writable() {
+		return nil, os.ErrPermission
+	}
+	fi, err := f.fs.Stat(ctx, name)
+	switch {
+	case err == nil && fi.IsDir():
+		return nil, manifestfs.ErrIsDir
+	case err == nil && flag&os.O_EXCL != 0:
+		return nil, os.ErrExist
+	case err != nil && !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	case err != nil && flag&os.O_CREATE == 0:
+		return nil, os.ErrNotExist
+	}
+	// the parent is checked before the data is uploaded
+	if parent, err := f.fs.Stat(ctx, path.Dir(name)); err != nil {
+		return nil, fsError(err)
+	} else if !parent.IsDir() {
+		return nil, os.ErrNotExist
+	}
+	return newWriter(ctx, f.fs, name), nil
+}
+
+// fileInfo provides the content type and the ETag of the file from
+// the manifest, so the data is not read to determine them.
+type fileInfo struct {
+	*manifestfs.FileInfo
+}
+
+func (fi fileInfo) ContentType(context.Context) (string, error) {
+	if fi.IsDir() || fi.FileInfo.ContentType() == "" {
+		return "", xwebdav.ErrNotImplemented
+	}
+	return fi.FileInfo.ContentType(), nil
+}
+
+func (fi fileInfo) ETag(context.Context) (string, error) {
+	if fi.IsDir() {
+		return "", xwebdav.ErrNotImplemented
+	}
+	return strconv.Quote(fi.Reference().String()), nil
+}
+
+// file is the file opened for reading.
+type file struct {
+	manifestfs.File
+	info *manifestfs.FileInfo
+}
+
+func (f *file) Close() error                       { return nil }
+func (f *file) Write([]byte) (int, error)          { return 0, errNotWritable }
+func (f *file) Readdir(int) ([]fs.FileInfo, error) { return nil, manifestfs.ErrIsDir }
+func (f *file) Stat() (fs.FileInfo, error)         { return fileInfo{f.info}, nil }
+
+// dir is the opened directory.
+type dir struct {
+	fs   *manifestfs.FS
+	ctx  context.Context
+	name string
+	info *manifestfs.FileInfo
+
+	entries []fs.FileInfo // nil until the directory is read
+	pos     int
+}
+
+func (d *dir) Close() error                   { return nil }
+func (d *dir) Read([]byte) (int, error)       { return 0, manifestfs.ErrIsDir }
+func (d *dir) Seek(int64, int) (int64, error) { return 0, manifestfs.ErrIsDir }
+func (d *dir) Write([]byte) (int, error)      { return 0, errNotWritable }
+func (d *dir) Stat() (fs.FileInfo, error)     { return fileInfo{d.info}, nil }
+
+// Readdir returns the next count entries of the directory,
+// or all the remaining ones when count is not positive.
+func (d *dir) Readdir(count int) ([]fs.FileInfo, error) {
+	if d.entries == nil {
+		infos, err := d.fs.ReadDir(d.ctx, d.name)
+		if err != nil {
+			return nil, fsError(err)
+		}
+		d.entries = make([]fs.FileInfo, 0, len(infos))
+		for _, fi := range infos {
+			d.entries = append(d.entries, fileInfo{fi})
+		}
+	}
+
+	rest := d.entries[d.pos:]
+	if count <= 0 {
+		d.pos = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	n := min(count, len(rest))
+	d.pos += n
+	return rest[:n], nil
+}
+
+// writer is the file opened for writing, the written data is streamed
+// to the upload which is finished when the file is closed.
+type writer struct {
+	name string
+	pw   *io.PipeWriter
+	done chan error
+	n    int64
+	err  error
+}
+
+func newWriter(ctx context.Context, mfs *manifestfs.FS, name string) *writer {
+	pr, pw := io.Pipe()
+	w := &writer{name: name, pw: pw, done: make(chan error, 1)}
+	go func() {
+		err := mfs.WriteFile(ctx, name, pr, mime.TypeByExtension(path.Ext(name)))
+		pr.CloseWithError(err)
+		w.done <- err
+	}()
+	return w
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	n, err := w.pw.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+func (w *writer) Close() error {
+	if w.done == nil {
+		return w.err
+	}
+	_ = w.pw.Close()
+	w.err = fsError(<-w.done)
+	w.done = nil
+	return w.err
+}
+
+func (w *writer) Read([]byte) (int, error)           { return 0, errNotReadable }
+func (w *writer) Seek(int64, int) (int64, error)     { return 0, errNotReadable }
+func (w *writer) Readdir(int) ([]fs.FileInfo, error) { return nil, errNotReadable }
+func (w *writer) Stat() (fs.FileInfo, error)         { return writerInfo{w}, nil }
+
+// writerInfo describes the file being written.
+type writerInfo struct {
+	w *writer
+}
+
+func (i writerInfo) Name() string       { return path.Base(i.w.name) }
+func (i writerInfo) Size() int64        { return i.w.n }
+func (i writerInfo) Mode() fs.FileMode  { return 0644 }
+func (i writerInfo) ModTime() time.Time { return time.Now() }
+func (i writerInfo) IsDir() bool        { return false }
+func (i writerInfo) Sys() any           { return nil }
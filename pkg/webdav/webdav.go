@@ -0,0 +1,131 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package webdav serves the manifests over WebDAV, so that the collections
+// can be mounted as the network drives without FUSE.
+//
+// The collection of the manifest reference is served read only under
+// /bzz/{reference}/. The collection of the feed of the node is served under
+// /feeds/{topic}/ and can be modified when the node stamps the uploads,
+// every change updates the feed to the new manifest. The requests are not
+// authenticated, the server should only listen on the trusted interface.
+package webdav
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/manifestfs"
+	"github.com/ethersphere/bee/v2/pkg/readonly"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	xwebdav "golang.org/x/net/webdav"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "webdav"
+
+// Options are the options of the server.
+type Options struct {
+	Storer manifestfs.Storer
+	// Putter is nil when the feeds are served read only.
+	Putter manifestfs.PutterFunc
+	Feeds  feeds.Factory
+	// Signer signs the feed updates, its address owns the feeds.
+	Signer crypto.Signer
+	// ReadOnly rejects the changes of the collections
+	// while the node is in the read-only mode.
+	ReadOnly *readonly.Mode
+	Logger   log.Logger
+}
+
+// Server serves the WebDAV requests.
+type Server struct {
+	store    *manifestfs.Store
+	locks    xwebdav.LockSystem
+	readOnly *readonly.Mode
+	logger   log.Logger
+}
+
+// New returns the server.
+func New(o Options) (*Server, error) {
+	store, err := manifestfs.NewStore(manifestfs.Options{
+		Storer: o.Storer,
+		Putter: o.Putter,
+		Feeds:  o.Feeds,
+		Signer: o.Signer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("manifest store: %w", err)
+	}
+	return &Server{
+		store:    store,
+		locks:    xwebdav.NewMemLS(),
+		readOnly: o.ReadOnly,
+		logger:   o.Logger.WithName(loggerName).Register(),
+	}, nil
+}
+
+// writeMethods are the methods modifying the collections.
+var writeMethods = map[string]bool{
+	http.MethodPut:    true,
+	http.MethodDelete: true,
+	"MKCOL":           true,
+	"COPY":            true,
+	"MOVE":            true,
+	"PROPPATCH":       true,
+	"LOCK":            true,
+	"UNLOCK":          true,
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if writeMethods[r.Method] && s.readOnly.Enabled() {
+		http.Error(w, readonly.ErrReadOnly.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	kind, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	id, _, _ := strings.Cut(rest, "/")
+
+	var mfs *manifestfs.FS
+	switch kind {
+	case "bzz":
+		ref, err := swarm.ParseHexAddress(id)
+		if err != nil {
+			http.Error(w, "invalid reference", http.StatusBadRequest)
+			return
+		}
+		mfs = s.store.Reference(ref)
+	case "feeds":
+		topic, err := hex.DecodeString(id)
+		if err != nil || len(topic) != swarm.HashSize {
+			http.Error(w, "invalid topic", http.StatusBadRequest)
+			return
+		}
+		mfs = s.store.Feed(topic)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if !mfs.Writable() && writeMethods[r.Method] {
+		http.Error(w, "read only collection", http.StatusForbidden)
+		return
+	}
+
+	h := &xwebdav.Handler{
+		Prefix:     "/" + kind + "/" + id,
+		FileSystem: &fileSystem{fs: mfs},
+		LockSystem: s.locks,
+		Logger: func(r *http.Request, err error) {
+			if err != nil {
+				s.logger.Debug("webdav request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+			}
+		},
+	}
+	h.ServeHTTP(w, r)
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package webdav_test
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/readonly"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/webdav"
+)
+
+func newServer(t *testing.T, readOnly *readonly.Mode) *httptest.Server {
+	t.Helper()
+
+	st := mockstorer.New()
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := webdav.New(webdav.Options{
+		Storer: st,
+		Putter: func(ctx context.Context) (storer.PutterSession, error) {
+			return st.Upload(ctx, false, 0)
+		},
+		Feeds:    factory.New(st.Download(true)),
+		Signer:   crypto.NewDefaultSigner(key),
+		ReadOnly: readOnly,
+		Logger:   log.Noop,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func request(t *testing.T, method, url string, body io.Reader, header http.Header, wantStatus int) (string, http.Header) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("%s %s: got status %d, want %d: %s", method, url, resp.StatusCode, wantStatus, b)
+	}
+	return string(b), resp.Header
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	srv := newServer(t, nil)
+	feed := srv.URL + "/feeds/" + hex.EncodeToString(make([]byte, swarm.HashSize))
+
+	request(t, "MKCOL", feed+"/docs", nil, nil, http.StatusCreated)
+	request(t, "MKCOL", feed+"/missing/docs", nil, nil, http.StatusConflict)
+	request(t, http.MethodPut, feed+"/docs/a.txt", strings.NewReader("hello"), nil, http.StatusCreated)
+	request(t, http.MethodPut, feed+"/missing/a.txt", strings.NewReader("hello"), nil, http.StatusConflict)
+
+	body, header := request(t, http.MethodGet, feed+"/docs/a.txt", nil, nil, http.StatusOK)
+	if body != "hello" {
+		t.Fatalf("got file %q", body)
+	}
+	if got := header.Get("Content-Type"); !strings.HasPrefix(got, "text/plain") {
+		t.Fatalf("got content type %q", got)
+	}
+	body, _ = request(t, http.MethodGet, feed+"/docs/a.txt", nil, http.Header{"Range": {"bytes=1-3"}}, http.StatusPartialContent)
+	if body != "ell" {
+		t.Fatalf("got range %q", body)
+	}
+
+	body, _ = request(t, "PROPFIND", feed+"/", nil, http.Header{"Depth": {"1"}}, http.StatusMultiStatus)
+	if !strings.Contains(body, "/docs/") {
+		t.Fatalf("got listing without the directory: %s", body)
+	}
+
+	request(t, "MOVE", feed+"/docs", nil, http.Header{"Destination": {feed + "/archive"}}, http.StatusCreated)
+	request(t, http.MethodGet, feed+"/docs/a.txt", nil, nil, http.StatusNotFound)
+	body, _ = request(t, http.MethodGet, feed+"/archive/a.txt", nil, nil, http.StatusOK)
+	if body != "hello" {
+		t.Fatalf("got file %q", body)
+	}
+
+	request(t, http.MethodDelete, feed+"/archive", nil, nil, http.StatusNoContent)
+	request(t, "PROPFIND", feed+"/archive", nil, http.Header{"Depth": {"0"}}, http.StatusNotFound)
+}
+
+func TestServerReference(t *testing.T) {
+	t.Parallel()
+
+	srv := newServer(t, nil)
+	ref := swarm.RandAddress(t).String()
+
+	request(t, http.MethodPut, srv.URL+"/bzz/"+ref+"/a.txt", strings.NewReader("hello"), nil, http.StatusForbidden)
+	request(t, "MKCOL", srv.URL+"/bzz/"+ref+"/docs", nil, nil, http.StatusForbidden)
+	request(t, http.MethodGet, srv.URL+"/bzz/invalid/a.txt", nil, nil, http.StatusBadRequest)
+	request(t, http.MethodGet, srv.URL+"/feeds/invalid/a.txt", nil, nil, http.StatusBadRequest)
+	request(t, http.MethodGet, srv.URL+"/other", nil, nil, http.StatusNotFound)
+}
+
+func TestServerReadOnly(t *testing.T) {
+	t.Parallel()
+
+	mode := readonly.New(false)
+	srv := newServer(t, mode)
+	feed := srv.URL + "/feeds/" + hex.EncodeToString(make([]byte, swarm.HashSize))
+
+	request(t, http.MethodPut, feed+"/a.txt", strings.NewReader("hello"), nil, http.StatusCreated)
+
+	mode.Set(true)
+	request(t, http.MethodPut, feed+"/b.txt", strings.NewReader("hello"), nil, http.StatusServiceUnavailable)
+	request(t, "MKCOL", feed+"/docs", nil, nil, http.StatusServiceUnavailable)
+	request(t, http.MethodDelete, feed+"/a.txt", nil, nil, http.StatusServiceUnavailable)
+
+	// the reads are served in the read-only mode
+	if body, _ := request(t, http.MethodGet, feed+"/a.txt", nil, nil, http.StatusOK); body != "hello" {
+		t.Fatalf("got file %q", body)
+	}
+	request(t, http.MethodGet, feed+"/b.txt", nil, nil, http.StatusNotFound)
+
+	mode.Set(false)
+	request(t, http.MethodPut, feed+"/b.txt", strings.NewReader("hello"), nil, http.StatusCreated)
+}